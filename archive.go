@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// exportWorkspace is `sphinx-tribes export --workspace <uuid> [--out <file>]`, it writes
+// the workspace to a gzipped archive another deployment can import
+func exportWorkspace(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	workspaceUuid := flags.String("workspace", "", "uuid of the workspace to export")
+	out := flags.String("out", "", "archive to write, <uuid>.tribes.json.gz by default")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *workspaceUuid == "" {
+		fmt.Println("usage: sphinx-tribes export --workspace <uuid> [--out <file>]")
+		return 2
+	}
+	if *out == "" {
+		*out = *workspaceUuid + ".tribes.json.gz"
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		fmt.Println("could not create archive", err)
+		return 1
+	}
+	counts, err := db.DB.ExportWorkspace(*workspaceUuid, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*out)
+		fmt.Println("could not export workspace", err)
+		return 1
+	}
+
+	printArchiveCounts(counts)
+	fmt.Printf("exported workspace %s to %s\n", *workspaceUuid, *out)
+	return 0
+}
+
+// importWorkspace is `sphinx-tribes import --file <archive>`, it loads an archive made
+// by export into this deployment
+func importWorkspace(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	path := flags.String("file", "", "archive made by sphinx-tribes export")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *path == "" {
+		fmt.Println("usage: sphinx-tribes import --file <archive>")
+		return 2
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		fmt.Println("could not open archive", err)
+		return 1
+	}
+	defer file.Close()
+
+	workspace, counts, err := db.DB.ImportWorkspace(file)
+	if err != nil {
+		fmt.Println("could not import workspace", err)
+		return 1
+	}
+
+	printArchiveCounts(counts)
+	fmt.Printf("imported workspace %s (%s)\n", workspace.Name, workspace.Uuid)
+	return 0
+}
+
+func printArchiveCounts(counts map[string]int) {
+	tables := make([]string, 0, len(counts))
+	for table := range counts {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		if counts[table] > 0 {
+			fmt.Printf("  %-32s %d\n", table, counts[table])
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// SuperAdminCacheTTL is how long the merged super admin list is kept before the
+// stored admins are read again, other instances see a change after at most this long
+const SuperAdminCacheTTL = time.Minute
+
+var superAdmins = struct {
+	sync.RWMutex
+	source  func() []string
+	pubkeys []string
+	loaded  time.Time
+}{}
+
+// SetSuperAdminSource sets where the super admins kept outside of the env are
+// read from, the db sets it so auth does not have to depend on the db
+func SetSuperAdminSource(source func() []string) {
+	superAdmins.Lock()
+	defer superAdmins.Unlock()
+	superAdmins.source = source
+	superAdmins.loaded = time.Time{}
+}
+
+// InvalidateSuperAdmins drops the cached super admins, the next check reads them again
+func InvalidateSuperAdmins() {
+	superAdmins.Lock()
+	defer superAdmins.Unlock()
+	superAdmins.loaded = time.Time{}
+}
+
+// StoredSuperAdmins returns the super admins that were added through the api
+func StoredSuperAdmins() []string {
+	superAdmins.RLock()
+	if time.Since(superAdmins.loaded) < SuperAdminCacheTTL {
+		defer superAdmins.RUnlock()
+		return superAdmins.pubkeys
+	}
+	superAdmins.RUnlock()
+
+	superAdmins.Lock()
+	defer superAdmins.Unlock()
+	superAdmins.pubkeys = []string{}
+	if superAdmins.source != nil {
+		superAdmins.pubkeys = superAdmins.source()
+	}
+	superAdmins.loaded = time.Now()
+	return superAdmins.pubkeys
+}
+
+// SuperAdmins returns the super admins of the env merged with the stored ones
+func SuperAdmins() []string {
+	admins := []string{}
+	seen := map[string]bool{}
+	for _, pubkey := range append(append([]string{}, config.SuperAdmins...), StoredSuperAdmins()...) {
+		if pubkey == "" || pubkey == config.AdminDevFreePass || seen[pubkey] {
+			continue
+		}
+		seen[pubkey] = true
+		admins = append(admins, pubkey)
+	}
+	return admins
+}
+
+// IsEnvSuperAdmin reports if a super admin is set in the env,
+// those can only be removed by changing the env
+func IsEnvSuperAdmin(pubkey string) bool {
+	for _, val := range config.SuperAdmins {
+		if val == pubkey {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuperAdmins(t *testing.T) {
+	envAdmins, adminStrings := config.SuperAdmins, config.AdminStrings
+	defer func() {
+		config.SuperAdmins, config.AdminStrings = envAdmins, adminStrings
+		SetSuperAdminSource(nil)
+	}()
+
+	config.SuperAdmins = []string{"env_admin"}
+	config.AdminStrings = "env_admin"
+
+	reads := 0
+	stored := []string{"db_admin", "env_admin"}
+	SetSuperAdminSource(func() []string {
+		reads++
+		return stored
+	})
+
+	t.Run("should merge the env and stored admins", func(t *testing.T) {
+		assert.Equal(t, []string{"env_admin", "db_admin"}, SuperAdmins())
+		assert.True(t, AdminCheck("env_admin"))
+		assert.True(t, AdminCheck("db_admin"))
+		assert.False(t, AdminCheck("someone"))
+		assert.False(t, AdminCheck(""))
+	})
+
+	t.Run("should cache the stored admins until they are invalidated", func(t *testing.T) {
+		InvalidateSuperAdmins()
+		reads = 0
+
+		AdminCheck("db_admin")
+		AdminCheck("db_admin")
+		assert.Equal(t, 1, reads)
+
+		stored = []string{}
+		assert.True(t, AdminCheck("db_admin"))
+
+		InvalidateSuperAdmins()
+		assert.False(t, AdminCheck("db_admin"))
+		assert.Equal(t, 2, reads)
+	})
+
+	t.Run("should only be a free pass while there are no admins", func(t *testing.T) {
+		config.SuperAdmins = []string{""}
+		config.AdminStrings = ""
+
+		stored = []string{}
+		InvalidateSuperAdmins()
+		assert.True(t, IsFreePass())
+
+		stored = []string{"db_admin"}
+		InvalidateSuperAdmins()
+		assert.False(t, IsFreePass())
+	})
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ApiToken is a workspace api token a machine, like a CI pipeline, authenticates
+// with. It acts as the workspace admin that minted it, but only for its scopes
+type ApiToken struct {
+	ID            uint
+	WorkspaceUuid string
+	CreatedBy     string
+	Scopes        []string
+}
+
+func (t ApiToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ApiTokenContextKey holds the ApiToken of a request made with one
+var ApiTokenContextKey = contextKey("api_token")
+
+var apiTokenVerifier func(token string) (ApiToken, error)
+
+// SetApiTokenVerifier sets how api tokens are looked up, the db sets it
+// so auth does not have to depend on the db
+func SetApiTokenVerifier(verifier func(token string) (ApiToken, error)) {
+	apiTokenVerifier = verifier
+}
+
+var apiTokenObserver func(r *http.Request, token ApiToken)
+
+// SetApiTokenObserver sets a function told about every request let in with an
+// api token, usage is counted this way without auth depending on it
+func SetApiTokenObserver(observer func(r *http.Request, token ApiToken)) {
+	apiTokenObserver = observer
+}
+
+// ApiTokenFromContext returns the api token a request was made with, if any
+func ApiTokenFromContext(ctx context.Context) (ApiToken, bool) {
+	token, ok := ctx.Value(ApiTokenContextKey).(ApiToken)
+	return token, ok
+}
+
+// ApiTokenContext lets a request in with a workspace api token from the
+// x-api-token header that has at least one of the scopes
+func ApiTokenContext(scopes ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.Header.Get("x-api-token")
+			if token == "" || apiTokenVerifier == nil {
+				fmt.Println("[auth] no api token")
+				writeAuthError(w, ErrNoCredentials)
+				return
+			}
+
+			apiToken, err := apiTokenVerifier(token)
+			if err != nil {
+				fmt.Println("[auth] invalid api token", err)
+				writeAuthError(w, ErrInvalidApiToken)
+				return
+			}
+
+			allowed := false
+			for _, scope := range scopes {
+				if apiToken.HasScope(scope) {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				fmt.Println("[auth] api token is missing the scope for", r.URL.Path)
+				writeAuthError(w, ErrMissingScope)
+				return
+			}
+
+			if apiTokenObserver != nil {
+				apiTokenObserver(r, apiToken)
+			}
+
+			ctx := context.WithValue(r.Context(), ContextKey, apiToken.CreatedBy)
+			ctx = context.WithValue(ctx, ApiTokenContextKey, apiToken)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiTokenContext(t *testing.T) {
+	defer SetApiTokenVerifier(nil)
+	SetApiTokenVerifier(func(token string) (ApiToken, error) {
+		if token != "sbt_valid" {
+			return ApiToken{}, errors.New("invalid")
+		}
+		return ApiToken{ID: 1, WorkspaceUuid: "workspace_uuid", CreatedBy: "admin_pubkey", Scopes: []string{"bounties:create"}}, nil
+	})
+
+	var seen ApiToken
+	var seenPubkey string
+	handler := func(scopes ...string) http.Handler {
+		return ApiTokenContext(scopes...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen, _ = ApiTokenFromContext(r.Context())
+			seenPubkey, _ = r.Context().Value(ContextKey).(string)
+		}))
+	}
+
+	serve := func(h http.Handler, token string) (int, string) {
+		req := httptest.NewRequest(http.MethodPost, "/gobounties/api", nil)
+		if token != "" {
+			req.Header.Set("x-api-token", token)
+		}
+		return refusal(t, func(http.Handler) http.Handler { return h }, req)
+	}
+
+	t.Run("should reject a missing or invalid token", func(t *testing.T) {
+		status, code := serve(handler("bounties:create"), "")
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "no_credentials", code)
+
+		status, code = serve(handler("bounties:create"), "sbt_revoked")
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "invalid_api_token", code)
+	})
+
+	t.Run("should reject a token without the scope", func(t *testing.T) {
+		status, code := serve(handler("bounties:update"), "sbt_valid")
+		assert.Equal(t, http.StatusForbidden, status)
+		assert.Equal(t, "missing_scope", code)
+	})
+
+	t.Run("should act as the admin that minted the token", func(t *testing.T) {
+		status, _ := serve(handler("bounties:update", "bounties:create"), "sbt_valid")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "admin_pubkey", seenPubkey)
+		assert.Equal(t, "workspace_uuid", seen.WorkspaceUuid)
+	})
+}
@@ -42,7 +42,7 @@ func PubKeyContext(next http.Handler) http.Handler {
 
 		if token == "" {
 			fmt.Println("[auth] no token")
-			http.Error(w, http.StatusText(401), 401)
+			writeAuthError(w, ErrNoCredentials)
 			return
 		}
 
@@ -53,13 +53,13 @@ func PubKeyContext(next http.Handler) http.Handler {
 
 			if err != nil {
 				fmt.Println("Failed to parse JWT")
-				http.Error(w, http.StatusText(401), 401)
+				writeAuthError(w, ErrInvalidToken)
 				return
 			}
 
 			if claims.VerifyExpiresAt(time.Now().UnixNano(), true) {
 				fmt.Println("Token has expired")
-				http.Error(w, http.StatusText(401), 401)
+				writeAuthError(w, ErrJwtExpired)
 				return
 			}
 
@@ -73,7 +73,7 @@ func PubKeyContext(next http.Handler) http.Handler {
 				if err != nil {
 					fmt.Println(err)
 				}
-				http.Error(w, http.StatusText(401), 401)
+				writeAuthError(w, err)
 				return
 			}
 
@@ -83,6 +83,34 @@ func PubKeyContext(next http.Handler) http.Handler {
 	})
 }
 
+// OptionalPubKeyContext is PubKeyContext for routes that anyone can call, the
+// pubkey is only set when the request has a valid token
+func OptionalPubKeyContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			token = r.Header.Get("x-jwt")
+		}
+
+		pubkey := ""
+		if strings.Contains(token, ".") && !strings.HasPrefix(token, ".") {
+			claims, err := DecodeJwt(token)
+			if err == nil && !claims.VerifyExpiresAt(time.Now().UnixNano(), true) {
+				pubkey, _ = claims["pubkey"].(string)
+			}
+		} else if token != "" {
+			pubkey, _ = VerifyTribeUUID(token, true)
+		}
+
+		if pubkey == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := context.WithValue(r.Context(), ContextKey, pubkey)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // PubKeyContext parses pukey from signed timestamp
 func PubKeyContextSuperAdmin(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -93,7 +121,7 @@ func PubKeyContextSuperAdmin(next http.Handler) http.Handler {
 
 		if token == "" {
 			fmt.Println("[auth] no token")
-			http.Error(w, http.StatusText(401), 401)
+			writeAuthError(w, ErrNoCredentials)
 			return
 		}
 
@@ -103,20 +131,20 @@ func PubKeyContextSuperAdmin(next http.Handler) http.Handler {
 
 			if err != nil {
 				fmt.Println("Failed to parse JWT")
-				http.Error(w, http.StatusText(401), 401)
+				writeAuthError(w, ErrInvalidToken)
 				return
 			}
 
 			if claims.VerifyExpiresAt(time.Now().UnixNano(), true) {
 				fmt.Println("Token has expired")
-				http.Error(w, http.StatusText(401), 401)
+				writeAuthError(w, ErrJwtExpired)
 				return
 			}
 
 			pubkey := fmt.Sprintf("%v", claims["pubkey"])
 			if !IsFreePass() && !AdminCheck(pubkey) {
 				fmt.Println("Not a super admin")
-				http.Error(w, http.StatusText(401), 401)
+				writeAuthError(w, ErrNotSuperAdmin)
 				return
 			}
 
@@ -130,13 +158,13 @@ func PubKeyContextSuperAdmin(next http.Handler) http.Handler {
 				if err != nil {
 					fmt.Println(err)
 				}
-				http.Error(w, http.StatusText(401), 401)
+				writeAuthError(w, err)
 				return
 			}
 
 			if !IsFreePass() && !AdminCheck(pubkey) {
 				fmt.Println("Not a super admin : auth")
-				http.Error(w, http.StatusText(401), 401)
+				writeAuthError(w, ErrNotSuperAdmin)
 				return
 			}
 
@@ -153,13 +181,13 @@ func ConnectionCodeContext(next http.Handler) http.Handler {
 
 		if token == "" {
 			fmt.Println("[auth] no token")
-			http.Error(w, http.StatusText(401), 401)
+			writeAuthError(w, ErrNoCredentials)
 			return
 		}
 
 		if token != config.Connection_Auth {
 			fmt.Println("Not a super admin : auth")
-			http.Error(w, http.StatusText(401), 401)
+			writeAuthError(w, ErrInvalidConnectionCode)
 			return
 		}
 		ctx := context.WithValue(r.Context(), ContextKey, token)
@@ -175,14 +203,15 @@ func CypressContext(next http.Handler) http.Handler {
 			next.ServeHTTP(w, r.WithContext(ctx))
 		} else {
 			fmt.Println("Endpoint is for testing only : test endpoint")
-			http.Error(w, http.StatusText(401), 401)
+			writeAuthError(w, ErrTestOnly)
 			return
 		}
 	})
 }
 
+// AdminCheck checks the pubkey against the env super admins and the stored ones
 func AdminCheck(pubkey string) bool {
-	for _, val := range config.SuperAdmins {
+	for _, val := range SuperAdmins() {
 		if val == pubkey {
 			return true
 		}
@@ -190,14 +219,56 @@ func AdminCheck(pubkey string) bool {
 	return false
 }
 
+// IsFreePass is true in dev mode, or while there are no super admins at all
+// so the first one can be added
 func IsFreePass() bool {
-	if len(config.SuperAdmins) == 1 && config.SuperAdmins[0] == config.AdminDevFreePass || config.AdminStrings == "" {
+	if len(config.SuperAdmins) == 1 && config.SuperAdmins[0] == config.AdminDevFreePass || config.AdminStrings == "" && len(StoredSuperAdmins()) == 0 {
 		return true
 	}
 	return false
 }
 
-// VerifyTribeUUID takes base64 uuid and returns hex pubkey
+// tribeTokenMaxAge is how many seconds a signed timestamp token is valid
+const tribeTokenMaxAge = 300
+
+var tokenNonceStore func(key string, ttl time.Duration) bool
+
+// SetTokenNonceStore sets where the used tribe tokens are remembered, the
+// store answers false for a key it already holds. Without a store a token can
+// be used again until it is too old
+func SetTokenNonceStore(store func(key string, ttl time.Duration) bool) {
+	tokenNonceStore = store
+}
+
+// useTribeToken remembers a token until it's too old, keyed by the pubkey and
+// timestamp it signed since the same timestamp can be signed more than one way
+func useTribeToken(pubkey string, ts uint32) error {
+	if tokenNonceStore == nil {
+		return nil
+	}
+	expires := time.Unix(int64(ts)+tribeTokenMaxAge+int64(config.TokenClockSkewSeconds), 0)
+	if !tokenNonceStore(fmt.Sprintf("tribe_token:%s:%d", pubkey, ts), time.Until(expires)+time.Second) {
+		return ErrTokenReplayed
+	}
+	return nil
+}
+
+// checkTokenTime lets in the tokens signed in the last five minutes, give or
+// take the clock skew of the device
+func checkTokenTime(ts uint32, now time.Time) error {
+	skew := int64(config.TokenClockSkewSeconds)
+	if int64(ts) < now.Unix()-tribeTokenMaxAge-skew {
+		return ErrTokenExpired
+	}
+	if int64(ts) > now.Unix()+skew {
+		return ErrTokenTooEarly
+	}
+	return nil
+}
+
+// VerifyTribeUUID takes base64 uuid and returns hex pubkey, with
+// checkTimestamp the token is refused with an AuthError once it is out of its
+// time window or was already used
 func VerifyTribeUUID(uuid string, checkTimestamp bool) (string, error) {
 
 	ts, timeBuf, sigBuf, err := ParseTokenString(uuid)
@@ -211,11 +282,12 @@ func VerifyTribeUUID(uuid string, checkTimestamp bool) (string, error) {
 	}
 
 	if checkTimestamp {
-		// 5 MINUTE MAX
-		now := time.Now().Unix()
-		if int64(ts) < now-300 {
-			fmt.Println("TOO LATE!")
-			return "", errors.New("too late")
+		if err := checkTokenTime(ts, time.Now()); err != nil {
+			fmt.Println("[auth]", err)
+			return "", err
+		}
+		if err := useTribeToken(pubkey, ts); err != nil {
+			return "", err
 		}
 	}
 
@@ -280,9 +352,22 @@ func VerifyDerSig(sig string, hash string, pubkey string) (bool, error) {
 }
 
 func DecodeJwt(token string) (jwt.MapClaims, error) {
+	jwtKey, previousJwtKey := config.JwtKeys()
+	claims, err := decodeJwtWithKey(token, jwtKey)
+
+	// tokens signed before the last key rotation are valid until they expire
+	if err != nil && previousJwtKey != "" {
+		if previousClaims, previousErr := decodeJwtWithKey(token, previousJwtKey); previousErr == nil {
+			return previousClaims, nil
+		}
+	}
+
+	return claims, err
+}
+
+func decodeJwtWithKey(token string, key string) (jwt.MapClaims, error) {
 	claims := jwt.MapClaims{}
 	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
-		key := config.JwtKey
 		return []byte(key), nil
 	})
 
@@ -297,7 +382,7 @@ func EncodeJwt(pubkey string) (string, error) {
 		"exp":    exp,
 	}
 
-	_, tokenString, err := TokenAuth.Encode(claims)
+	_, tokenString, err := currentTokenAuth().Encode(claims)
 
 	if err != nil {
 		return "", err
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	btcec "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/patrickmn/go-cache"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyTribeUUIDReplay(t *testing.T) {
+	used := cache.New(time.Minute, time.Minute)
+	ttls := []time.Duration{}
+	SetTokenNonceStore(func(key string, ttl time.Duration) bool {
+		ttls = append(ttls, ttl)
+		return used.Add(key, true, ttl) == nil
+	})
+	defer SetTokenNonceStore(nil)
+
+	key, _ := btcec.NewPrivateKey()
+	pubkey := hex.EncodeToString(key.PubKey().SerializeCompressed())
+	newToken := func(ts time.Time) string {
+		timeBuf := make([]byte, 4)
+		binary.BigEndian.PutUint32(timeBuf, uint32(ts.Unix()))
+		sig, _ := Sign(timeBuf, key)
+		return base64.URLEncoding.EncodeToString(append(timeBuf, sig...))
+	}
+
+	t.Run("should refuse a token the second time", func(t *testing.T) {
+		token := newToken(time.Now())
+
+		extracted, err := VerifyTribeUUID(token, true)
+		assert.NoError(t, err)
+		assert.Equal(t, pubkey, extracted)
+		assert.True(t, ttls[0] > 5*time.Minute && ttls[0] <= 6*time.Minute)
+
+		extracted, err = VerifyTribeUUID(token, true)
+		assert.ErrorIs(t, err, ErrTokenReplayed)
+		assert.Empty(t, extracted)
+	})
+
+	t.Run("should take a fresh token", func(t *testing.T) {
+		_, err := VerifyTribeUUID(newToken(time.Now().Add(-2*time.Second)), true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should leave tokens that only identify a tribe alone", func(t *testing.T) {
+		token := newToken(time.Now().Add(-time.Hour))
+		for i := 0; i < 2; i++ {
+			extracted, err := VerifyTribeUUID(token, false)
+			assert.NoError(t, err)
+			assert.Equal(t, pubkey, extracted)
+		}
+	})
+}
+
+func TestCheckTokenTime(t *testing.T) {
+	defer func(skew int) { config.TokenClockSkewSeconds = skew }(config.TokenClockSkewSeconds)
+	config.TokenClockSkewSeconds = 30
+	now := time.Unix(1700000000, 0)
+	at := func(offset int64) uint32 { return uint32(now.Unix() + offset) }
+
+	assert.NoError(t, checkTokenTime(at(0), now))
+	assert.NoError(t, checkTokenTime(at(20), now), "a device a little ahead")
+	assert.NoError(t, checkTokenTime(at(-320), now), "a device a little behind")
+	assert.ErrorIs(t, checkTokenTime(at(31), now), ErrTokenTooEarly)
+	assert.ErrorIs(t, checkTokenTime(at(-331), now), ErrTokenExpired)
+
+	config.TokenClockSkewSeconds = 120
+	assert.NoError(t, checkTokenTime(at(90), now))
+}
+
+func TestPubKeyContextTokenErrors(t *testing.T) {
+	key, _ := btcec.NewPrivateKey()
+	timeBuf := make([]byte, 4)
+	binary.BigEndian.PutUint32(timeBuf, uint32(time.Now().Add(time.Hour).Unix()))
+	sig, _ := Sign(timeBuf, key)
+	token := base64.URLEncoding.EncodeToString(append(timeBuf, sig...))
+
+	rr := httptest.NewRecorder()
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	PubKeyContext(next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/person?token="+token, nil))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	refused := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &refused))
+	assert.Equal(t, "token_clock_skew", refused["error"])
+	assert.NotZero(t, refused["server_time"])
+}
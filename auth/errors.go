@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// AuthError is why a middleware refused a request. Status is 401 when the
+// credentials are missing or invalid and 403 when they are valid but don't
+// allow the request, Code lets clients tell the cases apart
+type AuthError struct {
+	Status  int    `json:"-"`
+	Code    string `json:"error"`
+	Message string `json:"message"`
+}
+
+func (e *AuthError) Error() string {
+	return e.Message
+}
+
+var ErrNoCredentials = &AuthError{Status: http.StatusUnauthorized, Code: "no_credentials", Message: "the request has no token"}
+
+var ErrInvalidToken = &AuthError{Status: http.StatusUnauthorized, Code: "invalid_token", Message: "the token is not valid"}
+
+var ErrJwtExpired = &AuthError{Status: http.StatusUnauthorized, Code: "token_expired", Message: "the token has expired, sign in again"}
+
+var ErrTokenExpired = &AuthError{Status: http.StatusUnauthorized, Code: "token_expired", Message: "the token is too old, sign a new one or check the clock of the device"}
+
+var ErrTokenTooEarly = &AuthError{Status: http.StatusUnauthorized, Code: "token_clock_skew", Message: "the token is signed with a time ahead of the server, check the clock of the device"}
+
+var ErrTokenReplayed = &AuthError{Status: http.StatusUnauthorized, Code: "token_replayed", Message: "the token was already used, sign a new one for every request"}
+
+var ErrInvalidApiToken = &AuthError{Status: http.StatusUnauthorized, Code: "invalid_api_token", Message: "the api token is not valid or was revoked"}
+
+var ErrInvalidWebhookSignature = &AuthError{Status: http.StatusUnauthorized, Code: "invalid_signature", Message: "the webhook signature is not valid"}
+
+var ErrWebhookExpired = &AuthError{Status: http.StatusUnauthorized, Code: "webhook_expired", Message: "the webhook timestamp is too far from the server time"}
+
+var ErrWebhookReplayed = &AuthError{Status: http.StatusUnauthorized, Code: "webhook_replayed", Message: "the webhook was already received"}
+
+var ErrWebhookNotConfigured = &AuthError{Status: http.StatusServiceUnavailable, Code: "webhook_not_configured", Message: "the webhook source has no signing secret"}
+
+var ErrInvalidConnectionCode = &AuthError{Status: http.StatusUnauthorized, Code: "invalid_connection_code", Message: "the connection token is not valid"}
+
+var ErrNotSuperAdmin = &AuthError{Status: http.StatusForbidden, Code: "not_super_admin", Message: "only super admins can do this"}
+
+var ErrMissingScope = &AuthError{Status: http.StatusForbidden, Code: "missing_scope", Message: "the api token doesn't have the scope for this request"}
+
+var ErrTestOnly = &AuthError{Status: http.StatusForbidden, Code: "test_only", Message: "this endpoint is only there for tests"}
+
+// writeAuthError refuses a request with the status and code of its AuthError,
+// any other error is an invalid token. The server time is sent along so
+// clients can correct the clock their tokens are signed with
+func writeAuthError(w http.ResponseWriter, err error) {
+	authErr := &AuthError{}
+	if !errors.As(err, &authErr) {
+		authErr = ErrInvalidToken
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(authErr.Status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error":       authErr.Code,
+		"message":     authErr.Message,
+		"server_time": time.Now().Unix(),
+	})
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	btcec "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// refusal is the status and error code a middleware answered with
+func refusal(t *testing.T, middleware func(http.Handler) http.Handler, req *http.Request) (int, string) {
+	rr := httptest.NewRecorder()
+	middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rr, req)
+	if rr.Code == http.StatusOK {
+		return rr.Code, ""
+	}
+	body := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	code, _ := body["error"].(string)
+	return rr.Code, code
+}
+
+func TestAuthMiddlewareRefusals(t *testing.T) {
+	envAdmins, adminStrings, connectionAuth := config.SuperAdmins, config.AdminStrings, config.Connection_Auth
+	defer func() {
+		config.SuperAdmins, config.AdminStrings, config.Connection_Auth = envAdmins, adminStrings, connectionAuth
+		InvalidateSuperAdmins()
+	}()
+
+	adminKey, _ := btcec.NewPrivateKey()
+	memberKey, _ := btcec.NewPrivateKey()
+	config.SuperAdmins = []string{hex.EncodeToString(adminKey.PubKey().SerializeCompressed())}
+	config.AdminStrings = config.SuperAdmins[0]
+	config.Connection_Auth = "connection_secret"
+	InvalidateSuperAdmins()
+
+	withToken := func(key *btcec.PrivateKey) *http.Request {
+		timeBuf := make([]byte, 4)
+		binary.BigEndian.PutUint32(timeBuf, uint32(time.Now().Unix()))
+		sig, _ := Sign(timeBuf, key)
+		return httptest.NewRequest(http.MethodGet, "/admin?token="+base64.URLEncoding.EncodeToString(append(timeBuf, sig...)), nil)
+	}
+
+	t.Run("should answer 401 without credentials", func(t *testing.T) {
+		for _, middleware := range []func(http.Handler) http.Handler{PubKeyContext, PubKeyContextSuperAdmin, ConnectionCodeContext} {
+			status, code := refusal(t, middleware, httptest.NewRequest(http.MethodGet, "/admin", nil))
+			assert.Equal(t, http.StatusUnauthorized, status)
+			assert.Equal(t, "no_credentials", code)
+		}
+	})
+
+	t.Run("should answer 401 for invalid credentials", func(t *testing.T) {
+		status, code := refusal(t, PubKeyContext, httptest.NewRequest(http.MethodGet, "/admin?token=bm90IGEgdG9rZW4=", nil))
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "invalid_token", code)
+
+		status, code = refusal(t, PubKeyContextSuperAdmin, httptest.NewRequest(http.MethodGet, "/admin?token=a.b.c", nil))
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "invalid_token", code)
+
+		req := httptest.NewRequest(http.MethodGet, "/connectioncodes", nil)
+		req.Header.Set("token", "guess")
+		status, code = refusal(t, ConnectionCodeContext, req)
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "invalid_connection_code", code)
+	})
+
+	t.Run("should answer 403 to someone signed in without the rights", func(t *testing.T) {
+		status, code := refusal(t, PubKeyContextSuperAdmin, withToken(memberKey))
+		assert.Equal(t, http.StatusForbidden, status)
+		assert.Equal(t, "not_super_admin", code)
+
+		status, code = refusal(t, CypressContext, withToken(memberKey))
+		assert.Equal(t, http.StatusForbidden, status)
+		assert.Equal(t, "test_only", code)
+	})
+
+	t.Run("should let valid credentials through", func(t *testing.T) {
+		status, _ := refusal(t, PubKeyContextSuperAdmin, withToken(adminKey))
+		assert.Equal(t, http.StatusOK, status)
+
+		status, _ = refusal(t, PubKeyContext, withToken(memberKey))
+		assert.Equal(t, http.StatusOK, status)
+	})
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"log"
+	"sync"
 	"time"
 
 	"github.com/go-chi/jwtauth"
@@ -10,12 +11,26 @@ import (
 
 var TokenAuth *jwtauth.JWTAuth
 
+// tokenAuthMu guards TokenAuth, InitJwt builds it again when the key rotates
+var tokenAuthMu sync.RWMutex
+
 // Init auth
 func InitJwt() {
-	if config.JwtKey == "" {
+	jwtKey, _ := config.JwtKeys()
+	if jwtKey == "" {
 		log.Fatal("No JWT key")
 	}
-	TokenAuth = jwtauth.New("HS256", []byte(config.JwtKey), nil)
+	tokenAuth := jwtauth.New("HS256", []byte(jwtKey), nil)
+
+	tokenAuthMu.Lock()
+	defer tokenAuthMu.Unlock()
+	TokenAuth = tokenAuth
+}
+
+func currentTokenAuth() *jwtauth.JWTAuth {
+	tokenAuthMu.RLock()
+	defer tokenAuthMu.RUnlock()
+	return TokenAuth
 }
 
 // ExpireInHours for jwt
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// The services that call back into tribes, each signs with its own secrets
+const (
+	WebhookSourceStakwork  = "stakwork"
+	WebhookSourceCodeGraph = "codegraph"
+)
+
+var WebhookSources = []string{WebhookSourceStakwork, WebhookSourceCodeGraph}
+
+func IsWebhookSource(source string) bool {
+	for _, s := range WebhookSources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// maxSignedWebhookSize is the largest body a signed webhook may have
+const maxSignedWebhookSize = 10 << 20
+
+var webhookSecretSource func(source string) []string
+
+// SetWebhookSecretSource sets where the secrets of a webhook source are
+// looked up, a source can have more than one while a secret is rotated
+func SetWebhookSecretSource(source func(source string) []string) {
+	webhookSecretSource = source
+}
+
+// SignWebhook signs a webhook body sent at timestamp, for the
+// x-webhook-signature header
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks that a webhook was signed at timestamp with one of the
+// secrets, and that the timestamp is within WebhookMaxSkewSeconds of now
+func VerifyWebhook(secrets []string, timestamp string, signature string, body []byte, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+	skew := now.Sub(time.Unix(seconds, 0))
+	maxSkew := time.Duration(config.WebhookMaxSkewSeconds) * time.Second
+	if skew > maxSkew || skew < -maxSkew {
+		return ErrWebhookExpired
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil || len(expected) == 0 {
+		return ErrInvalidWebhookSignature
+	}
+	for _, secret := range secrets {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(timestamp + "."))
+		mac.Write(body)
+		if hmac.Equal(mac.Sum(nil), expected) {
+			return nil
+		}
+	}
+	return ErrInvalidWebhookSignature
+}
+
+// SignedWebhookContext lets in the webhooks of a source signed with one of its
+// secrets in the x-webhook-signature and x-webhook-timestamp headers. A signed
+// webhook is only let in once. While the source has no secret its webhooks
+// are refused, they can't be told apart from forged ones
+func SignedWebhookContext(source string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var secrets []string
+			if webhookSecretSource != nil {
+				secrets = webhookSecretSource(source)
+			}
+			if len(secrets) == 0 {
+				fmt.Println("[auth] no signing secret for webhooks from", source)
+				writeAuthError(w, ErrWebhookNotConfigured)
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxSignedWebhookSize))
+			r.Body.Close()
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			signature := r.Header.Get("x-webhook-signature")
+			if signature == "" {
+				fmt.Println("[auth] unsigned webhook from", source)
+				writeAuthError(w, ErrNoCredentials)
+				return
+			}
+			if err := VerifyWebhook(secrets, r.Header.Get("x-webhook-timestamp"), signature, body, time.Now()); err != nil {
+				fmt.Println("[auth] refused webhook from", source, err)
+				writeAuthError(w, err)
+				return
+			}
+			if tokenNonceStore != nil {
+				ttl := 2*time.Duration(config.WebhookMaxSkewSeconds)*time.Second + time.Second
+				if !tokenNonceStore("webhook:"+source+":"+signature, ttl) {
+					writeAuthError(w, ErrWebhookReplayed)
+					return
+				}
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"status":"completed"}`)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := SignWebhook("secret", now.Unix(), body)
+
+	assert.NoError(t, VerifyWebhook([]string{"secret"}, timestamp, signature, body, now))
+	assert.NoError(t, VerifyWebhook([]string{"old", "secret"}, timestamp, signature, body, now), "any secret of the source is accepted")
+	assert.NoError(t, VerifyWebhook([]string{"secret"}, timestamp, signature, body, now.Add(4*time.Minute)))
+
+	assert.Equal(t, ErrInvalidWebhookSignature, VerifyWebhook([]string{"other"}, timestamp, signature, body, now))
+	assert.Equal(t, ErrInvalidWebhookSignature, VerifyWebhook([]string{"secret"}, timestamp, signature, []byte(`{"status":"failed"}`), now))
+	assert.Equal(t, ErrInvalidWebhookSignature, VerifyWebhook([]string{"secret"}, timestamp, "sha256=nothex", body, now))
+	assert.Equal(t, ErrInvalidWebhookSignature, VerifyWebhook([]string{"secret"}, "", signature, body, now))
+	assert.Equal(t, ErrWebhookExpired, VerifyWebhook([]string{"secret"}, timestamp, signature, body, now.Add(6*time.Minute)))
+	assert.Equal(t, ErrWebhookExpired, VerifyWebhook([]string{"secret"}, timestamp, signature, body, now.Add(-6*time.Minute)))
+}
+
+func TestSignedWebhookContext(t *testing.T) {
+	defer SetWebhookSecretSource(nil)
+	defer SetTokenNonceStore(nil)
+
+	used := map[string]bool{}
+	SetTokenNonceStore(func(key string, ttl time.Duration) bool {
+		if used[key] {
+			return false
+		}
+		used[key] = true
+		return true
+	})
+
+	body := `{"status":"completed"}`
+	newRequest := func(signature string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/plans/plan_uuid/result", strings.NewReader(body))
+		if signature != "" {
+			req.Header.Set("x-webhook-timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+			req.Header.Set("x-webhook-signature", signature)
+		}
+		return req
+	}
+
+	t.Run("should refuse webhooks while the source has no secret", func(t *testing.T) {
+		SetWebhookSecretSource(func(source string) []string { return nil })
+		signature := SignWebhook("stakwork_secret", time.Now().Unix(), []byte(body))
+		status, code := refusal(t, SignedWebhookContext(WebhookSourceStakwork), newRequest(signature))
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Equal(t, "webhook_not_configured", code)
+	})
+
+	SetWebhookSecretSource(func(source string) []string {
+		if source == WebhookSourceStakwork {
+			return []string{"stakwork_secret"}
+		}
+		return []string{"other_secret"}
+	})
+
+	t.Run("should refuse an unsigned webhook", func(t *testing.T) {
+		status, code := refusal(t, SignedWebhookContext(WebhookSourceStakwork), newRequest(""))
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "no_credentials", code)
+	})
+
+	t.Run("should refuse a webhook signed with the secret of another source", func(t *testing.T) {
+		signature := SignWebhook("stakwork_secret", time.Now().Unix(), []byte(body))
+		status, code := refusal(t, SignedWebhookContext(WebhookSourceCodeGraph), newRequest(signature))
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "invalid_signature", code)
+	})
+
+	t.Run("should let a signed webhook in once with its body", func(t *testing.T) {
+		signature := SignWebhook("stakwork_secret", time.Now().Unix(), []byte(body))
+		rr := httptest.NewRecorder()
+		received := ""
+		SignedWebhookContext(WebhookSourceStakwork)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			read, _ := io.ReadAll(r.Body)
+			received = string(read)
+		})).ServeHTTP(rr, newRequest(signature))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, body, received)
+
+		status, code := refusal(t, SignedWebhookContext(WebhookSourceStakwork), newRequest(signature))
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "webhook_replayed", code)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -35,25 +36,203 @@ var AdminCheck string
 var AdminDevFreePass = "FREE_PASS"
 var Connection_Auth string
 var AdminStrings string
+var CodeGraphUrl string
+var CodeGraphToken string
+
+// ReceiptSigningKey signs the bounty payment receipts, it is required and
+// kept apart from the jwt key so rotating that one doesn't break receipts
+var ReceiptSigningKey string
+
+// CalendarSigningKey signs the tokens of the calendar feeds
+var CalendarSigningKey string
+
+// ShortLinkSigningKey signs the codes of the short links
+var ShortLinkSigningKey string
+
+// SentryDsn is where recovered panics are reported, empty disables it
+var SentryDsn string
+
+// OtelEndpoint is the OTLP/HTTP collector traces are exported to, empty disables tracing
+var OtelEndpoint string
+var StaleBountyWarnDays = 10
+var StaleBountyUnassignDays = 14
+
+// BountyDueReminderHours are how many hours before its due date the assignee of a
+// bounty is reminded, BountyOverdueReminderHours how many hours after it the
+// assignee and the owner are told it is overdue
+var BountyDueReminderHours = []int{48, 24}
+var BountyOverdueReminderHours = []int{24, 72}
+
+// Relay client settings, the timeout and the breaker cooldown are in seconds.
+// The breaker opens after RelayBreakerThreshold failed calls in a row
+var RelayTimeout = 15
+var RelayRetries = 2
+var RelayBreakerThreshold = 5
+var RelayBreakerCooldown = 30
+
+// RateProviderUrl answers the bitcoin price in the display currencies of the
+// workspaces, in the format of the coingecko simple price api. Rates are cached
+// for RateCacheSeconds
+var RateProviderUrl = "https://api.coingecko.com/api/v3/simple/price"
+var RateCacheSeconds = 300
+
+// Open public bounties are published as Nostr listings to NostrRelays, signed
+// with NostrPrivateKey, when both are set
+var NostrRelays []string
+var NostrPrivateKey string
+
+// SlackSigningSecret verifies that the slash commands come from the Slack app,
+// the /slack/commands endpoint refuses every request while it is empty
+var SlackSigningSecret string
+
+// TokenClockSkewSeconds is how far the clock of a device signing timestamp
+// tokens may be off, either way, before its tokens are refused
+var TokenClockSkewSeconds = 30
+
+// WebhookMaxSkewSeconds is how far the timestamp of a signed webhook may be
+// from the server time, either way, before it is refused
+var WebhookMaxSkewSeconds = 300
+
+// WebhookSecret is the secret a webhook source signs with in the env or the
+// secrets provider, WEBHOOK_SECRET_STAKWORK for the stakwork source
+func WebhookSecret(source string) string {
+	return GetSecret("WEBHOOK_SECRET_" + strings.ToUpper(source))
+}
+
+// HstsMaxAge is how many seconds browsers stick to https for the api, 0
+// leaves the Strict-Transport-Security header out
+var HstsMaxAge = 31536000
+
+// ContentSecurityPolicy is sent with every response. Its frame-ancestors is
+// always 'none', except on the embeddable previews that EmbedFrameAncestors
+// may frame
+var ContentSecurityPolicy = "default-src 'none'"
+var EmbedFrameAncestors = "*"
+
+// WorkspaceRetentionDays is how long a deleted workspace can be restored
+var WorkspaceRetentionDays = 30
+
+// WorkspaceSchemas lets new workspaces keep their data in their own Postgres schema
+var WorkspaceSchemas bool
+
+// Database pool settings, the lifetimes are in minutes and the
+// statement timeout in seconds
+var DbMaxOpenConns = 25
+var DbMaxIdleConns = 10
+var DbConnMaxLifetime = 30
+var DbConnMaxIdleTime = 5
+var DbStatementTimeout = 30
+
+// DbSlowQueryMs is how long a query can take before it is logged as slow
+var DbSlowQueryMs = 500
+
+// SearchRateLimit is how many public search requests an IP can make per minute
+var SearchRateLimit = 60
+
+// BusyBountyThreshold is how many unfinished assigned bounties
+// a hunter can have before they show as busy
+var BusyBountyThreshold = 3
+
+// OnchainPayoutThreshold is the amount in sats from which bounties are paid
+// on-chain to hunters with an on-chain address, 0 turns on-chain payouts off
+var OnchainPayoutThreshold = 0
+
+// OnchainConfirmations is how many confirmations settle an on-chain payout
+var OnchainConfirmations = 3
+var PaymentSandbox bool
+var AssetServiceUrl string
+var AssetServiceToken string
+
+// EmbeddingServiceUrl is an OpenAI compatible embeddings endpoint, the
+// workspace knowledge search is off without it
+var EmbeddingServiceUrl string
+var EmbeddingServiceKey string
+var EmbeddingModel = "text-embedding-3-small"
 
 var S3Client *s3.Client
 var PresignClient *s3.PresignClient
 
 func InitConfig() {
+	InitSecrets()
+
 	Host = os.Getenv("LN_SERVER_BASE_URL")
-	JwtKey = os.Getenv("LN_JWT_KEY")
+	JwtKey = GetSecret("LN_JWT_KEY")
 	RelayUrl = os.Getenv("RELAY_URL")
 	MemeUrl = os.Getenv("MEME_URL")
-	RelayAuthKey = os.Getenv("RELAY_AUTH_KEY")
+	RelayAuthKey = GetSecret("RELAY_AUTH_KEY")
 	AdminStrings = os.Getenv("ADMINS")
-	AwsSecret := os.Getenv("AWS_SECRET_ACCESS")
+	AwsSecret := GetSecret("AWS_SECRET_ACCESS")
 	AwsAccess := os.Getenv("AWS_ACCESS_KEY_ID")
 	AwsRegion := os.Getenv("AWS_REGION")
 	S3BucketName = os.Getenv("S3_BUCKET_NAME")
 	S3FolderName = os.Getenv("S3_FOLDER_NAME")
 	S3Url = os.Getenv("S3_URL")
 	AdminCheck = os.Getenv("ADMIN_CHECK")
-	Connection_Auth = os.Getenv("CONNECTION_AUTH")
+	Connection_Auth = GetSecret("CONNECTION_AUTH")
+	CodeGraphUrl = os.Getenv("CODE_GRAPH_URL")
+	CodeGraphToken = GetSecret("CODE_GRAPH_TOKEN")
+	ReceiptSigningKey = GetSecret("RECEIPT_SIGNING_KEY")
+	CalendarSigningKey = GetSecret("CALENDAR_SIGNING_KEY")
+	ShortLinkSigningKey = GetSecret("SHORT_LINK_SIGNING_KEY")
+	SentryDsn = os.Getenv("SENTRY_DSN")
+	OtelEndpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	StaleBountyWarnDays = getEnvInt("STALE_BOUNTY_WARN_DAYS", StaleBountyWarnDays)
+	StaleBountyUnassignDays = getEnvInt("STALE_BOUNTY_UNASSIGN_DAYS", StaleBountyUnassignDays)
+	BountyDueReminderHours = getEnvIntList("BOUNTY_DUE_REMINDER_HOURS", BountyDueReminderHours)
+	BountyOverdueReminderHours = getEnvIntList("BOUNTY_OVERDUE_REMINDER_HOURS", BountyOverdueReminderHours)
+	BusyBountyThreshold = getEnvInt("BUSY_BOUNTY_THRESHOLD", BusyBountyThreshold)
+	WorkspaceRetentionDays = getEnvInt("WORKSPACE_RETENTION_DAYS", WorkspaceRetentionDays)
+	RelayTimeout = getEnvInt("RELAY_TIMEOUT_SECONDS", RelayTimeout)
+	RelayRetries = getEnvInt("RELAY_RETRIES", RelayRetries)
+	RelayBreakerThreshold = getEnvInt("RELAY_BREAKER_THRESHOLD", RelayBreakerThreshold)
+	RelayBreakerCooldown = getEnvInt("RELAY_BREAKER_COOLDOWN_SECONDS", RelayBreakerCooldown)
+	if url := os.Getenv("RATE_PROVIDER_URL"); url != "" {
+		RateProviderUrl = url
+	}
+	RateCacheSeconds = getEnvInt("RATE_CACHE_SECONDS", RateCacheSeconds)
+	SearchRateLimit = getEnvInt("SEARCH_RATE_LIMIT", SearchRateLimit)
+	OnchainPayoutThreshold = getEnvInt("ONCHAIN_PAYOUT_THRESHOLD", OnchainPayoutThreshold)
+	OnchainConfirmations = getEnvInt("ONCHAIN_CONFIRMATIONS", OnchainConfirmations)
+	DbMaxOpenConns = getEnvInt("DB_MAX_OPEN_CONNS", DbMaxOpenConns)
+	DbMaxIdleConns = getEnvInt("DB_MAX_IDLE_CONNS", DbMaxIdleConns)
+	DbConnMaxLifetime = getEnvInt("DB_CONN_MAX_LIFETIME_MINUTES", DbConnMaxLifetime)
+	DbConnMaxIdleTime = getEnvInt("DB_CONN_MAX_IDLE_TIME_MINUTES", DbConnMaxIdleTime)
+	DbStatementTimeout = getEnvInt("DB_STATEMENT_TIMEOUT_SECONDS", DbStatementTimeout)
+	DbSlowQueryMs = getEnvInt("DB_SLOW_QUERY_MS", DbSlowQueryMs)
+	ConfigReloadSeconds = getEnvInt("CONFIG_RELOAD_SECONDS", ConfigReloadSeconds)
+	DisabledRouteGroups = parseRouteGroups(os.Getenv("DISABLED_ROUTE_GROUPS"))
+	NostrRelays = parseNostrRelays(os.Getenv("NOSTR_RELAYS"))
+	NostrPrivateKey = GetSecret("NOSTR_PRIVATE_KEY")
+	SlackSigningSecret = GetSecret("SLACK_SIGNING_SECRET")
+	HstsMaxAge = getEnvInt("HSTS_MAX_AGE_SECONDS", HstsMaxAge)
+	TokenClockSkewSeconds = getEnvInt("TOKEN_CLOCK_SKEW_SECONDS", TokenClockSkewSeconds)
+	WebhookMaxSkewSeconds = getEnvInt("WEBHOOK_MAX_SKEW_SECONDS", WebhookMaxSkewSeconds)
+	if policy := os.Getenv("CONTENT_SECURITY_POLICY"); policy != "" {
+		ContentSecurityPolicy = policy
+	}
+	if ancestors := os.Getenv("EMBED_FRAME_ANCESTORS"); ancestors != "" {
+		EmbedFrameAncestors = ancestors
+	}
+	if workflowId := os.Getenv("YOUTUBE_WORKFLOW_ID"); workflowId != "" {
+		YoutubeWorkflowId = workflowId
+	}
+	TicketReviewWorkflowId = os.Getenv("TICKET_REVIEW_WORKFLOW_ID")
+	PhasePlanWorkflowId = os.Getenv("PHASE_PLAN_WORKFLOW_ID")
+	if DbMaxIdleConns > DbMaxOpenConns {
+		DbMaxIdleConns = DbMaxOpenConns
+	}
+	if StaleBountyUnassignDays <= StaleBountyWarnDays {
+		panic("STALE_BOUNTY_UNASSIGN_DAYS must be more than STALE_BOUNTY_WARN_DAYS")
+	}
+	PaymentSandbox = os.Getenv("PAYMENT_SANDBOX") == "true"
+	WorkspaceSchemas = os.Getenv("WORKSPACE_SCHEMAS") == "true"
+	AssetServiceUrl = os.Getenv("ASSET_SERVICE_URL")
+	AssetServiceToken = GetSecret("ASSET_SERVICE_TOKEN")
+	EmbeddingServiceUrl = os.Getenv("EMBEDDING_SERVICE_URL")
+	EmbeddingServiceKey = GetSecret("EMBEDDING_SERVICE_KEY")
+	if model := os.Getenv("EMBEDDING_MODEL"); model != "" {
+		EmbeddingModel = model
+	}
 
 	// Add to super admins
 	SuperAdmins = StripSuperAdmins(AdminStrings)
@@ -71,6 +250,10 @@ func InitConfig() {
 	S3Client = s3.NewFromConfig(awsConfig)
 	PresignClient = s3.NewPresignClient(S3Client)
 
+	if PaymentSandbox {
+		InitPaymentSandbox()
+	}
+
 	// only make this call if there is a Relay auth key
 	if RelayAuthKey != "" {
 		RelayNodeKey = GetNodePubKey()
@@ -78,6 +261,12 @@ func InitConfig() {
 		panic("No relay auth key set")
 	}
 
+	// receipts are signed with their own key, they stay valid when the jwt
+	// key is rotated
+	if ReceiptSigningKey == "" {
+		panic("No receipt signing key set")
+	}
+
 	if Host == "" {
 		Host = "https://people.sphinx.chat"
 	}
@@ -90,6 +279,18 @@ func InitConfig() {
 		JwtKey = GenerateRandomString()
 	}
 
+	if CalendarSigningKey == "" {
+		CalendarSigningKey = JwtKey
+	}
+
+	if ShortLinkSigningKey == "" {
+		ShortLinkSigningKey = JwtKey
+	}
+
+	if AssetServiceUrl == "" {
+		AssetServiceUrl = "https://liquid.sphinx.chat"
+	}
+
 	if S3BucketName == "" {
 		S3BucketName = "sphinx-tribes"
 	}
@@ -103,6 +304,75 @@ func InitConfig() {
 	}
 }
 
+// InitPaymentSandbox routes all relay calls to a simulated Lightning backend
+func InitPaymentSandbox() {
+	if RelayUrl == "" {
+		RelayUrl = SandboxRelayUrl
+	}
+	if RelayAuthKey == "" {
+		RelayAuthKey = "sandbox"
+	}
+
+	failAmount, _ := strconv.Atoi(os.Getenv("PAYMENT_SANDBOX_FAIL_AMOUNT"))
+	http.DefaultTransport = SandboxTransport{
+		RelayUrl:   RelayUrl,
+		FailAmount: uint(failAmount),
+		FailKeys:   StripSuperAdmins(os.Getenv("PAYMENT_SANDBOX_FAIL_KEYS")),
+		Next:       http.DefaultTransport,
+	}
+	fmt.Println("Payment sandbox enabled, relay calls are simulated")
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// parseNostrRelays reads a comma separated list of relay urls, like
+// "wss://relay.damus.io,wss://nos.lol"
+func parseNostrRelays(value string) []string {
+	relays := []string{}
+	for _, relay := range strings.Split(value, ",") {
+		relay = strings.TrimSpace(relay)
+		if strings.HasPrefix(relay, "wss://") || strings.HasPrefix(relay, "ws://") {
+			relays = append(relays, relay)
+		}
+	}
+	return relays
+}
+
+// NostrEnabled tells if bounties are published to Nostr
+func NostrEnabled() bool {
+	return len(NostrRelays) > 0 && NostrPrivateKey != ""
+}
+
+// getEnvIntList reads a comma separated list of positive numbers, like "48,24"
+func getEnvIntList(key string, fallback []int) []int {
+	values, err := parseIntList(os.Getenv(key))
+	if err != nil || len(values) == 0 {
+		return fallback
+	}
+	return values
+}
+
+func parseIntList(value string) ([]int, error) {
+	values := []int{}
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || number <= 0 {
+			return nil, fmt.Errorf("%q is not a positive number", part)
+		}
+		values = append(values, number)
+	}
+	return values, nil
+}
+
 func StripSuperAdmins(adminStrings string) []string {
 	superAdmins := []string{}
 	if adminStrings != "" {
@@ -278,7 +548,7 @@ func GetNodePubKey() string {
 		log.Printf("Request Failed: %s", err)
 	}
 
-	req.Header.Set("x-user-token", RelayAuthKey)
+	req.Header.Set("x-user-token", CurrentRelayAuthKey())
 	req.Header.Set("Content-Type", "application/json")
 	res, err := client.Do(req)
 
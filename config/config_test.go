@@ -1,8 +1,11 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/h2non/gock"
@@ -80,3 +83,70 @@ func TestStripSuperAdmins(t *testing.T) {
 	admins2 := StripSuperAdmins(test2Admins)
 	assert.Equal(t, len(admins2), 2)
 }
+
+func TestParseNostrRelays(t *testing.T) {
+	relays := parseNostrRelays(" wss://relay.damus.io, https://nos.lol,,ws://localhost:7777")
+	assert.Equal(t, []string{"wss://relay.damus.io", "ws://localhost:7777"}, relays)
+	assert.Empty(t, parseNostrRelays(""))
+}
+
+func TestSandboxTransport(t *testing.T) {
+	client := &http.Client{Transport: SandboxTransport{
+		RelayUrl:   SandboxRelayUrl,
+		FailAmount: 13,
+		FailKeys:   []string{"failing_pubkey"},
+		Next:       http.DefaultTransport,
+	}}
+
+	keysend := func(body string) (*http.Response, map[string]interface{}) {
+		req, _ := http.NewRequest(http.MethodPost, SandboxRelayUrl+"/payment", strings.NewReader(body))
+		res, err := client.Do(req)
+		assert.NoError(t, err)
+
+		result := map[string]interface{}{}
+		json.NewDecoder(res.Body).Decode(&result)
+		return res, result
+	}
+
+	t.Run("should succeed a keysend payment", func(t *testing.T) {
+		res, result := keysend(`{"amount": 1000, "destination_key": "pubkey"}`)
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, true, result["success"])
+		assert.NotEmpty(t, result["response"].(map[string]interface{})["payment_hash"])
+	})
+
+	t.Run("should fail a keysend for the fail amount or a fail key", func(t *testing.T) {
+		res, _ := keysend(`{"amount": 13, "destination_key": "pubkey"}`)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+		res, _ = keysend(`{"amount": 1000, "destination_key": "failing_pubkey"}`)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("should settle a created invoice", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, SandboxRelayUrl+"/invoices", strings.NewReader(`{"amount": 100, "memo": "memo"}`))
+		res, err := client.Do(req)
+		assert.NoError(t, err)
+
+		created := struct {
+			Response struct {
+				Invoice string `json:"invoice"`
+			} `json:"response"`
+		}{}
+		json.NewDecoder(res.Body).Decode(&created)
+		assert.NotEmpty(t, created.Response.Invoice)
+
+		req, _ = http.NewRequest(http.MethodGet, SandboxRelayUrl+"/invoice?payment_request="+created.Response.Invoice, nil)
+		res, err = client.Do(req)
+		assert.NoError(t, err)
+
+		status := struct {
+			Response struct {
+				Settled bool `json:"settled"`
+			} `json:"response"`
+		}{}
+		json.NewDecoder(res.Body).Decode(&status)
+		assert.True(t, status.Response.Settled)
+	})
+}
@@ -0,0 +1,286 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FeatureFlagPrefix starts the keys of the feature flags in the configs table,
+// flag.<name> = true turns the feature on
+const FeatureFlagPrefix = "flag."
+
+// ConfigReloadSeconds is how often the configs table is read for changes
+var ConfigReloadSeconds = 30
+
+// YoutubeWorkflowId is the stakwork workflow that downloads youtube videos
+var YoutubeWorkflowId = "11848"
+
+// TicketReviewWorkflowId is the stakwork workflow that reviews tickets, ticket
+// reviews can't be submitted while it is empty
+var TicketReviewWorkflowId = ""
+
+// PhasePlanWorkflowId is the stakwork workflow that drafts the tickets of a
+// phase, phases can't be planned while it is empty
+var PhasePlanWorkflowId = ""
+
+// MaintenanceMode makes the api read-only, writes are answered with a 503
+// and MaintenanceMessage, set it through the configs table
+var MaintenanceMode = false
+
+// DefaultMaintenanceMessage is shown when no maintenance_message is set
+const DefaultMaintenanceMessage = "Sphinx Community is down for maintenance, changes can't be saved right now"
+
+var MaintenanceMessage = DefaultMaintenanceMessage
+
+// overridable is a config value that the configs table can change at runtime
+type overridable struct {
+	get      func() string
+	validate func(value string) error
+	set      func(value string)
+}
+
+func intOverride(target *int) overridable {
+	return overridable{
+		get: func() string { return strconv.Itoa(*target) },
+		validate: func(value string) error {
+			if number, err := strconv.Atoi(value); err != nil || number <= 0 {
+				return fmt.Errorf("%q is not a positive number", value)
+			}
+			return nil
+		},
+		set: func(value string) {
+			*target, _ = strconv.Atoi(value)
+		},
+	}
+}
+
+func intListOverride(target *[]int) overridable {
+	return overridable{
+		get: func() string {
+			values := make([]string, len(*target))
+			for i, value := range *target {
+				values[i] = strconv.Itoa(value)
+			}
+			return strings.Join(values, ",")
+		},
+		validate: func(value string) error {
+			values, err := parseIntList(value)
+			if err == nil && len(values) == 0 {
+				err = fmt.Errorf("value can't be empty")
+			}
+			return err
+		},
+		set: func(value string) {
+			*target, _ = parseIntList(value)
+		},
+	}
+}
+
+func boolOverride(target *bool) overridable {
+	return overridable{
+		get: func() string { return strconv.FormatBool(*target) },
+		validate: func(value string) error {
+			if _, err := strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("%q is not true or false", value)
+			}
+			return nil
+		},
+		set: func(value string) {
+			*target, _ = strconv.ParseBool(value)
+		},
+	}
+}
+
+func stringOverride(target *string) overridable {
+	return overridable{
+		get: func() string { return *target },
+		validate: func(value string) error {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Errorf("value can't be empty")
+			}
+			return nil
+		},
+		set: func(value string) {
+			*target = value
+		},
+	}
+}
+
+var overridables = map[string]overridable{
+	"search_rate_limit":             intOverride(&SearchRateLimit),
+	staleBountyWarnKey:              intOverride(&StaleBountyWarnDays),
+	staleBountyUnassignKey:          intOverride(&StaleBountyUnassignDays),
+	"bounty_due_reminder_hours":     intListOverride(&BountyDueReminderHours),
+	"bounty_overdue_reminder_hours": intListOverride(&BountyOverdueReminderHours),
+	"busy_bounty_threshold":         intOverride(&BusyBountyThreshold),
+	"db_slow_query_ms":              intOverride(&DbSlowQueryMs),
+	"webhook_max_skew_seconds":      intOverride(&WebhookMaxSkewSeconds),
+	"youtube_workflow_id":           stringOverride(&YoutubeWorkflowId),
+	"ticket_review_workflow_id":     stringOverride(&TicketReviewWorkflowId),
+	"phase_plan_workflow_id":        stringOverride(&PhasePlanWorkflowId),
+	"maintenance_mode":              boolOverride(&MaintenanceMode),
+	"maintenance_message":           stringOverride(&MaintenanceMessage),
+}
+
+var (
+	overridesMu     sync.RWMutex
+	overrideDefault map[string]string
+	featureFlags    = map[string]bool{}
+	reloadHooks     []func()
+)
+
+// OverridableKeys are the keys the configs table can set, besides feature flags
+func OverridableKeys() []string {
+	keys := make([]string, 0, len(overridables))
+	for key := range overridables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ValidateOverride checks that key can be overridden with value
+func ValidateOverride(key string, value string) error {
+	if err := validateOverride(key, value); err != nil {
+		return err
+	}
+
+	// the stale bounty days are checked against the value the other one has now
+	if key == staleBountyWarnKey || key == staleBountyUnassignKey {
+		return checkStaleBountyDays(map[string]string{key: value}, func(other string) string {
+			return overridables[other].get()
+		})
+	}
+	return nil
+}
+
+func validateOverride(key string, value string) error {
+	if strings.HasPrefix(key, FeatureFlagPrefix) {
+		if strings.TrimPrefix(key, FeatureFlagPrefix) == "" {
+			return fmt.Errorf("feature flag needs a name")
+		}
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+		return nil
+	}
+
+	o, ok := overridables[key]
+	if !ok {
+		return fmt.Errorf("%s can't be overridden, use one of %s or %s<name>", key, strings.Join(OverridableKeys(), ", "), FeatureFlagPrefix)
+	}
+	return o.validate(value)
+}
+
+const (
+	staleBountyWarnKey     = "stale_bounty_warn_days"
+	staleBountyUnassignKey = "stale_bounty_unassign_days"
+)
+
+// checkStaleBountyDays makes sure a stale bounty is warned before it is unassigned,
+// a day missing from values is taken from current
+func checkStaleBountyDays(values map[string]string, current func(key string) string) error {
+	day := func(key string) int {
+		value, ok := values[key]
+		if !ok {
+			value = current(key)
+		}
+		number, _ := strconv.Atoi(value)
+		return number
+	}
+	if warn, unassign := day(staleBountyWarnKey), day(staleBountyUnassignKey); unassign <= warn {
+		return fmt.Errorf("%s (%d) must be more than %s (%d)", staleBountyUnassignKey, unassign, staleBountyWarnKey, warn)
+	}
+	return nil
+}
+
+// OnConfigReload runs hook after overrides are applied, for values that are copied at startup
+func OnConfigReload(hook func()) {
+	overridesMu.Lock()
+	defer overridesMu.Unlock()
+	reloadHooks = append(reloadHooks, hook)
+}
+
+// ApplyOverrides sets the overridable values to the ones in overrides, a key that is
+// not in overrides any more goes back to its env or default value. Invalid values
+// are skipped and returned as errors. It returns whether anything changed
+func ApplyOverrides(overrides map[string]string) (bool, []error) {
+	overridesMu.Lock()
+
+	if overrideDefault == nil {
+		overrideDefault = map[string]string{}
+		for key, o := range overridables {
+			overrideDefault[key] = o.get()
+		}
+	}
+
+	errs := []error{}
+	values := map[string]string{}
+	flags := map[string]bool{}
+	for key, value := range overrides {
+		if err := validateOverride(key, value); err != nil {
+			errs = append(errs, fmt.Errorf("config %s: %w", key, err))
+			continue
+		}
+		if strings.HasPrefix(key, FeatureFlagPrefix) {
+			flags[strings.TrimPrefix(key, FeatureFlagPrefix)], _ = strconv.ParseBool(value)
+			continue
+		}
+		values[key] = value
+	}
+	if err := checkStaleBountyDays(values, func(key string) string { return overrideDefault[key] }); err != nil {
+		errs = append(errs, fmt.Errorf("config: %w", err))
+		delete(values, staleBountyWarnKey)
+		delete(values, staleBountyUnassignKey)
+	}
+
+	changed := len(flags) != len(featureFlags)
+	for name, enabled := range flags {
+		if current, ok := featureFlags[name]; !ok || current != enabled {
+			changed = true
+		}
+	}
+	for key, o := range overridables {
+		value, ok := values[key]
+		if !ok {
+			value = overrideDefault[key]
+		}
+		if o.get() != value {
+			o.set(value)
+			changed = true
+		}
+	}
+	featureFlags = flags
+
+	hooks := reloadHooks
+	overridesMu.Unlock()
+
+	if changed {
+		for _, hook := range hooks {
+			hook()
+		}
+	}
+	return changed, errs
+}
+
+// FeatureEnabled tells if the flag.<name> feature flag is on
+func FeatureEnabled(name string) bool {
+	overridesMu.RLock()
+	defer overridesMu.RUnlock()
+	return featureFlags[name]
+}
+
+// Maintenance tells if the api is in maintenance mode and the message to show
+func Maintenance() (bool, string) {
+	overridesMu.RLock()
+	defer overridesMu.RUnlock()
+	return MaintenanceMode, MaintenanceMessage
+}
+
+// ResetOverrides puts back the env or default values, for tests
+func ResetOverrides() {
+	ApplyOverrides(map[string]string{})
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyOverrides(t *testing.T) {
+	defer ResetOverrides()
+	defaultLimit := SearchRateLimit
+	defaultWorkflow := YoutubeWorkflowId
+
+	t.Run("should validate keys and values", func(t *testing.T) {
+		assert.NoError(t, ValidateOverride("search_rate_limit", "120"))
+		assert.NoError(t, ValidateOverride("youtube_workflow_id", "12000"))
+		assert.NoError(t, ValidateOverride("flag.board_v2", "true"))
+		assert.NoError(t, ValidateOverride("maintenance_mode", "true"))
+		assert.Error(t, ValidateOverride("maintenance_mode", "soon"))
+		assert.NoError(t, ValidateOverride("bounty_due_reminder_hours", "72, 24,1"))
+		assert.Error(t, ValidateOverride("bounty_due_reminder_hours", "24,soon"))
+		assert.Error(t, ValidateOverride("bounty_overdue_reminder_hours", ","))
+		assert.Error(t, ValidateOverride("search_rate_limit", "-1"))
+		assert.Error(t, ValidateOverride("search_rate_limit", "lots"))
+		assert.Error(t, ValidateOverride("jwt_key", "secret"))
+		assert.Error(t, ValidateOverride("flag.", "true"))
+		assert.Error(t, ValidateOverride("flag.board_v2", "maybe"))
+		assert.Equal(t, defaultLimit, SearchRateLimit, "validating should not change the value")
+	})
+
+	t.Run("should apply overrides and feature flags and run the reload hooks", func(t *testing.T) {
+		reloads := 0
+		OnConfigReload(func() { reloads++ })
+
+		changed, errs := ApplyOverrides(map[string]string{
+			"search_rate_limit":   "120",
+			"youtube_workflow_id": "12000",
+			"flag.board_v2":       "true",
+			"jwt_key":             "secret",
+		})
+		assert.True(t, changed)
+		assert.Len(t, errs, 1)
+		assert.Equal(t, 120, SearchRateLimit)
+		assert.Equal(t, "12000", YoutubeWorkflowId)
+		assert.True(t, FeatureEnabled("board_v2"))
+		assert.False(t, FeatureEnabled("other"))
+		assert.Equal(t, 1, reloads)
+
+		changed, _ = ApplyOverrides(map[string]string{
+			"search_rate_limit":   "120",
+			"youtube_workflow_id": "12000",
+			"flag.board_v2":       "true",
+		})
+		assert.False(t, changed)
+		assert.Equal(t, 1, reloads)
+	})
+
+	t.Run("should go back to the defaults when overrides are removed", func(t *testing.T) {
+		changed, errs := ApplyOverrides(map[string]string{})
+		assert.True(t, changed)
+		assert.Empty(t, errs)
+		assert.Equal(t, defaultLimit, SearchRateLimit)
+		assert.Equal(t, defaultWorkflow, YoutubeWorkflowId)
+		assert.False(t, FeatureEnabled("board_v2"))
+	})
+
+	t.Run("should keep the stale bounty unassign days over the warn days", func(t *testing.T) {
+		assert.NoError(t, ValidateOverride("stale_bounty_warn_days", "12"))
+		assert.Error(t, ValidateOverride("stale_bounty_warn_days", "14"))
+		assert.Error(t, ValidateOverride("stale_bounty_unassign_days", "10"))
+
+		_, errs := ApplyOverrides(map[string]string{
+			"stale_bounty_warn_days":     "20",
+			"stale_bounty_unassign_days": "30",
+		})
+		assert.Empty(t, errs)
+		assert.Equal(t, 20, StaleBountyWarnDays)
+		assert.Equal(t, 30, StaleBountyUnassignDays)
+
+		_, errs = ApplyOverrides(map[string]string{"stale_bounty_warn_days": "20"})
+		assert.Len(t, errs, 1)
+		assert.Equal(t, 10, StaleBountyWarnDays)
+		assert.Equal(t, 14, StaleBountyUnassignDays)
+	})
+}
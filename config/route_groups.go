@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Route groups a deployment can turn off with DISABLED_ROUTE_GROUPS, so a
+// community deployment can run without a lightning node or Stakwork
+const (
+	RouteGroupPayments = "payments"
+	RouteGroupAI       = "ai"
+	RouteGroupAdmin    = "admin"
+)
+
+var routeGroups = []string{RouteGroupPayments, RouteGroupAI, RouteGroupAdmin}
+
+// DisabledRouteGroups are the route groups that answer with a 501
+var DisabledRouteGroups []string
+
+// parseRouteGroups reads a comma separated list of route groups, unknown
+// groups are skipped with a warning
+func parseRouteGroups(value string) []string {
+	groups := []string{}
+	for _, group := range strings.Split(value, ",") {
+		group = strings.ToLower(strings.TrimSpace(group))
+		if group == "" {
+			continue
+		}
+		known := false
+		for _, routeGroup := range routeGroups {
+			known = known || routeGroup == group
+		}
+		if !known {
+			fmt.Printf("[config] unknown route group %q in DISABLED_ROUTE_GROUPS, use %s\n", group, strings.Join(routeGroups, ", "))
+			continue
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// RouteGroupDisabled tells if the routes of group are turned off
+func RouteGroupDisabled(group string) bool {
+	for _, disabled := range DisabledRouteGroups {
+		if disabled == group {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteGroups(t *testing.T) {
+	defer func() { DisabledRouteGroups = nil }()
+
+	t.Run("should skip unknown groups", func(t *testing.T) {
+		assert.Equal(t, []string{RouteGroupPayments, RouteGroupAI}, parseRouteGroups(" Payments, ai,,stakwork"))
+		assert.Empty(t, parseRouteGroups(""))
+	})
+
+	t.Run("should tell which groups are disabled", func(t *testing.T) {
+		DisabledRouteGroups = parseRouteGroups("admin")
+		assert.True(t, RouteGroupDisabled(RouteGroupAdmin))
+		assert.False(t, RouteGroupDisabled(RouteGroupPayments))
+	})
+}
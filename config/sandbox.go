@@ -0,0 +1,182 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const SandboxRelayUrl = "http://relay.sandbox"
+const SandboxNodePubkey = "03sandboxnode000000000000000000000000000000000000000000000000000000"
+
+// SandboxTransport answers relay requests with simulated Lightning responses,
+// so payment flows can run without a node. Every other request goes to Next.
+//
+// Results are deterministic: invoices settle and payments succeed, unless the
+// amount equals FailAmount or the destination pubkey / payment request is
+// listed in FailKeys.
+type SandboxTransport struct {
+	RelayUrl   string
+	FailAmount uint
+	FailKeys   []string
+	Next       http.RoundTripper
+}
+
+func (t SandboxTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	relay, err := url.Parse(t.RelayUrl)
+	if err != nil || req.URL.Host != relay.Host {
+		return t.Next.RoundTrip(req)
+	}
+
+	body := []byte{}
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, relay.Path), "/")
+
+	switch {
+	case req.Method == http.MethodGet && path == "/getinfo":
+		return sandboxResponse(req, http.StatusOK, map[string]interface{}{
+			"success":  true,
+			"response": map[string]interface{}{"identity_pubkey": SandboxNodePubkey, "alias": "sandbox"},
+		})
+
+	case req.Method == http.MethodPost && path == "/invoices":
+		return sandboxResponse(req, http.StatusOK, map[string]interface{}{
+			"success":  true,
+			"response": map[string]string{"invoice": "lnsandbox" + sandboxHash(body)},
+		})
+
+	case req.Method == http.MethodGet && path == "/invoice":
+		paymentRequest := req.URL.Query().Get("payment_request")
+		return sandboxResponse(req, http.StatusOK, map[string]interface{}{
+			"success": true,
+			"response": map[string]interface{}{
+				"settled":         !t.failsKey(paymentRequest),
+				"payment_request": paymentRequest,
+				"payment_hash":    sandboxHash([]byte(paymentRequest)),
+				"preimage":        "",
+				"amount":          "0",
+			},
+		})
+
+	case req.Method == http.MethodPut && path == "/invoices":
+		invoice := struct {
+			PaymentRequest string `json:"payment_request"`
+		}{}
+		json.Unmarshal(body, &invoice)
+
+		if t.failsKey(invoice.PaymentRequest) {
+			return sandboxResponse(req, http.StatusBadRequest, map[string]interface{}{
+				"success": false,
+				"error":   "sandbox invoice payment failed",
+			})
+		}
+		return sandboxResponse(req, http.StatusOK, map[string]interface{}{
+			"success": true,
+			"response": map[string]interface{}{
+				"settled":         true,
+				"payment_request": invoice.PaymentRequest,
+				"payment_hash":    sandboxHash([]byte(invoice.PaymentRequest)),
+				"preimage":        sandboxHash(body),
+				"amount":          "0",
+			},
+		})
+
+	case req.Method == http.MethodPost && path == "/payment":
+		keysend := struct {
+			Amount         uint   `json:"amount"`
+			DestinationKey string `json:"destination_key"`
+		}{}
+		json.Unmarshal(body, &keysend)
+
+		if (t.FailAmount != 0 && keysend.Amount == t.FailAmount) || t.failsKey(keysend.DestinationKey) {
+			return sandboxResponse(req, http.StatusBadRequest, map[string]interface{}{
+				"success": false,
+				"error":   "sandbox keysend payment failed",
+			})
+		}
+		return sandboxResponse(req, http.StatusOK, map[string]interface{}{
+			"success": true,
+			"response": map[string]interface{}{
+				"amount":          keysend.Amount,
+				"destination_key": keysend.DestinationKey,
+				"payment_hash":    sandboxHash(body),
+			},
+		})
+
+	case req.Method == http.MethodGet && path == "/onchain/fee":
+		return sandboxResponse(req, http.StatusOK, map[string]interface{}{
+			"success":  true,
+			"response": map[string]interface{}{"fee_sat": 250, "sat_per_vbyte": 2},
+		})
+
+	case req.Method == http.MethodPost && path == "/onchain/send":
+		send := struct {
+			Address string `json:"address"`
+			Amount  uint   `json:"amount"`
+		}{}
+		json.Unmarshal(body, &send)
+
+		if (t.FailAmount != 0 && send.Amount == t.FailAmount) || t.failsKey(send.Address) {
+			return sandboxResponse(req, http.StatusBadRequest, map[string]interface{}{
+				"success": false,
+				"error":   "sandbox on-chain payment failed",
+			})
+		}
+		return sandboxResponse(req, http.StatusOK, map[string]interface{}{
+			"success":  true,
+			"response": map[string]interface{}{"txid": sandboxHash(body)},
+		})
+
+	case req.Method == http.MethodGet && path == "/onchain/tx":
+		return sandboxResponse(req, http.StatusOK, map[string]interface{}{
+			"success": true,
+			"response": map[string]interface{}{
+				"txid":          req.URL.Query().Get("txid"),
+				"confirmations": 6,
+			},
+		})
+	}
+
+	return sandboxResponse(req, http.StatusNotFound, map[string]interface{}{
+		"success": false,
+		"error":   "not supported by the payment sandbox",
+	})
+}
+
+func (t SandboxTransport) failsKey(key string) bool {
+	for _, failKey := range t.FailKeys {
+		if failKey != "" && failKey == key {
+			return true
+		}
+	}
+	return false
+}
+
+func sandboxHash(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func sandboxResponse(req *http.Request, status int, body interface{}) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,252 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// SecretsProvider loads the secrets kept outside of the env, keyed by the name
+// of the env var they replace, e.g. LN_JWT_KEY or RDS_PASSWORD
+type SecretsProvider interface {
+	LoadSecrets(ctx context.Context) (map[string]string, error)
+}
+
+// EnvSecrets keeps every secret in the env, it is the default provider
+type EnvSecrets struct{}
+
+func (EnvSecrets) LoadSecrets(ctx context.Context) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+// VaultSecrets reads a KV version 2 secret from Vault, e.g. Path secret/data/tribes
+type VaultSecrets struct {
+	Url    string
+	Token  string
+	Path   string
+	Client *http.Client
+}
+
+func (v VaultSecrets) LoadSecrets(ctx context.Context) (map[string]string, error) {
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(v.Url, "/"), strings.TrimPrefix(v.Path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+
+	client := v.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned %d for %s", res.StatusCode, v.Path)
+	}
+
+	secret := struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&secret); err != nil {
+		return nil, fmt.Errorf("could not read vault secret %s: %w", v.Path, err)
+	}
+	return secret.Data.Data, nil
+}
+
+// AwsSecrets reads a secret from AWS Secrets Manager whose value is
+// a json object of the secrets
+type AwsSecrets struct {
+	Client   *secretsmanager.Client
+	SecretId string
+}
+
+func (a AwsSecrets) LoadSecrets(ctx context.Context) (map[string]string, error) {
+	output, err := a.Client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(a.SecretId),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := map[string]string{}
+	if err := json.Unmarshal([]byte(aws.ToString(output.SecretString)), &secrets); err != nil {
+		return nil, fmt.Errorf("secret %s is not a json object: %w", a.SecretId, err)
+	}
+	return secrets, nil
+}
+
+// NewSecretsProvider builds the provider named by SECRETS_PROVIDER, env, vault or aws
+func NewSecretsProvider(name string) (SecretsProvider, error) {
+	switch name {
+	case "", "env":
+		return EnvSecrets{}, nil
+	case "vault":
+		vault := VaultSecrets{
+			Url:    os.Getenv("VAULT_ADDR"),
+			Token:  os.Getenv("VAULT_TOKEN"),
+			Path:   os.Getenv("VAULT_SECRET_PATH"),
+			Client: &http.Client{Timeout: 10 * time.Second},
+		}
+		if vault.Url == "" || vault.Token == "" || vault.Path == "" {
+			return nil, errors.New("vault secrets need VAULT_ADDR, VAULT_TOKEN and VAULT_SECRET_PATH")
+		}
+		return vault, nil
+	case "aws":
+		secretId := os.Getenv("AWS_SECRET_ID")
+		if secretId == "" {
+			return nil, errors.New("aws secrets need AWS_SECRET_ID")
+		}
+		awsConfig, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(os.Getenv("AWS_REGION")))
+		if err != nil {
+			return nil, err
+		}
+		return AwsSecrets{Client: secretsmanager.NewFromConfig(awsConfig), SecretId: secretId}, nil
+	}
+	return nil, fmt.Errorf("unknown secrets provider %q, use env, vault or aws", name)
+}
+
+// SecretsRefreshMinutes is how often the secrets are loaded again to pick up
+// rotations, 0 only loads them at startup
+var SecretsRefreshMinutes = 0
+
+// PreviousJwtKey is the key before the last rotation, tokens signed with it
+// are still accepted so a rotation doesn't log everyone out
+var PreviousJwtKey string
+
+var (
+	secretsMu       sync.RWMutex
+	secretsProvider SecretsProvider = EnvSecrets{}
+	loadedSecrets                   = map[string]string{}
+	rotationHooks   []func()
+)
+
+// keysMu guards JwtKey, PreviousJwtKey and RelayAuthKey, which RotateSecrets
+// changes while requests read them
+var keysMu sync.RWMutex
+
+// JwtKeys returns the JWT key and the one before the last rotation
+func JwtKeys() (current string, previous string) {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	return JwtKey, PreviousJwtKey
+}
+
+// CurrentRelayAuthKey returns the relay key, rotated ones included
+func CurrentRelayAuthKey() string {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	return RelayAuthKey
+}
+
+// InitSecrets picks the provider and loads the secrets, it has to run before
+// anything reads them
+func InitSecrets() {
+	provider, err := NewSecretsProvider(os.Getenv("SECRETS_PROVIDER"))
+	if err != nil {
+		panic(err)
+	}
+	SecretsRefreshMinutes = getEnvInt("SECRETS_REFRESH_MINUTES", SecretsRefreshMinutes)
+	if err := SetSecretsProvider(provider); err != nil {
+		panic(fmt.Sprintf("could not load secrets: %s", err))
+	}
+}
+
+// SetSecretsProvider swaps the provider and loads its secrets
+func SetSecretsProvider(provider SecretsProvider) error {
+	secrets, err := loadSecrets(provider)
+	if err != nil {
+		return err
+	}
+
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	secretsProvider = provider
+	loadedSecrets = secrets
+	return nil
+}
+
+func loadSecrets(provider SecretsProvider) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	secrets, err := provider.LoadSecrets(ctx)
+	if secrets == nil {
+		secrets = map[string]string{}
+	}
+	return secrets, err
+}
+
+// GetSecret returns the secret from the provider, or the env var of the
+// same name when the provider doesn't have it
+func GetSecret(name string) string {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+
+	if value, ok := loadedSecrets[name]; ok && value != "" {
+		return value
+	}
+	return os.Getenv(name)
+}
+
+// OnSecretsRotated runs hook after a rotated secret is applied
+func OnSecretsRotated(hook func()) {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	rotationHooks = append(rotationHooks, hook)
+}
+
+// RotateSecrets loads the secrets again and applies the JWT and relay keys
+// if they changed. DB credentials are only read when connecting, so the
+// old ones have to stay valid until the next restart
+func RotateSecrets() (bool, error) {
+	secretsMu.RLock()
+	provider := secretsProvider
+	secretsMu.RUnlock()
+
+	secrets, err := loadSecrets(provider)
+	if err != nil {
+		return false, err
+	}
+
+	secretsMu.Lock()
+	loadedSecrets = secrets
+	hooks := rotationHooks
+	secretsMu.Unlock()
+
+	changed := false
+	keysMu.Lock()
+	if jwtKey := GetSecret("LN_JWT_KEY"); jwtKey != "" && jwtKey != JwtKey {
+		PreviousJwtKey = JwtKey
+		JwtKey = jwtKey
+		changed = true
+	}
+	if relayAuthKey := GetSecret("RELAY_AUTH_KEY"); relayAuthKey != "" && relayAuthKey != RelayAuthKey {
+		RelayAuthKey = relayAuthKey
+		changed = true
+	}
+	keysMu.Unlock()
+
+	if changed {
+		for _, hook := range hooks {
+			hook()
+		}
+	}
+	return changed, nil
+}
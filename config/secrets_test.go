@@ -0,0 +1,134 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticSecrets struct {
+	secrets map[string]string
+	err     error
+}
+
+func (s *staticSecrets) LoadSecrets(ctx context.Context) (map[string]string, error) {
+	return s.secrets, s.err
+}
+
+func TestVaultSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		assert.Equal(t, "/v1/secret/data/tribes", r.URL.Path)
+		w.Write([]byte(`{"data": {"data": {"LN_JWT_KEY": "vault-jwt", "RDS_PASSWORD": "vault-pass"}}}`))
+	}))
+	defer server.Close()
+
+	t.Run("should read the kv secret", func(t *testing.T) {
+		vault := VaultSecrets{Url: server.URL + "/", Token: "vault-token", Path: "/secret/data/tribes"}
+		secrets, err := vault.LoadSecrets(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "vault-jwt", secrets["LN_JWT_KEY"])
+		assert.Equal(t, "vault-pass", secrets["RDS_PASSWORD"])
+	})
+
+	t.Run("should return an error when vault refuses the token", func(t *testing.T) {
+		vault := VaultSecrets{Url: server.URL, Token: "wrong", Path: "secret/data/tribes"}
+		_, err := vault.LoadSecrets(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestNewSecretsProvider(t *testing.T) {
+	provider, err := NewSecretsProvider("")
+	assert.NoError(t, err)
+	assert.Equal(t, EnvSecrets{}, provider)
+
+	_, err = NewSecretsProvider("vault")
+	assert.Error(t, err, "vault needs its address, token and path")
+
+	_, err = NewSecretsProvider("keychain")
+	assert.Error(t, err)
+}
+
+func TestRotateSecrets(t *testing.T) {
+	defer SetSecretsProvider(EnvSecrets{})
+	defer func(jwtKey string, relayAuthKey string) {
+		JwtKey = jwtKey
+		RelayAuthKey = relayAuthKey
+		PreviousJwtKey = ""
+	}(JwtKey, RelayAuthKey)
+
+	os.Setenv("CODE_GRAPH_TOKEN", "env-token")
+	defer os.Unsetenv("CODE_GRAPH_TOKEN")
+
+	provider := &staticSecrets{secrets: map[string]string{"LN_JWT_KEY": "first", "RELAY_AUTH_KEY": "relay"}}
+	assert.NoError(t, SetSecretsProvider(provider))
+	JwtKey = GetSecret("LN_JWT_KEY")
+	RelayAuthKey = GetSecret("RELAY_AUTH_KEY")
+
+	rotations := 0
+	OnSecretsRotated(func() { rotations++ })
+
+	t.Run("should fall back to the env", func(t *testing.T) {
+		assert.Equal(t, "first", GetSecret("LN_JWT_KEY"))
+		assert.Equal(t, "env-token", GetSecret("CODE_GRAPH_TOKEN"))
+	})
+
+	t.Run("should do nothing when the secrets didn't change", func(t *testing.T) {
+		changed, err := RotateSecrets()
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, 0, rotations)
+	})
+
+	t.Run("should apply a rotated jwt key and keep the previous one", func(t *testing.T) {
+		provider.secrets = map[string]string{"LN_JWT_KEY": "second", "RELAY_AUTH_KEY": "relay"}
+		changed, err := RotateSecrets()
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "second", JwtKey)
+		assert.Equal(t, "first", PreviousJwtKey)
+		assert.Equal(t, "relay", RelayAuthKey)
+		assert.Equal(t, 1, rotations)
+
+		current, previous := JwtKeys()
+		assert.Equal(t, "second", current)
+		assert.Equal(t, "first", previous)
+		assert.Equal(t, "relay", CurrentRelayAuthKey())
+	})
+
+	t.Run("should keep the secrets when the provider fails", func(t *testing.T) {
+		provider.err = errors.New("vault sealed")
+		changed, err := RotateSecrets()
+		assert.Error(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, "second", JwtKey)
+		assert.Equal(t, "second", GetSecret("LN_JWT_KEY"))
+	})
+
+	t.Run("should let requests read the keys while they rotate", func(t *testing.T) {
+		done := make(chan bool)
+		go func() {
+			for i := 0; i < 100; i++ {
+				JwtKeys()
+				CurrentRelayAuthKey()
+			}
+			done <- true
+		}()
+
+		provider.err = nil
+		provider.secrets = map[string]string{"LN_JWT_KEY": "third", "RELAY_AUTH_KEY": "relay"}
+		changed, err := RotateSecrets()
+		<-done
+		assert.NoError(t, err)
+		assert.True(t, changed)
+	})
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrSuperAdminChangeNotFound = errors.New("super admin change not found")
+	ErrSuperAdminChangeExpired  = errors.New("super admin change has expired")
+
+	ErrSuperAdminChangeSelfConfirm = errors.New("super admin change has to be confirmed by another super admin")
+)
+
+func (db database) GetSuperAdmins() []SuperAdmin {
+	ms := []SuperAdmin{}
+	db.db.Model(&SuperAdmin{}).Order("created ASC").Find(&ms)
+	return ms
+}
+
+// GetSuperAdminPubkeys returns the pubkeys of the stored super admins,
+// it is the source auth merges with the env super admins
+func (db database) GetSuperAdminPubkeys() []string {
+	pubkeys := []string{}
+	db.db.Model(&SuperAdmin{}).Pluck("pubkey", &pubkeys)
+	return pubkeys
+}
+
+func (db database) CreateSuperAdminChange(change SuperAdminChange) (SuperAdminChange, error) {
+	if change.Created == nil {
+		now := time.Now()
+		change.Created = &now
+	}
+	if err := db.db.Create(&change).Error; err != nil {
+		return SuperAdminChange{}, err
+	}
+	return change, nil
+}
+
+// GetPendingSuperAdminChanges returns the changes that can still be confirmed
+func (db database) GetPendingSuperAdminChanges() []SuperAdminChange {
+	changes := []SuperAdminChange{}
+	db.db.Model(&SuperAdminChange{}).Where("confirmed IS NULL AND expires > ?", time.Now()).Order("created ASC").Find(&changes)
+	return changes
+}
+
+// ConfirmSuperAdminChange applies the pending change with the confirmation code,
+// a change can only be confirmed once, not after it has expired and not by
+// the super admin who requested it
+func (db database) ConfirmSuperAdminChange(code string, confirmedBy string) (SuperAdminChange, error) {
+	change := SuperAdminChange{}
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("confirmation_code = ? AND confirmed IS NULL", code).
+			Limit(1).Find(&change)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrSuperAdminChangeNotFound
+		}
+
+		now := time.Now()
+		if change.Expires != nil && now.After(*change.Expires) {
+			return ErrSuperAdminChangeExpired
+		}
+		if change.RequestedBy == confirmedBy {
+			return ErrSuperAdminChangeSelfConfirm
+		}
+
+		switch change.Action {
+		case SuperAdminAdd:
+			admin := SuperAdmin{Pubkey: change.Pubkey, AddedBy: change.RequestedBy, Created: &now}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&admin).Error; err != nil {
+				return err
+			}
+		case SuperAdminRemove:
+			if err := tx.Where("pubkey = ?", change.Pubkey).Delete(&SuperAdmin{}).Error; err != nil {
+				return err
+			}
+		}
+
+		change.ConfirmedBy = confirmedBy
+		change.Confirmed = &now
+		return tx.Model(&SuperAdminChange{}).Where("id = ?", change.ID).Updates(map[string]interface{}{
+			"confirmed_by": confirmedBy,
+			"confirmed":    &now,
+		}).Error
+	})
+	if err != nil {
+		return SuperAdminChange{}, err
+	}
+	return change, nil
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -121,3 +122,44 @@ func (db database) ProcessAlerts(p Person) {
 
 	return
 }
+
+// SendAlertDm sends a direct message to a user through the alert bot
+func SendAlertDm(pubkey string, content string) error {
+	relayUrl := os.Getenv("ALERT_URL")
+	alertSecret := os.Getenv("ALERT_SECRET")
+	alertTribeUuid := os.Getenv("ALERT_TRIBE_UUID")
+	botId := os.Getenv("ALERT_BOT_ID")
+	if relayUrl == "" || alertSecret == "" || alertTribeUuid == "" || botId == "" {
+		return errors.New("alert ENV information not found")
+	}
+
+	action := Action{
+		Action:   "dm",
+		ChatUuid: alertTribeUuid,
+		Pubkey:   pubkey,
+		Content:  content,
+		BotId:    botId,
+	}
+	buf, err := json.Marshal(action)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("POST", relayUrl, bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+
+	mac := hmac.New(sha256.New, []byte(alertSecret))
+	mac.Write(buf)
+	request.Header.Set("x-hub-signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	request.Header.Set("Content-Type", "application/json")
+
+	client := http.Client{}
+	res, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
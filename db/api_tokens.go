@@ -0,0 +1,104 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+)
+
+var (
+	ErrApiTokenNotFound = errors.New("api token not found")
+	ErrApiTokenInvalid  = errors.New("api token is invalid, expired or revoked")
+)
+
+// ApiTokenPrefix starts every workspace api token, so a leaked one is easy to recognise
+const ApiTokenPrefix = "sbt_"
+
+// Scopes a workspace api token can be given
+const (
+	ApiScopeCreateBounties    = "bounties:create"
+	ApiScopeUpdateBounties    = "bounties:update"
+	ApiScopeUploadTranscripts = "transcripts:upload"
+	ApiScopeCreateComments    = "comments:create"
+	ApiScopeChatMessages      = "chat:messages"
+)
+
+var ApiTokenScopes = []string{ApiScopeCreateBounties, ApiScopeUpdateBounties, ApiScopeUploadTranscripts, ApiScopeCreateComments, ApiScopeChatMessages}
+
+func ValidApiTokenScope(scope string) bool {
+	for _, s := range ApiTokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HashApiToken is how an api token is stored and looked up
+func HashApiToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (db database) CreateWorkspaceApiToken(token WorkspaceApiToken) (WorkspaceApiToken, error) {
+	if token.Created == nil {
+		now := time.Now()
+		token.Created = &now
+	}
+	if err := db.db.Create(&token).Error; err != nil {
+		return WorkspaceApiToken{}, err
+	}
+	return token, nil
+}
+
+func (db database) GetWorkspaceApiTokens(workspaceUuid string) []WorkspaceApiToken {
+	ms := []WorkspaceApiToken{}
+	db.db.Model(&WorkspaceApiToken{}).Where("workspace_uuid = ?", workspaceUuid).Order("created DESC").Find(&ms)
+	return ms
+}
+
+// RevokeWorkspaceApiToken stops a token of the workspace from working, revoking
+// a token twice keeps the first revoke time
+func (db database) RevokeWorkspaceApiToken(workspaceUuid string, id uint) error {
+	token := WorkspaceApiToken{}
+	result := db.db.Where("workspace_uuid = ? AND id = ?", workspaceUuid, id).Limit(1).Find(&token)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrApiTokenNotFound
+	}
+	if token.Revoked != nil {
+		return nil
+	}
+
+	now := time.Now()
+	return db.db.Model(&WorkspaceApiToken{}).Where("id = ?", id).Update("revoked", &now).Error
+}
+
+// VerifyWorkspaceApiToken looks up an active api token and records that it was used,
+// it is the verifier of the auth api token middleware
+func (db database) VerifyWorkspaceApiToken(token string) (auth.ApiToken, error) {
+	now := time.Now()
+	ms := WorkspaceApiToken{}
+	result := db.db.Where("token_hash = ? AND revoked IS NULL AND (expires IS NULL OR expires > ?)", HashApiToken(token), now).
+		Limit(1).Find(&ms)
+	if result.Error != nil {
+		return auth.ApiToken{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return auth.ApiToken{}, ErrApiTokenInvalid
+	}
+
+	db.db.Model(&WorkspaceApiToken{}).Where("id = ?", ms.ID).Update("last_used", &now)
+
+	return auth.ApiToken{
+		ID:            ms.ID,
+		WorkspaceUuid: ms.WorkspaceUuid,
+		CreatedBy:     ms.CreatedBy,
+		Scopes:        ms.Scopes,
+	}, nil
+}
@@ -0,0 +1,213 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+)
+
+var ErrArtifactNotFound = errors.New("artifact not found")
+
+func IsArtifactType(artifactType ArtifactType) bool {
+	for _, t := range ArtifactTypes {
+		if t == artifactType {
+			return true
+		}
+	}
+	return false
+}
+
+// MigrateChatArtifacts moves the artifacts chat messages kept in their json
+// artifacts column into the artifacts table, and drops the column
+func (db database) MigrateChatArtifacts() {
+	if !db.db.Migrator().HasColumn("chat_messages", "artifacts") {
+		return
+	}
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		rows := []struct {
+			Uuid         string
+			ChatId       string
+			SenderPubkey string
+			Created      *time.Time
+			Type         string
+			Title        string
+			Language     string
+			Content      string
+		}{}
+		if err := tx.Raw(`SELECT m.uuid, m.chat_id, m.sender_pubkey, m.created,
+				a->>'type' AS type, a->>'title' AS title, a->>'language' AS language, a->>'content' AS content
+			FROM chat_messages m
+			CROSS JOIN LATERAL jsonb_array_elements(COALESCE(m.artifacts, '[]'::jsonb)) a
+			ORDER BY m.id`).Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			artifact := Artifact{
+				ChatId:    row.ChatId,
+				MessageId: row.Uuid,
+				Type:      ArtifactType(row.Type),
+				Title:     row.Title,
+				Language:  row.Language,
+				Content:   row.Content,
+				CreatedBy: row.SenderPubkey,
+			}
+			tx.Raw("SELECT workspace_uuid FROM chats WHERE uuid = ?", row.ChatId).Scan(&artifact.WorkspaceUuid)
+			if !IsArtifactType(artifact.Type) {
+				artifact.Type = ArtifactText
+			}
+			if _, err := createArtifact(tx, artifact); err != nil {
+				return err
+			}
+		}
+		return tx.Migrator().DropColumn("chat_messages", "artifacts")
+	})
+	if err != nil {
+		fmt.Println("[artifacts] could not migrate chat artifacts", err)
+	}
+}
+
+func createArtifact(tx *gorm.DB, artifact Artifact) (Artifact, error) {
+	now := time.Now()
+	artifact.ID = 0
+	artifact.Uuid = xid.New().String()
+	artifact.Version = 1
+	artifact.Created = &now
+	artifact.Updated = &now
+
+	if err := tx.Create(&artifact).Error; err != nil {
+		return Artifact{}, err
+	}
+	if err := tx.Create(artifactVersion(artifact)).Error; err != nil {
+		return Artifact{}, err
+	}
+	return artifact, nil
+}
+
+func artifactVersion(artifact Artifact) *ArtifactVersion {
+	return &ArtifactVersion{
+		ArtifactUuid: artifact.Uuid,
+		Version:      artifact.Version,
+		Title:        artifact.Title,
+		Language:     artifact.Language,
+		Content:      artifact.Content,
+		CreatedBy:    artifact.CreatedBy,
+		Created:      artifact.Updated,
+	}
+}
+
+func (db database) CreateArtifact(artifact Artifact) (Artifact, error) {
+	var created Artifact
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		var err error
+		created, err = createArtifact(tx, artifact)
+		return err
+	})
+	return created, err
+}
+
+func (db database) GetArtifactByUuid(uuid string) (Artifact, error) {
+	artifact := Artifact{}
+	result := db.db.Model(&Artifact{}).Where("uuid = ?", uuid).Limit(1).Find(&artifact)
+	if result.Error != nil {
+		return artifact, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return artifact, ErrArtifactNotFound
+	}
+	return artifact, nil
+}
+
+// GetArtifacts returns the artifacts of a workspace matching filter, newest first
+func (db database) GetArtifacts(filter ArtifactFilter) []Artifact {
+	artifacts := []Artifact{}
+	query := db.db.Model(&Artifact{}).Where("workspace_uuid = ?", filter.WorkspaceUuid)
+	if filter.ChatId != "" {
+		query = query.Where("chat_id = ?", filter.ChatId)
+	}
+	if filter.MessageId != "" {
+		query = query.Where("message_id = ?", filter.MessageId)
+	}
+	if filter.FeatureUuid != "" {
+		query = query.Where("feature_uuid = ?", filter.FeatureUuid)
+	}
+	if filter.TicketUuid != "" {
+		query = query.Where("ticket_uuid = ?", filter.TicketUuid)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	query.Order("updated DESC, id DESC").Find(&artifacts)
+	return artifacts
+}
+
+// UpdateArtifact saves artifact, a change of its title, language or content
+// makes a new version while links to features and tickets are updated in place
+func (db database) UpdateArtifact(artifact Artifact, updatedBy string) (Artifact, error) {
+	var updated Artifact
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		current := Artifact{}
+		result := tx.Model(&Artifact{}).Where("uuid = ?", artifact.Uuid).Limit(1).Find(&current)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrArtifactNotFound
+		}
+
+		now := time.Now()
+		changed := current.Title != artifact.Title || current.Language != artifact.Language || current.Content != artifact.Content
+		updated = current
+		updated.Title = artifact.Title
+		updated.Language = artifact.Language
+		updated.Content = artifact.Content
+		updated.FeatureUuid = artifact.FeatureUuid
+		updated.TicketUuid = artifact.TicketUuid
+		updated.Updated = &now
+		if changed {
+			updated.Version = current.Version + 1
+		}
+
+		if err := tx.Model(&Artifact{}).Where("uuid = ?", updated.Uuid).Updates(map[string]interface{}{
+			"title":        updated.Title,
+			"language":     updated.Language,
+			"content":      updated.Content,
+			"feature_uuid": updated.FeatureUuid,
+			"ticket_uuid":  updated.TicketUuid,
+			"version":      updated.Version,
+			"updated":      updated.Updated,
+		}).Error; err != nil {
+			return err
+		}
+		if !changed {
+			return nil
+		}
+		version := artifactVersion(updated)
+		version.CreatedBy = updatedBy
+		return tx.Create(version).Error
+	})
+	if err != nil {
+		return Artifact{}, err
+	}
+	return updated, nil
+}
+
+// GetArtifactVersions returns the versions of an artifact, oldest first
+func (db database) GetArtifactVersions(uuid string) []ArtifactVersion {
+	versions := []ArtifactVersion{}
+	db.db.Model(&ArtifactVersion{}).Where("artifact_uuid = ?", uuid).Order("version ASC").Find(&versions)
+	return versions
+}
+
+func (db database) DeleteArtifact(uuid string) error {
+	return db.inTransaction(func(tx *gorm.DB) error {
+		if err := tx.Where("artifact_uuid = ?", uuid).Delete(&ArtifactVersion{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("uuid = ?", uuid).Delete(&Artifact{}).Error
+	})
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+// autocompleteIndexes are the prefix indexes the autocomplete queries use,
+// text_pattern_ops lets postgres answer LIKE 'prefix%' from the index
+var autocompleteIndexes = map[string]string{
+	"idx_people_owner_alias_prefix":         "people (LOWER(owner_alias) text_pattern_ops)",
+	"idx_people_unique_name_prefix":         "people (LOWER(unique_name) text_pattern_ops)",
+	"idx_tribes_name_prefix":                "tribes (LOWER(name) text_pattern_ops)",
+	"idx_workspace_features_name_prefix":    "workspace_features (LOWER(name) text_pattern_ops)",
+	"idx_workspace_features_workspace_uuid": "workspace_features (workspace_uuid)",
+}
+
+func (db database) MigrateAutocompleteIndexes() {
+	for name, on := range autocompleteIndexes {
+		if err := db.db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s", name, on)).Error; err != nil {
+			fmt.Println("[autocomplete] could not create index", name, err)
+		}
+	}
+}
+
+// AutocompletePeople suggests listed people whose alias or unique name starts with prefix,
+// the id is the pubkey so it can be used as a bounty assignee
+func (db database) AutocompletePeople(prefix string, limit int) ([]AutocompleteItem, error) {
+	items := []AutocompleteItem{}
+	q := escapeLike(strings.ToLower(prefix)) + "%"
+	err := db.db.Model(&Person{}).
+		Select("owner_pub_key AS id, owner_alias AS label").
+		Where("(deleted = false OR deleted IS NULL) AND (unlisted = false OR unlisted IS NULL)").
+		Where(`LOWER(owner_alias) LIKE ? ESCAPE '\' OR LOWER(unique_name) LIKE ? ESCAPE '\'`, q, q).
+		Order("LENGTH(owner_alias) ASC, owner_alias ASC").
+		Limit(limit).
+		Scan(&items).Error
+	return items, err
+}
+
+// AutocompleteTribes suggests public tribes whose name starts with prefix
+func (db database) AutocompleteTribes(prefix string, limit int) ([]AutocompleteItem, error) {
+	items := []AutocompleteItem{}
+	q := escapeLike(strings.ToLower(prefix)) + "%"
+	err := db.db.Model(&Tribe{}).
+		Select("uuid AS id, name AS label").
+		Where("(deleted = false OR deleted IS NULL) AND (unlisted = false OR unlisted IS NULL) AND (private = false OR private IS NULL)").
+		Where(`LOWER(name) LIKE ? ESCAPE '\'`, q).
+		Order("LENGTH(name) ASC, name ASC").
+		Limit(limit).
+		Scan(&items).Error
+	return items, err
+}
+
+// AutocompleteFeatures suggests features of public workspaces whose name starts with prefix,
+// an empty workspaceUuid suggests from every public workspace
+func (db database) AutocompleteFeatures(prefix string, workspaceUuid string, limit int) ([]AutocompleteItem, error) {
+	items := []AutocompleteItem{}
+	q := escapeLike(strings.ToLower(prefix)) + "%"
+	query := db.db.Table("workspace_features f").
+		Select("f.uuid AS id, f.name AS label").
+		Joins("INNER JOIN workspaces w ON w.uuid = f.workspace_uuid").
+		Where("w.show = true AND w.deleted = false").
+		Where(`LOWER(f.name) LIKE ? ESCAPE '\'`, q)
+	if workspaceUuid != "" {
+		query = query.Where("f.workspace_uuid = ?", workspaceUuid)
+	}
+	err := query.Order("LENGTH(f.name) ASC, f.name ASC").Limit(limit).Scan(&items).Error
+	return items, err
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+const (
+	AvailabilityAvailable = "available"
+	AvailabilityBusy      = "busy"
+	AvailabilityVacation  = "vacation"
+)
+
+var AvailabilityStatuses = []string{AvailabilityAvailable, AvailabilityBusy, AvailabilityVacation}
+
+// MaxWeeklyHours is the number of hours in a week
+const MaxWeeklyHours = 168
+
+func ValidAvailabilityStatus(status string) bool {
+	if status == "" {
+		return true
+	}
+	for _, s := range AvailabilityStatuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// EffectiveAvailability is the status others see for a person. Vacation always wins,
+// busy lasts until busy_until has passed and a hunter with more active bounties
+// than config.BusyBountyThreshold is busy whatever they set
+func EffectiveAvailability(p Person, activeBounties int64, now time.Time) string {
+	switch p.AvailabilityStatus {
+	case AvailabilityVacation:
+		return AvailabilityVacation
+	case AvailabilityBusy:
+		if p.BusyUntil == nil || p.BusyUntil.After(now) {
+			return AvailabilityBusy
+		}
+	}
+	if activeBounties > int64(config.BusyBountyThreshold) {
+		return AvailabilityBusy
+	}
+	return AvailabilityAvailable
+}
+
+// effectiveAvailabilitySql is EffectiveAvailability for a row of the people table
+const effectiveAvailabilitySql = `CASE
+	WHEN people.availability_status = 'vacation' THEN 'vacation'
+	WHEN people.availability_status = 'busy' AND (people.busy_until IS NULL OR people.busy_until > NOW()) THEN 'busy'
+	WHEN (SELECT COUNT(*) FROM bounty WHERE bounty.assignee = people.owner_pub_key AND bounty.paid = false AND bounty.completed = false) > %d THEN 'busy'
+	ELSE 'available'
+END`
+
+// availabilityFilter returns the people search condition for the availability
+// and min_weekly_hours params, or an empty string when neither is set.
+// The status is checked against AvailabilityStatuses so it is safe to inline
+func availabilityFilter(status string, minWeeklyHours int) string {
+	conditions := []string{}
+
+	if status != "" && ValidAvailabilityStatus(status) {
+		conditions = append(conditions, fmt.Sprintf("("+effectiveAvailabilitySql+") = '%s'", config.BusyBountyThreshold, status))
+	}
+	if minWeeklyHours > 0 {
+		conditions = append(conditions, fmt.Sprintf("people.weekly_hours >= %d", minWeeklyHours))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// availabilityFilterFromRequest reads the availability filters of a people search
+func availabilityFilterFromRequest(r *http.Request) string {
+	params := utils.NewQueryParams(r)
+	status := params.OneOf("availability", "", AvailabilityStatuses...)
+	minWeeklyHours := params.Int("min_weekly_hours", 0)
+	return availabilityFilter(status, minWeeklyHours)
+}
+
+// GetActiveAssignedBountiesCount returns the number of bounties assigned
+// to a hunter that are neither completed nor paid
+func (db database) GetActiveAssignedBountiesCount(pubkey string) int64 {
+	var count int64
+	db.db.Model(&NewBounty{}).Where("assignee = ? AND paid = ? AND completed = ?", pubkey, false, false).Count(&count)
+	return count
+}
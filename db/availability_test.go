@@ -0,0 +1,57 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectiveAvailability(t *testing.T) {
+	now := time.Now()
+	tomorrow := now.Add(24 * time.Hour)
+	yesterday := now.Add(-24 * time.Hour)
+	threshold := int64(config.BusyBountyThreshold)
+
+	t.Run("should be available by default", func(t *testing.T) {
+		assert.Equal(t, AvailabilityAvailable, EffectiveAvailability(Person{}, 0, now))
+	})
+
+	t.Run("should stay busy until busy_until has passed", func(t *testing.T) {
+		assert.Equal(t, AvailabilityBusy, EffectiveAvailability(Person{AvailabilityStatus: AvailabilityBusy}, 0, now))
+		assert.Equal(t, AvailabilityBusy, EffectiveAvailability(Person{AvailabilityStatus: AvailabilityBusy, BusyUntil: &tomorrow}, 0, now))
+		assert.Equal(t, AvailabilityAvailable, EffectiveAvailability(Person{AvailabilityStatus: AvailabilityBusy, BusyUntil: &yesterday}, 0, now))
+	})
+
+	t.Run("should be busy when active bounties exceed the threshold", func(t *testing.T) {
+		assert.Equal(t, AvailabilityAvailable, EffectiveAvailability(Person{}, threshold, now))
+		assert.Equal(t, AvailabilityBusy, EffectiveAvailability(Person{}, threshold+1, now))
+		assert.Equal(t, AvailabilityBusy, EffectiveAvailability(Person{AvailabilityStatus: AvailabilityBusy, BusyUntil: &yesterday}, threshold+1, now))
+	})
+
+	t.Run("should keep vacation whatever the bounty count", func(t *testing.T) {
+		assert.Equal(t, AvailabilityVacation, EffectiveAvailability(Person{AvailabilityStatus: AvailabilityVacation}, threshold+1, now))
+	})
+}
+
+func TestAvailabilityFilter(t *testing.T) {
+	t.Run("should be empty without filters", func(t *testing.T) {
+		assert.Equal(t, "", availabilityFilter("", 0))
+	})
+
+	t.Run("should ignore unknown statuses", func(t *testing.T) {
+		assert.Equal(t, "", availabilityFilter("available' OR 1=1 --", 0))
+	})
+
+	t.Run("should filter on status and weekly hours", func(t *testing.T) {
+		filter := availabilityFilter(AvailabilityBusy, 20)
+		assert.True(t, strings.HasSuffix(filter, "= 'busy' AND people.weekly_hours >= 20"))
+		assert.Contains(t, filter, "people.availability_status = 'vacation'")
+	})
+
+	t.Run("should filter on weekly hours only", func(t *testing.T) {
+		assert.Equal(t, "people.weekly_hours >= 10", availabilityFilter("", 10))
+	})
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+func (db database) CreateBadgeIssuance(issuance BadgeIssuance) (BadgeIssuance, error) {
+	now := time.Now()
+	issuance.Created = &now
+	issuance.Updated = &now
+	if issuance.Status == "" {
+		issuance.Status = BadgeIssuancePending
+	}
+
+	err := db.db.Create(&issuance).Error
+	return issuance, err
+}
+
+func (db database) UpdateBadgeIssuanceStatus(id uint, status BadgeIssuanceStatus, txId string, issueError string) error {
+	result := db.db.Model(&BadgeIssuance{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":  status,
+		"tx_id":   txId,
+		"error":   issueError,
+		"updated": time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("no badge issuance found")
+	}
+	return nil
+}
+
+func (db database) GetBadgeIssuances(tribeUuid string) []BadgeIssuance {
+	issuances := []BadgeIssuance{}
+	db.db.Model(&BadgeIssuance{}).Where("tribe_uuid = ?", tribeUuid).Order("created DESC").Find(&issuances)
+	return issuances
+}
+
+// GetTribeBadgeCounts returns, per badge, how many people hold it
+// and how many issuances are pending or failed
+func (db database) GetTribeBadgeCounts(tribeUuid string) []TribeBadge {
+	counts := []TribeBadge{}
+	db.db.Raw(`SELECT badge,
+		COUNT(DISTINCT recipient_pubkey) FILTER (WHERE status = ?) AS holders,
+		COUNT(*) FILTER (WHERE status = ?) AS pending,
+		COUNT(*) FILTER (WHERE status = ?) AS failed
+		FROM badge_issuances WHERE tribe_uuid = ? GROUP BY badge ORDER BY badge`,
+		BadgeIssuanceIssued, BadgeIssuancePending, BadgeIssuanceFailed, tribeUuid).Scan(&counts)
+	return counts
+}
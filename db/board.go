@@ -0,0 +1,53 @@
+package db
+
+import (
+	"time"
+)
+
+// BoardPaidDays is how long a paid bounty stays on the board of a person
+const BoardPaidDays = 30
+
+// BoardStates are the columns of a person board, in order
+var BoardStates = []BountyState{
+	BountyStateOpen,
+	BountyStateAssigned,
+	BountyStateInReview,
+	BountyStateCompleted,
+	BountyStatePaid,
+}
+
+var dueDateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+// EffectiveDueDate is the due date of a bounty, or else its parsed estimated
+// completion date, nil when it has neither
+func (b NewBounty) EffectiveDueDate() *time.Time {
+	if b.DueDate != nil {
+		return b.DueDate
+	}
+	if b.EstimatedCompletionDate == "" {
+		return nil
+	}
+	for _, layout := range dueDateLayouts {
+		if due, err := time.Parse(layout, b.EstimatedCompletionDate); err == nil {
+			return &due
+		}
+	}
+	return nil
+}
+
+// GetPersonBoardBounties returns the bounties a person is assigned to or created in
+// workspaces that are not deleted, leaving out canceled bounties and those paid
+// more than BoardPaidDays ago
+func (db database) GetPersonBoardBounties(pubkey string) []NewBounty {
+	ms := []NewBounty{}
+	paidSince := time.Now().AddDate(0, 0, -BoardPaidDays)
+
+	db.db.Model(&NewBounty{}).
+		Joins("JOIN workspaces ON workspaces.uuid = bounty.workspace_uuid").
+		Where("bounty.assignee = ? OR bounty.owner_id = ?", pubkey, pubkey).
+		Where("workspaces.deleted = ? AND bounty.state <> ?", false, BountyStateCanceled).
+		Where("bounty.paid = ? OR bounty.paid_date > ?", false, paidSince).
+		Order("bounty.created DESC").
+		Find(&ms)
+	return ms
+}
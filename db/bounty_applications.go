@@ -0,0 +1,119 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+func (db database) CreateBountyApplication(application BountyApplication) (BountyApplication, error) {
+	now := time.Now()
+	application.Created = &now
+	application.Updated = &now
+	application.Status = BountyApplicationPending
+
+	if err := db.db.Create(&application).Error; err != nil {
+		return application, err
+	}
+	return application, nil
+}
+
+func (db database) GetBountyApplications(bountyId uint) []BountyApplication {
+	applications := []BountyApplication{}
+	db.db.Model(&BountyApplication{}).Where("bounty_id = ?", bountyId).Order("created ASC").Find(&applications)
+	return applications
+}
+
+func (db database) GetBountyApplicationById(id uint) (BountyApplication, error) {
+	application := BountyApplication{}
+	result := db.db.Model(&BountyApplication{}).Where("id = ?", id).First(&application)
+	if result.RowsAffected == 0 {
+		return application, errors.New("no bounty application found")
+	}
+	return application, nil
+}
+
+// GetActiveBountyApplication returns the pending or accepted application of a hunter
+func (db database) GetActiveBountyApplication(bountyId uint, pubkey string) (BountyApplication, error) {
+	application := BountyApplication{}
+	result := db.db.Model(&BountyApplication{}).
+		Where("bounty_id = ? AND applicant_pubkey = ? AND status IN ?", bountyId, pubkey, []BountyApplicationStatus{BountyApplicationPending, BountyApplicationAccepted}).
+		First(&application)
+	if result.RowsAffected == 0 {
+		return application, errors.New("no bounty application found")
+	}
+	return application, nil
+}
+
+func (db database) UpdateBountyApplicationStatus(id uint, status BountyApplicationStatus) (BountyApplication, error) {
+	application := BountyApplication{}
+	now := time.Now()
+
+	result := db.db.Model(&BountyApplication{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":  status,
+		"updated": &now,
+	})
+	if result.RowsAffected == 0 {
+		return application, errors.New("no bounty application found")
+	}
+
+	db.db.Model(&BountyApplication{}).Where("id = ?", id).First(&application)
+	return application, nil
+}
+
+// AcceptBountyApplication assigns the bounty to the applicant and
+// rejects the other pending applications
+func (db database) AcceptBountyApplication(application BountyApplication) (NewBounty, error) {
+	bounty := NewBounty{}
+	tx := db.db.Begin()
+
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	now := time.Now()
+
+	result := tx.Model(&NewBounty{}).Where("id = ? AND (assignee = '' OR assignee IS NULL)", application.BountyID).Updates(map[string]interface{}{
+		"assignee":           application.ApplicantPubkey,
+		"assigned_date":      &now,
+		"last_activity_date": nil,
+		"stale_warning_date": nil,
+		"state":              BountyStateAssigned,
+		"updated":            &now,
+	})
+	if err = result.Error; err != nil {
+		tx.Rollback()
+		return bounty, err
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return bounty, errors.New("bounty is already assigned")
+	}
+
+	if err = tx.Model(&BountyApplication{}).Where("id = ?", application.ID).Updates(map[string]interface{}{
+		"status":  BountyApplicationAccepted,
+		"updated": &now,
+	}).Error; err != nil {
+		tx.Rollback()
+		return bounty, err
+	}
+
+	if err = tx.Model(&BountyApplication{}).
+		Where("bounty_id = ? AND id <> ? AND status = ?", application.BountyID, application.ID, BountyApplicationPending).
+		Updates(map[string]interface{}{
+			"status":  BountyApplicationRejected,
+			"updated": &now,
+		}).Error; err != nil {
+		tx.Rollback()
+		return bounty, err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		return bounty, err
+	}
+
+	db.db.Model(&NewBounty{}).Where("id = ?", application.BountyID).First(&bounty)
+	return bounty, nil
+}
@@ -0,0 +1,223 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (db database) CreateBountyAuction(auction BountyAuction) (BountyAuction, error) {
+	now := time.Now()
+	auction.Created = &now
+	auction.Updated = &now
+	auction.Status = BountyAuctionOpen
+
+	if err := db.db.Create(&auction).Error; err != nil {
+		return auction, err
+	}
+	return auction, nil
+}
+
+// GetBountyAuction returns the latest auction of a bounty
+func (db database) GetBountyAuction(bountyId uint) (BountyAuction, error) {
+	auction := BountyAuction{}
+	result := db.db.Model(&BountyAuction{}).Where("bounty_id = ?", bountyId).Order("created DESC").Limit(1).Find(&auction)
+	if result.RowsAffected == 0 {
+		return auction, errors.New("no bounty auction found")
+	}
+	return auction, nil
+}
+
+// GetDueBountyAuctions returns the open auctions whose window ended before now
+func (db database) GetDueBountyAuctions(now time.Time) []BountyAuction {
+	auctions := []BountyAuction{}
+	db.db.Model(&BountyAuction{}).Where("status = ? AND ends_at <= ?", BountyAuctionOpen, now).Find(&auctions)
+	return auctions
+}
+
+func (db database) UpdateBountyAuctionStatus(id uint, status BountyAuctionStatus) error {
+	now := time.Now()
+	result := db.db.Model(&BountyAuction{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":  status,
+		"updated": &now,
+	})
+	if result.RowsAffected == 0 {
+		return errors.New("no bounty auction found")
+	}
+	return result.Error
+}
+
+// SelectBountyBid records the bid the owner picked, it wins when the auction closes
+func (db database) SelectBountyBid(auctionId uint, bidId uint) error {
+	now := time.Now()
+	result := db.db.Model(&BountyAuction{}).Where("id = ? AND status = ?", auctionId, BountyAuctionOpen).Updates(map[string]interface{}{
+		"selected_bid_id": bidId,
+		"updated":         &now,
+	})
+	if result.RowsAffected == 0 {
+		return errors.New("auction is not open")
+	}
+	return result.Error
+}
+
+// PlaceBountyBid saves a hunter's bid, replacing their active bid on the auction
+func (db database) PlaceBountyBid(bid BountyBid) (BountyBid, error) {
+	now := time.Now()
+	bid.Status = BountyBidActive
+	bid.Updated = &now
+
+	existing := BountyBid{}
+	db.db.Model(&BountyBid{}).Where("auction_id = ? AND bidder_pubkey = ? AND status = ?", bid.AuctionID, bid.BidderPubkey, BountyBidActive).Limit(1).Find(&existing)
+	if existing.ID != 0 {
+		bid.ID = existing.ID
+		bid.Created = existing.Created
+		err := db.db.Model(&BountyBid{}).Where("id = ?", bid.ID).Updates(map[string]interface{}{
+			"amount":  bid.Amount,
+			"eta":     bid.Eta,
+			"updated": &now,
+		}).Error
+		return bid, err
+	}
+
+	bid.Created = &now
+	if err := db.db.Create(&bid).Error; err != nil {
+		return bid, err
+	}
+	return bid, nil
+}
+
+func (db database) GetBountyBids(auctionId uint) []BountyBid {
+	bids := []BountyBid{}
+	db.db.Model(&BountyBid{}).Where("auction_id = ?", auctionId).Order("amount ASC, created ASC").Find(&bids)
+	return bids
+}
+
+func (db database) GetBountyBidById(id uint) (BountyBid, error) {
+	bid := BountyBid{}
+	result := db.db.Model(&BountyBid{}).Where("id = ?", id).Limit(1).Find(&bid)
+	if result.RowsAffected == 0 {
+		return bid, errors.New("no bounty bid found")
+	}
+	return bid, nil
+}
+
+func (db database) WithdrawBountyBid(auctionId uint, pubkey string) error {
+	now := time.Now()
+	result := db.db.Model(&BountyBid{}).Where("auction_id = ? AND bidder_pubkey = ? AND status = ?", auctionId, pubkey, BountyBidActive).Updates(map[string]interface{}{
+		"status":  BountyBidWithdrawn,
+		"updated": &now,
+	})
+	if result.RowsAffected == 0 {
+		return errors.New("no active bid found")
+	}
+	return result.Error
+}
+
+// LowestBountyBid is the active bid with the lowest amount, the earliest
+// delivery and then the earliest bid break ties
+func LowestBountyBid(bids []BountyBid) (BountyBid, bool) {
+	lowest := BountyBid{}
+	found := false
+	for _, bid := range bids {
+		if bid.Status != BountyBidActive {
+			continue
+		}
+		if !found || bid.Amount < lowest.Amount || (bid.Amount == lowest.Amount && earlier(bid.Eta, lowest.Eta)) ||
+			(bid.Amount == lowest.Amount && sameTime(bid.Eta, lowest.Eta) && earlier(bid.Created, lowest.Created)) {
+			lowest = bid
+			found = true
+		}
+	}
+	return lowest, found
+}
+
+// earlier tells if a is before b, a missing time is never earlier
+func earlier(a *time.Time, b *time.Time) bool {
+	if a == nil {
+		return false
+	}
+	return b == nil || a.Before(*b)
+}
+
+func sameTime(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// CloseBountyAuction closes the auction in one transaction, assigning the bounty
+// to the bidder of bidId at the bid's amount and settling the other bids.
+// A zero bidId closes the auction without a winner
+func (db database) CloseBountyAuction(auction BountyAuction, bidId uint) (NewBounty, error) {
+	bounty := NewBounty{}
+
+	err := db.db.Transaction(func(tx *gorm.DB) error {
+		locked := BountyAuction{}
+		found := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", auction.ID).Limit(1).Find(&locked)
+		if found.Error != nil {
+			return found.Error
+		}
+		if found.RowsAffected == 0 {
+			return errors.New("no bounty auction found")
+		}
+		if locked.Status != BountyAuctionOpen && locked.Status != BountyAuctionAwaitingSelection {
+			return errors.New("auction is already closed")
+		}
+
+		now := time.Now()
+		auctionUpdates := map[string]interface{}{
+			"status":  BountyAuctionClosed,
+			"updated": &now,
+		}
+
+		if bidId != 0 {
+			bid := BountyBid{}
+			tx.Where("id = ? AND auction_id = ? AND status = ?", bidId, auction.ID, BountyBidActive).Limit(1).Find(&bid)
+			if bid.ID == 0 {
+				return errors.New("bid is not active on this auction")
+			}
+
+			result := tx.Model(&NewBounty{}).Where("id = ? AND (assignee = '' OR assignee IS NULL)", auction.BountyID).Updates(map[string]interface{}{
+				"assignee":           bid.BidderPubkey,
+				"price":              bid.Amount,
+				"assigned_date":      &now,
+				"last_activity_date": nil,
+				"stale_warning_date": nil,
+				"state":              BountyStateAssigned,
+				"updated":            &now,
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errors.New("bounty is already assigned")
+			}
+
+			if err := tx.Model(&BountyBid{}).Where("id = ?", bid.ID).Updates(map[string]interface{}{
+				"status":  BountyBidWon,
+				"updated": &now,
+			}).Error; err != nil {
+				return err
+			}
+			auctionUpdates["selected_bid_id"] = bid.ID
+		}
+
+		if err := tx.Model(&BountyBid{}).Where("auction_id = ? AND id <> ? AND status = ?", auction.ID, bidId, BountyBidActive).Updates(map[string]interface{}{
+			"status":  BountyBidLost,
+			"updated": &now,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&BountyAuction{}).Where("id = ?", auction.ID).Updates(auctionUpdates).Error
+	})
+	if err != nil {
+		return bounty, err
+	}
+
+	db.db.Model(&NewBounty{}).Where("id = ?", auction.BountyID).First(&bounty)
+	return bounty, nil
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLowestBountyBid(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	t.Run("should pick the lowest active bid", func(t *testing.T) {
+		bid, ok := LowestBountyBid([]BountyBid{
+			{ID: 1, Amount: 2000, Status: BountyBidActive},
+			{ID: 2, Amount: 500, Status: BountyBidWithdrawn},
+			{ID: 3, Amount: 1000, Status: BountyBidActive},
+		})
+		assert.True(t, ok)
+		assert.Equal(t, uint(3), bid.ID)
+	})
+
+	t.Run("should break ties on the earliest eta", func(t *testing.T) {
+		bid, _ := LowestBountyBid([]BountyBid{
+			{ID: 1, Amount: 1000, Eta: &later, Status: BountyBidActive},
+			{ID: 2, Amount: 1000, Eta: &now, Status: BountyBidActive},
+		})
+		assert.Equal(t, uint(2), bid.ID)
+	})
+
+	t.Run("should find nothing without active bids", func(t *testing.T) {
+		_, ok := LowestBountyBid([]BountyBid{{ID: 1, Amount: 1000, Status: BountyBidLost}})
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrBountyCommentNotFound = errors.New("bounty comment not found")
+
+func (db database) CreateBountyComment(comment BountyComment) (BountyComment, error) {
+	now := time.Now()
+	comment.ID = 0
+	comment.Created = &now
+	comment.Updated = &now
+
+	if err := db.db.Create(&comment).Error; err != nil {
+		return BountyComment{}, err
+	}
+	return comment, nil
+}
+
+// GetBountyComments returns the comments of a bounty, oldest first
+func (db database) GetBountyComments(bountyId uint) []BountyComment {
+	ms := []BountyComment{}
+	db.db.Model(&BountyComment{}).Where("bounty_id = ?", bountyId).Order("created ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetBountyComment(bountyId uint, id uint) (BountyComment, error) {
+	comment := BountyComment{}
+	result := db.db.Model(&BountyComment{}).Where("bounty_id = ? AND id = ?", bountyId, id).Limit(1).Find(&comment)
+	if result.Error != nil {
+		return comment, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return comment, ErrBountyCommentNotFound
+	}
+	return comment, nil
+}
+
+func (db database) DeleteBountyComment(id uint) error {
+	return db.db.Where("id = ?", id).Delete(&BountyComment{}).Error
+}
@@ -0,0 +1,166 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrBountyNotFound = errors.New("bounty not found")
+
+// BountyTransitions are the states a bounty can move to from each state.
+// A bounty is only paid by its payment, so no transition leads to paid here
+var BountyTransitions = map[BountyState][]BountyState{
+	BountyStateOpen:      {BountyStateAssigned, BountyStateCanceled},
+	BountyStateAssigned:  {BountyStateOpen, BountyStateInReview, BountyStateCompleted, BountyStateCanceled},
+	BountyStateInReview:  {BountyStateAssigned, BountyStateCompleted},
+	BountyStateCompleted: {BountyStateInReview},
+	BountyStateCanceled:  {BountyStateOpen},
+	BountyStatePaid:      {},
+}
+
+func CanTransitionBounty(from BountyState, to BountyState) bool {
+	for _, state := range BountyTransitions[from] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
+
+type InvalidBountyTransitionError struct {
+	From BountyState
+	To   BountyState
+}
+
+func (e InvalidBountyTransitionError) Error() string {
+	return fmt.Sprintf("a bounty can not move from %s to %s", e.From, e.To)
+}
+
+// bountyStateSql derives the state from the paid, completed and assignee columns
+// that older code paths still update, keeping in_review and canceled which they do not know
+const bountyStateSql = `CASE
+	WHEN paid = true THEN 'paid'
+	WHEN completed = true THEN 'completed'
+	WHEN state = 'canceled' THEN 'canceled'
+	WHEN assignee IS NULL OR assignee = '' THEN 'open'
+	WHEN state = 'in_review' THEN 'in_review'
+	ELSE 'assigned' END`
+
+// CurrentState is the state of the bounty, derived from its columns
+// for a bounty loaded before its state was stored
+func (b NewBounty) CurrentState() BountyState {
+	switch {
+	case b.Paid:
+		return BountyStatePaid
+	case b.Completed:
+		return BountyStateCompleted
+	case b.State == BountyStateCanceled:
+		return BountyStateCanceled
+	case b.Assignee == "":
+		return BountyStateOpen
+	case b.State == BountyStateInReview:
+		return BountyStateInReview
+	}
+	return BountyStateAssigned
+}
+
+// syncBountyState stores the state of the bounties matching the query after
+// an update of their paid, completed or assignee columns
+func (db database) syncBountyState(query interface{}, args ...interface{}) BountyState {
+	db.db.Model(&NewBounty{}).Where(query, args...).Update("state", gorm.Expr(bountyStateSql))
+
+	var state BountyState
+	db.db.Model(&NewBounty{}).Where(query, args...).Limit(1).Pluck("state", &state)
+	return state
+}
+
+// MigrateBountyStates stores the state of the bounties created before it was stored
+func (db database) MigrateBountyStates() {
+	db.db.Model(&NewBounty{}).Where("state IS NULL OR state = ''").Update("state", gorm.Expr(bountyStateSql))
+}
+
+// TransitionBountyState moves a bounty to another state and records the transition.
+// The paid, completed and assignee columns are set to match for the code that reads them
+func (db database) TransitionBountyState(bountyId uint, request BountyTransitionRequest, actor string) (NewBounty, BountyStateTransition, error) {
+	bounty := NewBounty{}
+	transition := BountyStateTransition{}
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		found := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", bountyId).Limit(1).Find(&bounty)
+		if found.Error != nil {
+			return found.Error
+		}
+		if found.RowsAffected == 0 {
+			return ErrBountyNotFound
+		}
+
+		from := bounty.CurrentState()
+		if !CanTransitionBounty(from, request.State) {
+			return InvalidBountyTransitionError{From: from, To: request.State}
+		}
+
+		now := time.Now()
+		updates := map[string]interface{}{
+			"state":   request.State,
+			"updated": &now,
+		}
+		switch request.State {
+		case BountyStateOpen:
+			updates["assignee"] = ""
+			updates["assigned_date"] = nil
+			updates["last_activity_date"] = nil
+			updates["stale_warning_date"] = nil
+			updates["completed"] = false
+			if from == BountyStateCanceled {
+				updates["show"] = true
+			}
+		case BountyStateAssigned:
+			if request.Assignee != "" && request.Assignee != bounty.Assignee {
+				updates["assignee"] = request.Assignee
+				updates["assigned_date"] = &now
+				updates["last_activity_date"] = nil
+				updates["stale_warning_date"] = nil
+			}
+			updates["completed"] = false
+		case BountyStateInReview:
+			updates["completed"] = false
+		case BountyStateCompleted:
+			updates["completed"] = true
+			updates["completion_date"] = &now
+		case BountyStateCanceled:
+			updates["show"] = false
+		}
+
+		if err := tx.Model(&NewBounty{}).Where("id = ?", bounty.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		transition = BountyStateTransition{
+			BountyID:  bounty.ID,
+			FromState: from,
+			ToState:   request.State,
+			Actor:     actor,
+			Reason:    request.Reason,
+			Created:   &now,
+		}
+		if err := tx.Create(&transition).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", bounty.ID).First(&bounty).Error
+	})
+	if err != nil {
+		return NewBounty{}, BountyStateTransition{}, err
+	}
+	return bounty, transition, nil
+}
+
+func (db database) GetBountyStateTransitions(bountyId uint) []BountyStateTransition {
+	ms := []BountyStateTransition{}
+	db.db.Model(&BountyStateTransition{}).Where("bounty_id = ?", bountyId).Order("created ASC").Find(&ms)
+	return ms
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransitionBounty(t *testing.T) {
+	assert.True(t, CanTransitionBounty(BountyStateOpen, BountyStateAssigned))
+	assert.True(t, CanTransitionBounty(BountyStateAssigned, BountyStateInReview))
+	assert.True(t, CanTransitionBounty(BountyStateInReview, BountyStateAssigned))
+	assert.True(t, CanTransitionBounty(BountyStateCanceled, BountyStateOpen))
+
+	assert.False(t, CanTransitionBounty(BountyStateOpen, BountyStateCompleted))
+	assert.False(t, CanTransitionBounty(BountyStateCompleted, BountyStatePaid))
+	assert.False(t, CanTransitionBounty(BountyStatePaid, BountyStateOpen))
+	assert.False(t, CanTransitionBounty(BountyStateInReview, BountyStateCanceled))
+	assert.False(t, CanTransitionBounty("unknown", BountyStateOpen))
+}
+
+func TestBountyCurrentState(t *testing.T) {
+	tests := []struct {
+		bounty NewBounty
+		state  BountyState
+	}{
+		{NewBounty{}, BountyStateOpen},
+		{NewBounty{Assignee: "hunter"}, BountyStateAssigned},
+		{NewBounty{Assignee: "hunter", State: BountyStateInReview}, BountyStateInReview},
+		{NewBounty{Assignee: "", State: BountyStateInReview}, BountyStateOpen},
+		{NewBounty{Assignee: "hunter", Completed: true, State: BountyStateInReview}, BountyStateCompleted},
+		{NewBounty{Assignee: "hunter", Completed: true, Paid: true}, BountyStatePaid},
+		{NewBounty{State: BountyStateCanceled}, BountyStateCanceled},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.state, tt.bounty.CurrentState(), "%+v", tt.bounty)
+	}
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTagNotFound  = errors.New("tag not found")
+	ErrTagNameTaken = errors.New("a tag with this name already exists in the workspace")
+)
+
+// bountyTagIndexes let postgres answer the array filters of the bounty lists,
+// tags @> ARRAY[...] and coding_languages && ARRAY[...], from an index
+var bountyTagIndexes = map[string]string{
+	"idx_bounty_tags":             "bounty USING GIN (tags)",
+	"idx_bounty_coding_languages": "bounty USING GIN (coding_languages)",
+}
+
+func (db database) MigrateBountyTagIndexes() {
+	for name, on := range bountyTagIndexes {
+		if err := db.db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s", name, on)).Error; err != nil {
+			fmt.Println("[tags] could not create index", name, err)
+		}
+	}
+}
+
+// bountyTagsQuery keeps the bounties that have every tag of a comma separated
+// list of tag uuids, it is empty when no tag is given
+func bountyTagsQuery(tags string) string {
+	quoted := []string{}
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			quoted = append(quoted, pq.QuoteLiteral(tag))
+		}
+	}
+	if len(quoted) == 0 {
+		return ""
+	}
+	return "AND tags @> ARRAY[" + strings.Join(quoted, ", ") + "]::text[]"
+}
+
+func (db database) workspaceTagNameTaken(tag WorkspaceTag) bool {
+	var count int64
+	db.db.Model(&WorkspaceTag{}).
+		Where("workspace_uuid = ? AND LOWER(name) = LOWER(?) AND uuid != ?", tag.WorkspaceUuid, tag.Name, tag.Uuid).
+		Count(&count)
+	return count > 0
+}
+
+func (db database) CreateWorkspaceTag(tag WorkspaceTag) (WorkspaceTag, error) {
+	if db.workspaceTagNameTaken(tag) {
+		return WorkspaceTag{}, ErrTagNameTaken
+	}
+	if tag.Created == nil {
+		now := time.Now()
+		tag.Created = &now
+		tag.Updated = &now
+	}
+	if err := db.db.Create(&tag).Error; err != nil {
+		return WorkspaceTag{}, err
+	}
+	return tag, nil
+}
+
+func (db database) GetWorkspaceTags(workspaceUuid string) []WorkspaceTag {
+	ms := []WorkspaceTag{}
+	db.db.Model(&WorkspaceTag{}).Where("workspace_uuid = ?", workspaceUuid).Order("LOWER(name) ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetWorkspaceTagByUuid(workspaceUuid string, uuid string) (WorkspaceTag, error) {
+	ms := WorkspaceTag{}
+	result := db.db.Model(&WorkspaceTag{}).Where("workspace_uuid = ? AND uuid = ?", workspaceUuid, uuid).Limit(1).Find(&ms)
+	if result.Error != nil {
+		return ms, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ms, ErrTagNotFound
+	}
+	return ms, nil
+}
+
+// UpdateWorkspaceTag renames or recolors a tag, the bounties keep it since they hold its uuid
+func (db database) UpdateWorkspaceTag(tag WorkspaceTag) (WorkspaceTag, error) {
+	if db.workspaceTagNameTaken(tag) {
+		return WorkspaceTag{}, ErrTagNameTaken
+	}
+	now := time.Now()
+	tag.Updated = &now
+	result := db.db.Model(&WorkspaceTag{}).
+		Where("workspace_uuid = ? AND uuid = ?", tag.WorkspaceUuid, tag.Uuid).
+		Updates(map[string]interface{}{
+			"name":        tag.Name,
+			"color":       tag.Color,
+			"description": tag.Description,
+			"updated":     now,
+		})
+	if result.Error != nil {
+		return WorkspaceTag{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return WorkspaceTag{}, ErrTagNotFound
+	}
+	return db.GetWorkspaceTagByUuid(tag.WorkspaceUuid, tag.Uuid)
+}
+
+// DeleteWorkspaceTag deletes a tag and takes it off the bounties of the workspace
+func (db database) DeleteWorkspaceTag(workspaceUuid string, uuid string) error {
+	return db.inTransaction(func(tx *gorm.DB) error {
+		result := tx.Where("workspace_uuid = ? AND uuid = ?", workspaceUuid, uuid).Delete(&WorkspaceTag{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTagNotFound
+		}
+		return tx.Exec("UPDATE bounty SET tags = array_remove(tags, ?) WHERE workspace_uuid = ? AND ? = ANY(tags)", uuid, workspaceUuid, uuid).Error
+	})
+}
+
+// SetBountyTags replaces the tags of a bounty, every tag must belong to the workspace of the bounty
+func (db database) SetBountyTags(bounty NewBounty, tags []string) (NewBounty, error) {
+	unique := pq.StringArray{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			unique = append(unique, tag)
+		}
+	}
+
+	if len(unique) > 0 {
+		var count int64
+		db.db.Model(&WorkspaceTag{}).Where("workspace_uuid = ? AND uuid IN ?", bounty.WorkspaceUuid, []string(unique)).Count(&count)
+		if bounty.WorkspaceUuid == "" || count != int64(len(unique)) {
+			return bounty, ErrTagNotFound
+		}
+	}
+
+	if err := db.db.Model(&NewBounty{}).Where("id = ?", bounty.ID).Update("tags", unique).Error; err != nil {
+		return bounty, err
+	}
+	bounty.Tags = unique
+	return bounty, nil
+}
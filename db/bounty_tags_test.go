@@ -0,0 +1,45 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBountyTagsQuery(t *testing.T) {
+	t.Run("should be empty without tags", func(t *testing.T) {
+		assert.Equal(t, "", bountyTagsQuery(""))
+		assert.Equal(t, "", bountyTagsQuery(" , ,"))
+	})
+
+	t.Run("should require every tag", func(t *testing.T) {
+		assert.Equal(t, "AND tags @> ARRAY['a1', 'b2']::text[]", bountyTagsQuery("a1, b2,"))
+	})
+
+	t.Run("should quote malicious tags", func(t *testing.T) {
+		assert.Equal(t, "AND tags @> ARRAY['x'') OR (1=1']::text[]", bountyTagsQuery("x') OR (1=1"))
+	})
+}
+
+func TestBountyDataTagsJson(t *testing.T) {
+	data := BountyData{
+		NewBounty:  NewBounty{Tags: []string{"ignored"}},
+		BountyTags: []string{"frontend", "urgent"},
+		Person:     Person{Tags: []string{"ignored"}},
+		OwnerTags:  []string{"golang"},
+	}
+
+	body, err := json.Marshal(data)
+	assert.NoError(t, err)
+
+	fields := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(body, &fields))
+	assert.Equal(t, []interface{}{"frontend", "urgent"}, fields["tags"])
+	assert.Equal(t, []interface{}{"golang"}, fields["owner_tags"])
+
+	decoded := BountyData{}
+	assert.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, data.BountyTags, decoded.BountyTags)
+	assert.Equal(t, data.OwnerTags, decoded.OwnerTags)
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+)
+
+// publicBountiesQuery is the condition for the bounties anyone can see
+const publicBountiesQuery = "visibility = 'public'"
+
+// ValidBountyVisibility tells if a bounty can be saved with visibility, an
+// empty one is saved as public
+func ValidBountyVisibility(visibility BountyVisibility) bool {
+	switch visibility {
+	case "", BountyVisibilityPublic, BountyVisibilityWorkspace, BountyVisibilityInviteOnly:
+		return true
+	}
+	return false
+}
+
+// bountyViewer is the pubkey the request is signed with, empty for anonymous requests
+func bountyViewer(r *http.Request) string {
+	viewer, _ := r.Context().Value(auth.ContextKey).(string)
+	return viewer
+}
+
+// bountyVisibilityQuery is the condition for the bounties viewer can see,
+// public ones, workspace ones of the workspaces viewer belongs to and invite
+// only ones viewer was invited to. Owners and assignees always see theirs
+func bountyVisibilityQuery(viewer string) string {
+	if viewer == "" {
+		return publicBountiesQuery
+	}
+	v := "'" + strings.ReplaceAll(viewer, "'", "''") + "'"
+	return "(" + publicBountiesQuery +
+		" OR owner_id = " + v +
+		" OR assignee = " + v +
+		" OR (visibility = 'workspace' AND workspace_uuid IN (SELECT workspace_uuid FROM workspace_users WHERE owner_pub_key = " + v +
+		" UNION SELECT uuid FROM workspaces WHERE owner_pub_key = " + v + "))" +
+		" OR (visibility = 'invite_only' AND " + v + " = ANY(invitees)))"
+}
+
+// BountyVisibleTo is bountyVisibilityQuery for a single bounty, member tells if
+// viewer belongs to the bounty's workspace
+func BountyVisibleTo(bounty NewBounty, viewer string, member bool) bool {
+	switch bounty.Visibility {
+	case "", BountyVisibilityPublic:
+		return true
+	}
+	if viewer == "" {
+		return false
+	}
+	if viewer == bounty.OwnerID || viewer == bounty.Assignee {
+		return true
+	}
+	switch bounty.Visibility {
+	case BountyVisibilityWorkspace:
+		return member
+	case BountyVisibilityInviteOnly:
+		for _, invitee := range bounty.Invitees {
+			if invitee == viewer {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBountyVisibleTo(t *testing.T) {
+	bounty := NewBounty{OwnerID: "owner", Assignee: "hunter", WorkspaceUuid: "workspace"}
+
+	t.Run("should show public bounties to everyone", func(t *testing.T) {
+		assert.True(t, BountyVisibleTo(bounty, "", false))
+		bounty.Visibility = BountyVisibilityPublic
+		assert.True(t, BountyVisibleTo(bounty, "", false))
+	})
+
+	t.Run("should show workspace bounties to members only", func(t *testing.T) {
+		bounty.Visibility = BountyVisibilityWorkspace
+		assert.False(t, BountyVisibleTo(bounty, "", true))
+		assert.False(t, BountyVisibleTo(bounty, "someone", false))
+		assert.True(t, BountyVisibleTo(bounty, "someone", true))
+	})
+
+	t.Run("should show invite only bounties to invitees only", func(t *testing.T) {
+		bounty.Visibility = BountyVisibilityInviteOnly
+		bounty.Invitees = []string{"invitee"}
+		assert.True(t, BountyVisibleTo(bounty, "invitee", false))
+		assert.False(t, BountyVisibleTo(bounty, "someone", true))
+	})
+
+	t.Run("should always show bounties to their owner and assignee", func(t *testing.T) {
+		assert.True(t, BountyVisibleTo(bounty, "owner", false))
+		assert.True(t, BountyVisibleTo(bounty, "hunter", false))
+	})
+}
+
+func TestBountyVisibilityQuery(t *testing.T) {
+	assert.Equal(t, "visibility = 'public'", bountyVisibilityQuery(""))
+
+	query := bountyVisibilityQuery("pub'key")
+	assert.Contains(t, query, "owner_id = 'pub''key'")
+	assert.Contains(t, query, "'pub''key' = ANY(invitees)")
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrBridgeNotFound = errors.New("bridge not found")
+
+func (db database) CreateWorkspaceBridge(bridge WorkspaceBridge) (WorkspaceBridge, error) {
+	now := time.Now()
+	bridge.Created = &now
+	bridge.Updated = &now
+	if err := db.db.Create(&bridge).Error; err != nil {
+		return WorkspaceBridge{}, err
+	}
+	return bridge, nil
+}
+
+func (db database) GetWorkspaceBridges(workspaceUuid string) []WorkspaceBridge {
+	ms := []WorkspaceBridge{}
+	db.db.Model(&WorkspaceBridge{}).Where("workspace_uuid = ?", workspaceUuid).Order("created DESC").Find(&ms)
+	return ms
+}
+
+func (db database) DeleteWorkspaceBridge(workspaceUuid string, uuid string) error {
+	result := db.db.Where("workspace_uuid = ? AND uuid = ?", workspaceUuid, uuid).Delete(&WorkspaceBridge{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBridgeNotFound
+	}
+	return nil
+}
+
+// RecordBridgeDelivery keeps the outcome of the last post of a bridge, a failed
+// post keeps the time of the last one that went through
+func (db database) RecordBridgeDelivery(uuid string, now time.Time, deliveryErr error) error {
+	updates := map[string]interface{}{"last_error": ""}
+	if deliveryErr != nil {
+		updates["last_error"] = deliveryErr.Error()
+	} else {
+		updates["last_delivered"] = now
+	}
+	return db.db.Model(&WorkspaceBridge{}).Where("uuid = ?", uuid).Updates(updates).Error
+}
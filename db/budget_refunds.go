@@ -0,0 +1,127 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (db database) CreateBudgetRefund(refund BudgetRefund) (BudgetRefund, error) {
+	now := time.Now()
+	refund.Created = &now
+	refund.Updated = &now
+
+	if err := db.db.Create(&refund).Error; err != nil {
+		return refund, err
+	}
+	return refund, nil
+}
+
+func (db database) GetBudgetRefund(id uint) (BudgetRefund, error) {
+	refund := BudgetRefund{}
+	result := db.db.Model(&BudgetRefund{}).Where("id = ?", id).Limit(1).Find(&refund)
+	if result.RowsAffected == 0 {
+		return refund, errors.New("no budget refund found")
+	}
+	return refund, nil
+}
+
+func (db database) GetBudgetRefunds(workspaceUuid string) []BudgetRefund {
+	refunds := []BudgetRefund{}
+	db.db.Model(&BudgetRefund{}).Where("workspace_uuid = ?", workspaceUuid).Order("created DESC").Find(&refunds)
+	return refunds
+}
+
+var ErrBudgetRefundNotPending = errors.New("refund is not pending approval")
+
+// ClaimBudgetRefund moves a refund waiting for approval to paying, only one
+// approval can claim a refund so its invoice is paid once
+func (db database) ClaimBudgetRefund(id uint, approvedBy string) error {
+	now := time.Now()
+	result := db.db.Model(&BudgetRefund{}).
+		Where("id = ? AND status = ?", id, BudgetRefundPendingApproval).
+		Updates(map[string]interface{}{
+			"status":      BudgetRefundPaying,
+			"approved_by": approvedBy,
+			"updated":     &now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return ErrBudgetRefundNotPending
+	}
+	return nil
+}
+
+// UpdateBudgetRefundStatus moves a refund that is not settled yet to status
+func (db database) UpdateBudgetRefundStatus(id uint, status BudgetRefundStatus, approvedBy string, refundError string) error {
+	now := time.Now()
+	result := db.db.Model(&BudgetRefund{}).
+		Where("id = ? AND status NOT IN ?", id, []BudgetRefundStatus{BudgetRefundPaid, BudgetRefundRejected}).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"approved_by": approvedBy,
+			"error":       refundError,
+			"updated":     &now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("refund is already settled")
+	}
+	return nil
+}
+
+// CompleteBudgetRefund records a paid refund that was claimed, it spends the refund's reservation
+// into the withdrawals account and marks the refund paid in one transaction
+func (db database) CompleteBudgetRefund(refund BudgetRefund) error {
+	return db.inTransaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		budgetHistory := NewPaymentHistory{
+			WorkspaceUuid:  refund.WorkspaceUuid,
+			Amount:         refund.Amount,
+			Status:         true,
+			PaymentType:    Withdraw,
+			Created:        &now,
+			Updated:        &now,
+			SenderPubKey:   refund.RequestedBy,
+			ReceiverPubKey: "",
+			BountyId:       0,
+		}
+		if err := tx.Create(&budgetHistory).Error; err != nil {
+			return err
+		}
+
+		reservation := BudgetReservation{
+			Id:            refund.ReservationId,
+			WorkspaceUuid: refund.WorkspaceUuid,
+			Amount:        refund.Amount,
+		}
+		if err := spendBudget(tx, reservation, ledgerTransfer{
+			WorkspaceUuid: refund.WorkspaceUuid,
+			To:            LedgerWithdrawalsAccount,
+			Amount:        refund.Amount,
+			ReferenceType: LedgerRefund,
+			ReferenceId:   fmt.Sprint(refund.ID),
+		}); err != nil {
+			return err
+		}
+
+		result := tx.Model(&BudgetRefund{}).Where("id = ? AND status = ?", refund.ID, BudgetRefundPaying).Updates(map[string]interface{}{
+			"status":      BudgetRefundPaid,
+			"approved_by": refund.ApprovedBy,
+			"updated":     &now,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("refund is not being paid")
+		}
+		return nil
+	})
+}
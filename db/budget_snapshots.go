@@ -0,0 +1,38 @@
+package db
+
+import (
+	"time"
+)
+
+// SnapshotWorkspaceBudgets records the budget of every workspace for a day in
+// one statement, a day that was already recorded is overwritten so the last
+// snapshot of a day is its closing balance. The open, assigned and completed
+// budgets are the ones of GetWorkspaceStatusBudget
+func (db database) SnapshotWorkspaceBudgets(day string) error {
+	return db.db.Exec(`INSERT INTO workspace_budget_snapshots
+		(workspace_uuid, day, total_budget, open_budget, assigned_budget, completed_budget, updated)
+		SELECT budget.workspace_uuid, ?, MAX(budget.total_budget),
+		COALESCE(MAX(sums.open_budget), 0), COALESCE(MAX(sums.assigned_budget), 0), COALESCE(MAX(sums.completed_budget), 0), ?
+		FROM bounty_budgets AS budget
+		LEFT JOIN (SELECT workspace_uuid,
+			SUM(price) FILTER (WHERE assignee = '' AND paid != true) AS open_budget,
+			SUM(price) FILTER (WHERE assignee != '' AND paid != true) AS assigned_budget,
+			SUM(price) FILTER (WHERE completed = true AND paid != true) AS completed_budget
+			FROM bounty GROUP BY workspace_uuid) sums ON sums.workspace_uuid = budget.workspace_uuid
+		WHERE budget.workspace_uuid != ''
+		GROUP BY budget.workspace_uuid
+		ON CONFLICT (workspace_uuid, day) DO UPDATE SET
+		total_budget = excluded.total_budget,
+		open_budget = excluded.open_budget,
+		assigned_budget = excluded.assigned_budget,
+		completed_budget = excluded.completed_budget,
+		updated = excluded.updated`, day, time.Now()).Error
+}
+
+// GetWorkspaceBudgetSnapshots returns the daily snapshots of a workspace
+// between two days, both included
+func (db database) GetWorkspaceBudgetSnapshots(workspaceUuid string, from string, to string) []WorkspaceBudgetSnapshot {
+	snapshots := []WorkspaceBudgetSnapshot{}
+	db.db.Where("workspace_uuid = ? AND day >= ? AND day <= ?", workspaceUuid, from, to).Order("day ASC").Find(&snapshots)
+	return snapshots
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+var ErrCalendarTokenInvalid = errors.New("calendar token is invalid or revoked")
+
+func signCalendarToken(uuid string, pubkey string) string {
+	mac := hmac.New(sha256.New, []byte(config.CalendarSigningKey))
+	mac.Write([]byte("calendar:" + uuid + ":" + pubkey))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// CalendarTokenString is the token a calendar app reads the feed with, the uuid
+// of the stored token and its signature
+func CalendarTokenString(token CalendarToken) string {
+	return token.Uuid + "." + signCalendarToken(token.Uuid, token.OwnerPubKey)
+}
+
+// CreateCalendarToken makes a new calendar token for a person, revoking the ones before it
+func (db database) CreateCalendarToken(pubkey string) (CalendarToken, error) {
+	if err := db.RevokeCalendarTokens(pubkey); err != nil {
+		return CalendarToken{}, err
+	}
+
+	now := time.Now()
+	token := CalendarToken{
+		Uuid:        xid.New().String(),
+		OwnerPubKey: pubkey,
+		Created:     &now,
+	}
+	if err := db.db.Create(&token).Error; err != nil {
+		return CalendarToken{}, err
+	}
+	return token, nil
+}
+
+func (db database) RevokeCalendarTokens(pubkey string) error {
+	now := time.Now()
+	return db.db.Model(&CalendarToken{}).
+		Where("owner_pub_key = ? AND revoked IS NULL", pubkey).
+		Update("revoked", &now).Error
+}
+
+// VerifyCalendarToken returns the token a calendar token string was made from
+// when its signature matches and it was not revoked
+func (db database) VerifyCalendarToken(tokenString string) (CalendarToken, error) {
+	uuid, signature, ok := strings.Cut(tokenString, ".")
+	if !ok {
+		return CalendarToken{}, ErrCalendarTokenInvalid
+	}
+
+	token := CalendarToken{}
+	result := db.db.Model(&CalendarToken{}).Where("uuid = ? AND revoked IS NULL", uuid).Limit(1).Find(&token)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return CalendarToken{}, ErrCalendarTokenInvalid
+	}
+	if !hmac.Equal([]byte(signature), []byte(signCalendarToken(token.Uuid, token.OwnerPubKey))) {
+		return CalendarToken{}, ErrCalendarTokenInvalid
+	}
+
+	now := time.Now()
+	db.db.Model(&CalendarToken{}).Where("id = ?", token.ID).Update("last_used", &now)
+	return token, nil
+}
+
+// GetPersonCalendarBounties returns the unfinished bounties assigned to a person
+// that have a due or estimated completion date
+func (db database) GetPersonCalendarBounties(pubkey string) []NewBounty {
+	bounties := []NewBounty{}
+	db.db.Model(&NewBounty{}).
+		Where("assignee = ? AND completed = ? AND paid = ? AND state <> ?", pubkey, false, false, BountyStateCanceled).
+		Where("due_date IS NOT NULL OR estimated_completion_date <> ''").
+		Order("due_date ASC NULLS LAST, id ASC").
+		Find(&bounties)
+	return bounties
+}
+
+// GetPersonScheduledFeatureCalls returns the calls scheduled since a time, or
+// recurring, on the features of the workspaces a person owns or is a member of
+func (db database) GetPersonScheduledFeatureCalls(pubkey string, since time.Time) []FeatureCall {
+	calls := []FeatureCall{}
+	db.db.Model(&FeatureCall{}).
+		Where("ended_at IS NULL AND scheduled_at IS NOT NULL").
+		Where("scheduled_at >= ? OR recurrence <> ''", since).
+		Where("workspace_uuid IN (SELECT uuid FROM workspaces WHERE owner_pub_key = ? AND deleted = ?) OR workspace_uuid IN (SELECT workspace_uuid FROM workspace_users WHERE owner_pub_key = ?)", pubkey, false, pubkey).
+		Order("scheduled_at ASC").
+		Find(&calls)
+	return calls
+}
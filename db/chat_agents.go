@@ -0,0 +1,67 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+)
+
+var ErrChatAgentWebhookNotFound = errors.New("chat agent webhook not found")
+
+// SetChatAgentWebhook registers the agent webhook of a chat, it replaces the
+// one the chat had
+func (db database) SetChatAgentWebhook(webhook ChatAgentWebhook) (ChatAgentWebhook, error) {
+	now := time.Now()
+	webhook.ID = 0
+	webhook.Uuid = xid.New().String()
+	webhook.Created = &now
+	webhook.Updated = &now
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		if err := tx.Where("chat_id = ?", webhook.ChatId).Delete(&ChatAgentWebhook{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&webhook).Error
+	})
+	if err != nil {
+		return ChatAgentWebhook{}, err
+	}
+	return webhook, nil
+}
+
+func (db database) GetChatAgentWebhook(chatId string) (ChatAgentWebhook, error) {
+	webhook := ChatAgentWebhook{}
+	result := db.db.Model(&ChatAgentWebhook{}).Where("chat_id = ?", chatId).Limit(1).Find(&webhook)
+	if result.Error != nil {
+		return webhook, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return webhook, ErrChatAgentWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func (db database) DeleteChatAgentWebhook(chatId string) error {
+	result := db.db.Where("chat_id = ?", chatId).Delete(&ChatAgentWebhook{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrChatAgentWebhookNotFound
+	}
+	return nil
+}
+
+// RecordChatAgentDelivery keeps the outcome of the last post to an agent like
+// RecordBridgeDelivery
+func (db database) RecordChatAgentDelivery(uuid string, now time.Time, deliveryErr error) error {
+	updates := map[string]interface{}{"last_error": ""}
+	if deliveryErr != nil {
+		updates["last_error"] = deliveryErr.Error()
+	} else {
+		updates["last_delivered"] = now
+	}
+	return db.db.Model(&ChatAgentWebhook{}).Where("uuid = ?", uuid).Updates(updates).Error
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+func (db database) CreateOrEditCodeGraph(m WorkspaceCodeGraph) (WorkspaceCodeGraph, error) {
+	m.Name = strings.TrimSpace(m.Name)
+	m.Url = strings.TrimSpace(m.Url)
+
+	now := time.Now()
+	m.Updated = &now
+
+	var existing WorkspaceCodeGraph
+	result := db.db.Model(&WorkspaceCodeGraph{}).Where("uuid = ?", m.Uuid).First(&existing)
+	if result.RowsAffected == 0 {
+		m.Created = &now
+		if m.Status == "" {
+			m.Status = CodeGraphPending
+		}
+		db.db.Create(&m)
+	} else {
+		db.db.Model(&WorkspaceCodeGraph{}).Where("uuid = ?", m.Uuid).Updates(m)
+	}
+
+	db.db.Model(&WorkspaceCodeGraph{}).Where("uuid = ?", m.Uuid).Find(&m)
+	return m, nil
+}
+
+func (db database) GetCodeGraphsByWorkspaceUuid(workspace_uuid string) []WorkspaceCodeGraph {
+	ms := []WorkspaceCodeGraph{}
+	db.db.Model(&WorkspaceCodeGraph{}).Where("workspace_uuid = ?", workspace_uuid).Order("created ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetCodeGraphByUuid(uuid string) (WorkspaceCodeGraph, error) {
+	ms := WorkspaceCodeGraph{}
+	result := db.db.Model(&WorkspaceCodeGraph{}).Where("uuid = ?", uuid).First(&ms)
+	if result.RowsAffected == 0 {
+		return ms, errors.New("no code graph found")
+	}
+	return ms, nil
+}
+
+func (db database) UpdateCodeGraphStatus(uuid string, status CodeGraphStatus, progress int, errMsg string) (WorkspaceCodeGraph, error) {
+	ms := WorkspaceCodeGraph{}
+	now := time.Now()
+
+	updates := map[string]interface{}{
+		"status":   status,
+		"progress": progress,
+		"error":    errMsg,
+		"updated":  &now,
+	}
+	if status == CodeGraphCompleted {
+		updates["last_synced"] = &now
+	}
+
+	result := db.db.Model(&WorkspaceCodeGraph{}).Where("uuid = ?", uuid).Updates(updates)
+	if result.RowsAffected == 0 {
+		return ms, errors.New("no code graph found to update")
+	}
+
+	db.db.Model(&WorkspaceCodeGraph{}).Where("uuid = ?", uuid).Find(&ms)
+	return ms, nil
+}
+
+func (db database) DeleteCodeGraph(workspace_uuid string, uuid string) error {
+	result := db.db.Where("workspace_uuid = ? AND uuid = ?", workspace_uuid, uuid).Delete(&WorkspaceCodeGraph{})
+	if result.RowsAffected == 0 {
+		return errors.New("no code graph found to delete")
+	}
+	return nil
+}
@@ -1,10 +1,16 @@
 package db
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/config"
 	"gopkg.in/go-playground/validator.v9"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -14,6 +20,7 @@ type database struct {
 	db                 *gorm.DB
 	getWorkspaceByUuid func(uuid string) Workspace
 	getUserRoles       func(uuid string, pubkey string) []WorkspaceUserRoles
+	getInheritedRoles  func(uuid string, pubkey string) []WorkspaceUserRoles
 }
 
 func NewDatabaseConfig(db *gorm.DB) *database {
@@ -21,6 +28,7 @@ func NewDatabaseConfig(db *gorm.DB) *database {
 		db:                 db,
 		getWorkspaceByUuid: DB.GetWorkspaceByUuid,
 		getUserRoles:       DB.GetUserRoles,
+		getInheritedRoles:  DB.GetInheritedWorkspaceRoles,
 	}
 }
 
@@ -28,15 +36,15 @@ func NewDatabaseConfig(db *gorm.DB) *database {
 var DB database
 
 func InitDB() {
-	dbURL := os.Getenv("DATABASE_URL")
+	dbURL := config.GetSecret("DATABASE_URL")
 	fmt.Printf("db url : %v", dbURL)
 
 	if dbURL == "" {
 		rdsHost := os.Getenv("RDS_HOSTNAME")
 		rdsPort := os.Getenv("RDS_PORT")
 		rdsDbName := os.Getenv("RDS_DB_NAME")
-		rdsUsername := os.Getenv("RDS_USERNAME")
-		rdsPassword := os.Getenv("RDS_PASSWORD")
+		rdsUsername := config.GetSecret("RDS_USERNAME")
+		rdsPassword := config.GetSecret("RDS_PASSWORD")
 		dbURL = fmt.Sprintf("postgres://%s:%s@%s:%s/%s", rdsUsername, rdsPassword, rdsHost, rdsPort, rdsDbName)
 	}
 
@@ -47,7 +55,7 @@ func InitDB() {
 	var err error
 
 	db, err := gorm.Open(postgres.New(postgres.Config{
-		DSN:                  dbURL,
+		DSN:                  withStatementTimeout(dbURL, time.Duration(config.DbStatementTimeout)*time.Second),
 		PreferSimpleProtocol: true,
 	}), &gorm.Config{})
 
@@ -55,10 +63,31 @@ func InitDB() {
 		panic(err)
 	}
 
+	if err := db.Use(tracingPlugin{}); err != nil {
+		fmt.Println("could not add db tracing", err)
+	}
+	if err := db.Use(slowQueryPlugin{}); err != nil {
+		fmt.Println("could not add slow query log", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		panic(err)
+	}
+	sqlDB.SetMaxOpenConns(config.DbMaxOpenConns)
+	sqlDB.SetMaxIdleConns(config.DbMaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(config.DbConnMaxLifetime) * time.Minute)
+	sqlDB.SetConnMaxIdleTime(time.Duration(config.DbConnMaxIdleTime) * time.Minute)
+	fmt.Printf("db pool : max open %d, max idle %d, max lifetime %dm, max idle time %dm, statement timeout %ds\n",
+		config.DbMaxOpenConns, config.DbMaxIdleConns, config.DbConnMaxLifetime, config.DbConnMaxIdleTime, config.DbStatementTimeout)
+
 	DB.db = db
 
 	fmt.Println("db connected")
 
+	// the key has to be there before any person is read
+	InitFieldEncryption()
+
 	// migrate table changes
 	db.AutoMigrate(&Tribe{})
 	db.AutoMigrate(&Person{})
@@ -68,12 +97,75 @@ func InitDB() {
 	db.AutoMigrate(&BountyRoles{})
 	db.AutoMigrate(&UserInvoiceData{})
 	db.AutoMigrate(&WorkspaceRepositories{})
+	db.AutoMigrate(&WorkspaceCodeGraph{})
 	db.AutoMigrate(&WorkspaceFeatures{})
 	db.AutoMigrate(&FeaturePhase{})
 	db.AutoMigrate(&FeatureStory{})
+	db.AutoMigrate(&PhaseDocument{})
+	db.AutoMigrate(&FeatureCall{})
+	db.AutoMigrate(&FeatureCallTranscript{})
+	db.AutoMigrate(&FeatureCallTranscriptChunk{})
+	db.AutoMigrate(&CalendarToken{})
+	db.AutoMigrate(&Chat{})
+	db.AutoMigrate(&ChatMessage{})
+	db.AutoMigrate(&Artifact{})
+	db.AutoMigrate(&ArtifactVersion{})
+	db.AutoMigrate(&BountyApplication{})
+	db.AutoMigrate(&BountyAuction{})
+	db.AutoMigrate(&BountyBid{})
+	db.AutoMigrate(&BountyAssigneeHistory{})
+	db.AutoMigrate(&BountyStateTransition{})
+	db.AutoMigrate(&BountyComment{})
+	db.AutoMigrate(&BountyReceipt{})
+	db.AutoMigrate(&BountyLeaderboardDaily{})
+	db.AutoMigrate(&Notification{})
+	db.AutoMigrate(&BadgeIssuance{})
+	db.AutoMigrate(&BountyHoursLog{})
+	db.AutoMigrate(&BudgetLedgerEntry{})
+	db.AutoMigrate(&BudgetRefund{})
+	db.AutoMigrate(&SuperAdmin{})
+	db.AutoMigrate(&SuperAdminChange{})
+	db.AutoMigrate(&WebhookSigningSecret{})
+	db.AutoMigrate(&PersonMerge{})
+	db.AutoMigrate(&HandleReservation{})
+	db.AutoMigrate(&RelayEventLog{})
+	db.AutoMigrate(&WorkspaceApiToken{})
+	db.AutoMigrate(&WorkspaceWebhook{})
+	db.AutoMigrate(&WorkspaceTag{})
+	db.AutoMigrate(&BountyPriceStat{})
+	db.AutoMigrate(&ShortLink{})
+	db.AutoMigrate(&NostrEvent{})
+	db.AutoMigrate(&WorkspaceBridge{})
+	db.AutoMigrate(&SlackUserLink{})
+	db.AutoMigrate(&SlackTeam{})
+	db.AutoMigrate(&TicketReview{})
+	db.AutoMigrate(&PhasePlan{})
+	db.AutoMigrate(&PhasePlanDraft{})
+	db.AutoMigrate(&WorkspaceUsageDaily{})
+	db.AutoMigrate(&WorkspaceBudgetSnapshot{})
+	db.AutoMigrate(&ChatAgentWebhook{})
+	db.AutoMigrate(&WorkspaceSettings{})
+	db.AutoMigrate(&WorkspaceArchive{})
+	db.AutoMigrate(&ParentOrganization{})
+	db.AutoMigrate(&ParentOrganizationWorkspace{})
+	db.AutoMigrate(&ParentOrganizationMember{})
+	db.AutoMigrate(&WebhookAlert{})
+	db.AutoMigrate(&JiraUserLink{})
+	db.AutoMigrate(&JiraIssueLink{})
+	db.AutoMigrate(&ConfigOverride{})
 
 	DB.MigrateTablesWithOrgUuid()
 	DB.MigrateOrganizationToWorkspace()
+	DB.MigrateBudgetLedger()
+	DB.MigrateBountyStates()
+	DB.MigrateAutocompleteIndexes()
+	DB.MigrateBountyTagIndexes()
+	DB.MigratePeopleSearchIndexes()
+	DB.MigrateWorkspaceSchemas()
+	DB.MigrateEmbeddings()
+	DB.MigrateChatArtifacts()
+	DB.MigrateFeatureBountyCounts()
+	DB.MigrateFeatureStoryDeliveryIndex()
 
 	people := DB.GetAllPeople()
 	for _, p := range people {
@@ -84,6 +176,27 @@ func InitDB() {
 
 }
 
+// withStatementTimeout sets the postgres statement_timeout of every connection
+// opened with the dsn, unless the dsn already sets one
+func withStatementTimeout(dsn string, timeout time.Duration) string {
+	if timeout <= 0 || strings.Contains(dsn, "statement_timeout") {
+		return dsn
+	}
+	value := strconv.FormatInt(timeout.Milliseconds(), 10)
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return dsn
+		}
+		query := u.Query()
+		query.Set("statement_timeout", value)
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+	return dsn + " statement_timeout=" + value
+}
+
 const (
 	EditOrg        = "EDIT ORGANIZATION"
 	AddBounty      = "ADD BOUNTY"
@@ -97,6 +210,8 @@ const (
 	AddBudget      = "ADD BUDGET"
 	WithdrawBudget = "WITHDRAW BUDGET"
 	ViewReport     = "VIEW REPORT"
+	// OverrideWipLimits lets a member move bounties past the state wip limits
+	OverrideWipLimits = "OVERRIDE WIP LIMITS"
 )
 
 var ConfigBountyRoles []BountyRoles = []BountyRoles{
@@ -136,6 +251,9 @@ var ConfigBountyRoles []BountyRoles = []BountyRoles{
 	{
 		Name: ViewReport,
 	},
+	{
+		Name: OverrideWipLimits,
+	},
 }
 
 var ManageBountiesGroup = []string{AddBounty, UpdateBounty, DeleteBounty, PayBounty}
@@ -162,6 +280,8 @@ var Peopleupdatables = []string{
 	"owner_route_hint",
 	"price_to_meet", "updated",
 	"extras",
+	"portfolio_private", "hide_earnings",
+	"availability_status", "busy_until", "weekly_hours",
 }
 
 var Validate *validator.Validate = validator.New()
@@ -368,32 +488,42 @@ func CheckUser(userRoles []WorkspaceUserRoles, pubkey string) bool {
 }
 
 func UserHasAccess(pubKeyFromAuth string, uuid string, role string) bool {
-	org := DB.GetWorkspaceByUuid(uuid)
-	var hasRole bool = false
-	if pubKeyFromAuth != org.OwnerPubKey {
-		userRoles := DB.GetUserRoles(uuid, pubKeyFromAuth)
-		hasRole = RolesCheck(userRoles, role)
-		return hasRole
-	}
-	return true
+	return NewDatabaseConfig(DB.db).UserHasAccess(pubKeyFromAuth, uuid, role)
 }
 
 func (db database) UserHasAccess(pubKeyFromAuth string, uuid string, role string) bool {
 	org := db.getWorkspaceByUuid(uuid)
+	// nobody manages a deleted workspace until a super admin restores it
+	if org.Deleted {
+		return false
+	}
 	var hasRole bool = false
 	if pubKeyFromAuth != org.OwnerPubKey {
-		userRoles := db.getUserRoles(uuid, pubKeyFromAuth)
+		userRoles := db.workspaceRoles(uuid, pubKeyFromAuth)
 		hasRole = RolesCheck(userRoles, role)
 		return hasRole
 	}
 	return true
 }
 
+// workspaceRoles are the roles of a user on a workspace, with the roles
+// they inherit from the parent organization of the workspace
+func (db database) workspaceRoles(uuid string, pubkey string) []WorkspaceUserRoles {
+	roles := db.getUserRoles(uuid, pubkey)
+	if db.getInheritedRoles != nil {
+		roles = append(roles, db.getInheritedRoles(uuid, pubkey)...)
+	}
+	return roles
+}
+
 func (db database) UserHasManageBountyRoles(pubKeyFromAuth string, uuid string) bool {
 	var manageRolesCount = len(ManageBountiesGroup)
 	org := db.getWorkspaceByUuid(uuid)
+	if org.Deleted {
+		return false
+	}
 	if pubKeyFromAuth != org.OwnerPubKey {
-		userRoles := db.getUserRoles(uuid, pubKeyFromAuth)
+		userRoles := db.workspaceRoles(uuid, pubKeyFromAuth)
 
 		for _, role := range ManageBountiesGroup {
 			// check for the manage bounty roles
@@ -409,3 +539,12 @@ func (db database) UserHasManageBountyRoles(pubKeyFromAuth string, uuid string)
 	}
 	return true
 }
+
+// Ping checks the database can still be reached, it backs the readiness check
+func (db database) Ping(ctx context.Context) error {
+	sqlDB, err := db.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
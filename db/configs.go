@@ -0,0 +1,39 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+var ErrConfigOverrideNotFound = errors.New("config override not found")
+
+func (db database) GetConfigOverrides() ([]ConfigOverride, error) {
+	ms := []ConfigOverride{}
+	err := db.db.Order("key ASC").Find(&ms).Error
+	return ms, err
+}
+
+func (db database) SaveConfigOverride(key string, value string, pubkey string) (ConfigOverride, error) {
+	now := time.Now()
+	override := ConfigOverride{Key: key, Value: value, UpdatedBy: pubkey, Updated: &now}
+	err := db.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_by", "updated"}),
+	}).Create(&override).Error
+	return override, err
+}
+
+// DeleteConfigOverride puts the value back to its env or default, it returns
+// ErrConfigOverrideNotFound when the key has no override
+func (db database) DeleteConfigOverride(key string) error {
+	result := db.db.Where("key = ?", key).Delete(&ConfigOverride{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrConfigOverrideNotFound
+	}
+	return nil
+}
@@ -268,7 +268,7 @@ func (db database) GetListedTribes(r *http.Request) []Tribe {
 	tags := keys.Get("tags") // this is a string of tags separated by commas
 	offset, limit, sortBy, direction, search := utils.GetPaginationParams(r)
 
-	thequery := db.db.Offset(offset).Limit(limit).Order(sortBy+" "+direction).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where("LOWER(name) LIKE ?", "%"+search+"%")
+	thequery := db.db.Offset(offset).Limit(limit).Order(TribeSortFields.OrderBy(sortBy, direction, "created")).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where("LOWER(name) LIKE ?", "%"+search+"%")
 
 	if tags != "" {
 		// pull out the tags and add them in here
@@ -317,13 +317,55 @@ func (db database) GetListedBots(r *http.Request) []Bot {
 	offset, limit, sortBy, direction, search := utils.GetPaginationParams(r)
 
 	// db.db.Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Find(&ms)
-	db.db.Offset(offset).Limit(limit).Order(sortBy+" "+direction).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where("LOWER(name) LIKE ?", "%"+search+"%").Find(&ms)
+	db.db.Offset(offset).Limit(limit).Order(BotSortFields.OrderBy(sortBy, direction, "created")).Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where("LOWER(name) LIKE ?", "%"+search+"%").Find(&ms)
 
 	return ms
 }
 
 func (db database) GetListedPeople(r *http.Request) []Person {
 	ms := []Person{}
+	query, args := listedPeopleQuery(r)
+	db.db.Raw(query, args...).Find(&ms)
+	return ms
+}
+
+// streamBatchSize is how many rows of a streamed list are held at once
+const streamBatchSize = 500
+
+// StreamListedPeople reads the people of GetListedPeople in batches, so a
+// dump of every person is read with flat memory
+func (db database) StreamListedPeople(r *http.Request, each func(people []Person) error) error {
+	query, args := listedPeopleQuery(r)
+	rows, err := db.db.Raw(query, args...).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	batch := []Person{}
+	for rows.Next() {
+		person := Person{}
+		if err := db.db.ScanRows(rows, &person); err != nil {
+			return err
+		}
+		batch = append(batch, person)
+		if len(batch) == streamBatchSize {
+			if err := each(batch); err != nil {
+				return err
+			}
+			batch = []Person{}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return each(batch)
+	}
+	return nil
+}
+
+func listedPeopleQuery(r *http.Request) (string, []interface{}) {
 	offset, limit, sortBy, direction, search := utils.GetPaginationParams(r)
 
 	// avoid dereference error, since r can be nil
@@ -339,26 +381,25 @@ func (db database) GetListedPeople(r *http.Request) []Person {
 	orderQuery := ""
 	limitQuery := ""
 	searchQuery := ""
+	searchArgs := []interface{}{}
 
 	languageQuery := ""
 
-	if sortBy != "" && direction != "" {
-		orderQuery = "ORDER BY " + sortBy + " " + direction
-	} else {
-		orderQuery = "ORDER BY " + sortBy + "" + "DESC"
-	}
+	orderQuery = "ORDER BY " + PeopleSortFields.OrderBy(sortBy, direction, "created")
 	if limit > -1 {
 		limitQuery = fmt.Sprintf("LIMIT %d  OFFSET %d", limit, offset)
 	}
 	if search != "" {
-		searchQuery = fmt.Sprintf("AND LOWER(owner_alias) LIKE %[1]s OR LOWER(unique_name) LIKE %[1]s", "'%"+strings.ToLower(search)+"%'")
+		condition, args := peopleSearchCondition(search)
+		searchQuery = "AND " + condition
+		searchArgs = args
 	}
 
 	if languageLength > 0 {
 		for i, val := range languageArray {
 			if val != "" {
 				if i == 0 {
-					languageQuery = "AND extras->'coding_languages' @> '[{\"label\": \"" + val + "\"}]'"
+					languageQuery = "AND (extras->'coding_languages' @> '[{\"label\": \"" + val + "\"}]'"
 				} else {
 					languageQuery += " OR extras->'coding_languages' @> '[{\"label\": \"" + val + "\"}]'"
 				}
@@ -367,12 +408,19 @@ func (db database) GetListedPeople(r *http.Request) []Person {
 
 	}
 
-	query := "SELECT * FROM people WHERE (unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)"
+	if languageQuery != "" {
+		languageQuery += ")"
+	}
 
-	allQuery := query + " " + searchQuery + " " + languageQuery + " " + orderQuery + " " + limitQuery
+	availabilityQuery := ""
+	if filter := availabilityFilterFromRequest(r); filter != "" {
+		availabilityQuery = "AND " + filter
+	}
 
-	db.db.Raw(allQuery).Find(&ms)
-	return ms
+	query := "SELECT * FROM people WHERE (unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)"
+
+	allQuery := query + " " + availabilityQuery + " " + searchQuery + " " + languageQuery + " " + orderQuery + " " + limitQuery
+	return allQuery, searchArgs
 }
 
 func (db database) ListAllPeople(r *http.Request) []Person {
@@ -420,7 +468,12 @@ func (db database) GetPeopleBySearch(r *http.Request) []Person {
 	// if search is empty, returns all
 
 	// return if like owner_alias, unique_name, or equals pubkey
-	db.db.Offset(offset).Limit(limit).Order(sortBy+" "+direction+" NULLS LAST").Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)").Where("LOWER(owner_alias) LIKE ?", "%"+search+"%").Or("LOWER(unique_name) LIKE ?", "%"+search+"%").Or("LOWER(owner_pub_key) = ?", search).Find(&ms)
+	query := db.db.Offset(offset).Limit(limit).Order(PeopleSortFields.OrderBy(sortBy, direction, "created") + " NULLS LAST").Where("(unlisted = 'f' OR unlisted is null) AND (deleted = 'f' OR deleted is null)")
+	if filter := availabilityFilterFromRequest(r); filter != "" {
+		query = query.Where(filter)
+	}
+	condition, args := peopleSearchCondition(search)
+	query.Where(condition, args...).Find(&ms)
 	return ms
 }
 
@@ -499,7 +552,7 @@ func (db database) GetListedPosts(r *http.Request) ([]PeopleExtra, error) {
 	}
 
 	// sort by newest
-	result := db.db.Offset(offset).Limit(limit).Order("arr.item_object->>'"+sortBy+"' DESC").Raw(
+	result := db.db.Offset(offset).Limit(limit).Order(ExtrasSortFields.OrderBy(sortBy, "desc", "created")).Raw(
 		rawQuery, "%"+search+"%").Find(&ms)
 
 	return ms, result.Error
@@ -561,8 +614,8 @@ func (db database) GetBountiesCount(r *http.Request) int64 {
 
 	var count int64
 
-	query := "SELECT COUNT(*) FROM bounty WHERE show != false"
-	allQuery := query + " " + openQuery + " " + assignedQuery + " " + completedQuery + " " + paidQuery
+	query := "SELECT COUNT(*) FROM bounty WHERE " + bountyVisibilityQuery(bountyViewer(r)) + " AND (show != false"
+	allQuery := query + " " + openQuery + " " + assignedQuery + " " + completedQuery + " " + paidQuery + ")"
 	db.db.Raw(allQuery).Scan(&count)
 	return count
 }
@@ -573,10 +626,10 @@ func (db database) GetFilterStatusCount() FilterStattuCount {
 	var completedCount int64
 	var paidCount int64
 
-	db.db.Model(&Bounty{}).Where("show != false").Where("assignee = ''").Where("paid != true").Count(&openCount)
-	db.db.Model(&Bounty{}).Where("show != false").Where("assignee != ''").Where("paid != true").Count(&assignedCount)
-	db.db.Model(&Bounty{}).Where("show != false").Where("assignee != ''").Where("completed = true").Where("paid != true").Count(&completedCount)
-	db.db.Model(&Bounty{}).Where("show != false").Where("assignee != ''").Where("paid = true").Count(&paidCount)
+	db.db.Model(&Bounty{}).Where("show != false").Where(publicBountiesQuery).Where("assignee = ''").Where("paid != true").Count(&openCount)
+	db.db.Model(&Bounty{}).Where("show != false").Where(publicBountiesQuery).Where("assignee != ''").Where("paid != true").Count(&assignedCount)
+	db.db.Model(&Bounty{}).Where("show != false").Where(publicBountiesQuery).Where("assignee != ''").Where("completed = true").Where("paid != true").Count(&completedCount)
+	db.db.Model(&Bounty{}).Where("show != false").Where(publicBountiesQuery).Where("assignee != ''").Where("paid = true").Count(&paidCount)
 
 	ms := FilterStattuCount{
 		Open:      openCount,
@@ -607,11 +660,7 @@ func (db database) GetWorkspaceBounties(r *http.Request, workspace_uuid string)
 	searchQuery := ""
 	languageQuery := ""
 
-	if sortBy != "" && direction != "" {
-		orderQuery = "ORDER BY " + sortBy + " " + direction
-	} else {
-		orderQuery = " ORDER BY " + sortBy + "" + "DESC"
-	}
+	orderQuery = "ORDER BY " + BountySortFields.OrderBy(sortBy, direction, "created")
 	if limit > 0 {
 		limitQuery = fmt.Sprintf("LIMIT %d", limit)
 	}
@@ -658,18 +707,9 @@ func (db database) GetWorkspaceBounties(r *http.Request, workspace_uuid string)
 		}
 	}
 
-	query := `SELECT * FROM bounty WHERE workspace_uuid = '` + workspace_uuid + `'`
-	allQuery := query + " " + statusQuery + " " + searchQuery + " " + languageQuery + " " + orderQuery + " " + limitQuery
+	query := `SELECT * FROM bounty WHERE workspace_uuid = '` + workspace_uuid + `' AND ` + bountyVisibilityQuery(bountyViewer(r))
+	allQuery := query + " " + statusQuery + " " + searchQuery + " " + languageQuery + " " + bountyTagsQuery(tags) + " " + orderQuery + " " + limitQuery
 	theQuery := db.db.Raw(allQuery)
-
-	if tags != "" {
-		// pull out the tags and add them in here
-		t := strings.Split(tags, ",")
-		for _, s := range t {
-			theQuery = theQuery.Where("'" + s + "'" + " = any (tags)")
-		}
-	}
-
 	theQuery.Scan(&ms)
 
 	return ms
@@ -732,18 +772,9 @@ func (db database) GetWorkspaceBountiesCount(r *http.Request, workspace_uuid str
 
 	var count int64
 
-	query := `SELECT COUNT(*) FROM bounty WHERE workspace_uuid = '` + workspace_uuid + `'`
-	allQuery := query + " " + statusQuery + " " + searchQuery + " " + languageQuery
+	query := `SELECT COUNT(*) FROM bounty WHERE workspace_uuid = '` + workspace_uuid + `' AND ` + bountyVisibilityQuery(bountyViewer(r))
+	allQuery := query + " " + statusQuery + " " + searchQuery + " " + languageQuery + " " + bountyTagsQuery(tags)
 	theQuery := db.db.Raw(allQuery)
-
-	if tags != "" {
-		// pull out the tags and add them in here
-		t := strings.Split(tags, ",")
-		for _, s := range t {
-			theQuery = theQuery.Where("'" + s + "'" + " = any (tags)")
-		}
-	}
-
 	theQuery.Scan(&count)
 
 	return count
@@ -781,18 +812,14 @@ func (db database) GetAssignedBounties(r *http.Request) ([]NewBounty, error) {
 		statusQuery = ""
 	}
 
-	if sortBy != "" && direction != "" {
-		orderQuery = "ORDER BY " + sortBy + " " + direction
-	} else {
-		orderQuery = " ORDER BY created DESC"
-	}
+	orderQuery = "ORDER BY " + BountySortFields.OrderBy(sortBy, direction, "created")
 	if offset >= 0 && limit > 1 {
 		limitQuery = fmt.Sprintf("LIMIT %d  OFFSET %d", limit, offset)
 	}
 
 	ms := []NewBounty{}
 
-	query := `SELECT * FROM public.bounty WHERE assignee = '` + pubkey + `' AND show != false`
+	query := `SELECT * FROM public.bounty WHERE assignee = '` + pubkey + `' AND show != false AND ` + bountyVisibilityQuery(bountyViewer(r))
 	allQuery := query + " " + statusQuery + " " + orderQuery + " " + limitQuery
 	err := db.db.Raw(allQuery).Find(&ms).Error
 	return ms, err
@@ -830,11 +857,7 @@ func (db database) GetCreatedBounties(r *http.Request) ([]NewBounty, error) {
 		statusQuery = ""
 	}
 
-	if sortBy != "" && direction != "" {
-		orderQuery = "ORDER BY " + sortBy + " " + direction
-	} else {
-		orderQuery = "ORDER BY created DESC"
-	}
+	orderQuery = "ORDER BY " + BountySortFields.OrderBy(sortBy, direction, "created")
 
 	if offset >= 0 && limit > 1 {
 		limitQuery = fmt.Sprintf("LIMIT %d  OFFSET %d", limit, offset)
@@ -842,7 +865,7 @@ func (db database) GetCreatedBounties(r *http.Request) ([]NewBounty, error) {
 
 	ms := []NewBounty{}
 
-	query := `SELECT * FROM public.bounty WHERE owner_id = '` + pubkey + `'`
+	query := `SELECT * FROM public.bounty WHERE owner_id = '` + pubkey + `' AND ` + bountyVisibilityQuery(bountyViewer(r))
 	allQuery := query + " " + statusQuery + " " + orderQuery + " " + limitQuery
 
 	err := db.db.Raw(allQuery).Find(&ms).Error
@@ -907,7 +930,7 @@ func (db database) GetNextBountyByCreated(r *http.Request) (uint, error) {
 		}
 	}
 
-	query := `SELECT id FROM public.bounty WHERE created > '` + created + `' AND show = true`
+	query := `SELECT id FROM public.bounty WHERE created > '` + created + `' AND show = true AND ` + bountyVisibilityQuery(bountyViewer(r))
 	orderQuery := "ORDER BY created ASC LIMIT 1"
 
 	allQuery := query + " " + searchQuery + " " + statusQuery + " " + languageQuery + " " + orderQuery
@@ -972,7 +995,7 @@ func (db database) GetPreviousBountyByCreated(r *http.Request) (uint, error) {
 		}
 	}
 
-	query := `SELECT id FROM public.bounty WHERE created < '` + created + `' AND show = true`
+	query := `SELECT id FROM public.bounty WHERE created < '` + created + `' AND show = true AND ` + bountyVisibilityQuery(bountyViewer(r))
 	orderQuery := "ORDER BY created DESC LIMIT 1"
 
 	allQuery := query + " " + searchQuery + " " + statusQuery + " " + languageQuery + " " + orderQuery
@@ -1038,7 +1061,7 @@ func (db database) GetNextWorkspaceBountyByCreated(r *http.Request) (uint, error
 		}
 	}
 
-	query := `SELECT id FROM public.bounty WHERE workspace_uuid = '` + uuid + `' AND created > '` + created + `' AND show = true`
+	query := `SELECT id FROM public.bounty WHERE workspace_uuid = '` + uuid + `' AND created > '` + created + `' AND show = true AND ` + bountyVisibilityQuery(bountyViewer(r))
 	orderQuery := "ORDER BY created ASC LIMIT 1"
 
 	allQuery := query + " " + searchQuery + " " + statusQuery + " " + languageQuery + " " + orderQuery
@@ -1104,7 +1127,7 @@ func (db database) GetPreviousWorkspaceBountyByCreated(r *http.Request) (uint, e
 		}
 	}
 
-	query := `SELECT id FROM public.bounty WHERE workspace_uuid = '` + uuid + `' AND created < '` + created + `' AND show = true`
+	query := `SELECT id FROM public.bounty WHERE workspace_uuid = '` + uuid + `' AND created < '` + created + `' AND show = true AND ` + bountyVisibilityQuery(bountyViewer(r))
 	orderQuery := "ORDER BY created DESC LIMIT 1"
 
 	allQuery := query + " " + searchQuery + " " + statusQuery + " " + languageQuery + " " + orderQuery
@@ -1160,11 +1183,7 @@ func (db database) GetAllBounties(r *http.Request) []NewBounty {
 	phaseUuidQuery := ""
 	phasePriorityQuery := ""
 
-	if sortBy != "" && direction != "" {
-		orderQuery = "ORDER BY " + sortBy + " " + direction
-	} else {
-		orderQuery = "ORDER BY " + sortBy + "" + "DESC"
-	}
+	orderQuery = "ORDER BY " + BountySortFields.OrderBy(sortBy, direction, "created")
 	if limit != 0 {
 		limitQuery = fmt.Sprintf("LIMIT %d  OFFSET %d", limit, offset)
 	}
@@ -1219,20 +1238,11 @@ func (db database) GetAllBounties(r *http.Request) []NewBounty {
 		}
 	}
 
-	query := "SELECT * FROM public.bounty WHERE show != false"
+	query := "SELECT * FROM public.bounty WHERE show != false AND " + bountyVisibilityQuery(bountyViewer(r))
 
-	allQuery := query + " " + statusQuery + " " + searchQuery + " " + workspaceQuery + " " + languageQuery + " " + phaseUuidQuery + " " + phasePriorityQuery + " " + orderQuery + " " + limitQuery
+	allQuery := query + " " + statusQuery + " " + searchQuery + " " + workspaceQuery + " " + languageQuery + " " + bountyTagsQuery(tags) + " " + phaseUuidQuery + " " + phasePriorityQuery + " " + orderQuery + " " + limitQuery
 
 	theQuery := db.db.Raw(allQuery)
-
-	if tags != "" {
-		// pull out the tags and add them in here
-		t := strings.Split(tags, ",")
-		for _, s := range t {
-			theQuery = theQuery.Where("'" + s + "'" + " = any (tags)")
-		}
-	}
-
 	theQuery.Scan(&ms)
 
 	return ms
@@ -1243,9 +1253,12 @@ func (db database) CreateOrEditBounty(b NewBounty) (NewBounty, error) {
 		return NewBounty{}, errors.New("no pub key")
 	}
 
+	// the state only moves through transitions, an edit can not set it
+	b.State = ""
 	if db.db.Model(&b).Where("id = ? OR owner_id = ? AND created = ?", b.ID, b.OwnerID, b.Created).Updates(&b).RowsAffected == 0 {
 		db.db.Create(&b)
 	}
+	b.State = db.syncBountyState("id = ? OR owner_id = ? AND created = ?", b.ID, b.OwnerID, b.Created)
 	return b, nil
 }
 
@@ -1253,6 +1266,7 @@ func (db database) UpdateBountyNullColumn(b NewBounty, column string) NewBounty
 	columnMap := make(map[string]interface{})
 	columnMap[column] = ""
 	db.db.Model(&b).Where("created = ?", b.Created).UpdateColumns(&columnMap)
+	b.State = db.syncBountyState("created = ?", b.Created)
 	return b
 }
 
@@ -1260,6 +1274,7 @@ func (db database) UpdateBountyBoolColumn(b NewBounty, column string) NewBounty
 	columnMap := make(map[string]interface{})
 	columnMap[column] = false
 	db.db.Model(&b).Select(column).UpdateColumns(columnMap)
+	b.State = db.syncBountyState("id = ?", b.ID)
 	return b
 }
 
@@ -1282,7 +1297,9 @@ func (db database) GetBounty(id uint) NewBounty {
 }
 
 func (db database) UpdateBounty(b NewBounty) (NewBounty, error) {
+	b.State = ""
 	db.db.Where("created", b.Created).Updates(&b)
+	b.State = db.syncBountyState("created = ?", b.Created)
 	return b, nil
 }
 
@@ -1290,7 +1307,9 @@ func (db database) UpdateBountyPayment(b NewBounty) (NewBounty, error) {
 	db.db.Model(&b).Where("created", b.Created).Updates(map[string]interface{}{
 		"paid": b.Paid,
 	})
+	b.State = ""
 	db.db.Model(&b).Where("created", b.Created).Updates(b)
+	b.State = db.syncBountyState("created = ?", b.Created)
 	return b, nil
 }
 
@@ -1298,7 +1317,9 @@ func (db database) UpdateBountyCompleted(b NewBounty) (NewBounty, error) {
 	db.db.Model(&b).Where("created", b.Created).Updates(map[string]interface{}{
 		"completed": b.Completed,
 	})
+	b.State = ""
 	db.db.Model(&b).Where("created", b.Created).Updates(b)
+	b.State = db.syncBountyState("created = ?", b.Created)
 	return b, nil
 }
 
@@ -1341,7 +1362,7 @@ func (db database) GetListedOffers(r *http.Request) ([]PeopleExtra, error) {
 	}
 
 	// sort by newest
-	result := db.db.Offset(offset).Limit(limit).Order("arr.item_object->>'"+sortBy+"' DESC").Raw(
+	result := db.db.Offset(offset).Limit(limit).Order(ExtrasSortFields.OrderBy(sortBy, "desc", "created")).Raw(
 		rawQuery, "%"+search+"%").Find(&ms)
 
 	return ms, result.Error
@@ -1401,6 +1422,47 @@ func (db database) GetPersonByPubkey(pubkey string) Person {
 	return m
 }
 
+// GetPeopleByPubkeys loads the people of many pubkeys in one query, keyed by
+// pubkey. Pubkeys without a person are left out, so a lookup gives an empty
+// Person like GetPersonByPubkey does
+func (db database) GetPeopleByPubkeys(pubkeys []string) map[string]Person {
+	people := map[string]Person{}
+	seen := map[string]bool{}
+	keys := []string{}
+	for _, pubkey := range pubkeys {
+		if pubkey == "" || seen[pubkey] {
+			continue
+		}
+		seen[pubkey] = true
+		keys = append(keys, pubkey)
+	}
+	if len(keys) == 0 {
+		return people
+	}
+
+	ms := []Person{}
+	db.db.Where("owner_pub_key IN ? AND (deleted = false OR deleted is null)", keys).Find(&ms)
+	for _, m := range ms {
+		people[m.OwnerPubKey] = m
+	}
+	return people
+}
+
+func (db database) GetCompletedBountiesByAssignee(pubkey string) []NewBounty {
+	ms := []NewBounty{}
+	db.db.Model(&NewBounty{}).Where("assignee = ? AND (completed = true OR paid = true)", pubkey).Where(publicBountiesQuery).Order("created DESC").Find(&ms)
+	return ms
+}
+
+func (db database) GetTotalEarnedByPubkey(pubkey string) uint {
+	var sum uint
+	db.db.Model(&NewPaymentHistory{}).
+		Where("receiver_pub_key = ? AND status = true AND payment_type = ?", pubkey, Payment).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&sum)
+	return sum
+}
+
 func (db database) GetPersonByUuid(uuid string) Person {
 	m := Person{}
 	db.db.Where("uuid = ? AND (deleted = 'f' OR deleted is null)", uuid).Find(&m)
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+// IsOverdue tells if a bounty is past its due date and still not done
+func (b NewBounty) IsOverdue(now time.Time) bool {
+	if b.DueDate == nil || !now.After(*b.DueDate) {
+		return false
+	}
+	switch b.CurrentState() {
+	case BountyStateCompleted, BountyStatePaid, BountyStateCanceled:
+		return false
+	}
+	return true
+}
+
+// SetBountyDueDate changes or clears the due date of a bounty, the reminders
+// start over for the new date
+func (db database) SetBountyDueDate(id uint, dueDate *time.Time) error {
+	result := db.db.Model(&NewBounty{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"due_date":           dueDate,
+		"due_reminder_hours": nil,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("no bounty found")
+	}
+	return nil
+}
+
+// GetBountiesDueBetween returns the unfinished bounties due between from and to
+func (db database) GetBountiesDueBetween(from time.Time, to time.Time) []NewBounty {
+	bounties := []NewBounty{}
+	db.db.Model(&NewBounty{}).
+		Where("due_date BETWEEN ? AND ?", from, to).
+		Where("completed = ? AND paid = ? AND state <> ?", false, false, BountyStateCanceled).
+		Find(&bounties)
+	return bounties
+}
+
+// SetBountyDueReminder records the offset from the due date of the last reminder sent
+func (db database) SetBountyDueReminder(id uint, hours int) error {
+	return db.db.Model(&NewBounty{}).Where("id = ?", id).Update("due_reminder_hours", hours).Error
+}
@@ -0,0 +1,69 @@
+package db
+
+import "sort"
+
+// GetPersonEarnings returns the earnings of a person per workspace and month, from
+// their bounty payment records and the completed bounties still waiting for one
+func (db database) GetPersonEarnings(pubkey string) []EarningsRow {
+	rows := []EarningsRow{}
+	db.db.Raw(`SELECT workspace_uuid, month,
+		SUM(pending) AS pending, SUM(in_flight) AS in_flight, SUM(settled) AS settled
+		FROM (
+			SELECT COALESCE(workspace_uuid, '') AS workspace_uuid, to_char(created, 'YYYY-MM') AS month, 0 AS pending,
+			CASE WHEN payment_method = ? AND onchain_confirmed IS NULL THEN amount ELSE 0 END AS in_flight,
+			CASE WHEN payment_method = ? AND onchain_confirmed IS NULL THEN 0 ELSE amount END AS settled
+			FROM payment_histories
+			WHERE receiver_pub_key = ? AND status = true AND payment_type = ?
+			UNION ALL
+			SELECT COALESCE(workspace_uuid, '') AS workspace_uuid, to_char(COALESCE(completion_date, updated), 'YYYY-MM') AS month,
+			price AS pending, 0 AS in_flight, 0 AS settled
+			FROM bounty
+			WHERE assignee = ? AND completed = true AND paid = false
+		) AS earnings
+		GROUP BY workspace_uuid, month
+		ORDER BY month ASC, workspace_uuid ASC`,
+		PaymentMethodOnchain, PaymentMethodOnchain, pubkey, Payment, pubkey).Scan(&rows)
+	return rows
+}
+
+// SummarizeEarnings totals earnings rows overall, per workspace and per month,
+// workspaces keep the order they first appear in and months are sorted
+func SummarizeEarnings(rows []EarningsRow) PersonEarnings {
+	earnings := PersonEarnings{
+		Workspaces: []WorkspaceEarnings{},
+		Months:     []MonthlyEarnings{},
+	}
+
+	workspaces := map[string]int{}
+	months := map[string]int{}
+	for _, row := range rows {
+		earnings.Total.add(row.EarningsAmounts)
+
+		i, ok := workspaces[row.WorkspaceUuid]
+		if !ok {
+			i = len(earnings.Workspaces)
+			workspaces[row.WorkspaceUuid] = i
+			earnings.Workspaces = append(earnings.Workspaces, WorkspaceEarnings{WorkspaceUuid: row.WorkspaceUuid})
+		}
+		earnings.Workspaces[i].add(row.EarningsAmounts)
+
+		j, ok := months[row.Month]
+		if !ok {
+			j = len(earnings.Months)
+			months[row.Month] = j
+			earnings.Months = append(earnings.Months, MonthlyEarnings{Month: row.Month})
+		}
+		earnings.Months[j].add(row.EarningsAmounts)
+	}
+
+	sort.SliceStable(earnings.Months, func(a, b int) bool {
+		return earnings.Months[a].Month < earnings.Months[b].Month
+	})
+	return earnings
+}
+
+func (e *EarningsAmounts) add(amounts EarningsAmounts) {
+	e.Pending += amounts.Pending
+	e.InFlight += amounts.InFlight
+	e.Settled += amounts.Settled
+}
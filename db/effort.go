@@ -0,0 +1,132 @@
+package db
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+func (db database) CreateBountyHoursLog(log BountyHoursLog) (BountyHoursLog, error) {
+	now := time.Now()
+	log.Created = &now
+
+	if err := db.db.Create(&log).Error; err != nil {
+		return log, err
+	}
+	return log, nil
+}
+
+func (db database) GetBountyHoursLogs(bountyId uint) []BountyHoursLog {
+	logs := []BountyHoursLog{}
+	db.db.Model(&BountyHoursLog{}).Where("bounty_id = ?", bountyId).Order("created ASC").Find(&logs)
+	return logs
+}
+
+// GetWorkspaceBountyEfforts returns the estimated and logged hours of the
+// completed or paid bounties of a workspace that have both
+func (db database) GetWorkspaceBountyEfforts(workspaceUuid string) []BountyEffortRow {
+	rows := []BountyEffortRow{}
+	db.db.Raw(`SELECT bounty.id AS bounty_id, bounty.assignee, bounty.coding_languages, bounty.estimated_hours,
+		SUM(bounty_hours_logs.hours) AS actual_hours
+		FROM bounty
+		JOIN bounty_hours_logs ON bounty_hours_logs.bounty_id = bounty.id
+		WHERE bounty.workspace_uuid = ?
+		AND bounty.estimated_hours > 0
+		AND (bounty.completed = true OR bounty.paid = true)
+		GROUP BY bounty.id
+		ORDER BY bounty.id`, workspaceUuid).Scan(&rows)
+	return rows
+}
+
+// TotalLoggedHours sums the hours of a bounty's logs
+func TotalLoggedHours(logs []BountyHoursLog) float64 {
+	total := 0.0
+	for _, log := range logs {
+		total += log.Hours
+	}
+	return total
+}
+
+type estimationTotals struct {
+	bounties  int
+	estimated float64
+	actual    float64
+	errorSum  float64
+}
+
+func (t *estimationTotals) add(row BountyEffortRow) {
+	t.bounties++
+	t.estimated += row.EstimatedHours
+	t.actual += row.ActualHours
+	t.errorSum += math.Abs(row.ActualHours-row.EstimatedHours) / row.EstimatedHours
+}
+
+func (t estimationTotals) accuracy(key string) EstimationAccuracy {
+	accuracy := EstimationAccuracy{
+		Key:            key,
+		Bounties:       t.bounties,
+		EstimatedHours: t.estimated,
+		ActualHours:    t.actual,
+	}
+	if t.estimated > 0 {
+		accuracy.Ratio = t.actual / t.estimated
+	}
+	if t.bounties > 0 {
+		accuracy.AverageError = t.errorSum / float64(t.bounties)
+	}
+	return accuracy
+}
+
+func sortedAccuracies(groups map[string]*estimationTotals) []EstimationAccuracy {
+	accuracies := []EstimationAccuracy{}
+	for key, totals := range groups {
+		accuracies = append(accuracies, totals.accuracy(key))
+	}
+	// most bounties first, they are the groups the numbers say the most about
+	sort.Slice(accuracies, func(i, j int) bool {
+		if accuracies[i].Bounties != accuracies[j].Bounties {
+			return accuracies[i].Bounties > accuracies[j].Bounties
+		}
+		return accuracies[i].Key < accuracies[j].Key
+	})
+	return accuracies
+}
+
+// BuildEstimationAccuracyReport groups bounty efforts by assignee and by coding language,
+// a bounty with several languages counts towards each of them
+func BuildEstimationAccuracyReport(workspaceUuid string, rows []BountyEffortRow) EstimationAccuracyReport {
+	overall := estimationTotals{}
+	byPerson := map[string]*estimationTotals{}
+	byLanguage := map[string]*estimationTotals{}
+
+	for _, row := range rows {
+		if row.EstimatedHours <= 0 {
+			continue
+		}
+		overall.add(row)
+
+		if row.Assignee != "" {
+			if byPerson[row.Assignee] == nil {
+				byPerson[row.Assignee] = &estimationTotals{}
+			}
+			byPerson[row.Assignee].add(row)
+		}
+
+		for _, language := range row.CodingLanguages {
+			if language == "" {
+				continue
+			}
+			if byLanguage[language] == nil {
+				byLanguage[language] = &estimationTotals{}
+			}
+			byLanguage[language].add(row)
+		}
+	}
+
+	return EstimationAccuracyReport{
+		WorkspaceUuid: workspaceUuid,
+		Overall:       overall.accuracy("overall"),
+		ByPerson:      sortedAccuracies(byPerson),
+		ByLanguage:    sortedAccuracies(byLanguage),
+	}
+}
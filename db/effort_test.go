@@ -0,0 +1,54 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildEstimationAccuracyReport(t *testing.T) {
+	rows := []BountyEffortRow{
+		{BountyID: 1, Assignee: "hunter_1", CodingLanguages: pq.StringArray{"Go"}, EstimatedHours: 4, ActualHours: 6},
+		{BountyID: 2, Assignee: "hunter_1", CodingLanguages: pq.StringArray{"Go", "Typescript"}, EstimatedHours: 10, ActualHours: 8},
+		{BountyID: 3, Assignee: "hunter_2", CodingLanguages: pq.StringArray{"Typescript"}, EstimatedHours: 2, ActualHours: 2},
+		{BountyID: 4, Assignee: "hunter_2", EstimatedHours: 0, ActualHours: 5},
+	}
+
+	report := BuildEstimationAccuracyReport("workspace_uuid", rows)
+
+	t.Run("should skip bounties without an estimate", func(t *testing.T) {
+		assert.Equal(t, 3, report.Overall.Bounties)
+		assert.Equal(t, 16.0, report.Overall.EstimatedHours)
+		assert.Equal(t, 16.0, report.Overall.ActualHours)
+		assert.Equal(t, 1.0, report.Overall.Ratio)
+	})
+
+	t.Run("should group by person", func(t *testing.T) {
+		assert.Len(t, report.ByPerson, 2)
+		hunter1 := report.ByPerson[0]
+		assert.Equal(t, "hunter_1", hunter1.Key)
+		assert.Equal(t, 2, hunter1.Bounties)
+		assert.InDelta(t, 14.0/14.0, hunter1.Ratio, 0.0001)
+		assert.InDelta(t, (0.5+0.2)/2, hunter1.AverageError, 0.0001)
+
+		hunter2 := report.ByPerson[1]
+		assert.Equal(t, "hunter_2", hunter2.Key)
+		assert.Equal(t, 0.0, hunter2.AverageError)
+	})
+
+	t.Run("should count a bounty for each of its languages", func(t *testing.T) {
+		assert.Len(t, report.ByLanguage, 2)
+		assert.Equal(t, "Go", report.ByLanguage[0].Key)
+		assert.Equal(t, 2, report.ByLanguage[0].Bounties)
+		assert.Equal(t, "Typescript", report.ByLanguage[1].Key)
+		assert.Equal(t, 12.0, report.ByLanguage[1].EstimatedHours)
+	})
+
+	t.Run("should return empty groups without bounties", func(t *testing.T) {
+		empty := BuildEstimationAccuracyReport("workspace_uuid", nil)
+		assert.Equal(t, 0, empty.Overall.Bounties)
+		assert.Empty(t, empty.ByPerson)
+		assert.NotNil(t, empty.ByLanguage)
+	})
+}
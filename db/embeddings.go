@@ -0,0 +1,57 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MigrateEmbeddings creates the embeddings table, it needs the pgvector extension
+// and is skipped on databases without it
+func (db database) MigrateEmbeddings() {
+	if err := db.db.Exec("CREATE EXTENSION IF NOT EXISTS vector").Error; err != nil {
+		fmt.Println("[embeddings] could not create vector extension", err)
+		return
+	}
+	if err := db.db.AutoMigrate(&WorkspaceEmbedding{}); err != nil {
+		fmt.Println("[embeddings] could not migrate embeddings", err)
+	}
+}
+
+// SaveWorkspaceEmbeddings replaces the chunks of a source with chunks
+func (db database) SaveWorkspaceEmbeddings(workspaceUuid string, sourceType EmbeddingSourceType, sourceId string, chunks []WorkspaceEmbedding) error {
+	return db.inTransaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_uuid = ? AND source_type = ? AND source_id = ?", workspaceUuid, sourceType, sourceId).
+			Delete(&WorkspaceEmbedding{}).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for i := range chunks {
+			chunks[i].ID = 0
+			chunks[i].WorkspaceUuid = workspaceUuid
+			chunks[i].SourceType = sourceType
+			chunks[i].SourceId = sourceId
+			chunks[i].Position = i
+			chunks[i].Created = &now
+		}
+		if len(chunks) == 0 {
+			return nil
+		}
+		return tx.Create(&chunks).Error
+	})
+}
+
+// SearchWorkspaceEmbeddings returns the topK chunks of a workspace closest to
+// embedding by cosine distance
+func (db database) SearchWorkspaceEmbeddings(workspaceUuid string, embedding Vector, topK int) []ContextSearchResult {
+	results := []ContextSearchResult{}
+	db.db.Raw(`SELECT id, workspace_uuid, source_type, source_id, position, content, created,
+		1 - (embedding <=> ?) AS score
+		FROM workspace_embeddings
+		WHERE workspace_uuid = ?
+		ORDER BY embedding <=> ?
+		LIMIT ?`, embedding, workspaceUuid, embedding, topK).Scan(&results)
+	return results
+}
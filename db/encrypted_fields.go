@@ -0,0 +1,159 @@
+package db
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// encryptedPrefix marks a value written by EncryptedString, values without
+// it were stored before encryption was turned on and are read as they are
+const encryptedPrefix = "enc:v1:"
+
+var (
+	fieldsCipherMu sync.RWMutex
+	fieldsCipher   cipher.AEAD
+)
+
+// InitFieldEncryption loads PERSON_FIELDS_KEY, a base64 AES-256 key, from the
+// secrets provider. Without it the sensitive fields are stored in plain text
+func InitFieldEncryption() {
+	key := config.GetSecret("PERSON_FIELDS_KEY")
+	if key == "" {
+		fmt.Println("PERSON_FIELDS_KEY not set, person fields are stored unencrypted")
+		return
+	}
+
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		panic("PERSON_FIELDS_KEY is not base64")
+	}
+	if err := SetFieldEncryptionKey(rawKey); err != nil {
+		panic(err)
+	}
+}
+
+// SetFieldEncryptionKey sets the AES-256 key of the encrypted fields, nil turns encryption off
+func SetFieldEncryptionKey(key []byte) error {
+	var aead cipher.AEAD
+	if key != nil {
+		if len(key) != 32 {
+			return errors.New("field encryption key has to be 32 bytes")
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return err
+		}
+		aead, err = cipher.NewGCM(block)
+		if err != nil {
+			return err
+		}
+	}
+
+	fieldsCipherMu.Lock()
+	defer fieldsCipherMu.Unlock()
+	fieldsCipher = aead
+	return nil
+}
+
+func getFieldsCipher() cipher.AEAD {
+	fieldsCipherMu.RLock()
+	defer fieldsCipherMu.RUnlock()
+	return fieldsCipher
+}
+
+// EncryptedString is a text column that is encrypted with AES-GCM when it
+// is written and decrypted when it is read, the rest of the code sees plain text
+type EncryptedString string
+
+func (s EncryptedString) String() string {
+	return string(s)
+}
+
+func (EncryptedString) GormDataType() string {
+	return "text"
+}
+
+func (s EncryptedString) Value() (driver.Value, error) {
+	aead := getFieldsCipher()
+	if aead == nil || s == "" {
+		return string(s), nil
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(s), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (s *EncryptedString) Scan(value interface{}) error {
+	var stored string
+	switch v := value.(type) {
+	case nil:
+		stored = ""
+	case string:
+		stored = v
+	case []byte:
+		stored = string(v)
+	default:
+		return fmt.Errorf("can't scan %T into EncryptedString", value)
+	}
+
+	if !strings.HasPrefix(stored, encryptedPrefix) {
+		*s = EncryptedString(stored)
+		return nil
+	}
+
+	aead := getFieldsCipher()
+	if aead == nil {
+		return errors.New("encrypted field read without PERSON_FIELDS_KEY")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, encryptedPrefix))
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return errors.New("encrypted field is malformed")
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return fmt.Errorf("could not decrypt field: %w", err)
+	}
+	*s = EncryptedString(plain)
+	return nil
+}
+
+// EncryptPersonFields rewrites the route hints and contact keys that are
+// still in plain text with the current key, it returns how many people were updated
+func (db database) EncryptPersonFields() (int, error) {
+	if getFieldsCipher() == nil {
+		return 0, errors.New("PERSON_FIELDS_KEY is not set")
+	}
+
+	people := []Person{}
+	err := db.db.Select("id", "owner_route_hint", "owner_contact_key").
+		Where("(owner_route_hint <> '' AND owner_route_hint NOT LIKE ?) OR (owner_contact_key <> '' AND owner_contact_key NOT LIKE ?)",
+			encryptedPrefix+"%", encryptedPrefix+"%").
+		Find(&people).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range people {
+		err := db.db.Model(&Person{}).Where("id = ?", p.ID).Updates(map[string]interface{}{
+			"owner_route_hint":  p.OwnerRouteHint,
+			"owner_contact_key": p.OwnerContactKey,
+		}).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(people), nil
+}
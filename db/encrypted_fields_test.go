@@ -0,0 +1,74 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptedString(t *testing.T) {
+	defer SetFieldEncryptionKey(nil)
+
+	t.Run("should store plain text without a key", func(t *testing.T) {
+		value, err := EncryptedString("route-hint").Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "route-hint", value)
+	})
+
+	t.Run("should reject keys that are not 32 bytes", func(t *testing.T) {
+		assert.Error(t, SetFieldEncryptionKey([]byte("short")))
+	})
+
+	key := bytes.Repeat([]byte{7}, 32)
+	assert.NoError(t, SetFieldEncryptionKey(key))
+
+	t.Run("should encrypt on write and decrypt on read", func(t *testing.T) {
+		value, err := EncryptedString("03abc:1099:2").Value()
+		assert.NoError(t, err)
+		stored := value.(string)
+		assert.True(t, strings.HasPrefix(stored, encryptedPrefix))
+		assert.NotContains(t, stored, "03abc")
+
+		again, _ := EncryptedString("03abc:1099:2").Value()
+		assert.NotEqual(t, stored, again, "every write should use a new nonce")
+
+		var read EncryptedString
+		assert.NoError(t, read.Scan([]byte(stored)))
+		assert.Equal(t, EncryptedString("03abc:1099:2"), read)
+	})
+
+	t.Run("should keep empty values empty", func(t *testing.T) {
+		value, err := EncryptedString("").Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "", value)
+
+		var read EncryptedString
+		assert.NoError(t, read.Scan(nil))
+		assert.Equal(t, EncryptedString(""), read)
+	})
+
+	t.Run("should read values stored before encryption as they are", func(t *testing.T) {
+		var read EncryptedString
+		assert.NoError(t, read.Scan("legacy-contact-key"))
+		assert.Equal(t, EncryptedString("legacy-contact-key"), read)
+	})
+
+	t.Run("should fail on values encrypted with another key", func(t *testing.T) {
+		value, _ := EncryptedString("secret").Value()
+		assert.NoError(t, SetFieldEncryptionKey(bytes.Repeat([]byte{8}, 32)))
+		defer SetFieldEncryptionKey(key)
+
+		var read EncryptedString
+		assert.Error(t, read.Scan(value))
+	})
+
+	t.Run("should stay plain text in json", func(t *testing.T) {
+		body, err := json.Marshal(Person{OwnerRouteHint: "hint", OwnerContactKey: "contact"})
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), `"owner_route_hint":"hint"`)
+		assert.Contains(t, string(body), `"owner_contact_key":"contact"`)
+	})
+}
@@ -0,0 +1,178 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrFeatureCallNotFound = errors.New("feature call not found")
+
+// CreateFeatureCall adds a call to a feature, the calls it already has are kept
+func (db database) CreateFeatureCall(call FeatureCall) (FeatureCall, error) {
+	now := time.Now()
+	call.ID = 0
+	call.Uuid = xid.New().String()
+	call.Created = &now
+	call.Updated = &now
+
+	if err := db.db.Create(&call).Error; err != nil {
+		return FeatureCall{}, err
+	}
+	return call, nil
+}
+
+// UpdateFeatureCall saves the schedule, recording and end of a call
+func (db database) UpdateFeatureCall(call FeatureCall) (FeatureCall, error) {
+	now := time.Now()
+	call.Updated = &now
+
+	result := db.db.Model(&FeatureCall{}).
+		Where("feature_uuid = ? AND uuid = ?", call.FeatureUuid, call.Uuid).
+		Updates(map[string]interface{}{
+			"title":            call.Title,
+			"provider":         call.Provider,
+			"url":              call.Url,
+			"scheduled_at":     call.ScheduledAt,
+			"duration_minutes": call.DurationMinutes,
+			"recurrence":       call.Recurrence,
+			"recording_url":    call.RecordingUrl,
+			"ended_at":         call.EndedAt,
+			"updated_by":       call.UpdatedBy,
+			"updated":          call.Updated,
+		})
+	if result.Error != nil {
+		return FeatureCall{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return FeatureCall{}, ErrFeatureCallNotFound
+	}
+	return db.GetFeatureCallByUuid(call.FeatureUuid, call.Uuid)
+}
+
+func (db database) GetFeatureCallByUuid(featureUuid, uuid string) (FeatureCall, error) {
+	call := FeatureCall{}
+	result := db.db.Model(&FeatureCall{}).Where("feature_uuid = ? AND uuid = ?", featureUuid, uuid).Limit(1).Find(&call)
+	if result.Error != nil {
+		return call, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return call, ErrFeatureCallNotFound
+	}
+	return call, nil
+}
+
+// GetFeatureCalls returns the calls of a feature that have not ended, the next one first
+func (db database) GetFeatureCalls(featureUuid string) []FeatureCall {
+	calls := []FeatureCall{}
+	db.db.Model(&FeatureCall{}).
+		Where("feature_uuid = ? AND ended_at IS NULL", featureUuid).
+		Order("scheduled_at ASC NULLS LAST, created DESC").
+		Find(&calls)
+	return calls
+}
+
+// GetFeatureCallHistory returns the ended calls of a feature, the latest first
+func (db database) GetFeatureCallHistory(featureUuid string) []FeatureCall {
+	calls := []FeatureCall{}
+	db.db.Model(&FeatureCall{}).
+		Where("feature_uuid = ? AND ended_at IS NOT NULL", featureUuid).
+		Order("ended_at DESC").
+		Find(&calls)
+	return calls
+}
+
+var ErrFeatureCallTranscriptNotFound = errors.New("feature call transcript not found")
+
+// maxTranscriptSearchResults is the most chunks a transcript search returns
+const maxTranscriptSearchResults = 50
+
+// SaveFeatureCallTranscript stores the transcript of a call with its chunks,
+// a transcript uploaded again for the call replaces the earlier one and its chunks
+func (db database) SaveFeatureCallTranscript(transcript FeatureCallTranscript, chunks []FeatureCallTranscriptChunk) (FeatureCallTranscript, error) {
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		existing := FeatureCallTranscript{}
+		found := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("call_uuid = ?", transcript.CallUuid).Limit(1).Find(&existing)
+		if found.Error != nil {
+			return found.Error
+		}
+
+		if found.RowsAffected > 0 {
+			if err := tx.Where("transcript_uuid = ?", existing.Uuid).Delete(&FeatureCallTranscriptChunk{}).Error; err != nil {
+				return err
+			}
+			transcript.ID = existing.ID
+			transcript.Uuid = existing.Uuid
+			transcript.Created = existing.Created
+		} else {
+			transcript.ID = 0
+			transcript.Uuid = xid.New().String()
+			transcript.Created = &now
+		}
+		transcript.ChunkCount = len(chunks)
+		transcript.Updated = &now
+
+		if err := tx.Save(&transcript).Error; err != nil {
+			return err
+		}
+
+		for i := range chunks {
+			chunks[i].ID = 0
+			chunks[i].TranscriptUuid = transcript.Uuid
+			chunks[i].CallUuid = transcript.CallUuid
+			chunks[i].FeatureUuid = transcript.FeatureUuid
+			chunks[i].Position = i
+		}
+		if len(chunks) > 0 {
+			if err := tx.Create(&chunks).Error; err != nil {
+				return err
+			}
+		}
+		transcript.Chunks = chunks
+		return nil
+	})
+	if err != nil {
+		return FeatureCallTranscript{}, err
+	}
+	return transcript, nil
+}
+
+func (db database) GetFeatureCallTranscript(callUuid string) (FeatureCallTranscript, error) {
+	transcript := FeatureCallTranscript{}
+	result := db.db.Model(&FeatureCallTranscript{}).Where("call_uuid = ?", callUuid).Limit(1).Find(&transcript)
+	if result.Error != nil {
+		return transcript, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return transcript, ErrFeatureCallTranscriptNotFound
+	}
+
+	transcript.Chunks = []FeatureCallTranscriptChunk{}
+	db.db.Model(&FeatureCallTranscriptChunk{}).Where("transcript_uuid = ?", transcript.Uuid).Order("position ASC").Find(&transcript.Chunks)
+	return transcript, nil
+}
+
+// GetFeatureTranscripts returns the transcripts of the calls of a feature, oldest first
+func (db database) GetFeatureTranscripts(featureUuid string) []FeatureCallTranscript {
+	transcripts := []FeatureCallTranscript{}
+	db.db.Model(&FeatureCallTranscript{}).Where("feature_uuid = ?", featureUuid).Order("created ASC").Find(&transcripts)
+	return transcripts
+}
+
+// SearchFeatureTranscriptChunks finds the transcript chunks of a feature containing the query
+func (db database) SearchFeatureTranscriptChunks(featureUuid string, query string) []FeatureCallTranscriptChunk {
+	chunks := []FeatureCallTranscriptChunk{}
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(strings.ToLower(query)) + "%"
+	db.db.Model(&FeatureCallTranscriptChunk{}).
+		Where("feature_uuid = ? AND LOWER(content) LIKE ?", featureUuid, pattern).
+		Order("id ASC").
+		Limit(maxTranscriptSearchResults).
+		Find(&chunks)
+	return chunks
+}
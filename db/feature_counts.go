@@ -0,0 +1,107 @@
+package db
+
+import (
+	"fmt"
+)
+
+// featureBountyCountColumns counts bounties by the states of
+// GetFeaturePhasesBountiesCount
+const featureBountyCountColumns = `COUNT(*) FILTER (WHERE bounty.assignee = '') AS open,
+	COUNT(*) FILTER (WHERE bounty.assignee != '' AND bounty.paid != true AND bounty.completed != true) AS assigned,
+	COUNT(*) FILTER (WHERE bounty.completed = true) AS completed`
+
+// featureBountyCountMigrations keep the bounty counts of features in the
+// database. Every change to a bounty recounts the feature of its phase, and
+// of its previous phase when it moved, so the counts don't depend on the code
+// paths that write bounties
+var featureBountyCountMigrations = []string{
+	`CREATE OR REPLACE FUNCTION refresh_feature_bounty_counts(feature text) RETURNS void AS $$
+	UPDATE workspace_features SET (bounties_count_open, bounties_count_assigned, bounties_count_completed) = (
+		SELECT ` + featureBountyCountColumns + `
+		FROM bounty JOIN feature_phases ON feature_phases.uuid = bounty.phase_uuid
+		WHERE feature_phases.feature_uuid = feature)
+	WHERE uuid = feature;
+	$$ LANGUAGE sql`,
+
+	`CREATE OR REPLACE FUNCTION bounty_feature_counts() RETURNS trigger AS $$
+	BEGIN
+		IF TG_OP = 'INSERT' THEN
+			PERFORM refresh_feature_bounty_counts(feature_uuid) FROM feature_phases WHERE uuid = NEW.phase_uuid;
+		ELSIF TG_OP = 'DELETE' THEN
+			PERFORM refresh_feature_bounty_counts(feature_uuid) FROM feature_phases WHERE uuid = OLD.phase_uuid;
+		ELSE
+			PERFORM refresh_feature_bounty_counts(feature_uuid) FROM feature_phases WHERE uuid = OLD.phase_uuid;
+			IF NEW.phase_uuid IS DISTINCT FROM OLD.phase_uuid THEN
+				PERFORM refresh_feature_bounty_counts(feature_uuid) FROM feature_phases WHERE uuid = NEW.phase_uuid;
+			END IF;
+		END IF;
+		RETURN NULL;
+	END
+	$$ LANGUAGE plpgsql`,
+
+	`DROP TRIGGER IF EXISTS bounty_feature_counts ON bounty`,
+
+	`CREATE TRIGGER bounty_feature_counts
+	AFTER INSERT OR DELETE OR UPDATE OF phase_uuid, assignee, completed, paid ON bounty
+	FOR EACH ROW EXECUTE PROCEDURE bounty_feature_counts()`,
+
+	`CREATE OR REPLACE FUNCTION phase_feature_counts() RETURNS trigger AS $$
+	BEGIN
+		PERFORM refresh_feature_bounty_counts(OLD.feature_uuid);
+		IF TG_OP = 'UPDATE' AND NEW.feature_uuid IS DISTINCT FROM OLD.feature_uuid THEN
+			PERFORM refresh_feature_bounty_counts(NEW.feature_uuid);
+		END IF;
+		RETURN NULL;
+	END
+	$$ LANGUAGE plpgsql`,
+
+	`DROP TRIGGER IF EXISTS phase_feature_counts ON feature_phases`,
+
+	`CREATE TRIGGER phase_feature_counts
+	AFTER DELETE OR UPDATE OF feature_uuid ON feature_phases
+	FOR EACH ROW EXECUTE PROCEDURE phase_feature_counts()`,
+}
+
+// MigrateFeatureBountyCounts installs the triggers that keep the bounty
+// counts of features and recounts the features that drifted before them
+func (db database) MigrateFeatureBountyCounts() {
+	for _, migration := range featureBountyCountMigrations {
+		if err := db.db.Exec(migration).Error; err != nil {
+			fmt.Println("[feature counts] could not install the count triggers", err)
+			return
+		}
+	}
+
+	for _, drift := range db.CheckFeatureBountyCounts() {
+		if err := db.SyncFeatureBountyCounts(drift.FeatureUuid); err != nil {
+			fmt.Println("[feature counts] could not recount feature", drift.FeatureUuid, err)
+		}
+	}
+}
+
+// CheckFeatureBountyCounts returns the features whose stored bounty counts
+// don't match the bounties of their phases
+func (db database) CheckFeatureBountyCounts() []FeatureCountDrift {
+	drifts := []FeatureCountDrift{}
+	db.db.Raw(`SELECT feature.uuid AS feature_uuid, feature.workspace_uuid,
+		feature.bounties_count_open AS stored_open,
+		feature.bounties_count_assigned AS stored_assigned,
+		feature.bounties_count_completed AS stored_completed,
+		COALESCE(counts.open, 0) AS actual_open,
+		COALESCE(counts.assigned, 0) AS actual_assigned,
+		COALESCE(counts.completed, 0) AS actual_completed
+		FROM workspace_features AS feature
+		LEFT JOIN (SELECT feature_phases.feature_uuid, ` + featureBountyCountColumns + `
+			FROM bounty JOIN feature_phases ON feature_phases.uuid = bounty.phase_uuid
+			GROUP BY feature_phases.feature_uuid) counts ON counts.feature_uuid = feature.uuid
+		WHERE feature.bounties_count_open <> COALESCE(counts.open, 0)
+		OR feature.bounties_count_assigned <> COALESCE(counts.assigned, 0)
+		OR feature.bounties_count_completed <> COALESCE(counts.completed, 0)
+		ORDER BY feature.id ASC`).Scan(&drifts)
+	return drifts
+}
+
+// SyncFeatureBountyCounts recounts the bounties of a feature
+func (db database) SyncFeatureBountyCounts(featureUuid string) error {
+	return db.db.Exec("SELECT refresh_feature_bounty_counts(?)", featureUuid).Error
+}
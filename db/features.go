@@ -8,8 +8,12 @@ import (
 	"time"
 
 	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var ErrFeatureNotFound = errors.New("feature not found")
+
 func (db database) GetFeaturesByWorkspaceUuid(uuid string, r *http.Request) []WorkspaceFeatures {
 	offset, limit, sortBy, direction, _ := utils.GetPaginationParams(r)
 
@@ -19,7 +23,7 @@ func (db database) GetFeaturesByWorkspaceUuid(uuid string, r *http.Request) []Wo
 	ms := []WorkspaceFeatures{}
 
 	if sortBy != "" && direction != "" {
-		orderQuery = "ORDER BY " + sortBy + " " + direction
+		orderQuery = "ORDER BY " + FeatureSortFields.OrderBy(sortBy, direction, "priority")
 	} else {
 		orderQuery = "ORDER BY priority ASC"
 	}
@@ -74,6 +78,24 @@ func (db database) CreateOrEditFeature(m WorkspaceFeatures) (WorkspaceFeatures,
 	return m, nil
 }
 
+func (db database) UpdateFeatureBrief(uuid string, brief string, sections BriefSections, updatedBy string) (WorkspaceFeatures, error) {
+	feature := WorkspaceFeatures{}
+	now := time.Now()
+
+	result := db.db.Model(&WorkspaceFeatures{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"brief":          strings.TrimSpace(brief),
+		"brief_sections": sections,
+		"updated":        &now,
+		"updated_by":     updatedBy,
+	})
+	if result.RowsAffected == 0 {
+		return feature, errors.New("no feature found")
+	}
+
+	db.db.Model(&WorkspaceFeatures{}).Where("uuid = ?", uuid).First(&feature)
+	return feature, nil
+}
+
 func (db database) DeleteFeatureByUuid(uuid string) error {
 	result := db.db.Where("uuid = ?", uuid).Delete(&WorkspaceFeatures{})
 
@@ -139,6 +161,17 @@ func (db database) CreateOrEditFeatureStory(story FeatureStory) (FeatureStory, e
 	existingStory := FeatureStory{}
 	result := db.db.Model(&FeatureStory{}).Where("uuid = ?", story.Uuid).First(&existingStory)
 
+	if result.RowsAffected == 0 && story.DeliveryId != nil {
+		// the delivery id is unique in the feature, that turns a replay racing
+		// the first delivery into a no-op, both answer the story the first one created
+		story.Created = &now
+		err := db.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&story).Error
+		if err != nil {
+			return FeatureStory{}, err
+		}
+		return db.GetFeatureStoryByDeliveryId(story.FeatureUuid, *story.DeliveryId)
+	}
+
 	if result.RowsAffected == 0 {
 		story.Created = &now
 		db.db.Create(&story)
@@ -151,6 +184,27 @@ func (db database) CreateOrEditFeatureStory(story FeatureStory) (FeatureStory, e
 	return story, nil
 }
 
+// GetFeatureStoryByDeliveryId finds the story a webhook delivery created in a feature
+func (db database) GetFeatureStoryByDeliveryId(featureUuid string, deliveryId string) (FeatureStory, error) {
+	story := FeatureStory{}
+	result := db.db.Model(&FeatureStory{}).Where("feature_uuid = ? AND delivery_id = ?", featureUuid, deliveryId).First(&story)
+	if result.RowsAffected == 0 {
+		return story, errors.New("no story found")
+	}
+	story.Description = strings.TrimSpace(story.Description)
+	return story, nil
+}
+
+// MigrateFeatureStoryDeliveryIndex drops the index that kept delivery ids
+// unique across features, they are only unique in their feature now
+func (db database) MigrateFeatureStoryDeliveryIndex() {
+	if db.db.Migrator().HasIndex(&FeatureStory{}, "idx_feature_stories_delivery_id") {
+		if err := db.db.Migrator().DropIndex(&FeatureStory{}, "idx_feature_stories_delivery_id"); err != nil {
+			fmt.Println("[features] could not drop the delivery id index", err)
+		}
+	}
+}
+
 func (db database) GetFeatureStoriesByFeatureUuid(featureUuid string) ([]FeatureStory, error) {
 	var stories []FeatureStory
 	result := db.db.Where("feature_uuid = ?", featureUuid).Order("priority ASC").Find(&stories)
@@ -198,7 +252,8 @@ func (db database) GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUui
 	query := db.db.Model(&Bounty{}).
 		Select("bounty.*").
 		Joins(`INNER JOIN "feature_phases" ON "feature_phases"."uuid" = "bounty"."phase_uuid"`).
-		Where(`"feature_phases"."feature_uuid" = ? AND "feature_phases"."uuid" = ?`, featureUuid, phaseUuid)
+		Where(`"feature_phases"."feature_uuid" = ? AND "feature_phases"."uuid" = ?`, featureUuid, phaseUuid).
+		Where(bountyVisibilityQuery(bountyViewer(r)))
 
 	// Add pagination if applicable
 	if limit > 1 {
@@ -206,11 +261,7 @@ func (db database) GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUui
 	}
 
 	// Add sorting if applicable
-	if sortBy != "" && direction != "" {
-		query = query.Order(fmt.Sprintf("%s %s", sortBy, direction))
-	} else {
-		query = query.Order("created_at DESC")
-	}
+	query = query.Order("bounty." + BountySortFields.OrderBy(sortBy, direction, "created"))
 
 	// Add search filter
 	if search != "" {
@@ -284,7 +335,8 @@ func (db database) GetBountiesCountByFeatureAndPhaseUuid(featureUuid string, pha
 	query := db.db.Model(&Bounty{}).
 		Select("COUNT(*)").
 		Joins(`INNER JOIN "feature_phases" ON "feature_phases"."uuid" = "bounty"."phase_uuid"`).
-		Where(`"feature_phases"."feature_uuid" = ? AND "feature_phases"."uuid" = ?`, featureUuid, phaseUuid)
+		Where(`"feature_phases"."feature_uuid" = ? AND "feature_phases"."uuid" = ?`, featureUuid, phaseUuid).
+		Where(bountyVisibilityQuery(bountyViewer(r)))
 
 	// Add status filters
 	var statusConditions []string
@@ -327,3 +379,47 @@ func (db database) GetBountiesByPhaseUuid(phaseUuid string) []Bounty {
 	db.db.Model(&Bounty{}).Where("phase_uuid = ?", phaseUuid).Find(&bounties)
 	return bounties
 }
+
+// phaseBountyCounts counts the bounties of the phases of a feature by state,
+// the states are the ones of GetFeaturePhasesBountiesCount
+const phaseBountyCounts = `LEFT JOIN (SELECT phase_uuid,
+	COUNT(*) FILTER (WHERE assignee = '') AS open,
+	COUNT(*) FILTER (WHERE assignee != '' AND paid != true AND completed != true) AS assigned,
+	COUNT(*) FILTER (WHERE completed = true) AS completed,
+	COUNT(*) FILTER (WHERE paid = true) AS paid
+	FROM bounty WHERE phase_uuid IN (SELECT uuid FROM feature_phases WHERE feature_uuid = ?)
+	GROUP BY phase_uuid) counts ON counts.phase_uuid = feature_phases.uuid`
+
+// GetFeatureDetail loads a feature with its phases and their bounty counts,
+// its stories and its open calls in one go
+func (db database) GetFeatureDetail(uuid string) (FeatureDetail, error) {
+	detail := FeatureDetail{}
+	result := db.db.
+		Preload("Phases", func(tx *gorm.DB) *gorm.DB {
+			return tx.Select(`feature_phases.*,
+				COALESCE(counts.open, 0) AS bounties_count_open,
+				COALESCE(counts.assigned, 0) AS bounties_count_assigned,
+				COALESCE(counts.completed, 0) AS bounties_count_completed,
+				COALESCE(counts.paid, 0) AS bounties_count_paid`).
+				Joins(phaseBountyCounts, uuid).
+				Order("feature_phases.created ASC")
+		}).
+		Preload("Stories", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("priority ASC")
+		}).
+		Preload("Calls", func(tx *gorm.DB) *gorm.DB {
+			return tx.Where("ended_at IS NULL").Order("scheduled_at ASC NULLS LAST, created DESC")
+		}).
+		Where("uuid = ?", uuid).Limit(1).Find(&detail)
+	if result.Error != nil {
+		return FeatureDetail{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return FeatureDetail{}, ErrFeatureNotFound
+	}
+
+	for i := range detail.Stories {
+		detail.Stories[i].Description = strings.TrimSpace(detail.Stories[i].Description)
+	}
+	return detail, nil
+}
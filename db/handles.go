@@ -0,0 +1,290 @@
+package db
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrHandleInvalid    = errors.New("handle must be 3 to 30 lowercase letters, numbers or underscores and start with a letter or number")
+	ErrHandleNotAllowed = errors.New("handle is not allowed")
+	ErrHandleReserved   = errors.New("handle is reserved")
+	ErrHandleTaken      = errors.New("handle is already taken")
+	ErrHandleSquatting  = errors.New("handle matches the name of another person")
+	ErrHandleCooldown   = errors.New("handle was changed too recently")
+)
+
+const (
+	// HandleChangeCooldown is how long a person waits between handle changes
+	HandleChangeCooldown = 30 * 24 * time.Hour
+	// HandleReleaseHold is how long a released handle stays reserved for its
+	// previous owner, so it cannot be sniped right after a change
+	HandleReleaseHold = 30 * 24 * time.Hour
+)
+
+var handlePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_]{2,29}$`)
+
+// reservedHandles can never be claimed, they would pass for the platform itself
+var reservedHandles = map[string]bool{
+	"admin": true, "administrator": true, "api": true, "help": true, "mod": true,
+	"moderator": true, "null": true, "official": true, "root": true, "security": true,
+	"sphinx": true, "stakwork": true, "staff": true, "support": true, "system": true,
+	"team": true, "tribes": true, "undefined": true,
+}
+
+// blockedHandleWords are rejected anywhere in a handle
+var blockedHandleWords = []string{
+	"fuck", "shit", "cunt", "bitch", "nigger", "faggot", "whore", "slut", "rape",
+}
+
+// NormalizeHandle lowercases a handle and drops the leading @
+func NormalizeHandle(handle string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+}
+
+// ValidateHandle checks the shape and words of a normalized handle,
+// the checks that need the database are done by SetPersonHandle
+func ValidateHandle(handle string) error {
+	if !handlePattern.MatchString(handle) {
+		return ErrHandleInvalid
+	}
+	if reservedHandles[handle] {
+		return ErrHandleReserved
+	}
+	compact := strings.ReplaceAll(handle, "_", "")
+	for _, word := range blockedHandleWords {
+		if strings.Contains(compact, word) {
+			return ErrHandleNotAllowed
+		}
+	}
+	return nil
+}
+
+// handleHeld reports whether a handle has an active reservation held for
+// someone other than pubkey
+func handleHeld(tx *gorm.DB, handle string, pubkey string, now time.Time) (bool, error) {
+	var count int64
+	err := tx.Model(&HandleReservation{}).
+		Where("handle = ? AND (expires IS NULL OR expires > ?) AND reserved_for <> ?", handle, now, pubkey).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// holdReleasedHandle reserves a handle its owner just gave up for them
+func holdReleasedHandle(tx *gorm.DB, handle string, pubkey string, now time.Time) error {
+	expires := now.Add(HandleReleaseHold)
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "handle"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reserved_for", "reason", "expires", "created_by", "created"}),
+	}).Create(&HandleReservation{
+		Handle:      handle,
+		ReservedFor: pubkey,
+		Reason:      "released",
+		Expires:     &expires,
+		CreatedBy:   pubkey,
+		Created:     &now,
+	}).Error
+}
+
+// SetPersonHandle claims a handle for a person, an empty handle clears it.
+// The previous handle stays reserved for the person for HandleReleaseHold
+func (db database) SetPersonHandle(pubkey string, handle string, now time.Time) (Person, error) {
+	handle = NormalizeHandle(handle)
+	if handle != "" {
+		if err := ValidateHandle(handle); err != nil {
+			return Person{}, err
+		}
+	}
+
+	person := Person{}
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("owner_pub_key = ? AND (deleted = false OR deleted IS NULL)", pubkey).
+			Limit(1).Find(&person)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		current := ""
+		if person.Handle != nil {
+			current = *person.Handle
+		}
+		if current == handle {
+			return nil
+		}
+		if person.HandleUpdated != nil && now.Sub(*person.HandleUpdated) < HandleChangeCooldown {
+			return ErrHandleCooldown
+		}
+
+		if handle != "" {
+			held, err := handleHeld(tx, handle, pubkey, now)
+			if err != nil {
+				return err
+			}
+			if held {
+				return ErrHandleReserved
+			}
+
+			var taken int64
+			if err := tx.Model(&Person{}).Where("handle = ? AND owner_pub_key <> ?", handle, pubkey).Count(&taken).Error; err != nil {
+				return err
+			}
+			if taken > 0 {
+				return ErrHandleTaken
+			}
+
+			var named int64
+			if err := tx.Model(&Person{}).
+				Where("LOWER(unique_name) = ? AND owner_pub_key <> ? AND (deleted = false OR deleted IS NULL)", handle, pubkey).
+				Count(&named).Error; err != nil {
+				return err
+			}
+			if named > 0 {
+				return ErrHandleSquatting
+			}
+		}
+
+		var value interface{}
+		person.Handle = nil
+		if handle != "" {
+			value = handle
+			person.Handle = &handle
+		}
+		person.HandleUpdated = &now
+		if err := tx.Model(&Person{}).Where("id = ?", person.ID).Updates(map[string]interface{}{
+			"handle":         value,
+			"handle_updated": &now,
+		}).Error; err != nil {
+			return err
+		}
+
+		if current != "" {
+			return holdReleasedHandle(tx, current, pubkey, now)
+		}
+		return nil
+	})
+	return person, err
+}
+
+func (db database) GetPersonByHandle(handle string) Person {
+	m := Person{}
+	db.db.Where("handle = ? AND (deleted = false OR deleted IS NULL)", NormalizeHandle(handle)).Find(&m)
+	return m
+}
+
+func (db database) GetHandleReservations() []HandleReservation {
+	ms := []HandleReservation{}
+	db.db.Model(&HandleReservation{}).Order("handle ASC").Find(&ms)
+	return ms
+}
+
+// CreateHandleReservation reserves a handle, replacing an earlier reservation of it
+func (db database) CreateHandleReservation(reservation HandleReservation) (HandleReservation, error) {
+	reservation.Handle = NormalizeHandle(reservation.Handle)
+	if !handlePattern.MatchString(reservation.Handle) {
+		return HandleReservation{}, ErrHandleInvalid
+	}
+	if reservation.Created == nil {
+		now := time.Now()
+		reservation.Created = &now
+	}
+	err := db.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "handle"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reserved_for", "reason", "expires", "created_by", "created"}),
+	}).Create(&reservation).Error
+	return reservation, err
+}
+
+func (db database) DeleteHandleReservation(handle string) error {
+	result := db.db.Where("handle = ?", NormalizeHandle(handle)).Delete(&HandleReservation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+var handleDisallowed = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// suggestHandle builds a handle from a name, padding or cutting it to fit the pattern
+func suggestHandle(name string, pubkey string) string {
+	handle := strings.Trim(handleDisallowed.ReplaceAllString(strings.ToLower(name), ""), "_")
+	if len(handle) > 22 {
+		handle = handle[:22]
+	}
+	suffix := pubkey
+	if len(suffix) > 6 {
+		suffix = suffix[:6]
+	}
+	if len(handle) < 3 {
+		return strings.ToLower("user_" + suffix)
+	}
+	return handle + "_" + strings.ToLower(suffix)
+}
+
+// GetAliasCollisions groups the people that share an alias, each with a suggested
+// handle: their handle if they have one, their unique name if it is a free
+// valid handle, or the alias with the start of their pubkey
+func (db database) GetAliasCollisions() ([]AliasCollision, error) {
+	people := []Person{}
+	err := db.db.Model(&Person{}).
+		Select("owner_pub_key, owner_alias, unique_name, handle").
+		Where("(deleted = false OR deleted IS NULL)").
+		Where(`LOWER(TRIM(owner_alias)) IN (
+			SELECT LOWER(TRIM(owner_alias)) FROM people
+			WHERE (deleted = false OR deleted IS NULL) AND TRIM(owner_alias) <> ''
+			GROUP BY LOWER(TRIM(owner_alias)) HAVING COUNT(*) > 1)`).
+		Order("LOWER(TRIM(owner_alias)) ASC, id ASC").
+		Find(&people).Error
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[string]bool{}
+	for _, p := range people {
+		if p.Handle != nil {
+			used[*p.Handle] = true
+		}
+	}
+
+	collisions := []AliasCollision{}
+	for _, p := range people {
+		alias := strings.ToLower(strings.TrimSpace(p.OwnerAlias))
+		if len(collisions) == 0 || collisions[len(collisions)-1].Alias != alias {
+			collisions = append(collisions, AliasCollision{Alias: alias})
+		}
+
+		suggested := ""
+		if p.Handle != nil {
+			suggested = *p.Handle
+		} else {
+			name := NormalizeHandle(p.UniqueName)
+			if ValidateHandle(name) == nil && !used[name] && db.GetPersonByHandle(name).ID == 0 {
+				suggested = name
+			} else {
+				suggested = suggestHandle(p.OwnerAlias, p.OwnerPubKey)
+			}
+			used[suggested] = true
+		}
+
+		group := &collisions[len(collisions)-1]
+		group.People = append(group.People, AliasCollisionPerson{
+			OwnerPubKey:     p.OwnerPubKey,
+			OwnerAlias:      p.OwnerAlias,
+			UniqueName:      p.UniqueName,
+			Handle:          p.Handle,
+			SuggestedHandle: suggested,
+		})
+	}
+	return collisions, nil
+}
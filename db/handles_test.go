@@ -0,0 +1,41 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateHandle(t *testing.T) {
+	t.Run("should normalize a handle", func(t *testing.T) {
+		assert.Equal(t, "alice_b", NormalizeHandle(" @Alice_B "))
+	})
+
+	t.Run("should accept lowercase handles of the right length", func(t *testing.T) {
+		assert.NoError(t, ValidateHandle("alice"))
+		assert.NoError(t, ValidateHandle("bob_2024"))
+		assert.NoError(t, ValidateHandle("0xdev"))
+	})
+
+	t.Run("should reject malformed handles", func(t *testing.T) {
+		for _, handle := range []string{"", "ab", "_alice", "Alice", "al ice", "al-ice", "a234567890123456789012345678901"} {
+			assert.ErrorIs(t, ValidateHandle(handle), ErrHandleInvalid, handle)
+		}
+	})
+
+	t.Run("should reject reserved handles", func(t *testing.T) {
+		assert.ErrorIs(t, ValidateHandle("admin"), ErrHandleReserved)
+		assert.ErrorIs(t, ValidateHandle("support"), ErrHandleReserved)
+	})
+
+	t.Run("should reject profanity anywhere in the handle", func(t *testing.T) {
+		assert.ErrorIs(t, ValidateHandle("shit_happens"), ErrHandleNotAllowed)
+		assert.ErrorIs(t, ValidateHandle("s_h_i_t"), ErrHandleNotAllowed)
+	})
+}
+
+func TestSuggestHandle(t *testing.T) {
+	assert.Equal(t, "alicesmith_02abcd", suggestHandle("Alice Smith!", "02abcdef"))
+	assert.Equal(t, "user_02abcd", suggestHandle("Al", "02abcdef"))
+	assert.NoError(t, ValidateHandle(suggestHandle("A very long alias that keeps on going", "02abcdef")))
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+)
+
+var ErrChatNotFound = errors.New("chat not found")
+
+func (db database) CreateChat(chat Chat) (Chat, error) {
+	now := time.Now()
+	chat.ID = 0
+	chat.Uuid = xid.New().String()
+	chat.Created = &now
+	chat.Updated = &now
+
+	if err := db.db.Create(&chat).Error; err != nil {
+		return Chat{}, err
+	}
+	return chat, nil
+}
+
+func (db database) GetChatByUuid(uuid string) (Chat, error) {
+	chat := Chat{}
+	result := db.db.Model(&Chat{}).Where("uuid = ?", uuid).Limit(1).Find(&chat)
+	if result.Error != nil {
+		return chat, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return chat, ErrChatNotFound
+	}
+	return chat, nil
+}
+
+// CreateChatMessage adds a message with its artifacts to its chat and bumps
+// the chat's updated time
+func (db database) CreateChatMessage(message ChatMessage) (ChatMessage, error) {
+	now := time.Now()
+	message.ID = 0
+	message.Uuid = xid.New().String()
+	message.Created = &now
+	if message.Artifacts == nil {
+		message.Artifacts = []Artifact{}
+	}
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&message).Error; err != nil {
+			return err
+		}
+		for i := range message.Artifacts {
+			message.Artifacts[i].ChatId = message.ChatId
+			message.Artifacts[i].MessageId = message.Uuid
+			message.Artifacts[i].CreatedBy = message.SenderPubkey
+			artifact, err := createArtifact(tx, message.Artifacts[i])
+			if err != nil {
+				return err
+			}
+			message.Artifacts[i] = artifact
+		}
+		return tx.Model(&Chat{}).Where("uuid = ?", message.ChatId).Update("updated", &now).Error
+	})
+	if err != nil {
+		return ChatMessage{}, err
+	}
+	return message, nil
+}
+
+// GetChatMessages returns the messages of a chat with their artifacts, oldest first
+func (db database) GetChatMessages(chatUuid string) []ChatMessage {
+	messages := []ChatMessage{}
+	db.db.Model(&ChatMessage{}).Where("chat_id = ?", chatUuid).Order("created ASC, id ASC").Find(&messages)
+
+	artifacts := []Artifact{}
+	db.db.Model(&Artifact{}).Where("chat_id = ? AND message_id <> ''", chatUuid).Order("id ASC").Find(&artifacts)
+
+	attachArtifacts(messages, artifacts)
+	return messages
+}
+
+// GetChatMessagesSince returns the messages of a chat that came after the one
+// with the given id, for clients catching up
+func (db database) GetChatMessagesSince(chatUuid string, sinceId uint) []ChatMessage {
+	messages := []ChatMessage{}
+	db.db.Model(&ChatMessage{}).Where("chat_id = ? AND id > ?", chatUuid, sinceId).Order("created ASC, id ASC").Find(&messages)
+	if len(messages) == 0 {
+		return messages
+	}
+
+	uuids := []string{}
+	for _, message := range messages {
+		uuids = append(uuids, message.Uuid)
+	}
+	artifacts := []Artifact{}
+	db.db.Model(&Artifact{}).Where("chat_id = ? AND message_id IN ?", chatUuid, uuids).Order("id ASC").Find(&artifacts)
+
+	attachArtifacts(messages, artifacts)
+	return messages
+}
+
+func attachArtifacts(messages []ChatMessage, artifacts []Artifact) {
+	byMessage := map[string][]Artifact{}
+	for _, artifact := range artifacts {
+		byMessage[artifact.MessageId] = append(byMessage[artifact.MessageId], artifact)
+	}
+	for i := range messages {
+		messages[i].Artifacts = byMessage[messages[i].Uuid]
+		if messages[i].Artifacts == nil {
+			messages[i].Artifacts = []Artifact{}
+		}
+	}
+}
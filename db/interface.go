@@ -1,8 +1,12 @@
 package db
 
 import (
+	"context"
+	"io"
 	"net/http"
 	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
 )
 
 type Database interface {
@@ -27,6 +31,7 @@ type Database interface {
 	GetChannel(id uint) Channel
 	GetListedBots(r *http.Request) []Bot
 	GetListedPeople(r *http.Request) []Person
+	StreamListedPeople(r *http.Request, each func(people []Person) error) error
 	GetPeopleBySearch(r *http.Request) []Person
 	GetListedPosts(r *http.Request) ([]PeopleExtra, error)
 	GetUserBountiesCount(personKey string, tabType string) int64
@@ -45,6 +50,7 @@ type Database interface {
 	AddBounty(b Bounty) (Bounty, error)
 	GetAllBounties(r *http.Request) []NewBounty
 	CreateOrEditBounty(b NewBounty) (NewBounty, error)
+	PatchBounty(bounty NewBounty, columns []string, conditional bool) (NewBounty, error)
 	UpdateBountyNullColumn(b NewBounty, column string) NewBounty
 	UpdateBountyBoolColumn(b NewBounty, column string) NewBounty
 	DeleteBounty(pubkey string, created string) (NewBounty, error)
@@ -52,12 +58,50 @@ type Database interface {
 	GetBounty(id uint) NewBounty
 	UpdateBounty(b NewBounty) (NewBounty, error)
 	UpdateBountyPayment(b NewBounty) (NewBounty, error)
+	CreateBountyApplication(application BountyApplication) (BountyApplication, error)
+	GetBountyApplications(bountyId uint) []BountyApplication
+	GetBountyApplicationById(id uint) (BountyApplication, error)
+	GetActiveBountyApplication(bountyId uint, pubkey string) (BountyApplication, error)
+	UpdateBountyApplicationStatus(id uint, status BountyApplicationStatus) (BountyApplication, error)
+	AcceptBountyApplication(application BountyApplication) (NewBounty, error)
+	CreateBountyAuction(auction BountyAuction) (BountyAuction, error)
+	GetBountyAuction(bountyId uint) (BountyAuction, error)
+	GetDueBountyAuctions(now time.Time) []BountyAuction
+	UpdateBountyAuctionStatus(id uint, status BountyAuctionStatus) error
+	SelectBountyBid(auctionId uint, bidId uint) error
+	PlaceBountyBid(bid BountyBid) (BountyBid, error)
+	GetBountyBids(auctionId uint) []BountyBid
+	GetBountyBidById(id uint) (BountyBid, error)
+	WithdrawBountyBid(auctionId uint, pubkey string) error
+	CloseBountyAuction(auction BountyAuction, bidId uint) (NewBounty, error)
+	GetStaleAssignedBounties(inactiveSince time.Time) []NewBounty
+	SetBountyStaleWarning(id uint) error
+	RecordBountyActivity(id uint) error
+	ResetBountyActivity(id uint) error
+	SetBountyDueDate(id uint, dueDate *time.Time) error
+	GetBountiesDueBetween(from time.Time, to time.Time) []NewBounty
+	SetBountyDueReminder(id uint, hours int) error
+	UnassignStaleBounty(bounty NewBounty, reason string) error
+	GetBountyAssigneeHistory(bountyId uint) []BountyAssigneeHistory
+	TransitionBountyState(bountyId uint, request BountyTransitionRequest, actor string) (NewBounty, BountyStateTransition, error)
+	GetBountyStateTransitions(bountyId uint) []BountyStateTransition
+	CreateBountyComment(comment BountyComment) (BountyComment, error)
+	GetBountyComments(bountyId uint) []BountyComment
+	GetBountyComment(bountyId uint, id uint) (BountyComment, error)
+	DeleteBountyComment(id uint) error
+	CreateBountyHoursLog(log BountyHoursLog) (BountyHoursLog, error)
+	GetBountyHoursLogs(bountyId uint) []BountyHoursLog
+	GetWorkspaceBountyEfforts(workspaceUuid string) []BountyEffortRow
 	GetListedOffers(r *http.Request) ([]PeopleExtra, error)
 	UpdateBot(uuid string, u map[string]interface{}) bool
 	GetAllTribes() []Tribe
 	GetTribesTotal() int64
 	GetTribeByIdAndPubkey(uuid string, pubkey string) Tribe
 	GetTribe(uuid string) Tribe
+	CreateBadgeIssuance(issuance BadgeIssuance) (BadgeIssuance, error)
+	UpdateBadgeIssuanceStatus(id uint, status BadgeIssuanceStatus, txId string, issueError string) error
+	GetBadgeIssuances(tribeUuid string) []BadgeIssuance
+	GetTribeBadgeCounts(tribeUuid string) []TribeBadge
 	GetPerson(id uint) Person
 	UpdatePerson(id uint, u map[string]interface{}) bool
 	GetPersonByUuid(uuid string) Person
@@ -83,6 +127,10 @@ type Database interface {
 	GetLnUser(lnKey string) int64
 	CreateLnUser(lnKey string) (Person, error)
 	GetBountiesLeaderboard() []LeaderData
+	RefreshLeaderboardAggregates() error
+	GetLeaderboardTotals(filter LeaderboardFilter) []BountyLeaderboard
+	RefreshBountyPriceStats() error
+	GetBountyPriceStats(workspaceUuid string) []BountyPriceStat
 	GetWorkspaces(r *http.Request) []Workspace
 	GetWorkspacesCount() int64
 	GetWorkspaceByUuid(uuid string) Workspace
@@ -107,12 +155,58 @@ type Database interface {
 	GetWorkspaceBudgetHistory(workspace_uuid string) []BudgetHistoryData
 	ProcessUpdateBudget(invoice NewInvoiceList) error
 	AddAndUpdateBudget(invoice NewInvoiceList) NewPaymentHistory
-	WithdrawBudget(sender_pubkey string, workspace_uuid string, amount uint)
+	WithdrawBudget(sender_pubkey string, reservation BudgetReservation) error
+	ReserveBudget(workspaceUuid string, amount uint) (BudgetReservation, error)
+	ReleaseBudget(reservation BudgetReservation) error
+	CreateBudgetRefund(refund BudgetRefund) (BudgetRefund, error)
+	GetBudgetRefund(id uint) (BudgetRefund, error)
+	GetBudgetRefunds(workspaceUuid string) []BudgetRefund
+	ClaimBudgetRefund(id uint, approvedBy string) error
+	UpdateBudgetRefundStatus(id uint, status BudgetRefundStatus, approvedBy string, refundError string) error
+	CompleteBudgetRefund(refund BudgetRefund) error
+	GetWorkspaceLedgerBalance(workspaceUuid string) int64
+	GetBudgetLedger(workspaceUuid string) []BudgetLedgerEntry
+	SyncWorkspaceBudget(workspaceUuid string) error
+	CheckBudgetLedgerConsistency() []BudgetLedgerInconsistency
+	SnapshotWorkspaceBudgets(day string) error
+	GetWorkspaceBudgetSnapshots(workspaceUuid string, from string, to string) []WorkspaceBudgetSnapshot
+	GetOpenBudgetReservations(before time.Time) []BudgetReservation
 	AddPaymentHistory(payment NewPaymentHistory) NewPaymentHistory
-	ProcessBountyPayment(payment NewPaymentHistory, bounty NewBounty) error
+	ProcessBountyPayment(payment NewPaymentHistory, bounty NewBounty, reservation BudgetReservation) error
+	GetBountyReceipt(bountyId uint) (BountyReceipt, error)
+	CreateNotification(n Notification) (Notification, error)
+	GetNotificationsByPubkey(pubkey string, unreadOnly bool) []Notification
+	MarkNotificationRead(pubkey string, id uint) error
 	GetPaymentHistory(workspace_uuid string, r *http.Request) []NewPaymentHistory
+	GetPersonEarnings(pubkey string) []EarningsRow
+	CreateChat(chat Chat) (Chat, error)
+	GetChatByUuid(uuid string) (Chat, error)
+	CreateChatMessage(message ChatMessage) (ChatMessage, error)
+	GetChatMessages(chatUuid string) []ChatMessage
+	GetChatMessagesSince(chatUuid string, sinceId uint) []ChatMessage
+	SetChatAgentWebhook(webhook ChatAgentWebhook) (ChatAgentWebhook, error)
+	GetChatAgentWebhook(chatId string) (ChatAgentWebhook, error)
+	DeleteChatAgentWebhook(chatId string) error
+	RecordChatAgentDelivery(uuid string, now time.Time, deliveryErr error) error
+	CreateArtifact(artifact Artifact) (Artifact, error)
+	GetArtifactByUuid(uuid string) (Artifact, error)
+	GetArtifacts(filter ArtifactFilter) []Artifact
+	UpdateArtifact(artifact Artifact, updatedBy string) (Artifact, error)
+	GetArtifactVersions(uuid string) []ArtifactVersion
+	DeleteArtifact(uuid string) error
+	SaveWorkspaceEmbeddings(workspaceUuid string, sourceType EmbeddingSourceType, sourceId string, chunks []WorkspaceEmbedding) error
+	SearchWorkspaceEmbeddings(workspaceUuid string, embedding Vector, topK int) []ContextSearchResult
+	GetUnconfirmedOnchainPayments() []NewPaymentHistory
+	UpdateOnchainConfirmations(id uint, confirmations uint, confirmed *time.Time) error
+	DropOnchainPayment(payment NewPaymentHistory, dropped time.Time) error
 	GetInvoice(payment_request string) NewInvoiceList
 	GetWorkspaceInvoices(workspace_uuid string) []NewInvoiceList
+	GetPendingInvoices() []NewInvoiceList
+	GetUnmarkedBountyPayments() []NewPaymentHistory
+	MarkBountyPaid(bountyId uint, paidDate *time.Time) error
+	AddUnsettledBountyPayment(payment NewPaymentHistory) error
+	HasUnsettledBountyPayment(bountyId uint) bool
+	GetUnsettledBountyPayments() []NewPaymentHistory
 	GetWorkspaceInvoicesCount(workspace_uuid string) int64
 	UpdateInvoice(payment_request string) NewInvoiceList
 	AddInvoice(invoice NewInvoiceList) NewInvoiceList
@@ -140,7 +234,69 @@ type Database interface {
 	NewHuntersPaid(r PaymentDateRange, workspace string) int64
 	TotalHuntersPaid(r PaymentDateRange, workspace string) int64
 	GetPersonByPubkey(pubkey string) Person
+	GetPeopleByPubkeys(pubkeys []string) map[string]Person
+	GetActiveAssignedBountiesCount(pubkey string) int64
+	GetSuperAdmins() []SuperAdmin
+	GetSuperAdminPubkeys() []string
+	CreateSuperAdminChange(change SuperAdminChange) (SuperAdminChange, error)
+	ConfirmSuperAdminChange(code string, confirmedBy string) (SuperAdminChange, error)
+	GetPendingSuperAdminChanges() []SuperAdminChange
+	MergePeople(source string, target string, mergedBy string, dryRun bool) (PersonMergeResult, error)
+	GetPersonMerges() []PersonMerge
+	SetPersonHandle(pubkey string, handle string, now time.Time) (Person, error)
+	GetPersonByHandle(handle string) Person
+	GetHandleReservations() []HandleReservation
+	CreateHandleReservation(reservation HandleReservation) (HandleReservation, error)
+	DeleteHandleReservation(handle string) error
+	GetAliasCollisions() ([]AliasCollision, error)
+	RecordRelayEvent(event RelayEvent, received time.Time) (bool, error)
+	SettleRelayPaymentEvent(event RelayEvent, invoice NewInvoiceList, received time.Time) (bool, error)
+	ApplyRelayTribeEvent(event RelayEvent, members uint64, messages uint64, received time.Time) (bool, error)
+	Ping(ctx context.Context) error
+	CreateWorkspaceApiToken(token WorkspaceApiToken) (WorkspaceApiToken, error)
+	GetWorkspaceApiTokens(workspaceUuid string) []WorkspaceApiToken
+	RevokeWorkspaceApiToken(workspaceUuid string, id uint) error
+	VerifyWorkspaceApiToken(token string) (auth.ApiToken, error)
+	CreateWorkspaceWebhook(webhook WorkspaceWebhook) (WorkspaceWebhook, error)
+	GetWorkspaceWebhooks(workspaceUuid string) []WorkspaceWebhook
+	GetWorkspaceWebhookByUuid(uuid string) (WorkspaceWebhook, error)
+	DeleteWorkspaceWebhook(workspaceUuid string, uuid string) error
+	CreateWorkspaceTag(tag WorkspaceTag) (WorkspaceTag, error)
+	GetWorkspaceTags(workspaceUuid string) []WorkspaceTag
+	GetWorkspaceTagByUuid(workspaceUuid string, uuid string) (WorkspaceTag, error)
+	UpdateWorkspaceTag(tag WorkspaceTag) (WorkspaceTag, error)
+	DeleteWorkspaceTag(workspaceUuid string, uuid string) error
+	SetBountyTags(bounty NewBounty, tags []string) (NewBounty, error)
+	RecordWebhookAlert(webhook WorkspaceWebhook, fingerprint string, draft NewBounty) (WebhookAlertResult, error)
+	CreateParentOrganization(org ParentOrganization) (ParentOrganization, error)
+	GetParentOrganizationByUuid(uuid string) (ParentOrganization, error)
+	GetParentOrganizationWorkspaces(orgUuid string) []Workspace
+	AddWorkspaceToParentOrganization(orgUuid string, workspaceUuid string) error
+	RemoveWorkspaceFromParentOrganization(orgUuid string, workspaceUuid string) error
+	GetParentOrganizationMembers(orgUuid string) []ParentOrganizationMember
+	GetParentOrganizationMemberRoles(orgUuid string, pubkey string) []ParentOrganizationMember
+	SetParentOrganizationMemberRoles(orgUuid string, pubkey string, roles []string) ([]ParentOrganizationMember, error)
+	GetParentOrganizationOverview(uuid string) (ParentOrganizationOverview, error)
+	TransferFeature(featureUuid string, workspaceUuid string) (WorkspaceFeatures, error)
+	GetDeletedWorkspaces() []DeletedWorkspace
+	RestoreWorkspace(uuid string) (Workspace, error)
+	PurgeExpiredWorkspaceArchives(before time.Time) (int64, error)
+	GetWorkspaceSettings(workspaceUuid string) WorkspaceSettings
+	SaveWorkspaceSettings(settings WorkspaceSettings) (WorkspaceSettings, error)
+	GetWorkspaceActiveBountiesCount(workspaceUuid string, assignee string) int64
+	GetWorkspaceBountiesInStateCount(workspaceUuid string, phaseUuid string, state BountyState) int64
+	GetWorkspaceAgentCommentsCount(workspaceUuid string, since time.Time) int64
+	GetJiraUserLinks(workspaceUuid string) []JiraUserLink
+	SaveJiraUserLink(link JiraUserLink) (JiraUserLink, error)
+	DeleteJiraUserLink(workspaceUuid string, email string) error
+	GetFeatureBounties(featureUuid string) []NewBounty
+	GetJiraIssueLinksByBountyIDs(ids []uint) []JiraIssueLink
+	ImportJiraBounties(workspaceUuid string, imports []JiraBountyImport) (JiraImportResult, error)
+	GetCompletedBountiesByAssignee(pubkey string) []NewBounty
+	GetPersonBoardBounties(pubkey string) []NewBounty
+	GetTotalEarnedByPubkey(pubkey string) uint
 	GetBountiesByDateRange(r PaymentDateRange, re *http.Request) []NewBounty
+	StreamBountiesByDateRange(r PaymentDateRange, re *http.Request, each func(bounties []NewBounty) error) error
 	GetBountiesByDateRangeCount(r PaymentDateRange, re *http.Request) int64
 	GetBountiesProviders(r PaymentDateRange, re *http.Request) []Person
 	PersonUniqueNameFromName(name string) (string, error)
@@ -150,10 +306,19 @@ type Database interface {
 	GetWorkspaceRepositorByWorkspaceUuid(uuid string) []WorkspaceRepositories
 	GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid string, uuid string) (WorkspaceRepositories, error)
 	DeleteWorkspaceRepository(workspace_uuid string, uuid string) bool
+	CreateOrEditCodeGraph(m WorkspaceCodeGraph) (WorkspaceCodeGraph, error)
+	GetCodeGraphsByWorkspaceUuid(workspace_uuid string) []WorkspaceCodeGraph
+	GetCodeGraphByUuid(uuid string) (WorkspaceCodeGraph, error)
+	UpdateCodeGraphStatus(uuid string, status CodeGraphStatus, progress int, errMsg string) (WorkspaceCodeGraph, error)
+	DeleteCodeGraph(workspace_uuid string, uuid string) error
 	CreateOrEditFeature(m WorkspaceFeatures) (WorkspaceFeatures, error)
+	PatchFeature(feature WorkspaceFeatures, columns []string, conditional bool) (WorkspaceFeatures, error)
 	GetFeaturesByWorkspaceUuid(uuid string, r *http.Request) []WorkspaceFeatures
 	GetWorkspaceFeaturesCount(uuid string) int64
 	GetFeatureByUuid(uuid string) WorkspaceFeatures
+	GetFeatureDetail(uuid string) (FeatureDetail, error)
+	CheckFeatureBountyCounts() []FeatureCountDrift
+	SyncFeatureBountyCounts(featureUuid string) error
 	CreateOrEditFeaturePhase(phase FeaturePhase) (FeaturePhase, error)
 	GetPhasesByFeatureUuid(featureUuid string) []FeaturePhase
 	GetFeaturePhaseByUuid(featureUuid, phaseUuid string) (FeaturePhase, error)
@@ -161,11 +326,78 @@ type Database interface {
 	CreateOrEditFeatureStory(story FeatureStory) (FeatureStory, error)
 	GetFeatureStoriesByFeatureUuid(featureUuid string) ([]FeatureStory, error)
 	GetFeatureStoryByUuid(featureUuid, storyUuid string) (FeatureStory, error)
+	GetFeatureStoryByDeliveryId(featureUuid string, deliveryId string) (FeatureStory, error)
 	DeleteFeatureStoryByUuid(featureUuid, storyUuid string) error
 	DeleteFeatureByUuid(uuid string) error
+	UpdateFeatureBrief(uuid string, brief string, sections BriefSections, updatedBy string) (WorkspaceFeatures, error)
 	GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) ([]NewBounty, error)
 	GetBountiesCountByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) int64
 	GetPhaseByUuid(phaseUuid string) (FeaturePhase, error)
 	GetBountiesByPhaseUuid(phaseUuid string) []Bounty
 	GetFeaturePhasesBountiesCount(bountyType string, phaseUuid string) int64
+	AddPhaseDocument(doc PhaseDocument) (PhaseDocument, error)
+	GetLatestPhaseDocument(phaseUuid string, docType PhaseDocumentType) (PhaseDocument, error)
+	GetPhaseDocumentByVersion(phaseUuid string, docType PhaseDocumentType, version int) (PhaseDocument, error)
+	GetPhaseDocumentHistory(phaseUuid string, docType PhaseDocumentType) []PhaseDocument
+	CreateFeatureCall(call FeatureCall) (FeatureCall, error)
+	UpdateFeatureCall(call FeatureCall) (FeatureCall, error)
+	GetFeatureCallByUuid(featureUuid, uuid string) (FeatureCall, error)
+	GetFeatureCalls(featureUuid string) []FeatureCall
+	GetFeatureCallHistory(featureUuid string) []FeatureCall
+	CreateCalendarToken(pubkey string) (CalendarToken, error)
+	RevokeCalendarTokens(pubkey string) error
+	VerifyCalendarToken(tokenString string) (CalendarToken, error)
+	GetPersonCalendarBounties(pubkey string) []NewBounty
+	GetPersonScheduledFeatureCalls(pubkey string, since time.Time) []FeatureCall
+	SaveFeatureCallTranscript(transcript FeatureCallTranscript, chunks []FeatureCallTranscriptChunk) (FeatureCallTranscript, error)
+	GetFeatureCallTranscript(callUuid string) (FeatureCallTranscript, error)
+	GetFeatureTranscripts(featureUuid string) []FeatureCallTranscript
+	SearchFeatureTranscriptChunks(featureUuid string, query string) []FeatureCallTranscriptChunk
+	PublicSearch(query string, types []string, limit int) (SearchResult, error)
+	AutocompletePeople(prefix string, limit int) ([]AutocompleteItem, error)
+	AutocompleteTribes(prefix string, limit int) ([]AutocompleteItem, error)
+	AutocompleteFeatures(prefix string, workspaceUuid string, limit int) ([]AutocompleteItem, error)
+	ListPublicTribes(filter ListFilter) ([]Tribe, int64, error)
+	ListPublicPeople(filter ListFilter) ([]PersonInShort, int64, error)
+	ListPublicBounties(filter ListFilter) ([]NewBounty, int64, error)
+	GetConfigOverrides() ([]ConfigOverride, error)
+	SaveConfigOverride(key string, value string, pubkey string) (ConfigOverride, error)
+	DeleteConfigOverride(key string) error
+	GetWebhookSigningSecrets() ([]WebhookSigningSecret, error)
+	GetWebhookSigningSecret(source string) (string, error)
+	SetWebhookSigningSecret(source string, secret string, pubkey string) (WebhookSigningSecret, error)
+	DeleteWebhookSigningSecret(source string) error
+	IsolateWorkspace(workspaceUuid string) (Workspace, error)
+	CloneWorkspace(sourceUuid string, ownerPubkey string, request WorkspaceCloneRequest) (WorkspaceCloneResult, error)
+	ExportWorkspace(workspaceUuid string, archive io.Writer) (map[string]int, error)
+	ImportWorkspace(archive io.Reader) (Workspace, map[string]int, error)
+	GetWorkspaceShowcase(workspace Workspace, limit int) (WorkspaceShowcase, error)
+	CreateShortLink(link ShortLink) (ShortLink, error)
+	GetShortLinkByCode(code string) (ShortLink, error)
+	FollowShortLink(code string, now time.Time) (ShortLink, error)
+	GetNostrBountySyncs(limit int) ([]NostrBountySync, error)
+	CreateNostrEvent(event NostrEvent) (NostrEvent, error)
+	GetBountyNostrEvents(bountyID uint) []NostrEvent
+	CreateWorkspaceBridge(bridge WorkspaceBridge) (WorkspaceBridge, error)
+	GetWorkspaceBridges(workspaceUuid string) []WorkspaceBridge
+	DeleteWorkspaceBridge(workspaceUuid string, uuid string) error
+	RecordBridgeDelivery(uuid string, now time.Time, deliveryErr error) error
+	CreateSlackLinkCode(teamId string, slackUserId string, code string, expires time.Time) error
+	CompleteSlackLink(code string, pubkey string, now time.Time) (SlackUserLink, error)
+	GetSlackUserLink(teamId string, slackUserId string) SlackUserLink
+	GetSlackTeam(teamId string) SlackTeam
+	SetSlackTeamWorkspace(teamId string, workspaceUuid string, pubkey string) (SlackTeam, error)
+	CreateTicketReview(review TicketReview) (TicketReview, error)
+	UpdateTicketReview(review TicketReview) (TicketReview, error)
+	GetTicketReviewByUuid(uuid string) (TicketReview, error)
+	GetTicketReviews(ticketUuid string) []TicketReview
+	CreatePhasePlan(plan PhasePlan) (PhasePlan, error)
+	UpdatePhasePlan(plan PhasePlan) (PhasePlan, error)
+	GetPhasePlanByUuid(uuid string) (PhasePlan, error)
+	GetPhasePlans(featureUuid string, phaseUuid string) []PhasePlan
+	SavePhasePlanTickets(planUuid string, tickets []PhasePlanTicket) (PhasePlan, error)
+	RollbackPhasePlan(planUuid string, pubkey string, now time.Time) (TicketBatchRollback, error)
+	AddWorkspaceUsage(usage []WorkspaceUsageDaily) error
+	GetWorkspaceUsage(workspaceUuid string, from string, to string) []WorkspaceUsageDaily
+	GetAllWorkspaceUsage(from string, to string) []WorkspaceUsageDaily
 }
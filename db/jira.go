@@ -0,0 +1,142 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrJiraUserLinkNotFound = errors.New("jira user link not found")
+
+func (db database) GetJiraUserLinks(workspaceUuid string) []JiraUserLink {
+	ms := []JiraUserLink{}
+	db.db.Model(&JiraUserLink{}).Where("workspace_uuid = ?", workspaceUuid).Order("email ASC").Find(&ms)
+	return ms
+}
+
+// SaveJiraUserLink links a Jira email to a pubkey, linking an email again replaces its pubkey
+func (db database) SaveJiraUserLink(link JiraUserLink) (JiraUserLink, error) {
+	now := time.Now()
+	link.Email = strings.ToLower(strings.TrimSpace(link.Email))
+	link.Created = &now
+	link.Updated = &now
+
+	err := db.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "workspace_uuid"}, {Name: "email"}},
+		DoUpdates: clause.AssignmentColumns([]string{"owner_pubkey", "created_by", "updated"}),
+	}).Create(&link).Error
+	if err != nil {
+		return JiraUserLink{}, err
+	}
+	return link, nil
+}
+
+func (db database) DeleteJiraUserLink(workspaceUuid string, email string) error {
+	result := db.db.Where("workspace_uuid = ? AND email = ?", workspaceUuid, strings.ToLower(email)).Delete(&JiraUserLink{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrJiraUserLinkNotFound
+	}
+	return nil
+}
+
+// GetFeatureBounties returns the bounties of all the phases of a feature
+func (db database) GetFeatureBounties(featureUuid string) []NewBounty {
+	ms := []NewBounty{}
+	db.db.Model(&NewBounty{}).
+		Select("bounty.*").
+		Joins(`INNER JOIN "feature_phases" ON "feature_phases"."uuid" = "bounty"."phase_uuid"`).
+		Where(`"feature_phases"."feature_uuid" = ?`, featureUuid).
+		Order("bounty.created ASC").
+		Find(&ms)
+	return ms
+}
+
+func (db database) GetJiraIssueLinksByBountyIDs(ids []uint) []JiraIssueLink {
+	ms := []JiraIssueLink{}
+	if len(ids) == 0 {
+		return ms
+	}
+	db.db.Model(&JiraIssueLink{}).Where("bounty_id IN ?", ids).Find(&ms)
+	return ms
+}
+
+// ImportJiraBounties creates a bounty for each Jira issue imported for the first time
+// and updates the bounty of an issue imported before, unless it was paid already.
+// Everything is imported in one transaction, so a failed import changes nothing
+func (db database) ImportJiraBounties(workspaceUuid string, imports []JiraBountyImport) (JiraImportResult, error) {
+	result := JiraImportResult{Created: []string{}, Updated: []string{}, Skipped: []string{}}
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		for _, imp := range imports {
+			link := JiraIssueLink{}
+			found := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("workspace_uuid = ? AND issue_key = ?", workspaceUuid, imp.IssueKey).
+				Limit(1).Find(&link)
+			if found.Error != nil {
+				return found.Error
+			}
+
+			if found.RowsAffected > 0 {
+				existing := NewBounty{}
+				bounty := tx.Where("id = ?", link.BountyID).Limit(1).Find(&existing)
+				if bounty.Error != nil {
+					return bounty.Error
+				}
+				if bounty.RowsAffected > 0 {
+					if existing.Paid {
+						result.Skipped = append(result.Skipped, imp.IssueKey)
+						continue
+					}
+					updates := map[string]interface{}{
+						"title":       imp.Bounty.Title,
+						"description": imp.Bounty.Description,
+						"assignee":    imp.Bounty.Assignee,
+						"completed":   imp.Bounty.Completed,
+						"state":       imp.Bounty.CurrentState(),
+						"phase_uuid":  imp.Bounty.PhaseUuid,
+						"updated":     &now,
+					}
+					if imp.Bounty.Assignee != existing.Assignee {
+						updates["last_activity_date"] = nil
+						updates["stale_warning_date"] = nil
+					}
+					if err := tx.Model(&NewBounty{}).Where("id = ?", existing.ID).Updates(updates).Error; err != nil {
+						return err
+					}
+					result.Updated = append(result.Updated, imp.IssueKey)
+					continue
+				}
+			}
+
+			draft := imp.Bounty
+			draft.State = draft.CurrentState()
+			if err := tx.Create(&draft).Error; err != nil {
+				return err
+			}
+
+			link.WorkspaceUuid = workspaceUuid
+			link.IssueKey = imp.IssueKey
+			link.BountyID = draft.ID
+			link.Updated = &now
+			if link.Created == nil {
+				link.Created = &now
+			}
+			if err := tx.Save(&link).Error; err != nil {
+				return err
+			}
+			result.Created = append(result.Created, imp.IssueKey)
+		}
+		return nil
+	})
+	if err != nil {
+		return JiraImportResult{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package db
+
+// RefreshLeaderboardAggregates rebuilds the daily leaderboard totals from the paid bounties
+func (db database) RefreshLeaderboardAggregates() error {
+	tx := db.db.Begin()
+
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = tx.Exec(`DELETE FROM bounty_leaderboard_dailies`).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Exec(`INSERT INTO bounty_leaderboard_dailies
+(day, owner_pubkey, workspace_uuid, language, total_bounties_completed, total_sats_earned)
+SELECT DATE(COALESCE(paid_date, updated)), assignee, COALESCE(workspace_uuid, ''), '', COUNT(*), SUM(CAST(price as integer))
+FROM bounty
+WHERE paid = true AND assignee != ''
+GROUP BY 1, 2, 3
+UNION ALL
+SELECT DATE(COALESCE(paid_date, updated)), assignee, COALESCE(workspace_uuid, ''), language, COUNT(*), SUM(CAST(price as integer))
+FROM bounty, unnest(coding_languages) AS language
+WHERE paid = true AND assignee != ''
+GROUP BY 1, 2, 3, 4`).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// GetLeaderboardTotals sums the daily leaderboard totals per hunter, ordered by sats earned
+func (db database) GetLeaderboardTotals(filter LeaderboardFilter) []BountyLeaderboard {
+	ms := []BountyLeaderboard{}
+
+	query := db.db.Model(&BountyLeaderboardDaily{}).
+		Select("owner_pubkey, SUM(total_bounties_completed) as total_bounties_completed, SUM(total_sats_earned) as total_sats_earned").
+		Where("language = ?", filter.Language)
+
+	if filter.WorkspaceUuid != "" {
+		query = query.Where("workspace_uuid = ?", filter.WorkspaceUuid)
+	}
+	if filter.From != nil {
+		query = query.Where("day >= ?", filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("day < ?", filter.To)
+	}
+
+	query.Group("owner_pubkey").Order("total_sats_earned DESC, owner_pubkey ASC").Scan(&ms)
+	return ms
+}
@@ -0,0 +1,305 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInsufficientBudget = errors.New("workspace budget is not enough")
+
+// DefaultBudgetCurrency is the currency workspace budgets are kept in
+const DefaultBudgetCurrency = "sats"
+
+// Ledger accounts outside of the workspaces, money enters a workspace
+// budget from deposits or adjustments and leaves it to payouts or withdrawals
+const (
+	LedgerDepositsAccount    = "external:deposits"
+	LedgerPayoutsAccount     = "external:payouts"
+	LedgerWithdrawalsAccount = "external:withdrawals"
+	LedgerAdjustmentsAccount = "external:adjustments"
+)
+
+func WorkspaceBudgetAccount(workspaceUuid string) string {
+	return "workspace:" + workspaceUuid
+}
+
+// ReservationAccount holds the budget of a reservation until it is spent or released
+func ReservationAccount(reservationId string) string {
+	return "reservation:" + reservationId
+}
+
+// ledgerTransfer moves an amount from one account to another
+type ledgerTransfer struct {
+	WorkspaceUuid string
+	From          string
+	To            string
+	Amount        uint
+	ReferenceType LedgerReferenceType
+	ReferenceId   string
+}
+
+// postBudgetTransaction writes the debit and credit entries of a transfer and
+// syncs the stored workspace budget. A reference is only ever posted once,
+// so retrying the same deposit or payment does not count it twice
+func postBudgetTransaction(tx *gorm.DB, transfer ledgerTransfer) error {
+	if transfer.Amount == 0 {
+		return nil
+	}
+
+	if err := lockWorkspaceBudget(tx, transfer.WorkspaceUuid); err != nil {
+		return err
+	}
+
+	var existing int64
+	if err := tx.Model(&BudgetLedgerEntry{}).
+		Where("reference_type = ? AND reference_id = ?", transfer.ReferenceType, transfer.ReferenceId).
+		Count(&existing).Error; err != nil {
+		return err
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	now := time.Now()
+	transactionId := xid.New().String()
+	entries := []BudgetLedgerEntry{
+		{
+			TransactionId: transactionId,
+			Account:       transfer.From,
+			WorkspaceUuid: transfer.WorkspaceUuid,
+			Currency:      DefaultBudgetCurrency,
+			Debit:         transfer.Amount,
+			ReferenceType: transfer.ReferenceType,
+			ReferenceId:   transfer.ReferenceId,
+			Created:       &now,
+		},
+		{
+			TransactionId: transactionId,
+			Account:       transfer.To,
+			WorkspaceUuid: transfer.WorkspaceUuid,
+			Currency:      DefaultBudgetCurrency,
+			Credit:        transfer.Amount,
+			ReferenceType: transfer.ReferenceType,
+			ReferenceId:   transfer.ReferenceId,
+			Created:       &now,
+		},
+	}
+	if err := tx.Create(&entries).Error; err != nil {
+		return err
+	}
+
+	return syncWorkspaceBudget(tx, transfer.WorkspaceUuid)
+}
+
+// lockWorkspaceBudget takes a SELECT ... FOR UPDATE lock on the budget row of
+// a workspace, so ledger postings of a workspace run one after the other
+// and a balance read after the lock stays true until the transaction ends
+func lockWorkspaceBudget(tx *gorm.DB, workspaceUuid string) error {
+	budget := NewBountyBudget{}
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("workspace_uuid = ?", workspaceUuid).
+		Limit(1).Find(&budget).Error
+}
+
+// spendBudget moves an amount out of a workspace budget, from its reservation when
+// there is one and otherwise straight from the budget once it has been checked
+func spendBudget(tx *gorm.DB, reservation BudgetReservation, transfer ledgerTransfer) error {
+	if err := lockWorkspaceBudget(tx, transfer.WorkspaceUuid); err != nil {
+		return err
+	}
+
+	transfer.From = WorkspaceBudgetAccount(transfer.WorkspaceUuid)
+	if reservation.Id != "" {
+		transfer.From = ReservationAccount(reservation.Id)
+	}
+
+	if ledgerBalance(tx, transfer.From) < int64(transfer.Amount) {
+		return ErrInsufficientBudget
+	}
+	return postBudgetTransaction(tx, transfer)
+}
+
+// ledgerBalance is what an account holds, credits minus debits
+func ledgerBalance(tx *gorm.DB, account string) int64 {
+	var balance int64
+	tx.Model(&BudgetLedgerEntry{}).
+		Where("account = ? AND currency = ?", account, DefaultBudgetCurrency).
+		Select("COALESCE(SUM(credit), 0) - COALESCE(SUM(debit), 0)").
+		Row().Scan(&balance)
+	return balance
+}
+
+func budgetFromBalance(balance int64) uint {
+	if balance < 0 {
+		return 0
+	}
+	return uint(balance)
+}
+
+// syncWorkspaceBudget stores the ledger balance of a workspace in bounty_budgets,
+// the stored total is only kept for queries, the ledger is what counts
+func syncWorkspaceBudget(tx *gorm.DB, workspaceUuid string) error {
+	total := budgetFromBalance(ledgerBalance(tx, WorkspaceBudgetAccount(workspaceUuid)))
+	now := time.Now()
+
+	result := tx.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", workspaceUuid).Updates(map[string]interface{}{
+		"total_budget": total,
+		"updated":      &now,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		budget := NewBountyBudget{
+			WorkspaceUuid: workspaceUuid,
+			TotalBudget:   total,
+			Created:       &now,
+			Updated:       &now,
+		}
+		return tx.Create(&budget).Error
+	}
+	return nil
+}
+
+// inTransaction runs fn in a db transaction and rolls back when it fails
+func (db database) inTransaction(fn func(tx *gorm.DB) error) (err error) {
+	tx := db.db.Begin()
+	if err = tx.Error; err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			err = fmt.Errorf("transaction panicked: %v", r)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// ReserveBudget sets an amount of a workspace budget aside for a payment,
+// it fails with ErrInsufficientBudget when the budget cannot cover it
+func (db database) ReserveBudget(workspaceUuid string, amount uint) (BudgetReservation, error) {
+	reservation := BudgetReservation{
+		Id:            xid.New().String(),
+		WorkspaceUuid: workspaceUuid,
+		Amount:        amount,
+	}
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		return spendBudget(tx, BudgetReservation{}, ledgerTransfer{
+			WorkspaceUuid: workspaceUuid,
+			To:            ReservationAccount(reservation.Id),
+			Amount:        amount,
+			ReferenceType: LedgerReserve,
+			ReferenceId:   reservation.Id,
+		})
+	})
+	if err != nil {
+		return BudgetReservation{}, err
+	}
+	return reservation, nil
+}
+
+// ReleaseBudget returns what is left of a reservation to the workspace budget,
+// releasing a reservation that has been spent does nothing
+func (db database) ReleaseBudget(reservation BudgetReservation) error {
+	return db.inTransaction(func(tx *gorm.DB) error {
+		if err := lockWorkspaceBudget(tx, reservation.WorkspaceUuid); err != nil {
+			return err
+		}
+
+		remaining := ledgerBalance(tx, ReservationAccount(reservation.Id))
+		if remaining <= 0 {
+			return nil
+		}
+
+		return postBudgetTransaction(tx, ledgerTransfer{
+			WorkspaceUuid: reservation.WorkspaceUuid,
+			From:          ReservationAccount(reservation.Id),
+			To:            WorkspaceBudgetAccount(reservation.WorkspaceUuid),
+			Amount:        uint(remaining),
+			ReferenceType: LedgerRelease,
+			ReferenceId:   reservation.Id,
+		})
+	})
+}
+
+func (db database) GetWorkspaceLedgerBalance(workspaceUuid string) int64 {
+	return ledgerBalance(db.db, WorkspaceBudgetAccount(workspaceUuid))
+}
+
+func (db database) GetBudgetLedger(workspaceUuid string) []BudgetLedgerEntry {
+	entries := []BudgetLedgerEntry{}
+	db.db.Model(&BudgetLedgerEntry{}).Where("workspace_uuid = ?", workspaceUuid).Order("created DESC, id DESC").Find(&entries)
+	return entries
+}
+
+func (db database) SyncWorkspaceBudget(workspaceUuid string) error {
+	return db.inTransaction(func(tx *gorm.DB) error {
+		return syncWorkspaceBudget(tx, workspaceUuid)
+	})
+}
+
+// CheckBudgetLedgerConsistency returns the transactions whose debits and credits
+// do not balance and the workspaces whose stored budget drifted from the ledger
+func (db database) CheckBudgetLedgerConsistency() []BudgetLedgerInconsistency {
+	inconsistencies := []BudgetLedgerInconsistency{}
+
+	unbalanced := []BudgetLedgerInconsistency{}
+	db.db.Raw(`SELECT ? AS kind, transaction_id, MAX(workspace_uuid) AS workspace_uuid,
+		SUM(debit) AS expected, SUM(credit) AS actual
+		FROM budget_ledger_entries
+		GROUP BY transaction_id, currency
+		HAVING SUM(debit) <> SUM(credit)`, LedgerUnbalancedTransaction).Scan(&unbalanced)
+	inconsistencies = append(inconsistencies, unbalanced...)
+
+	drifted := []BudgetLedgerInconsistency{}
+	db.db.Raw(`SELECT ? AS kind, budget.workspace_uuid,
+		GREATEST(COALESCE(SUM(ledger.credit), 0) - COALESCE(SUM(ledger.debit), 0), 0) AS expected,
+		budget.total_budget AS actual
+		FROM bounty_budgets AS budget
+		LEFT JOIN budget_ledger_entries AS ledger
+		ON ledger.account = 'workspace:' || budget.workspace_uuid AND ledger.currency = ?
+		GROUP BY budget.workspace_uuid, budget.total_budget
+		HAVING budget.total_budget <> GREATEST(COALESCE(SUM(ledger.credit), 0) - COALESCE(SUM(ledger.debit), 0), 0)`,
+		LedgerBudgetDrift, DefaultBudgetCurrency).Scan(&drifted)
+	inconsistencies = append(inconsistencies, drifted...)
+
+	return inconsistencies
+}
+
+// MigrateBudgetLedger opens the ledger of budgets that were kept before it existed,
+// the stored total becomes the opening balance of the workspace
+func (db database) MigrateBudgetLedger() {
+	budgets := []NewBountyBudget{}
+	db.db.Raw(`SELECT * FROM bounty_budgets AS budget
+		WHERE budget.total_budget > 0
+		AND NOT EXISTS (SELECT 1 FROM budget_ledger_entries AS ledger WHERE ledger.account = 'workspace:' || budget.workspace_uuid)`).Scan(&budgets)
+
+	for _, budget := range budgets {
+		err := db.inTransaction(func(tx *gorm.DB) error {
+			return postBudgetTransaction(tx, ledgerTransfer{
+				WorkspaceUuid: budget.WorkspaceUuid,
+				From:          LedgerAdjustmentsAccount,
+				To:            WorkspaceBudgetAccount(budget.WorkspaceUuid),
+				Amount:        budget.TotalBudget,
+				ReferenceType: LedgerOpening,
+				ReferenceId:   budget.WorkspaceUuid,
+			})
+		})
+		if err != nil {
+			fmt.Println("[ledger] could not open the ledger of workspace", budget.WorkspaceUuid, err)
+		}
+	}
+}
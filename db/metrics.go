@@ -228,6 +228,45 @@ func CalculateAverageDays(paidCount int64, paidSum uint) uint {
 }
 
 func (db database) GetBountiesByDateRange(r PaymentDateRange, re *http.Request) []NewBounty {
+	b := []NewBounty{}
+	db.db.Raw(bountiesByDateRangeQuery(r, re)).Find(&b)
+
+	return b
+}
+
+// StreamBountiesByDateRange reads the bounties of GetBountiesByDateRange in
+// batches, so an export of every bounty is read with flat memory
+func (db database) StreamBountiesByDateRange(r PaymentDateRange, re *http.Request, each func(bounties []NewBounty) error) error {
+	rows, err := db.db.Raw(bountiesByDateRangeQuery(r, re)).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	batch := []NewBounty{}
+	for rows.Next() {
+		bounty := NewBounty{}
+		if err := db.db.ScanRows(rows, &bounty); err != nil {
+			return err
+		}
+		batch = append(batch, bounty)
+		if len(batch) == streamBatchSize {
+			if err := each(batch); err != nil {
+				return err
+			}
+			batch = []NewBounty{}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return each(batch)
+	}
+	return nil
+}
+
+func bountiesByDateRangeQuery(r PaymentDateRange, re *http.Request) string {
 	offset, limit, sortBy, direction, _ := utils.GetPaginationParams(re)
 	keys := re.URL.Query()
 	open := keys.Get("Open")
@@ -259,11 +298,7 @@ func (db database) GetBountiesByDateRange(r PaymentDateRange, re *http.Request)
 		statusQuery = ""
 	}
 
-	if sortBy != "" && direction != "" {
-		orderQuery = "ORDER BY " + sortBy + " " + direction
-	} else {
-		orderQuery = " ORDER BY " + sortBy + " DESC"
-	}
+	orderQuery = "ORDER BY " + BountySortFields.OrderBy(sortBy, direction, "created")
 	if limit > 1 {
 		limitQuery = fmt.Sprintf("LIMIT %d  OFFSET %d", limit, offset)
 	}
@@ -278,12 +313,7 @@ func (db database) GetBountiesByDateRange(r PaymentDateRange, re *http.Request)
 	}
 
 	query := `SELECT * FROM public.bounty WHERE created >= '` + r.StartDate + `'  AND created <= '` + r.EndDate + `'` + providerCondition
-	allQuery := query + " " + workspaceQuery + " " + statusQuery + " " + orderQuery + " " + limitQuery
-
-	b := []NewBounty{}
-	db.db.Raw(allQuery).Find(&b)
-
-	return b
+	return query + " " + workspaceQuery + " " + statusQuery + " " + orderQuery + " " + limitQuery
 }
 
 func (db database) GetBountiesByDateRangeCount(r PaymentDateRange, re *http.Request) int64 {
@@ -377,9 +407,13 @@ func (db database) GetBountiesProviders(r PaymentDateRange, re *http.Request) []
 	allQuery := query + " " + statusQuery + " " + limitQuery
 	db.db.Raw(allQuery).Scan(&bountyOwners)
 
+	pubkeys := []string{}
+	for _, owner := range bountyOwners {
+		pubkeys = append(pubkeys, owner.OwnerID)
+	}
+	people := db.GetPeopleByPubkeys(pubkeys)
 	for _, owner := range bountyOwners {
-		person := db.GetPersonByPubkey(owner.OwnerID)
-		bountyProviders = append(bountyProviders, person)
+		bountyProviders = append(bountyProviders, people[owner.OwnerID])
 	}
 	return bountyProviders
 }
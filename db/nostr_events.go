@@ -0,0 +1,66 @@
+package db
+
+import (
+	"time"
+)
+
+// GetNostrBountySyncs returns the bounties with a live Nostr listing and up to
+// limit open public bounties that have none yet
+func (db database) GetNostrBountySyncs(limit int) ([]NostrBountySync, error) {
+	live := []NostrEvent{}
+	err := db.db.Raw(`SELECT * FROM (
+		SELECT DISTINCT ON (bounty_id) * FROM nostr_events WHERE status = ? ORDER BY bounty_id, id DESC
+	) latest WHERE action != ?`, NostrEventPublished, NostrEventDelete).Scan(&live).Error
+	if err != nil {
+		return nil, err
+	}
+
+	liveIds := []uint{}
+	for _, event := range live {
+		liveIds = append(liveIds, event.BountyID)
+	}
+	bounties := map[uint]NewBounty{}
+	if len(liveIds) > 0 {
+		found := []NewBounty{}
+		if err := db.db.Where("id IN ?", liveIds).Find(&found).Error; err != nil {
+			return nil, err
+		}
+		for _, bounty := range found {
+			bounties[bounty.ID] = bounty
+		}
+	}
+
+	syncs := []NostrBountySync{}
+	for i := range live {
+		syncs = append(syncs, NostrBountySync{BountyID: live[i].BountyID, Bounty: bounties[live[i].BountyID], Event: &live[i]})
+	}
+
+	open := []NewBounty{}
+	query := db.db.Where("show != false AND "+publicBountiesQuery+" AND state = ?", BountyStateOpen)
+	if len(liveIds) > 0 {
+		query = query.Where("id NOT IN ?", liveIds)
+	}
+	if err := query.Order("id ASC").Limit(limit).Find(&open).Error; err != nil {
+		return nil, err
+	}
+	for _, bounty := range open {
+		syncs = append(syncs, NostrBountySync{BountyID: bounty.ID, Bounty: bounty})
+	}
+	return syncs, nil
+}
+
+func (db database) CreateNostrEvent(event NostrEvent) (NostrEvent, error) {
+	now := time.Now()
+	event.Created = &now
+	if err := db.db.Create(&event).Error; err != nil {
+		return NostrEvent{}, err
+	}
+	return event, nil
+}
+
+// GetBountyNostrEvents lists the events sent about a bounty, newest first
+func (db database) GetBountyNostrEvents(bountyID uint) []NostrEvent {
+	events := []NostrEvent{}
+	db.db.Where("bounty_id = ?", bountyID).Order("id DESC").Find(&events)
+	return events
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+func (db database) CreateNotification(n Notification) (Notification, error) {
+	now := time.Now()
+	n.Created = &now
+
+	if err := db.db.Create(&n).Error; err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+func (db database) GetNotificationsByPubkey(pubkey string, unreadOnly bool) []Notification {
+	ms := []Notification{}
+	query := db.db.Model(&Notification{}).Where("recipient_pubkey = ?", pubkey)
+	if unreadOnly {
+		query = query.Where("read = ?", false)
+	}
+	query.Order("created DESC").Limit(100).Find(&ms)
+	return ms
+}
+
+func (db database) MarkNotificationRead(pubkey string, id uint) error {
+	result := db.db.Model(&Notification{}).Where("id = ? AND recipient_pubkey = ?", id, pubkey).Update("read", true)
+	if result.RowsAffected == 0 {
+		return errors.New("no notification found")
+	}
+	return result.Error
+}
@@ -0,0 +1,251 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrParentOrganizationNotFound = errors.New("organization not found")
+	ErrWorkspaceHasParent         = errors.New("workspace already belongs to another organization")
+	ErrWorkspaceNotInOrganization = errors.New("workspace does not belong to the organization")
+)
+
+func (db database) CreateParentOrganization(org ParentOrganization) (ParentOrganization, error) {
+	now := time.Now()
+	org.ID = 0
+	org.Uuid = xid.New().String()
+	org.Created = &now
+	org.Updated = &now
+
+	if err := db.db.Create(&org).Error; err != nil {
+		return ParentOrganization{}, err
+	}
+	return org, nil
+}
+
+func (db database) GetParentOrganizationByUuid(uuid string) (ParentOrganization, error) {
+	org := ParentOrganization{}
+	result := db.db.Model(&ParentOrganization{}).Where("uuid = ?", uuid).Limit(1).Find(&org)
+	if result.Error != nil {
+		return org, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return org, ErrParentOrganizationNotFound
+	}
+	return org, nil
+}
+
+// GetParentOrganizationWorkspaces returns the workspaces of an organization that are not deleted
+func (db database) GetParentOrganizationWorkspaces(orgUuid string) []Workspace {
+	ms := []Workspace{}
+	db.db.Model(&Workspace{}).
+		Joins("JOIN parent_organization_workspaces ON parent_organization_workspaces.workspace_uuid = workspaces.uuid").
+		Where("parent_organization_workspaces.org_uuid = ? AND workspaces.deleted = ?", orgUuid, false).
+		Order("workspaces.name ASC").
+		Find(&ms)
+	return ms
+}
+
+// AddWorkspaceToParentOrganization links a workspace to an organization, a workspace
+// belongs to one organization at most
+func (db database) AddWorkspaceToParentOrganization(orgUuid string, workspaceUuid string) error {
+	return db.inTransaction(func(tx *gorm.DB) error {
+		link := ParentOrganizationWorkspace{}
+		found := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("workspace_uuid = ?", workspaceUuid).Limit(1).Find(&link)
+		if found.Error != nil {
+			return found.Error
+		}
+		if found.RowsAffected > 0 {
+			if link.OrgUuid != orgUuid {
+				return ErrWorkspaceHasParent
+			}
+			return nil
+		}
+
+		now := time.Now()
+		return tx.Create(&ParentOrganizationWorkspace{OrgUuid: orgUuid, WorkspaceUuid: workspaceUuid, Created: &now}).Error
+	})
+}
+
+func (db database) RemoveWorkspaceFromParentOrganization(orgUuid string, workspaceUuid string) error {
+	result := db.db.Where("org_uuid = ? AND workspace_uuid = ?", orgUuid, workspaceUuid).Delete(&ParentOrganizationWorkspace{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWorkspaceNotInOrganization
+	}
+	return nil
+}
+
+func (db database) GetParentOrganizationMembers(orgUuid string) []ParentOrganizationMember {
+	ms := []ParentOrganizationMember{}
+	db.db.Model(&ParentOrganizationMember{}).Where("org_uuid = ?", orgUuid).Order("owner_pub_key ASC, role ASC").Find(&ms)
+	return ms
+}
+
+func (db database) GetParentOrganizationMemberRoles(orgUuid string, pubkey string) []ParentOrganizationMember {
+	ms := []ParentOrganizationMember{}
+	db.db.Model(&ParentOrganizationMember{}).Where("org_uuid = ? AND owner_pub_key = ?", orgUuid, pubkey).Find(&ms)
+	return ms
+}
+
+// SetParentOrganizationMemberRoles replaces the roles of a member of an organization,
+// no roles removes the member
+func (db database) SetParentOrganizationMemberRoles(orgUuid string, pubkey string, roles []string) ([]ParentOrganizationMember, error) {
+	members := []ParentOrganizationMember{}
+	now := time.Now()
+	for _, role := range roles {
+		members = append(members, ParentOrganizationMember{OrgUuid: orgUuid, OwnerPubKey: pubkey, Role: role, Created: &now})
+	}
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		if err := tx.Where("org_uuid = ? AND owner_pub_key = ?", orgUuid, pubkey).Delete(&ParentOrganizationMember{}).Error; err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return nil
+		}
+		return tx.Create(&members).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// GetInheritedWorkspaceRoles returns the roles a user gets on a workspace from its
+// organization, the owner of the organization gets every role
+func (db database) GetInheritedWorkspaceRoles(workspaceUuid string, pubkey string) []WorkspaceUserRoles {
+	org := ParentOrganization{}
+	result := db.db.Model(&ParentOrganization{}).
+		Joins("JOIN parent_organization_workspaces ON parent_organization_workspaces.org_uuid = parent_organizations.uuid").
+		Where("parent_organization_workspaces.workspace_uuid = ?", workspaceUuid).
+		Limit(1).Find(&org)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil
+	}
+
+	roles := []WorkspaceUserRoles{}
+	if org.OwnerPubKey == pubkey {
+		for _, role := range ConfigBountyRoles {
+			roles = append(roles, WorkspaceUserRoles{Role: role.Name, OwnerPubKey: pubkey, WorkspaceUuid: workspaceUuid})
+		}
+		return roles
+	}
+
+	for _, member := range db.GetParentOrganizationMemberRoles(org.Uuid, pubkey) {
+		roles = append(roles, WorkspaceUserRoles{Role: member.Role, OwnerPubKey: pubkey, WorkspaceUuid: workspaceUuid, Created: member.Created})
+	}
+	return roles
+}
+
+// GetParentOrganizationOverview rolls up the budgets and the bounties of the workspaces of an organization
+func (db database) GetParentOrganizationOverview(uuid string) (ParentOrganizationOverview, error) {
+	org, err := db.GetParentOrganizationByUuid(uuid)
+	if err != nil {
+		return ParentOrganizationOverview{}, err
+	}
+
+	overview := ParentOrganizationOverview{
+		Organization: org,
+		Workspaces:   []OrgWorkspaceSummary{},
+		Bounties:     []OrgBountyStateCount{},
+	}
+
+	workspaces := db.GetParentOrganizationWorkspaces(uuid)
+	if len(workspaces) == 0 {
+		return overview, nil
+	}
+
+	workspaceUuids := []string{}
+	for _, workspace := range workspaces {
+		workspaceUuids = append(workspaceUuids, workspace.Uuid)
+	}
+
+	counts := []struct {
+		WorkspaceUuid string
+		Count         int64
+	}{}
+	if err := db.db.Model(&NewBounty{}).
+		Select("workspace_uuid, COUNT(*) AS count").
+		Where("workspace_uuid IN ?", workspaceUuids).
+		Group("workspace_uuid").
+		Scan(&counts).Error; err != nil {
+		return overview, err
+	}
+	bountyCounts := map[string]int64{}
+	for _, count := range counts {
+		bountyCounts[count.WorkspaceUuid] = count.Count
+	}
+
+	for _, workspace := range workspaces {
+		budget := db.GetWorkspaceBudget(workspace.Uuid).TotalBudget
+		overview.TotalBudget += budget
+		overview.Workspaces = append(overview.Workspaces, OrgWorkspaceSummary{
+			Uuid:        workspace.Uuid,
+			Name:        workspace.Name,
+			Img:         workspace.Img,
+			Budget:      budget,
+			BountyCount: bountyCounts[workspace.Uuid],
+		})
+	}
+
+	if err := db.db.Model(&NewBounty{}).
+		Select("state, COUNT(*) AS count, COALESCE(SUM(price), 0) AS amount").
+		Where("workspace_uuid IN ?", workspaceUuids).
+		Group("state").
+		Order("state").
+		Scan(&overview.Bounties).Error; err != nil {
+		return overview, err
+	}
+	for _, count := range overview.Bounties {
+		if count.State == BountyStatePaid {
+			overview.TotalPaid = count.Amount
+		}
+	}
+
+	return overview, nil
+}
+
+// TransferFeature moves a feature to another workspace with its bounties, calls,
+// transcripts and webhooks. Payments made for its bounties stay on the ledger of
+// the workspace that paid them
+func (db database) TransferFeature(featureUuid string, workspaceUuid string) (WorkspaceFeatures, error) {
+	feature := WorkspaceFeatures{}
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", featureUuid).First(&feature).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(&WorkspaceFeatures{}).Where("uuid = ?", featureUuid).Updates(map[string]interface{}{
+			"workspace_uuid": workspaceUuid,
+			"updated":        &now,
+		}).Error; err != nil {
+			return err
+		}
+
+		phases := tx.Model(&FeaturePhase{}).Select("uuid").Where("feature_uuid = ?", featureUuid)
+		if err := tx.Model(&NewBounty{}).Where("phase_uuid IN (?)", phases).Update("workspace_uuid", workspaceUuid).Error; err != nil {
+			return err
+		}
+		for _, model := range []interface{}{&FeatureCall{}, &FeatureCallTranscript{}, &WorkspaceWebhook{}} {
+			if err := tx.Model(model).Where("feature_uuid = ?", featureUuid).Update("workspace_uuid", workspaceUuid).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Where("uuid = ?", featureUuid).First(&feature).Error
+	})
+	if err != nil {
+		return WorkspaceFeatures{}, err
+	}
+	return feature, nil
+}
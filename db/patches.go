@@ -0,0 +1,92 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrPatchConflict = errors.New("the record changed since it was read")
+
+// conditionalUpdate limits a write to the version of a record that was read,
+// the check and the write are then one statement
+func conditionalUpdate(query *gorm.DB, updated *time.Time) *gorm.DB {
+	if updated == nil {
+		return query.Where("updated IS NULL")
+	}
+	return query.Where("updated = ?", *updated)
+}
+
+func hasColumn(columns []string, column string) bool {
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// PatchBounty writes the given columns of a bounty and nothing else, so the
+// fields a client left out keep their value. A conditional patch is only
+// written when the bounty was not updated since it was read, otherwise it
+// fails with ErrPatchConflict. A new due date starts the reminders over
+func (db database) PatchBounty(bounty NewBounty, columns []string, conditional bool) (NewBounty, error) {
+	query := db.db.Model(&NewBounty{}).Where("id = ?", bounty.ID)
+	if conditional {
+		query = conditionalUpdate(query, bounty.Updated)
+	}
+
+	columns = append(columns, "updated")
+	if hasColumn(columns, "due_date") {
+		bounty.DueReminderHours = nil
+		columns = append(columns, "due_reminder_hours")
+	}
+	now := time.Now()
+	bounty.Updated = &now
+
+	result := query.Select(columns).Updates(&bounty)
+	if result.Error != nil {
+		return NewBounty{}, result.Error
+	}
+
+	patched := NewBounty{}
+	db.db.Where("id = ?", bounty.ID).Limit(1).Find(&patched)
+	if patched.ID == 0 {
+		return NewBounty{}, ErrBountyNotFound
+	}
+	if result.RowsAffected == 0 {
+		return patched, ErrPatchConflict
+	}
+	return patched, nil
+}
+
+// PatchFeature writes the given columns of a feature like PatchBounty
+func (db database) PatchFeature(feature WorkspaceFeatures, columns []string, conditional bool) (WorkspaceFeatures, error) {
+	query := db.db.Model(&WorkspaceFeatures{}).Where("uuid = ?", feature.Uuid)
+	if conditional {
+		query = conditionalUpdate(query, feature.Updated)
+	}
+
+	feature.Name = strings.TrimSpace(feature.Name)
+	feature.Requirements = strings.TrimSpace(feature.Requirements)
+	feature.Architecture = strings.TrimSpace(feature.Architecture)
+	now := time.Now()
+	feature.Updated = &now
+
+	result := query.Select(append(columns, "updated", "updated_by")).Updates(&feature)
+	if result.Error != nil {
+		return WorkspaceFeatures{}, result.Error
+	}
+
+	patched := WorkspaceFeatures{}
+	db.db.Where("uuid = ?", feature.Uuid).Limit(1).Find(&patched)
+	if patched.ID == 0 {
+		return WorkspaceFeatures{}, ErrFeatureNotFound
+	}
+	if result.RowsAffected == 0 {
+		return patched, ErrPatchConflict
+	}
+	return patched, nil
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"fmt"
+)
+
+// peopleSearchIndexes let postgres answer ILIKE '%term%' on people names from
+// a trigram index instead of scanning the table
+var peopleSearchIndexes = map[string]string{
+	"idx_people_owner_alias_trgm": "people USING gin (owner_alias gin_trgm_ops)",
+	"idx_people_unique_name_trgm": "people USING gin (unique_name gin_trgm_ops)",
+}
+
+func (db database) MigratePeopleSearchIndexes() {
+	if err := db.db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		fmt.Println("[people search] could not create pg_trgm extension", err)
+		return
+	}
+	for name, on := range peopleSearchIndexes {
+		if err := db.db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s", name, on)).Error; err != nil {
+			fmt.Println("[people search] could not create index", name, err)
+		}
+	}
+}
+
+// peopleSearchCondition matches people whose alias or unique name contains the
+// search term, or whose pubkey is the term. The columns are compared with ILIKE
+// and not LOWER(column) so the trigram indexes can be used
+func peopleSearchCondition(search string) (string, []interface{}) {
+	pattern := "%" + escapeLike(search) + "%"
+	return `(owner_alias ILIKE ? ESCAPE '\' OR unique_name ILIKE ? ESCAPE '\' OR owner_pub_key = ?)`,
+		[]interface{}{pattern, pattern, search}
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeopleSearchCondition(t *testing.T) {
+	t.Run("should match alias, unique name and pubkey", func(t *testing.T) {
+		condition, args := peopleSearchCondition("Alice")
+		assert.Equal(t, `(owner_alias ILIKE ? ESCAPE '\' OR unique_name ILIKE ? ESCAPE '\' OR owner_pub_key = ?)`, condition)
+		assert.Equal(t, []interface{}{"%Alice%", "%Alice%", "Alice"}, args)
+	})
+
+	t.Run("should escape like wildcards in the term", func(t *testing.T) {
+		_, args := peopleSearchCondition(`50%_off\`)
+		assert.Equal(t, `%50\%\_off\\%`, args[0])
+	})
+}
+
+// BenchmarkPeopleSearch compares the LOWER(column) LIKE query people search used to run,
+// which can't use an index and scans the table, with the ILIKE query the trigram indexes serve.
+// It needs the test DB: go test ./db -run '^$' -bench BenchmarkPeopleSearch
+func BenchmarkPeopleSearch(b *testing.B) {
+	connected := func() (ok bool) {
+		defer func() {
+			if r := recover(); r != nil {
+				ok = false
+			}
+		}()
+		InitTestDB()
+		return true
+	}()
+	if !connected {
+		b.Skip("test DB is not available")
+	}
+	CleanTestData()
+
+	people := make([]Person, 0, 50000)
+	for i := 0; i < cap(people); i++ {
+		people = append(people, Person{
+			Uuid:        fmt.Sprintf("uuid-%d", i),
+			OwnerPubKey: fmt.Sprintf("pubkey-%d", i),
+			OwnerAlias:  fmt.Sprintf("hunter %d", i),
+			UniqueName:  fmt.Sprintf("hunter_%d", i),
+		})
+	}
+	TestDB.db.CreateInBatches(people, 1000)
+	TestDB.db.Exec("ANALYZE people")
+	defer CleanTestData()
+
+	search := "nter 4999"
+
+	b.Run("lower like", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ms := []Person{}
+			TestDB.db.Where("LOWER(owner_alias) LIKE ? OR LOWER(unique_name) LIKE ? OR LOWER(owner_pub_key) = ?", "%"+search+"%", "%"+search+"%", search).Limit(20).Find(&ms)
+		}
+	})
+
+	b.Run("trigram", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ms := []Person{}
+			condition, args := peopleSearchCondition(search)
+			TestDB.db.Where(condition, args...).Limit(20).Find(&ms)
+		}
+	})
+}
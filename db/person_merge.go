@@ -0,0 +1,134 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPersonMergeSamePubkey = errors.New("cannot merge a person into itself")
+	ErrPersonMergeNotFound   = errors.New("person to merge not found")
+)
+
+type personMergeColumn struct {
+	table  string
+	column string
+	// unique are the other columns a person can only have one row for,
+	// a source row that the target already has is dropped instead of re-pointed
+	unique []string
+}
+
+// personMergeColumns are the columns that hold a person's pubkey,
+// the bounties, payments and memberships a merge moves to the target
+var personMergeColumns = []personMergeColumn{
+	{table: "bounty", column: "owner_id"},
+	{table: "bounty", column: "assignee"},
+	{table: "bounty_assignee_histories", column: "assignee"},
+	{table: "bounty_applications", column: "applicant_pubkey"},
+	{table: "bounty_bids", column: "bidder_pubkey"},
+	{table: "bounty_hours_logs", column: "pubkey"},
+	{table: "bounty_comments", column: "author_pubkey"},
+	{table: "payment_histories", column: "sender_pub_key"},
+	{table: "payment_histories", column: "receiver_pub_key"},
+	{table: "invoice_lists", column: "owner_pubkey"},
+	{table: "notifications", column: "recipient_pubkey"},
+	{table: "workspaces", column: "owner_pub_key"},
+	{table: "workspace_users", column: "owner_pub_key", unique: []string{"workspace_uuid"}},
+	{table: "workspace_user_roles", column: "owner_pub_key", unique: []string{"workspace_uuid", "role"}},
+	{table: "parent_organization_members", column: "owner_pub_key", unique: []string{"org_uuid", "role"}},
+}
+
+func (c personMergeColumn) key() string {
+	return c.table + "." + c.column
+}
+
+// dropDuplicates deletes the source rows the target already has a row for
+func (c personMergeColumn) dropDuplicates(tx *gorm.DB, source string, target string) error {
+	match := "target." + c.column + " = ?"
+	for _, col := range c.unique {
+		match += " AND target." + col + " = " + c.table + "." + col
+	}
+	return tx.Exec("DELETE FROM "+c.table+" WHERE "+c.column+" = ? AND EXISTS (SELECT 1 FROM "+c.table+" target WHERE "+match+")", source, target).Error
+}
+
+// MergePeople moves everything that points at the source pubkey to the target
+// and soft deletes the source person, a dry run only counts the rows it would move.
+// The counts are the source rows per column, memberships the target already has
+// are dropped rather than duplicated
+func (db database) MergePeople(source string, target string, mergedBy string, dryRun bool) (PersonMergeResult, error) {
+	result := PersonMergeResult{
+		SourcePubkey: source,
+		TargetPubkey: target,
+		DryRun:       dryRun,
+		Counts:       PersonMergeCounts{},
+	}
+	if source == target {
+		return result, ErrPersonMergeSamePubkey
+	}
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		var people int64
+		if err := tx.Model(&Person{}).
+			Where("owner_pub_key IN ? AND (deleted = false OR deleted IS NULL)", []string{source, target}).
+			Count(&people).Error; err != nil {
+			return err
+		}
+		if people != 2 {
+			return ErrPersonMergeNotFound
+		}
+
+		for _, c := range personMergeColumns {
+			var count int64
+			if err := tx.Table(c.table).Where(c.column+" = ?", source).Count(&count).Error; err != nil {
+				return err
+			}
+			result.Counts[c.key()] = count
+			if dryRun || count == 0 {
+				continue
+			}
+
+			if len(c.unique) > 0 {
+				if err := c.dropDuplicates(tx, source, target); err != nil {
+					return err
+				}
+			}
+			if err := tx.Table(c.table).Where(c.column+" = ?", source).Update(c.column, target).Error; err != nil {
+				return err
+			}
+		}
+
+		if dryRun {
+			return nil
+		}
+
+		now := time.Now()
+		if err := tx.Model(&Person{}).Where("owner_pub_key = ?", source).Updates(map[string]interface{}{
+			"deleted": true,
+			"updated": &now,
+		}).Error; err != nil {
+			return err
+		}
+
+		merge := PersonMerge{
+			SourcePubkey: source,
+			TargetPubkey: target,
+			MergedBy:     mergedBy,
+			Counts:       result.Counts,
+			Created:      &now,
+		}
+		if err := tx.Create(&merge).Error; err != nil {
+			return err
+		}
+		result.Merge = &merge
+		return nil
+	})
+	return result, err
+}
+
+func (db database) GetPersonMerges() []PersonMerge {
+	ms := []PersonMerge{}
+	db.db.Model(&PersonMerge{}).Order("created DESC").Find(&ms)
+	return ms
+}
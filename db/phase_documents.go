@@ -0,0 +1,63 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AddPhaseDocument stores content as the next version of a phase document,
+// existing versions are never modified. The phase row is locked while the
+// version is picked, so two saves can't take the same version
+func (db database) AddPhaseDocument(doc PhaseDocument) (PhaseDocument, error) {
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		phase := FeaturePhase{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", doc.PhaseUuid).First(&phase).Error; err != nil {
+			return err
+		}
+
+		var latest int
+		if err := tx.Model(&PhaseDocument{}).
+			Where("phase_uuid = ? AND doc_type = ?", doc.PhaseUuid, doc.DocType).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		doc.ID = 0
+		doc.Uuid = xid.New().String()
+		doc.Version = latest + 1
+		doc.Created = &now
+
+		return tx.Create(&doc).Error
+	})
+	return doc, err
+}
+
+func (db database) GetLatestPhaseDocument(phaseUuid string, docType PhaseDocumentType) (PhaseDocument, error) {
+	doc := PhaseDocument{}
+	result := db.db.Model(&PhaseDocument{}).Where("phase_uuid = ? AND doc_type = ?", phaseUuid, docType).Order("version DESC").First(&doc)
+	if result.RowsAffected == 0 {
+		return doc, errors.New("no phase document found")
+	}
+	return doc, nil
+}
+
+func (db database) GetPhaseDocumentByVersion(phaseUuid string, docType PhaseDocumentType, version int) (PhaseDocument, error) {
+	doc := PhaseDocument{}
+	result := db.db.Model(&PhaseDocument{}).Where("phase_uuid = ? AND doc_type = ? AND version = ?", phaseUuid, docType, version).First(&doc)
+	if result.RowsAffected == 0 {
+		return doc, errors.New("no phase document found")
+	}
+	return doc, nil
+}
+
+func (db database) GetPhaseDocumentHistory(phaseUuid string, docType PhaseDocumentType) []PhaseDocument {
+	docs := []PhaseDocument{}
+	db.db.Model(&PhaseDocument{}).Where("phase_uuid = ? AND doc_type = ?", phaseUuid, docType).Order("version DESC").Find(&docs)
+	return docs
+}
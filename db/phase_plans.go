@@ -0,0 +1,193 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrPhasePlanNotFound = errors.New("phase plan not found")
+
+var ErrPhasePlanFinished = errors.New("phase plan is already finished")
+
+var ErrTicketBatchNotRollbackable = errors.New("only the drafts of a completed plan can be rolled back")
+
+var ErrTicketBatchInUse = errors.New("some drafts of the batch were assigned or paid")
+
+func (db database) CreatePhasePlan(plan PhasePlan) (PhasePlan, error) {
+	now := time.Now()
+	plan.Created = &now
+	plan.Updated = &now
+	if err := db.db.Create(&plan).Error; err != nil {
+		return PhasePlan{}, err
+	}
+	return plan, nil
+}
+
+// UpdatePhasePlan saves the status of a plan and the project that runs it
+func (db database) UpdatePhasePlan(plan PhasePlan) (PhasePlan, error) {
+	now := time.Now()
+	plan.Updated = &now
+	err := db.db.Model(&PhasePlan{}).Where("uuid = ?", plan.Uuid).Updates(map[string]interface{}{
+		"status":     plan.Status,
+		"project_id": plan.ProjectId,
+		"error":      plan.Error,
+		"updated":    plan.Updated,
+		"completed":  plan.Completed,
+	}).Error
+	if err != nil {
+		return PhasePlan{}, err
+	}
+	return plan, nil
+}
+
+func (db database) GetPhasePlanByUuid(uuid string) (PhasePlan, error) {
+	plan := PhasePlan{}
+	db.db.Where("uuid = ?", uuid).Find(&plan)
+	if plan.ID == 0 {
+		return PhasePlan{}, ErrPhasePlanNotFound
+	}
+	plan.Drafts = []PhasePlanDraft{}
+	db.db.Where("plan_uuid = ?", uuid).Order("position ASC").Find(&plan.Drafts)
+	return plan, nil
+}
+
+// GetPhasePlans lists the plans of a phase with their drafts, newest first
+func (db database) GetPhasePlans(featureUuid string, phaseUuid string) []PhasePlan {
+	plans := []PhasePlan{}
+	db.db.Where("feature_uuid = ? AND phase_uuid = ?", featureUuid, phaseUuid).Order("id DESC").Find(&plans)
+	if len(plans) == 0 {
+		return plans
+	}
+
+	uuids := []string{}
+	for _, plan := range plans {
+		uuids = append(uuids, plan.Uuid)
+	}
+	drafts := []PhasePlanDraft{}
+	db.db.Where("plan_uuid IN ?", uuids).Order("position ASC").Find(&drafts)
+	for i := range plans {
+		plans[i].Drafts = []PhasePlanDraft{}
+		for _, draft := range drafts {
+			if draft.PlanUuid == plans[i].Uuid {
+				plans[i].Drafts = append(plans[i].Drafts, draft)
+			}
+		}
+	}
+	return plans
+}
+
+// SavePhasePlanTickets creates the ticket drafts of a plan with their
+// provenance and completes the plan, all or nothing. A plan is only completed
+// once, so a result posted twice doesn't create the drafts twice
+func (db database) SavePhasePlanTickets(planUuid string, tickets []PhasePlanTicket) (PhasePlan, error) {
+	plan := PhasePlan{}
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		found := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", planUuid).Limit(1).Find(&plan)
+		if found.Error != nil {
+			return found.Error
+		}
+		if found.RowsAffected == 0 {
+			return ErrPhasePlanNotFound
+		}
+		if plan.Finished() {
+			return ErrPhasePlanFinished
+		}
+
+		now := time.Now()
+		plan.Drafts = []PhasePlanDraft{}
+		for _, ticket := range tickets {
+			bounty := ticket.Bounty
+			bounty.State = bounty.CurrentState()
+			if err := tx.Create(&bounty).Error; err != nil {
+				return err
+			}
+
+			draft := ticket.Draft
+			draft.PlanUuid = plan.Uuid
+			draft.BountyID = bounty.ID
+			draft.Created = &now
+			if err := tx.Create(&draft).Error; err != nil {
+				return err
+			}
+			plan.Drafts = append(plan.Drafts, draft)
+		}
+
+		plan.Status = PhasePlanCompleted
+		plan.Error = ""
+		plan.Updated = &now
+		plan.Completed = &now
+		return tx.Model(&PhasePlan{}).Where("id = ?", plan.ID).Updates(map[string]interface{}{
+			"status":    plan.Status,
+			"error":     plan.Error,
+			"updated":   plan.Updated,
+			"completed": plan.Completed,
+		}).Error
+	})
+	if err != nil {
+		return PhasePlan{}, err
+	}
+	return plan, nil
+}
+
+// RollbackPhasePlan deletes the drafts of a completed plan as a batch. Drafts
+// someone was assigned or paid for are kept and block the rollback, they come
+// back with ErrTicketBatchInUse. Drafts deleted by hand are skipped
+func (db database) RollbackPhasePlan(planUuid string, pubkey string, now time.Time) (TicketBatchRollback, error) {
+	result := TicketBatchRollback{PlanUuid: planUuid, Deleted: []uint{}, Blocking: []uint{}}
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		plan := PhasePlan{}
+		found := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", planUuid).Limit(1).Find(&plan)
+		if found.Error != nil {
+			return found.Error
+		}
+		if found.RowsAffected == 0 {
+			return ErrPhasePlanNotFound
+		}
+		if plan.Status != PhasePlanCompleted {
+			return ErrTicketBatchNotRollbackable
+		}
+
+		drafts := []PhasePlanDraft{}
+		if err := tx.Where("plan_uuid = ?", planUuid).Find(&drafts).Error; err != nil {
+			return err
+		}
+		ids := []uint{}
+		for _, draft := range drafts {
+			ids = append(ids, draft.BountyID)
+		}
+
+		bounties := []NewBounty{}
+		if len(ids) > 0 {
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", ids).Order("id ASC").Find(&bounties).Error; err != nil {
+				return err
+			}
+		}
+		for _, bounty := range bounties {
+			if bounty.Assignee != "" || bounty.Paid {
+				result.Blocking = append(result.Blocking, bounty.ID)
+			} else {
+				result.Deleted = append(result.Deleted, bounty.ID)
+			}
+		}
+		if len(result.Blocking) > 0 {
+			result.Deleted = []uint{}
+			return ErrTicketBatchInUse
+		}
+
+		if len(result.Deleted) > 0 {
+			if err := tx.Where("id IN ?", result.Deleted).Delete(&NewBounty{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&PhasePlan{}).Where("id = ?", plan.ID).Updates(map[string]interface{}{
+			"status":         PhasePlanRolledBack,
+			"rolled_back":    now,
+			"rolled_back_by": pubkey,
+			"updated":        now,
+		}).Error
+	})
+	return result, err
+}
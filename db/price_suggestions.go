@@ -0,0 +1,152 @@
+package db
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MinPriceSamples is how many paid bounties a price stat needs to be used for a suggestion
+const MinPriceSamples = 3
+
+// priceSamplesForFullConfidence is the sample size from which the number of
+// paid bounties no longer lowers the confidence of a suggestion
+const priceSamplesForFullConfidence = 20
+
+// BountyScopeFor buckets estimated hours, bounties without an estimate have no scope
+func BountyScopeFor(estimatedHours float64) BountyScope {
+	switch {
+	case estimatedHours <= 0:
+		return ""
+	case estimatedHours <= 4:
+		return BountyScopeSmall
+	case estimatedHours <= 16:
+		return BountyScopeMedium
+	}
+	return BountyScopeLarge
+}
+
+const priceStatsSelect = `
+SELECT %s, %s, %s, COUNT(*), MIN(price),
+ROUND(percentile_cont(0.25) WITHIN GROUP (ORDER BY price)),
+ROUND(percentile_cont(0.5) WITHIN GROUP (ORDER BY price)),
+ROUND(percentile_cont(0.75) WITHIN GROUP (ORDER BY price)),
+MAX(price), ?`
+
+// RefreshBountyPriceStats rebuilds the price spreads of the paid bounties. Rows are
+// kept per workspace, language and scope and for each of them across all the others,
+// the language rows are built separately since a bounty can have several languages
+func (db database) RefreshBountyPriceStats() error {
+	now := time.Now()
+	prices := `FROM (
+SELECT COALESCE(workspace_uuid, '') AS workspace_uuid, coding_languages, CAST(price AS bigint) AS price,
+CASE WHEN estimated_hours <= 0 THEN '' WHEN estimated_hours <= 4 THEN 'small' WHEN estimated_hours <= 16 THEN 'medium' ELSE 'large' END AS scope
+FROM bounty WHERE paid = true AND price > 0
+) AS prices`
+	all := func(column string) string {
+		return "CASE WHEN GROUPING(" + column + ") = 1 THEN '*' ELSE " + column + " END"
+	}
+
+	return db.inTransaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM bounty_price_stats`).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`INSERT INTO bounty_price_stats
+(workspace_uuid, language, scope, bounties, min_sats, p25_sats, median_sats, p75_sats, max_sats, refreshed)`+
+			fmt.Sprintf(priceStatsSelect, all("workspace_uuid"), "'*'", all("scope"))+` `+prices+`
+GROUP BY GROUPING SETS ((workspace_uuid, scope), (workspace_uuid), (scope), ())
+UNION ALL`+
+			fmt.Sprintf(priceStatsSelect, all("workspace_uuid"), "language", all("scope"))+` `+prices+`, unnest(coding_languages) AS language
+GROUP BY GROUPING SETS ((workspace_uuid, language, scope), (workspace_uuid, language), (language, scope), (language))`, now, now).Error
+	})
+}
+
+// GetBountyPriceStats returns the price stats of a workspace and the ones across all workspaces
+func (db database) GetBountyPriceStats(workspaceUuid string) []BountyPriceStat {
+	ms := []BountyPriceStat{}
+	db.db.Model(&BountyPriceStat{}).Where("workspace_uuid IN ?", []string{workspaceUuid, PriceStatAll}).Find(&ms)
+	return ms
+}
+
+// SuggestBountyPrice picks the most specific price stat with enough paid bounties,
+// from the workspace, language and scope down to all bounties. When several languages
+// are given the one with the most paid bounties is used. The confidence drops with
+// every step away from the most specific stat and with fewer paid bounties
+func SuggestBountyPrice(stats []BountyPriceStat, workspaceUuid string, languages []string, scope BountyScope) (PriceSuggestion, bool) {
+	find := func(workspace string, byLanguage bool, scope string) (BountyPriceStat, bool) {
+		best := BountyPriceStat{}
+		for _, stat := range stats {
+			if stat.WorkspaceUuid != workspace || stat.Scope != scope || stat.Bounties < MinPriceSamples {
+				continue
+			}
+			if byLanguage != (stat.Language != PriceStatAll) {
+				continue
+			}
+			if byLanguage && !containsString(languages, stat.Language) {
+				continue
+			}
+			if stat.Bounties > best.Bounties {
+				best = stat
+			}
+		}
+		return best, best.Bounties > 0
+	}
+
+	step := 0
+	for _, workspace := range []string{workspaceUuid, PriceStatAll} {
+		for _, byLanguage := range []bool{true, false} {
+			for _, byScope := range []bool{true, false} {
+				step++
+				if workspace == "" || (byLanguage && len(languages) == 0) || (byScope && scope == "") {
+					continue
+				}
+				statScope := PriceStatAll
+				if byScope {
+					statScope = string(scope)
+				}
+				stat, ok := find(workspace, byLanguage, statScope)
+				if !ok {
+					continue
+				}
+				return newPriceSuggestion(stat, step), true
+			}
+		}
+	}
+	return PriceSuggestion{}, false
+}
+
+func newPriceSuggestion(stat BountyPriceStat, step int) PriceSuggestion {
+	specificity := math.Max(0.3, 1-0.1*float64(step-1))
+	samples := math.Min(1, float64(stat.Bounties)/priceSamplesForFullConfidence)
+	confidence := math.Round(specificity*samples*100) / 100
+
+	level := "low"
+	if confidence >= 0.6 {
+		level = "high"
+	} else if confidence >= 0.3 {
+		level = "medium"
+	}
+
+	return PriceSuggestion{
+		SuggestedSats:   stat.MedianSats,
+		LowSats:         stat.P25Sats,
+		HighSats:        stat.P75Sats,
+		Confidence:      confidence,
+		ConfidenceLevel: level,
+		SampleSize:      stat.Bounties,
+		WorkspaceUuid:   stat.WorkspaceUuid,
+		Language:        stat.Language,
+		Scope:           stat.Scope,
+	}
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
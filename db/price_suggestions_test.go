@@ -0,0 +1,64 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestBountyPrice(t *testing.T) {
+	stats := []BountyPriceStat{
+		{WorkspaceUuid: "ws", Language: "Go", Scope: "medium", Bounties: 2, P25Sats: 1, MedianSats: 1, P75Sats: 1},
+		{WorkspaceUuid: "ws", Language: "Go", Scope: PriceStatAll, Bounties: 10, P25Sats: 8000, MedianSats: 10000, P75Sats: 12000},
+		{WorkspaceUuid: "ws", Language: "Rust", Scope: PriceStatAll, Bounties: 4, P25Sats: 20000, MedianSats: 25000, P75Sats: 30000},
+		{WorkspaceUuid: "ws", Language: PriceStatAll, Scope: PriceStatAll, Bounties: 14, P25Sats: 9000, MedianSats: 12000, P75Sats: 20000},
+		{WorkspaceUuid: PriceStatAll, Language: "Python", Scope: "small", Bounties: 40, P25Sats: 2000, MedianSats: 3000, P75Sats: 4000},
+		{WorkspaceUuid: PriceStatAll, Language: PriceStatAll, Scope: PriceStatAll, Bounties: 100, P25Sats: 5000, MedianSats: 7000, P75Sats: 9000},
+	}
+
+	t.Run("should skip stats with too few paid bounties", func(t *testing.T) {
+		suggestion, ok := SuggestBountyPrice(stats, "ws", []string{"Go"}, BountyScopeMedium)
+		assert.True(t, ok)
+		assert.Equal(t, uint(10000), suggestion.SuggestedSats)
+		assert.Equal(t, uint(8000), suggestion.LowSats)
+		assert.Equal(t, uint(12000), suggestion.HighSats)
+		assert.Equal(t, "Go", suggestion.Language)
+		assert.Equal(t, PriceStatAll, suggestion.Scope)
+		assert.Equal(t, 0.45, suggestion.Confidence)
+		assert.Equal(t, "medium", suggestion.ConfidenceLevel)
+	})
+
+	t.Run("should use the language with the most paid bounties", func(t *testing.T) {
+		suggestion, ok := SuggestBountyPrice(stats, "ws", []string{"Rust", "Go"}, "")
+		assert.True(t, ok)
+		assert.Equal(t, "Go", suggestion.Language)
+	})
+
+	t.Run("should fall back to all the workspace bounties", func(t *testing.T) {
+		suggestion, ok := SuggestBountyPrice(stats, "ws", []string{"Python"}, BountyScopeSmall)
+		assert.True(t, ok)
+		assert.Equal(t, "ws", suggestion.WorkspaceUuid)
+		assert.Equal(t, uint(12000), suggestion.SuggestedSats)
+	})
+
+	t.Run("should fall back to all workspaces", func(t *testing.T) {
+		suggestion, ok := SuggestBountyPrice(stats, "other", []string{"Python"}, BountyScopeSmall)
+		assert.True(t, ok)
+		assert.Equal(t, PriceStatAll, suggestion.WorkspaceUuid)
+		assert.Equal(t, uint(3000), suggestion.SuggestedSats)
+		assert.Equal(t, 0.6, suggestion.Confidence)
+		assert.Equal(t, "high", suggestion.ConfidenceLevel)
+	})
+
+	t.Run("should not suggest without paid bounties", func(t *testing.T) {
+		_, ok := SuggestBountyPrice([]BountyPriceStat{}, "ws", []string{"Go"}, BountyScopeSmall)
+		assert.False(t, ok)
+	})
+}
+
+func TestBountyScopeFor(t *testing.T) {
+	assert.Equal(t, BountyScope(""), BountyScopeFor(0))
+	assert.Equal(t, BountyScopeSmall, BountyScopeFor(4))
+	assert.Equal(t, BountyScopeMedium, BountyScopeFor(4.5))
+	assert.Equal(t, BountyScopeLarge, BountyScopeFor(40))
+}
@@ -0,0 +1,65 @@
+package db
+
+// ListPublicTribes returns a page of the tribes that are not deleted, unlisted or private,
+// the biggest first, and how many there are in total
+func (db database) ListPublicTribes(filter ListFilter) ([]Tribe, int64, error) {
+	ms := []Tribe{}
+	var total int64
+
+	query := db.db.Model(&Tribe{}).
+		Where("(deleted = false OR deleted IS NULL) AND (unlisted = false OR unlisted IS NULL) AND (private = false OR private IS NULL)")
+	if filter.Search != "" {
+		query = query.Where(`name ILIKE ? ESCAPE '\'`, "%"+escapeLike(filter.Search)+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return ms, 0, err
+	}
+	err := query.Order("member_count DESC, uuid ASC").Offset(filter.Offset).Limit(filter.Limit).Find(&ms).Error
+	return ms, total, err
+}
+
+// ListPublicPeople returns a page of the listed people, the newest first
+func (db database) ListPublicPeople(filter ListFilter) ([]PersonInShort, int64, error) {
+	ms := []PersonInShort{}
+	var total int64
+
+	query := db.db.Model(&Person{}).
+		Where("(deleted = false OR deleted IS NULL) AND (unlisted = false OR unlisted IS NULL)")
+	if filter.Search != "" {
+		condition, args := peopleSearchCondition(filter.Search)
+		query = query.Where(condition, args...)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return ms, 0, err
+	}
+	err := query.Order("created DESC, id DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&ms).Error
+	return ms, total, err
+}
+
+// ListPublicBounties returns a page of the shown bounties of workspaces that are not deleted,
+// the newest first
+func (db database) ListPublicBounties(filter ListFilter) ([]NewBounty, int64, error) {
+	ms := []NewBounty{}
+	var total int64
+
+	query := db.db.Model(&NewBounty{}).
+		Joins("LEFT JOIN workspaces ON workspaces.uuid = bounty.workspace_uuid").
+		Where("bounty.show = true AND bounty.visibility = 'public' AND (workspaces.uuid IS NULL OR workspaces.deleted = false)")
+	if filter.Search != "" {
+		query = query.Where(`bounty.title ILIKE ? ESCAPE '\'`, "%"+escapeLike(filter.Search)+"%")
+	}
+	if filter.WorkspaceUuid != "" {
+		query = query.Where("bounty.workspace_uuid = ?", filter.WorkspaceUuid)
+	}
+	if filter.State != "" {
+		query = query.Where("bounty.state = ?", filter.State)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return ms, 0, err
+	}
+	err := query.Select("bounty.*").Order("bounty.created DESC, bounty.id DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&ms).Error
+	return ms, total, err
+}
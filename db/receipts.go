@@ -0,0 +1,80 @@
+package db
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"gorm.io/gorm/clause"
+)
+
+// SignReceipt returns the hex encoded HMAC-SHA256 of a receipt
+func SignReceipt(receipt []byte) string {
+	mac := hmac.New(sha256.New, []byte(config.ReceiptSigningKey))
+	mac.Write(receipt)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifyReceipt(receipt []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(config.ReceiptSigningKey))
+	mac.Write(receipt)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func (db database) buildBountyReceipt(payment NewPaymentHistory, bounty NewBounty) (BountyReceipt, error) {
+	workspace := db.GetWorkspaceByUuid(payment.WorkspaceUuid)
+
+	data, err := json.Marshal(BountyReceiptData{
+		BountyId:      bounty.ID,
+		Title:         bounty.Title,
+		Amount:        payment.Amount,
+		WorkspaceUuid: payment.WorkspaceUuid,
+		WorkspaceName: workspace.Name,
+		PayerPubKey:   payment.SenderPubKey,
+		HunterPubKey:  payment.ReceiverPubKey,
+		PaymentHash:   payment.PaymentHash,
+		PaidDate:      bounty.PaidDate,
+	})
+	if err != nil {
+		return BountyReceipt{}, err
+	}
+
+	now := time.Now()
+	return BountyReceipt{
+		BountyId:  bounty.ID,
+		PaymentId: payment.ID,
+		Receipt:   string(data),
+		Signature: SignReceipt(data),
+		Created:   &now,
+	}, nil
+}
+
+// saveBountyReceipt writes the receipt of a paid bounty, it replaces the
+// receipt of an earlier payment of the bounty
+func (db database) saveBountyReceipt(payment NewPaymentHistory, bounty NewBounty) error {
+	receipt, err := db.buildBountyReceipt(payment, bounty)
+	if err != nil {
+		return err
+	}
+	return db.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bounty_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"payment_id", "receipt", "signature", "created"}),
+	}).Create(&receipt).Error
+}
+
+func (db database) GetBountyReceipt(bountyId uint) (BountyReceipt, error) {
+	receipt := BountyReceipt{}
+	result := db.db.Model(&BountyReceipt{}).Where("bounty_id = ?", bountyId).First(&receipt)
+	if result.RowsAffected == 0 {
+		return receipt, errors.New("no bounty receipt found")
+	}
+	return receipt, nil
+}
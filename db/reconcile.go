@@ -0,0 +1,78 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GetPendingInvoices returns the invoices of every workspace that are not settled yet
+func (db database) GetPendingInvoices() []NewInvoiceList {
+	ms := []NewInvoiceList{}
+	db.db.Where("status = ?", false).Order("created ASC").Find(&ms)
+	return ms
+}
+
+// GetUnmarkedBountyPayments returns the successful bounty payments
+// whose bounty was never marked as paid
+func (db database) GetUnmarkedBountyPayments() []NewPaymentHistory {
+	ms := []NewPaymentHistory{}
+	db.db.Raw(`SELECT payment.* FROM payment_histories AS payment
+		INNER JOIN bounty ON bounty.id = payment.bounty_id
+		WHERE payment.payment_type = ? AND payment.status = true AND bounty.paid = false
+		ORDER BY payment.created ASC`, "payment").Scan(&ms)
+	return ms
+}
+
+// MarkBountyPaid sets a bounty as paid at paidDate, it is completed then too
+// unless it was completed before
+func (db database) MarkBountyPaid(bountyId uint, paidDate *time.Time) error {
+	return db.db.Model(&NewBounty{}).Where("id = ?", bountyId).Updates(map[string]interface{}{
+		"paid":            true,
+		"paid_date":       paidDate,
+		"completed":       true,
+		"completion_date": gorm.Expr("COALESCE(completion_date, ?)", paidDate),
+		"state":           BountyStatePaid,
+	}).Error
+}
+
+// GetOpenBudgetReservations returns the reservations that still hold budget
+// and have not moved since before, a payment that held them never finished
+func (db database) GetOpenBudgetReservations(before time.Time) []BudgetReservation {
+	ms := []BudgetReservation{}
+	db.db.Raw(`SELECT SUBSTRING(account FROM ?) AS id, MAX(workspace_uuid) AS workspace_uuid,
+		COALESCE(SUM(credit), 0) - COALESCE(SUM(debit), 0) AS amount
+		FROM budget_ledger_entries
+		WHERE account LIKE 'reservation:%' AND currency = ?
+		GROUP BY account
+		HAVING COALESCE(SUM(credit), 0) - COALESCE(SUM(debit), 0) > 0 AND MAX(created) < ?`,
+		len(ReservationAccount(""))+1, DefaultBudgetCurrency, before).Scan(&ms)
+	return ms
+}
+
+// AddUnsettledBountyPayment records a bounty payment with no known outcome, it
+// may have reached the hunter so it holds the bounty until it is reconciled
+func (db database) AddUnsettledBountyPayment(payment NewPaymentHistory) error {
+	payment.Status = false
+	payment.PaymentType = Payment
+	return db.db.Create(&payment).Error
+}
+
+// HasUnsettledBountyPayment tells if a bounty has a payment with no known outcome
+func (db database) HasUnsettledBountyPayment(bountyId uint) bool {
+	var count int64
+	db.db.Model(&NewPaymentHistory{}).Where("bounty_id = ? AND payment_type = ? AND status = false AND onchain_dropped IS NULL", bountyId, Payment).Count(&count)
+	return count > 0
+}
+
+// GetUnsettledBountyPayments returns the bounty payments with no known outcome
+// of the bounties that are still not paid
+func (db database) GetUnsettledBountyPayments() []NewPaymentHistory {
+	ms := []NewPaymentHistory{}
+	db.db.Raw(`SELECT payment.* FROM payment_histories AS payment
+		INNER JOIN bounty ON bounty.id = payment.bounty_id
+		WHERE payment.payment_type = ? AND payment.status = false AND payment.onchain_dropped IS NULL
+		AND bounty.paid = false
+		ORDER BY payment.created ASC`, Payment).Scan(&ms)
+	return ms
+}
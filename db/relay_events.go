@@ -0,0 +1,101 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// recordRelayEvent logs a relay event, it returns false when the event was seen before
+func recordRelayEvent(tx *gorm.DB, event RelayEvent, received time.Time) (bool, error) {
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&RelayEventLog{
+		EventId:   event.Id,
+		Type:      event.Type,
+		TribeUuid: event.TribeUuid,
+		Received:  &received,
+	})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (db database) RecordRelayEvent(event RelayEvent, received time.Time) (bool, error) {
+	return recordRelayEvent(db.db, event, received)
+}
+
+// ApplyRelayTribeEvent adds the members and messages of a relay event to the
+// stats of its tribe, an event that was applied before returns false
+func (db database) ApplyRelayTribeEvent(event RelayEvent, members uint64, messages uint64, received time.Time) (bool, error) {
+	applied := false
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		recorded, err := recordRelayEvent(tx, event, received)
+		if err != nil || !recorded {
+			return err
+		}
+
+		at := received.Unix()
+		if event.Timestamp > 0 && event.Timestamp < at {
+			at = event.Timestamp
+		}
+		result := tx.Model(&Tribe{}).
+			Where("uuid = ? AND (deleted = false OR deleted IS NULL)", event.TribeUuid).
+			UpdateColumns(map[string]interface{}{
+				"member_count":  gorm.Expr("COALESCE(member_count, 0) + ?", members),
+				"message_count": gorm.Expr("COALESCE(message_count, 0) + ?", messages),
+				"last_active":   gorm.Expr("GREATEST(COALESCE(last_active, 0), ?)", at),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		applied = true
+		return nil
+	})
+	return applied, err
+}
+
+// SettleRelayPaymentEvent settles the invoice of a payment confirmation and logs
+// the event in one transaction, so the event is only logged once the payment is
+// settled and a confirmation that could not be applied can be sent again. A budget
+// invoice credits its deposit. It returns false when the event was applied before
+func (db database) SettleRelayPaymentEvent(event RelayEvent, invoice NewInvoiceList, received time.Time) (bool, error) {
+	recorded := false
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		locked := NewInvoiceList{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("payment_request = ?", invoice.PaymentRequest).
+			First(&locked).Error; err != nil {
+			return err
+		}
+
+		// an invoice settled since it was read, by this event or another one, is not credited again
+		if !locked.Status {
+			if locked.Type == Budget {
+				deposit := NewPaymentHistory{}
+				if err := tx.Where("created = ? AND workspace_uuid = ?", locked.Created, locked.WorkspaceUuid).
+					Limit(1).Find(&deposit).Error; err != nil {
+					return err
+				}
+				if deposit.WorkspaceUuid == "" || deposit.Amount == 0 {
+					return errors.New("no deposit was recorded for the budget invoice")
+				}
+				if err := depositPayment(tx, deposit); err != nil {
+					return err
+				}
+			}
+			if err := tx.Model(&NewInvoiceList{}).Where("payment_request = ?", locked.PaymentRequest).Update("status", true).Error; err != nil {
+				return err
+			}
+		}
+
+		var err error
+		recorded, err = recordRelayEvent(tx, event, received)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return recorded, nil
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"strings"
+)
+
+const (
+	SearchTypeTribes   = "tribes"
+	SearchTypePeople   = "people"
+	SearchTypeBounties = "bounties"
+	SearchTypeFeatures = "features"
+)
+
+var SearchTypes = []string{SearchTypeTribes, SearchTypePeople, SearchTypeBounties, SearchTypeFeatures}
+
+// searchQueries select the public fields of each type as a SearchHit, rows
+// that are deleted, unlisted, private or in a hidden workspace never match
+var searchQueries = map[string]struct {
+	from   string
+	fields string
+	order  string
+}{
+	SearchTypeTribes: {
+		from: `tribes t
+		WHERE (t.deleted = false OR t.deleted IS NULL)
+		AND (t.unlisted = false OR t.unlisted IS NULL)
+		AND (t.private = false OR t.private IS NULL)
+		AND (LOWER(t.name) LIKE @q ESCAPE '\' OR LOWER(t.description) LIKE @q ESCAPE '\')`,
+		fields: `t.uuid AS id, t.name AS title, t.description AS subtitle, t.img AS img`,
+		order:  `t.member_count DESC`,
+	},
+	SearchTypePeople: {
+		from: `people p
+		WHERE (p.deleted = false OR p.deleted IS NULL)
+		AND (p.unlisted = false OR p.unlisted IS NULL)
+		AND (p.owner_alias ILIKE @q ESCAPE '\' OR p.unique_name ILIKE @q ESCAPE '\')`,
+		fields: `p.uuid AS id, p.owner_alias AS title, p.description AS subtitle, p.img AS img`,
+		order:  `p.created DESC`,
+	},
+	SearchTypeBounties: {
+		from: `bounty b
+		LEFT JOIN workspaces w ON w.uuid = b.workspace_uuid
+		WHERE b.show = true AND b.visibility = 'public'
+		AND (w.uuid IS NULL OR w.deleted = false)
+		AND LOWER(b.title) LIKE @q ESCAPE '\'`,
+		fields: `CAST(b.id AS TEXT) AS id, b.title AS title, COALESCE(w.name, '') AS subtitle, COALESCE(w.img, '') AS img`,
+		order:  `b.created DESC`,
+	},
+	SearchTypeFeatures: {
+		from: `workspace_features f
+		INNER JOIN workspaces w ON w.uuid = f.workspace_uuid
+		WHERE w.show = true AND w.deleted = false
+		AND LOWER(f.name) LIKE @q ESCAPE '\'`,
+		fields: `f.uuid AS id, f.name AS title, w.name AS subtitle, w.img AS img`,
+		order:  `f.created DESC`,
+	},
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// PublicSearch matches the query against the names of the given types,
+// it counts the matches of each type and returns up to limit hits per type
+func (db database) PublicSearch(query string, types []string, limit int) (SearchResult, error) {
+	result := SearchResult{
+		Query:  query,
+		Facets: map[string]int64{},
+		Hits:   []SearchHit{},
+	}
+	q := "%" + escapeLike(strings.ToLower(query)) + "%"
+
+	for _, searchType := range types {
+		sq, ok := searchQueries[searchType]
+		if !ok {
+			continue
+		}
+
+		var count int64
+		if err := db.db.Raw("SELECT COUNT(*) FROM "+sq.from, map[string]interface{}{"q": q}).Scan(&count).Error; err != nil {
+			return result, err
+		}
+		result.Facets[searchType] = count
+		if count == 0 {
+			continue
+		}
+
+		hits := []SearchHit{}
+		if err := db.db.Raw("SELECT '"+searchType+"' AS type, "+sq.fields+" FROM "+sq.from+" ORDER BY "+sq.order+" LIMIT @limit",
+			map[string]interface{}{"q": q, "limit": limit}).Scan(&hits).Error; err != nil {
+			return result, err
+		}
+		result.Hits = append(result.Hits, hits...)
+	}
+
+	return result, nil
+}
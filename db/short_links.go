@@ -0,0 +1,92 @@
+package db
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrShortLinkNotFound = errors.New("short link not found")
+	ErrShortLinkExpired  = errors.New("short link has expired")
+)
+
+const (
+	shortLinkIdLength        = 8
+	shortLinkSignatureLength = 6
+)
+
+var shortLinkEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func signShortLinkId(id string) string {
+	mac := hmac.New(sha256.New, []byte(config.ShortLinkSigningKey))
+	mac.Write([]byte("short_link:" + id))
+	return strings.ToLower(shortLinkEncoding.EncodeToString(mac.Sum(nil)))[:shortLinkSignatureLength]
+}
+
+// NewShortLinkCode is a random id followed by its signature
+func NewShortLinkCode() string {
+	id := strings.ToLower(utils.GetRandomToken(shortLinkIdLength))
+	return id + signShortLinkId(id)
+}
+
+// VerifyShortLinkCode tells if a code was made by NewShortLinkCode with the current key
+func VerifyShortLinkCode(code string) bool {
+	if len(code) != shortLinkIdLength+shortLinkSignatureLength {
+		return false
+	}
+	id, signature := code[:shortLinkIdLength], code[shortLinkIdLength:]
+	return hmac.Equal([]byte(signature), []byte(signShortLinkId(id)))
+}
+
+func (db database) CreateShortLink(link ShortLink) (ShortLink, error) {
+	if link.Created == nil {
+		now := time.Now()
+		link.Created = &now
+	}
+	if err := db.db.Create(&link).Error; err != nil {
+		return ShortLink{}, err
+	}
+	return link, nil
+}
+
+func (db database) GetShortLinkByCode(code string) (ShortLink, error) {
+	ms := ShortLink{}
+	result := db.db.Model(&ShortLink{}).Where("code = ?", code).Limit(1).Find(&ms)
+	if result.Error != nil {
+		return ms, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ms, ErrShortLinkNotFound
+	}
+	return ms, nil
+}
+
+// FollowShortLink counts a click on a short link and returns it, an expired link is not counted
+func (db database) FollowShortLink(code string, now time.Time) (ShortLink, error) {
+	link, err := db.GetShortLinkByCode(code)
+	if err != nil {
+		return link, err
+	}
+	if link.ExpiresAt != nil && !now.Before(*link.ExpiresAt) {
+		return link, ErrShortLinkExpired
+	}
+
+	err = db.db.Model(&ShortLink{}).Where("id = ?", link.ID).UpdateColumns(map[string]interface{}{
+		"clicks":       gorm.Expr("clicks + 1"),
+		"last_clicked": now,
+	}).Error
+	if err != nil {
+		return link, err
+	}
+	link.Clicks++
+	link.LastClicked = &now
+	return link, nil
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortLinkCode(t *testing.T) {
+	signingKey := config.ShortLinkSigningKey
+	config.ShortLinkSigningKey = "short_link_key"
+	defer func() { config.ShortLinkSigningKey = signingKey }()
+
+	t.Run("should verify a new code", func(t *testing.T) {
+		code := NewShortLinkCode()
+		assert.Len(t, code, shortLinkIdLength+shortLinkSignatureLength)
+		assert.True(t, VerifyShortLinkCode(code))
+	})
+
+	t.Run("should turn down guessed and tampered codes", func(t *testing.T) {
+		code := NewShortLinkCode()
+		assert.False(t, VerifyShortLinkCode(""))
+		assert.False(t, VerifyShortLinkCode(code[:len(code)-1]))
+		assert.False(t, VerifyShortLinkCode("aaaaaaaa"+code[shortLinkIdLength:]))
+	})
+
+	t.Run("should turn down codes signed with another key", func(t *testing.T) {
+		code := NewShortLinkCode()
+		config.ShortLinkSigningKey = "rotated_key"
+		defer func() { config.ShortLinkSigningKey = "short_link_key" }()
+		assert.False(t, VerifyShortLinkCode(code))
+	})
+}
@@ -0,0 +1,35 @@
+package db
+
+import "gorm.io/gorm"
+
+// GetWorkspaceShowcase builds the public page of a workspace from its public,
+// shown bounties: the newest open ones and the open and completed counts
+func (db database) GetWorkspaceShowcase(workspace Workspace, limit int) (WorkspaceShowcase, error) {
+	showcase := WorkspaceShowcase{
+		Uuid:         workspace.Uuid,
+		Name:         workspace.Name,
+		Img:          workspace.Img,
+		Description:  workspace.Description,
+		Mission:      workspace.Mission,
+		Website:      workspace.Website,
+		Github:       workspace.Github,
+		OpenBounties: []ShowcaseBounty{},
+	}
+
+	public := func() *gorm.DB {
+		return db.db.Model(&NewBounty{}).Where("workspace_uuid = ? AND show != false AND "+publicBountiesQuery, workspace.Uuid)
+	}
+
+	err := public().
+		Where("state = ?", BountyStateOpen).
+		Order("created DESC").
+		Limit(limit).
+		Scan(&showcase.OpenBounties).Error
+	if err == nil {
+		err = public().Where("state = ?", BountyStateOpen).Count(&showcase.OpenBountiesCount).Error
+	}
+	if err == nil {
+		err = public().Where("state IN ?", []BountyState{BountyStateCompleted, BountyStatePaid}).Count(&showcase.CompletedBountiesCount).Error
+	}
+	return showcase, err
+}
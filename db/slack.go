@@ -0,0 +1,74 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+var ErrSlackLinkNotFound = errors.New("link code not found or expired")
+
+// CreateSlackLinkCode starts linking a Slack user, a new code replaces the one
+// the user had and a confirmed link keeps its person until the code is redeemed
+func (db database) CreateSlackLinkCode(teamId string, slackUserId string, code string, expires time.Time) error {
+	now := time.Now()
+	link := SlackUserLink{TeamId: teamId, SlackUserId: slackUserId, Code: code, CodeExpires: &expires, Created: &now}
+	return db.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "team_id"}, {Name: "slack_user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"code", "code_expires"}),
+	}).Create(&link).Error
+}
+
+// CompleteSlackLink links the Slack user of an unexpired code to a person, the
+// code can only be redeemed once
+func (db database) CompleteSlackLink(code string, pubkey string, now time.Time) (SlackUserLink, error) {
+	link := SlackUserLink{}
+	if code == "" {
+		return link, ErrSlackLinkNotFound
+	}
+	db.db.Where("code = ? AND code_expires > ?", code, now).First(&link)
+	if link.ID == 0 {
+		return SlackUserLink{}, ErrSlackLinkNotFound
+	}
+
+	err := db.db.Model(&SlackUserLink{}).Where("id = ?", link.ID).Updates(map[string]interface{}{
+		"owner_pubkey": pubkey,
+		"linked":       now,
+		"code":         "",
+		"code_expires": nil,
+	}).Error
+	if err != nil {
+		return SlackUserLink{}, err
+	}
+
+	link.OwnerPubkey = pubkey
+	link.Linked = &now
+	link.Code = ""
+	link.CodeExpires = nil
+	return link, nil
+}
+
+func (db database) GetSlackUserLink(teamId string, slackUserId string) SlackUserLink {
+	link := SlackUserLink{}
+	db.db.Where("team_id = ? AND slack_user_id = ?", teamId, slackUserId).Find(&link)
+	return link
+}
+
+func (db database) GetSlackTeam(teamId string) SlackTeam {
+	team := SlackTeam{}
+	db.db.Where("team_id = ?", teamId).Find(&team)
+	return team
+}
+
+// SetSlackTeamWorkspace connects a Slack team to a workspace, it replaces the
+// workspace the team was connected to
+func (db database) SetSlackTeamWorkspace(teamId string, workspaceUuid string, pubkey string) (SlackTeam, error) {
+	now := time.Now()
+	team := SlackTeam{TeamId: teamId, WorkspaceUuid: workspaceUuid, ConnectedBy: pubkey, Created: &now, Updated: &now}
+	err := db.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "team_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"workspace_uuid", "connected_by", "updated"}),
+	}).Create(&team).Error
+	return team, err
+}
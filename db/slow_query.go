@@ -0,0 +1,71 @@
+package db
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"gorm.io/gorm"
+)
+
+const slowQueryStartKey = "slow_query:start"
+
+// logSlowQuery prints a slow query, tests replace it to see the line
+var logSlowQuery = func(line string) {
+	fmt.Println(line)
+}
+
+// slowQueryPlugin logs the queries that take longer than DB_SLOW_QUERY_MS,
+// with the handler that ran them
+type slowQueryPlugin struct{}
+
+func (slowQueryPlugin) Name() string {
+	return "slow_query"
+}
+
+func (slowQueryPlugin) Initialize(db *gorm.DB) error {
+	return registerQueryCallbacks(db, "slow_query", startQueryTimer, logQueryIfSlow)
+}
+
+func startQueryTimer(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		tx.InstanceSet(slowQueryStartKey, time.Now())
+	}
+}
+
+func logQueryIfSlow(tx *gorm.DB) {
+	value, ok := tx.InstanceGet(slowQueryStartKey)
+	if !ok {
+		return
+	}
+	start, ok := value.(time.Time)
+	if !ok {
+		return
+	}
+
+	elapsed := time.Since(start)
+	if elapsed < time.Duration(config.DbSlowQueryMs)*time.Millisecond {
+		return
+	}
+
+	logSlowQuery(fmt.Sprintf("[slow query] %dms handler=%s table=%s rows=%d sql=%s",
+		elapsed.Milliseconds(), callingHandler(), tx.Statement.Table, tx.Statement.RowsAffected, tx.Statement.SQL.String()))
+}
+
+// callingHandler is the first function of the handlers package on the stack,
+// the db layer has no request context to read it from
+func callingHandler() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if i := strings.Index(frame.Function, "/sphinx-tribes/handlers."); i >= 0 {
+			return strings.TrimPrefix(frame.Function[i+1:], "sphinx-tribes/")
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
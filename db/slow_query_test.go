@@ -0,0 +1,49 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestSlowQueryPlugin(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, gormDB.Use(slowQueryPlugin{}))
+
+	lines := []string{}
+	previousLog, previousThreshold := logSlowQuery, config.DbSlowQueryMs
+	logSlowQuery = func(line string) { lines = append(lines, line) }
+	defer func() { logSlowQuery, config.DbSlowQueryMs = previousLog, previousThreshold }()
+
+	t.Run("should not log queries under the threshold", func(t *testing.T) {
+		config.DbSlowQueryMs = 60000
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		gormDB.Find(&[]Person{})
+		assert.Empty(t, lines)
+	})
+
+	t.Run("should log slow queries with the table and rows", func(t *testing.T) {
+		config.DbSlowQueryMs = 0
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+		gormDB.Find(&[]Person{})
+		assert.Len(t, lines, 1)
+		assert.Contains(t, lines[0], "[slow query]")
+		assert.Contains(t, lines[0], "handler=unknown")
+		assert.Contains(t, lines[0], "table=people")
+		assert.Contains(t, lines[0], "rows=2")
+		assert.Contains(t, lines[0], `sql=SELECT * FROM "people"`)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"strings"
+)
+
+// SortFields maps the sortBy values a list accepts to the column it orders by,
+// sort params come straight from the query string so they are never put into SQL as is
+type SortFields map[string]string
+
+var TribeSortFields = SortFields{
+	"created":       "created",
+	"updated":       "updated",
+	"name":          "name",
+	"last_active":   "last_active",
+	"member_count":  "member_count",
+	"price_to_join": "price_to_join",
+}
+
+var BotSortFields = SortFields{
+	"created":     "created",
+	"updated":     "updated",
+	"name":        "name",
+	"unique_name": "unique_name",
+}
+
+var PeopleSortFields = SortFields{
+	"created":       "created",
+	"updated":       "updated",
+	"last_login":    "last_login",
+	"owner_alias":   "owner_alias",
+	"unique_name":   "unique_name",
+	"price_to_meet": "price_to_meet",
+}
+
+var BountySortFields = SortFields{
+	"id":              "id",
+	"created":         "created",
+	"updated":         "updated",
+	"price":           "price",
+	"title":           "title",
+	"paid":            "paid",
+	"assigned_date":   "assigned_date",
+	"completion_date": "completion_date",
+	"paid_date":       "paid_date",
+	"phase_priority":  "phase_priority",
+}
+
+var FeatureSortFields = SortFields{
+	"created":  "created",
+	"updated":  "updated",
+	"name":     "name",
+	"priority": "priority",
+}
+
+var WorkspaceSortFields = SortFields{
+	"created": "created",
+	"updated": "updated",
+	"name":    "name",
+}
+
+// ExtrasSortFields are the keys people extras (posts, offers) can be ordered by
+var ExtrasSortFields = SortFields{
+	"created": "arr.item_object->>'created'",
+}
+
+// SortDirection only lets asc or desc through, anything else is the fallback
+func SortDirection(direction string, fallback string) string {
+	switch strings.ToLower(strings.TrimSpace(direction)) {
+	case "asc":
+		return "ASC"
+	case "desc":
+		return "DESC"
+	}
+	return fallback
+}
+
+// OrderBy returns a "column direction" clause for a whitelisted sort field,
+// an unknown field orders by fallbackField and an invalid direction by DESC
+func (f SortFields) OrderBy(sortBy string, direction string, fallbackField string) string {
+	column, ok := f[strings.TrimSpace(sortBy)]
+	if !ok {
+		column = f[fallbackField]
+	}
+	return column + " " + SortDirection(direction, "DESC")
+}
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortFieldsOrderBy(t *testing.T) {
+	t.Run("should order by a whitelisted field", func(t *testing.T) {
+		assert.Equal(t, "price ASC", BountySortFields.OrderBy("price", "asc", "created"))
+		assert.Equal(t, "paid DESC", BountySortFields.OrderBy("paid", "desc", "created"))
+		assert.Equal(t, "priority DESC", FeatureSortFields.OrderBy("priority", "DESC", "priority"))
+		assert.Equal(t, "arr.item_object->>'created' DESC", ExtrasSortFields.OrderBy("created", "desc", "created"))
+	})
+
+	t.Run("should fall back for unknown or malicious fields", func(t *testing.T) {
+		malicious := []string{
+			"created; DROP TABLE bounty;--",
+			"(SELECT CASE WHEN (1=1) THEN created ELSE price END)",
+			"created desc, (select pg_sleep(5))",
+			"owner_pub_key",
+			"",
+		}
+		for _, sortBy := range malicious {
+			assert.Equal(t, "created DESC", BountySortFields.OrderBy(sortBy, "desc", "created"))
+		}
+	})
+
+	t.Run("should not let a malicious direction through", func(t *testing.T) {
+		assert.Equal(t, "created DESC", BountySortFields.OrderBy("created", "asc; DELETE FROM people", "created"))
+		assert.Equal(t, "name DESC", TribeSortFields.OrderBy("name", "", "created"))
+		assert.Equal(t, "name ASC", TribeSortFields.OrderBy("name", " Asc ", "created"))
+	})
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+// GetStaleAssignedBounties returns the open assigned bounties without
+// assignee activity since the given time
+func (db database) GetStaleAssignedBounties(inactiveSince time.Time) []NewBounty {
+	bounties := []NewBounty{}
+	db.db.Model(&NewBounty{}).
+		Where("assignee <> '' AND assignee IS NOT NULL AND completed = ? AND paid = ?", false, false).
+		Where("COALESCE(last_activity_date, assigned_date) < ?", inactiveSince).
+		Find(&bounties)
+	return bounties
+}
+
+func (db database) SetBountyStaleWarning(id uint) error {
+	now := time.Now()
+	result := db.db.Model(&NewBounty{}).Where("id = ?", id).Update("stale_warning_date", &now)
+	if result.RowsAffected == 0 {
+		return errors.New("no bounty found")
+	}
+	return result.Error
+}
+
+// RecordBountyActivity marks assignee activity and clears any stale warning
+func (db database) RecordBountyActivity(id uint) error {
+	now := time.Now()
+	result := db.db.Model(&NewBounty{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_activity_date": &now,
+		"stale_warning_date": nil,
+	})
+	if result.RowsAffected == 0 {
+		return errors.New("no bounty found")
+	}
+	return result.Error
+}
+
+// ResetBountyActivity clears the activity and stale warning of the previous
+// assignee, so a new assignee gets the full time before a warning
+func (db database) ResetBountyActivity(id uint) error {
+	return db.db.Model(&NewBounty{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_activity_date": nil,
+		"stale_warning_date": nil,
+	}).Error
+}
+
+// UnassignStaleBounty returns the bounty to open status and keeps
+// the previous assignee in the assignee history
+func (db database) UnassignStaleBounty(bounty NewBounty, reason string) error {
+	tx := db.db.Begin()
+
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	now := time.Now()
+	history := BountyAssigneeHistory{
+		BountyID:       bounty.ID,
+		Assignee:       bounty.Assignee,
+		AssignedDate:   bounty.AssignedDate,
+		UnassignedDate: &now,
+		Reason:         reason,
+	}
+	if err = tx.Create(&history).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Model(&NewBounty{}).Where("id = ? AND assignee = ?", bounty.ID, bounty.Assignee).Updates(map[string]interface{}{
+		"assignee":           "",
+		"assigned_date":      nil,
+		"last_activity_date": nil,
+		"stale_warning_date": nil,
+		"state":              BountyStateOpen,
+		"updated":            &now,
+	}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (db database) GetBountyAssigneeHistory(bountyId uint) []BountyAssigneeHistory {
+	history := []BountyAssigneeHistory{}
+	db.db.Model(&BountyAssigneeHistory{}).Where("bounty_id = ?", bountyId).Order("unassigned_date DESC").Find(&history)
+	return history
+}
@@ -58,6 +58,12 @@ func (s StoreData) GetCache(key string) (string, error) {
 	return c, nil
 }
 
+// UseOnce remembers a key for ttl and tells whether it was new, a key still
+// remembered can't be used again
+func (s StoreData) UseOnce(key string, ttl time.Duration) bool {
+	return s.Cache.Add(key, true, ttl) == nil
+}
+
 func (s StoreData) SetLnCache(key string, value LnStore) error {
 	s.Cache.Set(key, value, cache.DefaultExpiration)
 	return nil
@@ -132,6 +138,29 @@ func (s StoreData) GetChallengeCache(key string) (string, error) {
 	return c, nil
 }
 
+func featureBriefLockKey(featureUuid string) string {
+	return "feature_brief_lock_" + featureUuid
+}
+
+func (s StoreData) SetFeatureBriefLock(lock FeatureBriefLock) error {
+	s.Cache.Set(featureBriefLockKey(lock.FeatureUuid), lock, time.Until(lock.ExpiresAt))
+	return nil
+}
+
+func (s StoreData) GetFeatureBriefLock(featureUuid string) (FeatureBriefLock, error) {
+	value, found := s.Cache.Get(featureBriefLockKey(featureUuid))
+	c, _ := value.(FeatureBriefLock)
+	if !found {
+		return FeatureBriefLock{}, errors.New("Feature brief lock not found")
+	}
+	return c, nil
+}
+
+func (s StoreData) DeleteFeatureBriefLock(featureUuid string) error {
+	s.Cache.Delete(featureBriefLockKey(featureUuid))
+	return nil
+}
+
 func Ask(w http.ResponseWriter, r *http.Request) {
 	var m sync.Mutex
 	m.Lock()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/lib/pq"
+	"github.com/stakwork/sphinx-tribes/utils"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
@@ -31,6 +32,7 @@ type Tribe struct {
 	Created         *time.Time     `json:"created"`
 	Updated         *time.Time     `json:"updated"`
 	MemberCount     uint64         `json:"member_count"`
+	MessageCount    uint64         `json:"message_count"`
 	Unlisted        bool           `json:"unlisted"`
 	Private         bool           `json:"private"`
 	Deleted         bool           `json:"deleted"`
@@ -100,27 +102,42 @@ type Tabler interface {
 
 // Person struct
 type Person struct {
-	ID               uint           `json:"id"`
-	Uuid             string         `json:"uuid"`
-	OwnerPubKey      string         `gorm:"uniqueIndex,unique" json:"owner_pubkey"`
-	OwnerAlias       string         `json:"owner_alias"`
-	UniqueName       string         `json:"unique_name"`
-	Description      string         `json:"description"`
-	Tags             pq.StringArray `gorm:"type:text[]" json:"tags" null`
-	Img              string         `json:"img"`
-	Created          *time.Time     `json:"created"`
-	Updated          *time.Time     `json:"updated"`
-	Unlisted         bool           `json:"unlisted"`
-	Deleted          bool           `json:"deleted"`
-	LastLogin        int64          `json:"last_login"`
-	OwnerRouteHint   string         `json:"owner_route_hint"`
-	OwnerContactKey  string         `json:"owner_contact_key"`
-	PriceToMeet      int64          `json:"price_to_meet"`
-	NewTicketTime    int64          `json:"new_ticket_time", gorm: "-:all"`
-	TwitterConfirmed bool           `json:"twitter_confirmed"`
-	ReferredBy       uint           `json:"referred_by"`
-	Extras           PropertyMap    `json:"extras", type: jsonb not null default '{}'::jsonb`
-	GithubIssues     PropertyMap    `json:"github_issues", type: jsonb not null default '{}'::jsonb`
+	ID               uint            `json:"id"`
+	Uuid             string          `json:"uuid"`
+	OwnerPubKey      string          `gorm:"uniqueIndex,unique" json:"owner_pubkey"`
+	OwnerAlias       string          `json:"owner_alias"`
+	UniqueName       string          `json:"unique_name"`
+	Description      string          `json:"description"`
+	Tags             pq.StringArray  `gorm:"type:text[]" json:"tags" null`
+	Img              string          `json:"img"`
+	Created          *time.Time      `json:"created"`
+	Updated          *time.Time      `json:"updated"`
+	Unlisted         bool            `json:"unlisted"`
+	Deleted          bool            `json:"deleted"`
+	LastLogin        int64           `json:"last_login"`
+	OwnerRouteHint   EncryptedString `json:"owner_route_hint"`
+	OwnerContactKey  EncryptedString `json:"owner_contact_key"`
+	PriceToMeet      int64           `json:"price_to_meet"`
+	NewTicketTime    int64           `json:"new_ticket_time", gorm: "-:all"`
+	TwitterConfirmed bool            `json:"twitter_confirmed"`
+	ReferredBy       uint            `json:"referred_by"`
+	Extras           PropertyMap     `json:"extras", type: jsonb not null default '{}'::jsonb`
+	GithubIssues     PropertyMap     `json:"github_issues", type: jsonb not null default '{}'::jsonb`
+	PortfolioPrivate bool            `gorm:"default:false" json:"portfolio_private"`
+	HideEarnings     bool            `gorm:"default:false" json:"hide_earnings"`
+	// AvailabilityStatus is what the person set, Availability is the status
+	// others see once busy_until and active bounties are taken into account
+	AvailabilityStatus string     `json:"availability_status"`
+	BusyUntil          *time.Time `json:"busy_until"`
+	WeeklyHours        int        `json:"weekly_hours"`
+	Availability       string     `gorm:"-" json:"availability,omitempty"`
+	// LightningAddress is where bounty payouts go, keysend is the fallback
+	LightningAddress string `json:"lightning_address"`
+	// OnchainAddress receives the payouts too large to route over lightning
+	OnchainAddress string `json:"onchain_address"`
+	// Handle is the optional unique @handle, it is only set through SetPersonHandle
+	Handle        *string    `gorm:"uniqueIndex" json:"handle,omitempty"`
+	HandleUpdated *time.Time `json:"-"`
 }
 
 type GormDataTypeInterface interface {
@@ -143,6 +160,107 @@ func (p StringArray) Value() (driver.Value, error) {
 	return b, nil
 }
 
+type PortfolioWorkspace struct {
+	Uuid string `json:"uuid"`
+	Name string `json:"name"`
+	Img  string `json:"img"`
+}
+
+// PersonPortfolio is the public summary of a hunter's completed work
+type PersonPortfolio struct {
+	OwnerPubKey            string               `json:"owner_pubkey"`
+	OwnerAlias             string               `json:"owner_alias"`
+	CompletedBountiesCount int                  `json:"completed_bounties_count"`
+	CompletedBounties      []NewBounty          `json:"completed_bounties"`
+	TotalEarned            *uint                `json:"total_earned,omitempty"`
+	Languages              []string             `json:"languages"`
+	Workspaces             []PortfolioWorkspace `json:"workspaces"`
+}
+
+// PersonBoardBounty is a bounty on the board of a person, with their role on it
+type PersonBoardBounty struct {
+	NewBounty
+	Role          string     `json:"role"`
+	WorkspaceName string     `json:"workspace_name"`
+	DueDate       *time.Time `json:"due_date"`
+}
+
+type PersonBoardColumn struct {
+	State    BountyState         `json:"state"`
+	Count    int                 `json:"count"`
+	Bounties []PersonBoardBounty `json:"bounties"`
+}
+
+// PersonBoard is the work of a person across all their workspaces, one column per state
+type PersonBoard struct {
+	Workspaces []PortfolioWorkspace `json:"workspaces"`
+	Columns    []PersonBoardColumn  `json:"columns"`
+}
+
+// EarningsAmounts splits payouts in sats by how far they got, pending ones are
+// completed bounties not paid yet and in flight ones are unconfirmed on-chain payouts
+type EarningsAmounts struct {
+	Pending  uint `json:"pending"`
+	InFlight uint `json:"in_flight"`
+	Settled  uint `json:"settled"`
+}
+
+// EarningsRow is the earnings of a person in one workspace for one month
+type EarningsRow struct {
+	WorkspaceUuid string `json:"workspace_uuid"`
+	Month         string `json:"month"`
+	EarningsAmounts
+}
+
+type WorkspaceEarnings struct {
+	WorkspaceUuid string `json:"workspace_uuid"`
+	WorkspaceName string `json:"workspace_name"`
+	EarningsAmounts
+}
+
+type MonthlyEarnings struct {
+	Month string `json:"month"`
+	EarningsAmounts
+}
+
+// PersonEarnings is what a person earned across their workspaces, months are YYYY-MM
+type PersonEarnings struct {
+	Total      EarningsAmounts     `json:"total"`
+	Workspaces []WorkspaceEarnings `json:"workspaces"`
+	Months     []MonthlyEarnings   `json:"months"`
+}
+
+// SearchHit is one public result of the site search, whatever its type
+type SearchHit struct {
+	Type     string `json:"type"`
+	Id       string `json:"id"`
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle"`
+	Img      string `json:"img"`
+}
+
+type SearchResult struct {
+	Query  string           `json:"query"`
+	Facets map[string]int64 `json:"facets"`
+	Hits   []SearchHit      `json:"hits"`
+}
+
+// ListFilter is a page of a public list, the workspace and state
+// only apply to bounties
+type ListFilter struct {
+	Search        string
+	WorkspaceUuid string
+	State         BountyState
+	Offset        int
+	Limit         int
+}
+
+// AutocompleteItem is a typeahead suggestion, just enough for a picker
+type AutocompleteItem struct {
+	Id    string `json:"id"`
+	Label string `json:"label"`
+}
+
 type PersonInShort struct {
 	ID          uint   `json:"id"`
 	Uuid        string `json:"uuid"`
@@ -220,6 +338,42 @@ type BadgeCreationData struct {
 	Action    string `json:"action"`
 }
 
+type BadgeIssuanceStatus string
+
+const (
+	BadgeIssuancePending BadgeIssuanceStatus = "pending"
+	BadgeIssuanceIssued  BadgeIssuanceStatus = "issued"
+	BadgeIssuanceFailed  BadgeIssuanceStatus = "failed"
+)
+
+// BadgeIssuance tracks a tribe badge minted to a person by the asset service
+type BadgeIssuance struct {
+	ID              uint                `json:"id"`
+	TribeUuid       string              `gorm:"index" json:"tribe_uuid"`
+	Badge           string              `json:"badge"`
+	RecipientPubkey string              `json:"recipient_pubkey"`
+	Amount          uint                `json:"amount"`
+	Status          BadgeIssuanceStatus `json:"status"`
+	TxId            string              `json:"tx_id"`
+	Error           string              `json:"error,omitempty"`
+	IssuedBy        string              `json:"issued_by"`
+	Created         *time.Time          `json:"created"`
+	Updated         *time.Time          `json:"updated"`
+}
+
+type BadgeIssuanceRequest struct {
+	Badge   string   `json:"badge"`
+	Pubkeys []string `json:"pubkeys"`
+	Amount  uint     `json:"amount"`
+}
+
+type TribeBadge struct {
+	Badge   string `json:"badge"`
+	Holders int64  `json:"holders"`
+	Pending int64  `json:"pending"`
+	Failed  int64  `json:"failed"`
+}
+
 type ConnectionCodes struct {
 	ID               uint       `json:"id"`
 	ConnectionString string     `json:"connection_string"`
@@ -332,6 +486,18 @@ type KeysendError struct {
 	Error   string `json:"error"`
 }
 
+// LnurlPayResponse is what a lightning address (LUD-16) answers with, the
+// LNURL-pay parameters first and then the invoice from its callback
+type LnurlPayResponse struct {
+	Tag         string `json:"tag"`
+	Callback    string `json:"callback"`
+	MinSendable uint64 `json:"minSendable"`
+	MaxSendable uint64 `json:"maxSendable"`
+	Pr          string `json:"pr"`
+	Status      string `json:"status"`
+	Reason      string `json:"reason"`
+}
+
 type LnHost struct {
 	Msg  string `json:"msg"`
 	Host string `json:"host"`
@@ -348,6 +514,88 @@ type BountyLeaderboard struct {
 	Total_sats_earned        uint   `json:"total_sats_earned"`
 }
 
+// BountyLeaderboardDaily holds the paid bounty totals of a hunter per day,
+// workspace and language, an empty language row counts all languages
+type BountyLeaderboardDaily struct {
+	ID                     uint      `json:"id"`
+	Day                    time.Time `gorm:"type:date;index" json:"day"`
+	OwnerPubkey            string    `gorm:"index" json:"owner_pubkey"`
+	WorkspaceUuid          string    `json:"workspace_uuid"`
+	Language               string    `json:"language"`
+	TotalBountiesCompleted uint      `json:"total_bounties_completed"`
+	TotalSatsEarned        uint      `json:"total_sats_earned"`
+}
+
+// BountyScope buckets bounties by their estimated hours, so prices are
+// compared between bounties of a similar size
+type BountyScope string
+
+const (
+	BountyScopeSmall  BountyScope = "small"
+	BountyScopeMedium BountyScope = "medium"
+	BountyScopeLarge  BountyScope = "large"
+)
+
+// PriceStatAll is the workspace, language or scope of a price stat row that
+// covers all of them
+const PriceStatAll = "*"
+
+// BountyPriceStat holds the spread of the prices of paid bounties per workspace,
+// language and scope. It is rebuilt with the leaderboard aggregates
+type BountyPriceStat struct {
+	ID            uint      `json:"id"`
+	WorkspaceUuid string    `gorm:"index" json:"workspace_uuid"`
+	Language      string    `json:"language"`
+	Scope         string    `json:"scope"`
+	Bounties      uint      `json:"bounties"`
+	MinSats       uint      `json:"min_sats"`
+	P25Sats       uint      `json:"p25_sats"`
+	MedianSats    uint      `json:"median_sats"`
+	P75Sats       uint      `json:"p75_sats"`
+	MaxSats       uint      `json:"max_sats"`
+	Refreshed     time.Time `json:"refreshed"`
+}
+
+// PriceSuggestion is a price range for a new bounty, taken from the most
+// specific price stats with enough paid bounties
+type PriceSuggestion struct {
+	SuggestedSats   uint    `json:"suggested_sats"`
+	LowSats         uint    `json:"low_sats"`
+	HighSats        uint    `json:"high_sats"`
+	Confidence      float64 `json:"confidence"`
+	ConfidenceLevel string  `json:"confidence_level"`
+	SampleSize      uint    `json:"sample_size"`
+	WorkspaceUuid   string  `json:"workspace_uuid"`
+	Language        string  `json:"language"`
+	Scope           string  `json:"scope"`
+}
+
+type LeaderboardWindow string
+
+const (
+	LeaderboardWeek  LeaderboardWindow = "week"
+	LeaderboardMonth LeaderboardWindow = "month"
+	LeaderboardAll   LeaderboardWindow = "all"
+)
+
+type LeaderboardFilter struct {
+	WorkspaceUuid string
+	Language      string
+	From          *time.Time
+	To            *time.Time
+}
+
+type LeaderboardEntry struct {
+	OwnerPubkey            string `json:"owner_pubkey"`
+	OwnerAlias             string `json:"owner_alias"`
+	OwnerImg               string `json:"owner_img"`
+	TotalBountiesCompleted uint   `json:"total_bounties_completed"`
+	TotalSatsEarned        uint   `json:"total_sats_earned"`
+	Rank                   int    `json:"rank"`
+	PreviousRank           int    `json:"previous_rank"`
+	RankDelta              int    `json:"rank_delta"`
+}
+
 type YoutubeDownload struct {
 	YoutubeUrls []string `json:"youtube_urls"`
 }
@@ -355,6 +603,9 @@ type YoutubeDownload struct {
 type Client struct {
 	Host string
 	Conn *websocket.Conn
+	// Version is the websocket protocol the client negotiated, 0 for a
+	// client that never said hello
+	Version int
 }
 
 type Bounty struct {
@@ -394,39 +645,1066 @@ type Bounty struct {
 
 // Todo: Change back to Bounty
 type NewBounty struct {
-	ID                      uint           `json:"id"`
-	OwnerID                 string         `json:"owner_id"`
-	Paid                    bool           `json:"paid"`
-	Show                    bool           `gorm:"default:false" json:"show"`
-	Completed               bool           `gorm:"default:false" json:"completed"`
-	Type                    string         `json:"type"`
-	Award                   string         `json:"award"`
-	AssignedHours           uint8          `json:"assigned_hours"`
-	BountyExpires           string         `json:"bounty_expires"`
-	CommitmentFee           uint64         `json:"commitment_fee"`
-	Price                   uint           `json:"price"`
-	Title                   string         `json:"title"`
-	Tribe                   string         `json:"tribe"`
-	Assignee                string         `json:"assignee"`
-	TicketUrl               string         `json:"ticket_url"`
-	OrgUuid                 string         `gorm:"-" json:"org_uuid"`
-	WorkspaceUuid           string         `json:"workspace_uuid"`
-	Description             string         `json:"description"`
-	WantedType              string         `json:"wanted_type"`
-	Deliverables            string         `json:"deliverables"`
-	GithubDescription       bool           `json:"github_description"`
-	OneSentenceSummary      string         `json:"one_sentence_summary"`
-	EstimatedSessionLength  string         `json:"estimated_session_length"`
-	EstimatedCompletionDate string         `json:"estimated_completion_date"`
-	Created                 int64          `json:"created"`
-	Updated                 *time.Time     `json:"updated"`
-	AssignedDate            *time.Time     `json:"assigned_date,omitempty"`
-	CompletionDate          *time.Time     `json:"completion_date,omitempty"`
-	MarkAsPaidDate          *time.Time     `json:"mark_as_paid_date,omitempty"`
-	PaidDate                *time.Time     `json:"paid_date,omitempty"`
-	CodingLanguages         pq.StringArray `gorm:"type:text[];not null default:'[]'" json:"coding_languages"`
-	PhaseUuid               string         `json:"phase_uuid"`
-	PhasePriority           int            `json:"phase_priority"`
+	ID                      uint             `json:"id"`
+	OwnerID                 string           `json:"owner_id"`
+	Paid                    bool             `json:"paid"`
+	Show                    bool             `gorm:"default:false" json:"show"`
+	Completed               bool             `gorm:"default:false" json:"completed"`
+	Type                    string           `json:"type"`
+	Award                   string           `json:"award"`
+	AssignedHours           uint8            `json:"assigned_hours"`
+	BountyExpires           string           `json:"bounty_expires"`
+	CommitmentFee           uint64           `json:"commitment_fee"`
+	Price                   uint             `json:"price"`
+	Title                   string           `json:"title"`
+	Tribe                   string           `json:"tribe"`
+	Assignee                string           `json:"assignee"`
+	TicketUrl               string           `json:"ticket_url"`
+	OrgUuid                 string           `gorm:"-" json:"org_uuid"`
+	WorkspaceUuid           string           `json:"workspace_uuid"`
+	Description             string           `json:"description"`
+	WantedType              string           `json:"wanted_type"`
+	Deliverables            string           `json:"deliverables"`
+	GithubDescription       bool             `json:"github_description"`
+	OneSentenceSummary      string           `json:"one_sentence_summary"`
+	EstimatedSessionLength  string           `json:"estimated_session_length"`
+	EstimatedCompletionDate string           `json:"estimated_completion_date"`
+	Created                 int64            `json:"created"`
+	Updated                 *time.Time       `json:"updated"`
+	AssignedDate            *time.Time       `json:"assigned_date,omitempty"`
+	CompletionDate          *time.Time       `json:"completion_date,omitempty"`
+	MarkAsPaidDate          *time.Time       `json:"mark_as_paid_date,omitempty"`
+	PaidDate                *time.Time       `json:"paid_date,omitempty"`
+	CodingLanguages         pq.StringArray   `gorm:"type:text[];not null default:'[]'" json:"coding_languages"`
+	PhaseUuid               string           `json:"phase_uuid"`
+	PhasePriority           int              `json:"phase_priority"`
+	LastActivityDate        *time.Time       `json:"last_activity_date,omitempty"`
+	StaleWarningDate        *time.Time       `json:"stale_warning_date,omitempty"`
+	EstimatedHours          float64          `json:"estimated_hours"`
+	State                   BountyState      `gorm:"default:'open';index" json:"state"`
+	Visibility              BountyVisibility `gorm:"default:'public';index" json:"visibility"`
+	Invitees                pq.StringArray   `gorm:"type:text[]" json:"invitees,omitempty"`
+	Tags                    pq.StringArray   `gorm:"type:text[]" json:"tags"`
+	DueDate                 *time.Time       `gorm:"index" json:"due_date,omitempty"`
+	// DueReminderHours is the offset from the due date, negative before it, of the last reminder sent
+	DueReminderHours *int `json:"-"`
+	Overdue          bool `gorm:"-" json:"overdue"`
+}
+
+// BountyState is where a bounty is in its workflow, it only moves
+// along the transitions in BountyTransitions
+type BountyState string
+
+const (
+	BountyStateOpen      BountyState = "open"
+	BountyStateAssigned  BountyState = "assigned"
+	BountyStateInReview  BountyState = "in_review"
+	BountyStateCompleted BountyState = "completed"
+	BountyStatePaid      BountyState = "paid"
+	BountyStateCanceled  BountyState = "canceled"
+)
+
+type CommentAuthorType string
+
+const (
+	CommentAuthorHuman   CommentAuthorType = "human"
+	CommentAuthorAIAgent CommentAuthorType = "ai-agent"
+)
+
+// BountyComment is a comment on a bounty, comments of review agents posted
+// with a workspace api token have the ai-agent author type
+type BountyComment struct {
+	ID           uint              `json:"id"`
+	BountyID     uint              `gorm:"index;not null" json:"bounty_id"`
+	AuthorPubkey string            `gorm:"not null" json:"author_pubkey"`
+	AuthorType   CommentAuthorType `gorm:"not null;default:'human'" json:"author_type"`
+	AgentName    string            `json:"agent_name,omitempty"`
+	Body         string            `gorm:"not null" json:"body"`
+	Created      *time.Time        `json:"created"`
+	Updated      *time.Time        `json:"updated"`
+}
+
+type BountyCommentRequest struct {
+	Body      string `json:"body"`
+	AgentName string `json:"agent_name"`
+}
+
+// BountyStateTransition records a move of a bounty from one state to another
+type BountyStateTransition struct {
+	ID        uint        `json:"id"`
+	BountyID  uint        `gorm:"index;not null" json:"bounty_id"`
+	FromState BountyState `json:"from_state"`
+	ToState   BountyState `json:"to_state"`
+	Actor     string      `json:"actor"`
+	Reason    string      `json:"reason"`
+	Created   *time.Time  `json:"created"`
+}
+
+type BountyTransitionRequest struct {
+	State    BountyState `json:"state"`
+	Assignee string      `json:"assignee"`
+	Reason   string      `json:"reason"`
+}
+
+// BountyAssigneeHistory keeps the previous assignees of a bounty
+type BountyAssigneeHistory struct {
+	ID             uint       `json:"id"`
+	BountyID       uint       `gorm:"not null;index" json:"bounty_id"`
+	Assignee       string     `json:"assignee"`
+	AssignedDate   *time.Time `json:"assigned_date"`
+	UnassignedDate *time.Time `json:"unassigned_date"`
+	Reason         string     `json:"reason"`
+}
+
+type BountyApplicationStatus string
+
+const (
+	BountyApplicationPending   BountyApplicationStatus = "pending"
+	BountyApplicationAccepted  BountyApplicationStatus = "accepted"
+	BountyApplicationRejected  BountyApplicationStatus = "rejected"
+	BountyApplicationWithdrawn BountyApplicationStatus = "withdrawn"
+)
+
+// BountyApplication is a hunter's request to be assigned to an open bounty
+type BountyApplication struct {
+	ID              uint                    `json:"id"`
+	BountyID        uint                    `gorm:"not null;index" json:"bounty_id"`
+	ApplicantPubkey string                  `gorm:"not null" json:"applicant_pubkey"`
+	Message         string                  `json:"message"`
+	Eta             *time.Time              `json:"eta"`
+	Status          BountyApplicationStatus `json:"status"`
+	Created         *time.Time              `json:"created"`
+	Updated         *time.Time              `json:"updated"`
+}
+
+type BountyApplicationResponse struct {
+	BountyApplication
+	Applicant PersonInShort `json:"applicant"`
+}
+
+type BountyAuctionStatus string
+
+const (
+	BountyAuctionOpen              BountyAuctionStatus = "open"
+	BountyAuctionAwaitingSelection BountyAuctionStatus = "awaiting_selection"
+	BountyAuctionClosed            BountyAuctionStatus = "closed"
+	BountyAuctionCanceled          BountyAuctionStatus = "canceled"
+)
+
+// BountyAuction lets hunters bid on a bounty until EndsAt, when the bounty goes
+// to the bid the owner selected or, with AutoSelect, to the lowest bid
+type BountyAuction struct {
+	ID            uint                `json:"id"`
+	BountyID      uint                `gorm:"not null;index" json:"bounty_id"`
+	EndsAt        *time.Time          `gorm:"not null;index" json:"ends_at"`
+	AutoSelect    bool                `json:"auto_select"`
+	SelectedBidID *uint               `json:"selected_bid_id"`
+	Status        BountyAuctionStatus `gorm:"default:'open';index" json:"status"`
+	CreatedBy     string              `json:"created_by"`
+	Created       *time.Time          `json:"created"`
+	Updated       *time.Time          `json:"updated"`
+}
+
+type BountyBidStatus string
+
+const (
+	BountyBidActive    BountyBidStatus = "active"
+	BountyBidWon       BountyBidStatus = "won"
+	BountyBidLost      BountyBidStatus = "lost"
+	BountyBidWithdrawn BountyBidStatus = "withdrawn"
+)
+
+// BountyBid is a hunter's offer to do an auctioned bounty for Amount sats by Eta
+type BountyBid struct {
+	ID           uint            `json:"id"`
+	AuctionID    uint            `gorm:"not null;index" json:"auction_id"`
+	BidderPubkey string          `gorm:"not null" json:"bidder_pubkey"`
+	Amount       uint            `gorm:"not null" json:"amount"`
+	Eta          *time.Time      `json:"eta"`
+	Status       BountyBidStatus `gorm:"default:'active'" json:"status"`
+	Created      *time.Time      `json:"created"`
+	Updated      *time.Time      `json:"updated"`
+}
+
+type BountyAuctionResponse struct {
+	BountyAuction
+	Bids []BountyBid `json:"bids"`
+}
+
+// BountyHoursLog is time an assignee spent on a bounty
+type BountyHoursLog struct {
+	ID       uint       `json:"id"`
+	BountyID uint       `gorm:"not null;index" json:"bounty_id"`
+	Pubkey   string     `gorm:"not null" json:"pubkey"`
+	Hours    float64    `gorm:"not null" json:"hours"`
+	Note     string     `json:"note"`
+	Created  *time.Time `json:"created"`
+}
+
+type BountyHoursLogRequest struct {
+	Hours float64 `json:"hours"`
+	Note  string  `json:"note"`
+}
+
+type BountyEffort struct {
+	BountyID       uint             `json:"bounty_id"`
+	EstimatedHours float64          `json:"estimated_hours"`
+	ActualHours    float64          `json:"actual_hours"`
+	Logs           []BountyHoursLog `json:"logs"`
+}
+
+// BountyEffortRow is the estimate and logged hours of one finished bounty
+type BountyEffortRow struct {
+	BountyID        uint           `json:"bounty_id"`
+	Assignee        string         `json:"assignee"`
+	CodingLanguages pq.StringArray `gorm:"type:text[]" json:"coding_languages"`
+	EstimatedHours  float64        `json:"estimated_hours"`
+	ActualHours     float64        `json:"actual_hours"`
+}
+
+// EstimationAccuracy compares estimated and actual hours of a group of bounties,
+// Ratio is actual over estimated hours and AverageError the mean relative
+// error of the estimates, so 0.25 means estimates were 25% off on average
+type EstimationAccuracy struct {
+	Key            string  `json:"key"`
+	Bounties       int     `json:"bounties"`
+	EstimatedHours float64 `json:"estimated_hours"`
+	ActualHours    float64 `json:"actual_hours"`
+	Ratio          float64 `json:"ratio"`
+	AverageError   float64 `json:"average_error"`
+}
+
+type EstimationAccuracyReport struct {
+	WorkspaceUuid string               `json:"workspace_uuid"`
+	Overall       EstimationAccuracy   `json:"overall"`
+	ByPerson      []EstimationAccuracy `json:"by_person"`
+	ByLanguage    []EstimationAccuracy `json:"by_language"`
+}
+
+type NotificationType string
+
+const (
+	PaymentFailedNotification      NotificationType = "payment_failed"
+	BountyStateChangedNotification NotificationType = "bounty_state_changed"
+	RefundApprovalNotification     NotificationType = "refund_approval"
+)
+
+// Notification is an entry in a user's notification inbox
+type Notification struct {
+	ID              uint             `json:"id"`
+	RecipientPubkey string           `gorm:"not null;index" json:"recipient_pubkey"`
+	WorkspaceUuid   string           `json:"workspace_uuid"`
+	Type            NotificationType `json:"type"`
+	Title           string           `json:"title"`
+	Message         string           `json:"message"`
+	Link            string           `json:"link"`
+	Read            bool             `gorm:"default:false" json:"read"`
+	Created         *time.Time       `json:"created"`
+}
+
+type WorkspaceEventType string
+
+const (
+	BountyCreatedEvent      WorkspaceEventType = "bounty_created"
+	BountyStateChangedEvent WorkspaceEventType = "bounty_state_changed"
+	FeatureCreatedEvent     WorkspaceEventType = "feature_created"
+	FeatureUpdatedEvent     WorkspaceEventType = "feature_updated"
+)
+
+// WorkspaceEvent is something that happened in a workspace, sent to the
+// subscribers of the notification stream like the chat bridges
+type WorkspaceEvent struct {
+	WorkspaceUuid string             `json:"workspace_uuid"`
+	Type          WorkspaceEventType `json:"type"`
+	Title         string             `json:"title"`
+	Message       string             `json:"message"`
+	Link          string             `json:"link"`
+	Actor         string             `json:"actor"`
+	Created       time.Time          `json:"created"`
+}
+
+type BridgeKind string
+
+const (
+	BridgeDiscord BridgeKind = "discord"
+	BridgeMatrix  BridgeKind = "matrix"
+)
+
+// WorkspaceBridge posts the events of a workspace to a Discord channel, through
+// its webhook url, or to a Matrix room. The webhook url and the Matrix token are
+// credentials and never returned. Empty Events posts every event
+type WorkspaceBridge struct {
+	ID               uint           `json:"id"`
+	Uuid             string         `gorm:"uniqueIndex;not null" json:"uuid"`
+	WorkspaceUuid    string         `gorm:"index;not null" json:"workspace_uuid"`
+	Name             string         `json:"name"`
+	Kind             BridgeKind     `json:"kind"`
+	WebhookUrl       string         `json:"-"`
+	MatrixHomeserver string         `json:"matrix_homeserver,omitempty"`
+	MatrixRoomId     string         `json:"matrix_room_id,omitempty"`
+	MatrixToken      string         `json:"-"`
+	Events           pq.StringArray `gorm:"type:text[]" json:"events"`
+	LastDelivered    *time.Time     `json:"last_delivered,omitempty"`
+	LastError        string         `json:"last_error,omitempty"`
+	CreatedBy        string         `json:"created_by"`
+	Created          *time.Time     `json:"created"`
+	Updated          *time.Time     `json:"updated"`
+}
+
+type WorkspaceBridgeRequest struct {
+	Name             string     `json:"name"`
+	Kind             BridgeKind `json:"kind"`
+	WebhookUrl       string     `json:"webhook_url"`
+	MatrixHomeserver string     `json:"matrix_homeserver"`
+	MatrixRoomId     string     `json:"matrix_room_id"`
+	MatrixToken      string     `json:"matrix_token"`
+	Events           []string   `json:"events"`
+}
+
+// SlackUserLink maps a Slack user to a person. It starts as a pending link with
+// a one-time Code the Slack user redeems while signed in, OwnerPubkey is set
+// once the link is confirmed
+type SlackUserLink struct {
+	ID          uint       `json:"id"`
+	TeamId      string     `gorm:"uniqueIndex:idx_slack_user;not null" json:"team_id"`
+	SlackUserId string     `gorm:"uniqueIndex:idx_slack_user;not null" json:"slack_user_id"`
+	OwnerPubkey string     `gorm:"index" json:"owner_pubkey"`
+	Code        string     `gorm:"index" json:"-"`
+	CodeExpires *time.Time `json:"-"`
+	Linked      *time.Time `json:"linked,omitempty"`
+	Created     *time.Time `json:"created"`
+}
+
+// SlackTeam is the workspace the slash commands of a Slack team work on
+type SlackTeam struct {
+	ID            uint       `json:"id"`
+	TeamId        string     `gorm:"uniqueIndex;not null" json:"team_id"`
+	WorkspaceUuid string     `gorm:"not null" json:"workspace_uuid"`
+	ConnectedBy   string     `json:"connected_by"`
+	Created       *time.Time `json:"created"`
+	Updated       *time.Time `json:"updated"`
+}
+
+type SlackLinkRequest struct {
+	Code string `json:"code"`
+}
+
+type TicketReviewStatus string
+
+const (
+	TicketReviewPending   TicketReviewStatus = "pending"
+	TicketReviewSubmitted TicketReviewStatus = "submitted"
+	TicketReviewRunning   TicketReviewStatus = "running"
+	TicketReviewCompleted TicketReviewStatus = "completed"
+	TicketReviewFailed    TicketReviewStatus = "failed"
+)
+
+// TicketReview is a run of the Stakwork ticket review workflow: the ticket as
+// it was submitted, the progress the agent reports and its response and score.
+// CallbackToken authenticates the progress Stakwork posts back
+type TicketReview struct {
+	ID            uint               `json:"id"`
+	Uuid          string             `gorm:"uniqueIndex;not null" json:"uuid"`
+	TicketUuid    string             `gorm:"index;not null" json:"ticket_uuid"`
+	WorkspaceUuid string             `gorm:"index;not null" json:"workspace_uuid"`
+	Status        TicketReviewStatus `gorm:"index;not null" json:"status"`
+	ProjectId     int64              `json:"project_id,omitempty"`
+	Submission    string             `gorm:"type:text" json:"submission"`
+	Step          string             `json:"step"`
+	Progress      int                `json:"progress"`
+	Response      string             `gorm:"type:text" json:"response"`
+	Score         *float64           `json:"score,omitempty"`
+	Feedback      string             `gorm:"type:text" json:"feedback"`
+	Error         string             `json:"error,omitempty"`
+	CallbackToken string             `json:"-"`
+	SubmittedBy   string             `json:"submitted_by"`
+	Created       *time.Time         `json:"created"`
+	Updated       *time.Time         `json:"updated"`
+	Completed     *time.Time         `json:"completed,omitempty"`
+}
+
+func (review TicketReview) Finished() bool {
+	return review.Status == TicketReviewCompleted || review.Status == TicketReviewFailed
+}
+
+type TicketReviewRequest struct {
+	WorkspaceUuid string `json:"workspace_uuid"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+}
+
+// TicketReviewProgress is what the review workflow posts back while it runs,
+// Response, Score and Feedback come with the completed status
+type TicketReviewProgress struct {
+	Status   TicketReviewStatus `json:"status"`
+	Step     string             `json:"step"`
+	Progress int                `json:"progress"`
+	Response string             `json:"response"`
+	Score    *float64           `json:"score"`
+	Feedback string             `json:"feedback"`
+	Error    string             `json:"error"`
+}
+
+type PhasePlanStatus string
+
+const (
+	PhasePlanPending    PhasePlanStatus = "pending"
+	PhasePlanSubmitted  PhasePlanStatus = "submitted"
+	PhasePlanCompleted  PhasePlanStatus = "completed"
+	PhasePlanFailed     PhasePlanStatus = "failed"
+	PhasePlanRolledBack PhasePlanStatus = "rolled_back"
+)
+
+// PhasePlan is a run of the Stakwork planning workflow for a phase. Context is
+// the brief, stories and repositories it was given, the ticket drafts it
+// returns are saved as hidden bounties of the phase
+type PhasePlan struct {
+	ID            uint             `json:"id"`
+	Uuid          string           `gorm:"uniqueIndex;not null" json:"uuid"`
+	FeatureUuid   string           `gorm:"index;not null" json:"feature_uuid"`
+	PhaseUuid     string           `gorm:"index;not null" json:"phase_uuid"`
+	WorkspaceUuid string           `gorm:"not null" json:"workspace_uuid"`
+	Status        PhasePlanStatus  `gorm:"not null" json:"status"`
+	ProjectId     int64            `json:"project_id,omitempty"`
+	Context       string           `gorm:"type:text" json:"context"`
+	Error         string           `json:"error,omitempty"`
+	CallbackToken string           `json:"-"`
+	RequestedBy   string           `json:"requested_by"`
+	Created       *time.Time       `json:"created"`
+	Updated       *time.Time       `json:"updated"`
+	Completed     *time.Time       `json:"completed,omitempty"`
+	RolledBack    *time.Time       `json:"rolled_back,omitempty"`
+	RolledBackBy  string           `json:"rolled_back_by,omitempty"`
+	Drafts        []PhasePlanDraft `gorm:"-" json:"drafts"`
+}
+
+func (plan PhasePlan) Finished() bool {
+	return plan.Status == PhasePlanCompleted || plan.Status == PhasePlanFailed || plan.Status == PhasePlanRolledBack
+}
+
+// PhasePlanDraft is the provenance of a ticket draft: the plan that made it,
+// its place in the plan and the stories it covers
+type PhasePlanDraft struct {
+	ID         uint           `json:"id"`
+	PlanUuid   string         `gorm:"index;not null" json:"plan_uuid"`
+	BountyID   uint           `gorm:"uniqueIndex;not null" json:"bounty_id"`
+	Position   int            `json:"position"`
+	StoryUuids pq.StringArray `gorm:"type:text[]" json:"story_uuids"`
+	Rationale  string         `gorm:"type:text" json:"rationale"`
+	Created    *time.Time     `json:"created"`
+}
+
+// PlanTicketDraft is a ticket the planning workflow proposes
+type PlanTicketDraft struct {
+	Title          string   `json:"title"`
+	Description    string   `json:"description"`
+	EstimatedHours float64  `json:"estimated_hours"`
+	StoryUuids     []string `json:"story_uuids"`
+	Rationale      string   `json:"rationale"`
+}
+
+// PhasePlanResult is what the planning workflow posts back when it is done
+type PhasePlanResult struct {
+	Status  PhasePlanStatus   `json:"status"`
+	Error   string            `json:"error"`
+	Tickets []PlanTicketDraft `json:"tickets"`
+}
+
+type PhasePlanTicket struct {
+	Bounty NewBounty
+	Draft  PhasePlanDraft
+}
+
+// TicketBatchRollback is the outcome of rolling back the drafts of a plan,
+// Blocking are the drafts someone took on, which keep the batch from rolling back
+type TicketBatchRollback struct {
+	PlanUuid string `json:"plan_uuid"`
+	Deleted  []uint `json:"deleted"`
+	Blocking []uint `json:"blocking"`
+}
+
+type UsageKind string
+
+const (
+	UsageApiCall     UsageKind = "api_call"
+	UsageAiCall      UsageKind = "ai_call"
+	UsageStakworkRun UsageKind = "stakwork_run"
+)
+
+var UsageKinds = []UsageKind{UsageApiCall, UsageAiCall, UsageStakworkRun}
+
+// WorkspaceUsageDaily counts the calls of one kind a workspace made on a day,
+// Day is the UTC date as YYYY-MM-DD
+type WorkspaceUsageDaily struct {
+	ID            uint      `json:"id"`
+	WorkspaceUuid string    `gorm:"uniqueIndex:idx_workspace_usage_day;not null" json:"workspace_uuid"`
+	Day           string    `gorm:"uniqueIndex:idx_workspace_usage_day;size:10;not null" json:"day"`
+	Kind          UsageKind `gorm:"uniqueIndex:idx_workspace_usage_day;not null" json:"kind"`
+	Count         int64     `gorm:"not null;default:0" json:"count"`
+}
+
+type UsageDay struct {
+	Day    string              `json:"day"`
+	Counts map[UsageKind]int64 `json:"counts"`
+}
+
+type WorkspaceUsageReport struct {
+	WorkspaceUuid string              `json:"workspace_uuid"`
+	From          string              `json:"from"`
+	To            string              `json:"to"`
+	Totals        map[UsageKind]int64 `json:"totals"`
+	Days          []UsageDay          `json:"days"`
+}
+
+type WorkspaceUsageTotal struct {
+	WorkspaceUuid string              `json:"workspace_uuid"`
+	Name          string              `json:"name"`
+	Totals        map[UsageKind]int64 `json:"totals"`
+}
+
+// UsageCapacityReport is the usage of every workspace for the super admins
+type UsageCapacityReport struct {
+	From       string                `json:"from"`
+	To         string                `json:"to"`
+	Totals     map[UsageKind]int64   `json:"totals"`
+	Days       []UsageDay            `json:"days"`
+	Workspaces []WorkspaceUsageTotal `json:"workspaces"`
+}
+
+// FeaturePhaseDetail is a phase with the counts of its bounties, the counts
+// are read from a join and never written
+type FeaturePhaseDetail struct {
+	FeaturePhase
+	BountiesCountOpen      int `gorm:"->" json:"bounties_count_open"`
+	BountiesCountAssigned  int `gorm:"->" json:"bounties_count_assigned"`
+	BountiesCountCompleted int `gorm:"->" json:"bounties_count_completed"`
+	BountiesCountPaid      int `gorm:"->" json:"bounties_count_paid"`
+}
+
+func (FeaturePhaseDetail) TableName() string {
+	return "feature_phases"
+}
+
+// FeatureDetail is a feature with its phases, stories and open calls, all the
+// planner shows of a feature at once
+type FeatureDetail struct {
+	WorkspaceFeatures
+	Phases  []FeaturePhaseDetail `gorm:"foreignKey:FeatureUuid;references:Uuid" json:"phases"`
+	Stories []FeatureStory       `gorm:"foreignKey:FeatureUuid;references:Uuid" json:"stories"`
+	Calls   []FeatureCall        `gorm:"foreignKey:FeatureUuid;references:Uuid" json:"calls"`
+}
+
+func (FeatureDetail) TableName() string {
+	return "workspace_features"
+}
+
+// WorkspaceBudgetSnapshot is the budget of a workspace at the end of a day,
+// the snapshot of the current day is refreshed until the day is over
+type WorkspaceBudgetSnapshot struct {
+	ID              uint       `json:"id"`
+	WorkspaceUuid   string     `gorm:"uniqueIndex:idx_budget_snapshot_day;not null" json:"workspace_uuid"`
+	Day             string     `gorm:"uniqueIndex:idx_budget_snapshot_day;size:10;not null" json:"day"`
+	TotalBudget     uint       `gorm:"not null;default:0" json:"total_budget"`
+	OpenBudget      uint       `gorm:"not null;default:0" json:"open_budget"`
+	AssignedBudget  uint       `gorm:"not null;default:0" json:"assigned_budget"`
+	CompletedBudget uint       `gorm:"not null;default:0" json:"completed_budget"`
+	Updated         *time.Time `json:"updated"`
+}
+
+type BudgetGranularity string
+
+const (
+	BudgetByDay  BudgetGranularity = "day"
+	BudgetByWeek BudgetGranularity = "week"
+)
+
+type WorkspaceBudgetHistory struct {
+	WorkspaceUuid string                    `json:"workspace_uuid"`
+	Granularity   BudgetGranularity         `json:"granularity"`
+	From          string                    `json:"from"`
+	To            string                    `json:"to"`
+	Snapshots     []WorkspaceBudgetSnapshot `json:"snapshots"`
+}
+
+// WorkspacePresenceMember is a workspace member with the websocket open
+type WorkspacePresenceMember struct {
+	Pubkey     string    `json:"pubkey"`
+	OwnerAlias string    `json:"owner_alias"`
+	Img        string    `json:"img"`
+	Since      time.Time `json:"since"`
+}
+
+// WorkspaceApiToken is a scoped token a workspace admin minted for a machine,
+// only its hash is stored and the token itself is shown once when it is minted
+type WorkspaceApiToken struct {
+	ID            uint           `json:"id"`
+	WorkspaceUuid string         `gorm:"index;not null" json:"workspace_uuid"`
+	Name          string         `json:"name"`
+	TokenHash     string         `gorm:"uniqueIndex;not null" json:"-"`
+	TokenPrefix   string         `json:"token_prefix"`
+	Scopes        pq.StringArray `gorm:"type:text[]" json:"scopes"`
+	CreatedBy     string         `json:"created_by"`
+	Created       *time.Time     `json:"created"`
+	Expires       *time.Time     `json:"expires,omitempty"`
+	LastUsed      *time.Time     `json:"last_used,omitempty"`
+	Revoked       *time.Time     `json:"revoked,omitempty"`
+}
+
+type WorkspaceApiTokenRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// MintedWorkspaceApiToken is a new api token with the token itself
+type MintedWorkspaceApiToken struct {
+	WorkspaceApiToken
+	Token string `json:"token"`
+}
+
+type WebhookSource string
+
+const (
+	WebhookSourceSentry  WebhookSource = "sentry"
+	WebhookSourceGeneric WebhookSource = "generic"
+)
+
+type RelayEventType string
+
+const (
+	RelayEventMemberJoin       RelayEventType = "member_join"
+	RelayEventMessageCount     RelayEventType = "message_count"
+	RelayEventPaymentConfirmed RelayEventType = "payment_confirmed"
+)
+
+// RelayEvent is something sphinx-relay saw happen, Id is unique per event
+// so a batch that is sent again is only applied once
+type RelayEvent struct {
+	Id             string         `json:"id"`
+	Type           RelayEventType `json:"type"`
+	TribeUuid      string         `json:"tribe_uuid,omitempty"`
+	MemberPubkey   string         `json:"member_pubkey,omitempty"`
+	MessageCount   uint64         `json:"message_count,omitempty"`
+	PaymentRequest string         `json:"payment_request,omitempty"`
+	Timestamp      int64          `json:"timestamp"`
+}
+
+// RelayEventBatch is the signed body the relay posts, Timestamp is when it was signed
+type RelayEventBatch struct {
+	Timestamp int64        `json:"timestamp"`
+	Events    []RelayEvent `json:"events"`
+}
+
+// RelayEventLog records the relay events that were received
+type RelayEventLog struct {
+	ID        uint           `json:"id"`
+	EventId   string         `gorm:"uniqueIndex;not null" json:"event_id"`
+	Type      RelayEventType `json:"type"`
+	TribeUuid string         `json:"tribe_uuid"`
+	Received  *time.Time     `json:"received"`
+}
+
+type RelayEventsResult struct {
+	Applied     int                  `json:"applied"`
+	Duplicates  int                  `json:"duplicates"`
+	Rejected    []RelayEventRejected `json:"rejected"`
+	Corrections []PaymentCorrection  `json:"corrections"`
+}
+
+type RelayEventRejected struct {
+	Id    string `json:"id"`
+	Error string `json:"error"`
+}
+
+type BountyVisibility string
+
+const (
+	BountyVisibilityPublic     BountyVisibility = "public"
+	BountyVisibilityHidden     BountyVisibility = "hidden"
+	BountyVisibilityWorkspace  BountyVisibility = "workspace"
+	BountyVisibilityInviteOnly BountyVisibility = "invite_only"
+)
+
+// WorkspaceSettings holds the options of a workspace, a zero limit means no limit.
+// PaymentApprovalThreshold is the largest payment in sats someone other than the
+// workspace owner can make, WipLimit caps the unfinished bounties a hunter can hold
+// in the workspace, StateWipLimits cap the bounties in a state of a phase or of the
+// workspace and AiAgentMonthlyQuota caps the notes review agents post a month
+type WorkspaceSettings struct {
+	ID                       uint             `json:"id"`
+	WorkspaceUuid            string           `gorm:"uniqueIndex;not null" json:"workspace_uuid"`
+	PaymentApprovalThreshold uint             `json:"payment_approval_threshold"`
+	WipLimit                 uint             `json:"wip_limit"`
+	StateWipLimits           StateWipLimits   `gorm:"type:jsonb" json:"state_wip_limits"`
+	DefaultBountyVisibility  BountyVisibility `gorm:"default:'public'" json:"default_bounty_visibility"`
+	AiAgentMonthlyQuota      uint             `json:"ai_agent_monthly_quota"`
+	// the assistant runs of the workspace use this provider and model, the key
+	// reference names the secret holding the provider key and never the key itself
+	AiProvider       AiProvider `json:"ai_provider"`
+	AiModel          string     `json:"ai_model"`
+	AiTemperature    *float64   `json:"ai_temperature"`
+	AiProviderKeyRef string     `json:"ai_provider_key_ref"`
+	// PublicShowcase opts the workspace into the public showcase page
+	PublicShowcase bool `json:"public_showcase"`
+	// ActivityPub publishes the public bounties of the workspace to the fediverse
+	ActivityPub bool `json:"activitypub"`
+	// DisplayCurrency shows the budget and bounty amounts of the workspace in
+	// this currency next to the sats, empty shows sats only
+	DisplayCurrency string     `json:"display_currency"`
+	UpdatedBy       string     `json:"updated_by"`
+	Created         *time.Time `json:"created"`
+	Updated         *time.Time `json:"updated"`
+}
+
+// StateWipLimit caps the bounties that can be in a state at once, in one phase
+// or across the workspace when PhaseUuid is empty
+type StateWipLimit struct {
+	PhaseUuid string      `json:"phase_uuid,omitempty"`
+	State     BountyState `json:"state"`
+	Limit     uint        `json:"limit"`
+}
+
+type StateWipLimits []StateWipLimit
+
+func (l StateWipLimits) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	return string(b), err
+}
+
+func (l *StateWipLimits) Scan(src interface{}) error {
+	switch source := src.(type) {
+	case nil:
+		*l = StateWipLimits{}
+		return nil
+	case []byte:
+		return json.Unmarshal(source, l)
+	case string:
+		return json.Unmarshal([]byte(source), l)
+	}
+	return errors.New("type assertion .([]byte) failed")
+}
+
+// WipLimitCount is a state limit with the bounties it counts
+type WipLimitCount struct {
+	StateWipLimit
+	Count int64 `json:"count"`
+}
+
+type AiProvider string
+
+const (
+	AiProviderAnthropic AiProvider = "anthropic"
+	AiProviderOpenAI    AiProvider = "openai"
+	AiProviderStakwork  AiProvider = "stakwork"
+)
+
+// WorkspaceAiSettings is what the assistant jobs of a workspace are started with
+type WorkspaceAiSettings struct {
+	Provider    AiProvider `json:"provider"`
+	Model       string     `json:"model"`
+	Temperature *float64   `json:"temperature,omitempty"`
+	KeyRef      string     `json:"key_ref,omitempty"`
+}
+
+// WorkspaceWebhook turns alerts an external service like Sentry or a CI pipeline
+// posts into draft bounties under a feature phase. The templates map the alert
+// payload to the bounty and the fingerprint template dedupes repeated alerts
+type WorkspaceWebhook struct {
+	ID                  uint          `json:"id"`
+	Uuid                string        `gorm:"uniqueIndex;not null" json:"uuid"`
+	WorkspaceUuid       string        `gorm:"index;not null" json:"workspace_uuid"`
+	Name                string        `json:"name"`
+	Source              WebhookSource `json:"source"`
+	Secret              string        `json:"-"`
+	FeatureUuid         string        `json:"feature_uuid"`
+	PhaseUuid           string        `json:"phase_uuid"`
+	TitleTemplate       string        `json:"title_template"`
+	DescriptionTemplate string        `json:"description_template"`
+	FingerprintTemplate string        `json:"fingerprint_template"`
+	CreatedBy           string        `json:"created_by"`
+	Created             *time.Time    `json:"created"`
+	Updated             *time.Time    `json:"updated"`
+}
+
+// WorkspaceTag labels the bounties of a workspace, a bounty keeps the uuids of its tags
+type WorkspaceTag struct {
+	ID            uint       `json:"id"`
+	Uuid          string     `gorm:"uniqueIndex;not null" json:"uuid"`
+	WorkspaceUuid string     `gorm:"uniqueIndex:idx_workspace_tags_name;not null" json:"workspace_uuid"`
+	Name          string     `gorm:"uniqueIndex:idx_workspace_tags_name;not null" json:"name"`
+	Color         string     `json:"color"`
+	Description   string     `json:"description"`
+	CreatedBy     string     `json:"created_by"`
+	Created       *time.Time `json:"created"`
+	Updated       *time.Time `json:"updated"`
+}
+
+type BountyTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// CreatedWorkspaceWebhook is a new webhook with the secret its payloads are signed with
+type CreatedWorkspaceWebhook struct {
+	WorkspaceWebhook
+	Secret string `json:"secret"`
+}
+
+// WebhookAlert is an alert fingerprint a webhook has seen and the draft bounty made for it
+type WebhookAlert struct {
+	ID          uint       `json:"id"`
+	WebhookUuid string     `gorm:"uniqueIndex:idx_webhook_alert_fingerprint;not null" json:"webhook_uuid"`
+	Fingerprint string     `gorm:"uniqueIndex:idx_webhook_alert_fingerprint;not null" json:"fingerprint"`
+	BountyID    uint       `json:"bounty_id"`
+	Occurrences uint       `json:"occurrences"`
+	FirstSeen   *time.Time `json:"first_seen"`
+	LastSeen    *time.Time `json:"last_seen"`
+}
+
+type WebhookAlertResult struct {
+	BountyID     uint `json:"bounty_id"`
+	Deduplicated bool `json:"deduplicated"`
+	Occurrences  uint `json:"occurrences"`
+}
+
+// JiraUserLink maps the email of a Jira user to the pubkey of a workspace member
+type JiraUserLink struct {
+	ID            uint       `json:"id"`
+	WorkspaceUuid string     `gorm:"uniqueIndex:idx_jira_user_link_email;not null" json:"workspace_uuid"`
+	Email         string     `gorm:"uniqueIndex:idx_jira_user_link_email;not null" json:"email"`
+	OwnerPubkey   string     `gorm:"not null" json:"owner_pubkey"`
+	CreatedBy     string     `json:"created_by"`
+	Created       *time.Time `json:"created"`
+	Updated       *time.Time `json:"updated"`
+}
+
+// JiraIssueLink remembers the bounty a Jira issue was imported into,
+// so importing the issue again updates that bounty
+type JiraIssueLink struct {
+	ID            uint       `json:"id"`
+	WorkspaceUuid string     `gorm:"uniqueIndex:idx_jira_issue_link_key;not null" json:"workspace_uuid"`
+	IssueKey      string     `gorm:"uniqueIndex:idx_jira_issue_link_key;not null" json:"issue_key"`
+	BountyID      uint       `gorm:"index;not null" json:"bounty_id"`
+	Created       *time.Time `json:"created"`
+	Updated       *time.Time `json:"updated"`
+}
+
+type JiraNamed struct {
+	Name string `json:"name"`
+}
+
+type JiraUser struct {
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName,omitempty"`
+}
+
+type JiraIssueFields struct {
+	Summary     string    `json:"summary"`
+	Description string    `json:"description"`
+	IssueType   JiraNamed `json:"issuetype"`
+	Status      JiraNamed `json:"status"`
+	Assignee    *JiraUser `json:"assignee"`
+	Reporter    *JiraUser `json:"reporter"`
+	Labels      []string  `json:"labels"`
+}
+
+// JiraIssue is an issue in the shape of the Jira REST api v2,
+// used for both the JSON export and the import
+type JiraIssue struct {
+	Key    string          `json:"key,omitempty"`
+	Fields JiraIssueFields `json:"fields"`
+}
+
+type JiraIssues struct {
+	Issues []JiraIssue `json:"issues"`
+}
+
+// JiraBountyImport is a Jira issue mapped to the bounty it is imported as
+type JiraBountyImport struct {
+	IssueKey string
+	Bounty   NewBounty
+}
+
+type JiraImportResult struct {
+	Created           []string `json:"created"`
+	Updated           []string `json:"updated"`
+	Skipped           []string `json:"skipped"`
+	UnmappedAssignees []string `json:"unmapped_assignees"`
+}
+
+// ConfigOverride changes a config value at runtime, see config.ApplyOverrides
+type ConfigOverride struct {
+	Key       string     `gorm:"primaryKey" json:"key"`
+	Value     string     `gorm:"not null" json:"value"`
+	UpdatedBy string     `json:"updated_by"`
+	Updated   *time.Time `json:"updated"`
+}
+
+func (ConfigOverride) TableName() string {
+	return "configs"
+}
+
+type ConfigOverrideRequest struct {
+	Value string `json:"value"`
+}
+
+// MaintenanceStatus is the maintenance_mode and maintenance_message overrides
+type MaintenanceStatus struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"`
+}
+
+// SuperAdmin is a super admin added through the api, on top of the ones in SUPER_ADMINS
+type SuperAdmin struct {
+	ID      uint       `json:"id"`
+	Pubkey  string     `gorm:"uniqueIndex;not null" json:"pubkey"`
+	AddedBy string     `json:"added_by"`
+	Created *time.Time `json:"created"`
+}
+
+// WebhookSigningSecret is the secret a service signs the webhooks it calls
+// tribes with, on top of the one in WEBHOOK_SECRET_<SOURCE>
+type WebhookSigningSecret struct {
+	ID        uint            `json:"id"`
+	Source    string          `gorm:"uniqueIndex;not null" json:"source"`
+	Secret    EncryptedString `json:"-"`
+	UpdatedBy string          `json:"updated_by"`
+	Created   *time.Time      `json:"created"`
+	Updated   *time.Time      `json:"updated"`
+}
+
+// RotatedWebhookSigningSecret is a new signing secret, it is only shown once
+type RotatedWebhookSigningSecret struct {
+	WebhookSigningSecret
+	Secret string `json:"secret"`
+}
+
+type SuperAdminChangeAction string
+
+const (
+	SuperAdminAdd    SuperAdminChangeAction = "add"
+	SuperAdminRemove SuperAdminChangeAction = "remove"
+)
+
+// SuperAdminChange is a requested change to the super admins, it is only
+// applied once a super admin confirms it with its code before it expires
+type SuperAdminChange struct {
+	ID               uint                   `json:"id"`
+	Action           SuperAdminChangeAction `json:"action"`
+	Pubkey           string                 `json:"pubkey"`
+	RequestedBy      string                 `json:"requested_by"`
+	ConfirmationCode string                 `gorm:"uniqueIndex;not null" json:"confirmation_code,omitempty"`
+	ConfirmedBy      string                 `json:"confirmed_by,omitempty"`
+	Confirmed        *time.Time             `json:"confirmed,omitempty"`
+	Expires          *time.Time             `json:"expires"`
+	Created          *time.Time             `json:"created"`
+}
+
+type SuperAdminChangeRequest struct {
+	Pubkey string `json:"pubkey"`
+}
+
+type SuperAdminConfirmation struct {
+	ConfirmationCode string `json:"confirmation_code"`
+}
+
+// SuperAdminInfo is a super admin with where it is set, env admins cannot be removed
+type SuperAdminInfo struct {
+	Pubkey  string     `json:"pubkey"`
+	Source  string     `json:"source"`
+	AddedBy string     `json:"added_by,omitempty"`
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// PersonMergeCounts is the number of rows a merge re-points, keyed by table.column
+type PersonMergeCounts map[string]int64
+
+func (c PersonMergeCounts) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(c)
+	return string(b), err
+}
+
+func (c *PersonMergeCounts) Scan(src interface{}) error {
+	switch source := src.(type) {
+	case nil:
+		*c = PersonMergeCounts{}
+		return nil
+	case []byte:
+		return json.Unmarshal(source, c)
+	case string:
+		return json.Unmarshal([]byte(source), c)
+	}
+	return errors.New("type assertion .([]byte) failed")
+}
+
+// PersonMerge is the audit record of two person records merged into one
+type PersonMerge struct {
+	ID           uint              `json:"id"`
+	SourcePubkey string            `gorm:"index;not null" json:"source_pubkey"`
+	TargetPubkey string            `gorm:"index;not null" json:"target_pubkey"`
+	MergedBy     string            `json:"merged_by"`
+	Counts       PersonMergeCounts `gorm:"type:jsonb" json:"counts"`
+	Created      *time.Time        `json:"created"`
+}
+
+// HandleReservation keeps a handle from being claimed, only ReservedFor can take it
+// until it expires. An empty ReservedFor holds it for nobody, a nil Expires holds it forever
+type HandleReservation struct {
+	ID          uint       `json:"id"`
+	Handle      string     `gorm:"uniqueIndex;not null" json:"handle"`
+	ReservedFor string     `json:"reserved_for,omitempty"`
+	Reason      string     `json:"reason"`
+	Expires     *time.Time `json:"expires,omitempty"`
+	CreatedBy   string     `json:"created_by"`
+	Created     *time.Time `json:"created"`
+}
+
+type HandleRequest struct {
+	Handle string `json:"handle"`
+}
+
+type AliasCollisionPerson struct {
+	OwnerPubKey     string  `json:"owner_pubkey"`
+	OwnerAlias      string  `json:"owner_alias"`
+	UniqueName      string  `json:"unique_name"`
+	Handle          *string `json:"handle,omitempty"`
+	SuggestedHandle string  `json:"suggested_handle"`
+}
+
+// AliasCollision is a group of people sharing an alias, with the handles
+// that would tell them apart
+type AliasCollision struct {
+	Alias  string                 `json:"alias"`
+	People []AliasCollisionPerson `json:"people"`
+}
+
+type PersonMergeRequest struct {
+	SourcePubkey string `json:"source_pubkey"`
+	TargetPubkey string `json:"target_pubkey"`
+	DryRun       bool   `json:"dry_run"`
+}
+
+// PersonMergeResult is what a merge changed, or would change on a dry run
+type PersonMergeResult struct {
+	SourcePubkey string            `json:"source_pubkey"`
+	TargetPubkey string            `json:"target_pubkey"`
+	DryRun       bool              `json:"dry_run"`
+	Counts       PersonMergeCounts `json:"counts"`
+	Merge        *PersonMerge      `json:"merge,omitempty"`
+}
+
+type PaymentFailureAlert struct {
+	WorkspaceUuid string `json:"workspace_uuid"`
+	BountyId      uint   `json:"bounty_id,omitempty"`
+	PaymentType   string `json:"payment_type"`
+	Amount        uint   `json:"amount"`
+	Reason        string `json:"reason"`
+	RetryLink     string `json:"retry_link"`
 }
 
 type BountyOwners struct {
@@ -439,6 +1717,9 @@ type BountyData struct {
 	BountyCreated     int64      `json:"bounty_created"`
 	BountyUpdated     *time.Time `json:"bounty_updated"`
 	BountyDescription string     `json:"bounty_description"`
+	// the bounty and its owner both have tags, the embedded ones collide so the
+	// tags of the bounty are sent as tags and the ones of its owner as owner_tags
+	BountyTags pq.StringArray `json:"tags"`
 	Person
 	AssigneeAlias           string         `json:"assignee_alias"`
 	AssigneeId              uint           `json:"assignee_id"`
@@ -478,6 +1759,16 @@ type BountyResponse struct {
 	Owner        Person         `json:"owner"`
 	Organization WorkspaceShort `json:"organization"`
 	Workspace    WorkspaceShort `json:"workspace"`
+	Display      *DisplayAmount `json:"display,omitempty"`
+}
+
+// DisplayAmount is an amount in sats in the display currency of its workspace,
+// with the bitcoin price it was converted at and when that price was updated
+type DisplayAmount struct {
+	Currency      string    `json:"currency"`
+	Amount        float64   `json:"amount"`
+	BtcPrice      float64   `json:"btc_price"`
+	RateTimestamp time.Time `json:"rate_timestamp"`
 }
 
 type BountyCountResponse struct {
@@ -526,6 +1817,102 @@ type Workspace struct {
 	Tactics      string     `json:"tactics"`
 	SchematicUrl string     `json:"schematic_url"`
 	SchematicImg string     `json:"schematic_img"`
+	AlertWebhook string     `json:"alert_webhook" validate:"omitempty,uri"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	// DataSchema is the Postgres schema of an isolated workspace, see IsolateWorkspace.
+	// IsolatedData asks for one when the workspace is created
+	DataSchema   string `json:"data_schema,omitempty"`
+	IsolatedData bool   `gorm:"-" json:"isolated_data,omitempty"`
+}
+
+// ParentOrganization owns several workspaces. Its owner and members get their
+// organization roles on every workspace of the organization
+type ParentOrganization struct {
+	ID          uint       `json:"id"`
+	Uuid        string     `gorm:"uniqueIndex;not null" json:"uuid"`
+	Name        string     `gorm:"not null" json:"name"`
+	OwnerPubKey string     `gorm:"not null" json:"owner_pubkey"`
+	Created     *time.Time `json:"created"`
+	Updated     *time.Time `json:"updated"`
+}
+
+// ParentOrganizationWorkspace links a workspace to the one organization owning it
+type ParentOrganizationWorkspace struct {
+	ID            uint       `json:"id"`
+	OrgUuid       string     `gorm:"index;not null" json:"org_uuid"`
+	WorkspaceUuid string     `gorm:"uniqueIndex;not null" json:"workspace_uuid"`
+	Created       *time.Time `json:"created"`
+}
+
+// ParentOrganizationMember is a role a user has on all the workspaces of an organization
+type ParentOrganizationMember struct {
+	ID          uint       `json:"id"`
+	OrgUuid     string     `gorm:"uniqueIndex:idx_org_member_role;not null" json:"org_uuid"`
+	OwnerPubKey string     `gorm:"uniqueIndex:idx_org_member_role;not null" json:"owner_pubkey"`
+	Role        string     `gorm:"uniqueIndex:idx_org_member_role;not null" json:"role"`
+	Created     *time.Time `json:"created"`
+}
+
+type ParentOrganizationMemberRequest struct {
+	OwnerPubKey string   `json:"owner_pubkey"`
+	Roles       []string `json:"roles"`
+}
+
+type OrgWorkspaceSummary struct {
+	Uuid        string `json:"uuid"`
+	Name        string `json:"name"`
+	Img         string `json:"img"`
+	Budget      uint   `json:"budget"`
+	BountyCount int64  `json:"bounty_count"`
+}
+
+type OrgBountyStateCount struct {
+	State  BountyState `json:"state"`
+	Count  int64       `json:"count"`
+	Amount uint        `json:"amount"`
+}
+
+// ParentOrganizationOverview rolls the budgets and bounties of the workspaces of an organization up
+type ParentOrganizationOverview struct {
+	Organization ParentOrganization    `json:"organization"`
+	Workspaces   []OrgWorkspaceSummary `json:"workspaces"`
+	TotalBudget  uint                  `json:"total_budget"`
+	TotalPaid    uint                  `json:"total_paid"`
+	Bounties     []OrgBountyStateCount `json:"bounties"`
+}
+
+// OrgWorkspaceRequest names the workspace to link to an organization or to move a feature to
+type OrgWorkspaceRequest struct {
+	WorkspaceUuid string `json:"workspace_uuid"`
+}
+
+// WorkspaceArchiveMembers are the members and roles a workspace had when it was deleted
+type WorkspaceArchiveMembers struct {
+	Users []WorkspaceUsers     `json:"users"`
+	Roles []WorkspaceUserRoles `json:"roles"`
+}
+
+// WorkspaceArchive keeps what deleting a workspace removes or hides, so a super
+// admin can restore the workspace within the retention window
+type WorkspaceArchive struct {
+	ID             uint                    `json:"id"`
+	WorkspaceUuid  string                  `gorm:"uniqueIndex;not null" json:"workspace_uuid"`
+	Website        string                  `json:"website"`
+	Github         string                  `json:"github"`
+	Description    string                  `json:"description"`
+	Show           bool                    `json:"show"`
+	Members        WorkspaceArchiveMembers `gorm:"type:jsonb" json:"members"`
+	ShownBountyIDs pq.Int64Array           `gorm:"type:bigint[]" json:"shown_bounty_ids"`
+	DeletedAt      *time.Time              `json:"deleted_at"`
+}
+
+// DeletedWorkspace is a deleted workspace that can still be restored
+type DeletedWorkspace struct {
+	Uuid            string     `json:"uuid"`
+	Name            string     `json:"name"`
+	OwnerPubKey     string     `json:"owner_pubkey"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+	RestorableUntil time.Time  `json:"restorable_until"`
 }
 
 type WorkspaceShort struct {
@@ -558,6 +1945,49 @@ type WorkspaceUsersData struct {
 	Person
 }
 
+// WorkspaceCloneRequest names the workspace a clone creates, features are only
+// copied with their phases and stories when IncludeFeatures is set
+type WorkspaceCloneRequest struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	IncludeFeatures bool   `json:"include_features"`
+}
+
+// WorkspaceCloneResult is the new workspace and how much of the source was copied into it
+type WorkspaceCloneResult struct {
+	Workspace Workspace `json:"workspace"`
+	Members   int       `json:"members"`
+	Roles     int       `json:"roles"`
+	Tags      int       `json:"tags"`
+	Features  int       `json:"features"`
+	Phases    int       `json:"phases"`
+	Stories   int       `json:"stories"`
+}
+
+// WorkspaceShowcase is the public recruiting page of a workspace that opted in,
+// it only shows public bounties
+type WorkspaceShowcase struct {
+	Uuid                   string           `json:"uuid"`
+	Name                   string           `json:"name"`
+	Img                    string           `json:"img"`
+	Description            string           `json:"description"`
+	Mission                string           `json:"mission"`
+	Website                string           `json:"website"`
+	Github                 string           `json:"github"`
+	OpenBounties           []ShowcaseBounty `json:"open_bounties"`
+	OpenBountiesCount      int64            `json:"open_bounties_count"`
+	CompletedBountiesCount int64            `json:"completed_bounties_count"`
+}
+
+type ShowcaseBounty struct {
+	ID              uint           `json:"id"`
+	Title           string         `json:"title"`
+	Price           uint           `json:"price"`
+	CodingLanguages pq.StringArray `gorm:"type:text[]" json:"coding_languages"`
+	EstimatedHours  float64        `json:"estimated_hours"`
+	Created         int64          `json:"created"`
+}
+
 type WorkspaceRepositories struct {
 	ID            uint       `json:"id"`
 	Uuid          string     `gorm:"not null" json:"uuid"`
@@ -570,23 +2000,109 @@ type WorkspaceRepositories struct {
 	UpdatedBy     string     `json:"updated_by"`
 }
 
+type CodeGraphStatus string
+
+const (
+	CodeGraphPending   CodeGraphStatus = "pending"
+	CodeGraphSyncing   CodeGraphStatus = "syncing"
+	CodeGraphCompleted CodeGraphStatus = "completed"
+	CodeGraphFailed    CodeGraphStatus = "failed"
+)
+
+type WorkspaceCodeGraph struct {
+	ID            uint            `json:"id"`
+	Uuid          string          `gorm:"not null" json:"uuid"`
+	WorkspaceUuid string          `gorm:"not null" json:"workspace_uuid"`
+	Name          string          `gorm:"not null" json:"name"`
+	Url           string          `json:"url" validate:"omitempty,uri"`
+	Status        CodeGraphStatus `gorm:"default:'pending'" json:"status"`
+	Progress      int             `json:"progress"`
+	Error         string          `json:"error"`
+	LastSynced    *time.Time      `json:"last_synced"`
+	Created       *time.Time      `json:"created"`
+	Updated       *time.Time      `json:"updated"`
+	CreatedBy     string          `json:"created_by"`
+	UpdatedBy     string          `json:"updated_by"`
+}
+
+type CodeGraphSyncRequest struct {
+	Websocket_token string `json:"websocket_token,omitempty"`
+}
+
+type CodeGraphCallback struct {
+	Uuid     string          `json:"uuid"`
+	Status   CodeGraphStatus `json:"status"`
+	Progress int             `json:"progress"`
+	Error    string          `json:"error"`
+}
+
 type WorkspaceFeatures struct {
-	ID                     uint       `json:"id"`
-	Uuid                   string     `gorm:"not null" json:"uuid"`
-	WorkspaceUuid          string     `gorm:"not null" json:"workspace_uuid"`
-	Name                   string     `gorm:"not null" json:"name"`
-	Brief                  string     `json:"brief"`
-	Requirements           string     `json:"requirements"`
-	Architecture           string     `json:"architecture"`
-	Url                    string     `json:"url"`
-	Priority               int        `json:"priority"`
-	Created                *time.Time `json:"created"`
-	Updated                *time.Time `json:"updated"`
-	CreatedBy              string     `json:"created_by"`
-	UpdatedBy              string     `json:"updated_by"`
-	BountiesCountCompleted int        `gorm:"-" json:"bounties_count_completed"`
-	BountiesCountAssigned  int        `gorm:"-" json:"bounties_count_assigned"`
-	BountiesCountOpen      int        `gorm:"-" json:"bounties_count_open"`
+	ID                     uint          `json:"id"`
+	Uuid                   string        `gorm:"not null" json:"uuid"`
+	WorkspaceUuid          string        `gorm:"not null" json:"workspace_uuid"`
+	Name                   string        `gorm:"not null" json:"name"`
+	Brief                  string        `json:"brief"`
+	BriefSections          BriefSections `gorm:"type:jsonb" json:"brief_sections"`
+	Requirements           string        `json:"requirements"`
+	Architecture           string        `json:"architecture"`
+	Url                    string        `json:"url"`
+	Priority               int           `json:"priority"`
+	Created                *time.Time    `json:"created"`
+	Updated                *time.Time    `json:"updated"`
+	CreatedBy              string        `json:"created_by"`
+	UpdatedBy              string        `json:"updated_by"`
+	BountiesCountCompleted int           `gorm:"->;not null;default:0" json:"bounties_count_completed"`
+	BountiesCountAssigned  int           `gorm:"->;not null;default:0" json:"bounties_count_assigned"`
+	BountiesCountOpen      int           `gorm:"->;not null;default:0" json:"bounties_count_open"`
+}
+
+// FeatureCountDrift is a feature whose stored bounty counts don't match the
+// bounties of its phases
+type FeatureCountDrift struct {
+	FeatureUuid     string `json:"feature_uuid"`
+	WorkspaceUuid   string `json:"workspace_uuid"`
+	StoredOpen      int    `json:"stored_open"`
+	StoredAssigned  int    `json:"stored_assigned"`
+	StoredCompleted int    `json:"stored_completed"`
+	ActualOpen      int    `json:"actual_open"`
+	ActualAssigned  int    `json:"actual_assigned"`
+	ActualCompleted int    `json:"actual_completed"`
+}
+
+type BriefMergeMode string
+
+const (
+	BriefAppendMode       BriefMergeMode = "append"
+	BriefReplaceMode      BriefMergeMode = "replace"
+	BriefSectionMergeMode BriefMergeMode = "section-merge"
+)
+
+// BriefSection is a named part of a feature brief, generated briefs
+// only update their own section
+type BriefSection struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+type BriefSections []BriefSection
+
+type FeatureBriefRequest struct {
+	FeatureUuid string         `json:"feature_uuid"`
+	Brief       string         `json:"brief"`
+	Mode        BriefMergeMode `json:"mode"`
+	Section     string         `json:"section"`
+}
+
+// FeatureBriefLock is an advisory lock on a feature brief, it is
+// kept in the cache and released once it expires
+type FeatureBriefLock struct {
+	FeatureUuid string    `json:"feature_uuid"`
+	Holder      string    `json:"holder"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type FeatureBriefLockRequest struct {
+	Ttl int `json:"ttl"`
 }
 
 type FeaturePhase struct {
@@ -600,6 +2116,383 @@ type FeaturePhase struct {
 	UpdatedBy   string     `json:"updated_by"`
 }
 
+type PhaseDocumentType string
+
+const (
+	PhasePlanDocument         PhaseDocumentType = "plan"
+	PhaseRequirementsDocument PhaseDocumentType = "requirements"
+	PhaseArchitectureDocument PhaseDocumentType = "architecture"
+)
+
+// PhaseDocument is an immutable version of a phase document,
+// edits and restores always add a new version
+type PhaseDocument struct {
+	ID          uint              `json:"id"`
+	Uuid        string            `gorm:"not null" json:"uuid"`
+	FeatureUuid string            `gorm:"not null" json:"feature_uuid"`
+	PhaseUuid   string            `gorm:"not null;index;uniqueIndex:idx_phase_documents_version" json:"phase_uuid"`
+	DocType     PhaseDocumentType `gorm:"not null;uniqueIndex:idx_phase_documents_version" json:"doc_type"`
+	Version     int               `gorm:"not null;uniqueIndex:idx_phase_documents_version" json:"version"`
+	Content     string            `json:"content"`
+	Created     *time.Time        `json:"created"`
+	CreatedBy   string            `json:"created_by"`
+}
+
+type FeatureCallProvider string
+
+const (
+	FeatureCallJitsi FeatureCallProvider = "jitsi"
+	FeatureCallMeet  FeatureCallProvider = "meet"
+	FeatureCallZoom  FeatureCallProvider = "zoom"
+)
+
+// FeatureCall is a call scheduled for a feature, every call is its own row
+// so ended calls and their recordings stay in the history of the feature
+type FeatureCall struct {
+	ID              uint                `json:"id"`
+	Uuid            string              `gorm:"uniqueIndex;not null" json:"uuid"`
+	FeatureUuid     string              `gorm:"index;not null" json:"feature_uuid"`
+	WorkspaceUuid   string              `json:"workspace_uuid"`
+	Title           string              `json:"title"`
+	Provider        FeatureCallProvider `gorm:"not null" json:"provider"`
+	Url             string              `gorm:"not null" json:"url"`
+	ScheduledAt     *time.Time          `json:"scheduled_at"`
+	DurationMinutes int                 `json:"duration_minutes"`
+	Recurrence      string              `json:"recurrence"`
+	RecordingUrl    string              `json:"recording_url"`
+	EndedAt         *time.Time          `json:"ended_at"`
+	CreatedBy       string              `json:"created_by"`
+	UpdatedBy       string              `json:"updated_by"`
+	Created         *time.Time          `json:"created"`
+	Updated         *time.Time          `json:"updated"`
+}
+
+// CalendarToken lets a calendar app read the calendar feed of a person, it is
+// signed for its owner and stops working once revoked
+type CalendarToken struct {
+	ID          uint       `json:"id"`
+	Uuid        string     `gorm:"uniqueIndex;not null" json:"uuid"`
+	OwnerPubKey string     `gorm:"index;not null" json:"owner_pubkey"`
+	Created     *time.Time `json:"created"`
+	LastUsed    *time.Time `json:"last_used,omitempty"`
+	Revoked     *time.Time `json:"revoked,omitempty"`
+}
+
+type CalendarTokenResponse struct {
+	Token string `json:"token"`
+	Url   string `json:"url"`
+}
+
+type ShortLinkTarget string
+
+const (
+	ShortLinkBounty  ShortLinkTarget = "bounty"
+	ShortLinkFeature ShortLinkTarget = "feature"
+	ShortLinkInvite  ShortLinkTarget = "invite"
+)
+
+// ShortLink redirects /s/{code} to a bounty, a feature or an invite, the code
+// carries a signature so guessed codes are turned down before a lookup
+type ShortLink struct {
+	ID          uint            `json:"id"`
+	Code        string          `gorm:"uniqueIndex;not null" json:"code"`
+	TargetType  ShortLinkTarget `json:"target_type"`
+	TargetId    string          `json:"target_id"`
+	Url         string          `json:"url"`
+	Clicks      uint64          `json:"clicks"`
+	LastClicked *time.Time      `json:"last_clicked,omitempty"`
+	ExpiresAt   *time.Time      `json:"expires_at,omitempty"`
+	CreatedBy   string          `gorm:"index" json:"created_by"`
+	Created     *time.Time      `json:"created"`
+	ShortUrl    string          `gorm:"-" json:"short_url"`
+}
+
+type ShortLinkRequest struct {
+	TargetType    ShortLinkTarget `json:"target_type"`
+	TargetId      string          `json:"target_id"`
+	ExpiresInDays int             `json:"expires_in_days"`
+}
+
+type NostrEventAction string
+
+const (
+	NostrEventPublish NostrEventAction = "publish"
+	NostrEventUpdate  NostrEventAction = "update"
+	NostrEventDelete  NostrEventAction = "delete"
+)
+
+type NostrEventStatus string
+
+const (
+	// NostrEventPublished events were accepted by at least one relay
+	NostrEventPublished NostrEventStatus = "published"
+	NostrEventFailed    NostrEventStatus = "failed"
+)
+
+// NostrEvent is an event sent to the Nostr relays about a bounty, the listing of
+// an open bounty, its update or its deletion. Relays holds the relays that
+// accepted it and Error what the others answered
+type NostrEvent struct {
+	ID          uint             `json:"id"`
+	EventId     string           `gorm:"index;not null" json:"event_id"`
+	BountyID    uint             `gorm:"index;not null" json:"bounty_id"`
+	Kind        int              `json:"kind"`
+	Action      NostrEventAction `json:"action"`
+	BountyState BountyState      `json:"bounty_state"`
+	Status      NostrEventStatus `gorm:"index" json:"status"`
+	Relays      pq.StringArray   `gorm:"type:text[]" json:"relays"`
+	Error       string           `json:"error,omitempty"`
+	Created     *time.Time       `json:"created"`
+}
+
+// NostrBountySync is a bounty whose Nostr listing may be out of date, Event is
+// the last published event of a listing that was not deleted, nil when the
+// bounty has none yet. Bounty is empty when the bounty is gone
+type NostrBountySync struct {
+	BountyID uint
+	Bounty   NewBounty
+	Event    *NostrEvent
+}
+
+type TranscriptSegment struct {
+	Start   float64 `json:"start"`
+	End     float64 `json:"end"`
+	Speaker string  `json:"speaker"`
+	Text    string  `json:"text"`
+}
+
+// FeatureCallTranscriptRequest is a transcript uploaded by the recording pipeline,
+// as plain content or as timed segments
+type FeatureCallTranscriptRequest struct {
+	Content  string              `json:"content"`
+	Language string              `json:"language"`
+	Segments []TranscriptSegment `json:"segments"`
+}
+
+// FeatureCallTranscript is the transcript of a call, uploading it again replaces it
+type FeatureCallTranscript struct {
+	ID            uint                         `json:"id"`
+	Uuid          string                       `gorm:"uniqueIndex;not null" json:"uuid"`
+	CallUuid      string                       `gorm:"uniqueIndex;not null" json:"call_uuid"`
+	FeatureUuid   string                       `gorm:"index;not null" json:"feature_uuid"`
+	WorkspaceUuid string                       `json:"workspace_uuid"`
+	Language      string                       `json:"language"`
+	Content       string                       `json:"content"`
+	ChunkCount    int                          `json:"chunk_count"`
+	ContextTags   pq.StringArray               `gorm:"type:text[]" json:"context_tags"`
+	UploadedBy    string                       `json:"uploaded_by"`
+	Created       *time.Time                   `json:"created"`
+	Updated       *time.Time                   `json:"updated"`
+	Chunks        []FeatureCallTranscriptChunk `gorm:"-" json:"chunks,omitempty"`
+}
+
+// FeatureCallTranscriptChunk is a part of a transcript small enough to be
+// searched and quoted on its own
+type FeatureCallTranscriptChunk struct {
+	ID             uint     `json:"id"`
+	TranscriptUuid string   `gorm:"index;not null" json:"transcript_uuid"`
+	CallUuid       string   `gorm:"not null" json:"call_uuid"`
+	FeatureUuid    string   `gorm:"index;not null" json:"feature_uuid"`
+	Position       int      `json:"position"`
+	Content        string   `json:"content"`
+	StartSeconds   *float64 `json:"start_seconds"`
+	EndSeconds     *float64 `json:"end_seconds"`
+}
+
+type ChatRole string
+
+const (
+	ChatRoleUser      ChatRole = "user"
+	ChatRoleAssistant ChatRole = "assistant"
+)
+
+// Chat is a hivechat conversation in a workspace
+type Chat struct {
+	ID            uint       `json:"id"`
+	Uuid          string     `gorm:"uniqueIndex;not null" json:"uuid"`
+	WorkspaceUuid string     `gorm:"index;not null" json:"workspace_uuid"`
+	Title         string     `json:"title"`
+	CreatedBy     string     `json:"created_by"`
+	Created       *time.Time `json:"created"`
+	Updated       *time.Time `json:"updated"`
+}
+
+// ChatMessage is a message of a chat, assistant messages are posted by agents
+// with an api token and can come with artifacts like code blocks
+type ChatMessage struct {
+	ID           uint       `json:"id"`
+	Uuid         string     `gorm:"uniqueIndex;not null" json:"uuid"`
+	ChatId       string     `gorm:"index;not null" json:"chat_id"`
+	Role         ChatRole   `gorm:"not null" json:"role"`
+	SenderPubkey string     `json:"sender_pubkey"`
+	Content      string     `gorm:"type:text" json:"content"`
+	Artifacts    []Artifact `gorm:"-" json:"artifacts"`
+	Created      *time.Time `json:"created"`
+}
+
+type ArtifactType string
+
+const (
+	ArtifactCode   ArtifactType = "code"
+	ArtifactSse    ArtifactType = "sse"
+	ArtifactVisual ArtifactType = "visual"
+	ArtifactText   ArtifactType = "text"
+)
+
+var ArtifactTypes = []ArtifactType{ArtifactCode, ArtifactSse, ArtifactVisual, ArtifactText}
+
+// Artifact is an output of a chat, like a code block, an SSE item or a visual.
+// It can be linked to a feature or a ticket, and every change of its content
+// is kept as an ArtifactVersion
+type Artifact struct {
+	ID            uint         `json:"id"`
+	Uuid          string       `gorm:"uniqueIndex;not null" json:"uuid"`
+	WorkspaceUuid string       `gorm:"index;not null" json:"workspace_uuid"`
+	ChatId        string       `gorm:"index" json:"chat_id"`
+	MessageId     string       `gorm:"index" json:"message_id"`
+	FeatureUuid   string       `gorm:"index" json:"feature_uuid"`
+	TicketUuid    string       `gorm:"index" json:"ticket_uuid"`
+	Type          ArtifactType `gorm:"index;not null" json:"type"`
+	Title         string       `json:"title"`
+	Language      string       `json:"language"`
+	Content       string       `gorm:"type:text" json:"content"`
+	Version       int          `gorm:"not null;default:1" json:"version"`
+	CreatedBy     string       `json:"created_by"`
+	Created       *time.Time   `json:"created"`
+	Updated       *time.Time   `json:"updated"`
+}
+
+type ArtifactVersion struct {
+	ID           uint       `json:"id"`
+	ArtifactUuid string     `gorm:"uniqueIndex:artifact_version;not null" json:"artifact_uuid"`
+	Version      int        `gorm:"uniqueIndex:artifact_version;not null" json:"version"`
+	Title        string     `json:"title"`
+	Language     string     `json:"language"`
+	Content      string     `gorm:"type:text" json:"content"`
+	CreatedBy    string     `json:"created_by"`
+	Created      *time.Time `json:"created"`
+}
+
+type ArtifactFilter struct {
+	WorkspaceUuid string
+	ChatId        string
+	MessageId     string
+	FeatureUuid   string
+	TicketUuid    string
+	Type          ArtifactType
+}
+
+type ChatRequest struct {
+	WorkspaceUuid string `json:"workspace_uuid"`
+	Title         string `json:"title"`
+}
+
+type ChatMessageRequest struct {
+	Content   string     `json:"content"`
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// ChatWorkingRequest is an agent telling a chat it is working on an answer,
+// step is what it is doing right now
+type ChatWorkingRequest struct {
+	Working bool   `json:"working"`
+	Step    string `json:"step"`
+}
+
+// ChatAgentWebhook hands the user messages of a chat to an external agent. Every
+// message is posted to Url signed with Secret, and the agent answers through a
+// callback url that carries CallbackToken. Neither is returned once the webhook
+// is registered
+type ChatAgentWebhook struct {
+	ID            uint       `json:"id"`
+	Uuid          string     `gorm:"uniqueIndex;not null" json:"uuid"`
+	ChatId        string     `gorm:"uniqueIndex;not null" json:"chat_id"`
+	WorkspaceUuid string     `gorm:"index;not null" json:"workspace_uuid"`
+	Name          string     `json:"name"`
+	Url           string     `json:"url"`
+	Secret        string     `json:"-"`
+	CallbackToken string     `json:"-"`
+	LastDelivered *time.Time `json:"last_delivered,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedBy     string     `json:"created_by"`
+	Created       *time.Time `json:"created"`
+	Updated       *time.Time `json:"updated"`
+}
+
+type ChatAgentWebhookRequest struct {
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+// RegisteredChatAgentWebhook is a new agent webhook with its secret and the
+// callback url the agent answers on
+type RegisteredChatAgentWebhook struct {
+	ChatAgentWebhook
+	Secret      string `json:"secret"`
+	CallbackUrl string `json:"callback_url"`
+}
+
+// ChatAgentDelivery is what an agent webhook receives for a user message
+type ChatAgentDelivery struct {
+	ChatId        string      `json:"chat_id"`
+	WorkspaceUuid string      `json:"workspace_uuid"`
+	Message       ChatMessage `json:"message"`
+	CallbackUrl   string      `json:"callback_url"`
+}
+
+type EmbeddingSourceType string
+
+const (
+	EmbeddingSourceBrief  EmbeddingSourceType = "brief"
+	EmbeddingSourceTicket EmbeddingSourceType = "ticket"
+	EmbeddingSourceChat   EmbeddingSourceType = "chat"
+)
+
+// WorkspaceEmbedding is a chunk of workspace knowledge with its embedding,
+// the context search returns the chunks closest to a question
+type WorkspaceEmbedding struct {
+	ID            uint                `json:"id"`
+	WorkspaceUuid string              `gorm:"index:workspace_embeddings_source_idx;not null" json:"workspace_uuid"`
+	SourceType    EmbeddingSourceType `gorm:"index:workspace_embeddings_source_idx;not null" json:"source_type"`
+	SourceId      string              `gorm:"index:workspace_embeddings_source_idx;not null" json:"source_id"`
+	Position      int                 `json:"position"`
+	Content       string              `gorm:"type:text" json:"content"`
+	Embedding     Vector              `gorm:"type:vector" json:"-"`
+	Created       *time.Time          `json:"created"`
+}
+
+// EmbeddingIngestRequest is knowledge to embed, ingesting a source again replaces its chunks
+type EmbeddingIngestRequest struct {
+	WorkspaceUuid string              `json:"workspace_uuid"`
+	SourceType    EmbeddingSourceType `json:"source_type"`
+	SourceId      string              `json:"source_id"`
+	Content       string              `json:"content"`
+}
+
+type ContextSearchRequest struct {
+	WorkspaceUuid string `json:"workspace_uuid"`
+	Query         string `json:"query"`
+	TopK          int    `json:"top_k"`
+}
+
+// ContextSearchResult is a chunk found by the context search, a higher score is closer
+type ContextSearchResult struct {
+	WorkspaceEmbedding
+	Score float64 `json:"score"`
+}
+
+type FeatureCallTranscriptResult struct {
+	Transcript  FeatureCallTranscript `json:"transcript"`
+	BriefLinked bool                  `json:"brief_linked"`
+}
+
+type PhaseDocumentDiff struct {
+	DocType     PhaseDocumentType `json:"doc_type"`
+	FromVersion int               `json:"from_version"`
+	ToVersion   int               `json:"to_version"`
+	Lines       []utils.DiffLine  `json:"lines"`
+}
+
 type BountyRoles struct {
 	Name string `json:"name"`
 }
@@ -612,6 +2505,32 @@ type UserRoles struct {
 }
 
 // change back to UserRoles after migration
+type WorkspaceMemberImportRow struct {
+	Pubkey string   `json:"pubkey"`
+	Roles  []string `json:"roles"`
+}
+
+type WorkspaceMemberImportStatus string
+
+const (
+	MemberImportAdded  WorkspaceMemberImportStatus = "added"
+	MemberImportFailed WorkspaceMemberImportStatus = "failed"
+)
+
+type WorkspaceMemberImportResult struct {
+	Row    int                         `json:"row"`
+	Pubkey string                      `json:"pubkey"`
+	Roles  []string                    `json:"roles"`
+	Status WorkspaceMemberImportStatus `json:"status"`
+	Error  string                      `json:"error,omitempty"`
+}
+
+type WorkspaceMemberImportReport struct {
+	Added   int                           `json:"added"`
+	Failed  int                           `json:"failed"`
+	Results []WorkspaceMemberImportResult `json:"results"`
+}
+
 type WorkspaceUserRoles struct {
 	Role          string     `json:"role"`
 	OwnerPubKey   string     `json:"owner_pubkey"`
@@ -639,6 +2558,125 @@ type NewBountyBudget struct {
 	Updated       *time.Time `json:"updated"`
 }
 
+// BudgetLedgerEntry is one side of a budget transaction, the entries of a
+// transaction share a TransactionId and their debits and credits balance.
+// Amounts are in the smallest unit of Currency
+type BudgetLedgerEntry struct {
+	ID            uint                `json:"id"`
+	TransactionId string              `gorm:"not null;index" json:"transaction_id"`
+	Account       string              `gorm:"not null;index;uniqueIndex:idx_ledger_reference" json:"account"`
+	WorkspaceUuid string              `gorm:"index" json:"workspace_uuid"`
+	Currency      string              `gorm:"not null" json:"currency"`
+	Debit         uint                `json:"debit"`
+	Credit        uint                `json:"credit"`
+	ReferenceType LedgerReferenceType `gorm:"uniqueIndex:idx_ledger_reference" json:"reference_type"`
+	ReferenceId   string              `gorm:"uniqueIndex:idx_ledger_reference" json:"reference_id"`
+	Created       *time.Time          `json:"created"`
+}
+
+type LedgerReferenceType string
+
+const (
+	LedgerDeposit    LedgerReferenceType = "deposit"
+	LedgerPayment    LedgerReferenceType = "payment"
+	LedgerWithdraw   LedgerReferenceType = "withdraw"
+	LedgerAdjustment LedgerReferenceType = "adjustment"
+	LedgerOpening    LedgerReferenceType = "opening"
+	LedgerReserve    LedgerReferenceType = "reserve"
+	LedgerRelease    LedgerReferenceType = "release"
+	LedgerRefund     LedgerReferenceType = "refund"
+
+	// LedgerPayoutReversal returns a payout that never reached the hunter
+	LedgerPayoutReversal LedgerReferenceType = "payout_reversal"
+)
+
+type BudgetRefundStatus string
+
+const (
+	BudgetRefundPendingApproval BudgetRefundStatus = "pending_approval"
+	BudgetRefundPaid            BudgetRefundStatus = "paid"
+	BudgetRefundFailed          BudgetRefundStatus = "failed"
+	BudgetRefundRejected        BudgetRefundStatus = "rejected"
+	// BudgetRefundPaying is a refund claimed by an approval whose invoice is
+	// being paid, it can't be approved again. A refund whose payment has no
+	// known outcome stays paying until it is reconciled
+	BudgetRefundPaying BudgetRefundStatus = "paying"
+)
+
+// BudgetRefund is the workspace owner withdrawing unused budget to an invoice,
+// its amount stays reserved until it is paid, failed or rejected
+type BudgetRefund struct {
+	ID             uint               `json:"id"`
+	WorkspaceUuid  string             `gorm:"not null;index" json:"workspace_uuid"`
+	Amount         uint               `json:"amount"`
+	PaymentRequest string             `json:"payment_request"`
+	ReservationId  string             `json:"-"`
+	RequestedBy    string             `json:"requested_by"`
+	ApprovedBy     string             `json:"approved_by"`
+	Status         BudgetRefundStatus `gorm:"index" json:"status"`
+	Error          string             `json:"error,omitempty"`
+	Created        *time.Time         `json:"created"`
+	Updated        *time.Time         `json:"updated"`
+}
+
+// BudgetReservation is workspace budget set aside for a payment while it is
+// in flight, so parallel payments cannot spend the same budget twice
+type BudgetReservation struct {
+	Id            string `json:"id"`
+	WorkspaceUuid string `json:"workspace_uuid"`
+	Amount        uint   `json:"amount"`
+}
+
+type LedgerInconsistencyKind string
+
+const (
+	LedgerUnbalancedTransaction LedgerInconsistencyKind = "unbalanced_transaction"
+	LedgerBudgetDrift           LedgerInconsistencyKind = "budget_drift"
+)
+
+// BudgetLedgerInconsistency is a problem the ledger consistency check found,
+// for an unbalanced transaction Expected is the debits and Actual the credits,
+// for budget drift Expected is the ledger balance and Actual the stored budget
+type BudgetLedgerInconsistency struct {
+	Kind          LedgerInconsistencyKind `json:"kind"`
+	WorkspaceUuid string                  `json:"workspace_uuid,omitempty"`
+	TransactionId string                  `json:"transaction_id,omitempty"`
+	Expected      int64                   `json:"expected"`
+	Actual        int64                   `json:"actual"`
+}
+
+type PaymentCorrectionAction string
+
+const (
+	PaymentBudgetCredited   PaymentCorrectionAction = "budget_credited"
+	PaymentInvoiceSettled   PaymentCorrectionAction = "invoice_settled"
+	PaymentInvoiceExpired   PaymentCorrectionAction = "invoice_expired"
+	PaymentBountyMarkedPaid PaymentCorrectionAction = "bounty_marked_paid"
+	PaymentNeedsReview      PaymentCorrectionAction = "needs_review"
+)
+
+// PaymentCorrection is one pending payment record the reconciliation looked at,
+// Error is set when fixing it failed
+type PaymentCorrection struct {
+	Action         PaymentCorrectionAction `json:"action"`
+	PaymentRequest string                  `json:"payment_request,omitempty"`
+	WorkspaceUuid  string                  `json:"workspace_uuid,omitempty"`
+	BountyId       uint                    `json:"bounty_id,omitempty"`
+	ReservationId  string                  `json:"reservation_id,omitempty"`
+	Amount         uint                    `json:"amount,omitempty"`
+	Detail         string                  `json:"detail"`
+	Error          string                  `json:"error,omitempty"`
+}
+
+// PaymentReconciliationReport lists the corrections a reconciliation made and
+// the stuck payments it could not fix on its own
+type PaymentReconciliationReport struct {
+	InvoicesChecked int                 `json:"invoices_checked"`
+	Corrections     []PaymentCorrection `json:"corrections"`
+	Unresolved      []PaymentCorrection `json:"unresolved"`
+	Created         *time.Time          `json:"created"`
+}
+
 type StatusBudget struct {
 	OrgUuid             string `json:"org_uuid"`
 	WorkspaceUuid       string `json:"workspace_uuid"`
@@ -652,6 +2690,18 @@ type StatusBudget struct {
 	CompletedBudget     uint   `json:"completed_budget"`
 	CompletedCount      int64  `json:"completed_count"`
 	CompletedDifference int    `json:"completed_difference"`
+	// Display has the budgets in the display currency of the workspace
+	Display *BudgetDisplay `json:"display,omitempty"`
+}
+
+type BudgetDisplay struct {
+	Currency        string    `json:"currency"`
+	BtcPrice        float64   `json:"btc_price"`
+	RateTimestamp   time.Time `json:"rate_timestamp"`
+	CurrentBudget   float64   `json:"current_budget"`
+	OpenBudget      float64   `json:"open_budget"`
+	AssignedBudget  float64   `json:"assigned_budget"`
+	CompletedBudget float64   `json:"completed_budget"`
 }
 
 type BudgetInvoiceRequest struct {
@@ -694,13 +2744,16 @@ type BudgetHistory struct {
 type FeatureStory struct {
 	ID          uint       `json:"id"`
 	Uuid        string     `json:"uuid"`
-	FeatureUuid string     `json:"feature_uuid"`
+	FeatureUuid string     `gorm:"uniqueIndex:idx_feature_stories_delivery" json:"feature_uuid"`
 	Description string     `json:"description"`
 	Priority    int        `json:"priority"`
 	Created     *time.Time `json:"created"`
 	Updated     *time.Time `json:"updated"`
 	CreatedBy   string     `json:"created_by"`
 	UpdatedBy   string     `json:"updated_by"`
+	// DeliveryId is the id of the webhook delivery that created the story, a
+	// replayed delivery to the feature finds it instead of adding the story again
+	DeliveryId *string `gorm:"uniqueIndex:idx_feature_stories_delivery" json:"delivery_id,omitempty"`
 }
 
 type BudgetHistoryData struct {
@@ -730,9 +2783,69 @@ type NewPaymentHistory struct {
 	WorkspaceUuid  string      `json:"workspace_uuid,omitempty"`
 	SenderPubKey   string      `json:"sender_pubkey"`
 	ReceiverPubKey string      `json:"receiver_pubkey"`
+	PaymentHash    string      `json:"payment_hash,omitempty"`
 	Created        *time.Time  `json:"created"`
 	Updated        *time.Time  `json:"updated"`
 	Status         bool        `json:"status"`
+	// on-chain payouts are tracked until they have enough confirmations
+	PaymentMethod        PaymentMethod `json:"payment_method,omitempty"`
+	OnchainAddress       string        `json:"onchain_address,omitempty"`
+	OnchainTxid          string        `gorm:"index" json:"onchain_txid,omitempty"`
+	OnchainFee           uint          `json:"onchain_fee,omitempty"`
+	OnchainConfirmations uint          `json:"onchain_confirmations,omitempty"`
+	OnchainConfirmed     *time.Time    `json:"onchain_confirmed,omitempty"`
+	// a dropped payout never reached the hunter, its bounty is reopened
+	OnchainDropped *time.Time `json:"onchain_dropped,omitempty"`
+}
+
+type PaymentMethod string
+
+const (
+	PaymentMethodKeysend          PaymentMethod = "keysend"
+	PaymentMethodLightningAddress PaymentMethod = "lightning_address"
+	PaymentMethodOnchain          PaymentMethod = "onchain"
+)
+
+// OnchainFeeEstimate is the fee the node expects to pay to send an amount on-chain
+type OnchainFeeEstimate struct {
+	FeeSat      uint `json:"fee_sat"`
+	SatPerVbyte uint `json:"sat_per_vbyte"`
+}
+
+type OnchainTransaction struct {
+	Txid          string `json:"txid"`
+	Confirmations uint   `json:"confirmations"`
+	// Dropped is set once the transaction can no longer confirm, it was
+	// evicted from the mempool or a conflicting one confirmed
+	Dropped bool `json:"dropped"`
+}
+
+// BountyReceipt is the server signed receipt of a bounty payment,
+// Receipt holds the exact signed BountyReceiptData json
+type BountyReceipt struct {
+	ID        uint       `json:"id"`
+	BountyId  uint       `gorm:"uniqueIndex" json:"bounty_id"`
+	PaymentId uint       `json:"payment_id"`
+	Receipt   string     `gorm:"type:text" json:"receipt"`
+	Signature string     `json:"signature"`
+	Created   *time.Time `json:"created"`
+}
+
+type BountyReceiptData struct {
+	BountyId      uint       `json:"bounty_id"`
+	Title         string     `json:"title"`
+	Amount        uint       `json:"amount"`
+	WorkspaceUuid string     `json:"workspace_uuid"`
+	WorkspaceName string     `json:"workspace_name"`
+	PayerPubKey   string     `json:"payer_pubkey"`
+	HunterPubKey  string     `json:"hunter_pubkey"`
+	PaymentHash   string     `json:"payment_hash"`
+	PaidDate      *time.Time `json:"paid_date"`
+}
+
+type BountyReceiptResponse struct {
+	Receipt   json.RawMessage `json:"receipt"`
+	Signature string          `json:"signature"`
 }
 
 type PaymentHistoryData struct {
@@ -810,6 +2923,20 @@ type WithdrawBudgetRequest struct {
 	OrgUuid         string `json:"org_uuid"`
 }
 
+// ChatCommandRequest is a chat message run as a command, like /bounty 50000 Fix login bug
+type ChatCommandRequest struct {
+	Message         string `json:"message"`
+	WorkspaceUuid   string `json:"workspace_uuid"`
+	Websocket_token string `json:"websocket_token,omitempty"`
+}
+
+// ChatCommandReply links to what a chat command created, it is sent on the websocket too
+type ChatCommandReply struct {
+	Msg      string `json:"msg"`
+	BountyId uint   `json:"bounty_id"`
+	Link     string `json:"link"`
+}
+
 // change back to WithdrawBudgetReques
 type NewWithdrawBudgetRequest struct {
 	PaymentRequest  string `json:"payment_request"`
@@ -963,6 +3090,47 @@ func (p *PropertyMap) Scan(src interface{}) error {
 	return nil
 }
 
+// Value Marshal
+func (s BriefSections) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(s)
+	return string(b), err
+}
+
+func (m WorkspaceArchiveMembers) Value() (driver.Value, error) {
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+
+func (m *WorkspaceArchiveMembers) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = WorkspaceArchiveMembers{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	}
+	return errors.New("type assertion to []byte failed")
+}
+
+// Scan Unmarshal
+func (s *BriefSections) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = BriefSections{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	}
+	return errors.New("type assertion to []byte failed")
+}
+
 type JSONB []interface{}
 
 // Value Marshal
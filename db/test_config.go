@@ -2,6 +2,7 @@ package db
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/rs/xid"
 	"gorm.io/driver/postgres"
@@ -10,6 +11,80 @@ import (
 
 var TestDB database
 
+// testModels are the tables migrated into, and cleaned from, the test DB
+var testModels = []interface{}{
+	&Tribe{},
+	&Person{},
+	&Channel{},
+	&LeaderBoard{},
+	&ConnectionCodes{},
+	&BountyRoles{},
+	&UserInvoiceData{},
+	&WorkspaceRepositories{},
+	&WorkspaceCodeGraph{},
+	&WorkspaceFeatures{},
+	&FeaturePhase{},
+	&FeatureStory{},
+	&PhaseDocument{},
+	&FeatureCall{},
+	&FeatureCallTranscript{},
+	&FeatureCallTranscriptChunk{},
+	&CalendarToken{},
+	&Chat{},
+	&ChatMessage{},
+	&Artifact{},
+	&ArtifactVersion{},
+	&BountyApplication{},
+	&BountyAssigneeHistory{},
+	&BountyStateTransition{},
+	&BountyComment{},
+	&BountyReceipt{},
+	&BountyLeaderboardDaily{},
+	&Notification{},
+	&BadgeIssuance{},
+	&BountyHoursLog{},
+	&BudgetLedgerEntry{},
+	&SuperAdmin{},
+	&SuperAdminChange{},
+	&WebhookSigningSecret{},
+	&PersonMerge{},
+	&HandleReservation{},
+	&RelayEventLog{},
+	&WorkspaceApiToken{},
+	&WorkspaceWebhook{},
+	&WorkspaceTag{},
+	&BountyPriceStat{},
+	&ShortLink{},
+	&NostrEvent{},
+	&WorkspaceBridge{},
+	&SlackUserLink{},
+	&SlackTeam{},
+	&TicketReview{},
+	&PhasePlan{},
+	&PhasePlanDraft{},
+	&WorkspaceUsageDaily{},
+	&WorkspaceBudgetSnapshot{},
+	&ChatAgentWebhook{},
+	&WorkspaceSettings{},
+	&WorkspaceArchive{},
+	&ParentOrganization{},
+	&ParentOrganizationWorkspace{},
+	&ParentOrganizationMember{},
+	&WebhookAlert{},
+	&JiraUserLink{},
+	&JiraIssueLink{},
+	&ConfigOverride{},
+	&NewBounty{},
+	&BudgetHistory{},
+	&NewPaymentHistory{},
+	&NewInvoiceList{},
+	&NewBountyBudget{},
+	&Workspace{},
+	&WorkspaceUsers{},
+	&WorkspaceUserRoles{},
+	&Bot{},
+}
+
 func InitTestDB() {
 	rdsHost := "172.17.0.1"
 	rdsPort := fmt.Sprintf("%d", 5532)
@@ -38,26 +113,12 @@ func InitTestDB() {
 	fmt.Println("DB CONNECTED")
 
 	// migrate table changes
-	db.AutoMigrate(&Tribe{})
-	db.AutoMigrate(&Person{})
-	db.AutoMigrate(&Channel{})
-	db.AutoMigrate(&LeaderBoard{})
-	db.AutoMigrate(&ConnectionCodes{})
-	db.AutoMigrate(&BountyRoles{})
-	db.AutoMigrate(&UserInvoiceData{})
-	db.AutoMigrate(&WorkspaceRepositories{})
-	db.AutoMigrate(&WorkspaceFeatures{})
-	db.AutoMigrate(&FeaturePhase{})
-	db.AutoMigrate(&FeatureStory{})
-	db.AutoMigrate(&NewBounty{})
-	db.AutoMigrate(&BudgetHistory{})
-	db.AutoMigrate(&NewPaymentHistory{})
-	db.AutoMigrate(&NewInvoiceList{})
-	db.AutoMigrate(&NewBountyBudget{})
-	db.AutoMigrate(&Workspace{})
-	db.AutoMigrate(&WorkspaceUsers{})
-	db.AutoMigrate(&WorkspaceUserRoles{})
-	db.AutoMigrate(&Bot{})
+	for _, model := range testModels {
+		db.AutoMigrate(model)
+	}
+
+	TestDB.MigratePeopleSearchIndexes()
+	TestDB.MigrateFeatureBountyCounts()
 
 	people := TestDB.GetAllPeople()
 	for _, p := range people {
@@ -70,3 +131,17 @@ func InitTestDB() {
 func CleanDB() {
 	TestDB.db.Exec("DELETE FROM people")
 }
+
+// CleanTestData empties every test table and resets its ids,
+// so each test starts from the same state
+func CleanTestData() {
+	tables := []string{}
+	for _, model := range testModels {
+		stmt := &gorm.Statement{DB: TestDB.db}
+		if err := stmt.Parse(model); err == nil {
+			tables = append(tables, stmt.Schema.Table)
+		}
+	}
+
+	TestDB.db.Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(tables, ", ")))
+}
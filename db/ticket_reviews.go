@@ -0,0 +1,56 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrTicketReviewNotFound = errors.New("ticket review not found")
+
+func (db database) CreateTicketReview(review TicketReview) (TicketReview, error) {
+	now := time.Now()
+	review.Created = &now
+	review.Updated = &now
+	if err := db.db.Create(&review).Error; err != nil {
+		return TicketReview{}, err
+	}
+	return review, nil
+}
+
+// UpdateTicketReview saves the status, progress and outcome of a review
+func (db database) UpdateTicketReview(review TicketReview) (TicketReview, error) {
+	now := time.Now()
+	review.Updated = &now
+	err := db.db.Model(&TicketReview{}).Where("uuid = ?", review.Uuid).Updates(map[string]interface{}{
+		"status":     review.Status,
+		"project_id": review.ProjectId,
+		"step":       review.Step,
+		"progress":   review.Progress,
+		"response":   review.Response,
+		"score":      review.Score,
+		"feedback":   review.Feedback,
+		"error":      review.Error,
+		"updated":    review.Updated,
+		"completed":  review.Completed,
+	}).Error
+	if err != nil {
+		return TicketReview{}, err
+	}
+	return review, nil
+}
+
+func (db database) GetTicketReviewByUuid(uuid string) (TicketReview, error) {
+	review := TicketReview{}
+	db.db.Where("uuid = ?", uuid).Find(&review)
+	if review.ID == 0 {
+		return TicketReview{}, ErrTicketReviewNotFound
+	}
+	return review, nil
+}
+
+// GetTicketReviews lists the reviews of a ticket, newest first
+func (db database) GetTicketReviews(ticketUuid string) []TicketReview {
+	reviews := []TicketReview{}
+	db.db.Where("ticket_uuid = ?", ticketUuid).Order("id DESC").Find(&reviews)
+	return reviews
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"github.com/stakwork/sphinx-tribes/utils"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const tracingSpanKey = "tracing:span"
+
+// tracingPlugin starts a span for every query gorm runs, the span is a child of
+// the request span when the statement is run with the request context
+type tracingPlugin struct{}
+
+func (tracingPlugin) Name() string {
+	return "tracing"
+}
+
+func (tracingPlugin) Initialize(db *gorm.DB) error {
+	return registerQueryCallbacks(db, "tracing", startQuerySpan, endQuerySpan)
+}
+
+// registerQueryCallbacks runs before and after every kind of query gorm runs,
+// before gets the kind of query: create, query, update, delete, row or raw
+func registerQueryCallbacks(db *gorm.DB, name string, before func(operation string) func(*gorm.DB), after func(*gorm.DB)) error {
+	callback := db.Callback()
+	registers := []error{
+		callback.Create().Before("gorm:create").Register(name+":before_create", before("create")),
+		callback.Create().After("gorm:create").Register(name+":after_create", after),
+		callback.Query().Before("gorm:query").Register(name+":before_query", before("query")),
+		callback.Query().After("gorm:query").Register(name+":after_query", after),
+		callback.Update().Before("gorm:update").Register(name+":before_update", before("update")),
+		callback.Update().After("gorm:update").Register(name+":after_update", after),
+		callback.Delete().Before("gorm:delete").Register(name+":before_delete", before("delete")),
+		callback.Delete().After("gorm:delete").Register(name+":after_delete", after),
+		callback.Row().Before("gorm:row").Register(name+":before_row", before("row")),
+		callback.Row().After("gorm:row").Register(name+":after_row", after),
+		callback.Raw().Before("gorm:raw").Register(name+":before_raw", before("raw")),
+		callback.Raw().After("gorm:raw").Register(name+":after_raw", after),
+	}
+	for _, err := range registers {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startQuerySpan(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		name := "db." + operation
+		if tx.Statement.Table != "" {
+			name += " " + tx.Statement.Table
+		}
+		ctx, span := utils.StartSpan(tx.Statement.Context, name,
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(operation),
+			semconv.DBSQLTable(tx.Statement.Table),
+		)
+		tx.Statement.Context = ctx
+		tx.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+func endQuerySpan(tx *gorm.DB) {
+	value, ok := tx.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+
+	span.SetAttributes(
+		semconv.DBStatement(tx.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+	)
+	err := tx.Error
+	if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+	utils.EndSpan(span, err)
+}
@@ -0,0 +1,32 @@
+package db
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AddWorkspaceUsage adds counts to the daily rollups, so every instance can
+// flush what it counted without reading the rollups first
+func (db database) AddWorkspaceUsage(usage []WorkspaceUsageDaily) error {
+	if len(usage) == 0 {
+		return nil
+	}
+	return db.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "workspace_uuid"}, {Name: "day"}, {Name: "kind"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("workspace_usage_dailies.count + excluded.count")}),
+	}).Create(&usage).Error
+}
+
+// GetWorkspaceUsage returns the rollups of a workspace between two days, both included
+func (db database) GetWorkspaceUsage(workspaceUuid string, from string, to string) []WorkspaceUsageDaily {
+	usage := []WorkspaceUsageDaily{}
+	db.db.Where("workspace_uuid = ? AND day >= ? AND day <= ?", workspaceUuid, from, to).Order("day ASC").Find(&usage)
+	return usage
+}
+
+// GetAllWorkspaceUsage returns the rollups of every workspace between two days
+func (db database) GetAllWorkspaceUsage(from string, to string) []WorkspaceUsageDaily {
+	usage := []WorkspaceUsageDaily{}
+	db.db.Where("day >= ? AND day <= ?", from, to).Order("day ASC").Find(&usage)
+	return usage
+}
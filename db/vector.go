@@ -0,0 +1,57 @@
+package db
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Vector is a pgvector column, stored in its text form [1,2,3]
+type Vector []float32
+
+func (v Vector) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	values := make([]string, len(v))
+	for i, value := range v {
+		values[i] = strconv.FormatFloat(float64(value), 'f', -1, 32)
+	}
+	return "[" + strings.Join(values, ",") + "]", nil
+}
+
+func (v *Vector) Scan(src interface{}) error {
+	var text string
+	switch value := src.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case string:
+		text = value
+	case []byte:
+		text = string(value)
+	default:
+		return fmt.Errorf("cannot scan %T into a vector", src)
+	}
+
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "[") || !strings.HasSuffix(text, "]") {
+		return errors.New("vector must be written as [1,2,3]")
+	}
+	text = strings.TrimSpace(text[1 : len(text)-1])
+
+	vector := Vector{}
+	if text != "" {
+		for _, part := range strings.Split(text, ",") {
+			value, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+			if err != nil {
+				return err
+			}
+			vector = append(vector, float32(value))
+		}
+	}
+	*v = vector
+	return nil
+}
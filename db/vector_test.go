@@ -0,0 +1,28 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVector(t *testing.T) {
+	value, err := Vector{1, -0.5, 0.25}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,-0.5,0.25]", value)
+
+	nilValue, err := Vector(nil).Value()
+	assert.NoError(t, err)
+	assert.Nil(t, nilValue)
+
+	vector := Vector{}
+	assert.NoError(t, vector.Scan([]byte("[1, -0.5,0.25]")))
+	assert.Equal(t, Vector{1, -0.5, 0.25}, vector)
+
+	assert.NoError(t, vector.Scan("[]"))
+	assert.Equal(t, Vector{}, vector)
+
+	assert.Error(t, vector.Scan("1,2"))
+	assert.Error(t, vector.Scan("[1,a]"))
+	assert.Error(t, vector.Scan(12))
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+var ErrWebhookSigningSecretNotFound = errors.New("webhook signing secret not found")
+
+func (db database) GetWebhookSigningSecrets() ([]WebhookSigningSecret, error) {
+	ms := []WebhookSigningSecret{}
+	err := db.db.Order("source ASC").Find(&ms).Error
+	return ms, err
+}
+
+// GetWebhookSigningSecret returns the stored secret of a source, it returns
+// ErrWebhookSigningSecretNotFound when the source has none
+func (db database) GetWebhookSigningSecret(source string) (string, error) {
+	ms := WebhookSigningSecret{}
+	result := db.db.Where("source = ?", source).Limit(1).Find(&ms)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if result.RowsAffected == 0 {
+		return "", ErrWebhookSigningSecretNotFound
+	}
+	return ms.Secret.String(), nil
+}
+
+// SetWebhookSigningSecret replaces the stored secret of a source
+func (db database) SetWebhookSigningSecret(source string, secret string, pubkey string) (WebhookSigningSecret, error) {
+	now := time.Now()
+	ms := WebhookSigningSecret{Source: source, Secret: EncryptedString(secret), UpdatedBy: pubkey, Created: &now, Updated: &now}
+	err := db.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "source"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "updated_by", "updated"}),
+	}).Create(&ms).Error
+	return ms, err
+}
+
+func (db database) DeleteWebhookSigningSecret(source string) error {
+	result := db.db.Where("source = ?", source).Delete(&WebhookSigningSecret{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWebhookSigningSecretNotFound
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// WebhookTemplates are the templates a webhook source starts with,
+// a source without defaults needs its templates set
+var WebhookTemplates = map[WebhookSource]WorkspaceWebhook{
+	WebhookSourceSentry: {
+		TitleTemplate:       "{{.data.issue.title}}",
+		DescriptionTemplate: "{{.data.issue.culprit}}\n\n{{.data.issue.permalink}}",
+		FingerprintTemplate: "{{.data.issue.id}}",
+	},
+	WebhookSourceGeneric: {},
+}
+
+func (db database) CreateWorkspaceWebhook(webhook WorkspaceWebhook) (WorkspaceWebhook, error) {
+	if webhook.Created == nil {
+		now := time.Now()
+		webhook.Created = &now
+		webhook.Updated = &now
+	}
+	if err := db.db.Create(&webhook).Error; err != nil {
+		return WorkspaceWebhook{}, err
+	}
+	return webhook, nil
+}
+
+func (db database) GetWorkspaceWebhooks(workspaceUuid string) []WorkspaceWebhook {
+	ms := []WorkspaceWebhook{}
+	db.db.Model(&WorkspaceWebhook{}).Where("workspace_uuid = ?", workspaceUuid).Order("created DESC").Find(&ms)
+	return ms
+}
+
+func (db database) GetWorkspaceWebhookByUuid(uuid string) (WorkspaceWebhook, error) {
+	ms := WorkspaceWebhook{}
+	result := db.db.Model(&WorkspaceWebhook{}).Where("uuid = ?", uuid).Limit(1).Find(&ms)
+	if result.Error != nil {
+		return ms, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ms, ErrWebhookNotFound
+	}
+	return ms, nil
+}
+
+func (db database) DeleteWorkspaceWebhook(workspaceUuid string, uuid string) error {
+	result := db.db.Where("workspace_uuid = ? AND uuid = ?", workspaceUuid, uuid).Delete(&WorkspaceWebhook{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// RecordWebhookAlert creates the draft bounty of an alert, unless the webhook
+// already has an open bounty for the fingerprint, then the alert is only counted.
+// A fingerprint whose bounty was completed since gets a new bounty. The alert row
+// is upserted first, so alerts that arrive together count against the same row
+// and only one of them creates the bounty
+func (db database) RecordWebhookAlert(webhook WorkspaceWebhook, fingerprint string, draft NewBounty) (WebhookAlertResult, error) {
+	result := WebhookAlertResult{}
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		alert := WebhookAlert{
+			WebhookUuid: webhook.Uuid,
+			Fingerprint: fingerprint,
+			Occurrences: 1,
+			FirstSeen:   &now,
+			LastSeen:    &now,
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "webhook_uuid"}, {Name: "fingerprint"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"occurrences": gorm.Expr("webhook_alerts.occurrences + 1"),
+				"last_seen":   &now,
+			}),
+		}).Create(&alert).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("webhook_uuid = ? AND fingerprint = ?", webhook.Uuid, fingerprint).First(&alert).Error; err != nil {
+			return err
+		}
+
+		if alert.BountyID != 0 {
+			var open int64
+			if err := tx.Model(&NewBounty{}).
+				Where("id = ? AND completed = false AND paid = false AND state <> ?", alert.BountyID, BountyStateCanceled).
+				Count(&open).Error; err != nil {
+				return err
+			}
+			if open > 0 {
+				result = WebhookAlertResult{BountyID: alert.BountyID, Deduplicated: true, Occurrences: alert.Occurrences}
+				return nil
+			}
+		}
+
+		draft.State = draft.CurrentState()
+		if err := tx.Create(&draft).Error; err != nil {
+			return err
+		}
+
+		result = WebhookAlertResult{BountyID: draft.ID, Occurrences: 1}
+		return tx.Model(&WebhookAlert{}).Where("id = ?", alert.ID).Updates(map[string]interface{}{
+			"bounty_id":   draft.ID,
+			"occurrences": 1,
+			"first_seen":  &now,
+			"last_seen":   &now,
+		}).Error
+	})
+	if err != nil {
+		return WebhookAlertResult{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrWorkspaceNotDeleted       = errors.New("workspace is not deleted")
+	ErrWorkspaceRetentionExpired = errors.New("workspace retention window has expired")
+)
+
+// WorkspaceRetention is how long a deleted workspace can be restored
+func WorkspaceRetention() time.Duration {
+	return time.Duration(config.WorkspaceRetentionDays) * 24 * time.Hour
+}
+
+// GetDeletedWorkspaces returns the deleted workspaces that can still be restored, newest first
+func (db database) GetDeletedWorkspaces() []DeletedWorkspace {
+	ms := []DeletedWorkspace{}
+	db.db.Model(&Workspace{}).
+		Select("workspaces.uuid, workspaces.name, workspaces.owner_pub_key, workspace_archives.deleted_at").
+		Joins("JOIN workspace_archives ON workspace_archives.workspace_uuid = workspaces.uuid").
+		Where("workspaces.deleted = ? AND workspace_archives.deleted_at > ?", true, time.Now().Add(-WorkspaceRetention())).
+		Order("workspace_archives.deleted_at DESC").
+		Find(&ms)
+
+	for i := range ms {
+		if ms[i].DeletedAt != nil {
+			ms[i].RestorableUntil = ms[i].DeletedAt.Add(WorkspaceRetention())
+		}
+	}
+	return ms
+}
+
+// RestoreWorkspace undoes the soft delete of a workspace from its archive, its members,
+// roles and bounties come back and its budget is resynced from the ledger
+func (db database) RestoreWorkspace(uuid string) (Workspace, error) {
+	workspace := Workspace{}
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", uuid).First(&workspace).Error; err != nil {
+			return err
+		}
+		if !workspace.Deleted {
+			return ErrWorkspaceNotDeleted
+		}
+
+		archive := WorkspaceArchive{}
+		found := tx.Where("workspace_uuid = ?", uuid).Limit(1).Find(&archive)
+		if found.Error != nil {
+			return found.Error
+		}
+		if found.RowsAffected == 0 || archive.DeletedAt == nil || time.Since(*archive.DeletedAt) > WorkspaceRetention() {
+			return ErrWorkspaceRetentionExpired
+		}
+
+		now := time.Now()
+		if err := tx.Model(&Workspace{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+			"website":     archive.Website,
+			"github":      archive.Github,
+			"description": archive.Description,
+			"show":        archive.Show,
+			"deleted":     false,
+			"deleted_at":  nil,
+			"updated":     &now,
+		}).Error; err != nil {
+			return err
+		}
+
+		if len(archive.Members.Users) > 0 {
+			if err := tx.Create(&archive.Members.Users).Error; err != nil {
+				return err
+			}
+		}
+		if len(archive.Members.Roles) > 0 {
+			if err := tx.Create(&archive.Members.Roles).Error; err != nil {
+				return err
+			}
+		}
+		if len(archive.ShownBountyIDs) > 0 {
+			if err := tx.Model(&NewBounty{}).
+				Where("id IN ? AND workspace_uuid = ?", []int64(archive.ShownBountyIDs), uuid).
+				Update("show", true).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Delete(&archive).Error; err != nil {
+			return err
+		}
+		return tx.Where("uuid = ?", uuid).First(&workspace).Error
+	})
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	if err := db.SyncWorkspaceBudget(uuid); err != nil {
+		return workspace, err
+	}
+	return workspace, nil
+}
+
+// PurgeExpiredWorkspaceArchives drops the archives of workspaces deleted before a
+// time, those workspaces cannot be restored anymore
+func (db database) PurgeExpiredWorkspaceArchives(before time.Time) (int64, error) {
+	result := db.db.Where("deleted_at < ?", before).Delete(&WorkspaceArchive{})
+	return result.RowsAffected, result.Error
+}
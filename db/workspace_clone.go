@@ -0,0 +1,188 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWorkspaceNotFound  = errors.New("workspace not found")
+	ErrWorkspaceNameTaken = errors.New("workspace name already exists")
+)
+
+// CloneWorkspace creates a workspace owned by ownerPubkey from the setup of the
+// source: its members and their roles, its settings and its tags, and with
+// includeFeatures its features, phases and stories. Bounties, budgets and
+// payments are never copied. WIP limits of phases that were not copied are dropped
+func (db database) CloneWorkspace(sourceUuid string, ownerPubkey string, request WorkspaceCloneRequest) (WorkspaceCloneResult, error) {
+	result := WorkspaceCloneResult{}
+
+	source := db.GetWorkspaceByUuid(sourceUuid)
+	if source.ID == 0 {
+		return result, ErrWorkspaceNotFound
+	}
+	if db.GetWorkspaceByName(request.Name).ID != 0 {
+		return result, ErrWorkspaceNameTaken
+	}
+
+	description := request.Description
+	if description == "" {
+		description = source.Description
+	}
+
+	now := time.Now()
+	clone := Workspace{
+		Uuid:         xid.New().String(),
+		Name:         request.Name,
+		OwnerPubKey:  ownerPubkey,
+		Img:          source.Img,
+		Created:      &now,
+		Updated:      &now,
+		Show:         source.Show,
+		Website:      source.Website,
+		Github:       source.Github,
+		Description:  description,
+		Mission:      source.Mission,
+		Tactics:      source.Tactics,
+		SchematicUrl: source.SchematicUrl,
+		SchematicImg: source.SchematicImg,
+	}
+	settings := db.GetWorkspaceSettings(sourceUuid)
+
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&clone).Error; err != nil {
+			return err
+		}
+
+		users := []WorkspaceUsers{}
+		tx.Where("workspace_uuid = ? AND owner_pub_key != ?", sourceUuid, ownerPubkey).Find(&users)
+		for _, user := range users {
+			user.ID = 0
+			user.WorkspaceUuid = clone.Uuid
+			user.Created = &now
+			user.Updated = &now
+			if err := tx.Create(&user).Error; err != nil {
+				return err
+			}
+		}
+		result.Members = len(users)
+
+		roles := []WorkspaceUserRoles{}
+		tx.Where("workspace_uuid = ? AND owner_pub_key != ?", sourceUuid, ownerPubkey).Find(&roles)
+		for _, role := range roles {
+			role.WorkspaceUuid = clone.Uuid
+			role.Created = &now
+			if err := tx.Create(&role).Error; err != nil {
+				return err
+			}
+		}
+		result.Roles = len(roles)
+
+		tags := []WorkspaceTag{}
+		tx.Where("workspace_uuid = ?", sourceUuid).Find(&tags)
+		for _, tag := range tags {
+			tag.ID = 0
+			tag.Uuid = xid.New().String()
+			tag.WorkspaceUuid = clone.Uuid
+			tag.CreatedBy = ownerPubkey
+			tag.Created = &now
+			tag.Updated = &now
+			if err := tx.Create(&tag).Error; err != nil {
+				return err
+			}
+		}
+		result.Tags = len(tags)
+
+		phaseUuids := map[string]string{}
+		if request.IncludeFeatures {
+			if err := cloneWorkspaceFeatures(tx, sourceUuid, clone.Uuid, ownerPubkey, now, phaseUuids, &result); err != nil {
+				return err
+			}
+		}
+
+		limits := StateWipLimits{}
+		for _, limit := range settings.StateWipLimits {
+			if limit.PhaseUuid != "" {
+				phaseUuid, ok := phaseUuids[limit.PhaseUuid]
+				if !ok {
+					continue
+				}
+				limit.PhaseUuid = phaseUuid
+			}
+			limits = append(limits, limit)
+		}
+		settings.ID = 0
+		settings.WorkspaceUuid = clone.Uuid
+		settings.StateWipLimits = limits
+		settings.UpdatedBy = ownerPubkey
+		settings.Created = &now
+		settings.Updated = &now
+		return tx.Create(&settings).Error
+	})
+	if err != nil {
+		return WorkspaceCloneResult{}, err
+	}
+
+	result.Workspace = clone
+	return result, nil
+}
+
+// cloneWorkspaceFeatures copies the features of a workspace with their phases and
+// stories, phaseUuids maps the source phases to their copies
+func cloneWorkspaceFeatures(tx *gorm.DB, sourceUuid string, cloneUuid string, ownerPubkey string, now time.Time, phaseUuids map[string]string, result *WorkspaceCloneResult) error {
+	features := []WorkspaceFeatures{}
+	tx.Where("workspace_uuid = ?", sourceUuid).Order("priority ASC").Find(&features)
+
+	for _, feature := range features {
+		sourceFeature := feature.Uuid
+		feature.ID = 0
+		feature.Uuid = xid.New().String()
+		feature.WorkspaceUuid = cloneUuid
+		feature.CreatedBy = ownerPubkey
+		feature.UpdatedBy = ownerPubkey
+		feature.Created = &now
+		feature.Updated = &now
+		if err := tx.Create(&feature).Error; err != nil {
+			return err
+		}
+
+		phases := []FeaturePhase{}
+		tx.Where("feature_uuid = ?", sourceFeature).Find(&phases)
+		for _, phase := range phases {
+			sourcePhase := phase.Uuid
+			phase.Uuid = xid.New().String()
+			phase.FeatureUuid = feature.Uuid
+			phase.CreatedBy = ownerPubkey
+			phase.UpdatedBy = ownerPubkey
+			phase.Created = &now
+			phase.Updated = &now
+			if err := tx.Create(&phase).Error; err != nil {
+				return err
+			}
+			phaseUuids[sourcePhase] = phase.Uuid
+		}
+
+		stories := []FeatureStory{}
+		tx.Where("feature_uuid = ?", sourceFeature).Find(&stories)
+		for _, story := range stories {
+			story.ID = 0
+			story.Uuid = xid.New().String()
+			story.FeatureUuid = feature.Uuid
+			story.CreatedBy = ownerPubkey
+			story.UpdatedBy = ownerPubkey
+			story.Created = &now
+			story.Updated = &now
+			if err := tx.Create(&story).Error; err != nil {
+				return err
+			}
+		}
+
+		result.Features++
+		result.Phases += len(phases)
+		result.Stories += len(stories)
+	}
+	return nil
+}
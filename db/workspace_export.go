@@ -0,0 +1,327 @@
+package db
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WorkspaceExportVersion is the version of the archive ExportWorkspace writes,
+// ImportWorkspace only reads archives of the same version
+const WorkspaceExportVersion = 1
+
+var ErrWorkspaceExists = errors.New("workspace already exists")
+
+// workspaceExportTable is a table with rows of a workspace. Serial tables get new
+// ids when imported, refs are the columns holding ids of other serial tables
+type workspaceExportTable struct {
+	name   string
+	where  string
+	serial bool
+	refs   map[string]string
+}
+
+const (
+	featuresOfWorkspace = "feature_uuid IN (SELECT uuid FROM workspace_features WHERE workspace_uuid = ?)"
+	bountiesOfWorkspace = "bounty_id IN (SELECT id FROM bounty WHERE workspace_uuid = ?)"
+)
+
+var bountyRef = map[string]string{"bounty_id": "bounty"}
+
+// workspaceExportTables are imported in this order so the ids of a table are
+// known before the rows referencing them. People are shared by every workspace
+// and are not exported, neither are api tokens, which stay with their deployment
+var workspaceExportTables = []workspaceExportTable{
+	{name: "workspaces", where: "uuid = ?", serial: true},
+	{name: "workspace_users", where: "workspace_uuid = ?", serial: true},
+	{name: "workspace_user_roles", where: "workspace_uuid = ?"},
+	{name: "workspace_settings", where: "workspace_uuid = ?", serial: true},
+	{name: "workspace_repositories", where: "workspace_uuid = ?", serial: true},
+	{name: "workspace_code_graphs", where: "workspace_uuid = ?", serial: true},
+	{name: "workspace_tags", where: "workspace_uuid = ?", serial: true},
+	{name: "workspace_webhooks", where: "workspace_uuid = ?", serial: true},
+	{name: "workspace_features", where: "workspace_uuid = ?", serial: true},
+	{name: "feature_phases", where: featuresOfWorkspace},
+	{name: "feature_stories", where: featuresOfWorkspace, serial: true},
+	{name: "phase_documents", where: featuresOfWorkspace, serial: true},
+	{name: "feature_calls", where: "workspace_uuid = ?", serial: true},
+	{name: "feature_call_transcripts", where: "workspace_uuid = ?", serial: true},
+	{name: "feature_call_transcript_chunks", where: "transcript_uuid IN (SELECT uuid FROM feature_call_transcripts WHERE workspace_uuid = ?)", serial: true},
+	{name: "chats", where: "workspace_uuid = ?", serial: true},
+	{name: "chat_messages", where: "chat_id IN (SELECT uuid FROM chats WHERE workspace_uuid = ?)", serial: true},
+	{name: "artifacts", where: "workspace_uuid = ?", serial: true},
+	{name: "artifact_versions", where: "artifact_uuid IN (SELECT uuid FROM artifacts WHERE workspace_uuid = ?)", serial: true},
+	{name: "bounty", where: "workspace_uuid = ?", serial: true},
+	{name: "bounty_applications", where: bountiesOfWorkspace, serial: true, refs: bountyRef},
+	{name: "bounty_auctions", where: bountiesOfWorkspace, serial: true, refs: map[string]string{"bounty_id": "bounty", "selected_bid_id": "bounty_bids"}},
+	{name: "bounty_bids", where: "auction_id IN (SELECT id FROM bounty_auctions WHERE " + bountiesOfWorkspace + ")", serial: true, refs: map[string]string{"auction_id": "bounty_auctions"}},
+	{name: "bounty_assignee_histories", where: bountiesOfWorkspace, serial: true, refs: bountyRef},
+	{name: "bounty_state_transitions", where: bountiesOfWorkspace, serial: true, refs: bountyRef},
+	{name: "bounty_comments", where: bountiesOfWorkspace, serial: true, refs: bountyRef},
+	{name: "bounty_hours_logs", where: bountiesOfWorkspace, serial: true, refs: bountyRef},
+	{name: "jira_user_links", where: "workspace_uuid = ?", serial: true},
+	{name: "jira_issue_links", where: "workspace_uuid = ?", serial: true, refs: bountyRef},
+	{name: "bounty_budgets", where: "workspace_uuid = ?", serial: true},
+	{name: "budget_histories", where: "workspace_uuid = ?", serial: true},
+	{name: "payment_histories", where: "workspace_uuid = ?", serial: true, refs: bountyRef},
+	{name: "bounty_receipts", where: bountiesOfWorkspace, serial: true, refs: map[string]string{"bounty_id": "bounty", "payment_id": "payment_histories"}},
+	{name: "invoice_lists", where: "workspace_uuid = ?", serial: true},
+	{name: "budget_refunds", where: "workspace_uuid = ?", serial: true},
+	{name: "budget_ledger_entries", where: "workspace_uuid = ?", serial: true},
+}
+
+// ledgerReferenceTables are the tables the reference_id of ledger entries points
+// to, by reference type
+var ledgerReferenceTables = map[LedgerReferenceType]string{
+	LedgerDeposit: "budget_histories",
+	LedgerPayment: "payment_histories",
+	LedgerRefund:  "budget_refunds",
+
+	LedgerPayoutReversal: "payment_histories",
+}
+
+type WorkspaceExportRow map[string]json.RawMessage
+
+type WorkspaceExportTable struct {
+	Name string               `json:"name"`
+	Rows []WorkspaceExportRow `json:"rows"`
+}
+
+// WorkspaceExport is the archive of a workspace, written gzipped
+type WorkspaceExport struct {
+	Version       int                    `json:"version"`
+	WorkspaceUuid string                 `json:"workspace_uuid"`
+	Exported      time.Time              `json:"exported"`
+	Tables        []WorkspaceExportTable `json:"tables"`
+}
+
+// ExportWorkspace writes every row of a workspace to archive, the rows are kept
+// as Postgres renders them so all columns are exported. It returns the row
+// count of each table
+func (db database) ExportWorkspace(workspaceUuid string, archive io.Writer) (map[string]int, error) {
+	if db.GetWorkspaceByUuid(workspaceUuid).ID == 0 {
+		return nil, ErrWorkspaceNotFound
+	}
+	schema := db.workspaceSchema(workspaceUuid)
+
+	export := WorkspaceExport{
+		Version:       WorkspaceExportVersion,
+		WorkspaceUuid: workspaceUuid,
+		Exported:      time.Now(),
+	}
+	counts := map[string]int{}
+	for _, table := range workspaceExportTables {
+		from := table.name
+		if _, isolated := WorkspaceSchemaTables[table.name]; isolated && schema != "" {
+			from = schema + "." + table.name
+		}
+
+		rows := []string{}
+		err := db.db.Raw(fmt.Sprintf(`SELECT row_to_json(t)::text FROM %s t WHERE %s`, from, strings.ReplaceAll(table.where, "?", "@workspace")),
+			map[string]interface{}{"workspace": workspaceUuid}).Scan(&rows).Error
+		if err != nil {
+			return nil, fmt.Errorf("could not export %s: %w", table.name, err)
+		}
+
+		exported := WorkspaceExportTable{Name: table.name, Rows: make([]WorkspaceExportRow, len(rows))}
+		for i, row := range rows {
+			if err := json.Unmarshal([]byte(row), &exported.Rows[i]); err != nil {
+				return nil, fmt.Errorf("could not export %s: %w", table.name, err)
+			}
+		}
+		export.Tables = append(export.Tables, exported)
+		counts[table.name] = len(rows)
+	}
+
+	gz := gzip.NewWriter(archive)
+	if err := json.NewEncoder(gz).Encode(export); err != nil {
+		return nil, err
+	}
+	return counts, gz.Close()
+}
+
+// ImportWorkspace loads an archive of ExportWorkspace into this database in a
+// single transaction. Rows get new ids and the references to them are updated,
+// columns this database doesn't have are dropped. An isolated workspace is
+// imported into the public tables
+func (db database) ImportWorkspace(archive io.Reader) (Workspace, map[string]int, error) {
+	gz, err := gzip.NewReader(archive)
+	if err != nil {
+		return Workspace{}, nil, err
+	}
+	export := WorkspaceExport{}
+	if err := json.NewDecoder(gz).Decode(&export); err != nil {
+		return Workspace{}, nil, err
+	}
+	if export.Version != WorkspaceExportVersion {
+		return Workspace{}, nil, fmt.Errorf("archive version %d is not supported, expected %d", export.Version, WorkspaceExportVersion)
+	}
+
+	tables := map[string][]WorkspaceExportRow{}
+	for _, table := range export.Tables {
+		tables[table.Name] = table.Rows
+	}
+	if len(tables["workspaces"]) != 1 {
+		return Workspace{}, nil, errors.New("archive has no workspace")
+	}
+	if db.GetWorkspaceByUuid(export.WorkspaceUuid).ID != 0 {
+		return Workspace{}, nil, ErrWorkspaceExists
+	}
+	var name string
+	json.Unmarshal(tables["workspaces"][0]["name"], &name)
+	if db.GetWorkspaceByName(name).ID != 0 {
+		return Workspace{}, nil, ErrWorkspaceNameTaken
+	}
+
+	counts := map[string]int{}
+	err = db.inTransaction(func(tx *gorm.DB) error {
+		ids := map[string]map[string]json.RawMessage{}
+		imported := map[string]bool{}
+		late := []lateWorkspaceRef{}
+
+		for _, table := range workspaceExportTables {
+			rows := tables[table.name]
+			if table.serial {
+				ids[table.name] = map[string]json.RawMessage{}
+			}
+			if len(rows) == 0 {
+				imported[table.name] = true
+				continue
+			}
+
+			columns := map[string]bool{}
+			names := []string{}
+			tx.Raw(`SELECT column_name FROM information_schema.columns WHERE table_schema = 'public' AND table_name = ?`, table.name).Scan(&names)
+			for _, column := range names {
+				columns[column] = true
+			}
+
+			for _, row := range rows {
+				oldId := string(row["id"])
+				refs := remapWorkspaceExportRow(table, row, ids, imported)
+				if table.name == "workspaces" {
+					row["data_schema"] = json.RawMessage(`""`)
+				}
+
+				newId, err := insertWorkspaceExportRow(tx, table, row, columns)
+				if err != nil {
+					return fmt.Errorf("could not import %s: %w", table.name, err)
+				}
+				if table.serial {
+					ids[table.name][oldId] = newId
+				}
+				for column, value := range refs {
+					late = append(late, lateWorkspaceRef{table: table.name, id: newId, column: column, target: table.refs[column], value: value})
+				}
+			}
+			imported[table.name] = true
+			counts[table.name] = len(rows)
+		}
+
+		for _, ref := range late {
+			value, ok := ids[ref.target][string(ref.value)]
+			if !ok {
+				continue
+			}
+			newValue, _ := strconv.ParseInt(string(value), 10, 64)
+			id, _ := strconv.ParseInt(string(ref.id), 10, 64)
+			err := tx.Exec(fmt.Sprintf(`UPDATE %s SET %s = ? WHERE id = ?`, ref.table, ref.column), newValue, id).Error
+			if err != nil {
+				return fmt.Errorf("could not import %s: %w", ref.table, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Workspace{}, nil, err
+	}
+
+	workspace := db.GetWorkspaceByUuid(export.WorkspaceUuid)
+	return workspace, counts, nil
+}
+
+// lateWorkspaceRef is a reference to a table that is imported after the row
+// holding it, it is set once all the tables are imported
+type lateWorkspaceRef struct {
+	table  string
+	id     json.RawMessage
+	column string
+	target string
+	value  json.RawMessage
+}
+
+// remapWorkspaceExportRow drops the id of a row of a serial table and points its
+// references to the new ids. References to tables that are not imported yet are
+// zeroed and returned, to be set later
+func remapWorkspaceExportRow(table workspaceExportTable, row WorkspaceExportRow, ids map[string]map[string]json.RawMessage, imported map[string]bool) map[string]json.RawMessage {
+	if table.serial {
+		delete(row, "id")
+	}
+
+	late := map[string]json.RawMessage{}
+	for column, target := range table.refs {
+		value, ok := row[column]
+		if !ok {
+			continue
+		}
+		if !imported[target] {
+			late[column] = value
+			row[column] = json.RawMessage("0")
+			continue
+		}
+		if newId, ok := ids[target][string(value)]; ok {
+			row[column] = newId
+		}
+	}
+
+	if table.name == "budget_ledger_entries" {
+		var referenceType LedgerReferenceType
+		var referenceId string
+		json.Unmarshal(row["reference_type"], &referenceType)
+		json.Unmarshal(row["reference_id"], &referenceId)
+		if target, ok := ledgerReferenceTables[referenceType]; ok {
+			if newId, ok := ids[target][referenceId]; ok {
+				row["reference_id"], _ = json.Marshal(string(newId))
+			}
+		}
+	}
+	return late
+}
+
+// insertWorkspaceExportRow inserts the columns of row this database has and
+// returns the id the row got
+func insertWorkspaceExportRow(tx *gorm.DB, table workspaceExportTable, row WorkspaceExportRow, columns map[string]bool) (json.RawMessage, error) {
+	names := []string{}
+	values := WorkspaceExportRow{}
+	for column, value := range row {
+		if columns[column] {
+			names = append(names, `"`+column+`"`)
+			values[column] = value
+		}
+	}
+	sort.Strings(names)
+	body, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	list := strings.Join(names, ", ")
+	query := fmt.Sprintf(`INSERT INTO %s (%s) SELECT %s FROM json_populate_record(NULL::%s, ?::json)`, table.name, list, list, table.name)
+	if !table.serial {
+		return nil, tx.Exec(query, string(body)).Error
+	}
+
+	var id int64
+	if err := tx.Raw(query+` RETURNING id`, string(body)).Scan(&id).Error; err != nil {
+		return nil, err
+	}
+	return json.RawMessage(strconv.FormatInt(id, 10)), nil
+}
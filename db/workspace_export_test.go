@@ -0,0 +1,70 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func exportTable(name string) workspaceExportTable {
+	for _, table := range workspaceExportTables {
+		if table.name == name {
+			return table
+		}
+	}
+	return workspaceExportTable{}
+}
+
+func TestRemapWorkspaceExportRow(t *testing.T) {
+	ids := map[string]map[string]json.RawMessage{
+		"bounty":            {"7": json.RawMessage("107")},
+		"payment_histories": {"3": json.RawMessage("303")},
+	}
+	imported := map[string]bool{"bounty": true, "payment_histories": true}
+
+	t.Run("should drop the id and point references to the new ids", func(t *testing.T) {
+		row := WorkspaceExportRow{"id": json.RawMessage("1"), "bounty_id": json.RawMessage("7"), "payment_id": json.RawMessage("3")}
+		late := remapWorkspaceExportRow(exportTable("bounty_receipts"), row, ids, imported)
+		assert.Empty(t, late)
+		assert.NotContains(t, row, "id")
+		assert.Equal(t, "107", string(row["bounty_id"]))
+		assert.Equal(t, "303", string(row["payment_id"]))
+	})
+
+	t.Run("should keep references to rows outside the archive", func(t *testing.T) {
+		row := WorkspaceExportRow{"id": json.RawMessage("2"), "bounty_id": json.RawMessage("9")}
+		remapWorkspaceExportRow(exportTable("bounty_comments"), row, ids, imported)
+		assert.Equal(t, "9", string(row["bounty_id"]))
+	})
+
+	t.Run("should return references to tables imported later", func(t *testing.T) {
+		row := WorkspaceExportRow{"id": json.RawMessage("4"), "bounty_id": json.RawMessage("7"), "selected_bid_id": json.RawMessage("12")}
+		late := remapWorkspaceExportRow(exportTable("bounty_auctions"), row, ids, imported)
+		assert.Equal(t, map[string]json.RawMessage{"selected_bid_id": json.RawMessage("12")}, late)
+		assert.Equal(t, "0", string(row["selected_bid_id"]))
+		assert.Equal(t, "107", string(row["bounty_id"]))
+	})
+
+	t.Run("should point ledger entries to the new payments", func(t *testing.T) {
+		row := WorkspaceExportRow{"id": json.RawMessage("5"), "reference_type": json.RawMessage(`"payment"`), "reference_id": json.RawMessage(`"3"`)}
+		remapWorkspaceExportRow(exportTable("budget_ledger_entries"), row, ids, imported)
+		assert.Equal(t, `"303"`, string(row["reference_id"]))
+
+		row = WorkspaceExportRow{"id": json.RawMessage("6"), "reference_type": json.RawMessage(`"reserve"`), "reference_id": json.RawMessage(`"3"`)}
+		remapWorkspaceExportRow(exportTable("budget_ledger_entries"), row, ids, imported)
+		assert.Equal(t, `"3"`, string(row["reference_id"]))
+	})
+
+	t.Run("should list tables before the tables referencing them", func(t *testing.T) {
+		seen := map[string]bool{}
+		for _, table := range workspaceExportTables {
+			for column, target := range table.refs {
+				if column != "selected_bid_id" {
+					assert.True(t, seen[target], "%s.%s references %s", table.name, column, target)
+				}
+			}
+			seen[table.name] = true
+		}
+	})
+}
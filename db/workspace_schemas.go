@@ -0,0 +1,127 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// WorkspaceSchemaTables are the tables an isolated workspace keeps in its own
+// Postgres schema, every query on them goes through workspaceDB
+var WorkspaceSchemaTables = map[string]interface{}{
+	"workspace_repositories": &WorkspaceRepositories{},
+	"workspace_settings":     &WorkspaceSettings{},
+}
+
+var workspaceSchemaPattern = regexp.MustCompile(`^ws_[a-z0-9]+$`)
+var notSchemaChars = regexp.MustCompile(`[^a-zA-Z0-9]`)
+
+// schemas of isolated workspaces, a workspace never leaves its schema so only
+// those are cached
+var workspaceSchemas sync.Map
+
+// WorkspaceSchemaName is the schema an isolated workspace's data lives in
+func WorkspaceSchemaName(workspaceUuid string) string {
+	return "ws_" + strings.ToLower(notSchemaChars.ReplaceAllString(workspaceUuid, ""))
+}
+
+func (db database) workspaceSchema(workspaceUuid string) string {
+	if schema, ok := workspaceSchemas.Load(workspaceUuid); ok {
+		return schema.(string)
+	}
+
+	var schema string
+	db.db.Model(&Workspace{}).Where("uuid = ?", workspaceUuid).Select("data_schema").Limit(1).Scan(&schema)
+	if schema != "" {
+		workspaceSchemas.Store(workspaceUuid, schema)
+	}
+	return schema
+}
+
+// inSchema points the query's table to the same table in schema
+func inSchema(schema string) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		model := tx.Statement.Model
+		if model == nil {
+			model = tx.Statement.Dest
+		}
+		if err := tx.Statement.Parse(model); err != nil {
+			tx.AddError(err)
+			return tx
+		}
+		return tx.Table(schema + "." + tx.Statement.Schema.Table)
+	}
+}
+
+// workspaceDB is the db to query a workspace's WorkspaceSchemaTables with,
+// scoped to its schema when the workspace is isolated
+func (db database) workspaceDB(workspaceUuid string) *gorm.DB {
+	schema := db.workspaceSchema(workspaceUuid)
+	if schema == "" {
+		return db.db
+	}
+	return db.db.Scopes(inSchema(schema))
+}
+
+// IsolateWorkspace creates the workspace's schema, moves its rows of the
+// WorkspaceSchemaTables there and routes its queries to it from then on.
+// It is used for new workspaces and to migrate existing ones
+func (db database) IsolateWorkspace(workspaceUuid string) (Workspace, error) {
+	workspace := db.GetWorkspaceByUuid(workspaceUuid)
+	if workspace.ID == 0 {
+		return Workspace{}, errors.New("workspace not found")
+	}
+	if workspace.DataSchema != "" {
+		return workspace, nil
+	}
+
+	schema := WorkspaceSchemaName(workspaceUuid)
+	if !workspaceSchemaPattern.MatchString(schema) {
+		return Workspace{}, fmt.Errorf("can't make a schema name from %q", workspaceUuid)
+	}
+
+	err := db.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS %s`, schema)).Error; err != nil {
+			return err
+		}
+		for table := range WorkspaceSchemaTables {
+			err := tx.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s.%s (LIKE public.%s INCLUDING ALL)`, schema, table, table)).Error
+			if err == nil {
+				err = tx.Exec(fmt.Sprintf(`INSERT INTO %s.%s SELECT * FROM public.%s WHERE workspace_uuid = ?`, schema, table, table), workspaceUuid).Error
+			}
+			if err == nil {
+				err = tx.Exec(fmt.Sprintf(`DELETE FROM public.%s WHERE workspace_uuid = ?`, table), workspaceUuid).Error
+			}
+			if err != nil {
+				return fmt.Errorf("could not move %s: %w", table, err)
+			}
+		}
+		return tx.Model(&Workspace{}).Where("uuid = ?", workspaceUuid).Update("data_schema", schema).Error
+	})
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	workspaceSchemas.Store(workspaceUuid, schema)
+	workspace.DataSchema = schema
+	return workspace, nil
+}
+
+// MigrateWorkspaceSchemas brings the tables of the isolated workspaces up to
+// date with the models, like AutoMigrate does for the public ones
+func (db database) MigrateWorkspaceSchemas() {
+	schemas := []string{}
+	db.db.Model(&Workspace{}).Where("data_schema <> ''").Pluck("data_schema", &schemas)
+
+	for _, schema := range schemas {
+		for table, model := range WorkspaceSchemaTables {
+			if err := db.db.Table(schema + "." + table).AutoMigrate(model); err != nil {
+				fmt.Println("could not migrate", schema+"."+table, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspaceSchemaName(t *testing.T) {
+	assert.Equal(t, "ws_cn2q9b4n3v8g00d1k7e0", WorkspaceSchemaName("cn2q9b4n3v8g00d1k7e0"))
+	assert.Equal(t, "ws_3f2b8c1e9a7d", WorkspaceSchemaName("3F2B8C1E-9A7D"))
+	assert.Equal(t, "ws_xdroptablepeople", WorkspaceSchemaName(`x"; drop table people; --`))
+	assert.Regexp(t, workspaceSchemaPattern, WorkspaceSchemaName("cn2q9b4n3v8g00d1k7e0"))
+}
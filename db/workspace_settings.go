@@ -0,0 +1,101 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// DefaultWorkspaceSettings are the settings of a workspace that never saved any
+func DefaultWorkspaceSettings(workspaceUuid string) WorkspaceSettings {
+	return WorkspaceSettings{
+		WorkspaceUuid:           workspaceUuid,
+		DefaultBountyVisibility: BountyVisibilityPublic,
+		StateWipLimits:          StateWipLimits{},
+	}
+}
+
+// AiSettings are the assistant settings of the workspace, ok is false when it has none
+func (s WorkspaceSettings) AiSettings() (WorkspaceAiSettings, bool) {
+	if s.AiProvider == "" {
+		return WorkspaceAiSettings{}, false
+	}
+	return WorkspaceAiSettings{
+		Provider:    s.AiProvider,
+		Model:       s.AiModel,
+		Temperature: s.AiTemperature,
+		KeyRef:      s.AiProviderKeyRef,
+	}, true
+}
+
+func (db database) GetWorkspaceSettings(workspaceUuid string) WorkspaceSettings {
+	settings := WorkspaceSettings{}
+	result := db.workspaceDB(workspaceUuid).Model(&WorkspaceSettings{}).Where("workspace_uuid = ?", workspaceUuid).Limit(1).Find(&settings)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return DefaultWorkspaceSettings(workspaceUuid)
+	}
+	return settings
+}
+
+func (db database) SaveWorkspaceSettings(settings WorkspaceSettings) (WorkspaceSettings, error) {
+	now := time.Now()
+	settings.ID = 0
+	settings.Created = &now
+	settings.Updated = &now
+
+	err := db.workspaceDB(settings.WorkspaceUuid).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "workspace_uuid"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"payment_approval_threshold",
+			"wip_limit",
+			"state_wip_limits",
+			"default_bounty_visibility",
+			"ai_agent_monthly_quota",
+			"ai_provider",
+			"ai_model",
+			"ai_temperature",
+			"ai_provider_key_ref",
+			"public_showcase",
+			"display_currency",
+			"activity_pub",
+			"updated_by",
+			"updated",
+		}),
+	}).Create(&settings).Error
+	if err != nil {
+		return WorkspaceSettings{}, err
+	}
+	return db.GetWorkspaceSettings(settings.WorkspaceUuid), nil
+}
+
+// GetWorkspaceActiveBountiesCount counts the unfinished bounties a hunter holds in a workspace
+func (db database) GetWorkspaceActiveBountiesCount(workspaceUuid string, assignee string) int64 {
+	var count int64
+	db.db.Model(&NewBounty{}).
+		Where("workspace_uuid = ? AND assignee = ? AND paid = ? AND completed = ?", workspaceUuid, assignee, false, false).
+		Count(&count)
+	return count
+}
+
+// GetWorkspaceBountiesInStateCount counts the bounties of a workspace in a state,
+// only those of a phase when phaseUuid is set
+func (db database) GetWorkspaceBountiesInStateCount(workspaceUuid string, phaseUuid string, state BountyState) int64 {
+	var count int64
+	query := db.db.Model(&NewBounty{}).Where("workspace_uuid = ? AND state = ?", workspaceUuid, state)
+	if phaseUuid != "" {
+		query = query.Where("phase_uuid = ?", phaseUuid)
+	}
+	query.Count(&count)
+	return count
+}
+
+// GetWorkspaceAgentCommentsCount counts the notes review agents posted on the
+// bounties of a workspace since a time
+func (db database) GetWorkspaceAgentCommentsCount(workspaceUuid string, since time.Time) int64 {
+	var count int64
+	db.db.Model(&BountyComment{}).
+		Joins("JOIN bounty ON bounty.id = bounty_comments.bounty_id").
+		Where("bounty.workspace_uuid = ? AND bounty_comments.author_type = ? AND bounty_comments.created >= ?", workspaceUuid, CommentAuthorAIAgent, since).
+		Count(&count)
+	return count
+}
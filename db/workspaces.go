@@ -7,7 +7,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/rs/xid"
 	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func (db database) GetWorkspaces(r *http.Request) []Workspace {
@@ -17,7 +20,7 @@ func (db database) GetWorkspaces(r *http.Request) []Workspace {
 	query := db.db.Model(&ms).Where("LOWER(name) LIKE ?", "%"+search+"%").Where("deleted != ?", true)
 
 	if limit > 1 {
-		query.Offset(offset).Limit(limit).Order(sortBy + " " + direction + " ")
+		query.Offset(offset).Limit(limit).Order(WorkspaceSortFields.OrderBy(sortBy, direction, "created"))
 	}
 
 	query.Find(&ms)
@@ -65,12 +68,13 @@ func (db database) CreateOrEditWorkspaceRepository(m WorkspaceRepositories) (Wor
 	now := time.Now()
 	m.Updated = &now
 
-	if db.db.Model(&m).Where("uuid = ?", m.Uuid).Updates(&m).RowsAffected == 0 {
+	wdb := db.workspaceDB(m.WorkspaceUuid)
+	if wdb.Model(&m).Where("uuid = ?", m.Uuid).Updates(&m).RowsAffected == 0 {
 		m.Created = &now
-		db.db.Create(&m)
+		wdb.Create(&m)
 	}
 
-	db.db.Model(&WorkspaceRepositories{}).Where("uuid = ?", m.Uuid).Find(&m)
+	wdb.Model(&WorkspaceRepositories{}).Where("uuid = ?", m.Uuid).Find(&m)
 
 	return m, nil
 }
@@ -78,7 +82,7 @@ func (db database) CreateOrEditWorkspaceRepository(m WorkspaceRepositories) (Wor
 func (db database) GetWorkspaceRepositorByWorkspaceUuid(uuid string) []WorkspaceRepositories {
 	ms := []WorkspaceRepositories{}
 
-	db.db.Model(&WorkspaceRepositories{}).Where("workspace_uuid = ?", uuid).Order("Created").Find(&ms)
+	db.workspaceDB(uuid).Model(&WorkspaceRepositories{}).Where("workspace_uuid = ?", uuid).Order("Created").Find(&ms)
 
 	return ms
 }
@@ -86,7 +90,7 @@ func (db database) GetWorkspaceRepositorByWorkspaceUuid(uuid string) []Workspace
 func (db database) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid string, uuid string) (WorkspaceRepositories, error) {
 	var ms WorkspaceRepositories
 
-	result := db.db.Model(&WorkspaceRepositories{}).Where("workspace_uuid = ?", workspace_uuid).Where("uuid = ?", uuid).Find(&ms)
+	result := db.workspaceDB(workspace_uuid).Model(&WorkspaceRepositories{}).Where("workspace_uuid = ?", workspace_uuid).Where("uuid = ?", uuid).Find(&ms)
 	if result.RowsAffected == 0 {
 		return ms, fmt.Errorf("workspace repository not found")
 	}
@@ -95,7 +99,7 @@ func (db database) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid str
 }
 
 func (db database) DeleteWorkspaceRepository(workspace_uuid string, uuid string) bool {
-	db.db.Where("workspace_uuid = ?", workspace_uuid).Where("uuid = ?", uuid).Delete(&WorkspaceRepositories{})
+	db.workspaceDB(workspace_uuid).Where("workspace_uuid = ?", workspace_uuid).Where("uuid = ?", uuid).Delete(&WorkspaceRepositories{})
 	return true
 }
 
@@ -174,14 +178,52 @@ func (db database) AddBudgetHistory(budget BudgetHistory) BudgetHistory {
 	return budget
 }
 
+// CreateWorkspaceBudget opens the budget of a workspace,
+// a starting total is posted to the ledger as an adjustment
 func (db database) CreateWorkspaceBudget(budget NewBountyBudget) NewBountyBudget {
-	db.db.Create(&budget)
+	db.inTransaction(func(tx *gorm.DB) error {
+		total := budget.TotalBudget
+		budget.TotalBudget = 0
+		if err := tx.Create(&budget).Error; err != nil {
+			return err
+		}
+		budget.TotalBudget = total
+
+		return postBudgetTransaction(tx, ledgerTransfer{
+			WorkspaceUuid: budget.WorkspaceUuid,
+			From:          LedgerAdjustmentsAccount,
+			To:            WorkspaceBudgetAccount(budget.WorkspaceUuid),
+			Amount:        total,
+			ReferenceType: LedgerOpening,
+			ReferenceId:   budget.WorkspaceUuid,
+		})
+	})
 	return budget
 }
 
+// UpdateWorkspaceBudget sets the budget of a workspace to a new total
+// by posting the difference to the ledger as an adjustment
 func (db database) UpdateWorkspaceBudget(budget NewBountyBudget) NewBountyBudget {
-	db.db.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", budget.WorkspaceUuid).Updates(map[string]interface{}{
-		"total_budget": budget.TotalBudget,
+	db.inTransaction(func(tx *gorm.DB) error {
+		account := WorkspaceBudgetAccount(budget.WorkspaceUuid)
+		transfer := ledgerTransfer{
+			WorkspaceUuid: budget.WorkspaceUuid,
+			From:          LedgerAdjustmentsAccount,
+			To:            account,
+			ReferenceType: LedgerAdjustment,
+			ReferenceId:   xid.New().String(),
+		}
+
+		balance := ledgerBalance(tx, account)
+		target := int64(budget.TotalBudget)
+		if target < balance {
+			transfer.From, transfer.To = account, LedgerAdjustmentsAccount
+			transfer.Amount = uint(balance - target)
+		} else {
+			transfer.Amount = uint(target - balance)
+		}
+
+		return postBudgetTransaction(tx, transfer)
 	})
 	return budget
 }
@@ -192,10 +234,14 @@ func (db database) GetPaymentHistoryByCreated(created *time.Time, workspace_uuid
 	return ms
 }
 
+// GetWorkspaceBudget returns the budget of a workspace with its total derived from the ledger
 func (db database) GetWorkspaceBudget(workspace_uuid string) NewBountyBudget {
 	ms := NewBountyBudget{}
 	db.db.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", workspace_uuid).Find(&ms)
 
+	if ms.WorkspaceUuid != "" {
+		ms.TotalBudget = budgetFromBalance(db.GetWorkspaceLedgerBalance(workspace_uuid))
+	}
 	return ms
 }
 
@@ -251,68 +297,41 @@ func (db database) GetWorkspaceBudgetHistory(workspace_uuid string) []BudgetHist
 	return budgetHistory
 }
 
-func (db database) ProcessUpdateBudget(invoice NewInvoiceList) error {
-	// Start db transaction
-	tx := db.db.Begin()
-
-	var err error
-
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
-	if err = tx.Error; err != nil {
+// depositPayment marks a budget deposit as paid and credits it to the workspace
+func depositPayment(tx *gorm.DB, paymentHistory NewPaymentHistory) error {
+	paymentHistory.Status = true
+	if err := tx.Model(&NewPaymentHistory{}).Where("id = ?", paymentHistory.ID).Update("status", true).Error; err != nil {
 		return err
 	}
 
+	return postBudgetTransaction(tx, ledgerTransfer{
+		WorkspaceUuid: paymentHistory.WorkspaceUuid,
+		From:          LedgerDepositsAccount,
+		To:            WorkspaceBudgetAccount(paymentHistory.WorkspaceUuid),
+		Amount:        paymentHistory.Amount,
+		ReferenceType: LedgerDeposit,
+		ReferenceId:   fmt.Sprint(paymentHistory.ID),
+	})
+}
+
+func (db database) ProcessUpdateBudget(invoice NewInvoiceList) error {
 	created := invoice.Created
 	workspace_uuid := invoice.WorkspaceUuid
 
-	// Get payment history and update budget
-	paymentHistory := db.GetPaymentHistoryByCreated(created, workspace_uuid)
-	if paymentHistory.WorkspaceUuid != "" && paymentHistory.Amount != 0 {
-		paymentHistory.Status = true
-
-		// Update payment history
-		if err = tx.Where("created = ?", created).Where("workspace_uuid = ? ", workspace_uuid).Updates(paymentHistory).Error; err != nil {
-			tx.Rollback()
+	return db.inTransaction(func(tx *gorm.DB) error {
+		// Get payment history and update budget
+		paymentHistory := db.GetPaymentHistoryByCreated(created, workspace_uuid)
+		if paymentHistory.WorkspaceUuid == "" || paymentHistory.Amount == 0 {
+			return nil
 		}
 
-		// get Workspace budget and add payment to total budget
-		WorkspaceBudget := db.GetWorkspaceBudget(workspace_uuid)
-
-		if WorkspaceBudget.WorkspaceUuid == "" {
-			now := time.Now()
-			workBudget := NewBountyBudget{
-				WorkspaceUuid: workspace_uuid,
-				TotalBudget:   paymentHistory.Amount,
-				Created:       &now,
-				Updated:       &now,
-			}
-
-			if err = tx.Create(&workBudget).Error; err != nil {
-				tx.Rollback()
-			}
-		} else {
-			totalBudget := WorkspaceBudget.TotalBudget
-			WorkspaceBudget.TotalBudget = totalBudget + paymentHistory.Amount
-
-			if err = tx.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", WorkspaceBudget.WorkspaceUuid).Updates(map[string]interface{}{
-				"total_budget": WorkspaceBudget.TotalBudget,
-			}).Error; err != nil {
-				tx.Rollback()
-			}
+		if err := depositPayment(tx, paymentHistory); err != nil {
+			return err
 		}
 
 		// update invoice
-		if err = tx.Model(&NewInvoiceList{}).Where("payment_request = ?", invoice.PaymentRequest).Update("status", true).Error; err != nil {
-			tx.Rollback()
-		}
-	}
-
-	return tx.Commit().Error
+		return tx.Model(&NewInvoiceList{}).Where("payment_request = ?", invoice.PaymentRequest).Update("status", true).Error
+	})
 }
 
 func (db database) AddAndUpdateBudget(invoice NewInvoiceList) NewPaymentHistory {
@@ -322,133 +341,105 @@ func (db database) AddAndUpdateBudget(invoice NewInvoiceList) NewPaymentHistory
 	paymentHistory := db.GetPaymentHistoryByCreated(created, workspace_uuid)
 
 	if paymentHistory.WorkspaceUuid != "" && paymentHistory.Amount != 0 {
-		paymentHistory.Status = true
-		db.db.Where("created = ?", created).Where("workspace_uuid = ? ", workspace_uuid).Updates(paymentHistory)
-
-		// get Workspace budget and add payment to total budget
-		WorkspaceBudget := db.GetWorkspaceBudget(workspace_uuid)
-
-		if WorkspaceBudget.WorkspaceUuid == "" {
-			now := time.Now()
-			workBudget := NewBountyBudget{
-				WorkspaceUuid: workspace_uuid,
-				TotalBudget:   paymentHistory.Amount,
-				Created:       &now,
-				Updated:       &now,
-			}
-			db.CreateWorkspaceBudget(workBudget)
-		} else {
-			totalBudget := WorkspaceBudget.TotalBudget
-			WorkspaceBudget.TotalBudget = totalBudget + paymentHistory.Amount
-			db.UpdateWorkspaceBudget(WorkspaceBudget)
+		err := db.inTransaction(func(tx *gorm.DB) error {
+			return depositPayment(tx, paymentHistory)
+		})
+		if err == nil {
+			paymentHistory.Status = true
 		}
 	}
 
 	return paymentHistory
 }
 
-func (db database) WithdrawBudget(sender_pubkey string, workspace_uuid string, amount uint) {
-	tx := db.db.Begin()
-	var err error
-
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+// WithdrawBudget records a budget withdrawal and takes it out of the reservation made
+// for it, without a reservation the budget is locked and checked before it is spent
+func (db database) WithdrawBudget(sender_pubkey string, reservation BudgetReservation) error {
+	return db.inTransaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		budgetHistory := NewPaymentHistory{
+			WorkspaceUuid:  reservation.WorkspaceUuid,
+			Amount:         reservation.Amount,
+			Status:         true,
+			PaymentType:    "withdraw",
+			Created:        &now,
+			Updated:        &now,
+			SenderPubKey:   sender_pubkey,
+			ReceiverPubKey: "",
+			BountyId:       0,
 		}
-	}()
-
-	if err = tx.Error; err != nil {
-		return
-	}
 
-	// get Workspace budget and add payment to total budget
-	WorkspaceBudget := db.GetWorkspaceBudget(workspace_uuid)
-	totalBudget := WorkspaceBudget.TotalBudget
-
-	newBudget := totalBudget - amount
-
-	if err = tx.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", workspace_uuid).Updates(map[string]interface{}{
-		"total_budget": newBudget,
-	}).Error; err != nil {
-		tx.Rollback()
-	}
+		if err := tx.Create(&budgetHistory).Error; err != nil {
+			return err
+		}
 
-	now := time.Now()
-	budgetHistory := NewPaymentHistory{
-		WorkspaceUuid:  workspace_uuid,
-		Amount:         amount,
-		Status:         true,
-		PaymentType:    "withdraw",
-		Created:        &now,
-		Updated:        &now,
-		SenderPubKey:   sender_pubkey,
-		ReceiverPubKey: "",
-		BountyId:       0,
-	}
+		return spendBudget(tx, reservation, ledgerTransfer{
+			WorkspaceUuid: reservation.WorkspaceUuid,
+			To:            LedgerWithdrawalsAccount,
+			Amount:        reservation.Amount,
+			ReferenceType: LedgerWithdraw,
+			ReferenceId:   fmt.Sprint(budgetHistory.ID),
+		})
+	})
+}
 
-	if err = tx.Create(&budgetHistory).Error; err != nil {
-		tx.Rollback()
+// payoutTransfer pays a bounty out of the workspace budget
+func payoutTransfer(payment NewPaymentHistory) ledgerTransfer {
+	return ledgerTransfer{
+		WorkspaceUuid: payment.WorkspaceUuid,
+		To:            LedgerPayoutsAccount,
+		Amount:        payment.Amount,
+		ReferenceType: LedgerPayment,
+		ReferenceId:   fmt.Sprint(payment.ID),
 	}
-	tx.Commit()
 }
 
 func (db database) AddPaymentHistory(payment NewPaymentHistory) NewPaymentHistory {
-	db.db.Create(&payment)
-
-	// get Workspace budget and subtract payment from total budget
-	WorkspaceBudget := db.GetWorkspaceBudget(payment.WorkspaceUuid)
-	totalBudget := WorkspaceBudget.TotalBudget
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&payment).Error; err != nil {
+			return err
+		}
 
-	// deduct amount if it's a bounty payment
-	if payment.PaymentType == "payment" {
-		WorkspaceBudget.TotalBudget = totalBudget - payment.Amount
+		// deduct amount if it's a bounty payment
+		if payment.PaymentType == Payment {
+			return spendBudget(tx, BudgetReservation{}, payoutTransfer(payment))
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Println("[ledger] could not add payment history", err)
 	}
 
-	db.UpdateWorkspaceBudget(WorkspaceBudget)
-
 	return payment
 }
 
-func (db database) ProcessBountyPayment(payment NewPaymentHistory, bounty NewBounty) error {
-	tx := db.db.Begin()
-	var err error
-
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+// ProcessBountyPayment records a bounty payment and takes it out of the reservation made
+// for it, without a reservation the budget is locked and checked before it is spent
+func (db database) ProcessBountyPayment(payment NewPaymentHistory, bounty NewBounty, reservation BudgetReservation) error {
+	err := db.inTransaction(func(tx *gorm.DB) error {
+		// add to payment history
+		if err := tx.Create(&payment).Error; err != nil {
+			return err
 		}
-	}()
-
-	if err = tx.Error; err != nil {
-		return err
-	}
-
-	// add to payment history
-	if err = tx.Create(&payment).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
 
-	// get Workspace budget and subtract payment from total budget
-	WorkspaceBudget := db.GetWorkspaceBudget(payment.WorkspaceUuid)
-	totalBudget := WorkspaceBudget.TotalBudget
+		// subtract payment from the workspace budget
+		if err := spendBudget(tx, reservation, payoutTransfer(payment)); err != nil {
+			return err
+		}
 
-	// update budget
-	WorkspaceBudget.TotalBudget = totalBudget - payment.Amount
-	if err = tx.Model(&NewBountyBudget{}).Where("workspace_uuid = ?", payment.WorkspaceUuid).Updates(map[string]interface{}{
-		"total_budget": WorkspaceBudget.TotalBudget,
-	}).Error; err != nil {
-		tx.Rollback()
+		// updatge bounty status
+		return tx.Where("created", bounty.Created).Updates(&bounty).Error
+	})
+	if err != nil {
 		return err
 	}
 
-	// updatge bounty status
-	if err = tx.Where("created", bounty.Created).Updates(&bounty).Error; err != nil {
-		tx.Rollback()
-		return err
+	// the receipt comes after the payment is recorded, the sats are gone by
+	// now so a receipt that can't be written must not undo the payment
+	if err := db.saveBountyReceipt(payment, bounty); err != nil {
+		fmt.Println("[receipts] could not save the receipt of bounty", bounty.ID, err)
 	}
-
-	return tx.Commit().Error
+	return nil
 }
 
 func (db database) GetPaymentHistory(workspace_uuid string, r *http.Request) []NewPaymentHistory {
@@ -465,6 +456,65 @@ func (db database) GetPaymentHistory(workspace_uuid string, r *http.Request) []N
 	return payment
 }
 
+// GetUnconfirmedOnchainPayments returns the on-chain payouts still waiting for confirmations
+func (db database) GetUnconfirmedOnchainPayments() []NewPaymentHistory {
+	payments := []NewPaymentHistory{}
+	db.db.Model(&NewPaymentHistory{}).Where("payment_method = ? AND onchain_txid <> '' AND onchain_confirmed IS NULL AND onchain_dropped IS NULL", PaymentMethodOnchain).Find(&payments)
+	return payments
+}
+
+// UpdateOnchainConfirmations records the confirmations of an on-chain payout,
+// confirmed is set once it has enough of them
+func (db database) UpdateOnchainConfirmations(id uint, confirmations uint, confirmed *time.Time) error {
+	now := time.Now()
+	return db.db.Model(&NewPaymentHistory{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"onchain_confirmations": confirmations,
+		"onchain_confirmed":     confirmed,
+		"updated":               &now,
+	}).Error
+}
+
+// DropOnchainPayment reopens the bounty of an on-chain payout that can no longer
+// confirm, the payout goes back to the workspace budget and its receipt is removed
+func (db database) DropOnchainPayment(payment NewPaymentHistory, dropped time.Time) error {
+	return db.inTransaction(func(tx *gorm.DB) error {
+		result := tx.Model(&NewPaymentHistory{}).
+			Where("id = ? AND onchain_confirmed IS NULL AND onchain_dropped IS NULL", payment.ID).
+			Updates(map[string]interface{}{
+				"status":          false,
+				"onchain_dropped": &dropped,
+				"updated":         &dropped,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("on-chain payment is confirmed or dropped already")
+		}
+
+		if err := postBudgetTransaction(tx, ledgerTransfer{
+			WorkspaceUuid: payment.WorkspaceUuid,
+			From:          LedgerPayoutsAccount,
+			To:            WorkspaceBudgetAccount(payment.WorkspaceUuid),
+			Amount:        payment.Amount,
+			ReferenceType: LedgerPayoutReversal,
+			ReferenceId:   fmt.Sprint(payment.ID),
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Where("payment_id = ?", payment.ID).Delete(&BountyReceipt{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&NewBounty{}).Where("id = ?", payment.BountyId).Updates(map[string]interface{}{
+			"paid":      false,
+			"paid_date": nil,
+			"state":     BountyStateCompleted,
+		}).Error
+	})
+}
+
 func (db database) GetWorkspaceInvoices(workspace_uuid string) []NewInvoiceList {
 	ms := []NewInvoiceList{}
 	db.db.Where("workspace_uuid = ?", workspace_uuid).Where("status", false).Find(&ms)
@@ -503,50 +553,68 @@ func (db database) UpdateWorkspaceForDeletion(uuid string) error {
 	return nil
 }
 
+// ProcessDeleteWorkspace soft deletes a workspace. Its members and roles are removed
+// and its bounties hidden at once, what they were is archived so the workspace can
+// be restored within config.WorkspaceRetentionDays
 func (db database) ProcessDeleteWorkspace(workspace_uuid string) error {
-	tx := db.db.Begin()
-	var err error
-
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	return db.inTransaction(func(tx *gorm.DB) error {
+		workspace := Workspace{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", workspace_uuid).First(&workspace).Error; err != nil {
+			return err
+		}
+		if workspace.Deleted {
+			return nil
 		}
-	}()
-
-	if err = tx.Error; err != nil {
-		return err
-	}
-
-	updates := map[string]interface{}{
-		"website":     "",
-		"github":      "",
-		"description": "",
-		"show":        false,
-	}
-
-	// Update workspace
-	if err = tx.Model(&Workspace{}).Where("uuid = ?", workspace_uuid).Updates(updates).Error; err != nil {
-		tx.Rollback()
-	}
 
-	// Delete all users associated with the Workspace
-	if err = tx.Where("workspace_uuid = ?", workspace_uuid).Delete(&WorkspaceUsers{}).Error; err != nil {
-		tx.Rollback()
-	}
+		now := time.Now()
+		archive := WorkspaceArchive{
+			WorkspaceUuid: workspace_uuid,
+			Website:       workspace.Website,
+			Github:        workspace.Github,
+			Description:   workspace.Description,
+			Show:          workspace.Show,
+			DeletedAt:     &now,
+		}
+		if err := tx.Where("workspace_uuid = ?", workspace_uuid).Find(&archive.Members.Users).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_uuid = ?", workspace_uuid).Find(&archive.Members.Roles).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&NewBounty{}).Where("workspace_uuid = ? AND show = ?", workspace_uuid, true).Pluck("id", &archive.ShownBountyIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_uuid = ?", workspace_uuid).Delete(&WorkspaceArchive{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&archive).Error; err != nil {
+			return err
+		}
 
-	// Delete all user roles associated with the Workspace
-	if err = tx.Where("workspace_uuid = ?", workspace_uuid).Delete(&WorkspaceUserRoles{}).Error; err != nil {
-		tx.Rollback()
-	}
+		if err := tx.Model(&Workspace{}).Where("uuid = ?", workspace_uuid).Updates(map[string]interface{}{
+			"website":     "",
+			"github":      "",
+			"description": "",
+			"show":        false,
+			"deleted":     true,
+			"deleted_at":  &now,
+		}).Error; err != nil {
+			return err
+		}
 
-	// Change delete status to true
-	if err = tx.Model(&Workspace{}).Where("uuid", workspace_uuid).Updates(map[string]interface{}{
-		"deleted": true,
-	}).Error; err != nil {
-		tx.Rollback()
-	}
+		// Delete all users and user roles associated with the Workspace
+		if err := tx.Where("workspace_uuid = ?", workspace_uuid).Delete(&WorkspaceUsers{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_uuid = ?", workspace_uuid).Delete(&WorkspaceUserRoles{}).Error; err != nil {
+			return err
+		}
 
-	return tx.Commit().Error
+		if len(archive.ShownBountyIDs) > 0 {
+			return tx.Model(&NewBounty{}).Where("id IN ?", []int64(archive.ShownBountyIDs)).Update("show", false).Error
+		}
+		return nil
+	})
 }
 
 func (db database) DeleteAllUsersFromWorkspace(workspace_uuid string) error {
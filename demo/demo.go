@@ -0,0 +1,362 @@
+// Package demo seeds a small, realistic set of tribes, people, workspaces,
+// features and bounties for local development and the Cypress tests. Seeding
+// again updates the same rows instead of adding new ones.
+package demo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+var created = time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+var Alice = db.Person{
+	Uuid:        "demo_alice_uuid",
+	OwnerPubKey: "02d1f1a4e1b86c8f2c3a2f7c9b9d7e5e0f8a3c1b5d6e7f8091a2b3c4d5e6f7a8b9",
+	OwnerAlias:  "Alice",
+	UniqueName:  "alice",
+	Description: "Runs the Lightning Wallet workspace and funds its bounties",
+	Tags:        pq.StringArray{"lightning", "product"},
+	Created:     &created,
+}
+
+var Bob = db.Person{
+	Uuid:        "demo_bob_uuid",
+	OwnerPubKey: "03b0b5e2c7d4a1f9e8c6b3a2d1f0e9c8b7a6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b1",
+	OwnerAlias:  "Bob",
+	UniqueName:  "bob",
+	Description: "Go and TypeScript developer hunting lightning bounties",
+	Tags:        pq.StringArray{"golang", "typescript"},
+	Created:     &created,
+}
+
+var Carol = db.Person{
+	Uuid:        "demo_carol_uuid",
+	OwnerPubKey: "02ca701d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c",
+	OwnerAlias:  "Carol",
+	UniqueName:  "carol",
+	Description: "Designer and workspace member",
+	Tags:        pq.StringArray{"design"},
+	Created:     &created,
+}
+
+var Dave = db.Person{
+	Uuid:        "demo_dave_uuid",
+	OwnerPubKey: "03da7e4c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d",
+	OwnerAlias:  "Dave",
+	UniqueName:  "dave",
+	Description: "Rust developer, mostly node and backup work",
+	Tags:        pq.StringArray{"rust"},
+	Created:     &created,
+}
+
+var People = []db.Person{Alice, Bob, Carol, Dave}
+
+var Tribes = []db.Tribe{
+	{
+		UUID:        "demo_tribe_developers",
+		OwnerPubKey: Alice.OwnerPubKey,
+		OwnerAlias:  Alice.OwnerAlias,
+		Name:        "Sphinx Developers",
+		UniqueName:  "sphinx-developers",
+		Description: "Builders of apps on the Lightning Network",
+		Tags:        pq.StringArray{"Bitcoin", "Lightning", "Tech"},
+		PriceToJoin: 10,
+		Created:     &created,
+		Updated:     &created,
+	},
+	{
+		UUID:            "demo_tribe_podcasts",
+		OwnerPubKey:     Alice.OwnerPubKey,
+		OwnerAlias:      Alice.OwnerAlias,
+		Name:            "Lightning Podcasts",
+		UniqueName:      "lightning-podcasts",
+		Description:     "Podcasts streamed with sats",
+		Tags:            pq.StringArray{"Podcast", "Music"},
+		PricePerMessage: 1,
+		FeedURL:         "https://feeds.podcastindex.org/pc20.xml",
+		FeedType:        0,
+		Created:         &created,
+		Updated:         &created,
+	},
+}
+
+var WalletWorkspace = db.Workspace{
+	Uuid:        "demo_workspace_wallet",
+	Name:        "Demo Lightning Wallet",
+	OwnerPubKey: Alice.OwnerPubKey,
+	Description: "A mobile lightning wallet",
+	Mission:     "Make lightning payments as easy as sending a message",
+	Tactics:     "Small bounties for every step of the wallet",
+	Website:     "https://example.com/wallet",
+	Github:      "https://github.com/stakwork/sphinx-tribes",
+	Show:        true,
+	Created:     &created,
+	Updated:     &created,
+}
+
+var PodcastWorkspace = db.Workspace{
+	Uuid:        "demo_workspace_podcast",
+	Name:        "Demo Podcast Index",
+	OwnerPubKey: Alice.OwnerPubKey,
+	Description: "Search and stream podcast episodes",
+	Mission:     "Every episode one search away",
+	Show:        true,
+	Created:     &created,
+	Updated:     &created,
+}
+
+var Workspaces = []db.Workspace{WalletWorkspace, PodcastWorkspace}
+
+var Members = []db.WorkspaceUsers{
+	{WorkspaceUuid: WalletWorkspace.Uuid, OwnerPubKey: Bob.OwnerPubKey, Created: &created, Updated: &created},
+	{WorkspaceUuid: WalletWorkspace.Uuid, OwnerPubKey: Carol.OwnerPubKey, Created: &created, Updated: &created},
+	{WorkspaceUuid: WalletWorkspace.Uuid, OwnerPubKey: Dave.OwnerPubKey, Created: &created, Updated: &created},
+	{WorkspaceUuid: PodcastWorkspace.Uuid, OwnerPubKey: Carol.OwnerPubKey, Created: &created, Updated: &created},
+}
+
+var Budgets = []db.NewBountyBudget{
+	{WorkspaceUuid: WalletWorkspace.Uuid, TotalBudget: 500000, Created: &created, Updated: &created},
+	{WorkspaceUuid: PodcastWorkspace.Uuid, TotalBudget: 200000, Created: &created, Updated: &created},
+}
+
+var ScanningFeature = db.WorkspaceFeatures{
+	Uuid:          "demo_feature_scanning",
+	WorkspaceUuid: WalletWorkspace.Uuid,
+	Name:          "Invoice scanning",
+	Brief:         "Pay an invoice by pointing the camera at its QR code",
+	Requirements:  "Scan BOLT11 invoices and lightning addresses, confirm the amount before paying",
+	Priority:      1,
+	CreatedBy:     Alice.OwnerPubKey,
+	UpdatedBy:     Alice.OwnerPubKey,
+}
+
+var BackupFeature = db.WorkspaceFeatures{
+	Uuid:          "demo_feature_backup",
+	WorkspaceUuid: WalletWorkspace.Uuid,
+	Name:          "Backup and restore",
+	Brief:         "Restore the wallet on a new phone from an encrypted backup",
+	Priority:      2,
+	CreatedBy:     Alice.OwnerPubKey,
+	UpdatedBy:     Alice.OwnerPubKey,
+}
+
+var SearchFeature = db.WorkspaceFeatures{
+	Uuid:          "demo_feature_search",
+	WorkspaceUuid: PodcastWorkspace.Uuid,
+	Name:          "Episode search",
+	Brief:         "Search episodes by title, host and transcript",
+	Priority:      1,
+	CreatedBy:     Alice.OwnerPubKey,
+	UpdatedBy:     Alice.OwnerPubKey,
+}
+
+var Features = []db.WorkspaceFeatures{ScanningFeature, BackupFeature, SearchFeature}
+
+var DesignPhase = db.FeaturePhase{
+	Uuid:        "demo_phase_design",
+	FeatureUuid: ScanningFeature.Uuid,
+	Name:        "Design",
+	Priority:    1,
+	CreatedBy:   Alice.OwnerPubKey,
+	UpdatedBy:   Alice.OwnerPubKey,
+}
+
+var BuildPhase = db.FeaturePhase{
+	Uuid:        "demo_phase_build",
+	FeatureUuid: ScanningFeature.Uuid,
+	Name:        "Build",
+	Priority:    2,
+	CreatedBy:   Alice.OwnerPubKey,
+	UpdatedBy:   Alice.OwnerPubKey,
+}
+
+var Phases = []db.FeaturePhase{DesignPhase, BuildPhase}
+
+var Stories = []db.FeatureStory{
+	{Uuid: "demo_story_scan", FeatureUuid: ScanningFeature.Uuid, Description: "As a user I can scan an invoice to pay it", Priority: 1, CreatedBy: Alice.OwnerPubKey, UpdatedBy: Alice.OwnerPubKey},
+	{Uuid: "demo_story_confirm", FeatureUuid: ScanningFeature.Uuid, Description: "As a user I see the amount and memo before I pay", Priority: 2, CreatedBy: Alice.OwnerPubKey, UpdatedBy: Alice.OwnerPubKey},
+	{Uuid: "demo_story_restore", FeatureUuid: BackupFeature.Uuid, Description: "As a user I can restore my wallet with my backup phrase", Priority: 1, CreatedBy: Alice.OwnerPubKey, UpdatedBy: Alice.OwnerPubKey},
+}
+
+// bountyCreated keeps the created timestamps of the bounties fixed, they are
+// how a bounty is found again when seeding twice
+func bountyCreated(n int64) int64 {
+	return created.Unix() + n
+}
+
+var Bounties = []db.NewBounty{
+	{
+		Type:            "coding_task",
+		Title:           "Design the scan screen",
+		Description:     "Figma mockups of the camera view, the confirm sheet and the error states",
+		WorkspaceUuid:   WalletWorkspace.Uuid,
+		PhaseUuid:       DesignPhase.Uuid,
+		OwnerID:         Alice.OwnerPubKey,
+		Assignee:        Carol.OwnerPubKey,
+		Price:           25000,
+		EstimatedHours:  6,
+		Show:            true,
+		Completed:       true,
+		Paid:            true,
+		CodingLanguages: pq.StringArray{"Figma"},
+		Created:         bountyCreated(1),
+	},
+	{
+		Type:            "coding_task",
+		Title:           "Decode BOLT11 invoices from QR codes",
+		Description:     "Parse the scanned payload and show the amount, memo and expiry",
+		WorkspaceUuid:   WalletWorkspace.Uuid,
+		PhaseUuid:       BuildPhase.Uuid,
+		OwnerID:         Alice.OwnerPubKey,
+		Assignee:        Bob.OwnerPubKey,
+		Price:           60000,
+		EstimatedHours:  12,
+		Show:            true,
+		CodingLanguages: pq.StringArray{"Typescript"},
+		Created:         bountyCreated(2),
+	},
+	{
+		Type:            "coding_task",
+		Title:           "Support lightning addresses in the scanner",
+		Description:     "Resolve user@domain addresses through LNURL-pay",
+		WorkspaceUuid:   WalletWorkspace.Uuid,
+		PhaseUuid:       BuildPhase.Uuid,
+		OwnerID:         Alice.OwnerPubKey,
+		Price:           40000,
+		EstimatedHours:  8,
+		Show:            true,
+		CodingLanguages: pq.StringArray{"Typescript", "Golang"},
+		Created:         bountyCreated(3),
+	},
+	{
+		Type:            "coding_task",
+		Title:           "Encrypt static channel backups",
+		Description:     "Encrypt the channel backup with the wallet seed before it is uploaded",
+		WorkspaceUuid:   WalletWorkspace.Uuid,
+		OwnerID:         Alice.OwnerPubKey,
+		Assignee:        Dave.OwnerPubKey,
+		Price:           80000,
+		EstimatedHours:  20,
+		Show:            true,
+		Completed:       true,
+		CodingLanguages: pq.StringArray{"Rust"},
+		Created:         bountyCreated(4),
+	},
+	{
+		Type:            "coding_task",
+		Title:           "Index episode transcripts",
+		Description:     "Store transcripts in the search index so episodes are found by what was said",
+		WorkspaceUuid:   PodcastWorkspace.Uuid,
+		OwnerID:         Alice.OwnerPubKey,
+		Price:           50000,
+		EstimatedHours:  10,
+		Show:            true,
+		CodingLanguages: pq.StringArray{"Golang"},
+		Created:         bountyCreated(5),
+	},
+	{
+		Type:            "coding_task",
+		Title:           "Fix search on Safari",
+		Description:     "The search box loses focus after the first keystroke on Safari",
+		WorkspaceUuid:   PodcastWorkspace.Uuid,
+		OwnerID:         Alice.OwnerPubKey,
+		Assignee:        Bob.OwnerPubKey,
+		Price:           10000,
+		EstimatedHours:  2,
+		Show:            true,
+		Completed:       true,
+		Paid:            true,
+		CodingLanguages: pq.StringArray{"Typescript"},
+		Created:         bountyCreated(6),
+	},
+}
+
+// Seeded is what Seed saved, with the ids the database gave the rows
+type Seeded struct {
+	People     []db.Person            `json:"people"`
+	Tribes     []db.Tribe             `json:"tribes"`
+	Workspaces []db.Workspace         `json:"workspaces"`
+	Features   []db.WorkspaceFeatures `json:"features"`
+	Phases     []db.FeaturePhase      `json:"phases"`
+	Bounties   []db.NewBounty         `json:"bounties"`
+}
+
+// Seed saves the demo data, rows seeded before are updated
+func Seed(database db.Database) (Seeded, error) {
+	seeded := Seeded{}
+
+	for _, person := range People {
+		person.ID = database.GetPersonByPubkey(person.OwnerPubKey).ID
+		person, err := database.CreateOrEditPerson(person)
+		if err != nil {
+			return seeded, fmt.Errorf("could not seed %s: %w", person.OwnerAlias, err)
+		}
+		seeded.People = append(seeded.People, person)
+	}
+
+	for _, tribe := range Tribes {
+		tribe, err := database.CreateOrEditTribe(tribe)
+		if err != nil {
+			return seeded, fmt.Errorf("could not seed tribe %s: %w", tribe.Name, err)
+		}
+		seeded.Tribes = append(seeded.Tribes, tribe)
+	}
+
+	for _, workspace := range Workspaces {
+		workspace, err := database.CreateOrEditWorkspace(workspace)
+		if err != nil {
+			return seeded, fmt.Errorf("could not seed workspace %s: %w", workspace.Name, err)
+		}
+		seeded.Workspaces = append(seeded.Workspaces, workspace)
+	}
+	for _, member := range Members {
+		if database.GetWorkspaceUser(member.OwnerPubKey, member.WorkspaceUuid).ID == 0 {
+			database.CreateWorkspaceUser(member)
+		}
+	}
+	for _, budget := range Budgets {
+		if database.GetWorkspaceBudget(budget.WorkspaceUuid).WorkspaceUuid == "" {
+			database.CreateWorkspaceBudget(budget)
+		}
+	}
+
+	for _, feature := range Features {
+		feature, err := database.CreateOrEditFeature(feature)
+		if err != nil {
+			return seeded, fmt.Errorf("could not seed feature %s: %w", feature.Name, err)
+		}
+		seeded.Features = append(seeded.Features, feature)
+	}
+	for _, phase := range Phases {
+		phase, err := database.CreateOrEditFeaturePhase(phase)
+		if err != nil {
+			return seeded, fmt.Errorf("could not seed phase %s: %w", phase.Name, err)
+		}
+		seeded.Phases = append(seeded.Phases, phase)
+	}
+	for _, story := range Stories {
+		if _, err := database.CreateOrEditFeatureStory(story); err != nil {
+			return seeded, fmt.Errorf("could not seed story %s: %w", story.Uuid, err)
+		}
+	}
+
+	for _, bounty := range Bounties {
+		bounty, err := database.CreateOrEditBounty(bounty)
+		if err != nil {
+			return seeded, fmt.Errorf("could not seed bounty %s: %w", bounty.Title, err)
+		}
+		if bounty.ID == 0 {
+			saved, err := database.GetBountyByCreated(uint(bounty.Created))
+			if err != nil {
+				return seeded, fmt.Errorf("could not seed bounty %s: %w", bounty.Title, err)
+			}
+			bounty.ID = saved.ID
+		}
+		seeded.Bounties = append(seeded.Bounties, bounty)
+	}
+	return seeded, nil
+}
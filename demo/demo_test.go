@@ -0,0 +1,63 @@
+package demo
+
+import (
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSeed(t *testing.T) {
+	t.Run("should update the rows seeded before", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+
+		for i, person := range People {
+			pubkey, id := person.OwnerPubKey, uint(i+1)
+			mockDb.On("GetPersonByPubkey", pubkey).Return(db.Person{ID: id}).Once()
+			mockDb.On("CreateOrEditPerson", mock.MatchedBy(func(p db.Person) bool {
+				return p.OwnerPubKey == pubkey && p.ID == id
+			})).Return(func(p db.Person) (db.Person, error) { return p, nil }).Once()
+		}
+		mockDb.On("CreateOrEditTribe", mock.Anything).Return(func(tribe db.Tribe) (db.Tribe, error) { return tribe, nil }).Times(len(Tribes))
+		mockDb.On("CreateOrEditWorkspace", mock.Anything).Return(func(w db.Workspace) (db.Workspace, error) { return w, nil }).Times(len(Workspaces))
+		mockDb.On("GetWorkspaceUser", mock.Anything, mock.Anything).Return(db.WorkspaceUsers{ID: 1}).Times(len(Members))
+		mockDb.On("GetWorkspaceBudget", mock.Anything).Return(func(uuid string) db.NewBountyBudget {
+			return db.NewBountyBudget{WorkspaceUuid: uuid}
+		}).Times(len(Budgets))
+		mockDb.On("CreateOrEditFeature", mock.Anything).Return(func(f db.WorkspaceFeatures) (db.WorkspaceFeatures, error) { return f, nil }).Times(len(Features))
+		mockDb.On("CreateOrEditFeaturePhase", mock.Anything).Return(func(p db.FeaturePhase) (db.FeaturePhase, error) { return p, nil }).Times(len(Phases))
+		mockDb.On("CreateOrEditFeatureStory", mock.Anything).Return(func(s db.FeatureStory) (db.FeatureStory, error) { return s, nil }).Times(len(Stories))
+		mockDb.On("CreateOrEditBounty", mock.Anything).Return(func(b db.NewBounty) (db.NewBounty, error) { return b, nil }).Times(len(Bounties))
+		mockDb.On("GetBountyByCreated", mock.Anything).Return(func(created uint) (db.NewBounty, error) {
+			return db.NewBounty{ID: created - uint(bountyCreated(0))}, nil
+		}).Times(len(Bounties))
+
+		seeded, err := Seed(mockDb)
+		assert.NoError(t, err)
+		assert.Len(t, seeded.People, len(People))
+		assert.Len(t, seeded.Bounties, len(Bounties))
+		for i, bounty := range seeded.Bounties {
+			assert.Equal(t, uint(i+1), bounty.ID)
+		}
+	})
+
+	t.Run("should keep every demo row inside the demo workspaces", func(t *testing.T) {
+		workspaces := map[string]bool{}
+		for _, workspace := range Workspaces {
+			workspaces[workspace.Uuid] = true
+		}
+		phases := map[string]bool{}
+		for _, phase := range Phases {
+			phases[phase.Uuid] = true
+		}
+		for _, bounty := range Bounties {
+			assert.True(t, workspaces[bounty.WorkspaceUuid], bounty.Title)
+			assert.True(t, bounty.PhaseUuid == "" || phases[bounty.PhaseUuid], bounty.Title)
+		}
+		for _, feature := range Features {
+			assert.True(t, workspaces[feature.WorkspaceUuid], feature.Name)
+		}
+	})
+}
@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	activityPubContentType = "application/activity+json"
+	activityPubContext     = "https://www.w3.org/ns/activitystreams"
+	activityPubPublic      = "https://www.w3.org/ns/activitystreams#Public"
+	activityPubOutboxLimit = 20
+	activityPubInboxLimit  = 1 << 20
+)
+
+type activityPubHandler struct {
+	db db.Database
+}
+
+func NewActivityPubHandler(database db.Database) *activityPubHandler {
+	return &activityPubHandler{db: database}
+}
+
+type webFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type,omitempty"`
+	Href string `json:"href"`
+}
+
+type webFingerResource struct {
+	Subject string          `json:"subject"`
+	Aliases []string        `json:"aliases"`
+	Links   []webFingerLink `json:"links"`
+}
+
+type activityPubActor struct {
+	Context           string            `json:"@context"`
+	Id                string            `json:"id"`
+	Type              string            `json:"type"`
+	PreferredUsername string            `json:"preferredUsername"`
+	Name              string            `json:"name"`
+	Summary           string            `json:"summary"`
+	Url               string            `json:"url"`
+	Icon              *activityPubImage `json:"icon,omitempty"`
+	Inbox             string            `json:"inbox"`
+	Outbox            string            `json:"outbox"`
+	Followers         string            `json:"followers"`
+}
+
+type activityPubImage struct {
+	Type string `json:"type"`
+	Url  string `json:"url"`
+}
+
+type activityPubTag struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type activityPubNote struct {
+	Id           string           `json:"id"`
+	Type         string           `json:"type"`
+	AttributedTo string           `json:"attributedTo"`
+	Content      string           `json:"content"`
+	Url          string           `json:"url"`
+	Published    string           `json:"published"`
+	To           []string         `json:"to"`
+	Cc           []string         `json:"cc"`
+	Tag          []activityPubTag `json:"tag"`
+}
+
+type activityPubActivity struct {
+	Id        string          `json:"id"`
+	Type      string          `json:"type"`
+	Actor     string          `json:"actor"`
+	Published string          `json:"published"`
+	To        []string        `json:"to"`
+	Cc        []string        `json:"cc"`
+	Object    activityPubNote `json:"object"`
+}
+
+type activityPubCollection struct {
+	Context      string                `json:"@context"`
+	Id           string                `json:"id"`
+	Type         string                `json:"type"`
+	TotalItems   int64                 `json:"totalItems"`
+	OrderedItems []activityPubActivity `json:"orderedItems"`
+}
+
+func activityPubActorUrl(uuid string) string {
+	return fmt.Sprintf("%s/ap/workspaces/%s", config.Host, uuid)
+}
+
+// activityPubDomain is the domain of the acct: handles, the host of config.Host
+func activityPubDomain() string {
+	host, err := url.Parse(config.Host)
+	if err != nil || host.Host == "" {
+		return config.Host
+	}
+	return host.Host
+}
+
+// activityPubWorkspace is the workspace of an actor, ok is false when it does not
+// exist or has not turned on activitypub in its settings
+func (ah *activityPubHandler) activityPubWorkspace(uuid string) (db.Workspace, bool) {
+	workspace := ah.db.GetWorkspaceByUuid(uuid)
+	if workspace.Uuid == "" || workspace.Deleted {
+		return workspace, false
+	}
+	return workspace, ah.db.GetWorkspaceSettings(uuid).ActivityPub
+}
+
+func writeActivityPub(w http.ResponseWriter, contentType string, body interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(body)
+}
+
+// WebFinger resolves acct:<workspace uuid>@<domain> to the actor of the workspace
+func (ah *activityPubHandler) WebFinger(w http.ResponseWriter, r *http.Request) {
+	resource := r.URL.Query().Get("resource")
+	account := strings.TrimPrefix(resource, "acct:")
+	uuid, domain, found := strings.Cut(account, "@")
+	if resource == account || !found || uuid == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("resource must be an acct: handle")
+		return
+	}
+
+	if domain != activityPubDomain() {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Account not found")
+		return
+	}
+	if _, ok := ah.activityPubWorkspace(uuid); !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Account not found")
+		return
+	}
+
+	actor := activityPubActorUrl(uuid)
+	profile := fmt.Sprintf("%s/workspace/%s", config.Host, uuid)
+	writeActivityPub(w, "application/jrd+json", webFingerResource{
+		Subject: resource,
+		Aliases: []string{actor, profile},
+		Links: []webFingerLink{
+			{Rel: "self", Type: activityPubContentType, Href: actor},
+			{Rel: "http://webfinger.net/rel/profile-page", Type: "text/html", Href: profile},
+		},
+	})
+}
+
+// GetActor is the ActivityPub actor of a workspace
+func (ah *activityPubHandler) GetActor(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+	workspace, ok := ah.activityPubWorkspace(uuid)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Actor not found")
+		return
+	}
+
+	actor := activityPubActorUrl(uuid)
+	body := activityPubActor{
+		Context:           activityPubContext,
+		Id:                actor,
+		Type:              "Organization",
+		PreferredUsername: workspace.Uuid,
+		Name:              workspace.Name,
+		Summary:           html.EscapeString(workspace.Description),
+		Url:               fmt.Sprintf("%s/workspace/%s", config.Host, uuid),
+		Inbox:             actor + "/inbox",
+		Outbox:            actor + "/outbox",
+		Followers:         actor + "/followers",
+	}
+	if workspace.Img != "" {
+		body.Icon = &activityPubImage{Type: "Image", Url: workspace.Img}
+	}
+	writeActivityPub(w, activityPubContentType, body)
+}
+
+// GetOutbox publishes the newest open public bounties of a workspace as Notes
+func (ah *activityPubHandler) GetOutbox(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+	workspace, ok := ah.activityPubWorkspace(uuid)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Actor not found")
+		return
+	}
+
+	showcase, err := ah.db.GetWorkspaceShowcase(workspace, activityPubOutboxLimit)
+	if err != nil {
+		fmt.Println("[activitypub] could not get bounties", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not get the outbox")
+		return
+	}
+
+	actor := activityPubActorUrl(uuid)
+	outbox := activityPubCollection{
+		Context:      activityPubContext,
+		Id:           actor + "/outbox",
+		Type:         "OrderedCollection",
+		TotalItems:   showcase.OpenBountiesCount,
+		OrderedItems: []activityPubActivity{},
+	}
+	for _, bounty := range showcase.OpenBounties {
+		outbox.OrderedItems = append(outbox.OrderedItems, bountyActivity(actor, bounty))
+	}
+	writeActivityPub(w, activityPubContentType, outbox)
+}
+
+// bountyActivity is the Create activity of the Note announcing a bounty
+func bountyActivity(actor string, bounty db.ShowcaseBounty) activityPubActivity {
+	link := fmt.Sprintf("%s/bounty/%d", config.Host, bounty.ID)
+	published := time.Unix(bounty.Created, 0).UTC().Format(time.RFC3339)
+	to := []string{activityPubPublic}
+	cc := []string{actor + "/followers"}
+
+	content := fmt.Sprintf("<p>New bounty: %s</p><p>%d sats</p>", html.EscapeString(bounty.Title), bounty.Price)
+	tags := []activityPubTag{}
+	if len(bounty.CodingLanguages) > 0 {
+		hashtags := []string{}
+		for _, language := range bounty.CodingLanguages {
+			hashtag := "#" + strings.ReplaceAll(language, " ", "")
+			hashtags = append(hashtags, html.EscapeString(hashtag))
+			tags = append(tags, activityPubTag{Type: "Hashtag", Name: hashtag})
+		}
+		content += fmt.Sprintf("<p>%s</p>", strings.Join(hashtags, " "))
+	}
+	content += fmt.Sprintf(`<p><a href="%s">%s</a></p>`, link, link)
+
+	return activityPubActivity{
+		Id:        fmt.Sprintf("%s/bounties/%d/create", actor, bounty.ID),
+		Type:      "Create",
+		Actor:     actor,
+		Published: published,
+		To:        to,
+		Cc:        cc,
+		Object: activityPubNote{
+			Id:           link,
+			Type:         "Note",
+			AttributedTo: actor,
+			Content:      content,
+			Url:          link,
+			Published:    published,
+			To:           to,
+			Cc:           cc,
+			Tag:          tags,
+		},
+	}
+}
+
+// GetFollowers is a stub, follows are not stored yet so the collection is empty
+func (ah *activityPubHandler) GetFollowers(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+	if _, ok := ah.activityPubWorkspace(uuid); !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Actor not found")
+		return
+	}
+
+	writeActivityPub(w, activityPubContentType, activityPubCollection{
+		Context:      activityPubContext,
+		Id:           activityPubActorUrl(uuid) + "/followers",
+		Type:         "OrderedCollection",
+		OrderedItems: []activityPubActivity{},
+	})
+}
+
+// PostInbox is a stub that accepts activities without acting on them, servers
+// following a workspace read its outbox
+func (ah *activityPubHandler) PostInbox(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+	if _, ok := ah.activityPubWorkspace(uuid); !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Actor not found")
+		return
+	}
+
+	activity := struct {
+		Type  string `json:"type"`
+		Actor string `json:"actor"`
+	}{}
+	body, err := io.ReadAll(io.LimitReader(r.Body, activityPubInboxLimit))
+	r.Body.Close()
+	if err != nil || json.Unmarshal(body, &activity) != nil || activity.Type == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid activity")
+		return
+	}
+
+	fmt.Println("[activitypub] received", activity.Type, "from", activity.Actor, "for", uuid)
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/lib/pq"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivityPub(t *testing.T) {
+	host := config.Host
+	config.Host = "https://community.sphinx.chat"
+	defer func() { config.Host = host }()
+
+	mockDb := dbMocks.NewDatabase(t)
+	aHandler := NewActivityPubHandler(mockDb)
+
+	workspace := db.Workspace{Uuid: "workspace_uuid", Name: "Sphinx", Description: "Lightning & chat", Img: "https://img.sphinx.chat/logo.png"}
+	enabled := db.DefaultWorkspaceSettings(workspace.Uuid)
+	enabled.ActivityPub = true
+
+	newRequest := func(method string, path string, uuid string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", uuid)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), method, path, strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should not have an actor for workspaces that did not turn it on", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(db.DefaultWorkspaceSettings(workspace.Uuid)).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetActor).ServeHTTP(rr, newRequest(http.MethodGet, "/ap/workspaces/workspace_uuid", workspace.Uuid, ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should resolve the acct handle of a workspace", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(enabled).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.WebFinger).ServeHTTP(rr, newRequest(http.MethodGet, "/.well-known/webfinger?resource=acct:workspace_uuid@community.sphinx.chat", "", ""))
+
+		resource := webFingerResource{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resource))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/jrd+json", rr.Header().Get("Content-Type"))
+		assert.Equal(t, webFingerLink{Rel: "self", Type: activityPubContentType, Href: "https://community.sphinx.chat/ap/workspaces/workspace_uuid"}, resource.Links[0])
+	})
+
+	t.Run("should reject handles of other domains and other resources", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.WebFinger).ServeHTTP(rr, newRequest(http.MethodGet, "/.well-known/webfinger?resource=acct:workspace_uuid@mastodon.social", "", ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(aHandler.WebFinger).ServeHTTP(rr, newRequest(http.MethodGet, "/.well-known/webfinger?resource=https://community.sphinx.chat", "", ""))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return the actor with its inbox and outbox", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(enabled).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetActor).ServeHTTP(rr, newRequest(http.MethodGet, "/ap/workspaces/workspace_uuid", workspace.Uuid, ""))
+
+		actor := activityPubActor{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actor))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, activityPubContentType, rr.Header().Get("Content-Type"))
+		assert.Equal(t, "Sphinx", actor.Name)
+		assert.Equal(t, "Lightning &amp; chat", actor.Summary)
+		assert.Equal(t, "https://community.sphinx.chat/ap/workspaces/workspace_uuid/inbox", actor.Inbox)
+		assert.Equal(t, "https://community.sphinx.chat/ap/workspaces/workspace_uuid/outbox", actor.Outbox)
+	})
+
+	t.Run("should publish the open public bounties as notes", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(enabled).Once()
+		mockDb.On("GetWorkspaceShowcase", workspace, activityPubOutboxLimit).Return(db.WorkspaceShowcase{
+			OpenBounties: []db.ShowcaseBounty{
+				{ID: 12, Title: "Fix <script> escaping", Price: 25000, CodingLanguages: pq.StringArray{"Go", "Type Script"}, Created: 1718020800},
+			},
+			OpenBountiesCount: 1,
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.GetOutbox).ServeHTTP(rr, newRequest(http.MethodGet, "/ap/workspaces/workspace_uuid/outbox", workspace.Uuid, ""))
+
+		outbox := activityPubCollection{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &outbox))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, int64(1), outbox.TotalItems)
+
+		activity := outbox.OrderedItems[0]
+		assert.Equal(t, "Create", activity.Type)
+		assert.Equal(t, "Note", activity.Object.Type)
+		assert.Equal(t, "https://community.sphinx.chat/bounty/12", activity.Object.Id)
+		assert.Equal(t, "2024-06-10T12:00:00Z", activity.Object.Published)
+		assert.Equal(t, []string{activityPubPublic}, activity.Object.To)
+		assert.Contains(t, activity.Object.Content, "New bounty: Fix &lt;script&gt; escaping")
+		assert.Contains(t, activity.Object.Content, "25000 sats")
+		assert.Equal(t, []activityPubTag{{Type: "Hashtag", Name: "#Go"}, {Type: "Hashtag", Name: "#TypeScript"}}, activity.Object.Tag)
+	})
+
+	t.Run("should accept activities in the inbox", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Twice()
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(enabled).Twice()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(aHandler.PostInbox).ServeHTTP(rr, newRequest(http.MethodPost, "/ap/workspaces/workspace_uuid/inbox", workspace.Uuid,
+			`{"type": "Follow", "actor": "https://mastodon.social/users/alice", "object": "https://community.sphinx.chat/ap/workspaces/workspace_uuid"}`))
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(aHandler.PostInbox).ServeHTTP(rr, newRequest(http.MethodPost, "/ap/workspaces/workspace_uuid/inbox", workspace.Uuid, `not json`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// getArtifact returns the artifact of the uuid url param when pubkey belongs to its workspace
+func (hh *hivechatHandler) getArtifact(w http.ResponseWriter, r *http.Request, pubkey string) (db.Artifact, bool) {
+	artifact, err := hh.db.GetArtifactByUuid(chi.URLParam(r, "uuid"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Artifact not found")
+		return artifact, false
+	}
+	if !hh.isWorkspaceMember(pubkey, artifact.WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to this artifact")
+		return artifact, false
+	}
+	return artifact, true
+}
+
+// validateArtifactLinks checks that the chat and feature an artifact points to
+// are in its workspace
+func (hh *hivechatHandler) validateArtifactLinks(artifact db.Artifact) error {
+	if artifact.ChatId != "" {
+		chat, err := hh.db.GetChatByUuid(artifact.ChatId)
+		if err != nil || chat.WorkspaceUuid != artifact.WorkspaceUuid {
+			return errors.New("chat is not in the artifact's workspace")
+		}
+	}
+	if artifact.FeatureUuid != "" {
+		if hh.db.GetFeatureByUuid(artifact.FeatureUuid).WorkspaceUuid != artifact.WorkspaceUuid {
+			return errors.New("feature is not in the artifact's workspace")
+		}
+	}
+	return nil
+}
+
+func (hh *hivechatHandler) GetArtifacts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[artifacts] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := db.ArtifactFilter{
+		WorkspaceUuid: query.Get("workspace_uuid"),
+		ChatId:        query.Get("chat_id"),
+		MessageId:     query.Get("message_id"),
+		FeatureUuid:   query.Get("feature_uuid"),
+		TicketUuid:    query.Get("ticket_uuid"),
+		Type:          db.ArtifactType(query.Get("type")),
+	}
+	if filter.Type != "" && !db.IsArtifactType(filter.Type) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("unknown artifact type %q", filter.Type)})
+		return
+	}
+	if !hh.isWorkspaceMember(pubKeyFromAuth, filter.WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to this workspace")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(hh.db.GetArtifacts(filter))
+}
+
+func (hh *hivechatHandler) CreateArtifact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[artifacts] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	artifact := db.Artifact{}
+	if err := json.NewDecoder(r.Body).Decode(&artifact); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	r.Body.Close()
+
+	if !db.IsArtifactType(artifact.Type) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("unknown artifact type %q", artifact.Type)})
+		return
+	}
+	if !hh.isWorkspaceMember(pubKeyFromAuth, artifact.WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to this workspace")
+		return
+	}
+	if err := hh.validateArtifactLinks(artifact); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	artifact.CreatedBy = pubKeyFromAuth
+	artifact, err := hh.db.CreateArtifact(artifact)
+	if err != nil {
+		fmt.Println("[artifacts] could not create artifact", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(artifact)
+}
+
+func (hh *hivechatHandler) GetArtifact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[artifacts] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	artifact, ok := hh.getArtifact(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(artifact)
+}
+
+// UpdateArtifact edits an artifact or links it to a feature or ticket, edits of
+// its content are saved as a new version
+func (hh *hivechatHandler) UpdateArtifact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[artifacts] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	current, ok := hh.getArtifact(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	// fields left out of the body keep their current value
+	artifact := current
+	if err := json.NewDecoder(r.Body).Decode(&artifact); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	r.Body.Close()
+
+	artifact.Uuid = current.Uuid
+	artifact.WorkspaceUuid = current.WorkspaceUuid
+	artifact.ChatId = current.ChatId
+	if err := hh.validateArtifactLinks(artifact); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	artifact, err := hh.db.UpdateArtifact(artifact, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[artifacts] could not update artifact", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(artifact)
+}
+
+func (hh *hivechatHandler) GetArtifactVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[artifacts] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	artifact, ok := hh.getArtifact(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(hh.db.GetArtifactVersions(artifact.Uuid))
+}
+
+func (hh *hivechatHandler) DeleteArtifact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[artifacts] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	artifact, ok := hh.getArtifact(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	if err := hh.db.DeleteArtifact(artifact.Uuid); err != nil {
+		fmt.Println("[artifacts] could not delete artifact", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(artifact.Uuid)
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+type liquidAssetService struct {
+	httpClient HttpClient
+}
+
+func NewAssetService(httpClient HttpClient) AssetService {
+	return &liquidAssetService{httpClient: httpClient}
+}
+
+func (s *liquidAssetService) assetServiceUrl() string {
+	if config.AssetServiceUrl == "" {
+		return "https://liquid.sphinx.chat"
+	}
+	return config.AssetServiceUrl
+}
+
+func (s *liquidAssetService) GetBalances(pubkey string) ([]db.AssetBalanceData, error) {
+	testMode, err := strconv.ParseBool(os.Getenv("TEST_MODE"))
+	if err != nil {
+		testMode = false
+	}
+
+	url := os.Getenv(liquidTestModeUrl)
+	if !testMode || url == "" {
+		url = s.assetServiceUrl() + "/balances?pubkey=" + pubkey
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		fmt.Println("GET error:", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var r db.AssetResponse
+	body, err := io.ReadAll(resp.Body)
+	err = json.Unmarshal(body, &r)
+	if err != nil {
+		fmt.Println("json unmarshall error", err)
+		return nil, err
+	}
+
+	return r.Balances, nil
+}
+
+func (s *liquidAssetService) GetAssets(pubkey string) ([]db.AssetListData, error) {
+	url := os.Getenv("ASSET_LIST_URL")
+	if url == "" {
+		url = s.assetServiceUrl() + "/assets"
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url+"?pubkey="+pubkey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		fmt.Println("GET error:", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var r []db.AssetListData
+	body, err := io.ReadAll(resp.Body)
+	err = json.Unmarshal(body, &r)
+	if err != nil {
+		fmt.Println("json unmarshall error", err)
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// IssueBadge mints amount of the badge asset to pubkey and returns the transaction id
+func (s *liquidAssetService) IssueBadge(badge string, pubkey string, amount uint) (string, error) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"asset":  badge,
+		"pubkey": pubkey,
+		"amount": amount,
+	})
+
+	req, err := http.NewRequest(http.MethodPost, s.assetServiceUrl()+"/transfer", bytes.NewBuffer(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-admin-token", config.AssetServiceToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	result := struct {
+		TxId  string `json:"txid"`
+		Error string `json:"error"`
+	}{}
+	respBody, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(respBody, &result)
+
+	if resp.StatusCode != http.StatusOK {
+		if result.Error == "" {
+			result.Error = fmt.Sprintf("asset service returned status %d", resp.StatusCode)
+		}
+		return "", errors.New(result.Error)
+	}
+	return result.TxId, nil
+}
@@ -2,14 +2,20 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/form3tech-oss/jwt-go"
+	"github.com/go-chi/chi"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
 )
 
 type authHandler struct {
@@ -31,7 +37,7 @@ func GetAdminPubkeys(w http.ResponseWriter, r *http.Request) {
 		Pubkeys []string `json:"pubkeys"`
 	}
 	pubkeys := PubKeysReturn{
-		Pubkeys: config.SuperAdmins,
+		Pubkeys: auth.SuperAdmins(),
 	}
 	json.NewEncoder(w).Encode(pubkeys)
 	w.WriteHeader(http.StatusOK)
@@ -93,6 +99,212 @@ func (ah *authHandler) GetConnectionCode(w http.ResponseWriter, _ *http.Request)
 	json.NewEncoder(w).Encode(connectionCode)
 }
 
+// SuperAdminChangeTTL is how long a requested super admin change can be confirmed
+const SuperAdminChangeTTL = 10 * time.Minute
+
+func (ah *authHandler) GetSuperAdmins(w http.ResponseWriter, r *http.Request) {
+	admins := []db.SuperAdminInfo{}
+	for _, pubkey := range config.SuperAdmins {
+		if pubkey == "" || pubkey == config.AdminDevFreePass {
+			continue
+		}
+		admins = append(admins, db.SuperAdminInfo{Pubkey: pubkey, Source: "env"})
+	}
+	for _, admin := range ah.db.GetSuperAdmins() {
+		if auth.IsEnvSuperAdmin(admin.Pubkey) {
+			continue
+		}
+		admins = append(admins, db.SuperAdminInfo{
+			Pubkey:  admin.Pubkey,
+			Source:  "db",
+			AddedBy: admin.AddedBy,
+			Created: admin.Created,
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(admins)
+}
+
+// AddSuperAdmin requests a new super admin, it is only added once the change is confirmed
+func (ah *authHandler) AddSuperAdmin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[auth] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.SuperAdminChangeRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[auth]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	pubkey := strings.TrimSpace(request.Pubkey)
+	if pubkey == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("pubkey is required")
+		return
+	}
+	if auth.AdminCheck(pubkey) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("pubkey is already a super admin")
+		return
+	}
+
+	ah.requestSuperAdminChange(w, db.SuperAdminAdd, pubkey, pubKeyFromAuth)
+}
+
+// RemoveSuperAdmin requests the removal of a super admin that was added through the api
+func (ah *authHandler) RemoveSuperAdmin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	pubkey := chi.URLParam(r, "pubkey")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[auth] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if pubkey == pubKeyFromAuth {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("cannot remove yourself as a super admin")
+		return
+	}
+	if auth.IsEnvSuperAdmin(pubkey) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("super admin is set in the env and cannot be removed here")
+		return
+	}
+
+	stored := false
+	for _, admin := range ah.db.GetSuperAdminPubkeys() {
+		if admin == pubkey {
+			stored = true
+			break
+		}
+	}
+	if !stored {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("super admin not found")
+		return
+	}
+
+	ah.requestSuperAdminChange(w, db.SuperAdminRemove, pubkey, pubKeyFromAuth)
+}
+
+func (ah *authHandler) requestSuperAdminChange(w http.ResponseWriter, action db.SuperAdminChangeAction, pubkey string, requestedBy string) {
+	now := time.Now()
+	expires := now.Add(SuperAdminChangeTTL)
+
+	change, err := ah.db.CreateSuperAdminChange(db.SuperAdminChange{
+		Action:           action,
+		Pubkey:           pubkey,
+		RequestedBy:      requestedBy,
+		ConfirmationCode: utils.GetRandomToken(32),
+		Expires:          &expires,
+		Created:          &now,
+	})
+	if err != nil {
+		fmt.Println("[auth] could not request super admin change", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("could not request the change")
+		return
+	}
+
+	// the code is for the other super admins, the requester can't confirm the change
+	change.ConfirmationCode = ""
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(change)
+}
+
+// GetPendingSuperAdminChanges lists the changes waiting for a confirmation, with
+// the codes of the changes other super admins requested
+func (ah *authHandler) GetPendingSuperAdminChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[auth] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	changes := ah.db.GetPendingSuperAdminChanges()
+	for i := range changes {
+		if changes[i].RequestedBy == pubKeyFromAuth {
+			changes[i].ConfirmationCode = ""
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(changes)
+}
+
+// ConfirmSuperAdminChange applies a requested super admin change with its confirmation code
+func (ah *authHandler) ConfirmSuperAdminChange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[auth] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	confirmation := db.SuperAdminConfirmation{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &confirmation)
+	}
+	if err != nil {
+		fmt.Println("[auth]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	change, err := ah.db.ConfirmSuperAdminChange(confirmation.ConfirmationCode, pubKeyFromAuth)
+	if errors.Is(err, db.ErrSuperAdminChangeNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if errors.Is(err, db.ErrSuperAdminChangeExpired) {
+		w.WriteHeader(http.StatusGone)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if errors.Is(err, db.ErrSuperAdminChangeSelfConfirm) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[auth] could not confirm super admin change", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("could not confirm the change")
+		return
+	}
+
+	auth.InvalidateSuperAdmins()
+	fmt.Printf("[auth] super admin %s %s, requested by %s and confirmed by %s\n", change.Pubkey, change.Action, change.RequestedBy, pubKeyFromAuth)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(change)
+}
+
 func GetLnurlAuth(w http.ResponseWriter, r *http.Request) {
 	socketKey := r.URL.Query().Get("socketKey")
 	socket, _ := db.Store.GetSocketConnections(socketKey)
@@ -171,7 +383,7 @@ func ReceiveLnAuthData(w http.ResponseWriter, r *http.Request) {
 		socket, err := db.Store.GetSocketConnections(k1[0:20])
 
 		if err == nil {
-			socket.Conn.WriteJSON(socketMsg)
+			websocket.SendMessage(socket, socketMsg)
 			db.Store.DeleteCache(k1[0:20])
 		} else {
 			fmt.Println("[auth] Socket Error", err)
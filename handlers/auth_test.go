@@ -15,10 +15,12 @@ import (
 	"time"
 
 	"github.com/form3tech-oss/jwt-go"
+	"github.com/go-chi/chi"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetAdminPubkeys(t *testing.T) {
@@ -307,3 +309,142 @@ func TestRefreshToken(t *testing.T) {
 		assert.EqualValues(t, person, fetchedPerson)
 	})
 }
+
+func TestSuperAdminManagement(t *testing.T) {
+	envAdmins, adminStrings := config.SuperAdmins, config.AdminStrings
+	defer func() {
+		config.SuperAdmins, config.AdminStrings = envAdmins, adminStrings
+		auth.InvalidateSuperAdmins()
+	}()
+	config.SuperAdmins = []string{"env_admin"}
+	config.AdminStrings = "env_admin"
+	auth.InvalidateSuperAdmins()
+
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "env_admin")
+
+	serve := func(handler http.HandlerFunc, method string, path string, body string) *httptest.ResponseRecorder {
+		r := chi.NewRouter()
+		r.Post("/admin/super_admins", handler)
+		r.Delete("/admin/super_admins/{pubkey}", handler)
+		r.Post("/admin/super_admins/confirm", handler)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(ctx, method, path, bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should list the env and stored super admins", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("GetSuperAdmins").Return([]db.SuperAdmin{{Pubkey: "db_admin", AddedBy: "env_admin"}}).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/admin/super_admins", nil)
+		http.HandlerFunc(aHandler.GetSuperAdmins).ServeHTTP(rr, req)
+
+		admins := []db.SuperAdminInfo{}
+		json.Unmarshal(rr.Body.Bytes(), &admins)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, []db.SuperAdminInfo{
+			{Pubkey: "env_admin", Source: "env"},
+			{Pubkey: "db_admin", Source: "db", AddedBy: "env_admin"},
+		}, admins)
+	})
+
+	t.Run("should request adding a super admin", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("CreateSuperAdminChange", mock.MatchedBy(func(change db.SuperAdminChange) bool {
+			return change.Action == db.SuperAdminAdd && change.Pubkey == "new_admin" && change.RequestedBy == "env_admin" &&
+				change.ConfirmationCode != "" && change.Expires != nil
+		})).Return(func(change db.SuperAdminChange) (db.SuperAdminChange, error) {
+			return change, nil
+		}).Once()
+
+		rr := serve(aHandler.AddSuperAdmin, http.MethodPost, "/admin/super_admins", `{"pubkey": "new_admin"}`)
+
+		change := db.SuperAdminChange{}
+		json.Unmarshal(rr.Body.Bytes(), &change)
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		assert.Equal(t, "new_admin", change.Pubkey)
+		assert.Empty(t, change.ConfirmationCode, "the requester should not get the code")
+	})
+
+	t.Run("should only show the codes of changes other super admins requested", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+		mockDb.On("GetPendingSuperAdminChanges").Return([]db.SuperAdminChange{
+			{ID: 1, Action: db.SuperAdminAdd, Pubkey: "new_admin", RequestedBy: "env_admin", ConfirmationCode: "own-code"},
+			{ID: 2, Action: db.SuperAdminRemove, Pubkey: "db_admin", RequestedBy: "other_admin", ConfirmationCode: "other-code"},
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/admin/super_admins/changes", nil)
+		http.HandlerFunc(aHandler.GetPendingSuperAdminChanges).ServeHTTP(rr, req)
+
+		changes := []db.SuperAdminChange{}
+		json.Unmarshal(rr.Body.Bytes(), &changes)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, changes, 2)
+		assert.Empty(t, changes[0].ConfirmationCode)
+		assert.Equal(t, "other-code", changes[1].ConfirmationCode)
+	})
+
+	t.Run("should not add an existing super admin", func(t *testing.T) {
+		aHandler := NewAuthHandler(mocks.NewDatabase(t))
+
+		rr := serve(aHandler.AddSuperAdmin, http.MethodPost, "/admin/super_admins", `{"pubkey": "env_admin"}`)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		rr = serve(aHandler.AddSuperAdmin, http.MethodPost, "/admin/super_admins", `{"pubkey": " "}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should only request removing stored super admins other than yourself", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+
+		rr := serve(aHandler.RemoveSuperAdmin, http.MethodDelete, "/admin/super_admins/env_admin", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		config.SuperAdmins = []string{"env_admin", "other_env_admin"}
+		rr = serve(aHandler.RemoveSuperAdmin, http.MethodDelete, "/admin/super_admins/other_env_admin", "")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		config.SuperAdmins = []string{"env_admin"}
+
+		mockDb.On("GetSuperAdminPubkeys").Return([]string{"db_admin"}).Twice()
+		rr = serve(aHandler.RemoveSuperAdmin, http.MethodDelete, "/admin/super_admins/unknown", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		mockDb.On("CreateSuperAdminChange", mock.MatchedBy(func(change db.SuperAdminChange) bool {
+			return change.Action == db.SuperAdminRemove && change.Pubkey == "db_admin"
+		})).Return(db.SuperAdminChange{Action: db.SuperAdminRemove, Pubkey: "db_admin"}, nil).Once()
+		rr = serve(aHandler.RemoveSuperAdmin, http.MethodDelete, "/admin/super_admins/db_admin", "")
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+	})
+
+	t.Run("should confirm a requested change", func(t *testing.T) {
+		mockDb := mocks.NewDatabase(t)
+		aHandler := NewAuthHandler(mockDb)
+
+		mockDb.On("ConfirmSuperAdminChange", "code", "env_admin").Return(db.SuperAdminChange{Action: db.SuperAdminAdd, Pubkey: "new_admin"}, nil).Once()
+		rr := serve(aHandler.ConfirmSuperAdminChange, http.MethodPost, "/admin/super_admins/confirm", `{"confirmation_code": "code"}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		mockDb.On("ConfirmSuperAdminChange", "unknown", "env_admin").Return(db.SuperAdminChange{}, db.ErrSuperAdminChangeNotFound).Once()
+		rr = serve(aHandler.ConfirmSuperAdminChange, http.MethodPost, "/admin/super_admins/confirm", `{"confirmation_code": "unknown"}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		mockDb.On("ConfirmSuperAdminChange", "expired", "env_admin").Return(db.SuperAdminChange{}, db.ErrSuperAdminChangeExpired).Once()
+		rr = serve(aHandler.ConfirmSuperAdminChange, http.MethodPost, "/admin/super_admins/confirm", `{"confirmation_code": "expired"}`)
+		assert.Equal(t, http.StatusGone, rr.Code)
+
+		mockDb.On("ConfirmSuperAdminChange", "own", "env_admin").Return(db.SuperAdminChange{}, db.ErrSuperAdminChangeSelfConfirm).Once()
+		rr = serve(aHandler.ConfirmSuperAdminChange, http.MethodPost, "/admin/super_admins/confirm", `{"confirmation_code": "own"}`)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
@@ -8,9 +8,11 @@ import (
 	"net/http"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
 )
 
 func GetWantedsHeader(w http.ResponseWriter, r *http.Request) {
@@ -44,6 +46,88 @@ func GetBountiesLeaderboard(w http.ResponseWriter, _ *http.Request) {
 	json.NewEncoder(w).Encode(leaderBoard)
 }
 
+// GetRankedBountiesLeaderboard returns the leaderboard for a time window with
+// optional workspace and language filters and the rank change since the previous window
+func GetRankedBountiesLeaderboard(w http.ResponseWriter, r *http.Request) {
+	params := utils.NewQueryParams(r)
+	window := db.LeaderboardWindow(params.OneOf("window", string(db.LeaderboardAll), string(db.LeaderboardWeek), string(db.LeaderboardMonth), string(db.LeaderboardAll)))
+	if err := params.Err(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	current, previous, _ := leaderboardWindowFilters(window, time.Now())
+	current.WorkspaceUuid = params.String("workspace", "")
+	current.Language = params.String("language", "")
+	previous.WorkspaceUuid = current.WorkspaceUuid
+	previous.Language = current.Language
+
+	leaderboard := rankLeaderboard(db.DB.GetLeaderboardTotals(current), db.DB.GetLeaderboardTotals(previous))
+	if len(leaderboard) > 0 {
+		pubkeys := []string{}
+		for _, entry := range leaderboard {
+			pubkeys = append(pubkeys, entry.OwnerPubkey)
+		}
+		people := db.DB.GetPeopleByPubkeys(pubkeys)
+		for i := range leaderboard {
+			leaderboard[i].OwnerAlias = people[leaderboard[i].OwnerPubkey].OwnerAlias
+			leaderboard[i].OwnerImg = people[leaderboard[i].OwnerPubkey].Img
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(leaderboard)
+}
+
+// leaderboardWindowFilters returns the date range of a window and of the window before it,
+// for all time the previous window is the leaderboard as of a week ago
+func leaderboardWindowFilters(window db.LeaderboardWindow, now time.Time) (db.LeaderboardFilter, db.LeaderboardFilter, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	var days int
+	switch window {
+	case db.LeaderboardWeek:
+		days = 7
+	case db.LeaderboardMonth:
+		days = 30
+	case db.LeaderboardAll:
+		weekAgo := tomorrow.AddDate(0, 0, -7)
+		return db.LeaderboardFilter{}, db.LeaderboardFilter{To: &weekAgo}, true
+	default:
+		return db.LeaderboardFilter{}, db.LeaderboardFilter{}, false
+	}
+
+	from := tomorrow.AddDate(0, 0, -days)
+	previousFrom := from.AddDate(0, 0, -days)
+	return db.LeaderboardFilter{From: &from, To: &tomorrow}, db.LeaderboardFilter{From: &previousFrom, To: &from}, true
+}
+
+func rankLeaderboard(current []db.BountyLeaderboard, previous []db.BountyLeaderboard) []db.LeaderboardEntry {
+	previousRanks := map[string]int{}
+	for i, leader := range previous {
+		previousRanks[leader.Owner_pubkey] = i + 1
+	}
+
+	entries := []db.LeaderboardEntry{}
+	for i, leader := range current {
+		entry := db.LeaderboardEntry{
+			OwnerPubkey:            leader.Owner_pubkey,
+			TotalBountiesCompleted: leader.Total_bounties_completed,
+			TotalSatsEarned:        leader.Total_sats_earned,
+			Rank:                   i + 1,
+		}
+		// a positive delta means the hunter moved up
+		if previousRank, ok := previousRanks[leader.Owner_pubkey]; ok {
+			entry.PreviousRank = previousRank
+			entry.RankDelta = previousRank - entry.Rank
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
 func DeleteBountyAssignee(w http.ResponseWriter, r *http.Request) {
 	invoice := db.DeleteBountyAssignee{}
 	body, err := io.ReadAll(r.Body)
@@ -72,6 +156,9 @@ func DeleteBountyAssignee(w http.ResponseWriter, r *http.Request) {
 		b.BountyExpires = ""
 
 		db.DB.UpdateBounty(b)
+		if err := db.DB.ResetBountyActivity(b.ID); err != nil {
+			log.Printf("Could not reset bounty activity: %s", err)
+		}
 
 		deletedAssignee = true
 	} else {
@@ -2,12 +2,16 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,33 +19,73 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/rates"
+	"github.com/stakwork/sphinx-tribes/relay"
 	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
+	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
 )
 
+// publicCallTimeout bounds a call to a url a user gave
+const publicCallTimeout = 10 * time.Second
+
+// maxLnurlResponseSize bounds what is read of a LNURL-pay answer
+const maxLnurlResponseSize = 1 << 20
+
 type bountyHandler struct {
 	httpClient               HttpClient
+	relay                    *relay.Client
 	db                       db.Database
+	onchainService           OnchainService
 	getSocketConnections     func(host string) (db.Client, error)
 	generateBountyResponse   func(bounties []db.NewBounty) []db.BountyResponse
 	userHasAccess            func(pubKeyFromAuth string, uuid string, role string) bool
 	userHasManageBountyRoles func(pubKeyFromAuth string, uuid string) bool
+	invoiceExpired           func(paymentRequest string) bool
+	transitionHooks          []BountyTransitionHook
+	rates                    rates.Provider
 	m                        sync.Mutex
+
+	// publicClient calls the urls users give, like lightning addresses, it
+	// only dials public addresses
+	publicClient    HttpClient
+	checkPublicHost func(host string) error
 }
 
+// BountyTransitionHook runs after a bounty moved to another state
+type BountyTransitionHook func(bounty db.NewBounty, transition db.BountyStateTransition)
+
 func NewBountyHandler(httpClient HttpClient, database db.Database) *bountyHandler {
 	dbConf := db.NewDatabaseConfig(&gorm.DB{})
-	return &bountyHandler{
+	h := &bountyHandler{
 
 		httpClient:               httpClient,
+		relay:                    relay.NewClient(httpClient),
 		db:                       database,
+		onchainService:           NewOnchainService(httpClient),
 		getSocketConnections:     db.Store.GetSocketConnections,
 		userHasAccess:            dbConf.UserHasAccess,
 		userHasManageBountyRoles: dbConf.UserHasManageBountyRoles,
+		invoiceExpired:           utils.GetInvoiceExpired,
+		rates:                    rates.Default(),
+		publicClient:             utils.NewPublicHttpClient(publicCallTimeout),
+		checkPublicHost:          utils.CheckPublicHost,
 	}
+	h.transitionHooks = []BountyTransitionHook{h.notifyBountyTransition}
+	return h
+}
+
+// AddTransitionHook adds a hook run after every bounty state transition
+func (h *bountyHandler) AddTransitionHook(hook BountyTransitionHook) {
+	h.transitionHooks = append(h.transitionHooks, hook)
 }
 
 func (h *bountyHandler) GetAllBounties(w http.ResponseWriter, r *http.Request) {
+	if !validListParams(w, r) {
+		return
+	}
+
 	bounties := h.db.GetAllBounties(r)
 	var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
 
@@ -59,7 +103,10 @@ func (h *bountyHandler) GetBountyById(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		fmt.Println("[bounty] Error", err)
 	} else {
-		var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
+		var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(h.visibleBounties(r, bounties))
+		if len(bountyResponse) == 1 {
+			w.Header().Set("ETag", recordETag(bountyResponse[0].Bounty.Updated))
+		}
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(bountyResponse)
 	}
@@ -130,7 +177,7 @@ func (h *bountyHandler) GetBountyByCreated(w http.ResponseWriter, r *http.Reques
 		w.WriteHeader(http.StatusBadRequest)
 		fmt.Println("[bounty] Error", err)
 	} else {
-		var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(bounties)
+		var bountyResponse []db.BountyResponse = h.GenerateBountyResponse(h.visibleBounties(r, bounties))
 
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(bountyResponse)
@@ -157,6 +204,10 @@ func GetBountyCount(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *bountyHandler) GetPersonCreatedBounties(w http.ResponseWriter, r *http.Request) {
+	if !validListParams(w, r) {
+		return
+	}
+
 	bounties, err := h.db.GetCreatedBounties(r)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -169,6 +220,10 @@ func (h *bountyHandler) GetPersonCreatedBounties(w http.ResponseWriter, r *http.
 }
 
 func (h *bountyHandler) GetPersonAssignedBounties(w http.ResponseWriter, r *http.Request) {
+	if !validListParams(w, r) {
+		return
+	}
+
 	bounties, err := h.db.GetAssignedBounties(r)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -203,10 +258,23 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 
 	now := time.Now()
 
+	// tags are only set through the tag endpoints, which check they belong to the workspace
+	bounty.Tags = nil
+
 	if bounty.WorkspaceUuid == "" && bounty.OrgUuid != "" {
 		bounty.WorkspaceUuid = bounty.OrgUuid
 	}
 
+	// a request made with an api token is limited to the workspace and scopes of the token
+	if apiToken, ok := auth.ApiTokenFromContext(ctx); ok {
+		if msg := h.checkApiTokenBounty(apiToken, &bounty); msg != "" {
+			fmt.Println("[bounty]", msg)
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(msg)
+			return
+		}
+	}
+
 	//Check if bounty exists
 	bounty.Updated = &now
 
@@ -228,6 +296,12 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if bounty.EstimatedHours < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Estimated hours cannot be negative")
+		return
+	}
+
 	if bounty.Assignee != "" {
 		now := time.Now()
 		bounty.AssignedDate = &now
@@ -249,9 +323,13 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 		bounty.Created = time.Now().Unix()
 	}
 
+	previousAssignee := ""
+	previousBounty := db.NewBounty{}
 	if bounty.Title != "" && bounty.ID != 0 {
 		// get bounty from DB
 		dbBounty := h.db.GetBounty(bounty.ID)
+		previousAssignee = dbBounty.Assignee
+		previousBounty = dbBounty
 
 		// trying to update
 		// check if bounty belongs to user
@@ -275,6 +353,55 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	// a due date left out of an edit stays, an explicit null clears it
+	dueDateChanged := false
+	if hasJsonField(body, "due_date") {
+		dueDateChanged = !sameTime(bounty.DueDate, previousBounty.DueDate)
+	} else {
+		bounty.DueDate = previousBounty.DueDate
+	}
+	if dueDateChanged && bounty.DueDate != nil && !bounty.DueDate.After(now) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Due date must be in the future")
+		return
+	}
+
+	if !db.ValidBountyVisibility(bounty.Visibility) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Visibility should be public, workspace or invite_only")
+		return
+	}
+	if bounty.Visibility == db.BountyVisibilityWorkspace && bounty.WorkspaceUuid == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Only workspace bounties can be visible to the workspace")
+		return
+	}
+
+	if bounty.Visibility == "" {
+		bounty.Visibility = db.BountyVisibilityPublic
+	}
+
+	// a new bounty that does not say if it shows takes the workspace default
+	if bounty.ID == 0 && bounty.WorkspaceUuid != "" && !hasJsonField(body, "show") {
+		bounty.Show = h.db.GetWorkspaceSettings(bounty.WorkspaceUuid).DefaultBountyVisibility != db.BountyVisibilityHidden
+	}
+
+	if bounty.Assignee != previousAssignee {
+		if limit, reached := h.wipLimitReached(bounty.WorkspaceUuid, bounty.Assignee); reached {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(fmt.Sprintf("The assignee already has %d unfinished bounties in this workspace", limit))
+			return
+		}
+	}
+
+	// a new bounty, an assignment or a move to another phase counts against the state limits
+	if state := bounty.CurrentState(); bounty.ID == 0 || state != previousBounty.CurrentState() || bounty.PhaseUuid != previousBounty.PhaseUuid {
+		if exceeded := h.stateWipLimitsExceeded(pubKeyFromAuth, bounty.WorkspaceUuid, bounty.PhaseUuid, state); len(exceeded) > 0 {
+			writeStateWipLimitsExceeded(w, state, exceeded)
+			return
+		}
+	}
+
 	if bounty.PhaseUuid != "" {
 		phase, err := h.db.GetPhaseByUuid(bounty.PhaseUuid)
 		if err != nil {
@@ -296,10 +423,218 @@ func (h *bountyHandler) CreateOrEditBounty(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// an edit can not clear a column, and a new due date starts the reminders over
+	if dueDateChanged && bounty.ID != 0 {
+		if err := h.db.SetBountyDueDate(b.ID, bounty.DueDate); err != nil {
+			fmt.Println("[bounty] could not set due date", err)
+		}
+		b.DueDate = bounty.DueDate
+	}
+
+	// a new assignee starts without the activity and stale warning of the previous one
+	if bounty.ID != 0 && bounty.Assignee != previousAssignee {
+		if err := h.db.ResetBountyActivity(b.ID); err != nil {
+			fmt.Println("[bounty] could not reset activity", err)
+		}
+		b.LastActivityDate = nil
+		b.StaleWarningDate = nil
+	}
+	b.Overdue = b.IsOverdue(now)
+
+	if bounty.ID == 0 && b.Visibility != db.BountyVisibilityHidden {
+		PublishWorkspaceEvent(db.WorkspaceEvent{
+			WorkspaceUuid: b.WorkspaceUuid,
+			Type:          db.BountyCreatedEvent,
+			Title:         "New bounty",
+			Message:       fmt.Sprintf("%s for %d sats", b.Title, b.Price),
+			Link:          fmt.Sprintf("%s/bounty/%d", config.Host, b.ID),
+			Actor:         pubKeyFromAuth,
+		})
+	}
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(b)
 }
 
+// bountyPatchFields are the fields a PATCH can change, assignment, payment
+// and tags go through their own endpoints
+var bountyPatchFields = map[string]bool{
+	"type":                      true,
+	"title":                     true,
+	"description":               true,
+	"price":                     true,
+	"show":                      true,
+	"award":                     true,
+	"tribe":                     true,
+	"ticket_url":                true,
+	"wanted_type":               true,
+	"deliverables":              true,
+	"github_description":        true,
+	"one_sentence_summary":      true,
+	"estimated_session_length":  true,
+	"estimated_completion_date": true,
+	"estimated_hours":           true,
+	"coding_languages":          true,
+	"phase_uuid":                true,
+	"phase_priority":            true,
+	"visibility":                true,
+	"invitees":                  true,
+	"due_date":                  true,
+}
+
+// PatchBounty changes the fields of a bounty a JSON merge patch names and
+// leaves the others as they are. With If-Match the patch only applies to the
+// version of the bounty the client read, the ETag of the answer is the new one
+func (h *bountyHandler) PatchBounty(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	current, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if pubKeyFromAuth != current.OwnerID && (current.WorkspaceUuid == "" || !h.userHasManageBountyRoles(pubKeyFromAuth, current.WorkspaceUuid)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to edit the bounty")
+		return
+	}
+
+	conditional, matched := ifMatch(r, recordETag(current.Updated))
+	if !matched {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		json.NewEncoder(w).Encode("The bounty changed since it was read")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	bounty := db.NewBounty{}
+	fields := []string{}
+	if err == nil {
+		fields, err = applyMergePatch(current, body, &bounty)
+	}
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if field := unpatchableField(fields, bountyPatchFields); field != "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("%s cannot be patched", field))
+		return
+	}
+
+	now := time.Now()
+	if bounty.Type == "" || bounty.Title == "" || bounty.Description == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Type, title and description cannot be removed")
+		return
+	}
+	if bounty.EstimatedHours < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Estimated hours cannot be negative")
+		return
+	}
+	if bounty.DueDate != nil && !sameTime(bounty.DueDate, current.DueDate) && !bounty.DueDate.After(now) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Due date must be in the future")
+		return
+	}
+
+	if bounty.Visibility == "" {
+		bounty.Visibility = db.BountyVisibilityPublic
+	}
+	if !db.ValidBountyVisibility(bounty.Visibility) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Visibility should be public, workspace or invite_only")
+		return
+	}
+	if bounty.Visibility == db.BountyVisibilityWorkspace && bounty.WorkspaceUuid == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Only workspace bounties can be visible to the workspace")
+		return
+	}
+
+	if bounty.PhaseUuid != current.PhaseUuid {
+		if bounty.PhaseUuid != "" {
+			if _, err := h.db.GetPhaseByUuid(bounty.PhaseUuid); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode("Not a valid phase")
+				return
+			}
+		}
+		if exceeded := h.stateWipLimitsExceeded(pubKeyFromAuth, bounty.WorkspaceUuid, bounty.PhaseUuid, bounty.CurrentState()); len(exceeded) > 0 {
+			writeStateWipLimitsExceeded(w, bounty.CurrentState(), exceeded)
+			return
+		}
+	}
+
+	if len(fields) > 0 {
+		bounty, err = h.db.PatchBounty(bounty, fields, conditional)
+		if errors.Is(err, db.ErrPatchConflict) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			json.NewEncoder(w).Encode("The bounty changed since it was read")
+			return
+		}
+		if err != nil {
+			fmt.Println("[bounty] could not patch bounty", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+	bounty.Overdue = bounty.IsOverdue(now)
+
+	w.Header().Set("ETag", recordETag(bounty.Updated))
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bounty)
+}
+
+func sameTime(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// checkApiTokenBounty returns why an api token cannot create or edit the bounty,
+// a created bounty is owned by the admin that minted the token
+func (h *bountyHandler) checkApiTokenBounty(apiToken auth.ApiToken, bounty *db.NewBounty) string {
+	if bounty.WorkspaceUuid == "" {
+		bounty.WorkspaceUuid = apiToken.WorkspaceUuid
+	}
+	if bounty.WorkspaceUuid != apiToken.WorkspaceUuid {
+		return "Api token is not valid for this workspace"
+	}
+
+	if bounty.ID == 0 {
+		if !apiToken.HasScope(db.ApiScopeCreateBounties) {
+			return "Api token cannot create bounties"
+		}
+		if !h.userHasAccess(apiToken.CreatedBy, apiToken.WorkspaceUuid, db.AddBounty) {
+			return "Api token admin can no longer add bounties"
+		}
+		bounty.OwnerID = apiToken.CreatedBy
+		return ""
+	}
+
+	if !apiToken.HasScope(db.ApiScopeUpdateBounties) {
+		return "Api token cannot update bounties"
+	}
+	if h.db.GetBounty(bounty.ID).WorkspaceUuid != apiToken.WorkspaceUuid {
+		return "Api token is not valid for this bounty"
+	}
+	if !h.userHasAccess(apiToken.CreatedBy, apiToken.WorkspaceUuid, db.UpdateBounty) {
+		return "Api token admin can no longer update bounties"
+	}
+	return ""
+}
+
 func (h *bountyHandler) DeleteBounty(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
@@ -394,14 +729,28 @@ func UpdateCompletedStatus(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(bounty)
 }
 
+// bountyPeople loads the owners and assignees of bounties in one query, keyed by pubkey
+func bountyPeople(database db.Database, bounties []db.NewBounty) map[string]db.Person {
+	if len(bounties) == 0 {
+		return map[string]db.Person{}
+	}
+	pubkeys := []string{}
+	for _, bounty := range bounties {
+		pubkeys = append(pubkeys, bounty.OwnerID, bounty.Assignee)
+	}
+	return database.GetPeopleByPubkeys(pubkeys)
+}
+
 func (h *bountyHandler) GenerateBountyResponse(bounties []db.NewBounty) []db.BountyResponse {
 	var bountyResponse []db.BountyResponse
+	displayRates := map[string]*rates.Rate{}
+	people := bountyPeople(h.db, bounties)
 
 	for i := 0; i < len(bounties); i++ {
 		bounty := bounties[i]
 
-		owner := h.db.GetPersonByPubkey(bounty.OwnerID)
-		assignee := h.db.GetPersonByPubkey(bounty.Assignee)
+		owner := people[bounty.OwnerID]
+		assignee := people[bounty.Assignee]
 		workspace := h.db.GetWorkspaceByUuid(bounty.WorkspaceUuid)
 
 		b := db.BountyResponse{
@@ -433,6 +782,8 @@ func (h *bountyHandler) GenerateBountyResponse(bounties []db.NewBounty) []db.Bou
 				Updated:                 bounty.Updated,
 				CodingLanguages:         bounty.CodingLanguages,
 				Completed:               bounty.Completed,
+				DueDate:                 bounty.DueDate,
+				Overdue:                 bounty.IsOverdue(time.Now()),
 			},
 			Assignee: db.Person{
 				ID:               assignee.ID,
@@ -479,12 +830,45 @@ func (h *bountyHandler) GenerateBountyResponse(bounties []db.NewBounty) []db.Bou
 				Img:  workspace.Img,
 			},
 		}
+		if rate := h.displayRate(bounty.WorkspaceUuid, displayRates); rate != nil {
+			b.Display = &db.DisplayAmount{
+				Currency:      rate.Currency,
+				Amount:        rate.Convert(bounty.Price),
+				BtcPrice:      rate.BtcPrice,
+				RateTimestamp: rate.Timestamp,
+			}
+		}
 		bountyResponse = append(bountyResponse, b)
 	}
 
 	return bountyResponse
 }
 
+// displayRate is the rate of the display currency of a workspace, nil when the
+// workspace shows sats only or the rate is unavailable. Rates are kept in seen
+// so a page of bounties reads the settings of each workspace once
+func (h *bountyHandler) displayRate(workspaceUuid string, seen map[string]*rates.Rate) *rates.Rate {
+	if workspaceUuid == "" {
+		return nil
+	}
+	if rate, ok := seen[workspaceUuid]; ok {
+		return rate
+	}
+
+	seen[workspaceUuid] = nil
+	currency := h.db.GetWorkspaceSettings(workspaceUuid).DisplayCurrency
+	if currency == "" {
+		return nil
+	}
+	rate, err := h.rates.Rate(context.Background(), currency)
+	if err != nil {
+		fmt.Println("[bounty] could not get the rate of", currency, err)
+		return nil
+	}
+	seen[workspaceUuid] = &rate
+	return &rate
+}
+
 func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request) {
 	h.m.Lock()
 
@@ -538,6 +922,17 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// payments over the approval threshold of the workspace need its owner
+	settings := h.db.GetWorkspaceSettings(bounty.WorkspaceUuid)
+	if settings.PaymentApprovalThreshold > 0 && amount > settings.PaymentApprovalThreshold {
+		if h.db.GetWorkspaceByUuid(bounty.WorkspaceUuid).OwnerPubKey != pubKeyFromAuth {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(fmt.Sprintf("Payments over %d sats need the workspace owner", settings.PaymentApprovalThreshold))
+			h.m.Unlock()
+			return
+		}
+	}
+
 	// check if the workspace bounty balance
 	// is greater than the amount
 	orgBudget := h.db.GetWorkspaceBudget(bounty.WorkspaceUuid)
@@ -566,52 +961,100 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	url := fmt.Sprintf("%s/payment", config.RelayUrl)
-
-	assignee := h.db.GetPersonByPubkey(bounty.Assignee)
-	bodyData := utils.BuildKeysendBodyData(amount, assignee.OwnerPubKey, assignee.OwnerRouteHint)
-
-	jsonBody := []byte(bodyData)
-
-	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
-	req.Header.Set("x-user-token", config.RelayAuthKey)
-	req.Header.Set("Content-Type", "application/json")
-	log.Printf("[bounty] Making Bounty Payment: amount: %d, pubkey: %s, route_hint: %s", amount, assignee.OwnerPubKey, assignee.OwnerRouteHint)
-	res, err := h.httpClient.Do(req)
-
-	if err != nil {
-		log.Printf("[bounty] Request Failed: %s", err)
+	// a payment with no known outcome may have reached the hunter,
+	// the bounty is not paid again until it is reconciled
+	if h.db.HasUnsettledBountyPayment(bounty.ID) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("A payment of this bounty has no known outcome yet")
 		h.m.Unlock()
 		return
 	}
 
-	defer res.Body.Close()
-	body, err = io.ReadAll(res.Body)
+	// set the amount aside so a parallel payment cannot spend it too
+	reservation, err := h.db.ReserveBudget(bounty.WorkspaceUuid, amount)
 	if err != nil {
-		fmt.Println("[read body]", err)
-		w.WriteHeader(http.StatusNotAcceptable)
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("workspace budget is not enough to pay the amount")
 		h.m.Unlock()
 		return
 	}
 
-	msg := make(map[string]interface{})
+	assignee := h.db.GetPersonByPubkey(bounty.Assignee)
 
-	// payment is successful add to payment history
-	// and reduce workspaces budget
-	if res.StatusCode == 200 {
-		// Unmarshal result
-		keysendRes := db.KeysendSuccess{}
-		err = json.Unmarshal(body, &keysendRes)
+	fallbackErrors := []string{}
+
+	// payouts too large to route go on-chain to hunters with an on-chain address,
+	// the confirmation cron tracks them until they are confirmed or dropped
+	if config.OnchainPayoutThreshold > 0 && amount >= uint(config.OnchainPayoutThreshold) && assignee.OnchainAddress != "" {
+		txid, estimate, err := h.payOnchain(assignee.OnchainAddress, amount)
+		// the coins may have been sent, so the hunter is not paid another way
+		if errors.Is(err, errPaymentUnknown) {
+			log.Printf("[bounty] payment to on-chain address %s has no known outcome: %s", assignee.OnchainAddress, err)
+			now := time.Now()
+			h.holdUnsettledPayment(w, db.NewPaymentHistory{
+				Amount:         amount,
+				SenderPubKey:   pubKeyFromAuth,
+				ReceiverPubKey: assignee.OwnerPubKey,
+				WorkspaceUuid:  bounty.WorkspaceUuid,
+				BountyId:       id,
+				Created:        &now,
+				Updated:        &now,
+				PaymentType:    "payment",
+				PaymentMethod:  db.PaymentMethodOnchain,
+				OnchainAddress: assignee.OnchainAddress,
+				OnchainFee:     estimate.FeeSat,
+			}, request.Websocket_token)
+			h.m.Unlock()
+			return
+		}
+		if err == nil {
+			now := time.Now()
+			paymentHistory := db.NewPaymentHistory{
+				Amount:         amount,
+				SenderPubKey:   pubKeyFromAuth,
+				ReceiverPubKey: assignee.OwnerPubKey,
+				WorkspaceUuid:  bounty.WorkspaceUuid,
+				BountyId:       id,
+				Created:        &now,
+				Updated:        &now,
+				Status:         true,
+				PaymentType:    "payment",
+				PaymentMethod:  db.PaymentMethodOnchain,
+				OnchainAddress: assignee.OnchainAddress,
+				OnchainTxid:    txid,
+				OnchainFee:     estimate.FeeSat,
+			}
 
-		if err != nil {
-			fmt.Println("[Unmarshal]", err)
-			w.WriteHeader(http.StatusNotAcceptable)
+			bounty.Paid = true
+			bounty.PaidDate = &now
+			bounty.Completed = true
+			bounty.CompletionDate = &now
+
+			if err := h.recordBountyPayment(paymentHistory, bounty, reservation); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode("The payment was sent but could not be recorded")
+				h.m.Unlock()
+				return
+			}
+
+			msg := map[string]interface{}{"msg": "keysend_success", "invoice": "", "payment_method": db.PaymentMethodOnchain, "txid": txid}
+			socket, err := h.getSocketConnections(request.Websocket_token)
+			if err == nil {
+				websocket.SendMessage(socket, msg)
+			}
 			h.m.Unlock()
 			return
 		}
 
-		now := time.Now()
+		fallbackErrors = append(fallbackErrors, "on-chain: "+err.Error())
+		log.Printf("[bounty] could not pay on-chain address %s, falling back to lightning: %s", assignee.OnchainAddress, err)
+	}
 
+	// hunters with a lightning address are paid to an invoice from it, when that
+	// surely failed they are paid with keysend like everyone else
+	if assignee.LightningAddress != "" {
+		paymentSuccess, err := h.payLightningAddress(ctx, assignee.LightningAddress, amount)
+		now := time.Now()
 		paymentHistory := db.NewPaymentHistory{
 			Amount:         amount,
 			SenderPubKey:   pubKeyFromAuth,
@@ -620,62 +1063,189 @@ func (h *bountyHandler) MakeBountyPayment(w http.ResponseWriter, r *http.Request
 			BountyId:       id,
 			Created:        &now,
 			Updated:        &now,
-			Status:         true,
 			PaymentType:    "payment",
+			PaymentMethod:  db.PaymentMethodLightningAddress,
 		}
 
-		bounty.Paid = true
-		bounty.PaidDate = &now
-		bounty.Completed = true
-		bounty.CompletionDate = &now
+		// the invoice may have been paid, so the hunter is not paid another way
+		if errors.Is(err, errPaymentUnknown) {
+			log.Printf("[bounty] payment to lightning address %s has no known outcome", assignee.LightningAddress)
+			h.holdUnsettledPayment(w, paymentHistory, request.Websocket_token)
+			h.m.Unlock()
+			return
+		}
 
-		h.db.ProcessBountyPayment(paymentHistory, bounty)
+		if err == nil {
+			paymentHistory.PaymentHash = paymentSuccess.Response.Payment_hash
+			paymentHistory.Status = true
 
-		msg["msg"] = "keysend_success"
-		msg["invoice"] = ""
+			bounty.Paid = true
+			bounty.PaidDate = &now
+			bounty.Completed = true
+			bounty.CompletionDate = &now
 
-		socket, err := h.getSocketConnections(request.Websocket_token)
-		if err == nil {
-			socket.Conn.WriteJSON(msg)
-		}
-	} else {
-		msg["msg"] = "keysend_error"
-		msg["invoice"] = ""
+			if err := h.recordBountyPayment(paymentHistory, bounty, reservation); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode("The payment was sent but could not be recorded")
+				h.m.Unlock()
+				return
+			}
 
-		socket, err := h.getSocketConnections(request.Websocket_token)
-		if err == nil {
-			socket.Conn.WriteJSON(msg)
+			msg := map[string]interface{}{"msg": "keysend_success", "invoice": "", "payment_method": db.PaymentMethodLightningAddress}
+			socket, err := h.getSocketConnections(request.Websocket_token)
+			if err == nil {
+				websocket.SendMessage(socket, msg)
+			}
+			h.m.Unlock()
+			return
 		}
-	}
 
-	h.m.Unlock()
-}
+		fallbackErrors = append(fallbackErrors, "lightning address: "+err.Error())
+		log.Printf("[bounty] could not pay lightning address %s, falling back to keysend: %s", assignee.LightningAddress, err)
+	}
 
-func (h *bountyHandler) BountyBudgetWithdraw(w http.ResponseWriter, r *http.Request) {
-	h.m.Lock()
+	bodyData := utils.BuildKeysendBodyData(amount, assignee.OwnerPubKey, assignee.OwnerRouteHint.String())
 
-	ctx := r.Context()
-	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	jsonBody := []byte(bodyData)
 
-	if pubKeyFromAuth == "" {
-		fmt.Println("[bounty] no pubkey from auth")
-		w.WriteHeader(http.StatusUnauthorized)
+	req, err := h.relay.NewRequest(ctx, http.MethodPost, "/payment", jsonBody)
+	if err != nil {
+		log.Printf("[bounty] Request Failed: %s", err)
+		h.releaseBudget(reservation)
+		w.WriteHeader(http.StatusInternalServerError)
 		h.m.Unlock()
 		return
 	}
+	log.Printf("[bounty] Making Bounty Payment: amount: %d, pubkey: %s, route_hint: %s", amount, assignee.OwnerPubKey, assignee.OwnerRouteHint)
+	paymentCtx, paymentSpan := utils.StartSpan(ctx, "payment.keysend",
+		attribute.Int("bounty.id", int(bounty.ID)),
+		attribute.Int64("payment.amount", int64(amount)),
+	)
+	res, err := h.relay.Do(req.WithContext(paymentCtx))
+	utils.EndSpan(paymentSpan, relayError(res, err))
 
-	request := db.WithdrawBudgetRequest{}
-	body, err := io.ReadAll(r.Body)
-	r.Body.Close()
+	now := time.Now()
+	paymentHistory := db.NewPaymentHistory{
+		Amount:         amount,
+		SenderPubKey:   pubKeyFromAuth,
+		ReceiverPubKey: assignee.OwnerPubKey,
+		WorkspaceUuid:  bounty.WorkspaceUuid,
+		BountyId:       id,
+		Created:        &now,
+		Updated:        &now,
+		PaymentType:    "payment",
+		PaymentMethod:  db.PaymentMethodKeysend,
+	}
 
 	if err != nil {
-		w.WriteHeader(http.StatusNotAcceptable)
+		log.Printf("[bounty] Request Failed: %s", err)
+		h.holdUnsettledPayment(w, paymentHistory, request.Websocket_token)
 		h.m.Unlock()
 		return
 	}
 
-	err = json.Unmarshal(body, &request)
-	if err != nil {
+	defer res.Body.Close()
+	body, err = io.ReadAll(res.Body)
+	if err != nil {
+		fmt.Println("[read body]", err)
+	}
+
+	msg := make(map[string]interface{})
+
+	// payment is successful add to payment history
+	// and reduce workspaces budget
+	if res.StatusCode == 200 {
+		// the relay sent the payment, only its hash is lost when the answer can't be read
+		keysendRes := db.KeysendSuccess{}
+		if err := json.Unmarshal(body, &keysendRes); err != nil {
+			fmt.Println("[Unmarshal]", err)
+		}
+		paymentHash, _ := keysendRes.Response["payment_hash"].(string)
+
+		paymentHistory.PaymentHash = paymentHash
+		paymentHistory.Status = true
+
+		bounty.Paid = true
+		bounty.PaidDate = &now
+		bounty.Completed = true
+		bounty.CompletionDate = &now
+
+		if err := h.recordBountyPayment(paymentHistory, bounty, reservation); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("The payment was sent but could not be recorded")
+			h.m.Unlock()
+			return
+		}
+
+		msg["msg"] = "keysend_success"
+		msg["invoice"] = ""
+
+		socket, err := h.getSocketConnections(request.Websocket_token)
+		if err == nil {
+			websocket.SendMessage(socket, msg)
+		}
+	} else {
+		// only a refusal the relay explains is known to have sent nothing
+		keysendError := db.KeysendError{}
+		if err := json.Unmarshal(body, &keysendError); err != nil || keysendError.Error == "" {
+			log.Printf("[bounty] keysend of bounty %d answered %d without an error", bounty.ID, res.StatusCode)
+			h.holdUnsettledPayment(w, paymentHistory, request.Websocket_token)
+			h.m.Unlock()
+			return
+		}
+
+		h.releaseBudget(reservation)
+
+		reason := keysendError.Error
+		if len(fallbackErrors) > 0 {
+			reason = strings.Join(append(fallbackErrors, "keysend: "+reason), ", ")
+		}
+		h.notifyPaymentFailure(db.PaymentFailureAlert{
+			WorkspaceUuid: bounty.WorkspaceUuid,
+			BountyId:      bounty.ID,
+			PaymentType:   "keysend",
+			Amount:        amount,
+			Reason:        reason,
+			RetryLink:     fmt.Sprintf("%s/bounty/%d", config.Host, bounty.ID),
+		})
+
+		msg["msg"] = "keysend_error"
+		msg["invoice"] = ""
+
+		socket, err := h.getSocketConnections(request.Websocket_token)
+		if err == nil {
+			websocket.SendMessage(socket, msg)
+		}
+	}
+
+	h.m.Unlock()
+}
+
+func (h *bountyHandler) BountyBudgetWithdraw(w http.ResponseWriter, r *http.Request) {
+	h.m.Lock()
+
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		h.m.Unlock()
+		return
+	}
+
+	request := db.WithdrawBudgetRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		h.m.Unlock()
+		return
+	}
+
+	err = json.Unmarshal(body, &request)
+	if err != nil {
 		w.WriteHeader(http.StatusNotAcceptable)
 		h.m.Unlock()
 		return
@@ -706,13 +1276,34 @@ func (h *bountyHandler) BountyBudgetWithdraw(w http.ResponseWriter, r *http.Requ
 			h.m.Unlock()
 			return
 		}
-		paymentSuccess, paymentError := h.PayLightningInvoice(request.PaymentRequest)
+
+		// set the amount aside so a parallel payment cannot spend it too
+		reservation, err := h.db.ReserveBudget(request.OrgUuid, amount)
+		if err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			errMsg := formatPayError("Workspace budget is not enough to withdraw the amount")
+			json.NewEncoder(w).Encode(errMsg)
+			h.m.Unlock()
+			return
+		}
+
+		paymentSuccess, paymentError := h.PayLightningInvoice(ctx, request.PaymentRequest)
 		if paymentSuccess.Success {
 			// withdraw amount from workspace budget
-			h.db.WithdrawBudget(pubKeyFromAuth, request.OrgUuid, amount)
+			if err := h.db.WithdrawBudget(pubKeyFromAuth, reservation); err != nil {
+				log.Printf("[bounty] could not record budget withdrawal: %s", err)
+			}
 			w.WriteHeader(http.StatusOK)
 			json.NewEncoder(w).Encode(paymentSuccess)
 		} else {
+			h.releaseBudget(reservation)
+			h.notifyPaymentFailure(db.PaymentFailureAlert{
+				WorkspaceUuid: request.OrgUuid,
+				PaymentType:   "invoice",
+				Amount:        amount,
+				Reason:        paymentError.Error,
+				RetryLink:     fmt.Sprintf("%s/workspace/%s", config.Host, request.OrgUuid),
+			})
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(paymentError)
 		}
@@ -780,13 +1371,34 @@ func (h *bountyHandler) NewBountyBudgetWithdraw(w http.ResponseWriter, r *http.R
 			h.m.Unlock()
 			return
 		}
-		paymentSuccess, paymentError := h.PayLightningInvoice(request.PaymentRequest)
+
+		// set the amount aside so a parallel payment cannot spend it too
+		reservation, err := h.db.ReserveBudget(request.WorkspaceUuid, amount)
+		if err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			errMsg := formatPayError("Workspace budget is not enough to withdraw the amount")
+			json.NewEncoder(w).Encode(errMsg)
+			h.m.Unlock()
+			return
+		}
+
+		paymentSuccess, paymentError := h.PayLightningInvoice(ctx, request.PaymentRequest)
 		if paymentSuccess.Success {
 			// withdraw amount from workspace budget
-			h.db.WithdrawBudget(pubKeyFromAuth, request.WorkspaceUuid, amount)
+			if err := h.db.WithdrawBudget(pubKeyFromAuth, reservation); err != nil {
+				log.Printf("[bounty] could not record budget withdrawal: %s", err)
+			}
 			w.WriteHeader(http.StatusOK)
 			json.NewEncoder(w).Encode(paymentSuccess)
 		} else {
+			h.releaseBudget(reservation)
+			h.notifyPaymentFailure(db.PaymentFailureAlert{
+				WorkspaceUuid: request.WorkspaceUuid,
+				PaymentType:   "invoice",
+				Amount:        amount,
+				Reason:        paymentError.Error,
+				RetryLink:     fmt.Sprintf("%s/workspace/%s", config.Host, request.WorkspaceUuid),
+			})
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(paymentError)
 		}
@@ -799,163 +1411,646 @@ func (h *bountyHandler) NewBountyBudgetWithdraw(w http.ResponseWriter, r *http.R
 	h.m.Unlock()
 }
 
-func formatPayError(errorMsg string) db.InvoicePayError {
-	return db.InvoicePayError{
-		Success: false,
-		Error:   errorMsg,
+// WithdrawWorkspaceBudget refunds unused budget to the workspace owner's invoice.
+// Refunds over the workspace's payment approval threshold wait for a second admin
+// with the withdraw budget role to approve them, their amount stays reserved meanwhile
+func (h *bountyHandler) WithdrawWorkspaceBudget(w http.ResponseWriter, r *http.Request) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
 	}
-}
 
-func (h *bountyHandler) GetLightningInvoice(payment_request string) (db.InvoiceResult, db.InvoiceError) {
-	url := fmt.Sprintf("%s/invoice?payment_request=%s", config.RelayUrl, payment_request)
+	uuid := chi.URLParam(r, "uuid")
+	workspace := h.db.GetWorkspaceByUuid(uuid)
+	if workspace.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+	if workspace.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(formatPayError("Only the workspace owner can withdraw unused budget"))
+		return
+	}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	request := db.NewWithdrawBudgetRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
 
-	req.Header.Set("x-user-token", config.RelayAuthKey)
-	req.Header.Set("Content-Type", "application/json")
-	res, _ := h.httpClient.Do(req)
+	amount := utils.GetInvoiceAmount(request.PaymentRequest)
+	if amount == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(formatPayError("Could not read the invoice amount"))
+		return
+	}
 
+	reservation, err := h.db.ReserveBudget(uuid, amount)
 	if err != nil {
-		log.Printf("[bounty] Request Failed: %s", err)
-		return db.InvoiceResult{}, db.InvoiceError{}
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(formatPayError("Workspace budget is not enough to withdraw the amount"))
+		return
 	}
 
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-
-	if res.StatusCode != 200 {
-		// Unmarshal result
-		invoiceErr := db.InvoiceError{}
-		err = json.Unmarshal(body, &invoiceErr)
-
-		if err != nil {
-			log.Printf("[bounty] Reading Invoice body failed: %s", err)
-			return db.InvoiceResult{}, invoiceErr
-		}
-
-		return db.InvoiceResult{}, invoiceErr
-	} else {
-		// Unmarshal result
-		invoiceRes := db.InvoiceResult{}
-		err = json.Unmarshal(body, &invoiceRes)
-
-		if err != nil {
-			log.Printf("[bounty] Reading Invoice body failed: %s", err)
-			return invoiceRes, db.InvoiceError{}
-		}
-
-		return invoiceRes, db.InvoiceError{}
+	threshold := h.db.GetWorkspaceSettings(uuid).PaymentApprovalThreshold
+	refund, err := h.db.CreateBudgetRefund(db.BudgetRefund{
+		WorkspaceUuid:  uuid,
+		Amount:         amount,
+		PaymentRequest: request.PaymentRequest,
+		ReservationId:  reservation.Id,
+		RequestedBy:    pubKeyFromAuth,
+		Status:         db.BudgetRefundPendingApproval,
+	})
+	if err != nil {
+		h.releaseBudget(reservation)
+		fmt.Println("[bounty] could not save refund", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
-}
-
-func (h *bountyHandler) PayLightningInvoice(payment_request string) (db.InvoicePaySuccess, db.InvoicePayError) {
-	url := fmt.Sprintf("%s/invoices", config.RelayUrl)
-	bodyData := fmt.Sprintf(`{"payment_request": "%s"}`, payment_request)
-	jsonBody := []byte(bodyData)
 
-	req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(jsonBody))
-
-	req.Header.Set("x-user-token", config.RelayAuthKey)
-	req.Header.Set("Content-Type", "application/json")
-	res, err := h.httpClient.Do(req)
-
-	if err != nil {
-		log.Printf("[bounty] Request Failed: %s", err)
-		return db.InvoicePaySuccess{}, db.InvoicePayError{}
+	if threshold > 0 && amount > threshold {
+		h.notifyRefundApprovers(workspace, refund)
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(refund)
+		return
 	}
 
-	defer res.Body.Close()
+	h.payBudgetRefund(ctx, w, refund)
+}
 
-	body, err := io.ReadAll(res.Body)
+// ApproveWorkspaceBudgetRefund is the second admin approving a refund, it is paid right away
+func (h *bountyHandler) ApproveWorkspaceBudgetRefund(w http.ResponseWriter, r *http.Request) {
+	h.m.Lock()
+	defer h.m.Unlock()
 
-	if res.StatusCode != 200 {
-		invoiceError := db.InvoicePayError{}
-		err = json.Unmarshal(body, &invoiceError)
+	ctx := r.Context()
+	refund, ok := h.getPendingBudgetRefund(w, r)
+	if !ok {
+		return
+	}
 
-		if err != nil {
-			log.Printf("[bounty] Reading Invoice pay error body failed: %s", err)
-			return db.InvoicePaySuccess{}, db.InvoicePayError{}
-		}
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == refund.RequestedBy {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(formatPayError("A refund has to be approved by someone else than who requested it"))
+		return
+	}
 
-		return db.InvoicePaySuccess{}, invoiceError
-	} else {
-		invoiceSuccess := db.InvoicePaySuccess{}
-		err = json.Unmarshal(body, &invoiceSuccess)
+	refund.ApprovedBy = pubKeyFromAuth
+	h.payBudgetRefund(ctx, w, refund)
+}
 
-		if err != nil {
-			log.Printf("[bounty] Reading Invoice pay success body failed: %s", err)
-			return db.InvoicePaySuccess{}, db.InvoicePayError{}
-		}
+// RejectWorkspaceBudgetRefund drops a pending refund and returns its amount to the budget
+func (h *bountyHandler) RejectWorkspaceBudgetRefund(w http.ResponseWriter, r *http.Request) {
+	h.m.Lock()
+	defer h.m.Unlock()
 
-		return invoiceSuccess, db.InvoicePayError{}
+	refund, ok := h.getPendingBudgetRefund(w, r)
+	if !ok {
+		return
 	}
-}
-
-func (h *bountyHandler) GetInvoiceData(w http.ResponseWriter, r *http.Request) {
-	paymentRequest := chi.URLParam(r, "paymentRequest")
-	invoiceData, invoiceErr := h.GetLightningInvoice(paymentRequest)
 
-	if invoiceErr.Error != "" {
-		w.WriteHeader(http.StatusForbidden)
-		json.NewEncoder(w).Encode(invoiceErr)
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	if err := h.db.UpdateBudgetRefundStatus(refund.ID, db.BudgetRefundRejected, pubKeyFromAuth, ""); err != nil {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
 		return
 	}
+	h.releaseBudget(budgetRefundReservation(refund))
 
+	refund.Status = db.BudgetRefundRejected
+	refund.ApprovedBy = pubKeyFromAuth
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(invoiceData)
+	json.NewEncoder(w).Encode(refund)
 }
 
-func (h *bountyHandler) PollInvoice(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
-	paymentRequest := chi.URLParam(r, "paymentRequest")
-	var err error
+func (h *bountyHandler) GetWorkspaceBudgetRefunds(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
 
 	if pubKeyFromAuth == "" {
 		fmt.Println("[bounty] no pubkey from auth")
 		w.WriteHeader(http.StatusUnauthorized)
 		return
 	}
-
-	invoiceRes, invoiceErr := h.GetLightningInvoice(paymentRequest)
-
-	if invoiceErr.Error != "" {
-		w.WriteHeader(http.StatusForbidden)
-		json.NewEncoder(w).Encode(invoiceErr)
+	if !h.userHasAccess(pubKeyFromAuth, uuid, db.WithdrawBudget) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have appropriate permissions to view the refunds")
 		return
 	}
 
-	if invoiceRes.Response.Settled {
-		// Todo if an invoice is settled
-		invoice := h.db.GetInvoice(paymentRequest)
-		invData := h.db.GetUserInvoiceData(paymentRequest)
-		dbInvoice := h.db.GetInvoice(paymentRequest)
-
-		// Make any change only if the invoice has not been settled
-		if !dbInvoice.Status {
-			if invoice.Type == "BUDGET" {
-				h.db.AddAndUpdateBudget(invoice)
-			} else if invoice.Type == "KEYSEND" {
-				url := fmt.Sprintf("%s/payment", config.RelayUrl)
-
-				amount := invData.Amount
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.db.GetBudgetRefunds(uuid))
+}
 
-				bodyData := utils.BuildKeysendBodyData(amount, invData.UserPubkey, invData.RouteHint)
+// getPendingBudgetRefund returns the refund of the url when it waits for approval
+// and the user can withdraw budget from its workspace
+func (h *bountyHandler) getPendingBudgetRefund(w http.ResponseWriter, r *http.Request) (db.BudgetRefund, bool) {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
 
-				jsonBody := []byte(bodyData)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return db.BudgetRefund{}, false
+	}
+	if !h.userHasAccess(pubKeyFromAuth, uuid, db.WithdrawBudget) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(formatPayError("You don't have appropriate permissions to approve refunds"))
+		return db.BudgetRefund{}, false
+	}
 
-				req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	refundId, err := utils.ConvertStringToUint(chi.URLParam(r, "refundId"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Not a valid refund id")
+		return db.BudgetRefund{}, false
+	}
 
-				req.Header.Set("x-user-token", config.RelayAuthKey)
-				req.Header.Set("Content-Type", "application/json")
-				res, _ := h.httpClient.Do(req)
+	refund, err := h.db.GetBudgetRefund(refundId)
+	if err != nil || refund.WorkspaceUuid != uuid {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Refund not found")
+		return refund, false
+	}
+	if refund.Status != db.BudgetRefundPendingApproval {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("Refund is not pending approval")
+		return refund, false
+	}
+	return refund, true
+}
 
-				defer res.Body.Close()
+// payBudgetRefund claims the refund and pays its invoice, then records it in
+// the ledger. Claiming it first keeps two approvals, on any instance, from
+// paying it twice. A payment the relay refused returns the reserved amount to
+// the budget, one without a known outcome leaves the refund paying
+func (h *bountyHandler) payBudgetRefund(ctx context.Context, w http.ResponseWriter, refund db.BudgetRefund) {
+	if err := h.db.ClaimBudgetRefund(refund.ID, refund.ApprovedBy); err != nil {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	refund.Status = db.BudgetRefundPaying
 
-				body, _ := io.ReadAll(res.Body)
+	if h.invoiceExpired(refund.PaymentRequest) {
+		h.releaseBudget(budgetRefundReservation(refund))
+		if err := h.db.UpdateBudgetRefundStatus(refund.ID, db.BudgetRefundFailed, refund.ApprovedBy, "invoice expired"); err != nil {
+			log.Printf("[bounty] could not update refund %d: %s", refund.ID, err)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(formatPayError("The invoice of the refund has expired"))
+		return
+	}
 
-				if res.StatusCode == 200 {
+	paymentSuccess, paymentError := h.PayLightningInvoice(ctx, refund.PaymentRequest)
+	if invoicePaymentUnknown(paymentSuccess, paymentError) {
+		log.Printf("[bounty] refund %d has no known payment outcome, it stays paying", refund.ID)
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(refund)
+		return
+	}
+	if !paymentSuccess.Success {
+		h.releaseBudget(budgetRefundReservation(refund))
+		if err := h.db.UpdateBudgetRefundStatus(refund.ID, db.BudgetRefundFailed, refund.ApprovedBy, paymentError.Error); err != nil {
+			log.Printf("[bounty] could not update refund %d: %s", refund.ID, err)
+		}
+		h.notifyPaymentFailure(db.PaymentFailureAlert{
+			WorkspaceUuid: refund.WorkspaceUuid,
+			PaymentType:   "refund",
+			Amount:        refund.Amount,
+			Reason:        paymentError.Error,
+			RetryLink:     fmt.Sprintf("%s/workspace/%s", config.Host, refund.WorkspaceUuid),
+		})
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(paymentError)
+		return
+	}
+
+	if err := h.db.CompleteBudgetRefund(refund); err != nil {
+		log.Printf("[bounty] could not record paid refund %d, it stays paying: %s", refund.ID, err)
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(paymentSuccess)
+}
+
+// notifyRefundApprovers asks the workspace admins who can withdraw budget to approve a refund
+func (h *bountyHandler) notifyRefundApprovers(workspace db.Workspace, refund db.BudgetRefund) {
+	users, _ := h.db.GetWorkspaceUsers(workspace.Uuid)
+	for _, user := range users {
+		if user.OwnerPubKey == refund.RequestedBy || !h.userHasAccess(user.OwnerPubKey, workspace.Uuid, db.WithdrawBudget) {
+			continue
+		}
+		h.db.CreateNotification(db.Notification{
+			RecipientPubkey: user.OwnerPubKey,
+			WorkspaceUuid:   workspace.Uuid,
+			Type:            db.RefundApprovalNotification,
+			Title:           "Refund needs approval",
+			Message:         fmt.Sprintf("A refund of %d sats from %s needs a second approval", refund.Amount, workspace.Name),
+			Link:            fmt.Sprintf("%s/workspace/%s", config.Host, workspace.Uuid),
+		})
+	}
+}
+
+func budgetRefundReservation(refund db.BudgetRefund) db.BudgetReservation {
+	return db.BudgetReservation{Id: refund.ReservationId, WorkspaceUuid: refund.WorkspaceUuid, Amount: refund.Amount}
+}
+
+// payOnchain sends amount to an on-chain address, the network fee comes out
+// of the amount so the workspace spends exactly the bounty price
+func (h *bountyHandler) payOnchain(address string, amount uint) (string, db.OnchainFeeEstimate, error) {
+	estimate, err := h.onchainService.EstimateFee(address, amount)
+	if err != nil {
+		return "", estimate, err
+	}
+	if estimate.FeeSat >= amount {
+		return "", estimate, fmt.Errorf("on-chain fee of %d sats is not less than the payout", estimate.FeeSat)
+	}
+
+	txid, err := h.onchainService.SendCoins(address, amount-estimate.FeeSat, estimate.SatPerVbyte)
+	return txid, estimate, err
+}
+
+// errPaymentUnknown is returned for a payment the relay gave no clear answer for
+var errPaymentUnknown = errors.New("payment has no known outcome")
+
+// payLightningAddress resolves a lightning address to an invoice for amount and pays it,
+// it returns errPaymentUnknown when the invoice may have been paid
+func (h *bountyHandler) payLightningAddress(ctx context.Context, address string, amount uint) (db.InvoicePaySuccess, error) {
+	paymentRequest, err := h.resolveLightningAddress(ctx, address, amount)
+	if err != nil {
+		return db.InvoicePaySuccess{}, err
+	}
+
+	paymentSuccess, paymentError := h.PayLightningInvoice(ctx, paymentRequest)
+	if invoicePaymentUnknown(paymentSuccess, paymentError) {
+		return paymentSuccess, errPaymentUnknown
+	}
+	if !paymentSuccess.Success {
+		return paymentSuccess, errors.New(paymentError.Error)
+	}
+	return paymentSuccess, nil
+}
+
+// resolveLightningAddress asks the LNURL-pay server of a lightning address (LUD-16)
+// for an invoice of amount sats
+func (h *bountyHandler) resolveLightningAddress(ctx context.Context, address string, amount uint) (string, error) {
+	addressUrl, err := utils.LightningAddressUrl(address)
+	if err != nil {
+		return "", err
+	}
+	domain, err := url.Parse(addressUrl)
+	if err != nil {
+		return "", err
+	}
+	if err := h.checkPublicHost(domain.Hostname()); err != nil {
+		return "", errors.New("lightning address is not on a public host")
+	}
+
+	params := db.LnurlPayResponse{}
+	if err := h.getLnurl(ctx, addressUrl, &params); err != nil {
+		return "", err
+	}
+	if params.Tag != "payRequest" || params.Callback == "" {
+		return "", errors.New("lightning address does not accept payments")
+	}
+
+	msats := uint64(amount) * 1000
+	if msats < params.MinSendable || (params.MaxSendable > 0 && msats > params.MaxSendable) {
+		return "", fmt.Errorf("lightning address accepts %d to %d sats", params.MinSendable/1000, params.MaxSendable/1000)
+	}
+
+	callback, err := url.Parse(params.Callback)
+	if err != nil || callback.Scheme != "https" || callback.Hostname() == "" {
+		return "", errors.New("lightning address has an invalid callback")
+	}
+	if err := h.checkPublicHost(callback.Hostname()); err != nil {
+		return "", errors.New("lightning address callback is not on a public host")
+	}
+	query := callback.Query()
+	query.Set("amount", strconv.FormatUint(msats, 10))
+	callback.RawQuery = query.Encode()
+
+	invoice := db.LnurlPayResponse{}
+	if err := h.getLnurl(ctx, callback.String(), &invoice); err != nil {
+		return "", err
+	}
+	if invoice.Pr == "" {
+		return "", errors.New("lightning address did not return an invoice")
+	}
+	if utils.GetInvoiceAmount(invoice.Pr) != amount {
+		return "", errors.New("lightning address invoice amount does not match")
+	}
+	return invoice.Pr, nil
+}
+
+func (h *bountyHandler) getLnurl(ctx context.Context, lnurl string, response *db.LnurlPayResponse) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lnurl, nil)
+	if err != nil {
+		return err
+	}
+	res, err := h.publicClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxLnurlResponseSize)).Decode(response); err != nil {
+		return fmt.Errorf("lightning address answered with status %d", res.StatusCode)
+	}
+	if response.Status == "ERROR" {
+		return errors.New(response.Reason)
+	}
+	return nil
+}
+
+// releaseBudget returns the budget reserved for a payment that did not go through
+func (h *bountyHandler) releaseBudget(reservation db.BudgetReservation) {
+	if err := h.db.ReleaseBudget(reservation); err != nil {
+		log.Printf("[bounty] could not release budget reservation %s: %s", reservation.Id, err)
+	}
+}
+
+// recordBountyPayment records a payment that reached the hunter, it is tried twice
+// since the sats are gone either way. When that still fails the payment is kept as
+// one with no known outcome, which holds the bounty and its reservation until the
+// payment is reconciled
+func (h *bountyHandler) recordBountyPayment(payment db.NewPaymentHistory, bounty db.NewBounty, reservation db.BudgetReservation) error {
+	err := h.db.ProcessBountyPayment(payment, bounty, reservation)
+	if err == nil {
+		return nil
+	}
+	log.Printf("[bounty] could not record the payment of bounty %d, retrying: %s", bounty.ID, err)
+
+	if err = h.db.ProcessBountyPayment(payment, bounty, reservation); err == nil {
+		return nil
+	}
+	log.Printf("[bounty] could not record the payment of bounty %d: %s", bounty.ID, err)
+
+	if addErr := h.db.AddUnsettledBountyPayment(payment); addErr != nil {
+		log.Printf("[bounty] could not keep the unrecorded payment of bounty %d: %s", bounty.ID, addErr)
+	}
+	return err
+}
+
+// holdUnsettledPayment keeps a payment the relay gave no clear answer for, it may
+// have reached the hunter so its reservation stays held until it is reconciled
+func (h *bountyHandler) holdUnsettledPayment(w http.ResponseWriter, payment db.NewPaymentHistory, websocketToken string) {
+	if err := h.db.AddUnsettledBountyPayment(payment); err != nil {
+		log.Printf("[bounty] could not keep the unsettled payment of bounty %d: %s", payment.BountyId, err)
+	}
+
+	socket, err := h.getSocketConnections(websocketToken)
+	if err == nil {
+		websocket.SendMessage(socket, map[string]interface{}{"msg": "keysend_pending", "invoice": "", "payment_method": payment.PaymentMethod})
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode("The payment has no known outcome yet, it is held until it is reconciled")
+}
+
+// relayError is the error a relay call ended with, for its span
+func relayError(res *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("relay answered with status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// notifyPaymentFailure alerts the workspace admins about a failed payment
+// in their notification inbox and on the workspace alert webhook
+func (h *bountyHandler) notifyPaymentFailure(alert db.PaymentFailureAlert) {
+	workspace := h.db.GetWorkspaceByUuid(alert.WorkspaceUuid)
+	if workspace.Uuid == "" {
+		return
+	}
+
+	if alert.Reason == "" {
+		alert.Reason = "unknown error"
+	}
+
+	recipients := []string{workspace.OwnerPubKey}
+	users, _ := h.db.GetWorkspaceUsers(workspace.Uuid)
+	for _, user := range users {
+		if user.OwnerPubKey != workspace.OwnerPubKey && h.userHasAccess(user.OwnerPubKey, workspace.Uuid, db.PayBounty) {
+			recipients = append(recipients, user.OwnerPubKey)
+		}
+	}
+
+	for _, pubkey := range recipients {
+		h.db.CreateNotification(db.Notification{
+			RecipientPubkey: pubkey,
+			WorkspaceUuid:   workspace.Uuid,
+			Type:            db.PaymentFailedNotification,
+			Title:           "Payment failed",
+			Message:         fmt.Sprintf("A %s payment of %d sats failed: %s", alert.PaymentType, alert.Amount, alert.Reason),
+			Link:            alert.RetryLink,
+		})
+	}
+
+	if workspace.AlertWebhook != "" {
+		body, _ := json.Marshal(alert)
+		go func() {
+			req, err := http.NewRequest(http.MethodPost, workspace.AlertWebhook, bytes.NewBuffer(body))
+			if err != nil {
+				log.Printf("[bounty] Alert webhook request failed: %s", err)
+				return
+			}
+			req.Header.Set("Content-Type", "application/json")
+			res, err := h.publicClient.Do(req)
+			if err != nil {
+				log.Printf("[bounty] Alert webhook request failed: %s", err)
+				return
+			}
+			res.Body.Close()
+		}()
+	}
+}
+
+func formatPayError(errorMsg string) db.InvoicePayError {
+	return db.InvoicePayError{
+		Success: false,
+		Error:   errorMsg,
+	}
+}
+
+func (h *bountyHandler) GetLightningInvoice(payment_request string) (db.InvoiceResult, db.InvoiceError) {
+	req, err := h.relay.NewRequest(context.Background(), http.MethodGet, "/invoice?payment_request="+payment_request, nil)
+	if err != nil {
+		log.Printf("[bounty] Request Failed: %s", err)
+		return db.InvoiceResult{}, db.InvoiceError{Error: err.Error()}
+	}
+
+	res, err := h.relay.Do(req)
+
+	if err != nil {
+		log.Printf("[bounty] Request Failed: %s", err)
+		return db.InvoiceResult{}, db.InvoiceError{Error: err.Error()}
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+
+	if res.StatusCode != 200 {
+		// Unmarshal result
+		invoiceErr := db.InvoiceError{}
+		err = json.Unmarshal(body, &invoiceErr)
+
+		if err != nil {
+			log.Printf("[bounty] Reading Invoice body failed: %s", err)
+			return db.InvoiceResult{}, invoiceErr
+		}
+
+		return db.InvoiceResult{}, invoiceErr
+	} else {
+		// Unmarshal result
+		invoiceRes := db.InvoiceResult{}
+		err = json.Unmarshal(body, &invoiceRes)
+
+		if err != nil {
+			log.Printf("[bounty] Reading Invoice body failed: %s", err)
+			return invoiceRes, db.InvoiceError{}
+		}
+
+		return invoiceRes, db.InvoiceError{}
+	}
+}
+
+// invoicePaymentUnknown tells if an invoice payment has no known outcome, the
+// relay didn't answer or answered something that couldn't be read. The invoice
+// may have been paid, so it must not be paid another way or released
+func invoicePaymentUnknown(success db.InvoicePaySuccess, payErr db.InvoicePayError) bool {
+	return !success.Success && payErr.Error == ""
+}
+
+// PayLightningInvoice pays an invoice through the relay. A refused payment
+// comes back with the error of the relay, see invoicePaymentUnknown
+func (h *bountyHandler) PayLightningInvoice(ctx context.Context, payment_request string) (db.InvoicePaySuccess, db.InvoicePayError) {
+	bodyData := fmt.Sprintf(`{"payment_request": "%s"}`, payment_request)
+	jsonBody := []byte(bodyData)
+
+	ctx, span := utils.StartSpan(ctx, "payment.invoice")
+	req, err := h.relay.NewRequest(ctx, http.MethodPut, "/invoices", jsonBody)
+	if err != nil {
+		utils.EndSpan(span, err)
+		log.Printf("[bounty] Request Failed: %s", err)
+		return db.InvoicePaySuccess{}, db.InvoicePayError{Error: err.Error()}
+	}
+
+	res, err := h.relay.Do(req)
+	utils.EndSpan(span, relayError(res, err))
+
+	if err != nil {
+		log.Printf("[bounty] Request Failed: %s", err)
+		return db.InvoicePaySuccess{}, db.InvoicePayError{}
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+
+	if res.StatusCode != 200 {
+		invoiceError := db.InvoicePayError{}
+		err = json.Unmarshal(body, &invoiceError)
+
+		if err != nil {
+			log.Printf("[bounty] Reading Invoice pay error body failed: %s", err)
+			return db.InvoicePaySuccess{}, db.InvoicePayError{}
+		}
+
+		return db.InvoicePaySuccess{}, invoiceError
+	} else {
+		invoiceSuccess := db.InvoicePaySuccess{}
+		err = json.Unmarshal(body, &invoiceSuccess)
+
+		if err != nil {
+			log.Printf("[bounty] Reading Invoice pay success body failed: %s", err)
+			return db.InvoicePaySuccess{}, db.InvoicePayError{}
+		}
+
+		return invoiceSuccess, db.InvoicePayError{}
+	}
+}
+
+func (h *bountyHandler) GetInvoiceData(w http.ResponseWriter, r *http.Request) {
+	paymentRequest := chi.URLParam(r, "paymentRequest")
+	invoiceData, invoiceErr := h.GetLightningInvoice(paymentRequest)
+
+	if invoiceErr.Error != "" {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(invoiceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(invoiceData)
+}
+
+func (h *bountyHandler) PollInvoice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	paymentRequest := chi.URLParam(r, "paymentRequest")
+	var err error
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	invoiceRes, invoiceErr := h.GetLightningInvoice(paymentRequest)
+
+	if invoiceErr.Error != "" {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(invoiceErr)
+		return
+	}
+
+	if invoiceRes.Response.Settled {
+		// Todo if an invoice is settled
+		invoice := h.db.GetInvoice(paymentRequest)
+		invData := h.db.GetUserInvoiceData(paymentRequest)
+		dbInvoice := h.db.GetInvoice(paymentRequest)
+
+		// Make any change only if the invoice has not been settled
+		if !dbInvoice.Status {
+			if invoice.Type == "BUDGET" {
+				h.db.AddAndUpdateBudget(invoice)
+			} else if invoice.Type == "KEYSEND" {
+				amount := invData.Amount
+
+				bodyData := utils.BuildKeysendBodyData(amount, invData.UserPubkey, invData.RouteHint)
+
+				jsonBody := []byte(bodyData)
+
+				var res *http.Response
+				req, _ := h.relay.NewRequest(r.Context(), http.MethodPost, "/payment", jsonBody)
+				res, err = h.relay.Do(req)
+				if err != nil {
+					log.Printf("[bounty] Request Failed: %s", err)
+					w.WriteHeader(http.StatusBadGateway)
+					json.NewEncoder(w).Encode("Could not reach the relay")
+					return
+				}
+
+				defer res.Body.Close()
+
+				body, _ := io.ReadAll(res.Body)
+
+				if res.StatusCode == 200 {
 					// Unmarshal result
 					keysendRes := db.KeysendSuccess{}
 					err = json.Unmarshal(body, &keysendRes)
@@ -999,8 +2094,1259 @@ func (h *bountyHandler) PollInvoice(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(invoiceRes)
 }
 
-func GetFilterCount(w http.ResponseWriter, r *http.Request) {
-	filterCount := db.DB.GetFilterStatusCount()
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(filterCount)
+// StaleReservationAge is how long a budget reservation can stay open before
+// reconciliation reports it, a payment only holds one for a single request
+const StaleReservationAge = 30 * time.Minute
+
+// ReconcilePayments cross-checks the pending payment records against the lightning
+// backend, fixes the stuck ones it can and reports every correction it made
+func (h *bountyHandler) ReconcilePayments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	now := time.Now()
+	report := db.PaymentReconciliationReport{
+		Corrections: []db.PaymentCorrection{},
+		Unresolved:  []db.PaymentCorrection{},
+		Created:     &now,
+	}
+
+	for _, invoice := range h.db.GetPendingInvoices() {
+		report.InvoicesChecked++
+		h.reconcileInvoice(&report, invoice)
+	}
+
+	for _, payment := range h.db.GetUnmarkedBountyPayments() {
+		correction := db.PaymentCorrection{
+			Action:        db.PaymentBountyMarkedPaid,
+			WorkspaceUuid: payment.WorkspaceUuid,
+			BountyId:      payment.BountyId,
+			Amount:        payment.Amount,
+			Detail:        "bounty payment succeeded but the bounty was not marked as paid",
+		}
+		if err := h.db.MarkBountyPaid(payment.BountyId, payment.Created); err != nil {
+			correction.Action = db.PaymentNeedsReview
+			correction.Error = err.Error()
+			report.Unresolved = append(report.Unresolved, correction)
+			continue
+		}
+		report.Corrections = append(report.Corrections, correction)
+	}
+
+	for _, payment := range h.db.GetUnsettledBountyPayments() {
+		report.Unresolved = append(report.Unresolved, db.PaymentCorrection{
+			Action:        db.PaymentNeedsReview,
+			WorkspaceUuid: payment.WorkspaceUuid,
+			BountyId:      payment.BountyId,
+			Amount:        payment.Amount,
+			Detail:        "bounty payment has no known outcome",
+		})
+	}
+
+	// a payment that never finished may or may not have reached the hunter,
+	// so the budget it holds is only released by hand
+	for _, reservation := range h.db.GetOpenBudgetReservations(now.Add(-StaleReservationAge)) {
+		report.Unresolved = append(report.Unresolved, db.PaymentCorrection{
+			Action:        db.PaymentNeedsReview,
+			WorkspaceUuid: reservation.WorkspaceUuid,
+			ReservationId: reservation.Id,
+			Amount:        reservation.Amount,
+			Detail:        "budget reservation was never spent or released",
+		})
+	}
+
+	log.Printf("[bounty] payment reconciliation by %s: %d invoices checked, %d corrections, %d unresolved",
+		pubKeyFromAuth, report.InvoicesChecked, len(report.Corrections), len(report.Unresolved))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
+
+// reconcileInvoice settles, credits or removes a pending invoice to match
+// the state the lightning backend has for it
+func (h *bountyHandler) reconcileInvoice(report *db.PaymentReconciliationReport, invoice db.NewInvoiceList) {
+	correction := db.PaymentCorrection{
+		PaymentRequest: invoice.PaymentRequest,
+		WorkspaceUuid:  invoice.WorkspaceUuid,
+	}
+
+	invoiceRes, invoiceErr := h.GetLightningInvoice(invoice.PaymentRequest)
+	if invoiceErr.Error != "" {
+		correction.Action = db.PaymentNeedsReview
+		correction.Detail = "could not check the invoice with the lightning backend"
+		correction.Error = invoiceErr.Error
+		report.Unresolved = append(report.Unresolved, correction)
+		return
+	}
+
+	if !invoiceRes.Response.Settled {
+		// an invoice that can still be paid is not stuck
+		if utils.GetInvoiceExpired(invoice.PaymentRequest) {
+			h.db.DeleteInvoice(invoice.PaymentRequest)
+			correction.Action = db.PaymentInvoiceExpired
+			correction.Detail = "invoice expired without being paid and was removed"
+			report.Corrections = append(report.Corrections, correction)
+		}
+		return
+	}
+
+	h.reconcileSettledInvoice(report, invoice, h.settleInvoice)
+}
+
+// settleInvoice credits the deposit of a settled budget invoice, or marks a
+// keysend invoice as settled
+func (h *bountyHandler) settleInvoice(invoice db.NewInvoiceList) error {
+	if invoice.Type == db.Budget {
+		return h.db.ProcessUpdateBudget(invoice)
+	}
+	h.db.UpdateInvoice(invoice.PaymentRequest)
+	return nil
+}
+
+// reconcileSettledInvoice credits or marks a pending invoice that is known to be settled,
+// by the lightning backend or by a payment confirmation from the relay. settle applies
+// the settlement once the invoice is known to be creditable
+func (h *bountyHandler) reconcileSettledInvoice(report *db.PaymentReconciliationReport, invoice db.NewInvoiceList, settle func(invoice db.NewInvoiceList) error) {
+	correction := db.PaymentCorrection{
+		PaymentRequest: invoice.PaymentRequest,
+		WorkspaceUuid:  invoice.WorkspaceUuid,
+	}
+
+	switch invoice.Type {
+	case db.Budget:
+		deposit := h.db.GetPaymentHistoryByCreated(invoice.Created, invoice.WorkspaceUuid)
+		if deposit.WorkspaceUuid == "" || deposit.Amount == 0 {
+			correction.Action = db.PaymentNeedsReview
+			correction.Detail = "budget invoice is settled but no deposit was recorded for it"
+			report.Unresolved = append(report.Unresolved, correction)
+			return
+		}
+
+		correction.Amount = deposit.Amount
+		if err := settle(invoice); err != nil {
+			correction.Action = db.PaymentNeedsReview
+			correction.Detail = "budget invoice is settled but the deposit could not be credited"
+			correction.Error = err.Error()
+			report.Unresolved = append(report.Unresolved, correction)
+			return
+		}
+		correction.Action = db.PaymentBudgetCredited
+		correction.Detail = "settled budget invoice was credited to the workspace budget"
+		report.Corrections = append(report.Corrections, correction)
+	case db.Keysend:
+		invData := h.db.GetUserInvoiceData(invoice.PaymentRequest)
+		correction.Amount = invData.Amount
+
+		bounty, err := h.db.GetBountyByCreated(uint(invData.Created))
+		if err != nil || !bounty.Paid {
+			correction.Action = db.PaymentNeedsReview
+			correction.BountyId = bounty.ID
+			correction.Detail = "invoice is settled but the bounty was not paid out"
+			report.Unresolved = append(report.Unresolved, correction)
+			return
+		}
+
+		correction.BountyId = bounty.ID
+		if err := settle(invoice); err != nil {
+			correction.Action = db.PaymentNeedsReview
+			correction.Detail = "invoice is settled but could not be marked as settled"
+			correction.Error = err.Error()
+			report.Unresolved = append(report.Unresolved, correction)
+			return
+		}
+		correction.Action = db.PaymentInvoiceSettled
+		correction.Detail = "bounty was already paid out, the invoice was marked as settled"
+		report.Corrections = append(report.Corrections, correction)
+	default:
+		correction.Action = db.PaymentNeedsReview
+		correction.Detail = fmt.Sprintf("invoice of type %q is settled but was never processed", invoice.Type)
+		report.Unresolved = append(report.Unresolved, correction)
+	}
+}
+
+func GetFilterCount(w http.ResponseWriter, r *http.Request) {
+	filterCount := db.DB.GetFilterStatusCount()
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(filterCount)
+}
+
+// canManageBounty checks if the user owns the bounty or can manage
+// the bounties of its workspace
+func (h *bountyHandler) canManageBounty(pubKeyFromAuth string, bounty db.NewBounty) bool {
+	if pubKeyFromAuth == bounty.OwnerID {
+		return true
+	}
+	if bounty.WorkspaceUuid != "" {
+		return h.userHasManageBountyRoles(pubKeyFromAuth, bounty.WorkspaceUuid)
+	}
+	return false
+}
+
+// visibleBounties leaves out the bounties the request's pubkey can't see
+func (h *bountyHandler) visibleBounties(r *http.Request, bounties []db.NewBounty) []db.NewBounty {
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+
+	visible := []db.NewBounty{}
+	for _, bounty := range bounties {
+		member := false
+		if bounty.Visibility == db.BountyVisibilityWorkspace && pubKeyFromAuth != "" && bounty.WorkspaceUuid != "" {
+			member = h.db.GetWorkspaceUser(pubKeyFromAuth, bounty.WorkspaceUuid).ID != 0 ||
+				h.db.GetWorkspaceByUuid(bounty.WorkspaceUuid).OwnerPubKey == pubKeyFromAuth
+		}
+		if db.BountyVisibleTo(bounty, pubKeyFromAuth, member) {
+			visible = append(visible, bounty)
+		}
+	}
+	return visible
+}
+
+// hasJsonField tells if a JSON object has a top level field
+func hasJsonField(body []byte, field string) bool {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return false
+	}
+	_, ok := fields[field]
+	return ok
+}
+
+// wipLimitReached tells if a hunter holds as many unfinished bounties in a
+// workspace as its settings allow, and returns the limit
+func (h *bountyHandler) wipLimitReached(workspaceUuid string, assignee string) (uint, bool) {
+	if workspaceUuid == "" || assignee == "" {
+		return 0, false
+	}
+	limit := h.db.GetWorkspaceSettings(workspaceUuid).WipLimit
+	if limit == 0 {
+		return 0, false
+	}
+	return limit, h.db.GetWorkspaceActiveBountiesCount(workspaceUuid, assignee) >= int64(limit)
+}
+
+// stateWipLimitsExceeded returns the state limits of the workspace a bounty moving
+// to state in phaseUuid would go over, with their current counts. Members with
+// the override role can go over the limits
+func (h *bountyHandler) stateWipLimitsExceeded(actor string, workspaceUuid string, phaseUuid string, state db.BountyState) []db.WipLimitCount {
+	if workspaceUuid == "" {
+		return nil
+	}
+
+	exceeded := []db.WipLimitCount{}
+	for _, limit := range h.db.GetWorkspaceSettings(workspaceUuid).StateWipLimits {
+		if limit.State != state || (limit.PhaseUuid != "" && limit.PhaseUuid != phaseUuid) {
+			continue
+		}
+		count := h.db.GetWorkspaceBountiesInStateCount(workspaceUuid, limit.PhaseUuid, state)
+		if count >= int64(limit.Limit) {
+			exceeded = append(exceeded, db.WipLimitCount{StateWipLimit: limit, Count: count})
+		}
+	}
+	if len(exceeded) == 0 || h.userHasAccess(actor, workspaceUuid, db.OverrideWipLimits) {
+		return nil
+	}
+	return exceeded
+}
+
+func writeStateWipLimitsExceeded(w http.ResponseWriter, state db.BountyState, exceeded []db.WipLimitCount) {
+	w.WriteHeader(http.StatusConflict)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error":  fmt.Sprintf("The wip limit of %s bounties is reached", strings.ReplaceAll(string(state), "_", " ")),
+		"limits": exceeded,
+	})
+}
+
+func (h *bountyHandler) getBountyFromParam(w http.ResponseWriter, r *http.Request) (db.NewBounty, bool) {
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Not a valid bounty id")
+		return db.NewBounty{}, false
+	}
+
+	bounty := h.db.GetBounty(id)
+	if bounty.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Bounty not found")
+		return bounty, false
+	}
+
+	return bounty, true
+}
+
+func (h *bountyHandler) ApplyForBounty(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if bounty.Assignee != "" || bounty.Completed || bounty.Paid {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Bounty is not open for applications")
+		return
+	}
+
+	if bounty.OwnerID == pubKeyFromAuth {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Cannot apply for your own bounty")
+		return
+	}
+
+	if _, err := h.db.GetActiveBountyApplication(bounty.ID, pubKeyFromAuth); err == nil {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("You already applied for this bounty")
+		return
+	}
+
+	application := db.BountyApplication{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &application)
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	application.BountyID = bounty.ID
+	application.ApplicantPubkey = pubKeyFromAuth
+
+	a, err := h.db.CreateBountyApplication(application)
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(a)
+}
+
+func (h *bountyHandler) WithdrawBountyApplication(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	application, err := h.db.GetActiveBountyApplication(bounty.ID, pubKeyFromAuth)
+	if err != nil || application.Status != db.BountyApplicationPending {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("No pending application found")
+		return
+	}
+
+	a, err := h.db.UpdateBountyApplicationStatus(application.ID, db.BountyApplicationWithdrawn)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(a)
+}
+
+func (h *bountyHandler) GetBountyApplicants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.canManageBounty(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to view the bounty applicants")
+		return
+	}
+
+	applications := h.db.GetBountyApplications(bounty.ID)
+	applicants := []db.BountyApplicationResponse{}
+	pubkeys := []string{}
+	for _, application := range applications {
+		pubkeys = append(pubkeys, application.ApplicantPubkey)
+	}
+	people := map[string]db.Person{}
+	if len(pubkeys) > 0 {
+		people = h.db.GetPeopleByPubkeys(pubkeys)
+	}
+	for _, application := range applications {
+		person := people[application.ApplicantPubkey]
+		applicants = append(applicants, db.BountyApplicationResponse{
+			BountyApplication: application,
+			Applicant: db.PersonInShort{
+				ID:          person.ID,
+				Uuid:        person.Uuid,
+				OwnerPubKey: person.OwnerPubKey,
+				OwnerAlias:  person.OwnerAlias,
+				UniqueName:  person.UniqueName,
+				Img:         person.Img,
+			},
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(applicants)
+}
+
+func (h *bountyHandler) getApplicationFromParam(w http.ResponseWriter, r *http.Request, bounty db.NewBounty) (db.BountyApplication, bool) {
+	applicationId, err := utils.ConvertStringToUint(chi.URLParam(r, "applicationId"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Not a valid application id")
+		return db.BountyApplication{}, false
+	}
+
+	application, err := h.db.GetBountyApplicationById(applicationId)
+	if err != nil || application.BountyID != bounty.ID {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Application not found")
+		return application, false
+	}
+
+	if application.Status != db.BountyApplicationPending {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Application is not pending")
+		return application, false
+	}
+
+	return application, true
+}
+
+// AcceptBountyApplication assigns the bounty to the applicant
+func (h *bountyHandler) AcceptBountyApplication(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.canManageBounty(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to accept bounty applicants")
+		return
+	}
+
+	if bounty.Assignee != "" {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("Bounty is already assigned")
+		return
+	}
+
+	application, ok := h.getApplicationFromParam(w, r, bounty)
+	if !ok {
+		return
+	}
+
+	if limit, reached := h.wipLimitReached(bounty.WorkspaceUuid, application.ApplicantPubkey); reached {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(fmt.Sprintf("The applicant already has %d unfinished bounties in this workspace", limit))
+		return
+	}
+	if exceeded := h.stateWipLimitsExceeded(pubKeyFromAuth, bounty.WorkspaceUuid, bounty.PhaseUuid, db.BountyStateAssigned); len(exceeded) > 0 {
+		writeStateWipLimitsExceeded(w, db.BountyStateAssigned, exceeded)
+		return
+	}
+
+	b, err := h.db.AcceptBountyApplication(application)
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(b)
+}
+
+func (h *bountyHandler) RejectBountyApplication(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.canManageBounty(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to reject bounty applicants")
+		return
+	}
+
+	application, ok := h.getApplicationFromParam(w, r, bounty)
+	if !ok {
+		return
+	}
+
+	a, err := h.db.UpdateBountyApplicationStatus(application.ID, db.BountyApplicationRejected)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(a)
+}
+
+// openBountyAuction returns the auction of a bounty that is still taking bids or a selection
+func (h *bountyHandler) openBountyAuction(w http.ResponseWriter, bounty db.NewBounty) (db.BountyAuction, bool) {
+	auction, err := h.db.GetBountyAuction(bounty.ID)
+	if err != nil || (auction.Status != db.BountyAuctionOpen && auction.Status != db.BountyAuctionAwaitingSelection) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("No open auction found")
+		return auction, false
+	}
+	return auction, true
+}
+
+// CreateBountyAuction opens a bounty for bids until ends_at
+func (h *bountyHandler) CreateBountyAuction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.canManageBounty(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to auction the bounty")
+		return
+	}
+
+	if bounty.Assignee != "" || bounty.Completed || bounty.Paid {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Only open bounties can be auctioned")
+		return
+	}
+
+	if auction, err := h.db.GetBountyAuction(bounty.ID); err == nil &&
+		(auction.Status == db.BountyAuctionOpen || auction.Status == db.BountyAuctionAwaitingSelection) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("Bounty already has an open auction")
+		return
+	}
+
+	auction := db.BountyAuction{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &auction); err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if auction.EndsAt == nil || !auction.EndsAt.After(time.Now()) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("ends_at should be in the future")
+		return
+	}
+
+	auction.BountyID = bounty.ID
+	auction.CreatedBy = pubKeyFromAuth
+	auction.SelectedBidID = nil
+
+	a, err := h.db.CreateBountyAuction(auction)
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(a)
+}
+
+// GetBountyAuction returns the auction of a bounty, the bids are only
+// all listed for its managers, bidders see their own
+func (h *bountyHandler) GetBountyAuction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	auction, err := h.db.GetBountyAuction(bounty.ID)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("No auction found")
+		return
+	}
+
+	canManage := h.canManageBounty(pubKeyFromAuth, bounty)
+	bids := []db.BountyBid{}
+	for _, bid := range h.db.GetBountyBids(auction.ID) {
+		if canManage || bid.BidderPubkey == pubKeyFromAuth {
+			bids = append(bids, bid)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.BountyAuctionResponse{BountyAuction: auction, Bids: bids})
+}
+
+// PlaceBountyBid bids on an open auction, a new bid replaces the hunter's previous one
+func (h *bountyHandler) PlaceBountyBid(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if bounty.OwnerID == pubKeyFromAuth {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Cannot bid on your own bounty")
+		return
+	}
+
+	auction, ok := h.openBountyAuction(w, bounty)
+	if !ok {
+		return
+	}
+	if auction.Status != db.BountyAuctionOpen || !time.Now().Before(*auction.EndsAt) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("The auction is not taking bids anymore")
+		return
+	}
+
+	bid := db.BountyBid{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &bid); err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if bid.Amount == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Bid amount should be more than 0")
+		return
+	}
+
+	bid.AuctionID = auction.ID
+	bid.BidderPubkey = pubKeyFromAuth
+
+	b, err := h.db.PlaceBountyBid(bid)
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(b)
+}
+
+func (h *bountyHandler) WithdrawBountyBid(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	auction, ok := h.openBountyAuction(w, bounty)
+	if !ok {
+		return
+	}
+
+	if err := h.db.WithdrawBountyBid(auction.ID, pubKeyFromAuth); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("No active bid found")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Bid withdrawn")
+}
+
+// SelectBountyBid picks the winner of an auction, it is assigned when the
+// auction closes, or right away if the auction already ended
+func (h *bountyHandler) SelectBountyBid(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.canManageBounty(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to select the auction winner")
+		return
+	}
+
+	auction, ok := h.openBountyAuction(w, bounty)
+	if !ok {
+		return
+	}
+
+	bidId, err := utils.ConvertStringToUint(chi.URLParam(r, "bidId"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Not a valid bid id")
+		return
+	}
+
+	bid, err := h.db.GetBountyBidById(bidId)
+	if err != nil || bid.AuctionID != auction.ID || bid.Status != db.BountyBidActive {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Bid not found")
+		return
+	}
+
+	if limit, reached := h.wipLimitReached(bounty.WorkspaceUuid, bid.BidderPubkey); reached {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(fmt.Sprintf("The bidder already has %d unfinished bounties in this workspace", limit))
+		return
+	}
+	if exceeded := h.stateWipLimitsExceeded(pubKeyFromAuth, bounty.WorkspaceUuid, bounty.PhaseUuid, db.BountyStateAssigned); len(exceeded) > 0 {
+		writeStateWipLimitsExceeded(w, db.BountyStateAssigned, exceeded)
+		return
+	}
+
+	if auction.Status == db.BountyAuctionOpen {
+		if err := h.db.SelectBountyBid(auction.ID, bid.ID); err != nil {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(err.Error())
+			return
+		}
+		auction.SelectedBidID = &bid.ID
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(auction)
+		return
+	}
+
+	b, err := h.db.CloseBountyAuction(auction, bid.ID)
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(b)
+}
+
+// RecordBountyActivity lets the assignee show progress, it resets the stale bounty timer
+func (h *bountyHandler) RecordBountyActivity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if bounty.Assignee != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the assignee can record bounty activity")
+		return
+	}
+
+	if err := h.db.RecordBountyActivity(bounty.ID); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Bounty activity recorded"})
+}
+
+// MaxLoggedHours is the most time a single hours log can record
+const MaxLoggedHours = 24
+
+// LogBountyHours records time the assignee spent on a bounty
+func (h *bountyHandler) LogBountyHours(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if bounty.Assignee != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the assignee can log hours")
+		return
+	}
+
+	if bounty.Paid {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Cannot log hours on a paid bounty")
+		return
+	}
+
+	request := db.BountyHoursLogRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	err = json.Unmarshal(body, &request)
+	if err != nil {
+		fmt.Println("[bounty] could not parse hours log", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if request.Hours <= 0 || request.Hours > MaxLoggedHours {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Hours must be more than 0 and at most %d", MaxLoggedHours))
+		return
+	}
+
+	log, err := h.db.CreateBountyHoursLog(db.BountyHoursLog{
+		BountyID: bounty.ID,
+		Pubkey:   pubKeyFromAuth,
+		Hours:    request.Hours,
+		Note:     request.Note,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(log)
+}
+
+// GetBountyEffort returns the estimate of a bounty with the hours logged against it
+func (h *bountyHandler) GetBountyEffort(w http.ResponseWriter, r *http.Request) {
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	logs := h.db.GetBountyHoursLogs(bounty.ID)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.BountyEffort{
+		BountyID:       bounty.ID,
+		EstimatedHours: bounty.EstimatedHours,
+		ActualHours:    db.TotalLoggedHours(logs),
+		Logs:           logs,
+	})
+}
+
+func (h *bountyHandler) GetBountyAssigneeHistory(w http.ResponseWriter, r *http.Request) {
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	history := h.db.GetBountyAssigneeHistory(bounty.ID)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(history)
+}
+
+// GetBountyReceipt returns the signed payment receipt of a paid bounty
+func (h *bountyHandler) GetBountyReceipt(w http.ResponseWriter, r *http.Request) {
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	receipt, err := h.db.GetBountyReceipt(bounty.ID)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.BountyReceiptResponse{
+		Receipt:   json.RawMessage(receipt.Receipt),
+		Signature: receipt.Signature,
+	})
+}
+
+func VerifyBountyReceipt(w http.ResponseWriter, r *http.Request) {
+	request := db.BountyReceiptResponse{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &request)
+	if err != nil || len(request.Receipt) == 0 {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]bool{"valid": db.VerifyReceipt(request.Receipt, request.Signature)})
+}
+
+// canTransitionBounty tells if a user can move a bounty to a state, the owner and
+// workspace members who can update bounties can make any move, the assignee can
+// submit the bounty for review or give it up
+func (h *bountyHandler) canTransitionBounty(pubkey string, bounty db.NewBounty, to db.BountyState) bool {
+	if pubkey == bounty.OwnerID {
+		return true
+	}
+	if pubkey == bounty.Assignee && (to == db.BountyStateInReview || to == db.BountyStateOpen) {
+		return true
+	}
+	return bounty.WorkspaceUuid != "" && h.userHasAccess(pubkey, bounty.WorkspaceUuid, db.UpdateBounty)
+}
+
+// TransitionBounty moves a bounty to the state in the request when the move is allowed
+func (h *bountyHandler) TransitionBounty(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.BountyTransitionRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if _, ok := db.BountyTransitions[request.State]; !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Unknown bounty state %q", request.State))
+		return
+	}
+	if request.State == db.BountyStatePaid {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A bounty is marked paid by its payment")
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.canTransitionBounty(pubKeyFromAuth, bounty, request.State) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to move this bounty")
+		return
+	}
+	if request.State == db.BountyStateAssigned && request.Assignee == "" && bounty.Assignee == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("An assignee is required to assign the bounty")
+		return
+	}
+	if request.State == db.BountyStateAssigned && request.Assignee != "" && request.Assignee != bounty.Assignee {
+		if limit, reached := h.wipLimitReached(bounty.WorkspaceUuid, request.Assignee); reached {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(fmt.Sprintf("The hunter already has %d unfinished bounties in this workspace", limit))
+			return
+		}
+	}
+	if request.State != bounty.CurrentState() {
+		if exceeded := h.stateWipLimitsExceeded(pubKeyFromAuth, bounty.WorkspaceUuid, bounty.PhaseUuid, request.State); len(exceeded) > 0 {
+			writeStateWipLimitsExceeded(w, request.State, exceeded)
+			return
+		}
+	}
+
+	bounty, transition, err := h.db.TransitionBountyState(bounty.ID, request, pubKeyFromAuth)
+	var invalid db.InvalidBountyTransitionError
+	if errors.As(err, &invalid) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(invalid.Error())
+		return
+	}
+	if errors.Is(err, db.ErrBountyNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[bounty] could not transition bounty", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	for _, hook := range h.transitionHooks {
+		hook(bounty, transition)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bounty)
+}
+
+func (h *bountyHandler) GetBountyStateTransitions(w http.ResponseWriter, r *http.Request) {
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	transitions := h.db.GetBountyStateTransitions(bounty.ID)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(transitions)
+}
+
+// notifyBountyTransition tells the owner and the assignee of a bounty
+// that it moved, unless they moved it themselves
+func (h *bountyHandler) notifyBountyTransition(bounty db.NewBounty, transition db.BountyStateTransition) {
+	recipients := []string{bounty.OwnerID}
+	if bounty.Assignee != "" && bounty.Assignee != bounty.OwnerID {
+		recipients = append(recipients, bounty.Assignee)
+	}
+
+	state := strings.ReplaceAll(string(transition.ToState), "_", " ")
+	message := fmt.Sprintf("%s moved from %s to %s", bounty.Title, strings.ReplaceAll(string(transition.FromState), "_", " "), state)
+	for _, pubkey := range recipients {
+		if pubkey == "" || pubkey == transition.Actor {
+			continue
+		}
+		h.db.CreateNotification(db.Notification{
+			RecipientPubkey: pubkey,
+			WorkspaceUuid:   bounty.WorkspaceUuid,
+			Type:            db.BountyStateChangedNotification,
+			Title:           "Bounty " + state,
+			Message:         message,
+			Link:            fmt.Sprintf("/bounty/%d", bounty.ID),
+		})
+	}
+
+	if bounty.Visibility != db.BountyVisibilityHidden {
+		PublishWorkspaceEvent(db.WorkspaceEvent{
+			WorkspaceUuid: bounty.WorkspaceUuid,
+			Type:          db.BountyStateChangedEvent,
+			Title:         "Bounty " + state,
+			Message:       message,
+			Link:          fmt.Sprintf("%s/bounty/%d", config.Host, bounty.ID),
+			Actor:         transition.Actor,
+		})
+	}
+}
+
+// MaxBountyCommentLength is the longest a bounty comment can be
+const MaxBountyCommentLength = 10000
+
+func (h *bountyHandler) GetBountyComments(w http.ResponseWriter, r *http.Request) {
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	comments := h.db.GetBountyComments(bounty.ID)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(comments)
+}
+
+// CreateBountyComment adds a comment to a bounty. A comment posted with a workspace
+// api token is a note of a review agent, anyone else comments as a human
+func (h *bountyHandler) CreateBountyComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	request := db.BountyCommentRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	request.Body = strings.TrimSpace(request.Body)
+	if request.Body == "" || len(request.Body) > MaxBountyCommentLength {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("A comment must have between 1 and %d characters", MaxBountyCommentLength))
+		return
+	}
+
+	comment := db.BountyComment{
+		BountyID:     bounty.ID,
+		AuthorPubkey: pubKeyFromAuth,
+		AuthorType:   db.CommentAuthorHuman,
+		Body:         request.Body,
+	}
+
+	if apiToken, ok := auth.ApiTokenFromContext(ctx); ok {
+		if bounty.WorkspaceUuid != apiToken.WorkspaceUuid {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode("Api token is not valid for this bounty")
+			return
+		}
+		comment.AgentName = strings.TrimSpace(request.AgentName)
+		if comment.AgentName == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("An agent note needs the agent_name")
+			return
+		}
+		comment.AuthorType = db.CommentAuthorAIAgent
+
+		quota := h.db.GetWorkspaceSettings(bounty.WorkspaceUuid).AiAgentMonthlyQuota
+		if quota > 0 {
+			now := time.Now().UTC()
+			monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+			if h.db.GetWorkspaceAgentCommentsCount(bounty.WorkspaceUuid, monthStart) >= int64(quota) {
+				w.WriteHeader(http.StatusTooManyRequests)
+				json.NewEncoder(w).Encode(fmt.Sprintf("The workspace has used its %d agent notes for this month", quota))
+				return
+			}
+		}
+	} else if !bounty.Show && pubKeyFromAuth != bounty.Assignee && !h.canManageBounty(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to comment on this bounty")
+		return
+	}
+
+	comment, err = h.db.CreateBountyComment(comment)
+	if err != nil {
+		fmt.Println("[bounty] could not create comment", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(comment)
+}
+
+// DeleteBountyComment removes a comment, by its author or whoever manages the bounty
+func (h *bountyHandler) DeleteBountyComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	commentId, err := utils.ConvertStringToUint(chi.URLParam(r, "commentId"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Not a valid comment id")
+		return
+	}
+
+	comment, err := h.db.GetBountyComment(bounty.ID, commentId)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	isAuthor := comment.AuthorType == db.CommentAuthorHuman && comment.AuthorPubkey == pubKeyFromAuth
+	if !isAuthor && !h.canManageBounty(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to delete this comment")
+		return
+	}
+
+	if err := h.db.DeleteBountyComment(comment.ID); err != nil {
+		fmt.Println("[bounty] could not delete comment", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Comment deleted")
 }
@@ -0,0 +1,439 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/nostr"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+func InitStaleBountyCron() {
+	s := gocron.NewScheduler(time.UTC)
+
+	s.Every(1).Hour().Do(func() {
+		ProcessStaleBounties(db.DB, time.Now(), db.SendAlertDm)
+	})
+
+	s.StartAsync()
+}
+
+func InitBountyDueReminderCron() {
+	s := gocron.NewScheduler(time.UTC)
+
+	s.Every(15).Minutes().Do(func() {
+		ProcessBountyDueReminders(db.DB, time.Now(), db.SendAlertDm)
+	})
+
+	s.StartAsync()
+}
+
+func InitBountyAuctionCron() {
+	s := gocron.NewScheduler(time.UTC)
+
+	s.Every(1).Minute().Do(func() {
+		CloseDueBountyAuctions(db.DB, time.Now(), db.SendAlertDm)
+	})
+
+	s.StartAsync()
+}
+
+func InitOnchainPaymentCron() {
+	s := gocron.NewScheduler(time.UTC)
+
+	s.Every(5).Minutes().Do(func() {
+		TrackOnchainPayments(db.DB, NewOnchainService(utils.TracedClient), time.Now())
+	})
+
+	s.StartAsync()
+}
+
+func InitNostrBountyCron() {
+	if !config.NostrEnabled() {
+		return
+	}
+	key, err := nostr.ParseKey(config.NostrPrivateKey)
+	if err != nil {
+		fmt.Println("[nostr] could not read NOSTR_PRIVATE_KEY", err)
+		return
+	}
+	publisher := nostr.NewRelayPublisher(config.NostrRelays)
+	s := gocron.NewScheduler(time.UTC)
+
+	s.Every(5).Minutes().Do(func() {
+		SyncNostrBounties(db.DB, publisher, key, time.Now())
+	})
+
+	s.StartAsync()
+}
+
+func InitLeaderboardCron() {
+	s := gocron.NewScheduler(time.UTC)
+
+	// runs once at start and then every hour
+	s.Every(1).Hour().Do(func() {
+		if err := db.DB.RefreshLeaderboardAggregates(); err != nil {
+			fmt.Println("[leaderboard] could not refresh aggregates", err)
+		}
+		if err := db.DB.RefreshBountyPriceStats(); err != nil {
+			fmt.Println("[price suggestions] could not refresh price stats", err)
+		}
+	})
+
+	s.StartAsync()
+}
+
+func InitBudgetLedgerCron() {
+	s := gocron.NewScheduler(time.UTC)
+
+	s.Every(1).Hour().Do(func() {
+		CheckBudgetLedger(db.DB)
+	})
+
+	s.StartAsync()
+}
+
+// InitFeatureCountsCron verifies the bounty counts of features every night
+func InitFeatureCountsCron() {
+	s := gocron.NewScheduler(time.UTC)
+
+	s.Every(1).Day().At("03:00").Do(func() {
+		CheckFeatureCounts(db.DB)
+	})
+
+	s.StartAsync()
+}
+
+// InitBudgetSnapshotCron records the budget of every workspace for the
+// current day, the last run of a day leaves its closing balance
+func InitBudgetSnapshotCron() {
+	s := gocron.NewScheduler(time.UTC)
+
+	s.Every(1).Hour().Do(func() {
+		if err := db.DB.SnapshotWorkspaceBudgets(time.Now().UTC().Format(usageDayLayout)); err != nil {
+			fmt.Println("[budget] could not snapshot budgets", err)
+		}
+	})
+
+	s.StartAsync()
+}
+
+func InitWorkspaceRetentionCron() {
+	s := gocron.NewScheduler(time.UTC)
+
+	s.Every(1).Hour().Do(func() {
+		PurgeDeletedWorkspaces(db.DB, time.Now())
+	})
+
+	s.StartAsync()
+}
+
+func InitConfigReloadCron() {
+	s := gocron.NewScheduler(time.UTC)
+
+	s.Every(config.ConfigReloadSeconds).Seconds().Do(func() {
+		ReloadConfigOverrides(db.DB)
+	})
+
+	s.StartAsync()
+}
+
+// InitUsageFlushCron adds the usage every instance counted to the daily rollups
+func InitUsageFlushCron() {
+	s := gocron.NewScheduler(time.UTC)
+
+	s.Every(1).Minute().Do(func() {
+		FlushWorkspaceUsage(db.DB)
+	})
+
+	s.StartAsync()
+}
+
+func InitSecretsRotationCron() {
+	if config.SecretsRefreshMinutes == 0 {
+		return
+	}
+	s := gocron.NewScheduler(time.UTC)
+
+	s.Every(config.SecretsRefreshMinutes).Minutes().Do(func() {
+		changed, err := config.RotateSecrets()
+		if err != nil {
+			fmt.Println("[secrets] could not refresh secrets", err)
+			return
+		}
+		if changed {
+			fmt.Println("[secrets] applied rotated secrets")
+		}
+	})
+
+	s.StartAsync()
+}
+
+// ReloadConfigOverrides applies the overrides in the configs table,
+// a bad value is logged and its key keeps the env or default value
+func ReloadConfigOverrides(database db.Database) {
+	rows, err := database.GetConfigOverrides()
+	if err != nil {
+		fmt.Println("[config] could not read config overrides", err)
+		return
+	}
+
+	overrides := map[string]string{}
+	for _, row := range rows {
+		overrides[row.Key] = row.Value
+	}
+	changed, errs := config.ApplyOverrides(overrides)
+	for _, err := range errs {
+		fmt.Println("[config] skipped override", err)
+	}
+	if changed {
+		fmt.Printf("[config] applied %d config overrides\n", len(overrides)-len(errs))
+	}
+}
+
+// PurgeDeletedWorkspaces drops the archives of workspaces deleted longer than
+// the retention window ago, after which they cannot be restored
+func PurgeDeletedWorkspaces(database db.Database, now time.Time) {
+	purged, err := database.PurgeExpiredWorkspaceArchives(now.Add(-db.WorkspaceRetention()))
+	if err != nil {
+		fmt.Println("[workspaces] could not purge deleted workspaces", err)
+		return
+	}
+	if purged > 0 {
+		fmt.Printf("[workspaces] purged %d deleted workspaces past retention\n", purged)
+	}
+}
+
+// CheckFeatureCounts recounts the features whose stored bounty counts drifted
+// from their bounties, the triggers should keep them right so every drift is logged
+func CheckFeatureCounts(database db.Database) []db.FeatureCountDrift {
+	drifts := database.CheckFeatureBountyCounts()
+	for _, drift := range drifts {
+		fmt.Printf("[feature counts] feature %s counts open %d assigned %d completed %d but has %d %d %d, recounting\n",
+			drift.FeatureUuid, drift.StoredOpen, drift.StoredAssigned, drift.StoredCompleted,
+			drift.ActualOpen, drift.ActualAssigned, drift.ActualCompleted)
+		if err := database.SyncFeatureBountyCounts(drift.FeatureUuid); err != nil {
+			fmt.Println("[feature counts] could not recount feature", err)
+		}
+	}
+	return drifts
+}
+
+// CheckBudgetLedger logs ledger transactions that do not balance and
+// resyncs workspace budgets that drifted from their ledger balance
+func CheckBudgetLedger(database db.Database) []db.BudgetLedgerInconsistency {
+	inconsistencies := database.CheckBudgetLedgerConsistency()
+	for _, inconsistency := range inconsistencies {
+		switch inconsistency.Kind {
+		case db.LedgerUnbalancedTransaction:
+			fmt.Printf("[ledger] transaction %s does not balance, debits %d credits %d\n", inconsistency.TransactionId, inconsistency.Expected, inconsistency.Actual)
+		case db.LedgerBudgetDrift:
+			fmt.Printf("[ledger] budget of workspace %s is %d but the ledger balance is %d, resyncing\n", inconsistency.WorkspaceUuid, inconsistency.Actual, inconsistency.Expected)
+			if err := database.SyncWorkspaceBudget(inconsistency.WorkspaceUuid); err != nil {
+				fmt.Println("[ledger] could not resync budget", err)
+			}
+		}
+	}
+	return inconsistencies
+}
+
+// ProcessStaleBounties warns about assigned bounties without activity and
+// unassigns them once the warning has not been acted on
+func ProcessStaleBounties(database db.Database, now time.Time, notify func(pubkey string, content string) error) {
+	day := 24 * time.Hour
+	warnSince := now.Add(-time.Duration(config.StaleBountyWarnDays) * day)
+	unassignSince := now.Add(-time.Duration(config.StaleBountyUnassignDays) * day)
+	gracePeriod := time.Duration(config.StaleBountyUnassignDays-config.StaleBountyWarnDays) * day
+
+	bounties := database.GetStaleAssignedBounties(warnSince)
+	for _, bounty := range bounties {
+		lastActivity := bounty.AssignedDate
+		if bounty.LastActivityDate != nil {
+			lastActivity = bounty.LastActivityDate
+		}
+		if lastActivity == nil {
+			continue
+		}
+
+		bountyLink := fmt.Sprintf("%s/bounty/%d", config.Host, bounty.ID)
+
+		if bounty.StaleWarningDate == nil {
+			if err := database.SetBountyStaleWarning(bounty.ID); err != nil {
+				fmt.Println("[stale bounty] could not set warning", err)
+				continue
+			}
+
+			days := config.StaleBountyUnassignDays - config.StaleBountyWarnDays
+			notify(bounty.Assignee, fmt.Sprintf("Your bounty \"%s\" has no activity, it will be unassigned in %d days if there is no activity - %s", bounty.Title, days, bountyLink))
+			notify(bounty.OwnerID, fmt.Sprintf("The assignee of your bounty \"%s\" has been warned for inactivity - %s", bounty.Title, bountyLink))
+			continue
+		}
+
+		if lastActivity.Before(unassignSince) && now.Sub(*bounty.StaleWarningDate) >= gracePeriod {
+			reason := fmt.Sprintf("no activity for %d days", config.StaleBountyUnassignDays)
+			if err := database.UnassignStaleBounty(bounty, reason); err != nil {
+				fmt.Println("[stale bounty] could not unassign bounty", err)
+				continue
+			}
+
+			notify(bounty.Assignee, fmt.Sprintf("You have been unassigned from the bounty \"%s\" for inactivity - %s", bounty.Title, bountyLink))
+			notify(bounty.OwnerID, fmt.Sprintf("Your bounty \"%s\" has been unassigned for inactivity and is open again - %s", bounty.Title, bountyLink))
+		}
+	}
+}
+
+// dueReminderOffsets are the configured reminder times in hours from the due date,
+// negative before it, in order
+func dueReminderOffsets() []int {
+	offsets := []int{}
+	for _, hours := range config.BountyDueReminderHours {
+		offsets = append(offsets, -hours)
+	}
+	offsets = append(offsets, config.BountyOverdueReminderHours...)
+	sort.Ints(offsets)
+	return offsets
+}
+
+func formatReminderHours(hours int) string {
+	if hours%24 == 0 && hours >= 48 {
+		return fmt.Sprintf("%d days", hours/24)
+	}
+	if hours == 1 {
+		return "1 hour"
+	}
+	return fmt.Sprintf("%d hours", hours)
+}
+
+// ProcessBountyDueReminders reminds assignees of the bounties due soon and tells
+// assignees and owners about overdue ones, at the configured times. A bounty gets
+// at most one reminder a run, the latest one that is due
+func ProcessBountyDueReminders(database db.Database, now time.Time, notify func(pubkey string, content string) error) {
+	offsets := dueReminderOffsets()
+	if len(offsets) == 0 {
+		return
+	}
+	first := time.Duration(offsets[0]) * time.Hour
+	last := time.Duration(offsets[len(offsets)-1]) * time.Hour
+
+	for _, bounty := range database.GetBountiesDueBetween(now.Add(-last), now.Add(-first)) {
+		if bounty.DueDate == nil {
+			continue
+		}
+
+		due := -1
+		for i, offset := range offsets {
+			if !now.Before(bounty.DueDate.Add(time.Duration(offset) * time.Hour)) {
+				due = i
+			}
+		}
+		if due < 0 || (bounty.DueReminderHours != nil && *bounty.DueReminderHours >= offsets[due]) {
+			continue
+		}
+
+		offset := offsets[due]
+		if err := database.SetBountyDueReminder(bounty.ID, offset); err != nil {
+			fmt.Println("[due reminder] could not record reminder", err)
+			continue
+		}
+
+		bountyLink := fmt.Sprintf("%s/bounty/%d", config.Host, bounty.ID)
+		if offset < 0 {
+			recipient := bounty.Assignee
+			if recipient == "" {
+				recipient = bounty.OwnerID
+			}
+			notify(recipient, fmt.Sprintf("The bounty \"%s\" is due in %s - %s", bounty.Title, formatReminderHours(-offset), bountyLink))
+			continue
+		}
+
+		if bounty.Assignee != "" && bounty.Assignee != bounty.OwnerID {
+			notify(bounty.Assignee, fmt.Sprintf("The bounty \"%s\" you are assigned to is overdue by %s - %s", bounty.Title, formatReminderHours(offset), bountyLink))
+		}
+		notify(bounty.OwnerID, fmt.Sprintf("Your bounty \"%s\" is overdue by %s - %s", bounty.Title, formatReminderHours(offset), bountyLink))
+	}
+}
+
+// auctionWinner is the bid an ended auction goes to, the one its owner selected
+// or with auto select the lowest one
+func auctionWinner(auction db.BountyAuction, bids []db.BountyBid) (db.BountyBid, bool) {
+	if auction.SelectedBidID != nil {
+		for _, bid := range bids {
+			if bid.ID == *auction.SelectedBidID && bid.Status == db.BountyBidActive {
+				return bid, true
+			}
+		}
+	}
+	if auction.AutoSelect {
+		return db.LowestBountyBid(bids)
+	}
+	return db.BountyBid{}, false
+}
+
+// CloseDueBountyAuctions assigns the bounties of the auctions that ended to
+// their winner, auctions without a winner wait for the owner to select one
+func CloseDueBountyAuctions(database db.Database, now time.Time, notify func(pubkey string, content string) error) {
+	for _, auction := range database.GetDueBountyAuctions(now) {
+		bids := database.GetBountyBids(auction.ID)
+		bountyLink := fmt.Sprintf("%s/bounty/%d", config.Host, auction.BountyID)
+
+		winner, ok := auctionWinner(auction, bids)
+		if !ok {
+			if _, hasBids := db.LowestBountyBid(bids); hasBids {
+				if err := database.UpdateBountyAuctionStatus(auction.ID, db.BountyAuctionAwaitingSelection); err != nil {
+					fmt.Println("[bounty auction] could not update auction", err)
+					continue
+				}
+				notify(auction.CreatedBy, fmt.Sprintf("The auction of your bounty has ended, select the winning bid - %s", bountyLink))
+				continue
+			}
+		}
+
+		bounty, err := database.CloseBountyAuction(auction, winner.ID)
+		if err != nil {
+			fmt.Println("[bounty auction] could not close auction", err)
+			continue
+		}
+
+		if !ok {
+			notify(auction.CreatedBy, fmt.Sprintf("The auction of your bounty \"%s\" ended without bids - %s", bounty.Title, bountyLink))
+			continue
+		}
+		notify(winner.BidderPubkey, fmt.Sprintf("You won the auction of the bounty \"%s\" for %d sats - %s", bounty.Title, winner.Amount, bountyLink))
+		notify(auction.CreatedBy, fmt.Sprintf("The auction of your bounty \"%s\" has been won for %d sats - %s", bounty.Title, winner.Amount, bountyLink))
+	}
+}
+
+// TrackOnchainPayments records the confirmations of the on-chain payouts, they are
+// confirmed once they reach config.OnchainConfirmations. A payout that was dropped
+// reopens its bounty so it can be paid again
+func TrackOnchainPayments(database db.Database, onchain OnchainService, now time.Time) {
+	for _, payment := range database.GetUnconfirmedOnchainPayments() {
+		tx, err := onchain.GetTransaction(payment.OnchainTxid)
+		if err != nil {
+			fmt.Println("[onchain] could not get transaction", payment.OnchainTxid, err)
+			continue
+		}
+		if tx.Dropped {
+			fmt.Println("[onchain] transaction was dropped, reopening bounty", payment.BountyId, payment.OnchainTxid)
+			if err := database.DropOnchainPayment(payment, now); err != nil {
+				fmt.Println("[onchain] could not drop payment", payment.ID, err)
+			}
+			continue
+		}
+		if tx.Confirmations == payment.OnchainConfirmations {
+			continue
+		}
+
+		var confirmed *time.Time
+		if tx.Confirmations >= uint(config.OnchainConfirmations) {
+			confirmed = &now
+		}
+		if err := database.UpdateOnchainConfirmations(payment.ID, tx.Confirmations, confirmed); err != nil {
+			fmt.Println("[onchain] could not update payment", payment.ID, err)
+		}
+	}
+}
@@ -20,11 +20,14 @@ import (
 	"github.com/stakwork/sphinx-tribes/utils"
 
 	"github.com/go-chi/chi"
+	"github.com/lib/pq"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers/mocks"
 	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/rates"
+	"github.com/stakwork/sphinx-tribes/testfixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -352,7 +355,7 @@ func TestPayLightningInvoice(t *testing.T) {
 			return req.Method == http.MethodPut && expectedUrl == req.URL.String() && req.Header.Get("x-user-token") == config.RelayAuthKey && expectedBody == string(bodyByt)
 		})).Return(nil, errors.New("some-error")).Once()
 
-		success, invoicePayErr := handler.PayLightningInvoice("req-id")
+		success, invoicePayErr := handler.PayLightningInvoice(context.Background(), "req-id")
 
 		assert.Empty(t, invoicePayErr)
 		assert.Empty(t, success)
@@ -372,7 +375,7 @@ func TestPayLightningInvoice(t *testing.T) {
 			Body:       r,
 		}, nil)
 
-		success, invoicePayErr := handler.PayLightningInvoice("req-id")
+		success, invoicePayErr := handler.PayLightningInvoice(context.Background(), "req-id")
 
 		assert.False(t, invoicePayErr.Success)
 		assert.Empty(t, success)
@@ -392,7 +395,7 @@ func TestPayLightningInvoice(t *testing.T) {
 			Body:       r,
 		}, nil).Once()
 
-		success, invoicePayErr := handler.PayLightningInvoice("req-id")
+		success, invoicePayErr := handler.PayLightningInvoice(context.Background(), "req-id")
 
 		assert.Equal(t, invoicePayErr.Error, "internal server error")
 		assert.Empty(t, success)
@@ -412,7 +415,7 @@ func TestPayLightningInvoice(t *testing.T) {
 			Body:       r,
 		}, nil).Once()
 
-		success, invoicePayErr := handler.PayLightningInvoice("req-id")
+		success, invoicePayErr := handler.PayLightningInvoice(context.Background(), "req-id")
 
 		assert.False(t, success.Success)
 		assert.Empty(t, invoicePayErr)
@@ -442,7 +445,7 @@ func TestPayLightningInvoice(t *testing.T) {
 			Body:       r,
 		}, nil).Once()
 
-		success, invoicePayErr := handler.PayLightningInvoice("req-id")
+		success, invoicePayErr := handler.PayLightningInvoice(context.Background(), "req-id")
 
 		assert.Empty(t, invoicePayErr)
 		assert.EqualValues(t, expectedSuccessMsg, success)
@@ -613,9 +616,9 @@ func TestGetBountyByCreated(t *testing.T) {
 		rctx.URLParams.Add("created", "1707991475")
 		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/created/1707991475", nil)
 		mockDb.On("GetBountyDataByCreated", createdStr).Return([]db.NewBounty{bounty}, nil).Once()
-		mockDb.On("GetPersonByPubkey", "owner-1").Return(db.Person{}).Once()
-		mockDb.On("GetPersonByPubkey", "user1").Return(db.Person{}).Once()
+		mockDb.On("GetPeopleByPubkeys", []string{"owner-1", "user1"}).Return(map[string]db.Person{}).Once()
 		mockDb.On("GetWorkspaceByUuid", "work-1").Return(db.Workspace{}).Once()
+		mockDb.On("GetWorkspaceSettings", "work-1").Return(db.DefaultWorkspaceSettings("work-1")).Once()
 		handler.ServeHTTP(rr, req)
 
 		var returnedBounty []db.BountyResponse
@@ -993,6 +996,7 @@ func TestGetWorkspacePreviousBountyByCreated(t *testing.T) {
 func TestGetBountyById(t *testing.T) {
 	teardownSuite := SetupSuite(t)
 	defer teardownSuite(t)
+	testfixtures.Reset()
 
 	mockHttpClient := mocks.NewHttpClient(t)
 	bHandler := NewBountyHandler(mockHttpClient, db.TestDB)
@@ -1001,27 +1005,10 @@ func TestGetBountyById(t *testing.T) {
 		rr := httptest.NewRecorder()
 		handler := http.HandlerFunc(bHandler.GetBountyById)
 
-		now := time.Now().Unix()
-		bounty := db.NewBounty{
-			Type:          "coding",
-			Title:         "Bounty With ID",
-			Description:   "Bounty ID description",
-			WorkspaceUuid: "",
-			Assignee:      "",
-			OwnerID:       bountyOwner.OwnerPubKey,
-			Show:          true,
-			Created:       now,
-		}
-
-		db.TestDB.CreateOrEditBounty(bounty)
-
-		bountyInDb, err := db.TestDB.GetBountyByCreated(uint(bounty.Created))
-		assert.NoError(t, err)
-		assert.NotNil(t, bountyInDb)
-
+		bountyId := strconv.Itoa(int(testfixtures.OpenBounty.ID))
 		rctx := chi.NewRouteContext()
-		rctx.URLParams.Add("bountyId", strconv.Itoa(int(bountyInDb.ID)))
-		req, err := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/bounty/"+strconv.Itoa(int(bountyInDb.ID)), nil)
+		rctx.URLParams.Add("bountyId", bountyId)
+		req, err := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/bounty/"+bountyId, nil)
 		assert.NoError(t, err)
 
 		handler.ServeHTTP(rr, req)
@@ -1030,7 +1017,8 @@ func TestGetBountyById(t *testing.T) {
 		err = json.Unmarshal(rr.Body.Bytes(), &returnedBounty)
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rr.Code)
-		assert.NotEmpty(t, returnedBounty)
+		assert.Len(t, returnedBounty, 1)
+		assert.Equal(t, testfixtures.OpenBounty.Title, returnedBounty[0].Bounty.Title)
 	})
 
 	t.Run("bounty not found", func(t *testing.T) {
@@ -1051,6 +1039,7 @@ func TestGetBountyById(t *testing.T) {
 func TestGetBountyIndexById(t *testing.T) {
 	teardownSuite := SetupSuite(t)
 	defer teardownSuite(t)
+	testfixtures.Reset()
 
 	mockHttpClient := mocks.NewHttpClient(t)
 	bHandler := NewBountyHandler(mockHttpClient, db.TestDB)
@@ -1059,30 +1048,12 @@ func TestGetBountyIndexById(t *testing.T) {
 		rr := httptest.NewRecorder()
 		handler := http.HandlerFunc(bHandler.GetBountyIndexById)
 
-		now := time.Now().Unix()
-		bounty := db.NewBounty{
-			ID:            1,
-			Type:          "coding",
-			Title:         "Bounty With ID",
-			Description:   "Bounty description",
-			WorkspaceUuid: "",
-			Assignee:      "",
-			OwnerID:       bountyOwner.OwnerPubKey,
-			Show:          true,
-			Created:       now,
-		}
-
-		db.TestDB.CreateOrEditBounty(bounty)
-
-		bountyInDb, err := db.TestDB.GetBountyByCreated(uint(bounty.Created))
-		assert.Equal(t, bounty, bountyInDb)
-		assert.NoError(t, err)
-
-		bountyIndex := db.TestDB.GetBountyIndexById(strconv.Itoa(int(bountyInDb.ID)))
+		bountyId := strconv.Itoa(int(testfixtures.AssignedBounty.ID))
+		bountyIndex := db.TestDB.GetBountyIndexById(bountyId)
 
 		rctx := chi.NewRouteContext()
-		rctx.URLParams.Add("bountyId", strconv.Itoa(int(bountyInDb.ID)))
-		req, err := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/index/"+strconv.Itoa(int(bountyInDb.ID)), nil)
+		rctx.URLParams.Add("bountyId", bountyId)
+		req, err := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/index/"+bountyId, nil)
 		assert.NoError(t, err)
 
 		handler.ServeHTTP(rr, req)
@@ -1330,6 +1301,7 @@ func TestMakeBountyPayment(t *testing.T) {
 			Assignee:      "assignee-1",
 			Paid:          false,
 		}, nil)
+		mockDb.On("GetWorkspaceSettings", "work-1").Return(db.DefaultWorkspaceSettings("work-1"))
 		mockDb.On("GetWorkspaceBudget", "work-1").Return(db.NewBountyBudget{
 			TotalBudget: 500,
 		}, nil)
@@ -1355,9 +1327,13 @@ func TestMakeBountyPayment(t *testing.T) {
 		bHandler.userHasAccess = mockUserHasAccessTrue
 
 		mockDb.On("GetBounty", bountyID).Return(bounty, nil)
+		reservation := db.BudgetReservation{Id: "reservation-1", WorkspaceUuid: bounty.WorkspaceUuid, Amount: bounty.Price}
+		mockDb.On("GetWorkspaceSettings", bounty.WorkspaceUuid).Return(db.DefaultWorkspaceSettings(bounty.WorkspaceUuid))
 		mockDb.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb.On("HasUnsettledBountyPayment", bountyID).Return(false)
+		mockDb.On("ReserveBudget", bounty.WorkspaceUuid, bounty.Price).Return(reservation, nil)
 		mockDb.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1", OwnerRouteHint: "OwnerRouteHint"}, nil)
-		mockDb.On("ProcessBountyPayment", mock.AnythingOfType("db.NewPaymentHistory"), mock.AnythingOfType("db.NewBounty")).Return(nil)
+		mockDb.On("ProcessBountyPayment", mock.AnythingOfType("db.NewPaymentHistory"), mock.AnythingOfType("db.NewBounty"), reservation).Return(nil)
 
 		expectedUrl := fmt.Sprintf("%s/payment", config.RelayUrl)
 		expectedBody := `{"amount": 1000, "destination_key": "assignee-1", "route_hint": "OwnerRouteHint", "text": "memotext added for notification"}`
@@ -1398,13 +1374,23 @@ func TestMakeBountyPayment(t *testing.T) {
 		bHandler2.userHasAccess = mockUserHasAccessTrue
 
 		mockDb2.On("GetBounty", bountyID).Return(bounty, nil)
+		reservation := db.BudgetReservation{Id: "reservation-1", WorkspaceUuid: bounty.WorkspaceUuid, Amount: bounty.Price}
+		mockDb2.On("GetWorkspaceSettings", bounty.WorkspaceUuid).Return(db.DefaultWorkspaceSettings(bounty.WorkspaceUuid))
 		mockDb2.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb2.On("HasUnsettledBountyPayment", bountyID).Return(false)
+		mockDb2.On("ReserveBudget", bounty.WorkspaceUuid, bounty.Price).Return(reservation, nil)
+		mockDb2.On("ReleaseBudget", reservation).Return(nil).Once()
 		mockDb2.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1", OwnerRouteHint: "OwnerRouteHint"}, nil)
+		mockDb2.On("GetWorkspaceByUuid", bounty.WorkspaceUuid).Return(db.Workspace{Uuid: bounty.WorkspaceUuid, OwnerPubKey: "owner-pubkey"})
+		mockDb2.On("GetWorkspaceUsers", bounty.WorkspaceUuid).Return([]db.WorkspaceUsersData{}, nil)
+		mockDb2.On("CreateNotification", mock.MatchedBy(func(n db.Notification) bool {
+			return n.RecipientPubkey == "owner-pubkey" && n.Type == db.PaymentFailedNotification
+		})).Return(db.Notification{}, nil).Once()
 
 		expectedUrl := fmt.Sprintf("%s/payment", config.RelayUrl)
 		expectedBody := `{"amount": 1000, "destination_key": "assignee-1", "route_hint": "OwnerRouteHint", "text": "memotext added for notification"}`
 
-		r := io.NopCloser(bytes.NewReader([]byte(`"internal server error"`)))
+		r := io.NopCloser(bytes.NewReader([]byte(`{"success": false, "error": "no route to the hunter"}`)))
 		mockHttpClient2.On("Do", mock.MatchedBy(func(req *http.Request) bool {
 			bodyByt, _ := io.ReadAll(req.Body)
 			return req.Method == http.MethodPost && expectedUrl == req.URL.String() && req.Header.Get("x-user-token") == config.RelayAuthKey && expectedBody == string(bodyByt)
@@ -1429,6 +1415,293 @@ func TestMakeBountyPayment(t *testing.T) {
 		mockDb2.AssertExpectations(t)
 		mockHttpClient2.AssertExpectations(t)
 	})
+
+	t.Run("Should pay bounties over the on-chain threshold to the hunter's on-chain address", func(t *testing.T) {
+		config.OnchainPayoutThreshold = 1000
+		defer func() { config.OnchainPayoutThreshold = 0 }()
+
+		mockDb3 := dbMocks.NewDatabase(t)
+		mockOnchain := mocks.NewOnchainService(t)
+		bHandler3 := NewBountyHandler(mocks.NewHttpClient(t), mockDb3)
+		bHandler3.onchainService = mockOnchain
+		bHandler3.getSocketConnections = mockGetSocketConnections
+		bHandler3.userHasAccess = mockUserHasAccessTrue
+
+		address := "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"
+		reservation := db.BudgetReservation{Id: "reservation-1", WorkspaceUuid: bounty.WorkspaceUuid, Amount: bounty.Price}
+		mockDb3.On("GetBounty", bountyID).Return(bounty, nil)
+		mockDb3.On("GetWorkspaceSettings", bounty.WorkspaceUuid).Return(db.DefaultWorkspaceSettings(bounty.WorkspaceUuid))
+		mockDb3.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb3.On("HasUnsettledBountyPayment", bountyID).Return(false)
+		mockDb3.On("ReserveBudget", bounty.WorkspaceUuid, bounty.Price).Return(reservation, nil)
+		mockDb3.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1", OnchainAddress: address}, nil)
+		mockOnchain.On("EstimateFee", address, uint(1000)).Return(db.OnchainFeeEstimate{FeeSat: 150, SatPerVbyte: 2}, nil).Once()
+		mockOnchain.On("SendCoins", address, uint(850), uint(2)).Return("txid-1", nil).Once()
+		mockDb3.On("ProcessBountyPayment", mock.MatchedBy(func(payment db.NewPaymentHistory) bool {
+			return payment.PaymentMethod == db.PaymentMethodOnchain && payment.OnchainTxid == "txid-1" &&
+				payment.OnchainAddress == address && payment.OnchainFee == 150 && payment.Amount == 1000
+		}), mock.AnythingOfType("db.NewBounty"), reservation).Return(nil).Once()
+
+		ro := chi.NewRouter()
+		ro.Post("/gobounties/pay/{id}", bHandler3.MakeBountyPayment)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/gobounties/pay/1", bytes.NewBuffer([]byte("{}")))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ro.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should not fall back to lightning when the on-chain payment has no known outcome", func(t *testing.T) {
+		config.OnchainPayoutThreshold = 1000
+		defer func() { config.OnchainPayoutThreshold = 0 }()
+
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		mockOnchain := mocks.NewOnchainService(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.onchainService = mockOnchain
+		bHandler.getSocketConnections = mockGetSocketConnections
+		bHandler.userHasAccess = mockUserHasAccessTrue
+
+		address := "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"
+		reservation := db.BudgetReservation{Id: "reservation-1", WorkspaceUuid: bounty.WorkspaceUuid, Amount: bounty.Price}
+		mockDb.On("GetBounty", bountyID).Return(bounty, nil)
+		mockDb.On("GetWorkspaceSettings", bounty.WorkspaceUuid).Return(db.DefaultWorkspaceSettings(bounty.WorkspaceUuid))
+		mockDb.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb.On("HasUnsettledBountyPayment", bountyID).Return(false)
+		mockDb.On("ReserveBudget", bounty.WorkspaceUuid, bounty.Price).Return(reservation, nil)
+		mockDb.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1", OnchainAddress: address, LightningAddress: "hunter@example.com"}, nil)
+		mockOnchain.On("EstimateFee", address, uint(1000)).Return(db.OnchainFeeEstimate{FeeSat: 150, SatPerVbyte: 2}, nil).Once()
+		mockOnchain.On("SendCoins", address, uint(850), uint(2)).Return("", fmt.Errorf("%w: connection reset", errPaymentUnknown)).Once()
+		mockDb.On("AddUnsettledBountyPayment", mock.MatchedBy(func(payment db.NewPaymentHistory) bool {
+			return payment.PaymentMethod == db.PaymentMethodOnchain && payment.OnchainAddress == address
+		})).Return(nil).Once()
+
+		ro := chi.NewRouter()
+		ro.Post("/gobounties/pay/{id}", bHandler.MakeBountyPayment)
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/gobounties/pay/1", bytes.NewBuffer([]byte("{}")))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ro.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+		mockDb.AssertNotCalled(t, "ReleaseBudget", mock.Anything)
+	})
+
+	keysendHandler := func(t *testing.T) (*bountyHandler, *dbMocks.Database, *mocks.HttpClient, db.BudgetReservation) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.getSocketConnections = mockGetSocketConnections
+		bHandler.userHasAccess = mockUserHasAccessTrue
+
+		reservation := db.BudgetReservation{Id: "reservation-1", WorkspaceUuid: bounty.WorkspaceUuid, Amount: bounty.Price}
+		mockDb.On("GetBounty", bountyID).Return(bounty, nil)
+		mockDb.On("GetWorkspaceSettings", bounty.WorkspaceUuid).Return(db.DefaultWorkspaceSettings(bounty.WorkspaceUuid))
+		mockDb.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb.On("HasUnsettledBountyPayment", bountyID).Return(false)
+		mockDb.On("ReserveBudget", bounty.WorkspaceUuid, bounty.Price).Return(reservation, nil)
+		mockDb.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{OwnerPubKey: "assignee-1", OwnerRouteHint: "OwnerRouteHint"}, nil)
+		return bHandler, mockDb, mockHttpClient, reservation
+	}
+	pay := func(bHandler *bountyHandler) *httptest.ResponseRecorder {
+		ro := chi.NewRouter()
+		ro.Post("/gobounties/pay/{id}", bHandler.MakeBountyPayment)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(authorizedCtx, http.MethodPost, "/gobounties/pay/1", bytes.NewBuffer([]byte("{}")))
+		ro.ServeHTTP(rr, req)
+		return rr
+	}
+	unsettled := mock.MatchedBy(func(payment db.NewPaymentHistory) bool {
+		return payment.BountyId == bountyID && payment.Amount == bounty.Price && payment.PaymentMethod == db.PaymentMethodKeysend
+	})
+
+	t.Run("409 when a payment of the bounty has no known outcome", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		bHandler := NewBountyHandler(mocks.NewHttpClient(t), mockDb)
+		bHandler.userHasAccess = mockUserHasAccessTrue
+
+		mockDb.On("GetBounty", bountyID).Return(bounty, nil)
+		mockDb.On("GetWorkspaceSettings", bounty.WorkspaceUuid).Return(db.DefaultWorkspaceSettings(bounty.WorkspaceUuid))
+		mockDb.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb.On("HasUnsettledBountyPayment", bountyID).Return(true)
+
+		rr := pay(bHandler)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockDb.AssertNotCalled(t, "ReserveBudget", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should hold the reservation when the relay gives no answer", func(t *testing.T) {
+		bHandler, mockDb, mockHttpClient, _ := keysendHandler(t)
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(nil, errors.New("connection reset")).Once()
+		mockDb.On("AddUnsettledBountyPayment", unsettled).Return(nil).Once()
+
+		rr := pay(bHandler)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockDb.AssertNotCalled(t, "ReleaseBudget", mock.Anything)
+	})
+
+	t.Run("Should hold the reservation when the relay fails without an error", func(t *testing.T) {
+		bHandler, mockDb, mockHttpClient, _ := keysendHandler(t)
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
+			StatusCode: 502,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`<html>bad gateway</html>`))),
+		}, nil).Once()
+		mockDb.On("AddUnsettledBountyPayment", unsettled).Return(nil).Once()
+
+		rr := pay(bHandler)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockDb.AssertNotCalled(t, "ReleaseBudget", mock.Anything)
+	})
+
+	t.Run("Should record a keysend the relay took as paid when its answer can't be read", func(t *testing.T) {
+		bHandler, mockDb, mockHttpClient, reservation := keysendHandler(t)
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`not json`))),
+		}, nil).Once()
+		mockDb.On("ProcessBountyPayment", mock.MatchedBy(func(payment db.NewPaymentHistory) bool {
+			return payment.Status && payment.PaymentHash == "" && payment.PaymentMethod == db.PaymentMethodKeysend
+		}), mock.MatchedBy(func(paid db.NewBounty) bool {
+			return paid.Paid
+		}), reservation).Return(nil).Once()
+
+		rr := pay(bHandler)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should keep a sent payment that could not be recorded", func(t *testing.T) {
+		bHandler, mockDb, mockHttpClient, reservation := keysendHandler(t)
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"success": true, "response": {"payment_hash": "hash"}}`))),
+		}, nil).Once()
+		mockDb.On("ProcessBountyPayment", mock.AnythingOfType("db.NewPaymentHistory"), mock.AnythingOfType("db.NewBounty"), reservation).
+			Return(errors.New("database is down")).Twice()
+		mockDb.On("AddUnsettledBountyPayment", mock.MatchedBy(func(payment db.NewPaymentHistory) bool {
+			return payment.PaymentHash == "hash" && payment.BountyId == bountyID
+		})).Return(nil).Once()
+
+		rr := pay(bHandler)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockDb.AssertNotCalled(t, "ReleaseBudget", mock.Anything)
+	})
+
+	addressInvoice := "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
+	lightningAddressHandler := func(t *testing.T) (*bountyHandler, *dbMocks.Database, *mocks.HttpClient, db.BudgetReservation) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.getSocketConnections = mockGetSocketConnections
+		bHandler.userHasAccess = mockUserHasAccessTrue
+		bHandler.publicClient = mockHttpClient
+		bHandler.checkPublicHost = func(host string) error { return nil }
+
+		addressBounty := bounty
+		addressBounty.Price = 1500
+		reservation := db.BudgetReservation{Id: "reservation-1", WorkspaceUuid: bounty.WorkspaceUuid, Amount: addressBounty.Price}
+		mockDb.On("GetBounty", bountyID).Return(addressBounty, nil)
+		mockDb.On("GetWorkspaceSettings", bounty.WorkspaceUuid).Return(db.DefaultWorkspaceSettings(bounty.WorkspaceUuid))
+		mockDb.On("GetWorkspaceBudget", bounty.WorkspaceUuid).Return(db.NewBountyBudget{TotalBudget: 2000}, nil)
+		mockDb.On("HasUnsettledBountyPayment", bountyID).Return(false)
+		mockDb.On("ReserveBudget", bounty.WorkspaceUuid, addressBounty.Price).Return(reservation, nil)
+		mockDb.On("GetPersonByPubkey", bounty.Assignee).Return(db.Person{
+			OwnerPubKey:      "assignee-1",
+			OwnerRouteHint:   "OwnerRouteHint",
+			LightningAddress: "hunter@example.com",
+		}, nil)
+
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "https://example.com/.well-known/lnurlp/hunter"
+		})).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"tag": "payRequest", "callback": "https://example.com/callback", "minSendable": 1000, "maxSendable": 100000000}`)),
+		}, nil).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return strings.HasPrefix(req.URL.String(), "https://example.com/callback?")
+		})).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"pr": "` + addressInvoice + `"}`)),
+		}, nil).Once()
+		return bHandler, mockDb, mockHttpClient, reservation
+	}
+	relayCall := func(path string) interface{} {
+		return mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == config.RelayUrl+path
+		})
+	}
+
+	t.Run("Should not fall back to keysend when the lightning address payment has no known outcome", func(t *testing.T) {
+		bHandler, mockDb, mockHttpClient, _ := lightningAddressHandler(t)
+		mockHttpClient.On("Do", relayCall("/invoices")).Return(nil, errors.New("connection reset")).Once()
+		mockDb.On("AddUnsettledBountyPayment", mock.MatchedBy(func(payment db.NewPaymentHistory) bool {
+			return payment.PaymentMethod == db.PaymentMethodLightningAddress && payment.Amount == 1500
+		})).Return(nil).Once()
+
+		rr := pay(bHandler)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockHttpClient.AssertNotCalled(t, "Do", relayCall("/payment"))
+		mockDb.AssertNotCalled(t, "ReleaseBudget", mock.Anything)
+	})
+
+	t.Run("Should fall back to keysend when the relay refuses the lightning address payment", func(t *testing.T) {
+		bHandler, mockDb, mockHttpClient, reservation := lightningAddressHandler(t)
+		mockHttpClient.On("Do", relayCall("/invoices")).Return(&http.Response{
+			StatusCode: 400,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"success": false, "error": "no route"}`)),
+		}, nil).Once()
+		mockHttpClient.On("Do", relayCall("/payment")).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"success": true, "response": {"payment_hash": "hash"}}`)),
+		}, nil).Once()
+		mockDb.On("ProcessBountyPayment", mock.MatchedBy(func(payment db.NewPaymentHistory) bool {
+			return payment.PaymentMethod == db.PaymentMethodKeysend && payment.PaymentHash == "hash"
+		}), mock.AnythingOfType("db.NewBounty"), reservation).Return(nil).Once()
+
+		rr := pay(bHandler)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestTrackOnchainPayments(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mockOnchain := mocks.NewOnchainService(t)
+	now := time.Now()
+
+	dropped := db.NewPaymentHistory{ID: 4, BountyId: 7, OnchainTxid: "txid-4", OnchainConfirmations: 0}
+	mockDb.On("GetUnconfirmedOnchainPayments").Return([]db.NewPaymentHistory{
+		{ID: 1, OnchainTxid: "txid-1", OnchainConfirmations: 1},
+		{ID: 2, OnchainTxid: "txid-2", OnchainConfirmations: 0},
+		{ID: 3, OnchainTxid: "txid-3", OnchainConfirmations: 1},
+		dropped,
+	})
+	mockOnchain.On("GetTransaction", "txid-1").Return(db.OnchainTransaction{Txid: "txid-1", Confirmations: 3}, nil)
+	mockOnchain.On("GetTransaction", "txid-2").Return(db.OnchainTransaction{Txid: "txid-2", Confirmations: 1}, nil)
+	mockOnchain.On("GetTransaction", "txid-3").Return(db.OnchainTransaction{Txid: "txid-3", Confirmations: 1}, nil)
+	mockOnchain.On("GetTransaction", "txid-4").Return(db.OnchainTransaction{Txid: "txid-4", Dropped: true}, nil)
+	mockDb.On("UpdateOnchainConfirmations", uint(1), uint(3), &now).Return(nil).Once()
+	mockDb.On("UpdateOnchainConfirmations", uint(2), uint(1), (*time.Time)(nil)).Return(nil).Once()
+	mockDb.On("DropOnchainPayment", dropped, now).Return(nil).Once()
+
+	TrackOnchainPayments(mockDb, mockOnchain, now)
 }
 
 func TestBountyBudgetWithdraw(t *testing.T) {
@@ -1521,6 +1794,36 @@ func TestBountyBudgetWithdraw(t *testing.T) {
 		assert.Contains(t, rr.Body.String(), "Workspace budget is not enough to withdraw the amount", "Expected specific error message")
 	})
 
+	t.Run("403 error when a parallel withdrawal reserved the budget first", func(t *testing.T) {
+		ctxs := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = mockUserHasAccessTrue
+
+		mockDb.On("GetWorkspaceBudget", "org-1").Return(db.NewBountyBudget{
+			TotalBudget: 5000,
+		}, nil)
+		mockDb.On("ReserveBudget", "org-1", uint(1500)).Return(db.BudgetReservation{}, db.ErrInsufficientBudget)
+
+		invoice := "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
+
+		withdrawRequest := db.WithdrawBudgetRequest{
+			PaymentRequest: invoice,
+			OrgUuid:        "org-1",
+		}
+		requestBody, _ := json.Marshal(withdrawRequest)
+		req, _ := http.NewRequestWithContext(ctxs, http.MethodPost, "/budget/withdraw", bytes.NewReader(requestBody))
+
+		rr := httptest.NewRecorder()
+
+		bHandler.BountyBudgetWithdraw(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Workspace budget is not enough to withdraw the amount")
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
 	t.Run("budget invoices get paid if amount is lesser than workspace's budget", func(t *testing.T) {
 		ctxs := context.WithValue(context.Background(), auth.ContextKey, "valid-key")
 		mockDb := dbMocks.NewDatabase(t)
@@ -1533,7 +1836,9 @@ func TestBountyBudgetWithdraw(t *testing.T) {
 		mockDb.On("GetWorkspaceBudget", "org-1").Return(db.NewBountyBudget{
 			TotalBudget: 5000,
 		}, nil)
-		mockDb.On("WithdrawBudget", "valid-key", "org-1", paymentAmount).Return(nil)
+		reservation := db.BudgetReservation{Id: "reservation-1", WorkspaceUuid: "org-1", Amount: paymentAmount}
+		mockDb.On("ReserveBudget", "org-1", paymentAmount).Return(reservation, nil)
+		mockDb.On("WithdrawBudget", "valid-key", reservation).Return(nil)
 		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
 			StatusCode: 200,
 			Body:       io.NopCloser(bytes.NewBufferString(`{"success": true}`)),
@@ -1557,7 +1862,7 @@ func TestBountyBudgetWithdraw(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, response.Success, "Expected invoice payment to succeed")
 
-		mockDb.AssertCalled(t, "WithdrawBudget", "valid-key", "org-1", paymentAmount)
+		mockDb.AssertCalled(t, "WithdrawBudget", "valid-key", reservation)
 	})
 
 	t.Run("400 BadRequest error if there is an error with invoice payment", func(t *testing.T) {
@@ -1567,13 +1872,23 @@ func TestBountyBudgetWithdraw(t *testing.T) {
 		bHandler := NewBountyHandler(mockHttpClient, mockDb)
 		bHandler.userHasAccess = mockUserHasAccessTrue
 
+		reservation := db.BudgetReservation{Id: "reservation-1", WorkspaceUuid: "org-1", Amount: 1500}
 		mockDb.On("GetWorkspaceBudget", "org-1").Return(db.NewBountyBudget{
 			TotalBudget: 5000,
 		}, nil)
+		mockDb.On("ReserveBudget", "org-1", uint(1500)).Return(reservation, nil)
+		mockDb.On("ReleaseBudget", reservation).Return(nil).Once()
 		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
 			StatusCode: 400,
 			Body:       io.NopCloser(bytes.NewBufferString(`{"success": false, "error": "Payment error"}`)),
 		}, nil)
+		mockDb.On("GetWorkspaceByUuid", "org-1").Return(db.Workspace{Uuid: "org-1", OwnerPubKey: "owner-key"})
+		mockDb.On("GetWorkspaceUsers", "org-1").Return([]db.WorkspaceUsersData{
+			{Person: db.Person{OwnerPubKey: "valid-key"}},
+		}, nil)
+		mockDb.On("CreateNotification", mock.MatchedBy(func(n db.Notification) bool {
+			return n.WorkspaceUuid == "org-1" && n.Link == config.Host+"/workspace/org-1" && strings.Contains(n.Message, "Payment error")
+		})).Return(db.Notification{}, nil).Twice()
 
 		invoice := "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
 
@@ -1619,7 +1934,9 @@ func TestBountyBudgetWithdraw(t *testing.T) {
 			mockDb.On("GetWorkspaceBudget", "org-1").Return(db.NewBountyBudget{
 				TotalBudget: expectedFinalBudget,
 			}, nil)
-			mockDb.On("WithdrawBudget", "valid-key", "org-1", paymentAmount).Return(nil)
+			reservation := db.BudgetReservation{Id: fmt.Sprintf("reservation-%d", i), WorkspaceUuid: "org-1", Amount: paymentAmount}
+			mockDb.On("ReserveBudget", "org-1", paymentAmount).Return(reservation, nil)
+			mockDb.On("WithdrawBudget", "valid-key", reservation).Return(nil)
 			mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(&http.Response{
 				StatusCode: 200,
 				Body:       io.NopCloser(bytes.NewBufferString(`{"success": true}`)),
@@ -1864,3 +2181,1600 @@ func TestPollInvoice(t *testing.T) {
 		mockHttpClient.AssertExpectations(t)
 	})
 }
+
+func TestPatchBounty(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	bHandler := NewBountyHandler(mocks.NewHttpClient(t), mockDb)
+	bHandler.userHasManageBountyRoles = func(pubKeyFromAuth string, uuid string) bool {
+		return false
+	}
+
+	updated := time.Now().Add(-time.Hour)
+	bounty := db.NewBounty{ID: 1, OwnerID: "owner_pubkey", Type: "coding", Title: "Add login", Description: "With lightning", Price: 1000, Show: true, Updated: &updated}
+	newRequest := func(pubkey string, ifMatch string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPatch, "/1", bytes.NewBufferString(body))
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		return req
+	}
+
+	t.Run("should only let the owner or bounty managers patch", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.PatchBounty).ServeHTTP(rr, newRequest("stranger_pubkey", "", `{"price": 2000}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should refuse fields that can't be patched", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.PatchBounty).ServeHTTP(rr, newRequest("owner_pubkey", "", `{"paid": true}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "paid cannot be patched")
+	})
+
+	t.Run("should refuse a patch of another version", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.PatchBounty).ServeHTTP(rr, newRequest("owner_pubkey", `"1"`, `{"price": 2000}`))
+		assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+	})
+
+	t.Run("should only write the patched fields", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("PatchBounty", mock.MatchedBy(func(b db.NewBounty) bool {
+			return b.Price == 2000 && !b.Show && b.Title == "Add login" && b.Description == "With lightning"
+		}), []string{"price", "show"}, true).Return(func(b db.NewBounty, columns []string, conditional bool) (db.NewBounty, error) {
+			now := time.Now()
+			b.Updated = &now
+			return b, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.PatchBounty).ServeHTTP(rr, newRequest("owner_pubkey", recordETag(&updated), `{"price": 2000, "show": false}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotEqual(t, recordETag(&updated), rr.Header().Get("ETag"))
+
+		patched := db.NewBounty{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &patched))
+		assert.Equal(t, uint(2000), patched.Price)
+	})
+
+	t.Run("should answer 412 when the bounty changed while patching", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("PatchBounty", mock.Anything, []string{"price"}, true).Return(bounty, db.ErrPatchConflict).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.PatchBounty).ServeHTTP(rr, newRequest("owner_pubkey", recordETag(&updated), `{"price": 2000}`))
+		assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+	})
+}
+
+func TestBountyApplications(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	bHandler := NewBountyHandler(mockHttpClient, mockDb)
+	bHandler.userHasManageBountyRoles = func(pubKeyFromAuth string, uuid string) bool {
+		return false
+	}
+
+	openBounty := db.NewBounty{ID: 1, OwnerID: "owner_pubkey", Title: "bounty"}
+
+	newRequest := func(method string, pubKey string, applicationId string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		rctx.URLParams.Add("applicationId", applicationId)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubKey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), method, "/1/apply", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should not let the owner apply for their bounty", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(openBounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.ApplyForBounty).ServeHTTP(rr, newRequest(http.MethodPost, "owner_pubkey", "", `{"message": "me"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return 409 if the hunter already applied", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(openBounty).Once()
+		mockDb.On("GetActiveBountyApplication", uint(1), "hunter_pubkey").Return(db.BountyApplication{ID: 1}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.ApplyForBounty).ServeHTTP(rr, newRequest(http.MethodPost, "hunter_pubkey", "", `{"message": "me"}`))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should create a pending application", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(openBounty).Once()
+		mockDb.On("GetActiveBountyApplication", uint(1), "hunter_pubkey").Return(db.BountyApplication{}, errors.New("no bounty application found")).Once()
+		mockDb.On("CreateBountyApplication", mock.MatchedBy(func(a db.BountyApplication) bool {
+			return a.BountyID == 1 && a.ApplicantPubkey == "hunter_pubkey" && a.Message == "me"
+		})).Return(db.BountyApplication{ID: 2, BountyID: 1, Status: db.BountyApplicationPending}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.ApplyForBounty).ServeHTTP(rr, newRequest(http.MethodPost, "hunter_pubkey", "", `{"message": "me"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should only let the owner list applicants", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(openBounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.GetBountyApplicants).ServeHTTP(rr, newRequest(http.MethodGet, "hunter_pubkey", "", ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should assign the bounty when the owner accepts an application", func(t *testing.T) {
+		application := db.BountyApplication{ID: 2, BountyID: 1, ApplicantPubkey: "hunter_pubkey", Status: db.BountyApplicationPending}
+		mockDb.On("GetBounty", uint(1)).Return(openBounty).Once()
+		mockDb.On("GetBountyApplicationById", uint(2)).Return(application, nil).Once()
+		mockDb.On("AcceptBountyApplication", application).Return(db.NewBounty{ID: 1, Assignee: "hunter_pubkey"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.AcceptBountyApplication).ServeHTTP(rr, newRequest(http.MethodPost, "owner_pubkey", "2", ""))
+
+		var bounty db.NewBounty
+		json.Unmarshal(rr.Body.Bytes(), &bounty)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "hunter_pubkey", bounty.Assignee)
+	})
+}
+
+func TestProcessStaleBounties(t *testing.T) {
+	now := time.Now()
+	daysAgo := func(days int) *time.Time {
+		date := now.Add(-time.Duration(days) * 24 * time.Hour)
+		return &date
+	}
+
+	t.Run("should warn an inactive assignee before unassigning", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		bounty := db.NewBounty{ID: 1, OwnerID: "owner_pubkey", Assignee: "hunter_pubkey", AssignedDate: daysAgo(20)}
+		mockDb.On("GetStaleAssignedBounties", mock.AnythingOfType("time.Time")).Return([]db.NewBounty{bounty}).Once()
+		mockDb.On("SetBountyStaleWarning", uint(1)).Return(nil).Once()
+
+		notified := []string{}
+		ProcessStaleBounties(mockDb, now, func(pubkey string, content string) error {
+			notified = append(notified, pubkey)
+			return nil
+		})
+
+		assert.Equal(t, []string{"hunter_pubkey", "owner_pubkey"}, notified)
+	})
+
+	t.Run("should unassign a warned bounty after the grace period", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		bounty := db.NewBounty{ID: 1, OwnerID: "owner_pubkey", Assignee: "hunter_pubkey", AssignedDate: daysAgo(20), StaleWarningDate: daysAgo(5)}
+		mockDb.On("GetStaleAssignedBounties", mock.AnythingOfType("time.Time")).Return([]db.NewBounty{bounty}).Once()
+		mockDb.On("UnassignStaleBounty", bounty, mock.AnythingOfType("string")).Return(nil).Once()
+
+		notified := []string{}
+		ProcessStaleBounties(mockDb, now, func(pubkey string, content string) error {
+			notified = append(notified, pubkey)
+			return nil
+		})
+
+		assert.Equal(t, []string{"hunter_pubkey", "owner_pubkey"}, notified)
+	})
+
+	t.Run("should not unassign a bounty that was warned recently", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		bounty := db.NewBounty{ID: 1, OwnerID: "owner_pubkey", Assignee: "hunter_pubkey", AssignedDate: daysAgo(20), StaleWarningDate: daysAgo(1)}
+		mockDb.On("GetStaleAssignedBounties", mock.AnythingOfType("time.Time")).Return([]db.NewBounty{bounty}).Once()
+
+		ProcessStaleBounties(mockDb, now, func(pubkey string, content string) error {
+			t.Fatal("should not notify")
+			return nil
+		})
+	})
+}
+
+func TestProcessBountyDueReminders(t *testing.T) {
+	now := time.Now()
+	hoursFromNow := func(hours int) *time.Time {
+		date := now.Add(time.Duration(hours) * time.Hour)
+		return &date
+	}
+	reminded := func(hours int) *int {
+		return &hours
+	}
+	run := func(t *testing.T, bounty db.NewBounty) []string {
+		mockDb := dbMocks.NewDatabase(t)
+		mockDb.On("GetBountiesDueBetween", now.Add(-72*time.Hour), now.Add(48*time.Hour)).Return([]db.NewBounty{bounty}).Once()
+		if bounty.Title != "already reminded" {
+			mockDb.On("SetBountyDueReminder", bounty.ID, mock.AnythingOfType("int")).Return(nil).Once()
+		}
+
+		messages := []string{}
+		ProcessBountyDueReminders(mockDb, now, func(pubkey string, content string) error {
+			messages = append(messages, pubkey+": "+content)
+			return nil
+		})
+		return messages
+	}
+
+	t.Run("should only send the latest reminder that is due", func(t *testing.T) {
+		bounty := db.NewBounty{ID: 1, Title: "Fix login", OwnerID: "owner_pubkey", Assignee: "hunter_pubkey", DueDate: hoursFromNow(20)}
+		messages := run(t, bounty)
+		assert.Equal(t, []string{fmt.Sprintf(`hunter_pubkey: The bounty "Fix login" is due in 24 hours - %s/bounty/1`, config.Host)}, messages)
+	})
+
+	t.Run("should not send a reminder twice", func(t *testing.T) {
+		bounty := db.NewBounty{ID: 1, Title: "already reminded", OwnerID: "owner_pubkey", Assignee: "hunter_pubkey", DueDate: hoursFromNow(20), DueReminderHours: reminded(-24)}
+		assert.Empty(t, run(t, bounty))
+	})
+
+	t.Run("should tell the assignee and the owner about an overdue bounty", func(t *testing.T) {
+		bounty := db.NewBounty{ID: 1, Title: "Fix login", OwnerID: "owner_pubkey", Assignee: "hunter_pubkey", DueDate: hoursFromNow(-30), DueReminderHours: reminded(-24)}
+		messages := run(t, bounty)
+		assert.Len(t, messages, 2)
+		assert.Contains(t, messages[0], `hunter_pubkey: The bounty "Fix login" you are assigned to is overdue by 24 hours`)
+		assert.Contains(t, messages[1], `owner_pubkey: Your bounty "Fix login" is overdue by 24 hours`)
+	})
+
+	t.Run("should remind the owner of an unassigned bounty", func(t *testing.T) {
+		bounty := db.NewBounty{ID: 1, Title: "Fix login", OwnerID: "owner_pubkey", DueDate: hoursFromNow(47)}
+		messages := run(t, bounty)
+		assert.Len(t, messages, 1)
+		assert.Contains(t, messages[0], `owner_pubkey: The bounty "Fix login" is due in 2 days`)
+	})
+}
+
+func TestBountyDueDate(t *testing.T) {
+	t.Run("should reject a due date in the past", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		bHandler := NewBountyHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("UpdateBountyNullColumn", mock.Anything, "assignee").Return(db.NewBounty{}).Once()
+
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner_pubkey")
+		body := `{"type": "coding", "title": "Fix login", "description": "it fails", "owner_id": "owner_pubkey", "due_date": "2020-01-01T00:00:00Z"}`
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/gobounties", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.CreateOrEditBounty).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Due date must be in the future")
+	})
+
+	t.Run("should flag unfinished bounties past their due date", func(t *testing.T) {
+		now := time.Now()
+		past := now.Add(-time.Hour)
+		assert.True(t, db.NewBounty{Assignee: "hunter_pubkey", DueDate: &past}.IsOverdue(now))
+		assert.False(t, db.NewBounty{Assignee: "hunter_pubkey", Completed: true, DueDate: &past}.IsOverdue(now))
+		assert.False(t, db.NewBounty{Assignee: "hunter_pubkey"}.IsOverdue(now))
+	})
+}
+
+func TestBountyReceipt(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+	config.ReceiptSigningKey = "receipt_key"
+	defer func() {
+		config.ReceiptSigningKey = ""
+	}()
+
+	receiptData := `{"bounty_id":1,"amount":1000,"payment_hash":"hash"}`
+	newRequest := func() *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/1/receipt", nil)
+		return req
+	}
+
+	t.Run("should return 404 if the bounty has no receipt", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1}).Once()
+		mockDb.On("GetBountyReceipt", uint(1)).Return(db.BountyReceipt{}, errors.New("no bounty receipt found")).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.GetBountyReceipt).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return a receipt that can be verified", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, Paid: true}).Once()
+		mockDb.On("GetBountyReceipt", uint(1)).Return(db.BountyReceipt{
+			BountyId:  1,
+			Receipt:   receiptData,
+			Signature: db.SignReceipt([]byte(receiptData)),
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.GetBountyReceipt).ServeHTTP(rr, newRequest())
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		verifyReq, _ := http.NewRequest(http.MethodPost, "/receipt/verify", bytes.NewReader(rr.Body.Bytes()))
+		verifyRr := httptest.NewRecorder()
+		http.HandlerFunc(VerifyBountyReceipt).ServeHTTP(verifyRr, verifyReq)
+
+		assert.Equal(t, http.StatusOK, verifyRr.Code)
+		assert.JSONEq(t, `{"valid": true}`, verifyRr.Body.String())
+	})
+
+	t.Run("should not verify a tampered receipt", func(t *testing.T) {
+		body := fmt.Sprintf(`{"receipt": {"bounty_id":1,"amount":5000,"payment_hash":"hash"}, "signature": "%s"}`, db.SignReceipt([]byte(receiptData)))
+		verifyReq, _ := http.NewRequest(http.MethodPost, "/receipt/verify", strings.NewReader(body))
+		verifyRr := httptest.NewRecorder()
+		http.HandlerFunc(VerifyBountyReceipt).ServeHTTP(verifyRr, verifyReq)
+
+		assert.JSONEq(t, `{"valid": false}`, verifyRr.Body.String())
+	})
+}
+
+func TestRankLeaderboard(t *testing.T) {
+	t.Run("should rank hunters and compute the rank delta", func(t *testing.T) {
+		current := []db.BountyLeaderboard{
+			{Owner_pubkey: "hunter_2", Total_sats_earned: 3000},
+			{Owner_pubkey: "hunter_1", Total_sats_earned: 2000},
+			{Owner_pubkey: "hunter_3", Total_sats_earned: 1000},
+		}
+		previous := []db.BountyLeaderboard{
+			{Owner_pubkey: "hunter_1", Total_sats_earned: 2000},
+			{Owner_pubkey: "hunter_2", Total_sats_earned: 1000},
+		}
+
+		entries := rankLeaderboard(current, previous)
+
+		assert.Equal(t, 1, entries[0].Rank)
+		assert.Equal(t, 2, entries[0].PreviousRank)
+		assert.Equal(t, 1, entries[0].RankDelta)
+		assert.Equal(t, -1, entries[1].RankDelta)
+		assert.Equal(t, 0, entries[2].PreviousRank)
+		assert.Equal(t, 0, entries[2].RankDelta)
+	})
+
+	t.Run("should build consecutive window ranges", func(t *testing.T) {
+		now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+
+		current, previous, ok := leaderboardWindowFilters(db.LeaderboardWeek, now)
+
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC), *current.To)
+		assert.Equal(t, time.Date(2024, 5, 9, 0, 0, 0, 0, time.UTC), *current.From)
+		assert.Equal(t, *current.From, *previous.To)
+		assert.Equal(t, time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), *previous.From)
+
+		_, _, ok = leaderboardWindowFilters("year", now)
+		assert.False(t, ok)
+	})
+}
+
+func TestBountyHours(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+	bounty := db.NewBounty{ID: 1, OwnerID: "owner_pubkey", Assignee: "hunter_pubkey", EstimatedHours: 4}
+
+	newRequest := func(method string, pubKey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubKey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), method, "/1/hours", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should only let the assignee log hours", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.LogBountyHours).ServeHTTP(rr, newRequest(http.MethodPost, "owner_pubkey", `{"hours": 2}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should reject invalid hours", func(t *testing.T) {
+		for _, body := range []string{`{"hours": 0}`, `{"hours": -1}`, `{"hours": 25}`} {
+			mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(bHandler.LogBountyHours).ServeHTTP(rr, newRequest(http.MethodPost, "hunter_pubkey", body))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+	})
+
+	t.Run("should log hours for the assignee", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("CreateBountyHoursLog", mock.MatchedBy(func(log db.BountyHoursLog) bool {
+			return log.BountyID == bounty.ID && log.Pubkey == "hunter_pubkey" && log.Hours == 2.5 && log.Note == "tests"
+		})).Return(db.BountyHoursLog{ID: 1, BountyID: bounty.ID, Pubkey: "hunter_pubkey", Hours: 2.5}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.LogBountyHours).ServeHTTP(rr, newRequest(http.MethodPost, "hunter_pubkey", `{"hours": 2.5, "note": "tests"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return the estimate and the logged hours", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetBountyHoursLogs", bounty.ID).Return([]db.BountyHoursLog{
+			{ID: 1, BountyID: bounty.ID, Hours: 2.5},
+			{ID: 2, BountyID: bounty.ID, Hours: 3},
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.GetBountyEffort).ServeHTTP(rr, newRequest(http.MethodGet, "", ""))
+
+		var effort db.BountyEffort
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &effort))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 4.0, effort.EstimatedHours)
+		assert.Equal(t, 5.5, effort.ActualHours)
+		assert.Len(t, effort.Logs, 2)
+	})
+}
+
+func TestPurgeDeletedWorkspaces(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	now := time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC)
+	mockDb.On("PurgeExpiredWorkspaceArchives", now.Add(-db.WorkspaceRetention())).Return(int64(1), nil).Once()
+
+	PurgeDeletedWorkspaces(mockDb, now)
+}
+
+func TestCheckBudgetLedger(t *testing.T) {
+	t.Run("should resync drifted budgets and only log unbalanced transactions", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockDb.On("CheckBudgetLedgerConsistency").Return([]db.BudgetLedgerInconsistency{
+			{Kind: db.LedgerUnbalancedTransaction, TransactionId: "tx", Expected: 1000, Actual: 900},
+			{Kind: db.LedgerBudgetDrift, WorkspaceUuid: "workspace_uuid", Expected: 5000, Actual: 6000},
+		}).Once()
+		mockDb.On("SyncWorkspaceBudget", "workspace_uuid").Return(nil).Once()
+
+		inconsistencies := CheckBudgetLedger(mockDb)
+
+		assert.Len(t, inconsistencies, 2)
+	})
+
+	t.Run("should do nothing on a consistent ledger", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockDb.On("CheckBudgetLedgerConsistency").Return([]db.BudgetLedgerInconsistency{}).Once()
+
+		assert.Empty(t, CheckBudgetLedger(mockDb))
+	})
+}
+
+func TestCheckFeatureCounts(t *testing.T) {
+	t.Run("should recount drifted features", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockDb.On("CheckFeatureBountyCounts").Return([]db.FeatureCountDrift{
+			{FeatureUuid: "feature_uuid", StoredOpen: 2, ActualOpen: 1, ActualCompleted: 1},
+		}).Once()
+		mockDb.On("SyncFeatureBountyCounts", "feature_uuid").Return(nil).Once()
+
+		assert.Len(t, CheckFeatureCounts(mockDb), 1)
+	})
+
+	t.Run("should do nothing when the counts are right", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockDb.On("CheckFeatureBountyCounts").Return([]db.FeatureCountDrift{}).Once()
+
+		assert.Empty(t, CheckFeatureCounts(mockDb))
+	})
+}
+
+func TestReconcilePayments(t *testing.T) {
+	ctx := context.Background()
+	authorizedCtx := context.WithValue(ctx, auth.ContextKey, "super_admin")
+	expiredInvoice := "lnbcrt100u1pnr5gtzpp5r7ew6nzqd9y9w5ktsspftnckxdn3te0y04n9mw7c6hkkrznh4pgsdqhgf6kgem9wssyjmnkda5kxegcqzpgxqyz5vqsp5mc09mpl4l3rllnfl3y902yxa29flke8r4ertqswdcrk766z5nq4q9qyyssq7wteenxtwlxatsd8dqdncqnn6u23jmcpe0d7ne6dcpafwlx9ckr3dp6y4p7sl4j3pq6l93g6vc4w8z04ry9yzwjv6cggm06eecad9psp9dh6u5"
+
+	mockInvoiceCheck := func(mockHttpClient *mocks.HttpClient, paymentRequest string, settled bool) {
+		body := fmt.Sprintf(`{"success": true, "response": {"settled": %t, "payment_request": "%s"}}`, settled, paymentRequest)
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Method == http.MethodGet && req.URL.Query().Get("payment_request") == paymentRequest
+		})).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+		}, nil).Once()
+	}
+
+	reconcile := func(bHandler *bountyHandler, ctx context.Context) (*httptest.ResponseRecorder, db.PaymentReconciliationReport) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/bounties/payments/reconcile", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		http.HandlerFunc(bHandler.ReconcilePayments).ServeHTTP(rr, req)
+
+		report := db.PaymentReconciliationReport{}
+		json.Unmarshal(rr.Body.Bytes(), &report)
+		return rr, report
+	}
+
+	t.Run("should return 401 without a pubkey", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		bHandler := NewBountyHandler(&mocks.HttpClient{}, mockDb)
+
+		rr, _ := reconcile(bHandler, context.WithValue(ctx, auth.ContextKey, ""))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should fix stuck payments and report the ones it cannot fix", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+		now := time.Now()
+		paidDate := now.Add(-time.Hour)
+		budgetInvoice := db.NewInvoiceList{PaymentRequest: "budget-invoice", Type: db.Budget, WorkspaceUuid: "workspace_uuid", Created: &now}
+		keysendInvoice := db.NewInvoiceList{PaymentRequest: "keysend-invoice", Type: db.Keysend, WorkspaceUuid: "workspace_uuid", Created: &now}
+		unpaidKeysendInvoice := db.NewInvoiceList{PaymentRequest: "unpaid-keysend-invoice", Type: db.Keysend, WorkspaceUuid: "workspace_uuid", Created: &now}
+		pendingInvoice := db.NewInvoiceList{PaymentRequest: "lnbc1pendinginvoice", Type: db.Budget, WorkspaceUuid: "workspace_uuid", Created: &now}
+		expired := db.NewInvoiceList{PaymentRequest: expiredInvoice, Type: db.Budget, WorkspaceUuid: "workspace_uuid", Created: &now}
+
+		mockDb.On("GetPendingInvoices").Return([]db.NewInvoiceList{
+			budgetInvoice, keysendInvoice, unpaidKeysendInvoice, pendingInvoice, expired,
+		}).Once()
+
+		mockInvoiceCheck(mockHttpClient, budgetInvoice.PaymentRequest, true)
+		mockDb.On("GetPaymentHistoryByCreated", &now, "workspace_uuid").Return(db.NewPaymentHistory{WorkspaceUuid: "workspace_uuid", Amount: 5000}).Once()
+		mockDb.On("ProcessUpdateBudget", budgetInvoice).Return(nil).Once()
+
+		mockInvoiceCheck(mockHttpClient, keysendInvoice.PaymentRequest, true)
+		mockDb.On("GetUserInvoiceData", keysendInvoice.PaymentRequest).Return(db.UserInvoiceData{Amount: 1000, Created: 1}).Once()
+		mockDb.On("GetBountyByCreated", uint(1)).Return(db.NewBounty{ID: 1, Paid: true}, nil).Once()
+		mockDb.On("UpdateInvoice", keysendInvoice.PaymentRequest).Return(db.NewInvoiceList{Status: true}).Once()
+
+		mockInvoiceCheck(mockHttpClient, unpaidKeysendInvoice.PaymentRequest, true)
+		mockDb.On("GetUserInvoiceData", unpaidKeysendInvoice.PaymentRequest).Return(db.UserInvoiceData{Amount: 2000, Created: 2}).Once()
+		mockDb.On("GetBountyByCreated", uint(2)).Return(db.NewBounty{ID: 2}, nil).Once()
+
+		mockInvoiceCheck(mockHttpClient, pendingInvoice.PaymentRequest, false)
+
+		mockInvoiceCheck(mockHttpClient, expiredInvoice, false)
+		mockDb.On("DeleteInvoice", expiredInvoice).Return(db.NewInvoiceList{}).Once()
+
+		mockDb.On("GetUnmarkedBountyPayments").Return([]db.NewPaymentHistory{
+			{BountyId: 3, WorkspaceUuid: "workspace_uuid", Amount: 3000, Created: &paidDate},
+		}).Once()
+		mockDb.On("MarkBountyPaid", uint(3), &paidDate).Return(nil).Once()
+
+		mockDb.On("GetUnsettledBountyPayments").Return([]db.NewPaymentHistory{
+			{BountyId: 4, WorkspaceUuid: "workspace_uuid", Amount: 2500},
+		}).Once()
+
+		mockDb.On("GetOpenBudgetReservations", mock.AnythingOfType("time.Time")).Return([]db.BudgetReservation{
+			{Id: "reservation", WorkspaceUuid: "workspace_uuid", Amount: 4000},
+		}).Once()
+
+		rr, report := reconcile(bHandler, authorizedCtx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 5, report.InvoicesChecked)
+
+		corrected := map[db.PaymentCorrectionAction]db.PaymentCorrection{}
+		for _, correction := range report.Corrections {
+			corrected[correction.Action] = correction
+		}
+		assert.Len(t, report.Corrections, 4)
+		assert.Equal(t, uint(5000), corrected[db.PaymentBudgetCredited].Amount)
+		assert.Equal(t, uint(1), corrected[db.PaymentInvoiceSettled].BountyId)
+		assert.Equal(t, expiredInvoice, corrected[db.PaymentInvoiceExpired].PaymentRequest)
+		assert.Equal(t, uint(3), corrected[db.PaymentBountyMarkedPaid].BountyId)
+
+		assert.Len(t, report.Unresolved, 3)
+		assert.Equal(t, unpaidKeysendInvoice.PaymentRequest, report.Unresolved[0].PaymentRequest)
+		assert.Equal(t, uint(4), report.Unresolved[1].BountyId)
+		assert.Equal(t, "reservation", report.Unresolved[2].ReservationId)
+		mockHttpClient.AssertExpectations(t)
+	})
+
+	t.Run("should report invoices the lightning backend could not check", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := &mocks.HttpClient{}
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+
+		mockDb.On("GetPendingInvoices").Return([]db.NewInvoiceList{{PaymentRequest: "budget-invoice", Type: db.Budget}}).Once()
+		// checking an invoice is a GET, so the relay client retries it before giving up
+		mockHttpClient.On("Do", mock.Anything).Return(nil, errors.New("relay is down")).Times(1 + config.RelayRetries)
+		mockDb.On("GetUnmarkedBountyPayments").Return([]db.NewPaymentHistory{}).Once()
+		mockDb.On("GetUnsettledBountyPayments").Return([]db.NewPaymentHistory{}).Once()
+		mockDb.On("GetOpenBudgetReservations", mock.AnythingOfType("time.Time")).Return([]db.BudgetReservation{}).Once()
+
+		rr, report := reconcile(bHandler, authorizedCtx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, report.Corrections)
+		assert.Len(t, report.Unresolved, 1)
+		assert.Equal(t, db.PaymentNeedsReview, report.Unresolved[0].Action)
+		assert.Equal(t, "relay is down", report.Unresolved[0].Error)
+	})
+}
+
+func TestCreateBountyWithApiToken(t *testing.T) {
+	apiToken := auth.ApiToken{ID: 1, WorkspaceUuid: "workspace_uuid", CreatedBy: "admin_pubkey", Scopes: []string{db.ApiScopeCreateBounties}}
+
+	serve := func(bHandler *bountyHandler, token auth.ApiToken, body string) *httptest.ResponseRecorder {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, token.CreatedBy)
+		ctx = context.WithValue(ctx, auth.ApiTokenContextKey, token)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/api", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.CreateOrEditBounty).ServeHTTP(rr, req)
+		return rr
+	}
+	newHandler := func(t *testing.T) (*bountyHandler, *dbMocks.Database) {
+		mockDb := dbMocks.NewDatabase(t)
+		bHandler := NewBountyHandler(mocks.NewHttpClient(t), mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return pubKeyFromAuth == "admin_pubkey" && role == db.AddBounty
+		}
+		return bHandler, mockDb
+	}
+
+	t.Run("should create the bounty in the token workspace as the token admin", func(t *testing.T) {
+		bHandler, mockDb := newHandler(t)
+		mockDb.On("UpdateBountyNullColumn", mock.Anything, "assignee").Return(db.NewBounty{}).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(db.DefaultWorkspaceSettings("workspace_uuid")).Twice()
+		mockDb.On("CreateOrEditBounty", mock.MatchedBy(func(b db.NewBounty) bool {
+			return b.WorkspaceUuid == "workspace_uuid" && b.OwnerID == "admin_pubkey" && b.Show
+		})).Return(func(b db.NewBounty) (db.NewBounty, error) {
+			return b, nil
+		}).Once()
+
+		rr := serve(bHandler, apiToken, `{"type": "coding", "title": "Flaky test", "description": "failed on main", "owner_id": "someone_else"}`)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should reject another workspace", func(t *testing.T) {
+		bHandler, _ := newHandler(t)
+
+		rr := serve(bHandler, apiToken, `{"type": "coding", "title": "Flaky test", "description": "failed", "workspace_uuid": "other_workspace"}`)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should reject updates without the update scope", func(t *testing.T) {
+		bHandler, _ := newHandler(t)
+
+		rr := serve(bHandler, apiToken, `{"id": 1, "type": "coding", "title": "Flaky test", "description": "failed"}`)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should reject the token once its admin lost access", func(t *testing.T) {
+		bHandler, _ := newHandler(t)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return false
+		}
+
+		rr := serve(bHandler, apiToken, `{"type": "coding", "title": "Flaky test", "description": "failed"}`)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should only update bounties of the token workspace", func(t *testing.T) {
+		bHandler, mockDb := newHandler(t)
+		updateToken := apiToken
+		updateToken.Scopes = []string{db.ApiScopeUpdateBounties}
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, WorkspaceUuid: "other_workspace"}).Once()
+
+		rr := serve(bHandler, updateToken, `{"id": 1, "type": "coding", "title": "Flaky test", "description": "failed"}`)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestTransitionBounty(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+	bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "manager_pubkey" && role == db.UpdateBounty
+	}
+
+	bounty := db.NewBounty{ID: 1, Title: "Fix login", OwnerID: "owner_pubkey", Assignee: "hunter_pubkey", WorkspaceUuid: "workspace_uuid", State: db.BountyStateAssigned}
+
+	newRequest := func(pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/1/transition", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should reject unknown states and paid", func(t *testing.T) {
+		for _, body := range []string{`{"state": "archived"}`, `{"state": "paid"}`} {
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(bHandler.TransitionBounty).ServeHTTP(rr, newRequest("owner_pubkey", body))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+	})
+
+	t.Run("should only let the assignee submit or give up the bounty", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.TransitionBounty).ServeHTTP(rr, newRequest("hunter_pubkey", `{"state": "completed"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should require an assignee to assign an open bounty", func(t *testing.T) {
+		open := bounty
+		open.Assignee = ""
+		open.State = db.BountyStateOpen
+		mockDb.On("GetBounty", uint(1)).Return(open).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.TransitionBounty).ServeHTTP(rr, newRequest("manager_pubkey", `{"state": "assigned"}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return 409 for a move the state machine does not allow", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(db.DefaultWorkspaceSettings("workspace_uuid")).Once()
+		mockDb.On("TransitionBountyState", uint(1), db.BountyTransitionRequest{State: db.BountyStateOpen}, "owner_pubkey").
+			Return(db.NewBounty{}, db.BountyStateTransition{}, db.InvalidBountyTransitionError{From: db.BountyStatePaid, To: db.BountyStateOpen}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.TransitionBounty).ServeHTTP(rr, newRequest("owner_pubkey", `{"state": "open"}`))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should move the bounty to review and run the hooks", func(t *testing.T) {
+		inReview := bounty
+		inReview.State = db.BountyStateInReview
+		transition := db.BountyStateTransition{BountyID: 1, FromState: db.BountyStateAssigned, ToState: db.BountyStateInReview, Actor: "hunter_pubkey"}
+
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(db.DefaultWorkspaceSettings("workspace_uuid")).Once()
+		mockDb.On("TransitionBountyState", uint(1), db.BountyTransitionRequest{State: db.BountyStateInReview, Reason: "ready"}, "hunter_pubkey").
+			Return(inReview, transition, nil).Once()
+		mockDb.On("CreateNotification", mock.MatchedBy(func(n db.Notification) bool {
+			return n.RecipientPubkey == "owner_pubkey" && n.Type == db.BountyStateChangedNotification &&
+				n.Message == "Fix login moved from assigned to in review"
+		})).Return(db.Notification{}, nil).Once()
+
+		hooked := []db.BountyStateTransition{}
+		bHandler.AddTransitionHook(func(b db.NewBounty, tr db.BountyStateTransition) {
+			hooked = append(hooked, tr)
+		})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.TransitionBounty).ServeHTTP(rr, newRequest("hunter_pubkey", `{"state": "in_review", "reason": "ready"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, []db.BountyStateTransition{transition}, hooked)
+
+		result := db.NewBounty{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, db.BountyStateInReview, result.State)
+	})
+}
+
+func TestStateWipLimits(t *testing.T) {
+	bounty := db.NewBounty{ID: 1, Title: "Fix login", OwnerID: "owner_pubkey", Assignee: "hunter_pubkey", WorkspaceUuid: "workspace_uuid", PhaseUuid: "phase_uuid", State: db.BountyStateAssigned}
+	settings := db.DefaultWorkspaceSettings("workspace_uuid")
+	settings.StateWipLimits = db.StateWipLimits{
+		{State: db.BountyStateInReview, Limit: 10},
+		{PhaseUuid: "phase_uuid", State: db.BountyStateInReview, Limit: 2},
+		{PhaseUuid: "other_phase", State: db.BountyStateInReview, Limit: 1},
+	}
+
+	newHandler := func(t *testing.T, overrides bool) (*bountyHandler, *dbMocks.Database) {
+		mockDb := dbMocks.NewDatabase(t)
+		bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return role == db.OverrideWipLimits && overrides
+		}
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(settings).Once()
+		mockDb.On("GetWorkspaceBountiesInStateCount", "workspace_uuid", "", db.BountyStateInReview).Return(int64(4)).Once()
+		mockDb.On("GetWorkspaceBountiesInStateCount", "workspace_uuid", "phase_uuid", db.BountyStateInReview).Return(int64(2)).Once()
+		return bHandler, mockDb
+	}
+	newRequest := func() *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner_pubkey")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/1/transition", bytes.NewBufferString(`{"state": "in_review"}`))
+		return req
+	}
+
+	t.Run("should return 409 with the counts of the limits a move goes over", func(t *testing.T) {
+		bHandler, _ := newHandler(t, false)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.TransitionBounty).ServeHTTP(rr, newRequest())
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		response := struct {
+			Error  string             `json:"error"`
+			Limits []db.WipLimitCount `json:"limits"`
+		}{}
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "The wip limit of in review bounties is reached", response.Error)
+		assert.Equal(t, []db.WipLimitCount{{StateWipLimit: settings.StateWipLimits[1], Count: 2}}, response.Limits)
+	})
+
+	t.Run("should let members with the override role go over the limits", func(t *testing.T) {
+		bHandler, mockDb := newHandler(t, true)
+		mockDb.On("TransitionBountyState", uint(1), db.BountyTransitionRequest{State: db.BountyStateInReview}, "owner_pubkey").
+			Return(bounty, db.BountyStateTransition{BountyID: 1, FromState: db.BountyStateAssigned, ToState: db.BountyStateInReview, Actor: "owner_pubkey"}, nil).Once()
+		mockDb.On("CreateNotification", mock.Anything).Return(db.Notification{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.TransitionBounty).ServeHTTP(rr, newRequest())
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestBountyComments(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+	bHandler.userHasManageBountyRoles = func(pubKeyFromAuth string, uuid string) bool {
+		return pubKeyFromAuth == "manager_pubkey"
+	}
+
+	bounty := db.NewBounty{ID: 1, Title: "Fix login", OwnerID: "owner_pubkey", Assignee: "hunter_pubkey", WorkspaceUuid: "workspace_uuid", Show: true}
+
+	newRequest := func(method string, pubkey string, body string, commentId string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		if commentId != "" {
+			rctx.URLParams.Add("commentId", commentId)
+		}
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), method, "/1/comments", bytes.NewBufferString(body))
+		return req
+	}
+	withToken := func(req *http.Request, workspaceUuid string) *http.Request {
+		token := auth.ApiToken{ID: 1, WorkspaceUuid: workspaceUuid, CreatedBy: "owner_pubkey", Scopes: []string{db.ApiScopeCreateComments}}
+		return req.WithContext(context.WithValue(req.Context(), auth.ApiTokenContextKey, token))
+	}
+
+	t.Run("should reject an empty comment", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.CreateBountyComment).ServeHTTP(rr, newRequest(http.MethodPost, "hunter_pubkey", `{"body": "   "}`, ""))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should not let outsiders comment on a hidden bounty", func(t *testing.T) {
+		hidden := bounty
+		hidden.Show = false
+		mockDb.On("GetBounty", uint(1)).Return(hidden).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.CreateBountyComment).ServeHTTP(rr, newRequest(http.MethodPost, "other_pubkey", `{"body": "hello"}`, ""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should add a human comment and ignore the agent name", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("CreateBountyComment", db.BountyComment{BountyID: 1, AuthorPubkey: "hunter_pubkey", AuthorType: db.CommentAuthorHuman, Body: "On it"}).
+			Return(db.BountyComment{ID: 3, BountyID: 1, AuthorPubkey: "hunter_pubkey", AuthorType: db.CommentAuthorHuman, Body: "On it"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.CreateBountyComment).ServeHTTP(rr, newRequest(http.MethodPost, "hunter_pubkey", `{"body": " On it ", "agent_name": "reviewer"}`, ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var comment db.BountyComment
+		json.Unmarshal(rr.Body.Bytes(), &comment)
+		assert.Equal(t, db.CommentAuthorHuman, comment.AuthorType)
+	})
+
+	t.Run("should not take an agent note from another workspace token", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		req := withToken(newRequest(http.MethodPost, "owner_pubkey", `{"body": "LGTM", "agent_name": "reviewer"}`, ""), "other_workspace")
+		http.HandlerFunc(bHandler.CreateBountyComment).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should stop agent notes once the monthly quota is used", func(t *testing.T) {
+		settings := db.DefaultWorkspaceSettings("workspace_uuid")
+		settings.AiAgentMonthlyQuota = 5
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(settings).Once()
+		mockDb.On("GetWorkspaceAgentCommentsCount", "workspace_uuid", mock.AnythingOfType("time.Time")).Return(int64(5)).Once()
+
+		rr := httptest.NewRecorder()
+		req := withToken(newRequest(http.MethodPost, "owner_pubkey", `{"body": "LGTM", "agent_name": "reviewer"}`, ""), "workspace_uuid")
+		http.HandlerFunc(bHandler.CreateBountyComment).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	})
+
+	t.Run("should add an agent note with an api token", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(db.DefaultWorkspaceSettings("workspace_uuid")).Once()
+		mockDb.On("CreateBountyComment", db.BountyComment{BountyID: 1, AuthorPubkey: "owner_pubkey", AuthorType: db.CommentAuthorAIAgent, AgentName: "reviewer", Body: "LGTM"}).
+			Return(db.BountyComment{ID: 4, BountyID: 1, AuthorType: db.CommentAuthorAIAgent, AgentName: "reviewer", Body: "LGTM"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req := withToken(newRequest(http.MethodPost, "owner_pubkey", `{"body": "LGTM", "agent_name": "reviewer"}`, ""), "workspace_uuid")
+		http.HandlerFunc(bHandler.CreateBountyComment).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should only let the author or a manager delete a comment", func(t *testing.T) {
+		comment := db.BountyComment{ID: 3, BountyID: 1, AuthorPubkey: "hunter_pubkey", AuthorType: db.CommentAuthorHuman, Body: "On it"}
+
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetBountyComment", uint(1), uint(3)).Return(comment, nil).Once()
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.DeleteBountyComment).ServeHTTP(rr, newRequest(http.MethodDelete, "other_pubkey", "", "3"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetBountyComment", uint(1), uint(3)).Return(comment, nil).Once()
+		mockDb.On("DeleteBountyComment", uint(3)).Return(nil).Once()
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(bHandler.DeleteBountyComment).ServeHTTP(rr, newRequest(http.MethodDelete, "manager_pubkey", "", "3"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return 404 for a missing comment", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetBountyComment", uint(1), uint(9)).Return(db.BountyComment{}, db.ErrBountyCommentNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.DeleteBountyComment).ServeHTTP(rr, newRequest(http.MethodDelete, "owner_pubkey", "", "9"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestWorkspaceSettingsLimits(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	bHandler := NewBountyHandler(http.DefaultClient, mockDb)
+	bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return true
+	}
+	bHandler.userHasManageBountyRoles = func(pubKeyFromAuth string, uuid string) bool {
+		return true
+	}
+
+	settings := db.DefaultWorkspaceSettings("workspace_uuid")
+	settings.WipLimit = 2
+	settings.PaymentApprovalThreshold = 500
+
+	newRequest := func(pubkey string, params map[string]string) *http.Request {
+		rctx := chi.NewRouteContext()
+		for k, v := range params {
+			rctx.URLParams.Add(k, v)
+		}
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/", bytes.NewBufferString("{}"))
+		return req
+	}
+
+	t.Run("should not accept an applicant at the wip limit", func(t *testing.T) {
+		bounty := db.NewBounty{ID: 1, OwnerID: "owner_pubkey", WorkspaceUuid: "workspace_uuid"}
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("GetBountyApplicationById", uint(7)).Return(db.BountyApplication{ID: 7, BountyID: 1, ApplicantPubkey: "hunter_pubkey", Status: db.BountyApplicationPending}, nil).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(settings).Once()
+		mockDb.On("GetWorkspaceActiveBountiesCount", "workspace_uuid", "hunter_pubkey").Return(int64(2)).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.AcceptBountyApplication).ServeHTTP(rr, newRequest("owner_pubkey", map[string]string{"id": "1", "applicationId": "7"}))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should leave payments over the threshold to the workspace owner", func(t *testing.T) {
+		bounty := db.NewBounty{ID: 2, OwnerID: "owner_pubkey", WorkspaceUuid: "workspace_uuid", Assignee: "hunter_pubkey", Price: 1000}
+		mockDb.On("GetBounty", uint(2)).Return(bounty).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(settings).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.MakeBountyPayment).ServeHTTP(rr, newRequest("payer_pubkey", map[string]string{"id": "2"}))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestGetBountyByIdVisibility(t *testing.T) {
+	mockHttpClient := mocks.NewHttpClient(t)
+	bounty := db.NewBounty{
+		ID:            7,
+		OwnerID:       "owner_pubkey",
+		Title:         "internal work item",
+		WorkspaceUuid: "workspace_uuid",
+		Visibility:    db.BountyVisibilityWorkspace,
+	}
+
+	getBounty := func(pubkey string) []db.BountyResponse {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("bountyId", "7")
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		if pubkey != "" {
+			ctx = context.WithValue(ctx, auth.ContextKey, pubkey)
+		}
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/gobounties/id/7", nil)
+		rr := httptest.NewRecorder()
+
+		mockDb := dbMocks.NewDatabase(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		mockDb.On("GetBountyById", "7").Return([]db.NewBounty{bounty}, nil).Once()
+		mockDb.On("GetWorkspaceUser", mock.Anything, "workspace_uuid").Return(db.WorkspaceUsers{}).Maybe()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "workspace_owner"}).Maybe()
+		mockDb.On("GetPeopleByPubkeys", mock.Anything).Return(map[string]db.Person{}).Maybe()
+		mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(db.DefaultWorkspaceSettings("workspace_uuid")).Maybe()
+		http.HandlerFunc(bHandler.GetBountyById).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		bounties := []db.BountyResponse{}
+		json.Unmarshal(rr.Body.Bytes(), &bounties)
+		return bounties
+	}
+
+	t.Run("should hide workspace bounties from anonymous requests", func(t *testing.T) {
+		assert.Empty(t, getBounty(""))
+	})
+
+	t.Run("should hide workspace bounties from people outside the workspace", func(t *testing.T) {
+		assert.Empty(t, getBounty("outsider"))
+	})
+
+	t.Run("should show workspace bounties to the workspace owner and the bounty owner", func(t *testing.T) {
+		assert.Len(t, getBounty("workspace_owner"), 1)
+		assert.Len(t, getBounty("owner_pubkey"), 1)
+	})
+}
+
+func TestGenerateBountyResponseDisplay(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	bHandler := NewBountyHandler(mocks.NewHttpClient(t), mockDb)
+	rateTimestamp := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	bHandler.rates = fakeRates{rate: rates.Rate{Currency: "eur", BtcPrice: 50000, Timestamp: rateTimestamp}}
+
+	settings := db.DefaultWorkspaceSettings("workspace_uuid")
+	settings.DisplayCurrency = "eur"
+	mockDb.On("GetPeopleByPubkeys", mock.Anything).Return(map[string]db.Person{})
+	mockDb.On("GetWorkspaceByUuid", mock.Anything).Return(db.Workspace{})
+	mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(settings).Once()
+	mockDb.On("GetWorkspaceSettings", "sats_workspace").Return(db.DefaultWorkspaceSettings("sats_workspace")).Once()
+
+	responses := bHandler.GenerateBountyResponse([]db.NewBounty{
+		{ID: 1, WorkspaceUuid: "workspace_uuid", Price: 20000},
+		{ID: 2, WorkspaceUuid: "workspace_uuid", Price: 3000},
+		{ID: 3, WorkspaceUuid: "sats_workspace", Price: 3000},
+	})
+
+	assert.Equal(t, &db.DisplayAmount{Currency: "eur", Amount: 10, BtcPrice: 50000, RateTimestamp: rateTimestamp}, responses[0].Display)
+	assert.Equal(t, 1.5, responses[1].Display.Amount)
+	assert.Nil(t, responses[2].Display)
+}
+
+func TestGenerateBountyResponsePeople(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	bHandler := NewBountyHandler(mocks.NewHttpClient(t), mockDb)
+
+	mockDb.On("GetPeopleByPubkeys", []string{"owner_pubkey", "hunter_pubkey", "owner_pubkey", ""}).Return(map[string]db.Person{
+		"owner_pubkey":  {ID: 1, OwnerPubKey: "owner_pubkey", OwnerAlias: "owner"},
+		"hunter_pubkey": {ID: 2, OwnerPubKey: "hunter_pubkey", OwnerAlias: "hunter"},
+	}).Once()
+	mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid"})
+	mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(db.DefaultWorkspaceSettings("workspace_uuid"))
+
+	responses := bHandler.GenerateBountyResponse([]db.NewBounty{
+		{ID: 1, WorkspaceUuid: "workspace_uuid", OwnerID: "owner_pubkey", Assignee: "hunter_pubkey"},
+		{ID: 2, WorkspaceUuid: "workspace_uuid", OwnerID: "owner_pubkey"},
+	})
+
+	assert.Equal(t, "owner", responses[0].Owner.OwnerAlias)
+	assert.Equal(t, "hunter", responses[0].Assignee.OwnerAlias)
+	assert.Equal(t, "owner", responses[1].Owner.OwnerAlias)
+	assert.Empty(t, responses[1].Assignee.OwnerPubKey)
+}
+
+func TestCloseDueBountyAuctions(t *testing.T) {
+	now := time.Now()
+	bids := []db.BountyBid{
+		{ID: 1, AuctionID: 1, BidderPubkey: "expensive_hunter", Amount: 5000, Status: db.BountyBidActive},
+		{ID: 2, AuctionID: 1, BidderPubkey: "cheap_hunter", Amount: 3000, Status: db.BountyBidActive},
+	}
+	notifier := func(notified *[]string) func(pubkey string, content string) error {
+		return func(pubkey string, content string) error {
+			*notified = append(*notified, pubkey)
+			return nil
+		}
+	}
+
+	t.Run("should assign the lowest bid of auto select auctions", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		auction := db.BountyAuction{ID: 1, BountyID: 3, AutoSelect: true, CreatedBy: "owner_pubkey", Status: db.BountyAuctionOpen}
+		mockDb.On("GetDueBountyAuctions", now).Return([]db.BountyAuction{auction}).Once()
+		mockDb.On("GetBountyBids", uint(1)).Return(bids).Once()
+		mockDb.On("CloseBountyAuction", auction, uint(2)).Return(db.NewBounty{ID: 3, Assignee: "cheap_hunter", Price: 3000}, nil).Once()
+
+		notified := []string{}
+		CloseDueBountyAuctions(mockDb, now, notifier(&notified))
+
+		assert.Equal(t, []string{"cheap_hunter", "owner_pubkey"}, notified)
+	})
+
+	t.Run("should assign the bid the owner selected", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		selected := uint(1)
+		auction := db.BountyAuction{ID: 1, BountyID: 3, AutoSelect: true, SelectedBidID: &selected, CreatedBy: "owner_pubkey", Status: db.BountyAuctionOpen}
+		mockDb.On("GetDueBountyAuctions", now).Return([]db.BountyAuction{auction}).Once()
+		mockDb.On("GetBountyBids", uint(1)).Return(bids).Once()
+		mockDb.On("CloseBountyAuction", auction, uint(1)).Return(db.NewBounty{ID: 3, Assignee: "expensive_hunter", Price: 5000}, nil).Once()
+
+		notified := []string{}
+		CloseDueBountyAuctions(mockDb, now, notifier(&notified))
+
+		assert.Equal(t, []string{"expensive_hunter", "owner_pubkey"}, notified)
+	})
+
+	t.Run("should wait for the owner to select a bid without auto select", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		auction := db.BountyAuction{ID: 1, BountyID: 3, CreatedBy: "owner_pubkey", Status: db.BountyAuctionOpen}
+		mockDb.On("GetDueBountyAuctions", now).Return([]db.BountyAuction{auction}).Once()
+		mockDb.On("GetBountyBids", uint(1)).Return(bids).Once()
+		mockDb.On("UpdateBountyAuctionStatus", uint(1), db.BountyAuctionAwaitingSelection).Return(nil).Once()
+
+		notified := []string{}
+		CloseDueBountyAuctions(mockDb, now, notifier(&notified))
+
+		assert.Equal(t, []string{"owner_pubkey"}, notified)
+	})
+
+	t.Run("should close auctions without bids", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		auction := db.BountyAuction{ID: 1, BountyID: 3, CreatedBy: "owner_pubkey", Status: db.BountyAuctionOpen}
+		mockDb.On("GetDueBountyAuctions", now).Return([]db.BountyAuction{auction}).Once()
+		mockDb.On("GetBountyBids", uint(1)).Return([]db.BountyBid{}).Once()
+		mockDb.On("CloseBountyAuction", auction, uint(0)).Return(db.NewBounty{ID: 3}, nil).Once()
+
+		notified := []string{}
+		CloseDueBountyAuctions(mockDb, now, notifier(&notified))
+
+		assert.Equal(t, []string{"owner_pubkey"}, notified)
+	})
+}
+
+func TestPlaceBountyBid(t *testing.T) {
+	mockHttpClient := mocks.NewHttpClient(t)
+	openBounty := db.NewBounty{ID: 1, OwnerID: "owner_pubkey", Title: "bounty"}
+
+	newRequest := func(pubKey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubKey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/1/auction/bids", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should not take bids after the auction ended", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		ended := time.Now().Add(-time.Minute)
+		mockDb.On("GetBounty", uint(1)).Return(openBounty).Once()
+		mockDb.On("GetBountyAuction", uint(1)).Return(db.BountyAuction{ID: 4, BountyID: 1, EndsAt: &ended, Status: db.BountyAuctionOpen}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.PlaceBountyBid).ServeHTTP(rr, newRequest("hunter_pubkey", `{"amount": 1000}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should place the bid of a hunter", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		ends := time.Now().Add(time.Hour)
+		mockDb.On("GetBounty", uint(1)).Return(openBounty).Once()
+		mockDb.On("GetBountyAuction", uint(1)).Return(db.BountyAuction{ID: 4, BountyID: 1, EndsAt: &ends, Status: db.BountyAuctionOpen}, nil).Once()
+		mockDb.On("PlaceBountyBid", mock.MatchedBy(func(b db.BountyBid) bool {
+			return b.AuctionID == 4 && b.BidderPubkey == "hunter_pubkey" && b.Amount == 1000 && b.Eta != nil
+		})).Return(db.BountyBid{ID: 9, AuctionID: 4, Amount: 1000}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.PlaceBountyBid).ServeHTTP(rr, newRequest("hunter_pubkey", `{"amount": 1000, "eta": "2030-01-02T00:00:00Z"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should not let the owner bid", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		mockDb.On("GetBounty", uint(1)).Return(openBounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.PlaceBountyBid).ServeHTTP(rr, newRequest("owner_pubkey", `{"amount": 1000}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestWithdrawWorkspaceBudget(t *testing.T) {
+	invoice := "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
+	workspace := db.Workspace{Uuid: "workspace-1", Name: "workspace", OwnerPubKey: "owner_pubkey"}
+	reservation := db.BudgetReservation{Id: "reservation-1", WorkspaceUuid: "workspace-1", Amount: 1500}
+
+	newRequest := func(pubKey string, refundId string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace-1")
+		rctx.URLParams.Add("refundId", refundId)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubKey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/budget/workspace-1/withdraw", strings.NewReader(body))
+		return req
+	}
+	paidResponse := func() *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"success": true}`))}
+	}
+
+	t.Run("should only let the workspace owner withdraw", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		bHandler := NewBountyHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetWorkspaceByUuid", "workspace-1").Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.WithdrawWorkspaceBudget).ServeHTTP(rr, newRequest("admin_pubkey", "", `{"payment_request": "`+invoice+`"}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should pay refunds under the approval threshold right away", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.invoiceExpired = func(paymentRequest string) bool { return false }
+		refund := db.BudgetRefund{ID: 1, WorkspaceUuid: "workspace-1", Amount: 1500, PaymentRequest: invoice, ReservationId: "reservation-1", RequestedBy: "owner_pubkey", Status: db.BudgetRefundPendingApproval}
+		mockDb.On("GetWorkspaceByUuid", "workspace-1").Return(workspace).Once()
+		mockDb.On("ReserveBudget", "workspace-1", uint(1500)).Return(reservation, nil).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace-1").Return(db.WorkspaceSettings{PaymentApprovalThreshold: 2000}).Once()
+		mockDb.On("CreateBudgetRefund", mock.AnythingOfType("db.BudgetRefund")).Return(refund, nil).Once()
+		mockDb.On("ClaimBudgetRefund", uint(1), "").Return(nil).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(paidResponse(), nil).Once()
+		mockDb.On("CompleteBudgetRefund", mock.MatchedBy(func(r db.BudgetRefund) bool {
+			return r.ID == 1 && r.Status == db.BudgetRefundPaying
+		})).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.WithdrawWorkspaceBudget).ServeHTTP(rr, newRequest("owner_pubkey", "", `{"payment_request": "`+invoice+`"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should hold refunds over the approval threshold for a second admin", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return pubKeyFromAuth == "admin_pubkey"
+		}
+		refund := db.BudgetRefund{ID: 1, WorkspaceUuid: "workspace-1", Amount: 1500, RequestedBy: "owner_pubkey", Status: db.BudgetRefundPendingApproval}
+		mockDb.On("GetWorkspaceByUuid", "workspace-1").Return(workspace).Once()
+		mockDb.On("ReserveBudget", "workspace-1", uint(1500)).Return(reservation, nil).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace-1").Return(db.WorkspaceSettings{PaymentApprovalThreshold: 1000}).Once()
+		mockDb.On("CreateBudgetRefund", mock.AnythingOfType("db.BudgetRefund")).Return(refund, nil).Once()
+		mockDb.On("GetWorkspaceUsers", "workspace-1").Return([]db.WorkspaceUsersData{{Person: db.Person{OwnerPubKey: "admin_pubkey"}}, {Person: db.Person{OwnerPubKey: "hunter_pubkey"}}}, nil).Once()
+		mockDb.On("CreateNotification", mock.MatchedBy(func(n db.Notification) bool {
+			return n.RecipientPubkey == "admin_pubkey" && n.Type == db.RefundApprovalNotification
+		})).Return(db.Notification{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.WithdrawWorkspaceBudget).ServeHTTP(rr, newRequest("owner_pubkey", "", `{"payment_request": "`+invoice+`"}`))
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should not let the requester approve their own refund", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		bHandler := NewBountyHandler(mocks.NewHttpClient(t), mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return true
+		}
+		mockDb.On("GetBudgetRefund", uint(1)).Return(db.BudgetRefund{ID: 1, WorkspaceUuid: "workspace-1", RequestedBy: "owner_pubkey", Status: db.BudgetRefundPendingApproval}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.ApproveWorkspaceBudgetRefund).ServeHTTP(rr, newRequest("owner_pubkey", "1", ""))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should pay a refund once a second admin approves it", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return true
+		}
+		bHandler.invoiceExpired = func(paymentRequest string) bool { return false }
+		refund := db.BudgetRefund{ID: 1, WorkspaceUuid: "workspace-1", Amount: 1500, PaymentRequest: invoice, RequestedBy: "owner_pubkey", Status: db.BudgetRefundPendingApproval}
+		mockDb.On("GetBudgetRefund", uint(1)).Return(refund, nil).Once()
+		mockDb.On("ClaimBudgetRefund", uint(1), "admin_pubkey").Return(nil).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(paidResponse(), nil).Once()
+		mockDb.On("CompleteBudgetRefund", mock.MatchedBy(func(r db.BudgetRefund) bool {
+			return r.ID == 1 && r.ApprovedBy == "admin_pubkey"
+		})).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.ApproveWorkspaceBudgetRefund).ServeHTTP(rr, newRequest("admin_pubkey", "1", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	approver := func(mockHttpClient *mocks.HttpClient, mockDb *dbMocks.Database) *bountyHandler {
+		bHandler := NewBountyHandler(mockHttpClient, mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return true
+		}
+		bHandler.invoiceExpired = func(paymentRequest string) bool { return false }
+		mockDb.On("GetBudgetRefund", uint(1)).Return(db.BudgetRefund{ID: 1, WorkspaceUuid: "workspace-1", Amount: 1500, PaymentRequest: invoice, ReservationId: "reservation-1", RequestedBy: "owner_pubkey", Status: db.BudgetRefundPendingApproval}, nil).Once()
+		return bHandler
+	}
+
+	t.Run("should not pay a refund another approval claimed", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := approver(mockHttpClient, mockDb)
+		mockDb.On("ClaimBudgetRefund", uint(1), "admin_pubkey").Return(db.ErrBudgetRefundNotPending).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.ApproveWorkspaceBudgetRefund).ServeHTTP(rr, newRequest("admin_pubkey", "1", ""))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should fail a refund whose invoice expired while it waited", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := approver(mockHttpClient, mockDb)
+		bHandler.invoiceExpired = func(paymentRequest string) bool { return true }
+		mockDb.On("ClaimBudgetRefund", uint(1), "admin_pubkey").Return(nil).Once()
+		mockDb.On("ReleaseBudget", reservation).Return(nil).Once()
+		mockDb.On("UpdateBudgetRefundStatus", uint(1), db.BudgetRefundFailed, "admin_pubkey", "invoice expired").Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.ApproveWorkspaceBudgetRefund).ServeHTTP(rr, newRequest("admin_pubkey", "1", ""))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should leave a refund paying when the payment has no known outcome", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := approver(mockHttpClient, mockDb)
+		mockDb.On("ClaimBudgetRefund", uint(1), "admin_pubkey").Return(nil).Once()
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(nil, errors.New("connection reset")).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.ApproveWorkspaceBudgetRefund).ServeHTTP(rr, newRequest("admin_pubkey", "1", ""))
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockDb.AssertNotCalled(t, "ReleaseBudget", mock.Anything)
+		mockDb.AssertNotCalled(t, "UpdateBudgetRefundStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestResolveLightningAddress(t *testing.T) {
+	invoice := "lnbc15u1p3xnhl2pp5jptserfk3zk4qy42tlucycrfwxhydvlemu9pqr93tuzlv9cc7g3sdqsvfhkcap3xyhx7un8cqzpgxqzjcsp5f8c52y2stc300gl6s4xswtjpc37hrnnr3c9wvtgjfuvqmpm35evq9qyyssqy4lgd8tj637qcjp05rdpxxykjenthxftej7a2zzmwrmrl70fyj9hvj0rewhzj7jfyuwkwcg9g2jpwtk3wkjtwnkdks84hsnu8xps5vsq4gj5hs"
+	response := func(body string) *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(body))}
+	}
+	params := `{"tag": "payRequest", "callback": "https://getalby.com/lnurlp/hunter/callback", "minSendable": 1000, "maxSendable": 100000000}`
+	lnurlHandler := func(mockHttpClient *mocks.HttpClient) *bountyHandler {
+		bHandler := NewBountyHandler(mockHttpClient, dbMocks.NewDatabase(t))
+		bHandler.publicClient = mockHttpClient
+		bHandler.checkPublicHost = func(host string) error { return nil }
+		return bHandler
+	}
+
+	t.Run("should get an invoice for the amount from the callback", func(t *testing.T) {
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := lnurlHandler(mockHttpClient)
+		mockHttpClient.On("Do", mock.MatchedBy(func(r *http.Request) bool {
+			return r.URL.String() == "https://getalby.com/.well-known/lnurlp/hunter"
+		})).Return(response(params), nil).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(r *http.Request) bool {
+			return r.URL.Path == "/lnurlp/hunter/callback" && r.URL.Query().Get("amount") == "1500000"
+		})).Return(response(`{"pr": "`+invoice+`"}`), nil).Once()
+
+		pr, err := bHandler.resolveLightningAddress(context.Background(), "hunter@getalby.com", 1500)
+
+		assert.NoError(t, err)
+		assert.Equal(t, invoice, pr)
+	})
+
+	t.Run("should reject an invoice for another amount", func(t *testing.T) {
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := lnurlHandler(mockHttpClient)
+		mockHttpClient.On("Do", mock.Anything).Return(response(params), nil).Once()
+		mockHttpClient.On("Do", mock.Anything).Return(response(`{"pr": "`+invoice+`"}`), nil).Once()
+
+		_, err := bHandler.resolveLightningAddress(context.Background(), "hunter@getalby.com", 2000)
+
+		assert.EqualError(t, err, "lightning address invoice amount does not match")
+	})
+
+	t.Run("should report the error of the lightning address", func(t *testing.T) {
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := lnurlHandler(mockHttpClient)
+		mockHttpClient.On("Do", mock.Anything).Return(response(`{"status": "ERROR", "reason": "unknown user"}`), nil).Once()
+
+		_, err := bHandler.resolveLightningAddress(context.Background(), "hunter@getalby.com", 1500)
+
+		assert.EqualError(t, err, "unknown user")
+	})
+
+	t.Run("should not resolve amounts outside of the sendable range", func(t *testing.T) {
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := lnurlHandler(mockHttpClient)
+		mockHttpClient.On("Do", mock.Anything).Return(response(`{"tag": "payRequest", "callback": "https://getalby.com/cb", "minSendable": 1000, "maxSendable": 1000000}`), nil).Once()
+
+		_, err := bHandler.resolveLightningAddress(context.Background(), "hunter@getalby.com", 1500)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should not call a lightning address on an internal host", func(t *testing.T) {
+		mockHttpClient := mocks.NewHttpClient(t)
+		bHandler := lnurlHandler(mockHttpClient)
+		bHandler.checkPublicHost = func(host string) error { return utils.ErrNotPublicAddress }
+
+		_, err := bHandler.resolveLightningAddress(context.Background(), "hunter@metadata.internal", 1500)
+
+		assert.EqualError(t, err, "lightning address is not on a public host")
+		mockHttpClient.AssertNotCalled(t, "Do", mock.Anything)
+	})
+
+	t.Run("should not call a callback that is not public https", func(t *testing.T) {
+		for callback, message := range map[string]string{
+			"http://getalby.com/callback":      "lightning address has an invalid callback",
+			"https://169.254.169.254/callback": "lightning address callback is not on a public host",
+		} {
+			mockHttpClient := mocks.NewHttpClient(t)
+			bHandler := lnurlHandler(mockHttpClient)
+			bHandler.checkPublicHost = func(host string) error {
+				if host == "getalby.com" {
+					return nil
+				}
+				return utils.ErrNotPublicAddress
+			}
+			mockHttpClient.On("Do", mock.Anything).Return(response(`{"tag": "payRequest", "callback": "`+callback+`", "minSendable": 1000, "maxSendable": 100000000}`), nil).Once()
+
+			_, err := bHandler.resolveLightningAddress(context.Background(), "hunter@getalby.com", 1500)
+
+			assert.EqualError(t, err, message, callback)
+		}
+	})
+}
+
+func TestBountyTags(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	bHandler := NewBountyHandler(mockHttpClient, mockDb)
+	bHandler.userHasManageBountyRoles = func(pubKeyFromAuth string, uuid string) bool {
+		return false
+	}
+
+	bounty := db.NewBounty{ID: 1, OwnerID: "owner_pubkey", WorkspaceUuid: "workspace_uuid", Tags: pq.StringArray{"tag_a"}}
+
+	newRequest := func(method string, pubKey string, tagUuid string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		rctx.URLParams.Add("tag_uuid", tagUuid)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubKey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), method, "/1/tags", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should only let the bounty managers tag it", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.AddBountyTag).ServeHTTP(rr, newRequest(http.MethodPost, "other_pubkey", "tag_b", ""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should add a tag to the bounty", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("SetBountyTags", bounty, []string{"tag_a", "tag_b"}).Return(db.NewBounty{ID: 1, Tags: pq.StringArray{"tag_a", "tag_b"}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.AddBountyTag).ServeHTTP(rr, newRequest(http.MethodPost, "owner_pubkey", "tag_b", ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		tags := []string{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tags))
+		assert.Equal(t, []string{"tag_a", "tag_b"}, tags)
+	})
+
+	t.Run("should remove a tag from the bounty", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("SetBountyTags", bounty, []string{}).Return(db.NewBounty{ID: 1, Tags: pq.StringArray{}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.RemoveBountyTag).ServeHTTP(rr, newRequest(http.MethodDelete, "owner_pubkey", "tag_a", ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should refuse tags of another workspace", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(bounty).Once()
+		mockDb.On("SetBountyTags", bounty, []string{"foreign_tag"}).Return(bounty, db.ErrTagNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.SetBountyTags).ServeHTTP(rr, newRequest(http.MethodPut, "owner_pubkey", "", `{"tags": ["foreign_tag"]}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestSuggestBountyPrice(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	bHandler := NewBountyHandler(mockHttpClient, mockDb)
+	defer config.ResetOverrides()
+
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+
+	newRequest := func(pubKey string, query string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubKey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/price/suggestion?"+query, nil)
+		return req
+	}
+
+	t.Run("should be hidden while the feature flag is off", func(t *testing.T) {
+		config.ResetOverrides()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.SuggestBountyPrice).ServeHTTP(rr, newRequest("owner_pubkey", "workspace_uuid=workspace_uuid"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	config.ApplyOverrides(map[string]string{config.FeatureFlagPrefix + PriceSuggestionsFlag: "true"})
+
+	t.Run("should refuse a negative estimate", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.SuggestBountyPrice).ServeHTTP(rr, newRequest("owner_pubkey", "workspace_uuid=workspace_uuid&estimated_hours=-2"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should only suggest prices to workspace members", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceUser", "other_pubkey", workspace.Uuid).Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.SuggestBountyPrice).ServeHTTP(rr, newRequest("other_pubkey", "workspace_uuid=workspace_uuid"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should suggest a price range from the paid bounties", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetBountyPriceStats", workspace.Uuid).Return([]db.BountyPriceStat{
+			{WorkspaceUuid: workspace.Uuid, Language: "Go", Scope: "medium", Bounties: 20, P25Sats: 8000, MedianSats: 10000, P75Sats: 12000},
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.SuggestBountyPrice).ServeHTTP(rr, newRequest("owner_pubkey", "workspace_uuid=workspace_uuid&languages=Go,Rust&estimated_hours=8"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		suggestion := db.PriceSuggestion{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &suggestion))
+		assert.Equal(t, uint(10000), suggestion.SuggestedSats)
+		assert.Equal(t, uint(8000), suggestion.LowSats)
+		assert.Equal(t, uint(12000), suggestion.HighSats)
+		assert.Equal(t, "high", suggestion.ConfidenceLevel)
+	})
+
+	t.Run("should return 404 without enough paid bounties", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetBountyPriceStats", workspace.Uuid).Return([]db.BountyPriceStat{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.SuggestBountyPrice).ServeHTTP(rr, newRequest("owner_pubkey", "workspace_uuid=workspace_uuid"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
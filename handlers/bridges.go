@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+)
+
+const bridgeTimeout = 10 * time.Second
+
+var bridgeEventTypes = []db.WorkspaceEventType{
+	db.BountyCreatedEvent,
+	db.BountyStateChangedEvent,
+	db.FeatureCreatedEvent,
+	db.FeatureUpdatedEvent,
+}
+
+type bridgeHandler struct {
+	db            db.Database
+	httpClient    HttpClient
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+
+	// bridgeClient posts to the bridge targets, it only dials public addresses
+	bridgeClient    HttpClient
+	checkBridgeHost func(host string) error
+}
+
+func NewBridgeHandler(httpClient HttpClient, database db.Database) *bridgeHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &bridgeHandler{
+		db:            database,
+		httpClient:    httpClient,
+		userHasAccess: dbConf.UserHasAccess,
+
+		bridgeClient:    utils.NewPublicHttpClient(bridgeTimeout),
+		checkBridgeHost: utils.CheckPublicHost,
+	}
+}
+
+// validateBridgeRequest checks the target of a bridge, Discord bridges need a
+// Discord webhook url and Matrix bridges a homeserver, a room id and a token
+func (bh *bridgeHandler) validateBridgeRequest(request db.WorkspaceBridgeRequest) error {
+	if strings.TrimSpace(request.Name) == "" {
+		return errors.New("name is a required field")
+	}
+
+	switch request.Kind {
+	case db.BridgeDiscord:
+		webhook, err := url.Parse(request.WebhookUrl)
+		if err != nil || webhook.Scheme != "https" || (webhook.Host != "discord.com" && webhook.Host != "discordapp.com") || !strings.HasPrefix(webhook.Path, "/api/webhooks/") {
+			return errors.New("webhook_url must be a Discord webhook url")
+		}
+	case db.BridgeMatrix:
+		homeserver, err := url.Parse(request.MatrixHomeserver)
+		if err != nil || homeserver.Scheme != "https" || homeserver.Hostname() == "" {
+			return errors.New("matrix_homeserver must be an https url")
+		}
+		if err := bh.checkBridgeHost(homeserver.Hostname()); err != nil {
+			fmt.Println("[bridges] matrix homeserver refused", err)
+			return errors.New("matrix_homeserver must be a public https url")
+		}
+		if !strings.HasPrefix(request.MatrixRoomId, "!") || !strings.Contains(request.MatrixRoomId, ":") {
+			return errors.New("matrix_room_id must be a room id like !room:matrix.org")
+		}
+		if request.MatrixToken == "" {
+			return errors.New("matrix_token is a required field")
+		}
+	default:
+		return fmt.Errorf("kind must be %q or %q", db.BridgeDiscord, db.BridgeMatrix)
+	}
+
+	for _, event := range request.Events {
+		known := false
+		for _, eventType := range bridgeEventTypes {
+			known = known || event == string(eventType)
+		}
+		if !known {
+			return fmt.Errorf("unknown event %q", event)
+		}
+	}
+	return nil
+}
+
+func (bh *bridgeHandler) CreateWorkspaceBridge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bridges] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !bh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage bridges")
+		return
+	}
+
+	request := db.WorkspaceBridgeRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[bridges]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if err := bh.validateBridgeRequest(request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	bridge := db.WorkspaceBridge{
+		Uuid:          xid.New().String(),
+		WorkspaceUuid: uuid,
+		Name:          strings.TrimSpace(request.Name),
+		Kind:          request.Kind,
+		Events:        request.Events,
+		CreatedBy:     pubKeyFromAuth,
+	}
+	if request.Kind == db.BridgeDiscord {
+		bridge.WebhookUrl = request.WebhookUrl
+	} else {
+		bridge.MatrixHomeserver = strings.TrimSuffix(request.MatrixHomeserver, "/")
+		bridge.MatrixRoomId = request.MatrixRoomId
+		bridge.MatrixToken = request.MatrixToken
+	}
+	if bridge.Events == nil {
+		bridge.Events = []string{}
+	}
+
+	bridge, err = bh.db.CreateWorkspaceBridge(bridge)
+	if err != nil {
+		fmt.Println("[bridges] could not create bridge", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not create the bridge")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bridge)
+}
+
+func (bh *bridgeHandler) GetWorkspaceBridges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bridges] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !bh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage bridges")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bh.db.GetWorkspaceBridges(uuid))
+}
+
+func (bh *bridgeHandler) DeleteWorkspaceBridge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bridges] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !bh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage bridges")
+		return
+	}
+
+	err := bh.db.DeleteWorkspaceBridge(uuid, chi.URLParam(r, "bridge_uuid"))
+	if errors.Is(err, db.ErrBridgeNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[bridges] could not delete bridge", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete the bridge")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Bridge deleted")
+}
+
+// OnWorkspaceEvent posts an event to the bridges of its workspace in the
+// background, it is subscribed to the workspace events in the router
+func (bh *bridgeHandler) OnWorkspaceEvent(event db.WorkspaceEvent) {
+	go bh.DeliverWorkspaceEvent(event)
+}
+
+// DeliverWorkspaceEvent posts an event to the bridges of its workspace that
+// follow its type and records how each post went
+func (bh *bridgeHandler) DeliverWorkspaceEvent(event db.WorkspaceEvent) {
+	for _, bridge := range bh.db.GetWorkspaceBridges(event.WorkspaceUuid) {
+		if !bridgeFollows(bridge, event.Type) {
+			continue
+		}
+
+		var err error
+		switch bridge.Kind {
+		case db.BridgeDiscord:
+			err = bh.postDiscord(bridge, event)
+		case db.BridgeMatrix:
+			err = bh.postMatrix(bridge, event)
+		default:
+			continue
+		}
+		if err != nil {
+			fmt.Println("[bridges] could not post to bridge", bridge.Uuid, err)
+		}
+		if err := bh.db.RecordBridgeDelivery(bridge.Uuid, time.Now(), err); err != nil {
+			fmt.Println("[bridges] could not record delivery", bridge.Uuid, err)
+		}
+	}
+}
+
+func bridgeFollows(bridge db.WorkspaceBridge, eventType db.WorkspaceEventType) bool {
+	if len(bridge.Events) == 0 {
+		return true
+	}
+	for _, event := range bridge.Events {
+		if event == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (bh *bridgeHandler) post(method string, target string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), bridgeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := bh.bridgeClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s answered %d", req.URL.Host, res.StatusCode)
+	}
+	return nil
+}
+
+// postDiscord sends the event as an embed to a Discord webhook
+func (bh *bridgeHandler) postDiscord(bridge db.WorkspaceBridge, event db.WorkspaceEvent) error {
+	return bh.post(http.MethodPost, bridge.WebhookUrl, nil, map[string]interface{}{
+		"embeds": []map[string]interface{}{{
+			"title":       event.Title,
+			"description": event.Message,
+			"url":         event.Link,
+			"timestamp":   event.Created.UTC().Format(time.RFC3339),
+		}},
+	})
+}
+
+// postMatrix sends the event as a notice to a Matrix room, the transaction id
+// keeps a retried send from posting twice
+func (bh *bridgeHandler) postMatrix(bridge db.WorkspaceBridge, event db.WorkspaceEvent) error {
+	target := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		bridge.MatrixHomeserver, url.PathEscape(bridge.MatrixRoomId), xid.New().String())
+	return bh.post(http.MethodPut, target, map[string]string{"Authorization": "Bearer " + bridge.MatrixToken}, map[string]string{
+		"msgtype": "m.notice",
+		"body":    fmt.Sprintf("%s: %s %s", event.Title, event.Message, event.Link),
+		"format":  "org.matrix.custom.html",
+		"formatted_body": fmt.Sprintf(`<b>%s</b>: %s <a href="%s">%s</a>`,
+			html.EscapeString(event.Title), html.EscapeString(event.Message), html.EscapeString(event.Link), html.EscapeString(event.Link)),
+	})
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateWorkspaceBridge(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	bHandler := NewBridgeHandler(mocks.NewHttpClient(t), mockDb)
+	bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "admin_pubkey" && role == db.EditOrg
+	}
+	bHandler.checkBridgeHost = func(host string) error {
+		if host == "matrix.org" {
+			return nil
+		}
+		return utils.ErrNotPublicAddress
+	}
+
+	newRequest := func(pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/workspace_uuid/bridges", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should only let workspace admins create bridges", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.CreateWorkspaceBridge).ServeHTTP(rr, newRequest("member_pubkey", `{"name": "dev", "kind": "discord"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should validate the target of the bridge", func(t *testing.T) {
+		for _, body := range []string{
+			`{"kind": "discord", "webhook_url": "https://discord.com/api/webhooks/1/abc"}`,
+			`{"name": "dev", "kind": "slack"}`,
+			`{"name": "dev", "kind": "discord", "webhook_url": "https://evil.example.com/api/webhooks/1/abc"}`,
+			`{"name": "dev", "kind": "discord", "webhook_url": "http://discord.com/api/webhooks/1/abc"}`,
+			`{"name": "dev", "kind": "matrix", "matrix_homeserver": "https://matrix.org", "matrix_room_id": "#dev:matrix.org", "matrix_token": "syt_token"}`,
+			`{"name": "dev", "kind": "matrix", "matrix_homeserver": "https://matrix.org", "matrix_room_id": "!dev:matrix.org"}`,
+			`{"name": "dev", "kind": "matrix", "matrix_homeserver": "https://10.0.0.5:8448", "matrix_room_id": "!dev:matrix.org", "matrix_token": "syt_token"}`,
+			`{"name": "dev", "kind": "matrix", "matrix_homeserver": "https://metadata.internal", "matrix_room_id": "!dev:matrix.org", "matrix_token": "syt_token"}`,
+			`{"name": "dev", "kind": "discord", "webhook_url": "https://discord.com/api/webhooks/1/abc", "events": ["bounty_paid"]}`,
+		} {
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(bHandler.CreateWorkspaceBridge).ServeHTTP(rr, newRequest("admin_pubkey", body))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+	})
+
+	t.Run("should create a bridge without returning its credentials", func(t *testing.T) {
+		mockDb.On("CreateWorkspaceBridge", mock.MatchedBy(func(bridge db.WorkspaceBridge) bool {
+			return bridge.WorkspaceUuid == "workspace_uuid" && bridge.Uuid != "" && bridge.Kind == db.BridgeMatrix &&
+				bridge.MatrixHomeserver == "https://matrix.org" && bridge.MatrixToken == "syt_token" && bridge.CreatedBy == "admin_pubkey"
+		})).Return(func(bridge db.WorkspaceBridge) (db.WorkspaceBridge, error) {
+			return bridge, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.CreateWorkspaceBridge).ServeHTTP(rr, newRequest("admin_pubkey",
+			`{"name": "dev", "kind": "matrix", "matrix_homeserver": "https://matrix.org/", "matrix_room_id": "!dev:matrix.org", "matrix_token": "syt_token", "events": ["bounty_created"]}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "syt_token")
+
+		created := db.WorkspaceBridge{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+		assert.Equal(t, "!dev:matrix.org", created.MatrixRoomId)
+	})
+}
+
+// requestBody reads the body of a request without consuming it, every
+// expectation of the http client mock reads it
+func requestBody(req *http.Request) []byte {
+	reader, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	body, _ := io.ReadAll(reader)
+	return body
+}
+
+func TestDeliverWorkspaceEvent(t *testing.T) {
+	event := db.WorkspaceEvent{
+		WorkspaceUuid: "workspace_uuid",
+		Type:          db.BountyCreatedEvent,
+		Title:         "New bounty",
+		Message:       "Fix <script> escaping for 25000 sats",
+		Link:          "https://community.sphinx.chat/bounty/12",
+		Created:       time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC),
+	}
+	discord := db.WorkspaceBridge{Uuid: "discord_bridge", Kind: db.BridgeDiscord, WebhookUrl: "https://discord.com/api/webhooks/1/abc"}
+	matrix := db.WorkspaceBridge{Uuid: "matrix_bridge", Kind: db.BridgeMatrix, MatrixHomeserver: "https://matrix.org", MatrixRoomId: "!dev:matrix.org", MatrixToken: "syt_token"}
+	featuresOnly := db.WorkspaceBridge{Uuid: "features_bridge", Kind: db.BridgeDiscord, WebhookUrl: "https://discord.com/api/webhooks/2/def", Events: []string{string(db.FeatureCreatedEvent)}}
+
+	answer := func(status int) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(`{}`))}
+	}
+
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	bHandler := NewBridgeHandler(mockHttpClient, mockDb)
+	bHandler.bridgeClient = mockHttpClient
+
+	mockDb.On("GetWorkspaceBridges", "workspace_uuid").Return([]db.WorkspaceBridge{discord, matrix, featuresOnly}).Once()
+	mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		body := requestBody(req)
+		payload := map[string][]map[string]string{}
+		json.Unmarshal(body, &payload)
+		return req.Method == http.MethodPost && req.URL.String() == discord.WebhookUrl && len(payload["embeds"]) == 1 &&
+			payload["embeds"][0]["title"] == "New bounty" && payload["embeds"][0]["url"] == event.Link
+	})).Return(answer(http.StatusNoContent), nil).Once()
+	mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		body := requestBody(req)
+		payload := map[string]string{}
+		json.Unmarshal(body, &payload)
+		return req.Method == http.MethodPut && req.Header.Get("Authorization") == "Bearer syt_token" &&
+			strings.HasPrefix(req.URL.EscapedPath(), "/_matrix/client/v3/rooms/%21dev:matrix.org/send/m.room.message/") &&
+			payload["msgtype"] == "m.notice" && strings.Contains(payload["formatted_body"], "Fix &lt;script&gt; escaping")
+	})).Return(answer(http.StatusForbidden), nil).Once()
+	mockDb.On("RecordBridgeDelivery", "discord_bridge", mock.Anything, nil).Return(nil).Once()
+	mockDb.On("RecordBridgeDelivery", "matrix_bridge", mock.Anything, errors.New("matrix.org answered 403")).Return(nil).Once()
+
+	bHandler.DeliverWorkspaceEvent(event)
+}
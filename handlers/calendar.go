@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// calendarCallsSince is how far back the calendar feed lists feature calls
+const calendarCallsSince = 30 * 24 * time.Hour
+
+const icsDateTime = "20060102T150405Z"
+
+// CreateCalendarToken makes the token of the calendar feed of the signed in
+// person, the token made before stops working
+func (ph *peopleHandler) CreateCalendarToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[calendar] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	token, err := ph.db.CreateCalendarToken(pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[calendar] could not create token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	tokenString := db.CalendarTokenString(token)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.CalendarTokenResponse{
+		Token: tokenString,
+		Url:   fmt.Sprintf("%s/people/me/calendar.ics?token=%s", config.Host, url.QueryEscape(tokenString)),
+	})
+}
+
+func (ph *peopleHandler) RevokeCalendarToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[calendar] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if err := ph.db.RevokeCalendarTokens(pubKeyFromAuth); err != nil {
+		fmt.Println("[calendar] could not revoke tokens", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetMyCalendar is the iCal feed of the due dates of the bounties assigned to a
+// person and the calls scheduled in their workspaces. Calendar apps can not sign
+// in, so the feed is read with the calendar token of the person
+func (ph *peopleHandler) GetMyCalendar(w http.ResponseWriter, r *http.Request) {
+	token, err := ph.db.VerifyCalendarToken(r.URL.Query().Get("token"))
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	now := time.Now()
+	bounties := ph.db.GetPersonCalendarBounties(token.OwnerPubKey)
+	calls := ph.db.GetPersonScheduledFeatureCalls(token.OwnerPubKey, now.Add(-calendarCallsSince))
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="calendar.ics"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(renderCalendar(bounties, calls, now)))
+}
+
+// icsWriter writes the lines of an iCalendar file, folded at 75 octets
+type icsWriter struct {
+	b strings.Builder
+}
+
+func (w *icsWriter) line(name string, value string) {
+	line := name + ":" + value
+	for len(line) > 75 {
+		cut := 75
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		w.b.WriteString(line[:cut] + "\r\n")
+		line = " " + line[cut:]
+	}
+	w.b.WriteString(line + "\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func icsEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+func icsDomain() string {
+	if u, err := url.Parse(config.Host); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	return "sphinx-tribes"
+}
+
+// icsStart is the start of an event, a date without a time of day is a whole day
+func icsStart(w *icsWriter, t time.Time) {
+	t = t.UTC()
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		w.line("DTSTART;VALUE=DATE", t.Format("20060102"))
+		return
+	}
+	w.line("DTSTART", t.Format(icsDateTime))
+}
+
+func renderCalendar(bounties []db.NewBounty, calls []db.FeatureCall, now time.Time) string {
+	w := &icsWriter{}
+	domain := icsDomain()
+	stamp := now.UTC().Format(icsDateTime)
+
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//Sphinx Tribes//Assigned work//EN")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("X-WR-CALNAME", "Sphinx bounties")
+
+	for _, bounty := range bounties {
+		due := bounty.EffectiveDueDate()
+		if due == nil {
+			continue
+		}
+		link := fmt.Sprintf("%s/bounty/%d", config.Host, bounty.ID)
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", fmt.Sprintf("bounty-%d@%s", bounty.ID, domain))
+		w.line("DTSTAMP", stamp)
+		icsStart(w, *due)
+		w.line("SUMMARY", icsEscape("Due: "+bounty.Title))
+		w.line("DESCRIPTION", icsEscape(link))
+		w.line("URL", link)
+		w.line("END", "VEVENT")
+	}
+
+	for _, call := range calls {
+		if call.ScheduledAt == nil {
+			continue
+		}
+		title := call.Title
+		if title == "" {
+			title = "Feature call"
+		}
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", fmt.Sprintf("call-%s@%s", call.Uuid, domain))
+		w.line("DTSTAMP", stamp)
+		w.line("DTSTART", call.ScheduledAt.UTC().Format(icsDateTime))
+		if call.DurationMinutes > 0 {
+			w.line("DTEND", call.ScheduledAt.Add(time.Duration(call.DurationMinutes)*time.Minute).UTC().Format(icsDateTime))
+		}
+		if call.Recurrence != "" {
+			w.line("RRULE", strings.TrimPrefix(call.Recurrence, "RRULE:"))
+		}
+		w.line("SUMMARY", icsEscape(title))
+		w.line("LOCATION", icsEscape(call.Url))
+		w.line("URL", call.Url)
+		w.line("END", "VEVENT")
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.b.String()
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+// chatCommand is a slash command typed in a chat message, /bounty 50000 Fix login bug
+type chatCommand struct {
+	Name   string
+	Amount uint
+	Title  string
+}
+
+var errNotChatCommand = errors.New("message is not a command")
+
+// parseChatCommand reads the command of a chat message, amounts can use a k
+// suffix for thousands and commas or underscores between digits
+func parseChatCommand(message string) (chatCommand, error) {
+	fields := strings.Fields(message)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "/") {
+		return chatCommand{}, errNotChatCommand
+	}
+
+	command := chatCommand{Name: strings.ToLower(strings.TrimPrefix(fields[0], "/"))}
+	switch command.Name {
+	case "bounty":
+		if len(fields) < 3 {
+			return command, errors.New("usage: /bounty <amount> <title>")
+		}
+		amount, err := parseChatAmount(fields[1])
+		if err != nil {
+			return command, err
+		}
+		command.Amount = amount
+		command.Title = strings.Join(fields[2:], " ")
+		return command, nil
+	}
+	return command, fmt.Errorf("unknown command /%s", command.Name)
+}
+
+func parseChatAmount(amount string) (uint, error) {
+	multiplier := uint64(1)
+	digits := strings.NewReplacer(",", "", "_", "").Replace(strings.ToLower(amount))
+	if strings.HasSuffix(digits, "k") {
+		multiplier = 1000
+		digits = strings.TrimSuffix(digits, "k")
+	}
+
+	value, err := strconv.ParseUint(digits, 10, 32)
+	if err != nil || value == 0 {
+		return 0, fmt.Errorf("%s is not an amount in sats", amount)
+	}
+	return uint(value * multiplier), nil
+}
+
+// HandleChatCommand runs the slash command of a chat message, /bounty creates a
+// draft bounty in the workspace of the chat and replies with its link on the
+// websocket of the sender
+func (h *bountyHandler) HandleChatCommand(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[chat] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.ChatCommandRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	command, err := parseChatCommand(request.Message)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	if request.WorkspaceUuid != "" && !h.userHasAccess(pubKeyFromAuth, request.WorkspaceUuid, db.AddBounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("You don't have appropriate permissions to add bounties")
+		return
+	}
+
+	bounty, err := h.db.CreateOrEditBounty(db.NewBounty{
+		OwnerID:       pubKeyFromAuth,
+		Title:         command.Title,
+		Price:         command.Amount,
+		WorkspaceUuid: request.WorkspaceUuid,
+		Type:          "freelance_job_request",
+		Visibility:    db.BountyVisibilityPublic,
+		Show:          false,
+		Created:       time.Now().Unix(),
+	})
+	if err != nil {
+		fmt.Println("[chat] could not create bounty", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	reply := db.ChatCommandReply{
+		Msg:      "chat_bounty_created",
+		BountyId: bounty.ID,
+		Link:     fmt.Sprintf("%s/bounty/%d", config.Host, bounty.ID),
+	}
+	socket, err := h.getSocketConnections(request.Websocket_token)
+	if err == nil {
+		websocket.SendMessage(socket, reply)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reply)
+}
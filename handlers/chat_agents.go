@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+const chatAgentTimeout = 10 * time.Second
+
+func chatAgentCallbackUrl(webhook db.ChatAgentWebhook) string {
+	return fmt.Sprintf("%s/hivechat/%s/agent/callback?token=%s", config.Host, webhook.ChatId, url.QueryEscape(webhook.CallbackToken))
+}
+
+// SetChatAgentWebhook registers the external agent of a chat, it replaces the
+// one the chat had. The secret and the callback url are only returned here
+func (hh *hivechatHandler) SetChatAgentWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[hivechat] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	chat, ok := hh.getChat(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	request := db.ChatAgentWebhookRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[hivechat]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if strings.TrimSpace(request.Name) == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("name is a required field")
+		return
+	}
+	target, err := url.Parse(request.Url)
+	if err != nil || target.Scheme != "https" || target.Hostname() == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("url must be an https url")
+		return
+	}
+	if err := hh.checkAgentHost(target.Hostname()); err != nil {
+		fmt.Println("[hivechat] agent webhook host refused", err)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("url must be a public https url")
+		return
+	}
+
+	secret := utils.GetRandomToken(32)
+	webhook, err := hh.db.SetChatAgentWebhook(db.ChatAgentWebhook{
+		ChatId:        chat.Uuid,
+		WorkspaceUuid: chat.WorkspaceUuid,
+		Name:          strings.TrimSpace(request.Name),
+		Url:           request.Url,
+		Secret:        secret,
+		CallbackToken: utils.GetRandomToken(32),
+		CreatedBy:     pubKeyFromAuth,
+	})
+	if err != nil {
+		fmt.Println("[hivechat] could not register agent webhook", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not register the agent webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.RegisteredChatAgentWebhook{
+		ChatAgentWebhook: webhook,
+		Secret:           secret,
+		CallbackUrl:      chatAgentCallbackUrl(webhook),
+	})
+}
+
+func (hh *hivechatHandler) GetChatAgentWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[hivechat] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	chat, ok := hh.getChat(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	webhook, err := hh.db.GetChatAgentWebhook(chat.Uuid)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(webhook)
+}
+
+func (hh *hivechatHandler) DeleteChatAgentWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[hivechat] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	chat, ok := hh.getChat(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	err := hh.db.DeleteChatAgentWebhook(chat.Uuid)
+	if errors.Is(err, db.ErrChatAgentWebhookNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete the agent webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Agent webhook deleted")
+}
+
+// DeliverChatMessage posts a user message to the agent of its chat, signed
+// with the webhook secret in the x-hub-signature-256 header, and records how
+// the post went
+func (hh *hivechatHandler) DeliverChatMessage(webhook db.ChatAgentWebhook, message db.ChatMessage) error {
+	body, err := json.Marshal(db.ChatAgentDelivery{
+		ChatId:        webhook.ChatId,
+		WorkspaceUuid: webhook.WorkspaceUuid,
+		Message:       message,
+		CallbackUrl:   chatAgentCallbackUrl(webhook),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = hh.postToAgent(webhook, body)
+	if err != nil {
+		fmt.Println("[hivechat] could not deliver message to agent", webhook.Uuid, err)
+	}
+	if recordErr := hh.db.RecordChatAgentDelivery(webhook.Uuid, time.Now(), err); recordErr != nil {
+		fmt.Println("[hivechat] could not record agent delivery", webhook.Uuid, recordErr)
+	}
+	return err
+}
+
+func (hh *hivechatHandler) postToAgent(webhook db.ChatAgentWebhook, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), chatAgentTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Hub-Signature-256", signWebhookPayload(webhook.Secret, body))
+
+	res, err := hh.agentClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s answered %d", req.URL.Host, res.StatusCode)
+	}
+	return nil
+}
+
+// ReceiveChatAgentResponse posts the answer of an external agent to its chat
+// as an assistant message, authenticated by the token of the callback url
+func (hh *hivechatHandler) ReceiveChatAgentResponse(w http.ResponseWriter, r *http.Request) {
+	webhook, err := hh.db.GetChatAgentWebhook(chi.URLParam(r, "chat_id"))
+	if errors.Is(err, db.ErrChatAgentWebhookNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	token := r.URL.Query().Get("token")
+	if token == "" || !hmac.Equal([]byte(token), []byte(webhook.CallbackToken)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Invalid token")
+		return
+	}
+
+	request := db.ChatMessageRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	r.Body.Close()
+
+	if err := validateChatMessage(request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	for i := range request.Artifacts {
+		request.Artifacts[i].WorkspaceUuid = webhook.WorkspaceUuid
+	}
+
+	message, err := hh.db.CreateChatMessage(db.ChatMessage{
+		ChatId:       webhook.ChatId,
+		Role:         db.ChatRoleAssistant,
+		SenderPubkey: webhook.CreatedBy,
+		Content:      request.Content,
+		Artifacts:    request.Artifacts,
+	})
+	if err != nil {
+		fmt.Println("[hivechat] could not create agent message", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	hh.publishChatMessage(message)
+	hh.broadcastChatWorking(webhook.ChatId, false, "")
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(message)
+}
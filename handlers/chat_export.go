@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const chatExportTimeFormat = "2006-01-02 15:04 MST"
+
+func chatTitle(chat db.Chat) string {
+	if chat.Title != "" {
+		return chat.Title
+	}
+	return "Chat " + chat.Uuid
+}
+
+func chatRoleName(role db.ChatRole) string {
+	if role == db.ChatRoleAssistant {
+		return "Assistant"
+	}
+	return "User"
+}
+
+func chatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(chatExportTimeFormat)
+}
+
+// markdownFence is a code fence longer than any backtick run in content
+func markdownFence(content string) string {
+	longest, run := 0, 0
+	for _, c := range content {
+		if c == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	if longest < 3 {
+		return "```"
+	}
+	return strings.Repeat("`", longest+1)
+}
+
+// renderChatMarkdown writes a chat as Markdown, one section per message with
+// its artifacts as code blocks
+func renderChatMarkdown(chat db.Chat, messages []db.ChatMessage) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", chatTitle(chat))
+	fmt.Fprintf(&b, "_Workspace %s, started %s_\n", chat.WorkspaceUuid, chatTime(chat.Created))
+
+	for _, message := range messages {
+		fmt.Fprintf(&b, "\n## %s · %s\n\n", chatRoleName(message.Role), chatTime(message.Created))
+		if content := strings.TrimSpace(message.Content); content != "" {
+			b.WriteString(content + "\n")
+		}
+
+		for _, artifact := range message.Artifacts {
+			title := string(artifact.Type)
+			if artifact.Title != "" {
+				title = fmt.Sprintf("%s (%s)", artifact.Title, artifact.Type)
+			}
+			fence := markdownFence(artifact.Content)
+			fmt.Fprintf(&b, "\n**Artifact: %s**\n\n%s%s\n%s\n%s\n", title, fence, artifact.Language, artifact.Content, fence)
+		}
+	}
+	return b.String()
+}
+
+var chatHTMLTemplate = template.Must(template.New("chat").Funcs(template.FuncMap{
+	"role": chatRoleName,
+	"time": chatTime,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 48rem; margin: 2rem auto; }
+.message { border-top: 1px solid #ddd; padding: 1rem 0; }
+.content { white-space: pre-wrap; }
+pre { background: #f5f5f5; padding: 0.75rem; overflow-x: auto; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p><em>Workspace {{.Chat.WorkspaceUuid}}, started {{time .Chat.Created}}</em></p>
+{{range .Messages}}<div class="message {{.Role}}">
+<h2>{{role .Role}} · {{time .Created}}</h2>
+<div class="content">{{.Content}}</div>
+{{range .Artifacts}}<h3>Artifact: {{if .Title}}{{.Title}} ({{.Type}}){{else}}{{.Type}}{{end}}</h3>
+<pre><code{{if .Language}} class="language-{{.Language}}"{{end}}>{{.Content}}</code></pre>
+{{end}}</div>
+{{end}}</body>
+</html>
+`))
+
+// renderChatHTML writes a chat as a standalone HTML page, contents are escaped
+func renderChatHTML(w io.Writer, chat db.Chat, messages []db.ChatMessage) error {
+	return chatHTMLTemplate.Execute(w, struct {
+		Title    string
+		Chat     db.Chat
+		Messages []db.ChatMessage
+	}{chatTitle(chat), chat, messages})
+}
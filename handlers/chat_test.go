@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseChatCommand(t *testing.T) {
+	command, err := parseChatCommand("/bounty 50000 Fix login bug")
+	assert.NoError(t, err)
+	assert.Equal(t, chatCommand{Name: "bounty", Amount: 50000, Title: "Fix login bug"}, command)
+
+	command, err = parseChatCommand("  /Bounty 1,500  Fix   the  docs ")
+	assert.NoError(t, err)
+	assert.Equal(t, chatCommand{Name: "bounty", Amount: 1500, Title: "Fix the docs"}, command)
+
+	command, err = parseChatCommand("/bounty 25k Add dark mode")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(25000), command.Amount)
+
+	_, err = parseChatCommand("hello /bounty 100 title")
+	assert.Equal(t, errNotChatCommand, err)
+
+	_, err = parseChatCommand("/bounty 100")
+	assert.EqualError(t, err, "usage: /bounty <amount> <title>")
+
+	_, err = parseChatCommand("/bounty lots Fix login bug")
+	assert.EqualError(t, err, "lots is not an amount in sats")
+
+	_, err = parseChatCommand("/bounty 0 Fix login bug")
+	assert.Error(t, err)
+
+	_, err = parseChatCommand("/ticket Fix login bug")
+	assert.EqualError(t, err, "unknown command /ticket")
+}
+
+func TestHandleChatCommand(t *testing.T) {
+	newRequest := func(pubkey string, body string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/gobounties/chat", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should return 401 without a pubkey", func(t *testing.T) {
+		bHandler := NewBountyHandler(mocks.NewHttpClient(t), dbMocks.NewDatabase(t))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.HandleChatCommand).ServeHTTP(rr, newRequest("", `{"message": "/bounty 100 Title"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should return 400 for a message that is not a valid command", func(t *testing.T) {
+		bHandler := NewBountyHandler(mocks.NewHttpClient(t), dbMocks.NewDatabase(t))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.HandleChatCommand).ServeHTTP(rr, newRequest("owner_pubkey", `{"message": "/bounty many Title"}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should not create bounties in workspaces without the add bounty role", func(t *testing.T) {
+		bHandler := NewBountyHandler(mocks.NewHttpClient(t), dbMocks.NewDatabase(t))
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return false
+		}
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.HandleChatCommand).ServeHTTP(rr, newRequest("owner_pubkey", `{"message": "/bounty 100 Title", "workspace_uuid": "workspace_1"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should create a draft bounty and reply with its link", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		bHandler := NewBountyHandler(mocks.NewHttpClient(t), mockDb)
+		bHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return role == db.AddBounty
+		}
+		bHandler.getSocketConnections = func(host string) (db.Client, error) {
+			return db.Client{}, errors.New("no socket")
+		}
+
+		mockDb.On("CreateOrEditBounty", mock.MatchedBy(func(b db.NewBounty) bool {
+			return b.OwnerID == "owner_pubkey" && b.Title == "Fix login bug" && b.Price == 50000 &&
+				b.WorkspaceUuid == "workspace_1" && !b.Show && b.Created != 0
+		})).Return(db.NewBounty{ID: 12}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(bHandler.HandleChatCommand).ServeHTTP(rr, newRequest("owner_pubkey", `{"message": "/bounty 50000 Fix login bug", "workspace_uuid": "workspace_1"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		reply := db.ChatCommandReply{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &reply))
+		assert.Equal(t, db.ChatCommandReply{Msg: "chat_bounty_created", BountyId: 12, Link: fmt.Sprintf("%s/bounty/12", config.Host)}, reply)
+	})
+}
@@ -0,0 +1,353 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/websocket"
+	"gorm.io/gorm"
+)
+
+type codeGraphHandler struct {
+	httpClient           HttpClient
+	db                   db.Database
+	getSocketConnections func(host string) (db.Client, error)
+	userHasAccess        func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewCodeGraphHandler(httpClient HttpClient, database db.Database) *codeGraphHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &codeGraphHandler{
+		httpClient:           httpClient,
+		db:                   database,
+		getSocketConnections: db.Store.GetSocketConnections,
+		userHasAccess:        dbConf.UserHasAccess,
+	}
+}
+
+func codeGraphSocketKey(uuid string) string {
+	return "codegraph_" + uuid
+}
+
+func (ch *codeGraphHandler) CreateOrEditCodeGraph(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[codegraph] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	codeGraph := db.WorkspaceCodeGraph{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &codeGraph)
+	if err != nil {
+		fmt.Println("[codegraph]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	workspaceUuid := chi.URLParam(r, "workspace_uuid")
+	codeGraph.WorkspaceUuid = workspaceUuid
+
+	workspace := ch.db.GetWorkspaceByUuid(workspaceUuid)
+	if workspace.Uuid != workspaceUuid || workspaceUuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace does not exists")
+		return
+	}
+
+	if !ch.userHasAccess(pubKeyFromAuth, workspaceUuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to edit code graphs")
+		return
+	}
+
+	if codeGraph.Uuid == "" {
+		codeGraph.Uuid = xid.New().String()
+		codeGraph.CreatedBy = pubKeyFromAuth
+	} else {
+		existing, err := ch.db.GetCodeGraphByUuid(codeGraph.Uuid)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode("Code graph does not exists")
+			return
+		}
+		if existing.WorkspaceUuid != workspaceUuid {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("Code graph does not belong to workspace")
+			return
+		}
+	}
+	codeGraph.UpdatedBy = pubKeyFromAuth
+
+	// status is only changed by a sync or the indexer callback
+	codeGraph.Status = ""
+	codeGraph.Progress = 0
+	codeGraph.Error = ""
+
+	err = db.Validate.Struct(codeGraph)
+	if err != nil || codeGraph.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		msg := fmt.Sprintf("Error: did not pass validation test : %s", err)
+		json.NewEncoder(w).Encode(msg)
+		return
+	}
+
+	p, err := ch.db.CreateOrEditCodeGraph(codeGraph)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(p)
+}
+
+func (ch *codeGraphHandler) GetCodeGraphsByWorkspaceUuid(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[codegraph] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	workspaceUuid := chi.URLParam(r, "workspace_uuid")
+	if !isWorkspaceMember(ch.db, pubKeyFromAuth, workspaceUuid) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("Don't have access to the workspace")
+		return
+	}
+
+	codeGraphs := ch.db.GetCodeGraphsByWorkspaceUuid(workspaceUuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(codeGraphs)
+}
+
+func (ch *codeGraphHandler) GetCodeGraphByUuid(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[codegraph] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	workspaceUuid := chi.URLParam(r, "workspace_uuid")
+	uuid := chi.URLParam(r, "uuid")
+
+	if !isWorkspaceMember(ch.db, pubKeyFromAuth, workspaceUuid) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("Don't have access to the workspace")
+		return
+	}
+
+	codeGraph, err := ch.db.GetCodeGraphByUuid(uuid)
+	if err != nil || codeGraph.WorkspaceUuid != workspaceUuid {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Code graph not found"})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(codeGraph)
+}
+
+func (ch *codeGraphHandler) DeleteCodeGraph(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[codegraph] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	workspaceUuid := chi.URLParam(r, "workspace_uuid")
+	uuid := chi.URLParam(r, "uuid")
+
+	if !ch.userHasAccess(pubKeyFromAuth, workspaceUuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to delete code graphs")
+		return
+	}
+
+	err := ch.db.DeleteCodeGraph(workspaceUuid, uuid)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Code graph deleted successfully"})
+}
+
+// SyncCodeGraph asks the external indexer to (re)build the code graph,
+// the indexer reports back through CodeGraphCallback
+func (ch *codeGraphHandler) SyncCodeGraph(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[codegraph] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	workspaceUuid := chi.URLParam(r, "workspace_uuid")
+	uuid := chi.URLParam(r, "uuid")
+
+	if !ch.userHasAccess(pubKeyFromAuth, workspaceUuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to sync code graphs")
+		return
+	}
+
+	codeGraph, err := ch.db.GetCodeGraphByUuid(uuid)
+	if err != nil || codeGraph.WorkspaceUuid != workspaceUuid {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Code graph not found"})
+		return
+	}
+
+	if codeGraph.Status == db.CodeGraphSyncing {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("Code graph is already syncing")
+		return
+	}
+
+	if config.CodeGraphUrl == "" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode("Code graph indexer is not configured")
+		return
+	}
+
+	request := db.CodeGraphSyncRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &request); err != nil {
+			fmt.Println("[codegraph]", err)
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+	}
+
+	indexerRequest := map[string]interface{}{
+		"uuid":           codeGraph.Uuid,
+		"workspace_uuid": codeGraph.WorkspaceUuid,
+		"url":            codeGraph.Url,
+		"callback_url":   fmt.Sprintf("%s/workspaces/codegraph/callback", config.Host),
+	}
+	// the indexer summarizes the code with the assistant model the workspace picked
+	if ai, ok := ch.db.GetWorkspaceSettings(workspaceUuid).AiSettings(); ok {
+		indexerRequest["ai"] = ai
+	}
+	indexerBody, _ := json.Marshal(indexerRequest)
+
+	req, _ := http.NewRequest(http.MethodPost, config.CodeGraphUrl, bytes.NewBuffer(indexerBody))
+	req.Header.Set("token", config.CodeGraphToken)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := ch.httpClient.Do(req)
+	if err != nil {
+		log.Printf("[codegraph] Request Failed: %s", err)
+		w.WriteHeader(http.StatusBadGateway)
+		json.NewEncoder(w).Encode("Could not reach code graph indexer")
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		log.Printf("[codegraph] Indexer returned status %d", res.StatusCode)
+		w.WriteHeader(http.StatusBadGateway)
+		json.NewEncoder(w).Encode("Code graph indexer rejected the sync")
+		return
+	}
+
+	if request.Websocket_token != "" {
+		db.Store.SetCache(codeGraphSocketKey(codeGraph.Uuid), request.Websocket_token)
+	}
+
+	codeGraph, err = ch.db.UpdateCodeGraphStatus(codeGraph.Uuid, db.CodeGraphSyncing, 0, "")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(codeGraph)
+}
+
+// CodeGraphCallback is called by the external indexer to report progress or completion
+func (ch *codeGraphHandler) CodeGraphCallback(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("token")
+	if config.CodeGraphToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(config.CodeGraphToken)) != 1 {
+		fmt.Println("[codegraph] invalid callback token")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	callback := db.CodeGraphCallback{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &callback)
+	if err != nil {
+		fmt.Println("[codegraph]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	switch callback.Status {
+	case db.CodeGraphSyncing, db.CodeGraphCompleted, db.CodeGraphFailed:
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Not a valid code graph status")
+		return
+	}
+
+	if callback.Status == db.CodeGraphCompleted {
+		callback.Progress = 100
+	}
+
+	codeGraph, err := ch.db.UpdateCodeGraphStatus(callback.Uuid, callback.Status, callback.Progress, callback.Error)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	socketKey := codeGraphSocketKey(codeGraph.Uuid)
+	if websocketToken, err := db.Store.GetCache(socketKey); err == nil {
+		msg := map[string]interface{}{
+			"msg":      "code_graph_sync",
+			"uuid":     codeGraph.Uuid,
+			"status":   codeGraph.Status,
+			"progress": codeGraph.Progress,
+			"error":    codeGraph.Error,
+		}
+
+		socket, err := ch.getSocketConnections(websocketToken)
+		if err == nil {
+			websocket.SendMessage(socket, msg)
+		}
+
+		if codeGraph.Status != db.CodeGraphSyncing {
+			db.Store.DeleteCache(socketKey)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(codeGraph)
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateOrEditCodeGraph(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	cHandler := NewCodeGraphHandler(mockHttpClient, mockDb)
+
+	workspaceUuid := "workspace_uuid"
+	authorizedCtx := context.WithValue(context.Background(), auth.ContextKey, "pub_key")
+
+	t.Run("should return 401 if no pubkey in context", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/", nil)
+
+		http.HandlerFunc(cHandler.CreateOrEditCodeGraph).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should return 401 if user does not have edit role", func(t *testing.T) {
+		cHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return false
+		}
+		mockDb.On("GetWorkspaceByUuid", workspaceUuid).Return(db.Workspace{Uuid: workspaceUuid}).Once()
+
+		body, _ := json.Marshal(db.WorkspaceCodeGraph{Name: "graph"})
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspaceUuid)
+		req, _ := http.NewRequestWithContext(context.WithValue(authorizedCtx, chi.RouteCtxKey, rctx), http.MethodPost, "/"+workspaceUuid+"/codegraph", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(cHandler.CreateOrEditCodeGraph).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should create a code graph with a pending status", func(t *testing.T) {
+		cHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return true
+		}
+		mockDb.On("GetWorkspaceByUuid", workspaceUuid).Return(db.Workspace{Uuid: workspaceUuid}).Once()
+		mockDb.On("CreateOrEditCodeGraph", mock.MatchedBy(func(c db.WorkspaceCodeGraph) bool {
+			return c.Name == "graph" && c.WorkspaceUuid == workspaceUuid && c.CreatedBy == "pub_key" && c.Status == ""
+		})).Return(db.WorkspaceCodeGraph{Uuid: "graph_uuid", Name: "graph", Status: db.CodeGraphPending}, nil).Once()
+
+		body, _ := json.Marshal(db.WorkspaceCodeGraph{Name: "graph", Status: db.CodeGraphCompleted})
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspaceUuid)
+		req, _ := http.NewRequestWithContext(context.WithValue(authorizedCtx, chi.RouteCtxKey, rctx), http.MethodPost, "/"+workspaceUuid+"/codegraph", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(cHandler.CreateOrEditCodeGraph).ServeHTTP(rr, req)
+
+		var returned db.WorkspaceCodeGraph
+		json.Unmarshal(rr.Body.Bytes(), &returned)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, db.CodeGraphPending, returned.Status)
+	})
+
+	t.Run("should return 404 when editing a code graph that does not exist", func(t *testing.T) {
+		cHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return true
+		}
+		mockDb.On("GetWorkspaceByUuid", workspaceUuid).Return(db.Workspace{Uuid: workspaceUuid}).Once()
+		mockDb.On("GetCodeGraphByUuid", "missing_uuid").Return(db.WorkspaceCodeGraph{}, errors.New("no code graph found")).Once()
+
+		body, _ := json.Marshal(db.WorkspaceCodeGraph{Uuid: "missing_uuid", Name: "graph"})
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspaceUuid)
+		req, _ := http.NewRequestWithContext(context.WithValue(authorizedCtx, chi.RouteCtxKey, rctx), http.MethodPost, "/"+workspaceUuid+"/codegraph", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(cHandler.CreateOrEditCodeGraph).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockDb.AssertNotCalled(t, "CreateOrEditCodeGraph", mock.MatchedBy(func(c db.WorkspaceCodeGraph) bool {
+			return c.Uuid == "missing_uuid"
+		}))
+	})
+}
+
+func TestGetCodeGraphs(t *testing.T) {
+	workspaceUuid := "workspace_uuid"
+	graph := db.WorkspaceCodeGraph{Uuid: "graph_uuid", WorkspaceUuid: workspaceUuid, Name: "graph"}
+	newRequest := func(pubkey string, uuid string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspaceUuid)
+		rctx.URLParams.Add("uuid", uuid)
+		ctx := context.WithValue(context.WithValue(context.Background(), auth.ContextKey, pubkey), chi.RouteCtxKey, rctx)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/"+workspaceUuid+"/codegraph/"+uuid, nil)
+		return req
+	}
+
+	t.Run("should return 403 to someone outside of the workspace", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		cHandler := NewCodeGraphHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetWorkspaceByUuid", workspaceUuid).Return(db.Workspace{Uuid: workspaceUuid, OwnerPubKey: "owner"})
+		mockDb.On("GetWorkspaceUser", "outsider", workspaceUuid).Return(db.WorkspaceUsers{})
+
+		for _, handler := range []http.HandlerFunc{cHandler.GetCodeGraphsByWorkspaceUuid, cHandler.GetCodeGraphByUuid} {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newRequest("outsider", graph.Uuid))
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+		}
+		mockDb.AssertNotCalled(t, "GetCodeGraphsByWorkspaceUuid", mock.Anything)
+		mockDb.AssertNotCalled(t, "GetCodeGraphByUuid", mock.Anything)
+	})
+
+	t.Run("should return the code graphs to a member of the workspace", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		cHandler := NewCodeGraphHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetWorkspaceByUuid", workspaceUuid).Return(db.Workspace{Uuid: workspaceUuid, OwnerPubKey: "owner"})
+		mockDb.On("GetWorkspaceUser", "member", workspaceUuid).Return(db.WorkspaceUsers{OwnerPubKey: "member"})
+		mockDb.On("GetCodeGraphsByWorkspaceUuid", workspaceUuid).Return([]db.WorkspaceCodeGraph{graph}).Once()
+		mockDb.On("GetCodeGraphByUuid", graph.Uuid).Return(graph, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(cHandler.GetCodeGraphsByWorkspaceUuid).ServeHTTP(rr, newRequest("member", ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(cHandler.GetCodeGraphByUuid).ServeHTTP(rr, newRequest("member", graph.Uuid))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestSyncCodeGraph(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	cHandler := NewCodeGraphHandler(mockHttpClient, mockDb)
+	cHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return true
+	}
+
+	workspaceUuid := "workspace_uuid"
+	authorizedCtx := context.WithValue(context.Background(), auth.ContextKey, "pub_key")
+
+	newRequest := func() *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("workspace_uuid", workspaceUuid)
+		rctx.URLParams.Add("uuid", "graph_uuid")
+		req, _ := http.NewRequestWithContext(context.WithValue(authorizedCtx, chi.RouteCtxKey, rctx), http.MethodPost, "/"+workspaceUuid+"/codegraph/graph_uuid/sync", strings.NewReader(`{}`))
+		return req
+	}
+
+	t.Run("should return 409 if the code graph is already syncing", func(t *testing.T) {
+		mockDb.On("GetCodeGraphByUuid", "graph_uuid").Return(db.WorkspaceCodeGraph{Uuid: "graph_uuid", WorkspaceUuid: workspaceUuid, Status: db.CodeGraphSyncing}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(cHandler.SyncCodeGraph).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should trigger the indexer and mark the code graph as syncing", func(t *testing.T) {
+		config.CodeGraphUrl = "https://indexer.test/sync"
+		config.CodeGraphToken = "indexer_token"
+		defer func() {
+			config.CodeGraphUrl = ""
+			config.CodeGraphToken = ""
+		}()
+
+		temperature := 0.2
+		settings := db.DefaultWorkspaceSettings(workspaceUuid)
+		settings.AiProvider = db.AiProviderAnthropic
+		settings.AiModel = "claude-sonnet"
+		settings.AiTemperature = &temperature
+		settings.AiProviderKeyRef = "ANTHROPIC_API_KEY"
+
+		mockDb.On("GetCodeGraphByUuid", "graph_uuid").Return(db.WorkspaceCodeGraph{Uuid: "graph_uuid", WorkspaceUuid: workspaceUuid, Url: "https://github.com/stakwork/sphinx-tribes", Status: db.CodeGraphCompleted}, nil).Once()
+		mockDb.On("GetWorkspaceSettings", workspaceUuid).Return(settings).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			indexerRequest := struct {
+				Ai db.WorkspaceAiSettings `json:"ai"`
+			}{}
+			json.NewDecoder(req.Body).Decode(&indexerRequest)
+			return req.URL.String() == config.CodeGraphUrl && req.Header.Get("token") == config.CodeGraphToken &&
+				indexerRequest.Ai.Model == "claude-sonnet" && *indexerRequest.Ai.Temperature == 0.2 && indexerRequest.Ai.KeyRef == "ANTHROPIC_API_KEY"
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+		}, nil).Once()
+		mockDb.On("UpdateCodeGraphStatus", "graph_uuid", db.CodeGraphSyncing, 0, "").Return(db.WorkspaceCodeGraph{Uuid: "graph_uuid", Status: db.CodeGraphSyncing}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(cHandler.SyncCodeGraph).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestCodeGraphCallback(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	cHandler := NewCodeGraphHandler(mockHttpClient, mockDb)
+
+	config.CodeGraphToken = "indexer_token"
+	defer func() {
+		config.CodeGraphToken = ""
+	}()
+
+	t.Run("should return 401 if the callback token is wrong", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/codegraph/callback", strings.NewReader(`{"uuid": "graph_uuid", "status": "completed"}`))
+		req.Header.Set("token", "wrong_token")
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(cHandler.CodeGraphCallback).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should return 400 for an unknown status", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/codegraph/callback", strings.NewReader(`{"uuid": "graph_uuid", "status": "pending"}`))
+		req.Header.Set("token", "indexer_token")
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(cHandler.CodeGraphCallback).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+type configHandler struct {
+	db db.Database
+}
+
+func NewConfigHandler(database db.Database) *configHandler {
+	return &configHandler{db: database}
+}
+
+// GetConfigOverrides lists the overrides in the configs table and the keys that can be overridden
+func (ch *configHandler) GetConfigOverrides(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[config] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	overrides, err := ch.db.GetConfigOverrides()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not read config overrides")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"overrides":           overrides,
+		"overridable_keys":    config.OverridableKeys(),
+		"feature_flag_prefix": config.FeatureFlagPrefix,
+	})
+}
+
+// SetConfigOverride saves an override and applies it on this instance right away,
+// the other instances pick it up on their next reload
+func (ch *configHandler) SetConfigOverride(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[config] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.ConfigOverrideRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	key := chi.URLParam(r, "key")
+	if err := config.ValidateOverride(key, request.Value); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	override, err := ch.db.SaveConfigOverride(key, request.Value, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[config] could not save override", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save config override")
+		return
+	}
+	ReloadConfigOverrides(ch.db)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(override)
+}
+
+// DeleteConfigOverride puts a value back to its env or default
+func (ch *configHandler) DeleteConfigOverride(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[config] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	err := ch.db.DeleteConfigOverride(chi.URLParam(r, "key"))
+	if errors.Is(err, db.ErrConfigOverrideNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Config override not found")
+		return
+	}
+	if err != nil {
+		fmt.Println("[config] could not delete override", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete config override")
+		return
+	}
+	ReloadConfigOverrides(ch.db)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Config override deleted")
+}
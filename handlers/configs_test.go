@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestConfigOverrides(t *testing.T) {
+	defer config.ResetOverrides()
+	mockDb := mocks.NewDatabase(t)
+	cHandler := NewConfigHandler(mockDb)
+	defaultLimit := config.SearchRateLimit
+
+	request := func(handler http.HandlerFunc, method string, key string, body string, pubkey string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/admin/configs/"+key, bytes.NewBufferString(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("key", key)
+		ctx := context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, pubkey)
+		handler.ServeHTTP(rr, req.WithContext(ctx))
+		return rr
+	}
+
+	t.Run("should return 401 without a pubkey", func(t *testing.T) {
+		rr := request(cHandler.SetConfigOverride, http.MethodPut, "search_rate_limit", `{"value": "120"}`, "")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should reject unknown keys and bad values", func(t *testing.T) {
+		rr := request(cHandler.SetConfigOverride, http.MethodPut, "jwt_key", `{"value": "secret"}`, "admin")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = request(cHandler.SetConfigOverride, http.MethodPut, "search_rate_limit", `{"value": "0"}`, "admin")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should save an override and apply it right away", func(t *testing.T) {
+		override := db.ConfigOverride{Key: "search_rate_limit", Value: "120", UpdatedBy: "admin"}
+		mockDb.On("SaveConfigOverride", "search_rate_limit", "120", "admin").Return(override, nil).Once()
+		mockDb.On("GetConfigOverrides").Return([]db.ConfigOverride{override}, nil).Once()
+
+		rr := request(cHandler.SetConfigOverride, http.MethodPut, "search_rate_limit", `{"value": "120"}`, "admin")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 120, config.SearchRateLimit)
+	})
+
+	t.Run("should go back to the default when the override is deleted", func(t *testing.T) {
+		mockDb.On("DeleteConfigOverride", "search_rate_limit").Return(nil).Once()
+		mockDb.On("GetConfigOverrides").Return([]db.ConfigOverride{}, nil).Once()
+
+		rr := request(cHandler.DeleteConfigOverride, http.MethodDelete, "search_rate_limit", "", "admin")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, defaultLimit, config.SearchRateLimit)
+	})
+
+	t.Run("should return 404 for a key without an override", func(t *testing.T) {
+		mockDb.On("DeleteConfigOverride", "busy_bounty_threshold").Return(db.ErrConfigOverrideNotFound).Once()
+
+		rr := request(cHandler.DeleteConfigOverride, http.MethodDelete, "busy_bounty_threshold", "", "admin")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should keep the config when the table can't be read", func(t *testing.T) {
+		mockDb.On("GetConfigOverrides").Return(nil, errors.New("db down")).Once()
+
+		ReloadConfigOverrides(mockDb)
+		assert.Equal(t, defaultLimit, config.SearchRateLimit)
+		mockDb.AssertNotCalled(t, "SaveConfigOverride", mock.Anything, mock.Anything, "other")
+	})
+}
+
+func TestMaintenance(t *testing.T) {
+	defer config.ResetOverrides()
+	mockDb := mocks.NewDatabase(t)
+	cHandler := NewConfigHandler(mockDb)
+
+	write := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+		MaintenanceMiddleware(next).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, path, nil))
+		return rr
+	}
+
+	t.Run("should let writes through when maintenance is off", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, write("/gobounties").Code)
+	})
+
+	t.Run("should turn maintenance on and answer writes with a 503", func(t *testing.T) {
+		mockDb.On("SaveConfigOverride", "maintenance_mode", "true", "admin").Return(db.ConfigOverride{}, nil).Once()
+		mockDb.On("SaveConfigOverride", "maintenance_message", "Migrating", "admin").Return(db.ConfigOverride{}, nil).Once()
+		mockDb.On("GetConfigOverrides").Return([]db.ConfigOverride{
+			{Key: "maintenance_mode", Value: "true"},
+			{Key: "maintenance_message", Value: "Migrating"},
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/admin/maintenance", bytes.NewBufferString(`{"enabled": true, "message": "Migrating"}`))
+		http.HandlerFunc(cHandler.SetMaintenance).ServeHTTP(rr, req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "admin")))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rr = write("/gobounties")
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, maintenanceRetryAfter, rr.Header().Get("Retry-After"))
+		assert.JSONEq(t, `{"enabled": true, "message": "Migrating"}`, rr.Body.String())
+
+		assert.Equal(t, http.StatusOK, write("/admin/maintenance").Code, "admins can still turn it off")
+
+		rr = httptest.NewRecorder()
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+		MaintenanceMiddleware(next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/gobounties/all", nil))
+		assert.Equal(t, http.StatusOK, rr.Code, "reads go through")
+	})
+
+	t.Run("should turn maintenance off and drop the message", func(t *testing.T) {
+		mockDb.On("SaveConfigOverride", "maintenance_mode", "false", "admin").Return(db.ConfigOverride{}, nil).Once()
+		mockDb.On("DeleteConfigOverride", "maintenance_message").Return(db.ErrConfigOverrideNotFound).Once()
+		mockDb.On("GetConfigOverrides").Return([]db.ConfigOverride{{Key: "maintenance_mode", Value: "false"}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/admin/maintenance", bytes.NewBufferString(`{"enabled": false}`))
+		http.HandlerFunc(cHandler.SetMaintenance).ServeHTTP(rr, req.WithContext(context.WithValue(req.Context(), auth.ContextKey, "admin")))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, config.DefaultMaintenanceMessage, config.MaintenanceMessage)
+
+		assert.Equal(t, http.StatusOK, write("/gobounties").Code)
+	})
+}
+
+func TestWebhookSigningSecrets(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	cHandler := NewConfigHandler(mockDb)
+
+	request := func(handler http.HandlerFunc, method string, source string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/admin/webhook_secrets/"+source, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("source", source)
+		ctx := context.WithValue(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), auth.ContextKey, "admin")
+		handler.ServeHTTP(rr, req.WithContext(ctx))
+		return rr
+	}
+
+	t.Run("should reject a source that doesn't call back", func(t *testing.T) {
+		rr := request(cHandler.RotateWebhookSigningSecret, http.MethodPut, "github")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should show a rotated secret once", func(t *testing.T) {
+		var stored string
+		mockDb.On("SetWebhookSigningSecret", auth.WebhookSourceStakwork, mock.AnythingOfType("string"), "admin").Return(func(source string, secret string, pubkey string) (db.WebhookSigningSecret, error) {
+			stored = secret
+			return db.WebhookSigningSecret{Source: source, Secret: db.EncryptedString(secret), UpdatedBy: pubkey}, nil
+		}).Once()
+
+		rr := request(cHandler.RotateWebhookSigningSecret, http.MethodPut, auth.WebhookSourceStakwork)
+		rotated := db.RotatedWebhookSigningSecret{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &rotated))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotEmpty(t, stored)
+		assert.Equal(t, stored, rotated.Secret)
+
+		mockDb.On("GetWebhookSigningSecrets").Return([]db.WebhookSigningSecret{{Source: auth.WebhookSourceStakwork, Secret: db.EncryptedString(stored)}}, nil).Once()
+		rr = request(cHandler.GetWebhookSigningSecrets, http.MethodGet, "")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), stored)
+	})
+
+	t.Run("should return 404 when the source has no stored secret", func(t *testing.T) {
+		mockDb.On("DeleteWebhookSigningSecret", auth.WebhookSourceCodeGraph).Return(db.ErrWebhookSigningSecretNotFound).Once()
+		rr := request(cHandler.DeleteWebhookSigningSecret, http.MethodDelete, auth.WebhookSourceCodeGraph)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+type openAIEmbeddingService struct {
+	httpClient HttpClient
+}
+
+func NewEmbeddingService(httpClient HttpClient) EmbeddingService {
+	return &openAIEmbeddingService{httpClient: httpClient}
+}
+
+func (s *openAIEmbeddingService) Embed(texts []string) ([]db.Vector, error) {
+	if config.EmbeddingServiceUrl == "" {
+		return nil, errEmbeddingsNotConfigured
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"model": config.EmbeddingModel,
+		"input": texts,
+	})
+	req, err := http.NewRequest(http.MethodPost, config.EmbeddingServiceUrl, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if config.EmbeddingServiceKey != "" {
+		req.Header.Set("Authorization", "Bearer "+config.EmbeddingServiceKey)
+	}
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	result := struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding db.Vector `json:"embedding"`
+		} `json:"data"`
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	resBody, _ := io.ReadAll(res.Body)
+	json.Unmarshal(resBody, &result)
+
+	if res.StatusCode != http.StatusOK {
+		if result.Error.Message == "" {
+			result.Error.Message = fmt.Sprintf("embedding service returned status %d", res.StatusCode)
+		}
+		return nil, errors.New(result.Error.Message)
+	}
+
+	embeddings := make([]db.Vector, len(texts))
+	for _, data := range result.Data {
+		if data.Index < 0 || data.Index >= len(texts) {
+			return nil, errors.New("embedding service returned an unknown index")
+		}
+		embeddings[data.Index] = data.Embedding
+	}
+	for _, embedding := range embeddings {
+		if len(embedding) == 0 {
+			return nil, errors.New("embedding service left out a text")
+		}
+	}
+	return embeddings, nil
+}
@@ -2,29 +2,51 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/rs/xid"
 	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
 )
 
 type featureHandler struct {
 	db                    db.Database
 	generateBountyHandler func(bounties []db.NewBounty) []db.BountyResponse
+	broadcastMessage      func(message websocket.Message)
 }
 
+const (
+	defaultBriefLockTtl = 120
+	maxBriefLockTtl     = 600
+)
+
 func NewFeatureHandler(database db.Database) *featureHandler {
-	bHandler := NewBountyHandler(http.DefaultClient, database)
+	bHandler := NewBountyHandler(utils.TracedClient, database)
 	return &featureHandler{
 		db:                    database,
 		generateBountyHandler: bHandler.GenerateBountyResponse,
+		broadcastMessage:      broadcastToWebsocketPool,
 	}
 }
 
+func broadcastToWebsocketPool(message websocket.Message) {
+	go func() {
+		websocket.WebsocketPool.Broadcast <- message
+	}()
+}
+
 func (oh *featureHandler) CreateOrEditFeatures(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
@@ -76,6 +98,20 @@ func (oh *featureHandler) CreateOrEditFeatures(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	event := db.WorkspaceEvent{
+		WorkspaceUuid: p.WorkspaceUuid,
+		Type:          db.FeatureCreatedEvent,
+		Title:         "New feature",
+		Message:       p.Name,
+		Link:          fmt.Sprintf("%s/feature/%s", config.Host, p.Uuid),
+		Actor:         pubKeyFromAuth,
+	}
+	if features.UpdatedBy != "" {
+		event.Type = db.FeatureUpdatedEvent
+		event.Title = "Feature updated"
+	}
+	PublishWorkspaceEvent(event)
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(p)
 }
@@ -111,6 +147,10 @@ func (oh *featureHandler) GetFeaturesByWorkspaceUuid(w http.ResponseWriter, r *h
 		return
 	}
 
+	if !validListParams(w, r) {
+		return
+	}
+
 	uuid := chi.URLParam(r, "workspace_uuid")
 	workspaceFeatures := oh.db.GetFeaturesByWorkspaceUuid(uuid, r)
 
@@ -146,10 +186,405 @@ func (oh *featureHandler) GetFeatureByUuid(w http.ResponseWriter, r *http.Reques
 	uuid := chi.URLParam(r, "uuid")
 	workspaceFeature := oh.db.GetFeatureByUuid(uuid)
 
+	if workspaceFeature.Uuid != "" {
+		w.Header().Set("ETag", recordETag(workspaceFeature.Updated))
+	}
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(workspaceFeature)
 }
 
+// featurePatchFields are the fields a PATCH can change, the brief goes
+// through its own endpoint which respects the brief lock
+var featurePatchFields = map[string]bool{
+	"name":         true,
+	"requirements": true,
+	"architecture": true,
+	"url":          true,
+	"priority":     true,
+}
+
+// PatchFeature changes the fields of a feature a JSON merge patch names and
+// leaves the others as they are, conditional on If-Match like PatchBounty
+func (oh *featureHandler) PatchFeature(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[features] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	current := oh.db.GetFeatureByUuid(chi.URLParam(r, "uuid"))
+	if current.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(db.ErrFeatureNotFound.Error())
+		return
+	}
+	if !isWorkspaceMember(oh.db, pubKeyFromAuth, current.WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the workspace")
+		return
+	}
+
+	conditional, matched := ifMatch(r, recordETag(current.Updated))
+	if !matched {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		json.NewEncoder(w).Encode("The feature changed since it was read")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	feature := db.WorkspaceFeatures{}
+	fields := []string{}
+	if err == nil {
+		fields, err = applyMergePatch(current, body, &feature)
+	}
+	if err != nil {
+		fmt.Println("[features]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if field := unpatchableField(fields, featurePatchFields); field != "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("%s cannot be patched", field))
+		return
+	}
+	if len(fields) == 0 {
+		w.Header().Set("ETag", recordETag(current.Updated))
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(current)
+		return
+	}
+
+	feature.UpdatedBy = pubKeyFromAuth
+	if err := db.Validate.Struct(feature); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Error: did not pass validation test : %s", err))
+		return
+	}
+
+	feature, err = oh.db.PatchFeature(feature, fields, conditional)
+	if errors.Is(err, db.ErrPatchConflict) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		json.NewEncoder(w).Encode("The feature changed since it was read")
+		return
+	}
+	if err != nil {
+		fmt.Println("[features] could not patch feature", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	PublishWorkspaceEvent(db.WorkspaceEvent{
+		WorkspaceUuid: feature.WorkspaceUuid,
+		Type:          db.FeatureUpdatedEvent,
+		Title:         "Feature updated",
+		Message:       feature.Name,
+		Link:          fmt.Sprintf("%s/feature/%s", config.Host, feature.Uuid),
+		Actor:         pubKeyFromAuth,
+	})
+
+	w.Header().Set("ETag", recordETag(feature.Updated))
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(feature)
+}
+
+// GetFeatureDetail returns a feature with its phases, their bounty counts,
+// its stories and its open calls, so the planner loads a feature with one call
+func (oh *featureHandler) GetFeatureDetail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[features] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	detail, err := oh.db.GetFeatureDetail(chi.URLParam(r, "uuid"))
+	if errors.Is(err, db.ErrFeatureNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[features] could not load feature", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !isWorkspaceMember(oh.db, pubKeyFromAuth, detail.WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the workspace")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(detail)
+}
+
+// RepairFeatureCounts recounts the features whose bounty counts drifted and
+// returns them with the counts they had
+func (oh *featureHandler) RepairFeatureCounts(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(CheckFeatureCounts(oh.db))
+}
+
+// renderBriefSections joins the sections into the plain text brief,
+// an unnamed section holds text written before sections were used
+func renderBriefSections(sections db.BriefSections) string {
+	parts := []string{}
+	for _, section := range sections {
+		if section.Name == "" {
+			parts = append(parts, section.Content)
+		} else {
+			parts = append(parts, fmt.Sprintf("## %s\n\n%s", section.Name, section.Content))
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func mergeFeatureBrief(feature db.WorkspaceFeatures, request db.FeatureBriefRequest) (string, db.BriefSections, error) {
+	brief := strings.TrimSpace(request.Brief)
+	sections := feature.BriefSections
+
+	switch request.Mode {
+	case db.BriefReplaceMode:
+		return brief, db.BriefSections{}, nil
+	case db.BriefSectionMergeMode:
+		name := strings.TrimSpace(request.Section)
+		if name == "" {
+			return "", nil, errors.New("section is required for section-merge")
+		}
+
+		merged := db.BriefSections{}
+		if len(sections) == 0 && strings.TrimSpace(feature.Brief) != "" {
+			merged = append(merged, db.BriefSection{Content: strings.TrimSpace(feature.Brief)})
+		}
+
+		found := false
+		for _, section := range sections {
+			if section.Name == name {
+				section.Content = brief
+				found = true
+			}
+			merged = append(merged, section)
+		}
+		if !found {
+			merged = append(merged, db.BriefSection{Name: name, Content: brief})
+		}
+
+		return renderBriefSections(merged), merged, nil
+	case db.BriefAppendMode, "":
+		if strings.TrimSpace(feature.Brief) == "" {
+			return brief, sections, nil
+		}
+		if len(sections) > 0 {
+			// keep appended text in the brief when it is rendered from sections
+			sections = append(sections, db.BriefSection{Content: brief})
+			return renderBriefSections(sections), sections, nil
+		}
+		return strings.TrimSpace(feature.Brief) + "\n\n" + brief, sections, nil
+	}
+
+	return "", nil, errors.New("not a valid brief merge mode")
+}
+
+// UpdateFeatureBrief merges generated or edited text into a feature brief
+// using the requested mode, the default mode appends
+func (oh *featureHandler) UpdateFeatureBrief(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	oh.updateFeatureBrief(w, r, pubKeyFromAuth)
+}
+
+// UpdateFeatureBriefWebhook is UpdateFeatureBrief for the signed Stakwork
+// callback, which has no user, the brief is updated by stakwork
+func (oh *featureHandler) UpdateFeatureBriefWebhook(w http.ResponseWriter, r *http.Request) {
+	oh.updateFeatureBrief(w, r, auth.WebhookSourceStakwork)
+}
+
+func (oh *featureHandler) updateFeatureBrief(w http.ResponseWriter, r *http.Request, pubKeyFromAuth string) {
+	request := db.FeatureBriefRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	err := json.Unmarshal(body, &request)
+	if err != nil {
+		fmt.Println(err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	feature := oh.db.GetFeatureByUuid(request.FeatureUuid)
+	if feature.Uuid == "" || feature.Uuid != request.FeatureUuid {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Feature does not exists")
+		return
+	}
+
+	if lock, err := db.Store.GetFeatureBriefLock(feature.Uuid); err == nil && lock.Holder != pubKeyFromAuth {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(lock)
+		return
+	}
+
+	brief, sections, err := mergeFeatureBrief(feature, request)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	p, err := oh.db.UpdateFeatureBrief(feature.Uuid, brief, sections, pubKeyFromAuth)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(p)
+}
+
+func (oh *featureHandler) broadcastBriefLock(featureUuid string, lock *db.FeatureBriefLock) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"feature_uuid": featureUuid,
+		"locked":       lock != nil,
+		"lock":         lock,
+	})
+	oh.broadcastMessage(websocket.Message{Type: 1, Msg: "feature_brief_lock", Body: string(body)})
+}
+
+// LockFeatureBrief acquires or refreshes the advisory edit lock of a feature brief
+func (oh *featureHandler) LockFeatureBrief(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "uuid")
+	if !oh.featureBriefMember(w, uuid, pubKeyFromAuth) {
+		return
+	}
+
+	request := db.FeatureBriefLockRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &request); err != nil {
+			fmt.Println(err)
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+	}
+
+	ttl := request.Ttl
+	if ttl <= 0 {
+		ttl = defaultBriefLockTtl
+	} else if ttl > maxBriefLockTtl {
+		ttl = maxBriefLockTtl
+	}
+
+	existing, err := db.Store.GetFeatureBriefLock(uuid)
+	if err == nil && existing.Holder != pubKeyFromAuth {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(existing)
+		return
+	}
+
+	lock := db.FeatureBriefLock{
+		FeatureUuid: uuid,
+		Holder:      pubKeyFromAuth,
+		ExpiresAt:   time.Now().Add(time.Duration(ttl) * time.Second),
+	}
+	db.Store.SetFeatureBriefLock(lock)
+	oh.broadcastBriefLock(uuid, &lock)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(lock)
+}
+
+// featureBriefMember checks the feature exists and pubkey is a member of its
+// workspace, and writes the error response when not
+func (oh *featureHandler) featureBriefMember(w http.ResponseWriter, uuid string, pubkey string) bool {
+	feature := oh.db.GetFeatureByUuid(uuid)
+	if feature.Uuid == "" || feature.Uuid != uuid {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Feature does not exists")
+		return false
+	}
+	if !isWorkspaceMember(oh.db, pubkey, feature.WorkspaceUuid) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("Don't have access to the workspace")
+		return false
+	}
+	return true
+}
+
+func (oh *featureHandler) GetFeatureBriefLock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "uuid")
+	if !oh.featureBriefMember(w, uuid, pubKeyFromAuth) {
+		return
+	}
+
+	lock, err := db.Store.GetFeatureBriefLock(uuid)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(lock)
+}
+
+// UnlockFeatureBrief releases the brief lock, only the holder can release it
+func (oh *featureHandler) UnlockFeatureBrief(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "uuid")
+	lock, err := db.Store.GetFeatureBriefLock(uuid)
+	if err != nil {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Feature brief is not locked"})
+		return
+	}
+
+	if lock.Holder != pubKeyFromAuth {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(lock)
+		return
+	}
+
+	db.Store.DeleteFeatureBriefLock(uuid)
+	oh.broadcastBriefLock(uuid, nil)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Feature brief unlocked"})
+}
+
 func (oh *featureHandler) CreateOrEditFeaturePhase(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
@@ -245,6 +680,16 @@ func (oh *featureHandler) CreateOrEditStory(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	oh.createOrEditStory(w, r, pubKeyFromAuth)
+}
+
+// CreateOrEditStoryWebhook is CreateOrEditStory for the signed Stakwork
+// callback, which has no user, the story is written by stakwork
+func (oh *featureHandler) CreateOrEditStoryWebhook(w http.ResponseWriter, r *http.Request) {
+	oh.createOrEditStory(w, r, auth.WebhookSourceStakwork)
+}
+
+func (oh *featureHandler) createOrEditStory(w http.ResponseWriter, r *http.Request, pubKeyFromAuth string) {
 	newStory := db.FeatureStory{}
 	decoder := json.NewDecoder(r.Body)
 	err := decoder.Decode(&newStory)
@@ -254,11 +699,28 @@ func (oh *featureHandler) CreateOrEditStory(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if newStory.DeliveryId != nil && strings.TrimSpace(*newStory.DeliveryId) == "" {
+		newStory.DeliveryId = nil
+	}
+
 	if newStory.Uuid == "" {
 		newStory.Uuid = xid.New().String()
 	}
 
-	existingStory, _ := oh.db.GetFeatureStoryByUuid(newStory.FeatureUuid, newStory.Uuid)
+	existingStory, existingErr := oh.db.GetFeatureStoryByUuid(newStory.FeatureUuid, newStory.Uuid)
+
+	if existingErr == nil {
+		// the delivery id stays the one of the delivery that created the story
+		newStory.DeliveryId = nil
+	} else if newStory.DeliveryId != nil {
+		// webhooks can deliver the same story twice, the replay is a no-op
+		delivered, err := oh.db.GetFeatureStoryByDeliveryId(newStory.FeatureUuid, *newStory.DeliveryId)
+		if err == nil {
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(delivered)
+			return
+		}
+	}
 
 	if existingStory.CreatedBy == "" {
 		newStory.CreatedBy = pubKeyFromAuth
@@ -321,6 +783,10 @@ func (oh *featureHandler) GetBountiesByFeatureAndPhaseUuid(w http.ResponseWriter
 	featureUuid := chi.URLParam(r, "feature_uuid")
 	phaseUuid := chi.URLParam(r, "phase_uuid")
 
+	if !validListParams(w, r) {
+		return
+	}
+
 	bounties, err := oh.db.GetBountiesByFeatureAndPhaseUuid(featureUuid, phaseUuid, r)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
@@ -342,3 +808,654 @@ func (oh *featureHandler) GetBountiesCountByFeatureAndPhaseUuid(w http.ResponseW
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(bountiesCount)
 }
+
+func isValidPhaseDocumentType(docType db.PhaseDocumentType) bool {
+	switch docType {
+	case db.PhasePlanDocument, db.PhaseRequirementsDocument, db.PhaseArchitectureDocument:
+		return true
+	}
+	return false
+}
+
+// getPhaseDocumentParams validates the phase document url params and that the
+// caller is a member of the workspace of the feature, and writes the error
+// response when they are not
+func (oh *featureHandler) getPhaseDocumentParams(w http.ResponseWriter, r *http.Request) (db.FeaturePhase, db.PhaseDocumentType, bool) {
+	featureUuid := chi.URLParam(r, "feature_uuid")
+	phaseUuid := chi.URLParam(r, "phase_uuid")
+	docType := db.PhaseDocumentType(chi.URLParam(r, "doc_type"))
+
+	if !isValidPhaseDocumentType(docType) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Not a valid phase document type")
+		return db.FeaturePhase{}, docType, false
+	}
+
+	pubKeyFromAuth, _ := r.Context().Value(auth.ContextKey).(string)
+	feature := oh.db.GetFeatureByUuid(featureUuid)
+	if feature.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(db.ErrFeatureNotFound.Error())
+		return db.FeaturePhase{}, docType, false
+	}
+	if !isWorkspaceMember(oh.db, pubKeyFromAuth, feature.WorkspaceUuid) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("Don't have access to the workspace")
+		return db.FeaturePhase{}, docType, false
+	}
+
+	phase, err := oh.db.GetFeaturePhaseByUuid(featureUuid, phaseUuid)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return phase, docType, false
+	}
+
+	return phase, docType, true
+}
+
+func (oh *featureHandler) AddPhaseDocument(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	phase, docType, ok := oh.getPhaseDocumentParams(w, r)
+	if !ok {
+		return
+	}
+
+	newDoc := db.PhaseDocument{}
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&newDoc)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error decoding request body: %v", err)
+		return
+	}
+
+	// an unchanged document does not create a new version
+	latest, err := oh.db.GetLatestPhaseDocument(phase.Uuid, docType)
+	if err == nil && latest.Content == newDoc.Content {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(latest)
+		return
+	}
+
+	doc, err := oh.db.AddPhaseDocument(db.PhaseDocument{
+		FeatureUuid: phase.FeatureUuid,
+		PhaseUuid:   phase.Uuid,
+		DocType:     docType,
+		Content:     newDoc.Content,
+		CreatedBy:   pubKeyFromAuth,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Error creating phase document: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(doc)
+}
+
+func (oh *featureHandler) GetPhaseDocument(w http.ResponseWriter, r *http.Request) {
+	phase, docType, ok := oh.getPhaseDocumentParams(w, r)
+	if !ok {
+		return
+	}
+
+	doc, err := oh.db.GetLatestPhaseDocument(phase.Uuid, docType)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(doc)
+}
+
+func (oh *featureHandler) GetPhaseDocumentHistory(w http.ResponseWriter, r *http.Request) {
+	phase, docType, ok := oh.getPhaseDocumentParams(w, r)
+	if !ok {
+		return
+	}
+
+	docs := oh.db.GetPhaseDocumentHistory(phase.Uuid, docType)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(docs)
+}
+
+// RestorePhaseDocument adds the content of an older version as the newest version
+func (oh *featureHandler) RestorePhaseDocument(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	phase, docType, ok := oh.getPhaseDocumentParams(w, r)
+	if !ok {
+		return
+	}
+
+	version, err := utils.ConvertStringToInt(chi.URLParam(r, "version"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Not a valid version")
+		return
+	}
+
+	old, err := oh.db.GetPhaseDocumentByVersion(phase.Uuid, docType, version)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	doc, err := oh.db.AddPhaseDocument(db.PhaseDocument{
+		FeatureUuid: phase.FeatureUuid,
+		PhaseUuid:   phase.Uuid,
+		DocType:     docType,
+		Content:     old.Content,
+		CreatedBy:   pubKeyFromAuth,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Error restoring phase document: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(doc)
+}
+
+// GetPhaseDocumentDiff compares two versions, by default the previous and the latest one
+func (oh *featureHandler) GetPhaseDocumentDiff(w http.ResponseWriter, r *http.Request) {
+	phase, docType, ok := oh.getPhaseDocumentParams(w, r)
+	if !ok {
+		return
+	}
+
+	latest, err := oh.db.GetLatestPhaseDocument(phase.Uuid, docType)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	params := utils.NewQueryParams(r)
+	toVersion := params.Int("to", latest.Version)
+	fromVersion := params.Int("from", toVersion-1)
+	if err := params.Err(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	toDoc, err := oh.db.GetPhaseDocumentByVersion(phase.Uuid, docType, toVersion)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	// version 0 is the empty document before the first version
+	fromDoc := db.PhaseDocument{}
+	if fromVersion > 0 {
+		fromDoc, err = oh.db.GetPhaseDocumentByVersion(phase.Uuid, docType, fromVersion)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.PhaseDocumentDiff{
+		DocType:     docType,
+		FromVersion: fromDoc.Version,
+		ToVersion:   toDoc.Version,
+		Lines:       utils.DiffLines(fromDoc.Content, toDoc.Content),
+	})
+}
+
+var (
+	jitsiRoomRegex  = regexp.MustCompile(`^(/[A-Za-z0-9_-]+)+$`)
+	meetCodeRegex   = regexp.MustCompile(`^/[a-z]{3}-[a-z]{4}-[a-z]{3}$`)
+	zoomMeetingPath = regexp.MustCompile(`^/(j|my)/[A-Za-z0-9._-]+$`)
+)
+
+var recurrenceDays = map[string]bool{"MO": true, "TU": true, "WE": true, "TH": true, "FR": true, "SA": true, "SU": true}
+
+// maxFeatureCallMinutes is the longest a feature call can be scheduled for
+const maxFeatureCallMinutes = 24 * 60
+
+// validateFeatureCallUrl checks that a call url is a meeting link of its provider,
+// jitsi can be self hosted so only its room path is checked
+func validateFeatureCallUrl(provider db.FeatureCallProvider, rawUrl string) error {
+	u, err := url.Parse(rawUrl)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errors.New("call url must be an https url")
+	}
+	host := strings.ToLower(u.Hostname())
+
+	switch provider {
+	case db.FeatureCallJitsi:
+		if !jitsiRoomRegex.MatchString(u.Path) {
+			return errors.New("jitsi url must have a room name")
+		}
+	case db.FeatureCallMeet:
+		if host != "meet.google.com" || !meetCodeRegex.MatchString(u.Path) {
+			return errors.New("meet url must be a meet.google.com meeting code")
+		}
+	case db.FeatureCallZoom:
+		if (host != "zoom.us" && !strings.HasSuffix(host, ".zoom.us")) || !zoomMeetingPath.MatchString(u.Path) {
+			return errors.New("zoom url must be a zoom.us meeting link")
+		}
+	default:
+		return fmt.Errorf("unknown call provider %q", provider)
+	}
+	return nil
+}
+
+// validRecurrence checks a recurring rule, the daily, weekly and monthly
+// subset of an RFC 5545 RRULE such as FREQ=WEEKLY;BYDAY=MO,WE
+func validRecurrence(rule string) bool {
+	rule = strings.TrimPrefix(rule, "RRULE:")
+	hasFreq := false
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return false
+		}
+		switch key {
+		case "FREQ":
+			if value != "DAILY" && value != "WEEKLY" && value != "MONTHLY" {
+				return false
+			}
+			hasFreq = true
+		case "INTERVAL", "COUNT":
+			if n, err := strconv.Atoi(value); err != nil || n < 1 {
+				return false
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				if !recurrenceDays[day] {
+					return false
+				}
+			}
+		case "UNTIL":
+			if _, err := time.Parse("20060102T150405Z", value); err != nil {
+				if _, err := time.Parse("20060102", value); err != nil {
+					return false
+				}
+			}
+		default:
+			return false
+		}
+	}
+	return hasFreq
+}
+
+func validateFeatureCall(call db.FeatureCall) error {
+	if err := validateFeatureCallUrl(call.Provider, call.Url); err != nil {
+		return err
+	}
+	if call.DurationMinutes < 0 || call.DurationMinutes > maxFeatureCallMinutes {
+		return fmt.Errorf("duration must be between 0 and %d minutes", maxFeatureCallMinutes)
+	}
+	if call.Recurrence != "" {
+		if call.ScheduledAt == nil {
+			return errors.New("a recurring call needs a schedule")
+		}
+		if !validRecurrence(call.Recurrence) {
+			return errors.New("recurrence must be a daily, weekly or monthly RRULE")
+		}
+	}
+	if call.RecordingUrl != "" {
+		if u, err := url.Parse(call.RecordingUrl); err != nil || u.Scheme != "https" || u.Host == "" {
+			return errors.New("recording url must be an https url")
+		}
+	}
+	return nil
+}
+
+// CreateFeatureCall adds a call to a feature, earlier calls stay in its history
+func (oh *featureHandler) CreateFeatureCall(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	feature := oh.db.GetFeatureByUuid(chi.URLParam(r, "feature_uuid"))
+	if feature.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "feature not found"})
+		return
+	}
+
+	call := db.FeatureCall{}
+	if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error decoding request body: %v", err)
+		return
+	}
+
+	if err := validateFeatureCall(call); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	call.FeatureUuid = feature.Uuid
+	call.WorkspaceUuid = feature.WorkspaceUuid
+	call.CreatedBy = pubKeyFromAuth
+	call.UpdatedBy = pubKeyFromAuth
+
+	call, err := oh.db.CreateFeatureCall(call)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Error creating feature call: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(call)
+}
+
+// UpdateFeatureCall changes the fields sent for one call, such as
+// its schedule or the recording once the call has ended
+func (oh *featureHandler) UpdateFeatureCall(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	existing, err := oh.db.GetFeatureCallByUuid(chi.URLParam(r, "feature_uuid"), chi.URLParam(r, "call_uuid"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	call := existing
+	if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error decoding request body: %v", err)
+		return
+	}
+
+	if err := validateFeatureCall(call); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	call.ID = existing.ID
+	call.Uuid = existing.Uuid
+	call.FeatureUuid = existing.FeatureUuid
+	call.WorkspaceUuid = existing.WorkspaceUuid
+	call.CreatedBy = existing.CreatedBy
+	call.Created = existing.Created
+	call.UpdatedBy = pubKeyFromAuth
+
+	call, err = oh.db.UpdateFeatureCall(call)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Error updating feature call: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(call)
+}
+
+func (oh *featureHandler) GetFeatureCalls(w http.ResponseWriter, r *http.Request) {
+	calls := oh.db.GetFeatureCalls(chi.URLParam(r, "feature_uuid"))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(calls)
+}
+
+func (oh *featureHandler) GetFeatureCallHistory(w http.ResponseWriter, r *http.Request) {
+	calls := oh.db.GetFeatureCallHistory(chi.URLParam(r, "feature_uuid"))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(calls)
+}
+
+// transcriptChunkSize is the length a transcript chunk is kept under,
+// a single longer segment stays one chunk
+const transcriptChunkSize = 2000
+
+// transcriptBriefSection is the feature brief section listing the call transcripts
+const transcriptBriefSection = "Call Transcripts"
+
+type transcriptChunker struct {
+	chunks  []db.FeatureCallTranscriptChunk
+	current []string
+	length  int
+	start   *float64
+	end     *float64
+}
+
+func (c *transcriptChunker) add(text string, sep string, start *float64, end *float64) {
+	if c.length > 0 && c.length+len(sep)+len(text) > transcriptChunkSize {
+		c.flush(sep)
+	}
+	if c.length > 0 {
+		c.length += len(sep)
+	}
+	if c.start == nil {
+		c.start = start
+	}
+	c.end = end
+	c.current = append(c.current, text)
+	c.length += len(text)
+}
+
+func (c *transcriptChunker) flush(sep string) {
+	if len(c.current) == 0 {
+		return
+	}
+	c.chunks = append(c.chunks, db.FeatureCallTranscriptChunk{
+		Content:      strings.Join(c.current, sep),
+		StartSeconds: c.start,
+		EndSeconds:   c.end,
+	})
+	c.current, c.length, c.start, c.end = nil, 0, nil, nil
+}
+
+// chunkTranscript returns the text of an uploaded transcript and its chunks,
+// segments are chunked with their timing and plain content by paragraph
+func chunkTranscript(request db.FeatureCallTranscriptRequest) (string, []db.FeatureCallTranscriptChunk) {
+	chunker := transcriptChunker{}
+
+	if len(request.Segments) > 0 {
+		lines := []string{}
+		for _, segment := range request.Segments {
+			line := strings.TrimSpace(segment.Text)
+			if line == "" {
+				continue
+			}
+			if speaker := strings.TrimSpace(segment.Speaker); speaker != "" {
+				line = speaker + ": " + line
+			}
+			start, end := segment.Start, segment.End
+			lines = append(lines, line)
+			chunker.add(line, "\n", &start, &end)
+		}
+		chunker.flush("\n")
+		return strings.Join(lines, "\n"), chunker.chunks
+	}
+
+	content := strings.TrimSpace(request.Content)
+	chunker.addParagraphs(content)
+	return content, chunker.chunks
+}
+
+// addParagraphs chunks plain content by paragraph
+func (c *transcriptChunker) addParagraphs(content string) {
+	for _, paragraph := range strings.Split(content, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		if len(paragraph) <= transcriptChunkSize {
+			c.add(paragraph, "\n\n", nil, nil)
+			continue
+		}
+		// a paragraph too long for one chunk is split between words
+		c.flush("\n\n")
+		for _, word := range strings.Fields(paragraph) {
+			c.add(word, " ", nil, nil)
+		}
+		c.flush(" ")
+	}
+	c.flush("\n\n")
+}
+
+// linkTranscriptsInBrief lists the transcripts of a feature in a section of its brief,
+// the brief is left alone while someone holds its edit lock
+func (oh *featureHandler) linkTranscriptsInBrief(feature db.WorkspaceFeatures, updatedBy string) bool {
+	if _, err := db.Store.GetFeatureBriefLock(feature.Uuid); err == nil {
+		return false
+	}
+
+	lines := []string{}
+	for _, transcript := range oh.db.GetFeatureTranscripts(feature.Uuid) {
+		title := "Call"
+		if call, err := oh.db.GetFeatureCallByUuid(feature.Uuid, transcript.CallUuid); err == nil && call.Title != "" {
+			title = call.Title
+		}
+		date := ""
+		if transcript.Created != nil {
+			date = " (" + transcript.Created.Format("2006-01-02") + ")"
+		}
+		lines = append(lines, fmt.Sprintf("- %s%s: /features/%s/calls/%s/transcript", title, date, feature.Uuid, transcript.CallUuid))
+	}
+
+	brief, sections, err := mergeFeatureBrief(feature, db.FeatureBriefRequest{
+		FeatureUuid: feature.Uuid,
+		Brief:       strings.Join(lines, "\n"),
+		Mode:        db.BriefSectionMergeMode,
+		Section:     transcriptBriefSection,
+	})
+	if err != nil {
+		return false
+	}
+
+	_, err = oh.db.UpdateFeatureBrief(feature.Uuid, brief, sections, updatedBy)
+	return err == nil
+}
+
+// UploadFeatureCallTranscript stores the transcript of a call sent by the recording
+// pipeline with a workspace api token, and links it in the feature brief
+func (oh *featureHandler) UploadFeatureCallTranscript(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	apiToken, ok := auth.ApiTokenFromContext(ctx)
+	if !ok || pubKeyFromAuth == "" {
+		fmt.Println("no api token from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	feature := oh.db.GetFeatureByUuid(chi.URLParam(r, "feature_uuid"))
+	if feature.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "feature not found"})
+		return
+	}
+	if feature.WorkspaceUuid != apiToken.WorkspaceUuid {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]string{"error": "api token is not for the workspace of this feature"})
+		return
+	}
+
+	call, err := oh.db.GetFeatureCallByUuid(feature.Uuid, chi.URLParam(r, "call_uuid"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	request := db.FeatureCallTranscriptRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error decoding request body: %v", err)
+		return
+	}
+
+	content, chunks := chunkTranscript(request)
+	if content == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "transcript is empty"})
+		return
+	}
+
+	transcript, err := oh.db.SaveFeatureCallTranscript(db.FeatureCallTranscript{
+		CallUuid:      call.Uuid,
+		FeatureUuid:   feature.Uuid,
+		WorkspaceUuid: feature.WorkspaceUuid,
+		Language:      request.Language,
+		Content:       content,
+		ContextTags:   []string{"workspace:" + feature.WorkspaceUuid, "feature:" + feature.Uuid, "call:" + call.Uuid},
+		UploadedBy:    pubKeyFromAuth,
+	}, chunks)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Error saving transcript: %v", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(db.FeatureCallTranscriptResult{
+		Transcript:  transcript,
+		BriefLinked: oh.linkTranscriptsInBrief(feature, pubKeyFromAuth),
+	})
+}
+
+func (oh *featureHandler) GetFeatureCallTranscript(w http.ResponseWriter, r *http.Request) {
+	featureUuid := chi.URLParam(r, "feature_uuid")
+
+	transcript, err := oh.db.GetFeatureCallTranscript(chi.URLParam(r, "call_uuid"))
+	if err != nil || transcript.FeatureUuid != featureUuid {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": db.ErrFeatureCallTranscriptNotFound.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(transcript)
+}
+
+// SearchFeatureTranscripts finds the transcript chunks of a feature containing ?q=
+func (oh *featureHandler) SearchFeatureTranscripts(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "q is required"})
+		return
+	}
+
+	chunks := oh.db.SearchFeatureTranscriptChunks(chi.URLParam(r, "feature_uuid"), query)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(chunks)
+}
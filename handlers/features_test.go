@@ -0,0 +1,644 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newPhaseDocumentRequest(method string, docType string, body string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("feature_uuid", "feature_uuid")
+	rctx.URLParams.Add("phase_uuid", "phase_uuid")
+	rctx.URLParams.Add("doc_type", docType)
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "pub_key")
+	req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), method, "/feature_uuid/phase/phase_uuid/documents/"+docType, strings.NewReader(body))
+	return req
+}
+
+// expectPhaseDocumentMember lets pub_key in the workspace of feature_uuid
+func expectPhaseDocumentMember(mockDb *dbMocks.Database) {
+	mockDb.On("GetFeatureByUuid", "feature_uuid").Return(db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid"})
+	mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "pub_key"})
+}
+
+func TestAddPhaseDocument(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	fHandler := NewFeatureHandler(mockDb)
+	phase := db.FeaturePhase{Uuid: "phase_uuid", FeatureUuid: "feature_uuid"}
+	expectPhaseDocumentMember(mockDb)
+
+	t.Run("should return 400 for an unknown document type", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.AddPhaseDocument).ServeHTTP(rr, newPhaseDocumentRequest(http.MethodPost, "notes", `{"content": "text"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should not add a version for unchanged content", func(t *testing.T) {
+		mockDb.On("GetFeaturePhaseByUuid", "feature_uuid", "phase_uuid").Return(phase, nil).Once()
+		mockDb.On("GetLatestPhaseDocument", "phase_uuid", db.PhasePlanDocument).Return(db.PhaseDocument{Version: 2, Content: "text"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.AddPhaseDocument).ServeHTTP(rr, newPhaseDocumentRequest(http.MethodPost, "plan", `{"content": "text"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should add a new version for changed content", func(t *testing.T) {
+		mockDb.On("GetFeaturePhaseByUuid", "feature_uuid", "phase_uuid").Return(phase, nil).Once()
+		mockDb.On("GetLatestPhaseDocument", "phase_uuid", db.PhasePlanDocument).Return(db.PhaseDocument{Version: 2, Content: "text"}, nil).Once()
+		mockDb.On("AddPhaseDocument", mock.MatchedBy(func(d db.PhaseDocument) bool {
+			return d.Content == "new text" && d.DocType == db.PhasePlanDocument && d.CreatedBy == "pub_key"
+		})).Return(db.PhaseDocument{Version: 3, Content: "new text"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.AddPhaseDocument).ServeHTTP(rr, newPhaseDocumentRequest(http.MethodPost, "plan", `{"content": "new text"}`))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+}
+
+func TestGetPhaseDocumentDiff(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	fHandler := NewFeatureHandler(mockDb)
+	phase := db.FeaturePhase{Uuid: "phase_uuid", FeatureUuid: "feature_uuid"}
+	expectPhaseDocumentMember(mockDb)
+
+	t.Run("should diff the previous and the latest version by default", func(t *testing.T) {
+		latest := db.PhaseDocument{Version: 2, Content: "one\nthree"}
+		mockDb.On("GetFeaturePhaseByUuid", "feature_uuid", "phase_uuid").Return(phase, nil).Once()
+		mockDb.On("GetLatestPhaseDocument", "phase_uuid", db.PhaseRequirementsDocument).Return(latest, nil).Once()
+		mockDb.On("GetPhaseDocumentByVersion", "phase_uuid", db.PhaseRequirementsDocument, 2).Return(latest, nil).Once()
+		mockDb.On("GetPhaseDocumentByVersion", "phase_uuid", db.PhaseRequirementsDocument, 1).Return(db.PhaseDocument{Version: 1, Content: "one\ntwo"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.GetPhaseDocumentDiff).ServeHTTP(rr, newPhaseDocumentRequest(http.MethodGet, "requirements", ""))
+
+		var diff db.PhaseDocumentDiff
+		json.Unmarshal(rr.Body.Bytes(), &diff)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 1, diff.FromVersion)
+		assert.Equal(t, 2, diff.ToVersion)
+		assert.Equal(t, []utils.DiffLine{
+			{Op: utils.DiffEqual, Text: "one"},
+			{Op: utils.DiffDelete, Text: "two"},
+			{Op: utils.DiffInsert, Text: "three"},
+		}, diff.Lines)
+	})
+}
+
+func TestPhaseDocumentAccess(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	fHandler := NewFeatureHandler(mockDb)
+	mockDb.On("GetFeatureByUuid", "feature_uuid").Return(db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid"})
+	mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner"})
+	mockDb.On("GetWorkspaceUser", "pub_key", "workspace_uuid").Return(db.WorkspaceUsers{})
+
+	for _, handler := range []http.HandlerFunc{fHandler.AddPhaseDocument, fHandler.GetPhaseDocument, fHandler.GetPhaseDocumentHistory, fHandler.GetPhaseDocumentDiff, fHandler.RestorePhaseDocument} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newPhaseDocumentRequest(http.MethodPost, "plan", `{"content": "text"}`))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code, "someone outside of the workspace should not reach the documents")
+	}
+	mockDb.AssertNotCalled(t, "GetFeaturePhaseByUuid", mock.Anything, mock.Anything)
+}
+
+func TestLockFeatureBrief(t *testing.T) {
+	db.InitCache()
+	mockDb := dbMocks.NewDatabase(t)
+	fHandler := NewFeatureHandler(mockDb)
+
+	messages := []websocket.Message{}
+	fHandler.broadcastMessage = func(message websocket.Message) {
+		messages = append(messages, message)
+	}
+
+	newRequest := func(pubKey string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "feature_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubKey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/feature_uuid/brief/lock", strings.NewReader(`{"ttl": 60}`))
+		return req
+	}
+	mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "pub_key"})
+	mockDb.On("GetWorkspaceUser", "other_pub_key", "workspace_uuid").Return(db.WorkspaceUsers{OwnerPubKey: "other_pub_key"})
+	mockDb.On("GetWorkspaceUser", "outsider", "workspace_uuid").Return(db.WorkspaceUsers{})
+
+	t.Run("should lock the brief and broadcast the lock", func(t *testing.T) {
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.LockFeatureBrief).ServeHTTP(rr, newRequest("pub_key"))
+
+		var lock db.FeatureBriefLock
+		json.Unmarshal(rr.Body.Bytes(), &lock)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "pub_key", lock.Holder)
+		assert.Len(t, messages, 1)
+		assert.Equal(t, "feature_brief_lock", messages[0].Msg)
+	})
+
+	t.Run("should return 403 to someone outside of the workspace", func(t *testing.T) {
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid"}).Twice()
+
+		for _, handler := range []http.HandlerFunc{fHandler.LockFeatureBrief, fHandler.GetFeatureBriefLock} {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newRequest("outsider"))
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+		}
+		assert.Len(t, messages, 1)
+	})
+
+	t.Run("should show the lock to a member of the workspace", func(t *testing.T) {
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.GetFeatureBriefLock).ServeHTTP(rr, newRequest("other_pub_key"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return 409 if another user holds the lock", func(t *testing.T) {
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.LockFeatureBrief).ServeHTTP(rr, newRequest("other_pub_key"))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should not let another user unlock the brief", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.UnlockFeatureBrief).ServeHTTP(rr, newRequest("other_pub_key"))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should unlock the brief for the holder", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.UnlockFeatureBrief).ServeHTTP(rr, newRequest("pub_key"))
+
+		_, err := db.Store.GetFeatureBriefLock("feature_uuid")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Error(t, err)
+		assert.Len(t, messages, 2)
+	})
+}
+
+func TestMergeFeatureBrief(t *testing.T) {
+	feature := db.WorkspaceFeatures{Brief: "existing brief"}
+
+	t.Run("should append by default", func(t *testing.T) {
+		brief, _, err := mergeFeatureBrief(feature, db.FeatureBriefRequest{Brief: "more"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "existing brief\n\nmore", brief)
+	})
+
+	t.Run("should replace the brief", func(t *testing.T) {
+		brief, sections, err := mergeFeatureBrief(feature, db.FeatureBriefRequest{Brief: "new", Mode: db.BriefReplaceMode})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new", brief)
+		assert.Empty(t, sections)
+	})
+
+	t.Run("should only update its own section", func(t *testing.T) {
+		brief, sections, err := mergeFeatureBrief(feature, db.FeatureBriefRequest{Brief: "first", Mode: db.BriefSectionMergeMode, Section: "Summary"})
+		assert.NoError(t, err)
+		assert.Equal(t, "existing brief\n\n## Summary\n\nfirst", brief)
+
+		feature := db.WorkspaceFeatures{Brief: brief, BriefSections: sections}
+		brief, sections, err = mergeFeatureBrief(feature, db.FeatureBriefRequest{Brief: "second", Mode: db.BriefSectionMergeMode, Section: "Summary"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "existing brief\n\n## Summary\n\nsecond", brief)
+		assert.Len(t, sections, 2)
+	})
+
+	t.Run("should require a section and a valid mode", func(t *testing.T) {
+		_, _, err := mergeFeatureBrief(feature, db.FeatureBriefRequest{Brief: "text", Mode: db.BriefSectionMergeMode})
+		assert.Error(t, err)
+
+		_, _, err = mergeFeatureBrief(feature, db.FeatureBriefRequest{Brief: "text", Mode: "prepend"})
+		assert.Error(t, err)
+	})
+}
+
+func newFeatureCallRequest(method string, callUuid string, body string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("feature_uuid", "feature_uuid")
+	rctx.URLParams.Add("call_uuid", callUuid)
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "pub_key")
+	req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), method, "/feature_uuid/calls/"+callUuid, strings.NewReader(body))
+	return req
+}
+
+func TestValidateFeatureCallUrl(t *testing.T) {
+	tests := []struct {
+		provider db.FeatureCallProvider
+		url      string
+		valid    bool
+	}{
+		{db.FeatureCallJitsi, "https://meet.jit.si/sphinx-standup", true},
+		{db.FeatureCallJitsi, "https://jitsi.example.com/tenant/room_1", true},
+		{db.FeatureCallJitsi, "https://meet.jit.si/", false},
+		{db.FeatureCallJitsi, "http://meet.jit.si/room", false},
+		{db.FeatureCallMeet, "https://meet.google.com/abc-defg-hij", true},
+		{db.FeatureCallMeet, "https://meet.google.com/abc", false},
+		{db.FeatureCallMeet, "https://meet.example.com/abc-defg-hij", false},
+		{db.FeatureCallZoom, "https://us02web.zoom.us/j/123456789?pwd=abc", true},
+		{db.FeatureCallZoom, "https://zoom.us/my/sphinx", true},
+		{db.FeatureCallZoom, "https://zoom.us.example.com/j/123", false},
+		{"skype", "https://join.skype.com/abc", false},
+	}
+
+	for _, tt := range tests {
+		err := validateFeatureCallUrl(tt.provider, tt.url)
+		assert.Equal(t, tt.valid, err == nil, "%s %s", tt.provider, tt.url)
+	}
+}
+
+func TestValidRecurrence(t *testing.T) {
+	assert.True(t, validRecurrence("FREQ=WEEKLY;BYDAY=MO,WE"))
+	assert.True(t, validRecurrence("RRULE:FREQ=DAILY;INTERVAL=2;COUNT=10"))
+	assert.True(t, validRecurrence("FREQ=MONTHLY;UNTIL=20270101T000000Z"))
+	assert.False(t, validRecurrence("BYDAY=MO"))
+	assert.False(t, validRecurrence("FREQ=HOURLY"))
+	assert.False(t, validRecurrence("FREQ=WEEKLY;BYDAY=XX"))
+	assert.False(t, validRecurrence("FREQ=WEEKLY;INTERVAL=0"))
+	assert.False(t, validRecurrence("FREQ=WEEKLY;BYSECOND=1"))
+}
+
+func TestCreateFeatureCall(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	fHandler := NewFeatureHandler(mockDb)
+	mockDb.On("GetFeatureByUuid", "feature_uuid").Return(db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid"})
+
+	t.Run("should reject a url that does not match the provider", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.CreateFeatureCall).ServeHTTP(rr, newFeatureCallRequest(http.MethodPost, "", `{"provider": "meet", "url": "https://zoom.us/j/123"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should require a schedule for a recurring call", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.CreateFeatureCall).ServeHTTP(rr, newFeatureCallRequest(http.MethodPost, "", `{"provider": "jitsi", "url": "https://meet.jit.si/room", "recurrence": "FREQ=WEEKLY"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should add a new call to the feature", func(t *testing.T) {
+		mockDb.On("CreateFeatureCall", mock.MatchedBy(func(c db.FeatureCall) bool {
+			return c.FeatureUuid == "feature_uuid" && c.WorkspaceUuid == "workspace_uuid" &&
+				c.Provider == db.FeatureCallJitsi && c.Recurrence == "FREQ=WEEKLY;BYDAY=MO" && c.CreatedBy == "pub_key"
+		})).Return(db.FeatureCall{Uuid: "call_uuid", FeatureUuid: "feature_uuid"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"provider": "jitsi", "url": "https://meet.jit.si/room", "scheduled_at": "2026-11-02T15:00:00Z", "duration_minutes": 30, "recurrence": "FREQ=WEEKLY;BYDAY=MO"}`
+		http.HandlerFunc(fHandler.CreateFeatureCall).ServeHTTP(rr, newFeatureCallRequest(http.MethodPost, "", body))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+}
+
+func TestUpdateFeatureCall(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	fHandler := NewFeatureHandler(mockDb)
+	existing := db.FeatureCall{
+		ID:          1,
+		Uuid:        "call_uuid",
+		FeatureUuid: "feature_uuid",
+		Provider:    db.FeatureCallMeet,
+		Url:         "https://meet.google.com/abc-defg-hij",
+		CreatedBy:   "creator_pubkey",
+	}
+
+	t.Run("should return 404 for an unknown call", func(t *testing.T) {
+		mockDb.On("GetFeatureCallByUuid", "feature_uuid", "unknown").Return(db.FeatureCall{}, db.ErrFeatureCallNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.UpdateFeatureCall).ServeHTTP(rr, newFeatureCallRequest(http.MethodPut, "unknown", `{}`))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should end a call with its recording and keep the other fields", func(t *testing.T) {
+		mockDb.On("GetFeatureCallByUuid", "feature_uuid", "call_uuid").Return(existing, nil).Once()
+		mockDb.On("UpdateFeatureCall", mock.MatchedBy(func(c db.FeatureCall) bool {
+			return c.Uuid == "call_uuid" && c.Url == existing.Url && c.CreatedBy == "creator_pubkey" &&
+				c.UpdatedBy == "pub_key" && c.EndedAt != nil && c.RecordingUrl == "https://recordings.example.com/1.mp4"
+		})).Return(func(c db.FeatureCall) (db.FeatureCall, error) {
+			return c, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"ended_at": "2026-11-02T15:30:00Z", "recording_url": "https://recordings.example.com/1.mp4", "uuid": "other_uuid"}`
+		http.HandlerFunc(fHandler.UpdateFeatureCall).ServeHTTP(rr, newFeatureCallRequest(http.MethodPut, "call_uuid", body))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		call := db.FeatureCall{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &call))
+		assert.Equal(t, "call_uuid", call.Uuid)
+	})
+}
+
+func TestChunkTranscript(t *testing.T) {
+	t.Run("should chunk segments with their timing", func(t *testing.T) {
+		long := strings.Repeat("a", transcriptChunkSize-10)
+		content, chunks := chunkTranscript(db.FeatureCallTranscriptRequest{Segments: []db.TranscriptSegment{
+			{Start: 0, End: 5, Speaker: "Ann", Text: "Hello"},
+			{Start: 5, End: 9, Text: " "},
+			{Start: 9, End: 12, Speaker: "Bob", Text: "Hi"},
+			{Start: 12, End: 60, Text: long},
+		}})
+
+		assert.Equal(t, "Ann: Hello\nBob: Hi\n"+long, content)
+		assert.Len(t, chunks, 2)
+		assert.Equal(t, "Ann: Hello\nBob: Hi", chunks[0].Content)
+		assert.Equal(t, 0.0, *chunks[0].StartSeconds)
+		assert.Equal(t, 12.0, *chunks[0].EndSeconds)
+		assert.Equal(t, 12.0, *chunks[1].StartSeconds)
+	})
+
+	t.Run("should chunk plain content by paragraph", func(t *testing.T) {
+		paragraph := strings.Repeat("word ", transcriptChunkSize/10)
+		content := paragraph + "\n\n" + paragraph + "\n\n" + strings.Repeat("long ", transcriptChunkSize/2)
+		_, chunks := chunkTranscript(db.FeatureCallTranscriptRequest{Content: content})
+
+		// both short paragraphs fit in one chunk, the long one is split in three
+		assert.Len(t, chunks, 4)
+		assert.Equal(t, strings.TrimSpace(paragraph)+"\n\n"+strings.TrimSpace(paragraph), chunks[0].Content)
+		for _, chunk := range chunks {
+			assert.LessOrEqual(t, len(chunk.Content), transcriptChunkSize)
+			assert.Nil(t, chunk.StartSeconds)
+		}
+	})
+}
+
+func TestUploadFeatureCallTranscript(t *testing.T) {
+	db.InitCache()
+	mockDb := dbMocks.NewDatabase(t)
+	fHandler := NewFeatureHandler(mockDb)
+	feature := db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid", Brief: "Existing brief"}
+	call := db.FeatureCall{Uuid: "call_uuid", FeatureUuid: "feature_uuid", Title: "Kickoff"}
+
+	newRequest := func(workspaceUuid string, body string) *http.Request {
+		req := newFeatureCallRequest(http.MethodPost, "call_uuid", body)
+		token := auth.ApiToken{ID: 1, WorkspaceUuid: workspaceUuid, CreatedBy: "pub_key", Scopes: []string{db.ApiScopeUploadTranscripts}}
+		return req.WithContext(context.WithValue(req.Context(), auth.ApiTokenContextKey, token))
+	}
+
+	mockDb.On("GetFeatureByUuid", "feature_uuid").Return(feature)
+
+	t.Run("should reject a token of another workspace", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.UploadFeatureCallTranscript).ServeHTTP(rr, newRequest("other_workspace", `{"content": "text"}`))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should reject an empty transcript", func(t *testing.T) {
+		mockDb.On("GetFeatureCallByUuid", "feature_uuid", "call_uuid").Return(call, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.UploadFeatureCallTranscript).ServeHTTP(rr, newRequest("workspace_uuid", `{"segments": [{"text": " "}]}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should store the transcript and link it in the brief", func(t *testing.T) {
+		created := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		mockDb.On("GetFeatureCallByUuid", "feature_uuid", "call_uuid").Return(call, nil).Twice()
+		mockDb.On("SaveFeatureCallTranscript", mock.MatchedBy(func(tr db.FeatureCallTranscript) bool {
+			return tr.CallUuid == "call_uuid" && tr.Content == "Ann: Hello" && tr.UploadedBy == "pub_key" &&
+				len(tr.ContextTags) == 3 && tr.ContextTags[2] == "call:call_uuid"
+		}), mock.MatchedBy(func(chunks []db.FeatureCallTranscriptChunk) bool {
+			return len(chunks) == 1
+		})).Return(db.FeatureCallTranscript{Uuid: "transcript_uuid", CallUuid: "call_uuid", Created: &created}, nil).Once()
+		mockDb.On("GetFeatureTranscripts", "feature_uuid").Return([]db.FeatureCallTranscript{{CallUuid: "call_uuid", Created: &created}}).Once()
+		mockDb.On("UpdateFeatureBrief", "feature_uuid", mock.MatchedBy(func(brief string) bool {
+			return strings.HasPrefix(brief, "Existing brief") &&
+				strings.Contains(brief, "## Call Transcripts\n\n- Kickoff (2026-10-01): /features/feature_uuid/calls/call_uuid/transcript")
+		}), mock.Anything, "pub_key").Return(feature, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.UploadFeatureCallTranscript).ServeHTTP(rr, newRequest("workspace_uuid", `{"segments": [{"start": 0, "end": 2, "speaker": "Ann", "text": "Hello"}]}`))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		result := db.FeatureCallTranscriptResult{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.True(t, result.BriefLinked)
+		assert.Equal(t, "transcript_uuid", result.Transcript.Uuid)
+	})
+}
+
+func TestPatchFeature(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	fHandler := NewFeatureHandler(mockDb)
+
+	feature := db.WorkspaceFeatures{ID: 1, Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid", Name: "Login", Requirements: "Lightning", Priority: 2}
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+	newRequest := func(pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "feature_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPatch, "/feature_uuid", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should answer 404 for an unknown feature", func(t *testing.T) {
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(db.WorkspaceFeatures{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.PatchFeature).ServeHTTP(rr, newRequest("owner_pubkey", `{"priority": 1}`))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should not patch the brief", func(t *testing.T) {
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(feature).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.PatchFeature).ServeHTTP(rr, newRequest("owner_pubkey", `{"brief": "Locked"}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should keep the fields the patch leaves out", func(t *testing.T) {
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(feature).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(workspace).Once()
+		mockDb.On("PatchFeature", mock.MatchedBy(func(f db.WorkspaceFeatures) bool {
+			return f.Priority == 1 && f.Name == "Login" && f.Requirements == "Lightning" && f.UpdatedBy == "owner_pubkey"
+		}), []string{"priority"}, false).Return(func(f db.WorkspaceFeatures, columns []string, conditional bool) (db.WorkspaceFeatures, error) {
+			return f, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.PatchFeature).ServeHTTP(rr, newRequest("owner_pubkey", `{"priority": 1}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotEmpty(t, rr.Header().Get("ETag"))
+	})
+}
+
+func TestGetFeatureDetail(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	fHandler := NewFeatureHandler(mockDb)
+
+	newRequest := func(pubkey string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "feature_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodGet, "/feature_uuid/full", nil)
+		return req
+	}
+	detail := db.FeatureDetail{
+		WorkspaceFeatures: db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid", Name: "Login", BountiesCountOpen: 3},
+		Phases: []db.FeaturePhaseDetail{
+			{FeaturePhase: db.FeaturePhase{Uuid: "phase_uuid", FeatureUuid: "feature_uuid", Name: "MVP"}, BountiesCountOpen: 3},
+		},
+		Stories: []db.FeatureStory{{Uuid: "story_uuid", FeatureUuid: "feature_uuid", Description: "Sign in"}},
+		Calls:   []db.FeatureCall{{Uuid: "call_uuid", FeatureUuid: "feature_uuid", Provider: db.FeatureCallJitsi}},
+	}
+
+	t.Run("should answer 404 for an unknown feature", func(t *testing.T) {
+		mockDb.On("GetFeatureDetail", "feature_uuid").Return(db.FeatureDetail{}, db.ErrFeatureNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.GetFeatureDetail).ServeHTTP(rr, newRequest("owner_pubkey"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should only show the feature to workspace members", func(t *testing.T) {
+		mockDb.On("GetFeatureDetail", "feature_uuid").Return(detail, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}).Once()
+		mockDb.On("GetWorkspaceUser", "stranger_pubkey", "workspace_uuid").Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.GetFeatureDetail).ServeHTTP(rr, newRequest("stranger_pubkey"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should return the feature with its phases, stories and calls", func(t *testing.T) {
+		mockDb.On("GetFeatureDetail", "feature_uuid").Return(detail, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.GetFeatureDetail).ServeHTTP(rr, newRequest("owner_pubkey"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		body := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, "Login", body["name"])
+		assert.Equal(t, float64(3), body["bounties_count_open"])
+		phases := body["phases"].([]interface{})
+		assert.Equal(t, float64(3), phases[0].(map[string]interface{})["bounties_count_open"])
+		assert.Len(t, body["stories"], 1)
+		assert.Len(t, body["calls"], 1)
+	})
+}
+
+func TestCreateOrEditStory(t *testing.T) {
+	deliveryId := "delivery_1"
+	newStoryRequest := func(body string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "pub_key")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/features/story", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should answer the stored story when a delivery is replayed", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		fHandler := NewFeatureHandler(mockDb)
+		delivered := db.FeatureStory{Uuid: "story_uuid", FeatureUuid: "feature_uuid", Description: "As a user", DeliveryId: &deliveryId}
+		mockDb.On("GetFeatureStoryByUuid", "feature_uuid", mock.Anything).Return(db.FeatureStory{}, errors.New("no story found")).Once()
+		mockDb.On("GetFeatureStoryByDeliveryId", "feature_uuid", deliveryId).Return(delivered, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.CreateOrEditStory).ServeHTTP(rr, newStoryRequest(`{"feature_uuid": "feature_uuid", "description": "As a user", "delivery_id": "delivery_1"}`))
+
+		story := db.FeatureStory{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &story))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, delivered, story)
+		mockDb.AssertNotCalled(t, "CreateOrEditFeatureStory", mock.Anything)
+	})
+
+	t.Run("should create the story of a first delivery with its id", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		fHandler := NewFeatureHandler(mockDb)
+		mockDb.On("GetFeatureStoryByUuid", "feature_uuid", mock.Anything).Return(db.FeatureStory{}, errors.New("no story found")).Once()
+		mockDb.On("GetFeatureStoryByDeliveryId", "feature_uuid", deliveryId).Return(db.FeatureStory{}, errors.New("no story found")).Once()
+		mockDb.On("CreateOrEditFeatureStory", mock.MatchedBy(func(s db.FeatureStory) bool {
+			return s.DeliveryId != nil && *s.DeliveryId == deliveryId && s.CreatedBy == "pub_key"
+		})).Return(func(s db.FeatureStory) (db.FeatureStory, error) { return s, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.CreateOrEditStory).ServeHTTP(rr, newStoryRequest(`{"feature_uuid": "feature_uuid", "description": "As a user", "delivery_id": "delivery_1"}`))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("should not keep a blank delivery id", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		fHandler := NewFeatureHandler(mockDb)
+		mockDb.On("GetFeatureStoryByUuid", "feature_uuid", mock.Anything).Return(db.FeatureStory{}, errors.New("no story found")).Once()
+		mockDb.On("CreateOrEditFeatureStory", mock.MatchedBy(func(s db.FeatureStory) bool {
+			return s.DeliveryId == nil
+		})).Return(func(s db.FeatureStory) (db.FeatureStory, error) { return s, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.CreateOrEditStory).ServeHTTP(rr, newStoryRequest(`{"feature_uuid": "feature_uuid", "description": "As a user", "delivery_id": " "}`))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("should create the story of a delivery id used in another feature", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		fHandler := NewFeatureHandler(mockDb)
+		mockDb.On("GetFeatureStoryByUuid", "other_feature_uuid", mock.Anything).Return(db.FeatureStory{}, errors.New("no story found")).Once()
+		mockDb.On("GetFeatureStoryByDeliveryId", "other_feature_uuid", deliveryId).Return(db.FeatureStory{}, errors.New("no story found")).Once()
+		mockDb.On("CreateOrEditFeatureStory", mock.MatchedBy(func(s db.FeatureStory) bool {
+			return s.FeatureUuid == "other_feature_uuid" && s.DeliveryId != nil && *s.DeliveryId == deliveryId
+		})).Return(func(s db.FeatureStory) (db.FeatureStory, error) { return s, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.CreateOrEditStory).ServeHTTP(rr, newStoryRequest(`{"feature_uuid": "other_feature_uuid", "description": "As a user", "delivery_id": "delivery_1"}`))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("should edit a story sent with a delivery id that was used before", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		fHandler := NewFeatureHandler(mockDb)
+		stored := db.FeatureStory{ID: 1, Uuid: "story_uuid", FeatureUuid: "feature_uuid", Description: "As a user", CreatedBy: "creator", DeliveryId: &deliveryId}
+		mockDb.On("GetFeatureStoryByUuid", "feature_uuid", "story_uuid").Return(stored, nil).Once()
+		mockDb.On("CreateOrEditFeatureStory", mock.MatchedBy(func(s db.FeatureStory) bool {
+			return s.Uuid == "story_uuid" && s.Description == "As an admin" && s.DeliveryId == nil && s.CreatedBy == ""
+		})).Return(func(s db.FeatureStory) (db.FeatureStory, error) { return s, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(fHandler.CreateOrEditStory).ServeHTTP(rr, newStoryRequest(`{"uuid": "story_uuid", "feature_uuid": "feature_uuid", "description": "As an admin", "delivery_id": "delivery_1"}`))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockDb.AssertNotCalled(t, "GetFeatureStoryByDeliveryId", mock.Anything, mock.Anything)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/feeds"
 	"google.golang.org/api/option"
@@ -92,7 +93,7 @@ func DownloadYoutubeFeed(w http.ResponseWriter, r *http.Request) {
 }
 
 func processYoutubeDownload(data []string) {
-	stakworkKey := fmt.Sprintf("Token token=%s", os.Getenv("STAKWORK_KEY"))
+	stakworkKey := fmt.Sprintf("Token token=%s", config.GetSecret("STAKWORK_KEY"))
 	if stakworkKey == "" {
 		fmt.Println("[feed] Youtube Download Error: Stakwork key not found")
 	} else {
@@ -122,7 +123,7 @@ func processYoutubeDownload(data []string) {
 
 		body := map[string]interface{}{
 			"name":            "Sphinx Youtube Content Storage",
-			"workflow_id":     "11848",
+			"workflow_id":     config.YoutubeWorkflowId,
 			"workflow_params": workflows,
 		}
 
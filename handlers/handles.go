@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"gorm.io/gorm"
+)
+
+// handleErrorStatus maps the errors of claiming a handle to a response status
+func handleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, db.ErrHandleInvalid), errors.Is(err, db.ErrHandleNotAllowed):
+		return http.StatusBadRequest
+	case errors.Is(err, db.ErrHandleReserved), errors.Is(err, db.ErrHandleTaken), errors.Is(err, db.ErrHandleSquatting):
+		return http.StatusConflict
+	case errors.Is(err, db.ErrHandleCooldown):
+		return http.StatusTooManyRequests
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func (ph *peopleHandler) GetPersonByHandle(w http.ResponseWriter, r *http.Request) {
+	handle := chi.URLParam(r, "handle")
+
+	person := ph.db.GetPersonByHandle(handle)
+	if person.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Person not found")
+		return
+	}
+
+	activeBounties := ph.db.GetActiveAssignedBountiesCount(person.OwnerPubKey)
+	person.Availability = db.EffectiveAvailability(person, activeBounties, time.Now())
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(person)
+}
+
+// SetMyHandle claims a handle for the signed in person
+func (ph *peopleHandler) SetMyHandle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[people] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.HandleRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[people]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if db.NormalizeHandle(request.Handle) == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(db.ErrHandleInvalid.Error())
+		return
+	}
+
+	ph.setHandle(w, pubKeyFromAuth, request.Handle)
+}
+
+// ClearMyHandle drops the handle of the signed in person,
+// it stays reserved for them for a while
+func (ph *peopleHandler) ClearMyHandle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[people] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	ph.setHandle(w, pubKeyFromAuth, "")
+}
+
+func (ph *peopleHandler) setHandle(w http.ResponseWriter, pubkey string, handle string) {
+	person, err := ph.db.SetPersonHandle(pubkey, handle, time.Now())
+	if err != nil {
+		status := handleErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			fmt.Println("[people] could not set handle", err)
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode("Could not set the handle")
+			return
+		}
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(person)
+}
+
+func (ph *peopleHandler) GetHandleReservations(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ph.db.GetHandleReservations())
+}
+
+// CreateHandleReservation lets an admin hold a handle, for nobody or for a given pubkey
+func (ph *peopleHandler) CreateHandleReservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	reservation := db.HandleReservation{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &reservation); err != nil {
+		fmt.Println("[people]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	if reservation.Expires != nil && !reservation.Expires.After(time.Now()) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Expiry must be in the future")
+		return
+	}
+
+	reservation.ID = 0
+	reservation.CreatedBy = pubKeyFromAuth
+	reservation.Created = nil
+	reservation, err := ph.db.CreateHandleReservation(reservation)
+	if errors.Is(err, db.ErrHandleInvalid) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[people] could not reserve handle", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not reserve the handle")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reservation)
+}
+
+func (ph *peopleHandler) DeleteHandleReservation(w http.ResponseWriter, r *http.Request) {
+	handle := chi.URLParam(r, "handle")
+
+	err := ph.db.DeleteHandleReservation(handle)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Reservation not found")
+		return
+	}
+	if err != nil {
+		fmt.Println("[people] could not delete handle reservation", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete the reservation")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Reservation deleted")
+}
+
+// GetAliasCollisions reports the people sharing an alias with the handles
+// suggested to tell them apart
+func (ph *peopleHandler) GetAliasCollisions(w http.ResponseWriter, r *http.Request) {
+	collisions, err := ph.db.GetAliasCollisions()
+	if err != nil {
+		fmt.Println("[people] could not get alias collisions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not get the alias collisions")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(collisions)
+}
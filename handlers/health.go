@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/relay"
+)
+
+const readinessTimeout = 2 * time.Second
+
+type healthHandler struct {
+	db          db.Database
+	relayHealth func() relay.Health
+}
+
+func NewHealthHandler(database db.Database) *healthHandler {
+	return &healthHandler{db: database, relayHealth: relay.Status}
+}
+
+type databaseHealth struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type readiness struct {
+	Status   string         `json:"status"`
+	Database databaseHealth `json:"database"`
+	Relay    relay.Health   `json:"relay"`
+}
+
+// Readyz reports whether the server can take traffic. Only the database is
+// required, while the relay is unavailable the server is ready but degraded
+// and the calls that need the relay fail fast
+func (hh *healthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	report := readiness{
+		Status:   "ok",
+		Database: databaseHealth{Status: "ok"},
+		Relay:    hh.relayHealth(),
+	}
+	if report.Relay.State != relay.StateClosed {
+		report.Status = "degraded"
+	}
+
+	status := http.StatusOK
+	if err := hh.db.Ping(ctx); err != nil {
+		fmt.Println("[health] database is unreachable", err)
+		report.Status = "unavailable"
+		report.Database = databaseHealth{Status: "unavailable", Error: err.Error()}
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/relay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReadyz(t *testing.T) {
+	serve := func(hh *healthHandler) (*httptest.ResponseRecorder, readiness) {
+		req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hh.Readyz).ServeHTTP(rr, req)
+		report := readiness{}
+		json.Unmarshal(rr.Body.Bytes(), &report)
+		return rr, report
+	}
+
+	t.Run("should be ready when the database and relay are up", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hh := NewHealthHandler(mockDb)
+		hh.relayHealth = func() relay.Health { return relay.Health{State: relay.StateClosed} }
+		mockDb.On("Ping", mock.Anything).Return(nil).Once()
+
+		rr, report := serve(hh)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "ok", report.Status)
+	})
+
+	t.Run("should stay ready but degraded while the relay is down", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hh := NewHealthHandler(mockDb)
+		hh.relayHealth = func() relay.Health {
+			return relay.Health{State: relay.StateOpen, ConsecutiveFailures: 5, LastError: "connection refused"}
+		}
+		mockDb.On("Ping", mock.Anything).Return(nil).Once()
+
+		rr, report := serve(hh)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "degraded", report.Status)
+		assert.Equal(t, relay.StateOpen, report.Relay.State)
+		assert.Equal(t, "connection refused", report.Relay.LastError)
+	})
+
+	t.Run("should not be ready without the database", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hh := NewHealthHandler(mockDb)
+		hh.relayHealth = func() relay.Health { return relay.Health{State: relay.StateClosed} }
+		mockDb.On("Ping", mock.Anything).Return(errors.New("connection refused")).Once()
+
+		rr, report := serve(hh)
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, "unavailable", report.Status)
+		assert.Equal(t, "connection refused", report.Database.Error)
+	})
+}
@@ -0,0 +1,475 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+// chatPollTimeout is how long a poll for chat messages is held when there is
+// nothing new, below the timeout of the router
+const chatPollTimeout = 25 * time.Second
+
+// defaultContextSearchResults and maxContextSearchResults bound the top_k of a context search
+const (
+	defaultContextSearchResults = 5
+	maxContextSearchResults     = 50
+)
+
+var errEmbeddingsNotConfigured = errors.New("embedding service is not configured")
+
+type hivechatHandler struct {
+	db                   db.Database
+	httpClient           HttpClient
+	embeddingService     EmbeddingService
+	broadcastChatWorking func(chatId string, working bool, step string) bool
+	publishChatMessage   func(message db.ChatMessage)
+	subscribeChat        func(chatId string) (<-chan struct{}, func())
+	chatPollTimeout      time.Duration
+
+	// agentClient posts to the agent webhooks, it only dials public addresses
+	agentClient    HttpClient
+	checkAgentHost func(host string) error
+}
+
+func NewHivechatHandler(httpClient HttpClient, database db.Database) *hivechatHandler {
+	return &hivechatHandler{
+		db:                   database,
+		httpClient:           httpClient,
+		embeddingService:     NewEmbeddingService(httpClient),
+		broadcastChatWorking: websocket.BroadcastChatWorking,
+		publishChatMessage:   websocket.ChatMessages.Publish,
+		subscribeChat:        websocket.ChatMessages.Subscribe,
+		chatPollTimeout:      chatPollTimeout,
+		agentClient:          utils.NewPublicHttpClient(chatAgentTimeout),
+		checkAgentHost:       utils.CheckPublicHost,
+	}
+}
+
+// isWorkspaceMember tells if pubkey owns the workspace or was added to it
+func isWorkspaceMember(database db.Database, pubkey string, workspaceUuid string) bool {
+	workspace := database.GetWorkspaceByUuid(workspaceUuid)
+	if workspace.Uuid == "" {
+		return false
+	}
+	return workspace.OwnerPubKey == pubkey || database.GetWorkspaceUser(pubkey, workspaceUuid).OwnerPubKey != ""
+}
+
+func (hh *hivechatHandler) isWorkspaceMember(pubkey string, workspaceUuid string) bool {
+	return isWorkspaceMember(hh.db, pubkey, workspaceUuid)
+}
+
+// IngestEmbeddings chunks and embeds a brief, ticket or chat history of a
+// workspace, replacing what was ingested before for the same source
+func (hh *hivechatHandler) IngestEmbeddings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[hivechat] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.EmbeddingIngestRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	r.Body.Close()
+
+	switch request.SourceType {
+	case db.EmbeddingSourceBrief, db.EmbeddingSourceTicket, db.EmbeddingSourceChat:
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "source_type must be brief, ticket or chat"})
+		return
+	}
+	request.SourceId = strings.TrimSpace(request.SourceId)
+	if request.SourceId == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "source_id is required"})
+		return
+	}
+
+	if !hh.isWorkspaceMember(pubKeyFromAuth, request.WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to this workspace")
+		return
+	}
+
+	chunker := transcriptChunker{}
+	chunker.addParagraphs(strings.TrimSpace(request.Content))
+	texts := make([]string, len(chunker.chunks))
+	for i, chunk := range chunker.chunks {
+		texts[i] = chunk.Content
+	}
+
+	chunks := []db.WorkspaceEmbedding{}
+	if len(texts) > 0 {
+		embeddings, err := hh.embeddingService.Embed(texts)
+		if err != nil {
+			hh.writeEmbeddingError(w, err)
+			return
+		}
+		RecordWorkspaceUsage(request.WorkspaceUuid, db.UsageAiCall)
+		for i, text := range texts {
+			chunks = append(chunks, db.WorkspaceEmbedding{Content: text, Embedding: embeddings[i]})
+		}
+	}
+
+	if err := hh.db.SaveWorkspaceEmbeddings(request.WorkspaceUuid, request.SourceType, request.SourceId, chunks); err != nil {
+		fmt.Println("[hivechat] could not save embeddings", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]int{"chunks": len(chunks)})
+}
+
+// ContextSearch returns the top_k workspace chunks most relevant to a query,
+// to ground the answers of the assistant
+func (hh *hivechatHandler) ContextSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[hivechat] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.ContextSearchRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	r.Body.Close()
+
+	request.Query = strings.TrimSpace(request.Query)
+	if request.Query == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "query is required"})
+		return
+	}
+	if request.TopK == 0 {
+		request.TopK = defaultContextSearchResults
+	}
+	if request.TopK < 0 || request.TopK > maxContextSearchResults {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("top_k must be between 1 and %d", maxContextSearchResults)})
+		return
+	}
+
+	if !hh.isWorkspaceMember(pubKeyFromAuth, request.WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to this workspace")
+		return
+	}
+
+	embeddings, err := hh.embeddingService.Embed([]string{request.Query})
+	if err != nil {
+		hh.writeEmbeddingError(w, err)
+		return
+	}
+	RecordWorkspaceUsage(request.WorkspaceUuid, db.UsageAiCall)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(hh.db.SearchWorkspaceEmbeddings(request.WorkspaceUuid, embeddings[0], request.TopK))
+}
+
+func (hh *hivechatHandler) writeEmbeddingError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errEmbeddingsNotConfigured) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode("Embedding service is not configured")
+		return
+	}
+	fmt.Println("[hivechat] could not embed", err)
+	w.WriteHeader(http.StatusBadGateway)
+	json.NewEncoder(w).Encode("Could not reach the embedding service")
+}
+
+// getChat returns the chat of the chat_id url param when pubkey belongs to its workspace
+func (hh *hivechatHandler) getChat(w http.ResponseWriter, r *http.Request, pubkey string) (db.Chat, bool) {
+	chat, err := hh.db.GetChatByUuid(chi.URLParam(r, "chat_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Chat not found")
+		return chat, false
+	}
+	if apiToken, ok := auth.ApiTokenFromContext(r.Context()); ok {
+		if apiToken.WorkspaceUuid != chat.WorkspaceUuid {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode("Api token is not valid for this chat")
+			return chat, false
+		}
+		return chat, true
+	}
+	if !hh.isWorkspaceMember(pubkey, chat.WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to this chat")
+		return chat, false
+	}
+	return chat, true
+}
+
+func (hh *hivechatHandler) CreateChat(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[hivechat] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.ChatRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	r.Body.Close()
+
+	if !hh.isWorkspaceMember(pubKeyFromAuth, request.WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to this workspace")
+		return
+	}
+
+	chat, err := hh.db.CreateChat(db.Chat{
+		WorkspaceUuid: request.WorkspaceUuid,
+		Title:         strings.TrimSpace(request.Title),
+		CreatedBy:     pubKeyFromAuth,
+	})
+	if err != nil {
+		fmt.Println("[hivechat] could not create chat", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(chat)
+}
+
+func (hh *hivechatHandler) GetChatMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[hivechat] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	chat, ok := hh.getChat(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(hh.db.GetChatMessages(chat.Uuid))
+}
+
+// CreateChatMessage adds a message to a chat, messages posted with an api token
+// are the answers of the assistant
+func (hh *hivechatHandler) CreateChatMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[hivechat] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	chat, ok := hh.getChat(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	request := db.ChatMessageRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	r.Body.Close()
+
+	if err := validateChatMessage(request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	for i := range request.Artifacts {
+		request.Artifacts[i].WorkspaceUuid = chat.WorkspaceUuid
+	}
+
+	role := db.ChatRoleUser
+	if _, ok := auth.ApiTokenFromContext(ctx); ok {
+		role = db.ChatRoleAssistant
+	}
+
+	message, err := hh.db.CreateChatMessage(db.ChatMessage{
+		ChatId:       chat.Uuid,
+		Role:         role,
+		SenderPubkey: pubKeyFromAuth,
+		Content:      request.Content,
+		Artifacts:    request.Artifacts,
+	})
+	if err != nil {
+		fmt.Println("[hivechat] could not create message", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	hh.publishChatMessage(message)
+	if role == db.ChatRoleAssistant {
+		hh.broadcastChatWorking(chat.Uuid, false, "")
+	} else if webhook, err := hh.db.GetChatAgentWebhook(chat.Uuid); err == nil {
+		go hh.DeliverChatMessage(webhook, message)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(message)
+}
+
+// SetChatWorking lets an agent show that it is working on a chat, the
+// indicator goes to the websocket connections following the chat and is
+// cleared when the agent posts its message
+func (hh *hivechatHandler) SetChatWorking(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[hivechat] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	chat, ok := hh.getChat(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	request := db.ChatWorkingRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+	r.Body.Close()
+
+	sent := hh.broadcastChatWorking(chat.Uuid, request.Working, strings.TrimSpace(request.Step))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]bool{"sent": sent})
+}
+
+// IsChatMember lets the websocket check that a pubkey may follow a chat
+func IsChatMember(pubkey string, chatId string) bool {
+	chat, err := db.DB.GetChatByUuid(chatId)
+	if err != nil {
+		return false
+	}
+	return isWorkspaceMember(db.DB, pubkey, chat.WorkspaceUuid)
+}
+
+// PollChatMessages answers with the messages of a chat after the id in since,
+// for clients that can't keep a websocket open. When there are none yet the
+// request is held until one is posted or the poll times out with an empty list
+func (hh *hivechatHandler) PollChatMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[hivechat] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	chat, ok := hh.getChat(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+
+	since := uint64(0)
+	if param := r.URL.Query().Get("since"); param != "" {
+		var err error
+		since, err = strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "since must be a message id"})
+			return
+		}
+	}
+
+	// subscribe before reading so a message posted in between still wakes the poll
+	posted, unsubscribe := hh.subscribeChat(chat.Uuid)
+	defer unsubscribe()
+
+	messages := hh.db.GetChatMessagesSince(chat.Uuid, uint(since))
+	if len(messages) == 0 {
+		timeout := time.NewTimer(hh.chatPollTimeout)
+		defer timeout.Stop()
+
+		select {
+		case <-posted:
+			messages = hh.db.GetChatMessagesSince(chat.Uuid, uint(since))
+		case <-timeout.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(messages)
+}
+
+// validateChatMessage checks that a message has something in it and that its
+// artifacts have a known type
+func validateChatMessage(request db.ChatMessageRequest) error {
+	if strings.TrimSpace(request.Content) == "" && len(request.Artifacts) == 0 {
+		return errors.New("a message needs content or artifacts")
+	}
+	for _, artifact := range request.Artifacts {
+		if !db.IsArtifactType(artifact.Type) {
+			return fmt.Errorf("unknown artifact type %q", artifact.Type)
+		}
+	}
+	return nil
+}
+
+// ExportChat renders a chat as Markdown, or as HTML for export.html, for handing
+// the conversation over as documentation
+func (hh *hivechatHandler) ExportChat(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[hivechat] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	chat, ok := hh.getChat(w, r, pubKeyFromAuth)
+	if !ok {
+		return
+	}
+	messages := hh.db.GetChatMessages(chat.Uuid)
+	filename := fmt.Sprintf("chat-%s", chat.Uuid)
+
+	if strings.HasSuffix(r.URL.Path, ".html") {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".html"))
+		w.WriteHeader(http.StatusOK)
+		if err := renderChatHTML(w, chat, messages); err != nil {
+			fmt.Println("[hivechat] could not render chat", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".md"))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(renderChatMarkdown(chat, messages)))
+}
@@ -0,0 +1,608 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIngestEmbeddings(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+
+	newHandler := func(t *testing.T) (*hivechatHandler, *dbMocks.Database, *mocks.EmbeddingService) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockEmbeddings := mocks.NewEmbeddingService(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		hHandler.embeddingService = mockEmbeddings
+		return hHandler, mockDb, mockEmbeddings
+	}
+	newRequest := func(pubkey string, body string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/hivechat/embeddings", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should reject unknown source types", func(t *testing.T) {
+		hHandler, _, _ := newHandler(t)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.IngestEmbeddings).ServeHTTP(rr, newRequest("owner_pubkey", `{"workspace_uuid": "workspace_uuid", "source_type": "email", "source_id": "1", "content": "text"}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should only let workspace members ingest", func(t *testing.T) {
+		hHandler, mockDb, _ := newHandler(t)
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceUser", "stranger_pubkey", workspace.Uuid).Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.IngestEmbeddings).ServeHTTP(rr, newRequest("stranger_pubkey", `{"workspace_uuid": "workspace_uuid", "source_type": "brief", "source_id": "feature_1", "content": "text"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should chunk, embed and save the content", func(t *testing.T) {
+		hHandler, mockDb, mockEmbeddings := newHandler(t)
+		long := strings.Repeat("word ", transcriptChunkSize/5+10)
+		content := "First paragraph\n\n" + long
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockEmbeddings.On("Embed", mock.MatchedBy(func(texts []string) bool {
+			return len(texts) == 3 && texts[0] == "First paragraph"
+		})).Return([]db.Vector{{1, 0}, {0, 1}, {1, 1}}, nil).Once()
+		mockDb.On("SaveWorkspaceEmbeddings", workspace.Uuid, db.EmbeddingSourceBrief, "feature_1", mock.MatchedBy(func(chunks []db.WorkspaceEmbedding) bool {
+			return len(chunks) == 3 && chunks[0].Content == "First paragraph" && chunks[2].Embedding[1] == 1
+		})).Return(nil).Once()
+
+		body, _ := json.Marshal(db.EmbeddingIngestRequest{WorkspaceUuid: workspace.Uuid, SourceType: db.EmbeddingSourceBrief, SourceId: "feature_1", Content: content})
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.IngestEmbeddings).ServeHTTP(rr, newRequest("owner_pubkey", string(body)))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"chunks": 3}`, rr.Body.String())
+	})
+
+	t.Run("should return 503 without an embedding service", func(t *testing.T) {
+		hHandler, mockDb, mockEmbeddings := newHandler(t)
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockEmbeddings.On("Embed", []string{"chat line"}).Return(nil, errEmbeddingsNotConfigured).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.IngestEmbeddings).ServeHTTP(rr, newRequest("owner_pubkey", `{"workspace_uuid": "workspace_uuid", "source_type": "chat", "source_id": "chat_1", "content": "chat line"}`))
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	})
+}
+
+func TestContextSearch(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+	mockDb := dbMocks.NewDatabase(t)
+	mockEmbeddings := mocks.NewEmbeddingService(t)
+	hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+	hHandler.embeddingService = mockEmbeddings
+
+	newRequest := func(body string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "member_pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/hivechat/context-search", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should validate the query and top_k", func(t *testing.T) {
+		for _, body := range []string{`{"workspace_uuid": "workspace_uuid", "query": " "}`, `{"workspace_uuid": "workspace_uuid", "query": "login", "top_k": 500}`} {
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(hHandler.ContextSearch).ServeHTTP(rr, newRequest(body))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+	})
+
+	t.Run("should return the closest chunks", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceUser", "member_pubkey", workspace.Uuid).Return(db.WorkspaceUsers{OwnerPubKey: "member_pubkey"}).Once()
+		mockEmbeddings.On("Embed", []string{"how does login work"}).Return([]db.Vector{{0.5, 0.5}}, nil).Once()
+		mockDb.On("SearchWorkspaceEmbeddings", workspace.Uuid, db.Vector{0.5, 0.5}, defaultContextSearchResults).Return([]db.ContextSearchResult{
+			{WorkspaceEmbedding: db.WorkspaceEmbedding{SourceType: db.EmbeddingSourceTicket, SourceId: "12", Content: "Login uses LNURL-auth"}, Score: 0.91},
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.ContextSearch).ServeHTTP(rr, newRequest(`{"workspace_uuid": "workspace_uuid", "query": "how does login work"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		results := []db.ContextSearchResult{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &results))
+		assert.Len(t, results, 1)
+		assert.Equal(t, "Login uses LNURL-auth", results[0].Content)
+		assert.Equal(t, 0.91, results[0].Score)
+	})
+
+	t.Run("should return 502 when the embedding service fails", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(db.Workspace{Uuid: workspace.Uuid, OwnerPubKey: "member_pubkey"}).Once()
+		mockEmbeddings.On("Embed", []string{"login"}).Return(nil, errors.New("timeout")).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.ContextSearch).ServeHTTP(rr, newRequest(`{"workspace_uuid": "workspace_uuid", "query": "login", "top_k": 3}`))
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
+	})
+}
+
+func TestEmbeddingService(t *testing.T) {
+	config.EmbeddingServiceUrl = "https://embeddings.test/v1/embeddings"
+	config.EmbeddingServiceKey = "embedding_key"
+	defer func() {
+		config.EmbeddingServiceUrl = ""
+		config.EmbeddingServiceKey = ""
+	}()
+
+	mockHttpClient := mocks.NewHttpClient(t)
+	mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.URL.String() == config.EmbeddingServiceUrl && req.Header.Get("Authorization") == "Bearer embedding_key"
+	})).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"data": [{"index": 1, "embedding": [0, 1]}, {"index": 0, "embedding": [1, 0]}]}`)),
+	}, nil).Once()
+
+	embeddings, err := NewEmbeddingService(mockHttpClient).Embed([]string{"first", "second"})
+	assert.NoError(t, err)
+	assert.Equal(t, []db.Vector{{1, 0}, {0, 1}}, embeddings)
+}
+
+func TestChatMessages(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+	chat := db.Chat{Uuid: "chat_uuid", WorkspaceUuid: workspace.Uuid, Title: "Onboarding"}
+
+	newRequest := func(ctx context.Context, method string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("chat_id", chat.Uuid)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		req, _ := http.NewRequestWithContext(ctx, method, "/hivechat/"+chat.Uuid+"/messages", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should only let workspace members read a chat", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetChatByUuid", chat.Uuid).Return(chat, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceUser", "stranger_pubkey", workspace.Uuid).Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "stranger_pubkey")
+		http.HandlerFunc(hHandler.GetChatMessages).ServeHTTP(rr, newRequest(ctx, http.MethodGet, ""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should return 404 for unknown chats", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetChatByUuid", chat.Uuid).Return(db.Chat{}, db.ErrChatNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner_pubkey")
+		http.HandlerFunc(hHandler.GetChatMessages).ServeHTTP(rr, newRequest(ctx, http.MethodGet, ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should post members' messages as the user", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetChatByUuid", chat.Uuid).Return(chat, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("CreateChatMessage", mock.MatchedBy(func(m db.ChatMessage) bool {
+			return m.ChatId == chat.Uuid && m.Role == db.ChatRoleUser && m.SenderPubkey == "owner_pubkey" && m.Content == "How do I deploy?"
+		})).Return(db.ChatMessage{Uuid: "message_uuid", Role: db.ChatRoleUser}, nil).Once()
+		mockDb.On("GetChatAgentWebhook", chat.Uuid).Return(db.ChatAgentWebhook{}, db.ErrChatAgentWebhookNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner_pubkey")
+		http.HandlerFunc(hHandler.CreateChatMessage).ServeHTTP(rr, newRequest(ctx, http.MethodPost, `{"content": "How do I deploy?"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should post api token messages as the assistant", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		token := auth.ApiToken{ID: 1, WorkspaceUuid: workspace.Uuid, CreatedBy: "owner_pubkey", Scopes: []string{db.ApiScopeChatMessages}}
+		mockDb.On("GetChatByUuid", chat.Uuid).Return(chat, nil).Once()
+		mockDb.On("CreateChatMessage", mock.MatchedBy(func(m db.ChatMessage) bool {
+			return m.Role == db.ChatRoleAssistant && len(m.Artifacts) == 1
+		})).Return(db.ChatMessage{Uuid: "message_uuid", Role: db.ChatRoleAssistant}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, token.CreatedBy)
+		ctx = context.WithValue(ctx, auth.ApiTokenContextKey, token)
+		stopped := false
+		hHandler.broadcastChatWorking = func(chatId string, working bool, step string) bool {
+			stopped = chatId == chat.Uuid && !working
+			return true
+		}
+		body := `{"content": "Run the script", "artifacts": [{"type": "code", "language": "bash", "content": "make deploy"}]}`
+		http.HandlerFunc(hHandler.CreateChatMessage).ServeHTTP(rr, newRequest(ctx, http.MethodPost, body))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, stopped)
+	})
+
+	t.Run("should reject api tokens of another workspace", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		token := auth.ApiToken{ID: 1, WorkspaceUuid: "other_workspace", CreatedBy: "owner_pubkey", Scopes: []string{db.ApiScopeChatMessages}}
+		mockDb.On("GetChatByUuid", chat.Uuid).Return(chat, nil).Once()
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, token.CreatedBy)
+		ctx = context.WithValue(ctx, auth.ApiTokenContextKey, token)
+		http.HandlerFunc(hHandler.CreateChatMessage).ServeHTTP(rr, newRequest(ctx, http.MethodPost, `{"content": "hi"}`))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestPollChatMessages(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+	chat := db.Chat{Uuid: "chat_uuid", WorkspaceUuid: workspace.Uuid}
+	message := db.ChatMessage{ID: 8, Uuid: "message_uuid", ChatId: chat.Uuid, Role: db.ChatRoleAssistant, Content: "Done", Artifacts: []db.Artifact{}}
+
+	newRequest := func(query string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("chat_id", chat.Uuid)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner_pubkey")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodGet, "/hivechat/"+chat.Uuid+"/messages/poll?"+query, nil)
+		return req
+	}
+	newHandler := func(mockDb *dbMocks.Database, posted chan struct{}) *hivechatHandler {
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		hHandler.subscribeChat = func(chatId string) (<-chan struct{}, func()) {
+			return posted, func() {}
+		}
+		mockDb.On("GetChatByUuid", chat.Uuid).Return(chat, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		return hHandler
+	}
+
+	t.Run("should answer at once when there are new messages", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := newHandler(mockDb, make(chan struct{}))
+		mockDb.On("GetChatMessagesSince", chat.Uuid, uint(7)).Return([]db.ChatMessage{message}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.PollChatMessages).ServeHTTP(rr, newRequest("since=7"))
+
+		var messages []db.ChatMessage
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &messages))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, []db.ChatMessage{message}, messages)
+	})
+
+	t.Run("should hold the poll until a message is posted", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		posted := make(chan struct{}, 1)
+		hHandler := newHandler(mockDb, posted)
+		mockDb.On("GetChatMessagesSince", chat.Uuid, uint(8)).Return([]db.ChatMessage{}).Once()
+		mockDb.On("GetChatMessagesSince", chat.Uuid, uint(8)).Return([]db.ChatMessage{message}).Once()
+
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			posted <- struct{}{}
+		}()
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.PollChatMessages).ServeHTTP(rr, newRequest("since=8"))
+
+		var messages []db.ChatMessage
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &messages))
+		assert.Equal(t, []db.ChatMessage{message}, messages)
+	})
+
+	t.Run("should answer an empty list when the poll times out", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := newHandler(mockDb, make(chan struct{}))
+		hHandler.chatPollTimeout = 10 * time.Millisecond
+		mockDb.On("GetChatMessagesSince", chat.Uuid, uint(0)).Return([]db.ChatMessage{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.PollChatMessages).ServeHTTP(rr, newRequest(""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "[]\n", rr.Body.String())
+	})
+
+	t.Run("should reject a since that isn't a message id", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := newHandler(mockDb, make(chan struct{}))
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.PollChatMessages).ServeHTTP(rr, newRequest("since=yesterday"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestSetChatWorking(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+	chat := db.Chat{Uuid: "chat_uuid", WorkspaceUuid: workspace.Uuid}
+	token := auth.ApiToken{ID: 1, WorkspaceUuid: workspace.Uuid, CreatedBy: "owner_pubkey", Scopes: []string{db.ApiScopeChatMessages}}
+
+	newRequest := func(body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("chat_id", chat.Uuid)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, token.CreatedBy)
+		ctx = context.WithValue(ctx, auth.ApiTokenContextKey, token)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/hivechat/"+chat.Uuid+"/working", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should pass the working step on to the chat", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetChatByUuid", chat.Uuid).Return(chat, nil).Once()
+		updates := []string{}
+		hHandler.broadcastChatWorking = func(chatId string, working bool, step string) bool {
+			updates = append(updates, step)
+			return false
+		}
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.SetChatWorking).ServeHTTP(rr, newRequest(`{"working": true, "step": " reading the repo "}`))
+
+		var sent map[string]bool
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &sent))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, []string{"reading the repo"}, updates)
+		assert.False(t, sent["sent"])
+	})
+
+	t.Run("should reject a body that isn't json", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetChatByUuid", chat.Uuid).Return(chat, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.SetChatWorking).ServeHTTP(rr, newRequest(`working`))
+		assert.Equal(t, http.StatusNotAcceptable, rr.Code)
+	})
+}
+
+func TestChatAgentWebhooks(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+	chat := db.Chat{Uuid: "chat_uuid", WorkspaceUuid: workspace.Uuid}
+	webhook := db.ChatAgentWebhook{Uuid: "webhook_uuid", ChatId: chat.Uuid, WorkspaceUuid: workspace.Uuid, Url: "https://agent.example.com/hook", Secret: "secret", CallbackToken: "callback_token", CreatedBy: "owner_pubkey"}
+
+	newRequest := func(method string, path string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("chat_id", chat.Uuid)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner_pubkey")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), method, path, bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should only register https agents", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetChatByUuid", chat.Uuid).Return(chat, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.SetChatAgentWebhook).ServeHTTP(rr, newRequest(http.MethodPut, "/hivechat/chat_uuid/agent", `{"name": "agent", "url": "http://agent.example.com"}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should not register agents on internal addresses", func(t *testing.T) {
+		for _, agentUrl := range []string{"https://127.0.0.1/hook", "https://169.254.169.254/latest/meta-data", "https://10.0.0.7:8443/hook", "https://[::1]/hook"} {
+			mockDb := dbMocks.NewDatabase(t)
+			hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+			mockDb.On("GetChatByUuid", chat.Uuid).Return(chat, nil).Once()
+			mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(hHandler.SetChatAgentWebhook).ServeHTTP(rr, newRequest(http.MethodPut, "/hivechat/chat_uuid/agent", fmt.Sprintf(`{"name": "agent", "url": "%s"}`, agentUrl)))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, agentUrl)
+		}
+	})
+
+	t.Run("should return the secret and callback url once", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		hHandler.checkAgentHost = func(host string) error { return nil }
+		mockDb.On("GetChatByUuid", chat.Uuid).Return(chat, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("SetChatAgentWebhook", mock.MatchedBy(func(w db.ChatAgentWebhook) bool {
+			return w.ChatId == chat.Uuid && w.Name == "agent" && w.Secret != "" && w.CallbackToken != "" && w.CallbackToken != w.Secret
+		})).Return(func(w db.ChatAgentWebhook) (db.ChatAgentWebhook, error) {
+			w.Uuid = "webhook_uuid"
+			return w, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.SetChatAgentWebhook).ServeHTTP(rr, newRequest(http.MethodPut, "/hivechat/chat_uuid/agent", `{"name": " agent ", "url": "https://agent.example.com/hook"}`))
+
+		registered := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &registered))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotEmpty(t, registered["secret"])
+		assert.Contains(t, registered["callback_url"], "/hivechat/chat_uuid/agent/callback?token=")
+		assert.NotContains(t, registered, "callback_token")
+	})
+
+	t.Run("should post user messages signed with the secret", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		mockHttpClient := mocks.NewHttpClient(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		hHandler.agentClient = mockHttpClient
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			body := requestBody(req)
+			delivery := db.ChatAgentDelivery{}
+			json.Unmarshal(body, &delivery)
+			return req.URL.String() == webhook.Url && verifyWebhookSignature(webhook.Secret, body, req.Header) &&
+				delivery.Message.Content == "How do I deploy?" && strings.HasSuffix(delivery.CallbackUrl, "token=callback_token")
+		})).Return(&http.Response{StatusCode: http.StatusAccepted, Body: io.NopCloser(strings.NewReader(""))}, nil).Once()
+		mockDb.On("RecordChatAgentDelivery", webhook.Uuid, mock.Anything, nil).Return(nil).Once()
+
+		err := hHandler.DeliverChatMessage(webhook, db.ChatMessage{ChatId: chat.Uuid, Role: db.ChatRoleUser, Content: "How do I deploy?"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject callbacks without the token", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetChatAgentWebhook", chat.Uuid).Return(webhook, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.ReceiveChatAgentResponse).ServeHTTP(rr, newRequest(http.MethodPost, "/hivechat/chat_uuid/agent/callback?token=wrong", `{"content": "hi"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should post the answer as the assistant", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		stopped := false
+		hHandler.broadcastChatWorking = func(chatId string, working bool, step string) bool {
+			stopped = !working
+			return true
+		}
+		mockDb.On("GetChatAgentWebhook", chat.Uuid).Return(webhook, nil).Once()
+		mockDb.On("CreateChatMessage", mock.MatchedBy(func(m db.ChatMessage) bool {
+			return m.ChatId == chat.Uuid && m.Role == db.ChatRoleAssistant && m.Content == "Run make deploy"
+		})).Return(db.ChatMessage{Uuid: "message_uuid", Role: db.ChatRoleAssistant}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.ReceiveChatAgentResponse).ServeHTTP(rr, newRequest(http.MethodPost, "/hivechat/chat_uuid/agent/callback?token=callback_token", `{"content": "Run make deploy"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, stopped)
+	})
+}
+
+func TestExportChat(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+	created := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	answered := created.Add(2 * time.Minute)
+	chat := db.Chat{Uuid: "chat_uuid", WorkspaceUuid: workspace.Uuid, Title: "Deploy <help>", Created: &created}
+	messages := []db.ChatMessage{
+		{Uuid: "m1", ChatId: chat.Uuid, Role: db.ChatRoleUser, Content: "How do I deploy?", Created: &created},
+		{Uuid: "m2", ChatId: chat.Uuid, Role: db.ChatRoleAssistant, Content: "Use the make target.", Created: &answered, Artifacts: []db.Artifact{
+			{Type: "code", Title: "README", Language: "md", Content: "```bash\nmake deploy\n```"},
+		}},
+	}
+
+	serve := func(t *testing.T, path string) *httptest.ResponseRecorder {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetChatByUuid", chat.Uuid).Return(chat, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetChatMessages", chat.Uuid).Return(messages).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("chat_id", chat.Uuid)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner_pubkey")
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.ExportChat).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should render the conversation as markdown", func(t *testing.T) {
+		rr := serve(t, "/hivechat/chat_uuid/export.md")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/markdown; charset=utf-8", rr.Header().Get("Content-Type"))
+
+		body := rr.Body.String()
+		assert.True(t, strings.HasPrefix(body, "# Deploy <help>\n"))
+		assert.Contains(t, body, "## User · 2024-03-01 09:30 UTC\n\nHow do I deploy?\n")
+		assert.Contains(t, body, "## Assistant · 2024-03-01 09:32 UTC\n\nUse the make target.\n")
+		assert.Contains(t, body, "**Artifact: README (code)**\n\n````md\n```bash\nmake deploy\n```\n````\n")
+	})
+
+	t.Run("should render the conversation as escaped html", func(t *testing.T) {
+		rr := serve(t, "/hivechat/chat_uuid/export.html")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+
+		body := rr.Body.String()
+		assert.Contains(t, body, "<h1>Deploy &lt;help&gt;</h1>")
+		assert.Contains(t, body, "<h2>Assistant · 2024-03-01 09:32 UTC</h2>")
+		assert.Contains(t, body, `<code class="language-md">`)
+	})
+}
+
+func TestArtifacts(t *testing.T) {
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+	artifact := db.Artifact{Uuid: "artifact_uuid", WorkspaceUuid: workspace.Uuid, Type: db.ArtifactCode, Content: "make deploy", Version: 1}
+
+	newRequest := func(method string, uuid string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", uuid)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner_pubkey")
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		req, _ := http.NewRequestWithContext(ctx, method, "/hivechat/artifacts/"+uuid, bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should query the artifacts of a workspace", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		filter := db.ArtifactFilter{WorkspaceUuid: workspace.Uuid, FeatureUuid: "feature_uuid", Type: db.ArtifactCode}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetArtifacts", filter).Return([]db.Artifact{artifact}).Once()
+
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner_pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/hivechat/artifacts?workspace_uuid=workspace_uuid&feature_uuid=feature_uuid&type=code", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.GetArtifacts).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		artifacts := []db.Artifact{}
+		json.Unmarshal(rr.Body.Bytes(), &artifacts)
+		assert.Equal(t, []db.Artifact{artifact}, artifacts)
+	})
+
+	t.Run("should reject unknown artifact types", func(t *testing.T) {
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), dbMocks.NewDatabase(t))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.CreateArtifact).ServeHTTP(rr, newRequest(http.MethodPost, "", `{"workspace_uuid": "workspace_uuid", "type": "blob"}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should not link an artifact to a feature of another workspace", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetArtifactByUuid", artifact.Uuid).Return(artifact, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetFeatureByUuid", "other_feature").Return(db.WorkspaceFeatures{Uuid: "other_feature", WorkspaceUuid: "other_workspace"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.UpdateArtifact).ServeHTTP(rr, newRequest(http.MethodPut, artifact.Uuid, `{"feature_uuid": "other_feature"}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should keep fields left out of an update", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetArtifactByUuid", artifact.Uuid).Return(artifact, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("UpdateArtifact", mock.MatchedBy(func(a db.Artifact) bool {
+			return a.Uuid == artifact.Uuid && a.Content == "make release" && a.Type == db.ArtifactCode && a.TicketUuid == "ticket_1"
+		}), "owner_pubkey").Return(db.Artifact{Uuid: artifact.Uuid, Content: "make release", Version: 2}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.UpdateArtifact).ServeHTTP(rr, newRequest(http.MethodPut, artifact.Uuid, `{"content": "make release", "ticket_uuid": "ticket_1"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return 404 for unknown artifacts", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		hHandler := NewHivechatHandler(mocks.NewHttpClient(t), mockDb)
+		mockDb.On("GetArtifactByUuid", "missing").Return(db.Artifact{}, db.ErrArtifactNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(hHandler.GetArtifactVersions).ServeHTTP(rr, newRequest(http.MethodGet, "missing", ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
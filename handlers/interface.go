@@ -1,7 +1,31 @@
 package handlers
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
 
 type HttpClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
+
+// AssetService wraps the asset server that holds and mints tribe badges
+type AssetService interface {
+	GetBalances(pubkey string) ([]db.AssetBalanceData, error)
+	GetAssets(pubkey string) ([]db.AssetListData, error)
+	IssueBadge(badge string, pubkey string, amount uint) (string, error)
+}
+
+// OnchainService wraps the on-chain wallet of the lightning node, used for
+// payouts too large to route over lightning
+type OnchainService interface {
+	EstimateFee(address string, amount uint) (db.OnchainFeeEstimate, error)
+	SendCoins(address string, amount uint, satPerVbyte uint) (string, error)
+	GetTransaction(txid string) (db.OnchainTransaction, error)
+}
+
+// EmbeddingService turns texts into embeddings, one per text in the same order
+type EmbeddingService interface {
+	Embed(texts []string) ([]db.Vector, error)
+}
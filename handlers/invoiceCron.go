@@ -14,6 +14,7 @@ import (
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
 )
 
 // Keep for future reference
@@ -32,7 +33,7 @@ func InitInvoiceCron() {
 				client := &http.Client{}
 				req, err := http.NewRequest(http.MethodGet, url, nil)
 
-				req.Header.Set("x-user-token", config.RelayAuthKey)
+				req.Header.Set("x-user-token", config.CurrentRelayAuthKey())
 				req.Header.Set("Content-Type", "application/json")
 				res, _ := client.Do(req)
 
@@ -67,7 +68,7 @@ func InitInvoiceCron() {
 						socket, err := db.Store.GetSocketConnections(inv.Host)
 
 						if err == nil {
-							socket.Conn.WriteJSON(msg)
+							websocket.SendMessage(socket, msg)
 						}
 
 						if inv.Type == "KEYSEND" {
@@ -82,7 +83,7 @@ func InitInvoiceCron() {
 							client := &http.Client{}
 							req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
 
-							req.Header.Set("x-user-token", config.RelayAuthKey)
+							req.Header.Set("x-user-token", config.CurrentRelayAuthKey())
 							req.Header.Set("Content-Type", "application/json")
 							res, _ := client.Do(req)
 
@@ -117,7 +118,7 @@ func InitInvoiceCron() {
 
 								socket, err := db.Store.GetSocketConnections(inv.Host)
 								if err == nil {
-									socket.Conn.WriteJSON(msg)
+									websocket.SendMessage(socket, msg)
 								}
 							} else {
 								// Unmarshal result
@@ -131,7 +132,7 @@ func InitInvoiceCron() {
 								socket, err := db.Store.GetSocketConnections(inv.Host)
 
 								if err == nil {
-									socket.Conn.WriteJSON(msg)
+									websocket.SendMessage(socket, msg)
 								}
 
 								updateInvoiceCache(invoiceList, index)
@@ -153,6 +154,11 @@ func InitInvoiceCron() {
 							}
 
 							db.DB.UpdateBounty(bounty)
+							if err == nil {
+								if err := db.DB.ResetBountyActivity(bounty.ID); err != nil {
+									log.Printf("Could not reset bounty activity: %s", err)
+								}
+							}
 
 							// Delete the index from the store array list and reset the store
 							updateInvoiceCache(invoiceList, index)
@@ -163,7 +169,7 @@ func InitInvoiceCron() {
 
 							socket, err := db.Store.GetSocketConnections(inv.Host)
 							if err == nil {
-								socket.Conn.WriteJSON(msg)
+								websocket.SendMessage(socket, msg)
 							}
 						}
 					}
@@ -183,7 +189,7 @@ func InitInvoiceCron() {
 				client := &http.Client{}
 				req, err := http.NewRequest(http.MethodGet, url, nil)
 
-				req.Header.Set("x-user-token", config.RelayAuthKey)
+				req.Header.Set("x-user-token", config.CurrentRelayAuthKey())
 				req.Header.Set("Content-Type", "application/json")
 				res, _ := client.Do(req)
 
@@ -218,7 +224,7 @@ func InitInvoiceCron() {
 						socket, err := db.Store.GetSocketConnections(inv.Host)
 
 						if err == nil {
-							socket.Conn.WriteJSON(msg)
+							websocket.SendMessage(socket, msg)
 						}
 
 						// db.DB.AddAndUpdateBudget(inv)
@@ -0,0 +1,410 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"gorm.io/gorm"
+)
+
+// maxJiraImportIssues is the most issues imported in one request
+const maxJiraImportIssues = 500
+
+// jiraCsvHeader follows the column names of Jira's CSV importer
+var jiraCsvHeader = []string{"Issue key", "Summary", "Issue Type", "Status", "Description", "Assignee", "Reporter", "Labels", "Sphinx Bounty ID"}
+
+type jiraHandler struct {
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewJiraHandler(database db.Database) *jiraHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &jiraHandler{
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// jiraStatus is the status of Jira's default workflow a bounty is in
+func jiraStatus(bounty db.NewBounty) string {
+	switch bounty.CurrentState() {
+	case db.BountyStatePaid, db.BountyStateCompleted:
+		return "Done"
+	case db.BountyStateInReview:
+		return "In Review"
+	case db.BountyStateAssigned:
+		return "In Progress"
+	case db.BountyStateCanceled:
+		return "Canceled"
+	}
+	return "To Do"
+}
+
+// jiraStatusDone tells if a Jira status completes the bounty
+func jiraStatusDone(status string) bool {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "done", "closed", "resolved":
+		return true
+	}
+	return false
+}
+
+func (jh *jiraHandler) GetJiraUserLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[jira] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !jh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage jira users")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(jh.db.GetJiraUserLinks(uuid))
+}
+
+func (jh *jiraHandler) SaveJiraUserLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[jira] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !jh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage jira users")
+		return
+	}
+
+	link := db.JiraUserLink{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &link)
+	}
+	if err != nil {
+		fmt.Println("[jira]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if !strings.Contains(link.Email, "@") {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A valid email is required")
+		return
+	}
+	if link.OwnerPubkey == "" || jh.db.GetPersonByPubkey(link.OwnerPubkey).OwnerPubKey == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Pubkey does not belong to a user")
+		return
+	}
+
+	link.ID = 0
+	link.WorkspaceUuid = uuid
+	link.CreatedBy = pubKeyFromAuth
+
+	link, err = jh.db.SaveJiraUserLink(link)
+	if err != nil {
+		fmt.Println("[jira] could not save user link", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save the jira user")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(link)
+}
+
+func (jh *jiraHandler) DeleteJiraUserLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[jira] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !jh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage jira users")
+		return
+	}
+
+	err := jh.db.DeleteJiraUserLink(uuid, chi.URLParam(r, "email"))
+	if errors.Is(err, db.ErrJiraUserLinkNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[jira] could not delete user link", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete the jira user")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Jira user deleted")
+}
+
+// ExportFeatureToJira returns the bounties of a feature as Jira issues,
+// as JSON in the shape of the Jira api or as CSV for Jira's importer with ?format=csv
+func (jh *jiraHandler) ExportFeatureToJira(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[jira] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Format must be json or csv")
+		return
+	}
+
+	feature := jh.db.GetFeatureByUuid(chi.URLParam(r, "feature_uuid"))
+	if feature.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Feature not found")
+		return
+	}
+
+	if !jh.userHasAccess(pubKeyFromAuth, feature.WorkspaceUuid, db.ViewReport) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to export this feature")
+		return
+	}
+
+	bounties := jh.db.GetFeatureBounties(feature.Uuid)
+
+	phaseLabels := map[string]string{}
+	for _, phase := range jh.db.GetPhasesByFeatureUuid(feature.Uuid) {
+		phaseLabels[phase.Uuid] = strings.Join(strings.Fields(phase.Name), "-")
+	}
+
+	emails := map[string]string{}
+	for _, link := range jh.db.GetJiraUserLinks(feature.WorkspaceUuid) {
+		if _, ok := emails[link.OwnerPubkey]; !ok {
+			emails[link.OwnerPubkey] = link.Email
+		}
+	}
+
+	ids := make([]uint, 0, len(bounties))
+	for _, bounty := range bounties {
+		ids = append(ids, bounty.ID)
+	}
+	keys := map[uint]string{}
+	for _, link := range jh.db.GetJiraIssueLinksByBountyIDs(ids) {
+		keys[link.BountyID] = link.IssueKey
+	}
+
+	jiraUser := func(pubkey string) *db.JiraUser {
+		if email, ok := emails[pubkey]; ok && pubkey != "" {
+			return &db.JiraUser{EmailAddress: email}
+		}
+		return nil
+	}
+
+	issues := db.JiraIssues{Issues: make([]db.JiraIssue, 0, len(bounties))}
+	for _, bounty := range bounties {
+		issue := db.JiraIssue{
+			Key: keys[bounty.ID],
+			Fields: db.JiraIssueFields{
+				Summary:     bounty.Title,
+				Description: bounty.Description,
+				IssueType:   db.JiraNamed{Name: "Task"},
+				Status:      db.JiraNamed{Name: jiraStatus(bounty)},
+				Assignee:    jiraUser(bounty.Assignee),
+				Reporter:    jiraUser(bounty.OwnerID),
+				Labels:      []string{},
+			},
+		}
+		if label := phaseLabels[bounty.PhaseUuid]; label != "" {
+			issue.Fields.Labels = append(issue.Fields.Labels, label)
+		}
+		issues.Issues = append(issues.Issues, issue)
+	}
+
+	if format == "json" {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(issues)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "jira-"+feature.Uuid+".csv"))
+	w.WriteHeader(http.StatusOK)
+
+	email := func(user *db.JiraUser) string {
+		if user == nil {
+			return ""
+		}
+		return user.EmailAddress
+	}
+	writer := csv.NewWriter(w)
+	writer.Write(jiraCsvHeader)
+	for i, issue := range issues.Issues {
+		writer.Write([]string{
+			issue.Key,
+			issue.Fields.Summary,
+			issue.Fields.IssueType.Name,
+			issue.Fields.Status.Name,
+			issue.Fields.Description,
+			email(issue.Fields.Assignee),
+			email(issue.Fields.Reporter),
+			strings.Join(issue.Fields.Labels, " "),
+			strconv.FormatUint(uint64(bounties[i].ID), 10),
+		})
+	}
+	writer.Flush()
+}
+
+// ImportJiraIssues imports Jira issues as bounties of a phase, an issue imported
+// before updates its bounty. Assignees are mapped by the jira users of the workspace
+func (jh *jiraHandler) ImportJiraIssues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[jira] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	featureUuid := chi.URLParam(r, "feature_uuid")
+	feature := jh.db.GetFeatureByUuid(featureUuid)
+	if feature.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Feature not found")
+		return
+	}
+	phase, err := jh.db.GetFeaturePhaseByUuid(featureUuid, chi.URLParam(r, "phase_uuid"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Phase not found")
+		return
+	}
+
+	if !jh.userHasAccess(pubKeyFromAuth, feature.WorkspaceUuid, db.AddBounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to add bounties")
+		return
+	}
+
+	issues := db.JiraIssues{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &issues)
+	}
+	if err != nil {
+		fmt.Println("[jira]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if len(issues.Issues) == 0 || len(issues.Issues) > maxJiraImportIssues {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Import between 1 and %d issues", maxJiraImportIssues))
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, issue := range issues.Issues {
+		if issue.Key == "" || strings.TrimSpace(issue.Fields.Summary) == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("Every issue needs a key and a summary")
+			return
+		}
+		if seen[issue.Key] {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(fmt.Sprintf("Issue %s is in the import twice", issue.Key))
+			return
+		}
+		seen[issue.Key] = true
+	}
+
+	pubkeys := map[string]string{}
+	for _, link := range jh.db.GetJiraUserLinks(feature.WorkspaceUuid) {
+		pubkeys[link.Email] = link.OwnerPubkey
+	}
+
+	unmapped := []string{}
+	reported := map[string]bool{}
+	now := time.Now()
+	imports := make([]db.JiraBountyImport, 0, len(issues.Issues))
+	for i, issue := range issues.Issues {
+		assignee := ""
+		if issue.Fields.Assignee != nil && issue.Fields.Assignee.EmailAddress != "" {
+			email := strings.ToLower(strings.TrimSpace(issue.Fields.Assignee.EmailAddress))
+			if pubkey, ok := pubkeys[email]; ok {
+				assignee = pubkey
+			} else if !reported[email] {
+				reported[email] = true
+				unmapped = append(unmapped, email)
+			}
+		}
+
+		imports = append(imports, db.JiraBountyImport{
+			IssueKey: issue.Key,
+			Bounty: db.NewBounty{
+				OwnerID:       pubKeyFromAuth,
+				WorkspaceUuid: feature.WorkspaceUuid,
+				PhaseUuid:     phase.Uuid,
+				Type:          "coding",
+				Title:         strings.TrimSpace(issue.Fields.Summary),
+				Description:   issue.Fields.Description,
+				Assignee:      assignee,
+				Completed:     jiraStatusDone(issue.Fields.Status.Name),
+				Tribe:         "None",
+				Show:          false,
+				// created identifies a bounty, so issues imported together get their own
+				Created: now.Unix() + int64(i),
+				Updated: &now,
+			},
+		})
+	}
+
+	result, err := jh.db.ImportJiraBounties(feature.WorkspaceUuid, imports)
+	if err != nil {
+		fmt.Println("[jira] could not import issues", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not import the issues")
+		return
+	}
+	result.UnmappedAssignees = unmapped
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newJiraRequest(pubkey string, method string, target string, params map[string]string, body string) *http.Request {
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+	req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), method, target, bytes.NewBufferString(body))
+	return req
+}
+
+func TestExportFeatureToJira(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	jHandler := NewJiraHandler(mockDb)
+	jHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "admin_pubkey" && uuid == "workspace_uuid"
+	}
+	params := map[string]string{"feature_uuid": "feature_uuid"}
+
+	mockDb.On("GetFeatureByUuid", "feature_uuid").Return(db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid"})
+	setupBounties := func() {
+		mockDb.On("GetFeatureBounties", "feature_uuid").Return([]db.NewBounty{
+			{ID: 1, Title: "Open", PhaseUuid: "phase_uuid", OwnerID: "admin_pubkey"},
+			{ID: 2, Title: "Assigned", PhaseUuid: "phase_uuid", Assignee: "dev_pubkey"},
+			{ID: 3, Title: "Paid", PhaseUuid: "phase_uuid", Assignee: "other_pubkey", Paid: true},
+		}).Once()
+		mockDb.On("GetPhasesByFeatureUuid", "feature_uuid").Return([]db.FeaturePhase{{Uuid: "phase_uuid", Name: "Phase One"}}).Once()
+		mockDb.On("GetJiraUserLinks", "workspace_uuid").Return([]db.JiraUserLink{
+			{Email: "admin@example.com", OwnerPubkey: "admin_pubkey"},
+			{Email: "dev@example.com", OwnerPubkey: "dev_pubkey"},
+		}).Once()
+		mockDb.On("GetJiraIssueLinksByBountyIDs", []uint{1, 2, 3}).Return([]db.JiraIssueLink{{IssueKey: "PROJ-7", BountyID: 2}}).Once()
+	}
+
+	t.Run("should require access to the workspace", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(jHandler.ExportFeatureToJira).ServeHTTP(rr, newJiraRequest("member_pubkey", http.MethodGet, "/feature_uuid/jira/export", params, ""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should reject an unknown format", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(jHandler.ExportFeatureToJira).ServeHTTP(rr, newJiraRequest("admin_pubkey", http.MethodGet, "/feature_uuid/jira/export?format=xml", params, ""))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should export bounties as jira issues", func(t *testing.T) {
+		setupBounties()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(jHandler.ExportFeatureToJira).ServeHTTP(rr, newJiraRequest("admin_pubkey", http.MethodGet, "/feature_uuid/jira/export", params, ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		issues := db.JiraIssues{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &issues))
+		assert.Len(t, issues.Issues, 3)
+
+		assert.Equal(t, "To Do", issues.Issues[0].Fields.Status.Name)
+		assert.Nil(t, issues.Issues[0].Fields.Assignee)
+		assert.Equal(t, "admin@example.com", issues.Issues[0].Fields.Reporter.EmailAddress)
+		assert.Equal(t, []string{"Phase-One"}, issues.Issues[0].Fields.Labels)
+
+		assert.Equal(t, "PROJ-7", issues.Issues[1].Key)
+		assert.Equal(t, "In Progress", issues.Issues[1].Fields.Status.Name)
+		assert.Equal(t, "dev@example.com", issues.Issues[1].Fields.Assignee.EmailAddress)
+
+		assert.Equal(t, "Done", issues.Issues[2].Fields.Status.Name)
+		assert.Nil(t, issues.Issues[2].Fields.Assignee)
+	})
+
+	t.Run("should export bounties as csv for the jira importer", func(t *testing.T) {
+		setupBounties()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(jHandler.ExportFeatureToJira).ServeHTTP(rr, newJiraRequest("admin_pubkey", http.MethodGet, "/feature_uuid/jira/export?format=csv", params, ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+
+		rows, err := csv.NewReader(rr.Body).ReadAll()
+		assert.NoError(t, err)
+		assert.Len(t, rows, 4)
+		assert.Equal(t, jiraCsvHeader, rows[0])
+		assert.Equal(t, []string{"PROJ-7", "Assigned", "Task", "In Progress", "", "dev@example.com", "", "Phase-One", "2"}, rows[2])
+	})
+}
+
+func TestImportJiraIssues(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	jHandler := NewJiraHandler(mockDb)
+	jHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "admin_pubkey" && role == db.AddBounty
+	}
+	params := map[string]string{"feature_uuid": "feature_uuid", "phase_uuid": "phase_uuid"}
+
+	mockDb.On("GetFeatureByUuid", "feature_uuid").Return(db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid"})
+	mockDb.On("GetFeaturePhaseByUuid", "feature_uuid", "phase_uuid").Return(db.FeaturePhase{Uuid: "phase_uuid", FeatureUuid: "feature_uuid"}, nil)
+
+	t.Run("should require access to add bounties", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(jHandler.ImportJiraIssues).ServeHTTP(rr, newJiraRequest("member_pubkey", http.MethodPost, "/jira/import", params, `{"issues": []}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should validate the issues", func(t *testing.T) {
+		for _, body := range []string{
+			`{"issues": []}`,
+			`{"issues": [{"fields": {"summary": "no key"}}]}`,
+			`{"issues": [{"key": "PROJ-1", "fields": {"summary": " "}}]}`,
+			`{"issues": [{"key": "PROJ-1", "fields": {"summary": "a"}}, {"key": "PROJ-1", "fields": {"summary": "b"}}]}`,
+		} {
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(jHandler.ImportJiraIssues).ServeHTTP(rr, newJiraRequest("admin_pubkey", http.MethodPost, "/jira/import", params, body))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+	})
+
+	t.Run("should map status and assignee of the issues", func(t *testing.T) {
+		mockDb.On("GetJiraUserLinks", "workspace_uuid").Return([]db.JiraUserLink{{Email: "dev@example.com", OwnerPubkey: "dev_pubkey"}}).Once()
+		mockDb.On("ImportJiraBounties", "workspace_uuid", mock.MatchedBy(func(imports []db.JiraBountyImport) bool {
+			if len(imports) != 3 {
+				return false
+			}
+			for _, imp := range imports {
+				if imp.Bounty.PhaseUuid != "phase_uuid" || imp.Bounty.OwnerID != "admin_pubkey" || imp.Bounty.Show {
+					return false
+				}
+			}
+			return imports[0].IssueKey == "PROJ-1" && imports[0].Bounty.Assignee == "dev_pubkey" && !imports[0].Bounty.Completed &&
+				imports[1].Bounty.Assignee == "" && imports[1].Bounty.Completed &&
+				imports[2].Bounty.Assignee == "" && imports[0].Bounty.Created != imports[1].Bounty.Created
+		})).Return(db.JiraImportResult{Created: []string{"PROJ-1", "PROJ-2"}, Updated: []string{"PROJ-3"}, Skipped: []string{}}, nil).Once()
+
+		body := `{"issues": [
+			{"key": "PROJ-1", "fields": {"summary": "Login", "status": {"name": "In Progress"}, "assignee": {"emailAddress": "Dev@Example.com"}}},
+			{"key": "PROJ-2", "fields": {"summary": "Logout", "status": {"name": "Done"}, "assignee": {"emailAddress": "stranger@example.com"}}},
+			{"key": "PROJ-3", "fields": {"summary": "Signup", "status": {"name": "To Do"}}}
+		]}`
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(jHandler.ImportJiraIssues).ServeHTTP(rr, newJiraRequest("admin_pubkey", http.MethodPost, "/jira/import", params, body))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		result := db.JiraImportResult{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, []string{"PROJ-1", "PROJ-2"}, result.Created)
+		assert.Equal(t, []string{"stranger@example.com"}, result.UnmappedAssignees)
+	})
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+// maintenanceRetryAfter is the Retry-After of writes turned down in maintenance mode
+const maintenanceRetryAfter = "120"
+
+var (
+	maintenanceMu        sync.Mutex
+	broadcastMaintenance = db.MaintenanceStatus{Enabled: false, Message: config.DefaultMaintenanceMessage}
+)
+
+func maintenanceStatus() db.MaintenanceStatus {
+	enabled, message := config.Maintenance()
+	return db.MaintenanceStatus{Enabled: enabled, Message: message}
+}
+
+// MaintenanceMiddleware answers writes with a 503 while the api is in maintenance
+// mode. Reads go through, and so do the admin routes so maintenance can be turned off
+func MaintenanceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+		status := maintenanceStatus()
+		if !status.Enabled || strings.HasPrefix(r.URL.Path, "/admin/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", maintenanceRetryAfter)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(status)
+	})
+}
+
+// BroadcastMaintenanceChange tells the websocket clients of this instance to show
+// or hide the maintenance banner, it runs after every config reload so each
+// instance reaches its own clients
+func BroadcastMaintenanceChange() {
+	status := maintenanceStatus()
+
+	maintenanceMu.Lock()
+	changed := status != broadcastMaintenance
+	broadcastMaintenance = status
+	maintenanceMu.Unlock()
+	if !changed {
+		return
+	}
+
+	body, _ := json.Marshal(status)
+	broadcastToWebsocketPool(websocket.Message{Type: 1, Msg: "maintenance", Body: string(body)})
+}
+
+// GetMaintenance lets the frontend show the maintenance banner on load
+func (ch *configHandler) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(maintenanceStatus())
+}
+
+// SetMaintenance turns maintenance mode on or off through the configs table,
+// so every instance picks it up on its next reload
+func (ch *configHandler) SetMaintenance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[maintenance] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.MaintenanceStatus{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if _, err := ch.db.SaveConfigOverride("maintenance_mode", strconv.FormatBool(request.Enabled), pubKeyFromAuth); err != nil {
+		fmt.Println("[maintenance] could not save maintenance mode", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save maintenance mode")
+		return
+	}
+	if message := strings.TrimSpace(request.Message); message != "" {
+		_, err = ch.db.SaveConfigOverride("maintenance_message", message, pubKeyFromAuth)
+	} else if err = ch.db.DeleteConfigOverride("maintenance_message"); errors.Is(err, db.ErrConfigOverrideNotFound) {
+		err = nil
+	}
+	if err != nil {
+		fmt.Println("[maintenance] could not save maintenance message", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save maintenance message")
+		return
+	}
+	ReloadConfigOverrides(ch.db)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(maintenanceStatus())
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,6 +17,8 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/relay"
+	"github.com/stakwork/sphinx-tribes/utils"
 )
 
 func MemeImageUpload(w http.ResponseWriter, r *http.Request) {
@@ -109,17 +112,13 @@ func GetMemeChallenge() db.MemeChallenge {
 }
 
 func SignChallenge(challenge string) db.RelaySignerResponse {
-	url := fmt.Sprintf("%s/signer/%s", config.RelayUrl, challenge)
-
-	client := &http.Client{}
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-
-	req.Header.Set("x-user-token", config.RelayAuthKey)
-	req.Header.Set("Content-Type", "application/json")
-	res, _ := client.Do(req)
+	client := relay.NewClient(utils.TracedClient)
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/signer/"+challenge, nil)
+	res, err := client.Do(req)
 
 	if err != nil {
 		log.Printf("Request Failed: %s", err)
+		return db.RelaySignerResponse{}
 	}
 
 	defer res.Body.Close()
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// applyMergePatch applies a JSON merge patch (RFC 7396) to a record and
+// unmarshals the result into patched, it returns the top level fields the
+// patch names. A null removes a field, which leaves it at its zero value
+func applyMergePatch(record interface{}, patch []byte, patched interface{}) ([]string, error) {
+	changes := map[string]interface{}{}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, err
+	}
+	if changes == nil {
+		return nil, errors.New("a patch must be a JSON object")
+	}
+
+	original, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	document := map[string]interface{}{}
+	if err := json.Unmarshal(original, &document); err != nil {
+		return nil, err
+	}
+
+	fields := []string{}
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	merged, err := json.Marshal(mergePatchValue(document, changes))
+	if err != nil {
+		return nil, err
+	}
+	return fields, json.Unmarshal(merged, patched)
+}
+
+func mergePatchValue(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergePatchValue(targetObject[key], value)
+		}
+	}
+	return targetObject
+}
+
+// unpatchableField returns the first field a patch may not change
+func unpatchableField(fields []string, patchable map[string]bool) string {
+	for _, field := range fields {
+		if !patchable[field] {
+			return field
+		}
+	}
+	return ""
+}
+
+// recordETag is the version of a record for conditional patches, it changes
+// with every write of the record
+func recordETag(updated *time.Time) string {
+	if updated == nil {
+		return `"0"`
+	}
+	return fmt.Sprintf(`"%d"`, updated.UnixNano())
+}
+
+// ifMatch checks the If-Match header of a patch against the version of the
+// record, a patch without one, or with *, is not conditional
+func ifMatch(r *http.Request, etag string) (conditional bool, matched bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return false, true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true, true
+		}
+	}
+	return true, false
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	due := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	bounty := db.NewBounty{ID: 1, Title: "Add login", Description: "With lightning", Price: 1000, Show: true, DueDate: &due}
+
+	t.Run("should only change the fields the patch names", func(t *testing.T) {
+		patched := db.NewBounty{}
+		fields, err := applyMergePatch(bounty, []byte(`{"title": "Add logout", "show": false}`), &patched)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"show", "title"}, fields)
+		assert.Equal(t, "Add logout", patched.Title)
+		assert.False(t, patched.Show)
+		assert.Equal(t, "With lightning", patched.Description)
+		assert.Equal(t, uint(1000), patched.Price)
+	})
+
+	t.Run("should remove a field patched with null", func(t *testing.T) {
+		patched := db.NewBounty{}
+		fields, err := applyMergePatch(bounty, []byte(`{"due_date": null}`), &patched)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"due_date"}, fields)
+		assert.Nil(t, patched.DueDate)
+	})
+
+	t.Run("should reject a patch that is not an object", func(t *testing.T) {
+		for _, patch := range []string{`null`, `["title"]`, `{"title": `} {
+			_, err := applyMergePatch(bounty, []byte(patch), &db.NewBounty{})
+			assert.Error(t, err, patch)
+		}
+	})
+}
+
+func TestIfMatch(t *testing.T) {
+	updated := time.Unix(1700000000, 0)
+	etag := recordETag(&updated)
+
+	check := func(header string) (bool, bool) {
+		req, _ := http.NewRequest(http.MethodPatch, "/1", nil)
+		if header != "" {
+			req.Header.Set("If-Match", header)
+		}
+		return ifMatch(req, etag)
+	}
+
+	conditional, matched := check("")
+	assert.False(t, conditional)
+	assert.True(t, matched)
+
+	conditional, matched = check("*")
+	assert.False(t, conditional)
+	assert.True(t, matched)
+
+	conditional, matched = check(`"1", W/` + etag)
+	assert.True(t, conditional)
+	assert.True(t, matched)
+
+	conditional, matched = check(`"1"`)
+	assert.True(t, conditional)
+	assert.False(t, matched)
+}
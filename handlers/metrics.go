@@ -19,6 +19,7 @@ import (
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
 	"github.com/tuan78/jsonconv"
 )
 
@@ -210,11 +211,21 @@ func (mh *metricHandler) MetricsBounties(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	metricBounties := mh.db.GetBountiesByDateRange(request, r)
-	metricBountiesData := mh.GetMetricsBountiesData(metricBounties)
-
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(metricBountiesData)
+	stream := utils.NewJSONStream(w, r)
+	err = mh.db.StreamBountiesByDateRange(request, r, func(bounties []db.NewBounty) error {
+		for _, bounty := range mh.GetMetricsBountiesData(bounties) {
+			if err := stream.Write(bounty); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Println("[metrics] could not stream bounties", err)
+		stream.Abort()
+		return
+	}
+	stream.Close()
 }
 
 func (mh *metricHandler) MetricsBountiesCount(w http.ResponseWriter, r *http.Request) {
@@ -325,11 +336,27 @@ func GetAdminWorkspaces(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(workspaces)
 }
 
+// GetRouteLatency returns the latency histogram of every route since the server started
+func GetRouteLatency(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(utils.RouteLatencies.Snapshot())
+}
+
 func (mh *metricHandler) GetMetricsBountiesData(metricBounties []db.NewBounty) []db.BountyData {
 	var metricBountiesData []db.BountyData
+	people := bountyPeople(mh.db, metricBounties)
 	for _, bounty := range metricBounties {
-		bountyOwner := mh.db.GetPersonByPubkey(bounty.OwnerID)
-		bountyAssignee := mh.db.GetPersonByPubkey(bounty.Assignee)
+		bountyOwner := people[bounty.OwnerID]
+		bountyAssignee := people[bounty.Assignee]
 		workspace := mh.db.GetWorkspaceByUuid(bounty.WorkspaceUuid)
 
 		bountyData := db.BountyData{
@@ -339,11 +366,13 @@ func (mh *metricHandler) GetMetricsBountiesData(metricBounties []db.NewBounty) [
 			BountyCreated:           bounty.Created,
 			BountyDescription:       bounty.Description,
 			BountyUpdated:           bounty.Updated,
+			BountyTags:              bounty.Tags,
+			OwnerTags:               bountyOwner.Tags,
 			AssigneeId:              bountyAssignee.ID,
 			AssigneeImg:             bountyAssignee.Img,
 			AssigneeAlias:           bountyAssignee.OwnerAlias,
 			AssigneeDescription:     bountyAssignee.Description,
-			AssigneeRouteHint:       bountyAssignee.OwnerRouteHint,
+			AssigneeRouteHint:       bountyAssignee.OwnerRouteHint.String(),
 			BountyOwnerId:           bountyOwner.ID,
 			OwnerUuid:               bountyOwner.Uuid,
 			OwnerDescription:        bountyOwner.Description,
@@ -363,21 +392,25 @@ func (mh *metricHandler) GetMetricsBountiesData(metricBounties []db.NewBounty) [
 	return metricBountiesData
 }
 
+var bountyStateLabels = map[db.BountyState]string{
+	db.BountyStateOpen:      "Open",
+	db.BountyStateAssigned:  "Assigned",
+	db.BountyStateInReview:  "In Review",
+	db.BountyStateCompleted: "Completed",
+	db.BountyStatePaid:      "Paid",
+	db.BountyStateCanceled:  "Canceled",
+}
+
 func getMetricsBountyCsv(metricBounties []db.NewBounty) []db.MetricsBountyCsv {
 	var metricBountiesCsv []db.MetricsBountyCsv
+	people := bountyPeople(db.DB, metricBounties)
 	for _, bounty := range metricBounties {
-		bountyOwner := db.DB.GetPersonByPubkey(bounty.OwnerID)
-		bountyAssignee := db.DB.GetPersonByPubkey(bounty.Assignee)
+		bountyOwner := people[bounty.OwnerID]
+		bountyAssignee := people[bounty.Assignee]
 		workspace := db.DB.GetWorkspaceByUuid(bounty.WorkspaceUuid)
 
 		bountyLink := fmt.Sprintf("https://community.sphinx.chat/bounty/%d", bounty.ID)
-		bountyStatus := "Open"
-
-		if bounty.Assignee != "" && !bounty.Paid {
-			bountyStatus = "Assigned"
-		} else {
-			bountyStatus = "Paid"
-		}
+		bountyStatus := bountyStateLabels[bounty.CurrentState()]
 
 		tm := time.Unix(bounty.Created, 0)
 		bountyCsv := db.MetricsBountyCsv{
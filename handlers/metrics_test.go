@@ -16,6 +16,7 @@ import (
 
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -653,3 +654,32 @@ func TestMetricsBountiesProviders(t *testing.T) {
 	})
 
 }
+
+func TestGetRouteLatency(t *testing.T) {
+	t.Run("should return 401 without a pubkey", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/metrics/latency", nil)
+		http.HandlerFunc(GetRouteLatency).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should return the route histograms", func(t *testing.T) {
+		utils.RouteLatencies.Observe("GET /gobounties/{id}", 30*time.Millisecond)
+
+		rr := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "test-key")
+		req := httptest.NewRequest(http.MethodGet, "/metrics/latency", nil).WithContext(ctx)
+		http.HandlerFunc(GetRouteLatency).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		latencies := []utils.RouteLatency{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &latencies))
+		routes := []string{}
+		for _, latency := range latencies {
+			routes = append(routes, latency.Route)
+		}
+		assert.Contains(t, routes, "GET /gobounties/{id}")
+	})
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/nostr"
+)
+
+// nostrNewListingsPerRun caps the bounties listed in one sync, so turning Nostr
+// on for a large deployment spreads the first listings over a few runs
+const nostrNewListingsPerRun = 50
+
+// nostrListingId is the d tag of the listing of a bounty, an update replaces the
+// listing with the same d tag
+func nostrListingId(bountyID uint) string {
+	return fmt.Sprintf("sphinx-bounty-%d", bountyID)
+}
+
+// nostrBountyListing is the NIP-99 classified listing of a bounty, it is active
+// while the bounty is open and sold once someone is working on it
+func nostrBountyListing(bounty db.NewBounty, now time.Time) nostr.Event {
+	status := "sold"
+	if bounty.CurrentState() == db.BountyStateOpen {
+		status = "active"
+	}
+	link := fmt.Sprintf("%s/bounty/%d", config.Host, bounty.ID)
+
+	tags := [][]string{
+		{"d", nostrListingId(bounty.ID)},
+		{"title", bounty.Title},
+		{"published_at", strconv.FormatInt(bounty.Created, 10)},
+		{"price", strconv.FormatUint(uint64(bounty.Price), 10), "SATS"},
+		{"status", status},
+		{"r", link},
+		{"t", "bounty"},
+	}
+	if bounty.OneSentenceSummary != "" {
+		tags = append(tags, []string{"summary", bounty.OneSentenceSummary})
+	}
+	for _, language := range bounty.CodingLanguages {
+		tags = append(tags, []string{"t", strings.ToLower(strings.ReplaceAll(language, " ", ""))})
+	}
+
+	content := bounty.Description
+	if content == "" {
+		content = bounty.Title
+	}
+	return nostr.Event{
+		CreatedAt: now.Unix(),
+		Kind:      nostr.KindClassifiedListing,
+		Tags:      tags,
+		Content:   fmt.Sprintf("%s\n\n%s", content, link),
+	}
+}
+
+// nostrBountyDeletion asks the relays to drop the listing of a bounty, NIP-09
+func nostrBountyDeletion(bountyID uint, listing db.NostrEvent, pubkey string, now time.Time, reason string) nostr.Event {
+	return nostr.Event{
+		CreatedAt: now.Unix(),
+		Kind:      nostr.KindDeletion,
+		Tags: [][]string{
+			{"e", listing.EventId},
+			{"a", fmt.Sprintf("%d:%s:%s", nostr.KindClassifiedListing, pubkey, nostrListingId(bountyID))},
+		},
+		Content: reason,
+	}
+}
+
+// nostrSyncAction is what a bounty needs on Nostr: a first listing, an update
+// once it was assigned, reopened or edited, or a deletion once it was paid,
+// canceled, hidden or removed. ok is false when its listing is up to date
+func nostrSyncAction(sync db.NostrBountySync) (action db.NostrEventAction, reason string, ok bool) {
+	if sync.Event == nil {
+		return db.NostrEventPublish, "", true
+	}
+
+	bounty := sync.Bounty
+	switch {
+	case bounty.ID == 0:
+		return db.NostrEventDelete, "bounty removed", true
+	case !bounty.Show || (bounty.Visibility != "" && bounty.Visibility != db.BountyVisibilityPublic):
+		return db.NostrEventDelete, "bounty hidden", true
+	}
+
+	state := bounty.CurrentState()
+	switch state {
+	case db.BountyStatePaid:
+		return db.NostrEventDelete, "bounty paid", true
+	case db.BountyStateCanceled:
+		return db.NostrEventDelete, "bounty canceled", true
+	}
+
+	edited := bounty.Updated != nil && sync.Event.Created != nil && bounty.Updated.After(*sync.Event.Created)
+	if state != sync.Event.BountyState || edited {
+		return db.NostrEventUpdate, "", true
+	}
+	return "", "", false
+}
+
+// SyncNostrBounties brings the Nostr listings of the bounties up to date and
+// records every event sent with the relays that accepted it. Events no relay
+// accepted are tried again on the next run
+func SyncNostrBounties(database db.Database, publisher nostr.Publisher, key *btcec.PrivateKey, now time.Time) {
+	syncs, err := database.GetNostrBountySyncs(nostrNewListingsPerRun)
+	if err != nil {
+		fmt.Println("[nostr] could not get bounties to sync", err)
+		return
+	}
+
+	for _, sync := range syncs {
+		action, reason, ok := nostrSyncAction(sync)
+		if !ok {
+			continue
+		}
+
+		event := nostrBountyListing(sync.Bounty, now)
+		if action == db.NostrEventDelete {
+			event = nostrBountyDeletion(sync.BountyID, *sync.Event, nostr.PublicKey(key), now, reason)
+		}
+		if err := event.Sign(key); err != nil {
+			fmt.Println("[nostr] could not sign event", err)
+			continue
+		}
+
+		record := db.NostrEvent{
+			EventId:  event.ID,
+			BountyID: sync.BountyID,
+			Kind:     event.Kind,
+			Action:   action,
+			Status:   db.NostrEventFailed,
+			Relays:   []string{},
+		}
+		if sync.Bounty.ID != 0 {
+			record.BountyState = sync.Bounty.CurrentState()
+		}
+
+		results := publisher.Publish(context.Background(), event)
+		relays := []string{}
+		for relay := range results {
+			relays = append(relays, relay)
+		}
+		sort.Strings(relays)
+		failures := []string{}
+		for _, relay := range relays {
+			if err := results[relay]; err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %s", relay, err))
+				continue
+			}
+			record.Relays = append(record.Relays, relay)
+		}
+		if len(record.Relays) > 0 {
+			record.Status = db.NostrEventPublished
+		}
+		record.Error = strings.Join(failures, "; ")
+
+		if _, err := database.CreateNostrEvent(record); err != nil {
+			fmt.Println("[nostr] could not record event", event.ID, err)
+		}
+	}
+}
+
+// GetBountyNostrEvents lists the Nostr events sent about a bounty with their status
+func (h *bountyHandler) GetBountyNostrEvents(w http.ResponseWriter, r *http.Request) {
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.db.GetBountyNostrEvents(bounty.ID))
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/nostr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeNostrPublisher struct {
+	events  []nostr.Event
+	results map[string]error
+}
+
+func (f *fakeNostrPublisher) Publish(ctx context.Context, event nostr.Event) map[string]error {
+	f.events = append(f.events, event)
+	return f.results
+}
+
+func nostrTag(event nostr.Event, name string) []string {
+	for _, tag := range event.Tags {
+		if tag[0] == name {
+			return tag
+		}
+	}
+	return nil
+}
+
+func TestSyncNostrBounties(t *testing.T) {
+	key, _ := nostr.ParseKey("7f7ff03d123792d6ac594bfa67bf6d0c0ab55b6b1fdb6249303fe861f1ccba9a")
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	listed := now.Add(-time.Hour)
+	relays := map[string]error{"wss://relay.damus.io": nil, "wss://nos.lol": errors.New("connection refused")}
+
+	open := db.NewBounty{ID: 1, Title: "Add dark mode", Description: "Make it dark", Price: 20000, Show: true, Visibility: db.BountyVisibilityPublic,
+		State: db.BountyStateOpen, Created: 1718000000, CodingLanguages: pq.StringArray{"Type Script"}}
+	listing := &db.NostrEvent{EventId: "listing_event", BountyID: 1, Action: db.NostrEventPublish, BountyState: db.BountyStateOpen, Status: db.NostrEventPublished, Created: &listed}
+
+	t.Run("should list open bounties and record the relays that took them", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		publisher := &fakeNostrPublisher{results: relays}
+		mockDb.On("GetNostrBountySyncs", nostrNewListingsPerRun).Return([]db.NostrBountySync{{BountyID: 1, Bounty: open}}, nil).Once()
+		mockDb.On("CreateNostrEvent", mock.MatchedBy(func(e db.NostrEvent) bool {
+			return e.BountyID == 1 && e.Action == db.NostrEventPublish && e.Status == db.NostrEventPublished && e.BountyState == db.BountyStateOpen &&
+				len(e.Relays) == 1 && e.Relays[0] == "wss://relay.damus.io" && e.Error == "wss://nos.lol: connection refused"
+		})).Return(db.NostrEvent{}, nil).Once()
+
+		SyncNostrBounties(mockDb, publisher, key, now)
+
+		event := publisher.events[0]
+		assert.True(t, event.Verify())
+		assert.Equal(t, nostr.KindClassifiedListing, event.Kind)
+		assert.Equal(t, []string{"d", "sphinx-bounty-1"}, nostrTag(event, "d"))
+		assert.Equal(t, []string{"price", "20000", "SATS"}, nostrTag(event, "price"))
+		assert.Equal(t, []string{"status", "active"}, nostrTag(event, "status"))
+		assert.Contains(t, event.Tags, []string{"t", "typescript"})
+	})
+
+	t.Run("should mark the listing sold once the bounty is assigned", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		publisher := &fakeNostrPublisher{results: relays}
+		assigned := open
+		assigned.Assignee = "hunter_pubkey"
+		mockDb.On("GetNostrBountySyncs", nostrNewListingsPerRun).Return([]db.NostrBountySync{{BountyID: 1, Bounty: assigned, Event: listing}}, nil).Once()
+		mockDb.On("CreateNostrEvent", mock.MatchedBy(func(e db.NostrEvent) bool {
+			return e.Action == db.NostrEventUpdate && e.BountyState == db.BountyStateAssigned
+		})).Return(db.NostrEvent{}, nil).Once()
+
+		SyncNostrBounties(mockDb, publisher, key, now)
+
+		assert.Equal(t, []string{"status", "sold"}, nostrTag(publisher.events[0], "status"))
+		assert.Equal(t, []string{"d", "sphinx-bounty-1"}, nostrTag(publisher.events[0], "d"))
+	})
+
+	t.Run("should delete the listing once the bounty is paid", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		publisher := &fakeNostrPublisher{results: relays}
+		paid := open
+		paid.Assignee = "hunter_pubkey"
+		paid.Paid = true
+		mockDb.On("GetNostrBountySyncs", nostrNewListingsPerRun).Return([]db.NostrBountySync{{BountyID: 1, Bounty: paid, Event: listing}}, nil).Once()
+		mockDb.On("CreateNostrEvent", mock.MatchedBy(func(e db.NostrEvent) bool {
+			return e.Action == db.NostrEventDelete && e.Kind == nostr.KindDeletion && e.BountyState == db.BountyStatePaid
+		})).Return(db.NostrEvent{}, nil).Once()
+
+		SyncNostrBounties(mockDb, publisher, key, now)
+
+		event := publisher.events[0]
+		assert.True(t, event.Verify())
+		assert.Equal(t, []string{"e", "listing_event"}, nostrTag(event, "e"))
+		assert.Equal(t, []string{"a", "30402:" + nostr.PublicKey(key) + ":sphinx-bounty-1"}, nostrTag(event, "a"))
+		assert.Equal(t, "bounty paid", event.Content)
+	})
+
+	t.Run("should leave up to date listings alone and track failed events", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		publisher := &fakeNostrPublisher{results: map[string]error{"wss://relay.damus.io": errors.New("timeout")}}
+		mockDb.On("GetNostrBountySyncs", nostrNewListingsPerRun).Return([]db.NostrBountySync{
+			{BountyID: 1, Bounty: open, Event: listing},
+			{BountyID: 2, Event: &db.NostrEvent{EventId: "gone_event", BountyID: 2, BountyState: db.BountyStateOpen, Created: &listed}},
+		}, nil).Once()
+		mockDb.On("CreateNostrEvent", mock.MatchedBy(func(e db.NostrEvent) bool {
+			return e.BountyID == 2 && e.Action == db.NostrEventDelete && e.Status == db.NostrEventFailed && len(e.Relays) == 0
+		})).Return(db.NostrEvent{}, nil).Once()
+
+		SyncNostrBounties(mockDb, publisher, key, now)
+
+		assert.Len(t, publisher.events, 1)
+		assert.Equal(t, "bounty removed", publisher.events[0].Content)
+	})
+}
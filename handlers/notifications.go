@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+var (
+	workspaceEventHooks   []func(event db.WorkspaceEvent)
+	workspaceEventHooksMu sync.RWMutex
+)
+
+// OnWorkspaceEvent subscribes a hook to the workspace events, hooks run in the
+// request that caused the event so they hand slow work to a goroutine
+func OnWorkspaceEvent(hook func(event db.WorkspaceEvent)) {
+	workspaceEventHooksMu.Lock()
+	defer workspaceEventHooksMu.Unlock()
+	workspaceEventHooks = append(workspaceEventHooks, hook)
+}
+
+// PublishWorkspaceEvent sends an event to the subscribers of the workspace events
+func PublishWorkspaceEvent(event db.WorkspaceEvent) {
+	if event.WorkspaceUuid == "" {
+		return
+	}
+	if event.Created.IsZero() {
+		event.Created = time.Now()
+	}
+
+	workspaceEventHooksMu.RLock()
+	hooks := workspaceEventHooks
+	workspaceEventHooksMu.RUnlock()
+	for _, hook := range hooks {
+		hook(event)
+	}
+}
+
+type notificationHandler struct {
+	db db.Database
+}
+
+func NewNotificationHandler(database db.Database) *notificationHandler {
+	return &notificationHandler{db: database}
+}
+
+func (nh *notificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	params := utils.NewQueryParams(r)
+	unreadOnly := params.Bool("unread", false)
+	if err := params.Err(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	notifications := nh.db.GetNotificationsByPubkey(pubKeyFromAuth, unreadOnly)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(notifications)
+}
+
+func (nh *notificationHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Not a valid notification id")
+		return
+	}
+
+	err = nh.db.MarkNotificationRead(pubKeyFromAuth, id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Notification marked as read"})
+}
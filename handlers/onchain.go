@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/relay"
+)
+
+type relayOnchainService struct {
+	relay *relay.Client
+}
+
+func NewOnchainService(httpClient HttpClient) OnchainService {
+	return &relayOnchainService{relay: relay.NewClient(httpClient)}
+}
+
+// EstimateFee asks the node what sending amount to address costs
+func (s *relayOnchainService) EstimateFee(address string, amount uint) (db.OnchainFeeEstimate, error) {
+	estimate := db.OnchainFeeEstimate{}
+	query := url.Values{}
+	query.Set("address", address)
+	query.Set("amount", fmt.Sprint(amount))
+
+	err := s.relayRequest(http.MethodGet, "/onchain/fee?"+query.Encode(), nil, &estimate)
+	return estimate, err
+}
+
+// errRelayUnanswered is returned when the relay gave no answer that could be read
+var errRelayUnanswered = errors.New("relay gave no answer")
+
+// SendCoins sends amount to address at the fee rate and returns the transaction id,
+// it returns errPaymentUnknown when the coins may have been sent
+func (s *relayOnchainService) SendCoins(address string, amount uint, satPerVbyte uint) (string, error) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"address":       address,
+		"amount":        amount,
+		"sat_per_vbyte": satPerVbyte,
+	})
+
+	tx := db.OnchainTransaction{}
+	err := s.relayRequest(http.MethodPost, "/onchain/send", body, &tx)
+	if errors.Is(err, errRelayUnanswered) {
+		return "", fmt.Errorf("%w: %s", errPaymentUnknown, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	if tx.Txid == "" {
+		return "", fmt.Errorf("%w: relay did not return a transaction id", errPaymentUnknown)
+	}
+	return tx.Txid, nil
+}
+
+func (s *relayOnchainService) GetTransaction(txid string) (db.OnchainTransaction, error) {
+	tx := db.OnchainTransaction{}
+	err := s.relayRequest(http.MethodGet, "/onchain/tx?txid="+url.QueryEscape(txid), nil, &tx)
+	return tx, err
+}
+
+// relayRequest calls the relay and decodes the response field of its answer into result,
+// only an error the relay answered with is not wrapped in errRelayUnanswered
+func (s *relayOnchainService) relayRequest(method string, path string, body []byte, result interface{}) error {
+	req, err := s.relay.NewRequest(context.Background(), method, path, body)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.relay.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errRelayUnanswered, err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errRelayUnanswered, err)
+	}
+
+	answer := struct {
+		Success  bool            `json:"success"`
+		Response json.RawMessage `json:"response"`
+		Error    string          `json:"error"`
+	}{}
+	json.Unmarshal(data, &answer)
+
+	if res.StatusCode != http.StatusOK || !answer.Success {
+		if answer.Error == "" {
+			return fmt.Errorf("%w: relay answered with status %d", errRelayUnanswered, res.StatusCode)
+		}
+		return errors.New(answer.Error)
+	}
+	if err := json.Unmarshal(answer.Response, result); err != nil {
+		return fmt.Errorf("%w: %s", errRelayUnanswered, err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSendCoins(t *testing.T) {
+	answer := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewBufferString(body))}
+	}
+
+	t.Run("should return the transaction id of the sent coins", func(t *testing.T) {
+		mockHttpClient := mocks.NewHttpClient(t)
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(answer(200, `{"success": true, "response": {"txid": "txid-1"}}`), nil).Once()
+
+		txid, err := NewOnchainService(mockHttpClient).SendCoins("address", 1000, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "txid-1", txid)
+	})
+
+	t.Run("should fail when the relay refuses to send the coins", func(t *testing.T) {
+		mockHttpClient := mocks.NewHttpClient(t)
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(answer(400, `{"success": false, "error": "insufficient funds"}`), nil).Once()
+
+		_, err := NewOnchainService(mockHttpClient).SendCoins("address", 1000, 2)
+
+		assert.EqualError(t, err, "insufficient funds")
+		assert.False(t, errors.Is(err, errPaymentUnknown))
+	})
+
+	t.Run("should have no known outcome when the relay gives no clear answer", func(t *testing.T) {
+		for name, res := range map[string]*http.Response{
+			"unreadable error": answer(502, `<html>bad gateway</html>`),
+			"no transaction":   answer(200, `{"success": true, "response": {}}`),
+		} {
+			mockHttpClient := mocks.NewHttpClient(t)
+			mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(res, nil).Once()
+
+			_, err := NewOnchainService(mockHttpClient).SendCoins("address", 1000, 2)
+
+			assert.True(t, errors.Is(err, errPaymentUnknown), name)
+		}
+
+		mockHttpClient := mocks.NewHttpClient(t)
+		mockHttpClient.On("Do", mock.AnythingOfType("*http.Request")).Return(nil, errors.New("connection reset")).Once()
+
+		_, err := NewOnchainService(mockHttpClient).SendCoins("address", 1000, 2)
+
+		assert.True(t, errors.Is(err, errPaymentUnknown))
+	})
+}
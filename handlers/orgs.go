@@ -0,0 +1,391 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"gorm.io/gorm"
+)
+
+type orgHandler struct {
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewOrgHandler(database db.Database) *orgHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &orgHandler{
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// getOrgFromParam writes the error response and returns false when the
+// organization of the uuid param does not exist
+func (oh *orgHandler) getOrgFromParam(w http.ResponseWriter, r *http.Request) (db.ParentOrganization, bool) {
+	org, err := oh.db.GetParentOrganizationByUuid(chi.URLParam(r, "uuid"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Organization not found")
+		return org, false
+	}
+	return org, true
+}
+
+// canViewOrg tells if a user is the owner or a member of an organization
+func (oh *orgHandler) canViewOrg(pubkey string, org db.ParentOrganization) bool {
+	return pubkey == org.OwnerPubKey || len(oh.db.GetParentOrganizationMemberRoles(org.Uuid, pubkey)) > 0
+}
+
+// canManageOrg tells if a user is the owner of an organization or has its edit role
+func (oh *orgHandler) canManageOrg(pubkey string, org db.ParentOrganization) bool {
+	if pubkey == org.OwnerPubKey {
+		return true
+	}
+	for _, member := range oh.db.GetParentOrganizationMemberRoles(org.Uuid, pubkey) {
+		if member.Role == db.EditOrg {
+			return true
+		}
+	}
+	return false
+}
+
+func (oh *orgHandler) orgHasWorkspace(org db.ParentOrganization, workspaceUuid string) bool {
+	for _, workspace := range oh.db.GetParentOrganizationWorkspaces(org.Uuid) {
+		if workspace.Uuid == workspaceUuid {
+			return true
+		}
+	}
+	return false
+}
+
+func (oh *orgHandler) CreateOrg(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[orgs] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	org := db.ParentOrganization{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &org)
+	}
+	if err != nil {
+		fmt.Println("[orgs]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	org.Name = strings.TrimSpace(org.Name)
+	if org.Name == "" || len(org.Name) > 100 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Name must have between 1 and 100 characters")
+		return
+	}
+	org.OwnerPubKey = pubKeyFromAuth
+
+	org, err = oh.db.CreateParentOrganization(org)
+	if err != nil {
+		fmt.Println("[orgs] could not create organization", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not create the organization")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(org)
+}
+
+// GetOrgOverview rolls up the budgets and bounty metrics of all the workspaces of an organization
+func (oh *orgHandler) GetOrgOverview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[orgs] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	org, ok := oh.getOrgFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !oh.canViewOrg(pubKeyFromAuth, org) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to this organization")
+		return
+	}
+
+	overview, err := oh.db.GetParentOrganizationOverview(org.Uuid)
+	if err != nil {
+		fmt.Println("[orgs] could not get overview", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not get the organization overview")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(overview)
+}
+
+// AddOrgWorkspace puts a workspace under an organization, it takes a manager of
+// the organization who also owns the workspace
+func (oh *orgHandler) AddOrgWorkspace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[orgs] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	org, ok := oh.getOrgFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	request := db.OrgWorkspaceRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[orgs]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	workspace := oh.db.GetWorkspaceByUuid(request.WorkspaceUuid)
+	if workspace.Uuid == "" || workspace.Deleted {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+
+	if !oh.canManageOrg(pubKeyFromAuth, org) || workspace.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the workspace owner can add it to an organization they manage")
+		return
+	}
+
+	err = oh.db.AddWorkspaceToParentOrganization(org.Uuid, workspace.Uuid)
+	if errors.Is(err, db.ErrWorkspaceHasParent) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[orgs] could not add workspace", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not add the workspace")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(oh.db.GetParentOrganizationWorkspaces(org.Uuid))
+}
+
+func (oh *orgHandler) RemoveOrgWorkspace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[orgs] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	org, ok := oh.getOrgFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !oh.canManageOrg(pubKeyFromAuth, org) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage this organization")
+		return
+	}
+
+	err := oh.db.RemoveWorkspaceFromParentOrganization(org.Uuid, chi.URLParam(r, "workspace_uuid"))
+	if errors.Is(err, db.ErrWorkspaceNotInOrganization) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[orgs] could not remove workspace", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not remove the workspace")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Workspace removed from the organization")
+}
+
+func (oh *orgHandler) GetOrgMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[orgs] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	org, ok := oh.getOrgFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !oh.canViewOrg(pubKeyFromAuth, org) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to this organization")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(oh.db.GetParentOrganizationMembers(org.Uuid))
+}
+
+// SetOrgMemberRoles replaces the roles a user inherits on every workspace of an organization
+func (oh *orgHandler) SetOrgMemberRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[orgs] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	org, ok := oh.getOrgFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !oh.canManageOrg(pubKeyFromAuth, org) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage this organization")
+		return
+	}
+
+	request := db.ParentOrganizationMemberRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[orgs]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if request.OwnerPubKey == "" || request.OwnerPubKey == org.OwnerPubKey {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A member other than the owner is required")
+		return
+	}
+
+	rolesMap := db.GetRolesMap()
+	seen := map[string]bool{}
+	roles := []string{}
+	for _, role := range request.Roles {
+		if _, ok := rolesMap[role]; !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(fmt.Sprintf("Unknown role %q", role))
+			return
+		}
+		if !seen[role] {
+			seen[role] = true
+			roles = append(roles, role)
+		}
+	}
+
+	members, err := oh.db.SetParentOrganizationMemberRoles(org.Uuid, request.OwnerPubKey, roles)
+	if err != nil {
+		fmt.Println("[orgs] could not set member roles", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not set the member roles")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(members)
+}
+
+// TransferOrgFeature moves a feature between two workspaces of an organization,
+// the caller needs to edit both workspaces
+func (oh *orgHandler) TransferOrgFeature(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[orgs] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	org, ok := oh.getOrgFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	request := db.OrgWorkspaceRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[orgs]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	feature := oh.db.GetFeatureByUuid(chi.URLParam(r, "feature_uuid"))
+	if feature.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Feature not found")
+		return
+	}
+
+	if feature.WorkspaceUuid == request.WorkspaceUuid {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Feature is already in this workspace")
+		return
+	}
+
+	if !oh.orgHasWorkspace(org, feature.WorkspaceUuid) || !oh.orgHasWorkspace(org, request.WorkspaceUuid) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Both workspaces must belong to the organization")
+		return
+	}
+
+	if !oh.userHasAccess(pubKeyFromAuth, feature.WorkspaceUuid, db.EditOrg) || !oh.userHasAccess(pubKeyFromAuth, request.WorkspaceUuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to edit both workspaces")
+		return
+	}
+
+	feature, err = oh.db.TransferFeature(feature.Uuid, request.WorkspaceUuid)
+	if err != nil {
+		fmt.Println("[orgs] could not transfer feature", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not transfer the feature")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(feature)
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var testOrg = db.ParentOrganization{Uuid: "org_uuid", Name: "Stakwork", OwnerPubKey: "owner_pubkey"}
+
+func newOrgRequest(pubkey string, body string, params map[string]string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("uuid", testOrg.Uuid)
+	for k, v := range params {
+		rctx.URLParams.Add(k, v)
+	}
+	ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+	req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/", bytes.NewBufferString(body))
+	return req
+}
+
+func TestCreateOrg(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewOrgHandler(mockDb)
+
+	t.Run("should require a name", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.CreateOrg).ServeHTTP(rr, newOrgRequest("owner_pubkey", `{"name": "  "}`, nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should create the organization owned by the caller", func(t *testing.T) {
+		mockDb.On("CreateParentOrganization", db.ParentOrganization{Name: "Stakwork", OwnerPubKey: "owner_pubkey"}).Return(testOrg, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.CreateOrg).ServeHTTP(rr, newOrgRequest("owner_pubkey", `{"name": "Stakwork", "owner_pubkey": "someone_else"}`, nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestGetOrgOverview(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewOrgHandler(mockDb)
+
+	t.Run("should return 404 for an unknown organization", func(t *testing.T) {
+		mockDb.On("GetParentOrganizationByUuid", testOrg.Uuid).Return(db.ParentOrganization{}, db.ErrParentOrganizationNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetOrgOverview).ServeHTTP(rr, newOrgRequest("owner_pubkey", "", nil))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should only show the overview to members", func(t *testing.T) {
+		mockDb.On("GetParentOrganizationByUuid", testOrg.Uuid).Return(testOrg, nil).Once()
+		mockDb.On("GetParentOrganizationMemberRoles", testOrg.Uuid, "other_pubkey").Return([]db.ParentOrganizationMember{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetOrgOverview).ServeHTTP(rr, newOrgRequest("other_pubkey", "", nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should roll up the workspaces for a member", func(t *testing.T) {
+		overview := db.ParentOrganizationOverview{
+			Organization: testOrg,
+			Workspaces: []db.OrgWorkspaceSummary{
+				{Uuid: "workspace_1", Budget: 1000, BountyCount: 2},
+				{Uuid: "workspace_2", Budget: 500, BountyCount: 1},
+			},
+			TotalBudget: 1500,
+			TotalPaid:   300,
+			Bounties:    []db.OrgBountyStateCount{{State: db.BountyStatePaid, Count: 1, Amount: 300}},
+		}
+		mockDb.On("GetParentOrganizationByUuid", testOrg.Uuid).Return(testOrg, nil).Once()
+		mockDb.On("GetParentOrganizationMemberRoles", testOrg.Uuid, "member_pubkey").Return([]db.ParentOrganizationMember{{Role: db.ViewReport}}).Once()
+		mockDb.On("GetParentOrganizationOverview", testOrg.Uuid).Return(overview, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetOrgOverview).ServeHTTP(rr, newOrgRequest("member_pubkey", "", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		returned := db.ParentOrganizationOverview{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &returned))
+		assert.Equal(t, uint(1500), returned.TotalBudget)
+		assert.Len(t, returned.Workspaces, 2)
+	})
+}
+
+func TestAddOrgWorkspace(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewOrgHandler(mockDb)
+
+	t.Run("should need the owner of the workspace", func(t *testing.T) {
+		mockDb.On("GetParentOrganizationByUuid", testOrg.Uuid).Return(testOrg, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "someone_else"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.AddOrgWorkspace).ServeHTTP(rr, newOrgRequest("owner_pubkey", `{"workspace_uuid": "workspace_uuid"}`, nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should not take a workspace of another organization", func(t *testing.T) {
+		mockDb.On("GetParentOrganizationByUuid", testOrg.Uuid).Return(testOrg, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}).Once()
+		mockDb.On("AddWorkspaceToParentOrganization", testOrg.Uuid, "workspace_uuid").Return(db.ErrWorkspaceHasParent).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.AddOrgWorkspace).ServeHTTP(rr, newOrgRequest("owner_pubkey", `{"workspace_uuid": "workspace_uuid"}`, nil))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should add the workspace", func(t *testing.T) {
+		mockDb.On("GetParentOrganizationByUuid", testOrg.Uuid).Return(testOrg, nil).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}).Once()
+		mockDb.On("AddWorkspaceToParentOrganization", testOrg.Uuid, "workspace_uuid").Return(nil).Once()
+		mockDb.On("GetParentOrganizationWorkspaces", testOrg.Uuid).Return([]db.Workspace{{Uuid: "workspace_uuid"}}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.AddOrgWorkspace).ServeHTTP(rr, newOrgRequest("owner_pubkey", `{"workspace_uuid": "workspace_uuid"}`, nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestSetOrgMemberRoles(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewOrgHandler(mockDb)
+
+	t.Run("should reject unknown roles", func(t *testing.T) {
+		mockDb.On("GetParentOrganizationByUuid", testOrg.Uuid).Return(testOrg, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.SetOrgMemberRoles).ServeHTTP(rr, newOrgRequest("owner_pubkey", `{"owner_pubkey": "member_pubkey", "roles": ["SUPER USER"]}`, nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should let an org editor set deduplicated roles", func(t *testing.T) {
+		mockDb.On("GetParentOrganizationByUuid", testOrg.Uuid).Return(testOrg, nil).Once()
+		mockDb.On("GetParentOrganizationMemberRoles", testOrg.Uuid, "editor_pubkey").Return([]db.ParentOrganizationMember{{Role: db.EditOrg}}).Once()
+		mockDb.On("SetParentOrganizationMemberRoles", testOrg.Uuid, "member_pubkey", []string{db.AddBounty, db.ViewReport}).Return([]db.ParentOrganizationMember{}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		body := `{"owner_pubkey": "member_pubkey", "roles": ["ADD BOUNTY", "VIEW REPORT", "ADD BOUNTY"]}`
+		http.HandlerFunc(oHandler.SetOrgMemberRoles).ServeHTTP(rr, newOrgRequest("editor_pubkey", body, nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestTransferOrgFeature(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewOrgHandler(mockDb)
+	oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "owner_pubkey" && role == db.EditOrg
+	}
+
+	feature := db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_1"}
+	params := map[string]string{"feature_uuid": "feature_uuid"}
+
+	t.Run("should only move features between workspaces of the organization", func(t *testing.T) {
+		mockDb.On("GetParentOrganizationByUuid", testOrg.Uuid).Return(testOrg, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(feature).Once()
+		mockDb.On("GetParentOrganizationWorkspaces", testOrg.Uuid).Return([]db.Workspace{{Uuid: "workspace_1"}}).Twice()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.TransferOrgFeature).ServeHTTP(rr, newOrgRequest("owner_pubkey", `{"workspace_uuid": "outside"}`, params))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should move the feature", func(t *testing.T) {
+		mockDb.On("GetParentOrganizationByUuid", testOrg.Uuid).Return(testOrg, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(feature).Once()
+		mockDb.On("GetParentOrganizationWorkspaces", testOrg.Uuid).Return([]db.Workspace{{Uuid: "workspace_1"}, {Uuid: "workspace_2"}}).Twice()
+		mockDb.On("TransferFeature", "feature_uuid", "workspace_2").Return(db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_2"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.TransferOrgFeature).ServeHTTP(rr, newOrgRequest("owner_pubkey", `{"workspace_uuid": "workspace_2"}`, params))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		moved := db.WorkspaceFeatures{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &moved))
+		assert.Equal(t, "workspace_2", moved.WorkspaceUuid)
+	})
+
+	t.Run("should need edit access on both workspaces", func(t *testing.T) {
+		mockDb.On("GetParentOrganizationByUuid", testOrg.Uuid).Return(testOrg, nil).Once()
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(feature).Once()
+		mockDb.On("GetParentOrganizationWorkspaces", testOrg.Uuid).Return([]db.Workspace{{Uuid: "workspace_1"}, {Uuid: "workspace_2"}}).Twice()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.TransferOrgFeature).ServeHTTP(rr, newOrgRequest("member_pubkey", `{"workspace_uuid": "workspace_2"}`, params))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
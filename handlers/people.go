@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -60,6 +61,25 @@ func (ph *peopleHandler) CreateOrEditPerson(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if msg := validateAvailability(person); msg != "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": msg})
+		return
+	}
+	if person.AvailabilityStatus != db.AvailabilityBusy {
+		person.BusyUntil = nil
+	}
+	if person.LightningAddress != "" && !utils.IsLightningAddress(person.LightningAddress) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "lightning_address must look like name@domain.com"})
+		return
+	}
+	if person.OnchainAddress != "" && !utils.IsBitcoinAddress(person.OnchainAddress) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "onchain_address is not a bitcoin address"})
+		return
+	}
+
 	existing := ph.db.GetPersonByPubkey(pubKeyFromAuth)
 	if existing.ID == 0 {
 		if person.ID != 0 {
@@ -95,6 +115,9 @@ func (ph *peopleHandler) CreateOrEditPerson(w http.ResponseWriter, r *http.Reque
 
 	person.OwnerPubKey = pubKeyFromAuth
 	person.Updated = &now
+	// the handle is claimed through SetPersonHandle only
+	person.Handle = existing.Handle
+	person.HandleUpdated = existing.HandleUpdated
 
 	if person.NewTicketTime != 0 {
 		go ph.db.ProcessAlerts(person)
@@ -117,6 +140,16 @@ func (ph *peopleHandler) CreateOrEditPerson(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(p)
 }
 
+func validateAvailability(person db.Person) string {
+	if !db.ValidAvailabilityStatus(person.AvailabilityStatus) {
+		return "availability_status must be one of " + strings.Join(db.AvailabilityStatuses, ", ")
+	}
+	if person.WeeklyHours < 0 || person.WeeklyHours > db.MaxWeeklyHours {
+		return fmt.Sprintf("weekly_hours must be between 0 and %d", db.MaxWeeklyHours)
+	}
+	return ""
+}
+
 func (ph *peopleHandler) UpsertLogin(w http.ResponseWriter, r *http.Request) {
 	person := db.Person{}
 	body, err := io.ReadAll(r.Body)
@@ -154,6 +187,9 @@ func (ph *peopleHandler) UpsertLogin(w http.ResponseWriter, r *http.Request) {
 
 	person.OwnerPubKey = pubKeyFromAuth
 	person.Updated = &now
+	// the handle is claimed through SetPersonHandle only
+	person.Handle = existing.Handle
+	person.HandleUpdated = existing.HandleUpdated
 
 	if person.NewTicketTime != 0 {
 		go ph.db.ProcessAlerts(person)
@@ -415,10 +451,73 @@ func (ph *peopleHandler) GetPersonByPubkey(w http.ResponseWriter, r *http.Reques
 	pubkey := chi.URLParam(r, "pubkey")
 
 	person := ph.db.GetPersonByPubkey(pubkey)
+	if person.ID != 0 {
+		activeBounties := ph.db.GetActiveAssignedBountiesCount(person.OwnerPubKey)
+		person.Availability = db.EffectiveAvailability(person, activeBounties, time.Now())
+	}
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(person)
 }
 
+// GetPersonPortfolio returns the completed work of a hunter,
+// respecting the privacy settings of their profile
+func (ph *peopleHandler) GetPersonPortfolio(w http.ResponseWriter, r *http.Request) {
+	pubkey := chi.URLParam(r, "pubkey")
+
+	person := ph.db.GetPersonByPubkey(pubkey)
+	if person.ID == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Person not found")
+		return
+	}
+
+	if person.PortfolioPrivate {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode("Portfolio is private")
+		return
+	}
+
+	bounties := ph.db.GetCompletedBountiesByAssignee(pubkey)
+
+	portfolio := db.PersonPortfolio{
+		OwnerPubKey:            person.OwnerPubKey,
+		OwnerAlias:             person.OwnerAlias,
+		CompletedBountiesCount: len(bounties),
+		CompletedBounties:      bounties,
+		Languages:              []string{},
+		Workspaces:             []db.PortfolioWorkspace{},
+	}
+
+	languages := map[string]bool{}
+	workspaces := map[string]bool{}
+	for _, bounty := range bounties {
+		for _, language := range bounty.CodingLanguages {
+			if language != "" && !languages[language] {
+				languages[language] = true
+				portfolio.Languages = append(portfolio.Languages, language)
+			}
+		}
+
+		if bounty.WorkspaceUuid != "" && !workspaces[bounty.WorkspaceUuid] {
+			workspaces[bounty.WorkspaceUuid] = true
+			workspace := ph.db.GetWorkspaceByUuid(bounty.WorkspaceUuid)
+			portfolio.Workspaces = append(portfolio.Workspaces, db.PortfolioWorkspace{
+				Uuid: workspace.Uuid,
+				Name: workspace.Name,
+				Img:  workspace.Img,
+			})
+		}
+	}
+
+	if !person.HideEarnings {
+		totalEarned := ph.db.GetTotalEarnedByPubkey(pubkey)
+		portfolio.TotalEarned = &totalEarned
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(portfolio)
+}
+
 func (ph *peopleHandler) GetPersonById(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	id, _ := strconv.ParseUint(idParam, 10, 32)
@@ -524,71 +623,11 @@ func (ph *peopleHandler) DeletePerson(w http.ResponseWriter, r *http.Request) {
 }
 
 func GetAssetByPubkey(pubkey string) ([]db.AssetBalanceData, error) {
-	client := &http.Client{}
-	testMode, err := strconv.ParseBool(os.Getenv("TEST_MODE"))
-	if err != nil {
-		testMode = false
-	}
-
-	url := os.Getenv(liquidTestModeUrl)
-	if testMode && (url != "") {
-		url = os.Getenv(liquidTestModeUrl)
-	} else {
-		url = "https://liquid.sphinx.chat/balances?pubkey=" + pubkey
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-
-	resp, err := client.Do(req)
-
-	if err != nil {
-		fmt.Println("GET error:", err)
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var r db.AssetResponse
-	body, err := io.ReadAll(resp.Body)
-	err = json.Unmarshal(body, &r)
-	if err != nil {
-		fmt.Println("json unmarshall error", err)
-		return nil, err
-	}
-
-	balances := r.Balances
-
-	return balances, nil
+	return NewAssetService(&http.Client{}).GetBalances(pubkey)
 }
 
 func GetAssetList(pubkey string) ([]db.AssetListData, error) {
-	client := &http.Client{}
-
-	url := os.Getenv("ASSET_LIST_URL")
-	if url == "" {
-		url = "https://liquid.sphinx.chat/assets"
-	}
-
-	url = url + "?pubkey=" + pubkey
-
-	req, err := http.NewRequest("GET", url, nil)
-
-	resp, err := client.Do(req)
-
-	if err != nil {
-		fmt.Println("GET error:", err)
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var r []db.AssetListData
-	body, err := io.ReadAll(resp.Body)
-	err = json.Unmarshal(body, &r)
-	if err != nil {
-		fmt.Println("json unmarshall error", err)
-		return nil, err
-	}
-
-	return r, nil
+	return NewAssetService(&http.Client{}).GetAssets(pubkey)
 }
 
 func AddOrRemoveBadge(w http.ResponseWriter, r *http.Request) {
@@ -693,15 +732,35 @@ func GetPeopleShortList(w http.ResponseWriter, r *http.Request) {
 }
 
 func (ph *peopleHandler) GetPeopleBySearch(w http.ResponseWriter, r *http.Request) {
+	if !validListParams(w, r) || !validAvailabilityParams(w, r) {
+		return
+	}
+
 	people := ph.db.GetPeopleBySearch(r)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(people)
 }
 
 func (ph *peopleHandler) GetListedPeople(w http.ResponseWriter, r *http.Request) {
-	people := ph.db.GetListedPeople(r)
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(people)
+	if !validListParams(w, r) || !validAvailabilityParams(w, r) {
+		return
+	}
+
+	stream := utils.NewJSONStream(w, r)
+	err := ph.db.StreamListedPeople(r, func(people []db.Person) error {
+		for _, person := range people {
+			if err := stream.Write(person); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Println("[people] could not stream people", err)
+		stream.Abort()
+		return
+	}
+	stream.Close()
 }
 
 func GetListedPosts(w http.ResponseWriter, r *http.Request) {
@@ -713,3 +772,90 @@ func GetListedPosts(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(people)
 	}
 }
+
+// GetMyBoard returns the bounties the caller is assigned to or created across all
+// their workspaces, grouped by state and sorted by due date, undated ones last
+func (ph *peopleHandler) GetMyBoard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[people] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	board := db.PersonBoard{
+		Workspaces: []db.PortfolioWorkspace{},
+		Columns:    []db.PersonBoardColumn{},
+	}
+
+	columns := map[db.BountyState][]db.PersonBoardBounty{}
+	workspaceNames := map[string]string{}
+	for _, bounty := range ph.db.GetPersonBoardBounties(pubKeyFromAuth) {
+		name, ok := workspaceNames[bounty.WorkspaceUuid]
+		if !ok {
+			workspace := ph.db.GetWorkspaceByUuid(bounty.WorkspaceUuid)
+			name = workspace.Name
+			workspaceNames[bounty.WorkspaceUuid] = name
+			board.Workspaces = append(board.Workspaces, db.PortfolioWorkspace{
+				Uuid: workspace.Uuid,
+				Name: workspace.Name,
+				Img:  workspace.Img,
+			})
+		}
+
+		role := "author"
+		if bounty.Assignee == pubKeyFromAuth {
+			role = "assignee"
+		}
+
+		state := bounty.CurrentState()
+		bounty.Overdue = bounty.IsOverdue(time.Now())
+		columns[state] = append(columns[state], db.PersonBoardBounty{
+			NewBounty:     bounty,
+			Role:          role,
+			WorkspaceName: name,
+			DueDate:       bounty.EffectiveDueDate(),
+		})
+	}
+
+	for _, state := range db.BoardStates {
+		bounties := columns[state]
+		if bounties == nil {
+			bounties = []db.PersonBoardBounty{}
+		}
+		sort.SliceStable(bounties, func(i, j int) bool {
+			a, b := bounties[i].DueDate, bounties[j].DueDate
+			if a == nil || b == nil {
+				return a != nil
+			}
+			return a.Before(*b)
+		})
+		board.Columns = append(board.Columns, db.PersonBoardColumn{State: state, Count: len(bounties), Bounties: bounties})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(board)
+}
+
+// GetMyEarnings summarizes the payouts of the signed in person across their
+// workspaces, in total, per workspace and per month
+func (ph *peopleHandler) GetMyEarnings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[people] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	earnings := db.SummarizeEarnings(ph.db.GetPersonEarnings(pubKeyFromAuth))
+	for i, workspace := range earnings.Workspaces {
+		if workspace.WorkspaceUuid != "" {
+			earnings.Workspaces[i].WorkspaceName = ph.db.GetWorkspaceByUuid(workspace.WorkspaceUuid).Name
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(earnings)
+}
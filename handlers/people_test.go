@@ -4,17 +4,24 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"github.com/go-chi/chi"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetPersonByPuKey(t *testing.T) {
@@ -602,3 +609,497 @@ func TestGetPersonByUuid(t *testing.T) {
 		assert.Empty(t, returnedPerson)
 	})
 }
+
+func TestGetPersonPortfolio(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	pHandler := NewPeopleHandler(mockDb)
+
+	newRequest := func() *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("pubkey", "hunter_pubkey")
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/hunter_pubkey/portfolio", nil)
+		return req
+	}
+
+	t.Run("should return 403 for a private portfolio", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "hunter_pubkey").Return(db.Person{ID: 1, OwnerPubKey: "hunter_pubkey", PortfolioPrivate: true}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetPersonPortfolio).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should aggregate completed bounties and hide earnings", func(t *testing.T) {
+		mockDb.On("GetPersonByPubkey", "hunter_pubkey").Return(db.Person{ID: 1, OwnerPubKey: "hunter_pubkey", HideEarnings: true}).Once()
+		mockDb.On("GetCompletedBountiesByAssignee", "hunter_pubkey").Return([]db.NewBounty{
+			{ID: 1, WorkspaceUuid: "workspace_1", CodingLanguages: pq.StringArray{"Go", "Typescript"}},
+			{ID: 2, WorkspaceUuid: "workspace_1", CodingLanguages: pq.StringArray{"Go"}},
+		}).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_1").Return(db.Workspace{Uuid: "workspace_1", Name: "Workspace"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetPersonPortfolio).ServeHTTP(rr, newRequest())
+
+		var portfolio db.PersonPortfolio
+		json.Unmarshal(rr.Body.Bytes(), &portfolio)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 2, portfolio.CompletedBountiesCount)
+		assert.Equal(t, []string{"Go", "Typescript"}, portfolio.Languages)
+		assert.Len(t, portfolio.Workspaces, 1)
+		assert.Nil(t, portfolio.TotalEarned)
+	})
+}
+
+func TestGetListedPeopleInvalidParams(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	pHandler := NewPeopleHandler(mockDb)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/people?page=first&direction=sideways", nil)
+
+	http.HandlerFunc(pHandler.GetListedPeople).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "page")
+	assert.Contains(t, rr.Body.String(), "direction")
+}
+
+func TestGetListedPeopleStream(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	pHandler := NewPeopleHandler(mockDb)
+
+	t.Run("should stream every batch as ndjson", func(t *testing.T) {
+		mockDb.On("StreamListedPeople", mock.Anything, mock.Anything).Return(func(r *http.Request, each func([]db.Person) error) error {
+			if err := each([]db.Person{{OwnerAlias: "first"}, {OwnerAlias: "second"}}); err != nil {
+				return err
+			}
+			return each([]db.Person{{OwnerAlias: "third"}})
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/people?format=ndjson", nil)
+		http.HandlerFunc(pHandler.GetListedPeople).ServeHTTP(rr, req)
+
+		lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, lines, 3)
+		assert.Contains(t, lines[2], `"owner_alias":"third"`)
+	})
+
+	t.Run("should answer 500 when the people can't be read", func(t *testing.T) {
+		mockDb.On("StreamListedPeople", mock.Anything, mock.Anything).Return(errors.New("connection lost")).Once()
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/people", nil)
+		http.HandlerFunc(pHandler.GetListedPeople).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestPersonAvailability(t *testing.T) {
+	t.Run("should return the effective availability of a person", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		pHandler := NewPeopleHandler(mockDb)
+
+		person := db.Person{ID: 1, OwnerPubKey: "hunter_pubkey", AvailabilityStatus: db.AvailabilityAvailable}
+		mockDb.On("GetPersonByPubkey", person.OwnerPubKey).Return(person).Once()
+		mockDb.On("GetActiveAssignedBountiesCount", person.OwnerPubKey).Return(int64(config.BusyBountyThreshold + 1)).Once()
+
+		rr := httptest.NewRecorder()
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("pubkey", person.OwnerPubKey)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/person/"+person.OwnerPubKey, nil)
+
+		http.HandlerFunc(pHandler.GetPersonByPubkey).ServeHTTP(rr, req)
+
+		var returned db.Person
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &returned))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, db.AvailabilityAvailable, returned.AvailabilityStatus)
+		assert.Equal(t, db.AvailabilityBusy, returned.Availability)
+	})
+
+	t.Run("should reject an unknown availability status", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		pHandler := NewPeopleHandler(mockDb)
+
+		body := []byte(`{"owner_pubkey": "hunter_pubkey", "availability_status": "sleeping"}`)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "hunter_pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/person", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(pHandler.CreateOrEditPerson).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "availability_status")
+	})
+
+	t.Run("should reject more weekly hours than a week has", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		pHandler := NewPeopleHandler(mockDb)
+
+		body := []byte(`{"owner_pubkey": "hunter_pubkey", "weekly_hours": 200}`)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "hunter_pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/person", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(pHandler.CreateOrEditPerson).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "weekly_hours")
+	})
+
+	t.Run("should reject an unknown availability filter", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		pHandler := NewPeopleHandler(mockDb)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/people/search?availability=asleep&min_weekly_hours=many", nil)
+
+		http.HandlerFunc(pHandler.GetPeopleBySearch).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "availability")
+		assert.Contains(t, rr.Body.String(), "min_weekly_hours")
+	})
+}
+
+func TestGetMyBoard(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	pHandler := NewPeopleHandler(mockDb)
+
+	newRequest := func(pubkey string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/me/board", nil)
+		return req
+	}
+
+	t.Run("should return 401 without a pubkey", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetMyBoard).ServeHTTP(rr, newRequest(""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should group bounties of all workspaces by state and sort them by due date", func(t *testing.T) {
+		mockDb.On("GetPersonBoardBounties", "hunter_pubkey").Return([]db.NewBounty{
+			{ID: 1, WorkspaceUuid: "workspace_1", Assignee: "hunter_pubkey", State: db.BountyStateAssigned},
+			{ID: 2, WorkspaceUuid: "workspace_2", Assignee: "hunter_pubkey", State: db.BountyStateAssigned, EstimatedCompletionDate: "2024-06-10"},
+			{ID: 3, WorkspaceUuid: "workspace_1", Assignee: "hunter_pubkey", State: db.BountyStateAssigned, EstimatedCompletionDate: "2024-06-01T10:00:00Z"},
+			{ID: 4, WorkspaceUuid: "workspace_2", OwnerID: "hunter_pubkey", State: db.BountyStateOpen},
+		}).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_1").Return(db.Workspace{Uuid: "workspace_1", Name: "One"}).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_2").Return(db.Workspace{Uuid: "workspace_2", Name: "Two"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetMyBoard).ServeHTTP(rr, newRequest("hunter_pubkey"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		board := db.PersonBoard{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &board))
+		assert.Len(t, board.Workspaces, 2)
+		assert.Len(t, board.Columns, len(db.BoardStates))
+
+		open := board.Columns[0]
+		assert.Equal(t, db.BountyStateOpen, open.State)
+		assert.Equal(t, 1, open.Count)
+		assert.Equal(t, "author", open.Bounties[0].Role)
+		assert.Equal(t, "Two", open.Bounties[0].WorkspaceName)
+
+		assigned := board.Columns[1]
+		assert.Equal(t, db.BountyStateAssigned, assigned.State)
+		ids := []uint{}
+		for _, b := range assigned.Bounties {
+			ids = append(ids, b.ID)
+			assert.Equal(t, "assignee", b.Role)
+		}
+		assert.Equal(t, []uint{3, 2, 1}, ids)
+		assert.Empty(t, board.Columns[4].Bounties)
+	})
+}
+
+func TestGetMyEarnings(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	pHandler := NewPeopleHandler(mockDb)
+
+	newRequest := func(pubkey string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/me/earnings", nil)
+		return req
+	}
+
+	t.Run("should return 401 without a pubkey", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetMyEarnings).ServeHTTP(rr, newRequest(""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should total the earnings per workspace and month", func(t *testing.T) {
+		mockDb.On("GetPersonEarnings", "hunter_pubkey").Return([]db.EarningsRow{
+			{WorkspaceUuid: "workspace_1", Month: "2026-08", EarningsAmounts: db.EarningsAmounts{Settled: 1000}},
+			{WorkspaceUuid: "workspace_2", Month: "2026-09", EarningsAmounts: db.EarningsAmounts{Settled: 500, InFlight: 20000}},
+			{WorkspaceUuid: "workspace_1", Month: "2026-10", EarningsAmounts: db.EarningsAmounts{Pending: 300}},
+			{WorkspaceUuid: "workspace_2", Month: "2026-08", EarningsAmounts: db.EarningsAmounts{Settled: 200}},
+		}).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_1").Return(db.Workspace{Uuid: "workspace_1", Name: "One"}).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_2").Return(db.Workspace{Uuid: "workspace_2", Name: "Two"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetMyEarnings).ServeHTTP(rr, newRequest("hunter_pubkey"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		earnings := db.PersonEarnings{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &earnings))
+		assert.Equal(t, db.EarningsAmounts{Pending: 300, InFlight: 20000, Settled: 1700}, earnings.Total)
+		assert.Equal(t, []db.WorkspaceEarnings{
+			{WorkspaceUuid: "workspace_1", WorkspaceName: "One", EarningsAmounts: db.EarningsAmounts{Pending: 300, Settled: 1000}},
+			{WorkspaceUuid: "workspace_2", WorkspaceName: "Two", EarningsAmounts: db.EarningsAmounts{InFlight: 20000, Settled: 700}},
+		}, earnings.Workspaces)
+		assert.Equal(t, []db.MonthlyEarnings{
+			{Month: "2026-08", EarningsAmounts: db.EarningsAmounts{Settled: 1200}},
+			{Month: "2026-09", EarningsAmounts: db.EarningsAmounts{InFlight: 20000, Settled: 500}},
+			{Month: "2026-10", EarningsAmounts: db.EarningsAmounts{Pending: 300}},
+		}, earnings.Months)
+	})
+}
+
+func TestGetMyCalendar(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	pHandler := NewPeopleHandler(mockDb)
+
+	newRequest := func(token string) *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, "/me/calendar.ics?token="+token, nil)
+		return req
+	}
+
+	t.Run("should return 401 for an invalid or revoked token", func(t *testing.T) {
+		mockDb.On("VerifyCalendarToken", "revoked").Return(db.CalendarToken{}, db.ErrCalendarTokenInvalid).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetMyCalendar).ServeHTTP(rr, newRequest("revoked"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should list bounty due dates and scheduled calls", func(t *testing.T) {
+		due := time.Date(2026, 11, 2, 17, 0, 0, 0, time.UTC)
+		scheduled := time.Date(2026, 10, 20, 15, 30, 0, 0, time.UTC)
+		mockDb.On("VerifyCalendarToken", "valid").Return(db.CalendarToken{Uuid: "token_uuid", OwnerPubKey: "hunter_pubkey"}, nil).Once()
+		mockDb.On("GetPersonCalendarBounties", "hunter_pubkey").Return([]db.NewBounty{
+			{ID: 7, Title: "Fix login, then logout", DueDate: &due},
+			{ID: 8, Title: "Docs", EstimatedCompletionDate: "2026-11-05"},
+		}).Once()
+		mockDb.On("GetPersonScheduledFeatureCalls", "hunter_pubkey", mock.AnythingOfType("time.Time")).Return([]db.FeatureCall{
+			{Uuid: "call_uuid", Title: "Weekly sync", Url: "https://meet.example.com/abc", ScheduledAt: &scheduled, DurationMinutes: 45, Recurrence: "RRULE:FREQ=WEEKLY;BYDAY=TU"},
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetMyCalendar).ServeHTTP(rr, newRequest("valid"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+
+		body := rr.Body.String()
+		assert.True(t, strings.HasPrefix(body, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(body, "END:VCALENDAR\r\n"))
+		assert.Contains(t, body, "DTSTART:20261102T170000Z\r\nSUMMARY:Due: Fix login\\, then logout\r\n")
+		assert.Contains(t, body, "DTSTART;VALUE=DATE:20261105\r\nSUMMARY:Due: Docs\r\n")
+		assert.Contains(t, body, "DTSTART:20261020T153000Z\r\nDTEND:20261020T161500Z\r\nRRULE:FREQ=WEEKLY;BYDAY=TU\r\nSUMMARY:Weekly sync\r\n")
+		assert.Equal(t, 3, strings.Count(body, "BEGIN:VEVENT"))
+	})
+
+	t.Run("should fold long lines", func(t *testing.T) {
+		due := time.Date(2026, 11, 2, 17, 0, 0, 0, time.UTC)
+		body := renderCalendar([]db.NewBounty{{ID: 1, Title: strings.Repeat("é", 60), DueDate: &due}}, nil, due)
+		for _, line := range strings.Split(strings.TrimSuffix(body, "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(line), 75)
+			assert.True(t, utf8.ValidString(line))
+		}
+		assert.Contains(t, strings.ReplaceAll(body, "\r\n ", ""), "SUMMARY:Due: "+strings.Repeat("é", 60)+"\r\n")
+	})
+}
+
+func TestCreateCalendarToken(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	pHandler := NewPeopleHandler(mockDb)
+	mockDb.On("CreateCalendarToken", "hunter_pubkey").Return(db.CalendarToken{Uuid: "token_uuid", OwnerPubKey: "hunter_pubkey"}, nil).Once()
+
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "hunter_pubkey")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/me/calendar/token", nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(pHandler.CreateCalendarToken).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	response := db.CalendarTokenResponse{}
+	json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.True(t, strings.HasPrefix(response.Token, "token_uuid."))
+	assert.Equal(t, db.CalendarTokenString(db.CalendarToken{Uuid: "token_uuid", OwnerPubKey: "hunter_pubkey"}), response.Token)
+	assert.NotEqual(t, db.CalendarTokenString(db.CalendarToken{Uuid: "token_uuid", OwnerPubKey: "other_pubkey"}), response.Token)
+	assert.Contains(t, response.Url, "/people/me/calendar.ics?token=token_uuid.")
+}
+
+func TestMergePeople(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	pHandler := NewPeopleHandler(mockDb)
+
+	newRequest := func(body interface{}) *http.Request {
+		requestBody, _ := json.Marshal(body)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "super_admin")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/admin/people/merge", bytes.NewReader(requestBody))
+		return req
+	}
+
+	t.Run("should require both pubkeys", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.MergePeople).ServeHTTP(rr, newRequest(db.PersonMergeRequest{SourcePubkey: "old_pubkey"}))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should map merge errors to statuses", func(t *testing.T) {
+		cases := map[error]int{
+			db.ErrPersonMergeSamePubkey: http.StatusBadRequest,
+			db.ErrPersonMergeNotFound:   http.StatusNotFound,
+		}
+		for err, status := range cases {
+			mockDb.On("MergePeople", "old_pubkey", "new_pubkey", "super_admin", false).Return(db.PersonMergeResult{}, err).Once()
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(pHandler.MergePeople).ServeHTTP(rr, newRequest(db.PersonMergeRequest{SourcePubkey: "old_pubkey", TargetPubkey: "new_pubkey"}))
+			assert.Equal(t, status, rr.Code, err.Error())
+		}
+	})
+
+	t.Run("should report the counts of a dry run", func(t *testing.T) {
+		counts := db.PersonMergeCounts{"bounty.assignee": 3, "workspace_users.owner_pub_key": 1}
+		mockDb.On("MergePeople", "old_pubkey", "new_pubkey", "super_admin", true).Return(db.PersonMergeResult{
+			SourcePubkey: "old_pubkey",
+			TargetPubkey: "new_pubkey",
+			DryRun:       true,
+			Counts:       counts,
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.MergePeople).ServeHTTP(rr, newRequest(db.PersonMergeRequest{SourcePubkey: "old_pubkey", TargetPubkey: "new_pubkey", DryRun: true}))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		result := db.PersonMergeResult{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.True(t, result.DryRun)
+		assert.Equal(t, counts, result.Counts)
+		assert.Nil(t, result.Merge)
+	})
+
+	t.Run("should return the audit record of a merge", func(t *testing.T) {
+		merge := &db.PersonMerge{ID: 1, SourcePubkey: "old_pubkey", TargetPubkey: "new_pubkey", MergedBy: "super_admin"}
+		mockDb.On("MergePeople", "old_pubkey", "new_pubkey", "super_admin", false).Return(db.PersonMergeResult{
+			SourcePubkey: "old_pubkey",
+			TargetPubkey: "new_pubkey",
+			Counts:       db.PersonMergeCounts{"bounty.assignee": 3},
+			Merge:        merge,
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.MergePeople).ServeHTTP(rr, newRequest(db.PersonMergeRequest{SourcePubkey: " old_pubkey ", TargetPubkey: "new_pubkey"}))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		result := db.PersonMergeResult{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, "super_admin", result.Merge.MergedBy)
+	})
+}
+
+func TestPersonHandle(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	pHandler := NewPeopleHandler(mockDb)
+	handle := "alice"
+
+	newRequest := func(method string, body interface{}) *http.Request {
+		requestBody, _ := json.Marshal(body)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "alice_pubkey")
+		req, _ := http.NewRequestWithContext(ctx, method, "/me/handle", bytes.NewReader(requestBody))
+		return req
+	}
+
+	t.Run("should look a person up by handle", func(t *testing.T) {
+		mockDb.On("GetPersonByHandle", "alice").Return(db.Person{ID: 1, OwnerPubKey: "alice_pubkey", Handle: &handle}).Once()
+		mockDb.On("GetActiveAssignedBountiesCount", "alice_pubkey").Return(int64(0)).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("handle", "alice")
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/handle/alice", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetPersonByHandle).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		person := db.Person{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &person))
+		assert.Equal(t, "alice", *person.Handle)
+	})
+
+	t.Run("should return 404 for an unknown handle", func(t *testing.T) {
+		mockDb.On("GetPersonByHandle", "nobody").Return(db.Person{}).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("handle", "nobody")
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/handle/nobody", nil)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetPersonByHandle).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should require a handle to set", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.SetMyHandle).ServeHTTP(rr, newRequest(http.MethodPut, db.HandleRequest{Handle: " @ "}))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should map handle errors to statuses", func(t *testing.T) {
+		cases := map[error]int{
+			db.ErrHandleInvalid:    http.StatusBadRequest,
+			db.ErrHandleNotAllowed: http.StatusBadRequest,
+			db.ErrHandleReserved:   http.StatusConflict,
+			db.ErrHandleTaken:      http.StatusConflict,
+			db.ErrHandleSquatting:  http.StatusConflict,
+			db.ErrHandleCooldown:   http.StatusTooManyRequests,
+		}
+		for err, status := range cases {
+			mockDb.On("SetPersonHandle", "alice_pubkey", "alice", mock.AnythingOfType("time.Time")).Return(db.Person{}, err).Once()
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(pHandler.SetMyHandle).ServeHTTP(rr, newRequest(http.MethodPut, db.HandleRequest{Handle: "alice"}))
+			assert.Equal(t, status, rr.Code, err.Error())
+		}
+	})
+
+	t.Run("should set and clear a handle", func(t *testing.T) {
+		mockDb.On("SetPersonHandle", "alice_pubkey", "alice", mock.AnythingOfType("time.Time")).Return(db.Person{ID: 1, Handle: &handle}, nil).Once()
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.SetMyHandle).ServeHTTP(rr, newRequest(http.MethodPut, db.HandleRequest{Handle: "alice"}))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		mockDb.On("SetPersonHandle", "alice_pubkey", "", mock.AnythingOfType("time.Time")).Return(db.Person{ID: 1}, nil).Once()
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(pHandler.ClearMyHandle).ServeHTTP(rr, newRequest(http.MethodDelete, nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		person := db.Person{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &person))
+		assert.Nil(t, person.Handle)
+	})
+
+	t.Run("should report alias collisions", func(t *testing.T) {
+		collisions := []db.AliasCollision{{Alias: "alice", People: []db.AliasCollisionPerson{
+			{OwnerPubKey: "pubkey_1", OwnerAlias: "Alice", SuggestedHandle: "alice"},
+			{OwnerPubKey: "pubkey_2", OwnerAlias: "alice", SuggestedHandle: "alice_pubkey"},
+		}}}
+		mockDb.On("GetAliasCollisions").Return(collisions, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.GetAliasCollisions).ServeHTTP(rr, newRequest(http.MethodGet, nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		returned := []db.AliasCollision{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &returned))
+		assert.Equal(t, collisions, returned)
+	})
+}
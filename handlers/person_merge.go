@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// MergePeople merges a duplicate person into the one that is kept, pointing its
+// bounties, payments and memberships at the target pubkey. A dry run reports
+// what would move without changing anything
+func (ph *peopleHandler) MergePeople(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[people] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.PersonMergeRequest{}
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err := json.Unmarshal(body, &request); err != nil {
+		fmt.Println("[people]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	source := strings.TrimSpace(request.SourcePubkey)
+	target := strings.TrimSpace(request.TargetPubkey)
+	if source == "" || target == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Source and target pubkeys are required")
+		return
+	}
+
+	result, err := ph.db.MergePeople(source, target, pubKeyFromAuth, request.DryRun)
+	switch {
+	case errors.Is(err, db.ErrPersonMergeSamePubkey):
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	case errors.Is(err, db.ErrPersonMergeNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	case err != nil:
+		fmt.Println("[people] could not merge people", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not merge the people")
+		return
+	}
+
+	if !request.DryRun {
+		fmt.Printf("[people] %s merged into %s by %s\n", source, target, pubKeyFromAuth)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// GetPersonMerges lists the audit records of past merges
+func (ph *peopleHandler) GetPersonMerges(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ph.db.GetPersonMerges())
+}
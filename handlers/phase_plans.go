@@ -0,0 +1,401 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+)
+
+// maxPlanTickets caps the drafts a plan can add to a phase
+const maxPlanTickets = 50
+
+type phasePlanHandler struct {
+	db            db.Database
+	httpClient    HttpClient
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewPhasePlanHandler(httpClient HttpClient, database db.Database) *phasePlanHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &phasePlanHandler{
+		db:            database,
+		httpClient:    httpClient,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// phasePlanContext is what the planning workflow knows of a phase: the brief of
+// its feature, the user stories and the repositories of the workspace
+func phasePlanContext(feature db.WorkspaceFeatures, phase db.FeaturePhase, stories []db.FeatureStory, repositories []db.WorkspaceRepositories) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\nPhase: %s\n", feature.Name, phase.Name)
+
+	sections := []struct{ title, content string }{
+		{"Brief", feature.Brief},
+		{"Requirements", feature.Requirements},
+		{"Architecture", feature.Architecture},
+	}
+	for _, section := range sections {
+		if strings.TrimSpace(section.content) != "" {
+			fmt.Fprintf(&b, "\n## %s\n\n%s\n", section.title, strings.TrimSpace(section.content))
+		}
+	}
+
+	if len(stories) > 0 {
+		b.WriteString("\n## User Stories\n\n")
+		for _, story := range stories {
+			fmt.Fprintf(&b, "- [%s] %s\n", story.Uuid, story.Description)
+		}
+	}
+
+	if len(repositories) > 0 {
+		b.WriteString("\n## Repositories\n\n")
+		for _, repository := range repositories {
+			fmt.Fprintf(&b, "- %s: %s\n", repository.Name, repository.Url)
+		}
+	}
+	return b.String()
+}
+
+// PlanPhase sends a phase to the planning workflow with its context, the
+// ticket drafts come back to the result url of the plan
+func (ph *phasePlanHandler) PlanPhase(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[plans] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	feature := ph.db.GetFeatureByUuid(chi.URLParam(r, "feature_uuid"))
+	if feature.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Feature not found")
+		return
+	}
+	phase, err := ph.db.GetFeaturePhaseByUuid(feature.Uuid, chi.URLParam(r, "phase_uuid"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Phase not found")
+		return
+	}
+
+	if !ph.userHasAccess(pubKeyFromAuth, feature.WorkspaceUuid, db.AddBounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to add bounties")
+		return
+	}
+
+	if config.PhasePlanWorkflowId == "" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode("Phase planning is not configured")
+		return
+	}
+
+	stories, err := ph.db.GetFeatureStoriesByFeatureUuid(feature.Uuid)
+	if err != nil {
+		fmt.Println("[plans] could not get stories", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not get the stories")
+		return
+	}
+	repositories := ph.db.GetWorkspaceRepositorByWorkspaceUuid(feature.WorkspaceUuid)
+
+	plan, err := ph.db.CreatePhasePlan(db.PhasePlan{
+		Uuid:          xid.New().String(),
+		FeatureUuid:   feature.Uuid,
+		PhaseUuid:     phase.Uuid,
+		WorkspaceUuid: feature.WorkspaceUuid,
+		Status:        db.PhasePlanPending,
+		Context:       phasePlanContext(feature, phase, stories, repositories),
+		CallbackToken: utils.GetRandomToken(32),
+		RequestedBy:   pubKeyFromAuth,
+	})
+	if err != nil {
+		fmt.Println("[plans] could not create plan", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not create the plan")
+		return
+	}
+
+	callback := fmt.Sprintf("%s/features/plans/%s/result?token=%s", config.Host, plan.Uuid, url.QueryEscape(plan.CallbackToken))
+	projectId, err := submitStakworkProject(ph.httpClient, fmt.Sprintf("Phase plan %s", phase.Name), config.PhasePlanWorkflowId, callback, map[string]string{
+		"feature_uuid":   feature.Uuid,
+		"phase_uuid":     phase.Uuid,
+		"workspace_uuid": feature.WorkspaceUuid,
+		"context":        plan.Context,
+		"result_url":     callback,
+	})
+	if err != nil {
+		fmt.Println("[plans] could not submit plan", plan.Uuid, err)
+		now := time.Now()
+		plan.Status = db.PhasePlanFailed
+		plan.Error = err.Error()
+		plan.Completed = &now
+	} else {
+		RecordWorkspaceUsage(feature.WorkspaceUuid, db.UsageStakworkRun)
+		plan.Status = db.PhasePlanSubmitted
+		plan.ProjectId = projectId
+	}
+
+	plan, err = ph.db.UpdatePhasePlan(plan)
+	if err != nil {
+		fmt.Println("[plans] could not update plan", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not update the plan")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(plan)
+}
+
+// GetPhasePlans lists the plans of a phase with the drafts they made
+func (ph *phasePlanHandler) GetPhasePlans(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[plans] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	feature := ph.db.GetFeatureByUuid(chi.URLParam(r, "feature_uuid"))
+	if feature.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Feature not found")
+		return
+	}
+	if !isWorkspaceMember(ph.db, pubKeyFromAuth, feature.WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the workspace")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ph.db.GetPhasePlans(feature.Uuid, chi.URLParam(r, "phase_uuid")))
+}
+
+// validatePlanTickets checks the drafts of a plan, the stories a draft covers
+// must be stories of the feature
+func validatePlanTickets(tickets []db.PlanTicketDraft, stories []db.FeatureStory) error {
+	if len(tickets) == 0 || len(tickets) > maxPlanTickets {
+		return fmt.Errorf("a plan has between 1 and %d tickets", maxPlanTickets)
+	}
+
+	known := map[string]bool{}
+	for _, story := range stories {
+		known[story.Uuid] = true
+	}
+	for i, ticket := range tickets {
+		if strings.TrimSpace(ticket.Title) == "" {
+			return fmt.Errorf("ticket %d has no title", i+1)
+		}
+		if ticket.EstimatedHours < 0 {
+			return fmt.Errorf("ticket %d has negative estimated hours", i+1)
+		}
+		for _, storyUuid := range ticket.StoryUuids {
+			if !known[storyUuid] {
+				return fmt.Errorf("ticket %d covers unknown story %s", i+1, storyUuid)
+			}
+		}
+	}
+	return nil
+}
+
+// ReceivePhasePlanResult saves the ticket drafts the planning workflow returns
+// as hidden bounties of the phase, authenticated by the token of the result url
+func (ph *phasePlanHandler) ReceivePhasePlanResult(w http.ResponseWriter, r *http.Request) {
+	plan, err := ph.db.GetPhasePlanByUuid(chi.URLParam(r, "plan_uuid"))
+	if errors.Is(err, db.ErrPhasePlanNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	token := r.URL.Query().Get("token")
+	if token == "" || !hmac.Equal([]byte(token), []byte(plan.CallbackToken)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Invalid token")
+		return
+	}
+
+	result := db.PhasePlanResult{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &result)
+	}
+	if err != nil {
+		fmt.Println("[plans]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if plan.Finished() {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(db.ErrPhasePlanFinished.Error())
+		return
+	}
+
+	switch result.Status {
+	case db.PhasePlanFailed:
+		now := time.Now()
+		plan.Status = db.PhasePlanFailed
+		plan.Error = result.Error
+		plan.Completed = &now
+		plan, err = ph.db.UpdatePhasePlan(plan)
+		if err != nil {
+			fmt.Println("[plans] could not update plan", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("Could not update the plan")
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(plan)
+		return
+	case db.PhasePlanCompleted:
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("status must be completed or failed")
+		return
+	}
+
+	stories, err := ph.db.GetFeatureStoriesByFeatureUuid(plan.FeatureUuid)
+	if err != nil {
+		fmt.Println("[plans] could not get stories", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if err := validatePlanTickets(result.Tickets, stories); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	now := time.Now()
+	tickets := make([]db.PhasePlanTicket, 0, len(result.Tickets))
+	for i, draft := range result.Tickets {
+		description := strings.TrimSpace(draft.Description)
+		if description == "" {
+			description = strings.TrimSpace(draft.Title)
+		}
+		storyUuids := draft.StoryUuids
+		if storyUuids == nil {
+			storyUuids = []string{}
+		}
+
+		tickets = append(tickets, db.PhasePlanTicket{
+			Bounty: db.NewBounty{
+				OwnerID:        plan.RequestedBy,
+				WorkspaceUuid:  plan.WorkspaceUuid,
+				PhaseUuid:      plan.PhaseUuid,
+				Type:           "coding",
+				Title:          strings.TrimSpace(draft.Title),
+				Description:    description,
+				EstimatedHours: draft.EstimatedHours,
+				Tribe:          "None",
+				Show:           false,
+				// created identifies a bounty, so drafts saved together get their own
+				Created: now.Unix() + int64(i),
+				Updated: &now,
+			},
+			Draft: db.PhasePlanDraft{
+				Position:   i,
+				StoryUuids: storyUuids,
+				Rationale:  draft.Rationale,
+			},
+		})
+	}
+
+	plan, err = ph.db.SavePhasePlanTickets(plan.Uuid, tickets)
+	if errors.Is(err, db.ErrPhasePlanFinished) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[plans] could not save drafts", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save the drafts")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(plan)
+}
+
+// RollbackTicketBatch deletes the drafts a plan made when the generation was
+// poor, the batch is the plan and it rolls back as a whole or not at all
+func (ph *phasePlanHandler) RollbackTicketBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[plans] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	plan, err := ph.db.GetPhasePlanByUuid(chi.URLParam(r, "id"))
+	if errors.Is(err, db.ErrPhasePlanNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !ph.userHasAccess(pubKeyFromAuth, plan.WorkspaceUuid, db.DeleteBounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to delete bounties")
+		return
+	}
+
+	result, err := ph.db.RollbackPhasePlan(plan.Uuid, pubKeyFromAuth, time.Now())
+	switch {
+	case errors.Is(err, db.ErrTicketBatchInUse):
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(result)
+		return
+	case errors.Is(err, db.ErrTicketBatchNotRollbackable):
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	case errors.Is(err, db.ErrPhasePlanNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	case err != nil:
+		fmt.Println("[plans] could not roll back batch", plan.Uuid, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not roll back the batch")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
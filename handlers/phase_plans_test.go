@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPhasePlanContext(t *testing.T) {
+	feature := db.WorkspaceFeatures{Name: "Lightning login", Brief: "Log in with a wallet", Architecture: " "}
+	phase := db.FeaturePhase{Name: "MVP"}
+	stories := []db.FeatureStory{{Uuid: "story_1", Description: "As a user I scan a QR code"}}
+	repositories := []db.WorkspaceRepositories{{Name: "frontend", Url: "https://github.com/stakwork/sphinx-tribes-frontend"}}
+
+	planContext := phasePlanContext(feature, phase, stories, repositories)
+	assert.Equal(t, "# Lightning login\n\nPhase: MVP\n\n## Brief\n\nLog in with a wallet\n"+
+		"\n## User Stories\n\n- [story_1] As a user I scan a QR code\n"+
+		"\n## Repositories\n\n- frontend: https://github.com/stakwork/sphinx-tribes-frontend\n", planContext)
+}
+
+func TestPlanPhase(t *testing.T) {
+	config.PhasePlanWorkflowId = "51234"
+	defer func() { config.PhasePlanWorkflowId = "" }()
+
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	pHandler := NewPhasePlanHandler(mockHttpClient, mockDb)
+	pHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "admin_pubkey" && role == db.AddBounty
+	}
+
+	feature := db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid", Name: "Lightning login", Brief: "Log in with a wallet"}
+	phase := db.FeaturePhase{Uuid: "phase_uuid", FeatureUuid: "feature_uuid", Name: "MVP"}
+	newRequest := func(pubkey string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("feature_uuid", "feature_uuid")
+		rctx.URLParams.Add("phase_uuid", "phase_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/feature_uuid/phase/phase_uuid/plan", nil)
+		return req
+	}
+
+	t.Run("should only let people who add bounties plan a phase", func(t *testing.T) {
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(feature).Once()
+		mockDb.On("GetFeaturePhaseByUuid", "feature_uuid", "phase_uuid").Return(phase, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.PlanPhase).ServeHTTP(rr, newRequest("member_pubkey"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should submit the phase with its context to the planning workflow", func(t *testing.T) {
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(feature).Once()
+		mockDb.On("GetFeaturePhaseByUuid", "feature_uuid", "phase_uuid").Return(phase, nil).Once()
+		mockDb.On("GetFeatureStoriesByFeatureUuid", "feature_uuid").Return([]db.FeatureStory{{Uuid: "story_1", Description: "Scan a QR code"}}, nil).Once()
+		mockDb.On("GetWorkspaceRepositorByWorkspaceUuid", "workspace_uuid").Return([]db.WorkspaceRepositories{}).Once()
+		mockDb.On("CreatePhasePlan", mock.MatchedBy(func(plan db.PhasePlan) bool {
+			return plan.FeatureUuid == "feature_uuid" && plan.PhaseUuid == "phase_uuid" && plan.Status == db.PhasePlanPending &&
+				strings.Contains(plan.Context, "- [story_1] Scan a QR code") && plan.RequestedBy == "admin_pubkey"
+		})).Return(func(plan db.PhasePlan) (db.PhasePlan, error) {
+			return plan, nil
+		}).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			payload := map[string]interface{}{}
+			json.Unmarshal(requestBody(req), &payload)
+			return payload["workflow_id"] == "51234" && strings.Contains(payload["webhook_url"].(string), "/features/plans/")
+		})).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"success": true, "data": {"project_id": 77}}`))}, nil).Once()
+		mockDb.On("UpdatePhasePlan", mock.MatchedBy(func(plan db.PhasePlan) bool {
+			return plan.Status == db.PhasePlanSubmitted && plan.ProjectId == 77
+		})).Return(func(plan db.PhasePlan) (db.PhasePlan, error) {
+			return plan, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.PlanPhase).ServeHTTP(rr, newRequest("admin_pubkey"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestReceivePhasePlanResult(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	pHandler := NewPhasePlanHandler(mocks.NewHttpClient(t), mockDb)
+
+	plan := db.PhasePlan{Uuid: "plan_uuid", FeatureUuid: "feature_uuid", PhaseUuid: "phase_uuid", WorkspaceUuid: "workspace_uuid",
+		Status: db.PhasePlanSubmitted, CallbackToken: "callback_token", RequestedBy: "admin_pubkey"}
+	stories := []db.FeatureStory{{Uuid: "story_1"}, {Uuid: "story_2"}}
+	newRequest := func(token string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("plan_uuid", "plan_uuid")
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodPost,
+			"/plans/plan_uuid/result?token="+token, bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should refuse results without the callback token", func(t *testing.T) {
+		mockDb.On("GetPhasePlanByUuid", "plan_uuid").Return(plan, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.ReceivePhasePlanResult).ServeHTTP(rr, newRequest("wrong", `{"status": "completed"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should refuse drafts for stories of other features", func(t *testing.T) {
+		mockDb.On("GetPhasePlanByUuid", "plan_uuid").Return(plan, nil).Once()
+		mockDb.On("GetFeatureStoriesByFeatureUuid", "feature_uuid").Return(stories, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.ReceivePhasePlanResult).ServeHTTP(rr, newRequest("callback_token",
+			`{"status": "completed", "tickets": [{"title": "QR login", "story_uuids": ["story_9"]}]}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "unknown story story_9")
+	})
+
+	t.Run("should save the drafts as hidden bounties of the phase", func(t *testing.T) {
+		mockDb.On("GetPhasePlanByUuid", "plan_uuid").Return(plan, nil).Once()
+		mockDb.On("GetFeatureStoriesByFeatureUuid", "feature_uuid").Return(stories, nil).Once()
+		mockDb.On("SavePhasePlanTickets", "plan_uuid", mock.MatchedBy(func(tickets []db.PhasePlanTicket) bool {
+			return len(tickets) == 2 &&
+				tickets[0].Bounty.Title == "QR login" && tickets[0].Bounty.PhaseUuid == "phase_uuid" && !tickets[0].Bounty.Show &&
+				tickets[0].Bounty.OwnerID == "admin_pubkey" && tickets[0].Bounty.Description == "QR login" &&
+				tickets[0].Draft.Position == 0 && tickets[0].Draft.StoryUuids[0] == "story_1" && tickets[0].Draft.Rationale == "Covers the scan" &&
+				tickets[1].Bounty.EstimatedHours == 3 && tickets[1].Bounty.Created != tickets[0].Bounty.Created && tickets[1].Draft.Position == 1
+		})).Return(db.PhasePlan{Uuid: "plan_uuid", Status: db.PhasePlanCompleted}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.ReceivePhasePlanResult).ServeHTTP(rr, newRequest("callback_token", `{"status": "completed", "tickets": [
+			{"title": "QR login", "story_uuids": ["story_1"], "rationale": "Covers the scan"},
+			{"title": "Session tokens", "description": "Issue a jwt", "estimated_hours": 3}
+		]}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should record a failed plan", func(t *testing.T) {
+		mockDb.On("GetPhasePlanByUuid", "plan_uuid").Return(plan, nil).Once()
+		mockDb.On("UpdatePhasePlan", mock.MatchedBy(func(updated db.PhasePlan) bool {
+			return updated.Status == db.PhasePlanFailed && updated.Error == "brief too short" && updated.Completed != nil
+		})).Return(func(updated db.PhasePlan) (db.PhasePlan, error) {
+			return updated, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.ReceivePhasePlanResult).ServeHTTP(rr, newRequest("callback_token", `{"status": "failed", "error": "brief too short"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should not save the drafts of a finished plan twice", func(t *testing.T) {
+		finished := plan
+		finished.Status = db.PhasePlanCompleted
+		mockDb.On("GetPhasePlanByUuid", "plan_uuid").Return(finished, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.ReceivePhasePlanResult).ServeHTTP(rr, newRequest("callback_token", `{"status": "completed", "tickets": [{"title": "QR login"}]}`))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
+
+func TestRollbackTicketBatch(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	pHandler := NewPhasePlanHandler(mocks.NewHttpClient(t), mockDb)
+	pHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "admin_pubkey" && uuid == "workspace_uuid" && role == db.DeleteBounty
+	}
+
+	plan := db.PhasePlan{Uuid: "plan_uuid", WorkspaceUuid: "workspace_uuid", Status: db.PhasePlanCompleted}
+	newRequest := func(pubkey string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "plan_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/plan_uuid/rollback", nil)
+		return req
+	}
+
+	t.Run("should only let people who delete bounties roll back a batch", func(t *testing.T) {
+		mockDb.On("GetPhasePlanByUuid", "plan_uuid").Return(plan, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.RollbackTicketBatch).ServeHTTP(rr, newRequest("member_pubkey"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should keep a batch with assigned drafts", func(t *testing.T) {
+		mockDb.On("GetPhasePlanByUuid", "plan_uuid").Return(plan, nil).Once()
+		mockDb.On("RollbackPhasePlan", "plan_uuid", "admin_pubkey", mock.Anything).
+			Return(db.TicketBatchRollback{PlanUuid: "plan_uuid", Deleted: []uint{}, Blocking: []uint{12}}, db.ErrTicketBatchInUse).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.RollbackTicketBatch).ServeHTTP(rr, newRequest("admin_pubkey"))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		result := db.TicketBatchRollback{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, []uint{12}, result.Blocking)
+	})
+
+	t.Run("should roll back the drafts of the batch", func(t *testing.T) {
+		mockDb.On("GetPhasePlanByUuid", "plan_uuid").Return(plan, nil).Once()
+		mockDb.On("RollbackPhasePlan", "plan_uuid", "admin_pubkey", mock.Anything).
+			Return(db.TicketBatchRollback{PlanUuid: "plan_uuid", Deleted: []uint{12, 13}, Blocking: []uint{}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.RollbackTicketBatch).ServeHTTP(rr, newRequest("admin_pubkey"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		result := db.TicketBatchRollback{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, []uint{12, 13}, result.Deleted)
+	})
+
+	t.Run("should only roll back completed plans", func(t *testing.T) {
+		mockDb.On("GetPhasePlanByUuid", "plan_uuid").Return(plan, nil).Once()
+		mockDb.On("RollbackPhasePlan", "plan_uuid", "admin_pubkey", mock.Anything).
+			Return(db.TicketBatchRollback{}, db.ErrTicketBatchNotRollbackable).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(pHandler.RollbackTicketBatch).ServeHTTP(rr, newRequest("admin_pubkey"))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+// PriceSuggestionsFlag is the feature flag that turns on bounty price suggestions
+const PriceSuggestionsFlag = "price_suggestions"
+
+// SuggestBountyPrice suggests a price range for a new bounty of a workspace from
+// the prices paid for bounties with the same languages and a similar estimate
+func (h *bountyHandler) SuggestBountyPrice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !config.FeatureEnabled(PriceSuggestionsFlag) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Price suggestions are not enabled")
+		return
+	}
+
+	keys := r.URL.Query()
+	workspaceUuid := keys.Get("workspace_uuid")
+	if workspaceUuid == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("workspace_uuid is required")
+		return
+	}
+
+	var estimatedHours float64
+	if hours := keys.Get("estimated_hours"); hours != "" {
+		var err error
+		estimatedHours, err = strconv.ParseFloat(hours, 64)
+		if err != nil || estimatedHours < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("estimated_hours must be a positive number")
+			return
+		}
+	}
+
+	languages := []string{}
+	for _, language := range strings.Split(keys.Get("languages"), ",") {
+		if language = strings.TrimSpace(language); language != "" {
+			languages = append(languages, language)
+		}
+	}
+
+	workspace := h.db.GetWorkspaceByUuid(workspaceUuid)
+	if workspace.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+	if workspace.OwnerPubKey != pubKeyFromAuth && h.db.GetWorkspaceUser(pubKeyFromAuth, workspaceUuid).OwnerPubKey == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the workspace")
+		return
+	}
+
+	stats := h.db.GetBountyPriceStats(workspaceUuid)
+	suggestion, ok := db.SuggestBountyPrice(stats, workspaceUuid, languages, db.BountyScopeFor(estimatedHours))
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Not enough paid bounties to suggest a price")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(suggestion)
+}
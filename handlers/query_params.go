@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// validListParams rejects list requests with malformed pagination
+// or sort parameters before they reach the database
+func validListParams(w http.ResponseWriter, r *http.Request) bool {
+	params := utils.NewQueryParams(r)
+	params.Pagination()
+
+	if err := params.Err(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+// validAvailabilityParams rejects people searches filtering
+// on an unknown availability or a malformed weekly hours minimum
+func validAvailabilityParams(w http.ResponseWriter, r *http.Request) bool {
+	params := utils.NewQueryParams(r)
+	params.OneOf("availability", "", db.AvailabilityStatuses...)
+	params.Int("min_weekly_hours", 0)
+
+	if err := params.Err(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return false
+	}
+	return true
+}
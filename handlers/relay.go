@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"gorm.io/gorm"
+)
+
+const (
+	maxRelayEventsSize     = 1 << 20
+	maxRelayEventsPerBatch = 500
+	// RelayEventMaxSkew is how far the signed timestamp of a batch may be
+	// from now, an older batch is treated as a replay
+	RelayEventMaxSkew = 5 * time.Minute
+)
+
+type relayHandler struct {
+	db              db.Database
+	bounty          *bountyHandler
+	verifySignature func(sig string, msg string) (string, error)
+}
+
+func NewRelayHandler(httpClient HttpClient, database db.Database) *relayHandler {
+	return &relayHandler{
+		db:              database,
+		bounty:          NewBountyHandler(httpClient, database),
+		verifySignature: auth.VerifyArbitrary,
+	}
+}
+
+// ReceiveRelayEvents takes the member joins, message counts and payment
+// confirmations sphinx-relay pushes. The body is signed with the relay node key
+// in the x-relay-signature header, each event is only applied once
+func (rh *relayHandler) ReceiveRelayEvents(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRelayEventsSize+1))
+	r.Body.Close()
+	if err != nil || len(body) > maxRelayEventsSize {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		json.NewEncoder(w).Encode("Relay events are too large")
+		return
+	}
+
+	signature := r.Header.Get("x-relay-signature")
+	if config.RelayNodeKey == "" || signature == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	pubkey, err := rh.verifySignature(signature, string(body))
+	if err != nil || pubkey != config.RelayNodeKey {
+		fmt.Println("[relay] invalid signature for relay events", err)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	batch := db.RelayEventBatch{}
+	if err := json.Unmarshal(body, &batch); err != nil {
+		fmt.Println("[relay]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	now := time.Now()
+	signed := time.Unix(batch.Timestamp, 0)
+	if signed.Before(now.Add(-RelayEventMaxSkew)) || signed.After(now.Add(RelayEventMaxSkew)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Relay events timestamp is out of range")
+		return
+	}
+	if len(batch.Events) > maxRelayEventsPerBatch {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("At most %d events can be sent at once", maxRelayEventsPerBatch))
+		return
+	}
+
+	result := db.RelayEventsResult{
+		Rejected:    []db.RelayEventRejected{},
+		Corrections: []db.PaymentCorrection{},
+	}
+	for _, event := range batch.Events {
+		applied, err := rh.applyRelayEvent(&result, event, now)
+		switch {
+		case err != nil:
+			result.Rejected = append(result.Rejected, db.RelayEventRejected{Id: event.Id, Error: err.Error()})
+		case applied:
+			result.Applied++
+		default:
+			result.Duplicates++
+		}
+	}
+
+	fmt.Printf("[relay] %d events applied, %d duplicates, %d rejected\n", result.Applied, result.Duplicates, len(result.Rejected))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// applyRelayEvent applies one event, it returns false for an event that was applied before
+func (rh *relayHandler) applyRelayEvent(result *db.RelayEventsResult, event db.RelayEvent, now time.Time) (bool, error) {
+	if event.Id == "" {
+		return false, errors.New("event id is required")
+	}
+
+	switch event.Type {
+	case db.RelayEventMemberJoin, db.RelayEventMessageCount:
+		if event.TribeUuid == "" {
+			return false, errors.New("tribe_uuid is required")
+		}
+		var members, messages uint64
+		if event.Type == db.RelayEventMemberJoin {
+			members = 1
+		} else {
+			if event.MessageCount == 0 {
+				return false, errors.New("message_count is required")
+			}
+			messages = event.MessageCount
+		}
+
+		applied, err := rh.db.ApplyRelayTribeEvent(event, members, messages, now)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, errors.New("tribe not found")
+		}
+		return applied, err
+	case db.RelayEventPaymentConfirmed:
+		if event.PaymentRequest == "" {
+			return false, errors.New("payment_request is required")
+		}
+		invoice := rh.db.GetInvoice(event.PaymentRequest)
+		if invoice.PaymentRequest == "" {
+			return false, errors.New("invoice not found")
+		}
+
+		if invoice.Status {
+			return rh.db.RecordRelayEvent(event, now)
+		}
+
+		// the relay saw the payment settle, so the invoice is settled the same
+		// way reconciliation settles it after asking the lightning backend. The
+		// event is only logged with the settlement, so a confirmation that could
+		// not be applied is not taken for a duplicate when the relay sends it again
+		settled, duplicate := false, false
+		report := db.PaymentReconciliationReport{}
+		rh.bounty.reconcileSettledInvoice(&report, invoice, func(invoice db.NewInvoiceList) error {
+			recorded, err := rh.db.SettleRelayPaymentEvent(event, invoice, now)
+			settled = recorded
+			duplicate = err == nil && !recorded
+			return err
+		})
+		if duplicate {
+			return false, nil
+		}
+		result.Corrections = append(result.Corrections, report.Corrections...)
+		result.Corrections = append(result.Corrections, report.Unresolved...)
+		if !settled {
+			return false, errors.New("payment could not be settled, see the corrections")
+		}
+		return true, nil
+	}
+	return false, fmt.Errorf("unknown event type %q", event.Type)
+}
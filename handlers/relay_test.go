@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	btcec "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestReceiveRelayEvents(t *testing.T) {
+	relayKey, _ := btcec.NewPrivateKey()
+	otherKey, _ := btcec.NewPrivateKey()
+
+	previousNodeKey := config.RelayNodeKey
+	config.RelayNodeKey = hex.EncodeToString(relayKey.PubKey().SerializeCompressed())
+	defer func() { config.RelayNodeKey = previousNodeKey }()
+
+	newRequest := func(batch db.RelayEventBatch, key *btcec.PrivateKey) *http.Request {
+		body, _ := json.Marshal(batch)
+		req, _ := http.NewRequest(http.MethodPost, "/relay/events", bytes.NewReader(body))
+		if key != nil {
+			sig, _ := auth.Sign(body, key)
+			req.Header.Set("x-relay-signature", base64.URLEncoding.EncodeToString(sig))
+		}
+		return req
+	}
+	serve := func(rh *relayHandler, req *http.Request) (*httptest.ResponseRecorder, db.RelayEventsResult) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(rh.ReceiveRelayEvents).ServeHTTP(rr, req)
+		result := db.RelayEventsResult{}
+		json.Unmarshal(rr.Body.Bytes(), &result)
+		return rr, result
+	}
+
+	t.Run("should reject unsigned events and events signed by another key", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		rh := NewRelayHandler(&http.Client{}, mockDb)
+		batch := db.RelayEventBatch{Timestamp: time.Now().Unix()}
+
+		rr, _ := serve(rh, newRequest(batch, nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		rr, _ = serve(rh, newRequest(batch, otherKey))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should reject a stale batch", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		rh := NewRelayHandler(&http.Client{}, mockDb)
+
+		rr, _ := serve(rh, newRequest(db.RelayEventBatch{Timestamp: time.Now().Add(-time.Hour).Unix()}, relayKey))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should feed member joins and message counts into tribe stats", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		rh := NewRelayHandler(&http.Client{}, mockDb)
+		join := db.RelayEvent{Id: "event_1", Type: db.RelayEventMemberJoin, TribeUuid: "tribe_uuid", MemberPubkey: "member_pubkey"}
+		messages := db.RelayEvent{Id: "event_2", Type: db.RelayEventMessageCount, TribeUuid: "tribe_uuid", MessageCount: 12}
+		repeated := db.RelayEvent{Id: "event_3", Type: db.RelayEventMemberJoin, TribeUuid: "tribe_uuid"}
+		missing := db.RelayEvent{Id: "event_4", Type: db.RelayEventMemberJoin, TribeUuid: "missing_uuid"}
+
+		mockDb.On("ApplyRelayTribeEvent", join, uint64(1), uint64(0), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		mockDb.On("ApplyRelayTribeEvent", messages, uint64(0), uint64(12), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		mockDb.On("ApplyRelayTribeEvent", repeated, uint64(1), uint64(0), mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+		mockDb.On("ApplyRelayTribeEvent", missing, uint64(1), uint64(0), mock.AnythingOfType("time.Time")).Return(false, gorm.ErrRecordNotFound).Once()
+
+		rr, result := serve(rh, newRequest(db.RelayEventBatch{
+			Timestamp: time.Now().Unix(),
+			Events: []db.RelayEvent{
+				join, messages, repeated, missing,
+				{Id: "event_5", Type: db.RelayEventMessageCount, TribeUuid: "tribe_uuid"},
+				{Id: "event_6", Type: "unknown"},
+			},
+		}, relayKey))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 2, result.Applied)
+		assert.Equal(t, 1, result.Duplicates)
+		assert.Equal(t, []db.RelayEventRejected{
+			{Id: "event_4", Error: "tribe not found"},
+			{Id: "event_5", Error: "message_count is required"},
+			{Id: "event_6", Error: `unknown event type "unknown"`},
+		}, result.Rejected)
+	})
+
+	t.Run("should settle a confirmed budget invoice", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		rh := NewRelayHandler(&http.Client{}, mockDb)
+		created := time.Now()
+		invoice := db.NewInvoiceList{PaymentRequest: "lnbc1", Type: db.Budget, WorkspaceUuid: "workspace_uuid", Created: &created}
+		confirmed := db.RelayEvent{Id: "event_1", Type: db.RelayEventPaymentConfirmed, PaymentRequest: "lnbc1"}
+
+		mockDb.On("GetInvoice", "lnbc1").Return(invoice).Once()
+		mockDb.On("GetPaymentHistoryByCreated", &created, "workspace_uuid").Return(db.NewPaymentHistory{WorkspaceUuid: "workspace_uuid", Amount: 1000}).Once()
+		mockDb.On("SettleRelayPaymentEvent", confirmed, invoice, mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+
+		rr, result := serve(rh, newRequest(db.RelayEventBatch{Timestamp: time.Now().Unix(), Events: []db.RelayEvent{confirmed}}, relayKey))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 1, result.Applied)
+		assert.Len(t, result.Corrections, 1)
+		assert.Equal(t, db.PaymentBudgetCredited, result.Corrections[0].Action)
+	})
+
+	t.Run("should not log a confirmation whose payment could not be settled", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		rh := NewRelayHandler(&http.Client{}, mockDb)
+		created := time.Now()
+		invoice := db.NewInvoiceList{PaymentRequest: "lnbc1", Type: db.Budget, WorkspaceUuid: "workspace_uuid", Created: &created}
+		confirmed := db.RelayEvent{Id: "event_1", Type: db.RelayEventPaymentConfirmed, PaymentRequest: "lnbc1"}
+
+		mockDb.On("GetInvoice", "lnbc1").Return(invoice).Twice()
+		mockDb.On("GetPaymentHistoryByCreated", &created, "workspace_uuid").Return(db.NewPaymentHistory{WorkspaceUuid: "workspace_uuid", Amount: 1000}).Twice()
+		mockDb.On("SettleRelayPaymentEvent", confirmed, invoice, mock.AnythingOfType("time.Time")).Return(false, errors.New("db down")).Once()
+
+		rr, result := serve(rh, newRequest(db.RelayEventBatch{Timestamp: time.Now().Unix(), Events: []db.RelayEvent{confirmed}}, relayKey))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 0, result.Applied)
+		assert.Equal(t, []db.RelayEventRejected{{Id: "event_1", Error: "payment could not be settled, see the corrections"}}, result.Rejected)
+		assert.Equal(t, db.PaymentNeedsReview, result.Corrections[0].Action)
+
+		// the relay sends it again and it is applied, not taken for a duplicate
+		mockDb.On("SettleRelayPaymentEvent", confirmed, invoice, mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		rr, result = serve(rh, newRequest(db.RelayEventBatch{Timestamp: time.Now().Unix(), Events: []db.RelayEvent{confirmed}}, relayKey))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 1, result.Applied)
+		assert.Equal(t, db.PaymentBudgetCredited, result.Corrections[0].Action)
+	})
+
+	t.Run("should apply a payment confirmation once", func(t *testing.T) {
+		mockDb := dbMocks.NewDatabase(t)
+		rh := NewRelayHandler(&http.Client{}, mockDb)
+		confirmed := db.RelayEvent{Id: "event_1", Type: db.RelayEventPaymentConfirmed, PaymentRequest: "lnbc1"}
+		unknown := db.RelayEvent{Id: "event_2", Type: db.RelayEventPaymentConfirmed, PaymentRequest: "lnbc2"}
+
+		mockDb.On("GetInvoice", "lnbc1").Return(db.NewInvoiceList{PaymentRequest: "lnbc1", Type: db.Budget, Status: true}).Once()
+		mockDb.On("RecordRelayEvent", confirmed, mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+		mockDb.On("GetInvoice", "lnbc2").Return(db.NewInvoiceList{}).Once()
+
+		rr, result := serve(rh, newRequest(db.RelayEventBatch{Timestamp: time.Now().Unix(), Events: []db.RelayEvent{confirmed, unknown}}, relayKey))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 1, result.Duplicates)
+		assert.Equal(t, []db.RelayEventRejected{{Id: "event_2", Error: "invoice not found"}}, result.Rejected)
+		assert.Empty(t, result.Corrections)
+	})
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+const (
+	minSearchQueryLength = 2
+	maxSearchQueryLength = 100
+	maxSearchLimit       = 25
+
+	maxAutocompleteQueryLength = 50
+	maxAutocompleteLimit       = 20
+)
+
+type searchHandler struct {
+	db db.Database
+}
+
+func NewSearchHandler(database db.Database) *searchHandler {
+	return &searchHandler{db: database}
+}
+
+// Search looks for tribes, people, bounties and features matching q, the
+// type param is a comma separated list that narrows the types searched
+func (sh *searchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	params := utils.NewQueryParams(r)
+	query := strings.TrimSpace(params.String("q", ""))
+	typesParam := params.String("type", "")
+	limit := params.Int("limit", 10)
+	if err := params.Err(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	if length := utf8.RuneCountInString(query); length < minSearchQueryLength || length > maxSearchQueryLength {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("q must be between %d and %d characters", minSearchQueryLength, maxSearchQueryLength)})
+		return
+	}
+	if limit < 1 || limit > maxSearchLimit {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit)})
+		return
+	}
+
+	types := db.SearchTypes
+	if typesParam != "" {
+		requested := map[string]bool{}
+		for _, t := range strings.Split(typesParam, ",") {
+			requested[strings.TrimSpace(t)] = true
+		}
+		types = []string{}
+		for _, t := range db.SearchTypes {
+			if requested[t] {
+				types = append(types, t)
+				delete(requested, t)
+			}
+		}
+		if len(requested) > 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("type must be one of %s", strings.Join(db.SearchTypes, ", "))})
+			return
+		}
+	}
+
+	result, err := sh.db.PublicSearch(query, types, limit)
+	if err != nil {
+		fmt.Println("[search] could not search", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not search")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// Autocomplete suggests id and label pairs of people, tribes or features whose
+// name starts with q, features can be narrowed to one workspace
+func (sh *searchHandler) Autocomplete(w http.ResponseWriter, r *http.Request) {
+	kind := chi.URLParam(r, "type")
+
+	params := utils.NewQueryParams(r)
+	prefix := strings.TrimSpace(params.String("q", ""))
+	workspaceUuid := params.String("workspace_uuid", "")
+	limit := params.Int("limit", 10)
+	if err := params.Err(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	if prefix == "" || utf8.RuneCountInString(prefix) > maxAutocompleteQueryLength {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("q must be between 1 and %d characters", maxAutocompleteQueryLength)})
+		return
+	}
+	if limit < 1 || limit > maxAutocompleteLimit {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("limit must be between 1 and %d", maxAutocompleteLimit)})
+		return
+	}
+
+	var items []db.AutocompleteItem
+	var err error
+	switch kind {
+	case db.SearchTypePeople:
+		items, err = sh.db.AutocompletePeople(prefix, limit)
+	case db.SearchTypeTribes:
+		items, err = sh.db.AutocompleteTribes(prefix, limit)
+	case db.SearchTypeFeatures:
+		items, err = sh.db.AutocompleteFeatures(prefix, workspaceUuid, limit)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Unknown autocomplete type")
+		return
+	}
+	if err != nil {
+		fmt.Println("[autocomplete] could not autocomplete", kind, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not autocomplete")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=30")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(items)
+}
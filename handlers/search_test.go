@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	sHandler := NewSearchHandler(mockDb)
+
+	search := func(target string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		http.HandlerFunc(sHandler.Search).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should reject a query that is too short or too long", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, search("/search?q=a").Code)
+		assert.Equal(t, http.StatusBadRequest, search("/search").Code)
+
+		long := make([]byte, 101)
+		for i := range long {
+			long[i] = 'a'
+		}
+		assert.Equal(t, http.StatusBadRequest, search("/search?q="+string(long)).Code)
+	})
+
+	t.Run("should reject an unknown type or a limit out of range", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, search("/search?q=go&type=bounties,channels").Code)
+		assert.Equal(t, http.StatusBadRequest, search("/search?q=go&limit=26").Code)
+		assert.Equal(t, http.StatusBadRequest, search("/search?q=go&limit=abc").Code)
+	})
+
+	t.Run("should search every type by default", func(t *testing.T) {
+		result := db.SearchResult{
+			Query:  "sphinx",
+			Facets: map[string]int64{db.SearchTypeTribes: 1, db.SearchTypePeople: 0, db.SearchTypeBounties: 0, db.SearchTypeFeatures: 0},
+			Hits:   []db.SearchHit{{Type: db.SearchTypeTribes, Id: "tribe-uuid", Title: "Sphinx"}},
+		}
+		mockDb.On("PublicSearch", "sphinx", db.SearchTypes, 10).Return(result, nil).Once()
+
+		rr := search("/search?q=%20sphinx%20")
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var got db.SearchResult
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		assert.Equal(t, result, got)
+	})
+
+	t.Run("should only search the requested types in the default order", func(t *testing.T) {
+		mockDb.On("PublicSearch", "go", []string{db.SearchTypePeople, db.SearchTypeFeatures}, 5).
+			Return(db.SearchResult{Query: "go", Facets: map[string]int64{}, Hits: []db.SearchHit{}}, nil).Once()
+
+		rr := search("/search?q=go&type=features,people,features&limit=5")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return 500 when the search fails", func(t *testing.T) {
+		mockDb.On("PublicSearch", "go", db.SearchTypes, 10).Return(db.SearchResult{}, errors.New("db down")).Once()
+
+		rr := search("/search?q=go")
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestAutocomplete(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	sHandler := NewSearchHandler(mockDb)
+
+	autocomplete := func(kind string, query string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/autocomplete/"+kind+query, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("type", kind)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		http.HandlerFunc(sHandler.Autocomplete).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should return 404 for an unknown type", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, autocomplete("bounties", "?q=a").Code)
+	})
+
+	t.Run("should reject an empty query or a limit out of range", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, autocomplete("people", "?q=%20").Code)
+		assert.Equal(t, http.StatusBadRequest, autocomplete("people", "?q=a&limit=21").Code)
+	})
+
+	t.Run("should suggest people by prefix", func(t *testing.T) {
+		items := []db.AutocompleteItem{{Id: "pubkey", Label: "alice"}}
+		mockDb.On("AutocompletePeople", "al", 10).Return(items, nil).Once()
+
+		rr := autocomplete("people", "?q=al")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "public, max-age=30", rr.Header().Get("Cache-Control"))
+
+		var got []db.AutocompleteItem
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		assert.Equal(t, items, got)
+	})
+
+	t.Run("should suggest tribes by prefix", func(t *testing.T) {
+		mockDb.On("AutocompleteTribes", "sph", 5).Return([]db.AutocompleteItem{{Id: "tribe-uuid", Label: "Sphinx"}}, nil).Once()
+
+		rr := autocomplete("tribes", "?q=sph&limit=5")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should narrow features to a workspace", func(t *testing.T) {
+		mockDb.On("AutocompleteFeatures", "pay", "workspace-uuid", 10).Return([]db.AutocompleteItem{}, nil).Once()
+
+		rr := autocomplete("features", "?q=pay&workspace_uuid=workspace-uuid")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "[]\n", rr.Body.String())
+	})
+
+	t.Run("should return 500 when the lookup fails", func(t *testing.T) {
+		mockDb.On("AutocompleteFeatures", "pay", "", 10).Return(nil, errors.New("db down")).Once()
+
+		rr := autocomplete("features", "?q=pay")
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
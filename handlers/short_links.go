@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	shortLinkDefaultDays = 30
+	shortLinkMaxDays     = 365
+)
+
+type shortLinkHandler struct {
+	db db.Database
+}
+
+func NewShortLinkHandler(database db.Database) *shortLinkHandler {
+	return &shortLinkHandler{db: database}
+}
+
+func shortLinkUrl(code string) string {
+	return fmt.Sprintf("%s/s/%s", config.Host, code)
+}
+
+// shortLinkTarget resolves the deep link of a short link request, ok is false
+// when the target does not exist or the creator can't see it
+func (sh *shortLinkHandler) shortLinkTarget(pubkey string, request db.ShortLinkRequest) (string, bool) {
+	switch request.TargetType {
+	case db.ShortLinkBounty:
+		id, err := strconv.ParseUint(request.TargetId, 10, 32)
+		if err != nil {
+			return "", false
+		}
+		bounty := sh.db.GetBounty(uint(id))
+		if bounty.ID == 0 {
+			return "", false
+		}
+		member := false
+		if bounty.Visibility == db.BountyVisibilityWorkspace && bounty.WorkspaceUuid != "" {
+			member = sh.db.GetWorkspaceUser(pubkey, bounty.WorkspaceUuid).ID != 0 ||
+				sh.db.GetWorkspaceByUuid(bounty.WorkspaceUuid).OwnerPubKey == pubkey
+		}
+		if !db.BountyVisibleTo(bounty, pubkey, member) {
+			return "", false
+		}
+		return fmt.Sprintf("%s/bounty/%d", config.Host, bounty.ID), true
+	case db.ShortLinkFeature:
+		feature := sh.db.GetFeatureByUuid(request.TargetId)
+		if feature.Uuid == "" {
+			return "", false
+		}
+		workspace := sh.db.GetWorkspaceByUuid(feature.WorkspaceUuid)
+		if workspace.OwnerPubKey != pubkey && sh.db.GetWorkspaceUser(pubkey, feature.WorkspaceUuid).OwnerPubKey == "" {
+			return "", false
+		}
+		return fmt.Sprintf("%s/feature/%s", config.Host, feature.Uuid), true
+	case db.ShortLinkInvite:
+		// invite codes are handed out by the super admins
+		if request.TargetId == "" || !auth.AdminCheck(pubkey) {
+			return "", false
+		}
+		return "sphinx.chat://?action=i&d=" + url.QueryEscape(request.TargetId), true
+	}
+	return "", false
+}
+
+// CreateShortLink makes a /s/{code} link to a bounty, a feature or an invite code,
+// long deep links break in some chat clients
+func (sh *shortLinkHandler) CreateShortLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[short links] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.ShortLinkRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[short links]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if request.ExpiresInDays == 0 {
+		request.ExpiresInDays = shortLinkDefaultDays
+	}
+	if request.ExpiresInDays < 0 || request.ExpiresInDays > shortLinkMaxDays {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("expires_in_days must be between 1 and %d", shortLinkMaxDays))
+		return
+	}
+
+	target, ok := sh.shortLinkTarget(pubKeyFromAuth, request)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Link target not found")
+		return
+	}
+
+	now := time.Now()
+	expires := now.AddDate(0, 0, request.ExpiresInDays)
+	link, err := sh.db.CreateShortLink(db.ShortLink{
+		Code:       db.NewShortLinkCode(),
+		TargetType: request.TargetType,
+		TargetId:   request.TargetId,
+		Url:        target,
+		ExpiresAt:  &expires,
+		CreatedBy:  pubKeyFromAuth,
+		Created:    &now,
+	})
+	if err != nil {
+		fmt.Println("[short links] could not create link", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not create the link")
+		return
+	}
+
+	link.ShortUrl = shortLinkUrl(link.Code)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(link)
+}
+
+// GetShortLink returns a link with its clicks to the person who made it
+func (sh *shortLinkHandler) GetShortLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[short links] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	code := chi.URLParam(r, "code")
+	link := db.ShortLink{}
+	err := db.ErrShortLinkNotFound
+	if db.VerifyShortLinkCode(code) {
+		link, err = sh.db.GetShortLinkByCode(code)
+	}
+	if err != nil || link.CreatedBy != pubKeyFromAuth {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(db.ErrShortLinkNotFound.Error())
+		return
+	}
+
+	link.ShortUrl = shortLinkUrl(link.Code)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(link)
+}
+
+// FollowShortLink counts the click and redirects to the target of the link, the
+// signature of the code is checked first so guessed codes never reach the database
+func (sh *shortLinkHandler) FollowShortLink(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	if !db.VerifyShortLinkCode(code) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(db.ErrShortLinkNotFound.Error())
+		return
+	}
+
+	link, err := sh.db.FollowShortLink(code, time.Now())
+	switch {
+	case errors.Is(err, db.ErrShortLinkNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	case errors.Is(err, db.ErrShortLinkExpired):
+		w.WriteHeader(http.StatusGone)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	case err != nil:
+		fmt.Println("[short links] could not follow link", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, link.Url, http.StatusFound)
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateShortLink(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	sHandler := NewShortLinkHandler(mockDb)
+
+	newRequest := func(pubkey string, body string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should return 401 without a pubkey", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.CreateShortLink).ServeHTTP(rr, newRequest("", `{}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should turn down a too long expiry", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.CreateShortLink).ServeHTTP(rr, newRequest("pubkey", `{"target_type": "bounty", "target_id": "1", "expires_in_days": 1000}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should not link a bounty the creator can't see", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(2)).Return(db.NewBounty{ID: 2, OwnerID: "owner", Visibility: db.BountyVisibilityInviteOnly}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.CreateShortLink).ServeHTTP(rr, newRequest("pubkey", `{"target_type": "bounty", "target_id": "2"}`))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should not link invite codes for people who are not admins", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.CreateShortLink).ServeHTTP(rr, newRequest("pubkey", `{"target_type": "invite", "target_id": "code"}`))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should create a signed link to a bounty", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, OwnerID: "owner"}).Once()
+		mockDb.On("CreateShortLink", mock.MatchedBy(func(link db.ShortLink) bool {
+			return db.VerifyShortLinkCode(link.Code) && link.Url == config.Host+"/bounty/1" && link.CreatedBy == "pubkey" &&
+				link.ExpiresAt != nil && link.ExpiresAt.Sub(*link.Created) == 30*24*time.Hour
+		})).Return(func(link db.ShortLink) (db.ShortLink, error) { return link, nil }).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.CreateShortLink).ServeHTTP(rr, newRequest("pubkey", `{"target_type": "bounty", "target_id": "1"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		link := db.ShortLink{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &link))
+		assert.Equal(t, config.Host+"/s/"+link.Code, link.ShortUrl)
+	})
+}
+
+func TestFollowShortLink(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	sHandler := NewShortLinkHandler(mockDb)
+
+	newRequest := func(code string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("code", code)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/"+code, nil)
+		return req
+	}
+
+	t.Run("should not look up codes with a bad signature", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.FollowShortLink).ServeHTTP(rr, newRequest("aaaaaaaaaaaaaa"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return 410 for an expired link", func(t *testing.T) {
+		code := db.NewShortLinkCode()
+		mockDb.On("FollowShortLink", code, mock.Anything).Return(db.ShortLink{}, db.ErrShortLinkExpired).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.FollowShortLink).ServeHTTP(rr, newRequest(code))
+		assert.Equal(t, http.StatusGone, rr.Code)
+	})
+
+	t.Run("should redirect to the target", func(t *testing.T) {
+		code := db.NewShortLinkCode()
+		mockDb.On("FollowShortLink", code, mock.Anything).Return(db.ShortLink{Code: code, Url: "https://community.sphinx.chat/bounty/1"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.FollowShortLink).ServeHTTP(rr, newRequest(code))
+		assert.Equal(t, http.StatusFound, rr.Code)
+		assert.Equal(t, "https://community.sphinx.chat/bounty/1", rr.Header().Get("Location"))
+	})
+}
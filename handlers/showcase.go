@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/patrickmn/go-cache"
+)
+
+const (
+	showcaseCacheTTL      = time.Minute
+	showcaseBountiesLimit = 20
+)
+
+// GetWorkspaceShowcase is the public recruiting page of a workspace, it is only
+// there once the workspace turns on public_showcase in its settings. Pages are
+// cached for a minute, saving the settings drops the cached page
+func (oh *workspaceHandler) GetWorkspaceShowcase(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	showcase, ok := oh.showcaseCache.Get(uuid)
+	if !ok {
+		workspace := oh.db.GetWorkspaceByUuid(uuid)
+		if workspace.Uuid == "" || workspace.Deleted || !oh.db.GetWorkspaceSettings(uuid).PublicShowcase {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode("Workspace showcase not found")
+			return
+		}
+
+		page, err := oh.db.GetWorkspaceShowcase(workspace, showcaseBountiesLimit)
+		if err != nil {
+			fmt.Println("[workspaces] could not get showcase", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("Could not get the workspace showcase")
+			return
+		}
+		oh.showcaseCache.Set(uuid, page, cache.DefaultExpiration)
+		showcase = page
+	}
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(showcaseCacheTTL.Seconds())))
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(showcase)
+}
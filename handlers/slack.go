@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+)
+
+// slackMaxSkew is how old a signed Slack request can be, older ones are
+// refused so a captured request can not be replayed
+const slackMaxSkew = 5 * time.Minute
+
+const slackLinkCodeTtl = 15 * time.Minute
+
+const slackListLimit = 10
+
+const slackUsage = "Usage:\n" +
+	"`/bounty link` links your Slack user to your Sphinx profile\n" +
+	"`/bounty workspace <workspace uuid>` connects this Slack team to a workspace\n" +
+	"`/bounty create <sats> <title>` creates a bounty in the workspace\n" +
+	"`/bounty list` lists the open bounties of the workspace"
+
+type slackHandler struct {
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewSlackHandler(database db.Database) *slackHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &slackHandler{
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// slackMessage is the answer to a slash command, ephemeral messages are only
+// shown to the user who ran the command
+type slackMessage struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+func ephemeral(text string) slackMessage {
+	return slackMessage{ResponseType: "ephemeral", Text: text}
+}
+
+// slackEscape escapes the characters Slack reads as markup
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// verifySlackSignature checks the v0 signature Slack sends with every request,
+// an HMAC-SHA256 of the timestamp and the body signed with the signing secret
+func verifySlackSignature(secret string, body []byte, header http.Header, now time.Time) bool {
+	if secret == "" {
+		return false
+	}
+
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	skew := now.Sub(time.Unix(seconds, 0))
+	if skew > slackMaxSkew || skew < -slackMaxSkew {
+		return false
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(header.Get("X-Slack-Signature"), "v0="))
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// SlackCommand answers the /bounty slash command of the Slack app
+func (sh *slackHandler) SlackCommand(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if !verifySlackSignature(config.SlackSigningSecret, body, r.Header, time.Now()) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Invalid signature")
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	teamId := form.Get("team_id")
+	slackUserId := form.Get("user_id")
+	subcommand, args, _ := strings.Cut(strings.TrimSpace(form.Get("text")), " ")
+	args = strings.TrimSpace(args)
+
+	var message slackMessage
+	switch subcommand {
+	case "link":
+		message = sh.slackLink(teamId, slackUserId)
+	case "workspace":
+		message = sh.slackConnectWorkspace(teamId, slackUserId, args)
+	case "create":
+		message = sh.slackCreateBounty(teamId, slackUserId, args)
+	case "list":
+		message = sh.slackListBounties(teamId)
+	default:
+		message = ephemeral(slackUsage)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(message)
+}
+
+// slackLink gives the Slack user a one-time link to confirm while signed in
+func (sh *slackHandler) slackLink(teamId string, slackUserId string) slackMessage {
+	code := utils.GetRandomToken(32)
+	if err := sh.db.CreateSlackLinkCode(teamId, slackUserId, code, time.Now().Add(slackLinkCodeTtl)); err != nil {
+		fmt.Println("[slack] could not create link code", err)
+		return ephemeral("Could not start linking your account, try again")
+	}
+	return ephemeral(fmt.Sprintf("Open %s/slack/link?code=%s while signed in to link your Slack user, the link expires in %d minutes",
+		config.Host, code, int(slackLinkCodeTtl.Minutes())))
+}
+
+// slackPerson is the person the Slack user is linked to, ok is false with the
+// message to answer when the user is not linked yet
+func (sh *slackHandler) slackPerson(teamId string, slackUserId string) (string, slackMessage, bool) {
+	link := sh.db.GetSlackUserLink(teamId, slackUserId)
+	if link.OwnerPubkey == "" {
+		return "", ephemeral("Your Slack user is not linked yet, run `/bounty link` first"), false
+	}
+	return link.OwnerPubkey, slackMessage{}, true
+}
+
+func (sh *slackHandler) slackConnectWorkspace(teamId string, slackUserId string, workspaceUuid string) slackMessage {
+	pubkey, message, ok := sh.slackPerson(teamId, slackUserId)
+	if !ok {
+		return message
+	}
+	if workspaceUuid == "" {
+		return ephemeral("Usage: `/bounty workspace <workspace uuid>`")
+	}
+
+	workspace := sh.db.GetWorkspaceByUuid(workspaceUuid)
+	if workspace.Uuid == "" {
+		return ephemeral("Workspace not found")
+	}
+	if !sh.userHasAccess(pubkey, workspace.Uuid, db.EditOrg) {
+		return ephemeral("Only the admins of the workspace can connect it to Slack")
+	}
+
+	if _, err := sh.db.SetSlackTeamWorkspace(teamId, workspace.Uuid, pubkey); err != nil {
+		fmt.Println("[slack] could not connect workspace", err)
+		return ephemeral("Could not connect the workspace, try again")
+	}
+	return slackMessage{ResponseType: "in_channel", Text: fmt.Sprintf("This Slack team now works on the bounties of *%s*", slackEscape(workspace.Name))}
+}
+
+func (sh *slackHandler) slackCreateBounty(teamId string, slackUserId string, args string) slackMessage {
+	pubkey, message, ok := sh.slackPerson(teamId, slackUserId)
+	if !ok {
+		return message
+	}
+	team := sh.db.GetSlackTeam(teamId)
+	if team.WorkspaceUuid == "" {
+		return ephemeral("This Slack team is not connected to a workspace, run `/bounty workspace <workspace uuid>` first")
+	}
+
+	amount, title, _ := strings.Cut(args, " ")
+	title = strings.TrimSpace(title)
+	price, err := strconv.ParseUint(amount, 10, 32)
+	if err != nil || price == 0 || title == "" {
+		return ephemeral("Usage: `/bounty create <sats> <title>`")
+	}
+
+	if !sh.userHasAccess(pubkey, team.WorkspaceUuid, db.AddBounty) {
+		return ephemeral("You don't have access to create bounties in this workspace")
+	}
+
+	now := time.Now()
+	bounty := db.NewBounty{
+		OwnerID:       pubkey,
+		WorkspaceUuid: team.WorkspaceUuid,
+		Type:          "coding",
+		Title:         title,
+		Description:   title,
+		Price:         uint(price),
+		Tribe:         "None",
+		Visibility:    db.BountyVisibilityPublic,
+		Show:          sh.db.GetWorkspaceSettings(team.WorkspaceUuid).DefaultBountyVisibility != db.BountyVisibilityHidden,
+		Created:       now.Unix(),
+		Updated:       &now,
+	}
+	bounty, err = sh.db.CreateOrEditBounty(bounty)
+	if err != nil {
+		fmt.Println("[slack] could not create bounty", err)
+		return ephemeral("Could not create the bounty, try again")
+	}
+
+	link := fmt.Sprintf("%s/bounty/%d", config.Host, bounty.ID)
+	if bounty.Show {
+		PublishWorkspaceEvent(db.WorkspaceEvent{
+			WorkspaceUuid: bounty.WorkspaceUuid,
+			Type:          db.BountyCreatedEvent,
+			Title:         "New bounty",
+			Message:       fmt.Sprintf("%s for %d sats", bounty.Title, bounty.Price),
+			Link:          link,
+			Actor:         pubkey,
+		})
+	}
+	return slackMessage{ResponseType: "in_channel", Text: fmt.Sprintf("New bounty <%s|%s> for %d sats", link, slackEscape(bounty.Title), bounty.Price)}
+}
+
+func (sh *slackHandler) slackListBounties(teamId string) slackMessage {
+	team := sh.db.GetSlackTeam(teamId)
+	if team.WorkspaceUuid == "" {
+		return ephemeral("This Slack team is not connected to a workspace, run `/bounty workspace <workspace uuid>` first")
+	}
+
+	workspace := sh.db.GetWorkspaceByUuid(team.WorkspaceUuid)
+	showcase, err := sh.db.GetWorkspaceShowcase(workspace, slackListLimit)
+	if err != nil {
+		fmt.Println("[slack] could not get bounties", err)
+		return ephemeral("Could not get the bounties, try again")
+	}
+	if len(showcase.OpenBounties) == 0 {
+		return ephemeral(fmt.Sprintf("*%s* has no open bounties", slackEscape(workspace.Name)))
+	}
+
+	lines := []string{fmt.Sprintf("Open bounties of *%s* (%d):", slackEscape(workspace.Name), showcase.OpenBountiesCount)}
+	for _, bounty := range showcase.OpenBounties {
+		lines = append(lines, fmt.Sprintf("• <%s/bounty/%d|%s> %d sats", config.Host, bounty.ID, slackEscape(bounty.Title), bounty.Price))
+	}
+	return ephemeral(strings.Join(lines, "\n"))
+}
+
+// LinkSlackUser redeems a link code from /bounty link for the signed in person
+func (sh *slackHandler) LinkSlackUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[slack] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.SlackLinkRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[slack]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	link, err := sh.db.CompleteSlackLink(strings.TrimSpace(request.Code), pubKeyFromAuth, time.Now())
+	if errors.Is(err, db.ErrSlackLinkNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[slack] could not link user", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not link the Slack user")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(link)
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func signSlackRequest(secret string, timestamp int64, body string) http.Header {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + strconv.FormatInt(timestamp, 10) + ":" + body))
+	header := http.Header{}
+	header.Set("X-Slack-Request-Timestamp", strconv.FormatInt(timestamp, 10))
+	header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return header
+}
+
+func TestVerifySlackSignature(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	body := []byte("team_id=T1&user_id=U1&text=list")
+
+	assert.True(t, verifySlackSignature("secret", body, signSlackRequest("secret", now.Unix(), string(body)), now))
+	assert.False(t, verifySlackSignature("", body, signSlackRequest("", now.Unix(), string(body)), now))
+	assert.False(t, verifySlackSignature("secret", body, signSlackRequest("other", now.Unix(), string(body)), now))
+	assert.False(t, verifySlackSignature("secret", []byte("team_id=T1&user_id=U2&text=list"), signSlackRequest("secret", now.Unix(), string(body)), now))
+	assert.False(t, verifySlackSignature("secret", body, signSlackRequest("secret", now.Add(-6*time.Minute).Unix(), string(body)), now))
+	assert.False(t, verifySlackSignature("secret", body, http.Header{}, now))
+}
+
+func TestSlackCommand(t *testing.T) {
+	config.SlackSigningSecret = "slack_secret"
+	defer func() { config.SlackSigningSecret = "" }()
+
+	mockDb := dbMocks.NewDatabase(t)
+	sHandler := NewSlackHandler(mockDb)
+	sHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "admin_pubkey" && uuid == "workspace_uuid"
+	}
+
+	run := func(user string, text string) (int, slackMessage) {
+		body := url.Values{"team_id": {"T1"}, "user_id": {user}, "command": {"/bounty"}, "text": {text}}.Encode()
+		req, _ := http.NewRequest(http.MethodPost, "/slack/commands", bytes.NewBufferString(body))
+		req.Header = signSlackRequest("slack_secret", time.Now().Unix(), body)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.SlackCommand).ServeHTTP(rr, req)
+		message := slackMessage{}
+		json.Unmarshal(rr.Body.Bytes(), &message)
+		return rr.Code, message
+	}
+
+	t.Run("should refuse unsigned requests", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/slack/commands", bytes.NewBufferString("team_id=T1&user_id=U1&text=list"))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.SlackCommand).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should answer the usage to unknown commands", func(t *testing.T) {
+		code, message := run("U1", "help")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ephemeral", message.ResponseType)
+		assert.Contains(t, message.Text, "/bounty create <sats> <title>")
+	})
+
+	t.Run("should give a link code to link the Slack user", func(t *testing.T) {
+		mockDb.On("CreateSlackLinkCode", "T1", "U1", mock.MatchedBy(func(code string) bool { return len(code) == 32 }), mock.Anything).Return(nil).Once()
+
+		_, message := run("U1", "link")
+		assert.Equal(t, "ephemeral", message.ResponseType)
+		assert.Contains(t, message.Text, "/slack/link?code=")
+	})
+
+	t.Run("should ask unlinked users to link before creating bounties", func(t *testing.T) {
+		mockDb.On("GetSlackUserLink", "T1", "U2").Return(db.SlackUserLink{Code: "pending"}).Once()
+
+		_, message := run("U2", "create 1000 Fix the login page")
+		assert.Contains(t, message.Text, "not linked yet")
+	})
+
+	t.Run("should create a bounty in the connected workspace", func(t *testing.T) {
+		mockDb.On("GetSlackUserLink", "T1", "U1").Return(db.SlackUserLink{OwnerPubkey: "admin_pubkey"}).Once()
+		mockDb.On("GetSlackTeam", "T1").Return(db.SlackTeam{TeamId: "T1", WorkspaceUuid: "workspace_uuid"}).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(db.WorkspaceSettings{}).Once()
+		mockDb.On("CreateOrEditBounty", mock.MatchedBy(func(bounty db.NewBounty) bool {
+			return bounty.OwnerID == "admin_pubkey" && bounty.WorkspaceUuid == "workspace_uuid" && bounty.Price == 25000 &&
+				bounty.Title == "Fix <the> login page" && bounty.Show
+		})).Return(func(bounty db.NewBounty) (db.NewBounty, error) {
+			bounty.ID = 12
+			return bounty, nil
+		}).Once()
+
+		_, message := run("U1", "create 25000 Fix <the> login page")
+		assert.Equal(t, "in_channel", message.ResponseType)
+		assert.Contains(t, message.Text, "/bounty/12|Fix &lt;the&gt; login page> for 25000 sats")
+	})
+
+	t.Run("should validate the bounty to create", func(t *testing.T) {
+		for _, text := range []string{"create", "create 1000", "create lots Fix it", "create 0 Fix it"} {
+			mockDb.On("GetSlackUserLink", "T1", "U1").Return(db.SlackUserLink{OwnerPubkey: "admin_pubkey"}).Once()
+			mockDb.On("GetSlackTeam", "T1").Return(db.SlackTeam{TeamId: "T1", WorkspaceUuid: "workspace_uuid"}).Once()
+
+			_, message := run("U1", text)
+			assert.Contains(t, message.Text, "Usage", text)
+		}
+	})
+
+	t.Run("should only let workspace admins connect the team", func(t *testing.T) {
+		mockDb.On("GetSlackUserLink", "T1", "U3").Return(db.SlackUserLink{OwnerPubkey: "member_pubkey"}).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", Name: "Sphinx"}).Once()
+
+		_, message := run("U3", "workspace workspace_uuid")
+		assert.Contains(t, message.Text, "Only the admins")
+	})
+
+	t.Run("should connect the team to a workspace", func(t *testing.T) {
+		mockDb.On("GetSlackUserLink", "T1", "U1").Return(db.SlackUserLink{OwnerPubkey: "admin_pubkey"}).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", Name: "Sphinx"}).Once()
+		mockDb.On("SetSlackTeamWorkspace", "T1", "workspace_uuid", "admin_pubkey").Return(db.SlackTeam{TeamId: "T1", WorkspaceUuid: "workspace_uuid"}, nil).Once()
+
+		_, message := run("U1", "workspace workspace_uuid")
+		assert.Equal(t, "in_channel", message.ResponseType)
+		assert.Contains(t, message.Text, "*Sphinx*")
+	})
+
+	t.Run("should list the open bounties of the workspace", func(t *testing.T) {
+		workspace := db.Workspace{Uuid: "workspace_uuid", Name: "Sphinx"}
+		mockDb.On("GetSlackTeam", "T1").Return(db.SlackTeam{TeamId: "T1", WorkspaceUuid: "workspace_uuid"}).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(workspace).Once()
+		mockDb.On("GetWorkspaceShowcase", workspace, slackListLimit).Return(db.WorkspaceShowcase{
+			OpenBounties:      []db.ShowcaseBounty{{ID: 12, Title: "Fix the login page", Price: 25000}, {ID: 14, Title: "Add dark mode", Price: 5000}},
+			OpenBountiesCount: 2,
+		}, nil).Once()
+
+		_, message := run("U4", "list")
+		assert.Equal(t, "ephemeral", message.ResponseType)
+		assert.Contains(t, message.Text, "Open bounties of *Sphinx* (2):")
+		assert.Contains(t, message.Text, "/bounty/14|Add dark mode> 5000 sats")
+	})
+}
+
+func TestLinkSlackUser(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	sHandler := NewSlackHandler(mockDb)
+
+	newRequest := func(pubkey string, body string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/slack/link", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should require a signed in person", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.LinkSlackUser).ServeHTTP(rr, newRequest("", `{"code": "abc"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should refuse unknown or expired codes", func(t *testing.T) {
+		mockDb.On("CompleteSlackLink", "expired", "person_pubkey", mock.Anything).Return(db.SlackUserLink{}, db.ErrSlackLinkNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.LinkSlackUser).ServeHTTP(rr, newRequest("person_pubkey", `{"code": "expired"}`))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should link the Slack user to the person", func(t *testing.T) {
+		mockDb.On("CompleteSlackLink", "abc", "person_pubkey", mock.Anything).Return(db.SlackUserLink{TeamId: "T1", SlackUserId: "U1", OwnerPubkey: "person_pubkey"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(sHandler.LinkSlackUser).ServeHTTP(rr, newRequest("person_pubkey", `{"code": " abc "}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		link := db.SlackUserLink{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &link))
+		assert.Equal(t, "U1", link.SlackUserId)
+	})
+}
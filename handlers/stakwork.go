@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+const stakworkProjectsUrl = "https://jobs.stakwork.com/api/v1/projects"
+
+// submitStakworkProject starts a run of a Stakwork workflow with vars and
+// returns its project id, the workflow posts what it does to the callback url
+func submitStakworkProject(httpClient HttpClient, name string, workflowId string, callback string, vars map[string]string) (int64, error) {
+	payload := map[string]interface{}{
+		"name":        name,
+		"workflow_id": workflowId,
+		"webhook_url": callback,
+		"workflow_params": map[string]interface{}{
+			"set_var": map[string]interface{}{
+				"attributes": map[string]interface{}{
+					"vars": vars,
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, stakworkProjectsUrl, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Token token=%s", config.GetSecret("STAKWORK_KEY")))
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return 0, fmt.Errorf("stakwork answered %d", res.StatusCode)
+	}
+
+	project := struct {
+		Success bool `json:"success"`
+		Data    struct {
+			ProjectId int64 `json:"project_id"`
+		} `json:"data"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&project); err != nil {
+		return 0, err
+	}
+	if !project.Success || project.Data.ProjectId == 0 {
+		return 0, errors.New("stakwork did not start the project")
+	}
+	return project.Data.ProjectId, nil
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const maxTagNameLength = 50
+
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// GetWorkspaceTags lists the tags of a workspace, so the bounty lists can be filtered by them
+func (oh *workspaceHandler) GetWorkspaceTags(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	tags := oh.db.GetWorkspaceTags(uuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tags)
+}
+
+// readWorkspaceTag reads and checks the tag of a create or update request, it
+// writes the error response and returns false when the tag can't be saved
+func (oh *workspaceHandler) readWorkspaceTag(w http.ResponseWriter, r *http.Request) (db.WorkspaceTag, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tags] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return db.WorkspaceTag{}, false
+	}
+
+	if !oh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage tags")
+		return db.WorkspaceTag{}, false
+	}
+
+	tag := db.WorkspaceTag{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &tag)
+	}
+	if err != nil {
+		fmt.Println("[tags]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return db.WorkspaceTag{}, false
+	}
+
+	tag.Name = strings.TrimSpace(tag.Name)
+	if tag.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Name is a required field")
+		return db.WorkspaceTag{}, false
+	}
+	if len(tag.Name) > maxTagNameLength {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Name can be at most %d characters", maxTagNameLength))
+		return db.WorkspaceTag{}, false
+	}
+	if tag.Color != "" && !tagColorPattern.MatchString(tag.Color) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Color must be a hex color like #1a2b3c")
+		return db.WorkspaceTag{}, false
+	}
+
+	tag.WorkspaceUuid = uuid
+	return tag, true
+}
+
+func writeTagError(w http.ResponseWriter, err error, action string) {
+	switch {
+	case errors.Is(err, db.ErrTagNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+	case errors.Is(err, db.ErrTagNameTaken):
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+	default:
+		fmt.Println("[tags] could not", action, "tag", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Could not %s the tag", action))
+	}
+}
+
+func (oh *workspaceHandler) CreateWorkspaceTag(w http.ResponseWriter, r *http.Request) {
+	tag, ok := oh.readWorkspaceTag(w, r)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	tag.ID = 0
+	tag.Uuid = xid.New().String()
+	tag.CreatedBy, _ = r.Context().Value(auth.ContextKey).(string)
+	tag.Created = &now
+	tag.Updated = &now
+
+	tag, err := oh.db.CreateWorkspaceTag(tag)
+	if err != nil {
+		writeTagError(w, err, "create")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tag)
+}
+
+func (oh *workspaceHandler) UpdateWorkspaceTag(w http.ResponseWriter, r *http.Request) {
+	tag, ok := oh.readWorkspaceTag(w, r)
+	if !ok {
+		return
+	}
+	tag.Uuid = chi.URLParam(r, "tag_uuid")
+
+	tag, err := oh.db.UpdateWorkspaceTag(tag)
+	if err != nil {
+		writeTagError(w, err, "update")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tag)
+}
+
+// DeleteWorkspaceTag deletes a tag, the bounties tagged with it lose the tag
+func (oh *workspaceHandler) DeleteWorkspaceTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tags] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !oh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage tags")
+		return
+	}
+
+	if err := oh.db.DeleteWorkspaceTag(uuid, chi.URLParam(r, "tag_uuid")); err != nil {
+		writeTagError(w, err, "delete")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Tag deleted")
+}
+
+// tagBounty loads the bounty of the request and checks the user can manage it,
+// then saves the tags edit returns for it
+func (h *bountyHandler) tagBounty(w http.ResponseWriter, r *http.Request, edit func(tags []string) []string) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[bounty] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	bounty, ok := h.getBountyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.canManageBounty(pubKeyFromAuth, bounty) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to tag the bounty")
+		return
+	}
+
+	bounty, err := h.db.SetBountyTags(bounty, edit(bounty.Tags))
+	if errors.Is(err, db.ErrTagNotFound) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Tags must belong to the workspace of the bounty")
+		return
+	}
+	if err != nil {
+		fmt.Println("[bounty] could not tag bounty", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not tag the bounty")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bounty.Tags)
+}
+
+// SetBountyTags replaces all the tags of a bounty
+func (h *bountyHandler) SetBountyTags(w http.ResponseWriter, r *http.Request) {
+	request := db.BountyTagsRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[bounty]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	h.tagBounty(w, r, func(tags []string) []string {
+		return request.Tags
+	})
+}
+
+func (h *bountyHandler) AddBountyTag(w http.ResponseWriter, r *http.Request) {
+	tag := chi.URLParam(r, "tag_uuid")
+	h.tagBounty(w, r, func(tags []string) []string {
+		return append(tags, tag)
+	})
+}
+
+func (h *bountyHandler) RemoveBountyTag(w http.ResponseWriter, r *http.Request) {
+	tag := chi.URLParam(r, "tag_uuid")
+	h.tagBounty(w, r, func(tags []string) []string {
+		kept := []string{}
+		for _, t := range tags {
+			if t != tag {
+				kept = append(kept, t)
+			}
+		}
+		return kept
+	})
+}
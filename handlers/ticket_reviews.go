@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
+)
+
+type ticketReviewHandler struct {
+	db               db.Database
+	httpClient       HttpClient
+	broadcastMessage func(message websocket.Message)
+}
+
+func NewTicketReviewHandler(httpClient HttpClient, database db.Database) *ticketReviewHandler {
+	return &ticketReviewHandler{
+		db:               database,
+		httpClient:       httpClient,
+		broadcastMessage: broadcastToWebsocketPool,
+	}
+}
+
+// broadcastProgress tells the clients following a review that it moved on
+func (th *ticketReviewHandler) broadcastProgress(review db.TicketReview) {
+	body, _ := json.Marshal(review)
+	th.broadcastMessage(websocket.Message{Type: 1, Msg: "ticket_review_progress", Body: string(body)})
+}
+
+// SubmitTicketReview sends a ticket to the review workflow and records the run
+func (th *ticketReviewHandler) SubmitTicketReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	ticketUuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tickets] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	request := db.TicketReviewRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[tickets]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if strings.TrimSpace(request.Title) == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("title is a required field")
+		return
+	}
+
+	if !isWorkspaceMember(th.db, pubKeyFromAuth, request.WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the workspace")
+		return
+	}
+
+	if config.TicketReviewWorkflowId == "" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode("Ticket reviews are not configured")
+		return
+	}
+
+	review, err := th.db.CreateTicketReview(db.TicketReview{
+		Uuid:          xid.New().String(),
+		TicketUuid:    ticketUuid,
+		WorkspaceUuid: request.WorkspaceUuid,
+		Status:        db.TicketReviewPending,
+		Submission:    fmt.Sprintf("# %s\n\n%s", strings.TrimSpace(request.Title), request.Description),
+		CallbackToken: utils.GetRandomToken(32),
+		SubmittedBy:   pubKeyFromAuth,
+	})
+	if err != nil {
+		fmt.Println("[tickets] could not create review", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not create the review")
+		return
+	}
+
+	callback := fmt.Sprintf("%s/tickets/reviews/%s/progress?token=%s", config.Host, review.Uuid, url.QueryEscape(review.CallbackToken))
+	projectId, err := submitStakworkProject(th.httpClient, fmt.Sprintf("Ticket review %s", review.TicketUuid), config.TicketReviewWorkflowId, callback, map[string]string{
+		"ticket_uuid":    review.TicketUuid,
+		"workspace_uuid": review.WorkspaceUuid,
+		"title":          request.Title,
+		"description":    request.Description,
+		"progress_url":   callback,
+	})
+	if err != nil {
+		fmt.Println("[tickets] could not submit review", review.Uuid, err)
+		now := time.Now()
+		review.Status = db.TicketReviewFailed
+		review.Error = err.Error()
+		review.Completed = &now
+	} else {
+		RecordWorkspaceUsage(review.WorkspaceUuid, db.UsageStakworkRun)
+		review.Status = db.TicketReviewSubmitted
+		review.ProjectId = projectId
+	}
+
+	review, err = th.db.UpdateTicketReview(review)
+	if err != nil {
+		fmt.Println("[tickets] could not update review", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not update the review")
+		return
+	}
+	th.broadcastProgress(review)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(review)
+}
+
+// GetTicketReviews lists the review runs of a ticket, newest first
+func (th *ticketReviewHandler) GetTicketReviews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[tickets] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	reviews := th.db.GetTicketReviews(chi.URLParam(r, "uuid"))
+	if len(reviews) > 0 && !isWorkspaceMember(th.db, pubKeyFromAuth, reviews[0].WorkspaceUuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to the workspace")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reviews)
+}
+
+// ReceiveTicketReviewProgress records the progress the review workflow posts
+// back, authenticated by the token of the callback url
+func (th *ticketReviewHandler) ReceiveTicketReviewProgress(w http.ResponseWriter, r *http.Request) {
+	review, err := th.db.GetTicketReviewByUuid(chi.URLParam(r, "review_uuid"))
+	if errors.Is(err, db.ErrTicketReviewNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	token := r.URL.Query().Get("token")
+	if token == "" || !hmac.Equal([]byte(token), []byte(review.CallbackToken)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Invalid token")
+		return
+	}
+
+	progress := db.TicketReviewProgress{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &progress)
+	}
+	if err != nil {
+		fmt.Println("[tickets]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	switch progress.Status {
+	case db.TicketReviewRunning, db.TicketReviewCompleted, db.TicketReviewFailed:
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("status must be running, completed or failed")
+		return
+	}
+	if progress.Score != nil && (*progress.Score < 0 || *progress.Score > 100) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("score must be between 0 and 100")
+		return
+	}
+
+	if review.Finished() {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("The review is already finished")
+		return
+	}
+
+	review.Status = progress.Status
+	if progress.Step != "" {
+		review.Step = progress.Step
+	}
+	if progress.Progress > review.Progress && progress.Progress <= 100 {
+		review.Progress = progress.Progress
+	}
+	if progress.Response != "" {
+		review.Response = progress.Response
+	}
+	if progress.Score != nil {
+		review.Score = progress.Score
+	}
+	if progress.Feedback != "" {
+		review.Feedback = progress.Feedback
+	}
+	review.Error = progress.Error
+	if review.Finished() {
+		now := time.Now()
+		review.Completed = &now
+		if review.Status == db.TicketReviewCompleted {
+			review.Progress = 100
+		}
+	}
+
+	review, err = th.db.UpdateTicketReview(review)
+	if err != nil {
+		fmt.Println("[tickets] could not update review", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not update the review")
+		return
+	}
+	th.broadcastProgress(review)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(review)
+}
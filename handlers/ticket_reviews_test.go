@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSubmitTicketReview(t *testing.T) {
+	config.TicketReviewWorkflowId = "43198"
+	defer func() { config.TicketReviewWorkflowId = "" }()
+
+	mockDb := dbMocks.NewDatabase(t)
+	mockHttpClient := mocks.NewHttpClient(t)
+	tHandler := NewTicketReviewHandler(mockHttpClient, mockDb)
+	broadcasts := []websocket.Message{}
+	tHandler.broadcastMessage = func(message websocket.Message) {
+		broadcasts = append(broadcasts, message)
+	}
+
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+	newRequest := func(pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "ticket_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/ticket_uuid/reviews", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should only let workspace members submit reviews", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(workspace).Once()
+		mockDb.On("GetWorkspaceUser", "stranger_pubkey", "workspace_uuid").Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.SubmitTicketReview).ServeHTTP(rr, newRequest("stranger_pubkey", `{"workspace_uuid": "workspace_uuid", "title": "Add login"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should submit the ticket to the review workflow", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(workspace).Once()
+		mockDb.On("CreateTicketReview", mock.MatchedBy(func(review db.TicketReview) bool {
+			return review.TicketUuid == "ticket_uuid" && review.Status == db.TicketReviewPending &&
+				review.Submission == "# Add login\n\nWith lightning" && review.CallbackToken != ""
+		})).Return(func(review db.TicketReview) (db.TicketReview, error) {
+			return review, nil
+		}).Once()
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			payload := map[string]interface{}{}
+			json.Unmarshal(requestBody(req), &payload)
+			return req.URL.String() == stakworkProjectsUrl && payload["workflow_id"] == "43198" &&
+				strings.Contains(payload["webhook_url"].(string), "/progress?token=")
+		})).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"success": true, "data": {"project_id": 9001}}`))}, nil).Once()
+		mockDb.On("UpdateTicketReview", mock.MatchedBy(func(review db.TicketReview) bool {
+			return review.Status == db.TicketReviewSubmitted && review.ProjectId == 9001
+		})).Return(func(review db.TicketReview) (db.TicketReview, error) {
+			return review, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.SubmitTicketReview).ServeHTTP(rr, newRequest("owner_pubkey", `{"workspace_uuid": "workspace_uuid", "title": "Add login", "description": "With lightning"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "callback_token")
+		assert.Len(t, broadcasts, 1)
+		assert.Equal(t, "ticket_review_progress", broadcasts[0].Msg)
+	})
+
+	t.Run("should record a review Stakwork did not start as failed", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(workspace).Once()
+		mockDb.On("CreateTicketReview", mock.Anything).Return(func(review db.TicketReview) (db.TicketReview, error) {
+			return review, nil
+		}).Once()
+		mockHttpClient.On("Do", mock.Anything).Return(&http.Response{StatusCode: http.StatusUnauthorized, Body: io.NopCloser(strings.NewReader(`{}`))}, nil).Once()
+		mockDb.On("UpdateTicketReview", mock.MatchedBy(func(review db.TicketReview) bool {
+			return review.Status == db.TicketReviewFailed && review.Error == "stakwork answered 401" && review.Completed != nil
+		})).Return(func(review db.TicketReview) (db.TicketReview, error) {
+			return review, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.SubmitTicketReview).ServeHTTP(rr, newRequest("owner_pubkey", `{"workspace_uuid": "workspace_uuid", "title": "Add login"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestReceiveTicketReviewProgress(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	tHandler := NewTicketReviewHandler(mocks.NewHttpClient(t), mockDb)
+	broadcasts := []websocket.Message{}
+	tHandler.broadcastMessage = func(message websocket.Message) {
+		broadcasts = append(broadcasts, message)
+	}
+
+	review := db.TicketReview{Uuid: "review_uuid", TicketUuid: "ticket_uuid", Status: db.TicketReviewSubmitted, Progress: 40, CallbackToken: "callback_token"}
+	newRequest := func(token string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("review_uuid", "review_uuid")
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodPost,
+			"/reviews/review_uuid/progress?token="+token, bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should refuse progress without the callback token", func(t *testing.T) {
+		mockDb.On("GetTicketReviewByUuid", "review_uuid").Return(review, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.ReceiveTicketReviewProgress).ServeHTTP(rr, newRequest("wrong", `{"status": "running"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should validate the progress", func(t *testing.T) {
+		for _, body := range []string{`{"status": "submitted"}`, `{"status": "completed", "score": 120}`} {
+			mockDb.On("GetTicketReviewByUuid", "review_uuid").Return(review, nil).Once()
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(tHandler.ReceiveTicketReviewProgress).ServeHTTP(rr, newRequest("callback_token", body))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+	})
+
+	t.Run("should record the response and score of a completed review", func(t *testing.T) {
+		mockDb.On("GetTicketReviewByUuid", "review_uuid").Return(review, nil).Once()
+		mockDb.On("UpdateTicketReview", mock.MatchedBy(func(updated db.TicketReview) bool {
+			return updated.Status == db.TicketReviewCompleted && updated.Progress == 100 && updated.Step == "scoring" &&
+				updated.Response == "Looks ready" && *updated.Score == 87 && updated.Completed != nil
+		})).Return(func(updated db.TicketReview) (db.TicketReview, error) {
+			return updated, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.ReceiveTicketReviewProgress).ServeHTTP(rr, newRequest("callback_token",
+			`{"status": "completed", "step": "scoring", "progress": 90, "response": "Looks ready", "score": 87}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, broadcasts, 1)
+		assert.Contains(t, broadcasts[0].Body, `"score":87`)
+	})
+
+	t.Run("should not change a finished review", func(t *testing.T) {
+		finished := review
+		finished.Status = db.TicketReviewCompleted
+		mockDb.On("GetTicketReviewByUuid", "review_uuid").Return(finished, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.ReceiveTicketReviewProgress).ServeHTTP(rr, newRequest("callback_token", `{"status": "running"}`))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
+
+func TestGetTicketReviews(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	tHandler := NewTicketReviewHandler(mocks.NewHttpClient(t), mockDb)
+
+	newRequest := func(pubkey string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "ticket_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodGet, "/ticket_uuid/reviews", nil)
+		return req
+	}
+	reviews := []db.TicketReview{{Uuid: "review_uuid", TicketUuid: "ticket_uuid", WorkspaceUuid: "workspace_uuid", Status: db.TicketReviewRunning}}
+
+	t.Run("should only show reviews to workspace members", func(t *testing.T) {
+		mockDb.On("GetTicketReviews", "ticket_uuid").Return(reviews).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}).Once()
+		mockDb.On("GetWorkspaceUser", "stranger_pubkey", "workspace_uuid").Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetTicketReviews).ServeHTTP(rr, newRequest("stranger_pubkey"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should list the reviews of the ticket", func(t *testing.T) {
+		mockDb.On("GetTicketReviews", "ticket_uuid").Return(reviews).Once()
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetTicketReviews).ServeHTTP(rr, newRequest("owner_pubkey"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		listed := []db.TicketReview{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &listed))
+		assert.Len(t, listed, 1)
+		assert.Equal(t, db.TicketReviewRunning, listed[0].Status)
+	})
+}
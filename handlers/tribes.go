@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,8 +11,8 @@ import (
 
 	"github.com/go-chi/chi"
 	"github.com/stakwork/sphinx-tribes/auth"
-	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/relay"
 	"github.com/stakwork/sphinx-tribes/utils"
 )
 
@@ -21,6 +20,7 @@ type tribeHandler struct {
 	db                      db.Database
 	verifyTribeUUID         func(uuid string, checkTimestamp bool) (string, error)
 	tribeUniqueNameFromName func(name string) (string, error)
+	assetService            AssetService
 }
 
 func NewTribeHandler(db db.Database) *tribeHandler {
@@ -28,6 +28,7 @@ func NewTribeHandler(db db.Database) *tribeHandler {
 		db:                      db,
 		verifyTribeUUID:         auth.VerifyTribeUUID,
 		tribeUniqueNameFromName: TribeUniqueNameFromName,
+		assetService:            NewAssetService(utils.TracedClient),
 	}
 }
 
@@ -172,6 +173,142 @@ func (th *tribeHandler) GetTribe(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(theTribe)
 }
 
+// GetTribeBadges returns the badges of a tribe with how many people hold each one
+func (th *tribeHandler) GetTribeBadges(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+	tribe := th.db.GetTribe(uuid)
+	if tribe.UUID == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Tribe not found")
+		return
+	}
+
+	counts := th.db.GetTribeBadgeCounts(uuid)
+	countByBadge := map[string]db.TribeBadge{}
+	for _, count := range counts {
+		countByBadge[count.Badge] = count
+	}
+
+	badges := []db.TribeBadge{}
+	listed := map[string]bool{}
+	for _, badge := range tribe.Badges {
+		count, ok := countByBadge[badge]
+		if !ok {
+			count = db.TribeBadge{Badge: badge}
+		}
+		badges = append(badges, count)
+		listed[badge] = true
+	}
+	// badges removed from the tribe can still have holders
+	for _, count := range counts {
+		if !listed[count.Badge] {
+			badges = append(badges, count)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(badges)
+}
+
+// getOwnedTribe returns the tribe in the url if the authenticated user owns it
+func (th *tribeHandler) getOwnedTribe(w http.ResponseWriter, r *http.Request) (db.Tribe, string, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return db.Tribe{}, "", false
+	}
+
+	tribe := th.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Tribe not found")
+		return tribe, pubKeyFromAuth, false
+	}
+
+	if tribe.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the tribe owner can manage badges")
+		return tribe, pubKeyFromAuth, false
+	}
+	return tribe, pubKeyFromAuth, true
+}
+
+// IssueTribeBadge mints a tribe badge to each pubkey and tracks every issuance
+func (th *tribeHandler) IssueTribeBadge(w http.ResponseWriter, r *http.Request) {
+	tribe, pubKeyFromAuth, ok := th.getOwnedTribe(w, r)
+	if !ok {
+		return
+	}
+
+	request := db.BadgeIssuanceRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	err = json.Unmarshal(body, &request)
+	if err != nil {
+		fmt.Println(err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	isTribeBadge := false
+	for _, badge := range tribe.Badges {
+		if badge == request.Badge {
+			isTribeBadge = true
+		}
+	}
+	if !isTribeBadge || len(request.Pubkeys) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("A badge of the tribe and at least one pubkey are required")
+		return
+	}
+	if request.Amount == 0 {
+		request.Amount = 1
+	}
+
+	issuances := []db.BadgeIssuance{}
+	for _, pubkey := range request.Pubkeys {
+		issuance, err := th.db.CreateBadgeIssuance(db.BadgeIssuance{
+			TribeUuid:       tribe.UUID,
+			Badge:           request.Badge,
+			RecipientPubkey: pubkey,
+			Amount:          request.Amount,
+			IssuedBy:        pubKeyFromAuth,
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		txId, err := th.assetService.IssueBadge(request.Badge, pubkey, request.Amount)
+		if err != nil {
+			issuance.Status = db.BadgeIssuanceFailed
+			issuance.Error = err.Error()
+		} else {
+			issuance.Status = db.BadgeIssuanceIssued
+			issuance.TxId = txId
+		}
+		th.db.UpdateBadgeIssuanceStatus(issuance.ID, issuance.Status, issuance.TxId, issuance.Error)
+
+		issuances = append(issuances, issuance)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(issuances)
+}
+
+func (th *tribeHandler) GetTribeBadgeIssuances(w http.ResponseWriter, r *http.Request) {
+	tribe, _, ok := th.getOwnedTribe(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(th.db.GetBadgeIssuances(tribe.UUID))
+}
+
 func (th *tribeHandler) GetFirstTribeByFeed(w http.ResponseWriter, r *http.Request) {
 	url := r.URL.Query().Get("url")
 	tribe := th.db.GetFirstTribeByFeedURL(url)
@@ -490,21 +627,17 @@ func GenerateInvoice(w http.ResponseWriter, r *http.Request) {
 	routeHint := invoice.Route_hint
 	amount, _ := utils.ConvertStringToUint(invoice.Amount)
 
-	url := fmt.Sprintf("%s/invoices", config.RelayUrl)
-
 	bodyData := fmt.Sprintf(`{"amount": %d, "memo": "%s"}`, amount, memo)
 
 	jsonBody := []byte(bodyData)
 
-	client := &http.Client{}
-	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
-
-	req.Header.Set("x-user-token", config.RelayAuthKey)
-	req.Header.Set("Content-Type", "application/json")
-	res, _ := client.Do(req)
+	client := relay.NewClient(utils.TracedClient)
+	req, _ := client.NewRequest(r.Context(), http.MethodPost, "/invoices", jsonBody)
+	res, err := client.Do(req)
 
 	if err != nil {
 		log.Printf("Request Failed: %s", err)
+		w.WriteHeader(http.StatusBadGateway)
 		return
 	}
 
@@ -579,21 +712,17 @@ func (th *tribeHandler) GenerateBudgetInvoice(w http.ResponseWriter, r *http.Req
 		invoice.WorkspaceUuid = invoice.OrgUuid
 	}
 
-	url := fmt.Sprintf("%s/invoices", config.RelayUrl)
-
 	bodyData := fmt.Sprintf(`{"amount": %d, "memo": "%s"}`, invoice.Amount, "Budget Invoice")
 
 	jsonBody := []byte(bodyData)
 
-	client := &http.Client{}
-	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
-
-	req.Header.Set("x-user-token", config.RelayAuthKey)
-	req.Header.Set("Content-Type", "application/json")
-	res, _ := client.Do(req)
+	client := relay.NewClient(utils.TracedClient)
+	req, _ := client.NewRequest(r.Context(), http.MethodPost, "/invoices", jsonBody)
+	res, err := client.Do(req)
 
 	if err != nil {
 		log.Printf("Request Failed: %s", err)
+		w.WriteHeader(http.StatusBadGateway)
 		return
 	}
 
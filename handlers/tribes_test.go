@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -16,7 +17,10 @@ import (
 	"github.com/lib/pq"
 	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers/mocks"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetTribesByOwner(t *testing.T) {
@@ -958,3 +962,75 @@ func TestGenerateBudgetInvoice(t *testing.T) {
 		assert.Equal(t, "example_invoice", response.Response.Invoice, "The invoice in the response should match the mock")
 	})
 }
+
+func TestTribeBadges(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mockAssetService := mocks.NewAssetService(t)
+	tHandler := NewTribeHandler(mockDb)
+	tHandler.assetService = mockAssetService
+
+	tribe := db.Tribe{UUID: "tribe_uuid", OwnerPubKey: "owner_pubkey", Badges: pq.StringArray{"Helper", "Builder"}}
+
+	newRequest := func(method string, path string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", tribe.UUID)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner_pubkey")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), method, path, bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should return every tribe badge with its holder count", func(t *testing.T) {
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("GetTribeBadgeCounts", tribe.UUID).Return([]db.TribeBadge{
+			{Badge: "Builder", Holders: 3, Failed: 1},
+			{Badge: "Retired", Holders: 2},
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.GetTribeBadges).ServeHTTP(rr, newRequest(http.MethodGet, "/tribe_uuid/badges", ""))
+
+		var badges []db.TribeBadge
+		json.Unmarshal(rr.Body.Bytes(), &badges)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, []db.TribeBadge{
+			{Badge: "Helper"},
+			{Badge: "Builder", Holders: 3, Failed: 1},
+			{Badge: "Retired", Holders: 2},
+		}, badges)
+	})
+
+	t.Run("should track the status of each issuance", func(t *testing.T) {
+		mockDb.On("GetTribe", tribe.UUID).Return(tribe).Once()
+		mockDb.On("CreateBadgeIssuance", mock.MatchedBy(func(i db.BadgeIssuance) bool {
+			return i.Badge == "Helper" && i.RecipientPubkey == "pubkey_1" && i.Amount == 1
+		})).Return(db.BadgeIssuance{ID: 1, Badge: "Helper", RecipientPubkey: "pubkey_1", Amount: 1}, nil).Once()
+		mockDb.On("CreateBadgeIssuance", mock.MatchedBy(func(i db.BadgeIssuance) bool {
+			return i.RecipientPubkey == "pubkey_2"
+		})).Return(db.BadgeIssuance{ID: 2, Badge: "Helper", RecipientPubkey: "pubkey_2", Amount: 1}, nil).Once()
+		mockAssetService.On("IssueBadge", "Helper", "pubkey_1", uint(1)).Return("tx_1", nil).Once()
+		mockAssetService.On("IssueBadge", "Helper", "pubkey_2", uint(1)).Return("", errors.New("asset service down")).Once()
+		mockDb.On("UpdateBadgeIssuanceStatus", uint(1), db.BadgeIssuanceIssued, "tx_1", "").Return(nil).Once()
+		mockDb.On("UpdateBadgeIssuanceStatus", uint(2), db.BadgeIssuanceFailed, "", "asset service down").Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.IssueTribeBadge).ServeHTTP(rr, newRequest(http.MethodPost, "/tribe_uuid/badges/issue", `{"badge": "Helper", "pubkeys": ["pubkey_1", "pubkey_2"]}`))
+
+		var issuances []db.BadgeIssuance
+		json.Unmarshal(rr.Body.Bytes(), &issuances)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, issuances, 2)
+		assert.Equal(t, db.BadgeIssuanceIssued, issuances[0].Status)
+		assert.Equal(t, db.BadgeIssuanceFailed, issuances[1].Status)
+	})
+
+	t.Run("should only let the tribe owner issue badges", func(t *testing.T) {
+		mockDb.On("GetTribe", tribe.UUID).Return(db.Tribe{UUID: tribe.UUID, OwnerPubKey: "other_pubkey"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(tHandler.IssueTribeBadge).ServeHTTP(rr, newRequest(http.MethodPost, "/tribe_uuid/badges/issue", `{"badge": "Helper", "pubkeys": ["pubkey_1"]}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const usageDayLayout = "2006-01-02"
+
+// usageMaxDays is the longest range of days a usage report covers
+const usageMaxDays = 366
+
+type usageKey struct {
+	workspaceUuid string
+	day           string
+	kind          db.UsageKind
+}
+
+// UsageCounter counts the usage of the workspaces in memory until it's
+// flushed into the daily rollups
+type UsageCounter struct {
+	mu     sync.Mutex
+	counts map[usageKey]int64
+}
+
+func NewUsageCounter() *UsageCounter {
+	return &UsageCounter{counts: map[usageKey]int64{}}
+}
+
+// WorkspaceUsage is the usage counted since the last flush
+var WorkspaceUsage = NewUsageCounter()
+
+func (uc *UsageCounter) Record(workspaceUuid string, kind db.UsageKind, now time.Time) {
+	if workspaceUuid == "" {
+		return
+	}
+	key := usageKey{workspaceUuid: workspaceUuid, day: now.UTC().Format(usageDayLayout), kind: kind}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.counts[key]++
+}
+
+// take empties the counter and returns what it counted as rollups
+func (uc *UsageCounter) take() []db.WorkspaceUsageDaily {
+	uc.mu.Lock()
+	counts := uc.counts
+	uc.counts = map[usageKey]int64{}
+	uc.mu.Unlock()
+
+	usage := make([]db.WorkspaceUsageDaily, 0, len(counts))
+	for key, count := range counts {
+		usage = append(usage, db.WorkspaceUsageDaily{WorkspaceUuid: key.workspaceUuid, Day: key.day, Kind: key.kind, Count: count})
+	}
+	return usage
+}
+
+// restore puts back rollups that could not be flushed
+func (uc *UsageCounter) restore(usage []db.WorkspaceUsageDaily) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	for _, u := range usage {
+		uc.counts[usageKey{workspaceUuid: u.WorkspaceUuid, day: u.Day, kind: u.Kind}] += u.Count
+	}
+}
+
+// Flush adds what was counted to the daily rollups, if the db fails the
+// counts are kept for the next flush
+func (uc *UsageCounter) Flush(database db.Database) error {
+	usage := uc.take()
+	if err := database.AddWorkspaceUsage(usage); err != nil {
+		uc.restore(usage)
+		return err
+	}
+	return nil
+}
+
+// RecordWorkspaceUsage counts a call of a workspace to the ai or stakwork
+func RecordWorkspaceUsage(workspaceUuid string, kind db.UsageKind) {
+	WorkspaceUsage.Record(workspaceUuid, kind, time.Now())
+}
+
+func FlushWorkspaceUsage(database db.Database) {
+	if err := WorkspaceUsage.Flush(database); err != nil {
+		fmt.Println("[usage] could not flush usage", err)
+	}
+}
+
+type usageScopeKey struct{}
+
+// usageScope is the workspace a request counts for when its route doesn't say
+type usageScope struct {
+	workspaceUuid string
+}
+
+// ObserveApiTokenUsage counts a request made with an api token for the
+// workspace of the token, auth tells it about every such request
+func ObserveApiTokenUsage(r *http.Request, token auth.ApiToken) {
+	if scope, ok := r.Context().Value(usageScopeKey{}).(*usageScope); ok {
+		scope.workspaceUuid = token.WorkspaceUuid
+	}
+}
+
+// usageWorkspace finds the workspace of a request from its route, the uuid of
+// the workspace routes is the workspace and other routes call it workspace_uuid
+func usageWorkspace(rctx *chi.Context, scope *usageScope) string {
+	if uuid := rctx.URLParam("workspace_uuid"); uuid != "" {
+		return uuid
+	}
+	if strings.HasPrefix(rctx.RoutePattern(), "/workspaces/") {
+		if uuid := rctx.URLParam("uuid"); uuid != "" {
+			return uuid
+		}
+	}
+	return scope.workspaceUuid
+}
+
+// UsageMiddleware counts the api calls of every workspace. Calls that were
+// refused or hit a workspace that doesn't exist are not counted
+func UsageMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope := &usageScope{}
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), usageScopeKey{}, scope)))
+
+		switch ww.Status() {
+		case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+			return
+		}
+		rctx := chi.RouteContext(r.Context())
+		if rctx == nil || rctx.RoutePattern() == "" {
+			return
+		}
+		RecordWorkspaceUsage(usageWorkspace(rctx, scope), db.UsageApiCall)
+	})
+}
+
+// usageRange reads the from and to days of a report, the last 30 days by default
+func usageRange(r *http.Request, now time.Time) (string, string, error) {
+	to := now.UTC()
+	if value := r.URL.Query().Get("to"); value != "" {
+		parsed, err := time.Parse(usageDayLayout, value)
+		if err != nil {
+			return "", "", fmt.Errorf("to must be a day like %s", usageDayLayout)
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -29)
+	if value := r.URL.Query().Get("from"); value != "" {
+		parsed, err := time.Parse(usageDayLayout, value)
+		if err != nil {
+			return "", "", fmt.Errorf("from must be a day like %s", usageDayLayout)
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		return "", "", fmt.Errorf("from must not be after to")
+	}
+	if to.Sub(from) >= usageMaxDays*24*time.Hour {
+		return "", "", fmt.Errorf("a report covers at most %d days", usageMaxDays)
+	}
+	return from.Format(usageDayLayout), to.Format(usageDayLayout), nil
+}
+
+func newUsageTotals() map[db.UsageKind]int64 {
+	totals := map[db.UsageKind]int64{}
+	for _, kind := range db.UsageKinds {
+		totals[kind] = 0
+	}
+	return totals
+}
+
+// usageDays sums rollups per day and kind, the days come out in order
+func usageDays(usage []db.WorkspaceUsageDaily) ([]db.UsageDay, map[db.UsageKind]int64) {
+	totals := newUsageTotals()
+	days := []db.UsageDay{}
+	index := map[string]int{}
+	for _, u := range usage {
+		i, ok := index[u.Day]
+		if !ok {
+			i = len(days)
+			index[u.Day] = i
+			days = append(days, db.UsageDay{Day: u.Day, Counts: newUsageTotals()})
+		}
+		days[i].Counts[u.Kind] += u.Count
+		totals[u.Kind] += u.Count
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Day < days[j].Day
+	})
+	return days, totals
+}
+
+// GetWorkspaceUsage returns the daily usage of a workspace to its owner
+func (oh *workspaceHandler) GetWorkspaceUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[usage] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	workspace := oh.db.GetWorkspaceByUuid(uuid)
+	if workspace.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+	if workspace.OwnerPubKey != pubKeyFromAuth {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only the workspace owner can see its usage")
+		return
+	}
+
+	from, to, err := usageRange(r, time.Now())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	days, totals := usageDays(oh.db.GetWorkspaceUsage(uuid, from, to))
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.WorkspaceUsageReport{
+		WorkspaceUuid: uuid,
+		From:          from,
+		To:            to,
+		Totals:        totals,
+		Days:          days,
+	})
+}
+
+// GetUsageCapacity returns the usage of every workspace, the busiest first,
+// with the daily usage of all of them for capacity planning
+func (mh *metricHandler) GetUsageCapacity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[usage] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	from, to, err := usageRange(r, time.Now())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	usage := mh.db.GetAllWorkspaceUsage(from, to)
+	days, totals := usageDays(usage)
+
+	byWorkspace := map[string]*db.WorkspaceUsageTotal{}
+	workspaces := []*db.WorkspaceUsageTotal{}
+	for _, u := range usage {
+		total, ok := byWorkspace[u.WorkspaceUuid]
+		if !ok {
+			total = &db.WorkspaceUsageTotal{WorkspaceUuid: u.WorkspaceUuid, Totals: newUsageTotals()}
+			byWorkspace[u.WorkspaceUuid] = total
+			workspaces = append(workspaces, total)
+		}
+		total.Totals[u.Kind] += u.Count
+	}
+
+	report := db.UsageCapacityReport{From: from, To: to, Totals: totals, Days: days, Workspaces: []db.WorkspaceUsageTotal{}}
+	for _, total := range workspaces {
+		total.Name = mh.db.GetWorkspaceByUuid(total.WorkspaceUuid).Name
+		report.Workspaces = append(report.Workspaces, *total)
+	}
+	sort.SliceStable(report.Workspaces, func(i, j int) bool {
+		a, b := report.Workspaces[i], report.Workspaces[j]
+		if a.Totals[db.UsageApiCall] != b.Totals[db.UsageApiCall] {
+			return a.Totals[db.UsageApiCall] > b.Totals[db.UsageApiCall]
+		}
+		return a.WorkspaceUuid < b.WorkspaceUuid
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUsageCounterFlush(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	counter := NewUsageCounter()
+	day := time.Date(2026, 3, 14, 23, 30, 0, 0, time.UTC)
+
+	counter.Record("workspace_uuid", db.UsageApiCall, day)
+	counter.Record("workspace_uuid", db.UsageApiCall, day)
+	counter.Record("workspace_uuid", db.UsageStakworkRun, day)
+	counter.Record("", db.UsageApiCall, day)
+
+	t.Run("should keep the counts the db did not take", func(t *testing.T) {
+		mockDb.On("AddWorkspaceUsage", mock.Anything).Return(errors.New("db down")).Once()
+		assert.Error(t, counter.Flush(mockDb))
+		counter.Record("workspace_uuid", db.UsageApiCall, day)
+	})
+
+	t.Run("should add the counts per workspace, day and kind", func(t *testing.T) {
+		mockDb.On("AddWorkspaceUsage", mock.MatchedBy(func(usage []db.WorkspaceUsageDaily) bool {
+			counts := map[db.UsageKind]int64{}
+			for _, u := range usage {
+				if u.WorkspaceUuid != "workspace_uuid" || u.Day != "2026-03-14" {
+					return false
+				}
+				counts[u.Kind] = u.Count
+			}
+			return len(usage) == 2 && counts[db.UsageApiCall] == 3 && counts[db.UsageStakworkRun] == 1
+		})).Return(nil).Once()
+		assert.NoError(t, counter.Flush(mockDb))
+		assert.Empty(t, counter.take())
+	})
+}
+
+func TestUsageMiddleware(t *testing.T) {
+	WorkspaceUsage = NewUsageCounter()
+	defer func() { WorkspaceUsage = NewUsageCounter() }()
+
+	r := chi.NewRouter()
+	r.Use(UsageMiddleware)
+	r.Route("/workspaces", func(r chi.Router) {
+		r.Get("/{uuid}/tags", func(w http.ResponseWriter, r *http.Request) {})
+		r.Get("/{uuid}/missing", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+	})
+	r.Get("/tickets/{uuid}", func(w http.ResponseWriter, r *http.Request) {})
+	r.Post("/bounty/api", func(w http.ResponseWriter, r *http.Request) {
+		ObserveApiTokenUsage(r, auth.ApiToken{WorkspaceUuid: "token_workspace"})
+	})
+
+	for _, request := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/workspaces/workspace_uuid/tags", nil),
+		httptest.NewRequest(http.MethodGet, "/workspaces/workspace_uuid/missing", nil),
+		httptest.NewRequest(http.MethodGet, "/tickets/ticket_uuid", nil),
+		httptest.NewRequest(http.MethodPost, "/bounty/api", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	counts := map[string]int64{}
+	for _, u := range WorkspaceUsage.take() {
+		assert.Equal(t, db.UsageApiCall, u.Kind)
+		counts[u.WorkspaceUuid] += u.Count
+	}
+	assert.Equal(t, map[string]int64{"workspace_uuid": 1, "token_workspace": 1}, counts)
+}
+
+func TestGetWorkspaceUsage(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+	newRequest := func(pubkey string, query string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodGet, "/workspace_uuid/usage"+query, nil)
+		return req
+	}
+
+	t.Run("should only show the usage to the owner", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceUsage).ServeHTTP(rr, newRequest("member_pubkey", ""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should validate the range", func(t *testing.T) {
+		for _, query := range []string{"?from=yesterday", "?from=2026-03-02&to=2026-03-01", "?from=2024-01-01&to=2026-01-01"} {
+			mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(workspace).Once()
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(oHandler.GetWorkspaceUsage).ServeHTTP(rr, newRequest("owner_pubkey", query))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
+	})
+
+	t.Run("should sum the usage per day", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(workspace).Once()
+		mockDb.On("GetWorkspaceUsage", "workspace_uuid", "2026-03-01", "2026-03-07").Return([]db.WorkspaceUsageDaily{
+			{WorkspaceUuid: "workspace_uuid", Day: "2026-03-01", Kind: db.UsageApiCall, Count: 40},
+			{WorkspaceUuid: "workspace_uuid", Day: "2026-03-01", Kind: db.UsageAiCall, Count: 3},
+			{WorkspaceUuid: "workspace_uuid", Day: "2026-03-04", Kind: db.UsageApiCall, Count: 2},
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceUsage).ServeHTTP(rr, newRequest("owner_pubkey", "?from=2026-03-01&to=2026-03-07"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		report := db.WorkspaceUsageReport{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+		assert.Equal(t, int64(42), report.Totals[db.UsageApiCall])
+		assert.Equal(t, int64(0), report.Totals[db.UsageStakworkRun])
+		assert.Len(t, report.Days, 2)
+		assert.Equal(t, int64(3), report.Days[0].Counts[db.UsageAiCall])
+	})
+}
+
+func TestGetUsageCapacity(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	mh := NewMetricHandler(mockDb)
+
+	mockDb.On("GetAllWorkspaceUsage", "2026-03-01", "2026-03-07").Return([]db.WorkspaceUsageDaily{
+		{WorkspaceUuid: "quiet_uuid", Day: "2026-03-01", Kind: db.UsageApiCall, Count: 5},
+		{WorkspaceUuid: "busy_uuid", Day: "2026-03-01", Kind: db.UsageApiCall, Count: 90},
+		{WorkspaceUuid: "busy_uuid", Day: "2026-03-02", Kind: db.UsageStakworkRun, Count: 2},
+	}).Once()
+	mockDb.On("GetWorkspaceByUuid", "quiet_uuid").Return(db.Workspace{Uuid: "quiet_uuid", Name: "Quiet"}).Once()
+	mockDb.On("GetWorkspaceByUuid", "busy_uuid").Return(db.Workspace{Uuid: "busy_uuid", Name: "Busy"}).Once()
+
+	ctx := context.WithValue(context.Background(), auth.ContextKey, "admin_pubkey")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/usage?from=2026-03-01&to=2026-03-07", nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(mh.GetUsageCapacity).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	report := db.UsageCapacityReport{}
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+	assert.Equal(t, int64(95), report.Totals[db.UsageApiCall])
+	assert.Len(t, report.Days, 2)
+	assert.Len(t, report.Workspaces, 2)
+	assert.Equal(t, "Busy", report.Workspaces[0].Name)
+	assert.Equal(t, int64(2), report.Workspaces[0].Totals[db.UsageStakworkRun])
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+const (
+	v2DefaultLimit = 20
+	v2MaxLimit     = 100
+)
+
+// V2Envelope is the body of every v2 response, data on success and error otherwise
+type V2Envelope struct {
+	Data       interface{}   `json:"data"`
+	Pagination *V2Pagination `json:"pagination,omitempty"`
+	Error      *V2Error      `json:"error,omitempty"`
+}
+
+type V2Pagination struct {
+	Page    int   `json:"page"`
+	Limit   int   `json:"limit"`
+	Total   int64 `json:"total"`
+	HasMore bool  `json:"has_more"`
+}
+
+type V2Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+const (
+	V2ErrInvalidParams    = "invalid_params"
+	V2ErrNotFound         = "not_found"
+	V2ErrMethodNotAllowed = "method_not_allowed"
+	V2ErrInternal         = "internal"
+)
+
+// writeV2 sends data with the field names of the api version of the request,
+// the Deprecation header lists the old names that are still sent
+func writeV2(w http.ResponseWriter, r *http.Request, status int, data interface{}, pagination *V2Pagination) {
+	version := utils.ApiVersionFromContext(r.Context())
+	transformed, err := utils.TransformFields(version, data)
+	if err != nil {
+		fmt.Println("[v2] could not transform response fields", err)
+		writeV2Error(w, http.StatusInternalServerError, V2ErrInternal, "Could not encode the response")
+		return
+	}
+	if deprecated := utils.DeprecatedFields(version); len(deprecated) > 0 {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Deprecated-Fields", strings.Join(deprecated, ", "))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(V2Envelope{Data: transformed, Pagination: pagination})
+}
+
+func writeV2Error(w http.ResponseWriter, status int, code string, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(V2Envelope{Error: &V2Error{Code: code, Message: message}})
+}
+
+// V2NotFound answers the v2 paths that match no route
+func V2NotFound(w http.ResponseWriter, r *http.Request) {
+	writeV2Error(w, http.StatusNotFound, V2ErrNotFound, "Route not found")
+}
+
+func V2MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeV2Error(w, http.StatusMethodNotAllowed, V2ErrMethodNotAllowed, fmt.Sprintf("%s is not allowed on this route", r.Method))
+}
+
+type v2Handler struct {
+	db db.Database
+}
+
+func NewV2Handler(database db.Database) *v2Handler {
+	return &v2Handler{db: database}
+}
+
+// v2ListFilter reads page, limit and search, and the extra filters a list reads from params
+func v2ListFilter(w http.ResponseWriter, params *utils.QueryParams) (db.ListFilter, int, bool) {
+	page := params.Int("page", 1)
+	limit := params.Int("limit", v2DefaultLimit)
+	search := strings.TrimSpace(params.String("search", ""))
+	if err := params.Err(); err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2ErrInvalidParams, err.Error())
+		return db.ListFilter{}, 0, false
+	}
+	if page < 1 {
+		writeV2Error(w, http.StatusBadRequest, V2ErrInvalidParams, "page must be 1 or more")
+		return db.ListFilter{}, 0, false
+	}
+	if limit < 1 || limit > v2MaxLimit {
+		writeV2Error(w, http.StatusBadRequest, V2ErrInvalidParams, fmt.Sprintf("limit must be between 1 and %d", v2MaxLimit))
+		return db.ListFilter{}, 0, false
+	}
+
+	return db.ListFilter{Search: search, Offset: (page - 1) * limit, Limit: limit}, page, true
+}
+
+func v2Pagination(filter db.ListFilter, page int, total int64) *V2Pagination {
+	return &V2Pagination{
+		Page:    page,
+		Limit:   filter.Limit,
+		Total:   total,
+		HasMore: int64(filter.Offset+filter.Limit) < total,
+	}
+}
+
+func (vh *v2Handler) ListTribes(w http.ResponseWriter, r *http.Request) {
+	filter, page, ok := v2ListFilter(w, utils.NewQueryParams(r))
+	if !ok {
+		return
+	}
+
+	tribes, total, err := vh.db.ListPublicTribes(filter)
+	if err != nil {
+		fmt.Println("[v2] could not list tribes", err)
+		writeV2Error(w, http.StatusInternalServerError, V2ErrInternal, "Could not list tribes")
+		return
+	}
+	writeV2(w, r, http.StatusOK, tribes, v2Pagination(filter, page, total))
+}
+
+func (vh *v2Handler) GetTribe(w http.ResponseWriter, r *http.Request) {
+	tribe := vh.db.GetTribe(chi.URLParam(r, "uuid"))
+	if tribe.UUID == "" || tribe.Unlisted || tribe.Private {
+		writeV2Error(w, http.StatusNotFound, V2ErrNotFound, "Tribe not found")
+		return
+	}
+	writeV2(w, r, http.StatusOK, tribe, nil)
+}
+
+func (vh *v2Handler) ListPeople(w http.ResponseWriter, r *http.Request) {
+	filter, page, ok := v2ListFilter(w, utils.NewQueryParams(r))
+	if !ok {
+		return
+	}
+
+	people, total, err := vh.db.ListPublicPeople(filter)
+	if err != nil {
+		fmt.Println("[v2] could not list people", err)
+		writeV2Error(w, http.StatusInternalServerError, V2ErrInternal, "Could not list people")
+		return
+	}
+	writeV2(w, r, http.StatusOK, people, v2Pagination(filter, page, total))
+}
+
+func (vh *v2Handler) GetPerson(w http.ResponseWriter, r *http.Request) {
+	person := vh.db.GetPersonByUuid(chi.URLParam(r, "uuid"))
+	if person.ID == 0 || person.Unlisted || person.Deleted {
+		writeV2Error(w, http.StatusNotFound, V2ErrNotFound, "Person not found")
+		return
+	}
+	writeV2(w, r, http.StatusOK, person, nil)
+}
+
+func (vh *v2Handler) ListBounties(w http.ResponseWriter, r *http.Request) {
+	params := utils.NewQueryParams(r)
+	workspaceUuid := params.String("workspace_uuid", "")
+	state := params.OneOf("state", "",
+		string(db.BountyStateOpen), string(db.BountyStateAssigned), string(db.BountyStateInReview),
+		string(db.BountyStateCompleted), string(db.BountyStatePaid), string(db.BountyStateCanceled))
+	filter, page, ok := v2ListFilter(w, params)
+	if !ok {
+		return
+	}
+	filter.WorkspaceUuid = workspaceUuid
+	filter.State = db.BountyState(state)
+
+	bounties, total, err := vh.db.ListPublicBounties(filter)
+	if err != nil {
+		fmt.Println("[v2] could not list bounties", err)
+		writeV2Error(w, http.StatusInternalServerError, V2ErrInternal, "Could not list bounties")
+		return
+	}
+	writeV2(w, r, http.StatusOK, bounties, v2Pagination(filter, page, total))
+}
+
+func (vh *v2Handler) GetBounty(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertStringToUint(chi.URLParam(r, "id"))
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2ErrInvalidParams, "id must be a number")
+		return
+	}
+
+	bounty := vh.db.GetBounty(id)
+	if bounty.ID == 0 || !bounty.Show {
+		writeV2Error(w, http.StatusNotFound, V2ErrNotFound, "Bounty not found")
+		return
+	}
+	writeV2(w, r, http.StatusOK, bounty, nil)
+}
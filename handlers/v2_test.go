@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	mocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestV2Api(t *testing.T) {
+	mockDb := mocks.NewDatabase(t)
+	vHandler := NewV2Handler(mockDb)
+
+	serve := func(handler http.HandlerFunc, target string, params map[string]string) (*httptest.ResponseRecorder, map[string]json.RawMessage) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rctx := chi.NewRouteContext()
+		for key, value := range params {
+			rctx.URLParams.Add(key, value)
+		}
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		handler.ServeHTTP(rr, req)
+
+		body := map[string]json.RawMessage{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		return rr, body
+	}
+
+	t.Run("should page tribes with the pagination in the envelope", func(t *testing.T) {
+		tribes := []db.Tribe{{UUID: "tribe-1", Name: "Sphinx"}}
+		mockDb.On("ListPublicTribes", db.ListFilter{Search: "sph", Offset: 10, Limit: 10}).Return(tribes, int64(21), nil).Once()
+
+		rr, body := serve(vHandler.ListTribes, "/v2/tribes?page=2&limit=10&search=sph", nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		pagination := V2Pagination{}
+		assert.NoError(t, json.Unmarshal(body["pagination"], &pagination))
+		assert.Equal(t, V2Pagination{Page: 2, Limit: 10, Total: 21, HasMore: true}, pagination)
+
+		data := []db.Tribe{}
+		assert.NoError(t, json.Unmarshal(body["data"], &data))
+		assert.Equal(t, "tribe-1", data[0].UUID)
+		assert.NotContains(t, body, "error")
+	})
+
+	t.Run("should answer bad params with an error envelope", func(t *testing.T) {
+		for _, target := range []string{"/v2/people?page=0", "/v2/people?limit=101", "/v2/people?page=abc"} {
+			rr, body := serve(vHandler.ListPeople, target, nil)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, target)
+
+			v2Err := V2Error{}
+			assert.NoError(t, json.Unmarshal(body["error"], &v2Err))
+			assert.Equal(t, V2ErrInvalidParams, v2Err.Code)
+			assert.Equal(t, "null", string(body["data"]))
+		}
+
+		rr, _ := serve(vHandler.ListBounties, "/v2/bounties?state=lost", nil)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should page people with the default limit", func(t *testing.T) {
+		mockDb.On("ListPublicPeople", db.ListFilter{Limit: v2DefaultLimit}).Return([]db.PersonInShort{}, int64(0), nil).Once()
+
+		rr, body := serve(vHandler.ListPeople, "/v2/people", nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "[]", string(body["data"]))
+	})
+
+	t.Run("should filter bounties by workspace and state", func(t *testing.T) {
+		filter := db.ListFilter{WorkspaceUuid: "workspace-uuid", State: db.BountyStateInReview, Limit: v2DefaultLimit}
+		mockDb.On("ListPublicBounties", filter).Return([]db.NewBounty{{ID: 1, Show: true}}, int64(1), nil).Once()
+
+		rr, body := serve(vHandler.ListBounties, "/v2/bounties?workspace_uuid=workspace-uuid&state=in_review", nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		pagination := V2Pagination{}
+		assert.NoError(t, json.Unmarshal(body["pagination"], &pagination))
+		assert.False(t, pagination.HasMore)
+	})
+
+	t.Run("should answer 500 with an internal error when the list fails", func(t *testing.T) {
+		mockDb.On("ListPublicBounties", db.ListFilter{Limit: v2DefaultLimit}).Return(nil, int64(0), errors.New("db down")).Once()
+
+		rr, body := serve(vHandler.ListBounties, "/v2/bounties", nil)
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Contains(t, string(body["error"]), V2ErrInternal)
+	})
+
+	t.Run("should not find hidden bounties, private tribes or unlisted people", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(2)).Return(db.NewBounty{ID: 2, Show: false}).Once()
+		rr, body := serve(vHandler.GetBounty, "/v2/bounties/2", map[string]string{"id": "2"})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, string(body["error"]), V2ErrNotFound)
+
+		mockDb.On("GetTribe", "tribe-2").Return(db.Tribe{UUID: "tribe-2", Private: true}).Once()
+		rr, _ = serve(vHandler.GetTribe, "/v2/tribes/tribe-2", map[string]string{"uuid": "tribe-2"})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		mockDb.On("GetPersonByUuid", "person-2").Return(db.Person{ID: 2, Unlisted: true}).Once()
+		rr, _ = serve(vHandler.GetPerson, "/v2/people/person-2", map[string]string{"uuid": "person-2"})
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return a shown bounty", func(t *testing.T) {
+		mockDb.On("GetBounty", uint(1)).Return(db.NewBounty{ID: 1, Show: true, Title: "Fix search"}).Once()
+
+		rr, body := serve(vHandler.GetBounty, "/v2/bounties/1", map[string]string{"id": "1"})
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		bounty := db.NewBounty{}
+		assert.NoError(t, json.Unmarshal(body["data"], &bounty))
+		assert.Equal(t, "Fix search", bounty.Title)
+	})
+
+	t.Run("should send deprecated fields with both names on v2", func(t *testing.T) {
+		people := []db.PersonInShort{{Uuid: "person-1", OwnerAlias: "alice"}}
+		mockDb.On("ListPublicPeople", db.ListFilter{Limit: v2DefaultLimit}).Return(people, int64(1), nil).Once()
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v2/people", nil)
+		req = req.WithContext(utils.WithApiVersion(req.Context(), "v2"))
+		vHandler.ListPeople(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "true", rr.Header().Get("Deprecation"))
+		assert.Equal(t, "owner_alias", rr.Header().Get("Deprecated-Fields"))
+
+		body := struct {
+			Data []map[string]interface{} `json:"data"`
+		}{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, "alice", body.Data[0]["owner_alias"])
+		assert.Equal(t, "alice", body.Data[0]["alias"])
+	})
+
+	t.Run("should answer unknown routes with an error envelope", func(t *testing.T) {
+		rr, body := serve(V2NotFound, "/v2/unknown", nil)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, string(body["error"]), V2ErrNotFound)
+	})
+}
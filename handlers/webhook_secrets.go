@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// WebhookSecrets are the secrets a webhook source may sign with, the one in
+// the env and the stored one, both are accepted while a secret is rotated
+func WebhookSecrets(source string) []string {
+	secrets := []string{}
+	if secret := config.WebhookSecret(source); secret != "" {
+		secrets = append(secrets, secret)
+	}
+	secret, err := db.DB.GetWebhookSigningSecret(source)
+	if err != nil && !errors.Is(err, db.ErrWebhookSigningSecretNotFound) {
+		fmt.Println("[webhooks] could not read signing secret of", source, err)
+	}
+	if secret != "" {
+		secrets = append(secrets, secret)
+	}
+	return secrets
+}
+
+// GetWebhookSigningSecrets lists the webhook sources and the ones with a
+// stored secret, the secrets themselves aren't shown
+func (ch *configHandler) GetWebhookSigningSecrets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[config] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	secrets, err := ch.db.GetWebhookSigningSecrets()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not read webhook signing secrets")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"secrets": secrets,
+		"sources": auth.WebhookSources,
+	})
+}
+
+// RotateWebhookSigningSecret stores a new secret for a webhook source, it is
+// only shown in the answer. A secret in the env keeps working until it's removed
+func (ch *configHandler) RotateWebhookSigningSecret(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[config] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	source := chi.URLParam(r, "source")
+	if !auth.IsWebhookSource(source) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Not a webhook source")
+		return
+	}
+
+	secret := utils.GetRandomToken(32)
+	stored, err := ch.db.SetWebhookSigningSecret(source, secret, pubKeyFromAuth)
+	if err != nil {
+		fmt.Println("[config] could not save webhook signing secret", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save webhook signing secret")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.RotatedWebhookSigningSecret{WebhookSigningSecret: stored, Secret: secret})
+}
+
+// DeleteWebhookSigningSecret removes the stored secret of a webhook source,
+// its webhooks are refused unless it has one in the env
+func (ch *configHandler) DeleteWebhookSigningSecret(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[config] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	err := ch.db.DeleteWebhookSigningSecret(chi.URLParam(r, "source"))
+	if errors.Is(err, db.ErrWebhookSigningSecretNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Webhook signing secret not found")
+		return
+	}
+	if err != nil {
+		fmt.Println("[config] could not delete webhook signing secret", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete webhook signing secret")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Webhook signing secret deleted")
+}
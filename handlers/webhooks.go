@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/xid"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"gorm.io/gorm"
+)
+
+// maxWebhookPayloadSize is the largest alert payload a webhook accepts
+const maxWebhookPayloadSize = 1 << 20
+
+type webhookHandler struct {
+	db            db.Database
+	userHasAccess func(pubKeyFromAuth string, uuid string, role string) bool
+}
+
+func NewWebhookHandler(database db.Database) *webhookHandler {
+	dbConf := db.NewDatabaseConfig(&gorm.DB{})
+	return &webhookHandler{
+		db:            database,
+		userHasAccess: dbConf.UserHasAccess,
+	}
+}
+
+// renderWebhookTemplate executes a mapping template on an alert payload,
+// fields missing from the payload render as empty
+func renderWebhookTemplate(tmpl string, payload map[string]interface{}) (string, error) {
+	t, err := template.New("webhook").Option("missingkey=zero").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, payload); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(strings.ReplaceAll(out.String(), "<no value>", "")), nil
+}
+
+// verifyWebhookSignature checks the HMAC-SHA256 of the payload, signed with the
+// webhook secret, in the GitHub style x-hub-signature-256 header or Sentry's header
+func verifyWebhookSignature(secret string, body []byte, header http.Header) bool {
+	signature := strings.TrimPrefix(header.Get("x-hub-signature-256"), "sha256=")
+	if signature == "" {
+		signature = header.Get("sentry-hook-signature")
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// signWebhookPayload signs an outgoing payload the way verifyWebhookSignature
+// expects incoming ones, for the x-hub-signature-256 header
+func signWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (wh *webhookHandler) CreateWorkspaceWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[webhooks] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !wh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage webhooks")
+		return
+	}
+
+	webhook := db.WorkspaceWebhook{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &webhook)
+	}
+	if err != nil {
+		fmt.Println("[webhooks]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	webhook.Name = strings.TrimSpace(webhook.Name)
+	if webhook.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Name is a required field")
+		return
+	}
+
+	defaults, ok := db.WebhookTemplates[webhook.Source]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Unknown source %q", webhook.Source))
+		return
+	}
+	if webhook.TitleTemplate == "" {
+		webhook.TitleTemplate = defaults.TitleTemplate
+	}
+	if webhook.DescriptionTemplate == "" {
+		webhook.DescriptionTemplate = defaults.DescriptionTemplate
+	}
+	if webhook.FingerprintTemplate == "" {
+		webhook.FingerprintTemplate = defaults.FingerprintTemplate
+	}
+	if webhook.TitleTemplate == "" || webhook.FingerprintTemplate == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Title and fingerprint templates are required")
+		return
+	}
+	for _, tmpl := range []string{webhook.TitleTemplate, webhook.DescriptionTemplate, webhook.FingerprintTemplate} {
+		if _, err := template.New("webhook").Parse(tmpl); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(fmt.Sprintf("Invalid template: %s", err))
+			return
+		}
+	}
+
+	feature := wh.db.GetFeatureByUuid(webhook.FeatureUuid)
+	if feature.Uuid == "" || feature.WorkspaceUuid != uuid {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Feature not found in this workspace")
+		return
+	}
+	if _, err := wh.db.GetFeaturePhaseByUuid(webhook.FeatureUuid, webhook.PhaseUuid); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Phase not found in this feature")
+		return
+	}
+
+	now := time.Now()
+	webhook.ID = 0
+	webhook.Uuid = xid.New().String()
+	webhook.WorkspaceUuid = uuid
+	webhook.Secret = utils.GetRandomToken(32)
+	webhook.CreatedBy = pubKeyFromAuth
+	webhook.Created = &now
+	webhook.Updated = &now
+
+	webhook, err = wh.db.CreateWorkspaceWebhook(webhook)
+	if err != nil {
+		fmt.Println("[webhooks] could not create webhook", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not create the webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.CreatedWorkspaceWebhook{WorkspaceWebhook: webhook, Secret: webhook.Secret})
+}
+
+func (wh *webhookHandler) GetWorkspaceWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[webhooks] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !wh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage webhooks")
+		return
+	}
+
+	webhooks := wh.db.GetWorkspaceWebhooks(uuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(webhooks)
+}
+
+func (wh *webhookHandler) DeleteWorkspaceWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[webhooks] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !wh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage webhooks")
+		return
+	}
+
+	err := wh.db.DeleteWorkspaceWebhook(uuid, chi.URLParam(r, "webhook_uuid"))
+	if errors.Is(err, db.ErrWebhookNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[webhooks] could not delete webhook", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not delete the webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Webhook deleted")
+}
+
+// ReceiveWebhookAlert turns a signed alert payload into a draft bounty in the phase
+// of the webhook, repeated alerts with the same fingerprint only count on the open bounty
+func (wh *webhookHandler) ReceiveWebhookAlert(w http.ResponseWriter, r *http.Request) {
+	webhook, err := wh.db.GetWorkspaceWebhookByUuid(chi.URLParam(r, "uuid"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Webhook not found")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookPayloadSize+1))
+	r.Body.Close()
+	if err != nil || len(body) > maxWebhookPayloadSize {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		json.NewEncoder(w).Encode("Alert payload is too large")
+		return
+	}
+
+	if !verifyWebhookSignature(webhook.Secret, body, r.Header) {
+		fmt.Println("[webhooks] invalid signature for webhook", webhook.Uuid)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		fmt.Println("[webhooks]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	fingerprint, err := renderWebhookTemplate(webhook.FingerprintTemplate, payload)
+	if err != nil || fingerprint == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Could not get a fingerprint from the alert")
+		return
+	}
+
+	title, err := renderWebhookTemplate(webhook.TitleTemplate, payload)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Could not map the alert title: %s", err))
+		return
+	}
+	if title == "" {
+		title = "Alert from " + webhook.Name
+	}
+	description, err := renderWebhookTemplate(webhook.DescriptionTemplate, payload)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Could not map the alert description: %s", err))
+		return
+	}
+
+	now := time.Now()
+	draft := db.NewBounty{
+		OwnerID:       webhook.CreatedBy,
+		WorkspaceUuid: webhook.WorkspaceUuid,
+		PhaseUuid:     webhook.PhaseUuid,
+		Type:          "coding",
+		Title:         title,
+		Description:   description,
+		Tribe:         "None",
+		Show:          false,
+		Created:       now.Unix(),
+		Updated:       &now,
+	}
+
+	result, err := wh.db.RecordWebhookAlert(webhook, fingerprint, draft)
+	if err != nil {
+		fmt.Println("[webhooks] could not record alert", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not record the alert")
+		return
+	}
+
+	if result.Deduplicated {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateWorkspaceWebhook(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	wHandler := NewWebhookHandler(mockDb)
+	wHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "admin_pubkey" && role == db.EditOrg
+	}
+
+	newRequest := func(pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/workspace_uuid/webhooks", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should only let workspace admins create webhooks", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.CreateWorkspaceWebhook).ServeHTTP(rr, newRequest("member_pubkey", `{"name": "sentry", "source": "sentry"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should validate the webhook before looking up the feature", func(t *testing.T) {
+		for _, body := range []string{
+			`{"source": "sentry"}`,
+			`{"name": "alerts", "source": "pagerduty"}`,
+			`{"name": "alerts", "source": "generic"}`,
+			`{"name": "alerts", "source": "generic", "title_template": "{{.title", "fingerprint_template": "{{.id}}"}`,
+		} {
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(wHandler.CreateWorkspaceWebhook).ServeHTTP(rr, newRequest("admin_pubkey", body))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+	})
+
+	t.Run("should reject a feature of another workspace", func(t *testing.T) {
+		mockDb.On("GetFeatureByUuid", "other_feature").Return(db.WorkspaceFeatures{Uuid: "other_feature", WorkspaceUuid: "other_workspace"}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.CreateWorkspaceWebhook).ServeHTTP(rr, newRequest("admin_pubkey", `{"name": "sentry", "source": "sentry", "feature_uuid": "other_feature", "phase_uuid": "phase_uuid"}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should create a webhook with the source templates and return its secret", func(t *testing.T) {
+		mockDb.On("GetFeatureByUuid", "feature_uuid").Return(db.WorkspaceFeatures{Uuid: "feature_uuid", WorkspaceUuid: "workspace_uuid"}).Once()
+		mockDb.On("GetFeaturePhaseByUuid", "feature_uuid", "phase_uuid").Return(db.FeaturePhase{Uuid: "phase_uuid"}, nil).Once()
+		mockDb.On("CreateWorkspaceWebhook", mock.MatchedBy(func(webhook db.WorkspaceWebhook) bool {
+			return webhook.WorkspaceUuid == "workspace_uuid" &&
+				webhook.CreatedBy == "admin_pubkey" &&
+				webhook.Uuid != "" && webhook.Secret != "" &&
+				webhook.TitleTemplate == db.WebhookTemplates[db.WebhookSourceSentry].TitleTemplate &&
+				webhook.FingerprintTemplate == db.WebhookTemplates[db.WebhookSourceSentry].FingerprintTemplate
+		})).Return(func(webhook db.WorkspaceWebhook) (db.WorkspaceWebhook, error) {
+			webhook.ID = 1
+			return webhook, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.CreateWorkspaceWebhook).ServeHTTP(rr, newRequest("admin_pubkey", `{"name": "sentry", "source": "sentry", "feature_uuid": "feature_uuid", "phase_uuid": "phase_uuid"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		created := db.CreatedWorkspaceWebhook{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+		assert.NotEmpty(t, created.Secret)
+		assert.NotEmpty(t, created.Uuid)
+	})
+}
+
+func TestReceiveWebhookAlert(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	wHandler := NewWebhookHandler(mockDb)
+
+	webhook := db.WorkspaceWebhook{
+		Uuid:                "webhook_uuid",
+		WorkspaceUuid:       "workspace_uuid",
+		Name:                "sentry",
+		Source:              db.WebhookSourceSentry,
+		Secret:              "webhook_secret",
+		FeatureUuid:         "feature_uuid",
+		PhaseUuid:           "phase_uuid",
+		TitleTemplate:       db.WebhookTemplates[db.WebhookSourceSentry].TitleTemplate,
+		DescriptionTemplate: db.WebhookTemplates[db.WebhookSourceSentry].DescriptionTemplate,
+		FingerprintTemplate: db.WebhookTemplates[db.WebhookSourceSentry].FingerprintTemplate,
+		CreatedBy:           "admin_pubkey",
+	}
+	payload := `{"data": {"issue": {"id": "42", "title": "TypeError in checkout", "culprit": "cart.go", "permalink": "https://sentry.io/issues/42"}}}`
+
+	sign := func(secret string, body string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(body))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	newRequest := func(uuid string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", uuid)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodPost, "/webhooks/"+uuid, bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should return 404 for an unknown webhook", func(t *testing.T) {
+		mockDb.On("GetWorkspaceWebhookByUuid", "unknown").Return(db.WorkspaceWebhook{}, db.ErrWebhookNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.ReceiveWebhookAlert).ServeHTTP(rr, newRequest("unknown", payload))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should reject a payload without a valid signature", func(t *testing.T) {
+		mockDb.On("GetWorkspaceWebhookByUuid", "webhook_uuid").Return(webhook, nil).Twice()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.ReceiveWebhookAlert).ServeHTTP(rr, newRequest("webhook_uuid", payload))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		req := newRequest("webhook_uuid", payload)
+		req.Header.Set("sentry-hook-signature", sign("wrong_secret", payload))
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(wHandler.ReceiveWebhookAlert).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should reject an alert without a fingerprint", func(t *testing.T) {
+		mockDb.On("GetWorkspaceWebhookByUuid", "webhook_uuid").Return(webhook, nil).Once()
+
+		body := `{"data": {"issue": {"title": "no id"}}}`
+		req := newRequest("webhook_uuid", body)
+		req.Header.Set("x-hub-signature-256", "sha256="+sign(webhook.Secret, body))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.ReceiveWebhookAlert).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should map a new alert to a hidden draft bounty in the phase", func(t *testing.T) {
+		mockDb.On("GetWorkspaceWebhookByUuid", "webhook_uuid").Return(webhook, nil).Once()
+		mockDb.On("RecordWebhookAlert", webhook, "42", mock.MatchedBy(func(draft db.NewBounty) bool {
+			return draft.Title == "TypeError in checkout" &&
+				draft.Description == "cart.go\n\nhttps://sentry.io/issues/42" &&
+				draft.PhaseUuid == "phase_uuid" &&
+				draft.WorkspaceUuid == "workspace_uuid" &&
+				draft.OwnerID == "admin_pubkey" &&
+				!draft.Show
+		})).Return(db.WebhookAlertResult{BountyID: 7, Occurrences: 1}, nil).Once()
+
+		req := newRequest("webhook_uuid", payload)
+		req.Header.Set("sentry-hook-signature", sign(webhook.Secret, payload))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.ReceiveWebhookAlert).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+
+		result := db.WebhookAlertResult{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, uint(7), result.BountyID)
+	})
+
+	t.Run("should count a repeated alert on the open bounty", func(t *testing.T) {
+		mockDb.On("GetWorkspaceWebhookByUuid", "webhook_uuid").Return(webhook, nil).Once()
+		mockDb.On("RecordWebhookAlert", webhook, "42", mock.Anything).Return(db.WebhookAlertResult{BountyID: 7, Deduplicated: true, Occurrences: 2}, nil).Once()
+
+		req := newRequest("webhook_uuid", payload)
+		req.Header.Set("sentry-hook-signature", sign(webhook.Secret, payload))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.ReceiveWebhookAlert).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		result := db.WebhookAlertResult{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.True(t, result.Deduplicated)
+		assert.Equal(t, uint(2), result.Occurrences)
+	})
+
+	t.Run("should return 500 when the alert cannot be recorded", func(t *testing.T) {
+		mockDb.On("GetWorkspaceWebhookByUuid", "webhook_uuid").Return(webhook, nil).Once()
+		mockDb.On("RecordWebhookAlert", webhook, "42", mock.Anything).Return(db.WebhookAlertResult{}, errors.New("db down")).Once()
+
+		req := newRequest("webhook_uuid", payload)
+		req.Header.Set("sentry-hook-signature", sign(webhook.Secret, payload))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(wHandler.ReceiveWebhookAlert).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
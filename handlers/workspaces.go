@@ -1,18 +1,28 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi"
+	"github.com/patrickmn/go-cache"
 	"github.com/rs/xid"
 	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/rates"
 	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
 	"gorm.io/gorm"
 )
 
@@ -22,10 +32,14 @@ type workspaceHandler struct {
 	getLightningInvoice      func(payment_request string) (db.InvoiceResult, db.InvoiceError)
 	userHasAccess            func(pubKeyFromAuth string, uuid string, role string) bool
 	userHasManageBountyRoles func(pubKeyFromAuth string, uuid string) bool
+	showcaseCache            *cache.Cache
+	rates                    rates.Provider
+	onlineMembers            func(workspaceUuid string) []websocket.OnlineMember
+	checkPublicHost          func(host string) error
 }
 
 func NewWorkspaceHandler(database db.Database) *workspaceHandler {
-	bHandler := NewBountyHandler(http.DefaultClient, database)
+	bHandler := NewBountyHandler(utils.TracedClient, database)
 	dbConf := db.NewDatabaseConfig(&gorm.DB{})
 	return &workspaceHandler{
 		db:                       database,
@@ -33,9 +47,31 @@ func NewWorkspaceHandler(database db.Database) *workspaceHandler {
 		getLightningInvoice:      bHandler.GetLightningInvoice,
 		userHasAccess:            dbConf.UserHasAccess,
 		userHasManageBountyRoles: dbConf.UserHasManageBountyRoles,
+		showcaseCache:            cache.New(showcaseCacheTTL, 2*showcaseCacheTTL),
+		rates:                    rates.Default(),
+		onlineMembers:            websocket.WorkspacePresence.Online,
+		checkPublicHost:          utils.CheckPublicHost,
 	}
 }
 
+// validAlertWebhook checks the alert webhook of a workspace is a public https
+// url, the server posts the payment failures to it
+func (oh *workspaceHandler) validAlertWebhook(w http.ResponseWriter, webhook string) bool {
+	if webhook == "" {
+		return true
+	}
+	target, err := url.Parse(webhook)
+	if err == nil && target.Scheme == "https" && target.Hostname() != "" {
+		if err = oh.checkPublicHost(target.Hostname()); err == nil {
+			return true
+		}
+		fmt.Println("[workspaces] alert webhook host refused", err)
+	}
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode("Error: alert webhook must be a public https url")
+	return false
+}
+
 func (oh *workspaceHandler) CreateOrEditWorkspace(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
@@ -94,6 +130,10 @@ func (oh *workspaceHandler) CreateOrEditWorkspace(w http.ResponseWriter, r *http
 		return
 	}
 
+	if !oh.validAlertWebhook(w, workspace.AlertWebhook) {
+		return
+	}
+
 	if workspace.Github != "" && !strings.Contains(workspace.Github, "github.com/") {
 		w.WriteHeader(http.StatusBadRequest)
 		msg := "Error: not a valid github"
@@ -102,6 +142,8 @@ func (oh *workspaceHandler) CreateOrEditWorkspace(w http.ResponseWriter, r *http
 	}
 
 	existing := oh.db.GetWorkspaceByUuid(workspace.Uuid)
+	// the schema only changes through IsolateWorkspace
+	workspace.DataSchema = existing.DataSchema
 	if existing.ID == 0 { // new!
 		if workspace.ID != 0 { // can't try to "edit" if it does not exist already
 			fmt.Println("[workspaces] cant edit non existing")
@@ -109,6 +151,12 @@ func (oh *workspaceHandler) CreateOrEditWorkspace(w http.ResponseWriter, r *http
 			return
 		}
 
+		if workspace.IsolatedData && !config.WorkspaceSchemas {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("Error: isolated workspace data is not enabled on this server")
+			return
+		}
+
 		name := workspace.Name
 
 		// check if the workspace name already exists
@@ -135,6 +183,16 @@ func (oh *workspaceHandler) CreateOrEditWorkspace(w http.ResponseWriter, r *http
 		return
 	}
 
+	if existing.ID == 0 && workspace.IsolatedData {
+		p, err = oh.db.IsolateWorkspace(p.Uuid)
+		if err != nil {
+			fmt.Println("[workspaces] could not isolate workspace", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("Error: workspace was created but its data schema could not be")
+			return
+		}
+	}
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(p)
 }
@@ -444,6 +502,165 @@ func AddUserRoles(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(insertRoles)
 }
 
+const maxWorkspaceMemberImportRows = 500
+
+// ImportWorkspaceMembers adds many users with their roles to a workspace,
+// the body is a JSON array of rows or a CSV with pubkey,roles columns where
+// roles are separated by semicolons. Every row is validated on its own
+// and the response reports the result of each row.
+func (oh *workspaceHandler) ImportWorkspaceMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	uuid := chi.URLParam(r, "uuid")
+	workspace := oh.db.GetWorkspaceByUuid(uuid)
+	if workspace.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+
+	if !oh.userHasAccess(pubKeyFromAuth, uuid, db.AddUser) || !oh.userHasAccess(pubKeyFromAuth, uuid, db.AddRoles) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to add users")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		fmt.Println("[body] ", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	var rows []db.WorkspaceMemberImportRow
+	if strings.Contains(r.Header.Get("Content-Type"), "csv") {
+		rows, err = parseMemberImportCsv(body)
+	} else {
+		err = json.Unmarshal(body, &rows)
+	}
+	if err != nil {
+		fmt.Println("[workspaces]:", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	if len(rows) == 0 || len(rows) > maxWorkspaceMemberImportRows {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("An import must have between 1 and %d rows", maxWorkspaceMemberImportRows))
+		return
+	}
+
+	rolesMap := db.GetRolesMap()
+	seen := map[string]bool{}
+	report := db.WorkspaceMemberImportReport{Results: []db.WorkspaceMemberImportResult{}}
+
+	for i, row := range rows {
+		row.Pubkey = strings.TrimSpace(row.Pubkey)
+		result := db.WorkspaceMemberImportResult{Row: i + 1, Pubkey: row.Pubkey, Roles: row.Roles, Status: db.MemberImportFailed}
+
+		if err := oh.validateMemberImportRow(row, workspace, pubKeyFromAuth, rolesMap, seen); err != nil {
+			result.Error = err.Error()
+			report.Failed++
+		} else {
+			now := time.Now()
+			oh.db.CreateWorkspaceUser(db.WorkspaceUsers{
+				OwnerPubKey:   row.Pubkey,
+				WorkspaceUuid: uuid,
+				Created:       &now,
+				Updated:       &now,
+			})
+
+			if len(row.Roles) > 0 {
+				roles := []db.WorkspaceUserRoles{}
+				for _, role := range row.Roles {
+					roles = append(roles, db.WorkspaceUserRoles{Role: role, OwnerPubKey: row.Pubkey, WorkspaceUuid: uuid, Created: &now})
+				}
+				oh.db.CreateUserRoles(roles, uuid, row.Pubkey)
+			}
+
+			result.Status = db.MemberImportAdded
+			report.Added++
+		}
+
+		seen[row.Pubkey] = true
+		report.Results = append(report.Results, result)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
+
+func (oh *workspaceHandler) validateMemberImportRow(row db.WorkspaceMemberImportRow, workspace db.Workspace, pubKeyFromAuth string, rolesMap map[string]string, seen map[string]bool) error {
+	switch {
+	case row.Pubkey == "":
+		return errors.New("pubkey is required")
+	case seen[row.Pubkey]:
+		return errors.New("duplicate pubkey in the import")
+	case row.Pubkey == workspace.OwnerPubKey:
+		return errors.New("cannot add workspace admin as a user")
+	case row.Pubkey == pubKeyFromAuth:
+		return errors.New("cannot add yourself as a user")
+	}
+
+	for _, role := range row.Roles {
+		if _, ok := rolesMap[role]; !ok {
+			return fmt.Errorf("%s is not a valid user role", role)
+		}
+		if !oh.userHasAccess(pubKeyFromAuth, workspace.Uuid, role) {
+			return fmt.Errorf("cannot add the %s role you don't have", role)
+		}
+	}
+
+	if person := oh.db.GetPersonByPubkey(row.Pubkey); person.OwnerPubKey != row.Pubkey {
+		return errors.New("user doesn't exist in people")
+	}
+	if existing := oh.db.GetWorkspaceUser(row.Pubkey, workspace.Uuid); existing.ID != 0 {
+		return errors.New("user already exists in the workspace")
+	}
+	return nil
+}
+
+// parseMemberImportCsv reads pubkey,roles rows, a header row is optional
+func parseMemberImportCsv(body []byte) ([]db.WorkspaceMemberImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []db.WorkspaceMemberImportRow{}
+	for i, record := range records {
+		if i == 0 && len(record) > 0 && strings.EqualFold(strings.TrimSpace(record[0]), "pubkey") {
+			continue
+		}
+
+		row := db.WorkspaceMemberImportRow{Roles: []string{}}
+		if len(record) > 0 {
+			row.Pubkey = record[0]
+		}
+		if len(record) > 1 {
+			for _, role := range strings.Split(record[1], ";") {
+				if role = strings.TrimSpace(role); role != "" {
+					row.Roles = append(row.Roles, role)
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
 func GetUserRoles(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "uuid")
 	user := chi.URLParam(r, "user")
@@ -572,6 +789,22 @@ func (oh *workspaceHandler) GetWorkspaceBudget(w http.ResponseWriter, r *http.Re
 
 	// get the workspace budget
 	workspaceBudget := oh.db.GetWorkspaceStatusBudget(uuid)
+	if currency := oh.db.GetWorkspaceSettings(uuid).DisplayCurrency; currency != "" {
+		rate, err := oh.rates.Rate(ctx, currency)
+		if err != nil {
+			fmt.Println("[workspaces] could not get the rate of", currency, err)
+		} else {
+			workspaceBudget.Display = &db.BudgetDisplay{
+				Currency:        rate.Currency,
+				BtcPrice:        rate.BtcPrice,
+				RateTimestamp:   rate.Timestamp,
+				CurrentBudget:   rate.Convert(workspaceBudget.CurrentBudget),
+				OpenBudget:      rate.Convert(workspaceBudget.OpenBudget),
+				AssignedBudget:  rate.Convert(workspaceBudget.AssignedBudget),
+				CompletedBudget: rate.Convert(workspaceBudget.CompletedBudget),
+			}
+		}
+	}
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(workspaceBudget)
@@ -597,6 +830,291 @@ func (oh *workspaceHandler) GetWorkspaceBudgetHistory(w http.ResponseWriter, r *
 	json.NewEncoder(w).Encode(workspaceBudget)
 }
 
+// GetWorkspaceBudgetLedger returns the ledger entries the workspace budget is derived from
+func (oh *workspaceHandler) GetWorkspaceBudgetLedger(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	hasRole := oh.userHasAccess(pubKeyFromAuth, uuid, db.ViewReport)
+	if !hasRole {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to view the budget ledger")
+		return
+	}
+
+	ledger := oh.db.GetBudgetLedger(uuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ledger)
+}
+
+// GetWorkspaceBudgetSnapshots returns the recorded budget of a workspace per
+// day or per week for charting, between from and to like the usage report
+func (oh *workspaceHandler) GetWorkspaceBudgetSnapshots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	hasRole := oh.userHasAccess(pubKeyFromAuth, uuid, db.ViewReport)
+	if !hasRole {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to view budget history")
+		return
+	}
+
+	granularity := db.BudgetGranularity(r.URL.Query().Get("granularity"))
+	if granularity == "" {
+		granularity = db.BudgetByDay
+	}
+	if granularity != db.BudgetByDay && granularity != db.BudgetByWeek {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("granularity must be day or week")
+		return
+	}
+
+	from, to, err := usageRange(r, time.Now())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	snapshots := oh.db.GetWorkspaceBudgetSnapshots(uuid, from, to)
+	if granularity == db.BudgetByWeek {
+		snapshots = budgetWeeks(snapshots)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.WorkspaceBudgetHistory{
+		WorkspaceUuid: uuid,
+		Granularity:   granularity,
+		From:          from,
+		To:            to,
+		Snapshots:     snapshots,
+	})
+}
+
+// GetWorkspacePresence lists the members of a workspace that have the
+// websocket open and joined the workspace, for the collaboration indicators
+func (oh *workspaceHandler) GetWorkspacePresence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !isWorkspaceMember(oh.db, pubKeyFromAuth, uuid) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Only workspace members can see who is online")
+		return
+	}
+
+	online := oh.onlineMembers(uuid)
+	pubkeys := []string{}
+	for _, member := range online {
+		pubkeys = append(pubkeys, member.Pubkey)
+	}
+	people := map[string]db.Person{}
+	if len(pubkeys) > 0 {
+		people = oh.db.GetPeopleByPubkeys(pubkeys)
+	}
+
+	members := []db.WorkspacePresenceMember{}
+	for _, member := range online {
+		person := people[member.Pubkey]
+		members = append(members, db.WorkspacePresenceMember{
+			Pubkey:     member.Pubkey,
+			OwnerAlias: person.OwnerAlias,
+			Img:        person.Img,
+			Since:      member.Since,
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(members)
+}
+
+// IsPresenceMember lets the websocket check that a pubkey may join the
+// presence of a workspace
+func IsPresenceMember(pubkey string, workspaceUuid string) bool {
+	return isWorkspaceMember(db.DB, pubkey, workspaceUuid)
+}
+
+// budgetWeeks keeps the last snapshot of every week, dated on the monday the
+// week starts. The snapshots come in day order
+func budgetWeeks(snapshots []db.WorkspaceBudgetSnapshot) []db.WorkspaceBudgetSnapshot {
+	weeks := []db.WorkspaceBudgetSnapshot{}
+	for _, snapshot := range snapshots {
+		day, err := time.Parse(usageDayLayout, snapshot.Day)
+		if err != nil {
+			continue
+		}
+		offset := (int(day.Weekday()) + 6) % 7
+		snapshot.Day = day.AddDate(0, 0, -offset).Format(usageDayLayout)
+
+		if len(weeks) > 0 && weeks[len(weeks)-1].Day == snapshot.Day {
+			weeks[len(weeks)-1] = snapshot
+		} else {
+			weeks = append(weeks, snapshot)
+		}
+	}
+	return weeks
+}
+
+// MaxApiTokenLifetimeDays is the longest an api token can be minted for,
+// a token minted without expires_in_days works until it is revoked
+const MaxApiTokenLifetimeDays = 365
+
+// canManageApiTokens checks that the user is a workspace admin
+func (oh *workspaceHandler) canManageApiTokens(w http.ResponseWriter, r *http.Request) (string, bool) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return "", false
+	}
+
+	if !oh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to manage api tokens")
+		return "", false
+	}
+	return pubKeyFromAuth, true
+}
+
+// MintWorkspaceApiToken creates a scoped api token for the workspace, the token
+// is only returned here and cannot be read again
+func (oh *workspaceHandler) MintWorkspaceApiToken(w http.ResponseWriter, r *http.Request) {
+	pubKeyFromAuth, ok := oh.canManageApiTokens(w, r)
+	if !ok {
+		return
+	}
+	uuid := chi.URLParam(r, "uuid")
+
+	request := db.WorkspaceApiTokenRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[workspaces]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Name is a required field")
+		return
+	}
+	if len(request.Scopes) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("An api token needs at least one scope")
+		return
+	}
+	for _, scope := range request.Scopes {
+		if !db.ValidApiTokenScope(scope) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(fmt.Sprintf("Unknown scope %q, scopes are %s", scope, strings.Join(db.ApiTokenScopes, ", ")))
+			return
+		}
+	}
+	if request.ExpiresInDays < 0 || request.ExpiresInDays > MaxApiTokenLifetimeDays {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("expires_in_days must be between 0 and %d", MaxApiTokenLifetimeDays))
+		return
+	}
+
+	now := time.Now()
+	token := db.ApiTokenPrefix + utils.GetRandomToken(48)
+	apiToken := db.WorkspaceApiToken{
+		WorkspaceUuid: uuid,
+		Name:          name,
+		TokenHash:     db.HashApiToken(token),
+		TokenPrefix:   token[:len(db.ApiTokenPrefix)+6],
+		Scopes:        request.Scopes,
+		CreatedBy:     pubKeyFromAuth,
+		Created:       &now,
+	}
+	if request.ExpiresInDays > 0 {
+		expires := now.AddDate(0, 0, request.ExpiresInDays)
+		apiToken.Expires = &expires
+	}
+
+	apiToken, err = oh.db.CreateWorkspaceApiToken(apiToken)
+	if err != nil {
+		fmt.Println("[workspaces] could not mint api token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not create the api token")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.MintedWorkspaceApiToken{WorkspaceApiToken: apiToken, Token: token})
+}
+
+func (oh *workspaceHandler) GetWorkspaceApiTokens(w http.ResponseWriter, r *http.Request) {
+	if _, ok := oh.canManageApiTokens(w, r); !ok {
+		return
+	}
+
+	tokens := oh.db.GetWorkspaceApiTokens(chi.URLParam(r, "uuid"))
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tokens)
+}
+
+func (oh *workspaceHandler) RevokeWorkspaceApiToken(w http.ResponseWriter, r *http.Request) {
+	if _, ok := oh.canManageApiTokens(w, r); !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid api token id")
+		return
+	}
+
+	err = oh.db.RevokeWorkspaceApiToken(chi.URLParam(r, "uuid"), uint(id))
+	if errors.Is(err, db.ErrApiTokenNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		fmt.Println("[workspaces] could not revoke api token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not revoke the api token")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Api token revoked")
+}
+
 func GetPaymentHistory(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
@@ -620,9 +1138,17 @@ func GetPaymentHistory(w http.ResponseWriter, r *http.Request) {
 	paymentHistory := db.DB.GetPaymentHistory(uuid, r)
 	paymentHistoryData := []db.PaymentHistoryData{}
 
+	pubkeys := []string{}
+	for _, payment := range paymentHistory {
+		pubkeys = append(pubkeys, payment.SenderPubKey, payment.ReceiverPubKey)
+	}
+	people := map[string]db.Person{}
+	if len(pubkeys) > 0 {
+		people = db.DB.GetPeopleByPubkeys(pubkeys)
+	}
 	for _, payment := range paymentHistory {
-		sender := db.DB.GetPersonByPubkey(payment.SenderPubKey)
-		receiver := db.DB.GetPersonByPubkey(payment.ReceiverPubKey)
+		sender := people[payment.SenderPubKey]
+		receiver := people[payment.ReceiverPubKey]
 		paymentData := db.PaymentHistoryData{
 			NewPaymentHistory: payment,
 			SenderName:        sender.UniqueName,
@@ -637,6 +1163,169 @@ func GetPaymentHistory(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(paymentHistoryData)
 }
 
+// maxWipLimit, maxStateWipLimit, maxAiAgentMonthlyQuota and maxAiTemperature bound the workspace settings
+const (
+	maxWipLimit            = 100
+	maxStateWipLimit       = 1000
+	maxAiAgentMonthlyQuota = 100000
+	maxAiTemperature       = 2
+)
+
+var aiModelPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:/-]{0,99}$`)
+
+// aiKeyRefPattern matches secret names, like the ones config.GetSecret loads
+var aiKeyRefPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,99}$`)
+
+func validateWorkspaceSettings(settings db.WorkspaceSettings) error {
+	if settings.DefaultBountyVisibility != db.BountyVisibilityPublic && settings.DefaultBountyVisibility != db.BountyVisibilityHidden {
+		return fmt.Errorf("default_bounty_visibility must be %q or %q", db.BountyVisibilityPublic, db.BountyVisibilityHidden)
+	}
+	if settings.WipLimit > maxWipLimit {
+		return fmt.Errorf("wip_limit cannot be more than %d", maxWipLimit)
+	}
+	if settings.AiAgentMonthlyQuota > maxAiAgentMonthlyQuota {
+		return fmt.Errorf("ai_agent_monthly_quota cannot be more than %d", maxAiAgentMonthlyQuota)
+	}
+	if settings.DisplayCurrency != "" && !rates.Supported(settings.DisplayCurrency) {
+		return fmt.Errorf("display_currency must be one of %s", strings.Join(rates.Currencies, ", "))
+	}
+	if err := validateStateWipLimits(settings.StateWipLimits); err != nil {
+		return err
+	}
+	return validateAiSettings(settings)
+}
+
+// validateStateWipLimits allows one limit per phase and state, paid and canceled
+// bounties are done with and have no limit
+func validateStateWipLimits(limits db.StateWipLimits) error {
+	seen := map[db.StateWipLimit]bool{}
+	for _, limit := range limits {
+		switch limit.State {
+		case db.BountyStateOpen, db.BountyStateAssigned, db.BountyStateInReview, db.BountyStateCompleted:
+		default:
+			return fmt.Errorf("state_wip_limits can not limit %q bounties", limit.State)
+		}
+		if limit.Limit == 0 || limit.Limit > maxStateWipLimit {
+			return fmt.Errorf("state_wip_limits must be between 1 and %d", maxStateWipLimit)
+		}
+
+		key := db.StateWipLimit{PhaseUuid: limit.PhaseUuid, State: limit.State}
+		if seen[key] {
+			return fmt.Errorf("state_wip_limits has more than one limit for %s bounties", limit.State)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func validateAiSettings(settings db.WorkspaceSettings) error {
+	switch settings.AiProvider {
+	case "":
+		if settings.AiModel != "" || settings.AiTemperature != nil || settings.AiProviderKeyRef != "" {
+			return errors.New("ai_provider is required with the other ai settings")
+		}
+		return nil
+	case db.AiProviderAnthropic, db.AiProviderOpenAI, db.AiProviderStakwork:
+	default:
+		return fmt.Errorf("ai_provider must be %q, %q or %q", db.AiProviderAnthropic, db.AiProviderOpenAI, db.AiProviderStakwork)
+	}
+
+	if !aiModelPattern.MatchString(settings.AiModel) {
+		return errors.New("ai_model must be a model name of up to 100 characters")
+	}
+	if t := settings.AiTemperature; t != nil && (*t < 0 || *t > maxAiTemperature) {
+		return fmt.Errorf("ai_temperature must be between 0 and %d", maxAiTemperature)
+	}
+	if settings.AiProviderKeyRef != "" && !aiKeyRefPattern.MatchString(settings.AiProviderKeyRef) {
+		return errors.New("ai_provider_key_ref must be the name of a secret, like ANTHROPIC_API_KEY")
+	}
+	return nil
+}
+
+// GetWorkspaceSettings returns the settings of a workspace to its owner and members
+func (oh *workspaceHandler) GetWorkspaceSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	workspace := oh.db.GetWorkspaceByUuid(uuid)
+	if workspace.Uuid == "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	}
+
+	if workspace.OwnerPubKey != pubKeyFromAuth && oh.db.GetWorkspaceUser(pubKeyFromAuth, uuid).OwnerPubKey == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to view workspace settings")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(oh.db.GetWorkspaceSettings(uuid))
+}
+
+// UpdateWorkspaceSettings changes the settings of a workspace, fields left out of
+// the request keep their current values and unknown fields are rejected
+func (oh *workspaceHandler) UpdateWorkspaceSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !oh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to edit workspace settings")
+		return
+	}
+
+	settings := oh.db.GetWorkspaceSettings(uuid)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&settings)
+	r.Body.Close()
+	if err != nil {
+		fmt.Println("[workspaces]", err)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fmt.Sprintf("Invalid settings: %s", err))
+		return
+	}
+
+	settings.WorkspaceUuid = uuid
+	settings.UpdatedBy = pubKeyFromAuth
+	settings.DisplayCurrency = strings.ToLower(settings.DisplayCurrency)
+
+	if err := validateWorkspaceSettings(settings); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	settings, err = oh.db.SaveWorkspaceSettings(settings)
+	if err != nil {
+		fmt.Println("[workspaces] could not save settings", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not save the workspace settings")
+		return
+	}
+	oh.showcaseCache.Delete(uuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(settings)
+}
+
 func (oh *workspaceHandler) PollBudgetInvoices(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
@@ -790,6 +1479,50 @@ func (oh *workspaceHandler) DeleteWorkspace(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(workspace)
 }
 
+// GetDeletedWorkspaces lists the deleted workspaces a super admin can still restore
+func (oh *workspaceHandler) GetDeletedWorkspaces(w http.ResponseWriter, r *http.Request) {
+	workspaces := oh.db.GetDeletedWorkspaces()
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(workspaces)
+}
+
+// RestoreWorkspace brings back a deleted workspace with its members, roles and
+// bounties while it is within the retention window
+func (oh *workspaceHandler) RestoreWorkspace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	workspace, err := oh.db.RestoreWorkspace(uuid)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	case errors.Is(err, db.ErrWorkspaceNotDeleted):
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	case errors.Is(err, db.ErrWorkspaceRetentionExpired):
+		w.WriteHeader(http.StatusGone)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	case err != nil && workspace.Uuid == "":
+		fmt.Println("[workspaces] could not restore workspace", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not restore the workspace")
+		return
+	case err != nil:
+		fmt.Println("[workspaces] restored workspace but could not resync its budget", err)
+	}
+
+	fmt.Printf("[workspaces] workspace %s restored by %s\n", uuid, pubKeyFromAuth)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(workspace)
+}
+
 func (oh *workspaceHandler) UpdateWorkspace(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
@@ -831,6 +1564,10 @@ func (oh *workspaceHandler) UpdateWorkspace(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if !oh.validAlertWebhook(w, workspace.AlertWebhook) {
+		return
+	}
+
 	p, err := oh.db.CreateOrEditWorkspace(workspace)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -969,28 +1706,13 @@ func (oh *workspaceHandler) GetFeaturesByWorkspaceUuid(w http.ResponseWriter, r
 		return
 	}
 
+	if !validListParams(w, r) {
+		return
+	}
+
 	uuid := chi.URLParam(r, "workspace_uuid")
 	workspaceFeatures := oh.db.GetFeaturesByWorkspaceUuid(uuid, r)
 
-	for i, feature := range workspaceFeatures {
-		phases := oh.db.GetPhasesByFeatureUuid(feature.Uuid)
-		var totalCompleted, totalAssigned, totalOpen int
-
-		for _, phase := range phases {
-			completed := oh.db.GetFeaturePhasesBountiesCount("completed", phase.Uuid)
-			assigned := oh.db.GetFeaturePhasesBountiesCount("assigned", phase.Uuid)
-			open := oh.db.GetFeaturePhasesBountiesCount("open", phase.Uuid)
-
-			totalAssigned += int(assigned)
-			totalCompleted += int(completed)
-			totalOpen += int(open)
-		}
-
-		workspaceFeatures[i].BountiesCountCompleted = totalCompleted
-		workspaceFeatures[i].BountiesCountAssigned = totalAssigned
-		workspaceFeatures[i].BountiesCountOpen = totalOpen
-	}
-
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(workspaceFeatures)
 }
@@ -1021,3 +1743,92 @@ func GetAllUserWorkspaces(pubkey string) []db.Workspace {
 
 	return workspaces
 }
+
+// GetWorkspaceEstimationReport compares the estimated and logged hours of the
+// workspace's finished bounties by assignee and by coding language
+func (oh *workspaceHandler) GetWorkspaceEstimationReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !oh.userHasAccess(pubKeyFromAuth, uuid, db.ViewReport) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to view the estimation report")
+		return
+	}
+
+	efforts := oh.db.GetWorkspaceBountyEfforts(uuid)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(db.BuildEstimationAccuracyReport(uuid, efforts))
+}
+
+// CloneWorkspace starts a new workspace from the setup of an existing one, for
+// teams that run similar projects. The bounties and payments are not copied
+func (oh *workspaceHandler) CloneWorkspace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pubKeyFromAuth, _ := ctx.Value(auth.ContextKey).(string)
+	uuid := chi.URLParam(r, "uuid")
+
+	if pubKeyFromAuth == "" {
+		fmt.Println("[workspaces] no pubkey from auth")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !oh.userHasAccess(pubKeyFromAuth, uuid, db.EditOrg) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Don't have access to clone the workspace")
+		return
+	}
+
+	request := db.WorkspaceCloneRequest{}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		fmt.Println("[workspaces]", err)
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	request.Name = strings.TrimSpace(request.Name)
+	if len(request.Name) == 0 || len(request.Name) > 20 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Error: workspace name must be present and should not exceed 20 character")
+		return
+	}
+	if len(request.Description) > 120 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Error: workspace description should not exceed 120 character")
+		return
+	}
+
+	result, err := oh.db.CloneWorkspace(uuid, pubKeyFromAuth, request)
+	switch {
+	case errors.Is(err, db.ErrWorkspaceNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Workspace not found")
+		return
+	case errors.Is(err, db.ErrWorkspaceNameTaken):
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode("Workspace name already exists - " + request.Name)
+		return
+	case err != nil:
+		fmt.Println("[workspaces] could not clone workspace", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Could not clone the workspace")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
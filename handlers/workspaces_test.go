@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -14,9 +15,17 @@ import (
 
 	"github.com/go-chi/chi"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stakwork/sphinx-tribes/rates"
+	"github.com/stakwork/sphinx-tribes/utils"
+	"github.com/stakwork/sphinx-tribes/websocket"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestUnitCreateOrEditWorkspace(t *testing.T) {
@@ -687,3 +696,869 @@ func GetFeaturesByWorkspaceUuid(t *testing.T) {
 func TestDeleteWorkspaceRepository(t *testing.T) {
 
 }
+
+func TestImportWorkspaceMembers(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+	oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return role != db.PayBounty
+	}
+
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+
+	newRequest := func(contentType string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", workspace.Uuid)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "admin_pubkey")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/workspace_uuid/members/import", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		return req
+	}
+
+	t.Run("should report the result of every csv row", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetPersonByPubkey", "new_pubkey").Return(db.Person{OwnerPubKey: "new_pubkey"}).Once()
+		mockDb.On("GetWorkspaceUser", "new_pubkey", workspace.Uuid).Return(db.WorkspaceUsers{}).Once()
+		mockDb.On("CreateWorkspaceUser", mock.MatchedBy(func(u db.WorkspaceUsers) bool {
+			return u.OwnerPubKey == "new_pubkey" && u.WorkspaceUuid == workspace.Uuid
+		})).Return(db.WorkspaceUsers{}).Once()
+		mockDb.On("CreateUserRoles", mock.MatchedBy(func(roles []db.WorkspaceUserRoles) bool {
+			return len(roles) == 2 && roles[0].Role == db.AddBounty && roles[1].Role == db.ViewReport
+		}), workspace.Uuid, "new_pubkey").Return([]db.WorkspaceUserRoles{}).Once()
+		mockDb.On("GetPersonByPubkey", "member_pubkey").Return(db.Person{OwnerPubKey: "member_pubkey"}).Once()
+		mockDb.On("GetWorkspaceUser", "member_pubkey", workspace.Uuid).Return(db.WorkspaceUsers{ID: 1}).Once()
+
+		csv := "pubkey,roles\n" +
+			"new_pubkey,ADD BOUNTY;VIEW REPORT\n" +
+			"new_pubkey,ADD BOUNTY\n" +
+			"owner_pubkey,\n" +
+			"member_pubkey,\n" +
+			"other_pubkey,PAY BOUNTY\n" +
+			"another_pubkey,FLY\n"
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.ImportWorkspaceMembers).ServeHTTP(rr, newRequest("text/csv", csv))
+
+		var report db.WorkspaceMemberImportReport
+		json.Unmarshal(rr.Body.Bytes(), &report)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 1, report.Added)
+		assert.Equal(t, 5, report.Failed)
+		assert.Equal(t, db.MemberImportAdded, report.Results[0].Status)
+		assert.Equal(t, "duplicate pubkey in the import", report.Results[1].Error)
+		assert.Equal(t, "cannot add workspace admin as a user", report.Results[2].Error)
+		assert.Equal(t, "user already exists in the workspace", report.Results[3].Error)
+		assert.Equal(t, "cannot add the PAY BOUNTY role you don't have", report.Results[4].Error)
+		assert.Equal(t, "FLY is not a valid user role", report.Results[5].Error)
+	})
+
+	t.Run("should reject a user without access", func(t *testing.T) {
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return false
+		}
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.ImportWorkspaceMembers).ServeHTTP(rr, newRequest("application/json", `[{"pubkey": "new_pubkey"}]`))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestGetWorkspaceEstimationReport(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+
+	newRequest := func() *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "admin_pubkey")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodGet, "/workspace_uuid/estimation/report", nil)
+		return req
+	}
+
+	t.Run("should require the view report role", func(t *testing.T) {
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return false
+		}
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceEstimationReport).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should report estimation accuracy by person and language", func(t *testing.T) {
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return role == db.ViewReport
+		}
+		mockDb.On("GetWorkspaceBountyEfforts", "workspace_uuid").Return([]db.BountyEffortRow{
+			{BountyID: 1, Assignee: "hunter_1", CodingLanguages: pq.StringArray{"Go"}, EstimatedHours: 4, ActualHours: 6},
+			{BountyID: 2, Assignee: "hunter_2", CodingLanguages: pq.StringArray{"Go", "Typescript"}, EstimatedHours: 10, ActualHours: 10},
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceEstimationReport).ServeHTTP(rr, newRequest())
+
+		var report db.EstimationAccuracyReport
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 2, report.Overall.Bounties)
+		assert.Len(t, report.ByPerson, 2)
+		assert.Equal(t, "Go", report.ByLanguage[0].Key)
+		assert.Equal(t, 2, report.ByLanguage[0].Bounties)
+	})
+}
+
+func TestGetWorkspaceBudgetLedger(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+
+	newRequest := func() *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "admin_pubkey")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodGet, "/budget/workspace_uuid/ledger", nil)
+		return req
+	}
+
+	t.Run("should require the view report role", func(t *testing.T) {
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return false
+		}
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceBudgetLedger).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should return the ledger entries of the workspace", func(t *testing.T) {
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+			return role == db.ViewReport
+		}
+		entries := []db.BudgetLedgerEntry{
+			{ID: 1, TransactionId: "tx", Account: db.LedgerDepositsAccount, Debit: 1000},
+			{ID: 2, TransactionId: "tx", Account: db.WorkspaceBudgetAccount("workspace_uuid"), Credit: 1000},
+		}
+		mockDb.On("GetBudgetLedger", "workspace_uuid").Return(entries).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceBudgetLedger).ServeHTTP(rr, newRequest())
+
+		var returned []db.BudgetLedgerEntry
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &returned))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, entries, returned)
+	})
+}
+
+func TestGetWorkspaceBudgetSnapshots(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+	oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return role == db.ViewReport
+	}
+
+	newRequest := func(query string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "admin_pubkey")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodGet, "/budget/workspace_uuid/history?"+query, nil)
+		return req
+	}
+	snapshots := []db.WorkspaceBudgetSnapshot{
+		{WorkspaceUuid: "workspace_uuid", Day: "2026-03-01", TotalBudget: 1000},
+		{WorkspaceUuid: "workspace_uuid", Day: "2026-03-02", TotalBudget: 900},
+		{WorkspaceUuid: "workspace_uuid", Day: "2026-03-08", TotalBudget: 800},
+		{WorkspaceUuid: "workspace_uuid", Day: "2026-03-09", TotalBudget: 700},
+	}
+
+	t.Run("should reject an unknown granularity", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceBudgetSnapshots).ServeHTTP(rr, newRequest("granularity=month"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return the daily snapshots", func(t *testing.T) {
+		mockDb.On("GetWorkspaceBudgetSnapshots", "workspace_uuid", "2026-03-01", "2026-03-09").Return(snapshots).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceBudgetSnapshots).ServeHTTP(rr, newRequest("from=2026-03-01&to=2026-03-09"))
+
+		var history db.WorkspaceBudgetHistory
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &history))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, db.BudgetByDay, history.Granularity)
+		assert.Equal(t, snapshots, history.Snapshots)
+	})
+
+	t.Run("should keep the last snapshot of every week", func(t *testing.T) {
+		mockDb.On("GetWorkspaceBudgetSnapshots", "workspace_uuid", "2026-03-01", "2026-03-09").Return(snapshots).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceBudgetSnapshots).ServeHTTP(rr, newRequest("from=2026-03-01&to=2026-03-09&granularity=week"))
+
+		var history db.WorkspaceBudgetHistory
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &history))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Len(t, history.Snapshots, 3)
+		assert.Equal(t, "2026-02-23", history.Snapshots[0].Day)
+		assert.Equal(t, uint(1000), history.Snapshots[0].TotalBudget)
+		assert.Equal(t, "2026-03-02", history.Snapshots[1].Day)
+		assert.Equal(t, uint(800), history.Snapshots[1].TotalBudget)
+		assert.Equal(t, "2026-03-09", history.Snapshots[2].Day)
+	})
+}
+
+func TestGetWorkspacePresence(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+	since := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	oHandler.onlineMembers = func(workspaceUuid string) []websocket.OnlineMember {
+		return []websocket.OnlineMember{{Pubkey: "member_pubkey", Since: since}}
+	}
+
+	newRequest := func(pubKey string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubKey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodGet, "/workspace_uuid/presence", nil)
+		return req
+	}
+
+	t.Run("should refuse someone outside the workspace", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}).Once()
+		mockDb.On("GetWorkspaceUser", "stranger_pubkey", "workspace_uuid").Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspacePresence).ServeHTTP(rr, newRequest("stranger_pubkey"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should list the online members with their profiles", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}).Once()
+		mockDb.On("GetPeopleByPubkeys", []string{"member_pubkey"}).Return(map[string]db.Person{
+			"member_pubkey": {OwnerPubKey: "member_pubkey", OwnerAlias: "member", Img: "img"},
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspacePresence).ServeHTTP(rr, newRequest("owner_pubkey"))
+
+		var members []db.WorkspacePresenceMember
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &members))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, []db.WorkspacePresenceMember{{Pubkey: "member_pubkey", OwnerAlias: "member", Img: "img", Since: since}}, members)
+	})
+}
+
+func TestWorkspaceApiTokens(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+	oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "admin_pubkey" && role == db.EditOrg
+	}
+
+	newRequest := func(pubkey string, method string, id string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace_uuid")
+		rctx.URLParams.Add("id", id)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), method, "/workspace_uuid/api_tokens", bytes.NewBufferString(body))
+		return req
+	}
+
+	t.Run("should only let workspace admins manage api tokens", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.MintWorkspaceApiToken).ServeHTTP(rr, newRequest("member_pubkey", http.MethodPost, "", `{"name": "ci", "scopes": ["bounties:create"]}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceApiTokens).ServeHTTP(rr, newRequest("", http.MethodGet, "", ""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should validate the minted token", func(t *testing.T) {
+		for _, body := range []string{
+			`{"scopes": ["bounties:create"]}`,
+			`{"name": "ci", "scopes": []}`,
+			`{"name": "ci", "scopes": ["workspaces:delete"]}`,
+			`{"name": "ci", "scopes": ["bounties:create"], "expires_in_days": 1000}`,
+		} {
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(oHandler.MintWorkspaceApiToken).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodPost, "", body))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+	})
+
+	t.Run("should mint a token and only store its hash", func(t *testing.T) {
+		mockDb.On("CreateWorkspaceApiToken", mock.MatchedBy(func(token db.WorkspaceApiToken) bool {
+			return token.WorkspaceUuid == "workspace_uuid" && token.Name == "ci" && token.CreatedBy == "admin_pubkey" &&
+				len(token.TokenHash) == 64 && token.Expires != nil && len(token.Scopes) == 1
+		})).Return(func(token db.WorkspaceApiToken) (db.WorkspaceApiToken, error) {
+			token.ID = 1
+			return token, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.MintWorkspaceApiToken).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodPost, "",
+			`{"name": " ci ", "scopes": ["bounties:create"], "expires_in_days": 30}`))
+
+		minted := db.MintedWorkspaceApiToken{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &minted))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, strings.HasPrefix(minted.Token, db.ApiTokenPrefix))
+		assert.True(t, strings.HasPrefix(minted.Token, minted.TokenPrefix))
+		assert.NotContains(t, rr.Body.String(), db.HashApiToken(minted.Token))
+	})
+
+	t.Run("should list the tokens of the workspace", func(t *testing.T) {
+		tokens := []db.WorkspaceApiToken{{ID: 1, WorkspaceUuid: "workspace_uuid", Name: "ci", TokenPrefix: "sbt_ABCDEF"}}
+		mockDb.On("GetWorkspaceApiTokens", "workspace_uuid").Return(tokens).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceApiTokens).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodGet, "", ""))
+
+		returned := []db.WorkspaceApiToken{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &returned))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, tokens, returned)
+	})
+
+	t.Run("should revoke a token of the workspace", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.RevokeWorkspaceApiToken).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodDelete, "abc", ""))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		mockDb.On("RevokeWorkspaceApiToken", "workspace_uuid", uint(2)).Return(db.ErrApiTokenNotFound).Once()
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(oHandler.RevokeWorkspaceApiToken).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodDelete, "2", ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		mockDb.On("RevokeWorkspaceApiToken", "workspace_uuid", uint(1)).Return(nil).Once()
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(oHandler.RevokeWorkspaceApiToken).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodDelete, "1", ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestWorkspaceSettings(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+	oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "admin_pubkey" && role == db.EditOrg
+	}
+
+	workspace := db.Workspace{Uuid: "workspace_uuid", OwnerPubKey: "owner_pubkey"}
+
+	newRequest := func(pubkey string, method string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", workspace.Uuid)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), method, "/workspace_uuid/settings", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should only show the settings to workspace members", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceUser", "other_pubkey", workspace.Uuid).Return(db.WorkspaceUsers{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceSettings).ServeHTTP(rr, newRequest("other_pubkey", http.MethodGet, ""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should return the default settings to a member", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceUser", "member_pubkey", workspace.Uuid).Return(db.WorkspaceUsers{OwnerPubKey: "member_pubkey"}).Once()
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(db.DefaultWorkspaceSettings(workspace.Uuid)).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceSettings).ServeHTTP(rr, newRequest("member_pubkey", http.MethodGet, ""))
+
+		settings := db.WorkspaceSettings{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &settings))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, db.BountyVisibilityPublic, settings.DefaultBountyVisibility)
+	})
+
+	t.Run("should need the edit role to change the settings", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.UpdateWorkspaceSettings).ServeHTTP(rr, newRequest("member_pubkey", http.MethodPut, `{"wip_limit": 2}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should reject unknown and invalid settings", func(t *testing.T) {
+		for _, body := range []string{`{"wip_limits": 2}`, `{"default_bounty_visibility": "secret"}`, `{"wip_limit": 500}`, `{"wip_limit": -1}`,
+			`{"ai_model": "claude-sonnet"}`, `{"ai_provider": "skynet", "ai_model": "t800"}`, `{"ai_provider": "openai"}`,
+			`{"ai_provider": "openai", "ai_model": "gpt", "ai_temperature": 3}`, `{"ai_provider": "openai", "ai_model": "gpt", "ai_provider_key_ref": "sk-live-123"}`,
+			`{"state_wip_limits": [{"state": "paid", "limit": 5}]}`, `{"state_wip_limits": [{"state": "in_review", "limit": 0}]}`,
+			`{"state_wip_limits": [{"state": "in_review", "limit": 3}, {"state": "in_review", "limit": 5}]}`, `{"display_currency": "doge"}`} {
+			mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(db.DefaultWorkspaceSettings(workspace.Uuid)).Once()
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(oHandler.UpdateWorkspaceSettings).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodPut, body))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+	})
+
+	t.Run("should keep the settings left out of the request", func(t *testing.T) {
+		current := db.DefaultWorkspaceSettings(workspace.Uuid)
+		current.PaymentApprovalThreshold = 50000
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(current).Once()
+		mockDb.On("SaveWorkspaceSettings", mock.MatchedBy(func(s db.WorkspaceSettings) bool {
+			return s.PaymentApprovalThreshold == 50000 && s.WipLimit == 2 && s.DefaultBountyVisibility == db.BountyVisibilityHidden && s.UpdatedBy == "admin_pubkey"
+		})).Return(func(s db.WorkspaceSettings) (db.WorkspaceSettings, error) {
+			return s, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.UpdateWorkspaceSettings).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodPut, `{"wip_limit": 2, "default_bounty_visibility": "hidden"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should save the state wip limits", func(t *testing.T) {
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(db.DefaultWorkspaceSettings(workspace.Uuid)).Once()
+		mockDb.On("SaveWorkspaceSettings", mock.MatchedBy(func(s db.WorkspaceSettings) bool {
+			return len(s.StateWipLimits) == 2 && s.StateWipLimits[1] == db.StateWipLimit{PhaseUuid: "phase_uuid", State: db.BountyStateInReview, Limit: 3}
+		})).Return(func(s db.WorkspaceSettings) (db.WorkspaceSettings, error) {
+			return s, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.UpdateWorkspaceSettings).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodPut,
+			`{"state_wip_limits": [{"state": "in_review", "limit": 10}, {"phase_uuid": "phase_uuid", "state": "in_review", "limit": 3}]}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should save the assistant settings", func(t *testing.T) {
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(db.DefaultWorkspaceSettings(workspace.Uuid)).Once()
+		mockDb.On("SaveWorkspaceSettings", mock.MatchedBy(func(s db.WorkspaceSettings) bool {
+			return s.AiProvider == db.AiProviderStakwork && s.AiModel == "gpt-4o" && *s.AiTemperature == 0.7 && s.AiProviderKeyRef == "STAKWORK_KEY"
+		})).Return(func(s db.WorkspaceSettings) (db.WorkspaceSettings, error) {
+			return s, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.UpdateWorkspaceSettings).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodPut,
+			`{"ai_provider": "stakwork", "ai_model": "gpt-4o", "ai_temperature": 0.7, "ai_provider_key_ref": "STAKWORK_KEY"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should save the display currency in lowercase", func(t *testing.T) {
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(db.DefaultWorkspaceSettings(workspace.Uuid)).Once()
+		mockDb.On("SaveWorkspaceSettings", mock.MatchedBy(func(s db.WorkspaceSettings) bool {
+			return s.DisplayCurrency == "eur"
+		})).Return(func(s db.WorkspaceSettings) (db.WorkspaceSettings, error) {
+			return s, nil
+		}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.UpdateWorkspaceSettings).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodPut, `{"display_currency": "EUR"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+type fakeRates struct {
+	rate rates.Rate
+	err  error
+}
+
+func (f fakeRates) Rate(ctx context.Context, currency string) (rates.Rate, error) {
+	return f.rate, f.err
+}
+
+func TestWorkspaceBudgetDisplay(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+	oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return true
+	}
+
+	rateTimestamp := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	budget := db.StatusBudget{WorkspaceUuid: "workspace_uuid", CurrentBudget: 150000, OpenBudget: 20000}
+
+	newRequest := func() *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "admin_pubkey")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodGet, "/budget/workspace_uuid", nil)
+		return req
+	}
+
+	t.Run("should add the budgets in the display currency", func(t *testing.T) {
+		settings := db.DefaultWorkspaceSettings("workspace_uuid")
+		settings.DisplayCurrency = "usd"
+		mockDb.On("GetWorkspaceStatusBudget", "workspace_uuid").Return(budget).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(settings).Once()
+		oHandler.rates = fakeRates{rate: rates.Rate{Currency: "usd", BtcPrice: 60000, Timestamp: rateTimestamp}}
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceBudget).ServeHTTP(rr, newRequest())
+
+		returned := db.StatusBudget{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &returned))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, uint(150000), returned.CurrentBudget)
+		assert.Equal(t, &db.BudgetDisplay{
+			Currency:        "usd",
+			BtcPrice:        60000,
+			RateTimestamp:   rateTimestamp,
+			CurrentBudget:   90,
+			OpenBudget:      12,
+			AssignedBudget:  0,
+			CompletedBudget: 0,
+		}, returned.Display)
+	})
+
+	t.Run("should show sats only when the rate is unavailable", func(t *testing.T) {
+		settings := db.DefaultWorkspaceSettings("workspace_uuid")
+		settings.DisplayCurrency = "usd"
+		mockDb.On("GetWorkspaceStatusBudget", "workspace_uuid").Return(budget).Once()
+		mockDb.On("GetWorkspaceSettings", "workspace_uuid").Return(settings).Once()
+		oHandler.rates = fakeRates{err: errors.New("rate provider answered 429")}
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceBudget).ServeHTTP(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), `"display"`)
+	})
+}
+
+func TestRestoreWorkspace(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+
+	newRequest := func(uuid string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", uuid)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "super_admin")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/"+uuid+"/restore", nil)
+		return req
+	}
+
+	t.Run("should list the restorable workspaces", func(t *testing.T) {
+		deleted := []db.DeletedWorkspace{{Uuid: "workspace_uuid", Name: "Sphinx"}}
+		mockDb.On("GetDeletedWorkspaces").Return(deleted).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetDeletedWorkspaces).ServeHTTP(rr, newRequest(""))
+
+		returned := []db.DeletedWorkspace{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &returned))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, deleted, returned)
+	})
+
+	t.Run("should map restore errors to statuses", func(t *testing.T) {
+		cases := map[error]int{
+			gorm.ErrRecordNotFound:          http.StatusNotFound,
+			db.ErrWorkspaceNotDeleted:       http.StatusConflict,
+			db.ErrWorkspaceRetentionExpired: http.StatusGone,
+		}
+		for err, status := range cases {
+			mockDb.On("RestoreWorkspace", "workspace_uuid").Return(db.Workspace{}, err).Once()
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(oHandler.RestoreWorkspace).ServeHTTP(rr, newRequest("workspace_uuid"))
+			assert.Equal(t, status, rr.Code, err.Error())
+		}
+	})
+
+	t.Run("should restore a workspace", func(t *testing.T) {
+		mockDb.On("RestoreWorkspace", "workspace_uuid").Return(db.Workspace{Uuid: "workspace_uuid", Name: "Sphinx"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.RestoreWorkspace).ServeHTTP(rr, newRequest("workspace_uuid"))
+
+		workspace := db.Workspace{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &workspace))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.False(t, workspace.Deleted)
+	})
+}
+
+func TestWorkspaceAlertWebhook(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+	oHandler.checkPublicHost = func(host string) error {
+		if host == "hooks.example.com" {
+			return nil
+		}
+		return utils.ErrNotPublicAddress
+	}
+
+	newRequest := func(webhook string) *http.Request {
+		body, _ := json.Marshal(db.Workspace{Uuid: "workspace_uuid", Name: "Sphinx", OwnerPubKey: "owner", AlertWebhook: webhook})
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, "/", bytes.NewReader(body))
+		return req
+	}
+
+	t.Run("should refuse an alert webhook that is not a public https url", func(t *testing.T) {
+		for _, webhook := range []string{"http://hooks.example.com/alerts", "https://169.254.169.254/latest/meta-data", "https://localhost:8080/alerts"} {
+			for _, handler := range []http.HandlerFunc{oHandler.UpdateWorkspace, oHandler.CreateOrEditWorkspace} {
+				rr := httptest.NewRecorder()
+				handler.ServeHTTP(rr, newRequest(webhook))
+				assert.Equal(t, http.StatusBadRequest, rr.Code, webhook)
+			}
+		}
+		mockDb.AssertNotCalled(t, "CreateOrEditWorkspace", mock.Anything)
+	})
+
+	t.Run("should save a public https alert webhook", func(t *testing.T) {
+		mockDb.On("CreateOrEditWorkspace", mock.MatchedBy(func(workspace db.Workspace) bool {
+			return workspace.AlertWebhook == "https://hooks.example.com/alerts"
+		})).Return(db.Workspace{Uuid: "workspace_uuid", AlertWebhook: "https://hooks.example.com/alerts"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.UpdateWorkspace).ServeHTTP(rr, newRequest("https://hooks.example.com/alerts"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestCreateIsolatedWorkspace(t *testing.T) {
+	defer func() { config.WorkspaceSchemas = false }()
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+
+	newRequest := func(body string) *http.Request {
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner_pubkey")
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/", strings.NewReader(body))
+		return req
+	}
+	body := `{"uuid": "isolated_uuid", "name": "Isolated", "owner_pubkey": "owner_pubkey", "isolated_data": true, "data_schema": "public"}`
+
+	t.Run("should refuse isolated data when it is not enabled", func(t *testing.T) {
+		config.WorkspaceSchemas = false
+		mockDb.On("GetWorkspaceByUuid", "isolated_uuid").Return(db.Workspace{}).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.CreateOrEditWorkspace).ServeHTTP(rr, newRequest(body))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should create the workspace in its own schema", func(t *testing.T) {
+		config.WorkspaceSchemas = true
+		mockDb.On("GetWorkspaceByUuid", "isolated_uuid").Return(db.Workspace{}).Once()
+		mockDb.On("GetWorkspaceByName", "Isolated").Return(db.Workspace{}).Once()
+		mockDb.On("CreateOrEditWorkspace", mock.MatchedBy(func(w db.Workspace) bool {
+			return w.Uuid == "isolated_uuid" && w.DataSchema == ""
+		})).Return(db.Workspace{Uuid: "isolated_uuid", Name: "Isolated"}, nil).Once()
+		mockDb.On("IsolateWorkspace", "isolated_uuid").Return(db.Workspace{Uuid: "isolated_uuid", Name: "Isolated", DataSchema: "ws_isolateduuid"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.CreateOrEditWorkspace).ServeHTTP(rr, newRequest(body))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		workspace := db.Workspace{}
+		json.Unmarshal(rr.Body.Bytes(), &workspace)
+		assert.Equal(t, "ws_isolateduuid", workspace.DataSchema)
+	})
+
+	t.Run("should keep the schema when the workspace is edited", func(t *testing.T) {
+		existing := db.Workspace{ID: 1, Uuid: "isolated_uuid", Name: "Isolated", OwnerPubKey: "owner_pubkey", DataSchema: "ws_isolateduuid"}
+		mockDb.On("GetWorkspaceByUuid", "isolated_uuid").Return(existing).Once()
+		mockDb.On("CreateOrEditWorkspace", mock.MatchedBy(func(w db.Workspace) bool {
+			return w.DataSchema == "ws_isolateduuid"
+		})).Return(existing, nil).Once()
+
+		rr := httptest.NewRecorder()
+		edit := `{"id": 1, "uuid": "isolated_uuid", "name": "Isolated", "owner_pubkey": "owner_pubkey", "data_schema": "public"}`
+		http.HandlerFunc(oHandler.CreateOrEditWorkspace).ServeHTTP(rr, newRequest(edit))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestWorkspaceTags(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+	oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "admin_pubkey" && role == db.EditOrg
+	}
+
+	newRequest := func(pubkey string, method string, tagUuid string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace_uuid")
+		rctx.URLParams.Add("tag_uuid", tagUuid)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), method, "/workspace_uuid/tags", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should only let workspace admins create tags", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.CreateWorkspaceTag).ServeHTTP(rr, newRequest("other_pubkey", http.MethodPost, "", `{"name": "frontend"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should refuse an invalid color", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.CreateWorkspaceTag).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodPost, "", `{"name": "frontend", "color": "red"}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should create a tag in the workspace", func(t *testing.T) {
+		mockDb.On("CreateWorkspaceTag", mock.MatchedBy(func(tag db.WorkspaceTag) bool {
+			return tag.WorkspaceUuid == "workspace_uuid" && tag.Name == "frontend" && tag.Uuid != "" && tag.CreatedBy == "admin_pubkey"
+		})).Return(db.WorkspaceTag{Uuid: "tag_uuid", WorkspaceUuid: "workspace_uuid", Name: "frontend", Color: "#1a2b3c"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.CreateWorkspaceTag).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodPost, "", `{"name": " frontend ", "color": "#1a2b3c"}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		tag := db.WorkspaceTag{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tag))
+		assert.Equal(t, "tag_uuid", tag.Uuid)
+	})
+
+	t.Run("should refuse a name already used in the workspace", func(t *testing.T) {
+		mockDb.On("UpdateWorkspaceTag", mock.MatchedBy(func(tag db.WorkspaceTag) bool {
+			return tag.Uuid == "tag_uuid" && tag.Name == "backend"
+		})).Return(db.WorkspaceTag{}, db.ErrTagNameTaken).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.UpdateWorkspaceTag).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodPut, "tag_uuid", `{"name": "backend"}`))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should return 404 when deleting a missing tag", func(t *testing.T) {
+		mockDb.On("DeleteWorkspaceTag", "workspace_uuid", "missing_uuid").Return(db.ErrTagNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.DeleteWorkspaceTag).ServeHTTP(rr, newRequest("admin_pubkey", http.MethodDelete, "missing_uuid", ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestCloneWorkspace(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+	oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool {
+		return pubKeyFromAuth == "admin_pubkey" && role == db.EditOrg
+	}
+
+	newRequest := func(pubkey string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", "workspace_uuid")
+		ctx := context.WithValue(context.Background(), auth.ContextKey, pubkey)
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/workspace_uuid/clone", strings.NewReader(body))
+		return req
+	}
+
+	t.Run("should only let workspace admins clone it", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.CloneWorkspace).ServeHTTP(rr, newRequest("other_pubkey", `{"name": "Copy"}`))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should require a name", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.CloneWorkspace).ServeHTTP(rr, newRequest("admin_pubkey", `{"name": " "}`))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should refuse a name that is taken", func(t *testing.T) {
+		mockDb.On("CloneWorkspace", "workspace_uuid", "admin_pubkey", db.WorkspaceCloneRequest{Name: "Taken"}).Return(db.WorkspaceCloneResult{}, db.ErrWorkspaceNameTaken).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.CloneWorkspace).ServeHTTP(rr, newRequest("admin_pubkey", `{"name": "Taken"}`))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should clone the workspace with its features", func(t *testing.T) {
+		request := db.WorkspaceCloneRequest{Name: "Copy", IncludeFeatures: true}
+		mockDb.On("CloneWorkspace", "workspace_uuid", "admin_pubkey", request).Return(db.WorkspaceCloneResult{
+			Workspace: db.Workspace{Uuid: "clone_uuid", Name: "Copy", OwnerPubKey: "admin_pubkey"},
+			Members:   2,
+			Roles:     5,
+			Features:  1,
+			Phases:    3,
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.CloneWorkspace).ServeHTTP(rr, newRequest("admin_pubkey", `{"name": " Copy ", "include_features": true}`))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		result := db.WorkspaceCloneResult{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, "clone_uuid", result.Workspace.Uuid)
+		assert.Equal(t, 3, result.Phases)
+	})
+}
+
+func TestGetWorkspaceShowcase(t *testing.T) {
+	mockDb := dbMocks.NewDatabase(t)
+	oHandler := NewWorkspaceHandler(mockDb)
+
+	workspace := db.Workspace{Uuid: "workspace_uuid", Name: "Workspace", Mission: "Build things"}
+
+	newRequest := func() *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", workspace.Uuid)
+		req, _ := http.NewRequestWithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx), http.MethodGet, "/workspace_uuid/showcase", nil)
+		return req
+	}
+
+	t.Run("should hide workspaces that did not opt in", func(t *testing.T) {
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(db.DefaultWorkspaceSettings(workspace.Uuid)).Once()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceShowcase).ServeHTTP(rr, newRequest())
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should show and cache the showcase of a workspace that opted in", func(t *testing.T) {
+		settings := db.DefaultWorkspaceSettings(workspace.Uuid)
+		settings.PublicShowcase = true
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(settings).Once()
+		mockDb.On("GetWorkspaceShowcase", workspace, showcaseBountiesLimit).Return(db.WorkspaceShowcase{
+			Uuid:                   workspace.Uuid,
+			Mission:                workspace.Mission,
+			OpenBounties:           []db.ShowcaseBounty{{ID: 1, Title: "Open bounty", Price: 1000}},
+			OpenBountiesCount:      1,
+			CompletedBountiesCount: 4,
+		}, nil).Once()
+
+		for i := 0; i < 2; i++ {
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(oHandler.GetWorkspaceShowcase).ServeHTTP(rr, newRequest())
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "public, max-age=60", rr.Header().Get("Cache-Control"))
+
+			showcase := db.WorkspaceShowcase{}
+			assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &showcase))
+			assert.Equal(t, "Build things", showcase.Mission)
+			assert.Equal(t, int64(4), showcase.CompletedBountiesCount)
+			assert.Len(t, showcase.OpenBounties, 1)
+		}
+	})
+
+	t.Run("should drop the cached showcase when the settings are saved", func(t *testing.T) {
+		oHandler.userHasAccess = func(pubKeyFromAuth string, uuid string, role string) bool { return true }
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(db.DefaultWorkspaceSettings(workspace.Uuid)).Once()
+		mockDb.On("SaveWorkspaceSettings", mock.Anything).Return(db.DefaultWorkspaceSettings(workspace.Uuid), nil).Once()
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", workspace.Uuid)
+		ctx := context.WithValue(context.Background(), auth.ContextKey, "owner_pubkey")
+		req, _ := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPut, "/workspace_uuid/settings", strings.NewReader(`{"public_showcase": false}`))
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(oHandler.UpdateWorkspaceSettings).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		mockDb.On("GetWorkspaceByUuid", workspace.Uuid).Return(workspace).Once()
+		mockDb.On("GetWorkspaceSettings", workspace.Uuid).Return(db.DefaultWorkspaceSettings(workspace.Uuid)).Once()
+
+		rr = httptest.NewRecorder()
+		http.HandlerFunc(oHandler.GetWorkspaceShowcase).ServeHTTP(rr, newRequest())
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
 	"github.com/stakwork/sphinx-tribes/routes"
+	"github.com/stakwork/sphinx-tribes/utils"
 	"github.com/stakwork/sphinx-tribes/websocket"
 	"gopkg.in/go-playground/validator.v9"
 )
@@ -26,23 +27,86 @@ func main() {
 		fmt.Println("no .env file")
 	}
 
+	// Config has to be inited before the DB, for the pool settings,
+	// and before JWT, if not it will lead to NO JWT error
+	config.InitConfig()
+	if err := utils.InitTracing(config.OtelEndpoint); err != nil {
+		fmt.Println("could not init tracing", err)
+	}
 	db.InitDB()
+
+	if len(os.Args) > 1 && os.Args[1] == "encrypt-person-fields" {
+		count, err := db.DB.EncryptPersonFields()
+		if err != nil {
+			fmt.Println("could not encrypt person fields", err)
+			os.Exit(1)
+		}
+		fmt.Printf("encrypted the fields of %d people\n", count)
+		os.Exit(0)
+	}
+
+	if len(os.Args) > 2 && os.Args[1] == "isolate-workspace" {
+		workspace, err := db.DB.IsolateWorkspace(os.Args[2])
+		if err != nil {
+			fmt.Println("could not isolate workspace", err)
+			os.Exit(1)
+		}
+		fmt.Printf("workspace %s now keeps its data in schema %s\n", workspace.Uuid, workspace.DataSchema)
+		os.Exit(0)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(exportWorkspace(os.Args[2:]))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(importWorkspace(os.Args[2:]))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "seed-demo" {
+		os.Exit(seedDemo(os.Args[2:]))
+	}
+
 	db.InitRedis()
 	db.InitCache()
 	db.InitRoles()
-	// Config has to be inited before JWT, if not it will lead to NO JWT error
-	config.InitConfig()
 	auth.InitJwt()
+	config.OnSecretsRotated(auth.InitJwt)
+	auth.SetSuperAdminSource(db.DB.GetSuperAdminPubkeys)
+	auth.SetApiTokenVerifier(db.DB.VerifyWorkspaceApiToken)
+	auth.SetTokenNonceStore(db.Store.UseOnce)
+	auth.SetApiTokenObserver(handlers.ObserveApiTokenUsage)
+	auth.SetWebhookSecretSource(handlers.WebhookSecrets)
+	websocket.SetPresenceAuthorizer(handlers.IsPresenceMember)
+	websocket.SetChatAuthorizer(handlers.IsChatMember)
 
 	// validate
 	db.Validate = validator.New()
 	// Start websocket pool
 	go websocket.WebsocketPool.Start()
 
+	// apply the config overrides before the router reads the config
+	handlers.ReloadConfigOverrides(db.DB)
+	handlers.InitConfigReloadCron()
+	handlers.InitSecretsRotationCron()
+	handlers.InitUsageFlushCron()
+
 	skipLoops := os.Getenv("SKIP_LOOPS")
 	if skipLoops != "true" {
 		go handlers.ProcessTwitterConfirmationsLoop()
 		go handlers.ProcessGithubIssuesLoop()
+		handlers.InitStaleBountyCron()
+		handlers.InitBountyDueReminderCron()
+		handlers.InitBountyAuctionCron()
+		if !config.RouteGroupDisabled(config.RouteGroupPayments) {
+			handlers.InitOnchainPaymentCron()
+		}
+		handlers.InitNostrBountyCron()
+		handlers.InitLeaderboardCron()
+		handlers.InitBudgetLedgerCron()
+		handlers.InitBudgetSnapshotCron()
+		handlers.InitFeatureCountsCron()
+		handlers.InitWorkspaceRetentionCron()
 	}
 
 	run()
@@ -63,4 +127,9 @@ func run() {
 	if err := router.Shutdown(ctx); err != nil {
 		fmt.Printf("error shutting down server: %s", err.Error())
 	}
+	// keep the usage counted since the last flush
+	handlers.FlushWorkspaceUsage(db.DB)
+	if err := utils.ShutdownTracing(ctx); err != nil {
+		fmt.Printf("error flushing traces: %s", err.Error())
+	}
 }
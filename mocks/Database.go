@@ -3,10 +3,15 @@
 package db
 
 import (
-	http "net/http"
+	context "context"
 
+	auth "github.com/stakwork/sphinx-tribes/auth"
 	db "github.com/stakwork/sphinx-tribes/db"
 
+	http "net/http"
+
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
@@ -25,6 +30,62 @@ func (_m *Database) EXPECT() *Database_Expecter {
 	return &Database_Expecter{mock: &_m.Mock}
 }
 
+// AcceptBountyApplication provides a mock function with given fields: application
+func (_m *Database) AcceptBountyApplication(application db.BountyApplication) (db.NewBounty, error) {
+	ret := _m.Called(application)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptBountyApplication")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyApplication) (db.NewBounty, error)); ok {
+		return rf(application)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyApplication) db.NewBounty); ok {
+		r0 = rf(application)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BountyApplication) error); ok {
+		r1 = rf(application)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_AcceptBountyApplication_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptBountyApplication'
+type Database_AcceptBountyApplication_Call struct {
+	*mock.Call
+}
+
+// AcceptBountyApplication is a helper method to define mock.On call
+//   - application db.BountyApplication
+func (_e *Database_Expecter) AcceptBountyApplication(application interface{}) *Database_AcceptBountyApplication_Call {
+	return &Database_AcceptBountyApplication_Call{Call: _e.mock.On("AcceptBountyApplication", application)}
+}
+
+func (_c *Database_AcceptBountyApplication_Call) Run(run func(application db.BountyApplication)) *Database_AcceptBountyApplication_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BountyApplication))
+	})
+	return _c
+}
+
+func (_c *Database_AcceptBountyApplication_Call) Return(_a0 db.NewBounty, _a1 error) *Database_AcceptBountyApplication_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_AcceptBountyApplication_Call) RunAndReturn(run func(db.BountyApplication) (db.NewBounty, error)) *Database_AcceptBountyApplication_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddAndUpdateBudget provides a mock function with given fields: invoice
 func (_m *Database) AddAndUpdateBudget(invoice db.NewInvoiceList) db.NewPaymentHistory {
 	ret := _m.Called(invoice)
@@ -265,351 +326,389 @@ func (_c *Database_AddPaymentHistory_Call) RunAndReturn(run func(db.NewPaymentHi
 	return _c
 }
 
-// AddUserInvoiceData provides a mock function with given fields: userData
-func (_m *Database) AddUserInvoiceData(userData db.UserInvoiceData) db.UserInvoiceData {
-	ret := _m.Called(userData)
+// AddPhaseDocument provides a mock function with given fields: doc
+func (_m *Database) AddPhaseDocument(doc db.PhaseDocument) (db.PhaseDocument, error) {
+	ret := _m.Called(doc)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddUserInvoiceData")
+		panic("no return value specified for AddPhaseDocument")
 	}
 
-	var r0 db.UserInvoiceData
-	if rf, ok := ret.Get(0).(func(db.UserInvoiceData) db.UserInvoiceData); ok {
-		r0 = rf(userData)
+	var r0 db.PhaseDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.PhaseDocument) (db.PhaseDocument, error)); ok {
+		return rf(doc)
+	}
+	if rf, ok := ret.Get(0).(func(db.PhaseDocument) db.PhaseDocument); ok {
+		r0 = rf(doc)
 	} else {
-		r0 = ret.Get(0).(db.UserInvoiceData)
+		r0 = ret.Get(0).(db.PhaseDocument)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.PhaseDocument) error); ok {
+		r1 = rf(doc)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_AddUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUserInvoiceData'
-type Database_AddUserInvoiceData_Call struct {
+// Database_AddPhaseDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPhaseDocument'
+type Database_AddPhaseDocument_Call struct {
 	*mock.Call
 }
 
-// AddUserInvoiceData is a helper method to define mock.On call
-//   - userData db.UserInvoiceData
-func (_e *Database_Expecter) AddUserInvoiceData(userData interface{}) *Database_AddUserInvoiceData_Call {
-	return &Database_AddUserInvoiceData_Call{Call: _e.mock.On("AddUserInvoiceData", userData)}
+// AddPhaseDocument is a helper method to define mock.On call
+//   - doc db.PhaseDocument
+func (_e *Database_Expecter) AddPhaseDocument(doc interface{}) *Database_AddPhaseDocument_Call {
+	return &Database_AddPhaseDocument_Call{Call: _e.mock.On("AddPhaseDocument", doc)}
 }
 
-func (_c *Database_AddUserInvoiceData_Call) Run(run func(userData db.UserInvoiceData)) *Database_AddUserInvoiceData_Call {
+func (_c *Database_AddPhaseDocument_Call) Run(run func(doc db.PhaseDocument)) *Database_AddPhaseDocument_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.UserInvoiceData))
+		run(args[0].(db.PhaseDocument))
 	})
 	return _c
 }
 
-func (_c *Database_AddUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_AddUserInvoiceData_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_AddPhaseDocument_Call) Return(_a0 db.PhaseDocument, _a1 error) *Database_AddPhaseDocument_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_AddUserInvoiceData_Call) RunAndReturn(run func(db.UserInvoiceData) db.UserInvoiceData) *Database_AddUserInvoiceData_Call {
+func (_c *Database_AddPhaseDocument_Call) RunAndReturn(run func(db.PhaseDocument) (db.PhaseDocument, error)) *Database_AddPhaseDocument_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AverageCompletedTime provides a mock function with given fields: r, workspace
-func (_m *Database) AverageCompletedTime(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// AddUnsettledBountyPayment provides a mock function with given fields: payment
+func (_m *Database) AddUnsettledBountyPayment(payment db.NewPaymentHistory) error {
+	ret := _m.Called(payment)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AverageCompletedTime")
+		panic("no return value specified for AddUnsettledBountyPayment")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory) error); ok {
+		r0 = rf(payment)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_AverageCompletedTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AverageCompletedTime'
-type Database_AverageCompletedTime_Call struct {
+// Database_AddUnsettledBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUnsettledBountyPayment'
+type Database_AddUnsettledBountyPayment_Call struct {
 	*mock.Call
 }
 
-// AverageCompletedTime is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) AverageCompletedTime(r interface{}, workspace interface{}) *Database_AverageCompletedTime_Call {
-	return &Database_AverageCompletedTime_Call{Call: _e.mock.On("AverageCompletedTime", r, workspace)}
+// AddUnsettledBountyPayment is a helper method to define mock.On call
+//   - payment db.NewPaymentHistory
+func (_e *Database_Expecter) AddUnsettledBountyPayment(payment interface{}) *Database_AddUnsettledBountyPayment_Call {
+	return &Database_AddUnsettledBountyPayment_Call{Call: _e.mock.On("AddUnsettledBountyPayment", payment)}
 }
 
-func (_c *Database_AverageCompletedTime_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_AverageCompletedTime_Call {
+func (_c *Database_AddUnsettledBountyPayment_Call) Run(run func(payment db.NewPaymentHistory)) *Database_AddUnsettledBountyPayment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(db.NewPaymentHistory))
 	})
 	return _c
 }
 
-func (_c *Database_AverageCompletedTime_Call) Return(_a0 uint) *Database_AverageCompletedTime_Call {
+func (_c *Database_AddUnsettledBountyPayment_Call) Return(_a0 error) *Database_AddUnsettledBountyPayment_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_AverageCompletedTime_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_AverageCompletedTime_Call {
+func (_c *Database_AddUnsettledBountyPayment_Call) RunAndReturn(run func(db.NewPaymentHistory) error) *Database_AddUnsettledBountyPayment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AveragePaidTime provides a mock function with given fields: r, workspace
-func (_m *Database) AveragePaidTime(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// AddUserInvoiceData provides a mock function with given fields: userData
+func (_m *Database) AddUserInvoiceData(userData db.UserInvoiceData) db.UserInvoiceData {
+	ret := _m.Called(userData)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AveragePaidTime")
+		panic("no return value specified for AddUserInvoiceData")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 db.UserInvoiceData
+	if rf, ok := ret.Get(0).(func(db.UserInvoiceData) db.UserInvoiceData); ok {
+		r0 = rf(userData)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(db.UserInvoiceData)
 	}
 
 	return r0
 }
 
-// Database_AveragePaidTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AveragePaidTime'
-type Database_AveragePaidTime_Call struct {
+// Database_AddUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUserInvoiceData'
+type Database_AddUserInvoiceData_Call struct {
 	*mock.Call
 }
 
-// AveragePaidTime is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) AveragePaidTime(r interface{}, workspace interface{}) *Database_AveragePaidTime_Call {
-	return &Database_AveragePaidTime_Call{Call: _e.mock.On("AveragePaidTime", r, workspace)}
+// AddUserInvoiceData is a helper method to define mock.On call
+//   - userData db.UserInvoiceData
+func (_e *Database_Expecter) AddUserInvoiceData(userData interface{}) *Database_AddUserInvoiceData_Call {
+	return &Database_AddUserInvoiceData_Call{Call: _e.mock.On("AddUserInvoiceData", userData)}
 }
 
-func (_c *Database_AveragePaidTime_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_AveragePaidTime_Call {
+func (_c *Database_AddUserInvoiceData_Call) Run(run func(userData db.UserInvoiceData)) *Database_AddUserInvoiceData_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(db.UserInvoiceData))
 	})
 	return _c
 }
 
-func (_c *Database_AveragePaidTime_Call) Return(_a0 uint) *Database_AveragePaidTime_Call {
+func (_c *Database_AddUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_AddUserInvoiceData_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_AveragePaidTime_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_AveragePaidTime_Call {
+func (_c *Database_AddUserInvoiceData_Call) RunAndReturn(run func(db.UserInvoiceData) db.UserInvoiceData) *Database_AddUserInvoiceData_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// BountiesPaidPercentage provides a mock function with given fields: r, workspace
-func (_m *Database) BountiesPaidPercentage(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// AddWorkspaceToParentOrganization provides a mock function with given fields: orgUuid, workspaceUuid
+func (_m *Database) AddWorkspaceToParentOrganization(orgUuid string, workspaceUuid string) error {
+	ret := _m.Called(orgUuid, workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for BountiesPaidPercentage")
+		panic("no return value specified for AddWorkspaceToParentOrganization")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(orgUuid, workspaceUuid)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_BountiesPaidPercentage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BountiesPaidPercentage'
-type Database_BountiesPaidPercentage_Call struct {
+// Database_AddWorkspaceToParentOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddWorkspaceToParentOrganization'
+type Database_AddWorkspaceToParentOrganization_Call struct {
 	*mock.Call
 }
 
-// BountiesPaidPercentage is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) BountiesPaidPercentage(r interface{}, workspace interface{}) *Database_BountiesPaidPercentage_Call {
-	return &Database_BountiesPaidPercentage_Call{Call: _e.mock.On("BountiesPaidPercentage", r, workspace)}
+// AddWorkspaceToParentOrganization is a helper method to define mock.On call
+//   - orgUuid string
+//   - workspaceUuid string
+func (_e *Database_Expecter) AddWorkspaceToParentOrganization(orgUuid interface{}, workspaceUuid interface{}) *Database_AddWorkspaceToParentOrganization_Call {
+	return &Database_AddWorkspaceToParentOrganization_Call{Call: _e.mock.On("AddWorkspaceToParentOrganization", orgUuid, workspaceUuid)}
 }
 
-func (_c *Database_BountiesPaidPercentage_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_BountiesPaidPercentage_Call {
+func (_c *Database_AddWorkspaceToParentOrganization_Call) Run(run func(orgUuid string, workspaceUuid string)) *Database_AddWorkspaceToParentOrganization_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_BountiesPaidPercentage_Call) Return(_a0 uint) *Database_BountiesPaidPercentage_Call {
+func (_c *Database_AddWorkspaceToParentOrganization_Call) Return(_a0 error) *Database_AddWorkspaceToParentOrganization_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_BountiesPaidPercentage_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_BountiesPaidPercentage_Call {
+func (_c *Database_AddWorkspaceToParentOrganization_Call) RunAndReturn(run func(string, string) error) *Database_AddWorkspaceToParentOrganization_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ChangeWorkspaceDeleteStatus provides a mock function with given fields: workspace_uuid, status
-func (_m *Database) ChangeWorkspaceDeleteStatus(workspace_uuid string, status bool) db.Workspace {
-	ret := _m.Called(workspace_uuid, status)
+// AddWorkspaceUsage provides a mock function with given fields: usage
+func (_m *Database) AddWorkspaceUsage(usage []db.WorkspaceUsageDaily) error {
+	ret := _m.Called(usage)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ChangeWorkspaceDeleteStatus")
+		panic("no return value specified for AddWorkspaceUsage")
 	}
 
-	var r0 db.Workspace
-	if rf, ok := ret.Get(0).(func(string, bool) db.Workspace); ok {
-		r0 = rf(workspace_uuid, status)
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]db.WorkspaceUsageDaily) error); ok {
+		r0 = rf(usage)
 	} else {
-		r0 = ret.Get(0).(db.Workspace)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_ChangeWorkspaceDeleteStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeWorkspaceDeleteStatus'
-type Database_ChangeWorkspaceDeleteStatus_Call struct {
+// Database_AddWorkspaceUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddWorkspaceUsage'
+type Database_AddWorkspaceUsage_Call struct {
 	*mock.Call
 }
 
-// ChangeWorkspaceDeleteStatus is a helper method to define mock.On call
-//   - workspace_uuid string
-//   - status bool
-func (_e *Database_Expecter) ChangeWorkspaceDeleteStatus(workspace_uuid interface{}, status interface{}) *Database_ChangeWorkspaceDeleteStatus_Call {
-	return &Database_ChangeWorkspaceDeleteStatus_Call{Call: _e.mock.On("ChangeWorkspaceDeleteStatus", workspace_uuid, status)}
+// AddWorkspaceUsage is a helper method to define mock.On call
+//   - usage []db.WorkspaceUsageDaily
+func (_e *Database_Expecter) AddWorkspaceUsage(usage interface{}) *Database_AddWorkspaceUsage_Call {
+	return &Database_AddWorkspaceUsage_Call{Call: _e.mock.On("AddWorkspaceUsage", usage)}
 }
 
-func (_c *Database_ChangeWorkspaceDeleteStatus_Call) Run(run func(workspace_uuid string, status bool)) *Database_ChangeWorkspaceDeleteStatus_Call {
+func (_c *Database_AddWorkspaceUsage_Call) Run(run func(usage []db.WorkspaceUsageDaily)) *Database_AddWorkspaceUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(bool))
+		run(args[0].([]db.WorkspaceUsageDaily))
 	})
 	return _c
 }
 
-func (_c *Database_ChangeWorkspaceDeleteStatus_Call) Return(_a0 db.Workspace) *Database_ChangeWorkspaceDeleteStatus_Call {
+func (_c *Database_AddWorkspaceUsage_Call) Return(_a0 error) *Database_AddWorkspaceUsage_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_ChangeWorkspaceDeleteStatus_Call) RunAndReturn(run func(string, bool) db.Workspace) *Database_ChangeWorkspaceDeleteStatus_Call {
+func (_c *Database_AddWorkspaceUsage_Call) RunAndReturn(run func([]db.WorkspaceUsageDaily) error) *Database_AddWorkspaceUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CountBounties provides a mock function with given fields:
-func (_m *Database) CountBounties() uint64 {
-	ret := _m.Called()
+// ApplyRelayTribeEvent provides a mock function with given fields: event, members, messages, received
+func (_m *Database) ApplyRelayTribeEvent(event db.RelayEvent, members uint64, messages uint64, received time.Time) (bool, error) {
+	ret := _m.Called(event, members, messages, received)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountBounties")
+		panic("no return value specified for ApplyRelayTribeEvent")
 	}
 
-	var r0 uint64
-	if rf, ok := ret.Get(0).(func() uint64); ok {
-		r0 = rf()
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.RelayEvent, uint64, uint64, time.Time) (bool, error)); ok {
+		return rf(event, members, messages, received)
+	}
+	if rf, ok := ret.Get(0).(func(db.RelayEvent, uint64, uint64, time.Time) bool); ok {
+		r0 = rf(event, members, messages, received)
 	} else {
-		r0 = ret.Get(0).(uint64)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.RelayEvent, uint64, uint64, time.Time) error); ok {
+		r1 = rf(event, members, messages, received)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_CountBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBounties'
-type Database_CountBounties_Call struct {
+// Database_ApplyRelayTribeEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyRelayTribeEvent'
+type Database_ApplyRelayTribeEvent_Call struct {
 	*mock.Call
 }
 
-// CountBounties is a helper method to define mock.On call
-func (_e *Database_Expecter) CountBounties() *Database_CountBounties_Call {
-	return &Database_CountBounties_Call{Call: _e.mock.On("CountBounties")}
+// ApplyRelayTribeEvent is a helper method to define mock.On call
+//   - event db.RelayEvent
+//   - members uint64
+//   - messages uint64
+//   - received time.Time
+func (_e *Database_Expecter) ApplyRelayTribeEvent(event interface{}, members interface{}, messages interface{}, received interface{}) *Database_ApplyRelayTribeEvent_Call {
+	return &Database_ApplyRelayTribeEvent_Call{Call: _e.mock.On("ApplyRelayTribeEvent", event, members, messages, received)}
 }
 
-func (_c *Database_CountBounties_Call) Run(run func()) *Database_CountBounties_Call {
+func (_c *Database_ApplyRelayTribeEvent_Call) Run(run func(event db.RelayEvent, members uint64, messages uint64, received time.Time)) *Database_ApplyRelayTribeEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(db.RelayEvent), args[1].(uint64), args[2].(uint64), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_CountBounties_Call) Return(_a0 uint64) *Database_CountBounties_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ApplyRelayTribeEvent_Call) Return(_a0 bool, _a1 error) *Database_ApplyRelayTribeEvent_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CountBounties_Call) RunAndReturn(run func() uint64) *Database_CountBounties_Call {
+func (_c *Database_ApplyRelayTribeEvent_Call) RunAndReturn(run func(db.RelayEvent, uint64, uint64, time.Time) (bool, error)) *Database_ApplyRelayTribeEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CountDevelopers provides a mock function with given fields:
-func (_m *Database) CountDevelopers() int64 {
-	ret := _m.Called()
+// AutocompleteFeatures provides a mock function with given fields: prefix, workspaceUuid, limit
+func (_m *Database) AutocompleteFeatures(prefix string, workspaceUuid string, limit int) ([]db.AutocompleteItem, error) {
+	ret := _m.Called(prefix, workspaceUuid, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountDevelopers")
+		panic("no return value specified for AutocompleteFeatures")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
+	var r0 []db.AutocompleteItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int) ([]db.AutocompleteItem, error)); ok {
+		return rf(prefix, workspaceUuid, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int) []db.AutocompleteItem); ok {
+		r0 = rf(prefix, workspaceUuid, limit)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AutocompleteItem)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(prefix, workspaceUuid, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_CountDevelopers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDevelopers'
-type Database_CountDevelopers_Call struct {
+// Database_AutocompleteFeatures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AutocompleteFeatures'
+type Database_AutocompleteFeatures_Call struct {
 	*mock.Call
 }
 
-// CountDevelopers is a helper method to define mock.On call
-func (_e *Database_Expecter) CountDevelopers() *Database_CountDevelopers_Call {
-	return &Database_CountDevelopers_Call{Call: _e.mock.On("CountDevelopers")}
+// AutocompleteFeatures is a helper method to define mock.On call
+//   - prefix string
+//   - workspaceUuid string
+//   - limit int
+func (_e *Database_Expecter) AutocompleteFeatures(prefix interface{}, workspaceUuid interface{}, limit interface{}) *Database_AutocompleteFeatures_Call {
+	return &Database_AutocompleteFeatures_Call{Call: _e.mock.On("AutocompleteFeatures", prefix, workspaceUuid, limit)}
 }
 
-func (_c *Database_CountDevelopers_Call) Run(run func()) *Database_CountDevelopers_Call {
+func (_c *Database_AutocompleteFeatures_Call) Run(run func(prefix string, workspaceUuid string, limit int)) *Database_AutocompleteFeatures_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(string), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Database_CountDevelopers_Call) Return(_a0 int64) *Database_CountDevelopers_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_AutocompleteFeatures_Call) Return(_a0 []db.AutocompleteItem, _a1 error) *Database_AutocompleteFeatures_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CountDevelopers_Call) RunAndReturn(run func() int64) *Database_CountDevelopers_Call {
+func (_c *Database_AutocompleteFeatures_Call) RunAndReturn(run func(string, string, int) ([]db.AutocompleteItem, error)) *Database_AutocompleteFeatures_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateChannel provides a mock function with given fields: c
-func (_m *Database) CreateChannel(c db.Channel) (db.Channel, error) {
-	ret := _m.Called(c)
+// AutocompletePeople provides a mock function with given fields: prefix, limit
+func (_m *Database) AutocompletePeople(prefix string, limit int) ([]db.AutocompleteItem, error) {
+	ret := _m.Called(prefix, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateChannel")
+		panic("no return value specified for AutocompletePeople")
 	}
 
-	var r0 db.Channel
+	var r0 []db.AutocompleteItem
 	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Channel) (db.Channel, error)); ok {
-		return rf(c)
+	if rf, ok := ret.Get(0).(func(string, int) ([]db.AutocompleteItem, error)); ok {
+		return rf(prefix, limit)
 	}
-	if rf, ok := ret.Get(0).(func(db.Channel) db.Channel); ok {
-		r0 = rf(c)
+	if rf, ok := ret.Get(0).(func(string, int) []db.AutocompleteItem); ok {
+		r0 = rf(prefix, limit)
 	} else {
-		r0 = ret.Get(0).(db.Channel)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AutocompleteItem)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Channel) error); ok {
-		r1 = rf(c)
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(prefix, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -617,20 +716,1279 @@ func (_m *Database) CreateChannel(c db.Channel) (db.Channel, error) {
 	return r0, r1
 }
 
-// Database_CreateChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChannel'
-type Database_CreateChannel_Call struct {
+// Database_AutocompletePeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AutocompletePeople'
+type Database_AutocompletePeople_Call struct {
 	*mock.Call
 }
 
-// CreateChannel is a helper method to define mock.On call
-//   - c db.Channel
-func (_e *Database_Expecter) CreateChannel(c interface{}) *Database_CreateChannel_Call {
-	return &Database_CreateChannel_Call{Call: _e.mock.On("CreateChannel", c)}
+// AutocompletePeople is a helper method to define mock.On call
+//   - prefix string
+//   - limit int
+func (_e *Database_Expecter) AutocompletePeople(prefix interface{}, limit interface{}) *Database_AutocompletePeople_Call {
+	return &Database_AutocompletePeople_Call{Call: _e.mock.On("AutocompletePeople", prefix, limit)}
 }
 
-func (_c *Database_CreateChannel_Call) Run(run func(c db.Channel)) *Database_CreateChannel_Call {
+func (_c *Database_AutocompletePeople_Call) Run(run func(prefix string, limit int)) *Database_AutocompletePeople_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Channel))
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_AutocompletePeople_Call) Return(_a0 []db.AutocompleteItem, _a1 error) *Database_AutocompletePeople_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_AutocompletePeople_Call) RunAndReturn(run func(string, int) ([]db.AutocompleteItem, error)) *Database_AutocompletePeople_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AutocompleteTribes provides a mock function with given fields: prefix, limit
+func (_m *Database) AutocompleteTribes(prefix string, limit int) ([]db.AutocompleteItem, error) {
+	ret := _m.Called(prefix, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AutocompleteTribes")
+	}
+
+	var r0 []db.AutocompleteItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]db.AutocompleteItem, error)); ok {
+		return rf(prefix, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []db.AutocompleteItem); ok {
+		r0 = rf(prefix, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AutocompleteItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(prefix, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_AutocompleteTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AutocompleteTribes'
+type Database_AutocompleteTribes_Call struct {
+	*mock.Call
+}
+
+// AutocompleteTribes is a helper method to define mock.On call
+//   - prefix string
+//   - limit int
+func (_e *Database_Expecter) AutocompleteTribes(prefix interface{}, limit interface{}) *Database_AutocompleteTribes_Call {
+	return &Database_AutocompleteTribes_Call{Call: _e.mock.On("AutocompleteTribes", prefix, limit)}
+}
+
+func (_c *Database_AutocompleteTribes_Call) Run(run func(prefix string, limit int)) *Database_AutocompleteTribes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Database_AutocompleteTribes_Call) Return(_a0 []db.AutocompleteItem, _a1 error) *Database_AutocompleteTribes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_AutocompleteTribes_Call) RunAndReturn(run func(string, int) ([]db.AutocompleteItem, error)) *Database_AutocompleteTribes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AverageCompletedTime provides a mock function with given fields: r, workspace
+func (_m *Database) AverageCompletedTime(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AverageCompletedTime")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
+// Database_AverageCompletedTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AverageCompletedTime'
+type Database_AverageCompletedTime_Call struct {
+	*mock.Call
+}
+
+// AverageCompletedTime is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) AverageCompletedTime(r interface{}, workspace interface{}) *Database_AverageCompletedTime_Call {
+	return &Database_AverageCompletedTime_Call{Call: _e.mock.On("AverageCompletedTime", r, workspace)}
+}
+
+func (_c *Database_AverageCompletedTime_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_AverageCompletedTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_AverageCompletedTime_Call) Return(_a0 uint) *Database_AverageCompletedTime_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_AverageCompletedTime_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_AverageCompletedTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AveragePaidTime provides a mock function with given fields: r, workspace
+func (_m *Database) AveragePaidTime(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AveragePaidTime")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
+// Database_AveragePaidTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AveragePaidTime'
+type Database_AveragePaidTime_Call struct {
+	*mock.Call
+}
+
+// AveragePaidTime is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) AveragePaidTime(r interface{}, workspace interface{}) *Database_AveragePaidTime_Call {
+	return &Database_AveragePaidTime_Call{Call: _e.mock.On("AveragePaidTime", r, workspace)}
+}
+
+func (_c *Database_AveragePaidTime_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_AveragePaidTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_AveragePaidTime_Call) Return(_a0 uint) *Database_AveragePaidTime_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_AveragePaidTime_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_AveragePaidTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BountiesPaidPercentage provides a mock function with given fields: r, workspace
+func (_m *Database) BountiesPaidPercentage(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BountiesPaidPercentage")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
+// Database_BountiesPaidPercentage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BountiesPaidPercentage'
+type Database_BountiesPaidPercentage_Call struct {
+	*mock.Call
+}
+
+// BountiesPaidPercentage is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) BountiesPaidPercentage(r interface{}, workspace interface{}) *Database_BountiesPaidPercentage_Call {
+	return &Database_BountiesPaidPercentage_Call{Call: _e.mock.On("BountiesPaidPercentage", r, workspace)}
+}
+
+func (_c *Database_BountiesPaidPercentage_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_BountiesPaidPercentage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_BountiesPaidPercentage_Call) Return(_a0 uint) *Database_BountiesPaidPercentage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_BountiesPaidPercentage_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_BountiesPaidPercentage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeWorkspaceDeleteStatus provides a mock function with given fields: workspace_uuid, status
+func (_m *Database) ChangeWorkspaceDeleteStatus(workspace_uuid string, status bool) db.Workspace {
+	ret := _m.Called(workspace_uuid, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeWorkspaceDeleteStatus")
+	}
+
+	var r0 db.Workspace
+	if rf, ok := ret.Get(0).(func(string, bool) db.Workspace); ok {
+		r0 = rf(workspace_uuid, status)
+	} else {
+		r0 = ret.Get(0).(db.Workspace)
+	}
+
+	return r0
+}
+
+// Database_ChangeWorkspaceDeleteStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeWorkspaceDeleteStatus'
+type Database_ChangeWorkspaceDeleteStatus_Call struct {
+	*mock.Call
+}
+
+// ChangeWorkspaceDeleteStatus is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - status bool
+func (_e *Database_Expecter) ChangeWorkspaceDeleteStatus(workspace_uuid interface{}, status interface{}) *Database_ChangeWorkspaceDeleteStatus_Call {
+	return &Database_ChangeWorkspaceDeleteStatus_Call{Call: _e.mock.On("ChangeWorkspaceDeleteStatus", workspace_uuid, status)}
+}
+
+func (_c *Database_ChangeWorkspaceDeleteStatus_Call) Run(run func(workspace_uuid string, status bool)) *Database_ChangeWorkspaceDeleteStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *Database_ChangeWorkspaceDeleteStatus_Call) Return(_a0 db.Workspace) *Database_ChangeWorkspaceDeleteStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_ChangeWorkspaceDeleteStatus_Call) RunAndReturn(run func(string, bool) db.Workspace) *Database_ChangeWorkspaceDeleteStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckBudgetLedgerConsistency provides a mock function with given fields:
+func (_m *Database) CheckBudgetLedgerConsistency() []db.BudgetLedgerInconsistency {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckBudgetLedgerConsistency")
+	}
+
+	var r0 []db.BudgetLedgerInconsistency
+	if rf, ok := ret.Get(0).(func() []db.BudgetLedgerInconsistency); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BudgetLedgerInconsistency)
+		}
+	}
+
+	return r0
+}
+
+// Database_CheckBudgetLedgerConsistency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckBudgetLedgerConsistency'
+type Database_CheckBudgetLedgerConsistency_Call struct {
+	*mock.Call
+}
+
+// CheckBudgetLedgerConsistency is a helper method to define mock.On call
+func (_e *Database_Expecter) CheckBudgetLedgerConsistency() *Database_CheckBudgetLedgerConsistency_Call {
+	return &Database_CheckBudgetLedgerConsistency_Call{Call: _e.mock.On("CheckBudgetLedgerConsistency")}
+}
+
+func (_c *Database_CheckBudgetLedgerConsistency_Call) Run(run func()) *Database_CheckBudgetLedgerConsistency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_CheckBudgetLedgerConsistency_Call) Return(_a0 []db.BudgetLedgerInconsistency) *Database_CheckBudgetLedgerConsistency_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CheckBudgetLedgerConsistency_Call) RunAndReturn(run func() []db.BudgetLedgerInconsistency) *Database_CheckBudgetLedgerConsistency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckFeatureBountyCounts provides a mock function with given fields:
+func (_m *Database) CheckFeatureBountyCounts() []db.FeatureCountDrift {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckFeatureBountyCounts")
+	}
+
+	var r0 []db.FeatureCountDrift
+	if rf, ok := ret.Get(0).(func() []db.FeatureCountDrift); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeatureCountDrift)
+		}
+	}
+
+	return r0
+}
+
+// Database_CheckFeatureBountyCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckFeatureBountyCounts'
+type Database_CheckFeatureBountyCounts_Call struct {
+	*mock.Call
+}
+
+// CheckFeatureBountyCounts is a helper method to define mock.On call
+func (_e *Database_Expecter) CheckFeatureBountyCounts() *Database_CheckFeatureBountyCounts_Call {
+	return &Database_CheckFeatureBountyCounts_Call{Call: _e.mock.On("CheckFeatureBountyCounts")}
+}
+
+func (_c *Database_CheckFeatureBountyCounts_Call) Run(run func()) *Database_CheckFeatureBountyCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_CheckFeatureBountyCounts_Call) Return(_a0 []db.FeatureCountDrift) *Database_CheckFeatureBountyCounts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CheckFeatureBountyCounts_Call) RunAndReturn(run func() []db.FeatureCountDrift) *Database_CheckFeatureBountyCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimBudgetRefund provides a mock function with given fields: id, approvedBy
+func (_m *Database) ClaimBudgetRefund(id uint, approvedBy string) error {
+	ret := _m.Called(id, approvedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimBudgetRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(id, approvedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_ClaimBudgetRefund_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimBudgetRefund'
+type Database_ClaimBudgetRefund_Call struct {
+	*mock.Call
+}
+
+// ClaimBudgetRefund is a helper method to define mock.On call
+//   - id uint
+//   - approvedBy string
+func (_e *Database_Expecter) ClaimBudgetRefund(id interface{}, approvedBy interface{}) *Database_ClaimBudgetRefund_Call {
+	return &Database_ClaimBudgetRefund_Call{Call: _e.mock.On("ClaimBudgetRefund", id, approvedBy)}
+}
+
+func (_c *Database_ClaimBudgetRefund_Call) Run(run func(id uint, approvedBy string)) *Database_ClaimBudgetRefund_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_ClaimBudgetRefund_Call) Return(_a0 error) *Database_ClaimBudgetRefund_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_ClaimBudgetRefund_Call) RunAndReturn(run func(uint, string) error) *Database_ClaimBudgetRefund_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CloneWorkspace provides a mock function with given fields: sourceUuid, ownerPubkey, request
+func (_m *Database) CloneWorkspace(sourceUuid string, ownerPubkey string, request db.WorkspaceCloneRequest) (db.WorkspaceCloneResult, error) {
+	ret := _m.Called(sourceUuid, ownerPubkey, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneWorkspace")
+	}
+
+	var r0 db.WorkspaceCloneResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, db.WorkspaceCloneRequest) (db.WorkspaceCloneResult, error)); ok {
+		return rf(sourceUuid, ownerPubkey, request)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, db.WorkspaceCloneRequest) db.WorkspaceCloneResult); ok {
+		r0 = rf(sourceUuid, ownerPubkey, request)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceCloneResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, db.WorkspaceCloneRequest) error); ok {
+		r1 = rf(sourceUuid, ownerPubkey, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CloneWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloneWorkspace'
+type Database_CloneWorkspace_Call struct {
+	*mock.Call
+}
+
+// CloneWorkspace is a helper method to define mock.On call
+//   - sourceUuid string
+//   - ownerPubkey string
+//   - request db.WorkspaceCloneRequest
+func (_e *Database_Expecter) CloneWorkspace(sourceUuid interface{}, ownerPubkey interface{}, request interface{}) *Database_CloneWorkspace_Call {
+	return &Database_CloneWorkspace_Call{Call: _e.mock.On("CloneWorkspace", sourceUuid, ownerPubkey, request)}
+}
+
+func (_c *Database_CloneWorkspace_Call) Run(run func(sourceUuid string, ownerPubkey string, request db.WorkspaceCloneRequest)) *Database_CloneWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(db.WorkspaceCloneRequest))
+	})
+	return _c
+}
+
+func (_c *Database_CloneWorkspace_Call) Return(_a0 db.WorkspaceCloneResult, _a1 error) *Database_CloneWorkspace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CloneWorkspace_Call) RunAndReturn(run func(string, string, db.WorkspaceCloneRequest) (db.WorkspaceCloneResult, error)) *Database_CloneWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CloseBountyAuction provides a mock function with given fields: auction, bidId
+func (_m *Database) CloseBountyAuction(auction db.BountyAuction, bidId uint) (db.NewBounty, error) {
+	ret := _m.Called(auction, bidId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseBountyAuction")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyAuction, uint) (db.NewBounty, error)); ok {
+		return rf(auction, bidId)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyAuction, uint) db.NewBounty); ok {
+		r0 = rf(auction, bidId)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BountyAuction, uint) error); ok {
+		r1 = rf(auction, bidId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CloseBountyAuction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseBountyAuction'
+type Database_CloseBountyAuction_Call struct {
+	*mock.Call
+}
+
+// CloseBountyAuction is a helper method to define mock.On call
+//   - auction db.BountyAuction
+//   - bidId uint
+func (_e *Database_Expecter) CloseBountyAuction(auction interface{}, bidId interface{}) *Database_CloseBountyAuction_Call {
+	return &Database_CloseBountyAuction_Call{Call: _e.mock.On("CloseBountyAuction", auction, bidId)}
+}
+
+func (_c *Database_CloseBountyAuction_Call) Run(run func(auction db.BountyAuction, bidId uint)) *Database_CloseBountyAuction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BountyAuction), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_CloseBountyAuction_Call) Return(_a0 db.NewBounty, _a1 error) *Database_CloseBountyAuction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CloseBountyAuction_Call) RunAndReturn(run func(db.BountyAuction, uint) (db.NewBounty, error)) *Database_CloseBountyAuction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteBudgetRefund provides a mock function with given fields: refund
+func (_m *Database) CompleteBudgetRefund(refund db.BudgetRefund) error {
+	ret := _m.Called(refund)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteBudgetRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.BudgetRefund) error); ok {
+		r0 = rf(refund)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CompleteBudgetRefund_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteBudgetRefund'
+type Database_CompleteBudgetRefund_Call struct {
+	*mock.Call
+}
+
+// CompleteBudgetRefund is a helper method to define mock.On call
+//   - refund db.BudgetRefund
+func (_e *Database_Expecter) CompleteBudgetRefund(refund interface{}) *Database_CompleteBudgetRefund_Call {
+	return &Database_CompleteBudgetRefund_Call{Call: _e.mock.On("CompleteBudgetRefund", refund)}
+}
+
+func (_c *Database_CompleteBudgetRefund_Call) Run(run func(refund db.BudgetRefund)) *Database_CompleteBudgetRefund_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BudgetRefund))
+	})
+	return _c
+}
+
+func (_c *Database_CompleteBudgetRefund_Call) Return(_a0 error) *Database_CompleteBudgetRefund_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CompleteBudgetRefund_Call) RunAndReturn(run func(db.BudgetRefund) error) *Database_CompleteBudgetRefund_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteSlackLink provides a mock function with given fields: code, pubkey, now
+func (_m *Database) CompleteSlackLink(code string, pubkey string, now time.Time) (db.SlackUserLink, error) {
+	ret := _m.Called(code, pubkey, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteSlackLink")
+	}
+
+	var r0 db.SlackUserLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) (db.SlackUserLink, error)); ok {
+		return rf(code, pubkey, now)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) db.SlackUserLink); ok {
+		r0 = rf(code, pubkey, now)
+	} else {
+		r0 = ret.Get(0).(db.SlackUserLink)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, time.Time) error); ok {
+		r1 = rf(code, pubkey, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CompleteSlackLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteSlackLink'
+type Database_CompleteSlackLink_Call struct {
+	*mock.Call
+}
+
+// CompleteSlackLink is a helper method to define mock.On call
+//   - code string
+//   - pubkey string
+//   - now time.Time
+func (_e *Database_Expecter) CompleteSlackLink(code interface{}, pubkey interface{}, now interface{}) *Database_CompleteSlackLink_Call {
+	return &Database_CompleteSlackLink_Call{Call: _e.mock.On("CompleteSlackLink", code, pubkey, now)}
+}
+
+func (_c *Database_CompleteSlackLink_Call) Run(run func(code string, pubkey string, now time.Time)) *Database_CompleteSlackLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_CompleteSlackLink_Call) Return(_a0 db.SlackUserLink, _a1 error) *Database_CompleteSlackLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CompleteSlackLink_Call) RunAndReturn(run func(string, string, time.Time) (db.SlackUserLink, error)) *Database_CompleteSlackLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmSuperAdminChange provides a mock function with given fields: code, confirmedBy
+func (_m *Database) ConfirmSuperAdminChange(code string, confirmedBy string) (db.SuperAdminChange, error) {
+	ret := _m.Called(code, confirmedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmSuperAdminChange")
+	}
+
+	var r0 db.SuperAdminChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.SuperAdminChange, error)); ok {
+		return rf(code, confirmedBy)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.SuperAdminChange); ok {
+		r0 = rf(code, confirmedBy)
+	} else {
+		r0 = ret.Get(0).(db.SuperAdminChange)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(code, confirmedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_ConfirmSuperAdminChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmSuperAdminChange'
+type Database_ConfirmSuperAdminChange_Call struct {
+	*mock.Call
+}
+
+// ConfirmSuperAdminChange is a helper method to define mock.On call
+//   - code string
+//   - confirmedBy string
+func (_e *Database_Expecter) ConfirmSuperAdminChange(code interface{}, confirmedBy interface{}) *Database_ConfirmSuperAdminChange_Call {
+	return &Database_ConfirmSuperAdminChange_Call{Call: _e.mock.On("ConfirmSuperAdminChange", code, confirmedBy)}
+}
+
+func (_c *Database_ConfirmSuperAdminChange_Call) Run(run func(code string, confirmedBy string)) *Database_ConfirmSuperAdminChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_ConfirmSuperAdminChange_Call) Return(_a0 db.SuperAdminChange, _a1 error) *Database_ConfirmSuperAdminChange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_ConfirmSuperAdminChange_Call) RunAndReturn(run func(string, string) (db.SuperAdminChange, error)) *Database_ConfirmSuperAdminChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountBounties provides a mock function with given fields:
+func (_m *Database) CountBounties() uint64 {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountBounties")
+	}
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// Database_CountBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBounties'
+type Database_CountBounties_Call struct {
+	*mock.Call
+}
+
+// CountBounties is a helper method to define mock.On call
+func (_e *Database_Expecter) CountBounties() *Database_CountBounties_Call {
+	return &Database_CountBounties_Call{Call: _e.mock.On("CountBounties")}
+}
+
+func (_c *Database_CountBounties_Call) Run(run func()) *Database_CountBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_CountBounties_Call) Return(_a0 uint64) *Database_CountBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CountBounties_Call) RunAndReturn(run func() uint64) *Database_CountBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountDevelopers provides a mock function with given fields:
+func (_m *Database) CountDevelopers() int64 {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDevelopers")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_CountDevelopers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDevelopers'
+type Database_CountDevelopers_Call struct {
+	*mock.Call
+}
+
+// CountDevelopers is a helper method to define mock.On call
+func (_e *Database_Expecter) CountDevelopers() *Database_CountDevelopers_Call {
+	return &Database_CountDevelopers_Call{Call: _e.mock.On("CountDevelopers")}
+}
+
+func (_c *Database_CountDevelopers_Call) Run(run func()) *Database_CountDevelopers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_CountDevelopers_Call) Return(_a0 int64) *Database_CountDevelopers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CountDevelopers_Call) RunAndReturn(run func() int64) *Database_CountDevelopers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateArtifact provides a mock function with given fields: artifact
+func (_m *Database) CreateArtifact(artifact db.Artifact) (db.Artifact, error) {
+	ret := _m.Called(artifact)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateArtifact")
+	}
+
+	var r0 db.Artifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Artifact) (db.Artifact, error)); ok {
+		return rf(artifact)
+	}
+	if rf, ok := ret.Get(0).(func(db.Artifact) db.Artifact); ok {
+		r0 = rf(artifact)
+	} else {
+		r0 = ret.Get(0).(db.Artifact)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Artifact) error); ok {
+		r1 = rf(artifact)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateArtifact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateArtifact'
+type Database_CreateArtifact_Call struct {
+	*mock.Call
+}
+
+// CreateArtifact is a helper method to define mock.On call
+//   - artifact db.Artifact
+func (_e *Database_Expecter) CreateArtifact(artifact interface{}) *Database_CreateArtifact_Call {
+	return &Database_CreateArtifact_Call{Call: _e.mock.On("CreateArtifact", artifact)}
+}
+
+func (_c *Database_CreateArtifact_Call) Run(run func(artifact db.Artifact)) *Database_CreateArtifact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Artifact))
+	})
+	return _c
+}
+
+func (_c *Database_CreateArtifact_Call) Return(_a0 db.Artifact, _a1 error) *Database_CreateArtifact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateArtifact_Call) RunAndReturn(run func(db.Artifact) (db.Artifact, error)) *Database_CreateArtifact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBadgeIssuance provides a mock function with given fields: issuance
+func (_m *Database) CreateBadgeIssuance(issuance db.BadgeIssuance) (db.BadgeIssuance, error) {
+	ret := _m.Called(issuance)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBadgeIssuance")
+	}
+
+	var r0 db.BadgeIssuance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BadgeIssuance) (db.BadgeIssuance, error)); ok {
+		return rf(issuance)
+	}
+	if rf, ok := ret.Get(0).(func(db.BadgeIssuance) db.BadgeIssuance); ok {
+		r0 = rf(issuance)
+	} else {
+		r0 = ret.Get(0).(db.BadgeIssuance)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BadgeIssuance) error); ok {
+		r1 = rf(issuance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateBadgeIssuance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBadgeIssuance'
+type Database_CreateBadgeIssuance_Call struct {
+	*mock.Call
+}
+
+// CreateBadgeIssuance is a helper method to define mock.On call
+//   - issuance db.BadgeIssuance
+func (_e *Database_Expecter) CreateBadgeIssuance(issuance interface{}) *Database_CreateBadgeIssuance_Call {
+	return &Database_CreateBadgeIssuance_Call{Call: _e.mock.On("CreateBadgeIssuance", issuance)}
+}
+
+func (_c *Database_CreateBadgeIssuance_Call) Run(run func(issuance db.BadgeIssuance)) *Database_CreateBadgeIssuance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BadgeIssuance))
+	})
+	return _c
+}
+
+func (_c *Database_CreateBadgeIssuance_Call) Return(_a0 db.BadgeIssuance, _a1 error) *Database_CreateBadgeIssuance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateBadgeIssuance_Call) RunAndReturn(run func(db.BadgeIssuance) (db.BadgeIssuance, error)) *Database_CreateBadgeIssuance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBountyApplication provides a mock function with given fields: application
+func (_m *Database) CreateBountyApplication(application db.BountyApplication) (db.BountyApplication, error) {
+	ret := _m.Called(application)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBountyApplication")
+	}
+
+	var r0 db.BountyApplication
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyApplication) (db.BountyApplication, error)); ok {
+		return rf(application)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyApplication) db.BountyApplication); ok {
+		r0 = rf(application)
+	} else {
+		r0 = ret.Get(0).(db.BountyApplication)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BountyApplication) error); ok {
+		r1 = rf(application)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateBountyApplication_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBountyApplication'
+type Database_CreateBountyApplication_Call struct {
+	*mock.Call
+}
+
+// CreateBountyApplication is a helper method to define mock.On call
+//   - application db.BountyApplication
+func (_e *Database_Expecter) CreateBountyApplication(application interface{}) *Database_CreateBountyApplication_Call {
+	return &Database_CreateBountyApplication_Call{Call: _e.mock.On("CreateBountyApplication", application)}
+}
+
+func (_c *Database_CreateBountyApplication_Call) Run(run func(application db.BountyApplication)) *Database_CreateBountyApplication_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BountyApplication))
+	})
+	return _c
+}
+
+func (_c *Database_CreateBountyApplication_Call) Return(_a0 db.BountyApplication, _a1 error) *Database_CreateBountyApplication_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateBountyApplication_Call) RunAndReturn(run func(db.BountyApplication) (db.BountyApplication, error)) *Database_CreateBountyApplication_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBountyAuction provides a mock function with given fields: auction
+func (_m *Database) CreateBountyAuction(auction db.BountyAuction) (db.BountyAuction, error) {
+	ret := _m.Called(auction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBountyAuction")
+	}
+
+	var r0 db.BountyAuction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyAuction) (db.BountyAuction, error)); ok {
+		return rf(auction)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyAuction) db.BountyAuction); ok {
+		r0 = rf(auction)
+	} else {
+		r0 = ret.Get(0).(db.BountyAuction)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BountyAuction) error); ok {
+		r1 = rf(auction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateBountyAuction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBountyAuction'
+type Database_CreateBountyAuction_Call struct {
+	*mock.Call
+}
+
+// CreateBountyAuction is a helper method to define mock.On call
+//   - auction db.BountyAuction
+func (_e *Database_Expecter) CreateBountyAuction(auction interface{}) *Database_CreateBountyAuction_Call {
+	return &Database_CreateBountyAuction_Call{Call: _e.mock.On("CreateBountyAuction", auction)}
+}
+
+func (_c *Database_CreateBountyAuction_Call) Run(run func(auction db.BountyAuction)) *Database_CreateBountyAuction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BountyAuction))
+	})
+	return _c
+}
+
+func (_c *Database_CreateBountyAuction_Call) Return(_a0 db.BountyAuction, _a1 error) *Database_CreateBountyAuction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateBountyAuction_Call) RunAndReturn(run func(db.BountyAuction) (db.BountyAuction, error)) *Database_CreateBountyAuction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBountyComment provides a mock function with given fields: comment
+func (_m *Database) CreateBountyComment(comment db.BountyComment) (db.BountyComment, error) {
+	ret := _m.Called(comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBountyComment")
+	}
+
+	var r0 db.BountyComment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyComment) (db.BountyComment, error)); ok {
+		return rf(comment)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyComment) db.BountyComment); ok {
+		r0 = rf(comment)
+	} else {
+		r0 = ret.Get(0).(db.BountyComment)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BountyComment) error); ok {
+		r1 = rf(comment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateBountyComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBountyComment'
+type Database_CreateBountyComment_Call struct {
+	*mock.Call
+}
+
+// CreateBountyComment is a helper method to define mock.On call
+//   - comment db.BountyComment
+func (_e *Database_Expecter) CreateBountyComment(comment interface{}) *Database_CreateBountyComment_Call {
+	return &Database_CreateBountyComment_Call{Call: _e.mock.On("CreateBountyComment", comment)}
+}
+
+func (_c *Database_CreateBountyComment_Call) Run(run func(comment db.BountyComment)) *Database_CreateBountyComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BountyComment))
+	})
+	return _c
+}
+
+func (_c *Database_CreateBountyComment_Call) Return(_a0 db.BountyComment, _a1 error) *Database_CreateBountyComment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateBountyComment_Call) RunAndReturn(run func(db.BountyComment) (db.BountyComment, error)) *Database_CreateBountyComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBountyHoursLog provides a mock function with given fields: log
+func (_m *Database) CreateBountyHoursLog(log db.BountyHoursLog) (db.BountyHoursLog, error) {
+	ret := _m.Called(log)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBountyHoursLog")
+	}
+
+	var r0 db.BountyHoursLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyHoursLog) (db.BountyHoursLog, error)); ok {
+		return rf(log)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyHoursLog) db.BountyHoursLog); ok {
+		r0 = rf(log)
+	} else {
+		r0 = ret.Get(0).(db.BountyHoursLog)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BountyHoursLog) error); ok {
+		r1 = rf(log)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateBountyHoursLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBountyHoursLog'
+type Database_CreateBountyHoursLog_Call struct {
+	*mock.Call
+}
+
+// CreateBountyHoursLog is a helper method to define mock.On call
+//   - log db.BountyHoursLog
+func (_e *Database_Expecter) CreateBountyHoursLog(log interface{}) *Database_CreateBountyHoursLog_Call {
+	return &Database_CreateBountyHoursLog_Call{Call: _e.mock.On("CreateBountyHoursLog", log)}
+}
+
+func (_c *Database_CreateBountyHoursLog_Call) Run(run func(log db.BountyHoursLog)) *Database_CreateBountyHoursLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BountyHoursLog))
+	})
+	return _c
+}
+
+func (_c *Database_CreateBountyHoursLog_Call) Return(_a0 db.BountyHoursLog, _a1 error) *Database_CreateBountyHoursLog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateBountyHoursLog_Call) RunAndReturn(run func(db.BountyHoursLog) (db.BountyHoursLog, error)) *Database_CreateBountyHoursLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBudgetRefund provides a mock function with given fields: refund
+func (_m *Database) CreateBudgetRefund(refund db.BudgetRefund) (db.BudgetRefund, error) {
+	ret := _m.Called(refund)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBudgetRefund")
+	}
+
+	var r0 db.BudgetRefund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BudgetRefund) (db.BudgetRefund, error)); ok {
+		return rf(refund)
+	}
+	if rf, ok := ret.Get(0).(func(db.BudgetRefund) db.BudgetRefund); ok {
+		r0 = rf(refund)
+	} else {
+		r0 = ret.Get(0).(db.BudgetRefund)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.BudgetRefund) error); ok {
+		r1 = rf(refund)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateBudgetRefund_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBudgetRefund'
+type Database_CreateBudgetRefund_Call struct {
+	*mock.Call
+}
+
+// CreateBudgetRefund is a helper method to define mock.On call
+//   - refund db.BudgetRefund
+func (_e *Database_Expecter) CreateBudgetRefund(refund interface{}) *Database_CreateBudgetRefund_Call {
+	return &Database_CreateBudgetRefund_Call{Call: _e.mock.On("CreateBudgetRefund", refund)}
+}
+
+func (_c *Database_CreateBudgetRefund_Call) Run(run func(refund db.BudgetRefund)) *Database_CreateBudgetRefund_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.BudgetRefund))
+	})
+	return _c
+}
+
+func (_c *Database_CreateBudgetRefund_Call) Return(_a0 db.BudgetRefund, _a1 error) *Database_CreateBudgetRefund_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateBudgetRefund_Call) RunAndReturn(run func(db.BudgetRefund) (db.BudgetRefund, error)) *Database_CreateBudgetRefund_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCalendarToken provides a mock function with given fields: pubkey
+func (_m *Database) CreateCalendarToken(pubkey string) (db.CalendarToken, error) {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCalendarToken")
+	}
+
+	var r0 db.CalendarToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.CalendarToken, error)); ok {
+		return rf(pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.CalendarToken); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Get(0).(db.CalendarToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateCalendarToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCalendarToken'
+type Database_CreateCalendarToken_Call struct {
+	*mock.Call
+}
+
+// CreateCalendarToken is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) CreateCalendarToken(pubkey interface{}) *Database_CreateCalendarToken_Call {
+	return &Database_CreateCalendarToken_Call{Call: _e.mock.On("CreateCalendarToken", pubkey)}
+}
+
+func (_c *Database_CreateCalendarToken_Call) Run(run func(pubkey string)) *Database_CreateCalendarToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_CreateCalendarToken_Call) Return(_a0 db.CalendarToken, _a1 error) *Database_CreateCalendarToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateCalendarToken_Call) RunAndReturn(run func(string) (db.CalendarToken, error)) *Database_CreateCalendarToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateChannel provides a mock function with given fields: c
+func (_m *Database) CreateChannel(c db.Channel) (db.Channel, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateChannel")
+	}
+
+	var r0 db.Channel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Channel) (db.Channel, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(db.Channel) db.Channel); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Get(0).(db.Channel)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Channel) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChannel'
+type Database_CreateChannel_Call struct {
+	*mock.Call
+}
+
+// CreateChannel is a helper method to define mock.On call
+//   - c db.Channel
+func (_e *Database_Expecter) CreateChannel(c interface{}) *Database_CreateChannel_Call {
+	return &Database_CreateChannel_Call{Call: _e.mock.On("CreateChannel", c)}
+}
+
+func (_c *Database_CreateChannel_Call) Run(run func(c db.Channel)) *Database_CreateChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Channel))
 	})
 	return _c
 }
@@ -640,34 +1998,9194 @@ func (_c *Database_CreateChannel_Call) Return(_a0 db.Channel, _a1 error) *Databa
 	return _c
 }
 
-func (_c *Database_CreateChannel_Call) RunAndReturn(run func(db.Channel) (db.Channel, error)) *Database_CreateChannel_Call {
+func (_c *Database_CreateChannel_Call) RunAndReturn(run func(db.Channel) (db.Channel, error)) *Database_CreateChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateChat provides a mock function with given fields: chat
+func (_m *Database) CreateChat(chat db.Chat) (db.Chat, error) {
+	ret := _m.Called(chat)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateChat")
+	}
+
+	var r0 db.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Chat) (db.Chat, error)); ok {
+		return rf(chat)
+	}
+	if rf, ok := ret.Get(0).(func(db.Chat) db.Chat); ok {
+		r0 = rf(chat)
+	} else {
+		r0 = ret.Get(0).(db.Chat)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Chat) error); ok {
+		r1 = rf(chat)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChat'
+type Database_CreateChat_Call struct {
+	*mock.Call
+}
+
+// CreateChat is a helper method to define mock.On call
+//   - chat db.Chat
+func (_e *Database_Expecter) CreateChat(chat interface{}) *Database_CreateChat_Call {
+	return &Database_CreateChat_Call{Call: _e.mock.On("CreateChat", chat)}
+}
+
+func (_c *Database_CreateChat_Call) Run(run func(chat db.Chat)) *Database_CreateChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Chat))
+	})
+	return _c
+}
+
+func (_c *Database_CreateChat_Call) Return(_a0 db.Chat, _a1 error) *Database_CreateChat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateChat_Call) RunAndReturn(run func(db.Chat) (db.Chat, error)) *Database_CreateChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateChatMessage provides a mock function with given fields: message
+func (_m *Database) CreateChatMessage(message db.ChatMessage) (db.ChatMessage, error) {
+	ret := _m.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateChatMessage")
+	}
+
+	var r0 db.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.ChatMessage) (db.ChatMessage, error)); ok {
+		return rf(message)
+	}
+	if rf, ok := ret.Get(0).(func(db.ChatMessage) db.ChatMessage); ok {
+		r0 = rf(message)
+	} else {
+		r0 = ret.Get(0).(db.ChatMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.ChatMessage) error); ok {
+		r1 = rf(message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateChatMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChatMessage'
+type Database_CreateChatMessage_Call struct {
+	*mock.Call
+}
+
+// CreateChatMessage is a helper method to define mock.On call
+//   - message db.ChatMessage
+func (_e *Database_Expecter) CreateChatMessage(message interface{}) *Database_CreateChatMessage_Call {
+	return &Database_CreateChatMessage_Call{Call: _e.mock.On("CreateChatMessage", message)}
+}
+
+func (_c *Database_CreateChatMessage_Call) Run(run func(message db.ChatMessage)) *Database_CreateChatMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *Database_CreateChatMessage_Call) Return(_a0 db.ChatMessage, _a1 error) *Database_CreateChatMessage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateChatMessage_Call) RunAndReturn(run func(db.ChatMessage) (db.ChatMessage, error)) *Database_CreateChatMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateConnectionCode provides a mock function with given fields: c
+func (_m *Database) CreateConnectionCode(c []db.ConnectionCodes) ([]db.ConnectionCodes, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateConnectionCode")
+	}
+
+	var r0 []db.ConnectionCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]db.ConnectionCodes) ([]db.ConnectionCodes, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func([]db.ConnectionCodes) []db.ConnectionCodes); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ConnectionCodes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]db.ConnectionCodes) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateConnectionCode'
+type Database_CreateConnectionCode_Call struct {
+	*mock.Call
+}
+
+// CreateConnectionCode is a helper method to define mock.On call
+//   - c []db.ConnectionCodes
+func (_e *Database_Expecter) CreateConnectionCode(c interface{}) *Database_CreateConnectionCode_Call {
+	return &Database_CreateConnectionCode_Call{Call: _e.mock.On("CreateConnectionCode", c)}
+}
+
+func (_c *Database_CreateConnectionCode_Call) Run(run func(c []db.ConnectionCodes)) *Database_CreateConnectionCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]db.ConnectionCodes))
+	})
+	return _c
+}
+
+func (_c *Database_CreateConnectionCode_Call) Return(_a0 []db.ConnectionCodes, _a1 error) *Database_CreateConnectionCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateConnectionCode_Call) RunAndReturn(run func([]db.ConnectionCodes) ([]db.ConnectionCodes, error)) *Database_CreateConnectionCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateFeatureCall provides a mock function with given fields: call
+func (_m *Database) CreateFeatureCall(call db.FeatureCall) (db.FeatureCall, error) {
+	ret := _m.Called(call)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFeatureCall")
+	}
+
+	var r0 db.FeatureCall
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.FeatureCall) (db.FeatureCall, error)); ok {
+		return rf(call)
+	}
+	if rf, ok := ret.Get(0).(func(db.FeatureCall) db.FeatureCall); ok {
+		r0 = rf(call)
+	} else {
+		r0 = ret.Get(0).(db.FeatureCall)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.FeatureCall) error); ok {
+		r1 = rf(call)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateFeatureCall_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFeatureCall'
+type Database_CreateFeatureCall_Call struct {
+	*mock.Call
+}
+
+// CreateFeatureCall is a helper method to define mock.On call
+//   - call db.FeatureCall
+func (_e *Database_Expecter) CreateFeatureCall(call interface{}) *Database_CreateFeatureCall_Call {
+	return &Database_CreateFeatureCall_Call{Call: _e.mock.On("CreateFeatureCall", call)}
+}
+
+func (_c *Database_CreateFeatureCall_Call) Run(run func(call db.FeatureCall)) *Database_CreateFeatureCall_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.FeatureCall))
+	})
+	return _c
+}
+
+func (_c *Database_CreateFeatureCall_Call) Return(_a0 db.FeatureCall, _a1 error) *Database_CreateFeatureCall_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateFeatureCall_Call) RunAndReturn(run func(db.FeatureCall) (db.FeatureCall, error)) *Database_CreateFeatureCall_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateHandleReservation provides a mock function with given fields: reservation
+func (_m *Database) CreateHandleReservation(reservation db.HandleReservation) (db.HandleReservation, error) {
+	ret := _m.Called(reservation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateHandleReservation")
+	}
+
+	var r0 db.HandleReservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.HandleReservation) (db.HandleReservation, error)); ok {
+		return rf(reservation)
+	}
+	if rf, ok := ret.Get(0).(func(db.HandleReservation) db.HandleReservation); ok {
+		r0 = rf(reservation)
+	} else {
+		r0 = ret.Get(0).(db.HandleReservation)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.HandleReservation) error); ok {
+		r1 = rf(reservation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateHandleReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateHandleReservation'
+type Database_CreateHandleReservation_Call struct {
+	*mock.Call
+}
+
+// CreateHandleReservation is a helper method to define mock.On call
+//   - reservation db.HandleReservation
+func (_e *Database_Expecter) CreateHandleReservation(reservation interface{}) *Database_CreateHandleReservation_Call {
+	return &Database_CreateHandleReservation_Call{Call: _e.mock.On("CreateHandleReservation", reservation)}
+}
+
+func (_c *Database_CreateHandleReservation_Call) Run(run func(reservation db.HandleReservation)) *Database_CreateHandleReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.HandleReservation))
+	})
+	return _c
+}
+
+func (_c *Database_CreateHandleReservation_Call) Return(_a0 db.HandleReservation, _a1 error) *Database_CreateHandleReservation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateHandleReservation_Call) RunAndReturn(run func(db.HandleReservation) (db.HandleReservation, error)) *Database_CreateHandleReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateLeaderBoard provides a mock function with given fields: uuid, leaderboards
+func (_m *Database) CreateLeaderBoard(uuid string, leaderboards []db.LeaderBoard) ([]db.LeaderBoard, error) {
+	ret := _m.Called(uuid, leaderboards)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLeaderBoard")
+	}
+
+	var r0 []db.LeaderBoard
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []db.LeaderBoard) ([]db.LeaderBoard, error)); ok {
+		return rf(uuid, leaderboards)
+	}
+	if rf, ok := ret.Get(0).(func(string, []db.LeaderBoard) []db.LeaderBoard); ok {
+		r0 = rf(uuid, leaderboards)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LeaderBoard)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []db.LeaderBoard) error); ok {
+		r1 = rf(uuid, leaderboards)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLeaderBoard'
+type Database_CreateLeaderBoard_Call struct {
+	*mock.Call
+}
+
+// CreateLeaderBoard is a helper method to define mock.On call
+//   - uuid string
+//   - leaderboards []db.LeaderBoard
+func (_e *Database_Expecter) CreateLeaderBoard(uuid interface{}, leaderboards interface{}) *Database_CreateLeaderBoard_Call {
+	return &Database_CreateLeaderBoard_Call{Call: _e.mock.On("CreateLeaderBoard", uuid, leaderboards)}
+}
+
+func (_c *Database_CreateLeaderBoard_Call) Run(run func(uuid string, leaderboards []db.LeaderBoard)) *Database_CreateLeaderBoard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]db.LeaderBoard))
+	})
+	return _c
+}
+
+func (_c *Database_CreateLeaderBoard_Call) Return(_a0 []db.LeaderBoard, _a1 error) *Database_CreateLeaderBoard_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateLeaderBoard_Call) RunAndReturn(run func(string, []db.LeaderBoard) ([]db.LeaderBoard, error)) *Database_CreateLeaderBoard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateLnUser provides a mock function with given fields: lnKey
+func (_m *Database) CreateLnUser(lnKey string) (db.Person, error) {
+	ret := _m.Called(lnKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLnUser")
+	}
+
+	var r0 db.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Person, error)); ok {
+		return rf(lnKey)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(lnKey)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(lnKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateLnUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLnUser'
+type Database_CreateLnUser_Call struct {
+	*mock.Call
+}
+
+// CreateLnUser is a helper method to define mock.On call
+//   - lnKey string
+func (_e *Database_Expecter) CreateLnUser(lnKey interface{}) *Database_CreateLnUser_Call {
+	return &Database_CreateLnUser_Call{Call: _e.mock.On("CreateLnUser", lnKey)}
+}
+
+func (_c *Database_CreateLnUser_Call) Run(run func(lnKey string)) *Database_CreateLnUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_CreateLnUser_Call) Return(_a0 db.Person, _a1 error) *Database_CreateLnUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateLnUser_Call) RunAndReturn(run func(string) (db.Person, error)) *Database_CreateLnUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateNostrEvent provides a mock function with given fields: event
+func (_m *Database) CreateNostrEvent(event db.NostrEvent) (db.NostrEvent, error) {
+	ret := _m.Called(event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNostrEvent")
+	}
+
+	var r0 db.NostrEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NostrEvent) (db.NostrEvent, error)); ok {
+		return rf(event)
+	}
+	if rf, ok := ret.Get(0).(func(db.NostrEvent) db.NostrEvent); ok {
+		r0 = rf(event)
+	} else {
+		r0 = ret.Get(0).(db.NostrEvent)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.NostrEvent) error); ok {
+		r1 = rf(event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateNostrEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNostrEvent'
+type Database_CreateNostrEvent_Call struct {
+	*mock.Call
+}
+
+// CreateNostrEvent is a helper method to define mock.On call
+//   - event db.NostrEvent
+func (_e *Database_Expecter) CreateNostrEvent(event interface{}) *Database_CreateNostrEvent_Call {
+	return &Database_CreateNostrEvent_Call{Call: _e.mock.On("CreateNostrEvent", event)}
+}
+
+func (_c *Database_CreateNostrEvent_Call) Run(run func(event db.NostrEvent)) *Database_CreateNostrEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NostrEvent))
+	})
+	return _c
+}
+
+func (_c *Database_CreateNostrEvent_Call) Return(_a0 db.NostrEvent, _a1 error) *Database_CreateNostrEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateNostrEvent_Call) RunAndReturn(run func(db.NostrEvent) (db.NostrEvent, error)) *Database_CreateNostrEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateNotification provides a mock function with given fields: n
+func (_m *Database) CreateNotification(n db.Notification) (db.Notification, error) {
+	ret := _m.Called(n)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNotification")
+	}
+
+	var r0 db.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Notification) (db.Notification, error)); ok {
+		return rf(n)
+	}
+	if rf, ok := ret.Get(0).(func(db.Notification) db.Notification); ok {
+		r0 = rf(n)
+	} else {
+		r0 = ret.Get(0).(db.Notification)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Notification) error); ok {
+		r1 = rf(n)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNotification'
+type Database_CreateNotification_Call struct {
+	*mock.Call
+}
+
+// CreateNotification is a helper method to define mock.On call
+//   - n db.Notification
+func (_e *Database_Expecter) CreateNotification(n interface{}) *Database_CreateNotification_Call {
+	return &Database_CreateNotification_Call{Call: _e.mock.On("CreateNotification", n)}
+}
+
+func (_c *Database_CreateNotification_Call) Run(run func(n db.Notification)) *Database_CreateNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Notification))
+	})
+	return _c
+}
+
+func (_c *Database_CreateNotification_Call) Return(_a0 db.Notification, _a1 error) *Database_CreateNotification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateNotification_Call) RunAndReturn(run func(db.Notification) (db.Notification, error)) *Database_CreateNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditBot provides a mock function with given fields: b
+func (_m *Database) CreateOrEditBot(b db.Bot) (db.Bot, error) {
+	ret := _m.Called(b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditBot")
+	}
+
+	var r0 db.Bot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Bot) (db.Bot, error)); ok {
+		return rf(b)
+	}
+	if rf, ok := ret.Get(0).(func(db.Bot) db.Bot); ok {
+		r0 = rf(b)
+	} else {
+		r0 = ret.Get(0).(db.Bot)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Bot) error); ok {
+		r1 = rf(b)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBot'
+type Database_CreateOrEditBot_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditBot is a helper method to define mock.On call
+//   - b db.Bot
+func (_e *Database_Expecter) CreateOrEditBot(b interface{}) *Database_CreateOrEditBot_Call {
+	return &Database_CreateOrEditBot_Call{Call: _e.mock.On("CreateOrEditBot", b)}
+}
+
+func (_c *Database_CreateOrEditBot_Call) Run(run func(b db.Bot)) *Database_CreateOrEditBot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Bot))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditBot_Call) Return(_a0 db.Bot, _a1 error) *Database_CreateOrEditBot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditBot_Call) RunAndReturn(run func(db.Bot) (db.Bot, error)) *Database_CreateOrEditBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditBounty provides a mock function with given fields: b
+func (_m *Database) CreateOrEditBounty(b db.NewBounty) (db.NewBounty, error) {
+	ret := _m.Called(b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditBounty")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.NewBounty, error)); ok {
+		return rf(b)
+	}
+	if rf, ok := ret.Get(0).(func(db.NewBounty) db.NewBounty); ok {
+		r0 = rf(b)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
+		r1 = rf(b)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBounty'
+type Database_CreateOrEditBounty_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditBounty is a helper method to define mock.On call
+//   - b db.NewBounty
+func (_e *Database_Expecter) CreateOrEditBounty(b interface{}) *Database_CreateOrEditBounty_Call {
+	return &Database_CreateOrEditBounty_Call{Call: _e.mock.On("CreateOrEditBounty", b)}
+}
+
+func (_c *Database_CreateOrEditBounty_Call) Run(run func(b db.NewBounty)) *Database_CreateOrEditBounty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewBounty))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_CreateOrEditBounty_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditBounty_Call) RunAndReturn(run func(db.NewBounty) (db.NewBounty, error)) *Database_CreateOrEditBounty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditCodeGraph provides a mock function with given fields: m
+func (_m *Database) CreateOrEditCodeGraph(m db.WorkspaceCodeGraph) (db.WorkspaceCodeGraph, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditCodeGraph")
+	}
+
+	var r0 db.WorkspaceCodeGraph
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceCodeGraph) (db.WorkspaceCodeGraph, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceCodeGraph) db.WorkspaceCodeGraph); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceCodeGraph)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceCodeGraph) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditCodeGraph_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditCodeGraph'
+type Database_CreateOrEditCodeGraph_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditCodeGraph is a helper method to define mock.On call
+//   - m db.WorkspaceCodeGraph
+func (_e *Database_Expecter) CreateOrEditCodeGraph(m interface{}) *Database_CreateOrEditCodeGraph_Call {
+	return &Database_CreateOrEditCodeGraph_Call{Call: _e.mock.On("CreateOrEditCodeGraph", m)}
+}
+
+func (_c *Database_CreateOrEditCodeGraph_Call) Run(run func(m db.WorkspaceCodeGraph)) *Database_CreateOrEditCodeGraph_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceCodeGraph))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditCodeGraph_Call) Return(_a0 db.WorkspaceCodeGraph, _a1 error) *Database_CreateOrEditCodeGraph_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditCodeGraph_Call) RunAndReturn(run func(db.WorkspaceCodeGraph) (db.WorkspaceCodeGraph, error)) *Database_CreateOrEditCodeGraph_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditFeature provides a mock function with given fields: m
+func (_m *Database) CreateOrEditFeature(m db.WorkspaceFeatures) (db.WorkspaceFeatures, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditFeature")
+	}
+
+	var r0 db.WorkspaceFeatures
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures) (db.WorkspaceFeatures, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures) db.WorkspaceFeatures); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceFeatures)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceFeatures) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditFeature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeature'
+type Database_CreateOrEditFeature_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditFeature is a helper method to define mock.On call
+//   - m db.WorkspaceFeatures
+func (_e *Database_Expecter) CreateOrEditFeature(m interface{}) *Database_CreateOrEditFeature_Call {
+	return &Database_CreateOrEditFeature_Call{Call: _e.mock.On("CreateOrEditFeature", m)}
+}
+
+func (_c *Database_CreateOrEditFeature_Call) Run(run func(m db.WorkspaceFeatures)) *Database_CreateOrEditFeature_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceFeatures))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditFeature_Call) Return(_a0 db.WorkspaceFeatures, _a1 error) *Database_CreateOrEditFeature_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditFeature_Call) RunAndReturn(run func(db.WorkspaceFeatures) (db.WorkspaceFeatures, error)) *Database_CreateOrEditFeature_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditFeaturePhase provides a mock function with given fields: phase
+func (_m *Database) CreateOrEditFeaturePhase(phase db.FeaturePhase) (db.FeaturePhase, error) {
+	ret := _m.Called(phase)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditFeaturePhase")
+	}
+
+	var r0 db.FeaturePhase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.FeaturePhase) (db.FeaturePhase, error)); ok {
+		return rf(phase)
+	}
+	if rf, ok := ret.Get(0).(func(db.FeaturePhase) db.FeaturePhase); ok {
+		r0 = rf(phase)
+	} else {
+		r0 = ret.Get(0).(db.FeaturePhase)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.FeaturePhase) error); ok {
+		r1 = rf(phase)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditFeaturePhase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeaturePhase'
+type Database_CreateOrEditFeaturePhase_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditFeaturePhase is a helper method to define mock.On call
+//   - phase db.FeaturePhase
+func (_e *Database_Expecter) CreateOrEditFeaturePhase(phase interface{}) *Database_CreateOrEditFeaturePhase_Call {
+	return &Database_CreateOrEditFeaturePhase_Call{Call: _e.mock.On("CreateOrEditFeaturePhase", phase)}
+}
+
+func (_c *Database_CreateOrEditFeaturePhase_Call) Run(run func(phase db.FeaturePhase)) *Database_CreateOrEditFeaturePhase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.FeaturePhase))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditFeaturePhase_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_CreateOrEditFeaturePhase_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditFeaturePhase_Call) RunAndReturn(run func(db.FeaturePhase) (db.FeaturePhase, error)) *Database_CreateOrEditFeaturePhase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditFeatureStory provides a mock function with given fields: story
+func (_m *Database) CreateOrEditFeatureStory(story db.FeatureStory) (db.FeatureStory, error) {
+	ret := _m.Called(story)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditFeatureStory")
+	}
+
+	var r0 db.FeatureStory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.FeatureStory) (db.FeatureStory, error)); ok {
+		return rf(story)
+	}
+	if rf, ok := ret.Get(0).(func(db.FeatureStory) db.FeatureStory); ok {
+		r0 = rf(story)
+	} else {
+		r0 = ret.Get(0).(db.FeatureStory)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.FeatureStory) error); ok {
+		r1 = rf(story)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditFeatureStory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeatureStory'
+type Database_CreateOrEditFeatureStory_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditFeatureStory is a helper method to define mock.On call
+//   - story db.FeatureStory
+func (_e *Database_Expecter) CreateOrEditFeatureStory(story interface{}) *Database_CreateOrEditFeatureStory_Call {
+	return &Database_CreateOrEditFeatureStory_Call{Call: _e.mock.On("CreateOrEditFeatureStory", story)}
+}
+
+func (_c *Database_CreateOrEditFeatureStory_Call) Run(run func(story db.FeatureStory)) *Database_CreateOrEditFeatureStory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.FeatureStory))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditFeatureStory_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_CreateOrEditFeatureStory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditFeatureStory_Call) RunAndReturn(run func(db.FeatureStory) (db.FeatureStory, error)) *Database_CreateOrEditFeatureStory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditPerson provides a mock function with given fields: m
+func (_m *Database) CreateOrEditPerson(m db.Person) (db.Person, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditPerson")
+	}
+
+	var r0 db.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Person) (db.Person, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.Person) db.Person); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Person) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditPerson'
+type Database_CreateOrEditPerson_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditPerson is a helper method to define mock.On call
+//   - m db.Person
+func (_e *Database_Expecter) CreateOrEditPerson(m interface{}) *Database_CreateOrEditPerson_Call {
+	return &Database_CreateOrEditPerson_Call{Call: _e.mock.On("CreateOrEditPerson", m)}
+}
+
+func (_c *Database_CreateOrEditPerson_Call) Run(run func(m db.Person)) *Database_CreateOrEditPerson_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Person))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditPerson_Call) Return(_a0 db.Person, _a1 error) *Database_CreateOrEditPerson_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditPerson_Call) RunAndReturn(run func(db.Person) (db.Person, error)) *Database_CreateOrEditPerson_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditTribe provides a mock function with given fields: m
+func (_m *Database) CreateOrEditTribe(m db.Tribe) (db.Tribe, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditTribe")
+	}
+
+	var r0 db.Tribe
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Tribe) (db.Tribe, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.Tribe) db.Tribe); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.Tribe)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Tribe) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditTribe'
+type Database_CreateOrEditTribe_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditTribe is a helper method to define mock.On call
+//   - m db.Tribe
+func (_e *Database_Expecter) CreateOrEditTribe(m interface{}) *Database_CreateOrEditTribe_Call {
+	return &Database_CreateOrEditTribe_Call{Call: _e.mock.On("CreateOrEditTribe", m)}
+}
+
+func (_c *Database_CreateOrEditTribe_Call) Run(run func(m db.Tribe)) *Database_CreateOrEditTribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Tribe))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditTribe_Call) Return(_a0 db.Tribe, _a1 error) *Database_CreateOrEditTribe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditTribe_Call) RunAndReturn(run func(db.Tribe) (db.Tribe, error)) *Database_CreateOrEditTribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditWorkspace provides a mock function with given fields: m
+func (_m *Database) CreateOrEditWorkspace(m db.Workspace) (db.Workspace, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditWorkspace")
+	}
+
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Workspace) (db.Workspace, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.Workspace) db.Workspace); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.Workspace)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.Workspace) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspace'
+type Database_CreateOrEditWorkspace_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditWorkspace is a helper method to define mock.On call
+//   - m db.Workspace
+func (_e *Database_Expecter) CreateOrEditWorkspace(m interface{}) *Database_CreateOrEditWorkspace_Call {
+	return &Database_CreateOrEditWorkspace_Call{Call: _e.mock.On("CreateOrEditWorkspace", m)}
+}
+
+func (_c *Database_CreateOrEditWorkspace_Call) Run(run func(m db.Workspace)) *Database_CreateOrEditWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.Workspace))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditWorkspace_Call) Return(_a0 db.Workspace, _a1 error) *Database_CreateOrEditWorkspace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditWorkspace_Call) RunAndReturn(run func(db.Workspace) (db.Workspace, error)) *Database_CreateOrEditWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrEditWorkspaceRepository provides a mock function with given fields: m
+func (_m *Database) CreateOrEditWorkspaceRepository(m db.WorkspaceRepositories) (db.WorkspaceRepositories, error) {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrEditWorkspaceRepository")
+	}
+
+	var r0 db.WorkspaceRepositories
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceRepositories) (db.WorkspaceRepositories, error)); ok {
+		return rf(m)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceRepositories) db.WorkspaceRepositories); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceRepositories)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceRepositories) error); ok {
+		r1 = rf(m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateOrEditWorkspaceRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspaceRepository'
+type Database_CreateOrEditWorkspaceRepository_Call struct {
+	*mock.Call
+}
+
+// CreateOrEditWorkspaceRepository is a helper method to define mock.On call
+//   - m db.WorkspaceRepositories
+func (_e *Database_Expecter) CreateOrEditWorkspaceRepository(m interface{}) *Database_CreateOrEditWorkspaceRepository_Call {
+	return &Database_CreateOrEditWorkspaceRepository_Call{Call: _e.mock.On("CreateOrEditWorkspaceRepository", m)}
+}
+
+func (_c *Database_CreateOrEditWorkspaceRepository_Call) Run(run func(m db.WorkspaceRepositories)) *Database_CreateOrEditWorkspaceRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceRepositories))
+	})
+	return _c
+}
+
+func (_c *Database_CreateOrEditWorkspaceRepository_Call) Return(_a0 db.WorkspaceRepositories, _a1 error) *Database_CreateOrEditWorkspaceRepository_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateOrEditWorkspaceRepository_Call) RunAndReturn(run func(db.WorkspaceRepositories) (db.WorkspaceRepositories, error)) *Database_CreateOrEditWorkspaceRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateParentOrganization provides a mock function with given fields: org
+func (_m *Database) CreateParentOrganization(org db.ParentOrganization) (db.ParentOrganization, error) {
+	ret := _m.Called(org)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateParentOrganization")
+	}
+
+	var r0 db.ParentOrganization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.ParentOrganization) (db.ParentOrganization, error)); ok {
+		return rf(org)
+	}
+	if rf, ok := ret.Get(0).(func(db.ParentOrganization) db.ParentOrganization); ok {
+		r0 = rf(org)
+	} else {
+		r0 = ret.Get(0).(db.ParentOrganization)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.ParentOrganization) error); ok {
+		r1 = rf(org)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateParentOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateParentOrganization'
+type Database_CreateParentOrganization_Call struct {
+	*mock.Call
+}
+
+// CreateParentOrganization is a helper method to define mock.On call
+//   - org db.ParentOrganization
+func (_e *Database_Expecter) CreateParentOrganization(org interface{}) *Database_CreateParentOrganization_Call {
+	return &Database_CreateParentOrganization_Call{Call: _e.mock.On("CreateParentOrganization", org)}
+}
+
+func (_c *Database_CreateParentOrganization_Call) Run(run func(org db.ParentOrganization)) *Database_CreateParentOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.ParentOrganization))
+	})
+	return _c
+}
+
+func (_c *Database_CreateParentOrganization_Call) Return(_a0 db.ParentOrganization, _a1 error) *Database_CreateParentOrganization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateParentOrganization_Call) RunAndReturn(run func(db.ParentOrganization) (db.ParentOrganization, error)) *Database_CreateParentOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePhasePlan provides a mock function with given fields: plan
+func (_m *Database) CreatePhasePlan(plan db.PhasePlan) (db.PhasePlan, error) {
+	ret := _m.Called(plan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePhasePlan")
+	}
+
+	var r0 db.PhasePlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.PhasePlan) (db.PhasePlan, error)); ok {
+		return rf(plan)
+	}
+	if rf, ok := ret.Get(0).(func(db.PhasePlan) db.PhasePlan); ok {
+		r0 = rf(plan)
+	} else {
+		r0 = ret.Get(0).(db.PhasePlan)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.PhasePlan) error); ok {
+		r1 = rf(plan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreatePhasePlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePhasePlan'
+type Database_CreatePhasePlan_Call struct {
+	*mock.Call
+}
+
+// CreatePhasePlan is a helper method to define mock.On call
+//   - plan db.PhasePlan
+func (_e *Database_Expecter) CreatePhasePlan(plan interface{}) *Database_CreatePhasePlan_Call {
+	return &Database_CreatePhasePlan_Call{Call: _e.mock.On("CreatePhasePlan", plan)}
+}
+
+func (_c *Database_CreatePhasePlan_Call) Run(run func(plan db.PhasePlan)) *Database_CreatePhasePlan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PhasePlan))
+	})
+	return _c
+}
+
+func (_c *Database_CreatePhasePlan_Call) Return(_a0 db.PhasePlan, _a1 error) *Database_CreatePhasePlan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreatePhasePlan_Call) RunAndReturn(run func(db.PhasePlan) (db.PhasePlan, error)) *Database_CreatePhasePlan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateShortLink provides a mock function with given fields: link
+func (_m *Database) CreateShortLink(link db.ShortLink) (db.ShortLink, error) {
+	ret := _m.Called(link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateShortLink")
+	}
+
+	var r0 db.ShortLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.ShortLink) (db.ShortLink, error)); ok {
+		return rf(link)
+	}
+	if rf, ok := ret.Get(0).(func(db.ShortLink) db.ShortLink); ok {
+		r0 = rf(link)
+	} else {
+		r0 = ret.Get(0).(db.ShortLink)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.ShortLink) error); ok {
+		r1 = rf(link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateShortLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateShortLink'
+type Database_CreateShortLink_Call struct {
+	*mock.Call
+}
+
+// CreateShortLink is a helper method to define mock.On call
+//   - link db.ShortLink
+func (_e *Database_Expecter) CreateShortLink(link interface{}) *Database_CreateShortLink_Call {
+	return &Database_CreateShortLink_Call{Call: _e.mock.On("CreateShortLink", link)}
+}
+
+func (_c *Database_CreateShortLink_Call) Run(run func(link db.ShortLink)) *Database_CreateShortLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.ShortLink))
+	})
+	return _c
+}
+
+func (_c *Database_CreateShortLink_Call) Return(_a0 db.ShortLink, _a1 error) *Database_CreateShortLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateShortLink_Call) RunAndReturn(run func(db.ShortLink) (db.ShortLink, error)) *Database_CreateShortLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSlackLinkCode provides a mock function with given fields: teamId, slackUserId, code, expires
+func (_m *Database) CreateSlackLinkCode(teamId string, slackUserId string, code string, expires time.Time) error {
+	ret := _m.Called(teamId, slackUserId, code, expires)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSlackLinkCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, time.Time) error); ok {
+		r0 = rf(teamId, slackUserId, code, expires)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateSlackLinkCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSlackLinkCode'
+type Database_CreateSlackLinkCode_Call struct {
+	*mock.Call
+}
+
+// CreateSlackLinkCode is a helper method to define mock.On call
+//   - teamId string
+//   - slackUserId string
+//   - code string
+//   - expires time.Time
+func (_e *Database_Expecter) CreateSlackLinkCode(teamId interface{}, slackUserId interface{}, code interface{}, expires interface{}) *Database_CreateSlackLinkCode_Call {
+	return &Database_CreateSlackLinkCode_Call{Call: _e.mock.On("CreateSlackLinkCode", teamId, slackUserId, code, expires)}
+}
+
+func (_c *Database_CreateSlackLinkCode_Call) Run(run func(teamId string, slackUserId string, code string, expires time.Time)) *Database_CreateSlackLinkCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_CreateSlackLinkCode_Call) Return(_a0 error) *Database_CreateSlackLinkCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateSlackLinkCode_Call) RunAndReturn(run func(string, string, string, time.Time) error) *Database_CreateSlackLinkCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSuperAdminChange provides a mock function with given fields: change
+func (_m *Database) CreateSuperAdminChange(change db.SuperAdminChange) (db.SuperAdminChange, error) {
+	ret := _m.Called(change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSuperAdminChange")
+	}
+
+	var r0 db.SuperAdminChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.SuperAdminChange) (db.SuperAdminChange, error)); ok {
+		return rf(change)
+	}
+	if rf, ok := ret.Get(0).(func(db.SuperAdminChange) db.SuperAdminChange); ok {
+		r0 = rf(change)
+	} else {
+		r0 = ret.Get(0).(db.SuperAdminChange)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.SuperAdminChange) error); ok {
+		r1 = rf(change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateSuperAdminChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSuperAdminChange'
+type Database_CreateSuperAdminChange_Call struct {
+	*mock.Call
+}
+
+// CreateSuperAdminChange is a helper method to define mock.On call
+//   - change db.SuperAdminChange
+func (_e *Database_Expecter) CreateSuperAdminChange(change interface{}) *Database_CreateSuperAdminChange_Call {
+	return &Database_CreateSuperAdminChange_Call{Call: _e.mock.On("CreateSuperAdminChange", change)}
+}
+
+func (_c *Database_CreateSuperAdminChange_Call) Run(run func(change db.SuperAdminChange)) *Database_CreateSuperAdminChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.SuperAdminChange))
+	})
+	return _c
+}
+
+func (_c *Database_CreateSuperAdminChange_Call) Return(_a0 db.SuperAdminChange, _a1 error) *Database_CreateSuperAdminChange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateSuperAdminChange_Call) RunAndReturn(run func(db.SuperAdminChange) (db.SuperAdminChange, error)) *Database_CreateSuperAdminChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTicketReview provides a mock function with given fields: review
+func (_m *Database) CreateTicketReview(review db.TicketReview) (db.TicketReview, error) {
+	ret := _m.Called(review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTicketReview")
+	}
+
+	var r0 db.TicketReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TicketReview) (db.TicketReview, error)); ok {
+		return rf(review)
+	}
+	if rf, ok := ret.Get(0).(func(db.TicketReview) db.TicketReview); ok {
+		r0 = rf(review)
+	} else {
+		r0 = ret.Get(0).(db.TicketReview)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.TicketReview) error); ok {
+		r1 = rf(review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateTicketReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTicketReview'
+type Database_CreateTicketReview_Call struct {
+	*mock.Call
+}
+
+// CreateTicketReview is a helper method to define mock.On call
+//   - review db.TicketReview
+func (_e *Database_Expecter) CreateTicketReview(review interface{}) *Database_CreateTicketReview_Call {
+	return &Database_CreateTicketReview_Call{Call: _e.mock.On("CreateTicketReview", review)}
+}
+
+func (_c *Database_CreateTicketReview_Call) Run(run func(review db.TicketReview)) *Database_CreateTicketReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.TicketReview))
+	})
+	return _c
+}
+
+func (_c *Database_CreateTicketReview_Call) Return(_a0 db.TicketReview, _a1 error) *Database_CreateTicketReview_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateTicketReview_Call) RunAndReturn(run func(db.TicketReview) (db.TicketReview, error)) *Database_CreateTicketReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUserRoles provides a mock function with given fields: roles, uuid, pubkey
+func (_m *Database) CreateUserRoles(roles []db.WorkspaceUserRoles, uuid string, pubkey string) []db.WorkspaceUserRoles {
+	ret := _m.Called(roles, uuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserRoles")
+	}
+
+	var r0 []db.WorkspaceUserRoles
+	if rf, ok := ret.Get(0).(func([]db.WorkspaceUserRoles, string, string) []db.WorkspaceUserRoles); ok {
+		r0 = rf(roles, uuid, pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceUserRoles)
+		}
+	}
+
+	return r0
+}
+
+// Database_CreateUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserRoles'
+type Database_CreateUserRoles_Call struct {
+	*mock.Call
+}
+
+// CreateUserRoles is a helper method to define mock.On call
+//   - roles []db.WorkspaceUserRoles
+//   - uuid string
+//   - pubkey string
+func (_e *Database_Expecter) CreateUserRoles(roles interface{}, uuid interface{}, pubkey interface{}) *Database_CreateUserRoles_Call {
+	return &Database_CreateUserRoles_Call{Call: _e.mock.On("CreateUserRoles", roles, uuid, pubkey)}
+}
+
+func (_c *Database_CreateUserRoles_Call) Run(run func(roles []db.WorkspaceUserRoles, uuid string, pubkey string)) *Database_CreateUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]db.WorkspaceUserRoles), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_CreateUserRoles_Call) Return(_a0 []db.WorkspaceUserRoles) *Database_CreateUserRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateUserRoles_Call) RunAndReturn(run func([]db.WorkspaceUserRoles, string, string) []db.WorkspaceUserRoles) *Database_CreateUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceApiToken provides a mock function with given fields: token
+func (_m *Database) CreateWorkspaceApiToken(token db.WorkspaceApiToken) (db.WorkspaceApiToken, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceApiToken")
+	}
+
+	var r0 db.WorkspaceApiToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceApiToken) (db.WorkspaceApiToken, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceApiToken) db.WorkspaceApiToken); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceApiToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceApiToken) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateWorkspaceApiToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceApiToken'
+type Database_CreateWorkspaceApiToken_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceApiToken is a helper method to define mock.On call
+//   - token db.WorkspaceApiToken
+func (_e *Database_Expecter) CreateWorkspaceApiToken(token interface{}) *Database_CreateWorkspaceApiToken_Call {
+	return &Database_CreateWorkspaceApiToken_Call{Call: _e.mock.On("CreateWorkspaceApiToken", token)}
+}
+
+func (_c *Database_CreateWorkspaceApiToken_Call) Run(run func(token db.WorkspaceApiToken)) *Database_CreateWorkspaceApiToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceApiToken))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceApiToken_Call) Return(_a0 db.WorkspaceApiToken, _a1 error) *Database_CreateWorkspaceApiToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceApiToken_Call) RunAndReturn(run func(db.WorkspaceApiToken) (db.WorkspaceApiToken, error)) *Database_CreateWorkspaceApiToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceBridge provides a mock function with given fields: bridge
+func (_m *Database) CreateWorkspaceBridge(bridge db.WorkspaceBridge) (db.WorkspaceBridge, error) {
+	ret := _m.Called(bridge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceBridge")
+	}
+
+	var r0 db.WorkspaceBridge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBridge) (db.WorkspaceBridge, error)); ok {
+		return rf(bridge)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceBridge) db.WorkspaceBridge); ok {
+		r0 = rf(bridge)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceBridge)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceBridge) error); ok {
+		r1 = rf(bridge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateWorkspaceBridge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceBridge'
+type Database_CreateWorkspaceBridge_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceBridge is a helper method to define mock.On call
+//   - bridge db.WorkspaceBridge
+func (_e *Database_Expecter) CreateWorkspaceBridge(bridge interface{}) *Database_CreateWorkspaceBridge_Call {
+	return &Database_CreateWorkspaceBridge_Call{Call: _e.mock.On("CreateWorkspaceBridge", bridge)}
+}
+
+func (_c *Database_CreateWorkspaceBridge_Call) Run(run func(bridge db.WorkspaceBridge)) *Database_CreateWorkspaceBridge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceBridge))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceBridge_Call) Return(_a0 db.WorkspaceBridge, _a1 error) *Database_CreateWorkspaceBridge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceBridge_Call) RunAndReturn(run func(db.WorkspaceBridge) (db.WorkspaceBridge, error)) *Database_CreateWorkspaceBridge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceBudget provides a mock function with given fields: budget
+func (_m *Database) CreateWorkspaceBudget(budget db.NewBountyBudget) db.NewBountyBudget {
+	ret := _m.Called(budget)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceBudget")
+	}
+
+	var r0 db.NewBountyBudget
+	if rf, ok := ret.Get(0).(func(db.NewBountyBudget) db.NewBountyBudget); ok {
+		r0 = rf(budget)
+	} else {
+		r0 = ret.Get(0).(db.NewBountyBudget)
+	}
+
+	return r0
+}
+
+// Database_CreateWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceBudget'
+type Database_CreateWorkspaceBudget_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceBudget is a helper method to define mock.On call
+//   - budget db.NewBountyBudget
+func (_e *Database_Expecter) CreateWorkspaceBudget(budget interface{}) *Database_CreateWorkspaceBudget_Call {
+	return &Database_CreateWorkspaceBudget_Call{Call: _e.mock.On("CreateWorkspaceBudget", budget)}
+}
+
+func (_c *Database_CreateWorkspaceBudget_Call) Run(run func(budget db.NewBountyBudget)) *Database_CreateWorkspaceBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewBountyBudget))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceBudget_Call) Return(_a0 db.NewBountyBudget) *Database_CreateWorkspaceBudget_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceBudget_Call) RunAndReturn(run func(db.NewBountyBudget) db.NewBountyBudget) *Database_CreateWorkspaceBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceTag provides a mock function with given fields: tag
+func (_m *Database) CreateWorkspaceTag(tag db.WorkspaceTag) (db.WorkspaceTag, error) {
+	ret := _m.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceTag")
+	}
+
+	var r0 db.WorkspaceTag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceTag) (db.WorkspaceTag, error)); ok {
+		return rf(tag)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceTag) db.WorkspaceTag); ok {
+		r0 = rf(tag)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceTag)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceTag) error); ok {
+		r1 = rf(tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateWorkspaceTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceTag'
+type Database_CreateWorkspaceTag_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceTag is a helper method to define mock.On call
+//   - tag db.WorkspaceTag
+func (_e *Database_Expecter) CreateWorkspaceTag(tag interface{}) *Database_CreateWorkspaceTag_Call {
+	return &Database_CreateWorkspaceTag_Call{Call: _e.mock.On("CreateWorkspaceTag", tag)}
+}
+
+func (_c *Database_CreateWorkspaceTag_Call) Run(run func(tag db.WorkspaceTag)) *Database_CreateWorkspaceTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceTag))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceTag_Call) Return(_a0 db.WorkspaceTag, _a1 error) *Database_CreateWorkspaceTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceTag_Call) RunAndReturn(run func(db.WorkspaceTag) (db.WorkspaceTag, error)) *Database_CreateWorkspaceTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceUser provides a mock function with given fields: orgUser
+func (_m *Database) CreateWorkspaceUser(orgUser db.WorkspaceUsers) db.WorkspaceUsers {
+	ret := _m.Called(orgUser)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceUser")
+	}
+
+	var r0 db.WorkspaceUsers
+	if rf, ok := ret.Get(0).(func(db.WorkspaceUsers) db.WorkspaceUsers); ok {
+		r0 = rf(orgUser)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceUsers)
+	}
+
+	return r0
+}
+
+// Database_CreateWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceUser'
+type Database_CreateWorkspaceUser_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceUser is a helper method to define mock.On call
+//   - orgUser db.WorkspaceUsers
+func (_e *Database_Expecter) CreateWorkspaceUser(orgUser interface{}) *Database_CreateWorkspaceUser_Call {
+	return &Database_CreateWorkspaceUser_Call{Call: _e.mock.On("CreateWorkspaceUser", orgUser)}
+}
+
+func (_c *Database_CreateWorkspaceUser_Call) Run(run func(orgUser db.WorkspaceUsers)) *Database_CreateWorkspaceUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceUsers))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceUser_Call) Return(_a0 db.WorkspaceUsers) *Database_CreateWorkspaceUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceUser_Call) RunAndReturn(run func(db.WorkspaceUsers) db.WorkspaceUsers) *Database_CreateWorkspaceUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspaceWebhook provides a mock function with given fields: webhook
+func (_m *Database) CreateWorkspaceWebhook(webhook db.WorkspaceWebhook) (db.WorkspaceWebhook, error) {
+	ret := _m.Called(webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspaceWebhook")
+	}
+
+	var r0 db.WorkspaceWebhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceWebhook) (db.WorkspaceWebhook, error)); ok {
+		return rf(webhook)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceWebhook) db.WorkspaceWebhook); ok {
+		r0 = rf(webhook)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceWebhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceWebhook) error); ok {
+		r1 = rf(webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateWorkspaceWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceWebhook'
+type Database_CreateWorkspaceWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspaceWebhook is a helper method to define mock.On call
+//   - webhook db.WorkspaceWebhook
+func (_e *Database_Expecter) CreateWorkspaceWebhook(webhook interface{}) *Database_CreateWorkspaceWebhook_Call {
+	return &Database_CreateWorkspaceWebhook_Call{Call: _e.mock.On("CreateWorkspaceWebhook", webhook)}
+}
+
+func (_c *Database_CreateWorkspaceWebhook_Call) Run(run func(webhook db.WorkspaceWebhook)) *Database_CreateWorkspaceWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceWebhook))
+	})
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceWebhook_Call) Return(_a0 db.WorkspaceWebhook, _a1 error) *Database_CreateWorkspaceWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateWorkspaceWebhook_Call) RunAndReturn(run func(db.WorkspaceWebhook) (db.WorkspaceWebhook, error)) *Database_CreateWorkspaceWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAllUsersFromWorkspace provides a mock function with given fields: uuid
+func (_m *Database) DeleteAllUsersFromWorkspace(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllUsersFromWorkspace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteAllUsersFromWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAllUsersFromWorkspace'
+type Database_DeleteAllUsersFromWorkspace_Call struct {
+	*mock.Call
+}
+
+// DeleteAllUsersFromWorkspace is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteAllUsersFromWorkspace(uuid interface{}) *Database_DeleteAllUsersFromWorkspace_Call {
+	return &Database_DeleteAllUsersFromWorkspace_Call{Call: _e.mock.On("DeleteAllUsersFromWorkspace", uuid)}
+}
+
+func (_c *Database_DeleteAllUsersFromWorkspace_Call) Run(run func(uuid string)) *Database_DeleteAllUsersFromWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteAllUsersFromWorkspace_Call) Return(_a0 error) *Database_DeleteAllUsersFromWorkspace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteAllUsersFromWorkspace_Call) RunAndReturn(run func(string) error) *Database_DeleteAllUsersFromWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteArtifact provides a mock function with given fields: uuid
+func (_m *Database) DeleteArtifact(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteArtifact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteArtifact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteArtifact'
+type Database_DeleteArtifact_Call struct {
+	*mock.Call
+}
+
+// DeleteArtifact is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteArtifact(uuid interface{}) *Database_DeleteArtifact_Call {
+	return &Database_DeleteArtifact_Call{Call: _e.mock.On("DeleteArtifact", uuid)}
+}
+
+func (_c *Database_DeleteArtifact_Call) Run(run func(uuid string)) *Database_DeleteArtifact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteArtifact_Call) Return(_a0 error) *Database_DeleteArtifact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteArtifact_Call) RunAndReturn(run func(string) error) *Database_DeleteArtifact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBounty provides a mock function with given fields: pubkey, created
+func (_m *Database) DeleteBounty(pubkey string, created string) (db.NewBounty, error) {
+	ret := _m.Called(pubkey, created)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBounty")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.NewBounty, error)); ok {
+		return rf(pubkey, created)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.NewBounty); ok {
+		r0 = rf(pubkey, created)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(pubkey, created)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_DeleteBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBounty'
+type Database_DeleteBounty_Call struct {
+	*mock.Call
+}
+
+// DeleteBounty is a helper method to define mock.On call
+//   - pubkey string
+//   - created string
+func (_e *Database_Expecter) DeleteBounty(pubkey interface{}, created interface{}) *Database_DeleteBounty_Call {
+	return &Database_DeleteBounty_Call{Call: _e.mock.On("DeleteBounty", pubkey, created)}
+}
+
+func (_c *Database_DeleteBounty_Call) Run(run func(pubkey string, created string)) *Database_DeleteBounty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_DeleteBounty_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_DeleteBounty_Call) RunAndReturn(run func(string, string) (db.NewBounty, error)) *Database_DeleteBounty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBountyComment provides a mock function with given fields: id
+func (_m *Database) DeleteBountyComment(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBountyComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteBountyComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBountyComment'
+type Database_DeleteBountyComment_Call struct {
+	*mock.Call
+}
+
+// DeleteBountyComment is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) DeleteBountyComment(id interface{}) *Database_DeleteBountyComment_Call {
+	return &Database_DeleteBountyComment_Call{Call: _e.mock.On("DeleteBountyComment", id)}
+}
+
+func (_c *Database_DeleteBountyComment_Call) Run(run func(id uint)) *Database_DeleteBountyComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteBountyComment_Call) Return(_a0 error) *Database_DeleteBountyComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteBountyComment_Call) RunAndReturn(run func(uint) error) *Database_DeleteBountyComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteChatAgentWebhook provides a mock function with given fields: chatId
+func (_m *Database) DeleteChatAgentWebhook(chatId string) error {
+	ret := _m.Called(chatId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteChatAgentWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(chatId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteChatAgentWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChatAgentWebhook'
+type Database_DeleteChatAgentWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteChatAgentWebhook is a helper method to define mock.On call
+//   - chatId string
+func (_e *Database_Expecter) DeleteChatAgentWebhook(chatId interface{}) *Database_DeleteChatAgentWebhook_Call {
+	return &Database_DeleteChatAgentWebhook_Call{Call: _e.mock.On("DeleteChatAgentWebhook", chatId)}
+}
+
+func (_c *Database_DeleteChatAgentWebhook_Call) Run(run func(chatId string)) *Database_DeleteChatAgentWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteChatAgentWebhook_Call) Return(_a0 error) *Database_DeleteChatAgentWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteChatAgentWebhook_Call) RunAndReturn(run func(string) error) *Database_DeleteChatAgentWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCodeGraph provides a mock function with given fields: workspace_uuid, uuid
+func (_m *Database) DeleteCodeGraph(workspace_uuid string, uuid string) error {
+	ret := _m.Called(workspace_uuid, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCodeGraph")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(workspace_uuid, uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteCodeGraph_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCodeGraph'
+type Database_DeleteCodeGraph_Call struct {
+	*mock.Call
+}
+
+// DeleteCodeGraph is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - uuid string
+func (_e *Database_Expecter) DeleteCodeGraph(workspace_uuid interface{}, uuid interface{}) *Database_DeleteCodeGraph_Call {
+	return &Database_DeleteCodeGraph_Call{Call: _e.mock.On("DeleteCodeGraph", workspace_uuid, uuid)}
+}
+
+func (_c *Database_DeleteCodeGraph_Call) Run(run func(workspace_uuid string, uuid string)) *Database_DeleteCodeGraph_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteCodeGraph_Call) Return(_a0 error) *Database_DeleteCodeGraph_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteCodeGraph_Call) RunAndReturn(run func(string, string) error) *Database_DeleteCodeGraph_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteConfigOverride provides a mock function with given fields: key
+func (_m *Database) DeleteConfigOverride(key string) error {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteConfigOverride")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteConfigOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteConfigOverride'
+type Database_DeleteConfigOverride_Call struct {
+	*mock.Call
+}
+
+// DeleteConfigOverride is a helper method to define mock.On call
+//   - key string
+func (_e *Database_Expecter) DeleteConfigOverride(key interface{}) *Database_DeleteConfigOverride_Call {
+	return &Database_DeleteConfigOverride_Call{Call: _e.mock.On("DeleteConfigOverride", key)}
+}
+
+func (_c *Database_DeleteConfigOverride_Call) Run(run func(key string)) *Database_DeleteConfigOverride_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteConfigOverride_Call) Return(_a0 error) *Database_DeleteConfigOverride_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteConfigOverride_Call) RunAndReturn(run func(string) error) *Database_DeleteConfigOverride_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFeatureByUuid provides a mock function with given fields: uuid
+func (_m *Database) DeleteFeatureByUuid(uuid string) error {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeatureByUuid")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteFeatureByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureByUuid'
+type Database_DeleteFeatureByUuid_Call struct {
+	*mock.Call
+}
+
+// DeleteFeatureByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) DeleteFeatureByUuid(uuid interface{}) *Database_DeleteFeatureByUuid_Call {
+	return &Database_DeleteFeatureByUuid_Call{Call: _e.mock.On("DeleteFeatureByUuid", uuid)}
+}
+
+func (_c *Database_DeleteFeatureByUuid_Call) Run(run func(uuid string)) *Database_DeleteFeatureByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteFeatureByUuid_Call) Return(_a0 error) *Database_DeleteFeatureByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteFeatureByUuid_Call) RunAndReturn(run func(string) error) *Database_DeleteFeatureByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFeaturePhase provides a mock function with given fields: featureUuid, phaseUuid
+func (_m *Database) DeleteFeaturePhase(featureUuid string, phaseUuid string) error {
+	ret := _m.Called(featureUuid, phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeaturePhase")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(featureUuid, phaseUuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteFeaturePhase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeaturePhase'
+type Database_DeleteFeaturePhase_Call struct {
+	*mock.Call
+}
+
+// DeleteFeaturePhase is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+func (_e *Database_Expecter) DeleteFeaturePhase(featureUuid interface{}, phaseUuid interface{}) *Database_DeleteFeaturePhase_Call {
+	return &Database_DeleteFeaturePhase_Call{Call: _e.mock.On("DeleteFeaturePhase", featureUuid, phaseUuid)}
+}
+
+func (_c *Database_DeleteFeaturePhase_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_DeleteFeaturePhase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteFeaturePhase_Call) Return(_a0 error) *Database_DeleteFeaturePhase_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteFeaturePhase_Call) RunAndReturn(run func(string, string) error) *Database_DeleteFeaturePhase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFeatureStoryByUuid provides a mock function with given fields: featureUuid, storyUuid
+func (_m *Database) DeleteFeatureStoryByUuid(featureUuid string, storyUuid string) error {
+	ret := _m.Called(featureUuid, storyUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeatureStoryByUuid")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(featureUuid, storyUuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteFeatureStoryByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureStoryByUuid'
+type Database_DeleteFeatureStoryByUuid_Call struct {
+	*mock.Call
+}
+
+// DeleteFeatureStoryByUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - storyUuid string
+func (_e *Database_Expecter) DeleteFeatureStoryByUuid(featureUuid interface{}, storyUuid interface{}) *Database_DeleteFeatureStoryByUuid_Call {
+	return &Database_DeleteFeatureStoryByUuid_Call{Call: _e.mock.On("DeleteFeatureStoryByUuid", featureUuid, storyUuid)}
+}
+
+func (_c *Database_DeleteFeatureStoryByUuid_Call) Run(run func(featureUuid string, storyUuid string)) *Database_DeleteFeatureStoryByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteFeatureStoryByUuid_Call) Return(_a0 error) *Database_DeleteFeatureStoryByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteFeatureStoryByUuid_Call) RunAndReturn(run func(string, string) error) *Database_DeleteFeatureStoryByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteHandleReservation provides a mock function with given fields: handle
+func (_m *Database) DeleteHandleReservation(handle string) error {
+	ret := _m.Called(handle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteHandleReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(handle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteHandleReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteHandleReservation'
+type Database_DeleteHandleReservation_Call struct {
+	*mock.Call
+}
+
+// DeleteHandleReservation is a helper method to define mock.On call
+//   - handle string
+func (_e *Database_Expecter) DeleteHandleReservation(handle interface{}) *Database_DeleteHandleReservation_Call {
+	return &Database_DeleteHandleReservation_Call{Call: _e.mock.On("DeleteHandleReservation", handle)}
+}
+
+func (_c *Database_DeleteHandleReservation_Call) Run(run func(handle string)) *Database_DeleteHandleReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteHandleReservation_Call) Return(_a0 error) *Database_DeleteHandleReservation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteHandleReservation_Call) RunAndReturn(run func(string) error) *Database_DeleteHandleReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteInvoice provides a mock function with given fields: payment_request
+func (_m *Database) DeleteInvoice(payment_request string) db.NewInvoiceList {
+	ret := _m.Called(payment_request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvoice")
+	}
+
+	var r0 db.NewInvoiceList
+	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
+		r0 = rf(payment_request)
+	} else {
+		r0 = ret.Get(0).(db.NewInvoiceList)
+	}
+
+	return r0
+}
+
+// Database_DeleteInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInvoice'
+type Database_DeleteInvoice_Call struct {
+	*mock.Call
+}
+
+// DeleteInvoice is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) DeleteInvoice(payment_request interface{}) *Database_DeleteInvoice_Call {
+	return &Database_DeleteInvoice_Call{Call: _e.mock.On("DeleteInvoice", payment_request)}
+}
+
+func (_c *Database_DeleteInvoice_Call) Run(run func(payment_request string)) *Database_DeleteInvoice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_DeleteInvoice_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_DeleteInvoice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteJiraUserLink provides a mock function with given fields: workspaceUuid, email
+func (_m *Database) DeleteJiraUserLink(workspaceUuid string, email string) error {
+	ret := _m.Called(workspaceUuid, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteJiraUserLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(workspaceUuid, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteJiraUserLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteJiraUserLink'
+type Database_DeleteJiraUserLink_Call struct {
+	*mock.Call
+}
+
+// DeleteJiraUserLink is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - email string
+func (_e *Database_Expecter) DeleteJiraUserLink(workspaceUuid interface{}, email interface{}) *Database_DeleteJiraUserLink_Call {
+	return &Database_DeleteJiraUserLink_Call{Call: _e.mock.On("DeleteJiraUserLink", workspaceUuid, email)}
+}
+
+func (_c *Database_DeleteJiraUserLink_Call) Run(run func(workspaceUuid string, email string)) *Database_DeleteJiraUserLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteJiraUserLink_Call) Return(_a0 error) *Database_DeleteJiraUserLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteJiraUserLink_Call) RunAndReturn(run func(string, string) error) *Database_DeleteJiraUserLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserInvoiceData provides a mock function with given fields: payment_request
+func (_m *Database) DeleteUserInvoiceData(payment_request string) db.UserInvoiceData {
+	ret := _m.Called(payment_request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserInvoiceData")
+	}
+
+	var r0 db.UserInvoiceData
+	if rf, ok := ret.Get(0).(func(string) db.UserInvoiceData); ok {
+		r0 = rf(payment_request)
+	} else {
+		r0 = ret.Get(0).(db.UserInvoiceData)
+	}
+
+	return r0
+}
+
+// Database_DeleteUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserInvoiceData'
+type Database_DeleteUserInvoiceData_Call struct {
+	*mock.Call
+}
+
+// DeleteUserInvoiceData is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) DeleteUserInvoiceData(payment_request interface{}) *Database_DeleteUserInvoiceData_Call {
+	return &Database_DeleteUserInvoiceData_Call{Call: _e.mock.On("DeleteUserInvoiceData", payment_request)}
+}
+
+func (_c *Database_DeleteUserInvoiceData_Call) Run(run func(payment_request string)) *Database_DeleteUserInvoiceData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_DeleteUserInvoiceData_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteUserInvoiceData_Call) RunAndReturn(run func(string) db.UserInvoiceData) *Database_DeleteUserInvoiceData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhookSigningSecret provides a mock function with given fields: source
+func (_m *Database) DeleteWebhookSigningSecret(source string) error {
+	ret := _m.Called(source)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhookSigningSecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(source)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteWebhookSigningSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhookSigningSecret'
+type Database_DeleteWebhookSigningSecret_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhookSigningSecret is a helper method to define mock.On call
+//   - source string
+func (_e *Database_Expecter) DeleteWebhookSigningSecret(source interface{}) *Database_DeleteWebhookSigningSecret_Call {
+	return &Database_DeleteWebhookSigningSecret_Call{Call: _e.mock.On("DeleteWebhookSigningSecret", source)}
+}
+
+func (_c *Database_DeleteWebhookSigningSecret_Call) Run(run func(source string)) *Database_DeleteWebhookSigningSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWebhookSigningSecret_Call) Return(_a0 error) *Database_DeleteWebhookSigningSecret_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWebhookSigningSecret_Call) RunAndReturn(run func(string) error) *Database_DeleteWebhookSigningSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkspaceBridge provides a mock function with given fields: workspaceUuid, uuid
+func (_m *Database) DeleteWorkspaceBridge(workspaceUuid string, uuid string) error {
+	ret := _m.Called(workspaceUuid, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspaceBridge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(workspaceUuid, uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteWorkspaceBridge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceBridge'
+type Database_DeleteWorkspaceBridge_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkspaceBridge is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - uuid string
+func (_e *Database_Expecter) DeleteWorkspaceBridge(workspaceUuid interface{}, uuid interface{}) *Database_DeleteWorkspaceBridge_Call {
+	return &Database_DeleteWorkspaceBridge_Call{Call: _e.mock.On("DeleteWorkspaceBridge", workspaceUuid, uuid)}
+}
+
+func (_c *Database_DeleteWorkspaceBridge_Call) Run(run func(workspaceUuid string, uuid string)) *Database_DeleteWorkspaceBridge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceBridge_Call) Return(_a0 error) *Database_DeleteWorkspaceBridge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceBridge_Call) RunAndReturn(run func(string, string) error) *Database_DeleteWorkspaceBridge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkspaceRepository provides a mock function with given fields: workspace_uuid, uuid
+func (_m *Database) DeleteWorkspaceRepository(workspace_uuid string, uuid string) bool {
+	ret := _m.Called(workspace_uuid, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspaceRepository")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(workspace_uuid, uuid)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Database_DeleteWorkspaceRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceRepository'
+type Database_DeleteWorkspaceRepository_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkspaceRepository is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - uuid string
+func (_e *Database_Expecter) DeleteWorkspaceRepository(workspace_uuid interface{}, uuid interface{}) *Database_DeleteWorkspaceRepository_Call {
+	return &Database_DeleteWorkspaceRepository_Call{Call: _e.mock.On("DeleteWorkspaceRepository", workspace_uuid, uuid)}
+}
+
+func (_c *Database_DeleteWorkspaceRepository_Call) Run(run func(workspace_uuid string, uuid string)) *Database_DeleteWorkspaceRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceRepository_Call) Return(_a0 bool) *Database_DeleteWorkspaceRepository_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceRepository_Call) RunAndReturn(run func(string, string) bool) *Database_DeleteWorkspaceRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkspaceTag provides a mock function with given fields: workspaceUuid, uuid
+func (_m *Database) DeleteWorkspaceTag(workspaceUuid string, uuid string) error {
+	ret := _m.Called(workspaceUuid, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspaceTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(workspaceUuid, uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteWorkspaceTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceTag'
+type Database_DeleteWorkspaceTag_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkspaceTag is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - uuid string
+func (_e *Database_Expecter) DeleteWorkspaceTag(workspaceUuid interface{}, uuid interface{}) *Database_DeleteWorkspaceTag_Call {
+	return &Database_DeleteWorkspaceTag_Call{Call: _e.mock.On("DeleteWorkspaceTag", workspaceUuid, uuid)}
+}
+
+func (_c *Database_DeleteWorkspaceTag_Call) Run(run func(workspaceUuid string, uuid string)) *Database_DeleteWorkspaceTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceTag_Call) Return(_a0 error) *Database_DeleteWorkspaceTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceTag_Call) RunAndReturn(run func(string, string) error) *Database_DeleteWorkspaceTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkspaceUser provides a mock function with given fields: orgUser, org
+func (_m *Database) DeleteWorkspaceUser(orgUser db.WorkspaceUsersData, org string) db.WorkspaceUsersData {
+	ret := _m.Called(orgUser, org)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspaceUser")
+	}
+
+	var r0 db.WorkspaceUsersData
+	if rf, ok := ret.Get(0).(func(db.WorkspaceUsersData, string) db.WorkspaceUsersData); ok {
+		r0 = rf(orgUser, org)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceUsersData)
+	}
+
+	return r0
+}
+
+// Database_DeleteWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceUser'
+type Database_DeleteWorkspaceUser_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkspaceUser is a helper method to define mock.On call
+//   - orgUser db.WorkspaceUsersData
+//   - org string
+func (_e *Database_Expecter) DeleteWorkspaceUser(orgUser interface{}, org interface{}) *Database_DeleteWorkspaceUser_Call {
+	return &Database_DeleteWorkspaceUser_Call{Call: _e.mock.On("DeleteWorkspaceUser", orgUser, org)}
+}
+
+func (_c *Database_DeleteWorkspaceUser_Call) Run(run func(orgUser db.WorkspaceUsersData, org string)) *Database_DeleteWorkspaceUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceUsersData), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceUser_Call) Return(_a0 db.WorkspaceUsersData) *Database_DeleteWorkspaceUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceUser_Call) RunAndReturn(run func(db.WorkspaceUsersData, string) db.WorkspaceUsersData) *Database_DeleteWorkspaceUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkspaceWebhook provides a mock function with given fields: workspaceUuid, uuid
+func (_m *Database) DeleteWorkspaceWebhook(workspaceUuid string, uuid string) error {
+	ret := _m.Called(workspaceUuid, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkspaceWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(workspaceUuid, uuid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteWorkspaceWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceWebhook'
+type Database_DeleteWorkspaceWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkspaceWebhook is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - uuid string
+func (_e *Database_Expecter) DeleteWorkspaceWebhook(workspaceUuid interface{}, uuid interface{}) *Database_DeleteWorkspaceWebhook_Call {
+	return &Database_DeleteWorkspaceWebhook_Call{Call: _e.mock.On("DeleteWorkspaceWebhook", workspaceUuid, uuid)}
+}
+
+func (_c *Database_DeleteWorkspaceWebhook_Call) Run(run func(workspaceUuid string, uuid string)) *Database_DeleteWorkspaceWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceWebhook_Call) Return(_a0 error) *Database_DeleteWorkspaceWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteWorkspaceWebhook_Call) RunAndReturn(run func(string, string) error) *Database_DeleteWorkspaceWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DropOnchainPayment provides a mock function with given fields: payment, dropped
+func (_m *Database) DropOnchainPayment(payment db.NewPaymentHistory, dropped time.Time) error {
+	ret := _m.Called(payment, dropped)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropOnchainPayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, time.Time) error); ok {
+		r0 = rf(payment, dropped)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DropOnchainPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropOnchainPayment'
+type Database_DropOnchainPayment_Call struct {
+	*mock.Call
+}
+
+// DropOnchainPayment is a helper method to define mock.On call
+//   - payment db.NewPaymentHistory
+//   - dropped time.Time
+func (_e *Database_Expecter) DropOnchainPayment(payment interface{}, dropped interface{}) *Database_DropOnchainPayment_Call {
+	return &Database_DropOnchainPayment_Call{Call: _e.mock.On("DropOnchainPayment", payment, dropped)}
+}
+
+func (_c *Database_DropOnchainPayment_Call) Run(run func(payment db.NewPaymentHistory, dropped time.Time)) *Database_DropOnchainPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewPaymentHistory), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_DropOnchainPayment_Call) Return(_a0 error) *Database_DropOnchainPayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DropOnchainPayment_Call) RunAndReturn(run func(db.NewPaymentHistory, time.Time) error) *Database_DropOnchainPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportWorkspace provides a mock function with given fields: workspaceUuid, archive
+func (_m *Database) ExportWorkspace(workspaceUuid string, archive io.Writer) (map[string]int, error) {
+	ret := _m.Called(workspaceUuid, archive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportWorkspace")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, io.Writer) (map[string]int, error)); ok {
+		return rf(workspaceUuid, archive)
+	}
+	if rf, ok := ret.Get(0).(func(string, io.Writer) map[string]int); ok {
+		r0 = rf(workspaceUuid, archive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, io.Writer) error); ok {
+		r1 = rf(workspaceUuid, archive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_ExportWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportWorkspace'
+type Database_ExportWorkspace_Call struct {
+	*mock.Call
+}
+
+// ExportWorkspace is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - archive io.Writer
+func (_e *Database_Expecter) ExportWorkspace(workspaceUuid interface{}, archive interface{}) *Database_ExportWorkspace_Call {
+	return &Database_ExportWorkspace_Call{Call: _e.mock.On("ExportWorkspace", workspaceUuid, archive)}
+}
+
+func (_c *Database_ExportWorkspace_Call) Run(run func(workspaceUuid string, archive io.Writer)) *Database_ExportWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *Database_ExportWorkspace_Call) Return(_a0 map[string]int, _a1 error) *Database_ExportWorkspace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_ExportWorkspace_Call) RunAndReturn(run func(string, io.Writer) (map[string]int, error)) *Database_ExportWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FollowShortLink provides a mock function with given fields: code, now
+func (_m *Database) FollowShortLink(code string, now time.Time) (db.ShortLink, error) {
+	ret := _m.Called(code, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FollowShortLink")
+	}
+
+	var r0 db.ShortLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (db.ShortLink, error)); ok {
+		return rf(code, now)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) db.ShortLink); ok {
+		r0 = rf(code, now)
+	} else {
+		r0 = ret.Get(0).(db.ShortLink)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(code, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_FollowShortLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FollowShortLink'
+type Database_FollowShortLink_Call struct {
+	*mock.Call
+}
+
+// FollowShortLink is a helper method to define mock.On call
+//   - code string
+//   - now time.Time
+func (_e *Database_Expecter) FollowShortLink(code interface{}, now interface{}) *Database_FollowShortLink_Call {
+	return &Database_FollowShortLink_Call{Call: _e.mock.On("FollowShortLink", code, now)}
+}
+
+func (_c *Database_FollowShortLink_Call) Run(run func(code string, now time.Time)) *Database_FollowShortLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_FollowShortLink_Call) Return(_a0 db.ShortLink, _a1 error) *Database_FollowShortLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_FollowShortLink_Call) RunAndReturn(run func(string, time.Time) (db.ShortLink, error)) *Database_FollowShortLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveAssignedBountiesCount provides a mock function with given fields: pubkey
+func (_m *Database) GetActiveAssignedBountiesCount(pubkey string) int64 {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveAssignedBountiesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetActiveAssignedBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveAssignedBountiesCount'
+type Database_GetActiveAssignedBountiesCount_Call struct {
+	*mock.Call
+}
+
+// GetActiveAssignedBountiesCount is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetActiveAssignedBountiesCount(pubkey interface{}) *Database_GetActiveAssignedBountiesCount_Call {
+	return &Database_GetActiveAssignedBountiesCount_Call{Call: _e.mock.On("GetActiveAssignedBountiesCount", pubkey)}
+}
+
+func (_c *Database_GetActiveAssignedBountiesCount_Call) Run(run func(pubkey string)) *Database_GetActiveAssignedBountiesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetActiveAssignedBountiesCount_Call) Return(_a0 int64) *Database_GetActiveAssignedBountiesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetActiveAssignedBountiesCount_Call) RunAndReturn(run func(string) int64) *Database_GetActiveAssignedBountiesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveBountyApplication provides a mock function with given fields: bountyId, pubkey
+func (_m *Database) GetActiveBountyApplication(bountyId uint, pubkey string) (db.BountyApplication, error) {
+	ret := _m.Called(bountyId, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveBountyApplication")
+	}
+
+	var r0 db.BountyApplication
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, string) (db.BountyApplication, error)); ok {
+		return rf(bountyId, pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(uint, string) db.BountyApplication); ok {
+		r0 = rf(bountyId, pubkey)
+	} else {
+		r0 = ret.Get(0).(db.BountyApplication)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, string) error); ok {
+		r1 = rf(bountyId, pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetActiveBountyApplication_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveBountyApplication'
+type Database_GetActiveBountyApplication_Call struct {
+	*mock.Call
+}
+
+// GetActiveBountyApplication is a helper method to define mock.On call
+//   - bountyId uint
+//   - pubkey string
+func (_e *Database_Expecter) GetActiveBountyApplication(bountyId interface{}, pubkey interface{}) *Database_GetActiveBountyApplication_Call {
+	return &Database_GetActiveBountyApplication_Call{Call: _e.mock.On("GetActiveBountyApplication", bountyId, pubkey)}
+}
+
+func (_c *Database_GetActiveBountyApplication_Call) Run(run func(bountyId uint, pubkey string)) *Database_GetActiveBountyApplication_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetActiveBountyApplication_Call) Return(_a0 db.BountyApplication, _a1 error) *Database_GetActiveBountyApplication_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetActiveBountyApplication_Call) RunAndReturn(run func(uint, string) (db.BountyApplication, error)) *Database_GetActiveBountyApplication_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAliasCollisions provides a mock function with given fields:
+func (_m *Database) GetAliasCollisions() ([]db.AliasCollision, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAliasCollisions")
+	}
+
+	var r0 []db.AliasCollision
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]db.AliasCollision, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []db.AliasCollision); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AliasCollision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAliasCollisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAliasCollisions'
+type Database_GetAliasCollisions_Call struct {
+	*mock.Call
+}
+
+// GetAliasCollisions is a helper method to define mock.On call
+func (_e *Database_Expecter) GetAliasCollisions() *Database_GetAliasCollisions_Call {
+	return &Database_GetAliasCollisions_Call{Call: _e.mock.On("GetAliasCollisions")}
+}
+
+func (_c *Database_GetAliasCollisions_Call) Run(run func()) *Database_GetAliasCollisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetAliasCollisions_Call) Return(_a0 []db.AliasCollision, _a1 error) *Database_GetAliasCollisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAliasCollisions_Call) RunAndReturn(run func() ([]db.AliasCollision, error)) *Database_GetAliasCollisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllBounties provides a mock function with given fields: r
+func (_m *Database) GetAllBounties(r *http.Request) []db.NewBounty {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetAllBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBounties'
+type Database_GetAllBounties_Call struct {
+	*mock.Call
+}
+
+// GetAllBounties is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetAllBounties(r interface{}) *Database_GetAllBounties_Call {
+	return &Database_GetAllBounties_Call{Call: _e.mock.On("GetAllBounties", r)}
+}
+
+func (_c *Database_GetAllBounties_Call) Run(run func(r *http.Request)) *Database_GetAllBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetAllBounties_Call) Return(_a0 []db.NewBounty) *Database_GetAllBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAllBounties_Call) RunAndReturn(run func(*http.Request) []db.NewBounty) *Database_GetAllBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllTribes provides a mock function with given fields:
+func (_m *Database) GetAllTribes() []db.Tribe {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllTribes")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func() []db.Tribe); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetAllTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTribes'
+type Database_GetAllTribes_Call struct {
+	*mock.Call
+}
+
+// GetAllTribes is a helper method to define mock.On call
+func (_e *Database_Expecter) GetAllTribes() *Database_GetAllTribes_Call {
+	return &Database_GetAllTribes_Call{Call: _e.mock.On("GetAllTribes")}
+}
+
+func (_c *Database_GetAllTribes_Call) Run(run func()) *Database_GetAllTribes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetAllTribes_Call) Return(_a0 []db.Tribe) *Database_GetAllTribes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAllTribes_Call) RunAndReturn(run func() []db.Tribe) *Database_GetAllTribes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllTribesByOwner provides a mock function with given fields: pubkey
+func (_m *Database) GetAllTribesByOwner(pubkey string) []db.Tribe {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllTribesByOwner")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetAllTribesByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTribesByOwner'
+type Database_GetAllTribesByOwner_Call struct {
+	*mock.Call
+}
+
+// GetAllTribesByOwner is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetAllTribesByOwner(pubkey interface{}) *Database_GetAllTribesByOwner_Call {
+	return &Database_GetAllTribesByOwner_Call{Call: _e.mock.On("GetAllTribesByOwner", pubkey)}
+}
+
+func (_c *Database_GetAllTribesByOwner_Call) Run(run func(pubkey string)) *Database_GetAllTribesByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetAllTribesByOwner_Call) Return(_a0 []db.Tribe) *Database_GetAllTribesByOwner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAllTribesByOwner_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetAllTribesByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllWorkspaceUsage provides a mock function with given fields: from, to
+func (_m *Database) GetAllWorkspaceUsage(from string, to string) []db.WorkspaceUsageDaily {
+	ret := _m.Called(from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllWorkspaceUsage")
+	}
+
+	var r0 []db.WorkspaceUsageDaily
+	if rf, ok := ret.Get(0).(func(string, string) []db.WorkspaceUsageDaily); ok {
+		r0 = rf(from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceUsageDaily)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetAllWorkspaceUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllWorkspaceUsage'
+type Database_GetAllWorkspaceUsage_Call struct {
+	*mock.Call
+}
+
+// GetAllWorkspaceUsage is a helper method to define mock.On call
+//   - from string
+//   - to string
+func (_e *Database_Expecter) GetAllWorkspaceUsage(from interface{}, to interface{}) *Database_GetAllWorkspaceUsage_Call {
+	return &Database_GetAllWorkspaceUsage_Call{Call: _e.mock.On("GetAllWorkspaceUsage", from, to)}
+}
+
+func (_c *Database_GetAllWorkspaceUsage_Call) Run(run func(from string, to string)) *Database_GetAllWorkspaceUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetAllWorkspaceUsage_Call) Return(_a0 []db.WorkspaceUsageDaily) *Database_GetAllWorkspaceUsage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetAllWorkspaceUsage_Call) RunAndReturn(run func(string, string) []db.WorkspaceUsageDaily) *Database_GetAllWorkspaceUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtifactByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetArtifactByUuid(uuid string) (db.Artifact, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactByUuid")
+	}
+
+	var r0 db.Artifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Artifact, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Artifact); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Artifact)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetArtifactByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtifactByUuid'
+type Database_GetArtifactByUuid_Call struct {
+	*mock.Call
+}
+
+// GetArtifactByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetArtifactByUuid(uuid interface{}) *Database_GetArtifactByUuid_Call {
+	return &Database_GetArtifactByUuid_Call{Call: _e.mock.On("GetArtifactByUuid", uuid)}
+}
+
+func (_c *Database_GetArtifactByUuid_Call) Run(run func(uuid string)) *Database_GetArtifactByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetArtifactByUuid_Call) Return(_a0 db.Artifact, _a1 error) *Database_GetArtifactByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetArtifactByUuid_Call) RunAndReturn(run func(string) (db.Artifact, error)) *Database_GetArtifactByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtifactVersions provides a mock function with given fields: uuid
+func (_m *Database) GetArtifactVersions(uuid string) []db.ArtifactVersion {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactVersions")
+	}
+
+	var r0 []db.ArtifactVersion
+	if rf, ok := ret.Get(0).(func(string) []db.ArtifactVersion); ok {
+		r0 = rf(uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ArtifactVersion)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetArtifactVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtifactVersions'
+type Database_GetArtifactVersions_Call struct {
+	*mock.Call
+}
+
+// GetArtifactVersions is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetArtifactVersions(uuid interface{}) *Database_GetArtifactVersions_Call {
+	return &Database_GetArtifactVersions_Call{Call: _e.mock.On("GetArtifactVersions", uuid)}
+}
+
+func (_c *Database_GetArtifactVersions_Call) Run(run func(uuid string)) *Database_GetArtifactVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetArtifactVersions_Call) Return(_a0 []db.ArtifactVersion) *Database_GetArtifactVersions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetArtifactVersions_Call) RunAndReturn(run func(string) []db.ArtifactVersion) *Database_GetArtifactVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtifacts provides a mock function with given fields: filter
+func (_m *Database) GetArtifacts(filter db.ArtifactFilter) []db.Artifact {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifacts")
+	}
+
+	var r0 []db.Artifact
+	if rf, ok := ret.Get(0).(func(db.ArtifactFilter) []db.Artifact); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Artifact)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetArtifacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtifacts'
+type Database_GetArtifacts_Call struct {
+	*mock.Call
+}
+
+// GetArtifacts is a helper method to define mock.On call
+//   - filter db.ArtifactFilter
+func (_e *Database_Expecter) GetArtifacts(filter interface{}) *Database_GetArtifacts_Call {
+	return &Database_GetArtifacts_Call{Call: _e.mock.On("GetArtifacts", filter)}
+}
+
+func (_c *Database_GetArtifacts_Call) Run(run func(filter db.ArtifactFilter)) *Database_GetArtifacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.ArtifactFilter))
+	})
+	return _c
+}
+
+func (_c *Database_GetArtifacts_Call) Return(_a0 []db.Artifact) *Database_GetArtifacts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetArtifacts_Call) RunAndReturn(run func(db.ArtifactFilter) []db.Artifact) *Database_GetArtifacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssignedBounties provides a mock function with given fields: r
+func (_m *Database) GetAssignedBounties(r *http.Request) ([]db.NewBounty, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssignedBounties")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.NewBounty, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAssignedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignedBounties'
+type Database_GetAssignedBounties_Call struct {
+	*mock.Call
+}
+
+// GetAssignedBounties is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetAssignedBounties(r interface{}) *Database_GetAssignedBounties_Call {
+	return &Database_GetAssignedBounties_Call{Call: _e.mock.On("GetAssignedBounties", r)}
+}
+
+func (_c *Database_GetAssignedBounties_Call) Run(run func(r *http.Request)) *Database_GetAssignedBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetAssignedBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetAssignedBounties_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAssignedBounties_Call) RunAndReturn(run func(*http.Request) ([]db.NewBounty, error)) *Database_GetAssignedBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBadgeIssuances provides a mock function with given fields: tribeUuid
+func (_m *Database) GetBadgeIssuances(tribeUuid string) []db.BadgeIssuance {
+	ret := _m.Called(tribeUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBadgeIssuances")
+	}
+
+	var r0 []db.BadgeIssuance
+	if rf, ok := ret.Get(0).(func(string) []db.BadgeIssuance); ok {
+		r0 = rf(tribeUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BadgeIssuance)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBadgeIssuances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBadgeIssuances'
+type Database_GetBadgeIssuances_Call struct {
+	*mock.Call
+}
+
+// GetBadgeIssuances is a helper method to define mock.On call
+//   - tribeUuid string
+func (_e *Database_Expecter) GetBadgeIssuances(tribeUuid interface{}) *Database_GetBadgeIssuances_Call {
+	return &Database_GetBadgeIssuances_Call{Call: _e.mock.On("GetBadgeIssuances", tribeUuid)}
+}
+
+func (_c *Database_GetBadgeIssuances_Call) Run(run func(tribeUuid string)) *Database_GetBadgeIssuances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBadgeIssuances_Call) Return(_a0 []db.BadgeIssuance) *Database_GetBadgeIssuances_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBadgeIssuances_Call) RunAndReturn(run func(string) []db.BadgeIssuance) *Database_GetBadgeIssuances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBot provides a mock function with given fields: uuid
+func (_m *Database) GetBot(uuid string) db.Bot {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBot")
+	}
+
+	var r0 db.Bot
+	if rf, ok := ret.Get(0).(func(string) db.Bot); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Bot)
+	}
+
+	return r0
+}
+
+// Database_GetBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBot'
+type Database_GetBot_Call struct {
+	*mock.Call
+}
+
+// GetBot is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetBot(uuid interface{}) *Database_GetBot_Call {
+	return &Database_GetBot_Call{Call: _e.mock.On("GetBot", uuid)}
+}
+
+func (_c *Database_GetBot_Call) Run(run func(uuid string)) *Database_GetBot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBot_Call) Return(_a0 db.Bot) *Database_GetBot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBot_Call) RunAndReturn(run func(string) db.Bot) *Database_GetBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotByUniqueName provides a mock function with given fields: un
+func (_m *Database) GetBotByUniqueName(un string) db.Bot {
+	ret := _m.Called(un)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotByUniqueName")
+	}
+
+	var r0 db.Bot
+	if rf, ok := ret.Get(0).(func(string) db.Bot); ok {
+		r0 = rf(un)
+	} else {
+		r0 = ret.Get(0).(db.Bot)
+	}
+
+	return r0
+}
+
+// Database_GetBotByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotByUniqueName'
+type Database_GetBotByUniqueName_Call struct {
+	*mock.Call
+}
+
+// GetBotByUniqueName is a helper method to define mock.On call
+//   - un string
+func (_e *Database_Expecter) GetBotByUniqueName(un interface{}) *Database_GetBotByUniqueName_Call {
+	return &Database_GetBotByUniqueName_Call{Call: _e.mock.On("GetBotByUniqueName", un)}
+}
+
+func (_c *Database_GetBotByUniqueName_Call) Run(run func(un string)) *Database_GetBotByUniqueName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotByUniqueName_Call) Return(_a0 db.Bot) *Database_GetBotByUniqueName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotByUniqueName_Call) RunAndReturn(run func(string) db.Bot) *Database_GetBotByUniqueName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBotsByOwner provides a mock function with given fields: pubkey
+func (_m *Database) GetBotsByOwner(pubkey string) []db.Bot {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBotsByOwner")
+	}
+
+	var r0 []db.Bot
+	if rf, ok := ret.Get(0).(func(string) []db.Bot); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Bot)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBotsByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotsByOwner'
+type Database_GetBotsByOwner_Call struct {
+	*mock.Call
+}
+
+// GetBotsByOwner is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetBotsByOwner(pubkey interface{}) *Database_GetBotsByOwner_Call {
+	return &Database_GetBotsByOwner_Call{Call: _e.mock.On("GetBotsByOwner", pubkey)}
+}
+
+func (_c *Database_GetBotsByOwner_Call) Run(run func(pubkey string)) *Database_GetBotsByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBotsByOwner_Call) Return(_a0 []db.Bot) *Database_GetBotsByOwner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBotsByOwner_Call) RunAndReturn(run func(string) []db.Bot) *Database_GetBotsByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByDateRange provides a mock function with given fields: r, re
+func (_m *Database) GetBountiesByDateRange(r db.PaymentDateRange, re *http.Request) []db.NewBounty {
+	ret := _m.Called(r, re)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByDateRange")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.NewBounty); ok {
+		r0 = rf(r, re)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesByDateRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByDateRange'
+type Database_GetBountiesByDateRange_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByDateRange is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - re *http.Request
+func (_e *Database_Expecter) GetBountiesByDateRange(r interface{}, re interface{}) *Database_GetBountiesByDateRange_Call {
+	return &Database_GetBountiesByDateRange_Call{Call: _e.mock.On("GetBountiesByDateRange", r, re)}
+}
+
+func (_c *Database_GetBountiesByDateRange_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesByDateRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByDateRange_Call) Return(_a0 []db.NewBounty) *Database_GetBountiesByDateRange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesByDateRange_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) []db.NewBounty) *Database_GetBountiesByDateRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByDateRangeCount provides a mock function with given fields: r, re
+func (_m *Database) GetBountiesByDateRangeCount(r db.PaymentDateRange, re *http.Request) int64 {
+	ret := _m.Called(r, re)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByDateRangeCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) int64); ok {
+		r0 = rf(r, re)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetBountiesByDateRangeCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByDateRangeCount'
+type Database_GetBountiesByDateRangeCount_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByDateRangeCount is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - re *http.Request
+func (_e *Database_Expecter) GetBountiesByDateRangeCount(r interface{}, re interface{}) *Database_GetBountiesByDateRangeCount_Call {
+	return &Database_GetBountiesByDateRangeCount_Call{Call: _e.mock.On("GetBountiesByDateRangeCount", r, re)}
+}
+
+func (_c *Database_GetBountiesByDateRangeCount_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesByDateRangeCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByDateRangeCount_Call) Return(_a0 int64) *Database_GetBountiesByDateRangeCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesByDateRangeCount_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) int64) *Database_GetBountiesByDateRangeCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByFeatureAndPhaseUuid provides a mock function with given fields: featureUuid, phaseUuid, r
+func (_m *Database) GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) ([]db.NewBounty, error) {
+	ret := _m.Called(featureUuid, phaseUuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByFeatureAndPhaseUuid")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, *http.Request) ([]db.NewBounty, error)); ok {
+		return rf(featureUuid, phaseUuid, r)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, *http.Request) []db.NewBounty); ok {
+		r0 = rf(featureUuid, phaseUuid, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, *http.Request) error); ok {
+		r1 = rf(featureUuid, phaseUuid, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountiesByFeatureAndPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByFeatureAndPhaseUuid'
+type Database_GetBountiesByFeatureAndPhaseUuid_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByFeatureAndPhaseUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetBountiesByFeatureAndPhaseUuid(featureUuid interface{}, phaseUuid interface{}, r interface{}) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+	return &Database_GetBountiesByFeatureAndPhaseUuid_Call{Call: _e.mock.On("GetBountiesByFeatureAndPhaseUuid", featureUuid, phaseUuid, r)}
+}
+
+func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) Run(run func(featureUuid string, phaseUuid string, r *http.Request)) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) RunAndReturn(run func(string, string, *http.Request) ([]db.NewBounty, error)) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesByPhaseUuid provides a mock function with given fields: phaseUuid
+func (_m *Database) GetBountiesByPhaseUuid(phaseUuid string) []db.Bounty {
+	ret := _m.Called(phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesByPhaseUuid")
+	}
+
+	var r0 []db.Bounty
+	if rf, ok := ret.Get(0).(func(string) []db.Bounty); ok {
+		r0 = rf(phaseUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Bounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesByPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByPhaseUuid'
+type Database_GetBountiesByPhaseUuid_Call struct {
+	*mock.Call
+}
+
+// GetBountiesByPhaseUuid is a helper method to define mock.On call
+//   - phaseUuid string
+func (_e *Database_Expecter) GetBountiesByPhaseUuid(phaseUuid interface{}) *Database_GetBountiesByPhaseUuid_Call {
+	return &Database_GetBountiesByPhaseUuid_Call{Call: _e.mock.On("GetBountiesByPhaseUuid", phaseUuid)}
+}
+
+func (_c *Database_GetBountiesByPhaseUuid_Call) Run(run func(phaseUuid string)) *Database_GetBountiesByPhaseUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesByPhaseUuid_Call) Return(_a0 []db.Bounty) *Database_GetBountiesByPhaseUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesByPhaseUuid_Call) RunAndReturn(run func(string) []db.Bounty) *Database_GetBountiesByPhaseUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesCount provides a mock function with given fields: r
+func (_m *Database) GetBountiesCount(r *http.Request) int64 {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesCount'
+type Database_GetBountiesCount_Call struct {
+	*mock.Call
+}
+
+// GetBountiesCount is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetBountiesCount(r interface{}) *Database_GetBountiesCount_Call {
+	return &Database_GetBountiesCount_Call{Call: _e.mock.On("GetBountiesCount", r)}
+}
+
+func (_c *Database_GetBountiesCount_Call) Run(run func(r *http.Request)) *Database_GetBountiesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesCount_Call) Return(_a0 int64) *Database_GetBountiesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesCount_Call) RunAndReturn(run func(*http.Request) int64) *Database_GetBountiesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesCountByFeatureAndPhaseUuid provides a mock function with given fields: featureUuid, phaseUuid, r
+func (_m *Database) GetBountiesCountByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) int64 {
+	ret := _m.Called(featureUuid, phaseUuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesCountByFeatureAndPhaseUuid")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string, *http.Request) int64); ok {
+		r0 = rf(featureUuid, phaseUuid, r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetBountiesCountByFeatureAndPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesCountByFeatureAndPhaseUuid'
+type Database_GetBountiesCountByFeatureAndPhaseUuid_Call struct {
+	*mock.Call
+}
+
+// GetBountiesCountByFeatureAndPhaseUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetBountiesCountByFeatureAndPhaseUuid(featureUuid interface{}, phaseUuid interface{}, r interface{}) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+	return &Database_GetBountiesCountByFeatureAndPhaseUuid_Call{Call: _e.mock.On("GetBountiesCountByFeatureAndPhaseUuid", featureUuid, phaseUuid, r)}
+}
+
+func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) Run(run func(featureUuid string, phaseUuid string, r *http.Request)) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) Return(_a0 int64) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) RunAndReturn(run func(string, string, *http.Request) int64) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesDueBetween provides a mock function with given fields: from, to
+func (_m *Database) GetBountiesDueBetween(from time.Time, to time.Time) []db.NewBounty {
+	ret := _m.Called(from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesDueBetween")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []db.NewBounty); ok {
+		r0 = rf(from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesDueBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesDueBetween'
+type Database_GetBountiesDueBetween_Call struct {
+	*mock.Call
+}
+
+// GetBountiesDueBetween is a helper method to define mock.On call
+//   - from time.Time
+//   - to time.Time
+func (_e *Database_Expecter) GetBountiesDueBetween(from interface{}, to interface{}) *Database_GetBountiesDueBetween_Call {
+	return &Database_GetBountiesDueBetween_Call{Call: _e.mock.On("GetBountiesDueBetween", from, to)}
+}
+
+func (_c *Database_GetBountiesDueBetween_Call) Run(run func(from time.Time, to time.Time)) *Database_GetBountiesDueBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesDueBetween_Call) Return(_a0 []db.NewBounty) *Database_GetBountiesDueBetween_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesDueBetween_Call) RunAndReturn(run func(time.Time, time.Time) []db.NewBounty) *Database_GetBountiesDueBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesLeaderboard provides a mock function with given fields:
+func (_m *Database) GetBountiesLeaderboard() []db.LeaderData {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesLeaderboard")
+	}
+
+	var r0 []db.LeaderData
+	if rf, ok := ret.Get(0).(func() []db.LeaderData); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LeaderData)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesLeaderboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesLeaderboard'
+type Database_GetBountiesLeaderboard_Call struct {
+	*mock.Call
+}
+
+// GetBountiesLeaderboard is a helper method to define mock.On call
+func (_e *Database_Expecter) GetBountiesLeaderboard() *Database_GetBountiesLeaderboard_Call {
+	return &Database_GetBountiesLeaderboard_Call{Call: _e.mock.On("GetBountiesLeaderboard")}
+}
+
+func (_c *Database_GetBountiesLeaderboard_Call) Run(run func()) *Database_GetBountiesLeaderboard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesLeaderboard_Call) Return(_a0 []db.LeaderData) *Database_GetBountiesLeaderboard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesLeaderboard_Call) RunAndReturn(run func() []db.LeaderData) *Database_GetBountiesLeaderboard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountiesProviders provides a mock function with given fields: r, re
+func (_m *Database) GetBountiesProviders(r db.PaymentDateRange, re *http.Request) []db.Person {
+	ret := _m.Called(r, re)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountiesProviders")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.Person); ok {
+		r0 = rf(r, re)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountiesProviders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesProviders'
+type Database_GetBountiesProviders_Call struct {
+	*mock.Call
+}
+
+// GetBountiesProviders is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - re *http.Request
+func (_e *Database_Expecter) GetBountiesProviders(r interface{}, re interface{}) *Database_GetBountiesProviders_Call {
+	return &Database_GetBountiesProviders_Call{Call: _e.mock.On("GetBountiesProviders", r, re)}
+}
+
+func (_c *Database_GetBountiesProviders_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesProviders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountiesProviders_Call) Return(_a0 []db.Person) *Database_GetBountiesProviders_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountiesProviders_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) []db.Person) *Database_GetBountiesProviders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBounty provides a mock function with given fields: id
+func (_m *Database) GetBounty(id uint) db.NewBounty {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBounty")
+	}
+
+	var r0 db.NewBounty
+	if rf, ok := ret.Get(0).(func(uint) db.NewBounty); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	return r0
+}
+
+// Database_GetBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBounty'
+type Database_GetBounty_Call struct {
+	*mock.Call
+}
+
+// GetBounty is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetBounty(id interface{}) *Database_GetBounty_Call {
+	return &Database_GetBounty_Call{Call: _e.mock.On("GetBounty", id)}
+}
+
+func (_c *Database_GetBounty_Call) Run(run func(id uint)) *Database_GetBounty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBounty_Call) Return(_a0 db.NewBounty) *Database_GetBounty_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBounty_Call) RunAndReturn(run func(uint) db.NewBounty) *Database_GetBounty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyApplicationById provides a mock function with given fields: id
+func (_m *Database) GetBountyApplicationById(id uint) (db.BountyApplication, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyApplicationById")
+	}
+
+	var r0 db.BountyApplication
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (db.BountyApplication, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) db.BountyApplication); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.BountyApplication)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyApplicationById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyApplicationById'
+type Database_GetBountyApplicationById_Call struct {
+	*mock.Call
+}
+
+// GetBountyApplicationById is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetBountyApplicationById(id interface{}) *Database_GetBountyApplicationById_Call {
+	return &Database_GetBountyApplicationById_Call{Call: _e.mock.On("GetBountyApplicationById", id)}
+}
+
+func (_c *Database_GetBountyApplicationById_Call) Run(run func(id uint)) *Database_GetBountyApplicationById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyApplicationById_Call) Return(_a0 db.BountyApplication, _a1 error) *Database_GetBountyApplicationById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyApplicationById_Call) RunAndReturn(run func(uint) (db.BountyApplication, error)) *Database_GetBountyApplicationById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyApplications provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyApplications(bountyId uint) []db.BountyApplication {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyApplications")
+	}
+
+	var r0 []db.BountyApplication
+	if rf, ok := ret.Get(0).(func(uint) []db.BountyApplication); ok {
+		r0 = rf(bountyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyApplication)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyApplications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyApplications'
+type Database_GetBountyApplications_Call struct {
+	*mock.Call
+}
+
+// GetBountyApplications is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyApplications(bountyId interface{}) *Database_GetBountyApplications_Call {
+	return &Database_GetBountyApplications_Call{Call: _e.mock.On("GetBountyApplications", bountyId)}
+}
+
+func (_c *Database_GetBountyApplications_Call) Run(run func(bountyId uint)) *Database_GetBountyApplications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyApplications_Call) Return(_a0 []db.BountyApplication) *Database_GetBountyApplications_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyApplications_Call) RunAndReturn(run func(uint) []db.BountyApplication) *Database_GetBountyApplications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyAssigneeHistory provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyAssigneeHistory(bountyId uint) []db.BountyAssigneeHistory {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyAssigneeHistory")
+	}
+
+	var r0 []db.BountyAssigneeHistory
+	if rf, ok := ret.Get(0).(func(uint) []db.BountyAssigneeHistory); ok {
+		r0 = rf(bountyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyAssigneeHistory)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyAssigneeHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyAssigneeHistory'
+type Database_GetBountyAssigneeHistory_Call struct {
+	*mock.Call
+}
+
+// GetBountyAssigneeHistory is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyAssigneeHistory(bountyId interface{}) *Database_GetBountyAssigneeHistory_Call {
+	return &Database_GetBountyAssigneeHistory_Call{Call: _e.mock.On("GetBountyAssigneeHistory", bountyId)}
+}
+
+func (_c *Database_GetBountyAssigneeHistory_Call) Run(run func(bountyId uint)) *Database_GetBountyAssigneeHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyAssigneeHistory_Call) Return(_a0 []db.BountyAssigneeHistory) *Database_GetBountyAssigneeHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyAssigneeHistory_Call) RunAndReturn(run func(uint) []db.BountyAssigneeHistory) *Database_GetBountyAssigneeHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyAuction provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyAuction(bountyId uint) (db.BountyAuction, error) {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyAuction")
+	}
+
+	var r0 db.BountyAuction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (db.BountyAuction, error)); ok {
+		return rf(bountyId)
+	}
+	if rf, ok := ret.Get(0).(func(uint) db.BountyAuction); ok {
+		r0 = rf(bountyId)
+	} else {
+		r0 = ret.Get(0).(db.BountyAuction)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(bountyId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyAuction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyAuction'
+type Database_GetBountyAuction_Call struct {
+	*mock.Call
+}
+
+// GetBountyAuction is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyAuction(bountyId interface{}) *Database_GetBountyAuction_Call {
+	return &Database_GetBountyAuction_Call{Call: _e.mock.On("GetBountyAuction", bountyId)}
+}
+
+func (_c *Database_GetBountyAuction_Call) Run(run func(bountyId uint)) *Database_GetBountyAuction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyAuction_Call) Return(_a0 db.BountyAuction, _a1 error) *Database_GetBountyAuction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyAuction_Call) RunAndReturn(run func(uint) (db.BountyAuction, error)) *Database_GetBountyAuction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyBidById provides a mock function with given fields: id
+func (_m *Database) GetBountyBidById(id uint) (db.BountyBid, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyBidById")
+	}
+
+	var r0 db.BountyBid
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (db.BountyBid, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) db.BountyBid); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.BountyBid)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyBidById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyBidById'
+type Database_GetBountyBidById_Call struct {
+	*mock.Call
+}
+
+// GetBountyBidById is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetBountyBidById(id interface{}) *Database_GetBountyBidById_Call {
+	return &Database_GetBountyBidById_Call{Call: _e.mock.On("GetBountyBidById", id)}
+}
+
+func (_c *Database_GetBountyBidById_Call) Run(run func(id uint)) *Database_GetBountyBidById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyBidById_Call) Return(_a0 db.BountyBid, _a1 error) *Database_GetBountyBidById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyBidById_Call) RunAndReturn(run func(uint) (db.BountyBid, error)) *Database_GetBountyBidById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyBids provides a mock function with given fields: auctionId
+func (_m *Database) GetBountyBids(auctionId uint) []db.BountyBid {
+	ret := _m.Called(auctionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyBids")
+	}
+
+	var r0 []db.BountyBid
+	if rf, ok := ret.Get(0).(func(uint) []db.BountyBid); ok {
+		r0 = rf(auctionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyBid)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyBids_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyBids'
+type Database_GetBountyBids_Call struct {
+	*mock.Call
+}
+
+// GetBountyBids is a helper method to define mock.On call
+//   - auctionId uint
+func (_e *Database_Expecter) GetBountyBids(auctionId interface{}) *Database_GetBountyBids_Call {
+	return &Database_GetBountyBids_Call{Call: _e.mock.On("GetBountyBids", auctionId)}
+}
+
+func (_c *Database_GetBountyBids_Call) Run(run func(auctionId uint)) *Database_GetBountyBids_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyBids_Call) Return(_a0 []db.BountyBid) *Database_GetBountyBids_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyBids_Call) RunAndReturn(run func(uint) []db.BountyBid) *Database_GetBountyBids_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyByCreated provides a mock function with given fields: created
+func (_m *Database) GetBountyByCreated(created uint) (db.NewBounty, error) {
+	ret := _m.Called(created)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyByCreated")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (db.NewBounty, error)); ok {
+		return rf(created)
+	}
+	if rf, ok := ret.Get(0).(func(uint) db.NewBounty); ok {
+		r0 = rf(created)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(created)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyByCreated'
+type Database_GetBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetBountyByCreated is a helper method to define mock.On call
+//   - created uint
+func (_e *Database_Expecter) GetBountyByCreated(created interface{}) *Database_GetBountyByCreated_Call {
+	return &Database_GetBountyByCreated_Call{Call: _e.mock.On("GetBountyByCreated", created)}
+}
+
+func (_c *Database_GetBountyByCreated_Call) Run(run func(created uint)) *Database_GetBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyByCreated_Call) Return(_a0 db.NewBounty, _a1 error) *Database_GetBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyByCreated_Call) RunAndReturn(run func(uint) (db.NewBounty, error)) *Database_GetBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyById provides a mock function with given fields: id
+func (_m *Database) GetBountyById(id string) ([]db.NewBounty, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyById")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.NewBounty, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyById'
+type Database_GetBountyById_Call struct {
+	*mock.Call
+}
+
+// GetBountyById is a helper method to define mock.On call
+//   - id string
+func (_e *Database_Expecter) GetBountyById(id interface{}) *Database_GetBountyById_Call {
+	return &Database_GetBountyById_Call{Call: _e.mock.On("GetBountyById", id)}
+}
+
+func (_c *Database_GetBountyById_Call) Run(run func(id string)) *Database_GetBountyById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyById_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountyById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyById_Call) RunAndReturn(run func(string) ([]db.NewBounty, error)) *Database_GetBountyById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyComment provides a mock function with given fields: bountyId, id
+func (_m *Database) GetBountyComment(bountyId uint, id uint) (db.BountyComment, error) {
+	ret := _m.Called(bountyId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyComment")
+	}
+
+	var r0 db.BountyComment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (db.BountyComment, error)); ok {
+		return rf(bountyId, id)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) db.BountyComment); ok {
+		r0 = rf(bountyId, id)
+	} else {
+		r0 = ret.Get(0).(db.BountyComment)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(bountyId, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyComment'
+type Database_GetBountyComment_Call struct {
+	*mock.Call
+}
+
+// GetBountyComment is a helper method to define mock.On call
+//   - bountyId uint
+//   - id uint
+func (_e *Database_Expecter) GetBountyComment(bountyId interface{}, id interface{}) *Database_GetBountyComment_Call {
+	return &Database_GetBountyComment_Call{Call: _e.mock.On("GetBountyComment", bountyId, id)}
+}
+
+func (_c *Database_GetBountyComment_Call) Run(run func(bountyId uint, id uint)) *Database_GetBountyComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyComment_Call) Return(_a0 db.BountyComment, _a1 error) *Database_GetBountyComment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyComment_Call) RunAndReturn(run func(uint, uint) (db.BountyComment, error)) *Database_GetBountyComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyComments provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyComments(bountyId uint) []db.BountyComment {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyComments")
+	}
+
+	var r0 []db.BountyComment
+	if rf, ok := ret.Get(0).(func(uint) []db.BountyComment); ok {
+		r0 = rf(bountyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyComment)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyComments'
+type Database_GetBountyComments_Call struct {
+	*mock.Call
+}
+
+// GetBountyComments is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyComments(bountyId interface{}) *Database_GetBountyComments_Call {
+	return &Database_GetBountyComments_Call{Call: _e.mock.On("GetBountyComments", bountyId)}
+}
+
+func (_c *Database_GetBountyComments_Call) Run(run func(bountyId uint)) *Database_GetBountyComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyComments_Call) Return(_a0 []db.BountyComment) *Database_GetBountyComments_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyComments_Call) RunAndReturn(run func(uint) []db.BountyComment) *Database_GetBountyComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyDataByCreated provides a mock function with given fields: created
+func (_m *Database) GetBountyDataByCreated(created string) ([]db.NewBounty, error) {
+	ret := _m.Called(created)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyDataByCreated")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.NewBounty, error)); ok {
+		return rf(created)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(created)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(created)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyDataByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyDataByCreated'
+type Database_GetBountyDataByCreated_Call struct {
+	*mock.Call
+}
+
+// GetBountyDataByCreated is a helper method to define mock.On call
+//   - created string
+func (_e *Database_Expecter) GetBountyDataByCreated(created interface{}) *Database_GetBountyDataByCreated_Call {
+	return &Database_GetBountyDataByCreated_Call{Call: _e.mock.On("GetBountyDataByCreated", created)}
+}
+
+func (_c *Database_GetBountyDataByCreated_Call) Run(run func(created string)) *Database_GetBountyDataByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyDataByCreated_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountyDataByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyDataByCreated_Call) RunAndReturn(run func(string) ([]db.NewBounty, error)) *Database_GetBountyDataByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyHoursLogs provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyHoursLogs(bountyId uint) []db.BountyHoursLog {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyHoursLogs")
+	}
+
+	var r0 []db.BountyHoursLog
+	if rf, ok := ret.Get(0).(func(uint) []db.BountyHoursLog); ok {
+		r0 = rf(bountyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyHoursLog)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyHoursLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyHoursLogs'
+type Database_GetBountyHoursLogs_Call struct {
+	*mock.Call
+}
+
+// GetBountyHoursLogs is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyHoursLogs(bountyId interface{}) *Database_GetBountyHoursLogs_Call {
+	return &Database_GetBountyHoursLogs_Call{Call: _e.mock.On("GetBountyHoursLogs", bountyId)}
+}
+
+func (_c *Database_GetBountyHoursLogs_Call) Run(run func(bountyId uint)) *Database_GetBountyHoursLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyHoursLogs_Call) Return(_a0 []db.BountyHoursLog) *Database_GetBountyHoursLogs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyHoursLogs_Call) RunAndReturn(run func(uint) []db.BountyHoursLog) *Database_GetBountyHoursLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyIndexById provides a mock function with given fields: id
+func (_m *Database) GetBountyIndexById(id string) int64 {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyIndexById")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetBountyIndexById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyIndexById'
+type Database_GetBountyIndexById_Call struct {
+	*mock.Call
+}
+
+// GetBountyIndexById is a helper method to define mock.On call
+//   - id string
+func (_e *Database_Expecter) GetBountyIndexById(id interface{}) *Database_GetBountyIndexById_Call {
+	return &Database_GetBountyIndexById_Call{Call: _e.mock.On("GetBountyIndexById", id)}
+}
+
+func (_c *Database_GetBountyIndexById_Call) Run(run func(id string)) *Database_GetBountyIndexById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyIndexById_Call) Return(_a0 int64) *Database_GetBountyIndexById_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyIndexById_Call) RunAndReturn(run func(string) int64) *Database_GetBountyIndexById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyNostrEvents provides a mock function with given fields: bountyID
+func (_m *Database) GetBountyNostrEvents(bountyID uint) []db.NostrEvent {
+	ret := _m.Called(bountyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyNostrEvents")
+	}
+
+	var r0 []db.NostrEvent
+	if rf, ok := ret.Get(0).(func(uint) []db.NostrEvent); ok {
+		r0 = rf(bountyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NostrEvent)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyNostrEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyNostrEvents'
+type Database_GetBountyNostrEvents_Call struct {
+	*mock.Call
+}
+
+// GetBountyNostrEvents is a helper method to define mock.On call
+//   - bountyID uint
+func (_e *Database_Expecter) GetBountyNostrEvents(bountyID interface{}) *Database_GetBountyNostrEvents_Call {
+	return &Database_GetBountyNostrEvents_Call{Call: _e.mock.On("GetBountyNostrEvents", bountyID)}
+}
+
+func (_c *Database_GetBountyNostrEvents_Call) Run(run func(bountyID uint)) *Database_GetBountyNostrEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyNostrEvents_Call) Return(_a0 []db.NostrEvent) *Database_GetBountyNostrEvents_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyNostrEvents_Call) RunAndReturn(run func(uint) []db.NostrEvent) *Database_GetBountyNostrEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyPriceStats provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetBountyPriceStats(workspaceUuid string) []db.BountyPriceStat {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyPriceStats")
+	}
+
+	var r0 []db.BountyPriceStat
+	if rf, ok := ret.Get(0).(func(string) []db.BountyPriceStat); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyPriceStat)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyPriceStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyPriceStats'
+type Database_GetBountyPriceStats_Call struct {
+	*mock.Call
+}
+
+// GetBountyPriceStats is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetBountyPriceStats(workspaceUuid interface{}) *Database_GetBountyPriceStats_Call {
+	return &Database_GetBountyPriceStats_Call{Call: _e.mock.On("GetBountyPriceStats", workspaceUuid)}
+}
+
+func (_c *Database_GetBountyPriceStats_Call) Run(run func(workspaceUuid string)) *Database_GetBountyPriceStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyPriceStats_Call) Return(_a0 []db.BountyPriceStat) *Database_GetBountyPriceStats_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyPriceStats_Call) RunAndReturn(run func(string) []db.BountyPriceStat) *Database_GetBountyPriceStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyReceipt provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyReceipt(bountyId uint) (db.BountyReceipt, error) {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyReceipt")
+	}
+
+	var r0 db.BountyReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (db.BountyReceipt, error)); ok {
+		return rf(bountyId)
+	}
+	if rf, ok := ret.Get(0).(func(uint) db.BountyReceipt); ok {
+		r0 = rf(bountyId)
+	} else {
+		r0 = ret.Get(0).(db.BountyReceipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(bountyId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBountyReceipt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyReceipt'
+type Database_GetBountyReceipt_Call struct {
+	*mock.Call
+}
+
+// GetBountyReceipt is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyReceipt(bountyId interface{}) *Database_GetBountyReceipt_Call {
+	return &Database_GetBountyReceipt_Call{Call: _e.mock.On("GetBountyReceipt", bountyId)}
+}
+
+func (_c *Database_GetBountyReceipt_Call) Run(run func(bountyId uint)) *Database_GetBountyReceipt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyReceipt_Call) Return(_a0 db.BountyReceipt, _a1 error) *Database_GetBountyReceipt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBountyReceipt_Call) RunAndReturn(run func(uint) (db.BountyReceipt, error)) *Database_GetBountyReceipt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyRoles provides a mock function with given fields:
+func (_m *Database) GetBountyRoles() []db.BountyRoles {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyRoles")
+	}
+
+	var r0 []db.BountyRoles
+	if rf, ok := ret.Get(0).(func() []db.BountyRoles); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyRoles)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyRoles'
+type Database_GetBountyRoles_Call struct {
+	*mock.Call
+}
+
+// GetBountyRoles is a helper method to define mock.On call
+func (_e *Database_Expecter) GetBountyRoles() *Database_GetBountyRoles_Call {
+	return &Database_GetBountyRoles_Call{Call: _e.mock.On("GetBountyRoles")}
+}
+
+func (_c *Database_GetBountyRoles_Call) Run(run func()) *Database_GetBountyRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyRoles_Call) Return(_a0 []db.BountyRoles) *Database_GetBountyRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyRoles_Call) RunAndReturn(run func() []db.BountyRoles) *Database_GetBountyRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBountyStateTransitions provides a mock function with given fields: bountyId
+func (_m *Database) GetBountyStateTransitions(bountyId uint) []db.BountyStateTransition {
+	ret := _m.Called(bountyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBountyStateTransitions")
+	}
+
+	var r0 []db.BountyStateTransition
+	if rf, ok := ret.Get(0).(func(uint) []db.BountyStateTransition); ok {
+		r0 = rf(bountyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyStateTransition)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBountyStateTransitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyStateTransitions'
+type Database_GetBountyStateTransitions_Call struct {
+	*mock.Call
+}
+
+// GetBountyStateTransitions is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) GetBountyStateTransitions(bountyId interface{}) *Database_GetBountyStateTransitions_Call {
+	return &Database_GetBountyStateTransitions_Call{Call: _e.mock.On("GetBountyStateTransitions", bountyId)}
+}
+
+func (_c *Database_GetBountyStateTransitions_Call) Run(run func(bountyId uint)) *Database_GetBountyStateTransitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBountyStateTransitions_Call) Return(_a0 []db.BountyStateTransition) *Database_GetBountyStateTransitions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBountyStateTransitions_Call) RunAndReturn(run func(uint) []db.BountyStateTransition) *Database_GetBountyStateTransitions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBudgetLedger provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetBudgetLedger(workspaceUuid string) []db.BudgetLedgerEntry {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBudgetLedger")
+	}
+
+	var r0 []db.BudgetLedgerEntry
+	if rf, ok := ret.Get(0).(func(string) []db.BudgetLedgerEntry); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BudgetLedgerEntry)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBudgetLedger_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBudgetLedger'
+type Database_GetBudgetLedger_Call struct {
+	*mock.Call
+}
+
+// GetBudgetLedger is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetBudgetLedger(workspaceUuid interface{}) *Database_GetBudgetLedger_Call {
+	return &Database_GetBudgetLedger_Call{Call: _e.mock.On("GetBudgetLedger", workspaceUuid)}
+}
+
+func (_c *Database_GetBudgetLedger_Call) Run(run func(workspaceUuid string)) *Database_GetBudgetLedger_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBudgetLedger_Call) Return(_a0 []db.BudgetLedgerEntry) *Database_GetBudgetLedger_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBudgetLedger_Call) RunAndReturn(run func(string) []db.BudgetLedgerEntry) *Database_GetBudgetLedger_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBudgetRefund provides a mock function with given fields: id
+func (_m *Database) GetBudgetRefund(id uint) (db.BudgetRefund, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBudgetRefund")
+	}
+
+	var r0 db.BudgetRefund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (db.BudgetRefund, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) db.BudgetRefund); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.BudgetRefund)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetBudgetRefund_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBudgetRefund'
+type Database_GetBudgetRefund_Call struct {
+	*mock.Call
+}
+
+// GetBudgetRefund is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetBudgetRefund(id interface{}) *Database_GetBudgetRefund_Call {
+	return &Database_GetBudgetRefund_Call{Call: _e.mock.On("GetBudgetRefund", id)}
+}
+
+func (_c *Database_GetBudgetRefund_Call) Run(run func(id uint)) *Database_GetBudgetRefund_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetBudgetRefund_Call) Return(_a0 db.BudgetRefund, _a1 error) *Database_GetBudgetRefund_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetBudgetRefund_Call) RunAndReturn(run func(uint) (db.BudgetRefund, error)) *Database_GetBudgetRefund_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBudgetRefunds provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetBudgetRefunds(workspaceUuid string) []db.BudgetRefund {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBudgetRefunds")
+	}
+
+	var r0 []db.BudgetRefund
+	if rf, ok := ret.Get(0).(func(string) []db.BudgetRefund); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BudgetRefund)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetBudgetRefunds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBudgetRefunds'
+type Database_GetBudgetRefunds_Call struct {
+	*mock.Call
+}
+
+// GetBudgetRefunds is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetBudgetRefunds(workspaceUuid interface{}) *Database_GetBudgetRefunds_Call {
+	return &Database_GetBudgetRefunds_Call{Call: _e.mock.On("GetBudgetRefunds", workspaceUuid)}
+}
+
+func (_c *Database_GetBudgetRefunds_Call) Run(run func(workspaceUuid string)) *Database_GetBudgetRefunds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetBudgetRefunds_Call) Return(_a0 []db.BudgetRefund) *Database_GetBudgetRefunds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetBudgetRefunds_Call) RunAndReturn(run func(string) []db.BudgetRefund) *Database_GetBudgetRefunds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChannel provides a mock function with given fields: id
+func (_m *Database) GetChannel(id uint) db.Channel {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannel")
+	}
+
+	var r0 db.Channel
+	if rf, ok := ret.Get(0).(func(uint) db.Channel); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.Channel)
+	}
+
+	return r0
+}
+
+// Database_GetChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannel'
+type Database_GetChannel_Call struct {
+	*mock.Call
+}
+
+// GetChannel is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetChannel(id interface{}) *Database_GetChannel_Call {
+	return &Database_GetChannel_Call{Call: _e.mock.On("GetChannel", id)}
+}
+
+func (_c *Database_GetChannel_Call) Run(run func(id uint)) *Database_GetChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetChannel_Call) Return(_a0 db.Channel) *Database_GetChannel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetChannel_Call) RunAndReturn(run func(uint) db.Channel) *Database_GetChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChannelsByTribe provides a mock function with given fields: tribe_uuid
+func (_m *Database) GetChannelsByTribe(tribe_uuid string) []db.Channel {
+	ret := _m.Called(tribe_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelsByTribe")
+	}
+
+	var r0 []db.Channel
+	if rf, ok := ret.Get(0).(func(string) []db.Channel); ok {
+		r0 = rf(tribe_uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Channel)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetChannelsByTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannelsByTribe'
+type Database_GetChannelsByTribe_Call struct {
+	*mock.Call
+}
+
+// GetChannelsByTribe is a helper method to define mock.On call
+//   - tribe_uuid string
+func (_e *Database_Expecter) GetChannelsByTribe(tribe_uuid interface{}) *Database_GetChannelsByTribe_Call {
+	return &Database_GetChannelsByTribe_Call{Call: _e.mock.On("GetChannelsByTribe", tribe_uuid)}
+}
+
+func (_c *Database_GetChannelsByTribe_Call) Run(run func(tribe_uuid string)) *Database_GetChannelsByTribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetChannelsByTribe_Call) Return(_a0 []db.Channel) *Database_GetChannelsByTribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetChannelsByTribe_Call) RunAndReturn(run func(string) []db.Channel) *Database_GetChannelsByTribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChatAgentWebhook provides a mock function with given fields: chatId
+func (_m *Database) GetChatAgentWebhook(chatId string) (db.ChatAgentWebhook, error) {
+	ret := _m.Called(chatId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatAgentWebhook")
+	}
+
+	var r0 db.ChatAgentWebhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.ChatAgentWebhook, error)); ok {
+		return rf(chatId)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.ChatAgentWebhook); ok {
+		r0 = rf(chatId)
+	} else {
+		r0 = ret.Get(0).(db.ChatAgentWebhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(chatId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetChatAgentWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatAgentWebhook'
+type Database_GetChatAgentWebhook_Call struct {
+	*mock.Call
+}
+
+// GetChatAgentWebhook is a helper method to define mock.On call
+//   - chatId string
+func (_e *Database_Expecter) GetChatAgentWebhook(chatId interface{}) *Database_GetChatAgentWebhook_Call {
+	return &Database_GetChatAgentWebhook_Call{Call: _e.mock.On("GetChatAgentWebhook", chatId)}
+}
+
+func (_c *Database_GetChatAgentWebhook_Call) Run(run func(chatId string)) *Database_GetChatAgentWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetChatAgentWebhook_Call) Return(_a0 db.ChatAgentWebhook, _a1 error) *Database_GetChatAgentWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetChatAgentWebhook_Call) RunAndReturn(run func(string) (db.ChatAgentWebhook, error)) *Database_GetChatAgentWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChatByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetChatByUuid(uuid string) (db.Chat, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatByUuid")
+	}
+
+	var r0 db.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Chat, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Chat); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Chat)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetChatByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatByUuid'
+type Database_GetChatByUuid_Call struct {
+	*mock.Call
+}
+
+// GetChatByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetChatByUuid(uuid interface{}) *Database_GetChatByUuid_Call {
+	return &Database_GetChatByUuid_Call{Call: _e.mock.On("GetChatByUuid", uuid)}
+}
+
+func (_c *Database_GetChatByUuid_Call) Run(run func(uuid string)) *Database_GetChatByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetChatByUuid_Call) Return(_a0 db.Chat, _a1 error) *Database_GetChatByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetChatByUuid_Call) RunAndReturn(run func(string) (db.Chat, error)) *Database_GetChatByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChatMessages provides a mock function with given fields: chatUuid
+func (_m *Database) GetChatMessages(chatUuid string) []db.ChatMessage {
+	ret := _m.Called(chatUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatMessages")
+	}
+
+	var r0 []db.ChatMessage
+	if rf, ok := ret.Get(0).(func(string) []db.ChatMessage); ok {
+		r0 = rf(chatUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ChatMessage)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetChatMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatMessages'
+type Database_GetChatMessages_Call struct {
+	*mock.Call
+}
+
+// GetChatMessages is a helper method to define mock.On call
+//   - chatUuid string
+func (_e *Database_Expecter) GetChatMessages(chatUuid interface{}) *Database_GetChatMessages_Call {
+	return &Database_GetChatMessages_Call{Call: _e.mock.On("GetChatMessages", chatUuid)}
+}
+
+func (_c *Database_GetChatMessages_Call) Run(run func(chatUuid string)) *Database_GetChatMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetChatMessages_Call) Return(_a0 []db.ChatMessage) *Database_GetChatMessages_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetChatMessages_Call) RunAndReturn(run func(string) []db.ChatMessage) *Database_GetChatMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChatMessagesSince provides a mock function with given fields: chatUuid, sinceId
+func (_m *Database) GetChatMessagesSince(chatUuid string, sinceId uint) []db.ChatMessage {
+	ret := _m.Called(chatUuid, sinceId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatMessagesSince")
+	}
+
+	var r0 []db.ChatMessage
+	if rf, ok := ret.Get(0).(func(string, uint) []db.ChatMessage); ok {
+		r0 = rf(chatUuid, sinceId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ChatMessage)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetChatMessagesSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatMessagesSince'
+type Database_GetChatMessagesSince_Call struct {
+	*mock.Call
+}
+
+// GetChatMessagesSince is a helper method to define mock.On call
+//   - chatUuid string
+//   - sinceId uint
+func (_e *Database_Expecter) GetChatMessagesSince(chatUuid interface{}, sinceId interface{}) *Database_GetChatMessagesSince_Call {
+	return &Database_GetChatMessagesSince_Call{Call: _e.mock.On("GetChatMessagesSince", chatUuid, sinceId)}
+}
+
+func (_c *Database_GetChatMessagesSince_Call) Run(run func(chatUuid string, sinceId uint)) *Database_GetChatMessagesSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetChatMessagesSince_Call) Return(_a0 []db.ChatMessage) *Database_GetChatMessagesSince_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetChatMessagesSince_Call) RunAndReturn(run func(string, uint) []db.ChatMessage) *Database_GetChatMessagesSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCodeGraphByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetCodeGraphByUuid(uuid string) (db.WorkspaceCodeGraph, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCodeGraphByUuid")
+	}
+
+	var r0 db.WorkspaceCodeGraph
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.WorkspaceCodeGraph, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceCodeGraph); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceCodeGraph)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetCodeGraphByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCodeGraphByUuid'
+type Database_GetCodeGraphByUuid_Call struct {
+	*mock.Call
+}
+
+// GetCodeGraphByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetCodeGraphByUuid(uuid interface{}) *Database_GetCodeGraphByUuid_Call {
+	return &Database_GetCodeGraphByUuid_Call{Call: _e.mock.On("GetCodeGraphByUuid", uuid)}
+}
+
+func (_c *Database_GetCodeGraphByUuid_Call) Run(run func(uuid string)) *Database_GetCodeGraphByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetCodeGraphByUuid_Call) Return(_a0 db.WorkspaceCodeGraph, _a1 error) *Database_GetCodeGraphByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetCodeGraphByUuid_Call) RunAndReturn(run func(string) (db.WorkspaceCodeGraph, error)) *Database_GetCodeGraphByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCodeGraphsByWorkspaceUuid provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetCodeGraphsByWorkspaceUuid(workspace_uuid string) []db.WorkspaceCodeGraph {
+	ret := _m.Called(workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCodeGraphsByWorkspaceUuid")
+	}
+
+	var r0 []db.WorkspaceCodeGraph
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceCodeGraph); ok {
+		r0 = rf(workspace_uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceCodeGraph)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetCodeGraphsByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCodeGraphsByWorkspaceUuid'
+type Database_GetCodeGraphsByWorkspaceUuid_Call struct {
+	*mock.Call
+}
+
+// GetCodeGraphsByWorkspaceUuid is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetCodeGraphsByWorkspaceUuid(workspace_uuid interface{}) *Database_GetCodeGraphsByWorkspaceUuid_Call {
+	return &Database_GetCodeGraphsByWorkspaceUuid_Call{Call: _e.mock.On("GetCodeGraphsByWorkspaceUuid", workspace_uuid)}
+}
+
+func (_c *Database_GetCodeGraphsByWorkspaceUuid_Call) Run(run func(workspace_uuid string)) *Database_GetCodeGraphsByWorkspaceUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetCodeGraphsByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceCodeGraph) *Database_GetCodeGraphsByWorkspaceUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetCodeGraphsByWorkspaceUuid_Call) RunAndReturn(run func(string) []db.WorkspaceCodeGraph) *Database_GetCodeGraphsByWorkspaceUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCompletedBountiesByAssignee provides a mock function with given fields: pubkey
+func (_m *Database) GetCompletedBountiesByAssignee(pubkey string) []db.NewBounty {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCompletedBountiesByAssignee")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetCompletedBountiesByAssignee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCompletedBountiesByAssignee'
+type Database_GetCompletedBountiesByAssignee_Call struct {
+	*mock.Call
+}
+
+// GetCompletedBountiesByAssignee is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetCompletedBountiesByAssignee(pubkey interface{}) *Database_GetCompletedBountiesByAssignee_Call {
+	return &Database_GetCompletedBountiesByAssignee_Call{Call: _e.mock.On("GetCompletedBountiesByAssignee", pubkey)}
+}
+
+func (_c *Database_GetCompletedBountiesByAssignee_Call) Run(run func(pubkey string)) *Database_GetCompletedBountiesByAssignee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetCompletedBountiesByAssignee_Call) Return(_a0 []db.NewBounty) *Database_GetCompletedBountiesByAssignee_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetCompletedBountiesByAssignee_Call) RunAndReturn(run func(string) []db.NewBounty) *Database_GetCompletedBountiesByAssignee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConfigOverrides provides a mock function with given fields:
+func (_m *Database) GetConfigOverrides() ([]db.ConfigOverride, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfigOverrides")
+	}
+
+	var r0 []db.ConfigOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]db.ConfigOverride, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []db.ConfigOverride); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ConfigOverride)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetConfigOverrides_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConfigOverrides'
+type Database_GetConfigOverrides_Call struct {
+	*mock.Call
+}
+
+// GetConfigOverrides is a helper method to define mock.On call
+func (_e *Database_Expecter) GetConfigOverrides() *Database_GetConfigOverrides_Call {
+	return &Database_GetConfigOverrides_Call{Call: _e.mock.On("GetConfigOverrides")}
+}
+
+func (_c *Database_GetConfigOverrides_Call) Run(run func()) *Database_GetConfigOverrides_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetConfigOverrides_Call) Return(_a0 []db.ConfigOverride, _a1 error) *Database_GetConfigOverrides_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetConfigOverrides_Call) RunAndReturn(run func() ([]db.ConfigOverride, error)) *Database_GetConfigOverrides_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConnectionCode provides a mock function with given fields:
+func (_m *Database) GetConnectionCode() db.ConnectionCodesShort {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConnectionCode")
+	}
+
+	var r0 db.ConnectionCodesShort
+	if rf, ok := ret.Get(0).(func() db.ConnectionCodesShort); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(db.ConnectionCodesShort)
+	}
+
+	return r0
+}
+
+// Database_GetConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConnectionCode'
+type Database_GetConnectionCode_Call struct {
+	*mock.Call
+}
+
+// GetConnectionCode is a helper method to define mock.On call
+func (_e *Database_Expecter) GetConnectionCode() *Database_GetConnectionCode_Call {
+	return &Database_GetConnectionCode_Call{Call: _e.mock.On("GetConnectionCode")}
+}
+
+func (_c *Database_GetConnectionCode_Call) Run(run func()) *Database_GetConnectionCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetConnectionCode_Call) Return(_a0 db.ConnectionCodesShort) *Database_GetConnectionCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetConnectionCode_Call) RunAndReturn(run func() db.ConnectionCodesShort) *Database_GetConnectionCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCreatedBounties provides a mock function with given fields: r
+func (_m *Database) GetCreatedBounties(r *http.Request) ([]db.NewBounty, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCreatedBounties")
+	}
+
+	var r0 []db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.NewBounty, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetCreatedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCreatedBounties'
+type Database_GetCreatedBounties_Call struct {
+	*mock.Call
+}
+
+// GetCreatedBounties is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetCreatedBounties(r interface{}) *Database_GetCreatedBounties_Call {
+	return &Database_GetCreatedBounties_Call{Call: _e.mock.On("GetCreatedBounties", r)}
+}
+
+func (_c *Database_GetCreatedBounties_Call) Run(run func(r *http.Request)) *Database_GetCreatedBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetCreatedBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetCreatedBounties_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetCreatedBounties_Call) RunAndReturn(run func(*http.Request) ([]db.NewBounty, error)) *Database_GetCreatedBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedWorkspaces provides a mock function with given fields:
+func (_m *Database) GetDeletedWorkspaces() []db.DeletedWorkspace {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedWorkspaces")
+	}
+
+	var r0 []db.DeletedWorkspace
+	if rf, ok := ret.Get(0).(func() []db.DeletedWorkspace); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.DeletedWorkspace)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetDeletedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedWorkspaces'
+type Database_GetDeletedWorkspaces_Call struct {
+	*mock.Call
+}
+
+// GetDeletedWorkspaces is a helper method to define mock.On call
+func (_e *Database_Expecter) GetDeletedWorkspaces() *Database_GetDeletedWorkspaces_Call {
+	return &Database_GetDeletedWorkspaces_Call{Call: _e.mock.On("GetDeletedWorkspaces")}
+}
+
+func (_c *Database_GetDeletedWorkspaces_Call) Run(run func()) *Database_GetDeletedWorkspaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetDeletedWorkspaces_Call) Return(_a0 []db.DeletedWorkspace) *Database_GetDeletedWorkspaces_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetDeletedWorkspaces_Call) RunAndReturn(run func() []db.DeletedWorkspace) *Database_GetDeletedWorkspaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDueBountyAuctions provides a mock function with given fields: now
+func (_m *Database) GetDueBountyAuctions(now time.Time) []db.BountyAuction {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueBountyAuctions")
+	}
+
+	var r0 []db.BountyAuction
+	if rf, ok := ret.Get(0).(func(time.Time) []db.BountyAuction); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyAuction)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetDueBountyAuctions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDueBountyAuctions'
+type Database_GetDueBountyAuctions_Call struct {
+	*mock.Call
+}
+
+// GetDueBountyAuctions is a helper method to define mock.On call
+//   - now time.Time
+func (_e *Database_Expecter) GetDueBountyAuctions(now interface{}) *Database_GetDueBountyAuctions_Call {
+	return &Database_GetDueBountyAuctions_Call{Call: _e.mock.On("GetDueBountyAuctions", now)}
+}
+
+func (_c *Database_GetDueBountyAuctions_Call) Run(run func(now time.Time)) *Database_GetDueBountyAuctions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetDueBountyAuctions_Call) Return(_a0 []db.BountyAuction) *Database_GetDueBountyAuctions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetDueBountyAuctions_Call) RunAndReturn(run func(time.Time) []db.BountyAuction) *Database_GetDueBountyAuctions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureBounties provides a mock function with given fields: featureUuid
+func (_m *Database) GetFeatureBounties(featureUuid string) []db.NewBounty {
+	ret := _m.Called(featureUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(featureUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeatureBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureBounties'
+type Database_GetFeatureBounties_Call struct {
+	*mock.Call
+}
+
+// GetFeatureBounties is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) GetFeatureBounties(featureUuid interface{}) *Database_GetFeatureBounties_Call {
+	return &Database_GetFeatureBounties_Call{Call: _e.mock.On("GetFeatureBounties", featureUuid)}
+}
+
+func (_c *Database_GetFeatureBounties_Call) Run(run func(featureUuid string)) *Database_GetFeatureBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureBounties_Call) Return(_a0 []db.NewBounty) *Database_GetFeatureBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeatureBounties_Call) RunAndReturn(run func(string) []db.NewBounty) *Database_GetFeatureBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetFeatureByUuid(uuid string) db.WorkspaceFeatures {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureByUuid")
+	}
+
+	var r0 db.WorkspaceFeatures
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceFeatures); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceFeatures)
+	}
+
+	return r0
+}
+
+// Database_GetFeatureByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureByUuid'
+type Database_GetFeatureByUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeatureByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetFeatureByUuid(uuid interface{}) *Database_GetFeatureByUuid_Call {
+	return &Database_GetFeatureByUuid_Call{Call: _e.mock.On("GetFeatureByUuid", uuid)}
+}
+
+func (_c *Database_GetFeatureByUuid_Call) Run(run func(uuid string)) *Database_GetFeatureByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureByUuid_Call) Return(_a0 db.WorkspaceFeatures) *Database_GetFeatureByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeatureByUuid_Call) RunAndReturn(run func(string) db.WorkspaceFeatures) *Database_GetFeatureByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureCallByUuid provides a mock function with given fields: featureUuid, uuid
+func (_m *Database) GetFeatureCallByUuid(featureUuid string, uuid string) (db.FeatureCall, error) {
+	ret := _m.Called(featureUuid, uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureCallByUuid")
+	}
+
+	var r0 db.FeatureCall
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.FeatureCall, error)); ok {
+		return rf(featureUuid, uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.FeatureCall); ok {
+		r0 = rf(featureUuid, uuid)
+	} else {
+		r0 = ret.Get(0).(db.FeatureCall)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(featureUuid, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeatureCallByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureCallByUuid'
+type Database_GetFeatureCallByUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeatureCallByUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - uuid string
+func (_e *Database_Expecter) GetFeatureCallByUuid(featureUuid interface{}, uuid interface{}) *Database_GetFeatureCallByUuid_Call {
+	return &Database_GetFeatureCallByUuid_Call{Call: _e.mock.On("GetFeatureCallByUuid", featureUuid, uuid)}
+}
+
+func (_c *Database_GetFeatureCallByUuid_Call) Run(run func(featureUuid string, uuid string)) *Database_GetFeatureCallByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureCallByUuid_Call) Return(_a0 db.FeatureCall, _a1 error) *Database_GetFeatureCallByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeatureCallByUuid_Call) RunAndReturn(run func(string, string) (db.FeatureCall, error)) *Database_GetFeatureCallByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureCallHistory provides a mock function with given fields: featureUuid
+func (_m *Database) GetFeatureCallHistory(featureUuid string) []db.FeatureCall {
+	ret := _m.Called(featureUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureCallHistory")
+	}
+
+	var r0 []db.FeatureCall
+	if rf, ok := ret.Get(0).(func(string) []db.FeatureCall); ok {
+		r0 = rf(featureUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeatureCall)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeatureCallHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureCallHistory'
+type Database_GetFeatureCallHistory_Call struct {
+	*mock.Call
+}
+
+// GetFeatureCallHistory is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) GetFeatureCallHistory(featureUuid interface{}) *Database_GetFeatureCallHistory_Call {
+	return &Database_GetFeatureCallHistory_Call{Call: _e.mock.On("GetFeatureCallHistory", featureUuid)}
+}
+
+func (_c *Database_GetFeatureCallHistory_Call) Run(run func(featureUuid string)) *Database_GetFeatureCallHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureCallHistory_Call) Return(_a0 []db.FeatureCall) *Database_GetFeatureCallHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeatureCallHistory_Call) RunAndReturn(run func(string) []db.FeatureCall) *Database_GetFeatureCallHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureCallTranscript provides a mock function with given fields: callUuid
+func (_m *Database) GetFeatureCallTranscript(callUuid string) (db.FeatureCallTranscript, error) {
+	ret := _m.Called(callUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureCallTranscript")
+	}
+
+	var r0 db.FeatureCallTranscript
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.FeatureCallTranscript, error)); ok {
+		return rf(callUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.FeatureCallTranscript); ok {
+		r0 = rf(callUuid)
+	} else {
+		r0 = ret.Get(0).(db.FeatureCallTranscript)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(callUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeatureCallTranscript_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureCallTranscript'
+type Database_GetFeatureCallTranscript_Call struct {
+	*mock.Call
+}
+
+// GetFeatureCallTranscript is a helper method to define mock.On call
+//   - callUuid string
+func (_e *Database_Expecter) GetFeatureCallTranscript(callUuid interface{}) *Database_GetFeatureCallTranscript_Call {
+	return &Database_GetFeatureCallTranscript_Call{Call: _e.mock.On("GetFeatureCallTranscript", callUuid)}
+}
+
+func (_c *Database_GetFeatureCallTranscript_Call) Run(run func(callUuid string)) *Database_GetFeatureCallTranscript_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureCallTranscript_Call) Return(_a0 db.FeatureCallTranscript, _a1 error) *Database_GetFeatureCallTranscript_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeatureCallTranscript_Call) RunAndReturn(run func(string) (db.FeatureCallTranscript, error)) *Database_GetFeatureCallTranscript_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureCalls provides a mock function with given fields: featureUuid
+func (_m *Database) GetFeatureCalls(featureUuid string) []db.FeatureCall {
+	ret := _m.Called(featureUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureCalls")
+	}
+
+	var r0 []db.FeatureCall
+	if rf, ok := ret.Get(0).(func(string) []db.FeatureCall); ok {
+		r0 = rf(featureUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeatureCall)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeatureCalls_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureCalls'
+type Database_GetFeatureCalls_Call struct {
+	*mock.Call
+}
+
+// GetFeatureCalls is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) GetFeatureCalls(featureUuid interface{}) *Database_GetFeatureCalls_Call {
+	return &Database_GetFeatureCalls_Call{Call: _e.mock.On("GetFeatureCalls", featureUuid)}
+}
+
+func (_c *Database_GetFeatureCalls_Call) Run(run func(featureUuid string)) *Database_GetFeatureCalls_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureCalls_Call) Return(_a0 []db.FeatureCall) *Database_GetFeatureCalls_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeatureCalls_Call) RunAndReturn(run func(string) []db.FeatureCall) *Database_GetFeatureCalls_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureDetail provides a mock function with given fields: uuid
+func (_m *Database) GetFeatureDetail(uuid string) (db.FeatureDetail, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureDetail")
+	}
+
+	var r0 db.FeatureDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.FeatureDetail, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.FeatureDetail); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.FeatureDetail)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeatureDetail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureDetail'
+type Database_GetFeatureDetail_Call struct {
+	*mock.Call
+}
+
+// GetFeatureDetail is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetFeatureDetail(uuid interface{}) *Database_GetFeatureDetail_Call {
+	return &Database_GetFeatureDetail_Call{Call: _e.mock.On("GetFeatureDetail", uuid)}
+}
+
+func (_c *Database_GetFeatureDetail_Call) Run(run func(uuid string)) *Database_GetFeatureDetail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureDetail_Call) Return(_a0 db.FeatureDetail, _a1 error) *Database_GetFeatureDetail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeatureDetail_Call) RunAndReturn(run func(string) (db.FeatureDetail, error)) *Database_GetFeatureDetail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturePhaseByUuid provides a mock function with given fields: featureUuid, phaseUuid
+func (_m *Database) GetFeaturePhaseByUuid(featureUuid string, phaseUuid string) (db.FeaturePhase, error) {
+	ret := _m.Called(featureUuid, phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturePhaseByUuid")
+	}
+
+	var r0 db.FeaturePhase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.FeaturePhase, error)); ok {
+		return rf(featureUuid, phaseUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.FeaturePhase); ok {
+		r0 = rf(featureUuid, phaseUuid)
+	} else {
+		r0 = ret.Get(0).(db.FeaturePhase)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(featureUuid, phaseUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeaturePhaseByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturePhaseByUuid'
+type Database_GetFeaturePhaseByUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeaturePhaseByUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+func (_e *Database_Expecter) GetFeaturePhaseByUuid(featureUuid interface{}, phaseUuid interface{}) *Database_GetFeaturePhaseByUuid_Call {
+	return &Database_GetFeaturePhaseByUuid_Call{Call: _e.mock.On("GetFeaturePhaseByUuid", featureUuid, phaseUuid)}
+}
+
+func (_c *Database_GetFeaturePhaseByUuid_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_GetFeaturePhaseByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturePhaseByUuid_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_GetFeaturePhaseByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeaturePhaseByUuid_Call) RunAndReturn(run func(string, string) (db.FeaturePhase, error)) *Database_GetFeaturePhaseByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturePhasesBountiesCount provides a mock function with given fields: bountyType, phaseUuid
+func (_m *Database) GetFeaturePhasesBountiesCount(bountyType string, phaseUuid string) int64 {
+	ret := _m.Called(bountyType, phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturePhasesBountiesCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
+		r0 = rf(bountyType, phaseUuid)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetFeaturePhasesBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturePhasesBountiesCount'
+type Database_GetFeaturePhasesBountiesCount_Call struct {
+	*mock.Call
+}
+
+// GetFeaturePhasesBountiesCount is a helper method to define mock.On call
+//   - bountyType string
+//   - phaseUuid string
+func (_e *Database_Expecter) GetFeaturePhasesBountiesCount(bountyType interface{}, phaseUuid interface{}) *Database_GetFeaturePhasesBountiesCount_Call {
+	return &Database_GetFeaturePhasesBountiesCount_Call{Call: _e.mock.On("GetFeaturePhasesBountiesCount", bountyType, phaseUuid)}
+}
+
+func (_c *Database_GetFeaturePhasesBountiesCount_Call) Run(run func(bountyType string, phaseUuid string)) *Database_GetFeaturePhasesBountiesCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturePhasesBountiesCount_Call) Return(_a0 int64) *Database_GetFeaturePhasesBountiesCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeaturePhasesBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetFeaturePhasesBountiesCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureStoriesByFeatureUuid provides a mock function with given fields: featureUuid
+func (_m *Database) GetFeatureStoriesByFeatureUuid(featureUuid string) ([]db.FeatureStory, error) {
+	ret := _m.Called(featureUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureStoriesByFeatureUuid")
+	}
+
+	var r0 []db.FeatureStory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]db.FeatureStory, error)); ok {
+		return rf(featureUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) []db.FeatureStory); ok {
+		r0 = rf(featureUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeatureStory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(featureUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeatureStoriesByFeatureUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoriesByFeatureUuid'
+type Database_GetFeatureStoriesByFeatureUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeatureStoriesByFeatureUuid is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) GetFeatureStoriesByFeatureUuid(featureUuid interface{}) *Database_GetFeatureStoriesByFeatureUuid_Call {
+	return &Database_GetFeatureStoriesByFeatureUuid_Call{Call: _e.mock.On("GetFeatureStoriesByFeatureUuid", featureUuid)}
+}
+
+func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) Run(run func(featureUuid string)) *Database_GetFeatureStoriesByFeatureUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) Return(_a0 []db.FeatureStory, _a1 error) *Database_GetFeatureStoriesByFeatureUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) RunAndReturn(run func(string) ([]db.FeatureStory, error)) *Database_GetFeatureStoriesByFeatureUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureStoryByDeliveryId provides a mock function with given fields: featureUuid, deliveryId
+func (_m *Database) GetFeatureStoryByDeliveryId(featureUuid string, deliveryId string) (db.FeatureStory, error) {
+	ret := _m.Called(featureUuid, deliveryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureStoryByDeliveryId")
+	}
+
+	var r0 db.FeatureStory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.FeatureStory, error)); ok {
+		return rf(featureUuid, deliveryId)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.FeatureStory); ok {
+		r0 = rf(featureUuid, deliveryId)
+	} else {
+		r0 = ret.Get(0).(db.FeatureStory)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(featureUuid, deliveryId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeatureStoryByDeliveryId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoryByDeliveryId'
+type Database_GetFeatureStoryByDeliveryId_Call struct {
+	*mock.Call
+}
+
+// GetFeatureStoryByDeliveryId is a helper method to define mock.On call
+//   - featureUuid string
+//   - deliveryId string
+func (_e *Database_Expecter) GetFeatureStoryByDeliveryId(featureUuid interface{}, deliveryId interface{}) *Database_GetFeatureStoryByDeliveryId_Call {
+	return &Database_GetFeatureStoryByDeliveryId_Call{Call: _e.mock.On("GetFeatureStoryByDeliveryId", featureUuid, deliveryId)}
+}
+
+func (_c *Database_GetFeatureStoryByDeliveryId_Call) Run(run func(featureUuid string, deliveryId string)) *Database_GetFeatureStoryByDeliveryId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureStoryByDeliveryId_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_GetFeatureStoryByDeliveryId_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeatureStoryByDeliveryId_Call) RunAndReturn(run func(string, string) (db.FeatureStory, error)) *Database_GetFeatureStoryByDeliveryId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureStoryByUuid provides a mock function with given fields: featureUuid, storyUuid
+func (_m *Database) GetFeatureStoryByUuid(featureUuid string, storyUuid string) (db.FeatureStory, error) {
+	ret := _m.Called(featureUuid, storyUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureStoryByUuid")
+	}
+
+	var r0 db.FeatureStory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.FeatureStory, error)); ok {
+		return rf(featureUuid, storyUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.FeatureStory); ok {
+		r0 = rf(featureUuid, storyUuid)
+	} else {
+		r0 = ret.Get(0).(db.FeatureStory)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(featureUuid, storyUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetFeatureStoryByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoryByUuid'
+type Database_GetFeatureStoryByUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeatureStoryByUuid is a helper method to define mock.On call
+//   - featureUuid string
+//   - storyUuid string
+func (_e *Database_Expecter) GetFeatureStoryByUuid(featureUuid interface{}, storyUuid interface{}) *Database_GetFeatureStoryByUuid_Call {
+	return &Database_GetFeatureStoryByUuid_Call{Call: _e.mock.On("GetFeatureStoryByUuid", featureUuid, storyUuid)}
+}
+
+func (_c *Database_GetFeatureStoryByUuid_Call) Run(run func(featureUuid string, storyUuid string)) *Database_GetFeatureStoryByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureStoryByUuid_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_GetFeatureStoryByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetFeatureStoryByUuid_Call) RunAndReturn(run func(string, string) (db.FeatureStory, error)) *Database_GetFeatureStoryByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeatureTranscripts provides a mock function with given fields: featureUuid
+func (_m *Database) GetFeatureTranscripts(featureUuid string) []db.FeatureCallTranscript {
+	ret := _m.Called(featureUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeatureTranscripts")
+	}
+
+	var r0 []db.FeatureCallTranscript
+	if rf, ok := ret.Get(0).(func(string) []db.FeatureCallTranscript); ok {
+		r0 = rf(featureUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeatureCallTranscript)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeatureTranscripts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureTranscripts'
+type Database_GetFeatureTranscripts_Call struct {
+	*mock.Call
+}
+
+// GetFeatureTranscripts is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) GetFeatureTranscripts(featureUuid interface{}) *Database_GetFeatureTranscripts_Call {
+	return &Database_GetFeatureTranscripts_Call{Call: _e.mock.On("GetFeatureTranscripts", featureUuid)}
+}
+
+func (_c *Database_GetFeatureTranscripts_Call) Run(run func(featureUuid string)) *Database_GetFeatureTranscripts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeatureTranscripts_Call) Return(_a0 []db.FeatureCallTranscript) *Database_GetFeatureTranscripts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeatureTranscripts_Call) RunAndReturn(run func(string) []db.FeatureCallTranscript) *Database_GetFeatureTranscripts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeaturesByWorkspaceUuid provides a mock function with given fields: uuid, r
+func (_m *Database) GetFeaturesByWorkspaceUuid(uuid string, r *http.Request) []db.WorkspaceFeatures {
+	ret := _m.Called(uuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturesByWorkspaceUuid")
+	}
+
+	var r0 []db.WorkspaceFeatures
+	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.WorkspaceFeatures); ok {
+		r0 = rf(uuid, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceFeatures)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetFeaturesByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturesByWorkspaceUuid'
+type Database_GetFeaturesByWorkspaceUuid_Call struct {
+	*mock.Call
+}
+
+// GetFeaturesByWorkspaceUuid is a helper method to define mock.On call
+//   - uuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetFeaturesByWorkspaceUuid(uuid interface{}, r interface{}) *Database_GetFeaturesByWorkspaceUuid_Call {
+	return &Database_GetFeaturesByWorkspaceUuid_Call{Call: _e.mock.On("GetFeaturesByWorkspaceUuid", uuid, r)}
+}
+
+func (_c *Database_GetFeaturesByWorkspaceUuid_Call) Run(run func(uuid string, r *http.Request)) *Database_GetFeaturesByWorkspaceUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetFeaturesByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFeaturesByWorkspaceUuid_Call) RunAndReturn(run func(string, *http.Request) []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFilterStatusCount provides a mock function with given fields:
+func (_m *Database) GetFilterStatusCount() db.FilterStattuCount {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilterStatusCount")
+	}
+
+	var r0 db.FilterStattuCount
+	if rf, ok := ret.Get(0).(func() db.FilterStattuCount); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(db.FilterStattuCount)
+	}
+
+	return r0
+}
+
+// Database_GetFilterStatusCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFilterStatusCount'
+type Database_GetFilterStatusCount_Call struct {
+	*mock.Call
+}
+
+// GetFilterStatusCount is a helper method to define mock.On call
+func (_e *Database_Expecter) GetFilterStatusCount() *Database_GetFilterStatusCount_Call {
+	return &Database_GetFilterStatusCount_Call{Call: _e.mock.On("GetFilterStatusCount")}
+}
+
+func (_c *Database_GetFilterStatusCount_Call) Run(run func()) *Database_GetFilterStatusCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetFilterStatusCount_Call) Return(_a0 db.FilterStattuCount) *Database_GetFilterStatusCount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFilterStatusCount_Call) RunAndReturn(run func() db.FilterStattuCount) *Database_GetFilterStatusCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFirstTribeByFeedURL provides a mock function with given fields: feedURL
+func (_m *Database) GetFirstTribeByFeedURL(feedURL string) db.Tribe {
+	ret := _m.Called(feedURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirstTribeByFeedURL")
+	}
+
+	var r0 db.Tribe
+	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
+		r0 = rf(feedURL)
+	} else {
+		r0 = ret.Get(0).(db.Tribe)
+	}
+
+	return r0
+}
+
+// Database_GetFirstTribeByFeedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirstTribeByFeedURL'
+type Database_GetFirstTribeByFeedURL_Call struct {
+	*mock.Call
+}
+
+// GetFirstTribeByFeedURL is a helper method to define mock.On call
+//   - feedURL string
+func (_e *Database_Expecter) GetFirstTribeByFeedURL(feedURL interface{}) *Database_GetFirstTribeByFeedURL_Call {
+	return &Database_GetFirstTribeByFeedURL_Call{Call: _e.mock.On("GetFirstTribeByFeedURL", feedURL)}
+}
+
+func (_c *Database_GetFirstTribeByFeedURL_Call) Run(run func(feedURL string)) *Database_GetFirstTribeByFeedURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetFirstTribeByFeedURL_Call) Return(_a0 db.Tribe) *Database_GetFirstTribeByFeedURL_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetFirstTribeByFeedURL_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetFirstTribeByFeedURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHandleReservations provides a mock function with given fields:
+func (_m *Database) GetHandleReservations() []db.HandleReservation {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHandleReservations")
+	}
+
+	var r0 []db.HandleReservation
+	if rf, ok := ret.Get(0).(func() []db.HandleReservation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.HandleReservation)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetHandleReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHandleReservations'
+type Database_GetHandleReservations_Call struct {
+	*mock.Call
+}
+
+// GetHandleReservations is a helper method to define mock.On call
+func (_e *Database_Expecter) GetHandleReservations() *Database_GetHandleReservations_Call {
+	return &Database_GetHandleReservations_Call{Call: _e.mock.On("GetHandleReservations")}
+}
+
+func (_c *Database_GetHandleReservations_Call) Run(run func()) *Database_GetHandleReservations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetHandleReservations_Call) Return(_a0 []db.HandleReservation) *Database_GetHandleReservations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetHandleReservations_Call) RunAndReturn(run func() []db.HandleReservation) *Database_GetHandleReservations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvoice provides a mock function with given fields: payment_request
+func (_m *Database) GetInvoice(payment_request string) db.NewInvoiceList {
+	ret := _m.Called(payment_request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvoice")
+	}
+
+	var r0 db.NewInvoiceList
+	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
+		r0 = rf(payment_request)
+	} else {
+		r0 = ret.Get(0).(db.NewInvoiceList)
+	}
+
+	return r0
+}
+
+// Database_GetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvoice'
+type Database_GetInvoice_Call struct {
+	*mock.Call
+}
+
+// GetInvoice is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) GetInvoice(payment_request interface{}) *Database_GetInvoice_Call {
+	return &Database_GetInvoice_Call{Call: _e.mock.On("GetInvoice", payment_request)}
+}
+
+func (_c *Database_GetInvoice_Call) Run(run func(payment_request string)) *Database_GetInvoice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_GetInvoice_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_GetInvoice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJiraIssueLinksByBountyIDs provides a mock function with given fields: ids
+func (_m *Database) GetJiraIssueLinksByBountyIDs(ids []uint) []db.JiraIssueLink {
+	ret := _m.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJiraIssueLinksByBountyIDs")
+	}
+
+	var r0 []db.JiraIssueLink
+	if rf, ok := ret.Get(0).(func([]uint) []db.JiraIssueLink); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.JiraIssueLink)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetJiraIssueLinksByBountyIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJiraIssueLinksByBountyIDs'
+type Database_GetJiraIssueLinksByBountyIDs_Call struct {
+	*mock.Call
+}
+
+// GetJiraIssueLinksByBountyIDs is a helper method to define mock.On call
+//   - ids []uint
+func (_e *Database_Expecter) GetJiraIssueLinksByBountyIDs(ids interface{}) *Database_GetJiraIssueLinksByBountyIDs_Call {
+	return &Database_GetJiraIssueLinksByBountyIDs_Call{Call: _e.mock.On("GetJiraIssueLinksByBountyIDs", ids)}
+}
+
+func (_c *Database_GetJiraIssueLinksByBountyIDs_Call) Run(run func(ids []uint)) *Database_GetJiraIssueLinksByBountyIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetJiraIssueLinksByBountyIDs_Call) Return(_a0 []db.JiraIssueLink) *Database_GetJiraIssueLinksByBountyIDs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetJiraIssueLinksByBountyIDs_Call) RunAndReturn(run func([]uint) []db.JiraIssueLink) *Database_GetJiraIssueLinksByBountyIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJiraUserLinks provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetJiraUserLinks(workspaceUuid string) []db.JiraUserLink {
+	ret := _m.Called(workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJiraUserLinks")
+	}
+
+	var r0 []db.JiraUserLink
+	if rf, ok := ret.Get(0).(func(string) []db.JiraUserLink); ok {
+		r0 = rf(workspaceUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.JiraUserLink)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetJiraUserLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJiraUserLinks'
+type Database_GetJiraUserLinks_Call struct {
+	*mock.Call
+}
+
+// GetJiraUserLinks is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetJiraUserLinks(workspaceUuid interface{}) *Database_GetJiraUserLinks_Call {
+	return &Database_GetJiraUserLinks_Call{Call: _e.mock.On("GetJiraUserLinks", workspaceUuid)}
+}
+
+func (_c *Database_GetJiraUserLinks_Call) Run(run func(workspaceUuid string)) *Database_GetJiraUserLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetJiraUserLinks_Call) Return(_a0 []db.JiraUserLink) *Database_GetJiraUserLinks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetJiraUserLinks_Call) RunAndReturn(run func(string) []db.JiraUserLink) *Database_GetJiraUserLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestPhaseDocument provides a mock function with given fields: phaseUuid, docType
+func (_m *Database) GetLatestPhaseDocument(phaseUuid string, docType db.PhaseDocumentType) (db.PhaseDocument, error) {
+	ret := _m.Called(phaseUuid, docType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestPhaseDocument")
+	}
+
+	var r0 db.PhaseDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, db.PhaseDocumentType) (db.PhaseDocument, error)); ok {
+		return rf(phaseUuid, docType)
+	}
+	if rf, ok := ret.Get(0).(func(string, db.PhaseDocumentType) db.PhaseDocument); ok {
+		r0 = rf(phaseUuid, docType)
+	} else {
+		r0 = ret.Get(0).(db.PhaseDocument)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, db.PhaseDocumentType) error); ok {
+		r1 = rf(phaseUuid, docType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetLatestPhaseDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestPhaseDocument'
+type Database_GetLatestPhaseDocument_Call struct {
+	*mock.Call
+}
+
+// GetLatestPhaseDocument is a helper method to define mock.On call
+//   - phaseUuid string
+//   - docType db.PhaseDocumentType
+func (_e *Database_Expecter) GetLatestPhaseDocument(phaseUuid interface{}, docType interface{}) *Database_GetLatestPhaseDocument_Call {
+	return &Database_GetLatestPhaseDocument_Call{Call: _e.mock.On("GetLatestPhaseDocument", phaseUuid, docType)}
+}
+
+func (_c *Database_GetLatestPhaseDocument_Call) Run(run func(phaseUuid string, docType db.PhaseDocumentType)) *Database_GetLatestPhaseDocument_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(db.PhaseDocumentType))
+	})
+	return _c
+}
+
+func (_c *Database_GetLatestPhaseDocument_Call) Return(_a0 db.PhaseDocument, _a1 error) *Database_GetLatestPhaseDocument_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetLatestPhaseDocument_Call) RunAndReturn(run func(string, db.PhaseDocumentType) (db.PhaseDocument, error)) *Database_GetLatestPhaseDocument_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLeaderBoard provides a mock function with given fields: uuid
+func (_m *Database) GetLeaderBoard(uuid string) []db.LeaderBoard {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeaderBoard")
+	}
+
+	var r0 []db.LeaderBoard
+	if rf, ok := ret.Get(0).(func(string) []db.LeaderBoard); ok {
+		r0 = rf(uuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LeaderBoard)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderBoard'
+type Database_GetLeaderBoard_Call struct {
+	*mock.Call
+}
+
+// GetLeaderBoard is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetLeaderBoard(uuid interface{}) *Database_GetLeaderBoard_Call {
+	return &Database_GetLeaderBoard_Call{Call: _e.mock.On("GetLeaderBoard", uuid)}
+}
+
+func (_c *Database_GetLeaderBoard_Call) Run(run func(uuid string)) *Database_GetLeaderBoard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLeaderBoard_Call) Return(_a0 []db.LeaderBoard) *Database_GetLeaderBoard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLeaderBoard_Call) RunAndReturn(run func(string) []db.LeaderBoard) *Database_GetLeaderBoard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLeaderBoardByUuidAndAlias provides a mock function with given fields: uuid, alias
+func (_m *Database) GetLeaderBoardByUuidAndAlias(uuid string, alias string) db.LeaderBoard {
+	ret := _m.Called(uuid, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeaderBoardByUuidAndAlias")
+	}
+
+	var r0 db.LeaderBoard
+	if rf, ok := ret.Get(0).(func(string, string) db.LeaderBoard); ok {
+		r0 = rf(uuid, alias)
+	} else {
+		r0 = ret.Get(0).(db.LeaderBoard)
+	}
+
+	return r0
+}
+
+// Database_GetLeaderBoardByUuidAndAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderBoardByUuidAndAlias'
+type Database_GetLeaderBoardByUuidAndAlias_Call struct {
+	*mock.Call
+}
+
+// GetLeaderBoardByUuidAndAlias is a helper method to define mock.On call
+//   - uuid string
+//   - alias string
+func (_e *Database_Expecter) GetLeaderBoardByUuidAndAlias(uuid interface{}, alias interface{}) *Database_GetLeaderBoardByUuidAndAlias_Call {
+	return &Database_GetLeaderBoardByUuidAndAlias_Call{Call: _e.mock.On("GetLeaderBoardByUuidAndAlias", uuid, alias)}
+}
+
+func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) Run(run func(uuid string, alias string)) *Database_GetLeaderBoardByUuidAndAlias_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) Return(_a0 db.LeaderBoard) *Database_GetLeaderBoardByUuidAndAlias_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) RunAndReturn(run func(string, string) db.LeaderBoard) *Database_GetLeaderBoardByUuidAndAlias_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLeaderboardTotals provides a mock function with given fields: filter
+func (_m *Database) GetLeaderboardTotals(filter db.LeaderboardFilter) []db.BountyLeaderboard {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeaderboardTotals")
+	}
+
+	var r0 []db.BountyLeaderboard
+	if rf, ok := ret.Get(0).(func(db.LeaderboardFilter) []db.BountyLeaderboard); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyLeaderboard)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetLeaderboardTotals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderboardTotals'
+type Database_GetLeaderboardTotals_Call struct {
+	*mock.Call
+}
+
+// GetLeaderboardTotals is a helper method to define mock.On call
+//   - filter db.LeaderboardFilter
+func (_e *Database_Expecter) GetLeaderboardTotals(filter interface{}) *Database_GetLeaderboardTotals_Call {
+	return &Database_GetLeaderboardTotals_Call{Call: _e.mock.On("GetLeaderboardTotals", filter)}
+}
+
+func (_c *Database_GetLeaderboardTotals_Call) Run(run func(filter db.LeaderboardFilter)) *Database_GetLeaderboardTotals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.LeaderboardFilter))
+	})
+	return _c
+}
+
+func (_c *Database_GetLeaderboardTotals_Call) Return(_a0 []db.BountyLeaderboard) *Database_GetLeaderboardTotals_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLeaderboardTotals_Call) RunAndReturn(run func(db.LeaderboardFilter) []db.BountyLeaderboard) *Database_GetLeaderboardTotals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedBots provides a mock function with given fields: r
+func (_m *Database) GetListedBots(r *http.Request) []db.Bot {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedBots")
+	}
+
+	var r0 []db.Bot
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Bot); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Bot)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetListedBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedBots'
+type Database_GetListedBots_Call struct {
+	*mock.Call
+}
+
+// GetListedBots is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedBots(r interface{}) *Database_GetListedBots_Call {
+	return &Database_GetListedBots_Call{Call: _e.mock.On("GetListedBots", r)}
+}
+
+func (_c *Database_GetListedBots_Call) Run(run func(r *http.Request)) *Database_GetListedBots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedBots_Call) Return(_a0 []db.Bot) *Database_GetListedBots_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetListedBots_Call) RunAndReturn(run func(*http.Request) []db.Bot) *Database_GetListedBots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedOffers provides a mock function with given fields: r
+func (_m *Database) GetListedOffers(r *http.Request) ([]db.PeopleExtra, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedOffers")
+	}
+
+	var r0 []db.PeopleExtra
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.PeopleExtra, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.PeopleExtra); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PeopleExtra)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetListedOffers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedOffers'
+type Database_GetListedOffers_Call struct {
+	*mock.Call
+}
+
+// GetListedOffers is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedOffers(r interface{}) *Database_GetListedOffers_Call {
+	return &Database_GetListedOffers_Call{Call: _e.mock.On("GetListedOffers", r)}
+}
+
+func (_c *Database_GetListedOffers_Call) Run(run func(r *http.Request)) *Database_GetListedOffers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedOffers_Call) Return(_a0 []db.PeopleExtra, _a1 error) *Database_GetListedOffers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetListedOffers_Call) RunAndReturn(run func(*http.Request) ([]db.PeopleExtra, error)) *Database_GetListedOffers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedPeople provides a mock function with given fields: r
+func (_m *Database) GetListedPeople(r *http.Request) []db.Person {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedPeople")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Person); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetListedPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPeople'
+type Database_GetListedPeople_Call struct {
+	*mock.Call
+}
+
+// GetListedPeople is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedPeople(r interface{}) *Database_GetListedPeople_Call {
+	return &Database_GetListedPeople_Call{Call: _e.mock.On("GetListedPeople", r)}
+}
+
+func (_c *Database_GetListedPeople_Call) Run(run func(r *http.Request)) *Database_GetListedPeople_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedPeople_Call) Return(_a0 []db.Person) *Database_GetListedPeople_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetListedPeople_Call) RunAndReturn(run func(*http.Request) []db.Person) *Database_GetListedPeople_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedPosts provides a mock function with given fields: r
+func (_m *Database) GetListedPosts(r *http.Request) ([]db.PeopleExtra, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedPosts")
+	}
+
+	var r0 []db.PeopleExtra
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.PeopleExtra, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.PeopleExtra); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PeopleExtra)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetListedPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPosts'
+type Database_GetListedPosts_Call struct {
+	*mock.Call
+}
+
+// GetListedPosts is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedPosts(r interface{}) *Database_GetListedPosts_Call {
+	return &Database_GetListedPosts_Call{Call: _e.mock.On("GetListedPosts", r)}
+}
+
+func (_c *Database_GetListedPosts_Call) Run(run func(r *http.Request)) *Database_GetListedPosts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedPosts_Call) Return(_a0 []db.PeopleExtra, _a1 error) *Database_GetListedPosts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetListedPosts_Call) RunAndReturn(run func(*http.Request) ([]db.PeopleExtra, error)) *Database_GetListedPosts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetListedTribes provides a mock function with given fields: r
+func (_m *Database) GetListedTribes(r *http.Request) []db.Tribe {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetListedTribes")
+	}
+
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Tribe); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetListedTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedTribes'
+type Database_GetListedTribes_Call struct {
+	*mock.Call
+}
+
+// GetListedTribes is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetListedTribes(r interface{}) *Database_GetListedTribes_Call {
+	return &Database_GetListedTribes_Call{Call: _e.mock.On("GetListedTribes", r)}
+}
+
+func (_c *Database_GetListedTribes_Call) Run(run func(r *http.Request)) *Database_GetListedTribes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetListedTribes_Call) Return(_a0 []db.Tribe) *Database_GetListedTribes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetListedTribes_Call) RunAndReturn(run func(*http.Request) []db.Tribe) *Database_GetListedTribes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLnUser provides a mock function with given fields: lnKey
+func (_m *Database) GetLnUser(lnKey string) int64 {
+	ret := _m.Called(lnKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLnUser")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(lnKey)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// Database_GetLnUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLnUser'
+type Database_GetLnUser_Call struct {
+	*mock.Call
+}
+
+// GetLnUser is a helper method to define mock.On call
+//   - lnKey string
+func (_e *Database_Expecter) GetLnUser(lnKey interface{}) *Database_GetLnUser_Call {
+	return &Database_GetLnUser_Call{Call: _e.mock.On("GetLnUser", lnKey)}
+}
+
+func (_c *Database_GetLnUser_Call) Run(run func(lnKey string)) *Database_GetLnUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLnUser_Call) Return(_a0 int64) *Database_GetLnUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetLnUser_Call) RunAndReturn(run func(string) int64) *Database_GetLnUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNextBountyByCreated provides a mock function with given fields: r
+func (_m *Database) GetNextBountyByCreated(r *http.Request) (uint, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNextBountyByCreated")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetNextBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextBountyByCreated'
+type Database_GetNextBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetNextBountyByCreated is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetNextBountyByCreated(r interface{}) *Database_GetNextBountyByCreated_Call {
+	return &Database_GetNextBountyByCreated_Call{Call: _e.mock.On("GetNextBountyByCreated", r)}
+}
+
+func (_c *Database_GetNextBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetNextBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetNextBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetNextBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetNextBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetNextBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNextWorkspaceBountyByCreated provides a mock function with given fields: r
+func (_m *Database) GetNextWorkspaceBountyByCreated(r *http.Request) (uint, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNextWorkspaceBountyByCreated")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetNextWorkspaceBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextWorkspaceBountyByCreated'
+type Database_GetNextWorkspaceBountyByCreated_Call struct {
+	*mock.Call
+}
+
+// GetNextWorkspaceBountyByCreated is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetNextWorkspaceBountyByCreated(r interface{}) *Database_GetNextWorkspaceBountyByCreated_Call {
+	return &Database_GetNextWorkspaceBountyByCreated_Call{Call: _e.mock.On("GetNextWorkspaceBountyByCreated", r)}
+}
+
+func (_c *Database_GetNextWorkspaceBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetNextWorkspaceBountyByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetNextWorkspaceBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetNextWorkspaceBountyByCreated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetNextWorkspaceBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetNextWorkspaceBountyByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNostrBountySyncs provides a mock function with given fields: limit
+func (_m *Database) GetNostrBountySyncs(limit int) ([]db.NostrBountySync, error) {
+	ret := _m.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNostrBountySyncs")
+	}
+
+	var r0 []db.NostrBountySync
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]db.NostrBountySync, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []db.NostrBountySync); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NostrBountySync)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetNostrBountySyncs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNostrBountySyncs'
+type Database_GetNostrBountySyncs_Call struct {
+	*mock.Call
+}
+
+// GetNostrBountySyncs is a helper method to define mock.On call
+//   - limit int
+func (_e *Database_Expecter) GetNostrBountySyncs(limit interface{}) *Database_GetNostrBountySyncs_Call {
+	return &Database_GetNostrBountySyncs_Call{Call: _e.mock.On("GetNostrBountySyncs", limit)}
+}
+
+func (_c *Database_GetNostrBountySyncs_Call) Run(run func(limit int)) *Database_GetNostrBountySyncs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetNostrBountySyncs_Call) Return(_a0 []db.NostrBountySync, _a1 error) *Database_GetNostrBountySyncs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetNostrBountySyncs_Call) RunAndReturn(run func(int) ([]db.NostrBountySync, error)) *Database_GetNostrBountySyncs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotificationsByPubkey provides a mock function with given fields: pubkey, unreadOnly
+func (_m *Database) GetNotificationsByPubkey(pubkey string, unreadOnly bool) []db.Notification {
+	ret := _m.Called(pubkey, unreadOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationsByPubkey")
+	}
+
+	var r0 []db.Notification
+	if rf, ok := ret.Get(0).(func(string, bool) []db.Notification); ok {
+		r0 = rf(pubkey, unreadOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Notification)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetNotificationsByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotificationsByPubkey'
+type Database_GetNotificationsByPubkey_Call struct {
+	*mock.Call
+}
+
+// GetNotificationsByPubkey is a helper method to define mock.On call
+//   - pubkey string
+//   - unreadOnly bool
+func (_e *Database_Expecter) GetNotificationsByPubkey(pubkey interface{}, unreadOnly interface{}) *Database_GetNotificationsByPubkey_Call {
+	return &Database_GetNotificationsByPubkey_Call{Call: _e.mock.On("GetNotificationsByPubkey", pubkey, unreadOnly)}
+}
+
+func (_c *Database_GetNotificationsByPubkey_Call) Run(run func(pubkey string, unreadOnly bool)) *Database_GetNotificationsByPubkey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *Database_GetNotificationsByPubkey_Call) Return(_a0 []db.Notification) *Database_GetNotificationsByPubkey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetNotificationsByPubkey_Call) RunAndReturn(run func(string, bool) []db.Notification) *Database_GetNotificationsByPubkey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenBudgetReservations provides a mock function with given fields: before
+func (_m *Database) GetOpenBudgetReservations(before time.Time) []db.BudgetReservation {
+	ret := _m.Called(before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenBudgetReservations")
+	}
+
+	var r0 []db.BudgetReservation
+	if rf, ok := ret.Get(0).(func(time.Time) []db.BudgetReservation); ok {
+		r0 = rf(before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BudgetReservation)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetOpenBudgetReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenBudgetReservations'
+type Database_GetOpenBudgetReservations_Call struct {
+	*mock.Call
+}
+
+// GetOpenBudgetReservations is a helper method to define mock.On call
+//   - before time.Time
+func (_e *Database_Expecter) GetOpenBudgetReservations(before interface{}) *Database_GetOpenBudgetReservations_Call {
+	return &Database_GetOpenBudgetReservations_Call{Call: _e.mock.On("GetOpenBudgetReservations", before)}
+}
+
+func (_c *Database_GetOpenBudgetReservations_Call) Run(run func(before time.Time)) *Database_GetOpenBudgetReservations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetOpenBudgetReservations_Call) Return(_a0 []db.BudgetReservation) *Database_GetOpenBudgetReservations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetOpenBudgetReservations_Call) RunAndReturn(run func(time.Time) []db.BudgetReservation) *Database_GetOpenBudgetReservations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenGithubIssues provides a mock function with given fields: r
+func (_m *Database) GetOpenGithubIssues(r *http.Request) (int64, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenGithubIssues")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (int64, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetOpenGithubIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenGithubIssues'
+type Database_GetOpenGithubIssues_Call struct {
+	*mock.Call
+}
+
+// GetOpenGithubIssues is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetOpenGithubIssues(r interface{}) *Database_GetOpenGithubIssues_Call {
+	return &Database_GetOpenGithubIssues_Call{Call: _e.mock.On("GetOpenGithubIssues", r)}
+}
+
+func (_c *Database_GetOpenGithubIssues_Call) Run(run func(r *http.Request)) *Database_GetOpenGithubIssues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetOpenGithubIssues_Call) Return(_a0 int64, _a1 error) *Database_GetOpenGithubIssues_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetOpenGithubIssues_Call) RunAndReturn(run func(*http.Request) (int64, error)) *Database_GetOpenGithubIssues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetParentOrganizationByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetParentOrganizationByUuid(uuid string) (db.ParentOrganization, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParentOrganizationByUuid")
+	}
+
+	var r0 db.ParentOrganization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.ParentOrganization, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.ParentOrganization); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.ParentOrganization)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetParentOrganizationByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetParentOrganizationByUuid'
+type Database_GetParentOrganizationByUuid_Call struct {
+	*mock.Call
+}
+
+// GetParentOrganizationByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetParentOrganizationByUuid(uuid interface{}) *Database_GetParentOrganizationByUuid_Call {
+	return &Database_GetParentOrganizationByUuid_Call{Call: _e.mock.On("GetParentOrganizationByUuid", uuid)}
+}
+
+func (_c *Database_GetParentOrganizationByUuid_Call) Run(run func(uuid string)) *Database_GetParentOrganizationByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetParentOrganizationByUuid_Call) Return(_a0 db.ParentOrganization, _a1 error) *Database_GetParentOrganizationByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetParentOrganizationByUuid_Call) RunAndReturn(run func(string) (db.ParentOrganization, error)) *Database_GetParentOrganizationByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetParentOrganizationMemberRoles provides a mock function with given fields: orgUuid, pubkey
+func (_m *Database) GetParentOrganizationMemberRoles(orgUuid string, pubkey string) []db.ParentOrganizationMember {
+	ret := _m.Called(orgUuid, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParentOrganizationMemberRoles")
+	}
+
+	var r0 []db.ParentOrganizationMember
+	if rf, ok := ret.Get(0).(func(string, string) []db.ParentOrganizationMember); ok {
+		r0 = rf(orgUuid, pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ParentOrganizationMember)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetParentOrganizationMemberRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetParentOrganizationMemberRoles'
+type Database_GetParentOrganizationMemberRoles_Call struct {
+	*mock.Call
+}
+
+// GetParentOrganizationMemberRoles is a helper method to define mock.On call
+//   - orgUuid string
+//   - pubkey string
+func (_e *Database_Expecter) GetParentOrganizationMemberRoles(orgUuid interface{}, pubkey interface{}) *Database_GetParentOrganizationMemberRoles_Call {
+	return &Database_GetParentOrganizationMemberRoles_Call{Call: _e.mock.On("GetParentOrganizationMemberRoles", orgUuid, pubkey)}
+}
+
+func (_c *Database_GetParentOrganizationMemberRoles_Call) Run(run func(orgUuid string, pubkey string)) *Database_GetParentOrganizationMemberRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetParentOrganizationMemberRoles_Call) Return(_a0 []db.ParentOrganizationMember) *Database_GetParentOrganizationMemberRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetParentOrganizationMemberRoles_Call) RunAndReturn(run func(string, string) []db.ParentOrganizationMember) *Database_GetParentOrganizationMemberRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetParentOrganizationMembers provides a mock function with given fields: orgUuid
+func (_m *Database) GetParentOrganizationMembers(orgUuid string) []db.ParentOrganizationMember {
+	ret := _m.Called(orgUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParentOrganizationMembers")
+	}
+
+	var r0 []db.ParentOrganizationMember
+	if rf, ok := ret.Get(0).(func(string) []db.ParentOrganizationMember); ok {
+		r0 = rf(orgUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ParentOrganizationMember)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetParentOrganizationMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetParentOrganizationMembers'
+type Database_GetParentOrganizationMembers_Call struct {
+	*mock.Call
+}
+
+// GetParentOrganizationMembers is a helper method to define mock.On call
+//   - orgUuid string
+func (_e *Database_Expecter) GetParentOrganizationMembers(orgUuid interface{}) *Database_GetParentOrganizationMembers_Call {
+	return &Database_GetParentOrganizationMembers_Call{Call: _e.mock.On("GetParentOrganizationMembers", orgUuid)}
+}
+
+func (_c *Database_GetParentOrganizationMembers_Call) Run(run func(orgUuid string)) *Database_GetParentOrganizationMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetParentOrganizationMembers_Call) Return(_a0 []db.ParentOrganizationMember) *Database_GetParentOrganizationMembers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetParentOrganizationMembers_Call) RunAndReturn(run func(string) []db.ParentOrganizationMember) *Database_GetParentOrganizationMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetParentOrganizationOverview provides a mock function with given fields: uuid
+func (_m *Database) GetParentOrganizationOverview(uuid string) (db.ParentOrganizationOverview, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParentOrganizationOverview")
+	}
+
+	var r0 db.ParentOrganizationOverview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.ParentOrganizationOverview, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.ParentOrganizationOverview); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.ParentOrganizationOverview)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetParentOrganizationOverview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetParentOrganizationOverview'
+type Database_GetParentOrganizationOverview_Call struct {
+	*mock.Call
+}
+
+// GetParentOrganizationOverview is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetParentOrganizationOverview(uuid interface{}) *Database_GetParentOrganizationOverview_Call {
+	return &Database_GetParentOrganizationOverview_Call{Call: _e.mock.On("GetParentOrganizationOverview", uuid)}
+}
+
+func (_c *Database_GetParentOrganizationOverview_Call) Run(run func(uuid string)) *Database_GetParentOrganizationOverview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetParentOrganizationOverview_Call) Return(_a0 db.ParentOrganizationOverview, _a1 error) *Database_GetParentOrganizationOverview_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetParentOrganizationOverview_Call) RunAndReturn(run func(string) (db.ParentOrganizationOverview, error)) *Database_GetParentOrganizationOverview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetParentOrganizationWorkspaces provides a mock function with given fields: orgUuid
+func (_m *Database) GetParentOrganizationWorkspaces(orgUuid string) []db.Workspace {
+	ret := _m.Called(orgUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParentOrganizationWorkspaces")
+	}
+
+	var r0 []db.Workspace
+	if rf, ok := ret.Get(0).(func(string) []db.Workspace); ok {
+		r0 = rf(orgUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Workspace)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetParentOrganizationWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetParentOrganizationWorkspaces'
+type Database_GetParentOrganizationWorkspaces_Call struct {
+	*mock.Call
+}
+
+// GetParentOrganizationWorkspaces is a helper method to define mock.On call
+//   - orgUuid string
+func (_e *Database_Expecter) GetParentOrganizationWorkspaces(orgUuid interface{}) *Database_GetParentOrganizationWorkspaces_Call {
+	return &Database_GetParentOrganizationWorkspaces_Call{Call: _e.mock.On("GetParentOrganizationWorkspaces", orgUuid)}
+}
+
+func (_c *Database_GetParentOrganizationWorkspaces_Call) Run(run func(orgUuid string)) *Database_GetParentOrganizationWorkspaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetParentOrganizationWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetParentOrganizationWorkspaces_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetParentOrganizationWorkspaces_Call) RunAndReturn(run func(string) []db.Workspace) *Database_GetParentOrganizationWorkspaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPaymentHistory provides a mock function with given fields: workspace_uuid, r
+func (_m *Database) GetPaymentHistory(workspace_uuid string, r *http.Request) []db.NewPaymentHistory {
+	ret := _m.Called(workspace_uuid, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentHistory")
+	}
+
+	var r0 []db.NewPaymentHistory
+	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.NewPaymentHistory); ok {
+		r0 = rf(workspace_uuid, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewPaymentHistory)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPaymentHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentHistory'
+type Database_GetPaymentHistory_Call struct {
+	*mock.Call
+}
+
+// GetPaymentHistory is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - r *http.Request
+func (_e *Database_Expecter) GetPaymentHistory(workspace_uuid interface{}, r interface{}) *Database_GetPaymentHistory_Call {
+	return &Database_GetPaymentHistory_Call{Call: _e.mock.On("GetPaymentHistory", workspace_uuid, r)}
+}
+
+func (_c *Database_GetPaymentHistory_Call) Run(run func(workspace_uuid string, r *http.Request)) *Database_GetPaymentHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetPaymentHistory_Call) Return(_a0 []db.NewPaymentHistory) *Database_GetPaymentHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPaymentHistory_Call) RunAndReturn(run func(string, *http.Request) []db.NewPaymentHistory) *Database_GetPaymentHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPaymentHistoryByCreated provides a mock function with given fields: created, workspace_uuid
+func (_m *Database) GetPaymentHistoryByCreated(created *time.Time, workspace_uuid string) db.NewPaymentHistory {
+	ret := _m.Called(created, workspace_uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentHistoryByCreated")
+	}
+
+	var r0 db.NewPaymentHistory
+	if rf, ok := ret.Get(0).(func(*time.Time, string) db.NewPaymentHistory); ok {
+		r0 = rf(created, workspace_uuid)
+	} else {
+		r0 = ret.Get(0).(db.NewPaymentHistory)
+	}
+
+	return r0
+}
+
+// Database_GetPaymentHistoryByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentHistoryByCreated'
+type Database_GetPaymentHistoryByCreated_Call struct {
+	*mock.Call
+}
+
+// GetPaymentHistoryByCreated is a helper method to define mock.On call
+//   - created *time.Time
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetPaymentHistoryByCreated(created interface{}, workspace_uuid interface{}) *Database_GetPaymentHistoryByCreated_Call {
+	return &Database_GetPaymentHistoryByCreated_Call{Call: _e.mock.On("GetPaymentHistoryByCreated", created, workspace_uuid)}
+}
+
+func (_c *Database_GetPaymentHistoryByCreated_Call) Run(run func(created *time.Time, workspace_uuid string)) *Database_GetPaymentHistoryByCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*time.Time), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPaymentHistoryByCreated_Call) Return(_a0 db.NewPaymentHistory) *Database_GetPaymentHistoryByCreated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPaymentHistoryByCreated_Call) RunAndReturn(run func(*time.Time, string) db.NewPaymentHistory) *Database_GetPaymentHistoryByCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingInvoices provides a mock function with given fields:
+func (_m *Database) GetPendingInvoices() []db.NewInvoiceList {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingInvoices")
+	}
+
+	var r0 []db.NewInvoiceList
+	if rf, ok := ret.Get(0).(func() []db.NewInvoiceList); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewInvoiceList)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPendingInvoices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingInvoices'
+type Database_GetPendingInvoices_Call struct {
+	*mock.Call
+}
+
+// GetPendingInvoices is a helper method to define mock.On call
+func (_e *Database_Expecter) GetPendingInvoices() *Database_GetPendingInvoices_Call {
+	return &Database_GetPendingInvoices_Call{Call: _e.mock.On("GetPendingInvoices")}
+}
+
+func (_c *Database_GetPendingInvoices_Call) Run(run func()) *Database_GetPendingInvoices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetPendingInvoices_Call) Return(_a0 []db.NewInvoiceList) *Database_GetPendingInvoices_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPendingInvoices_Call) RunAndReturn(run func() []db.NewInvoiceList) *Database_GetPendingInvoices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingSuperAdminChanges provides a mock function with given fields:
+func (_m *Database) GetPendingSuperAdminChanges() []db.SuperAdminChange {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingSuperAdminChanges")
+	}
+
+	var r0 []db.SuperAdminChange
+	if rf, ok := ret.Get(0).(func() []db.SuperAdminChange); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SuperAdminChange)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPendingSuperAdminChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingSuperAdminChanges'
+type Database_GetPendingSuperAdminChanges_Call struct {
+	*mock.Call
+}
+
+// GetPendingSuperAdminChanges is a helper method to define mock.On call
+func (_e *Database_Expecter) GetPendingSuperAdminChanges() *Database_GetPendingSuperAdminChanges_Call {
+	return &Database_GetPendingSuperAdminChanges_Call{Call: _e.mock.On("GetPendingSuperAdminChanges")}
+}
+
+func (_c *Database_GetPendingSuperAdminChanges_Call) Run(run func()) *Database_GetPendingSuperAdminChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetPendingSuperAdminChanges_Call) Return(_a0 []db.SuperAdminChange) *Database_GetPendingSuperAdminChanges_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPendingSuperAdminChanges_Call) RunAndReturn(run func() []db.SuperAdminChange) *Database_GetPendingSuperAdminChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPeopleByPubkeys provides a mock function with given fields: pubkeys
+func (_m *Database) GetPeopleByPubkeys(pubkeys []string) map[string]db.Person {
+	ret := _m.Called(pubkeys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeopleByPubkeys")
+	}
+
+	var r0 map[string]db.Person
+	if rf, ok := ret.Get(0).(func([]string) map[string]db.Person); ok {
+		r0 = rf(pubkeys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPeopleByPubkeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleByPubkeys'
+type Database_GetPeopleByPubkeys_Call struct {
+	*mock.Call
+}
+
+// GetPeopleByPubkeys is a helper method to define mock.On call
+//   - pubkeys []string
+func (_e *Database_Expecter) GetPeopleByPubkeys(pubkeys interface{}) *Database_GetPeopleByPubkeys_Call {
+	return &Database_GetPeopleByPubkeys_Call{Call: _e.mock.On("GetPeopleByPubkeys", pubkeys)}
+}
+
+func (_c *Database_GetPeopleByPubkeys_Call) Run(run func(pubkeys []string)) *Database_GetPeopleByPubkeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPeopleByPubkeys_Call) Return(_a0 map[string]db.Person) *Database_GetPeopleByPubkeys_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPeopleByPubkeys_Call) RunAndReturn(run func([]string) map[string]db.Person) *Database_GetPeopleByPubkeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPeopleBySearch provides a mock function with given fields: r
+func (_m *Database) GetPeopleBySearch(r *http.Request) []db.Person {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeopleBySearch")
+	}
+
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Person); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPeopleBySearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleBySearch'
+type Database_GetPeopleBySearch_Call struct {
+	*mock.Call
+}
+
+// GetPeopleBySearch is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetPeopleBySearch(r interface{}) *Database_GetPeopleBySearch_Call {
+	return &Database_GetPeopleBySearch_Call{Call: _e.mock.On("GetPeopleBySearch", r)}
+}
+
+func (_c *Database_GetPeopleBySearch_Call) Run(run func(r *http.Request)) *Database_GetPeopleBySearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *Database_GetPeopleBySearch_Call) Return(_a0 []db.Person) *Database_GetPeopleBySearch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPeopleBySearch_Call) RunAndReturn(run func(*http.Request) []db.Person) *Database_GetPeopleBySearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPeopleListShort provides a mock function with given fields: count
+func (_m *Database) GetPeopleListShort(count uint32) *[]db.PersonInShort {
+	ret := _m.Called(count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeopleListShort")
+	}
+
+	var r0 *[]db.PersonInShort
+	if rf, ok := ret.Get(0).(func(uint32) *[]db.PersonInShort); ok {
+		r0 = rf(count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]db.PersonInShort)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPeopleListShort_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleListShort'
+type Database_GetPeopleListShort_Call struct {
+	*mock.Call
+}
+
+// GetPeopleListShort is a helper method to define mock.On call
+//   - count uint32
+func (_e *Database_Expecter) GetPeopleListShort(count interface{}) *Database_GetPeopleListShort_Call {
+	return &Database_GetPeopleListShort_Call{Call: _e.mock.On("GetPeopleListShort", count)}
+}
+
+func (_c *Database_GetPeopleListShort_Call) Run(run func(count uint32)) *Database_GetPeopleListShort_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint32))
+	})
+	return _c
+}
+
+func (_c *Database_GetPeopleListShort_Call) Return(_a0 *[]db.PersonInShort) *Database_GetPeopleListShort_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPeopleListShort_Call) RunAndReturn(run func(uint32) *[]db.PersonInShort) *Database_GetPeopleListShort_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPerson provides a mock function with given fields: id
+func (_m *Database) GetPerson(id uint) db.Person {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPerson")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(uint) db.Person); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPerson'
+type Database_GetPerson_Call struct {
+	*mock.Call
+}
+
+// GetPerson is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) GetPerson(id interface{}) *Database_GetPerson_Call {
+	return &Database_GetPerson_Call{Call: _e.mock.On("GetPerson", id)}
+}
+
+func (_c *Database_GetPerson_Call) Run(run func(id uint)) *Database_GetPerson_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *Database_GetPerson_Call) Return(_a0 db.Person) *Database_GetPerson_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPerson_Call) RunAndReturn(run func(uint) db.Person) *Database_GetPerson_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonBoardBounties provides a mock function with given fields: pubkey
+func (_m *Database) GetPersonBoardBounties(pubkey string) []db.NewBounty {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonBoardBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPersonBoardBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonBoardBounties'
+type Database_GetPersonBoardBounties_Call struct {
+	*mock.Call
+}
+
+// GetPersonBoardBounties is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetPersonBoardBounties(pubkey interface{}) *Database_GetPersonBoardBounties_Call {
+	return &Database_GetPersonBoardBounties_Call{Call: _e.mock.On("GetPersonBoardBounties", pubkey)}
+}
+
+func (_c *Database_GetPersonBoardBounties_Call) Run(run func(pubkey string)) *Database_GetPersonBoardBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonBoardBounties_Call) Return(_a0 []db.NewBounty) *Database_GetPersonBoardBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonBoardBounties_Call) RunAndReturn(run func(string) []db.NewBounty) *Database_GetPersonBoardBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByGithubName provides a mock function with given fields: github_name
+func (_m *Database) GetPersonByGithubName(github_name string) db.Person {
+	ret := _m.Called(github_name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByGithubName")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(github_name)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByGithubName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByGithubName'
+type Database_GetPersonByGithubName_Call struct {
+	*mock.Call
+}
+
+// GetPersonByGithubName is a helper method to define mock.On call
+//   - github_name string
+func (_e *Database_Expecter) GetPersonByGithubName(github_name interface{}) *Database_GetPersonByGithubName_Call {
+	return &Database_GetPersonByGithubName_Call{Call: _e.mock.On("GetPersonByGithubName", github_name)}
+}
+
+func (_c *Database_GetPersonByGithubName_Call) Run(run func(github_name string)) *Database_GetPersonByGithubName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByGithubName_Call) Return(_a0 db.Person) *Database_GetPersonByGithubName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByGithubName_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByGithubName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByHandle provides a mock function with given fields: handle
+func (_m *Database) GetPersonByHandle(handle string) db.Person {
+	ret := _m.Called(handle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByHandle")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(handle)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByHandle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByHandle'
+type Database_GetPersonByHandle_Call struct {
+	*mock.Call
+}
+
+// GetPersonByHandle is a helper method to define mock.On call
+//   - handle string
+func (_e *Database_Expecter) GetPersonByHandle(handle interface{}) *Database_GetPersonByHandle_Call {
+	return &Database_GetPersonByHandle_Call{Call: _e.mock.On("GetPersonByHandle", handle)}
+}
+
+func (_c *Database_GetPersonByHandle_Call) Run(run func(handle string)) *Database_GetPersonByHandle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByHandle_Call) Return(_a0 db.Person) *Database_GetPersonByHandle_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByHandle_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByHandle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByPubkey provides a mock function with given fields: pubkey
+func (_m *Database) GetPersonByPubkey(pubkey string) db.Person {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByPubkey")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(pubkey)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByPubkey'
+type Database_GetPersonByPubkey_Call struct {
+	*mock.Call
+}
+
+// GetPersonByPubkey is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetPersonByPubkey(pubkey interface{}) *Database_GetPersonByPubkey_Call {
+	return &Database_GetPersonByPubkey_Call{Call: _e.mock.On("GetPersonByPubkey", pubkey)}
+}
+
+func (_c *Database_GetPersonByPubkey_Call) Run(run func(pubkey string)) *Database_GetPersonByPubkey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByPubkey_Call) Return(_a0 db.Person) *Database_GetPersonByPubkey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByPubkey_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByPubkey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByUniqueName provides a mock function with given fields: un
+func (_m *Database) GetPersonByUniqueName(un string) db.Person {
+	ret := _m.Called(un)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByUniqueName")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(un)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByUniqueName'
+type Database_GetPersonByUniqueName_Call struct {
+	*mock.Call
+}
+
+// GetPersonByUniqueName is a helper method to define mock.On call
+//   - un string
+func (_e *Database_Expecter) GetPersonByUniqueName(un interface{}) *Database_GetPersonByUniqueName_Call {
+	return &Database_GetPersonByUniqueName_Call{Call: _e.mock.On("GetPersonByUniqueName", un)}
+}
+
+func (_c *Database_GetPersonByUniqueName_Call) Run(run func(un string)) *Database_GetPersonByUniqueName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByUniqueName_Call) Return(_a0 db.Person) *Database_GetPersonByUniqueName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByUniqueName_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByUniqueName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetPersonByUuid(uuid string) db.Person {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonByUuid")
+	}
+
+	var r0 db.Person
+	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	return r0
+}
+
+// Database_GetPersonByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByUuid'
+type Database_GetPersonByUuid_Call struct {
+	*mock.Call
+}
+
+// GetPersonByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetPersonByUuid(uuid interface{}) *Database_GetPersonByUuid_Call {
+	return &Database_GetPersonByUuid_Call{Call: _e.mock.On("GetPersonByUuid", uuid)}
+}
+
+func (_c *Database_GetPersonByUuid_Call) Run(run func(uuid string)) *Database_GetPersonByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonByUuid_Call) Return(_a0 db.Person) *Database_GetPersonByUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonByUuid_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonCalendarBounties provides a mock function with given fields: pubkey
+func (_m *Database) GetPersonCalendarBounties(pubkey string) []db.NewBounty {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonCalendarBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPersonCalendarBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonCalendarBounties'
+type Database_GetPersonCalendarBounties_Call struct {
+	*mock.Call
+}
+
+// GetPersonCalendarBounties is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetPersonCalendarBounties(pubkey interface{}) *Database_GetPersonCalendarBounties_Call {
+	return &Database_GetPersonCalendarBounties_Call{Call: _e.mock.On("GetPersonCalendarBounties", pubkey)}
+}
+
+func (_c *Database_GetPersonCalendarBounties_Call) Run(run func(pubkey string)) *Database_GetPersonCalendarBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonCalendarBounties_Call) Return(_a0 []db.NewBounty) *Database_GetPersonCalendarBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonCalendarBounties_Call) RunAndReturn(run func(string) []db.NewBounty) *Database_GetPersonCalendarBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonEarnings provides a mock function with given fields: pubkey
+func (_m *Database) GetPersonEarnings(pubkey string) []db.EarningsRow {
+	ret := _m.Called(pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonEarnings")
+	}
+
+	var r0 []db.EarningsRow
+	if rf, ok := ret.Get(0).(func(string) []db.EarningsRow); ok {
+		r0 = rf(pubkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.EarningsRow)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPersonEarnings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonEarnings'
+type Database_GetPersonEarnings_Call struct {
+	*mock.Call
+}
+
+// GetPersonEarnings is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetPersonEarnings(pubkey interface{}) *Database_GetPersonEarnings_Call {
+	return &Database_GetPersonEarnings_Call{Call: _e.mock.On("GetPersonEarnings", pubkey)}
+}
+
+func (_c *Database_GetPersonEarnings_Call) Run(run func(pubkey string)) *Database_GetPersonEarnings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonEarnings_Call) Return(_a0 []db.EarningsRow) *Database_GetPersonEarnings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonEarnings_Call) RunAndReturn(run func(string) []db.EarningsRow) *Database_GetPersonEarnings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonMerges provides a mock function with given fields:
+func (_m *Database) GetPersonMerges() []db.PersonMerge {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonMerges")
+	}
+
+	var r0 []db.PersonMerge
+	if rf, ok := ret.Get(0).(func() []db.PersonMerge); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PersonMerge)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPersonMerges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonMerges'
+type Database_GetPersonMerges_Call struct {
+	*mock.Call
+}
+
+// GetPersonMerges is a helper method to define mock.On call
+func (_e *Database_Expecter) GetPersonMerges() *Database_GetPersonMerges_Call {
+	return &Database_GetPersonMerges_Call{Call: _e.mock.On("GetPersonMerges")}
+}
+
+func (_c *Database_GetPersonMerges_Call) Run(run func()) *Database_GetPersonMerges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonMerges_Call) Return(_a0 []db.PersonMerge) *Database_GetPersonMerges_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonMerges_Call) RunAndReturn(run func() []db.PersonMerge) *Database_GetPersonMerges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPersonScheduledFeatureCalls provides a mock function with given fields: pubkey, since
+func (_m *Database) GetPersonScheduledFeatureCalls(pubkey string, since time.Time) []db.FeatureCall {
+	ret := _m.Called(pubkey, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonScheduledFeatureCalls")
+	}
+
+	var r0 []db.FeatureCall
+	if rf, ok := ret.Get(0).(func(string, time.Time) []db.FeatureCall); ok {
+		r0 = rf(pubkey, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeatureCall)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPersonScheduledFeatureCalls_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonScheduledFeatureCalls'
+type Database_GetPersonScheduledFeatureCalls_Call struct {
+	*mock.Call
+}
+
+// GetPersonScheduledFeatureCalls is a helper method to define mock.On call
+//   - pubkey string
+//   - since time.Time
+func (_e *Database_Expecter) GetPersonScheduledFeatureCalls(pubkey interface{}, since interface{}) *Database_GetPersonScheduledFeatureCalls_Call {
+	return &Database_GetPersonScheduledFeatureCalls_Call{Call: _e.mock.On("GetPersonScheduledFeatureCalls", pubkey, since)}
+}
+
+func (_c *Database_GetPersonScheduledFeatureCalls_Call) Run(run func(pubkey string, since time.Time)) *Database_GetPersonScheduledFeatureCalls_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetPersonScheduledFeatureCalls_Call) Return(_a0 []db.FeatureCall) *Database_GetPersonScheduledFeatureCalls_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPersonScheduledFeatureCalls_Call) RunAndReturn(run func(string, time.Time) []db.FeatureCall) *Database_GetPersonScheduledFeatureCalls_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhaseByUuid provides a mock function with given fields: phaseUuid
+func (_m *Database) GetPhaseByUuid(phaseUuid string) (db.FeaturePhase, error) {
+	ret := _m.Called(phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhaseByUuid")
+	}
+
+	var r0 db.FeaturePhase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.FeaturePhase, error)); ok {
+		return rf(phaseUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.FeaturePhase); ok {
+		r0 = rf(phaseUuid)
+	} else {
+		r0 = ret.Get(0).(db.FeaturePhase)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(phaseUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPhaseByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhaseByUuid'
+type Database_GetPhaseByUuid_Call struct {
+	*mock.Call
+}
+
+// GetPhaseByUuid is a helper method to define mock.On call
+//   - phaseUuid string
+func (_e *Database_Expecter) GetPhaseByUuid(phaseUuid interface{}) *Database_GetPhaseByUuid_Call {
+	return &Database_GetPhaseByUuid_Call{Call: _e.mock.On("GetPhaseByUuid", phaseUuid)}
+}
+
+func (_c *Database_GetPhaseByUuid_Call) Run(run func(phaseUuid string)) *Database_GetPhaseByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhaseByUuid_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_GetPhaseByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPhaseByUuid_Call) RunAndReturn(run func(string) (db.FeaturePhase, error)) *Database_GetPhaseByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhaseDocumentByVersion provides a mock function with given fields: phaseUuid, docType, version
+func (_m *Database) GetPhaseDocumentByVersion(phaseUuid string, docType db.PhaseDocumentType, version int) (db.PhaseDocument, error) {
+	ret := _m.Called(phaseUuid, docType, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhaseDocumentByVersion")
+	}
+
+	var r0 db.PhaseDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, db.PhaseDocumentType, int) (db.PhaseDocument, error)); ok {
+		return rf(phaseUuid, docType, version)
+	}
+	if rf, ok := ret.Get(0).(func(string, db.PhaseDocumentType, int) db.PhaseDocument); ok {
+		r0 = rf(phaseUuid, docType, version)
+	} else {
+		r0 = ret.Get(0).(db.PhaseDocument)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, db.PhaseDocumentType, int) error); ok {
+		r1 = rf(phaseUuid, docType, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPhaseDocumentByVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhaseDocumentByVersion'
+type Database_GetPhaseDocumentByVersion_Call struct {
+	*mock.Call
+}
+
+// GetPhaseDocumentByVersion is a helper method to define mock.On call
+//   - phaseUuid string
+//   - docType db.PhaseDocumentType
+//   - version int
+func (_e *Database_Expecter) GetPhaseDocumentByVersion(phaseUuid interface{}, docType interface{}, version interface{}) *Database_GetPhaseDocumentByVersion_Call {
+	return &Database_GetPhaseDocumentByVersion_Call{Call: _e.mock.On("GetPhaseDocumentByVersion", phaseUuid, docType, version)}
+}
+
+func (_c *Database_GetPhaseDocumentByVersion_Call) Run(run func(phaseUuid string, docType db.PhaseDocumentType, version int)) *Database_GetPhaseDocumentByVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(db.PhaseDocumentType), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhaseDocumentByVersion_Call) Return(_a0 db.PhaseDocument, _a1 error) *Database_GetPhaseDocumentByVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPhaseDocumentByVersion_Call) RunAndReturn(run func(string, db.PhaseDocumentType, int) (db.PhaseDocument, error)) *Database_GetPhaseDocumentByVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhaseDocumentHistory provides a mock function with given fields: phaseUuid, docType
+func (_m *Database) GetPhaseDocumentHistory(phaseUuid string, docType db.PhaseDocumentType) []db.PhaseDocument {
+	ret := _m.Called(phaseUuid, docType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhaseDocumentHistory")
+	}
+
+	var r0 []db.PhaseDocument
+	if rf, ok := ret.Get(0).(func(string, db.PhaseDocumentType) []db.PhaseDocument); ok {
+		r0 = rf(phaseUuid, docType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PhaseDocument)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPhaseDocumentHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhaseDocumentHistory'
+type Database_GetPhaseDocumentHistory_Call struct {
+	*mock.Call
+}
+
+// GetPhaseDocumentHistory is a helper method to define mock.On call
+//   - phaseUuid string
+//   - docType db.PhaseDocumentType
+func (_e *Database_Expecter) GetPhaseDocumentHistory(phaseUuid interface{}, docType interface{}) *Database_GetPhaseDocumentHistory_Call {
+	return &Database_GetPhaseDocumentHistory_Call{Call: _e.mock.On("GetPhaseDocumentHistory", phaseUuid, docType)}
+}
+
+func (_c *Database_GetPhaseDocumentHistory_Call) Run(run func(phaseUuid string, docType db.PhaseDocumentType)) *Database_GetPhaseDocumentHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(db.PhaseDocumentType))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhaseDocumentHistory_Call) Return(_a0 []db.PhaseDocument) *Database_GetPhaseDocumentHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPhaseDocumentHistory_Call) RunAndReturn(run func(string, db.PhaseDocumentType) []db.PhaseDocument) *Database_GetPhaseDocumentHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhasePlanByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetPhasePlanByUuid(uuid string) (db.PhasePlan, error) {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhasePlanByUuid")
+	}
+
+	var r0 db.PhasePlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.PhasePlan, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.PhasePlan); ok {
+		r0 = rf(uuid)
+	} else {
+		r0 = ret.Get(0).(db.PhasePlan)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetPhasePlanByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhasePlanByUuid'
+type Database_GetPhasePlanByUuid_Call struct {
+	*mock.Call
+}
+
+// GetPhasePlanByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetPhasePlanByUuid(uuid interface{}) *Database_GetPhasePlanByUuid_Call {
+	return &Database_GetPhasePlanByUuid_Call{Call: _e.mock.On("GetPhasePlanByUuid", uuid)}
+}
+
+func (_c *Database_GetPhasePlanByUuid_Call) Run(run func(uuid string)) *Database_GetPhasePlanByUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhasePlanByUuid_Call) Return(_a0 db.PhasePlan, _a1 error) *Database_GetPhasePlanByUuid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetPhasePlanByUuid_Call) RunAndReturn(run func(string) (db.PhasePlan, error)) *Database_GetPhasePlanByUuid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhasePlans provides a mock function with given fields: featureUuid, phaseUuid
+func (_m *Database) GetPhasePlans(featureUuid string, phaseUuid string) []db.PhasePlan {
+	ret := _m.Called(featureUuid, phaseUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhasePlans")
+	}
+
+	var r0 []db.PhasePlan
+	if rf, ok := ret.Get(0).(func(string, string) []db.PhasePlan); ok {
+		r0 = rf(featureUuid, phaseUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PhasePlan)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPhasePlans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhasePlans'
+type Database_GetPhasePlans_Call struct {
+	*mock.Call
+}
+
+// GetPhasePlans is a helper method to define mock.On call
+//   - featureUuid string
+//   - phaseUuid string
+func (_e *Database_Expecter) GetPhasePlans(featureUuid interface{}, phaseUuid interface{}) *Database_GetPhasePlans_Call {
+	return &Database_GetPhasePlans_Call{Call: _e.mock.On("GetPhasePlans", featureUuid, phaseUuid)}
+}
+
+func (_c *Database_GetPhasePlans_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_GetPhasePlans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhasePlans_Call) Return(_a0 []db.PhasePlan) *Database_GetPhasePlans_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPhasePlans_Call) RunAndReturn(run func(string, string) []db.PhasePlan) *Database_GetPhasePlans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhasesByFeatureUuid provides a mock function with given fields: featureUuid
+func (_m *Database) GetPhasesByFeatureUuid(featureUuid string) []db.FeaturePhase {
+	ret := _m.Called(featureUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhasesByFeatureUuid")
+	}
+
+	var r0 []db.FeaturePhase
+	if rf, ok := ret.Get(0).(func(string) []db.FeaturePhase); ok {
+		r0 = rf(featureUuid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeaturePhase)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetPhasesByFeatureUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhasesByFeatureUuid'
+type Database_GetPhasesByFeatureUuid_Call struct {
+	*mock.Call
+}
+
+// GetPhasesByFeatureUuid is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) GetPhasesByFeatureUuid(featureUuid interface{}) *Database_GetPhasesByFeatureUuid_Call {
+	return &Database_GetPhasesByFeatureUuid_Call{Call: _e.mock.On("GetPhasesByFeatureUuid", featureUuid)}
+}
+
+func (_c *Database_GetPhasesByFeatureUuid_Call) Run(run func(featureUuid string)) *Database_GetPhasesByFeatureUuid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetPhasesByFeatureUuid_Call) Return(_a0 []db.FeaturePhase) *Database_GetPhasesByFeatureUuid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetPhasesByFeatureUuid_Call) RunAndReturn(run func(string) []db.FeaturePhase) *Database_GetPhasesByFeatureUuid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateConnectionCode provides a mock function with given fields: c
-func (_m *Database) CreateConnectionCode(c []db.ConnectionCodes) ([]db.ConnectionCodes, error) {
-	ret := _m.Called(c)
+// GetPreviousBountyByCreated provides a mock function with given fields: r
+func (_m *Database) GetPreviousBountyByCreated(r *http.Request) (uint, error) {
+	ret := _m.Called(r)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateConnectionCode")
+		panic("no return value specified for GetPreviousBountyByCreated")
 	}
 
-	var r0 []db.ConnectionCodes
+	var r0 uint
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]db.ConnectionCodes) ([]db.ConnectionCodes, error)); ok {
-		return rf(c)
+	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+		return rf(r)
 	}
-	if rf, ok := ret.Get(0).(func([]db.ConnectionCodes) []db.ConnectionCodes); ok {
-		r0 = rf(c)
+	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+		r0 = rf(r)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.ConnectionCodes)
-		}
+		r0 = ret.Get(0).(uint)
 	}
 
-	if rf, ok := ret.Get(1).(func([]db.ConnectionCodes) error); ok {
-		r1 = rf(c)
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -675,57 +11193,55 @@ func (_m *Database) CreateConnectionCode(c []db.ConnectionCodes) ([]db.Connectio
 	return r0, r1
 }
 
-// Database_CreateConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateConnectionCode'
-type Database_CreateConnectionCode_Call struct {
+// Database_GetPreviousBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreviousBountyByCreated'
+type Database_GetPreviousBountyByCreated_Call struct {
 	*mock.Call
 }
 
-// CreateConnectionCode is a helper method to define mock.On call
-//   - c []db.ConnectionCodes
-func (_e *Database_Expecter) CreateConnectionCode(c interface{}) *Database_CreateConnectionCode_Call {
-	return &Database_CreateConnectionCode_Call{Call: _e.mock.On("CreateConnectionCode", c)}
+// GetPreviousBountyByCreated is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetPreviousBountyByCreated(r interface{}) *Database_GetPreviousBountyByCreated_Call {
+	return &Database_GetPreviousBountyByCreated_Call{Call: _e.mock.On("GetPreviousBountyByCreated", r)}
 }
 
-func (_c *Database_CreateConnectionCode_Call) Run(run func(c []db.ConnectionCodes)) *Database_CreateConnectionCode_Call {
+func (_c *Database_GetPreviousBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetPreviousBountyByCreated_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]db.ConnectionCodes))
+		run(args[0].(*http.Request))
 	})
 	return _c
 }
 
-func (_c *Database_CreateConnectionCode_Call) Return(_a0 []db.ConnectionCodes, _a1 error) *Database_CreateConnectionCode_Call {
+func (_c *Database_GetPreviousBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetPreviousBountyByCreated_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CreateConnectionCode_Call) RunAndReturn(run func([]db.ConnectionCodes) ([]db.ConnectionCodes, error)) *Database_CreateConnectionCode_Call {
+func (_c *Database_GetPreviousBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetPreviousBountyByCreated_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateLeaderBoard provides a mock function with given fields: uuid, leaderboards
-func (_m *Database) CreateLeaderBoard(uuid string, leaderboards []db.LeaderBoard) ([]db.LeaderBoard, error) {
-	ret := _m.Called(uuid, leaderboards)
+// GetPreviousWorkspaceBountyByCreated provides a mock function with given fields: r
+func (_m *Database) GetPreviousWorkspaceBountyByCreated(r *http.Request) (uint, error) {
+	ret := _m.Called(r)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateLeaderBoard")
+		panic("no return value specified for GetPreviousWorkspaceBountyByCreated")
 	}
 
-	var r0 []db.LeaderBoard
+	var r0 uint
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, []db.LeaderBoard) ([]db.LeaderBoard, error)); ok {
-		return rf(uuid, leaderboards)
+	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+		return rf(r)
 	}
-	if rf, ok := ret.Get(0).(func(string, []db.LeaderBoard) []db.LeaderBoard); ok {
-		r0 = rf(uuid, leaderboards)
+	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+		r0 = rf(r)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.LeaderBoard)
-		}
+		r0 = ret.Get(0).(uint)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, []db.LeaderBoard) error); ok {
-		r1 = rf(uuid, leaderboards)
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -733,56 +11249,55 @@ func (_m *Database) CreateLeaderBoard(uuid string, leaderboards []db.LeaderBoard
 	return r0, r1
 }
 
-// Database_CreateLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLeaderBoard'
-type Database_CreateLeaderBoard_Call struct {
+// Database_GetPreviousWorkspaceBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreviousWorkspaceBountyByCreated'
+type Database_GetPreviousWorkspaceBountyByCreated_Call struct {
 	*mock.Call
 }
 
-// CreateLeaderBoard is a helper method to define mock.On call
-//   - uuid string
-//   - leaderboards []db.LeaderBoard
-func (_e *Database_Expecter) CreateLeaderBoard(uuid interface{}, leaderboards interface{}) *Database_CreateLeaderBoard_Call {
-	return &Database_CreateLeaderBoard_Call{Call: _e.mock.On("CreateLeaderBoard", uuid, leaderboards)}
+// GetPreviousWorkspaceBountyByCreated is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetPreviousWorkspaceBountyByCreated(r interface{}) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+	return &Database_GetPreviousWorkspaceBountyByCreated_Call{Call: _e.mock.On("GetPreviousWorkspaceBountyByCreated", r)}
 }
 
-func (_c *Database_CreateLeaderBoard_Call) Run(run func(uuid string, leaderboards []db.LeaderBoard)) *Database_CreateLeaderBoard_Call {
+func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetPreviousWorkspaceBountyByCreated_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].([]db.LeaderBoard))
+		run(args[0].(*http.Request))
 	})
 	return _c
 }
 
-func (_c *Database_CreateLeaderBoard_Call) Return(_a0 []db.LeaderBoard, _a1 error) *Database_CreateLeaderBoard_Call {
+func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetPreviousWorkspaceBountyByCreated_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CreateLeaderBoard_Call) RunAndReturn(run func(string, []db.LeaderBoard) ([]db.LeaderBoard, error)) *Database_CreateLeaderBoard_Call {
+func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetPreviousWorkspaceBountyByCreated_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateLnUser provides a mock function with given fields: lnKey
-func (_m *Database) CreateLnUser(lnKey string) (db.Person, error) {
-	ret := _m.Called(lnKey)
+// GetShortLinkByCode provides a mock function with given fields: code
+func (_m *Database) GetShortLinkByCode(code string) (db.ShortLink, error) {
+	ret := _m.Called(code)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateLnUser")
+		panic("no return value specified for GetShortLinkByCode")
 	}
 
-	var r0 db.Person
+	var r0 db.ShortLink
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (db.Person, error)); ok {
-		return rf(lnKey)
+	if rf, ok := ret.Get(0).(func(string) (db.ShortLink, error)); ok {
+		return rf(code)
 	}
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(lnKey)
+	if rf, ok := ret.Get(0).(func(string) db.ShortLink); ok {
+		r0 = rf(code)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Get(0).(db.ShortLink)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(lnKey)
+		r1 = rf(code)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -790,111 +11305,290 @@ func (_m *Database) CreateLnUser(lnKey string) (db.Person, error) {
 	return r0, r1
 }
 
-// Database_CreateLnUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLnUser'
-type Database_CreateLnUser_Call struct {
+// Database_GetShortLinkByCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShortLinkByCode'
+type Database_GetShortLinkByCode_Call struct {
 	*mock.Call
 }
 
-// CreateLnUser is a helper method to define mock.On call
-//   - lnKey string
-func (_e *Database_Expecter) CreateLnUser(lnKey interface{}) *Database_CreateLnUser_Call {
-	return &Database_CreateLnUser_Call{Call: _e.mock.On("CreateLnUser", lnKey)}
+// GetShortLinkByCode is a helper method to define mock.On call
+//   - code string
+func (_e *Database_Expecter) GetShortLinkByCode(code interface{}) *Database_GetShortLinkByCode_Call {
+	return &Database_GetShortLinkByCode_Call{Call: _e.mock.On("GetShortLinkByCode", code)}
 }
 
-func (_c *Database_CreateLnUser_Call) Run(run func(lnKey string)) *Database_CreateLnUser_Call {
+func (_c *Database_GetShortLinkByCode_Call) Run(run func(code string)) *Database_GetShortLinkByCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateLnUser_Call) Return(_a0 db.Person, _a1 error) *Database_CreateLnUser_Call {
+func (_c *Database_GetShortLinkByCode_Call) Return(_a0 db.ShortLink, _a1 error) *Database_GetShortLinkByCode_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CreateLnUser_Call) RunAndReturn(run func(string) (db.Person, error)) *Database_CreateLnUser_Call {
+func (_c *Database_GetShortLinkByCode_Call) RunAndReturn(run func(string) (db.ShortLink, error)) *Database_GetShortLinkByCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditBot provides a mock function with given fields: b
-func (_m *Database) CreateOrEditBot(b db.Bot) (db.Bot, error) {
-	ret := _m.Called(b)
+// GetSlackTeam provides a mock function with given fields: teamId
+func (_m *Database) GetSlackTeam(teamId string) db.SlackTeam {
+	ret := _m.Called(teamId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditBot")
+		panic("no return value specified for GetSlackTeam")
 	}
 
-	var r0 db.Bot
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Bot) (db.Bot, error)); ok {
-		return rf(b)
+	var r0 db.SlackTeam
+	if rf, ok := ret.Get(0).(func(string) db.SlackTeam); ok {
+		r0 = rf(teamId)
+	} else {
+		r0 = ret.Get(0).(db.SlackTeam)
 	}
-	if rf, ok := ret.Get(0).(func(db.Bot) db.Bot); ok {
-		r0 = rf(b)
+
+	return r0
+}
+
+// Database_GetSlackTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSlackTeam'
+type Database_GetSlackTeam_Call struct {
+	*mock.Call
+}
+
+// GetSlackTeam is a helper method to define mock.On call
+//   - teamId string
+func (_e *Database_Expecter) GetSlackTeam(teamId interface{}) *Database_GetSlackTeam_Call {
+	return &Database_GetSlackTeam_Call{Call: _e.mock.On("GetSlackTeam", teamId)}
+}
+
+func (_c *Database_GetSlackTeam_Call) Run(run func(teamId string)) *Database_GetSlackTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetSlackTeam_Call) Return(_a0 db.SlackTeam) *Database_GetSlackTeam_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetSlackTeam_Call) RunAndReturn(run func(string) db.SlackTeam) *Database_GetSlackTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSlackUserLink provides a mock function with given fields: teamId, slackUserId
+func (_m *Database) GetSlackUserLink(teamId string, slackUserId string) db.SlackUserLink {
+	ret := _m.Called(teamId, slackUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSlackUserLink")
+	}
+
+	var r0 db.SlackUserLink
+	if rf, ok := ret.Get(0).(func(string, string) db.SlackUserLink); ok {
+		r0 = rf(teamId, slackUserId)
 	} else {
-		r0 = ret.Get(0).(db.Bot)
+		r0 = ret.Get(0).(db.SlackUserLink)
+	}
+
+	return r0
+}
+
+// Database_GetSlackUserLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSlackUserLink'
+type Database_GetSlackUserLink_Call struct {
+	*mock.Call
+}
+
+// GetSlackUserLink is a helper method to define mock.On call
+//   - teamId string
+//   - slackUserId string
+func (_e *Database_Expecter) GetSlackUserLink(teamId interface{}, slackUserId interface{}) *Database_GetSlackUserLink_Call {
+	return &Database_GetSlackUserLink_Call{Call: _e.mock.On("GetSlackUserLink", teamId, slackUserId)}
+}
+
+func (_c *Database_GetSlackUserLink_Call) Run(run func(teamId string, slackUserId string)) *Database_GetSlackUserLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetSlackUserLink_Call) Return(_a0 db.SlackUserLink) *Database_GetSlackUserLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetSlackUserLink_Call) RunAndReturn(run func(string, string) db.SlackUserLink) *Database_GetSlackUserLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStaleAssignedBounties provides a mock function with given fields: inactiveSince
+func (_m *Database) GetStaleAssignedBounties(inactiveSince time.Time) []db.NewBounty {
+	ret := _m.Called(inactiveSince)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStaleAssignedBounties")
+	}
+
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(time.Time) []db.NewBounty); ok {
+		r0 = rf(inactiveSince)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetStaleAssignedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStaleAssignedBounties'
+type Database_GetStaleAssignedBounties_Call struct {
+	*mock.Call
+}
+
+// GetStaleAssignedBounties is a helper method to define mock.On call
+//   - inactiveSince time.Time
+func (_e *Database_Expecter) GetStaleAssignedBounties(inactiveSince interface{}) *Database_GetStaleAssignedBounties_Call {
+	return &Database_GetStaleAssignedBounties_Call{Call: _e.mock.On("GetStaleAssignedBounties", inactiveSince)}
+}
+
+func (_c *Database_GetStaleAssignedBounties_Call) Run(run func(inactiveSince time.Time)) *Database_GetStaleAssignedBounties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_GetStaleAssignedBounties_Call) Return(_a0 []db.NewBounty) *Database_GetStaleAssignedBounties_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetStaleAssignedBounties_Call) RunAndReturn(run func(time.Time) []db.NewBounty) *Database_GetStaleAssignedBounties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSuperAdminPubkeys provides a mock function with given fields:
+func (_m *Database) GetSuperAdminPubkeys() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSuperAdminPubkeys")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// Database_GetSuperAdminPubkeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSuperAdminPubkeys'
+type Database_GetSuperAdminPubkeys_Call struct {
+	*mock.Call
+}
+
+// GetSuperAdminPubkeys is a helper method to define mock.On call
+func (_e *Database_Expecter) GetSuperAdminPubkeys() *Database_GetSuperAdminPubkeys_Call {
+	return &Database_GetSuperAdminPubkeys_Call{Call: _e.mock.On("GetSuperAdminPubkeys")}
+}
+
+func (_c *Database_GetSuperAdminPubkeys_Call) Run(run func()) *Database_GetSuperAdminPubkeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_GetSuperAdminPubkeys_Call) Return(_a0 []string) *Database_GetSuperAdminPubkeys_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_GetSuperAdminPubkeys_Call) RunAndReturn(run func() []string) *Database_GetSuperAdminPubkeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSuperAdmins provides a mock function with given fields:
+func (_m *Database) GetSuperAdmins() []db.SuperAdmin {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSuperAdmins")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Bot) error); ok {
-		r1 = rf(b)
+	var r0 []db.SuperAdmin
+	if rf, ok := ret.Get(0).(func() []db.SuperAdmin); ok {
+		r0 = rf()
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SuperAdmin)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBot'
-type Database_CreateOrEditBot_Call struct {
+// Database_GetSuperAdmins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSuperAdmins'
+type Database_GetSuperAdmins_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditBot is a helper method to define mock.On call
-//   - b db.Bot
-func (_e *Database_Expecter) CreateOrEditBot(b interface{}) *Database_CreateOrEditBot_Call {
-	return &Database_CreateOrEditBot_Call{Call: _e.mock.On("CreateOrEditBot", b)}
+// GetSuperAdmins is a helper method to define mock.On call
+func (_e *Database_Expecter) GetSuperAdmins() *Database_GetSuperAdmins_Call {
+	return &Database_GetSuperAdmins_Call{Call: _e.mock.On("GetSuperAdmins")}
 }
 
-func (_c *Database_CreateOrEditBot_Call) Run(run func(b db.Bot)) *Database_CreateOrEditBot_Call {
+func (_c *Database_GetSuperAdmins_Call) Run(run func()) *Database_GetSuperAdmins_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Bot))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditBot_Call) Return(_a0 db.Bot, _a1 error) *Database_CreateOrEditBot_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetSuperAdmins_Call) Return(_a0 []db.SuperAdmin) *Database_GetSuperAdmins_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditBot_Call) RunAndReturn(run func(db.Bot) (db.Bot, error)) *Database_CreateOrEditBot_Call {
+func (_c *Database_GetSuperAdmins_Call) RunAndReturn(run func() []db.SuperAdmin) *Database_GetSuperAdmins_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditBounty provides a mock function with given fields: b
-func (_m *Database) CreateOrEditBounty(b db.NewBounty) (db.NewBounty, error) {
-	ret := _m.Called(b)
+// GetTicketReviewByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetTicketReviewByUuid(uuid string) (db.TicketReview, error) {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditBounty")
+		panic("no return value specified for GetTicketReviewByUuid")
 	}
 
-	var r0 db.NewBounty
+	var r0 db.TicketReview
 	var r1 error
-	if rf, ok := ret.Get(0).(func(db.NewBounty) (db.NewBounty, error)); ok {
-		return rf(b)
+	if rf, ok := ret.Get(0).(func(string) (db.TicketReview, error)); ok {
+		return rf(uuid)
 	}
-	if rf, ok := ret.Get(0).(func(db.NewBounty) db.NewBounty); ok {
-		r0 = rf(b)
+	if rf, ok := ret.Get(0).(func(string) db.TicketReview); ok {
+		r0 = rf(uuid)
 	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		r0 = ret.Get(0).(db.TicketReview)
 	}
 
-	if rf, ok := ret.Get(1).(func(db.NewBounty) error); ok {
-		r1 = rf(b)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -902,2205 +11596,2118 @@ func (_m *Database) CreateOrEditBounty(b db.NewBounty) (db.NewBounty, error) {
 	return r0, r1
 }
 
-// Database_CreateOrEditBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditBounty'
-type Database_CreateOrEditBounty_Call struct {
+// Database_GetTicketReviewByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTicketReviewByUuid'
+type Database_GetTicketReviewByUuid_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditBounty is a helper method to define mock.On call
-//   - b db.NewBounty
-func (_e *Database_Expecter) CreateOrEditBounty(b interface{}) *Database_CreateOrEditBounty_Call {
-	return &Database_CreateOrEditBounty_Call{Call: _e.mock.On("CreateOrEditBounty", b)}
+// GetTicketReviewByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetTicketReviewByUuid(uuid interface{}) *Database_GetTicketReviewByUuid_Call {
+	return &Database_GetTicketReviewByUuid_Call{Call: _e.mock.On("GetTicketReviewByUuid", uuid)}
 }
 
-func (_c *Database_CreateOrEditBounty_Call) Run(run func(b db.NewBounty)) *Database_CreateOrEditBounty_Call {
+func (_c *Database_GetTicketReviewByUuid_Call) Run(run func(uuid string)) *Database_GetTicketReviewByUuid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewBounty))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_CreateOrEditBounty_Call {
+func (_c *Database_GetTicketReviewByUuid_Call) Return(_a0 db.TicketReview, _a1 error) *Database_GetTicketReviewByUuid_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_CreateOrEditBounty_Call) RunAndReturn(run func(db.NewBounty) (db.NewBounty, error)) *Database_CreateOrEditBounty_Call {
+func (_c *Database_GetTicketReviewByUuid_Call) RunAndReturn(run func(string) (db.TicketReview, error)) *Database_GetTicketReviewByUuid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditFeature provides a mock function with given fields: m
-func (_m *Database) CreateOrEditFeature(m db.WorkspaceFeatures) (db.WorkspaceFeatures, error) {
-	ret := _m.Called(m)
+// GetTicketReviews provides a mock function with given fields: ticketUuid
+func (_m *Database) GetTicketReviews(ticketUuid string) []db.TicketReview {
+	ret := _m.Called(ticketUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditFeature")
-	}
-
-	var r0 db.WorkspaceFeatures
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures) (db.WorkspaceFeatures, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures) db.WorkspaceFeatures); ok {
-		r0 = rf(m)
-	} else {
-		r0 = ret.Get(0).(db.WorkspaceFeatures)
+		panic("no return value specified for GetTicketReviews")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.WorkspaceFeatures) error); ok {
-		r1 = rf(m)
+	var r0 []db.TicketReview
+	if rf, ok := ret.Get(0).(func(string) []db.TicketReview); ok {
+		r0 = rf(ticketUuid)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TicketReview)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditFeature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeature'
-type Database_CreateOrEditFeature_Call struct {
+// Database_GetTicketReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTicketReviews'
+type Database_GetTicketReviews_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditFeature is a helper method to define mock.On call
-//   - m db.WorkspaceFeatures
-func (_e *Database_Expecter) CreateOrEditFeature(m interface{}) *Database_CreateOrEditFeature_Call {
-	return &Database_CreateOrEditFeature_Call{Call: _e.mock.On("CreateOrEditFeature", m)}
+// GetTicketReviews is a helper method to define mock.On call
+//   - ticketUuid string
+func (_e *Database_Expecter) GetTicketReviews(ticketUuid interface{}) *Database_GetTicketReviews_Call {
+	return &Database_GetTicketReviews_Call{Call: _e.mock.On("GetTicketReviews", ticketUuid)}
 }
 
-func (_c *Database_CreateOrEditFeature_Call) Run(run func(m db.WorkspaceFeatures)) *Database_CreateOrEditFeature_Call {
+func (_c *Database_GetTicketReviews_Call) Run(run func(ticketUuid string)) *Database_GetTicketReviews_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.WorkspaceFeatures))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeature_Call) Return(_a0 db.WorkspaceFeatures, _a1 error) *Database_CreateOrEditFeature_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetTicketReviews_Call) Return(_a0 []db.TicketReview) *Database_GetTicketReviews_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeature_Call) RunAndReturn(run func(db.WorkspaceFeatures) (db.WorkspaceFeatures, error)) *Database_CreateOrEditFeature_Call {
+func (_c *Database_GetTicketReviews_Call) RunAndReturn(run func(string) []db.TicketReview) *Database_GetTicketReviews_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditFeaturePhase provides a mock function with given fields: phase
-func (_m *Database) CreateOrEditFeaturePhase(phase db.FeaturePhase) (db.FeaturePhase, error) {
-	ret := _m.Called(phase)
+// GetTotalEarnedByPubkey provides a mock function with given fields: pubkey
+func (_m *Database) GetTotalEarnedByPubkey(pubkey string) uint {
+	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditFeaturePhase")
-	}
-
-	var r0 db.FeaturePhase
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.FeaturePhase) (db.FeaturePhase, error)); ok {
-		return rf(phase)
-	}
-	if rf, ok := ret.Get(0).(func(db.FeaturePhase) db.FeaturePhase); ok {
-		r0 = rf(phase)
-	} else {
-		r0 = ret.Get(0).(db.FeaturePhase)
+		panic("no return value specified for GetTotalEarnedByPubkey")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.FeaturePhase) error); ok {
-		r1 = rf(phase)
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(string) uint); ok {
+		r0 = rf(pubkey)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(uint)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditFeaturePhase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeaturePhase'
-type Database_CreateOrEditFeaturePhase_Call struct {
+// Database_GetTotalEarnedByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTotalEarnedByPubkey'
+type Database_GetTotalEarnedByPubkey_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditFeaturePhase is a helper method to define mock.On call
-//   - phase db.FeaturePhase
-func (_e *Database_Expecter) CreateOrEditFeaturePhase(phase interface{}) *Database_CreateOrEditFeaturePhase_Call {
-	return &Database_CreateOrEditFeaturePhase_Call{Call: _e.mock.On("CreateOrEditFeaturePhase", phase)}
+// GetTotalEarnedByPubkey is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetTotalEarnedByPubkey(pubkey interface{}) *Database_GetTotalEarnedByPubkey_Call {
+	return &Database_GetTotalEarnedByPubkey_Call{Call: _e.mock.On("GetTotalEarnedByPubkey", pubkey)}
 }
 
-func (_c *Database_CreateOrEditFeaturePhase_Call) Run(run func(phase db.FeaturePhase)) *Database_CreateOrEditFeaturePhase_Call {
+func (_c *Database_GetTotalEarnedByPubkey_Call) Run(run func(pubkey string)) *Database_GetTotalEarnedByPubkey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.FeaturePhase))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeaturePhase_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_CreateOrEditFeaturePhase_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetTotalEarnedByPubkey_Call) Return(_a0 uint) *Database_GetTotalEarnedByPubkey_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeaturePhase_Call) RunAndReturn(run func(db.FeaturePhase) (db.FeaturePhase, error)) *Database_CreateOrEditFeaturePhase_Call {
+func (_c *Database_GetTotalEarnedByPubkey_Call) RunAndReturn(run func(string) uint) *Database_GetTotalEarnedByPubkey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditFeatureStory provides a mock function with given fields: story
-func (_m *Database) CreateOrEditFeatureStory(story db.FeatureStory) (db.FeatureStory, error) {
-	ret := _m.Called(story)
+// GetTribe provides a mock function with given fields: uuid
+func (_m *Database) GetTribe(uuid string) db.Tribe {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditFeatureStory")
-	}
-
-	var r0 db.FeatureStory
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.FeatureStory) (db.FeatureStory, error)); ok {
-		return rf(story)
-	}
-	if rf, ok := ret.Get(0).(func(db.FeatureStory) db.FeatureStory); ok {
-		r0 = rf(story)
-	} else {
-		r0 = ret.Get(0).(db.FeatureStory)
+		panic("no return value specified for GetTribe")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.FeatureStory) error); ok {
-		r1 = rf(story)
+	var r0 db.Tribe
+	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
+		r0 = rf(uuid)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(db.Tribe)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditFeatureStory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditFeatureStory'
-type Database_CreateOrEditFeatureStory_Call struct {
+// Database_GetTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribe'
+type Database_GetTribe_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditFeatureStory is a helper method to define mock.On call
-//   - story db.FeatureStory
-func (_e *Database_Expecter) CreateOrEditFeatureStory(story interface{}) *Database_CreateOrEditFeatureStory_Call {
-	return &Database_CreateOrEditFeatureStory_Call{Call: _e.mock.On("CreateOrEditFeatureStory", story)}
+// GetTribe is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetTribe(uuid interface{}) *Database_GetTribe_Call {
+	return &Database_GetTribe_Call{Call: _e.mock.On("GetTribe", uuid)}
 }
 
-func (_c *Database_CreateOrEditFeatureStory_Call) Run(run func(story db.FeatureStory)) *Database_CreateOrEditFeatureStory_Call {
+func (_c *Database_GetTribe_Call) Run(run func(uuid string)) *Database_GetTribe_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.FeatureStory))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeatureStory_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_CreateOrEditFeatureStory_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetTribe_Call) Return(_a0 db.Tribe) *Database_GetTribe_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditFeatureStory_Call) RunAndReturn(run func(db.FeatureStory) (db.FeatureStory, error)) *Database_CreateOrEditFeatureStory_Call {
+func (_c *Database_GetTribe_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetTribe_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditPerson provides a mock function with given fields: m
-func (_m *Database) CreateOrEditPerson(m db.Person) (db.Person, error) {
-	ret := _m.Called(m)
+// GetTribeBadgeCounts provides a mock function with given fields: tribeUuid
+func (_m *Database) GetTribeBadgeCounts(tribeUuid string) []db.TribeBadge {
+	ret := _m.Called(tribeUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditPerson")
-	}
-
-	var r0 db.Person
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Person) (db.Person, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.Person) db.Person); ok {
-		r0 = rf(m)
-	} else {
-		r0 = ret.Get(0).(db.Person)
+		panic("no return value specified for GetTribeBadgeCounts")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Person) error); ok {
-		r1 = rf(m)
+	var r0 []db.TribeBadge
+	if rf, ok := ret.Get(0).(func(string) []db.TribeBadge); ok {
+		r0 = rf(tribeUuid)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.TribeBadge)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditPerson'
-type Database_CreateOrEditPerson_Call struct {
+// Database_GetTribeBadgeCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeBadgeCounts'
+type Database_GetTribeBadgeCounts_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditPerson is a helper method to define mock.On call
-//   - m db.Person
-func (_e *Database_Expecter) CreateOrEditPerson(m interface{}) *Database_CreateOrEditPerson_Call {
-	return &Database_CreateOrEditPerson_Call{Call: _e.mock.On("CreateOrEditPerson", m)}
+// GetTribeBadgeCounts is a helper method to define mock.On call
+//   - tribeUuid string
+func (_e *Database_Expecter) GetTribeBadgeCounts(tribeUuid interface{}) *Database_GetTribeBadgeCounts_Call {
+	return &Database_GetTribeBadgeCounts_Call{Call: _e.mock.On("GetTribeBadgeCounts", tribeUuid)}
 }
 
-func (_c *Database_CreateOrEditPerson_Call) Run(run func(m db.Person)) *Database_CreateOrEditPerson_Call {
+func (_c *Database_GetTribeBadgeCounts_Call) Run(run func(tribeUuid string)) *Database_GetTribeBadgeCounts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Person))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditPerson_Call) Return(_a0 db.Person, _a1 error) *Database_CreateOrEditPerson_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetTribeBadgeCounts_Call) Return(_a0 []db.TribeBadge) *Database_GetTribeBadgeCounts_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditPerson_Call) RunAndReturn(run func(db.Person) (db.Person, error)) *Database_CreateOrEditPerson_Call {
+func (_c *Database_GetTribeBadgeCounts_Call) RunAndReturn(run func(string) []db.TribeBadge) *Database_GetTribeBadgeCounts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditTribe provides a mock function with given fields: m
-func (_m *Database) CreateOrEditTribe(m db.Tribe) (db.Tribe, error) {
-	ret := _m.Called(m)
+// GetTribeByIdAndPubkey provides a mock function with given fields: uuid, pubkey
+func (_m *Database) GetTribeByIdAndPubkey(uuid string, pubkey string) db.Tribe {
+	ret := _m.Called(uuid, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditTribe")
+		panic("no return value specified for GetTribeByIdAndPubkey")
 	}
 
 	var r0 db.Tribe
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Tribe) (db.Tribe, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.Tribe) db.Tribe); ok {
-		r0 = rf(m)
+	if rf, ok := ret.Get(0).(func(string, string) db.Tribe); ok {
+		r0 = rf(uuid, pubkey)
 	} else {
 		r0 = ret.Get(0).(db.Tribe)
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Tribe) error); ok {
-		r1 = rf(m)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditTribe'
-type Database_CreateOrEditTribe_Call struct {
+// Database_GetTribeByIdAndPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeByIdAndPubkey'
+type Database_GetTribeByIdAndPubkey_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditTribe is a helper method to define mock.On call
-//   - m db.Tribe
-func (_e *Database_Expecter) CreateOrEditTribe(m interface{}) *Database_CreateOrEditTribe_Call {
-	return &Database_CreateOrEditTribe_Call{Call: _e.mock.On("CreateOrEditTribe", m)}
+// GetTribeByIdAndPubkey is a helper method to define mock.On call
+//   - uuid string
+//   - pubkey string
+func (_e *Database_Expecter) GetTribeByIdAndPubkey(uuid interface{}, pubkey interface{}) *Database_GetTribeByIdAndPubkey_Call {
+	return &Database_GetTribeByIdAndPubkey_Call{Call: _e.mock.On("GetTribeByIdAndPubkey", uuid, pubkey)}
 }
 
-func (_c *Database_CreateOrEditTribe_Call) Run(run func(m db.Tribe)) *Database_CreateOrEditTribe_Call {
+func (_c *Database_GetTribeByIdAndPubkey_Call) Run(run func(uuid string, pubkey string)) *Database_GetTribeByIdAndPubkey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Tribe))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditTribe_Call) Return(_a0 db.Tribe, _a1 error) *Database_CreateOrEditTribe_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetTribeByIdAndPubkey_Call) Return(_a0 db.Tribe) *Database_GetTribeByIdAndPubkey_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditTribe_Call) RunAndReturn(run func(db.Tribe) (db.Tribe, error)) *Database_CreateOrEditTribe_Call {
+func (_c *Database_GetTribeByIdAndPubkey_Call) RunAndReturn(run func(string, string) db.Tribe) *Database_GetTribeByIdAndPubkey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditWorkspace provides a mock function with given fields: m
-func (_m *Database) CreateOrEditWorkspace(m db.Workspace) (db.Workspace, error) {
-	ret := _m.Called(m)
+// GetTribeByUniqueName provides a mock function with given fields: un
+func (_m *Database) GetTribeByUniqueName(un string) db.Tribe {
+	ret := _m.Called(un)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditWorkspace")
-	}
-
-	var r0 db.Workspace
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.Workspace) (db.Workspace, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.Workspace) db.Workspace); ok {
-		r0 = rf(m)
-	} else {
-		r0 = ret.Get(0).(db.Workspace)
+		panic("no return value specified for GetTribeByUniqueName")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.Workspace) error); ok {
-		r1 = rf(m)
+	var r0 db.Tribe
+	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
+		r0 = rf(un)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(db.Tribe)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspace'
-type Database_CreateOrEditWorkspace_Call struct {
+// Database_GetTribeByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeByUniqueName'
+type Database_GetTribeByUniqueName_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditWorkspace is a helper method to define mock.On call
-//   - m db.Workspace
-func (_e *Database_Expecter) CreateOrEditWorkspace(m interface{}) *Database_CreateOrEditWorkspace_Call {
-	return &Database_CreateOrEditWorkspace_Call{Call: _e.mock.On("CreateOrEditWorkspace", m)}
+// GetTribeByUniqueName is a helper method to define mock.On call
+//   - un string
+func (_e *Database_Expecter) GetTribeByUniqueName(un interface{}) *Database_GetTribeByUniqueName_Call {
+	return &Database_GetTribeByUniqueName_Call{Call: _e.mock.On("GetTribeByUniqueName", un)}
 }
 
-func (_c *Database_CreateOrEditWorkspace_Call) Run(run func(m db.Workspace)) *Database_CreateOrEditWorkspace_Call {
+func (_c *Database_GetTribeByUniqueName_Call) Run(run func(un string)) *Database_GetTribeByUniqueName_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Workspace))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditWorkspace_Call) Return(_a0 db.Workspace, _a1 error) *Database_CreateOrEditWorkspace_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetTribeByUniqueName_Call) Return(_a0 db.Tribe) *Database_GetTribeByUniqueName_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditWorkspace_Call) RunAndReturn(run func(db.Workspace) (db.Workspace, error)) *Database_CreateOrEditWorkspace_Call {
+func (_c *Database_GetTribeByUniqueName_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetTribeByUniqueName_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrEditWorkspaceRepository provides a mock function with given fields: m
-func (_m *Database) CreateOrEditWorkspaceRepository(m db.WorkspaceRepositories) (db.WorkspaceRepositories, error) {
-	ret := _m.Called(m)
+// GetTribesByAppUrl provides a mock function with given fields: aurl
+func (_m *Database) GetTribesByAppUrl(aurl string) []db.Tribe {
+	ret := _m.Called(aurl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrEditWorkspaceRepository")
-	}
-
-	var r0 db.WorkspaceRepositories
-	var r1 error
-	if rf, ok := ret.Get(0).(func(db.WorkspaceRepositories) (db.WorkspaceRepositories, error)); ok {
-		return rf(m)
-	}
-	if rf, ok := ret.Get(0).(func(db.WorkspaceRepositories) db.WorkspaceRepositories); ok {
-		r0 = rf(m)
-	} else {
-		r0 = ret.Get(0).(db.WorkspaceRepositories)
+		panic("no return value specified for GetTribesByAppUrl")
 	}
 
-	if rf, ok := ret.Get(1).(func(db.WorkspaceRepositories) error); ok {
-		r1 = rf(m)
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+		r0 = rf(aurl)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateOrEditWorkspaceRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrEditWorkspaceRepository'
-type Database_CreateOrEditWorkspaceRepository_Call struct {
+// Database_GetTribesByAppUrl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByAppUrl'
+type Database_GetTribesByAppUrl_Call struct {
 	*mock.Call
 }
 
-// CreateOrEditWorkspaceRepository is a helper method to define mock.On call
-//   - m db.WorkspaceRepositories
-func (_e *Database_Expecter) CreateOrEditWorkspaceRepository(m interface{}) *Database_CreateOrEditWorkspaceRepository_Call {
-	return &Database_CreateOrEditWorkspaceRepository_Call{Call: _e.mock.On("CreateOrEditWorkspaceRepository", m)}
+// GetTribesByAppUrl is a helper method to define mock.On call
+//   - aurl string
+func (_e *Database_Expecter) GetTribesByAppUrl(aurl interface{}) *Database_GetTribesByAppUrl_Call {
+	return &Database_GetTribesByAppUrl_Call{Call: _e.mock.On("GetTribesByAppUrl", aurl)}
 }
 
-func (_c *Database_CreateOrEditWorkspaceRepository_Call) Run(run func(m db.WorkspaceRepositories)) *Database_CreateOrEditWorkspaceRepository_Call {
+func (_c *Database_GetTribesByAppUrl_Call) Run(run func(aurl string)) *Database_GetTribesByAppUrl_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.WorkspaceRepositories))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateOrEditWorkspaceRepository_Call) Return(_a0 db.WorkspaceRepositories, _a1 error) *Database_CreateOrEditWorkspaceRepository_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetTribesByAppUrl_Call) Return(_a0 []db.Tribe) *Database_GetTribesByAppUrl_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateOrEditWorkspaceRepository_Call) RunAndReturn(run func(db.WorkspaceRepositories) (db.WorkspaceRepositories, error)) *Database_CreateOrEditWorkspaceRepository_Call {
+func (_c *Database_GetTribesByAppUrl_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetTribesByAppUrl_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateUserRoles provides a mock function with given fields: roles, uuid, pubkey
-func (_m *Database) CreateUserRoles(roles []db.WorkspaceUserRoles, uuid string, pubkey string) []db.WorkspaceUserRoles {
-	ret := _m.Called(roles, uuid, pubkey)
+// GetTribesByOwner provides a mock function with given fields: pubkey
+func (_m *Database) GetTribesByOwner(pubkey string) []db.Tribe {
+	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUserRoles")
+		panic("no return value specified for GetTribesByOwner")
 	}
 
-	var r0 []db.WorkspaceUserRoles
-	if rf, ok := ret.Get(0).(func([]db.WorkspaceUserRoles, string, string) []db.WorkspaceUserRoles); ok {
-		r0 = rf(roles, uuid, pubkey)
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+		r0 = rf(pubkey)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceUserRoles)
+			r0 = ret.Get(0).([]db.Tribe)
 		}
 	}
 
 	return r0
 }
 
-// Database_CreateUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserRoles'
-type Database_CreateUserRoles_Call struct {
+// Database_GetTribesByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByOwner'
+type Database_GetTribesByOwner_Call struct {
 	*mock.Call
 }
 
-// CreateUserRoles is a helper method to define mock.On call
-//   - roles []db.WorkspaceUserRoles
-//   - uuid string
+// GetTribesByOwner is a helper method to define mock.On call
 //   - pubkey string
-func (_e *Database_Expecter) CreateUserRoles(roles interface{}, uuid interface{}, pubkey interface{}) *Database_CreateUserRoles_Call {
-	return &Database_CreateUserRoles_Call{Call: _e.mock.On("CreateUserRoles", roles, uuid, pubkey)}
+func (_e *Database_Expecter) GetTribesByOwner(pubkey interface{}) *Database_GetTribesByOwner_Call {
+	return &Database_GetTribesByOwner_Call{Call: _e.mock.On("GetTribesByOwner", pubkey)}
 }
 
-func (_c *Database_CreateUserRoles_Call) Run(run func(roles []db.WorkspaceUserRoles, uuid string, pubkey string)) *Database_CreateUserRoles_Call {
+func (_c *Database_GetTribesByOwner_Call) Run(run func(pubkey string)) *Database_GetTribesByOwner_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]db.WorkspaceUserRoles), args[1].(string), args[2].(string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_CreateUserRoles_Call) Return(_a0 []db.WorkspaceUserRoles) *Database_CreateUserRoles_Call {
+func (_c *Database_GetTribesByOwner_Call) Return(_a0 []db.Tribe) *Database_GetTribesByOwner_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateUserRoles_Call) RunAndReturn(run func([]db.WorkspaceUserRoles, string, string) []db.WorkspaceUserRoles) *Database_CreateUserRoles_Call {
+func (_c *Database_GetTribesByOwner_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetTribesByOwner_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateWorkspaceBudget provides a mock function with given fields: budget
-func (_m *Database) CreateWorkspaceBudget(budget db.NewBountyBudget) db.NewBountyBudget {
-	ret := _m.Called(budget)
+// GetTribesTotal provides a mock function with given fields:
+func (_m *Database) GetTribesTotal() int64 {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateWorkspaceBudget")
+		panic("no return value specified for GetTribesTotal")
 	}
 
-	var r0 db.NewBountyBudget
-	if rf, ok := ret.Get(0).(func(db.NewBountyBudget) db.NewBountyBudget); ok {
-		r0 = rf(budget)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(db.NewBountyBudget)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_CreateWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceBudget'
-type Database_CreateWorkspaceBudget_Call struct {
+// Database_GetTribesTotal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesTotal'
+type Database_GetTribesTotal_Call struct {
 	*mock.Call
 }
 
-// CreateWorkspaceBudget is a helper method to define mock.On call
-//   - budget db.NewBountyBudget
-func (_e *Database_Expecter) CreateWorkspaceBudget(budget interface{}) *Database_CreateWorkspaceBudget_Call {
-	return &Database_CreateWorkspaceBudget_Call{Call: _e.mock.On("CreateWorkspaceBudget", budget)}
+// GetTribesTotal is a helper method to define mock.On call
+func (_e *Database_Expecter) GetTribesTotal() *Database_GetTribesTotal_Call {
+	return &Database_GetTribesTotal_Call{Call: _e.mock.On("GetTribesTotal")}
 }
 
-func (_c *Database_CreateWorkspaceBudget_Call) Run(run func(budget db.NewBountyBudget)) *Database_CreateWorkspaceBudget_Call {
+func (_c *Database_GetTribesTotal_Call) Run(run func()) *Database_GetTribesTotal_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewBountyBudget))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_CreateWorkspaceBudget_Call) Return(_a0 db.NewBountyBudget) *Database_CreateWorkspaceBudget_Call {
+func (_c *Database_GetTribesTotal_Call) Return(_a0 int64) *Database_GetTribesTotal_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateWorkspaceBudget_Call) RunAndReturn(run func(db.NewBountyBudget) db.NewBountyBudget) *Database_CreateWorkspaceBudget_Call {
+func (_c *Database_GetTribesTotal_Call) RunAndReturn(run func() int64) *Database_GetTribesTotal_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateWorkspaceUser provides a mock function with given fields: orgUser
-func (_m *Database) CreateWorkspaceUser(orgUser db.WorkspaceUsers) db.WorkspaceUsers {
-	ret := _m.Called(orgUser)
+// GetUnconfirmedGithub provides a mock function with given fields:
+func (_m *Database) GetUnconfirmedGithub() []db.Person {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateWorkspaceUser")
+		panic("no return value specified for GetUnconfirmedGithub")
 	}
 
-	var r0 db.WorkspaceUsers
-	if rf, ok := ret.Get(0).(func(db.WorkspaceUsers) db.WorkspaceUsers); ok {
-		r0 = rf(orgUser)
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func() []db.Person); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceUsers)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
 	}
 
 	return r0
 }
 
-// Database_CreateWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspaceUser'
-type Database_CreateWorkspaceUser_Call struct {
+// Database_GetUnconfirmedGithub_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedGithub'
+type Database_GetUnconfirmedGithub_Call struct {
 	*mock.Call
 }
 
-// CreateWorkspaceUser is a helper method to define mock.On call
-//   - orgUser db.WorkspaceUsers
-func (_e *Database_Expecter) CreateWorkspaceUser(orgUser interface{}) *Database_CreateWorkspaceUser_Call {
-	return &Database_CreateWorkspaceUser_Call{Call: _e.mock.On("CreateWorkspaceUser", orgUser)}
+// GetUnconfirmedGithub is a helper method to define mock.On call
+func (_e *Database_Expecter) GetUnconfirmedGithub() *Database_GetUnconfirmedGithub_Call {
+	return &Database_GetUnconfirmedGithub_Call{Call: _e.mock.On("GetUnconfirmedGithub")}
 }
 
-func (_c *Database_CreateWorkspaceUser_Call) Run(run func(orgUser db.WorkspaceUsers)) *Database_CreateWorkspaceUser_Call {
+func (_c *Database_GetUnconfirmedGithub_Call) Run(run func()) *Database_GetUnconfirmedGithub_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.WorkspaceUsers))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_CreateWorkspaceUser_Call) Return(_a0 db.WorkspaceUsers) *Database_CreateWorkspaceUser_Call {
+func (_c *Database_GetUnconfirmedGithub_Call) Return(_a0 []db.Person) *Database_GetUnconfirmedGithub_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateWorkspaceUser_Call) RunAndReturn(run func(db.WorkspaceUsers) db.WorkspaceUsers) *Database_CreateWorkspaceUser_Call {
+func (_c *Database_GetUnconfirmedGithub_Call) RunAndReturn(run func() []db.Person) *Database_GetUnconfirmedGithub_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteAllUsersFromWorkspace provides a mock function with given fields: uuid
-func (_m *Database) DeleteAllUsersFromWorkspace(uuid string) error {
-	ret := _m.Called(uuid)
+// GetUnconfirmedOnchainPayments provides a mock function with given fields:
+func (_m *Database) GetUnconfirmedOnchainPayments() []db.NewPaymentHistory {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAllUsersFromWorkspace")
+		panic("no return value specified for GetUnconfirmedOnchainPayments")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(uuid)
+	var r0 []db.NewPaymentHistory
+	if rf, ok := ret.Get(0).(func() []db.NewPaymentHistory); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewPaymentHistory)
+		}
 	}
 
 	return r0
 }
 
-// Database_DeleteAllUsersFromWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAllUsersFromWorkspace'
-type Database_DeleteAllUsersFromWorkspace_Call struct {
+// Database_GetUnconfirmedOnchainPayments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedOnchainPayments'
+type Database_GetUnconfirmedOnchainPayments_Call struct {
 	*mock.Call
 }
 
-// DeleteAllUsersFromWorkspace is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) DeleteAllUsersFromWorkspace(uuid interface{}) *Database_DeleteAllUsersFromWorkspace_Call {
-	return &Database_DeleteAllUsersFromWorkspace_Call{Call: _e.mock.On("DeleteAllUsersFromWorkspace", uuid)}
+// GetUnconfirmedOnchainPayments is a helper method to define mock.On call
+func (_e *Database_Expecter) GetUnconfirmedOnchainPayments() *Database_GetUnconfirmedOnchainPayments_Call {
+	return &Database_GetUnconfirmedOnchainPayments_Call{Call: _e.mock.On("GetUnconfirmedOnchainPayments")}
 }
 
-func (_c *Database_DeleteAllUsersFromWorkspace_Call) Run(run func(uuid string)) *Database_DeleteAllUsersFromWorkspace_Call {
+func (_c *Database_GetUnconfirmedOnchainPayments_Call) Run(run func()) *Database_GetUnconfirmedOnchainPayments_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_DeleteAllUsersFromWorkspace_Call) Return(_a0 error) *Database_DeleteAllUsersFromWorkspace_Call {
+func (_c *Database_GetUnconfirmedOnchainPayments_Call) Return(_a0 []db.NewPaymentHistory) *Database_GetUnconfirmedOnchainPayments_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteAllUsersFromWorkspace_Call) RunAndReturn(run func(string) error) *Database_DeleteAllUsersFromWorkspace_Call {
+func (_c *Database_GetUnconfirmedOnchainPayments_Call) RunAndReturn(run func() []db.NewPaymentHistory) *Database_GetUnconfirmedOnchainPayments_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteBounty provides a mock function with given fields: pubkey, created
-func (_m *Database) DeleteBounty(pubkey string, created string) (db.NewBounty, error) {
-	ret := _m.Called(pubkey, created)
-
-	if len(ret) == 0 {
-		panic("no return value specified for DeleteBounty")
-	}
-
-	var r0 db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (db.NewBounty, error)); ok {
-		return rf(pubkey, created)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) db.NewBounty); ok {
-		r0 = rf(pubkey, created)
-	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+// GetUnconfirmedTwitter provides a mock function with given fields:
+func (_m *Database) GetUnconfirmedTwitter() []db.Person {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnconfirmedTwitter")
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(pubkey, created)
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func() []db.Person); ok {
+		r0 = rf()
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Person)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_DeleteBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBounty'
-type Database_DeleteBounty_Call struct {
+// Database_GetUnconfirmedTwitter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedTwitter'
+type Database_GetUnconfirmedTwitter_Call struct {
 	*mock.Call
 }
 
-// DeleteBounty is a helper method to define mock.On call
-//   - pubkey string
-//   - created string
-func (_e *Database_Expecter) DeleteBounty(pubkey interface{}, created interface{}) *Database_DeleteBounty_Call {
-	return &Database_DeleteBounty_Call{Call: _e.mock.On("DeleteBounty", pubkey, created)}
+// GetUnconfirmedTwitter is a helper method to define mock.On call
+func (_e *Database_Expecter) GetUnconfirmedTwitter() *Database_GetUnconfirmedTwitter_Call {
+	return &Database_GetUnconfirmedTwitter_Call{Call: _e.mock.On("GetUnconfirmedTwitter")}
 }
 
-func (_c *Database_DeleteBounty_Call) Run(run func(pubkey string, created string)) *Database_DeleteBounty_Call {
+func (_c *Database_GetUnconfirmedTwitter_Call) Run(run func()) *Database_GetUnconfirmedTwitter_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_DeleteBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_DeleteBounty_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetUnconfirmedTwitter_Call) Return(_a0 []db.Person) *Database_GetUnconfirmedTwitter_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteBounty_Call) RunAndReturn(run func(string, string) (db.NewBounty, error)) *Database_DeleteBounty_Call {
+func (_c *Database_GetUnconfirmedTwitter_Call) RunAndReturn(run func() []db.Person) *Database_GetUnconfirmedTwitter_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteFeatureByUuid provides a mock function with given fields: uuid
-func (_m *Database) DeleteFeatureByUuid(uuid string) error {
-	ret := _m.Called(uuid)
+// GetUnmarkedBountyPayments provides a mock function with given fields:
+func (_m *Database) GetUnmarkedBountyPayments() []db.NewPaymentHistory {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFeatureByUuid")
+		panic("no return value specified for GetUnmarkedBountyPayments")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(uuid)
+	var r0 []db.NewPaymentHistory
+	if rf, ok := ret.Get(0).(func() []db.NewPaymentHistory); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewPaymentHistory)
+		}
 	}
 
 	return r0
 }
 
-// Database_DeleteFeatureByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureByUuid'
-type Database_DeleteFeatureByUuid_Call struct {
+// Database_GetUnmarkedBountyPayments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnmarkedBountyPayments'
+type Database_GetUnmarkedBountyPayments_Call struct {
 	*mock.Call
 }
 
-// DeleteFeatureByUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) DeleteFeatureByUuid(uuid interface{}) *Database_DeleteFeatureByUuid_Call {
-	return &Database_DeleteFeatureByUuid_Call{Call: _e.mock.On("DeleteFeatureByUuid", uuid)}
+// GetUnmarkedBountyPayments is a helper method to define mock.On call
+func (_e *Database_Expecter) GetUnmarkedBountyPayments() *Database_GetUnmarkedBountyPayments_Call {
+	return &Database_GetUnmarkedBountyPayments_Call{Call: _e.mock.On("GetUnmarkedBountyPayments")}
 }
 
-func (_c *Database_DeleteFeatureByUuid_Call) Run(run func(uuid string)) *Database_DeleteFeatureByUuid_Call {
+func (_c *Database_GetUnmarkedBountyPayments_Call) Run(run func()) *Database_GetUnmarkedBountyPayments_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_DeleteFeatureByUuid_Call) Return(_a0 error) *Database_DeleteFeatureByUuid_Call {
+func (_c *Database_GetUnmarkedBountyPayments_Call) Return(_a0 []db.NewPaymentHistory) *Database_GetUnmarkedBountyPayments_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteFeatureByUuid_Call) RunAndReturn(run func(string) error) *Database_DeleteFeatureByUuid_Call {
+func (_c *Database_GetUnmarkedBountyPayments_Call) RunAndReturn(run func() []db.NewPaymentHistory) *Database_GetUnmarkedBountyPayments_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteFeaturePhase provides a mock function with given fields: featureUuid, phaseUuid
-func (_m *Database) DeleteFeaturePhase(featureUuid string, phaseUuid string) error {
-	ret := _m.Called(featureUuid, phaseUuid)
+// GetUnsettledBountyPayments provides a mock function with given fields:
+func (_m *Database) GetUnsettledBountyPayments() []db.NewPaymentHistory {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFeaturePhase")
+		panic("no return value specified for GetUnsettledBountyPayments")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(featureUuid, phaseUuid)
+	var r0 []db.NewPaymentHistory
+	if rf, ok := ret.Get(0).(func() []db.NewPaymentHistory); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewPaymentHistory)
+		}
 	}
 
 	return r0
 }
 
-// Database_DeleteFeaturePhase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeaturePhase'
-type Database_DeleteFeaturePhase_Call struct {
+// Database_GetUnsettledBountyPayments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnsettledBountyPayments'
+type Database_GetUnsettledBountyPayments_Call struct {
 	*mock.Call
 }
 
-// DeleteFeaturePhase is a helper method to define mock.On call
-//   - featureUuid string
-//   - phaseUuid string
-func (_e *Database_Expecter) DeleteFeaturePhase(featureUuid interface{}, phaseUuid interface{}) *Database_DeleteFeaturePhase_Call {
-	return &Database_DeleteFeaturePhase_Call{Call: _e.mock.On("DeleteFeaturePhase", featureUuid, phaseUuid)}
+// GetUnsettledBountyPayments is a helper method to define mock.On call
+func (_e *Database_Expecter) GetUnsettledBountyPayments() *Database_GetUnsettledBountyPayments_Call {
+	return &Database_GetUnsettledBountyPayments_Call{Call: _e.mock.On("GetUnsettledBountyPayments")}
 }
 
-func (_c *Database_DeleteFeaturePhase_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_DeleteFeaturePhase_Call {
+func (_c *Database_GetUnsettledBountyPayments_Call) Run(run func()) *Database_GetUnsettledBountyPayments_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_DeleteFeaturePhase_Call) Return(_a0 error) *Database_DeleteFeaturePhase_Call {
+func (_c *Database_GetUnsettledBountyPayments_Call) Return(_a0 []db.NewPaymentHistory) *Database_GetUnsettledBountyPayments_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteFeaturePhase_Call) RunAndReturn(run func(string, string) error) *Database_DeleteFeaturePhase_Call {
+func (_c *Database_GetUnsettledBountyPayments_Call) RunAndReturn(run func() []db.NewPaymentHistory) *Database_GetUnsettledBountyPayments_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteFeatureStoryByUuid provides a mock function with given fields: featureUuid, storyUuid
-func (_m *Database) DeleteFeatureStoryByUuid(featureUuid string, storyUuid string) error {
-	ret := _m.Called(featureUuid, storyUuid)
+// GetUserAssignedWorkspaces provides a mock function with given fields: pubkey
+func (_m *Database) GetUserAssignedWorkspaces(pubkey string) []db.WorkspaceUsers {
+	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFeatureStoryByUuid")
+		panic("no return value specified for GetUserAssignedWorkspaces")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(featureUuid, storyUuid)
+	var r0 []db.WorkspaceUsers
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceUsers); ok {
+		r0 = rf(pubkey)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceUsers)
+		}
 	}
 
 	return r0
 }
 
-// Database_DeleteFeatureStoryByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeatureStoryByUuid'
-type Database_DeleteFeatureStoryByUuid_Call struct {
+// Database_GetUserAssignedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAssignedWorkspaces'
+type Database_GetUserAssignedWorkspaces_Call struct {
 	*mock.Call
 }
 
-// DeleteFeatureStoryByUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - storyUuid string
-func (_e *Database_Expecter) DeleteFeatureStoryByUuid(featureUuid interface{}, storyUuid interface{}) *Database_DeleteFeatureStoryByUuid_Call {
-	return &Database_DeleteFeatureStoryByUuid_Call{Call: _e.mock.On("DeleteFeatureStoryByUuid", featureUuid, storyUuid)}
+// GetUserAssignedWorkspaces is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetUserAssignedWorkspaces(pubkey interface{}) *Database_GetUserAssignedWorkspaces_Call {
+	return &Database_GetUserAssignedWorkspaces_Call{Call: _e.mock.On("GetUserAssignedWorkspaces", pubkey)}
 }
 
-func (_c *Database_DeleteFeatureStoryByUuid_Call) Run(run func(featureUuid string, storyUuid string)) *Database_DeleteFeatureStoryByUuid_Call {
+func (_c *Database_GetUserAssignedWorkspaces_Call) Run(run func(pubkey string)) *Database_GetUserAssignedWorkspaces_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteFeatureStoryByUuid_Call) Return(_a0 error) *Database_DeleteFeatureStoryByUuid_Call {
+func (_c *Database_GetUserAssignedWorkspaces_Call) Return(_a0 []db.WorkspaceUsers) *Database_GetUserAssignedWorkspaces_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteFeatureStoryByUuid_Call) RunAndReturn(run func(string, string) error) *Database_DeleteFeatureStoryByUuid_Call {
+func (_c *Database_GetUserAssignedWorkspaces_Call) RunAndReturn(run func(string) []db.WorkspaceUsers) *Database_GetUserAssignedWorkspaces_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteInvoice provides a mock function with given fields: payment_request
-func (_m *Database) DeleteInvoice(payment_request string) db.NewInvoiceList {
-	ret := _m.Called(payment_request)
+// GetUserBountiesCount provides a mock function with given fields: personKey, tabType
+func (_m *Database) GetUserBountiesCount(personKey string, tabType string) int64 {
+	ret := _m.Called(personKey, tabType)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteInvoice")
+		panic("no return value specified for GetUserBountiesCount")
 	}
 
-	var r0 db.NewInvoiceList
-	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
-		r0 = rf(payment_request)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
+		r0 = rf(personKey, tabType)
 	} else {
-		r0 = ret.Get(0).(db.NewInvoiceList)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_DeleteInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInvoice'
-type Database_DeleteInvoice_Call struct {
+// Database_GetUserBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserBountiesCount'
+type Database_GetUserBountiesCount_Call struct {
 	*mock.Call
 }
 
-// DeleteInvoice is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) DeleteInvoice(payment_request interface{}) *Database_DeleteInvoice_Call {
-	return &Database_DeleteInvoice_Call{Call: _e.mock.On("DeleteInvoice", payment_request)}
+// GetUserBountiesCount is a helper method to define mock.On call
+//   - personKey string
+//   - tabType string
+func (_e *Database_Expecter) GetUserBountiesCount(personKey interface{}, tabType interface{}) *Database_GetUserBountiesCount_Call {
+	return &Database_GetUserBountiesCount_Call{Call: _e.mock.On("GetUserBountiesCount", personKey, tabType)}
 }
 
-func (_c *Database_DeleteInvoice_Call) Run(run func(payment_request string)) *Database_DeleteInvoice_Call {
+func (_c *Database_GetUserBountiesCount_Call) Run(run func(personKey string, tabType string)) *Database_GetUserBountiesCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_DeleteInvoice_Call {
+func (_c *Database_GetUserBountiesCount_Call) Return(_a0 int64) *Database_GetUserBountiesCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_DeleteInvoice_Call {
+func (_c *Database_GetUserBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetUserBountiesCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteUserInvoiceData provides a mock function with given fields: payment_request
-func (_m *Database) DeleteUserInvoiceData(payment_request string) db.UserInvoiceData {
-	ret := _m.Called(payment_request)
+// GetUserCreatedWorkspaces provides a mock function with given fields: pubkey
+func (_m *Database) GetUserCreatedWorkspaces(pubkey string) []db.Workspace {
+	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUserInvoiceData")
+		panic("no return value specified for GetUserCreatedWorkspaces")
 	}
 
-	var r0 db.UserInvoiceData
-	if rf, ok := ret.Get(0).(func(string) db.UserInvoiceData); ok {
-		r0 = rf(payment_request)
+	var r0 []db.Workspace
+	if rf, ok := ret.Get(0).(func(string) []db.Workspace); ok {
+		r0 = rf(pubkey)
 	} else {
-		r0 = ret.Get(0).(db.UserInvoiceData)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Workspace)
+		}
 	}
 
 	return r0
 }
 
-// Database_DeleteUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserInvoiceData'
-type Database_DeleteUserInvoiceData_Call struct {
+// Database_GetUserCreatedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserCreatedWorkspaces'
+type Database_GetUserCreatedWorkspaces_Call struct {
 	*mock.Call
 }
 
-// DeleteUserInvoiceData is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) DeleteUserInvoiceData(payment_request interface{}) *Database_DeleteUserInvoiceData_Call {
-	return &Database_DeleteUserInvoiceData_Call{Call: _e.mock.On("DeleteUserInvoiceData", payment_request)}
+// GetUserCreatedWorkspaces is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) GetUserCreatedWorkspaces(pubkey interface{}) *Database_GetUserCreatedWorkspaces_Call {
+	return &Database_GetUserCreatedWorkspaces_Call{Call: _e.mock.On("GetUserCreatedWorkspaces", pubkey)}
 }
 
-func (_c *Database_DeleteUserInvoiceData_Call) Run(run func(payment_request string)) *Database_DeleteUserInvoiceData_Call {
+func (_c *Database_GetUserCreatedWorkspaces_Call) Run(run func(pubkey string)) *Database_GetUserCreatedWorkspaces_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_DeleteUserInvoiceData_Call {
+func (_c *Database_GetUserCreatedWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetUserCreatedWorkspaces_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteUserInvoiceData_Call) RunAndReturn(run func(string) db.UserInvoiceData) *Database_DeleteUserInvoiceData_Call {
+func (_c *Database_GetUserCreatedWorkspaces_Call) RunAndReturn(run func(string) []db.Workspace) *Database_GetUserCreatedWorkspaces_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteWorkspaceRepository provides a mock function with given fields: workspace_uuid, uuid
-func (_m *Database) DeleteWorkspaceRepository(workspace_uuid string, uuid string) bool {
-	ret := _m.Called(workspace_uuid, uuid)
+// GetUserInvoiceData provides a mock function with given fields: payment_request
+func (_m *Database) GetUserInvoiceData(payment_request string) db.UserInvoiceData {
+	ret := _m.Called(payment_request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteWorkspaceRepository")
+		panic("no return value specified for GetUserInvoiceData")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
-		r0 = rf(workspace_uuid, uuid)
+	var r0 db.UserInvoiceData
+	if rf, ok := ret.Get(0).(func(string) db.UserInvoiceData); ok {
+		r0 = rf(payment_request)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(db.UserInvoiceData)
 	}
 
 	return r0
 }
 
-// Database_DeleteWorkspaceRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceRepository'
-type Database_DeleteWorkspaceRepository_Call struct {
+// Database_GetUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserInvoiceData'
+type Database_GetUserInvoiceData_Call struct {
 	*mock.Call
 }
 
-// DeleteWorkspaceRepository is a helper method to define mock.On call
-//   - workspace_uuid string
-//   - uuid string
-func (_e *Database_Expecter) DeleteWorkspaceRepository(workspace_uuid interface{}, uuid interface{}) *Database_DeleteWorkspaceRepository_Call {
-	return &Database_DeleteWorkspaceRepository_Call{Call: _e.mock.On("DeleteWorkspaceRepository", workspace_uuid, uuid)}
+// GetUserInvoiceData is a helper method to define mock.On call
+//   - payment_request string
+func (_e *Database_Expecter) GetUserInvoiceData(payment_request interface{}) *Database_GetUserInvoiceData_Call {
+	return &Database_GetUserInvoiceData_Call{Call: _e.mock.On("GetUserInvoiceData", payment_request)}
 }
 
-func (_c *Database_DeleteWorkspaceRepository_Call) Run(run func(workspace_uuid string, uuid string)) *Database_DeleteWorkspaceRepository_Call {
+func (_c *Database_GetUserInvoiceData_Call) Run(run func(payment_request string)) *Database_GetUserInvoiceData_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteWorkspaceRepository_Call) Return(_a0 bool) *Database_DeleteWorkspaceRepository_Call {
+func (_c *Database_GetUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_GetUserInvoiceData_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteWorkspaceRepository_Call) RunAndReturn(run func(string, string) bool) *Database_DeleteWorkspaceRepository_Call {
+func (_c *Database_GetUserInvoiceData_Call) RunAndReturn(run func(string) db.UserInvoiceData) *Database_GetUserInvoiceData_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteWorkspaceUser provides a mock function with given fields: orgUser, org
-func (_m *Database) DeleteWorkspaceUser(orgUser db.WorkspaceUsersData, org string) db.WorkspaceUsersData {
-	ret := _m.Called(orgUser, org)
+// GetWebhookSigningSecret provides a mock function with given fields: source
+func (_m *Database) GetWebhookSigningSecret(source string) (string, error) {
+	ret := _m.Called(source)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteWorkspaceUser")
+		panic("no return value specified for GetWebhookSigningSecret")
 	}
 
-	var r0 db.WorkspaceUsersData
-	if rf, ok := ret.Get(0).(func(db.WorkspaceUsersData, string) db.WorkspaceUsersData); ok {
-		r0 = rf(orgUser, org)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(source)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(source)
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceUsersData)
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(source)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// Database_DeleteWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkspaceUser'
-type Database_DeleteWorkspaceUser_Call struct {
+// Database_GetWebhookSigningSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookSigningSecret'
+type Database_GetWebhookSigningSecret_Call struct {
 	*mock.Call
 }
 
-// DeleteWorkspaceUser is a helper method to define mock.On call
-//   - orgUser db.WorkspaceUsersData
-//   - org string
-func (_e *Database_Expecter) DeleteWorkspaceUser(orgUser interface{}, org interface{}) *Database_DeleteWorkspaceUser_Call {
-	return &Database_DeleteWorkspaceUser_Call{Call: _e.mock.On("DeleteWorkspaceUser", orgUser, org)}
+// GetWebhookSigningSecret is a helper method to define mock.On call
+//   - source string
+func (_e *Database_Expecter) GetWebhookSigningSecret(source interface{}) *Database_GetWebhookSigningSecret_Call {
+	return &Database_GetWebhookSigningSecret_Call{Call: _e.mock.On("GetWebhookSigningSecret", source)}
 }
 
-func (_c *Database_DeleteWorkspaceUser_Call) Run(run func(orgUser db.WorkspaceUsersData, org string)) *Database_DeleteWorkspaceUser_Call {
+func (_c *Database_GetWebhookSigningSecret_Call) Run(run func(source string)) *Database_GetWebhookSigningSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.WorkspaceUsersData), args[1].(string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteWorkspaceUser_Call) Return(_a0 db.WorkspaceUsersData) *Database_DeleteWorkspaceUser_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetWebhookSigningSecret_Call) Return(_a0 string, _a1 error) *Database_GetWebhookSigningSecret_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_DeleteWorkspaceUser_Call) RunAndReturn(run func(db.WorkspaceUsersData, string) db.WorkspaceUsersData) *Database_DeleteWorkspaceUser_Call {
+func (_c *Database_GetWebhookSigningSecret_Call) RunAndReturn(run func(string) (string, error)) *Database_GetWebhookSigningSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllBounties provides a mock function with given fields: r
-func (_m *Database) GetAllBounties(r *http.Request) []db.NewBounty {
-	ret := _m.Called(r)
+// GetWebhookSigningSecrets provides a mock function with given fields:
+func (_m *Database) GetWebhookSigningSecrets() ([]db.WebhookSigningSecret, error) {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllBounties")
+		panic("no return value specified for GetWebhookSigningSecrets")
 	}
 
-	var r0 []db.NewBounty
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
-		r0 = rf(r)
+	var r0 []db.WebhookSigningSecret
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]db.WebhookSigningSecret, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []db.WebhookSigningSecret); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
+			r0 = ret.Get(0).([]db.WebhookSigningSecret)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetAllBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBounties'
-type Database_GetAllBounties_Call struct {
+// Database_GetWebhookSigningSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookSigningSecrets'
+type Database_GetWebhookSigningSecrets_Call struct {
 	*mock.Call
 }
 
-// GetAllBounties is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetAllBounties(r interface{}) *Database_GetAllBounties_Call {
-	return &Database_GetAllBounties_Call{Call: _e.mock.On("GetAllBounties", r)}
+// GetWebhookSigningSecrets is a helper method to define mock.On call
+func (_e *Database_Expecter) GetWebhookSigningSecrets() *Database_GetWebhookSigningSecrets_Call {
+	return &Database_GetWebhookSigningSecrets_Call{Call: _e.mock.On("GetWebhookSigningSecrets")}
 }
 
-func (_c *Database_GetAllBounties_Call) Run(run func(r *http.Request)) *Database_GetAllBounties_Call {
+func (_c *Database_GetWebhookSigningSecrets_Call) Run(run func()) *Database_GetWebhookSigningSecrets_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_GetAllBounties_Call) Return(_a0 []db.NewBounty) *Database_GetAllBounties_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetWebhookSigningSecrets_Call) Return(_a0 []db.WebhookSigningSecret, _a1 error) *Database_GetWebhookSigningSecrets_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetAllBounties_Call) RunAndReturn(run func(*http.Request) []db.NewBounty) *Database_GetAllBounties_Call {
+func (_c *Database_GetWebhookSigningSecrets_Call) RunAndReturn(run func() ([]db.WebhookSigningSecret, error)) *Database_GetWebhookSigningSecrets_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllTribes provides a mock function with given fields:
-func (_m *Database) GetAllTribes() []db.Tribe {
-	ret := _m.Called()
+// GetWorkspaceActiveBountiesCount provides a mock function with given fields: workspaceUuid, assignee
+func (_m *Database) GetWorkspaceActiveBountiesCount(workspaceUuid string, assignee string) int64 {
+	ret := _m.Called(workspaceUuid, assignee)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllTribes")
+		panic("no return value specified for GetWorkspaceActiveBountiesCount")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func() []db.Tribe); ok {
-		r0 = rf()
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
+		r0 = rf(workspaceUuid, assignee)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetAllTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTribes'
-type Database_GetAllTribes_Call struct {
+// Database_GetWorkspaceActiveBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceActiveBountiesCount'
+type Database_GetWorkspaceActiveBountiesCount_Call struct {
 	*mock.Call
 }
 
-// GetAllTribes is a helper method to define mock.On call
-func (_e *Database_Expecter) GetAllTribes() *Database_GetAllTribes_Call {
-	return &Database_GetAllTribes_Call{Call: _e.mock.On("GetAllTribes")}
+// GetWorkspaceActiveBountiesCount is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - assignee string
+func (_e *Database_Expecter) GetWorkspaceActiveBountiesCount(workspaceUuid interface{}, assignee interface{}) *Database_GetWorkspaceActiveBountiesCount_Call {
+	return &Database_GetWorkspaceActiveBountiesCount_Call{Call: _e.mock.On("GetWorkspaceActiveBountiesCount", workspaceUuid, assignee)}
 }
 
-func (_c *Database_GetAllTribes_Call) Run(run func()) *Database_GetAllTribes_Call {
+func (_c *Database_GetWorkspaceActiveBountiesCount_Call) Run(run func(workspaceUuid string, assignee string)) *Database_GetWorkspaceActiveBountiesCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetAllTribes_Call) Return(_a0 []db.Tribe) *Database_GetAllTribes_Call {
+func (_c *Database_GetWorkspaceActiveBountiesCount_Call) Return(_a0 int64) *Database_GetWorkspaceActiveBountiesCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetAllTribes_Call) RunAndReturn(run func() []db.Tribe) *Database_GetAllTribes_Call {
+func (_c *Database_GetWorkspaceActiveBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetWorkspaceActiveBountiesCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllTribesByOwner provides a mock function with given fields: pubkey
-func (_m *Database) GetAllTribesByOwner(pubkey string) []db.Tribe {
-	ret := _m.Called(pubkey)
+// GetWorkspaceAgentCommentsCount provides a mock function with given fields: workspaceUuid, since
+func (_m *Database) GetWorkspaceAgentCommentsCount(workspaceUuid string, since time.Time) int64 {
+	ret := _m.Called(workspaceUuid, since)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllTribesByOwner")
+		panic("no return value specified for GetWorkspaceAgentCommentsCount")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
-		r0 = rf(pubkey)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, time.Time) int64); ok {
+		r0 = rf(workspaceUuid, since)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetAllTribesByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTribesByOwner'
-type Database_GetAllTribesByOwner_Call struct {
+// Database_GetWorkspaceAgentCommentsCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceAgentCommentsCount'
+type Database_GetWorkspaceAgentCommentsCount_Call struct {
 	*mock.Call
 }
 
-// GetAllTribesByOwner is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetAllTribesByOwner(pubkey interface{}) *Database_GetAllTribesByOwner_Call {
-	return &Database_GetAllTribesByOwner_Call{Call: _e.mock.On("GetAllTribesByOwner", pubkey)}
+// GetWorkspaceAgentCommentsCount is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - since time.Time
+func (_e *Database_Expecter) GetWorkspaceAgentCommentsCount(workspaceUuid interface{}, since interface{}) *Database_GetWorkspaceAgentCommentsCount_Call {
+	return &Database_GetWorkspaceAgentCommentsCount_Call{Call: _e.mock.On("GetWorkspaceAgentCommentsCount", workspaceUuid, since)}
 }
 
-func (_c *Database_GetAllTribesByOwner_Call) Run(run func(pubkey string)) *Database_GetAllTribesByOwner_Call {
+func (_c *Database_GetWorkspaceAgentCommentsCount_Call) Run(run func(workspaceUuid string, since time.Time)) *Database_GetWorkspaceAgentCommentsCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_GetAllTribesByOwner_Call) Return(_a0 []db.Tribe) *Database_GetAllTribesByOwner_Call {
+func (_c *Database_GetWorkspaceAgentCommentsCount_Call) Return(_a0 int64) *Database_GetWorkspaceAgentCommentsCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetAllTribesByOwner_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetAllTribesByOwner_Call {
+func (_c *Database_GetWorkspaceAgentCommentsCount_Call) RunAndReturn(run func(string, time.Time) int64) *Database_GetWorkspaceAgentCommentsCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAssignedBounties provides a mock function with given fields: r
-func (_m *Database) GetAssignedBounties(r *http.Request) ([]db.NewBounty, error) {
-	ret := _m.Called(r)
+// GetWorkspaceApiTokens provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceApiTokens(workspaceUuid string) []db.WorkspaceApiToken {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAssignedBounties")
+		panic("no return value specified for GetWorkspaceApiTokens")
 	}
 
-	var r0 []db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.NewBounty, error)); ok {
-		return rf(r)
-	}
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
-		r0 = rf(r)
+	var r0 []db.WorkspaceApiToken
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceApiToken); ok {
+		r0 = rf(workspaceUuid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
+			r0 = ret.Get(0).([]db.WorkspaceApiToken)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
+	return r0
 }
 
-// Database_GetAssignedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignedBounties'
-type Database_GetAssignedBounties_Call struct {
+// Database_GetWorkspaceApiTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceApiTokens'
+type Database_GetWorkspaceApiTokens_Call struct {
 	*mock.Call
 }
 
-// GetAssignedBounties is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetAssignedBounties(r interface{}) *Database_GetAssignedBounties_Call {
-	return &Database_GetAssignedBounties_Call{Call: _e.mock.On("GetAssignedBounties", r)}
+// GetWorkspaceApiTokens is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceApiTokens(workspaceUuid interface{}) *Database_GetWorkspaceApiTokens_Call {
+	return &Database_GetWorkspaceApiTokens_Call{Call: _e.mock.On("GetWorkspaceApiTokens", workspaceUuid)}
 }
 
-func (_c *Database_GetAssignedBounties_Call) Run(run func(r *http.Request)) *Database_GetAssignedBounties_Call {
+func (_c *Database_GetWorkspaceApiTokens_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceApiTokens_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetAssignedBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetAssignedBounties_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetWorkspaceApiTokens_Call) Return(_a0 []db.WorkspaceApiToken) *Database_GetWorkspaceApiTokens_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetAssignedBounties_Call) RunAndReturn(run func(*http.Request) ([]db.NewBounty, error)) *Database_GetAssignedBounties_Call {
+func (_c *Database_GetWorkspaceApiTokens_Call) RunAndReturn(run func(string) []db.WorkspaceApiToken) *Database_GetWorkspaceApiTokens_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBot provides a mock function with given fields: uuid
-func (_m *Database) GetBot(uuid string) db.Bot {
-	ret := _m.Called(uuid)
+// GetWorkspaceBounties provides a mock function with given fields: r, workspace_uuid
+func (_m *Database) GetWorkspaceBounties(r *http.Request, workspace_uuid string) []db.NewBounty {
+	ret := _m.Called(r, workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBot")
+		panic("no return value specified for GetWorkspaceBounties")
 	}
 
-	var r0 db.Bot
-	if rf, ok := ret.Get(0).(func(string) db.Bot); ok {
-		r0 = rf(uuid)
+	var r0 []db.NewBounty
+	if rf, ok := ret.Get(0).(func(*http.Request, string) []db.NewBounty); ok {
+		r0 = rf(r, workspace_uuid)
 	} else {
-		r0 = ret.Get(0).(db.Bot)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewBounty)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBot'
-type Database_GetBot_Call struct {
+// Database_GetWorkspaceBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBounties'
+type Database_GetWorkspaceBounties_Call struct {
 	*mock.Call
 }
 
-// GetBot is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetBot(uuid interface{}) *Database_GetBot_Call {
-	return &Database_GetBot_Call{Call: _e.mock.On("GetBot", uuid)}
+// GetWorkspaceBounties is a helper method to define mock.On call
+//   - r *http.Request
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBounties(r interface{}, workspace_uuid interface{}) *Database_GetWorkspaceBounties_Call {
+	return &Database_GetWorkspaceBounties_Call{Call: _e.mock.On("GetWorkspaceBounties", r, workspace_uuid)}
 }
 
-func (_c *Database_GetBot_Call) Run(run func(uuid string)) *Database_GetBot_Call {
+func (_c *Database_GetWorkspaceBounties_Call) Run(run func(r *http.Request, workspace_uuid string)) *Database_GetWorkspaceBounties_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(*http.Request), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBot_Call) Return(_a0 db.Bot) *Database_GetBot_Call {
+func (_c *Database_GetWorkspaceBounties_Call) Return(_a0 []db.NewBounty) *Database_GetWorkspaceBounties_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBot_Call) RunAndReturn(run func(string) db.Bot) *Database_GetBot_Call {
+func (_c *Database_GetWorkspaceBounties_Call) RunAndReturn(run func(*http.Request, string) []db.NewBounty) *Database_GetWorkspaceBounties_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBotByUniqueName provides a mock function with given fields: un
-func (_m *Database) GetBotByUniqueName(un string) db.Bot {
-	ret := _m.Called(un)
+// GetWorkspaceBountiesCount provides a mock function with given fields: r, workspace_uuid
+func (_m *Database) GetWorkspaceBountiesCount(r *http.Request, workspace_uuid string) int64 {
+	ret := _m.Called(r, workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBotByUniqueName")
+		panic("no return value specified for GetWorkspaceBountiesCount")
 	}
 
-	var r0 db.Bot
-	if rf, ok := ret.Get(0).(func(string) db.Bot); ok {
-		r0 = rf(un)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*http.Request, string) int64); ok {
+		r0 = rf(r, workspace_uuid)
 	} else {
-		r0 = ret.Get(0).(db.Bot)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetBotByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotByUniqueName'
-type Database_GetBotByUniqueName_Call struct {
+// Database_GetWorkspaceBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountiesCount'
+type Database_GetWorkspaceBountiesCount_Call struct {
 	*mock.Call
 }
 
-// GetBotByUniqueName is a helper method to define mock.On call
-//   - un string
-func (_e *Database_Expecter) GetBotByUniqueName(un interface{}) *Database_GetBotByUniqueName_Call {
-	return &Database_GetBotByUniqueName_Call{Call: _e.mock.On("GetBotByUniqueName", un)}
+// GetWorkspaceBountiesCount is a helper method to define mock.On call
+//   - r *http.Request
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBountiesCount(r interface{}, workspace_uuid interface{}) *Database_GetWorkspaceBountiesCount_Call {
+	return &Database_GetWorkspaceBountiesCount_Call{Call: _e.mock.On("GetWorkspaceBountiesCount", r, workspace_uuid)}
 }
 
-func (_c *Database_GetBotByUniqueName_Call) Run(run func(un string)) *Database_GetBotByUniqueName_Call {
+func (_c *Database_GetWorkspaceBountiesCount_Call) Run(run func(r *http.Request, workspace_uuid string)) *Database_GetWorkspaceBountiesCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(*http.Request), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBotByUniqueName_Call) Return(_a0 db.Bot) *Database_GetBotByUniqueName_Call {
+func (_c *Database_GetWorkspaceBountiesCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountiesCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBotByUniqueName_Call) RunAndReturn(run func(string) db.Bot) *Database_GetBotByUniqueName_Call {
+func (_c *Database_GetWorkspaceBountiesCount_Call) RunAndReturn(run func(*http.Request, string) int64) *Database_GetWorkspaceBountiesCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBotsByOwner provides a mock function with given fields: pubkey
-func (_m *Database) GetBotsByOwner(pubkey string) []db.Bot {
-	ret := _m.Called(pubkey)
+// GetWorkspaceBountiesInStateCount provides a mock function with given fields: workspaceUuid, phaseUuid, state
+func (_m *Database) GetWorkspaceBountiesInStateCount(workspaceUuid string, phaseUuid string, state db.BountyState) int64 {
+	ret := _m.Called(workspaceUuid, phaseUuid, state)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBotsByOwner")
+		panic("no return value specified for GetWorkspaceBountiesInStateCount")
 	}
 
-	var r0 []db.Bot
-	if rf, ok := ret.Get(0).(func(string) []db.Bot); ok {
-		r0 = rf(pubkey)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string, db.BountyState) int64); ok {
+		r0 = rf(workspaceUuid, phaseUuid, state)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Bot)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetBotsByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBotsByOwner'
-type Database_GetBotsByOwner_Call struct {
+// Database_GetWorkspaceBountiesInStateCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountiesInStateCount'
+type Database_GetWorkspaceBountiesInStateCount_Call struct {
 	*mock.Call
 }
 
-// GetBotsByOwner is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetBotsByOwner(pubkey interface{}) *Database_GetBotsByOwner_Call {
-	return &Database_GetBotsByOwner_Call{Call: _e.mock.On("GetBotsByOwner", pubkey)}
+// GetWorkspaceBountiesInStateCount is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - phaseUuid string
+//   - state db.BountyState
+func (_e *Database_Expecter) GetWorkspaceBountiesInStateCount(workspaceUuid interface{}, phaseUuid interface{}, state interface{}) *Database_GetWorkspaceBountiesInStateCount_Call {
+	return &Database_GetWorkspaceBountiesInStateCount_Call{Call: _e.mock.On("GetWorkspaceBountiesInStateCount", workspaceUuid, phaseUuid, state)}
 }
 
-func (_c *Database_GetBotsByOwner_Call) Run(run func(pubkey string)) *Database_GetBotsByOwner_Call {
+func (_c *Database_GetWorkspaceBountiesInStateCount_Call) Run(run func(workspaceUuid string, phaseUuid string, state db.BountyState)) *Database_GetWorkspaceBountiesInStateCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string), args[2].(db.BountyState))
 	})
 	return _c
 }
 
-func (_c *Database_GetBotsByOwner_Call) Return(_a0 []db.Bot) *Database_GetBotsByOwner_Call {
+func (_c *Database_GetWorkspaceBountiesInStateCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountiesInStateCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBotsByOwner_Call) RunAndReturn(run func(string) []db.Bot) *Database_GetBotsByOwner_Call {
+func (_c *Database_GetWorkspaceBountiesInStateCount_Call) RunAndReturn(run func(string, string, db.BountyState) int64) *Database_GetWorkspaceBountiesInStateCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesByDateRange provides a mock function with given fields: r, re
-func (_m *Database) GetBountiesByDateRange(r db.PaymentDateRange, re *http.Request) []db.NewBounty {
-	ret := _m.Called(r, re)
+// GetWorkspaceBountyCount provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceBountyCount(uuid string) int64 {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesByDateRange")
+		panic("no return value specified for GetWorkspaceBountyCount")
 	}
 
-	var r0 []db.NewBounty
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.NewBounty); ok {
-		r0 = rf(r, re)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(uuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetBountiesByDateRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByDateRange'
-type Database_GetBountiesByDateRange_Call struct {
+// Database_GetWorkspaceBountyCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountyCount'
+type Database_GetWorkspaceBountyCount_Call struct {
 	*mock.Call
 }
 
-// GetBountiesByDateRange is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - re *http.Request
-func (_e *Database_Expecter) GetBountiesByDateRange(r interface{}, re interface{}) *Database_GetBountiesByDateRange_Call {
-	return &Database_GetBountiesByDateRange_Call{Call: _e.mock.On("GetBountiesByDateRange", r, re)}
+// GetWorkspaceBountyCount is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceBountyCount(uuid interface{}) *Database_GetWorkspaceBountyCount_Call {
+	return &Database_GetWorkspaceBountyCount_Call{Call: _e.mock.On("GetWorkspaceBountyCount", uuid)}
 }
 
-func (_c *Database_GetBountiesByDateRange_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesByDateRange_Call {
+func (_c *Database_GetWorkspaceBountyCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceBountyCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesByDateRange_Call) Return(_a0 []db.NewBounty) *Database_GetBountiesByDateRange_Call {
+func (_c *Database_GetWorkspaceBountyCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountyCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesByDateRange_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) []db.NewBounty) *Database_GetBountiesByDateRange_Call {
+func (_c *Database_GetWorkspaceBountyCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceBountyCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesByDateRangeCount provides a mock function with given fields: r, re
-func (_m *Database) GetBountiesByDateRangeCount(r db.PaymentDateRange, re *http.Request) int64 {
-	ret := _m.Called(r, re)
+// GetWorkspaceBountyEfforts provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceBountyEfforts(workspaceUuid string) []db.BountyEffortRow {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesByDateRangeCount")
+		panic("no return value specified for GetWorkspaceBountyEfforts")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) int64); ok {
-		r0 = rf(r, re)
+	var r0 []db.BountyEffortRow
+	if rf, ok := ret.Get(0).(func(string) []db.BountyEffortRow); ok {
+		r0 = rf(workspaceUuid)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BountyEffortRow)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetBountiesByDateRangeCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByDateRangeCount'
-type Database_GetBountiesByDateRangeCount_Call struct {
+// Database_GetWorkspaceBountyEfforts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountyEfforts'
+type Database_GetWorkspaceBountyEfforts_Call struct {
 	*mock.Call
 }
 
-// GetBountiesByDateRangeCount is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - re *http.Request
-func (_e *Database_Expecter) GetBountiesByDateRangeCount(r interface{}, re interface{}) *Database_GetBountiesByDateRangeCount_Call {
-	return &Database_GetBountiesByDateRangeCount_Call{Call: _e.mock.On("GetBountiesByDateRangeCount", r, re)}
+// GetWorkspaceBountyEfforts is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceBountyEfforts(workspaceUuid interface{}) *Database_GetWorkspaceBountyEfforts_Call {
+	return &Database_GetWorkspaceBountyEfforts_Call{Call: _e.mock.On("GetWorkspaceBountyEfforts", workspaceUuid)}
 }
 
-func (_c *Database_GetBountiesByDateRangeCount_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesByDateRangeCount_Call {
+func (_c *Database_GetWorkspaceBountyEfforts_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceBountyEfforts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesByDateRangeCount_Call) Return(_a0 int64) *Database_GetBountiesByDateRangeCount_Call {
+func (_c *Database_GetWorkspaceBountyEfforts_Call) Return(_a0 []db.BountyEffortRow) *Database_GetWorkspaceBountyEfforts_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesByDateRangeCount_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) int64) *Database_GetBountiesByDateRangeCount_Call {
+func (_c *Database_GetWorkspaceBountyEfforts_Call) RunAndReturn(run func(string) []db.BountyEffortRow) *Database_GetWorkspaceBountyEfforts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesByFeatureAndPhaseUuid provides a mock function with given fields: featureUuid, phaseUuid, r
-func (_m *Database) GetBountiesByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) ([]db.NewBounty, error) {
-	ret := _m.Called(featureUuid, phaseUuid, r)
+// GetWorkspaceBridges provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceBridges(workspaceUuid string) []db.WorkspaceBridge {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesByFeatureAndPhaseUuid")
+		panic("no return value specified for GetWorkspaceBridges")
 	}
 
-	var r0 []db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, *http.Request) ([]db.NewBounty, error)); ok {
-		return rf(featureUuid, phaseUuid, r)
-	}
-	if rf, ok := ret.Get(0).(func(string, string, *http.Request) []db.NewBounty); ok {
-		r0 = rf(featureUuid, phaseUuid, r)
+	var r0 []db.WorkspaceBridge
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceBridge); ok {
+		r0 = rf(workspaceUuid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
+			r0 = ret.Get(0).([]db.WorkspaceBridge)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, *http.Request) error); ok {
-		r1 = rf(featureUuid, phaseUuid, r)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
+	return r0
 }
 
-// Database_GetBountiesByFeatureAndPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByFeatureAndPhaseUuid'
-type Database_GetBountiesByFeatureAndPhaseUuid_Call struct {
+// Database_GetWorkspaceBridges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBridges'
+type Database_GetWorkspaceBridges_Call struct {
 	*mock.Call
 }
 
-// GetBountiesByFeatureAndPhaseUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - phaseUuid string
-//   - r *http.Request
-func (_e *Database_Expecter) GetBountiesByFeatureAndPhaseUuid(featureUuid interface{}, phaseUuid interface{}, r interface{}) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
-	return &Database_GetBountiesByFeatureAndPhaseUuid_Call{Call: _e.mock.On("GetBountiesByFeatureAndPhaseUuid", featureUuid, phaseUuid, r)}
+// GetWorkspaceBridges is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceBridges(workspaceUuid interface{}) *Database_GetWorkspaceBridges_Call {
+	return &Database_GetWorkspaceBridges_Call{Call: _e.mock.On("GetWorkspaceBridges", workspaceUuid)}
 }
 
-func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) Run(run func(featureUuid string, phaseUuid string, r *http.Request)) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBridges_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceBridges_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetWorkspaceBridges_Call) Return(_a0 []db.WorkspaceBridge) *Database_GetWorkspaceBridges_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesByFeatureAndPhaseUuid_Call) RunAndReturn(run func(string, string, *http.Request) ([]db.NewBounty, error)) *Database_GetBountiesByFeatureAndPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBridges_Call) RunAndReturn(run func(string) []db.WorkspaceBridge) *Database_GetWorkspaceBridges_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesByPhaseUuid provides a mock function with given fields: phaseUuid
-func (_m *Database) GetBountiesByPhaseUuid(phaseUuid string) []db.Bounty {
-	ret := _m.Called(phaseUuid)
+// GetWorkspaceBudget provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceBudget(workspace_uuid string) db.NewBountyBudget {
+	ret := _m.Called(workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesByPhaseUuid")
+		panic("no return value specified for GetWorkspaceBudget")
 	}
 
-	var r0 []db.Bounty
-	if rf, ok := ret.Get(0).(func(string) []db.Bounty); ok {
-		r0 = rf(phaseUuid)
+	var r0 db.NewBountyBudget
+	if rf, ok := ret.Get(0).(func(string) db.NewBountyBudget); ok {
+		r0 = rf(workspace_uuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Bounty)
-		}
+		r0 = ret.Get(0).(db.NewBountyBudget)
 	}
 
 	return r0
 }
 
-// Database_GetBountiesByPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesByPhaseUuid'
-type Database_GetBountiesByPhaseUuid_Call struct {
+// Database_GetWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudget'
+type Database_GetWorkspaceBudget_Call struct {
 	*mock.Call
 }
 
-// GetBountiesByPhaseUuid is a helper method to define mock.On call
-//   - phaseUuid string
-func (_e *Database_Expecter) GetBountiesByPhaseUuid(phaseUuid interface{}) *Database_GetBountiesByPhaseUuid_Call {
-	return &Database_GetBountiesByPhaseUuid_Call{Call: _e.mock.On("GetBountiesByPhaseUuid", phaseUuid)}
+// GetWorkspaceBudget is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBudget(workspace_uuid interface{}) *Database_GetWorkspaceBudget_Call {
+	return &Database_GetWorkspaceBudget_Call{Call: _e.mock.On("GetWorkspaceBudget", workspace_uuid)}
 }
 
-func (_c *Database_GetBountiesByPhaseUuid_Call) Run(run func(phaseUuid string)) *Database_GetBountiesByPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBudget_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesByPhaseUuid_Call) Return(_a0 []db.Bounty) *Database_GetBountiesByPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBudget_Call) Return(_a0 db.NewBountyBudget) *Database_GetWorkspaceBudget_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesByPhaseUuid_Call) RunAndReturn(run func(string) []db.Bounty) *Database_GetBountiesByPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBudget_Call) RunAndReturn(run func(string) db.NewBountyBudget) *Database_GetWorkspaceBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesCount provides a mock function with given fields: r
-func (_m *Database) GetBountiesCount(r *http.Request) int64 {
-	ret := _m.Called(r)
+// GetWorkspaceBudgetHistory provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceBudgetHistory(workspace_uuid string) []db.BudgetHistoryData {
+	ret := _m.Called(workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesCount")
+		panic("no return value specified for GetWorkspaceBudgetHistory")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
-		r0 = rf(r)
+	var r0 []db.BudgetHistoryData
+	if rf, ok := ret.Get(0).(func(string) []db.BudgetHistoryData); ok {
+		r0 = rf(workspace_uuid)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BudgetHistoryData)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesCount'
-type Database_GetBountiesCount_Call struct {
+// Database_GetWorkspaceBudgetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudgetHistory'
+type Database_GetWorkspaceBudgetHistory_Call struct {
 	*mock.Call
 }
 
-// GetBountiesCount is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetBountiesCount(r interface{}) *Database_GetBountiesCount_Call {
-	return &Database_GetBountiesCount_Call{Call: _e.mock.On("GetBountiesCount", r)}
+// GetWorkspaceBudgetHistory is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceBudgetHistory(workspace_uuid interface{}) *Database_GetWorkspaceBudgetHistory_Call {
+	return &Database_GetWorkspaceBudgetHistory_Call{Call: _e.mock.On("GetWorkspaceBudgetHistory", workspace_uuid)}
 }
 
-func (_c *Database_GetBountiesCount_Call) Run(run func(r *http.Request)) *Database_GetBountiesCount_Call {
+func (_c *Database_GetWorkspaceBudgetHistory_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudgetHistory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesCount_Call) Return(_a0 int64) *Database_GetBountiesCount_Call {
+func (_c *Database_GetWorkspaceBudgetHistory_Call) Return(_a0 []db.BudgetHistoryData) *Database_GetWorkspaceBudgetHistory_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesCount_Call) RunAndReturn(run func(*http.Request) int64) *Database_GetBountiesCount_Call {
+func (_c *Database_GetWorkspaceBudgetHistory_Call) RunAndReturn(run func(string) []db.BudgetHistoryData) *Database_GetWorkspaceBudgetHistory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesCountByFeatureAndPhaseUuid provides a mock function with given fields: featureUuid, phaseUuid, r
-func (_m *Database) GetBountiesCountByFeatureAndPhaseUuid(featureUuid string, phaseUuid string, r *http.Request) int64 {
-	ret := _m.Called(featureUuid, phaseUuid, r)
+// GetWorkspaceBudgetSnapshots provides a mock function with given fields: workspaceUuid, from, to
+func (_m *Database) GetWorkspaceBudgetSnapshots(workspaceUuid string, from string, to string) []db.WorkspaceBudgetSnapshot {
+	ret := _m.Called(workspaceUuid, from, to)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesCountByFeatureAndPhaseUuid")
+		panic("no return value specified for GetWorkspaceBudgetSnapshots")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string, *http.Request) int64); ok {
-		r0 = rf(featureUuid, phaseUuid, r)
+	var r0 []db.WorkspaceBudgetSnapshot
+	if rf, ok := ret.Get(0).(func(string, string, string) []db.WorkspaceBudgetSnapshot); ok {
+		r0 = rf(workspaceUuid, from, to)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceBudgetSnapshot)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetBountiesCountByFeatureAndPhaseUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesCountByFeatureAndPhaseUuid'
-type Database_GetBountiesCountByFeatureAndPhaseUuid_Call struct {
+// Database_GetWorkspaceBudgetSnapshots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudgetSnapshots'
+type Database_GetWorkspaceBudgetSnapshots_Call struct {
 	*mock.Call
 }
 
-// GetBountiesCountByFeatureAndPhaseUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - phaseUuid string
-//   - r *http.Request
-func (_e *Database_Expecter) GetBountiesCountByFeatureAndPhaseUuid(featureUuid interface{}, phaseUuid interface{}, r interface{}) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
-	return &Database_GetBountiesCountByFeatureAndPhaseUuid_Call{Call: _e.mock.On("GetBountiesCountByFeatureAndPhaseUuid", featureUuid, phaseUuid, r)}
+// GetWorkspaceBudgetSnapshots is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - from string
+//   - to string
+func (_e *Database_Expecter) GetWorkspaceBudgetSnapshots(workspaceUuid interface{}, from interface{}, to interface{}) *Database_GetWorkspaceBudgetSnapshots_Call {
+	return &Database_GetWorkspaceBudgetSnapshots_Call{Call: _e.mock.On("GetWorkspaceBudgetSnapshots", workspaceUuid, from, to)}
 }
 
-func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) Run(run func(featureUuid string, phaseUuid string, r *http.Request)) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBudgetSnapshots_Call) Run(run func(workspaceUuid string, from string, to string)) *Database_GetWorkspaceBudgetSnapshots_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(*http.Request))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) Return(_a0 int64) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBudgetSnapshots_Call) Return(_a0 []db.WorkspaceBudgetSnapshot) *Database_GetWorkspaceBudgetSnapshots_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesCountByFeatureAndPhaseUuid_Call) RunAndReturn(run func(string, string, *http.Request) int64) *Database_GetBountiesCountByFeatureAndPhaseUuid_Call {
+func (_c *Database_GetWorkspaceBudgetSnapshots_Call) RunAndReturn(run func(string, string, string) []db.WorkspaceBudgetSnapshot) *Database_GetWorkspaceBudgetSnapshots_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesLeaderboard provides a mock function with given fields:
-func (_m *Database) GetBountiesLeaderboard() []db.LeaderData {
-	ret := _m.Called()
+// GetWorkspaceByName provides a mock function with given fields: name
+func (_m *Database) GetWorkspaceByName(name string) db.Workspace {
+	ret := _m.Called(name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesLeaderboard")
+		panic("no return value specified for GetWorkspaceByName")
 	}
 
-	var r0 []db.LeaderData
-	if rf, ok := ret.Get(0).(func() []db.LeaderData); ok {
-		r0 = rf()
+	var r0 db.Workspace
+	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
+		r0 = rf(name)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.LeaderData)
-		}
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
 	return r0
 }
 
-// Database_GetBountiesLeaderboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesLeaderboard'
-type Database_GetBountiesLeaderboard_Call struct {
+// Database_GetWorkspaceByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceByName'
+type Database_GetWorkspaceByName_Call struct {
 	*mock.Call
 }
 
-// GetBountiesLeaderboard is a helper method to define mock.On call
-func (_e *Database_Expecter) GetBountiesLeaderboard() *Database_GetBountiesLeaderboard_Call {
-	return &Database_GetBountiesLeaderboard_Call{Call: _e.mock.On("GetBountiesLeaderboard")}
+// GetWorkspaceByName is a helper method to define mock.On call
+//   - name string
+func (_e *Database_Expecter) GetWorkspaceByName(name interface{}) *Database_GetWorkspaceByName_Call {
+	return &Database_GetWorkspaceByName_Call{Call: _e.mock.On("GetWorkspaceByName", name)}
 }
 
-func (_c *Database_GetBountiesLeaderboard_Call) Run(run func()) *Database_GetBountiesLeaderboard_Call {
+func (_c *Database_GetWorkspaceByName_Call) Run(run func(name string)) *Database_GetWorkspaceByName_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesLeaderboard_Call) Return(_a0 []db.LeaderData) *Database_GetBountiesLeaderboard_Call {
+func (_c *Database_GetWorkspaceByName_Call) Return(_a0 db.Workspace) *Database_GetWorkspaceByName_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesLeaderboard_Call) RunAndReturn(run func() []db.LeaderData) *Database_GetBountiesLeaderboard_Call {
+func (_c *Database_GetWorkspaceByName_Call) RunAndReturn(run func(string) db.Workspace) *Database_GetWorkspaceByName_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountiesProviders provides a mock function with given fields: r, re
-func (_m *Database) GetBountiesProviders(r db.PaymentDateRange, re *http.Request) []db.Person {
-	ret := _m.Called(r, re)
+// GetWorkspaceByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceByUuid(uuid string) db.Workspace {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountiesProviders")
+		panic("no return value specified for GetWorkspaceByUuid")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request) []db.Person); ok {
-		r0 = rf(r, re)
+	var r0 db.Workspace
+	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
+		r0 = rf(uuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
 	return r0
 }
 
-// Database_GetBountiesProviders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountiesProviders'
-type Database_GetBountiesProviders_Call struct {
+// Database_GetWorkspaceByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceByUuid'
+type Database_GetWorkspaceByUuid_Call struct {
 	*mock.Call
 }
 
-// GetBountiesProviders is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - re *http.Request
-func (_e *Database_Expecter) GetBountiesProviders(r interface{}, re interface{}) *Database_GetBountiesProviders_Call {
-	return &Database_GetBountiesProviders_Call{Call: _e.mock.On("GetBountiesProviders", r, re)}
+// GetWorkspaceByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceByUuid(uuid interface{}) *Database_GetWorkspaceByUuid_Call {
+	return &Database_GetWorkspaceByUuid_Call{Call: _e.mock.On("GetWorkspaceByUuid", uuid)}
 }
 
-func (_c *Database_GetBountiesProviders_Call) Run(run func(r db.PaymentDateRange, re *http.Request)) *Database_GetBountiesProviders_Call {
+func (_c *Database_GetWorkspaceByUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceByUuid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountiesProviders_Call) Return(_a0 []db.Person) *Database_GetBountiesProviders_Call {
+func (_c *Database_GetWorkspaceByUuid_Call) Return(_a0 db.Workspace) *Database_GetWorkspaceByUuid_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountiesProviders_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request) []db.Person) *Database_GetBountiesProviders_Call {
+func (_c *Database_GetWorkspaceByUuid_Call) RunAndReturn(run func(string) db.Workspace) *Database_GetWorkspaceByUuid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBounty provides a mock function with given fields: id
-func (_m *Database) GetBounty(id uint) db.NewBounty {
-	ret := _m.Called(id)
+// GetWorkspaceFeaturesCount provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceFeaturesCount(uuid string) int64 {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBounty")
+		panic("no return value specified for GetWorkspaceFeaturesCount")
 	}
 
-	var r0 db.NewBounty
-	if rf, ok := ret.Get(0).(func(uint) db.NewBounty); ok {
-		r0 = rf(id)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(uuid)
 	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBounty'
-type Database_GetBounty_Call struct {
+// Database_GetWorkspaceFeaturesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceFeaturesCount'
+type Database_GetWorkspaceFeaturesCount_Call struct {
 	*mock.Call
 }
 
-// GetBounty is a helper method to define mock.On call
-//   - id uint
-func (_e *Database_Expecter) GetBounty(id interface{}) *Database_GetBounty_Call {
-	return &Database_GetBounty_Call{Call: _e.mock.On("GetBounty", id)}
+// GetWorkspaceFeaturesCount is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceFeaturesCount(uuid interface{}) *Database_GetWorkspaceFeaturesCount_Call {
+	return &Database_GetWorkspaceFeaturesCount_Call{Call: _e.mock.On("GetWorkspaceFeaturesCount", uuid)}
 }
 
-func (_c *Database_GetBounty_Call) Run(run func(id uint)) *Database_GetBounty_Call {
+func (_c *Database_GetWorkspaceFeaturesCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceFeaturesCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBounty_Call) Return(_a0 db.NewBounty) *Database_GetBounty_Call {
+func (_c *Database_GetWorkspaceFeaturesCount_Call) Return(_a0 int64) *Database_GetWorkspaceFeaturesCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBounty_Call) RunAndReturn(run func(uint) db.NewBounty) *Database_GetBounty_Call {
+func (_c *Database_GetWorkspaceFeaturesCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceFeaturesCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyByCreated provides a mock function with given fields: created
-func (_m *Database) GetBountyByCreated(created uint) (db.NewBounty, error) {
-	ret := _m.Called(created)
+// GetWorkspaceInvoices provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceInvoices(workspace_uuid string) []db.NewInvoiceList {
+	ret := _m.Called(workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyByCreated")
-	}
-
-	var r0 db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(uint) (db.NewBounty, error)); ok {
-		return rf(created)
-	}
-	if rf, ok := ret.Get(0).(func(uint) db.NewBounty); ok {
-		r0 = rf(created)
-	} else {
-		r0 = ret.Get(0).(db.NewBounty)
+		panic("no return value specified for GetWorkspaceInvoices")
 	}
 
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(created)
+	var r0 []db.NewInvoiceList
+	if rf, ok := ret.Get(0).(func(string) []db.NewInvoiceList); ok {
+		r0 = rf(workspace_uuid)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.NewInvoiceList)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyByCreated'
-type Database_GetBountyByCreated_Call struct {
+// Database_GetWorkspaceInvoices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceInvoices'
+type Database_GetWorkspaceInvoices_Call struct {
 	*mock.Call
 }
 
-// GetBountyByCreated is a helper method to define mock.On call
-//   - created uint
-func (_e *Database_Expecter) GetBountyByCreated(created interface{}) *Database_GetBountyByCreated_Call {
-	return &Database_GetBountyByCreated_Call{Call: _e.mock.On("GetBountyByCreated", created)}
+// GetWorkspaceInvoices is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceInvoices(workspace_uuid interface{}) *Database_GetWorkspaceInvoices_Call {
+	return &Database_GetWorkspaceInvoices_Call{Call: _e.mock.On("GetWorkspaceInvoices", workspace_uuid)}
 }
 
-func (_c *Database_GetBountyByCreated_Call) Run(run func(created uint)) *Database_GetBountyByCreated_Call {
+func (_c *Database_GetWorkspaceInvoices_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceInvoices_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyByCreated_Call) Return(_a0 db.NewBounty, _a1 error) *Database_GetBountyByCreated_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetWorkspaceInvoices_Call) Return(_a0 []db.NewInvoiceList) *Database_GetWorkspaceInvoices_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountyByCreated_Call) RunAndReturn(run func(uint) (db.NewBounty, error)) *Database_GetBountyByCreated_Call {
+func (_c *Database_GetWorkspaceInvoices_Call) RunAndReturn(run func(string) []db.NewInvoiceList) *Database_GetWorkspaceInvoices_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyById provides a mock function with given fields: id
-func (_m *Database) GetBountyById(id string) ([]db.NewBounty, error) {
-	ret := _m.Called(id)
+// GetWorkspaceInvoicesCount provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceInvoicesCount(workspace_uuid string) int64 {
+	ret := _m.Called(workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyById")
-	}
-
-	var r0 []db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]db.NewBounty, error)); ok {
-		return rf(id)
-	}
-	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
-		r0 = rf(id)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		panic("no return value specified for GetWorkspaceInvoicesCount")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(workspace_uuid)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetBountyById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyById'
-type Database_GetBountyById_Call struct {
+// Database_GetWorkspaceInvoicesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceInvoicesCount'
+type Database_GetWorkspaceInvoicesCount_Call struct {
 	*mock.Call
 }
 
-// GetBountyById is a helper method to define mock.On call
-//   - id string
-func (_e *Database_Expecter) GetBountyById(id interface{}) *Database_GetBountyById_Call {
-	return &Database_GetBountyById_Call{Call: _e.mock.On("GetBountyById", id)}
+// GetWorkspaceInvoicesCount is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceInvoicesCount(workspace_uuid interface{}) *Database_GetWorkspaceInvoicesCount_Call {
+	return &Database_GetWorkspaceInvoicesCount_Call{Call: _e.mock.On("GetWorkspaceInvoicesCount", workspace_uuid)}
 }
 
-func (_c *Database_GetBountyById_Call) Run(run func(id string)) *Database_GetBountyById_Call {
+func (_c *Database_GetWorkspaceInvoicesCount_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceInvoicesCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyById_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountyById_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetWorkspaceInvoicesCount_Call) Return(_a0 int64) *Database_GetWorkspaceInvoicesCount_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountyById_Call) RunAndReturn(run func(string) ([]db.NewBounty, error)) *Database_GetBountyById_Call {
+func (_c *Database_GetWorkspaceInvoicesCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceInvoicesCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyDataByCreated provides a mock function with given fields: created
-func (_m *Database) GetBountyDataByCreated(created string) ([]db.NewBounty, error) {
-	ret := _m.Called(created)
+// GetWorkspaceLedgerBalance provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceLedgerBalance(workspaceUuid string) int64 {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyDataByCreated")
-	}
-
-	var r0 []db.NewBounty
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]db.NewBounty, error)); ok {
-		return rf(created)
-	}
-	if rf, ok := ret.Get(0).(func(string) []db.NewBounty); ok {
-		r0 = rf(created)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		panic("no return value specified for GetWorkspaceLedgerBalance")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(created)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(workspaceUuid)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetBountyDataByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyDataByCreated'
-type Database_GetBountyDataByCreated_Call struct {
+// Database_GetWorkspaceLedgerBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceLedgerBalance'
+type Database_GetWorkspaceLedgerBalance_Call struct {
 	*mock.Call
 }
 
-// GetBountyDataByCreated is a helper method to define mock.On call
-//   - created string
-func (_e *Database_Expecter) GetBountyDataByCreated(created interface{}) *Database_GetBountyDataByCreated_Call {
-	return &Database_GetBountyDataByCreated_Call{Call: _e.mock.On("GetBountyDataByCreated", created)}
+// GetWorkspaceLedgerBalance is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceLedgerBalance(workspaceUuid interface{}) *Database_GetWorkspaceLedgerBalance_Call {
+	return &Database_GetWorkspaceLedgerBalance_Call{Call: _e.mock.On("GetWorkspaceLedgerBalance", workspaceUuid)}
 }
 
-func (_c *Database_GetBountyDataByCreated_Call) Run(run func(created string)) *Database_GetBountyDataByCreated_Call {
+func (_c *Database_GetWorkspaceLedgerBalance_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceLedgerBalance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyDataByCreated_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetBountyDataByCreated_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetWorkspaceLedgerBalance_Call) Return(_a0 int64) *Database_GetWorkspaceLedgerBalance_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountyDataByCreated_Call) RunAndReturn(run func(string) ([]db.NewBounty, error)) *Database_GetBountyDataByCreated_Call {
+func (_c *Database_GetWorkspaceLedgerBalance_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceLedgerBalance_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyIndexById provides a mock function with given fields: id
-func (_m *Database) GetBountyIndexById(id string) int64 {
-	ret := _m.Called(id)
+// GetWorkspaceRepoByWorkspaceUuidAndRepoUuid provides a mock function with given fields: workspace_uuid, uuid
+func (_m *Database) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid string, uuid string) (db.WorkspaceRepositories, error) {
+	ret := _m.Called(workspace_uuid, uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyIndexById")
+		panic("no return value specified for GetWorkspaceRepoByWorkspaceUuidAndRepoUuid")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(id)
+	var r0 db.WorkspaceRepositories
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.WorkspaceRepositories, error)); ok {
+		return rf(workspace_uuid, uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceRepositories); ok {
+		r0 = rf(workspace_uuid, uuid)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.WorkspaceRepositories)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(workspace_uuid, uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetBountyIndexById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyIndexById'
-type Database_GetBountyIndexById_Call struct {
+// Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceRepoByWorkspaceUuidAndRepoUuid'
+type Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call struct {
 	*mock.Call
 }
 
-// GetBountyIndexById is a helper method to define mock.On call
-//   - id string
-func (_e *Database_Expecter) GetBountyIndexById(id interface{}) *Database_GetBountyIndexById_Call {
-	return &Database_GetBountyIndexById_Call{Call: _e.mock.On("GetBountyIndexById", id)}
+// GetWorkspaceRepoByWorkspaceUuidAndRepoUuid is a helper method to define mock.On call
+//   - workspace_uuid string
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid interface{}, uuid interface{}) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+	return &Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call{Call: _e.mock.On("GetWorkspaceRepoByWorkspaceUuidAndRepoUuid", workspace_uuid, uuid)}
 }
 
-func (_c *Database_GetBountyIndexById_Call) Run(run func(id string)) *Database_GetBountyIndexById_Call {
+func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) Run(run func(workspace_uuid string, uuid string)) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyIndexById_Call) Return(_a0 int64) *Database_GetBountyIndexById_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) Return(_a0 db.WorkspaceRepositories, _a1 error) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetBountyIndexById_Call) RunAndReturn(run func(string) int64) *Database_GetBountyIndexById_Call {
+func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) RunAndReturn(run func(string, string) (db.WorkspaceRepositories, error)) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBountyRoles provides a mock function with given fields:
-func (_m *Database) GetBountyRoles() []db.BountyRoles {
-	ret := _m.Called()
+// GetWorkspaceRepositorByWorkspaceUuid provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceRepositorByWorkspaceUuid(uuid string) []db.WorkspaceRepositories {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBountyRoles")
+		panic("no return value specified for GetWorkspaceRepositorByWorkspaceUuid")
 	}
 
-	var r0 []db.BountyRoles
-	if rf, ok := ret.Get(0).(func() []db.BountyRoles); ok {
-		r0 = rf()
+	var r0 []db.WorkspaceRepositories
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceRepositories); ok {
+		r0 = rf(uuid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.BountyRoles)
+			r0 = ret.Get(0).([]db.WorkspaceRepositories)
 		}
 	}
 
 	return r0
 }
 
-// Database_GetBountyRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBountyRoles'
-type Database_GetBountyRoles_Call struct {
+// Database_GetWorkspaceRepositorByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceRepositorByWorkspaceUuid'
+type Database_GetWorkspaceRepositorByWorkspaceUuid_Call struct {
 	*mock.Call
 }
 
-// GetBountyRoles is a helper method to define mock.On call
-func (_e *Database_Expecter) GetBountyRoles() *Database_GetBountyRoles_Call {
-	return &Database_GetBountyRoles_Call{Call: _e.mock.On("GetBountyRoles")}
+// GetWorkspaceRepositorByWorkspaceUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceRepositorByWorkspaceUuid(uuid interface{}) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+	return &Database_GetWorkspaceRepositorByWorkspaceUuid_Call{Call: _e.mock.On("GetWorkspaceRepositorByWorkspaceUuid", uuid)}
 }
 
-func (_c *Database_GetBountyRoles_Call) Run(run func()) *Database_GetBountyRoles_Call {
+func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetBountyRoles_Call) Return(_a0 []db.BountyRoles) *Database_GetBountyRoles_Call {
+func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceRepositories) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetBountyRoles_Call) RunAndReturn(run func() []db.BountyRoles) *Database_GetBountyRoles_Call {
+func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) RunAndReturn(run func(string) []db.WorkspaceRepositories) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChannel provides a mock function with given fields: id
-func (_m *Database) GetChannel(id uint) db.Channel {
-	ret := _m.Called(id)
+// GetWorkspaceSettings provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceSettings(workspaceUuid string) db.WorkspaceSettings {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChannel")
+		panic("no return value specified for GetWorkspaceSettings")
 	}
 
-	var r0 db.Channel
-	if rf, ok := ret.Get(0).(func(uint) db.Channel); ok {
-		r0 = rf(id)
+	var r0 db.WorkspaceSettings
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceSettings); ok {
+		r0 = rf(workspaceUuid)
 	} else {
-		r0 = ret.Get(0).(db.Channel)
+		r0 = ret.Get(0).(db.WorkspaceSettings)
 	}
 
 	return r0
 }
 
-// Database_GetChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannel'
-type Database_GetChannel_Call struct {
+// Database_GetWorkspaceSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceSettings'
+type Database_GetWorkspaceSettings_Call struct {
 	*mock.Call
 }
 
-// GetChannel is a helper method to define mock.On call
-//   - id uint
-func (_e *Database_Expecter) GetChannel(id interface{}) *Database_GetChannel_Call {
-	return &Database_GetChannel_Call{Call: _e.mock.On("GetChannel", id)}
+// GetWorkspaceSettings is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceSettings(workspaceUuid interface{}) *Database_GetWorkspaceSettings_Call {
+	return &Database_GetWorkspaceSettings_Call{Call: _e.mock.On("GetWorkspaceSettings", workspaceUuid)}
 }
 
-func (_c *Database_GetChannel_Call) Run(run func(id uint)) *Database_GetChannel_Call {
+func (_c *Database_GetWorkspaceSettings_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceSettings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetChannel_Call) Return(_a0 db.Channel) *Database_GetChannel_Call {
+func (_c *Database_GetWorkspaceSettings_Call) Return(_a0 db.WorkspaceSettings) *Database_GetWorkspaceSettings_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetChannel_Call) RunAndReturn(run func(uint) db.Channel) *Database_GetChannel_Call {
+func (_c *Database_GetWorkspaceSettings_Call) RunAndReturn(run func(string) db.WorkspaceSettings) *Database_GetWorkspaceSettings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChannelsByTribe provides a mock function with given fields: tribe_uuid
-func (_m *Database) GetChannelsByTribe(tribe_uuid string) []db.Channel {
-	ret := _m.Called(tribe_uuid)
+// GetWorkspaceShowcase provides a mock function with given fields: workspace, limit
+func (_m *Database) GetWorkspaceShowcase(workspace db.Workspace, limit int) (db.WorkspaceShowcase, error) {
+	ret := _m.Called(workspace, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChannelsByTribe")
+		panic("no return value specified for GetWorkspaceShowcase")
 	}
 
-	var r0 []db.Channel
-	if rf, ok := ret.Get(0).(func(string) []db.Channel); ok {
-		r0 = rf(tribe_uuid)
+	var r0 db.WorkspaceShowcase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Workspace, int) (db.WorkspaceShowcase, error)); ok {
+		return rf(workspace, limit)
+	}
+	if rf, ok := ret.Get(0).(func(db.Workspace, int) db.WorkspaceShowcase); ok {
+		r0 = rf(workspace, limit)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Channel)
-		}
+		r0 = ret.Get(0).(db.WorkspaceShowcase)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.Workspace, int) error); ok {
+		r1 = rf(workspace, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetChannelsByTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannelsByTribe'
-type Database_GetChannelsByTribe_Call struct {
+// Database_GetWorkspaceShowcase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceShowcase'
+type Database_GetWorkspaceShowcase_Call struct {
 	*mock.Call
 }
 
-// GetChannelsByTribe is a helper method to define mock.On call
-//   - tribe_uuid string
-func (_e *Database_Expecter) GetChannelsByTribe(tribe_uuid interface{}) *Database_GetChannelsByTribe_Call {
-	return &Database_GetChannelsByTribe_Call{Call: _e.mock.On("GetChannelsByTribe", tribe_uuid)}
+// GetWorkspaceShowcase is a helper method to define mock.On call
+//   - workspace db.Workspace
+//   - limit int
+func (_e *Database_Expecter) GetWorkspaceShowcase(workspace interface{}, limit interface{}) *Database_GetWorkspaceShowcase_Call {
+	return &Database_GetWorkspaceShowcase_Call{Call: _e.mock.On("GetWorkspaceShowcase", workspace, limit)}
 }
 
-func (_c *Database_GetChannelsByTribe_Call) Run(run func(tribe_uuid string)) *Database_GetChannelsByTribe_Call {
+func (_c *Database_GetWorkspaceShowcase_Call) Run(run func(workspace db.Workspace, limit int)) *Database_GetWorkspaceShowcase_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.Workspace), args[1].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetChannelsByTribe_Call) Return(_a0 []db.Channel) *Database_GetChannelsByTribe_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_GetWorkspaceShowcase_Call) Return(_a0 db.WorkspaceShowcase, _a1 error) *Database_GetWorkspaceShowcase_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetChannelsByTribe_Call) RunAndReturn(run func(string) []db.Channel) *Database_GetChannelsByTribe_Call {
+func (_c *Database_GetWorkspaceShowcase_Call) RunAndReturn(run func(db.Workspace, int) (db.WorkspaceShowcase, error)) *Database_GetWorkspaceShowcase_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetConnectionCode provides a mock function with given fields:
-func (_m *Database) GetConnectionCode() db.ConnectionCodesShort {
-	ret := _m.Called()
+// GetWorkspaceStatusBudget provides a mock function with given fields: workspace_uuid
+func (_m *Database) GetWorkspaceStatusBudget(workspace_uuid string) db.StatusBudget {
+	ret := _m.Called(workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetConnectionCode")
+		panic("no return value specified for GetWorkspaceStatusBudget")
 	}
 
-	var r0 db.ConnectionCodesShort
-	if rf, ok := ret.Get(0).(func() db.ConnectionCodesShort); ok {
-		r0 = rf()
+	var r0 db.StatusBudget
+	if rf, ok := ret.Get(0).(func(string) db.StatusBudget); ok {
+		r0 = rf(workspace_uuid)
 	} else {
-		r0 = ret.Get(0).(db.ConnectionCodesShort)
+		r0 = ret.Get(0).(db.StatusBudget)
 	}
 
 	return r0
 }
 
-// Database_GetConnectionCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConnectionCode'
-type Database_GetConnectionCode_Call struct {
+// Database_GetWorkspaceStatusBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceStatusBudget'
+type Database_GetWorkspaceStatusBudget_Call struct {
 	*mock.Call
 }
 
-// GetConnectionCode is a helper method to define mock.On call
-func (_e *Database_Expecter) GetConnectionCode() *Database_GetConnectionCode_Call {
-	return &Database_GetConnectionCode_Call{Call: _e.mock.On("GetConnectionCode")}
+// GetWorkspaceStatusBudget is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceStatusBudget(workspace_uuid interface{}) *Database_GetWorkspaceStatusBudget_Call {
+	return &Database_GetWorkspaceStatusBudget_Call{Call: _e.mock.On("GetWorkspaceStatusBudget", workspace_uuid)}
 }
 
-func (_c *Database_GetConnectionCode_Call) Run(run func()) *Database_GetConnectionCode_Call {
+func (_c *Database_GetWorkspaceStatusBudget_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceStatusBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetConnectionCode_Call) Return(_a0 db.ConnectionCodesShort) *Database_GetConnectionCode_Call {
+func (_c *Database_GetWorkspaceStatusBudget_Call) Return(_a0 db.StatusBudget) *Database_GetWorkspaceStatusBudget_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetConnectionCode_Call) RunAndReturn(run func() db.ConnectionCodesShort) *Database_GetConnectionCode_Call {
+func (_c *Database_GetWorkspaceStatusBudget_Call) RunAndReturn(run func(string) db.StatusBudget) *Database_GetWorkspaceStatusBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCreatedBounties provides a mock function with given fields: r
-func (_m *Database) GetCreatedBounties(r *http.Request) ([]db.NewBounty, error) {
-	ret := _m.Called(r)
+// GetWorkspaceTagByUuid provides a mock function with given fields: workspaceUuid, uuid
+func (_m *Database) GetWorkspaceTagByUuid(workspaceUuid string, uuid string) (db.WorkspaceTag, error) {
+	ret := _m.Called(workspaceUuid, uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCreatedBounties")
+		panic("no return value specified for GetWorkspaceTagByUuid")
 	}
 
-	var r0 []db.NewBounty
+	var r0 db.WorkspaceTag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.NewBounty, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(string, string) (db.WorkspaceTag, error)); ok {
+		return rf(workspaceUuid, uuid)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.NewBounty); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceTag); ok {
+		r0 = rf(workspaceUuid, uuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Get(0).(db.WorkspaceTag)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(workspaceUuid, uuid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3108,263 +13715,305 @@ func (_m *Database) GetCreatedBounties(r *http.Request) ([]db.NewBounty, error)
 	return r0, r1
 }
 
-// Database_GetCreatedBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCreatedBounties'
-type Database_GetCreatedBounties_Call struct {
+// Database_GetWorkspaceTagByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceTagByUuid'
+type Database_GetWorkspaceTagByUuid_Call struct {
 	*mock.Call
 }
 
-// GetCreatedBounties is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetCreatedBounties(r interface{}) *Database_GetCreatedBounties_Call {
-	return &Database_GetCreatedBounties_Call{Call: _e.mock.On("GetCreatedBounties", r)}
+// GetWorkspaceTagByUuid is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceTagByUuid(workspaceUuid interface{}, uuid interface{}) *Database_GetWorkspaceTagByUuid_Call {
+	return &Database_GetWorkspaceTagByUuid_Call{Call: _e.mock.On("GetWorkspaceTagByUuid", workspaceUuid, uuid)}
 }
 
-func (_c *Database_GetCreatedBounties_Call) Run(run func(r *http.Request)) *Database_GetCreatedBounties_Call {
+func (_c *Database_GetWorkspaceTagByUuid_Call) Run(run func(workspaceUuid string, uuid string)) *Database_GetWorkspaceTagByUuid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetCreatedBounties_Call) Return(_a0 []db.NewBounty, _a1 error) *Database_GetCreatedBounties_Call {
+func (_c *Database_GetWorkspaceTagByUuid_Call) Return(_a0 db.WorkspaceTag, _a1 error) *Database_GetWorkspaceTagByUuid_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetCreatedBounties_Call) RunAndReturn(run func(*http.Request) ([]db.NewBounty, error)) *Database_GetCreatedBounties_Call {
+func (_c *Database_GetWorkspaceTagByUuid_Call) RunAndReturn(run func(string, string) (db.WorkspaceTag, error)) *Database_GetWorkspaceTagByUuid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeatureByUuid provides a mock function with given fields: uuid
-func (_m *Database) GetFeatureByUuid(uuid string) db.WorkspaceFeatures {
-	ret := _m.Called(uuid)
+// GetWorkspaceTags provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceTags(workspaceUuid string) []db.WorkspaceTag {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeatureByUuid")
+		panic("no return value specified for GetWorkspaceTags")
 	}
 
-	var r0 db.WorkspaceFeatures
-	if rf, ok := ret.Get(0).(func(string) db.WorkspaceFeatures); ok {
-		r0 = rf(uuid)
+	var r0 []db.WorkspaceTag
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceTag); ok {
+		r0 = rf(workspaceUuid)
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceFeatures)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceTag)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetFeatureByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureByUuid'
-type Database_GetFeatureByUuid_Call struct {
+// Database_GetWorkspaceTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceTags'
+type Database_GetWorkspaceTags_Call struct {
 	*mock.Call
 }
 
-// GetFeatureByUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetFeatureByUuid(uuid interface{}) *Database_GetFeatureByUuid_Call {
-	return &Database_GetFeatureByUuid_Call{Call: _e.mock.On("GetFeatureByUuid", uuid)}
+// GetWorkspaceTags is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceTags(workspaceUuid interface{}) *Database_GetWorkspaceTags_Call {
+	return &Database_GetWorkspaceTags_Call{Call: _e.mock.On("GetWorkspaceTags", workspaceUuid)}
 }
 
-func (_c *Database_GetFeatureByUuid_Call) Run(run func(uuid string)) *Database_GetFeatureByUuid_Call {
+func (_c *Database_GetWorkspaceTags_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceTags_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeatureByUuid_Call) Return(_a0 db.WorkspaceFeatures) *Database_GetFeatureByUuid_Call {
+func (_c *Database_GetWorkspaceTags_Call) Return(_a0 []db.WorkspaceTag) *Database_GetWorkspaceTags_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetFeatureByUuid_Call) RunAndReturn(run func(string) db.WorkspaceFeatures) *Database_GetFeatureByUuid_Call {
+func (_c *Database_GetWorkspaceTags_Call) RunAndReturn(run func(string) []db.WorkspaceTag) *Database_GetWorkspaceTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeaturePhaseByUuid provides a mock function with given fields: featureUuid, phaseUuid
-func (_m *Database) GetFeaturePhaseByUuid(featureUuid string, phaseUuid string) (db.FeaturePhase, error) {
-	ret := _m.Called(featureUuid, phaseUuid)
+// GetWorkspaceUsage provides a mock function with given fields: workspaceUuid, from, to
+func (_m *Database) GetWorkspaceUsage(workspaceUuid string, from string, to string) []db.WorkspaceUsageDaily {
+	ret := _m.Called(workspaceUuid, from, to)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeaturePhaseByUuid")
-	}
-
-	var r0 db.FeaturePhase
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (db.FeaturePhase, error)); ok {
-		return rf(featureUuid, phaseUuid)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) db.FeaturePhase); ok {
-		r0 = rf(featureUuid, phaseUuid)
-	} else {
-		r0 = ret.Get(0).(db.FeaturePhase)
+		panic("no return value specified for GetWorkspaceUsage")
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(featureUuid, phaseUuid)
+	var r0 []db.WorkspaceUsageDaily
+	if rf, ok := ret.Get(0).(func(string, string, string) []db.WorkspaceUsageDaily); ok {
+		r0 = rf(workspaceUuid, from, to)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.WorkspaceUsageDaily)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetFeaturePhaseByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturePhaseByUuid'
-type Database_GetFeaturePhaseByUuid_Call struct {
+// Database_GetWorkspaceUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsage'
+type Database_GetWorkspaceUsage_Call struct {
 	*mock.Call
 }
 
-// GetFeaturePhaseByUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - phaseUuid string
-func (_e *Database_Expecter) GetFeaturePhaseByUuid(featureUuid interface{}, phaseUuid interface{}) *Database_GetFeaturePhaseByUuid_Call {
-	return &Database_GetFeaturePhaseByUuid_Call{Call: _e.mock.On("GetFeaturePhaseByUuid", featureUuid, phaseUuid)}
+// GetWorkspaceUsage is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - from string
+//   - to string
+func (_e *Database_Expecter) GetWorkspaceUsage(workspaceUuid interface{}, from interface{}, to interface{}) *Database_GetWorkspaceUsage_Call {
+	return &Database_GetWorkspaceUsage_Call{Call: _e.mock.On("GetWorkspaceUsage", workspaceUuid, from, to)}
 }
 
-func (_c *Database_GetFeaturePhaseByUuid_Call) Run(run func(featureUuid string, phaseUuid string)) *Database_GetFeaturePhaseByUuid_Call {
+func (_c *Database_GetWorkspaceUsage_Call) Run(run func(workspaceUuid string, from string, to string)) *Database_GetWorkspaceUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeaturePhaseByUuid_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_GetFeaturePhaseByUuid_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetWorkspaceUsage_Call) Return(_a0 []db.WorkspaceUsageDaily) *Database_GetWorkspaceUsage_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetFeaturePhaseByUuid_Call) RunAndReturn(run func(string, string) (db.FeaturePhase, error)) *Database_GetFeaturePhaseByUuid_Call {
+func (_c *Database_GetWorkspaceUsage_Call) RunAndReturn(run func(string, string, string) []db.WorkspaceUsageDaily) *Database_GetWorkspaceUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeaturePhasesBountiesCount provides a mock function with given fields: bountyType, phaseUuid
-func (_m *Database) GetFeaturePhasesBountiesCount(bountyType string, phaseUuid string) int64 {
-	ret := _m.Called(bountyType, phaseUuid)
+// GetWorkspaceUser provides a mock function with given fields: pubkey, workspace_uuid
+func (_m *Database) GetWorkspaceUser(pubkey string, workspace_uuid string) db.WorkspaceUsers {
+	ret := _m.Called(pubkey, workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeaturePhasesBountiesCount")
+		panic("no return value specified for GetWorkspaceUser")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = rf(bountyType, phaseUuid)
+	var r0 db.WorkspaceUsers
+	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceUsers); ok {
+		r0 = rf(pubkey, workspace_uuid)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.WorkspaceUsers)
 	}
 
 	return r0
 }
 
-// Database_GetFeaturePhasesBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturePhasesBountiesCount'
-type Database_GetFeaturePhasesBountiesCount_Call struct {
+// Database_GetWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUser'
+type Database_GetWorkspaceUser_Call struct {
 	*mock.Call
 }
 
-// GetFeaturePhasesBountiesCount is a helper method to define mock.On call
-//   - bountyType string
-//   - phaseUuid string
-func (_e *Database_Expecter) GetFeaturePhasesBountiesCount(bountyType interface{}, phaseUuid interface{}) *Database_GetFeaturePhasesBountiesCount_Call {
-	return &Database_GetFeaturePhasesBountiesCount_Call{Call: _e.mock.On("GetFeaturePhasesBountiesCount", bountyType, phaseUuid)}
+// GetWorkspaceUser is a helper method to define mock.On call
+//   - pubkey string
+//   - workspace_uuid string
+func (_e *Database_Expecter) GetWorkspaceUser(pubkey interface{}, workspace_uuid interface{}) *Database_GetWorkspaceUser_Call {
+	return &Database_GetWorkspaceUser_Call{Call: _e.mock.On("GetWorkspaceUser", pubkey, workspace_uuid)}
 }
 
-func (_c *Database_GetFeaturePhasesBountiesCount_Call) Run(run func(bountyType string, phaseUuid string)) *Database_GetFeaturePhasesBountiesCount_Call {
+func (_c *Database_GetWorkspaceUser_Call) Run(run func(pubkey string, workspace_uuid string)) *Database_GetWorkspaceUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeaturePhasesBountiesCount_Call) Return(_a0 int64) *Database_GetFeaturePhasesBountiesCount_Call {
+func (_c *Database_GetWorkspaceUser_Call) Return(_a0 db.WorkspaceUsers) *Database_GetWorkspaceUser_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetFeaturePhasesBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetFeaturePhasesBountiesCount_Call {
+func (_c *Database_GetWorkspaceUser_Call) RunAndReturn(run func(string, string) db.WorkspaceUsers) *Database_GetWorkspaceUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeatureStoriesByFeatureUuid provides a mock function with given fields: featureUuid
-func (_m *Database) GetFeatureStoriesByFeatureUuid(featureUuid string) ([]db.FeatureStory, error) {
-	ret := _m.Called(featureUuid)
+// GetWorkspaceUsers provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceUsers(uuid string) ([]db.WorkspaceUsersData, error) {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeatureStoriesByFeatureUuid")
+		panic("no return value specified for GetWorkspaceUsers")
 	}
 
-	var r0 []db.FeatureStory
+	var r0 []db.WorkspaceUsersData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]db.FeatureStory, error)); ok {
-		return rf(featureUuid)
+	if rf, ok := ret.Get(0).(func(string) ([]db.WorkspaceUsersData, error)); ok {
+		return rf(uuid)
 	}
-	if rf, ok := ret.Get(0).(func(string) []db.FeatureStory); ok {
-		r0 = rf(featureUuid)
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceUsersData); ok {
+		r0 = rf(uuid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.FeatureStory)
+			r0 = ret.Get(0).([]db.WorkspaceUsersData)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(featureUuid)
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetWorkspaceUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsers'
+type Database_GetWorkspaceUsers_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaceUsers is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceUsers(uuid interface{}) *Database_GetWorkspaceUsers_Call {
+	return &Database_GetWorkspaceUsers_Call{Call: _e.mock.On("GetWorkspaceUsers", uuid)}
+}
+
+func (_c *Database_GetWorkspaceUsers_Call) Run(run func(uuid string)) *Database_GetWorkspaceUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetWorkspaceUsers_Call) Return(_a0 []db.WorkspaceUsersData, _a1 error) *Database_GetWorkspaceUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetWorkspaceUsers_Call) RunAndReturn(run func(string) ([]db.WorkspaceUsersData, error)) *Database_GetWorkspaceUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaceUsersCount provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceUsersCount(uuid string) int64 {
+	ret := _m.Called(uuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaceUsersCount")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(uuid)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetFeatureStoriesByFeatureUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoriesByFeatureUuid'
-type Database_GetFeatureStoriesByFeatureUuid_Call struct {
+// Database_GetWorkspaceUsersCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsersCount'
+type Database_GetWorkspaceUsersCount_Call struct {
 	*mock.Call
 }
 
-// GetFeatureStoriesByFeatureUuid is a helper method to define mock.On call
-//   - featureUuid string
-func (_e *Database_Expecter) GetFeatureStoriesByFeatureUuid(featureUuid interface{}) *Database_GetFeatureStoriesByFeatureUuid_Call {
-	return &Database_GetFeatureStoriesByFeatureUuid_Call{Call: _e.mock.On("GetFeatureStoriesByFeatureUuid", featureUuid)}
+// GetWorkspaceUsersCount is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceUsersCount(uuid interface{}) *Database_GetWorkspaceUsersCount_Call {
+	return &Database_GetWorkspaceUsersCount_Call{Call: _e.mock.On("GetWorkspaceUsersCount", uuid)}
 }
 
-func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) Run(run func(featureUuid string)) *Database_GetFeatureStoriesByFeatureUuid_Call {
+func (_c *Database_GetWorkspaceUsersCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceUsersCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) Return(_a0 []db.FeatureStory, _a1 error) *Database_GetFeatureStoriesByFeatureUuid_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_GetWorkspaceUsersCount_Call) Return(_a0 int64) *Database_GetWorkspaceUsersCount_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetFeatureStoriesByFeatureUuid_Call) RunAndReturn(run func(string) ([]db.FeatureStory, error)) *Database_GetFeatureStoriesByFeatureUuid_Call {
+func (_c *Database_GetWorkspaceUsersCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceUsersCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeatureStoryByUuid provides a mock function with given fields: featureUuid, storyUuid
-func (_m *Database) GetFeatureStoryByUuid(featureUuid string, storyUuid string) (db.FeatureStory, error) {
-	ret := _m.Called(featureUuid, storyUuid)
+// GetWorkspaceWebhookByUuid provides a mock function with given fields: uuid
+func (_m *Database) GetWorkspaceWebhookByUuid(uuid string) (db.WorkspaceWebhook, error) {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeatureStoryByUuid")
+		panic("no return value specified for GetWorkspaceWebhookByUuid")
 	}
 
-	var r0 db.FeatureStory
+	var r0 db.WorkspaceWebhook
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (db.FeatureStory, error)); ok {
-		return rf(featureUuid, storyUuid)
+	if rf, ok := ret.Get(0).(func(string) (db.WorkspaceWebhook, error)); ok {
+		return rf(uuid)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) db.FeatureStory); ok {
-		r0 = rf(featureUuid, storyUuid)
+	if rf, ok := ret.Get(0).(func(string) db.WorkspaceWebhook); ok {
+		r0 = rf(uuid)
 	} else {
-		r0 = ret.Get(0).(db.FeatureStory)
+		r0 = ret.Get(0).(db.WorkspaceWebhook)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(featureUuid, storyUuid)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3372,643 +14021,709 @@ func (_m *Database) GetFeatureStoryByUuid(featureUuid string, storyUuid string)
 	return r0, r1
 }
 
-// Database_GetFeatureStoryByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeatureStoryByUuid'
-type Database_GetFeatureStoryByUuid_Call struct {
+// Database_GetWorkspaceWebhookByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceWebhookByUuid'
+type Database_GetWorkspaceWebhookByUuid_Call struct {
 	*mock.Call
 }
 
-// GetFeatureStoryByUuid is a helper method to define mock.On call
-//   - featureUuid string
-//   - storyUuid string
-func (_e *Database_Expecter) GetFeatureStoryByUuid(featureUuid interface{}, storyUuid interface{}) *Database_GetFeatureStoryByUuid_Call {
-	return &Database_GetFeatureStoryByUuid_Call{Call: _e.mock.On("GetFeatureStoryByUuid", featureUuid, storyUuid)}
+// GetWorkspaceWebhookByUuid is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) GetWorkspaceWebhookByUuid(uuid interface{}) *Database_GetWorkspaceWebhookByUuid_Call {
+	return &Database_GetWorkspaceWebhookByUuid_Call{Call: _e.mock.On("GetWorkspaceWebhookByUuid", uuid)}
 }
 
-func (_c *Database_GetFeatureStoryByUuid_Call) Run(run func(featureUuid string, storyUuid string)) *Database_GetFeatureStoryByUuid_Call {
+func (_c *Database_GetWorkspaceWebhookByUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceWebhookByUuid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeatureStoryByUuid_Call) Return(_a0 db.FeatureStory, _a1 error) *Database_GetFeatureStoryByUuid_Call {
+func (_c *Database_GetWorkspaceWebhookByUuid_Call) Return(_a0 db.WorkspaceWebhook, _a1 error) *Database_GetWorkspaceWebhookByUuid_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetFeatureStoryByUuid_Call) RunAndReturn(run func(string, string) (db.FeatureStory, error)) *Database_GetFeatureStoryByUuid_Call {
+func (_c *Database_GetWorkspaceWebhookByUuid_Call) RunAndReturn(run func(string) (db.WorkspaceWebhook, error)) *Database_GetWorkspaceWebhookByUuid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFeaturesByWorkspaceUuid provides a mock function with given fields: uuid, r
-func (_m *Database) GetFeaturesByWorkspaceUuid(uuid string, r *http.Request) []db.WorkspaceFeatures {
-	ret := _m.Called(uuid, r)
+// GetWorkspaceWebhooks provides a mock function with given fields: workspaceUuid
+func (_m *Database) GetWorkspaceWebhooks(workspaceUuid string) []db.WorkspaceWebhook {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFeaturesByWorkspaceUuid")
+		panic("no return value specified for GetWorkspaceWebhooks")
 	}
 
-	var r0 []db.WorkspaceFeatures
-	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.WorkspaceFeatures); ok {
-		r0 = rf(uuid, r)
+	var r0 []db.WorkspaceWebhook
+	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceWebhook); ok {
+		r0 = rf(workspaceUuid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceFeatures)
+			r0 = ret.Get(0).([]db.WorkspaceWebhook)
 		}
 	}
 
 	return r0
 }
 
-// Database_GetFeaturesByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeaturesByWorkspaceUuid'
-type Database_GetFeaturesByWorkspaceUuid_Call struct {
+// Database_GetWorkspaceWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceWebhooks'
+type Database_GetWorkspaceWebhooks_Call struct {
 	*mock.Call
 }
 
-// GetFeaturesByWorkspaceUuid is a helper method to define mock.On call
-//   - uuid string
-//   - r *http.Request
-func (_e *Database_Expecter) GetFeaturesByWorkspaceUuid(uuid interface{}, r interface{}) *Database_GetFeaturesByWorkspaceUuid_Call {
-	return &Database_GetFeaturesByWorkspaceUuid_Call{Call: _e.mock.On("GetFeaturesByWorkspaceUuid", uuid, r)}
+// GetWorkspaceWebhooks is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) GetWorkspaceWebhooks(workspaceUuid interface{}) *Database_GetWorkspaceWebhooks_Call {
+	return &Database_GetWorkspaceWebhooks_Call{Call: _e.mock.On("GetWorkspaceWebhooks", workspaceUuid)}
 }
 
-func (_c *Database_GetFeaturesByWorkspaceUuid_Call) Run(run func(uuid string, r *http.Request)) *Database_GetFeaturesByWorkspaceUuid_Call {
+func (_c *Database_GetWorkspaceWebhooks_Call) Run(run func(workspaceUuid string)) *Database_GetWorkspaceWebhooks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetFeaturesByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuid_Call {
+func (_c *Database_GetWorkspaceWebhooks_Call) Return(_a0 []db.WorkspaceWebhook) *Database_GetWorkspaceWebhooks_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetFeaturesByWorkspaceUuid_Call) RunAndReturn(run func(string, *http.Request) []db.WorkspaceFeatures) *Database_GetFeaturesByWorkspaceUuid_Call {
+func (_c *Database_GetWorkspaceWebhooks_Call) RunAndReturn(run func(string) []db.WorkspaceWebhook) *Database_GetWorkspaceWebhooks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFilterStatusCount provides a mock function with given fields:
-func (_m *Database) GetFilterStatusCount() db.FilterStattuCount {
-	ret := _m.Called()
+// GetWorkspaces provides a mock function with given fields: r
+func (_m *Database) GetWorkspaces(r *http.Request) []db.Workspace {
+	ret := _m.Called(r)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFilterStatusCount")
+		panic("no return value specified for GetWorkspaces")
 	}
 
-	var r0 db.FilterStattuCount
-	if rf, ok := ret.Get(0).(func() db.FilterStattuCount); ok {
-		r0 = rf()
+	var r0 []db.Workspace
+	if rf, ok := ret.Get(0).(func(*http.Request) []db.Workspace); ok {
+		r0 = rf(r)
 	} else {
-		r0 = ret.Get(0).(db.FilterStattuCount)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Workspace)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetFilterStatusCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFilterStatusCount'
-type Database_GetFilterStatusCount_Call struct {
+// Database_GetWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaces'
+type Database_GetWorkspaces_Call struct {
 	*mock.Call
 }
 
-// GetFilterStatusCount is a helper method to define mock.On call
-func (_e *Database_Expecter) GetFilterStatusCount() *Database_GetFilterStatusCount_Call {
-	return &Database_GetFilterStatusCount_Call{Call: _e.mock.On("GetFilterStatusCount")}
+// GetWorkspaces is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *Database_Expecter) GetWorkspaces(r interface{}) *Database_GetWorkspaces_Call {
+	return &Database_GetWorkspaces_Call{Call: _e.mock.On("GetWorkspaces", r)}
 }
 
-func (_c *Database_GetFilterStatusCount_Call) Run(run func()) *Database_GetFilterStatusCount_Call {
+func (_c *Database_GetWorkspaces_Call) Run(run func(r *http.Request)) *Database_GetWorkspaces_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(*http.Request))
 	})
 	return _c
 }
 
-func (_c *Database_GetFilterStatusCount_Call) Return(_a0 db.FilterStattuCount) *Database_GetFilterStatusCount_Call {
+func (_c *Database_GetWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetWorkspaces_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetFilterStatusCount_Call) RunAndReturn(run func() db.FilterStattuCount) *Database_GetFilterStatusCount_Call {
+func (_c *Database_GetWorkspaces_Call) RunAndReturn(run func(*http.Request) []db.Workspace) *Database_GetWorkspaces_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFirstTribeByFeedURL provides a mock function with given fields: feedURL
-func (_m *Database) GetFirstTribeByFeedURL(feedURL string) db.Tribe {
-	ret := _m.Called(feedURL)
+// GetWorkspacesCount provides a mock function with given fields:
+func (_m *Database) GetWorkspacesCount() int64 {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFirstTribeByFeedURL")
+		panic("no return value specified for GetWorkspacesCount")
 	}
 
-	var r0 db.Tribe
-	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
-		r0 = rf(feedURL)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		r0 = ret.Get(0).(int64)
 	}
 
 	return r0
 }
 
-// Database_GetFirstTribeByFeedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirstTribeByFeedURL'
-type Database_GetFirstTribeByFeedURL_Call struct {
+// Database_GetWorkspacesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspacesCount'
+type Database_GetWorkspacesCount_Call struct {
 	*mock.Call
 }
 
-// GetFirstTribeByFeedURL is a helper method to define mock.On call
-//   - feedURL string
-func (_e *Database_Expecter) GetFirstTribeByFeedURL(feedURL interface{}) *Database_GetFirstTribeByFeedURL_Call {
-	return &Database_GetFirstTribeByFeedURL_Call{Call: _e.mock.On("GetFirstTribeByFeedURL", feedURL)}
+// GetWorkspacesCount is a helper method to define mock.On call
+func (_e *Database_Expecter) GetWorkspacesCount() *Database_GetWorkspacesCount_Call {
+	return &Database_GetWorkspacesCount_Call{Call: _e.mock.On("GetWorkspacesCount")}
 }
 
-func (_c *Database_GetFirstTribeByFeedURL_Call) Run(run func(feedURL string)) *Database_GetFirstTribeByFeedURL_Call {
+func (_c *Database_GetWorkspacesCount_Call) Run(run func()) *Database_GetWorkspacesCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_GetFirstTribeByFeedURL_Call) Return(_a0 db.Tribe) *Database_GetFirstTribeByFeedURL_Call {
+func (_c *Database_GetWorkspacesCount_Call) Return(_a0 int64) *Database_GetWorkspacesCount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetFirstTribeByFeedURL_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetFirstTribeByFeedURL_Call {
+func (_c *Database_GetWorkspacesCount_Call) RunAndReturn(run func() int64) *Database_GetWorkspacesCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetInvoice provides a mock function with given fields: payment_request
-func (_m *Database) GetInvoice(payment_request string) db.NewInvoiceList {
-	ret := _m.Called(payment_request)
+// HasUnsettledBountyPayment provides a mock function with given fields: bountyId
+func (_m *Database) HasUnsettledBountyPayment(bountyId uint) bool {
+	ret := _m.Called(bountyId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetInvoice")
+		panic("no return value specified for HasUnsettledBountyPayment")
 	}
 
-	var r0 db.NewInvoiceList
-	if rf, ok := ret.Get(0).(func(string) db.NewInvoiceList); ok {
-		r0 = rf(payment_request)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint) bool); ok {
+		r0 = rf(bountyId)
 	} else {
-		r0 = ret.Get(0).(db.NewInvoiceList)
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// Database_GetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvoice'
-type Database_GetInvoice_Call struct {
+// Database_HasUnsettledBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasUnsettledBountyPayment'
+type Database_HasUnsettledBountyPayment_Call struct {
 	*mock.Call
 }
 
-// GetInvoice is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) GetInvoice(payment_request interface{}) *Database_GetInvoice_Call {
-	return &Database_GetInvoice_Call{Call: _e.mock.On("GetInvoice", payment_request)}
+// HasUnsettledBountyPayment is a helper method to define mock.On call
+//   - bountyId uint
+func (_e *Database_Expecter) HasUnsettledBountyPayment(bountyId interface{}) *Database_HasUnsettledBountyPayment_Call {
+	return &Database_HasUnsettledBountyPayment_Call{Call: _e.mock.On("HasUnsettledBountyPayment", bountyId)}
 }
 
-func (_c *Database_GetInvoice_Call) Run(run func(payment_request string)) *Database_GetInvoice_Call {
+func (_c *Database_HasUnsettledBountyPayment_Call) Run(run func(bountyId uint)) *Database_HasUnsettledBountyPayment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetInvoice_Call) Return(_a0 db.NewInvoiceList) *Database_GetInvoice_Call {
+func (_c *Database_HasUnsettledBountyPayment_Call) Return(_a0 bool) *Database_HasUnsettledBountyPayment_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetInvoice_Call) RunAndReturn(run func(string) db.NewInvoiceList) *Database_GetInvoice_Call {
+func (_c *Database_HasUnsettledBountyPayment_Call) RunAndReturn(run func(uint) bool) *Database_HasUnsettledBountyPayment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLeaderBoard provides a mock function with given fields: uuid
-func (_m *Database) GetLeaderBoard(uuid string) []db.LeaderBoard {
-	ret := _m.Called(uuid)
+// ImportJiraBounties provides a mock function with given fields: workspaceUuid, imports
+func (_m *Database) ImportJiraBounties(workspaceUuid string, imports []db.JiraBountyImport) (db.JiraImportResult, error) {
+	ret := _m.Called(workspaceUuid, imports)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLeaderBoard")
+		panic("no return value specified for ImportJiraBounties")
 	}
 
-	var r0 []db.LeaderBoard
-	if rf, ok := ret.Get(0).(func(string) []db.LeaderBoard); ok {
-		r0 = rf(uuid)
+	var r0 db.JiraImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []db.JiraBountyImport) (db.JiraImportResult, error)); ok {
+		return rf(workspaceUuid, imports)
+	}
+	if rf, ok := ret.Get(0).(func(string, []db.JiraBountyImport) db.JiraImportResult); ok {
+		r0 = rf(workspaceUuid, imports)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.LeaderBoard)
-		}
+		r0 = ret.Get(0).(db.JiraImportResult)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, []db.JiraBountyImport) error); ok {
+		r1 = rf(workspaceUuid, imports)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetLeaderBoard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderBoard'
-type Database_GetLeaderBoard_Call struct {
+// Database_ImportJiraBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportJiraBounties'
+type Database_ImportJiraBounties_Call struct {
 	*mock.Call
 }
 
-// GetLeaderBoard is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetLeaderBoard(uuid interface{}) *Database_GetLeaderBoard_Call {
-	return &Database_GetLeaderBoard_Call{Call: _e.mock.On("GetLeaderBoard", uuid)}
+// ImportJiraBounties is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - imports []db.JiraBountyImport
+func (_e *Database_Expecter) ImportJiraBounties(workspaceUuid interface{}, imports interface{}) *Database_ImportJiraBounties_Call {
+	return &Database_ImportJiraBounties_Call{Call: _e.mock.On("ImportJiraBounties", workspaceUuid, imports)}
 }
 
-func (_c *Database_GetLeaderBoard_Call) Run(run func(uuid string)) *Database_GetLeaderBoard_Call {
+func (_c *Database_ImportJiraBounties_Call) Run(run func(workspaceUuid string, imports []db.JiraBountyImport)) *Database_ImportJiraBounties_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].([]db.JiraBountyImport))
 	})
 	return _c
 }
 
-func (_c *Database_GetLeaderBoard_Call) Return(_a0 []db.LeaderBoard) *Database_GetLeaderBoard_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ImportJiraBounties_Call) Return(_a0 db.JiraImportResult, _a1 error) *Database_ImportJiraBounties_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetLeaderBoard_Call) RunAndReturn(run func(string) []db.LeaderBoard) *Database_GetLeaderBoard_Call {
+func (_c *Database_ImportJiraBounties_Call) RunAndReturn(run func(string, []db.JiraBountyImport) (db.JiraImportResult, error)) *Database_ImportJiraBounties_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLeaderBoardByUuidAndAlias provides a mock function with given fields: uuid, alias
-func (_m *Database) GetLeaderBoardByUuidAndAlias(uuid string, alias string) db.LeaderBoard {
-	ret := _m.Called(uuid, alias)
+// ImportWorkspace provides a mock function with given fields: archive
+func (_m *Database) ImportWorkspace(archive io.Reader) (db.Workspace, map[string]int, error) {
+	ret := _m.Called(archive)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLeaderBoardByUuidAndAlias")
+		panic("no return value specified for ImportWorkspace")
 	}
 
-	var r0 db.LeaderBoard
-	if rf, ok := ret.Get(0).(func(string, string) db.LeaderBoard); ok {
-		r0 = rf(uuid, alias)
+	var r0 db.Workspace
+	var r1 map[string]int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(io.Reader) (db.Workspace, map[string]int, error)); ok {
+		return rf(archive)
+	}
+	if rf, ok := ret.Get(0).(func(io.Reader) db.Workspace); ok {
+		r0 = rf(archive)
 	} else {
-		r0 = ret.Get(0).(db.LeaderBoard)
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(io.Reader) map[string]int); ok {
+		r1 = rf(archive)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(io.Reader) error); ok {
+		r2 = rf(archive)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// Database_GetLeaderBoardByUuidAndAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeaderBoardByUuidAndAlias'
-type Database_GetLeaderBoardByUuidAndAlias_Call struct {
+// Database_ImportWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportWorkspace'
+type Database_ImportWorkspace_Call struct {
 	*mock.Call
 }
 
-// GetLeaderBoardByUuidAndAlias is a helper method to define mock.On call
-//   - uuid string
-//   - alias string
-func (_e *Database_Expecter) GetLeaderBoardByUuidAndAlias(uuid interface{}, alias interface{}) *Database_GetLeaderBoardByUuidAndAlias_Call {
-	return &Database_GetLeaderBoardByUuidAndAlias_Call{Call: _e.mock.On("GetLeaderBoardByUuidAndAlias", uuid, alias)}
+// ImportWorkspace is a helper method to define mock.On call
+//   - archive io.Reader
+func (_e *Database_Expecter) ImportWorkspace(archive interface{}) *Database_ImportWorkspace_Call {
+	return &Database_ImportWorkspace_Call{Call: _e.mock.On("ImportWorkspace", archive)}
 }
 
-func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) Run(run func(uuid string, alias string)) *Database_GetLeaderBoardByUuidAndAlias_Call {
+func (_c *Database_ImportWorkspace_Call) Run(run func(archive io.Reader)) *Database_ImportWorkspace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(io.Reader))
 	})
 	return _c
 }
 
-func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) Return(_a0 db.LeaderBoard) *Database_GetLeaderBoardByUuidAndAlias_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ImportWorkspace_Call) Return(_a0 db.Workspace, _a1 map[string]int, _a2 error) *Database_ImportWorkspace_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *Database_GetLeaderBoardByUuidAndAlias_Call) RunAndReturn(run func(string, string) db.LeaderBoard) *Database_GetLeaderBoardByUuidAndAlias_Call {
+func (_c *Database_ImportWorkspace_Call) RunAndReturn(run func(io.Reader) (db.Workspace, map[string]int, error)) *Database_ImportWorkspace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedBots provides a mock function with given fields: r
-func (_m *Database) GetListedBots(r *http.Request) []db.Bot {
-	ret := _m.Called(r)
+// IsolateWorkspace provides a mock function with given fields: workspaceUuid
+func (_m *Database) IsolateWorkspace(workspaceUuid string) (db.Workspace, error) {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedBots")
+		panic("no return value specified for IsolateWorkspace")
 	}
 
-	var r0 []db.Bot
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Bot); ok {
-		r0 = rf(r)
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Workspace, error)); ok {
+		return rf(workspaceUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
+		r0 = rf(workspaceUuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Bot)
-		}
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(workspaceUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetListedBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedBots'
-type Database_GetListedBots_Call struct {
+// Database_IsolateWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsolateWorkspace'
+type Database_IsolateWorkspace_Call struct {
 	*mock.Call
 }
 
-// GetListedBots is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedBots(r interface{}) *Database_GetListedBots_Call {
-	return &Database_GetListedBots_Call{Call: _e.mock.On("GetListedBots", r)}
+// IsolateWorkspace is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) IsolateWorkspace(workspaceUuid interface{}) *Database_IsolateWorkspace_Call {
+	return &Database_IsolateWorkspace_Call{Call: _e.mock.On("IsolateWorkspace", workspaceUuid)}
 }
 
-func (_c *Database_GetListedBots_Call) Run(run func(r *http.Request)) *Database_GetListedBots_Call {
+func (_c *Database_IsolateWorkspace_Call) Run(run func(workspaceUuid string)) *Database_IsolateWorkspace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedBots_Call) Return(_a0 []db.Bot) *Database_GetListedBots_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_IsolateWorkspace_Call) Return(_a0 db.Workspace, _a1 error) *Database_IsolateWorkspace_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetListedBots_Call) RunAndReturn(run func(*http.Request) []db.Bot) *Database_GetListedBots_Call {
+func (_c *Database_IsolateWorkspace_Call) RunAndReturn(run func(string) (db.Workspace, error)) *Database_IsolateWorkspace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedOffers provides a mock function with given fields: r
-func (_m *Database) GetListedOffers(r *http.Request) ([]db.PeopleExtra, error) {
-	ret := _m.Called(r)
+// ListPublicBounties provides a mock function with given fields: filter
+func (_m *Database) ListPublicBounties(filter db.ListFilter) ([]db.NewBounty, int64, error) {
+	ret := _m.Called(filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedOffers")
+		panic("no return value specified for ListPublicBounties")
 	}
 
-	var r0 []db.PeopleExtra
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.PeopleExtra, error)); ok {
-		return rf(r)
+	var r0 []db.NewBounty
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(db.ListFilter) ([]db.NewBounty, int64, error)); ok {
+		return rf(filter)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.PeopleExtra); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(db.ListFilter) []db.NewBounty); ok {
+		r0 = rf(filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.PeopleExtra)
+			r0 = ret.Get(0).([]db.NewBounty)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(db.ListFilter) int64); ok {
+		r1 = rf(filter)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(db.ListFilter) error); ok {
+		r2 = rf(filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// Database_GetListedOffers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedOffers'
-type Database_GetListedOffers_Call struct {
+// Database_ListPublicBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublicBounties'
+type Database_ListPublicBounties_Call struct {
 	*mock.Call
 }
 
-// GetListedOffers is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedOffers(r interface{}) *Database_GetListedOffers_Call {
-	return &Database_GetListedOffers_Call{Call: _e.mock.On("GetListedOffers", r)}
+// ListPublicBounties is a helper method to define mock.On call
+//   - filter db.ListFilter
+func (_e *Database_Expecter) ListPublicBounties(filter interface{}) *Database_ListPublicBounties_Call {
+	return &Database_ListPublicBounties_Call{Call: _e.mock.On("ListPublicBounties", filter)}
 }
 
-func (_c *Database_GetListedOffers_Call) Run(run func(r *http.Request)) *Database_GetListedOffers_Call {
+func (_c *Database_ListPublicBounties_Call) Run(run func(filter db.ListFilter)) *Database_ListPublicBounties_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.ListFilter))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedOffers_Call) Return(_a0 []db.PeopleExtra, _a1 error) *Database_GetListedOffers_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_ListPublicBounties_Call) Return(_a0 []db.NewBounty, _a1 int64, _a2 error) *Database_ListPublicBounties_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *Database_GetListedOffers_Call) RunAndReturn(run func(*http.Request) ([]db.PeopleExtra, error)) *Database_GetListedOffers_Call {
+func (_c *Database_ListPublicBounties_Call) RunAndReturn(run func(db.ListFilter) ([]db.NewBounty, int64, error)) *Database_ListPublicBounties_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedPeople provides a mock function with given fields: r
-func (_m *Database) GetListedPeople(r *http.Request) []db.Person {
-	ret := _m.Called(r)
+// ListPublicPeople provides a mock function with given fields: filter
+func (_m *Database) ListPublicPeople(filter db.ListFilter) ([]db.PersonInShort, int64, error) {
+	ret := _m.Called(filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedPeople")
+		panic("no return value specified for ListPublicPeople")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Person); ok {
-		r0 = rf(r)
+	var r0 []db.PersonInShort
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(db.ListFilter) ([]db.PersonInShort, int64, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(db.ListFilter) []db.PersonInShort); ok {
+		r0 = rf(filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
+			r0 = ret.Get(0).([]db.PersonInShort)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.ListFilter) int64); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(db.ListFilter) error); ok {
+		r2 = rf(filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// Database_GetListedPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPeople'
-type Database_GetListedPeople_Call struct {
+// Database_ListPublicPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublicPeople'
+type Database_ListPublicPeople_Call struct {
 	*mock.Call
 }
 
-// GetListedPeople is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedPeople(r interface{}) *Database_GetListedPeople_Call {
-	return &Database_GetListedPeople_Call{Call: _e.mock.On("GetListedPeople", r)}
+// ListPublicPeople is a helper method to define mock.On call
+//   - filter db.ListFilter
+func (_e *Database_Expecter) ListPublicPeople(filter interface{}) *Database_ListPublicPeople_Call {
+	return &Database_ListPublicPeople_Call{Call: _e.mock.On("ListPublicPeople", filter)}
 }
 
-func (_c *Database_GetListedPeople_Call) Run(run func(r *http.Request)) *Database_GetListedPeople_Call {
+func (_c *Database_ListPublicPeople_Call) Run(run func(filter db.ListFilter)) *Database_ListPublicPeople_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.ListFilter))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedPeople_Call) Return(_a0 []db.Person) *Database_GetListedPeople_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ListPublicPeople_Call) Return(_a0 []db.PersonInShort, _a1 int64, _a2 error) *Database_ListPublicPeople_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *Database_GetListedPeople_Call) RunAndReturn(run func(*http.Request) []db.Person) *Database_GetListedPeople_Call {
+func (_c *Database_ListPublicPeople_Call) RunAndReturn(run func(db.ListFilter) ([]db.PersonInShort, int64, error)) *Database_ListPublicPeople_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedPosts provides a mock function with given fields: r
-func (_m *Database) GetListedPosts(r *http.Request) ([]db.PeopleExtra, error) {
-	ret := _m.Called(r)
+// ListPublicTribes provides a mock function with given fields: filter
+func (_m *Database) ListPublicTribes(filter db.ListFilter) ([]db.Tribe, int64, error) {
+	ret := _m.Called(filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedPosts")
+		panic("no return value specified for ListPublicTribes")
 	}
 
-	var r0 []db.PeopleExtra
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) ([]db.PeopleExtra, error)); ok {
-		return rf(r)
+	var r0 []db.Tribe
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(db.ListFilter) ([]db.Tribe, int64, error)); ok {
+		return rf(filter)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.PeopleExtra); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(db.ListFilter) []db.Tribe); ok {
+		r0 = rf(filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.PeopleExtra)
+			r0 = ret.Get(0).([]db.Tribe)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(db.ListFilter) int64); ok {
+		r1 = rf(filter)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(db.ListFilter) error); ok {
+		r2 = rf(filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// Database_GetListedPosts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedPosts'
-type Database_GetListedPosts_Call struct {
+// Database_ListPublicTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublicTribes'
+type Database_ListPublicTribes_Call struct {
 	*mock.Call
 }
 
-// GetListedPosts is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedPosts(r interface{}) *Database_GetListedPosts_Call {
-	return &Database_GetListedPosts_Call{Call: _e.mock.On("GetListedPosts", r)}
+// ListPublicTribes is a helper method to define mock.On call
+//   - filter db.ListFilter
+func (_e *Database_Expecter) ListPublicTribes(filter interface{}) *Database_ListPublicTribes_Call {
+	return &Database_ListPublicTribes_Call{Call: _e.mock.On("ListPublicTribes", filter)}
 }
 
-func (_c *Database_GetListedPosts_Call) Run(run func(r *http.Request)) *Database_GetListedPosts_Call {
+func (_c *Database_ListPublicTribes_Call) Run(run func(filter db.ListFilter)) *Database_ListPublicTribes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.ListFilter))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedPosts_Call) Return(_a0 []db.PeopleExtra, _a1 error) *Database_GetListedPosts_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_ListPublicTribes_Call) Return(_a0 []db.Tribe, _a1 int64, _a2 error) *Database_ListPublicTribes_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *Database_GetListedPosts_Call) RunAndReturn(run func(*http.Request) ([]db.PeopleExtra, error)) *Database_GetListedPosts_Call {
+func (_c *Database_ListPublicTribes_Call) RunAndReturn(run func(db.ListFilter) ([]db.Tribe, int64, error)) *Database_ListPublicTribes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetListedTribes provides a mock function with given fields: r
-func (_m *Database) GetListedTribes(r *http.Request) []db.Tribe {
-	ret := _m.Called(r)
+// MarkBountyPaid provides a mock function with given fields: bountyId, paidDate
+func (_m *Database) MarkBountyPaid(bountyId uint, paidDate *time.Time) error {
+	ret := _m.Called(bountyId, paidDate)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetListedTribes")
+		panic("no return value specified for MarkBountyPaid")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Tribe); ok {
-		r0 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, *time.Time) error); ok {
+		r0 = rf(bountyId, paidDate)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetListedTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetListedTribes'
-type Database_GetListedTribes_Call struct {
+// Database_MarkBountyPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkBountyPaid'
+type Database_MarkBountyPaid_Call struct {
 	*mock.Call
 }
 
-// GetListedTribes is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetListedTribes(r interface{}) *Database_GetListedTribes_Call {
-	return &Database_GetListedTribes_Call{Call: _e.mock.On("GetListedTribes", r)}
+// MarkBountyPaid is a helper method to define mock.On call
+//   - bountyId uint
+//   - paidDate *time.Time
+func (_e *Database_Expecter) MarkBountyPaid(bountyId interface{}, paidDate interface{}) *Database_MarkBountyPaid_Call {
+	return &Database_MarkBountyPaid_Call{Call: _e.mock.On("MarkBountyPaid", bountyId, paidDate)}
 }
 
-func (_c *Database_GetListedTribes_Call) Run(run func(r *http.Request)) *Database_GetListedTribes_Call {
+func (_c *Database_MarkBountyPaid_Call) Run(run func(bountyId uint, paidDate *time.Time)) *Database_MarkBountyPaid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(uint), args[1].(*time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_GetListedTribes_Call) Return(_a0 []db.Tribe) *Database_GetListedTribes_Call {
+func (_c *Database_MarkBountyPaid_Call) Return(_a0 error) *Database_MarkBountyPaid_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetListedTribes_Call) RunAndReturn(run func(*http.Request) []db.Tribe) *Database_GetListedTribes_Call {
+func (_c *Database_MarkBountyPaid_Call) RunAndReturn(run func(uint, *time.Time) error) *Database_MarkBountyPaid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLnUser provides a mock function with given fields: lnKey
-func (_m *Database) GetLnUser(lnKey string) int64 {
-	ret := _m.Called(lnKey)
+// MarkNotificationRead provides a mock function with given fields: pubkey, id
+func (_m *Database) MarkNotificationRead(pubkey string, id uint) error {
+	ret := _m.Called(pubkey, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLnUser")
+		panic("no return value specified for MarkNotificationRead")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(lnKey)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, uint) error); ok {
+		r0 = rf(pubkey, id)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetLnUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLnUser'
-type Database_GetLnUser_Call struct {
+// Database_MarkNotificationRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkNotificationRead'
+type Database_MarkNotificationRead_Call struct {
 	*mock.Call
 }
 
-// GetLnUser is a helper method to define mock.On call
-//   - lnKey string
-func (_e *Database_Expecter) GetLnUser(lnKey interface{}) *Database_GetLnUser_Call {
-	return &Database_GetLnUser_Call{Call: _e.mock.On("GetLnUser", lnKey)}
+// MarkNotificationRead is a helper method to define mock.On call
+//   - pubkey string
+//   - id uint
+func (_e *Database_Expecter) MarkNotificationRead(pubkey interface{}, id interface{}) *Database_MarkNotificationRead_Call {
+	return &Database_MarkNotificationRead_Call{Call: _e.mock.On("MarkNotificationRead", pubkey, id)}
 }
 
-func (_c *Database_GetLnUser_Call) Run(run func(lnKey string)) *Database_GetLnUser_Call {
+func (_c *Database_MarkNotificationRead_Call) Run(run func(pubkey string, id uint)) *Database_MarkNotificationRead_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetLnUser_Call) Return(_a0 int64) *Database_GetLnUser_Call {
+func (_c *Database_MarkNotificationRead_Call) Return(_a0 error) *Database_MarkNotificationRead_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetLnUser_Call) RunAndReturn(run func(string) int64) *Database_GetLnUser_Call {
+func (_c *Database_MarkNotificationRead_Call) RunAndReturn(run func(string, uint) error) *Database_MarkNotificationRead_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNextBountyByCreated provides a mock function with given fields: r
-func (_m *Database) GetNextBountyByCreated(r *http.Request) (uint, error) {
-	ret := _m.Called(r)
+// MergePeople provides a mock function with given fields: source, target, mergedBy, dryRun
+func (_m *Database) MergePeople(source string, target string, mergedBy string, dryRun bool) (db.PersonMergeResult, error) {
+	ret := _m.Called(source, target, mergedBy, dryRun)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNextBountyByCreated")
+		panic("no return value specified for MergePeople")
 	}
 
-	var r0 uint
+	var r0 db.PersonMergeResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(string, string, string, bool) (db.PersonMergeResult, error)); ok {
+		return rf(source, target, mergedBy, dryRun)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(string, string, string, bool) db.PersonMergeResult); ok {
+		r0 = rf(source, target, mergedBy, dryRun)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(db.PersonMergeResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(string, string, string, bool) error); ok {
+		r1 = rf(source, target, mergedBy, dryRun)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4016,111 +14731,105 @@ func (_m *Database) GetNextBountyByCreated(r *http.Request) (uint, error) {
 	return r0, r1
 }
 
-// Database_GetNextBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextBountyByCreated'
-type Database_GetNextBountyByCreated_Call struct {
+// Database_MergePeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergePeople'
+type Database_MergePeople_Call struct {
 	*mock.Call
 }
 
-// GetNextBountyByCreated is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetNextBountyByCreated(r interface{}) *Database_GetNextBountyByCreated_Call {
-	return &Database_GetNextBountyByCreated_Call{Call: _e.mock.On("GetNextBountyByCreated", r)}
+// MergePeople is a helper method to define mock.On call
+//   - source string
+//   - target string
+//   - mergedBy string
+//   - dryRun bool
+func (_e *Database_Expecter) MergePeople(source interface{}, target interface{}, mergedBy interface{}, dryRun interface{}) *Database_MergePeople_Call {
+	return &Database_MergePeople_Call{Call: _e.mock.On("MergePeople", source, target, mergedBy, dryRun)}
 }
 
-func (_c *Database_GetNextBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetNextBountyByCreated_Call {
+func (_c *Database_MergePeople_Call) Run(run func(source string, target string, mergedBy string, dryRun bool)) *Database_MergePeople_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_GetNextBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetNextBountyByCreated_Call {
+func (_c *Database_MergePeople_Call) Return(_a0 db.PersonMergeResult, _a1 error) *Database_MergePeople_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetNextBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetNextBountyByCreated_Call {
+func (_c *Database_MergePeople_Call) RunAndReturn(run func(string, string, string, bool) (db.PersonMergeResult, error)) *Database_MergePeople_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNextWorkspaceBountyByCreated provides a mock function with given fields: r
-func (_m *Database) GetNextWorkspaceBountyByCreated(r *http.Request) (uint, error) {
-	ret := _m.Called(r)
+// NewHuntersPaid provides a mock function with given fields: r, workspace
+func (_m *Database) NewHuntersPaid(r db.PaymentDateRange, workspace string) int64 {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNextWorkspaceBountyByCreated")
-	}
-
-	var r0 uint
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
-		return rf(r)
-	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
-		r0 = rf(r)
-	} else {
-		r0 = ret.Get(0).(uint)
+		panic("no return value specified for NewHuntersPaid")
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetNextWorkspaceBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextWorkspaceBountyByCreated'
-type Database_GetNextWorkspaceBountyByCreated_Call struct {
+// Database_NewHuntersPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewHuntersPaid'
+type Database_NewHuntersPaid_Call struct {
 	*mock.Call
 }
 
-// GetNextWorkspaceBountyByCreated is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetNextWorkspaceBountyByCreated(r interface{}) *Database_GetNextWorkspaceBountyByCreated_Call {
-	return &Database_GetNextWorkspaceBountyByCreated_Call{Call: _e.mock.On("GetNextWorkspaceBountyByCreated", r)}
+// NewHuntersPaid is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) NewHuntersPaid(r interface{}, workspace interface{}) *Database_NewHuntersPaid_Call {
+	return &Database_NewHuntersPaid_Call{Call: _e.mock.On("NewHuntersPaid", r, workspace)}
 }
 
-func (_c *Database_GetNextWorkspaceBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetNextWorkspaceBountyByCreated_Call {
+func (_c *Database_NewHuntersPaid_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_NewHuntersPaid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetNextWorkspaceBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetNextWorkspaceBountyByCreated_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_NewHuntersPaid_Call) Return(_a0 int64) *Database_NewHuntersPaid_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetNextWorkspaceBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetNextWorkspaceBountyByCreated_Call {
+func (_c *Database_NewHuntersPaid_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_NewHuntersPaid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOpenGithubIssues provides a mock function with given fields: r
-func (_m *Database) GetOpenGithubIssues(r *http.Request) (int64, error) {
-	ret := _m.Called(r)
+// PatchBounty provides a mock function with given fields: bounty, columns, conditional
+func (_m *Database) PatchBounty(bounty db.NewBounty, columns []string, conditional bool) (db.NewBounty, error) {
+	ret := _m.Called(bounty, columns, conditional)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOpenGithubIssues")
+		panic("no return value specified for PatchBounty")
 	}
 
-	var r0 int64
+	var r0 db.NewBounty
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (int64, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(db.NewBounty, []string, bool) (db.NewBounty, error)); ok {
+		return rf(bounty, columns, conditional)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) int64); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(db.NewBounty, []string, bool) db.NewBounty); ok {
+		r0 = rf(bounty, columns, conditional)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(db.NewBounty)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(db.NewBounty, []string, bool) error); ok {
+		r1 = rf(bounty, columns, conditional)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4128,581 +14837,598 @@ func (_m *Database) GetOpenGithubIssues(r *http.Request) (int64, error) {
 	return r0, r1
 }
 
-// Database_GetOpenGithubIssues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenGithubIssues'
-type Database_GetOpenGithubIssues_Call struct {
+// Database_PatchBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchBounty'
+type Database_PatchBounty_Call struct {
 	*mock.Call
 }
 
-// GetOpenGithubIssues is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetOpenGithubIssues(r interface{}) *Database_GetOpenGithubIssues_Call {
-	return &Database_GetOpenGithubIssues_Call{Call: _e.mock.On("GetOpenGithubIssues", r)}
+// PatchBounty is a helper method to define mock.On call
+//   - bounty db.NewBounty
+//   - columns []string
+//   - conditional bool
+func (_e *Database_Expecter) PatchBounty(bounty interface{}, columns interface{}, conditional interface{}) *Database_PatchBounty_Call {
+	return &Database_PatchBounty_Call{Call: _e.mock.On("PatchBounty", bounty, columns, conditional)}
 }
 
-func (_c *Database_GetOpenGithubIssues_Call) Run(run func(r *http.Request)) *Database_GetOpenGithubIssues_Call {
+func (_c *Database_PatchBounty_Call) Run(run func(bounty db.NewBounty, columns []string, conditional bool)) *Database_PatchBounty_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(db.NewBounty), args[1].([]string), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_GetOpenGithubIssues_Call) Return(_a0 int64, _a1 error) *Database_GetOpenGithubIssues_Call {
+func (_c *Database_PatchBounty_Call) Return(_a0 db.NewBounty, _a1 error) *Database_PatchBounty_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetOpenGithubIssues_Call) RunAndReturn(run func(*http.Request) (int64, error)) *Database_GetOpenGithubIssues_Call {
+func (_c *Database_PatchBounty_Call) RunAndReturn(run func(db.NewBounty, []string, bool) (db.NewBounty, error)) *Database_PatchBounty_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPaymentHistory provides a mock function with given fields: workspace_uuid, r
-func (_m *Database) GetPaymentHistory(workspace_uuid string, r *http.Request) []db.NewPaymentHistory {
-	ret := _m.Called(workspace_uuid, r)
+// PatchFeature provides a mock function with given fields: feature, columns, conditional
+func (_m *Database) PatchFeature(feature db.WorkspaceFeatures, columns []string, conditional bool) (db.WorkspaceFeatures, error) {
+	ret := _m.Called(feature, columns, conditional)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPaymentHistory")
+		panic("no return value specified for PatchFeature")
 	}
 
-	var r0 []db.NewPaymentHistory
-	if rf, ok := ret.Get(0).(func(string, *http.Request) []db.NewPaymentHistory); ok {
-		r0 = rf(workspace_uuid, r)
+	var r0 db.WorkspaceFeatures
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures, []string, bool) (db.WorkspaceFeatures, error)); ok {
+		return rf(feature, columns, conditional)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceFeatures, []string, bool) db.WorkspaceFeatures); ok {
+		r0 = rf(feature, columns, conditional)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewPaymentHistory)
-		}
+		r0 = ret.Get(0).(db.WorkspaceFeatures)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceFeatures, []string, bool) error); ok {
+		r1 = rf(feature, columns, conditional)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// Database_GetPaymentHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentHistory'
-type Database_GetPaymentHistory_Call struct {
+// Database_PatchFeature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchFeature'
+type Database_PatchFeature_Call struct {
 	*mock.Call
 }
 
-// GetPaymentHistory is a helper method to define mock.On call
-//   - workspace_uuid string
-//   - r *http.Request
-func (_e *Database_Expecter) GetPaymentHistory(workspace_uuid interface{}, r interface{}) *Database_GetPaymentHistory_Call {
-	return &Database_GetPaymentHistory_Call{Call: _e.mock.On("GetPaymentHistory", workspace_uuid, r)}
+// PatchFeature is a helper method to define mock.On call
+//   - feature db.WorkspaceFeatures
+//   - columns []string
+//   - conditional bool
+func (_e *Database_Expecter) PatchFeature(feature interface{}, columns interface{}, conditional interface{}) *Database_PatchFeature_Call {
+	return &Database_PatchFeature_Call{Call: _e.mock.On("PatchFeature", feature, columns, conditional)}
 }
 
-func (_c *Database_GetPaymentHistory_Call) Run(run func(workspace_uuid string, r *http.Request)) *Database_GetPaymentHistory_Call {
+func (_c *Database_PatchFeature_Call) Run(run func(feature db.WorkspaceFeatures, columns []string, conditional bool)) *Database_PatchFeature_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(*http.Request))
+		run(args[0].(db.WorkspaceFeatures), args[1].([]string), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *Database_GetPaymentHistory_Call) Return(_a0 []db.NewPaymentHistory) *Database_GetPaymentHistory_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_PatchFeature_Call) Return(_a0 db.WorkspaceFeatures, _a1 error) *Database_PatchFeature_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPaymentHistory_Call) RunAndReturn(run func(string, *http.Request) []db.NewPaymentHistory) *Database_GetPaymentHistory_Call {
+func (_c *Database_PatchFeature_Call) RunAndReturn(run func(db.WorkspaceFeatures, []string, bool) (db.WorkspaceFeatures, error)) *Database_PatchFeature_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPaymentHistoryByCreated provides a mock function with given fields: created, workspace_uuid
-func (_m *Database) GetPaymentHistoryByCreated(created *time.Time, workspace_uuid string) db.NewPaymentHistory {
-	ret := _m.Called(created, workspace_uuid)
+// PersonUniqueNameFromName provides a mock function with given fields: name
+func (_m *Database) PersonUniqueNameFromName(name string) (string, error) {
+	ret := _m.Called(name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPaymentHistoryByCreated")
+		panic("no return value specified for PersonUniqueNameFromName")
 	}
 
-	var r0 db.NewPaymentHistory
-	if rf, ok := ret.Get(0).(func(*time.Time, string) db.NewPaymentHistory); ok {
-		r0 = rf(created, workspace_uuid)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(name)
 	} else {
-		r0 = ret.Get(0).(db.NewPaymentHistory)
+		r0 = ret.Get(0).(string)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetPaymentHistoryByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentHistoryByCreated'
-type Database_GetPaymentHistoryByCreated_Call struct {
+// Database_PersonUniqueNameFromName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PersonUniqueNameFromName'
+type Database_PersonUniqueNameFromName_Call struct {
 	*mock.Call
 }
 
-// GetPaymentHistoryByCreated is a helper method to define mock.On call
-//   - created *time.Time
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetPaymentHistoryByCreated(created interface{}, workspace_uuid interface{}) *Database_GetPaymentHistoryByCreated_Call {
-	return &Database_GetPaymentHistoryByCreated_Call{Call: _e.mock.On("GetPaymentHistoryByCreated", created, workspace_uuid)}
+// PersonUniqueNameFromName is a helper method to define mock.On call
+//   - name string
+func (_e *Database_Expecter) PersonUniqueNameFromName(name interface{}) *Database_PersonUniqueNameFromName_Call {
+	return &Database_PersonUniqueNameFromName_Call{Call: _e.mock.On("PersonUniqueNameFromName", name)}
 }
 
-func (_c *Database_GetPaymentHistoryByCreated_Call) Run(run func(created *time.Time, workspace_uuid string)) *Database_GetPaymentHistoryByCreated_Call {
+func (_c *Database_PersonUniqueNameFromName_Call) Run(run func(name string)) *Database_PersonUniqueNameFromName_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*time.Time), args[1].(string))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPaymentHistoryByCreated_Call) Return(_a0 db.NewPaymentHistory) *Database_GetPaymentHistoryByCreated_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_PersonUniqueNameFromName_Call) Return(_a0 string, _a1 error) *Database_PersonUniqueNameFromName_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPaymentHistoryByCreated_Call) RunAndReturn(run func(*time.Time, string) db.NewPaymentHistory) *Database_GetPaymentHistoryByCreated_Call {
+func (_c *Database_PersonUniqueNameFromName_Call) RunAndReturn(run func(string) (string, error)) *Database_PersonUniqueNameFromName_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPeopleBySearch provides a mock function with given fields: r
-func (_m *Database) GetPeopleBySearch(r *http.Request) []db.Person {
-	ret := _m.Called(r)
+// Ping provides a mock function with given fields: ctx
+func (_m *Database) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPeopleBySearch")
+		panic("no return value specified for Ping")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Person); ok {
-		r0 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPeopleBySearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleBySearch'
-type Database_GetPeopleBySearch_Call struct {
+// Database_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type Database_Ping_Call struct {
 	*mock.Call
 }
 
-// GetPeopleBySearch is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetPeopleBySearch(r interface{}) *Database_GetPeopleBySearch_Call {
-	return &Database_GetPeopleBySearch_Call{Call: _e.mock.On("GetPeopleBySearch", r)}
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Database_Expecter) Ping(ctx interface{}) *Database_Ping_Call {
+	return &Database_Ping_Call{Call: _e.mock.On("Ping", ctx)}
 }
 
-func (_c *Database_GetPeopleBySearch_Call) Run(run func(r *http.Request)) *Database_GetPeopleBySearch_Call {
+func (_c *Database_Ping_Call) Run(run func(ctx context.Context)) *Database_Ping_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *Database_GetPeopleBySearch_Call) Return(_a0 []db.Person) *Database_GetPeopleBySearch_Call {
+func (_c *Database_Ping_Call) Return(_a0 error) *Database_Ping_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPeopleBySearch_Call) RunAndReturn(run func(*http.Request) []db.Person) *Database_GetPeopleBySearch_Call {
+func (_c *Database_Ping_Call) RunAndReturn(run func(context.Context) error) *Database_Ping_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPeopleListShort provides a mock function with given fields: count
-func (_m *Database) GetPeopleListShort(count uint32) *[]db.PersonInShort {
-	ret := _m.Called(count)
+// PlaceBountyBid provides a mock function with given fields: bid
+func (_m *Database) PlaceBountyBid(bid db.BountyBid) (db.BountyBid, error) {
+	ret := _m.Called(bid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPeopleListShort")
+		panic("no return value specified for PlaceBountyBid")
 	}
 
-	var r0 *[]db.PersonInShort
-	if rf, ok := ret.Get(0).(func(uint32) *[]db.PersonInShort); ok {
-		r0 = rf(count)
+	var r0 db.BountyBid
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.BountyBid) (db.BountyBid, error)); ok {
+		return rf(bid)
+	}
+	if rf, ok := ret.Get(0).(func(db.BountyBid) db.BountyBid); ok {
+		r0 = rf(bid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*[]db.PersonInShort)
-		}
+		r0 = ret.Get(0).(db.BountyBid)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.BountyBid) error); ok {
+		r1 = rf(bid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetPeopleListShort_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeopleListShort'
-type Database_GetPeopleListShort_Call struct {
+// Database_PlaceBountyBid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlaceBountyBid'
+type Database_PlaceBountyBid_Call struct {
 	*mock.Call
 }
 
-// GetPeopleListShort is a helper method to define mock.On call
-//   - count uint32
-func (_e *Database_Expecter) GetPeopleListShort(count interface{}) *Database_GetPeopleListShort_Call {
-	return &Database_GetPeopleListShort_Call{Call: _e.mock.On("GetPeopleListShort", count)}
+// PlaceBountyBid is a helper method to define mock.On call
+//   - bid db.BountyBid
+func (_e *Database_Expecter) PlaceBountyBid(bid interface{}) *Database_PlaceBountyBid_Call {
+	return &Database_PlaceBountyBid_Call{Call: _e.mock.On("PlaceBountyBid", bid)}
 }
 
-func (_c *Database_GetPeopleListShort_Call) Run(run func(count uint32)) *Database_GetPeopleListShort_Call {
+func (_c *Database_PlaceBountyBid_Call) Run(run func(bid db.BountyBid)) *Database_PlaceBountyBid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint32))
+		run(args[0].(db.BountyBid))
 	})
 	return _c
 }
 
-func (_c *Database_GetPeopleListShort_Call) Return(_a0 *[]db.PersonInShort) *Database_GetPeopleListShort_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_PlaceBountyBid_Call) Return(_a0 db.BountyBid, _a1 error) *Database_PlaceBountyBid_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPeopleListShort_Call) RunAndReturn(run func(uint32) *[]db.PersonInShort) *Database_GetPeopleListShort_Call {
+func (_c *Database_PlaceBountyBid_Call) RunAndReturn(run func(db.BountyBid) (db.BountyBid, error)) *Database_PlaceBountyBid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPerson provides a mock function with given fields: id
-func (_m *Database) GetPerson(id uint) db.Person {
-	ret := _m.Called(id)
+// ProcessAddInvoice provides a mock function with given fields: invoice, userData
+func (_m *Database) ProcessAddInvoice(invoice db.NewInvoiceList, userData db.UserInvoiceData) error {
+	ret := _m.Called(invoice, userData)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPerson")
+		panic("no return value specified for ProcessAddInvoice")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(uint) db.Person); ok {
-		r0 = rf(id)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewInvoiceList, db.UserInvoiceData) error); ok {
+		r0 = rf(invoice, userData)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPerson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPerson'
-type Database_GetPerson_Call struct {
+// Database_ProcessAddInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessAddInvoice'
+type Database_ProcessAddInvoice_Call struct {
 	*mock.Call
 }
 
-// GetPerson is a helper method to define mock.On call
-//   - id uint
-func (_e *Database_Expecter) GetPerson(id interface{}) *Database_GetPerson_Call {
-	return &Database_GetPerson_Call{Call: _e.mock.On("GetPerson", id)}
+// ProcessAddInvoice is a helper method to define mock.On call
+//   - invoice db.NewInvoiceList
+//   - userData db.UserInvoiceData
+func (_e *Database_Expecter) ProcessAddInvoice(invoice interface{}, userData interface{}) *Database_ProcessAddInvoice_Call {
+	return &Database_ProcessAddInvoice_Call{Call: _e.mock.On("ProcessAddInvoice", invoice, userData)}
 }
 
-func (_c *Database_GetPerson_Call) Run(run func(id uint)) *Database_GetPerson_Call {
+func (_c *Database_ProcessAddInvoice_Call) Run(run func(invoice db.NewInvoiceList, userData db.UserInvoiceData)) *Database_ProcessAddInvoice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(db.NewInvoiceList), args[1].(db.UserInvoiceData))
 	})
 	return _c
 }
 
-func (_c *Database_GetPerson_Call) Return(_a0 db.Person) *Database_GetPerson_Call {
+func (_c *Database_ProcessAddInvoice_Call) Return(_a0 error) *Database_ProcessAddInvoice_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPerson_Call) RunAndReturn(run func(uint) db.Person) *Database_GetPerson_Call {
+func (_c *Database_ProcessAddInvoice_Call) RunAndReturn(run func(db.NewInvoiceList, db.UserInvoiceData) error) *Database_ProcessAddInvoice_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPersonByGithubName provides a mock function with given fields: github_name
-func (_m *Database) GetPersonByGithubName(github_name string) db.Person {
-	ret := _m.Called(github_name)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetPersonByGithubName")
-	}
-
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(github_name)
-	} else {
-		r0 = ret.Get(0).(db.Person)
-	}
-
-	return r0
+// ProcessAlerts provides a mock function with given fields: p
+func (_m *Database) ProcessAlerts(p db.Person) {
+	_m.Called(p)
 }
 
-// Database_GetPersonByGithubName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByGithubName'
-type Database_GetPersonByGithubName_Call struct {
+// Database_ProcessAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessAlerts'
+type Database_ProcessAlerts_Call struct {
 	*mock.Call
 }
 
-// GetPersonByGithubName is a helper method to define mock.On call
-//   - github_name string
-func (_e *Database_Expecter) GetPersonByGithubName(github_name interface{}) *Database_GetPersonByGithubName_Call {
-	return &Database_GetPersonByGithubName_Call{Call: _e.mock.On("GetPersonByGithubName", github_name)}
+// ProcessAlerts is a helper method to define mock.On call
+//   - p db.Person
+func (_e *Database_Expecter) ProcessAlerts(p interface{}) *Database_ProcessAlerts_Call {
+	return &Database_ProcessAlerts_Call{Call: _e.mock.On("ProcessAlerts", p)}
 }
 
-func (_c *Database_GetPersonByGithubName_Call) Run(run func(github_name string)) *Database_GetPersonByGithubName_Call {
+func (_c *Database_ProcessAlerts_Call) Run(run func(p db.Person)) *Database_ProcessAlerts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.Person))
 	})
 	return _c
 }
 
-func (_c *Database_GetPersonByGithubName_Call) Return(_a0 db.Person) *Database_GetPersonByGithubName_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ProcessAlerts_Call) Return() *Database_ProcessAlerts_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *Database_GetPersonByGithubName_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByGithubName_Call {
+func (_c *Database_ProcessAlerts_Call) RunAndReturn(run func(db.Person)) *Database_ProcessAlerts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPersonByPubkey provides a mock function with given fields: pubkey
-func (_m *Database) GetPersonByPubkey(pubkey string) db.Person {
-	ret := _m.Called(pubkey)
+// ProcessBountyPayment provides a mock function with given fields: payment, bounty, reservation
+func (_m *Database) ProcessBountyPayment(payment db.NewPaymentHistory, bounty db.NewBounty, reservation db.BudgetReservation) error {
+	ret := _m.Called(payment, bounty, reservation)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPersonByPubkey")
+		panic("no return value specified for ProcessBountyPayment")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(pubkey)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, db.NewBounty, db.BudgetReservation) error); ok {
+		r0 = rf(payment, bounty, reservation)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPersonByPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByPubkey'
-type Database_GetPersonByPubkey_Call struct {
+// Database_ProcessBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBountyPayment'
+type Database_ProcessBountyPayment_Call struct {
 	*mock.Call
 }
 
-// GetPersonByPubkey is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetPersonByPubkey(pubkey interface{}) *Database_GetPersonByPubkey_Call {
-	return &Database_GetPersonByPubkey_Call{Call: _e.mock.On("GetPersonByPubkey", pubkey)}
+// ProcessBountyPayment is a helper method to define mock.On call
+//   - payment db.NewPaymentHistory
+//   - bounty db.NewBounty
+//   - reservation db.BudgetReservation
+func (_e *Database_Expecter) ProcessBountyPayment(payment interface{}, bounty interface{}, reservation interface{}) *Database_ProcessBountyPayment_Call {
+	return &Database_ProcessBountyPayment_Call{Call: _e.mock.On("ProcessBountyPayment", payment, bounty, reservation)}
 }
 
-func (_c *Database_GetPersonByPubkey_Call) Run(run func(pubkey string)) *Database_GetPersonByPubkey_Call {
+func (_c *Database_ProcessBountyPayment_Call) Run(run func(payment db.NewPaymentHistory, bounty db.NewBounty, reservation db.BudgetReservation)) *Database_ProcessBountyPayment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.NewPaymentHistory), args[1].(db.NewBounty), args[2].(db.BudgetReservation))
 	})
 	return _c
 }
 
-func (_c *Database_GetPersonByPubkey_Call) Return(_a0 db.Person) *Database_GetPersonByPubkey_Call {
+func (_c *Database_ProcessBountyPayment_Call) Return(_a0 error) *Database_ProcessBountyPayment_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPersonByPubkey_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByPubkey_Call {
+func (_c *Database_ProcessBountyPayment_Call) RunAndReturn(run func(db.NewPaymentHistory, db.NewBounty, db.BudgetReservation) error) *Database_ProcessBountyPayment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPersonByUniqueName provides a mock function with given fields: un
-func (_m *Database) GetPersonByUniqueName(un string) db.Person {
-	ret := _m.Called(un)
+// ProcessBudgetInvoice provides a mock function with given fields: paymentHistory, newInvoice
+func (_m *Database) ProcessBudgetInvoice(paymentHistory db.NewPaymentHistory, newInvoice db.NewInvoiceList) error {
+	ret := _m.Called(paymentHistory, newInvoice)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPersonByUniqueName")
+		panic("no return value specified for ProcessBudgetInvoice")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(un)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, db.NewInvoiceList) error); ok {
+		r0 = rf(paymentHistory, newInvoice)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPersonByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByUniqueName'
-type Database_GetPersonByUniqueName_Call struct {
+// Database_ProcessBudgetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBudgetInvoice'
+type Database_ProcessBudgetInvoice_Call struct {
 	*mock.Call
 }
 
-// GetPersonByUniqueName is a helper method to define mock.On call
-//   - un string
-func (_e *Database_Expecter) GetPersonByUniqueName(un interface{}) *Database_GetPersonByUniqueName_Call {
-	return &Database_GetPersonByUniqueName_Call{Call: _e.mock.On("GetPersonByUniqueName", un)}
+// ProcessBudgetInvoice is a helper method to define mock.On call
+//   - paymentHistory db.NewPaymentHistory
+//   - newInvoice db.NewInvoiceList
+func (_e *Database_Expecter) ProcessBudgetInvoice(paymentHistory interface{}, newInvoice interface{}) *Database_ProcessBudgetInvoice_Call {
+	return &Database_ProcessBudgetInvoice_Call{Call: _e.mock.On("ProcessBudgetInvoice", paymentHistory, newInvoice)}
 }
 
-func (_c *Database_GetPersonByUniqueName_Call) Run(run func(un string)) *Database_GetPersonByUniqueName_Call {
+func (_c *Database_ProcessBudgetInvoice_Call) Run(run func(paymentHistory db.NewPaymentHistory, newInvoice db.NewInvoiceList)) *Database_ProcessBudgetInvoice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.NewPaymentHistory), args[1].(db.NewInvoiceList))
 	})
 	return _c
 }
 
-func (_c *Database_GetPersonByUniqueName_Call) Return(_a0 db.Person) *Database_GetPersonByUniqueName_Call {
+func (_c *Database_ProcessBudgetInvoice_Call) Return(_a0 error) *Database_ProcessBudgetInvoice_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPersonByUniqueName_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByUniqueName_Call {
+func (_c *Database_ProcessBudgetInvoice_Call) RunAndReturn(run func(db.NewPaymentHistory, db.NewInvoiceList) error) *Database_ProcessBudgetInvoice_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPersonByUuid provides a mock function with given fields: uuid
-func (_m *Database) GetPersonByUuid(uuid string) db.Person {
-	ret := _m.Called(uuid)
+// ProcessDeleteWorkspace provides a mock function with given fields: workspace_uuid
+func (_m *Database) ProcessDeleteWorkspace(workspace_uuid string) error {
+	ret := _m.Called(workspace_uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPersonByUuid")
+		panic("no return value specified for ProcessDeleteWorkspace")
 	}
 
-	var r0 db.Person
-	if rf, ok := ret.Get(0).(func(string) db.Person); ok {
-		r0 = rf(uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(workspace_uuid)
 	} else {
-		r0 = ret.Get(0).(db.Person)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetPersonByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonByUuid'
-type Database_GetPersonByUuid_Call struct {
+// Database_ProcessDeleteWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessDeleteWorkspace'
+type Database_ProcessDeleteWorkspace_Call struct {
 	*mock.Call
 }
 
-// GetPersonByUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetPersonByUuid(uuid interface{}) *Database_GetPersonByUuid_Call {
-	return &Database_GetPersonByUuid_Call{Call: _e.mock.On("GetPersonByUuid", uuid)}
+// ProcessDeleteWorkspace is a helper method to define mock.On call
+//   - workspace_uuid string
+func (_e *Database_Expecter) ProcessDeleteWorkspace(workspace_uuid interface{}) *Database_ProcessDeleteWorkspace_Call {
+	return &Database_ProcessDeleteWorkspace_Call{Call: _e.mock.On("ProcessDeleteWorkspace", workspace_uuid)}
 }
 
-func (_c *Database_GetPersonByUuid_Call) Run(run func(uuid string)) *Database_GetPersonByUuid_Call {
+func (_c *Database_ProcessDeleteWorkspace_Call) Run(run func(workspace_uuid string)) *Database_ProcessDeleteWorkspace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetPersonByUuid_Call) Return(_a0 db.Person) *Database_GetPersonByUuid_Call {
+func (_c *Database_ProcessDeleteWorkspace_Call) Return(_a0 error) *Database_ProcessDeleteWorkspace_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPersonByUuid_Call) RunAndReturn(run func(string) db.Person) *Database_GetPersonByUuid_Call {
+func (_c *Database_ProcessDeleteWorkspace_Call) RunAndReturn(run func(string) error) *Database_ProcessDeleteWorkspace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPhaseByUuid provides a mock function with given fields: phaseUuid
-func (_m *Database) GetPhaseByUuid(phaseUuid string) (db.FeaturePhase, error) {
-	ret := _m.Called(phaseUuid)
+// ProcessUpdateBudget provides a mock function with given fields: invoice
+func (_m *Database) ProcessUpdateBudget(invoice db.NewInvoiceList) error {
+	ret := _m.Called(invoice)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPhaseByUuid")
-	}
-
-	var r0 db.FeaturePhase
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (db.FeaturePhase, error)); ok {
-		return rf(phaseUuid)
-	}
-	if rf, ok := ret.Get(0).(func(string) db.FeaturePhase); ok {
-		r0 = rf(phaseUuid)
-	} else {
-		r0 = ret.Get(0).(db.FeaturePhase)
+		panic("no return value specified for ProcessUpdateBudget")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(phaseUuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewInvoiceList) error); ok {
+		r0 = rf(invoice)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetPhaseByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhaseByUuid'
-type Database_GetPhaseByUuid_Call struct {
+// Database_ProcessUpdateBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessUpdateBudget'
+type Database_ProcessUpdateBudget_Call struct {
 	*mock.Call
 }
 
-// GetPhaseByUuid is a helper method to define mock.On call
-//   - phaseUuid string
-func (_e *Database_Expecter) GetPhaseByUuid(phaseUuid interface{}) *Database_GetPhaseByUuid_Call {
-	return &Database_GetPhaseByUuid_Call{Call: _e.mock.On("GetPhaseByUuid", phaseUuid)}
+// ProcessUpdateBudget is a helper method to define mock.On call
+//   - invoice db.NewInvoiceList
+func (_e *Database_Expecter) ProcessUpdateBudget(invoice interface{}) *Database_ProcessUpdateBudget_Call {
+	return &Database_ProcessUpdateBudget_Call{Call: _e.mock.On("ProcessUpdateBudget", invoice)}
 }
 
-func (_c *Database_GetPhaseByUuid_Call) Run(run func(phaseUuid string)) *Database_GetPhaseByUuid_Call {
+func (_c *Database_ProcessUpdateBudget_Call) Run(run func(invoice db.NewInvoiceList)) *Database_ProcessUpdateBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.NewInvoiceList))
 	})
 	return _c
 }
 
-func (_c *Database_GetPhaseByUuid_Call) Return(_a0 db.FeaturePhase, _a1 error) *Database_GetPhaseByUuid_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_ProcessUpdateBudget_Call) Return(_a0 error) *Database_ProcessUpdateBudget_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPhaseByUuid_Call) RunAndReturn(run func(string) (db.FeaturePhase, error)) *Database_GetPhaseByUuid_Call {
+func (_c *Database_ProcessUpdateBudget_Call) RunAndReturn(run func(db.NewInvoiceList) error) *Database_ProcessUpdateBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPhasesByFeatureUuid provides a mock function with given fields: featureUuid
-func (_m *Database) GetPhasesByFeatureUuid(featureUuid string) []db.FeaturePhase {
-	ret := _m.Called(featureUuid)
+// PublicSearch provides a mock function with given fields: query, types, limit
+func (_m *Database) PublicSearch(query string, types []string, limit int) (db.SearchResult, error) {
+	ret := _m.Called(query, types, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPhasesByFeatureUuid")
+		panic("no return value specified for PublicSearch")
 	}
 
-	var r0 []db.FeaturePhase
-	if rf, ok := ret.Get(0).(func(string) []db.FeaturePhase); ok {
-		r0 = rf(featureUuid)
+	var r0 db.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string, int) (db.SearchResult, error)); ok {
+		return rf(query, types, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string, int) db.SearchResult); ok {
+		r0 = rf(query, types, limit)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.FeaturePhase)
-		}
+		r0 = ret.Get(0).(db.SearchResult)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, []string, int) error); ok {
+		r1 = rf(query, types, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetPhasesByFeatureUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhasesByFeatureUuid'
-type Database_GetPhasesByFeatureUuid_Call struct {
+// Database_PublicSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicSearch'
+type Database_PublicSearch_Call struct {
 	*mock.Call
 }
 
-// GetPhasesByFeatureUuid is a helper method to define mock.On call
-//   - featureUuid string
-func (_e *Database_Expecter) GetPhasesByFeatureUuid(featureUuid interface{}) *Database_GetPhasesByFeatureUuid_Call {
-	return &Database_GetPhasesByFeatureUuid_Call{Call: _e.mock.On("GetPhasesByFeatureUuid", featureUuid)}
+// PublicSearch is a helper method to define mock.On call
+//   - query string
+//   - types []string
+//   - limit int
+func (_e *Database_Expecter) PublicSearch(query interface{}, types interface{}, limit interface{}) *Database_PublicSearch_Call {
+	return &Database_PublicSearch_Call{Call: _e.mock.On("PublicSearch", query, types, limit)}
 }
 
-func (_c *Database_GetPhasesByFeatureUuid_Call) Run(run func(featureUuid string)) *Database_GetPhasesByFeatureUuid_Call {
+func (_c *Database_PublicSearch_Call) Run(run func(query string, types []string, limit int)) *Database_PublicSearch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].([]string), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetPhasesByFeatureUuid_Call) Return(_a0 []db.FeaturePhase) *Database_GetPhasesByFeatureUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_PublicSearch_Call) Return(_a0 db.SearchResult, _a1 error) *Database_PublicSearch_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPhasesByFeatureUuid_Call) RunAndReturn(run func(string) []db.FeaturePhase) *Database_GetPhasesByFeatureUuid_Call {
+func (_c *Database_PublicSearch_Call) RunAndReturn(run func(string, []string, int) (db.SearchResult, error)) *Database_PublicSearch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPreviousBountyByCreated provides a mock function with given fields: r
-func (_m *Database) GetPreviousBountyByCreated(r *http.Request) (uint, error) {
-	ret := _m.Called(r)
+// PurgeExpiredWorkspaceArchives provides a mock function with given fields: before
+func (_m *Database) PurgeExpiredWorkspaceArchives(before time.Time) (int64, error) {
+	ret := _m.Called(before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPreviousBountyByCreated")
+		panic("no return value specified for PurgeExpiredWorkspaceArchives")
 	}
 
-	var r0 uint
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
-		return rf(r)
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(before)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
-		r0 = rf(r)
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(before)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4710,1584 +15436,1676 @@ func (_m *Database) GetPreviousBountyByCreated(r *http.Request) (uint, error) {
 	return r0, r1
 }
 
-// Database_GetPreviousBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreviousBountyByCreated'
-type Database_GetPreviousBountyByCreated_Call struct {
+// Database_PurgeExpiredWorkspaceArchives_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpiredWorkspaceArchives'
+type Database_PurgeExpiredWorkspaceArchives_Call struct {
 	*mock.Call
 }
 
-// GetPreviousBountyByCreated is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetPreviousBountyByCreated(r interface{}) *Database_GetPreviousBountyByCreated_Call {
-	return &Database_GetPreviousBountyByCreated_Call{Call: _e.mock.On("GetPreviousBountyByCreated", r)}
+// PurgeExpiredWorkspaceArchives is a helper method to define mock.On call
+//   - before time.Time
+func (_e *Database_Expecter) PurgeExpiredWorkspaceArchives(before interface{}) *Database_PurgeExpiredWorkspaceArchives_Call {
+	return &Database_PurgeExpiredWorkspaceArchives_Call{Call: _e.mock.On("PurgeExpiredWorkspaceArchives", before)}
 }
 
-func (_c *Database_GetPreviousBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetPreviousBountyByCreated_Call {
+func (_c *Database_PurgeExpiredWorkspaceArchives_Call) Run(run func(before time.Time)) *Database_PurgeExpiredWorkspaceArchives_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_GetPreviousBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetPreviousBountyByCreated_Call {
+func (_c *Database_PurgeExpiredWorkspaceArchives_Call) Return(_a0 int64, _a1 error) *Database_PurgeExpiredWorkspaceArchives_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetPreviousBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetPreviousBountyByCreated_Call {
+func (_c *Database_PurgeExpiredWorkspaceArchives_Call) RunAndReturn(run func(time.Time) (int64, error)) *Database_PurgeExpiredWorkspaceArchives_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPreviousWorkspaceBountyByCreated provides a mock function with given fields: r
-func (_m *Database) GetPreviousWorkspaceBountyByCreated(r *http.Request) (uint, error) {
-	ret := _m.Called(r)
+// RecordBountyActivity provides a mock function with given fields: id
+func (_m *Database) RecordBountyActivity(id uint) error {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPreviousWorkspaceBountyByCreated")
-	}
-
-	var r0 uint
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
-		return rf(r)
-	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
-		r0 = rf(r)
-	} else {
-		r0 = ret.Get(0).(uint)
+		panic("no return value specified for RecordBountyActivity")
 	}
 
-	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
-		r1 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetPreviousWorkspaceBountyByCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreviousWorkspaceBountyByCreated'
-type Database_GetPreviousWorkspaceBountyByCreated_Call struct {
+// Database_RecordBountyActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordBountyActivity'
+type Database_RecordBountyActivity_Call struct {
 	*mock.Call
 }
 
-// GetPreviousWorkspaceBountyByCreated is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetPreviousWorkspaceBountyByCreated(r interface{}) *Database_GetPreviousWorkspaceBountyByCreated_Call {
-	return &Database_GetPreviousWorkspaceBountyByCreated_Call{Call: _e.mock.On("GetPreviousWorkspaceBountyByCreated", r)}
+// RecordBountyActivity is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) RecordBountyActivity(id interface{}) *Database_RecordBountyActivity_Call {
+	return &Database_RecordBountyActivity_Call{Call: _e.mock.On("RecordBountyActivity", id)}
 }
 
-func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) Run(run func(r *http.Request)) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+func (_c *Database_RecordBountyActivity_Call) Run(run func(id uint)) *Database_RecordBountyActivity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) Return(_a0 uint, _a1 error) *Database_GetPreviousWorkspaceBountyByCreated_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_RecordBountyActivity_Call) Return(_a0 error) *Database_RecordBountyActivity_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetPreviousWorkspaceBountyByCreated_Call) RunAndReturn(run func(*http.Request) (uint, error)) *Database_GetPreviousWorkspaceBountyByCreated_Call {
+func (_c *Database_RecordBountyActivity_Call) RunAndReturn(run func(uint) error) *Database_RecordBountyActivity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribe provides a mock function with given fields: uuid
-func (_m *Database) GetTribe(uuid string) db.Tribe {
-	ret := _m.Called(uuid)
+// RecordBridgeDelivery provides a mock function with given fields: uuid, now, deliveryErr
+func (_m *Database) RecordBridgeDelivery(uuid string, now time.Time, deliveryErr error) error {
+	ret := _m.Called(uuid, now, deliveryErr)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribe")
+		panic("no return value specified for RecordBridgeDelivery")
 	}
 
-	var r0 db.Tribe
-	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
-		r0 = rf(uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, error) error); ok {
+		r0 = rf(uuid, now, deliveryErr)
 	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribe'
-type Database_GetTribe_Call struct {
+// Database_RecordBridgeDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordBridgeDelivery'
+type Database_RecordBridgeDelivery_Call struct {
 	*mock.Call
 }
 
-// GetTribe is a helper method to define mock.On call
+// RecordBridgeDelivery is a helper method to define mock.On call
 //   - uuid string
-func (_e *Database_Expecter) GetTribe(uuid interface{}) *Database_GetTribe_Call {
-	return &Database_GetTribe_Call{Call: _e.mock.On("GetTribe", uuid)}
+//   - now time.Time
+//   - deliveryErr error
+func (_e *Database_Expecter) RecordBridgeDelivery(uuid interface{}, now interface{}, deliveryErr interface{}) *Database_RecordBridgeDelivery_Call {
+	return &Database_RecordBridgeDelivery_Call{Call: _e.mock.On("RecordBridgeDelivery", uuid, now, deliveryErr)}
 }
 
-func (_c *Database_GetTribe_Call) Run(run func(uuid string)) *Database_GetTribe_Call {
+func (_c *Database_RecordBridgeDelivery_Call) Run(run func(uuid string, now time.Time, deliveryErr error)) *Database_RecordBridgeDelivery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(time.Time), args[2].(error))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribe_Call) Return(_a0 db.Tribe) *Database_GetTribe_Call {
+func (_c *Database_RecordBridgeDelivery_Call) Return(_a0 error) *Database_RecordBridgeDelivery_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribe_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetTribe_Call {
+func (_c *Database_RecordBridgeDelivery_Call) RunAndReturn(run func(string, time.Time, error) error) *Database_RecordBridgeDelivery_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribeByIdAndPubkey provides a mock function with given fields: uuid, pubkey
-func (_m *Database) GetTribeByIdAndPubkey(uuid string, pubkey string) db.Tribe {
-	ret := _m.Called(uuid, pubkey)
+// RecordChatAgentDelivery provides a mock function with given fields: uuid, now, deliveryErr
+func (_m *Database) RecordChatAgentDelivery(uuid string, now time.Time, deliveryErr error) error {
+	ret := _m.Called(uuid, now, deliveryErr)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribeByIdAndPubkey")
+		panic("no return value specified for RecordChatAgentDelivery")
 	}
 
-	var r0 db.Tribe
-	if rf, ok := ret.Get(0).(func(string, string) db.Tribe); ok {
-		r0 = rf(uuid, pubkey)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, error) error); ok {
+		r0 = rf(uuid, now, deliveryErr)
 	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribeByIdAndPubkey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeByIdAndPubkey'
-type Database_GetTribeByIdAndPubkey_Call struct {
+// Database_RecordChatAgentDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordChatAgentDelivery'
+type Database_RecordChatAgentDelivery_Call struct {
 	*mock.Call
 }
 
-// GetTribeByIdAndPubkey is a helper method to define mock.On call
+// RecordChatAgentDelivery is a helper method to define mock.On call
 //   - uuid string
-//   - pubkey string
-func (_e *Database_Expecter) GetTribeByIdAndPubkey(uuid interface{}, pubkey interface{}) *Database_GetTribeByIdAndPubkey_Call {
-	return &Database_GetTribeByIdAndPubkey_Call{Call: _e.mock.On("GetTribeByIdAndPubkey", uuid, pubkey)}
+//   - now time.Time
+//   - deliveryErr error
+func (_e *Database_Expecter) RecordChatAgentDelivery(uuid interface{}, now interface{}, deliveryErr interface{}) *Database_RecordChatAgentDelivery_Call {
+	return &Database_RecordChatAgentDelivery_Call{Call: _e.mock.On("RecordChatAgentDelivery", uuid, now, deliveryErr)}
 }
 
-func (_c *Database_GetTribeByIdAndPubkey_Call) Run(run func(uuid string, pubkey string)) *Database_GetTribeByIdAndPubkey_Call {
+func (_c *Database_RecordChatAgentDelivery_Call) Run(run func(uuid string, now time.Time, deliveryErr error)) *Database_RecordChatAgentDelivery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(time.Time), args[2].(error))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribeByIdAndPubkey_Call) Return(_a0 db.Tribe) *Database_GetTribeByIdAndPubkey_Call {
+func (_c *Database_RecordChatAgentDelivery_Call) Return(_a0 error) *Database_RecordChatAgentDelivery_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribeByIdAndPubkey_Call) RunAndReturn(run func(string, string) db.Tribe) *Database_GetTribeByIdAndPubkey_Call {
+func (_c *Database_RecordChatAgentDelivery_Call) RunAndReturn(run func(string, time.Time, error) error) *Database_RecordChatAgentDelivery_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribeByUniqueName provides a mock function with given fields: un
-func (_m *Database) GetTribeByUniqueName(un string) db.Tribe {
-	ret := _m.Called(un)
+// RecordRelayEvent provides a mock function with given fields: event, received
+func (_m *Database) RecordRelayEvent(event db.RelayEvent, received time.Time) (bool, error) {
+	ret := _m.Called(event, received)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribeByUniqueName")
+		panic("no return value specified for RecordRelayEvent")
 	}
 
-	var r0 db.Tribe
-	if rf, ok := ret.Get(0).(func(string) db.Tribe); ok {
-		r0 = rf(un)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.RelayEvent, time.Time) (bool, error)); ok {
+		return rf(event, received)
+	}
+	if rf, ok := ret.Get(0).(func(db.RelayEvent, time.Time) bool); ok {
+		r0 = rf(event, received)
 	} else {
-		r0 = ret.Get(0).(db.Tribe)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.RelayEvent, time.Time) error); ok {
+		r1 = rf(event, received)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetTribeByUniqueName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribeByUniqueName'
-type Database_GetTribeByUniqueName_Call struct {
+// Database_RecordRelayEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordRelayEvent'
+type Database_RecordRelayEvent_Call struct {
 	*mock.Call
 }
 
-// GetTribeByUniqueName is a helper method to define mock.On call
-//   - un string
-func (_e *Database_Expecter) GetTribeByUniqueName(un interface{}) *Database_GetTribeByUniqueName_Call {
-	return &Database_GetTribeByUniqueName_Call{Call: _e.mock.On("GetTribeByUniqueName", un)}
+// RecordRelayEvent is a helper method to define mock.On call
+//   - event db.RelayEvent
+//   - received time.Time
+func (_e *Database_Expecter) RecordRelayEvent(event interface{}, received interface{}) *Database_RecordRelayEvent_Call {
+	return &Database_RecordRelayEvent_Call{Call: _e.mock.On("RecordRelayEvent", event, received)}
 }
 
-func (_c *Database_GetTribeByUniqueName_Call) Run(run func(un string)) *Database_GetTribeByUniqueName_Call {
+func (_c *Database_RecordRelayEvent_Call) Run(run func(event db.RelayEvent, received time.Time)) *Database_RecordRelayEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.RelayEvent), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribeByUniqueName_Call) Return(_a0 db.Tribe) *Database_GetTribeByUniqueName_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_RecordRelayEvent_Call) Return(_a0 bool, _a1 error) *Database_RecordRelayEvent_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetTribeByUniqueName_Call) RunAndReturn(run func(string) db.Tribe) *Database_GetTribeByUniqueName_Call {
+func (_c *Database_RecordRelayEvent_Call) RunAndReturn(run func(db.RelayEvent, time.Time) (bool, error)) *Database_RecordRelayEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribesByAppUrl provides a mock function with given fields: aurl
-func (_m *Database) GetTribesByAppUrl(aurl string) []db.Tribe {
-	ret := _m.Called(aurl)
+// RecordWebhookAlert provides a mock function with given fields: webhook, fingerprint, draft
+func (_m *Database) RecordWebhookAlert(webhook db.WorkspaceWebhook, fingerprint string, draft db.NewBounty) (db.WebhookAlertResult, error) {
+	ret := _m.Called(webhook, fingerprint, draft)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribesByAppUrl")
+		panic("no return value specified for RecordWebhookAlert")
+	}
+
+	var r0 db.WebhookAlertResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceWebhook, string, db.NewBounty) (db.WebhookAlertResult, error)); ok {
+		return rf(webhook, fingerprint, draft)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceWebhook, string, db.NewBounty) db.WebhookAlertResult); ok {
+		r0 = rf(webhook, fingerprint, draft)
+	} else {
+		r0 = ret.Get(0).(db.WebhookAlertResult)
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
-		r0 = rf(aurl)
+	if rf, ok := ret.Get(1).(func(db.WorkspaceWebhook, string, db.NewBounty) error); ok {
+		r1 = rf(webhook, fingerprint, draft)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// Database_GetTribesByAppUrl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByAppUrl'
-type Database_GetTribesByAppUrl_Call struct {
+// Database_RecordWebhookAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordWebhookAlert'
+type Database_RecordWebhookAlert_Call struct {
 	*mock.Call
 }
 
-// GetTribesByAppUrl is a helper method to define mock.On call
-//   - aurl string
-func (_e *Database_Expecter) GetTribesByAppUrl(aurl interface{}) *Database_GetTribesByAppUrl_Call {
-	return &Database_GetTribesByAppUrl_Call{Call: _e.mock.On("GetTribesByAppUrl", aurl)}
+// RecordWebhookAlert is a helper method to define mock.On call
+//   - webhook db.WorkspaceWebhook
+//   - fingerprint string
+//   - draft db.NewBounty
+func (_e *Database_Expecter) RecordWebhookAlert(webhook interface{}, fingerprint interface{}, draft interface{}) *Database_RecordWebhookAlert_Call {
+	return &Database_RecordWebhookAlert_Call{Call: _e.mock.On("RecordWebhookAlert", webhook, fingerprint, draft)}
 }
 
-func (_c *Database_GetTribesByAppUrl_Call) Run(run func(aurl string)) *Database_GetTribesByAppUrl_Call {
+func (_c *Database_RecordWebhookAlert_Call) Run(run func(webhook db.WorkspaceWebhook, fingerprint string, draft db.NewBounty)) *Database_RecordWebhookAlert_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.WorkspaceWebhook), args[1].(string), args[2].(db.NewBounty))
 	})
 	return _c
 }
 
-func (_c *Database_GetTribesByAppUrl_Call) Return(_a0 []db.Tribe) *Database_GetTribesByAppUrl_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_RecordWebhookAlert_Call) Return(_a0 db.WebhookAlertResult, _a1 error) *Database_RecordWebhookAlert_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetTribesByAppUrl_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetTribesByAppUrl_Call {
+func (_c *Database_RecordWebhookAlert_Call) RunAndReturn(run func(db.WorkspaceWebhook, string, db.NewBounty) (db.WebhookAlertResult, error)) *Database_RecordWebhookAlert_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribesByOwner provides a mock function with given fields: pubkey
-func (_m *Database) GetTribesByOwner(pubkey string) []db.Tribe {
-	ret := _m.Called(pubkey)
+// RefreshBountyPriceStats provides a mock function with given fields:
+func (_m *Database) RefreshBountyPriceStats() error {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribesByOwner")
+		panic("no return value specified for RefreshBountyPriceStats")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
-		r0 = rf(pubkey)
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribesByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesByOwner'
-type Database_GetTribesByOwner_Call struct {
+// Database_RefreshBountyPriceStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshBountyPriceStats'
+type Database_RefreshBountyPriceStats_Call struct {
 	*mock.Call
 }
 
-// GetTribesByOwner is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetTribesByOwner(pubkey interface{}) *Database_GetTribesByOwner_Call {
-	return &Database_GetTribesByOwner_Call{Call: _e.mock.On("GetTribesByOwner", pubkey)}
+// RefreshBountyPriceStats is a helper method to define mock.On call
+func (_e *Database_Expecter) RefreshBountyPriceStats() *Database_RefreshBountyPriceStats_Call {
+	return &Database_RefreshBountyPriceStats_Call{Call: _e.mock.On("RefreshBountyPriceStats")}
 }
 
-func (_c *Database_GetTribesByOwner_Call) Run(run func(pubkey string)) *Database_GetTribesByOwner_Call {
+func (_c *Database_RefreshBountyPriceStats_Call) Run(run func()) *Database_RefreshBountyPriceStats_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run()
 	})
 	return _c
 }
 
-func (_c *Database_GetTribesByOwner_Call) Return(_a0 []db.Tribe) *Database_GetTribesByOwner_Call {
+func (_c *Database_RefreshBountyPriceStats_Call) Return(_a0 error) *Database_RefreshBountyPriceStats_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribesByOwner_Call) RunAndReturn(run func(string) []db.Tribe) *Database_GetTribesByOwner_Call {
+func (_c *Database_RefreshBountyPriceStats_Call) RunAndReturn(run func() error) *Database_RefreshBountyPriceStats_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTribesTotal provides a mock function with given fields:
-func (_m *Database) GetTribesTotal() int64 {
+// RefreshLeaderboardAggregates provides a mock function with given fields:
+func (_m *Database) RefreshLeaderboardAggregates() error {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTribesTotal")
+		panic("no return value specified for RefreshLeaderboardAggregates")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetTribesTotal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTribesTotal'
-type Database_GetTribesTotal_Call struct {
+// Database_RefreshLeaderboardAggregates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshLeaderboardAggregates'
+type Database_RefreshLeaderboardAggregates_Call struct {
 	*mock.Call
 }
 
-// GetTribesTotal is a helper method to define mock.On call
-func (_e *Database_Expecter) GetTribesTotal() *Database_GetTribesTotal_Call {
-	return &Database_GetTribesTotal_Call{Call: _e.mock.On("GetTribesTotal")}
+// RefreshLeaderboardAggregates is a helper method to define mock.On call
+func (_e *Database_Expecter) RefreshLeaderboardAggregates() *Database_RefreshLeaderboardAggregates_Call {
+	return &Database_RefreshLeaderboardAggregates_Call{Call: _e.mock.On("RefreshLeaderboardAggregates")}
 }
 
-func (_c *Database_GetTribesTotal_Call) Run(run func()) *Database_GetTribesTotal_Call {
+func (_c *Database_RefreshLeaderboardAggregates_Call) Run(run func()) *Database_RefreshLeaderboardAggregates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *Database_GetTribesTotal_Call) Return(_a0 int64) *Database_GetTribesTotal_Call {
+func (_c *Database_RefreshLeaderboardAggregates_Call) Return(_a0 error) *Database_RefreshLeaderboardAggregates_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTribesTotal_Call) RunAndReturn(run func() int64) *Database_GetTribesTotal_Call {
+func (_c *Database_RefreshLeaderboardAggregates_Call) RunAndReturn(run func() error) *Database_RefreshLeaderboardAggregates_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUnconfirmedGithub provides a mock function with given fields:
-func (_m *Database) GetUnconfirmedGithub() []db.Person {
-	ret := _m.Called()
+// ReleaseBudget provides a mock function with given fields: reservation
+func (_m *Database) ReleaseBudget(reservation db.BudgetReservation) error {
+	ret := _m.Called(reservation)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUnconfirmedGithub")
+		panic("no return value specified for ReleaseBudget")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func() []db.Person); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.BudgetReservation) error); ok {
+		r0 = rf(reservation)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetUnconfirmedGithub_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedGithub'
-type Database_GetUnconfirmedGithub_Call struct {
+// Database_ReleaseBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseBudget'
+type Database_ReleaseBudget_Call struct {
 	*mock.Call
 }
 
-// GetUnconfirmedGithub is a helper method to define mock.On call
-func (_e *Database_Expecter) GetUnconfirmedGithub() *Database_GetUnconfirmedGithub_Call {
-	return &Database_GetUnconfirmedGithub_Call{Call: _e.mock.On("GetUnconfirmedGithub")}
+// ReleaseBudget is a helper method to define mock.On call
+//   - reservation db.BudgetReservation
+func (_e *Database_Expecter) ReleaseBudget(reservation interface{}) *Database_ReleaseBudget_Call {
+	return &Database_ReleaseBudget_Call{Call: _e.mock.On("ReleaseBudget", reservation)}
 }
 
-func (_c *Database_GetUnconfirmedGithub_Call) Run(run func()) *Database_GetUnconfirmedGithub_Call {
+func (_c *Database_ReleaseBudget_Call) Run(run func(reservation db.BudgetReservation)) *Database_ReleaseBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(db.BudgetReservation))
 	})
 	return _c
 }
 
-func (_c *Database_GetUnconfirmedGithub_Call) Return(_a0 []db.Person) *Database_GetUnconfirmedGithub_Call {
+func (_c *Database_ReleaseBudget_Call) Return(_a0 error) *Database_ReleaseBudget_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetUnconfirmedGithub_Call) RunAndReturn(run func() []db.Person) *Database_GetUnconfirmedGithub_Call {
+func (_c *Database_ReleaseBudget_Call) RunAndReturn(run func(db.BudgetReservation) error) *Database_ReleaseBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUnconfirmedTwitter provides a mock function with given fields:
-func (_m *Database) GetUnconfirmedTwitter() []db.Person {
-	ret := _m.Called()
+// RemoveWorkspaceFromParentOrganization provides a mock function with given fields: orgUuid, workspaceUuid
+func (_m *Database) RemoveWorkspaceFromParentOrganization(orgUuid string, workspaceUuid string) error {
+	ret := _m.Called(orgUuid, workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUnconfirmedTwitter")
+		panic("no return value specified for RemoveWorkspaceFromParentOrganization")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func() []db.Person); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(orgUuid, workspaceUuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetUnconfirmedTwitter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedTwitter'
-type Database_GetUnconfirmedTwitter_Call struct {
+// Database_RemoveWorkspaceFromParentOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveWorkspaceFromParentOrganization'
+type Database_RemoveWorkspaceFromParentOrganization_Call struct {
 	*mock.Call
 }
 
-// GetUnconfirmedTwitter is a helper method to define mock.On call
-func (_e *Database_Expecter) GetUnconfirmedTwitter() *Database_GetUnconfirmedTwitter_Call {
-	return &Database_GetUnconfirmedTwitter_Call{Call: _e.mock.On("GetUnconfirmedTwitter")}
+// RemoveWorkspaceFromParentOrganization is a helper method to define mock.On call
+//   - orgUuid string
+//   - workspaceUuid string
+func (_e *Database_Expecter) RemoveWorkspaceFromParentOrganization(orgUuid interface{}, workspaceUuid interface{}) *Database_RemoveWorkspaceFromParentOrganization_Call {
+	return &Database_RemoveWorkspaceFromParentOrganization_Call{Call: _e.mock.On("RemoveWorkspaceFromParentOrganization", orgUuid, workspaceUuid)}
 }
 
-func (_c *Database_GetUnconfirmedTwitter_Call) Run(run func()) *Database_GetUnconfirmedTwitter_Call {
+func (_c *Database_RemoveWorkspaceFromParentOrganization_Call) Run(run func(orgUuid string, workspaceUuid string)) *Database_RemoveWorkspaceFromParentOrganization_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetUnconfirmedTwitter_Call) Return(_a0 []db.Person) *Database_GetUnconfirmedTwitter_Call {
+func (_c *Database_RemoveWorkspaceFromParentOrganization_Call) Return(_a0 error) *Database_RemoveWorkspaceFromParentOrganization_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetUnconfirmedTwitter_Call) RunAndReturn(run func() []db.Person) *Database_GetUnconfirmedTwitter_Call {
+func (_c *Database_RemoveWorkspaceFromParentOrganization_Call) RunAndReturn(run func(string, string) error) *Database_RemoveWorkspaceFromParentOrganization_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserAssignedWorkspaces provides a mock function with given fields: pubkey
-func (_m *Database) GetUserAssignedWorkspaces(pubkey string) []db.WorkspaceUsers {
-	ret := _m.Called(pubkey)
+// ReserveBudget provides a mock function with given fields: workspaceUuid, amount
+func (_m *Database) ReserveBudget(workspaceUuid string, amount uint) (db.BudgetReservation, error) {
+	ret := _m.Called(workspaceUuid, amount)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserAssignedWorkspaces")
+		panic("no return value specified for ReserveBudget")
 	}
 
-	var r0 []db.WorkspaceUsers
-	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceUsers); ok {
-		r0 = rf(pubkey)
+	var r0 db.BudgetReservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, uint) (db.BudgetReservation, error)); ok {
+		return rf(workspaceUuid, amount)
+	}
+	if rf, ok := ret.Get(0).(func(string, uint) db.BudgetReservation); ok {
+		r0 = rf(workspaceUuid, amount)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceUsers)
-		}
+		r0 = ret.Get(0).(db.BudgetReservation)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, uint) error); ok {
+		r1 = rf(workspaceUuid, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetUserAssignedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAssignedWorkspaces'
-type Database_GetUserAssignedWorkspaces_Call struct {
+// Database_ReserveBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveBudget'
+type Database_ReserveBudget_Call struct {
 	*mock.Call
 }
 
-// GetUserAssignedWorkspaces is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetUserAssignedWorkspaces(pubkey interface{}) *Database_GetUserAssignedWorkspaces_Call {
-	return &Database_GetUserAssignedWorkspaces_Call{Call: _e.mock.On("GetUserAssignedWorkspaces", pubkey)}
+// ReserveBudget is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - amount uint
+func (_e *Database_Expecter) ReserveBudget(workspaceUuid interface{}, amount interface{}) *Database_ReserveBudget_Call {
+	return &Database_ReserveBudget_Call{Call: _e.mock.On("ReserveBudget", workspaceUuid, amount)}
 }
 
-func (_c *Database_GetUserAssignedWorkspaces_Call) Run(run func(pubkey string)) *Database_GetUserAssignedWorkspaces_Call {
+func (_c *Database_ReserveBudget_Call) Run(run func(workspaceUuid string, amount uint)) *Database_ReserveBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetUserAssignedWorkspaces_Call) Return(_a0 []db.WorkspaceUsers) *Database_GetUserAssignedWorkspaces_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_ReserveBudget_Call) Return(_a0 db.BudgetReservation, _a1 error) *Database_ReserveBudget_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetUserAssignedWorkspaces_Call) RunAndReturn(run func(string) []db.WorkspaceUsers) *Database_GetUserAssignedWorkspaces_Call {
+func (_c *Database_ReserveBudget_Call) RunAndReturn(run func(string, uint) (db.BudgetReservation, error)) *Database_ReserveBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserBountiesCount provides a mock function with given fields: personKey, tabType
-func (_m *Database) GetUserBountiesCount(personKey string, tabType string) int64 {
-	ret := _m.Called(personKey, tabType)
+// ResetBountyActivity provides a mock function with given fields: id
+func (_m *Database) ResetBountyActivity(id uint) error {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserBountiesCount")
+		panic("no return value specified for ResetBountyActivity")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = rf(personKey, tabType)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetUserBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserBountiesCount'
-type Database_GetUserBountiesCount_Call struct {
+// Database_ResetBountyActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetBountyActivity'
+type Database_ResetBountyActivity_Call struct {
 	*mock.Call
 }
 
-// GetUserBountiesCount is a helper method to define mock.On call
-//   - personKey string
-//   - tabType string
-func (_e *Database_Expecter) GetUserBountiesCount(personKey interface{}, tabType interface{}) *Database_GetUserBountiesCount_Call {
-	return &Database_GetUserBountiesCount_Call{Call: _e.mock.On("GetUserBountiesCount", personKey, tabType)}
+// ResetBountyActivity is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) ResetBountyActivity(id interface{}) *Database_ResetBountyActivity_Call {
+	return &Database_ResetBountyActivity_Call{Call: _e.mock.On("ResetBountyActivity", id)}
 }
 
-func (_c *Database_GetUserBountiesCount_Call) Run(run func(personKey string, tabType string)) *Database_GetUserBountiesCount_Call {
+func (_c *Database_ResetBountyActivity_Call) Run(run func(id uint)) *Database_ResetBountyActivity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetUserBountiesCount_Call) Return(_a0 int64) *Database_GetUserBountiesCount_Call {
+func (_c *Database_ResetBountyActivity_Call) Return(_a0 error) *Database_ResetBountyActivity_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetUserBountiesCount_Call) RunAndReturn(run func(string, string) int64) *Database_GetUserBountiesCount_Call {
+func (_c *Database_ResetBountyActivity_Call) RunAndReturn(run func(uint) error) *Database_ResetBountyActivity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserCreatedWorkspaces provides a mock function with given fields: pubkey
-func (_m *Database) GetUserCreatedWorkspaces(pubkey string) []db.Workspace {
-	ret := _m.Called(pubkey)
+// RestoreWorkspace provides a mock function with given fields: uuid
+func (_m *Database) RestoreWorkspace(uuid string) (db.Workspace, error) {
+	ret := _m.Called(uuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserCreatedWorkspaces")
+		panic("no return value specified for RestoreWorkspace")
 	}
 
-	var r0 []db.Workspace
-	if rf, ok := ret.Get(0).(func(string) []db.Workspace); ok {
-		r0 = rf(pubkey)
+	var r0 db.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.Workspace, error)); ok {
+		return rf(uuid)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
+		r0 = rf(uuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Workspace)
-		}
+		r0 = ret.Get(0).(db.Workspace)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetUserCreatedWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserCreatedWorkspaces'
-type Database_GetUserCreatedWorkspaces_Call struct {
+// Database_RestoreWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreWorkspace'
+type Database_RestoreWorkspace_Call struct {
 	*mock.Call
 }
 
-// GetUserCreatedWorkspaces is a helper method to define mock.On call
-//   - pubkey string
-func (_e *Database_Expecter) GetUserCreatedWorkspaces(pubkey interface{}) *Database_GetUserCreatedWorkspaces_Call {
-	return &Database_GetUserCreatedWorkspaces_Call{Call: _e.mock.On("GetUserCreatedWorkspaces", pubkey)}
+// RestoreWorkspace is a helper method to define mock.On call
+//   - uuid string
+func (_e *Database_Expecter) RestoreWorkspace(uuid interface{}) *Database_RestoreWorkspace_Call {
+	return &Database_RestoreWorkspace_Call{Call: _e.mock.On("RestoreWorkspace", uuid)}
 }
 
-func (_c *Database_GetUserCreatedWorkspaces_Call) Run(run func(pubkey string)) *Database_GetUserCreatedWorkspaces_Call {
+func (_c *Database_RestoreWorkspace_Call) Run(run func(uuid string)) *Database_RestoreWorkspace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetUserCreatedWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetUserCreatedWorkspaces_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_RestoreWorkspace_Call) Return(_a0 db.Workspace, _a1 error) *Database_RestoreWorkspace_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetUserCreatedWorkspaces_Call) RunAndReturn(run func(string) []db.Workspace) *Database_GetUserCreatedWorkspaces_Call {
+func (_c *Database_RestoreWorkspace_Call) RunAndReturn(run func(string) (db.Workspace, error)) *Database_RestoreWorkspace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserInvoiceData provides a mock function with given fields: payment_request
-func (_m *Database) GetUserInvoiceData(payment_request string) db.UserInvoiceData {
-	ret := _m.Called(payment_request)
+// RevokeCalendarTokens provides a mock function with given fields: pubkey
+func (_m *Database) RevokeCalendarTokens(pubkey string) error {
+	ret := _m.Called(pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserInvoiceData")
+		panic("no return value specified for RevokeCalendarTokens")
 	}
 
-	var r0 db.UserInvoiceData
-	if rf, ok := ret.Get(0).(func(string) db.UserInvoiceData); ok {
-		r0 = rf(payment_request)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pubkey)
 	} else {
-		r0 = ret.Get(0).(db.UserInvoiceData)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetUserInvoiceData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserInvoiceData'
-type Database_GetUserInvoiceData_Call struct {
+// Database_RevokeCalendarTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeCalendarTokens'
+type Database_RevokeCalendarTokens_Call struct {
 	*mock.Call
 }
 
-// GetUserInvoiceData is a helper method to define mock.On call
-//   - payment_request string
-func (_e *Database_Expecter) GetUserInvoiceData(payment_request interface{}) *Database_GetUserInvoiceData_Call {
-	return &Database_GetUserInvoiceData_Call{Call: _e.mock.On("GetUserInvoiceData", payment_request)}
+// RevokeCalendarTokens is a helper method to define mock.On call
+//   - pubkey string
+func (_e *Database_Expecter) RevokeCalendarTokens(pubkey interface{}) *Database_RevokeCalendarTokens_Call {
+	return &Database_RevokeCalendarTokens_Call{Call: _e.mock.On("RevokeCalendarTokens", pubkey)}
 }
 
-func (_c *Database_GetUserInvoiceData_Call) Run(run func(payment_request string)) *Database_GetUserInvoiceData_Call {
+func (_c *Database_RevokeCalendarTokens_Call) Run(run func(pubkey string)) *Database_RevokeCalendarTokens_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetUserInvoiceData_Call) Return(_a0 db.UserInvoiceData) *Database_GetUserInvoiceData_Call {
+func (_c *Database_RevokeCalendarTokens_Call) Return(_a0 error) *Database_RevokeCalendarTokens_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetUserInvoiceData_Call) RunAndReturn(run func(string) db.UserInvoiceData) *Database_GetUserInvoiceData_Call {
+func (_c *Database_RevokeCalendarTokens_Call) RunAndReturn(run func(string) error) *Database_RevokeCalendarTokens_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBounties provides a mock function with given fields: r, workspace_uuid
-func (_m *Database) GetWorkspaceBounties(r *http.Request, workspace_uuid string) []db.NewBounty {
-	ret := _m.Called(r, workspace_uuid)
+// RevokeWorkspaceApiToken provides a mock function with given fields: workspaceUuid, id
+func (_m *Database) RevokeWorkspaceApiToken(workspaceUuid string, id uint) error {
+	ret := _m.Called(workspaceUuid, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBounties")
+		panic("no return value specified for RevokeWorkspaceApiToken")
 	}
 
-	var r0 []db.NewBounty
-	if rf, ok := ret.Get(0).(func(*http.Request, string) []db.NewBounty); ok {
-		r0 = rf(r, workspace_uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, uint) error); ok {
+		r0 = rf(workspaceUuid, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewBounty)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceBounties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBounties'
-type Database_GetWorkspaceBounties_Call struct {
+// Database_RevokeWorkspaceApiToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeWorkspaceApiToken'
+type Database_RevokeWorkspaceApiToken_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBounties is a helper method to define mock.On call
-//   - r *http.Request
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceBounties(r interface{}, workspace_uuid interface{}) *Database_GetWorkspaceBounties_Call {
-	return &Database_GetWorkspaceBounties_Call{Call: _e.mock.On("GetWorkspaceBounties", r, workspace_uuid)}
+// RevokeWorkspaceApiToken is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - id uint
+func (_e *Database_Expecter) RevokeWorkspaceApiToken(workspaceUuid interface{}, id interface{}) *Database_RevokeWorkspaceApiToken_Call {
+	return &Database_RevokeWorkspaceApiToken_Call{Call: _e.mock.On("RevokeWorkspaceApiToken", workspaceUuid, id)}
 }
 
-func (_c *Database_GetWorkspaceBounties_Call) Run(run func(r *http.Request, workspace_uuid string)) *Database_GetWorkspaceBounties_Call {
+func (_c *Database_RevokeWorkspaceApiToken_Call) Run(run func(workspaceUuid string, id uint)) *Database_RevokeWorkspaceApiToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request), args[1].(string))
+		run(args[0].(string), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBounties_Call) Return(_a0 []db.NewBounty) *Database_GetWorkspaceBounties_Call {
+func (_c *Database_RevokeWorkspaceApiToken_Call) Return(_a0 error) *Database_RevokeWorkspaceApiToken_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBounties_Call) RunAndReturn(run func(*http.Request, string) []db.NewBounty) *Database_GetWorkspaceBounties_Call {
+func (_c *Database_RevokeWorkspaceApiToken_Call) RunAndReturn(run func(string, uint) error) *Database_RevokeWorkspaceApiToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBountiesCount provides a mock function with given fields: r, workspace_uuid
-func (_m *Database) GetWorkspaceBountiesCount(r *http.Request, workspace_uuid string) int64 {
-	ret := _m.Called(r, workspace_uuid)
+// RollbackPhasePlan provides a mock function with given fields: planUuid, pubkey, now
+func (_m *Database) RollbackPhasePlan(planUuid string, pubkey string, now time.Time) (db.TicketBatchRollback, error) {
+	ret := _m.Called(planUuid, pubkey, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackPhasePlan")
+	}
 
-	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBountiesCount")
+	var r0 db.TicketBatchRollback
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) (db.TicketBatchRollback, error)); ok {
+		return rf(planUuid, pubkey, now)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) db.TicketBatchRollback); ok {
+		r0 = rf(planUuid, pubkey, now)
+	} else {
+		r0 = ret.Get(0).(db.TicketBatchRollback)
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(*http.Request, string) int64); ok {
-		r0 = rf(r, workspace_uuid)
+	if rf, ok := ret.Get(1).(func(string, string, time.Time) error); ok {
+		r1 = rf(planUuid, pubkey, now)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// Database_GetWorkspaceBountiesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountiesCount'
-type Database_GetWorkspaceBountiesCount_Call struct {
+// Database_RollbackPhasePlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollbackPhasePlan'
+type Database_RollbackPhasePlan_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBountiesCount is a helper method to define mock.On call
-//   - r *http.Request
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceBountiesCount(r interface{}, workspace_uuid interface{}) *Database_GetWorkspaceBountiesCount_Call {
-	return &Database_GetWorkspaceBountiesCount_Call{Call: _e.mock.On("GetWorkspaceBountiesCount", r, workspace_uuid)}
+// RollbackPhasePlan is a helper method to define mock.On call
+//   - planUuid string
+//   - pubkey string
+//   - now time.Time
+func (_e *Database_Expecter) RollbackPhasePlan(planUuid interface{}, pubkey interface{}, now interface{}) *Database_RollbackPhasePlan_Call {
+	return &Database_RollbackPhasePlan_Call{Call: _e.mock.On("RollbackPhasePlan", planUuid, pubkey, now)}
 }
 
-func (_c *Database_GetWorkspaceBountiesCount_Call) Run(run func(r *http.Request, workspace_uuid string)) *Database_GetWorkspaceBountiesCount_Call {
+func (_c *Database_RollbackPhasePlan_Call) Run(run func(planUuid string, pubkey string, now time.Time)) *Database_RollbackPhasePlan_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBountiesCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountiesCount_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_RollbackPhasePlan_Call) Return(_a0 db.TicketBatchRollback, _a1 error) *Database_RollbackPhasePlan_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBountiesCount_Call) RunAndReturn(run func(*http.Request, string) int64) *Database_GetWorkspaceBountiesCount_Call {
+func (_c *Database_RollbackPhasePlan_Call) RunAndReturn(run func(string, string, time.Time) (db.TicketBatchRollback, error)) *Database_RollbackPhasePlan_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBountyCount provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceBountyCount(uuid string) int64 {
-	ret := _m.Called(uuid)
+// SatsPaidPercentage provides a mock function with given fields: r, workspace
+func (_m *Database) SatsPaidPercentage(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBountyCount")
+		panic("no return value specified for SatsPaidPercentage")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(uuid)
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(uint)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceBountyCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBountyCount'
-type Database_GetWorkspaceBountyCount_Call struct {
+// Database_SatsPaidPercentage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SatsPaidPercentage'
+type Database_SatsPaidPercentage_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBountyCount is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceBountyCount(uuid interface{}) *Database_GetWorkspaceBountyCount_Call {
-	return &Database_GetWorkspaceBountyCount_Call{Call: _e.mock.On("GetWorkspaceBountyCount", uuid)}
+// SatsPaidPercentage is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) SatsPaidPercentage(r interface{}, workspace interface{}) *Database_SatsPaidPercentage_Call {
+	return &Database_SatsPaidPercentage_Call{Call: _e.mock.On("SatsPaidPercentage", r, workspace)}
 }
 
-func (_c *Database_GetWorkspaceBountyCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceBountyCount_Call {
+func (_c *Database_SatsPaidPercentage_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_SatsPaidPercentage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.PaymentDateRange), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBountyCount_Call) Return(_a0 int64) *Database_GetWorkspaceBountyCount_Call {
+func (_c *Database_SatsPaidPercentage_Call) Return(_a0 uint) *Database_SatsPaidPercentage_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBountyCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceBountyCount_Call {
+func (_c *Database_SatsPaidPercentage_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_SatsPaidPercentage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBudget provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceBudget(workspace_uuid string) db.NewBountyBudget {
-	ret := _m.Called(workspace_uuid)
+// SaveConfigOverride provides a mock function with given fields: key, value, pubkey
+func (_m *Database) SaveConfigOverride(key string, value string, pubkey string) (db.ConfigOverride, error) {
+	ret := _m.Called(key, value, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBudget")
+		panic("no return value specified for SaveConfigOverride")
 	}
 
-	var r0 db.NewBountyBudget
-	if rf, ok := ret.Get(0).(func(string) db.NewBountyBudget); ok {
-		r0 = rf(workspace_uuid)
+	var r0 db.ConfigOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (db.ConfigOverride, error)); ok {
+		return rf(key, value, pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) db.ConfigOverride); ok {
+		r0 = rf(key, value, pubkey)
 	} else {
-		r0 = ret.Get(0).(db.NewBountyBudget)
+		r0 = ret.Get(0).(db.ConfigOverride)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(key, value, pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudget'
-type Database_GetWorkspaceBudget_Call struct {
+// Database_SaveConfigOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveConfigOverride'
+type Database_SaveConfigOverride_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBudget is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceBudget(workspace_uuid interface{}) *Database_GetWorkspaceBudget_Call {
-	return &Database_GetWorkspaceBudget_Call{Call: _e.mock.On("GetWorkspaceBudget", workspace_uuid)}
+// SaveConfigOverride is a helper method to define mock.On call
+//   - key string
+//   - value string
+//   - pubkey string
+func (_e *Database_Expecter) SaveConfigOverride(key interface{}, value interface{}, pubkey interface{}) *Database_SaveConfigOverride_Call {
+	return &Database_SaveConfigOverride_Call{Call: _e.mock.On("SaveConfigOverride", key, value, pubkey)}
 }
 
-func (_c *Database_GetWorkspaceBudget_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudget_Call {
+func (_c *Database_SaveConfigOverride_Call) Run(run func(key string, value string, pubkey string)) *Database_SaveConfigOverride_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBudget_Call) Return(_a0 db.NewBountyBudget) *Database_GetWorkspaceBudget_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SaveConfigOverride_Call) Return(_a0 db.ConfigOverride, _a1 error) *Database_SaveConfigOverride_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBudget_Call) RunAndReturn(run func(string) db.NewBountyBudget) *Database_GetWorkspaceBudget_Call {
+func (_c *Database_SaveConfigOverride_Call) RunAndReturn(run func(string, string, string) (db.ConfigOverride, error)) *Database_SaveConfigOverride_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceBudgetHistory provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceBudgetHistory(workspace_uuid string) []db.BudgetHistoryData {
-	ret := _m.Called(workspace_uuid)
+// SaveFeatureCallTranscript provides a mock function with given fields: transcript, chunks
+func (_m *Database) SaveFeatureCallTranscript(transcript db.FeatureCallTranscript, chunks []db.FeatureCallTranscriptChunk) (db.FeatureCallTranscript, error) {
+	ret := _m.Called(transcript, chunks)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceBudgetHistory")
+		panic("no return value specified for SaveFeatureCallTranscript")
 	}
 
-	var r0 []db.BudgetHistoryData
-	if rf, ok := ret.Get(0).(func(string) []db.BudgetHistoryData); ok {
-		r0 = rf(workspace_uuid)
+	var r0 db.FeatureCallTranscript
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.FeatureCallTranscript, []db.FeatureCallTranscriptChunk) (db.FeatureCallTranscript, error)); ok {
+		return rf(transcript, chunks)
+	}
+	if rf, ok := ret.Get(0).(func(db.FeatureCallTranscript, []db.FeatureCallTranscriptChunk) db.FeatureCallTranscript); ok {
+		r0 = rf(transcript, chunks)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.BudgetHistoryData)
-		}
+		r0 = ret.Get(0).(db.FeatureCallTranscript)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.FeatureCallTranscript, []db.FeatureCallTranscriptChunk) error); ok {
+		r1 = rf(transcript, chunks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceBudgetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceBudgetHistory'
-type Database_GetWorkspaceBudgetHistory_Call struct {
+// Database_SaveFeatureCallTranscript_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveFeatureCallTranscript'
+type Database_SaveFeatureCallTranscript_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceBudgetHistory is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceBudgetHistory(workspace_uuid interface{}) *Database_GetWorkspaceBudgetHistory_Call {
-	return &Database_GetWorkspaceBudgetHistory_Call{Call: _e.mock.On("GetWorkspaceBudgetHistory", workspace_uuid)}
+// SaveFeatureCallTranscript is a helper method to define mock.On call
+//   - transcript db.FeatureCallTranscript
+//   - chunks []db.FeatureCallTranscriptChunk
+func (_e *Database_Expecter) SaveFeatureCallTranscript(transcript interface{}, chunks interface{}) *Database_SaveFeatureCallTranscript_Call {
+	return &Database_SaveFeatureCallTranscript_Call{Call: _e.mock.On("SaveFeatureCallTranscript", transcript, chunks)}
 }
 
-func (_c *Database_GetWorkspaceBudgetHistory_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceBudgetHistory_Call {
+func (_c *Database_SaveFeatureCallTranscript_Call) Run(run func(transcript db.FeatureCallTranscript, chunks []db.FeatureCallTranscriptChunk)) *Database_SaveFeatureCallTranscript_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.FeatureCallTranscript), args[1].([]db.FeatureCallTranscriptChunk))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBudgetHistory_Call) Return(_a0 []db.BudgetHistoryData) *Database_GetWorkspaceBudgetHistory_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SaveFeatureCallTranscript_Call) Return(_a0 db.FeatureCallTranscript, _a1 error) *Database_SaveFeatureCallTranscript_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceBudgetHistory_Call) RunAndReturn(run func(string) []db.BudgetHistoryData) *Database_GetWorkspaceBudgetHistory_Call {
+func (_c *Database_SaveFeatureCallTranscript_Call) RunAndReturn(run func(db.FeatureCallTranscript, []db.FeatureCallTranscriptChunk) (db.FeatureCallTranscript, error)) *Database_SaveFeatureCallTranscript_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceByName provides a mock function with given fields: name
-func (_m *Database) GetWorkspaceByName(name string) db.Workspace {
-	ret := _m.Called(name)
+// SaveJiraUserLink provides a mock function with given fields: link
+func (_m *Database) SaveJiraUserLink(link db.JiraUserLink) (db.JiraUserLink, error) {
+	ret := _m.Called(link)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceByName")
+		panic("no return value specified for SaveJiraUserLink")
 	}
 
-	var r0 db.Workspace
-	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
-		r0 = rf(name)
+	var r0 db.JiraUserLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.JiraUserLink) (db.JiraUserLink, error)); ok {
+		return rf(link)
+	}
+	if rf, ok := ret.Get(0).(func(db.JiraUserLink) db.JiraUserLink); ok {
+		r0 = rf(link)
 	} else {
-		r0 = ret.Get(0).(db.Workspace)
+		r0 = ret.Get(0).(db.JiraUserLink)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.JiraUserLink) error); ok {
+		r1 = rf(link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceByName'
-type Database_GetWorkspaceByName_Call struct {
+// Database_SaveJiraUserLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveJiraUserLink'
+type Database_SaveJiraUserLink_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceByName is a helper method to define mock.On call
-//   - name string
-func (_e *Database_Expecter) GetWorkspaceByName(name interface{}) *Database_GetWorkspaceByName_Call {
-	return &Database_GetWorkspaceByName_Call{Call: _e.mock.On("GetWorkspaceByName", name)}
+// SaveJiraUserLink is a helper method to define mock.On call
+//   - link db.JiraUserLink
+func (_e *Database_Expecter) SaveJiraUserLink(link interface{}) *Database_SaveJiraUserLink_Call {
+	return &Database_SaveJiraUserLink_Call{Call: _e.mock.On("SaveJiraUserLink", link)}
 }
 
-func (_c *Database_GetWorkspaceByName_Call) Run(run func(name string)) *Database_GetWorkspaceByName_Call {
+func (_c *Database_SaveJiraUserLink_Call) Run(run func(link db.JiraUserLink)) *Database_SaveJiraUserLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.JiraUserLink))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceByName_Call) Return(_a0 db.Workspace) *Database_GetWorkspaceByName_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SaveJiraUserLink_Call) Return(_a0 db.JiraUserLink, _a1 error) *Database_SaveJiraUserLink_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceByName_Call) RunAndReturn(run func(string) db.Workspace) *Database_GetWorkspaceByName_Call {
+func (_c *Database_SaveJiraUserLink_Call) RunAndReturn(run func(db.JiraUserLink) (db.JiraUserLink, error)) *Database_SaveJiraUserLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceByUuid provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceByUuid(uuid string) db.Workspace {
-	ret := _m.Called(uuid)
+// SavePhasePlanTickets provides a mock function with given fields: planUuid, tickets
+func (_m *Database) SavePhasePlanTickets(planUuid string, tickets []db.PhasePlanTicket) (db.PhasePlan, error) {
+	ret := _m.Called(planUuid, tickets)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceByUuid")
+		panic("no return value specified for SavePhasePlanTickets")
 	}
 
-	var r0 db.Workspace
-	if rf, ok := ret.Get(0).(func(string) db.Workspace); ok {
-		r0 = rf(uuid)
+	var r0 db.PhasePlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []db.PhasePlanTicket) (db.PhasePlan, error)); ok {
+		return rf(planUuid, tickets)
+	}
+	if rf, ok := ret.Get(0).(func(string, []db.PhasePlanTicket) db.PhasePlan); ok {
+		r0 = rf(planUuid, tickets)
 	} else {
-		r0 = ret.Get(0).(db.Workspace)
+		r0 = ret.Get(0).(db.PhasePlan)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, []db.PhasePlanTicket) error); ok {
+		r1 = rf(planUuid, tickets)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceByUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceByUuid'
-type Database_GetWorkspaceByUuid_Call struct {
+// Database_SavePhasePlanTickets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePhasePlanTickets'
+type Database_SavePhasePlanTickets_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceByUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceByUuid(uuid interface{}) *Database_GetWorkspaceByUuid_Call {
-	return &Database_GetWorkspaceByUuid_Call{Call: _e.mock.On("GetWorkspaceByUuid", uuid)}
+// SavePhasePlanTickets is a helper method to define mock.On call
+//   - planUuid string
+//   - tickets []db.PhasePlanTicket
+func (_e *Database_Expecter) SavePhasePlanTickets(planUuid interface{}, tickets interface{}) *Database_SavePhasePlanTickets_Call {
+	return &Database_SavePhasePlanTickets_Call{Call: _e.mock.On("SavePhasePlanTickets", planUuid, tickets)}
 }
 
-func (_c *Database_GetWorkspaceByUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceByUuid_Call {
+func (_c *Database_SavePhasePlanTickets_Call) Run(run func(planUuid string, tickets []db.PhasePlanTicket)) *Database_SavePhasePlanTickets_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].([]db.PhasePlanTicket))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceByUuid_Call) Return(_a0 db.Workspace) *Database_GetWorkspaceByUuid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SavePhasePlanTickets_Call) Return(_a0 db.PhasePlan, _a1 error) *Database_SavePhasePlanTickets_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceByUuid_Call) RunAndReturn(run func(string) db.Workspace) *Database_GetWorkspaceByUuid_Call {
+func (_c *Database_SavePhasePlanTickets_Call) RunAndReturn(run func(string, []db.PhasePlanTicket) (db.PhasePlan, error)) *Database_SavePhasePlanTickets_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceFeaturesCount provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceFeaturesCount(uuid string) int64 {
-	ret := _m.Called(uuid)
+// SaveWorkspaceEmbeddings provides a mock function with given fields: workspaceUuid, sourceType, sourceId, chunks
+func (_m *Database) SaveWorkspaceEmbeddings(workspaceUuid string, sourceType db.EmbeddingSourceType, sourceId string, chunks []db.WorkspaceEmbedding) error {
+	ret := _m.Called(workspaceUuid, sourceType, sourceId, chunks)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceFeaturesCount")
+		panic("no return value specified for SaveWorkspaceEmbeddings")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, db.EmbeddingSourceType, string, []db.WorkspaceEmbedding) error); ok {
+		r0 = rf(workspaceUuid, sourceType, sourceId, chunks)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceFeaturesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceFeaturesCount'
-type Database_GetWorkspaceFeaturesCount_Call struct {
+// Database_SaveWorkspaceEmbeddings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveWorkspaceEmbeddings'
+type Database_SaveWorkspaceEmbeddings_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceFeaturesCount is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceFeaturesCount(uuid interface{}) *Database_GetWorkspaceFeaturesCount_Call {
-	return &Database_GetWorkspaceFeaturesCount_Call{Call: _e.mock.On("GetWorkspaceFeaturesCount", uuid)}
+// SaveWorkspaceEmbeddings is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - sourceType db.EmbeddingSourceType
+//   - sourceId string
+//   - chunks []db.WorkspaceEmbedding
+func (_e *Database_Expecter) SaveWorkspaceEmbeddings(workspaceUuid interface{}, sourceType interface{}, sourceId interface{}, chunks interface{}) *Database_SaveWorkspaceEmbeddings_Call {
+	return &Database_SaveWorkspaceEmbeddings_Call{Call: _e.mock.On("SaveWorkspaceEmbeddings", workspaceUuid, sourceType, sourceId, chunks)}
 }
 
-func (_c *Database_GetWorkspaceFeaturesCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceFeaturesCount_Call {
+func (_c *Database_SaveWorkspaceEmbeddings_Call) Run(run func(workspaceUuid string, sourceType db.EmbeddingSourceType, sourceId string, chunks []db.WorkspaceEmbedding)) *Database_SaveWorkspaceEmbeddings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(db.EmbeddingSourceType), args[2].(string), args[3].([]db.WorkspaceEmbedding))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceFeaturesCount_Call) Return(_a0 int64) *Database_GetWorkspaceFeaturesCount_Call {
+func (_c *Database_SaveWorkspaceEmbeddings_Call) Return(_a0 error) *Database_SaveWorkspaceEmbeddings_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceFeaturesCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceFeaturesCount_Call {
+func (_c *Database_SaveWorkspaceEmbeddings_Call) RunAndReturn(run func(string, db.EmbeddingSourceType, string, []db.WorkspaceEmbedding) error) *Database_SaveWorkspaceEmbeddings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceInvoices provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceInvoices(workspace_uuid string) []db.NewInvoiceList {
-	ret := _m.Called(workspace_uuid)
+// SaveWorkspaceSettings provides a mock function with given fields: settings
+func (_m *Database) SaveWorkspaceSettings(settings db.WorkspaceSettings) (db.WorkspaceSettings, error) {
+	ret := _m.Called(settings)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceInvoices")
+		panic("no return value specified for SaveWorkspaceSettings")
 	}
 
-	var r0 []db.NewInvoiceList
-	if rf, ok := ret.Get(0).(func(string) []db.NewInvoiceList); ok {
-		r0 = rf(workspace_uuid)
+	var r0 db.WorkspaceSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceSettings) (db.WorkspaceSettings, error)); ok {
+		return rf(settings)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceSettings) db.WorkspaceSettings); ok {
+		r0 = rf(settings)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.NewInvoiceList)
-		}
+		r0 = ret.Get(0).(db.WorkspaceSettings)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.WorkspaceSettings) error); ok {
+		r1 = rf(settings)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_GetWorkspaceInvoices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceInvoices'
-type Database_GetWorkspaceInvoices_Call struct {
+// Database_SaveWorkspaceSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveWorkspaceSettings'
+type Database_SaveWorkspaceSettings_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceInvoices is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceInvoices(workspace_uuid interface{}) *Database_GetWorkspaceInvoices_Call {
-	return &Database_GetWorkspaceInvoices_Call{Call: _e.mock.On("GetWorkspaceInvoices", workspace_uuid)}
+// SaveWorkspaceSettings is a helper method to define mock.On call
+//   - settings db.WorkspaceSettings
+func (_e *Database_Expecter) SaveWorkspaceSettings(settings interface{}) *Database_SaveWorkspaceSettings_Call {
+	return &Database_SaveWorkspaceSettings_Call{Call: _e.mock.On("SaveWorkspaceSettings", settings)}
 }
 
-func (_c *Database_GetWorkspaceInvoices_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceInvoices_Call {
+func (_c *Database_SaveWorkspaceSettings_Call) Run(run func(settings db.WorkspaceSettings)) *Database_SaveWorkspaceSettings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.WorkspaceSettings))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceInvoices_Call) Return(_a0 []db.NewInvoiceList) *Database_GetWorkspaceInvoices_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SaveWorkspaceSettings_Call) Return(_a0 db.WorkspaceSettings, _a1 error) *Database_SaveWorkspaceSettings_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceInvoices_Call) RunAndReturn(run func(string) []db.NewInvoiceList) *Database_GetWorkspaceInvoices_Call {
+func (_c *Database_SaveWorkspaceSettings_Call) RunAndReturn(run func(db.WorkspaceSettings) (db.WorkspaceSettings, error)) *Database_SaveWorkspaceSettings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceInvoicesCount provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceInvoicesCount(workspace_uuid string) int64 {
-	ret := _m.Called(workspace_uuid)
+// SearchBots provides a mock function with given fields: s, limit, offset
+func (_m *Database) SearchBots(s string, limit int, offset int) []db.BotRes {
+	ret := _m.Called(s, limit, offset)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceInvoicesCount")
+		panic("no return value specified for SearchBots")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(workspace_uuid)
+	var r0 []db.BotRes
+	if rf, ok := ret.Get(0).(func(string, int, int) []db.BotRes); ok {
+		r0 = rf(s, limit, offset)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BotRes)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceInvoicesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceInvoicesCount'
-type Database_GetWorkspaceInvoicesCount_Call struct {
+// Database_SearchBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchBots'
+type Database_SearchBots_Call struct {
 	*mock.Call
 }
-
-// GetWorkspaceInvoicesCount is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceInvoicesCount(workspace_uuid interface{}) *Database_GetWorkspaceInvoicesCount_Call {
-	return &Database_GetWorkspaceInvoicesCount_Call{Call: _e.mock.On("GetWorkspaceInvoicesCount", workspace_uuid)}
+
+// SearchBots is a helper method to define mock.On call
+//   - s string
+//   - limit int
+//   - offset int
+func (_e *Database_Expecter) SearchBots(s interface{}, limit interface{}, offset interface{}) *Database_SearchBots_Call {
+	return &Database_SearchBots_Call{Call: _e.mock.On("SearchBots", s, limit, offset)}
 }
 
-func (_c *Database_GetWorkspaceInvoicesCount_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceInvoicesCount_Call {
+func (_c *Database_SearchBots_Call) Run(run func(s string, limit int, offset int)) *Database_SearchBots_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(int), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceInvoicesCount_Call) Return(_a0 int64) *Database_GetWorkspaceInvoicesCount_Call {
+func (_c *Database_SearchBots_Call) Return(_a0 []db.BotRes) *Database_SearchBots_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceInvoicesCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceInvoicesCount_Call {
+func (_c *Database_SearchBots_Call) RunAndReturn(run func(string, int, int) []db.BotRes) *Database_SearchBots_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceRepoByWorkspaceUuidAndRepoUuid provides a mock function with given fields: workspace_uuid, uuid
-func (_m *Database) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid string, uuid string) (db.WorkspaceRepositories, error) {
-	ret := _m.Called(workspace_uuid, uuid)
+// SearchFeatureTranscriptChunks provides a mock function with given fields: featureUuid, query
+func (_m *Database) SearchFeatureTranscriptChunks(featureUuid string, query string) []db.FeatureCallTranscriptChunk {
+	ret := _m.Called(featureUuid, query)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceRepoByWorkspaceUuidAndRepoUuid")
-	}
-
-	var r0 db.WorkspaceRepositories
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (db.WorkspaceRepositories, error)); ok {
-		return rf(workspace_uuid, uuid)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceRepositories); ok {
-		r0 = rf(workspace_uuid, uuid)
-	} else {
-		r0 = ret.Get(0).(db.WorkspaceRepositories)
+		panic("no return value specified for SearchFeatureTranscriptChunks")
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(workspace_uuid, uuid)
+	var r0 []db.FeatureCallTranscriptChunk
+	if rf, ok := ret.Get(0).(func(string, string) []db.FeatureCallTranscriptChunk); ok {
+		r0 = rf(featureUuid, query)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeatureCallTranscriptChunk)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceRepoByWorkspaceUuidAndRepoUuid'
-type Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call struct {
+// Database_SearchFeatureTranscriptChunks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchFeatureTranscriptChunks'
+type Database_SearchFeatureTranscriptChunks_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceRepoByWorkspaceUuidAndRepoUuid is a helper method to define mock.On call
-//   - workspace_uuid string
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceRepoByWorkspaceUuidAndRepoUuid(workspace_uuid interface{}, uuid interface{}) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
-	return &Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call{Call: _e.mock.On("GetWorkspaceRepoByWorkspaceUuidAndRepoUuid", workspace_uuid, uuid)}
+// SearchFeatureTranscriptChunks is a helper method to define mock.On call
+//   - featureUuid string
+//   - query string
+func (_e *Database_Expecter) SearchFeatureTranscriptChunks(featureUuid interface{}, query interface{}) *Database_SearchFeatureTranscriptChunks_Call {
+	return &Database_SearchFeatureTranscriptChunks_Call{Call: _e.mock.On("SearchFeatureTranscriptChunks", featureUuid, query)}
 }
 
-func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) Run(run func(workspace_uuid string, uuid string)) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+func (_c *Database_SearchFeatureTranscriptChunks_Call) Run(run func(featureUuid string, query string)) *Database_SearchFeatureTranscriptChunks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) Return(_a0 db.WorkspaceRepositories, _a1 error) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_SearchFeatureTranscriptChunks_Call) Return(_a0 []db.FeatureCallTranscriptChunk) *Database_SearchFeatureTranscriptChunks_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call) RunAndReturn(run func(string, string) (db.WorkspaceRepositories, error)) *Database_GetWorkspaceRepoByWorkspaceUuidAndRepoUuid_Call {
+func (_c *Database_SearchFeatureTranscriptChunks_Call) RunAndReturn(run func(string, string) []db.FeatureCallTranscriptChunk) *Database_SearchFeatureTranscriptChunks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceRepositorByWorkspaceUuid provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceRepositorByWorkspaceUuid(uuid string) []db.WorkspaceRepositories {
-	ret := _m.Called(uuid)
+// SearchPeople provides a mock function with given fields: s, limit, offset
+func (_m *Database) SearchPeople(s string, limit int, offset int) []db.Person {
+	ret := _m.Called(s, limit, offset)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceRepositorByWorkspaceUuid")
+		panic("no return value specified for SearchPeople")
 	}
 
-	var r0 []db.WorkspaceRepositories
-	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceRepositories); ok {
-		r0 = rf(uuid)
+	var r0 []db.Person
+	if rf, ok := ret.Get(0).(func(string, int, int) []db.Person); ok {
+		r0 = rf(s, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceRepositories)
+			r0 = ret.Get(0).([]db.Person)
 		}
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceRepositorByWorkspaceUuid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceRepositorByWorkspaceUuid'
-type Database_GetWorkspaceRepositorByWorkspaceUuid_Call struct {
+// Database_SearchPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchPeople'
+type Database_SearchPeople_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceRepositorByWorkspaceUuid is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceRepositorByWorkspaceUuid(uuid interface{}) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
-	return &Database_GetWorkspaceRepositorByWorkspaceUuid_Call{Call: _e.mock.On("GetWorkspaceRepositorByWorkspaceUuid", uuid)}
+// SearchPeople is a helper method to define mock.On call
+//   - s string
+//   - limit int
+//   - offset int
+func (_e *Database_Expecter) SearchPeople(s interface{}, limit interface{}, offset interface{}) *Database_SearchPeople_Call {
+	return &Database_SearchPeople_Call{Call: _e.mock.On("SearchPeople", s, limit, offset)}
 }
 
-func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) Run(run func(uuid string)) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+func (_c *Database_SearchPeople_Call) Run(run func(s string, limit int, offset int)) *Database_SearchPeople_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(int), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) Return(_a0 []db.WorkspaceRepositories) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+func (_c *Database_SearchPeople_Call) Return(_a0 []db.Person) *Database_SearchPeople_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceRepositorByWorkspaceUuid_Call) RunAndReturn(run func(string) []db.WorkspaceRepositories) *Database_GetWorkspaceRepositorByWorkspaceUuid_Call {
+func (_c *Database_SearchPeople_Call) RunAndReturn(run func(string, int, int) []db.Person) *Database_SearchPeople_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceStatusBudget provides a mock function with given fields: workspace_uuid
-func (_m *Database) GetWorkspaceStatusBudget(workspace_uuid string) db.StatusBudget {
-	ret := _m.Called(workspace_uuid)
+// SearchTribes provides a mock function with given fields: s
+func (_m *Database) SearchTribes(s string) []db.Tribe {
+	ret := _m.Called(s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceStatusBudget")
+		panic("no return value specified for SearchTribes")
 	}
 
-	var r0 db.StatusBudget
-	if rf, ok := ret.Get(0).(func(string) db.StatusBudget); ok {
-		r0 = rf(workspace_uuid)
+	var r0 []db.Tribe
+	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
+		r0 = rf(s)
 	} else {
-		r0 = ret.Get(0).(db.StatusBudget)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Tribe)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceStatusBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceStatusBudget'
-type Database_GetWorkspaceStatusBudget_Call struct {
+// Database_SearchTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchTribes'
+type Database_SearchTribes_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceStatusBudget is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceStatusBudget(workspace_uuid interface{}) *Database_GetWorkspaceStatusBudget_Call {
-	return &Database_GetWorkspaceStatusBudget_Call{Call: _e.mock.On("GetWorkspaceStatusBudget", workspace_uuid)}
+// SearchTribes is a helper method to define mock.On call
+//   - s string
+func (_e *Database_Expecter) SearchTribes(s interface{}) *Database_SearchTribes_Call {
+	return &Database_SearchTribes_Call{Call: _e.mock.On("SearchTribes", s)}
 }
 
-func (_c *Database_GetWorkspaceStatusBudget_Call) Run(run func(workspace_uuid string)) *Database_GetWorkspaceStatusBudget_Call {
+func (_c *Database_SearchTribes_Call) Run(run func(s string)) *Database_SearchTribes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceStatusBudget_Call) Return(_a0 db.StatusBudget) *Database_GetWorkspaceStatusBudget_Call {
+func (_c *Database_SearchTribes_Call) Return(_a0 []db.Tribe) *Database_SearchTribes_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceStatusBudget_Call) RunAndReturn(run func(string) db.StatusBudget) *Database_GetWorkspaceStatusBudget_Call {
+func (_c *Database_SearchTribes_Call) RunAndReturn(run func(string) []db.Tribe) *Database_SearchTribes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceUser provides a mock function with given fields: pubkey, workspace_uuid
-func (_m *Database) GetWorkspaceUser(pubkey string, workspace_uuid string) db.WorkspaceUsers {
-	ret := _m.Called(pubkey, workspace_uuid)
+// SearchWorkspaceEmbeddings provides a mock function with given fields: workspaceUuid, embedding, topK
+func (_m *Database) SearchWorkspaceEmbeddings(workspaceUuid string, embedding db.Vector, topK int) []db.ContextSearchResult {
+	ret := _m.Called(workspaceUuid, embedding, topK)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceUser")
+		panic("no return value specified for SearchWorkspaceEmbeddings")
 	}
 
-	var r0 db.WorkspaceUsers
-	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceUsers); ok {
-		r0 = rf(pubkey, workspace_uuid)
+	var r0 []db.ContextSearchResult
+	if rf, ok := ret.Get(0).(func(string, db.Vector, int) []db.ContextSearchResult); ok {
+		r0 = rf(workspaceUuid, embedding, topK)
 	} else {
-		r0 = ret.Get(0).(db.WorkspaceUsers)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ContextSearchResult)
+		}
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUser'
-type Database_GetWorkspaceUser_Call struct {
+// Database_SearchWorkspaceEmbeddings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchWorkspaceEmbeddings'
+type Database_SearchWorkspaceEmbeddings_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceUser is a helper method to define mock.On call
-//   - pubkey string
-//   - workspace_uuid string
-func (_e *Database_Expecter) GetWorkspaceUser(pubkey interface{}, workspace_uuid interface{}) *Database_GetWorkspaceUser_Call {
-	return &Database_GetWorkspaceUser_Call{Call: _e.mock.On("GetWorkspaceUser", pubkey, workspace_uuid)}
+// SearchWorkspaceEmbeddings is a helper method to define mock.On call
+//   - workspaceUuid string
+//   - embedding db.Vector
+//   - topK int
+func (_e *Database_Expecter) SearchWorkspaceEmbeddings(workspaceUuid interface{}, embedding interface{}, topK interface{}) *Database_SearchWorkspaceEmbeddings_Call {
+	return &Database_SearchWorkspaceEmbeddings_Call{Call: _e.mock.On("SearchWorkspaceEmbeddings", workspaceUuid, embedding, topK)}
 }
 
-func (_c *Database_GetWorkspaceUser_Call) Run(run func(pubkey string, workspace_uuid string)) *Database_GetWorkspaceUser_Call {
+func (_c *Database_SearchWorkspaceEmbeddings_Call) Run(run func(workspaceUuid string, embedding db.Vector, topK int)) *Database_SearchWorkspaceEmbeddings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(db.Vector), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUser_Call) Return(_a0 db.WorkspaceUsers) *Database_GetWorkspaceUser_Call {
+func (_c *Database_SearchWorkspaceEmbeddings_Call) Return(_a0 []db.ContextSearchResult) *Database_SearchWorkspaceEmbeddings_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUser_Call) RunAndReturn(run func(string, string) db.WorkspaceUsers) *Database_GetWorkspaceUser_Call {
+func (_c *Database_SearchWorkspaceEmbeddings_Call) RunAndReturn(run func(string, db.Vector, int) []db.ContextSearchResult) *Database_SearchWorkspaceEmbeddings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceUsers provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceUsers(uuid string) ([]db.WorkspaceUsersData, error) {
-	ret := _m.Called(uuid)
+// SelectBountyBid provides a mock function with given fields: auctionId, bidId
+func (_m *Database) SelectBountyBid(auctionId uint, bidId uint) error {
+	ret := _m.Called(auctionId, bidId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceUsers")
-	}
-
-	var r0 []db.WorkspaceUsersData
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]db.WorkspaceUsersData, error)); ok {
-		return rf(uuid)
-	}
-	if rf, ok := ret.Get(0).(func(string) []db.WorkspaceUsersData); ok {
-		r0 = rf(uuid)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.WorkspaceUsersData)
-		}
+		panic("no return value specified for SelectBountyBid")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(auctionId, bidId)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetWorkspaceUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsers'
-type Database_GetWorkspaceUsers_Call struct {
+// Database_SelectBountyBid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectBountyBid'
+type Database_SelectBountyBid_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceUsers is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceUsers(uuid interface{}) *Database_GetWorkspaceUsers_Call {
-	return &Database_GetWorkspaceUsers_Call{Call: _e.mock.On("GetWorkspaceUsers", uuid)}
+// SelectBountyBid is a helper method to define mock.On call
+//   - auctionId uint
+//   - bidId uint
+func (_e *Database_Expecter) SelectBountyBid(auctionId interface{}, bidId interface{}) *Database_SelectBountyBid_Call {
+	return &Database_SelectBountyBid_Call{Call: _e.mock.On("SelectBountyBid", auctionId, bidId)}
 }
 
-func (_c *Database_GetWorkspaceUsers_Call) Run(run func(uuid string)) *Database_GetWorkspaceUsers_Call {
+func (_c *Database_SelectBountyBid_Call) Run(run func(auctionId uint, bidId uint)) *Database_SelectBountyBid_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(uint), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUsers_Call) Return(_a0 []db.WorkspaceUsersData, _a1 error) *Database_GetWorkspaceUsers_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_SelectBountyBid_Call) Return(_a0 error) *Database_SelectBountyBid_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUsers_Call) RunAndReturn(run func(string) ([]db.WorkspaceUsersData, error)) *Database_GetWorkspaceUsers_Call {
+func (_c *Database_SelectBountyBid_Call) RunAndReturn(run func(uint, uint) error) *Database_SelectBountyBid_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaceUsersCount provides a mock function with given fields: uuid
-func (_m *Database) GetWorkspaceUsersCount(uuid string) int64 {
-	ret := _m.Called(uuid)
+// SetBountyDueDate provides a mock function with given fields: id, dueDate
+func (_m *Database) SetBountyDueDate(id uint, dueDate *time.Time) error {
+	ret := _m.Called(id, dueDate)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaceUsersCount")
+		panic("no return value specified for SetBountyDueDate")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(uuid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, *time.Time) error); ok {
+		r0 = rf(id, dueDate)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaceUsersCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaceUsersCount'
-type Database_GetWorkspaceUsersCount_Call struct {
+// Database_SetBountyDueDate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBountyDueDate'
+type Database_SetBountyDueDate_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaceUsersCount is a helper method to define mock.On call
-//   - uuid string
-func (_e *Database_Expecter) GetWorkspaceUsersCount(uuid interface{}) *Database_GetWorkspaceUsersCount_Call {
-	return &Database_GetWorkspaceUsersCount_Call{Call: _e.mock.On("GetWorkspaceUsersCount", uuid)}
+// SetBountyDueDate is a helper method to define mock.On call
+//   - id uint
+//   - dueDate *time.Time
+func (_e *Database_Expecter) SetBountyDueDate(id interface{}, dueDate interface{}) *Database_SetBountyDueDate_Call {
+	return &Database_SetBountyDueDate_Call{Call: _e.mock.On("SetBountyDueDate", id, dueDate)}
 }
 
-func (_c *Database_GetWorkspaceUsersCount_Call) Run(run func(uuid string)) *Database_GetWorkspaceUsersCount_Call {
+func (_c *Database_SetBountyDueDate_Call) Run(run func(id uint, dueDate *time.Time)) *Database_SetBountyDueDate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(uint), args[1].(*time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUsersCount_Call) Return(_a0 int64) *Database_GetWorkspaceUsersCount_Call {
+func (_c *Database_SetBountyDueDate_Call) Return(_a0 error) *Database_SetBountyDueDate_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaceUsersCount_Call) RunAndReturn(run func(string) int64) *Database_GetWorkspaceUsersCount_Call {
+func (_c *Database_SetBountyDueDate_Call) RunAndReturn(run func(uint, *time.Time) error) *Database_SetBountyDueDate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspaces provides a mock function with given fields: r
-func (_m *Database) GetWorkspaces(r *http.Request) []db.Workspace {
-	ret := _m.Called(r)
+// SetBountyDueReminder provides a mock function with given fields: id, hours
+func (_m *Database) SetBountyDueReminder(id uint, hours int) error {
+	ret := _m.Called(id, hours)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspaces")
+		panic("no return value specified for SetBountyDueReminder")
 	}
 
-	var r0 []db.Workspace
-	if rf, ok := ret.Get(0).(func(*http.Request) []db.Workspace); ok {
-		r0 = rf(r)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, int) error); ok {
+		r0 = rf(id, hours)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Workspace)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaces'
-type Database_GetWorkspaces_Call struct {
+// Database_SetBountyDueReminder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBountyDueReminder'
+type Database_SetBountyDueReminder_Call struct {
 	*mock.Call
 }
 
-// GetWorkspaces is a helper method to define mock.On call
-//   - r *http.Request
-func (_e *Database_Expecter) GetWorkspaces(r interface{}) *Database_GetWorkspaces_Call {
-	return &Database_GetWorkspaces_Call{Call: _e.mock.On("GetWorkspaces", r)}
+// SetBountyDueReminder is a helper method to define mock.On call
+//   - id uint
+//   - hours int
+func (_e *Database_Expecter) SetBountyDueReminder(id interface{}, hours interface{}) *Database_SetBountyDueReminder_Call {
+	return &Database_SetBountyDueReminder_Call{Call: _e.mock.On("SetBountyDueReminder", id, hours)}
 }
 
-func (_c *Database_GetWorkspaces_Call) Run(run func(r *http.Request)) *Database_GetWorkspaces_Call {
+func (_c *Database_SetBountyDueReminder_Call) Run(run func(id uint, hours int)) *Database_SetBountyDueReminder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*http.Request))
+		run(args[0].(uint), args[1].(int))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspaces_Call) Return(_a0 []db.Workspace) *Database_GetWorkspaces_Call {
+func (_c *Database_SetBountyDueReminder_Call) Return(_a0 error) *Database_SetBountyDueReminder_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspaces_Call) RunAndReturn(run func(*http.Request) []db.Workspace) *Database_GetWorkspaces_Call {
+func (_c *Database_SetBountyDueReminder_Call) RunAndReturn(run func(uint, int) error) *Database_SetBountyDueReminder_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkspacesCount provides a mock function with given fields:
-func (_m *Database) GetWorkspacesCount() int64 {
-	ret := _m.Called()
+// SetBountyStaleWarning provides a mock function with given fields: id
+func (_m *Database) SetBountyStaleWarning(id uint) error {
+	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkspacesCount")
+		panic("no return value specified for SetBountyStaleWarning")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_GetWorkspacesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspacesCount'
-type Database_GetWorkspacesCount_Call struct {
+// Database_SetBountyStaleWarning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBountyStaleWarning'
+type Database_SetBountyStaleWarning_Call struct {
 	*mock.Call
 }
 
-// GetWorkspacesCount is a helper method to define mock.On call
-func (_e *Database_Expecter) GetWorkspacesCount() *Database_GetWorkspacesCount_Call {
-	return &Database_GetWorkspacesCount_Call{Call: _e.mock.On("GetWorkspacesCount")}
+// SetBountyStaleWarning is a helper method to define mock.On call
+//   - id uint
+func (_e *Database_Expecter) SetBountyStaleWarning(id interface{}) *Database_SetBountyStaleWarning_Call {
+	return &Database_SetBountyStaleWarning_Call{Call: _e.mock.On("SetBountyStaleWarning", id)}
 }
 
-func (_c *Database_GetWorkspacesCount_Call) Run(run func()) *Database_GetWorkspacesCount_Call {
+func (_c *Database_SetBountyStaleWarning_Call) Run(run func(id uint)) *Database_SetBountyStaleWarning_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *Database_GetWorkspacesCount_Call) Return(_a0 int64) *Database_GetWorkspacesCount_Call {
+func (_c *Database_SetBountyStaleWarning_Call) Return(_a0 error) *Database_SetBountyStaleWarning_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetWorkspacesCount_Call) RunAndReturn(run func() int64) *Database_GetWorkspacesCount_Call {
+func (_c *Database_SetBountyStaleWarning_Call) RunAndReturn(run func(uint) error) *Database_SetBountyStaleWarning_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewHuntersPaid provides a mock function with given fields: r, workspace
-func (_m *Database) NewHuntersPaid(r db.PaymentDateRange, workspace string) int64 {
-	ret := _m.Called(r, workspace)
+// SetBountyTags provides a mock function with given fields: bounty, tags
+func (_m *Database) SetBountyTags(bounty db.NewBounty, tags []string) (db.NewBounty, error) {
+	ret := _m.Called(bounty, tags)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NewHuntersPaid")
+		panic("no return value specified for SetBountyTags")
+	}
+
+	var r0 db.NewBounty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty, []string) (db.NewBounty, error)); ok {
+		return rf(bounty, tags)
+	}
+	if rf, ok := ret.Get(0).(func(db.NewBounty, []string) db.NewBounty); ok {
+		r0 = rf(bounty, tags)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) int64); ok {
-		r0 = rf(r, workspace)
+	if rf, ok := ret.Get(1).(func(db.NewBounty, []string) error); ok {
+		r1 = rf(bounty, tags)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// Database_NewHuntersPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewHuntersPaid'
-type Database_NewHuntersPaid_Call struct {
+// Database_SetBountyTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBountyTags'
+type Database_SetBountyTags_Call struct {
 	*mock.Call
 }
 
-// NewHuntersPaid is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) NewHuntersPaid(r interface{}, workspace interface{}) *Database_NewHuntersPaid_Call {
-	return &Database_NewHuntersPaid_Call{Call: _e.mock.On("NewHuntersPaid", r, workspace)}
+// SetBountyTags is a helper method to define mock.On call
+//   - bounty db.NewBounty
+//   - tags []string
+func (_e *Database_Expecter) SetBountyTags(bounty interface{}, tags interface{}) *Database_SetBountyTags_Call {
+	return &Database_SetBountyTags_Call{Call: _e.mock.On("SetBountyTags", bounty, tags)}
 }
 
-func (_c *Database_NewHuntersPaid_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_NewHuntersPaid_Call {
+func (_c *Database_SetBountyTags_Call) Run(run func(bounty db.NewBounty, tags []string)) *Database_SetBountyTags_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(db.NewBounty), args[1].([]string))
 	})
 	return _c
 }
 
-func (_c *Database_NewHuntersPaid_Call) Return(_a0 int64) *Database_NewHuntersPaid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetBountyTags_Call) Return(_a0 db.NewBounty, _a1 error) *Database_SetBountyTags_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_NewHuntersPaid_Call) RunAndReturn(run func(db.PaymentDateRange, string) int64) *Database_NewHuntersPaid_Call {
+func (_c *Database_SetBountyTags_Call) RunAndReturn(run func(db.NewBounty, []string) (db.NewBounty, error)) *Database_SetBountyTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PersonUniqueNameFromName provides a mock function with given fields: name
-func (_m *Database) PersonUniqueNameFromName(name string) (string, error) {
-	ret := _m.Called(name)
+// SetChatAgentWebhook provides a mock function with given fields: webhook
+func (_m *Database) SetChatAgentWebhook(webhook db.ChatAgentWebhook) (db.ChatAgentWebhook, error) {
+	ret := _m.Called(webhook)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PersonUniqueNameFromName")
+		panic("no return value specified for SetChatAgentWebhook")
 	}
 
-	var r0 string
+	var r0 db.ChatAgentWebhook
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(db.ChatAgentWebhook) (db.ChatAgentWebhook, error)); ok {
+		return rf(webhook)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(db.ChatAgentWebhook) db.ChatAgentWebhook); ok {
+		r0 = rf(webhook)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(db.ChatAgentWebhook)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(db.ChatAgentWebhook) error); ok {
+		r1 = rf(webhook)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -6295,265 +17113,337 @@ func (_m *Database) PersonUniqueNameFromName(name string) (string, error) {
 	return r0, r1
 }
 
-// Database_PersonUniqueNameFromName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PersonUniqueNameFromName'
-type Database_PersonUniqueNameFromName_Call struct {
+// Database_SetChatAgentWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetChatAgentWebhook'
+type Database_SetChatAgentWebhook_Call struct {
 	*mock.Call
 }
 
-// PersonUniqueNameFromName is a helper method to define mock.On call
-//   - name string
-func (_e *Database_Expecter) PersonUniqueNameFromName(name interface{}) *Database_PersonUniqueNameFromName_Call {
-	return &Database_PersonUniqueNameFromName_Call{Call: _e.mock.On("PersonUniqueNameFromName", name)}
+// SetChatAgentWebhook is a helper method to define mock.On call
+//   - webhook db.ChatAgentWebhook
+func (_e *Database_Expecter) SetChatAgentWebhook(webhook interface{}) *Database_SetChatAgentWebhook_Call {
+	return &Database_SetChatAgentWebhook_Call{Call: _e.mock.On("SetChatAgentWebhook", webhook)}
 }
 
-func (_c *Database_PersonUniqueNameFromName_Call) Run(run func(name string)) *Database_PersonUniqueNameFromName_Call {
+func (_c *Database_SetChatAgentWebhook_Call) Run(run func(webhook db.ChatAgentWebhook)) *Database_SetChatAgentWebhook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.ChatAgentWebhook))
 	})
 	return _c
 }
 
-func (_c *Database_PersonUniqueNameFromName_Call) Return(_a0 string, _a1 error) *Database_PersonUniqueNameFromName_Call {
+func (_c *Database_SetChatAgentWebhook_Call) Return(_a0 db.ChatAgentWebhook, _a1 error) *Database_SetChatAgentWebhook_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_PersonUniqueNameFromName_Call) RunAndReturn(run func(string) (string, error)) *Database_PersonUniqueNameFromName_Call {
+func (_c *Database_SetChatAgentWebhook_Call) RunAndReturn(run func(db.ChatAgentWebhook) (db.ChatAgentWebhook, error)) *Database_SetChatAgentWebhook_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessAddInvoice provides a mock function with given fields: invoice, userData
-func (_m *Database) ProcessAddInvoice(invoice db.NewInvoiceList, userData db.UserInvoiceData) error {
-	ret := _m.Called(invoice, userData)
+// SetParentOrganizationMemberRoles provides a mock function with given fields: orgUuid, pubkey, roles
+func (_m *Database) SetParentOrganizationMemberRoles(orgUuid string, pubkey string, roles []string) ([]db.ParentOrganizationMember, error) {
+	ret := _m.Called(orgUuid, pubkey, roles)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessAddInvoice")
+		panic("no return value specified for SetParentOrganizationMemberRoles")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(db.NewInvoiceList, db.UserInvoiceData) error); ok {
-		r0 = rf(invoice, userData)
+	var r0 []db.ParentOrganizationMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, []string) ([]db.ParentOrganizationMember, error)); ok {
+		return rf(orgUuid, pubkey, roles)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, []string) []db.ParentOrganizationMember); ok {
+		r0 = rf(orgUuid, pubkey, roles)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ParentOrganizationMember)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string, []string) error); ok {
+		r1 = rf(orgUuid, pubkey, roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessAddInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessAddInvoice'
-type Database_ProcessAddInvoice_Call struct {
+// Database_SetParentOrganizationMemberRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetParentOrganizationMemberRoles'
+type Database_SetParentOrganizationMemberRoles_Call struct {
 	*mock.Call
 }
 
-// ProcessAddInvoice is a helper method to define mock.On call
-//   - invoice db.NewInvoiceList
-//   - userData db.UserInvoiceData
-func (_e *Database_Expecter) ProcessAddInvoice(invoice interface{}, userData interface{}) *Database_ProcessAddInvoice_Call {
-	return &Database_ProcessAddInvoice_Call{Call: _e.mock.On("ProcessAddInvoice", invoice, userData)}
+// SetParentOrganizationMemberRoles is a helper method to define mock.On call
+//   - orgUuid string
+//   - pubkey string
+//   - roles []string
+func (_e *Database_Expecter) SetParentOrganizationMemberRoles(orgUuid interface{}, pubkey interface{}, roles interface{}) *Database_SetParentOrganizationMemberRoles_Call {
+	return &Database_SetParentOrganizationMemberRoles_Call{Call: _e.mock.On("SetParentOrganizationMemberRoles", orgUuid, pubkey, roles)}
 }
 
-func (_c *Database_ProcessAddInvoice_Call) Run(run func(invoice db.NewInvoiceList, userData db.UserInvoiceData)) *Database_ProcessAddInvoice_Call {
+func (_c *Database_SetParentOrganizationMemberRoles_Call) Run(run func(orgUuid string, pubkey string, roles []string)) *Database_SetParentOrganizationMemberRoles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewInvoiceList), args[1].(db.UserInvoiceData))
+		run(args[0].(string), args[1].(string), args[2].([]string))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessAddInvoice_Call) Return(_a0 error) *Database_ProcessAddInvoice_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetParentOrganizationMemberRoles_Call) Return(_a0 []db.ParentOrganizationMember, _a1 error) *Database_SetParentOrganizationMemberRoles_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessAddInvoice_Call) RunAndReturn(run func(db.NewInvoiceList, db.UserInvoiceData) error) *Database_ProcessAddInvoice_Call {
+func (_c *Database_SetParentOrganizationMemberRoles_Call) RunAndReturn(run func(string, string, []string) ([]db.ParentOrganizationMember, error)) *Database_SetParentOrganizationMemberRoles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessAlerts provides a mock function with given fields: p
-func (_m *Database) ProcessAlerts(p db.Person) {
-	_m.Called(p)
+// SetPersonHandle provides a mock function with given fields: pubkey, handle, now
+func (_m *Database) SetPersonHandle(pubkey string, handle string, now time.Time) (db.Person, error) {
+	ret := _m.Called(pubkey, handle, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPersonHandle")
+	}
+
+	var r0 db.Person
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) (db.Person, error)); ok {
+		return rf(pubkey, handle, now)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) db.Person); ok {
+		r0 = rf(pubkey, handle, now)
+	} else {
+		r0 = ret.Get(0).(db.Person)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, time.Time) error); ok {
+		r1 = rf(pubkey, handle, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessAlerts'
-type Database_ProcessAlerts_Call struct {
+// Database_SetPersonHandle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPersonHandle'
+type Database_SetPersonHandle_Call struct {
 	*mock.Call
 }
 
-// ProcessAlerts is a helper method to define mock.On call
-//   - p db.Person
-func (_e *Database_Expecter) ProcessAlerts(p interface{}) *Database_ProcessAlerts_Call {
-	return &Database_ProcessAlerts_Call{Call: _e.mock.On("ProcessAlerts", p)}
+// SetPersonHandle is a helper method to define mock.On call
+//   - pubkey string
+//   - handle string
+//   - now time.Time
+func (_e *Database_Expecter) SetPersonHandle(pubkey interface{}, handle interface{}, now interface{}) *Database_SetPersonHandle_Call {
+	return &Database_SetPersonHandle_Call{Call: _e.mock.On("SetPersonHandle", pubkey, handle, now)}
 }
 
-func (_c *Database_ProcessAlerts_Call) Run(run func(p db.Person)) *Database_ProcessAlerts_Call {
+func (_c *Database_SetPersonHandle_Call) Run(run func(pubkey string, handle string, now time.Time)) *Database_SetPersonHandle_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.Person))
+		run(args[0].(string), args[1].(string), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessAlerts_Call) Return() *Database_ProcessAlerts_Call {
-	_c.Call.Return()
+func (_c *Database_SetPersonHandle_Call) Return(_a0 db.Person, _a1 error) *Database_SetPersonHandle_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessAlerts_Call) RunAndReturn(run func(db.Person)) *Database_ProcessAlerts_Call {
+func (_c *Database_SetPersonHandle_Call) RunAndReturn(run func(string, string, time.Time) (db.Person, error)) *Database_SetPersonHandle_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessBountyPayment provides a mock function with given fields: payment, bounty
-func (_m *Database) ProcessBountyPayment(payment db.NewPaymentHistory, bounty db.NewBounty) error {
-	ret := _m.Called(payment, bounty)
+// SetSlackTeamWorkspace provides a mock function with given fields: teamId, workspaceUuid, pubkey
+func (_m *Database) SetSlackTeamWorkspace(teamId string, workspaceUuid string, pubkey string) (db.SlackTeam, error) {
+	ret := _m.Called(teamId, workspaceUuid, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessBountyPayment")
+		panic("no return value specified for SetSlackTeamWorkspace")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, db.NewBounty) error); ok {
-		r0 = rf(payment, bounty)
+	var r0 db.SlackTeam
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (db.SlackTeam, error)); ok {
+		return rf(teamId, workspaceUuid, pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) db.SlackTeam); ok {
+		r0 = rf(teamId, workspaceUuid, pubkey)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.SlackTeam)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(teamId, workspaceUuid, pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessBountyPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBountyPayment'
-type Database_ProcessBountyPayment_Call struct {
+// Database_SetSlackTeamWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSlackTeamWorkspace'
+type Database_SetSlackTeamWorkspace_Call struct {
 	*mock.Call
 }
 
-// ProcessBountyPayment is a helper method to define mock.On call
-//   - payment db.NewPaymentHistory
-//   - bounty db.NewBounty
-func (_e *Database_Expecter) ProcessBountyPayment(payment interface{}, bounty interface{}) *Database_ProcessBountyPayment_Call {
-	return &Database_ProcessBountyPayment_Call{Call: _e.mock.On("ProcessBountyPayment", payment, bounty)}
+// SetSlackTeamWorkspace is a helper method to define mock.On call
+//   - teamId string
+//   - workspaceUuid string
+//   - pubkey string
+func (_e *Database_Expecter) SetSlackTeamWorkspace(teamId interface{}, workspaceUuid interface{}, pubkey interface{}) *Database_SetSlackTeamWorkspace_Call {
+	return &Database_SetSlackTeamWorkspace_Call{Call: _e.mock.On("SetSlackTeamWorkspace", teamId, workspaceUuid, pubkey)}
 }
 
-func (_c *Database_ProcessBountyPayment_Call) Run(run func(payment db.NewPaymentHistory, bounty db.NewBounty)) *Database_ProcessBountyPayment_Call {
+func (_c *Database_SetSlackTeamWorkspace_Call) Run(run func(teamId string, workspaceUuid string, pubkey string)) *Database_SetSlackTeamWorkspace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewPaymentHistory), args[1].(db.NewBounty))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessBountyPayment_Call) Return(_a0 error) *Database_ProcessBountyPayment_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetSlackTeamWorkspace_Call) Return(_a0 db.SlackTeam, _a1 error) *Database_SetSlackTeamWorkspace_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessBountyPayment_Call) RunAndReturn(run func(db.NewPaymentHistory, db.NewBounty) error) *Database_ProcessBountyPayment_Call {
+func (_c *Database_SetSlackTeamWorkspace_Call) RunAndReturn(run func(string, string, string) (db.SlackTeam, error)) *Database_SetSlackTeamWorkspace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessBudgetInvoice provides a mock function with given fields: paymentHistory, newInvoice
-func (_m *Database) ProcessBudgetInvoice(paymentHistory db.NewPaymentHistory, newInvoice db.NewInvoiceList) error {
-	ret := _m.Called(paymentHistory, newInvoice)
+// SetWebhookSigningSecret provides a mock function with given fields: source, secret, pubkey
+func (_m *Database) SetWebhookSigningSecret(source string, secret string, pubkey string) (db.WebhookSigningSecret, error) {
+	ret := _m.Called(source, secret, pubkey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessBudgetInvoice")
+		panic("no return value specified for SetWebhookSigningSecret")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(db.NewPaymentHistory, db.NewInvoiceList) error); ok {
-		r0 = rf(paymentHistory, newInvoice)
+	var r0 db.WebhookSigningSecret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (db.WebhookSigningSecret, error)); ok {
+		return rf(source, secret, pubkey)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) db.WebhookSigningSecret); ok {
+		r0 = rf(source, secret, pubkey)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.WebhookSigningSecret)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(source, secret, pubkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessBudgetInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBudgetInvoice'
-type Database_ProcessBudgetInvoice_Call struct {
+// Database_SetWebhookSigningSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWebhookSigningSecret'
+type Database_SetWebhookSigningSecret_Call struct {
 	*mock.Call
 }
 
-// ProcessBudgetInvoice is a helper method to define mock.On call
-//   - paymentHistory db.NewPaymentHistory
-//   - newInvoice db.NewInvoiceList
-func (_e *Database_Expecter) ProcessBudgetInvoice(paymentHistory interface{}, newInvoice interface{}) *Database_ProcessBudgetInvoice_Call {
-	return &Database_ProcessBudgetInvoice_Call{Call: _e.mock.On("ProcessBudgetInvoice", paymentHistory, newInvoice)}
+// SetWebhookSigningSecret is a helper method to define mock.On call
+//   - source string
+//   - secret string
+//   - pubkey string
+func (_e *Database_Expecter) SetWebhookSigningSecret(source interface{}, secret interface{}, pubkey interface{}) *Database_SetWebhookSigningSecret_Call {
+	return &Database_SetWebhookSigningSecret_Call{Call: _e.mock.On("SetWebhookSigningSecret", source, secret, pubkey)}
 }
 
-func (_c *Database_ProcessBudgetInvoice_Call) Run(run func(paymentHistory db.NewPaymentHistory, newInvoice db.NewInvoiceList)) *Database_ProcessBudgetInvoice_Call {
+func (_c *Database_SetWebhookSigningSecret_Call) Run(run func(source string, secret string, pubkey string)) *Database_SetWebhookSigningSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewPaymentHistory), args[1].(db.NewInvoiceList))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessBudgetInvoice_Call) Return(_a0 error) *Database_ProcessBudgetInvoice_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SetWebhookSigningSecret_Call) Return(_a0 db.WebhookSigningSecret, _a1 error) *Database_SetWebhookSigningSecret_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessBudgetInvoice_Call) RunAndReturn(run func(db.NewPaymentHistory, db.NewInvoiceList) error) *Database_ProcessBudgetInvoice_Call {
+func (_c *Database_SetWebhookSigningSecret_Call) RunAndReturn(run func(string, string, string) (db.WebhookSigningSecret, error)) *Database_SetWebhookSigningSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessDeleteWorkspace provides a mock function with given fields: workspace_uuid
-func (_m *Database) ProcessDeleteWorkspace(workspace_uuid string) error {
-	ret := _m.Called(workspace_uuid)
+// SettleRelayPaymentEvent provides a mock function with given fields: event, invoice, received
+func (_m *Database) SettleRelayPaymentEvent(event db.RelayEvent, invoice db.NewInvoiceList, received time.Time) (bool, error) {
+	ret := _m.Called(event, invoice, received)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessDeleteWorkspace")
+		panic("no return value specified for SettleRelayPaymentEvent")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(workspace_uuid)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.RelayEvent, db.NewInvoiceList, time.Time) (bool, error)); ok {
+		return rf(event, invoice, received)
+	}
+	if rf, ok := ret.Get(0).(func(db.RelayEvent, db.NewInvoiceList, time.Time) bool); ok {
+		r0 = rf(event, invoice, received)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.RelayEvent, db.NewInvoiceList, time.Time) error); ok {
+		r1 = rf(event, invoice, received)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_ProcessDeleteWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessDeleteWorkspace'
-type Database_ProcessDeleteWorkspace_Call struct {
+// Database_SettleRelayPaymentEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SettleRelayPaymentEvent'
+type Database_SettleRelayPaymentEvent_Call struct {
 	*mock.Call
 }
 
-// ProcessDeleteWorkspace is a helper method to define mock.On call
-//   - workspace_uuid string
-func (_e *Database_Expecter) ProcessDeleteWorkspace(workspace_uuid interface{}) *Database_ProcessDeleteWorkspace_Call {
-	return &Database_ProcessDeleteWorkspace_Call{Call: _e.mock.On("ProcessDeleteWorkspace", workspace_uuid)}
+// SettleRelayPaymentEvent is a helper method to define mock.On call
+//   - event db.RelayEvent
+//   - invoice db.NewInvoiceList
+//   - received time.Time
+func (_e *Database_Expecter) SettleRelayPaymentEvent(event interface{}, invoice interface{}, received interface{}) *Database_SettleRelayPaymentEvent_Call {
+	return &Database_SettleRelayPaymentEvent_Call{Call: _e.mock.On("SettleRelayPaymentEvent", event, invoice, received)}
 }
 
-func (_c *Database_ProcessDeleteWorkspace_Call) Run(run func(workspace_uuid string)) *Database_ProcessDeleteWorkspace_Call {
+func (_c *Database_SettleRelayPaymentEvent_Call) Run(run func(event db.RelayEvent, invoice db.NewInvoiceList, received time.Time)) *Database_SettleRelayPaymentEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(db.RelayEvent), args[1].(db.NewInvoiceList), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessDeleteWorkspace_Call) Return(_a0 error) *Database_ProcessDeleteWorkspace_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_SettleRelayPaymentEvent_Call) Return(_a0 bool, _a1 error) *Database_SettleRelayPaymentEvent_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_ProcessDeleteWorkspace_Call) RunAndReturn(run func(string) error) *Database_ProcessDeleteWorkspace_Call {
+func (_c *Database_SettleRelayPaymentEvent_Call) RunAndReturn(run func(db.RelayEvent, db.NewInvoiceList, time.Time) (bool, error)) *Database_SettleRelayPaymentEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProcessUpdateBudget provides a mock function with given fields: invoice
-func (_m *Database) ProcessUpdateBudget(invoice db.NewInvoiceList) error {
-	ret := _m.Called(invoice)
+// SnapshotWorkspaceBudgets provides a mock function with given fields: day
+func (_m *Database) SnapshotWorkspaceBudgets(day string) error {
+	ret := _m.Called(day)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessUpdateBudget")
+		panic("no return value specified for SnapshotWorkspaceBudgets")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(db.NewInvoiceList) error); ok {
-		r0 = rf(invoice)
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(day)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -6561,225 +17451,217 @@ func (_m *Database) ProcessUpdateBudget(invoice db.NewInvoiceList) error {
 	return r0
 }
 
-// Database_ProcessUpdateBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessUpdateBudget'
-type Database_ProcessUpdateBudget_Call struct {
+// Database_SnapshotWorkspaceBudgets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SnapshotWorkspaceBudgets'
+type Database_SnapshotWorkspaceBudgets_Call struct {
 	*mock.Call
 }
 
-// ProcessUpdateBudget is a helper method to define mock.On call
-//   - invoice db.NewInvoiceList
-func (_e *Database_Expecter) ProcessUpdateBudget(invoice interface{}) *Database_ProcessUpdateBudget_Call {
-	return &Database_ProcessUpdateBudget_Call{Call: _e.mock.On("ProcessUpdateBudget", invoice)}
+// SnapshotWorkspaceBudgets is a helper method to define mock.On call
+//   - day string
+func (_e *Database_Expecter) SnapshotWorkspaceBudgets(day interface{}) *Database_SnapshotWorkspaceBudgets_Call {
+	return &Database_SnapshotWorkspaceBudgets_Call{Call: _e.mock.On("SnapshotWorkspaceBudgets", day)}
 }
 
-func (_c *Database_ProcessUpdateBudget_Call) Run(run func(invoice db.NewInvoiceList)) *Database_ProcessUpdateBudget_Call {
+func (_c *Database_SnapshotWorkspaceBudgets_Call) Run(run func(day string)) *Database_SnapshotWorkspaceBudgets_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.NewInvoiceList))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_ProcessUpdateBudget_Call) Return(_a0 error) *Database_ProcessUpdateBudget_Call {
+func (_c *Database_SnapshotWorkspaceBudgets_Call) Return(_a0 error) *Database_SnapshotWorkspaceBudgets_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_ProcessUpdateBudget_Call) RunAndReturn(run func(db.NewInvoiceList) error) *Database_ProcessUpdateBudget_Call {
+func (_c *Database_SnapshotWorkspaceBudgets_Call) RunAndReturn(run func(string) error) *Database_SnapshotWorkspaceBudgets_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SatsPaidPercentage provides a mock function with given fields: r, workspace
-func (_m *Database) SatsPaidPercentage(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// StreamBountiesByDateRange provides a mock function with given fields: r, re, each
+func (_m *Database) StreamBountiesByDateRange(r db.PaymentDateRange, re *http.Request, each func([]db.NewBounty) error) error {
+	ret := _m.Called(r, re, each)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SatsPaidPercentage")
+		panic("no return value specified for StreamBountiesByDateRange")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, *http.Request, func([]db.NewBounty) error) error); ok {
+		r0 = rf(r, re, each)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_SatsPaidPercentage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SatsPaidPercentage'
-type Database_SatsPaidPercentage_Call struct {
+// Database_StreamBountiesByDateRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamBountiesByDateRange'
+type Database_StreamBountiesByDateRange_Call struct {
 	*mock.Call
 }
 
-// SatsPaidPercentage is a helper method to define mock.On call
+// StreamBountiesByDateRange is a helper method to define mock.On call
 //   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) SatsPaidPercentage(r interface{}, workspace interface{}) *Database_SatsPaidPercentage_Call {
-	return &Database_SatsPaidPercentage_Call{Call: _e.mock.On("SatsPaidPercentage", r, workspace)}
+//   - re *http.Request
+//   - each func([]db.NewBounty) error
+func (_e *Database_Expecter) StreamBountiesByDateRange(r interface{}, re interface{}, each interface{}) *Database_StreamBountiesByDateRange_Call {
+	return &Database_StreamBountiesByDateRange_Call{Call: _e.mock.On("StreamBountiesByDateRange", r, re, each)}
 }
 
-func (_c *Database_SatsPaidPercentage_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_SatsPaidPercentage_Call {
+func (_c *Database_StreamBountiesByDateRange_Call) Run(run func(r db.PaymentDateRange, re *http.Request, each func([]db.NewBounty) error)) *Database_StreamBountiesByDateRange_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(db.PaymentDateRange), args[1].(*http.Request), args[2].(func([]db.NewBounty) error))
 	})
 	return _c
 }
 
-func (_c *Database_SatsPaidPercentage_Call) Return(_a0 uint) *Database_SatsPaidPercentage_Call {
+func (_c *Database_StreamBountiesByDateRange_Call) Return(_a0 error) *Database_StreamBountiesByDateRange_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_SatsPaidPercentage_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_SatsPaidPercentage_Call {
+func (_c *Database_StreamBountiesByDateRange_Call) RunAndReturn(run func(db.PaymentDateRange, *http.Request, func([]db.NewBounty) error) error) *Database_StreamBountiesByDateRange_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchBots provides a mock function with given fields: s, limit, offset
-func (_m *Database) SearchBots(s string, limit int, offset int) []db.BotRes {
-	ret := _m.Called(s, limit, offset)
+// StreamListedPeople provides a mock function with given fields: r, each
+func (_m *Database) StreamListedPeople(r *http.Request, each func([]db.Person) error) error {
+	ret := _m.Called(r, each)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SearchBots")
+		panic("no return value specified for StreamListedPeople")
 	}
 
-	var r0 []db.BotRes
-	if rf, ok := ret.Get(0).(func(string, int, int) []db.BotRes); ok {
-		r0 = rf(s, limit, offset)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*http.Request, func([]db.Person) error) error); ok {
+		r0 = rf(r, each)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.BotRes)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
-
-// Database_SearchBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchBots'
-type Database_SearchBots_Call struct {
-	*mock.Call
-}
-
-// SearchBots is a helper method to define mock.On call
-//   - s string
-//   - limit int
-//   - offset int
-func (_e *Database_Expecter) SearchBots(s interface{}, limit interface{}, offset interface{}) *Database_SearchBots_Call {
-	return &Database_SearchBots_Call{Call: _e.mock.On("SearchBots", s, limit, offset)}
+
+// Database_StreamListedPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamListedPeople'
+type Database_StreamListedPeople_Call struct {
+	*mock.Call
 }
 
-func (_c *Database_SearchBots_Call) Run(run func(s string, limit int, offset int)) *Database_SearchBots_Call {
+// StreamListedPeople is a helper method to define mock.On call
+//   - r *http.Request
+//   - each func([]db.Person) error
+func (_e *Database_Expecter) StreamListedPeople(r interface{}, each interface{}) *Database_StreamListedPeople_Call {
+	return &Database_StreamListedPeople_Call{Call: _e.mock.On("StreamListedPeople", r, each)}
+}
+
+func (_c *Database_StreamListedPeople_Call) Run(run func(r *http.Request, each func([]db.Person) error)) *Database_StreamListedPeople_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int), args[2].(int))
+		run(args[0].(*http.Request), args[1].(func([]db.Person) error))
 	})
 	return _c
 }
 
-func (_c *Database_SearchBots_Call) Return(_a0 []db.BotRes) *Database_SearchBots_Call {
+func (_c *Database_StreamListedPeople_Call) Return(_a0 error) *Database_StreamListedPeople_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_SearchBots_Call) RunAndReturn(run func(string, int, int) []db.BotRes) *Database_SearchBots_Call {
+func (_c *Database_StreamListedPeople_Call) RunAndReturn(run func(*http.Request, func([]db.Person) error) error) *Database_StreamListedPeople_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchPeople provides a mock function with given fields: s, limit, offset
-func (_m *Database) SearchPeople(s string, limit int, offset int) []db.Person {
-	ret := _m.Called(s, limit, offset)
+// SyncFeatureBountyCounts provides a mock function with given fields: featureUuid
+func (_m *Database) SyncFeatureBountyCounts(featureUuid string) error {
+	ret := _m.Called(featureUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SearchPeople")
+		panic("no return value specified for SyncFeatureBountyCounts")
 	}
 
-	var r0 []db.Person
-	if rf, ok := ret.Get(0).(func(string, int, int) []db.Person); ok {
-		r0 = rf(s, limit, offset)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(featureUuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Person)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_SearchPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchPeople'
-type Database_SearchPeople_Call struct {
+// Database_SyncFeatureBountyCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncFeatureBountyCounts'
+type Database_SyncFeatureBountyCounts_Call struct {
 	*mock.Call
 }
 
-// SearchPeople is a helper method to define mock.On call
-//   - s string
-//   - limit int
-//   - offset int
-func (_e *Database_Expecter) SearchPeople(s interface{}, limit interface{}, offset interface{}) *Database_SearchPeople_Call {
-	return &Database_SearchPeople_Call{Call: _e.mock.On("SearchPeople", s, limit, offset)}
+// SyncFeatureBountyCounts is a helper method to define mock.On call
+//   - featureUuid string
+func (_e *Database_Expecter) SyncFeatureBountyCounts(featureUuid interface{}) *Database_SyncFeatureBountyCounts_Call {
+	return &Database_SyncFeatureBountyCounts_Call{Call: _e.mock.On("SyncFeatureBountyCounts", featureUuid)}
 }
 
-func (_c *Database_SearchPeople_Call) Run(run func(s string, limit int, offset int)) *Database_SearchPeople_Call {
+func (_c *Database_SyncFeatureBountyCounts_Call) Run(run func(featureUuid string)) *Database_SyncFeatureBountyCounts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(int), args[2].(int))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_SearchPeople_Call) Return(_a0 []db.Person) *Database_SearchPeople_Call {
+func (_c *Database_SyncFeatureBountyCounts_Call) Return(_a0 error) *Database_SyncFeatureBountyCounts_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_SearchPeople_Call) RunAndReturn(run func(string, int, int) []db.Person) *Database_SearchPeople_Call {
+func (_c *Database_SyncFeatureBountyCounts_Call) RunAndReturn(run func(string) error) *Database_SyncFeatureBountyCounts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchTribes provides a mock function with given fields: s
-func (_m *Database) SearchTribes(s string) []db.Tribe {
-	ret := _m.Called(s)
+// SyncWorkspaceBudget provides a mock function with given fields: workspaceUuid
+func (_m *Database) SyncWorkspaceBudget(workspaceUuid string) error {
+	ret := _m.Called(workspaceUuid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SearchTribes")
+		panic("no return value specified for SyncWorkspaceBudget")
 	}
 
-	var r0 []db.Tribe
-	if rf, ok := ret.Get(0).(func(string) []db.Tribe); ok {
-		r0 = rf(s)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(workspaceUuid)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Tribe)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_SearchTribes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchTribes'
-type Database_SearchTribes_Call struct {
+// Database_SyncWorkspaceBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncWorkspaceBudget'
+type Database_SyncWorkspaceBudget_Call struct {
 	*mock.Call
 }
 
-// SearchTribes is a helper method to define mock.On call
-//   - s string
-func (_e *Database_Expecter) SearchTribes(s interface{}) *Database_SearchTribes_Call {
-	return &Database_SearchTribes_Call{Call: _e.mock.On("SearchTribes", s)}
+// SyncWorkspaceBudget is a helper method to define mock.On call
+//   - workspaceUuid string
+func (_e *Database_Expecter) SyncWorkspaceBudget(workspaceUuid interface{}) *Database_SyncWorkspaceBudget_Call {
+	return &Database_SyncWorkspaceBudget_Call{Call: _e.mock.On("SyncWorkspaceBudget", workspaceUuid)}
 }
 
-func (_c *Database_SearchTribes_Call) Run(run func(s string)) *Database_SearchTribes_Call {
+func (_c *Database_SyncWorkspaceBudget_Call) Run(run func(workspaceUuid string)) *Database_SyncWorkspaceBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *Database_SearchTribes_Call) Return(_a0 []db.Tribe) *Database_SearchTribes_Call {
+func (_c *Database_SyncWorkspaceBudget_Call) Return(_a0 error) *Database_SyncWorkspaceBudget_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_SearchTribes_Call) RunAndReturn(run func(string) []db.Tribe) *Database_SearchTribes_Call {
+func (_c *Database_SyncWorkspaceBudget_Call) RunAndReturn(run func(string) error) *Database_SyncWorkspaceBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6977,91 +17859,366 @@ func (_m *Database) TotalSatsPaid(r db.PaymentDateRange, workspace string) uint
 	ret := _m.Called(r, workspace)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TotalSatsPaid")
+		panic("no return value specified for TotalSatsPaid")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
+// Database_TotalSatsPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalSatsPaid'
+type Database_TotalSatsPaid_Call struct {
+	*mock.Call
+}
+
+// TotalSatsPaid is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) TotalSatsPaid(r interface{}, workspace interface{}) *Database_TotalSatsPaid_Call {
+	return &Database_TotalSatsPaid_Call{Call: _e.mock.On("TotalSatsPaid", r, workspace)}
+}
+
+func (_c *Database_TotalSatsPaid_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalSatsPaid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_TotalSatsPaid_Call) Return(_a0 uint) *Database_TotalSatsPaid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_TotalSatsPaid_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_TotalSatsPaid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TotalSatsPosted provides a mock function with given fields: r, workspace
+func (_m *Database) TotalSatsPosted(r db.PaymentDateRange, workspace string) uint {
+	ret := _m.Called(r, workspace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TotalSatsPosted")
+	}
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
+		r0 = rf(r, workspace)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
+// Database_TotalSatsPosted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalSatsPosted'
+type Database_TotalSatsPosted_Call struct {
+	*mock.Call
+}
+
+// TotalSatsPosted is a helper method to define mock.On call
+//   - r db.PaymentDateRange
+//   - workspace string
+func (_e *Database_Expecter) TotalSatsPosted(r interface{}, workspace interface{}) *Database_TotalSatsPosted_Call {
+	return &Database_TotalSatsPosted_Call{Call: _e.mock.On("TotalSatsPosted", r, workspace)}
+}
+
+func (_c *Database_TotalSatsPosted_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalSatsPosted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PaymentDateRange), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_TotalSatsPosted_Call) Return(_a0 uint) *Database_TotalSatsPosted_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_TotalSatsPosted_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_TotalSatsPosted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransferFeature provides a mock function with given fields: featureUuid, workspaceUuid
+func (_m *Database) TransferFeature(featureUuid string, workspaceUuid string) (db.WorkspaceFeatures, error) {
+	ret := _m.Called(featureUuid, workspaceUuid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferFeature")
+	}
+
+	var r0 db.WorkspaceFeatures
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (db.WorkspaceFeatures, error)); ok {
+		return rf(featureUuid, workspaceUuid)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) db.WorkspaceFeatures); ok {
+		r0 = rf(featureUuid, workspaceUuid)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceFeatures)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(featureUuid, workspaceUuid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_TransferFeature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferFeature'
+type Database_TransferFeature_Call struct {
+	*mock.Call
+}
+
+// TransferFeature is a helper method to define mock.On call
+//   - featureUuid string
+//   - workspaceUuid string
+func (_e *Database_Expecter) TransferFeature(featureUuid interface{}, workspaceUuid interface{}) *Database_TransferFeature_Call {
+	return &Database_TransferFeature_Call{Call: _e.mock.On("TransferFeature", featureUuid, workspaceUuid)}
+}
+
+func (_c *Database_TransferFeature_Call) Run(run func(featureUuid string, workspaceUuid string)) *Database_TransferFeature_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_TransferFeature_Call) Return(_a0 db.WorkspaceFeatures, _a1 error) *Database_TransferFeature_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_TransferFeature_Call) RunAndReturn(run func(string, string) (db.WorkspaceFeatures, error)) *Database_TransferFeature_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransitionBountyState provides a mock function with given fields: bountyId, request, actor
+func (_m *Database) TransitionBountyState(bountyId uint, request db.BountyTransitionRequest, actor string) (db.NewBounty, db.BountyStateTransition, error) {
+	ret := _m.Called(bountyId, request, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransitionBountyState")
+	}
+
+	var r0 db.NewBounty
+	var r1 db.BountyStateTransition
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, db.BountyTransitionRequest, string) (db.NewBounty, db.BountyStateTransition, error)); ok {
+		return rf(bountyId, request, actor)
+	}
+	if rf, ok := ret.Get(0).(func(uint, db.BountyTransitionRequest, string) db.NewBounty); ok {
+		r0 = rf(bountyId, request, actor)
+	} else {
+		r0 = ret.Get(0).(db.NewBounty)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, db.BountyTransitionRequest, string) db.BountyStateTransition); ok {
+		r1 = rf(bountyId, request, actor)
+	} else {
+		r1 = ret.Get(1).(db.BountyStateTransition)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, db.BountyTransitionRequest, string) error); ok {
+		r2 = rf(bountyId, request, actor)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Database_TransitionBountyState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransitionBountyState'
+type Database_TransitionBountyState_Call struct {
+	*mock.Call
+}
+
+// TransitionBountyState is a helper method to define mock.On call
+//   - bountyId uint
+//   - request db.BountyTransitionRequest
+//   - actor string
+func (_e *Database_Expecter) TransitionBountyState(bountyId interface{}, request interface{}, actor interface{}) *Database_TransitionBountyState_Call {
+	return &Database_TransitionBountyState_Call{Call: _e.mock.On("TransitionBountyState", bountyId, request, actor)}
+}
+
+func (_c *Database_TransitionBountyState_Call) Run(run func(bountyId uint, request db.BountyTransitionRequest, actor string)) *Database_TransitionBountyState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(db.BountyTransitionRequest), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_TransitionBountyState_Call) Return(_a0 db.NewBounty, _a1 db.BountyStateTransition, _a2 error) *Database_TransitionBountyState_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Database_TransitionBountyState_Call) RunAndReturn(run func(uint, db.BountyTransitionRequest, string) (db.NewBounty, db.BountyStateTransition, error)) *Database_TransitionBountyState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnassignStaleBounty provides a mock function with given fields: bounty, reason
+func (_m *Database) UnassignStaleBounty(bounty db.NewBounty, reason string) error {
+	ret := _m.Called(bounty, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnassignStaleBounty")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.NewBounty, string) error); ok {
+		r0 = rf(bounty, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_UnassignStaleBounty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnassignStaleBounty'
+type Database_UnassignStaleBounty_Call struct {
+	*mock.Call
+}
+
+// UnassignStaleBounty is a helper method to define mock.On call
+//   - bounty db.NewBounty
+//   - reason string
+func (_e *Database_Expecter) UnassignStaleBounty(bounty interface{}, reason interface{}) *Database_UnassignStaleBounty_Call {
+	return &Database_UnassignStaleBounty_Call{Call: _e.mock.On("UnassignStaleBounty", bounty, reason)}
+}
+
+func (_c *Database_UnassignStaleBounty_Call) Run(run func(bounty db.NewBounty, reason string)) *Database_UnassignStaleBounty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.NewBounty), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_UnassignStaleBounty_Call) Return(_a0 error) *Database_UnassignStaleBounty_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_UnassignStaleBounty_Call) RunAndReturn(run func(db.NewBounty, string) error) *Database_UnassignStaleBounty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateArtifact provides a mock function with given fields: artifact, updatedBy
+func (_m *Database) UpdateArtifact(artifact db.Artifact, updatedBy string) (db.Artifact, error) {
+	ret := _m.Called(artifact, updatedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateArtifact")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 db.Artifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.Artifact, string) (db.Artifact, error)); ok {
+		return rf(artifact, updatedBy)
+	}
+	if rf, ok := ret.Get(0).(func(db.Artifact, string) db.Artifact); ok {
+		r0 = rf(artifact, updatedBy)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Get(0).(db.Artifact)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(db.Artifact, string) error); ok {
+		r1 = rf(artifact, updatedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Database_TotalSatsPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalSatsPaid'
-type Database_TotalSatsPaid_Call struct {
+// Database_UpdateArtifact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateArtifact'
+type Database_UpdateArtifact_Call struct {
 	*mock.Call
 }
 
-// TotalSatsPaid is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) TotalSatsPaid(r interface{}, workspace interface{}) *Database_TotalSatsPaid_Call {
-	return &Database_TotalSatsPaid_Call{Call: _e.mock.On("TotalSatsPaid", r, workspace)}
+// UpdateArtifact is a helper method to define mock.On call
+//   - artifact db.Artifact
+//   - updatedBy string
+func (_e *Database_Expecter) UpdateArtifact(artifact interface{}, updatedBy interface{}) *Database_UpdateArtifact_Call {
+	return &Database_UpdateArtifact_Call{Call: _e.mock.On("UpdateArtifact", artifact, updatedBy)}
 }
 
-func (_c *Database_TotalSatsPaid_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalSatsPaid_Call {
+func (_c *Database_UpdateArtifact_Call) Run(run func(artifact db.Artifact, updatedBy string)) *Database_UpdateArtifact_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(db.Artifact), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *Database_TotalSatsPaid_Call) Return(_a0 uint) *Database_TotalSatsPaid_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateArtifact_Call) Return(_a0 db.Artifact, _a1 error) *Database_UpdateArtifact_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_TotalSatsPaid_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_TotalSatsPaid_Call {
+func (_c *Database_UpdateArtifact_Call) RunAndReturn(run func(db.Artifact, string) (db.Artifact, error)) *Database_UpdateArtifact_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TotalSatsPosted provides a mock function with given fields: r, workspace
-func (_m *Database) TotalSatsPosted(r db.PaymentDateRange, workspace string) uint {
-	ret := _m.Called(r, workspace)
+// UpdateBadgeIssuanceStatus provides a mock function with given fields: id, status, txId, issueError
+func (_m *Database) UpdateBadgeIssuanceStatus(id uint, status db.BadgeIssuanceStatus, txId string, issueError string) error {
+	ret := _m.Called(id, status, txId, issueError)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TotalSatsPosted")
+		panic("no return value specified for UpdateBadgeIssuanceStatus")
 	}
 
-	var r0 uint
-	if rf, ok := ret.Get(0).(func(db.PaymentDateRange, string) uint); ok {
-		r0 = rf(r, workspace)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, db.BadgeIssuanceStatus, string, string) error); ok {
+		r0 = rf(id, status, txId, issueError)
 	} else {
-		r0 = ret.Get(0).(uint)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// Database_TotalSatsPosted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalSatsPosted'
-type Database_TotalSatsPosted_Call struct {
+// Database_UpdateBadgeIssuanceStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBadgeIssuanceStatus'
+type Database_UpdateBadgeIssuanceStatus_Call struct {
 	*mock.Call
 }
 
-// TotalSatsPosted is a helper method to define mock.On call
-//   - r db.PaymentDateRange
-//   - workspace string
-func (_e *Database_Expecter) TotalSatsPosted(r interface{}, workspace interface{}) *Database_TotalSatsPosted_Call {
-	return &Database_TotalSatsPosted_Call{Call: _e.mock.On("TotalSatsPosted", r, workspace)}
+// UpdateBadgeIssuanceStatus is a helper method to define mock.On call
+//   - id uint
+//   - status db.BadgeIssuanceStatus
+//   - txId string
+//   - issueError string
+func (_e *Database_Expecter) UpdateBadgeIssuanceStatus(id interface{}, status interface{}, txId interface{}, issueError interface{}) *Database_UpdateBadgeIssuanceStatus_Call {
+	return &Database_UpdateBadgeIssuanceStatus_Call{Call: _e.mock.On("UpdateBadgeIssuanceStatus", id, status, txId, issueError)}
 }
 
-func (_c *Database_TotalSatsPosted_Call) Run(run func(r db.PaymentDateRange, workspace string)) *Database_TotalSatsPosted_Call {
+func (_c *Database_UpdateBadgeIssuanceStatus_Call) Run(run func(id uint, status db.BadgeIssuanceStatus, txId string, issueError string)) *Database_UpdateBadgeIssuanceStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(db.PaymentDateRange), args[1].(string))
+		run(args[0].(uint), args[1].(db.BadgeIssuanceStatus), args[2].(string), args[3].(string))
 	})
 	return _c
 }
 
-func (_c *Database_TotalSatsPosted_Call) Return(_a0 uint) *Database_TotalSatsPosted_Call {
+func (_c *Database_UpdateBadgeIssuanceStatus_Call) Return(_a0 error) *Database_UpdateBadgeIssuanceStatus_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_TotalSatsPosted_Call) RunAndReturn(run func(db.PaymentDateRange, string) uint) *Database_TotalSatsPosted_Call {
+func (_c *Database_UpdateBadgeIssuanceStatus_Call) RunAndReturn(run func(uint, db.BadgeIssuanceStatus, string, string) error) *Database_UpdateBadgeIssuanceStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7169,6 +18326,110 @@ func (_c *Database_UpdateBounty_Call) RunAndReturn(run func(db.NewBounty) (db.Ne
 	return _c
 }
 
+// UpdateBountyApplicationStatus provides a mock function with given fields: id, status
+func (_m *Database) UpdateBountyApplicationStatus(id uint, status db.BountyApplicationStatus) (db.BountyApplication, error) {
+	ret := _m.Called(id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBountyApplicationStatus")
+	}
+
+	var r0 db.BountyApplication
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, db.BountyApplicationStatus) (db.BountyApplication, error)); ok {
+		return rf(id, status)
+	}
+	if rf, ok := ret.Get(0).(func(uint, db.BountyApplicationStatus) db.BountyApplication); ok {
+		r0 = rf(id, status)
+	} else {
+		r0 = ret.Get(0).(db.BountyApplication)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, db.BountyApplicationStatus) error); ok {
+		r1 = rf(id, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_UpdateBountyApplicationStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBountyApplicationStatus'
+type Database_UpdateBountyApplicationStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateBountyApplicationStatus is a helper method to define mock.On call
+//   - id uint
+//   - status db.BountyApplicationStatus
+func (_e *Database_Expecter) UpdateBountyApplicationStatus(id interface{}, status interface{}) *Database_UpdateBountyApplicationStatus_Call {
+	return &Database_UpdateBountyApplicationStatus_Call{Call: _e.mock.On("UpdateBountyApplicationStatus", id, status)}
+}
+
+func (_c *Database_UpdateBountyApplicationStatus_Call) Run(run func(id uint, status db.BountyApplicationStatus)) *Database_UpdateBountyApplicationStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(db.BountyApplicationStatus))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateBountyApplicationStatus_Call) Return(_a0 db.BountyApplication, _a1 error) *Database_UpdateBountyApplicationStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_UpdateBountyApplicationStatus_Call) RunAndReturn(run func(uint, db.BountyApplicationStatus) (db.BountyApplication, error)) *Database_UpdateBountyApplicationStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBountyAuctionStatus provides a mock function with given fields: id, status
+func (_m *Database) UpdateBountyAuctionStatus(id uint, status db.BountyAuctionStatus) error {
+	ret := _m.Called(id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBountyAuctionStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, db.BountyAuctionStatus) error); ok {
+		r0 = rf(id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_UpdateBountyAuctionStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBountyAuctionStatus'
+type Database_UpdateBountyAuctionStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateBountyAuctionStatus is a helper method to define mock.On call
+//   - id uint
+//   - status db.BountyAuctionStatus
+func (_e *Database_Expecter) UpdateBountyAuctionStatus(id interface{}, status interface{}) *Database_UpdateBountyAuctionStatus_Call {
+	return &Database_UpdateBountyAuctionStatus_Call{Call: _e.mock.On("UpdateBountyAuctionStatus", id, status)}
+}
+
+func (_c *Database_UpdateBountyAuctionStatus_Call) Run(run func(id uint, status db.BountyAuctionStatus)) *Database_UpdateBountyAuctionStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(db.BountyAuctionStatus))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateBountyAuctionStatus_Call) Return(_a0 error) *Database_UpdateBountyAuctionStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_UpdateBountyAuctionStatus_Call) RunAndReturn(run func(uint, db.BountyAuctionStatus) error) *Database_UpdateBountyAuctionStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateBountyBoolColumn provides a mock function with given fields: b, column
 func (_m *Database) UpdateBountyBoolColumn(b db.NewBounty, column string) db.NewBounty {
 	ret := _m.Called(b, column)
@@ -7319,6 +18580,55 @@ func (_c *Database_UpdateBountyPayment_Call) RunAndReturn(run func(db.NewBounty)
 	return _c
 }
 
+// UpdateBudgetRefundStatus provides a mock function with given fields: id, status, approvedBy, refundError
+func (_m *Database) UpdateBudgetRefundStatus(id uint, status db.BudgetRefundStatus, approvedBy string, refundError string) error {
+	ret := _m.Called(id, status, approvedBy, refundError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBudgetRefundStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, db.BudgetRefundStatus, string, string) error); ok {
+		r0 = rf(id, status, approvedBy, refundError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_UpdateBudgetRefundStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBudgetRefundStatus'
+type Database_UpdateBudgetRefundStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateBudgetRefundStatus is a helper method to define mock.On call
+//   - id uint
+//   - status db.BudgetRefundStatus
+//   - approvedBy string
+//   - refundError string
+func (_e *Database_Expecter) UpdateBudgetRefundStatus(id interface{}, status interface{}, approvedBy interface{}, refundError interface{}) *Database_UpdateBudgetRefundStatus_Call {
+	return &Database_UpdateBudgetRefundStatus_Call{Call: _e.mock.On("UpdateBudgetRefundStatus", id, status, approvedBy, refundError)}
+}
+
+func (_c *Database_UpdateBudgetRefundStatus_Call) Run(run func(id uint, status db.BudgetRefundStatus, approvedBy string, refundError string)) *Database_UpdateBudgetRefundStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(db.BudgetRefundStatus), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateBudgetRefundStatus_Call) Return(_a0 error) *Database_UpdateBudgetRefundStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_UpdateBudgetRefundStatus_Call) RunAndReturn(run func(uint, db.BudgetRefundStatus, string, string) error) *Database_UpdateBudgetRefundStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateChannel provides a mock function with given fields: id, u
 func (_m *Database) UpdateChannel(id uint, u map[string]interface{}) bool {
 	ret := _m.Called(id, u)
@@ -7349,19 +18659,193 @@ func (_e *Database_Expecter) UpdateChannel(id interface{}, u interface{}) *Datab
 	return &Database_UpdateChannel_Call{Call: _e.mock.On("UpdateChannel", id, u)}
 }
 
-func (_c *Database_UpdateChannel_Call) Run(run func(id uint, u map[string]interface{})) *Database_UpdateChannel_Call {
+func (_c *Database_UpdateChannel_Call) Run(run func(id uint, u map[string]interface{})) *Database_UpdateChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateChannel_Call) Return(_a0 bool) *Database_UpdateChannel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_UpdateChannel_Call) RunAndReturn(run func(uint, map[string]interface{}) bool) *Database_UpdateChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCodeGraphStatus provides a mock function with given fields: uuid, status, progress, errMsg
+func (_m *Database) UpdateCodeGraphStatus(uuid string, status db.CodeGraphStatus, progress int, errMsg string) (db.WorkspaceCodeGraph, error) {
+	ret := _m.Called(uuid, status, progress, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCodeGraphStatus")
+	}
+
+	var r0 db.WorkspaceCodeGraph
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, db.CodeGraphStatus, int, string) (db.WorkspaceCodeGraph, error)); ok {
+		return rf(uuid, status, progress, errMsg)
+	}
+	if rf, ok := ret.Get(0).(func(string, db.CodeGraphStatus, int, string) db.WorkspaceCodeGraph); ok {
+		r0 = rf(uuid, status, progress, errMsg)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceCodeGraph)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, db.CodeGraphStatus, int, string) error); ok {
+		r1 = rf(uuid, status, progress, errMsg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_UpdateCodeGraphStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCodeGraphStatus'
+type Database_UpdateCodeGraphStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateCodeGraphStatus is a helper method to define mock.On call
+//   - uuid string
+//   - status db.CodeGraphStatus
+//   - progress int
+//   - errMsg string
+func (_e *Database_Expecter) UpdateCodeGraphStatus(uuid interface{}, status interface{}, progress interface{}, errMsg interface{}) *Database_UpdateCodeGraphStatus_Call {
+	return &Database_UpdateCodeGraphStatus_Call{Call: _e.mock.On("UpdateCodeGraphStatus", uuid, status, progress, errMsg)}
+}
+
+func (_c *Database_UpdateCodeGraphStatus_Call) Run(run func(uuid string, status db.CodeGraphStatus, progress int, errMsg string)) *Database_UpdateCodeGraphStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(db.CodeGraphStatus), args[2].(int), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateCodeGraphStatus_Call) Return(_a0 db.WorkspaceCodeGraph, _a1 error) *Database_UpdateCodeGraphStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_UpdateCodeGraphStatus_Call) RunAndReturn(run func(string, db.CodeGraphStatus, int, string) (db.WorkspaceCodeGraph, error)) *Database_UpdateCodeGraphStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateFeatureBrief provides a mock function with given fields: uuid, brief, sections, updatedBy
+func (_m *Database) UpdateFeatureBrief(uuid string, brief string, sections db.BriefSections, updatedBy string) (db.WorkspaceFeatures, error) {
+	ret := _m.Called(uuid, brief, sections, updatedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFeatureBrief")
+	}
+
+	var r0 db.WorkspaceFeatures
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, db.BriefSections, string) (db.WorkspaceFeatures, error)); ok {
+		return rf(uuid, brief, sections, updatedBy)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, db.BriefSections, string) db.WorkspaceFeatures); ok {
+		r0 = rf(uuid, brief, sections, updatedBy)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceFeatures)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, db.BriefSections, string) error); ok {
+		r1 = rf(uuid, brief, sections, updatedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_UpdateFeatureBrief_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFeatureBrief'
+type Database_UpdateFeatureBrief_Call struct {
+	*mock.Call
+}
+
+// UpdateFeatureBrief is a helper method to define mock.On call
+//   - uuid string
+//   - brief string
+//   - sections db.BriefSections
+//   - updatedBy string
+func (_e *Database_Expecter) UpdateFeatureBrief(uuid interface{}, brief interface{}, sections interface{}, updatedBy interface{}) *Database_UpdateFeatureBrief_Call {
+	return &Database_UpdateFeatureBrief_Call{Call: _e.mock.On("UpdateFeatureBrief", uuid, brief, sections, updatedBy)}
+}
+
+func (_c *Database_UpdateFeatureBrief_Call) Run(run func(uuid string, brief string, sections db.BriefSections, updatedBy string)) *Database_UpdateFeatureBrief_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(db.BriefSections), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateFeatureBrief_Call) Return(_a0 db.WorkspaceFeatures, _a1 error) *Database_UpdateFeatureBrief_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_UpdateFeatureBrief_Call) RunAndReturn(run func(string, string, db.BriefSections, string) (db.WorkspaceFeatures, error)) *Database_UpdateFeatureBrief_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateFeatureCall provides a mock function with given fields: call
+func (_m *Database) UpdateFeatureCall(call db.FeatureCall) (db.FeatureCall, error) {
+	ret := _m.Called(call)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFeatureCall")
+	}
+
+	var r0 db.FeatureCall
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.FeatureCall) (db.FeatureCall, error)); ok {
+		return rf(call)
+	}
+	if rf, ok := ret.Get(0).(func(db.FeatureCall) db.FeatureCall); ok {
+		r0 = rf(call)
+	} else {
+		r0 = ret.Get(0).(db.FeatureCall)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.FeatureCall) error); ok {
+		r1 = rf(call)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_UpdateFeatureCall_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFeatureCall'
+type Database_UpdateFeatureCall_Call struct {
+	*mock.Call
+}
+
+// UpdateFeatureCall is a helper method to define mock.On call
+//   - call db.FeatureCall
+func (_e *Database_Expecter) UpdateFeatureCall(call interface{}) *Database_UpdateFeatureCall_Call {
+	return &Database_UpdateFeatureCall_Call{Call: _e.mock.On("UpdateFeatureCall", call)}
+}
+
+func (_c *Database_UpdateFeatureCall_Call) Run(run func(call db.FeatureCall)) *Database_UpdateFeatureCall_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].(map[string]interface{}))
+		run(args[0].(db.FeatureCall))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateChannel_Call) Return(_a0 bool) *Database_UpdateChannel_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateFeatureCall_Call) Return(_a0 db.FeatureCall, _a1 error) *Database_UpdateFeatureCall_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_UpdateChannel_Call) RunAndReturn(run func(uint, map[string]interface{}) bool) *Database_UpdateChannel_Call {
+func (_c *Database_UpdateFeatureCall_Call) RunAndReturn(run func(db.FeatureCall) (db.FeatureCall, error)) *Database_UpdateFeatureCall_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7528,6 +19012,54 @@ func (_c *Database_UpdateLeaderBoard_Call) RunAndReturn(run func(string, string,
 	return _c
 }
 
+// UpdateOnchainConfirmations provides a mock function with given fields: id, confirmations, confirmed
+func (_m *Database) UpdateOnchainConfirmations(id uint, confirmations uint, confirmed *time.Time) error {
+	ret := _m.Called(id, confirmations, confirmed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOnchainConfirmations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint, *time.Time) error); ok {
+		r0 = rf(id, confirmations, confirmed)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_UpdateOnchainConfirmations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOnchainConfirmations'
+type Database_UpdateOnchainConfirmations_Call struct {
+	*mock.Call
+}
+
+// UpdateOnchainConfirmations is a helper method to define mock.On call
+//   - id uint
+//   - confirmations uint
+//   - confirmed *time.Time
+func (_e *Database_Expecter) UpdateOnchainConfirmations(id interface{}, confirmations interface{}, confirmed interface{}) *Database_UpdateOnchainConfirmations_Call {
+	return &Database_UpdateOnchainConfirmations_Call{Call: _e.mock.On("UpdateOnchainConfirmations", id, confirmations, confirmed)}
+}
+
+func (_c *Database_UpdateOnchainConfirmations_Call) Run(run func(id uint, confirmations uint, confirmed *time.Time)) *Database_UpdateOnchainConfirmations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint), args[2].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateOnchainConfirmations_Call) Return(_a0 error) *Database_UpdateOnchainConfirmations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_UpdateOnchainConfirmations_Call) RunAndReturn(run func(uint, uint, *time.Time) error) *Database_UpdateOnchainConfirmations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdatePerson provides a mock function with given fields: id, u
 func (_m *Database) UpdatePerson(id uint, u map[string]interface{}) bool {
 	ret := _m.Called(id, u)
@@ -7575,6 +19107,118 @@ func (_c *Database_UpdatePerson_Call) RunAndReturn(run func(uint, map[string]int
 	return _c
 }
 
+// UpdatePhasePlan provides a mock function with given fields: plan
+func (_m *Database) UpdatePhasePlan(plan db.PhasePlan) (db.PhasePlan, error) {
+	ret := _m.Called(plan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePhasePlan")
+	}
+
+	var r0 db.PhasePlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.PhasePlan) (db.PhasePlan, error)); ok {
+		return rf(plan)
+	}
+	if rf, ok := ret.Get(0).(func(db.PhasePlan) db.PhasePlan); ok {
+		r0 = rf(plan)
+	} else {
+		r0 = ret.Get(0).(db.PhasePlan)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.PhasePlan) error); ok {
+		r1 = rf(plan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_UpdatePhasePlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePhasePlan'
+type Database_UpdatePhasePlan_Call struct {
+	*mock.Call
+}
+
+// UpdatePhasePlan is a helper method to define mock.On call
+//   - plan db.PhasePlan
+func (_e *Database_Expecter) UpdatePhasePlan(plan interface{}) *Database_UpdatePhasePlan_Call {
+	return &Database_UpdatePhasePlan_Call{Call: _e.mock.On("UpdatePhasePlan", plan)}
+}
+
+func (_c *Database_UpdatePhasePlan_Call) Run(run func(plan db.PhasePlan)) *Database_UpdatePhasePlan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.PhasePlan))
+	})
+	return _c
+}
+
+func (_c *Database_UpdatePhasePlan_Call) Return(_a0 db.PhasePlan, _a1 error) *Database_UpdatePhasePlan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_UpdatePhasePlan_Call) RunAndReturn(run func(db.PhasePlan) (db.PhasePlan, error)) *Database_UpdatePhasePlan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTicketReview provides a mock function with given fields: review
+func (_m *Database) UpdateTicketReview(review db.TicketReview) (db.TicketReview, error) {
+	ret := _m.Called(review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTicketReview")
+	}
+
+	var r0 db.TicketReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.TicketReview) (db.TicketReview, error)); ok {
+		return rf(review)
+	}
+	if rf, ok := ret.Get(0).(func(db.TicketReview) db.TicketReview); ok {
+		r0 = rf(review)
+	} else {
+		r0 = ret.Get(0).(db.TicketReview)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.TicketReview) error); ok {
+		r1 = rf(review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_UpdateTicketReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTicketReview'
+type Database_UpdateTicketReview_Call struct {
+	*mock.Call
+}
+
+// UpdateTicketReview is a helper method to define mock.On call
+//   - review db.TicketReview
+func (_e *Database_Expecter) UpdateTicketReview(review interface{}) *Database_UpdateTicketReview_Call {
+	return &Database_UpdateTicketReview_Call{Call: _e.mock.On("UpdateTicketReview", review)}
+}
+
+func (_c *Database_UpdateTicketReview_Call) Run(run func(review db.TicketReview)) *Database_UpdateTicketReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.TicketReview))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateTicketReview_Call) Return(_a0 db.TicketReview, _a1 error) *Database_UpdateTicketReview_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_UpdateTicketReview_Call) RunAndReturn(run func(db.TicketReview) (db.TicketReview, error)) *Database_UpdateTicketReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateTribe provides a mock function with given fields: uuid, u
 func (_m *Database) UpdateTribe(uuid string, u map[string]interface{}) bool {
 	ret := _m.Called(uuid, u)
@@ -7782,6 +19426,62 @@ func (_c *Database_UpdateWorkspaceForDeletion_Call) RunAndReturn(run func(string
 	return _c
 }
 
+// UpdateWorkspaceTag provides a mock function with given fields: tag
+func (_m *Database) UpdateWorkspaceTag(tag db.WorkspaceTag) (db.WorkspaceTag, error) {
+	ret := _m.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWorkspaceTag")
+	}
+
+	var r0 db.WorkspaceTag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(db.WorkspaceTag) (db.WorkspaceTag, error)); ok {
+		return rf(tag)
+	}
+	if rf, ok := ret.Get(0).(func(db.WorkspaceTag) db.WorkspaceTag); ok {
+		r0 = rf(tag)
+	} else {
+		r0 = ret.Get(0).(db.WorkspaceTag)
+	}
+
+	if rf, ok := ret.Get(1).(func(db.WorkspaceTag) error); ok {
+		r1 = rf(tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_UpdateWorkspaceTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWorkspaceTag'
+type Database_UpdateWorkspaceTag_Call struct {
+	*mock.Call
+}
+
+// UpdateWorkspaceTag is a helper method to define mock.On call
+//   - tag db.WorkspaceTag
+func (_e *Database_Expecter) UpdateWorkspaceTag(tag interface{}) *Database_UpdateWorkspaceTag_Call {
+	return &Database_UpdateWorkspaceTag_Call{Call: _e.mock.On("UpdateWorkspaceTag", tag)}
+}
+
+func (_c *Database_UpdateWorkspaceTag_Call) Run(run func(tag db.WorkspaceTag)) *Database_UpdateWorkspaceTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(db.WorkspaceTag))
+	})
+	return _c
+}
+
+func (_c *Database_UpdateWorkspaceTag_Call) Return(_a0 db.WorkspaceTag, _a1 error) *Database_UpdateWorkspaceTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_UpdateWorkspaceTag_Call) RunAndReturn(run func(db.WorkspaceTag) (db.WorkspaceTag, error)) *Database_UpdateWorkspaceTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UserHasAccess provides a mock function with given fields: pubKeyFromAuth, uuid, role
 func (_m *Database) UserHasAccess(pubKeyFromAuth string, uuid string, role string) bool {
 	ret := _m.Called(pubKeyFromAuth, uuid, role)
@@ -7877,9 +19577,181 @@ func (_c *Database_UserHasManageBountyRoles_Call) RunAndReturn(run func(string,
 	return _c
 }
 
-// WithdrawBudget provides a mock function with given fields: sender_pubkey, workspace_uuid, amount
-func (_m *Database) WithdrawBudget(sender_pubkey string, workspace_uuid string, amount uint) {
-	_m.Called(sender_pubkey, workspace_uuid, amount)
+// VerifyCalendarToken provides a mock function with given fields: tokenString
+func (_m *Database) VerifyCalendarToken(tokenString string) (db.CalendarToken, error) {
+	ret := _m.Called(tokenString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyCalendarToken")
+	}
+
+	var r0 db.CalendarToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (db.CalendarToken, error)); ok {
+		return rf(tokenString)
+	}
+	if rf, ok := ret.Get(0).(func(string) db.CalendarToken); ok {
+		r0 = rf(tokenString)
+	} else {
+		r0 = ret.Get(0).(db.CalendarToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_VerifyCalendarToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyCalendarToken'
+type Database_VerifyCalendarToken_Call struct {
+	*mock.Call
+}
+
+// VerifyCalendarToken is a helper method to define mock.On call
+//   - tokenString string
+func (_e *Database_Expecter) VerifyCalendarToken(tokenString interface{}) *Database_VerifyCalendarToken_Call {
+	return &Database_VerifyCalendarToken_Call{Call: _e.mock.On("VerifyCalendarToken", tokenString)}
+}
+
+func (_c *Database_VerifyCalendarToken_Call) Run(run func(tokenString string)) *Database_VerifyCalendarToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_VerifyCalendarToken_Call) Return(_a0 db.CalendarToken, _a1 error) *Database_VerifyCalendarToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_VerifyCalendarToken_Call) RunAndReturn(run func(string) (db.CalendarToken, error)) *Database_VerifyCalendarToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyWorkspaceApiToken provides a mock function with given fields: token
+func (_m *Database) VerifyWorkspaceApiToken(token string) (auth.ApiToken, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyWorkspaceApiToken")
+	}
+
+	var r0 auth.ApiToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (auth.ApiToken, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) auth.ApiToken); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(auth.ApiToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_VerifyWorkspaceApiToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyWorkspaceApiToken'
+type Database_VerifyWorkspaceApiToken_Call struct {
+	*mock.Call
+}
+
+// VerifyWorkspaceApiToken is a helper method to define mock.On call
+//   - token string
+func (_e *Database_Expecter) VerifyWorkspaceApiToken(token interface{}) *Database_VerifyWorkspaceApiToken_Call {
+	return &Database_VerifyWorkspaceApiToken_Call{Call: _e.mock.On("VerifyWorkspaceApiToken", token)}
+}
+
+func (_c *Database_VerifyWorkspaceApiToken_Call) Run(run func(token string)) *Database_VerifyWorkspaceApiToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_VerifyWorkspaceApiToken_Call) Return(_a0 auth.ApiToken, _a1 error) *Database_VerifyWorkspaceApiToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_VerifyWorkspaceApiToken_Call) RunAndReturn(run func(string) (auth.ApiToken, error)) *Database_VerifyWorkspaceApiToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithdrawBountyBid provides a mock function with given fields: auctionId, pubkey
+func (_m *Database) WithdrawBountyBid(auctionId uint, pubkey string) error {
+	ret := _m.Called(auctionId, pubkey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithdrawBountyBid")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(auctionId, pubkey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_WithdrawBountyBid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithdrawBountyBid'
+type Database_WithdrawBountyBid_Call struct {
+	*mock.Call
+}
+
+// WithdrawBountyBid is a helper method to define mock.On call
+//   - auctionId uint
+//   - pubkey string
+func (_e *Database_Expecter) WithdrawBountyBid(auctionId interface{}, pubkey interface{}) *Database_WithdrawBountyBid_Call {
+	return &Database_WithdrawBountyBid_Call{Call: _e.mock.On("WithdrawBountyBid", auctionId, pubkey)}
+}
+
+func (_c *Database_WithdrawBountyBid_Call) Run(run func(auctionId uint, pubkey string)) *Database_WithdrawBountyBid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_WithdrawBountyBid_Call) Return(_a0 error) *Database_WithdrawBountyBid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_WithdrawBountyBid_Call) RunAndReturn(run func(uint, string) error) *Database_WithdrawBountyBid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithdrawBudget provides a mock function with given fields: sender_pubkey, reservation
+func (_m *Database) WithdrawBudget(sender_pubkey string, reservation db.BudgetReservation) error {
+	ret := _m.Called(sender_pubkey, reservation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithdrawBudget")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, db.BudgetReservation) error); ok {
+		r0 = rf(sender_pubkey, reservation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // Database_WithdrawBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithdrawBudget'
@@ -7889,25 +19761,24 @@ type Database_WithdrawBudget_Call struct {
 
 // WithdrawBudget is a helper method to define mock.On call
 //   - sender_pubkey string
-//   - workspace_uuid string
-//   - amount uint
-func (_e *Database_Expecter) WithdrawBudget(sender_pubkey interface{}, workspace_uuid interface{}, amount interface{}) *Database_WithdrawBudget_Call {
-	return &Database_WithdrawBudget_Call{Call: _e.mock.On("WithdrawBudget", sender_pubkey, workspace_uuid, amount)}
+//   - reservation db.BudgetReservation
+func (_e *Database_Expecter) WithdrawBudget(sender_pubkey interface{}, reservation interface{}) *Database_WithdrawBudget_Call {
+	return &Database_WithdrawBudget_Call{Call: _e.mock.On("WithdrawBudget", sender_pubkey, reservation)}
 }
 
-func (_c *Database_WithdrawBudget_Call) Run(run func(sender_pubkey string, workspace_uuid string, amount uint)) *Database_WithdrawBudget_Call {
+func (_c *Database_WithdrawBudget_Call) Run(run func(sender_pubkey string, reservation db.BudgetReservation)) *Database_WithdrawBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(uint))
+		run(args[0].(string), args[1].(db.BudgetReservation))
 	})
 	return _c
 }
 
-func (_c *Database_WithdrawBudget_Call) Return() *Database_WithdrawBudget_Call {
-	_c.Call.Return()
+func (_c *Database_WithdrawBudget_Call) Return(_a0 error) *Database_WithdrawBudget_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_WithdrawBudget_Call) RunAndReturn(run func(string, string, uint)) *Database_WithdrawBudget_Call {
+func (_c *Database_WithdrawBudget_Call) RunAndReturn(run func(string, db.BudgetReservation) error) *Database_WithdrawBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,113 @@
+package nostr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+const (
+	// KindDeletion asks relays to drop earlier events, NIP-09
+	KindDeletion = 5
+	// KindClassifiedListing is a replaceable classified listing, NIP-99
+	KindClassifiedListing = 30402
+)
+
+var ErrInvalidKey = errors.New("nostr key must be a 32 byte hex private key")
+
+// Event is a signed nostr event, NIP-01
+type Event struct {
+	ID        string     `json:"id"`
+	PubKey    string     `json:"pubkey"`
+	CreatedAt int64      `json:"created_at"`
+	Kind      int        `json:"kind"`
+	Tags      [][]string `json:"tags"`
+	Content   string     `json:"content"`
+	Sig       string     `json:"sig"`
+}
+
+// ParseKey reads a hex private key
+func ParseKey(key string) (*btcec.PrivateKey, error) {
+	raw, err := hex.DecodeString(key)
+	if err != nil || len(raw) != 32 {
+		return nil, ErrInvalidKey
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(raw)
+	return privateKey, nil
+}
+
+// PublicKey is the x-only hex public key events of key are published under
+func PublicKey(key *btcec.PrivateKey) string {
+	return hex.EncodeToString(schnorr.SerializePubKey(key.PubKey()))
+}
+
+// serialize is the array the id of the event is the hash of, without the
+// html escaping of encoding/json
+func (e Event) serialize() ([]byte, error) {
+	tags := e.Tags
+	if tags == nil {
+		tags = [][]string{}
+	}
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode([]interface{}{0, e.PubKey, e.CreatedAt, e.Kind, tags, e.Content}); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Sign sets the pubkey, id and signature of the event
+func (e *Event) Sign(key *btcec.PrivateKey) error {
+	if e.Tags == nil {
+		e.Tags = [][]string{}
+	}
+	e.PubKey = PublicKey(key)
+	serialized, err := e.serialize()
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(serialized)
+	sig, err := schnorr.Sign(key, hash[:])
+	if err != nil {
+		return err
+	}
+	e.ID = hex.EncodeToString(hash[:])
+	e.Sig = hex.EncodeToString(sig.Serialize())
+	return nil
+}
+
+// Verify checks the id and the signature of the event
+func (e Event) Verify() bool {
+	serialized, err := e.serialize()
+	if err != nil {
+		return false
+	}
+	hash := sha256.Sum256(serialized)
+	if hex.EncodeToString(hash[:]) != e.ID {
+		return false
+	}
+
+	rawKey, err := hex.DecodeString(e.PubKey)
+	if err != nil {
+		return false
+	}
+	pubKey, err := schnorr.ParsePubKey(rawKey)
+	if err != nil {
+		return false
+	}
+	rawSig, err := hex.DecodeString(e.Sig)
+	if err != nil {
+		return false
+	}
+	sig, err := schnorr.ParseSignature(rawSig)
+	if err != nil {
+		return false
+	}
+	return sig.Verify(hash[:], pubKey)
+}
@@ -0,0 +1,87 @@
+package nostr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKey = "7f7ff03d123792d6ac594bfa67bf6d0c0ab55b6b1fdb6249303fe861f1ccba9a"
+
+func TestSign(t *testing.T) {
+	key, err := ParseKey(testKey)
+	assert.NoError(t, err)
+
+	event := Event{CreatedAt: 1718020800, Kind: KindClassifiedListing, Tags: [][]string{{"d", "sphinx-bounty-1"}}, Content: "Fix <b> & </b> escaping"}
+	assert.NoError(t, event.Sign(key))
+	assert.Len(t, event.ID, 64)
+	assert.Len(t, event.Sig, 128)
+	assert.Equal(t, PublicKey(key), event.PubKey)
+	assert.True(t, event.Verify())
+
+	serialized, err := event.serialize()
+	assert.NoError(t, err)
+	assert.Contains(t, string(serialized), `"Fix <b> & </b> escaping"`)
+
+	event.Content = "changed"
+	assert.False(t, event.Verify())
+}
+
+func TestParseKey(t *testing.T) {
+	for _, key := range []string{"", "nsec1abc", testKey[:62]} {
+		_, err := ParseKey(key)
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	}
+}
+
+// relayServer answers every EVENT with an OK message, accepted or not
+func relayServer(t *testing.T, accept bool) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		message := []json.RawMessage{}
+		if err := conn.ReadJSON(&message); err != nil {
+			return
+		}
+		event := Event{}
+		json.Unmarshal(message[1], &event)
+
+		conn.WriteJSON([]interface{}{"NOTICE", "welcome"})
+		reason := ""
+		if !accept {
+			reason = "blocked: not on the allow list"
+		}
+		conn.WriteJSON([]interface{}{"OK", event.ID, accept && event.Verify(), reason})
+	}))
+}
+
+func TestRelayPublisher(t *testing.T) {
+	accepting := relayServer(t, true)
+	defer accepting.Close()
+	rejecting := relayServer(t, false)
+	defer rejecting.Close()
+
+	key, _ := ParseKey(testKey)
+	event := Event{CreatedAt: 1718020800, Kind: KindClassifiedListing, Content: "bounty"}
+	assert.NoError(t, event.Sign(key))
+
+	acceptingUrl := "ws" + strings.TrimPrefix(accepting.URL, "http")
+	rejectingUrl := "ws" + strings.TrimPrefix(rejecting.URL, "http")
+	results := NewRelayPublisher([]string{acceptingUrl, rejectingUrl, "ws://127.0.0.1:1"}).Publish(context.Background(), event)
+
+	assert.Len(t, results, 3)
+	assert.NoError(t, results[acceptingUrl])
+	assert.EqualError(t, results[rejectingUrl], "relay rejected the event: blocked: not on the allow list")
+	assert.Error(t, results["ws://127.0.0.1:1"])
+}
@@ -0,0 +1,99 @@
+package nostr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Publisher sends an event to relays, the result has the error of every relay
+// that did not accept it and nil for the ones that did
+type Publisher interface {
+	Publish(ctx context.Context, event Event) map[string]error
+}
+
+// RelayPublisher sends events to a set of relays over their websockets, one
+// connection per event and relay
+type RelayPublisher struct {
+	Relays  []string
+	Timeout time.Duration
+	dialer  *websocket.Dialer
+}
+
+func NewRelayPublisher(relays []string) *RelayPublisher {
+	return &RelayPublisher{
+		Relays:  relays,
+		Timeout: 10 * time.Second,
+		dialer:  websocket.DefaultDialer,
+	}
+}
+
+func (p *RelayPublisher) Publish(ctx context.Context, event Event) map[string]error {
+	results := map[string]error{}
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for _, relay := range p.Relays {
+		wg.Add(1)
+		go func(relay string) {
+			defer wg.Done()
+			err := p.publishTo(ctx, relay, event)
+			mu.Lock()
+			results[relay] = err
+			mu.Unlock()
+		}(relay)
+	}
+	wg.Wait()
+	return results
+}
+
+// publishTo sends the event to a relay and waits for its OK message, NIP-20
+func (p *RelayPublisher) publishTo(ctx context.Context, relay string, event Event) error {
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	conn, _, err := p.dialer.DialContext(ctx, relay, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	conn.SetWriteDeadline(deadline)
+	conn.SetReadDeadline(deadline)
+	if err := conn.WriteJSON([]interface{}{"EVENT", event}); err != nil {
+		return err
+	}
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		answer := []json.RawMessage{}
+		if json.Unmarshal(message, &answer) != nil || len(answer) < 3 {
+			continue
+		}
+
+		var label, id string
+		json.Unmarshal(answer[0], &label)
+		json.Unmarshal(answer[1], &id)
+		if label != "OK" || id != event.ID {
+			continue
+		}
+
+		var accepted bool
+		var reason string
+		json.Unmarshal(answer[2], &accepted)
+		if len(answer) > 3 {
+			json.Unmarshal(answer[3], &reason)
+		}
+		if !accepted {
+			return fmt.Errorf("relay rejected the event: %s", reason)
+		}
+		return nil
+	}
+}
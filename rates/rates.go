@@ -0,0 +1,159 @@
+package rates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+const satsPerBtc = 100000000
+
+var ErrUnsupportedCurrency = errors.New("currency is not supported")
+
+// Currencies are the display currencies a workspace can pick, as lowercase codes
+var Currencies = []string{"usd", "eur", "gbp", "cad", "aud", "chf", "jpy", "inr", "brl", "ngn"}
+
+// Supported reports whether currency is one of Currencies
+func Supported(currency string) bool {
+	for _, c := range Currencies {
+		if c == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// Rate is the price of one bitcoin in a currency, Timestamp is when the
+// provider last updated it
+type Rate struct {
+	Currency  string
+	BtcPrice  float64
+	Timestamp time.Time
+}
+
+// Convert turns sats into the currency of the rate, rounded to cents
+func (r Rate) Convert(sats uint) float64 {
+	return math.Round(float64(sats)*r.BtcPrice/satsPerBtc*100) / 100
+}
+
+type Provider interface {
+	Rate(ctx context.Context, currency string) (Rate, error)
+}
+
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HttpProvider reads rates from an api answering like the coingecko simple
+// price endpoint, {"bitcoin": {"usd": 67000.5, "last_updated_at": 1718000000}}
+type HttpProvider struct {
+	httpClient HttpClient
+	Url        string
+	Timeout    time.Duration
+}
+
+func NewHttpProvider(httpClient HttpClient, url string) *HttpProvider {
+	return &HttpProvider{
+		httpClient: httpClient,
+		Url:        url,
+		Timeout:    10 * time.Second,
+	}
+}
+
+func (p *HttpProvider) Rate(ctx context.Context, currency string) (Rate, error) {
+	if !Supported(currency) {
+		return Rate{}, ErrUnsupportedCurrency
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("ids", "bitcoin")
+	query.Set("vs_currencies", currency)
+	query.Set("include_last_updated_at", "true")
+	separator := "?"
+	if strings.Contains(p.Url, "?") {
+		separator = "&"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.Url+separator+query.Encode(), nil)
+	if err != nil {
+		return Rate{}, err
+	}
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return Rate{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return Rate{}, fmt.Errorf("rate provider answered %d", res.StatusCode)
+	}
+
+	prices := map[string]map[string]float64{}
+	if err := json.NewDecoder(res.Body).Decode(&prices); err != nil {
+		return Rate{}, err
+	}
+	price, ok := prices["bitcoin"][currency]
+	if !ok || price <= 0 {
+		return Rate{}, fmt.Errorf("rate provider has no %s price", currency)
+	}
+
+	timestamp := time.Now()
+	if updated := prices["bitcoin"]["last_updated_at"]; updated > 0 {
+		timestamp = time.Unix(int64(updated), 0)
+	}
+	return Rate{Currency: currency, BtcPrice: price, Timestamp: timestamp.UTC()}, nil
+}
+
+// CachedProvider keeps the rates of another provider for a while, so a page of
+// bounties asks the provider once per currency
+type CachedProvider struct {
+	provider Provider
+	cache    *cache.Cache
+}
+
+func NewCachedProvider(provider Provider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{
+		provider: provider,
+		cache:    cache.New(ttl, 2*ttl),
+	}
+}
+
+func (p *CachedProvider) Rate(ctx context.Context, currency string) (Rate, error) {
+	if rate, ok := p.cache.Get(currency); ok {
+		return rate.(Rate), nil
+	}
+	rate, err := p.provider.Rate(ctx, currency)
+	if err != nil {
+		return Rate{}, err
+	}
+	p.cache.Set(currency, rate, cache.DefaultExpiration)
+	return rate, nil
+}
+
+var (
+	defaultProvider     Provider
+	defaultProviderOnce sync.Once
+)
+
+// Default is the cached provider of config.RateProviderUrl shared by the handlers
+func Default() Provider {
+	defaultProviderOnce.Do(func() {
+		defaultProvider = NewCachedProvider(
+			NewHttpProvider(http.DefaultClient, config.RateProviderUrl),
+			time.Duration(config.RateCacheSeconds)*time.Second,
+		)
+	})
+	return defaultProvider
+}
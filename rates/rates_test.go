@@ -0,0 +1,84 @@
+package rates
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClient struct {
+	calls  int
+	status int
+	body   string
+	err    error
+	query  string
+}
+
+func (f *fakeClient) Do(req *http.Request) (*http.Response, error) {
+	f.calls++
+	f.query = req.URL.RawQuery
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &http.Response{StatusCode: f.status, Body: io.NopCloser(strings.NewReader(f.body))}, nil
+}
+
+func TestHttpProvider(t *testing.T) {
+	t.Run("should read the price and its update time", func(t *testing.T) {
+		client := &fakeClient{status: http.StatusOK, body: `{"bitcoin": {"eur": 55000.5, "last_updated_at": 1718020800}}`}
+		provider := NewHttpProvider(client, "https://rates.example.com/simple/price")
+
+		rate, err := provider.Rate(context.Background(), "eur")
+		assert.NoError(t, err)
+		assert.Equal(t, Rate{Currency: "eur", BtcPrice: 55000.5, Timestamp: time.Unix(1718020800, 0).UTC()}, rate)
+		assert.Equal(t, "ids=bitcoin&include_last_updated_at=true&vs_currencies=eur", client.query)
+	})
+
+	t.Run("should reject unsupported currencies without a call", func(t *testing.T) {
+		client := &fakeClient{status: http.StatusOK}
+		_, err := NewHttpProvider(client, "https://rates.example.com").Rate(context.Background(), "doge")
+		assert.ErrorIs(t, err, ErrUnsupportedCurrency)
+		assert.Equal(t, 0, client.calls)
+	})
+
+	t.Run("should fail on errors and missing prices", func(t *testing.T) {
+		for _, client := range []*fakeClient{
+			{err: errors.New("connection refused")},
+			{status: http.StatusTooManyRequests, body: `{}`},
+			{status: http.StatusOK, body: `{"bitcoin": {"usd": 67000}}`},
+			{status: http.StatusOK, body: `not json`},
+		} {
+			_, err := NewHttpProvider(client, "https://rates.example.com").Rate(context.Background(), "eur")
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestCachedProvider(t *testing.T) {
+	client := &fakeClient{status: http.StatusOK, body: `{"bitcoin": {"usd": 67000, "eur": 62000}}`}
+	provider := NewCachedProvider(NewHttpProvider(client, "https://rates.example.com"), time.Minute)
+
+	for i := 0; i < 3; i++ {
+		rate, err := provider.Rate(context.Background(), "usd")
+		assert.NoError(t, err)
+		assert.Equal(t, float64(67000), rate.BtcPrice)
+	}
+	assert.Equal(t, 1, client.calls)
+
+	_, err := provider.Rate(context.Background(), "eur")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestConvert(t *testing.T) {
+	rate := Rate{Currency: "usd", BtcPrice: 67123.45}
+	assert.Equal(t, 67123.45, rate.Convert(100000000))
+	assert.Equal(t, 6.71, rate.Convert(10000))
+	assert.Equal(t, float64(0), rate.Convert(0))
+}
@@ -0,0 +1,125 @@
+package relay
+
+import (
+	"sync"
+	"time"
+)
+
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half_open"
+)
+
+// Health is what the breaker knows about the relay, it is reported by /readyz
+type Health struct {
+	State               State      `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastFailure         *time.Time `json:"last_failure,omitempty"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	OpenUntil           *time.Time `json:"open_until,omitempty"`
+}
+
+// Breaker stops calls to the relay after Threshold failures in a row. Once
+// Cooldown has passed one call is let through, its result closes or reopens it
+type Breaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool
+	health   Health
+	now      func() time.Time
+}
+
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		Threshold: threshold,
+		Cooldown:  cooldown,
+		state:     StateClosed,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a call can be made now
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.Cooldown {
+			return false
+		}
+		b.state = StateHalfOpen
+		b.trial = true
+		return true
+	case StateHalfOpen:
+		// only the trial call goes through until it has a result
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	}
+	return true
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.state = StateClosed
+	b.failures = 0
+	b.trial = false
+	b.health.LastSuccess = &now
+}
+
+func (b *Breaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.failures++
+	b.trial = false
+	b.health.LastFailure = &now
+	if err != nil {
+		b.health.LastError = err.Error()
+	}
+	if b.state == StateHalfOpen || b.failures >= b.Threshold {
+		b.state = StateOpen
+		b.openedAt = now
+	}
+}
+
+func (b *Breaker) Health() Health {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health := b.health
+	health.State = b.state
+	health.ConsecutiveFailures = b.failures
+	if b.state == StateOpen {
+		until := b.openedAt.Add(b.Cooldown)
+		health.OpenUntil = &until
+	}
+	return health
+}
+
+// Reset closes the breaker and forgets its history
+func (b *Breaker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = StateClosed
+	b.failures = 0
+	b.trial = false
+	b.health = Health{}
+}
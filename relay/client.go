@@ -0,0 +1,160 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+var ErrCircuitOpen = errors.New("relay is unavailable, calls are paused")
+
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+var (
+	defaultBreaker     *Breaker
+	defaultBreakerOnce sync.Once
+)
+
+// DefaultBreaker is shared by every relay client, so an outage seen by one
+// handler stops the calls of all of them
+func DefaultBreaker() *Breaker {
+	defaultBreakerOnce.Do(func() {
+		defaultBreaker = NewBreaker(config.RelayBreakerThreshold, time.Duration(config.RelayBreakerCooldown)*time.Second)
+	})
+	return defaultBreaker
+}
+
+// Status reports the health of the shared breaker
+func Status() Health {
+	return DefaultBreaker().Health()
+}
+
+// Client calls the relay with a timeout per attempt, retries the idempotent
+// calls that fail and fails fast while the breaker is open
+type Client struct {
+	httpClient HttpClient
+	breaker    *Breaker
+	Timeout    time.Duration
+	Retries    int
+	Backoff    time.Duration
+}
+
+func NewClient(httpClient HttpClient) *Client {
+	return NewClientWithBreaker(httpClient, DefaultBreaker())
+}
+
+func NewClientWithBreaker(httpClient HttpClient, breaker *Breaker) *Client {
+	return &Client{
+		httpClient: httpClient,
+		breaker:    breaker,
+		Timeout:    time.Duration(config.RelayTimeout) * time.Second,
+		Retries:    config.RelayRetries,
+		Backoff:    250 * time.Millisecond,
+	}
+}
+
+// NewRequest builds a request to a path of the relay, authenticated with the relay auth key
+func (c *Client) NewRequest(ctx context.Context, method string, path string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, config.RelayUrl+path, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-user-token", config.CurrentRelayAuthKey())
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+func (c *Client) Health() Health {
+	return c.breaker.Health()
+}
+
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// Do sends a request to the relay. A transport error or a 5xx answer is a failure,
+// only GET and HEAD are retried since a payment must never be sent twice.
+// After the last attempt a 5xx answer is returned as is for the caller to read
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if !c.breaker.Allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	attempts := 1
+	if idempotent(req.Method) {
+		attempts += c.Retries
+	}
+
+	for attempt := 1; ; attempt++ {
+		res, err := c.attempt(req, attempt)
+		if err == nil && res.StatusCode < http.StatusInternalServerError {
+			c.breaker.Success()
+			return res, nil
+		}
+
+		failure := err
+		if failure == nil {
+			failure = fmt.Errorf("relay answered %d", res.StatusCode)
+		}
+		if attempt >= attempts {
+			c.breaker.Failure(failure)
+			return res, err
+		}
+		if res != nil && res.Body != nil {
+			res.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			c.breaker.Failure(failure)
+			return nil, req.Context().Err()
+		case <-time.After(c.Backoff * time.Duration(attempt)):
+		}
+	}
+}
+
+func (c *Client) attempt(req *http.Request, attempt int) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), c.Timeout)
+	try := req.Clone(ctx)
+	if attempt > 1 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		try.Body = body
+	}
+
+	res, err := c.httpClient.Do(try)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if res.Body == nil {
+		cancel()
+		return res, nil
+	}
+	// the timeout covers reading the body, it is released once the caller closes it
+	res.Body = cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
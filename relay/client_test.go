@@ -0,0 +1,141 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClient struct {
+	mu        sync.Mutex
+	calls     int
+	responses []func(req *http.Request) (*http.Response, error)
+}
+
+func (f *fakeClient) Do(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	respond := f.responses[f.calls%len(f.responses)]
+	f.calls++
+	return respond(req)
+}
+
+func answer(status int) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+	}
+}
+
+func fail(req *http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func newTestClient(responses ...func(req *http.Request) (*http.Response, error)) (*Client, *fakeClient, *Breaker) {
+	fake := &fakeClient{responses: responses}
+	breaker := NewBreaker(3, time.Minute)
+	client := NewClientWithBreaker(fake, breaker)
+	client.Timeout = time.Second
+	client.Retries = 2
+	client.Backoff = time.Millisecond
+	return client, fake, breaker
+}
+
+func TestClientRetries(t *testing.T) {
+	t.Run("should retry a failed GET", func(t *testing.T) {
+		client, fake, breaker := newTestClient(fail, answer(http.StatusBadGateway), answer(http.StatusOK))
+		req, _ := client.NewRequest(context.Background(), http.MethodGet, "/invoice", nil)
+
+		res, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, 3, fake.calls)
+		assert.Equal(t, StateClosed, breaker.Health().State)
+	})
+
+	t.Run("should never retry a POST", func(t *testing.T) {
+		client, fake, breaker := newTestClient(fail, answer(http.StatusOK))
+		req, _ := client.NewRequest(context.Background(), http.MethodPost, "/payment", []byte(`{}`))
+
+		_, err := client.Do(req)
+		assert.Error(t, err)
+		assert.Equal(t, 1, fake.calls)
+		assert.Equal(t, 1, breaker.Health().ConsecutiveFailures)
+	})
+
+	t.Run("should return the last 5xx answer for the caller to read", func(t *testing.T) {
+		client, fake, _ := newTestClient(answer(http.StatusInternalServerError))
+		req, _ := client.NewRequest(context.Background(), http.MethodGet, "/invoice", nil)
+
+		res, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+		assert.Equal(t, 3, fake.calls)
+	})
+
+	t.Run("should time out a hanging call", func(t *testing.T) {
+		hang := func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		}
+		client, _, _ := newTestClient(hang)
+		client.Timeout = 10 * time.Millisecond
+		req, _ := client.NewRequest(context.Background(), http.MethodPost, "/payment", nil)
+
+		start := time.Now()
+		_, err := client.Do(req)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestBreaker(t *testing.T) {
+	t.Run("should fail fast once open and let a trial through after the cooldown", func(t *testing.T) {
+		client, fake, breaker := newTestClient(fail)
+		now := time.Now()
+		breaker.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			req, _ := client.NewRequest(context.Background(), http.MethodPost, "/payment", nil)
+			client.Do(req)
+		}
+		assert.Equal(t, StateOpen, breaker.Health().State)
+		assert.NotNil(t, breaker.Health().OpenUntil)
+
+		req, _ := client.NewRequest(context.Background(), http.MethodPost, "/payment", nil)
+		_, err := client.Do(req)
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, 3, fake.calls)
+
+		now = now.Add(time.Minute)
+		fake.responses = []func(req *http.Request) (*http.Response, error){answer(http.StatusOK)}
+		assert.True(t, breaker.Allow())
+		assert.False(t, breaker.Allow(), "only one trial call while half open")
+		breaker.Success()
+		assert.Equal(t, StateClosed, breaker.Health().State)
+	})
+
+	t.Run("should reopen when the trial call fails", func(t *testing.T) {
+		breaker := NewBreaker(1, time.Minute)
+		now := time.Now()
+		breaker.now = func() time.Time { return now }
+
+		breaker.Failure(errors.New("down"))
+		assert.False(t, breaker.Allow())
+
+		now = now.Add(time.Minute)
+		assert.True(t, breaker.Allow())
+		assert.Equal(t, StateHalfOpen, breaker.Health().State)
+		breaker.Failure(errors.New("still down"))
+
+		health := breaker.Health()
+		assert.Equal(t, StateOpen, health.State)
+		assert.Equal(t, "still down", health.LastError)
+	})
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+)
+
+func ActivityPubRoutes() chi.Router {
+	r := chi.NewRouter()
+	activityPubHandlers := handlers.NewActivityPubHandler(db.DB)
+	r.Group(func(r chi.Router) {
+		r.Get("/workspaces/{uuid}", activityPubHandlers.GetActor)
+		r.Get("/workspaces/{uuid}/outbox", activityPubHandlers.GetOutbox)
+		r.Get("/workspaces/{uuid}/followers", activityPubHandlers.GetFollowers)
+		r.Post("/workspaces/{uuid}/inbox", activityPubHandlers.PostInbox)
+	})
+	return r
+}
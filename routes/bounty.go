@@ -1,18 +1,40 @@
 package routes
 
 import (
-	"net/http"
-
 	"github.com/go-chi/chi"
 	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/utils"
 )
 
+// mountBountyRoutes mounts the bounty routes, /gobounties for the app and
+// /bounties for the super admin ones
+func mountBountyRoutes(r chi.Router) {
+	r.Mount("/gobounties", BountyRoutes())
+	r.Mount("/bounties", BountyAdminRoutes())
+}
+
+// BountyAdminRoutes are the bounty routes only super admins can call
+func BountyAdminRoutes() chi.Router {
+	r := chi.NewRouter()
+	bountyHandler := handlers.NewBountyHandler(utils.TracedClient, db.DB)
+	r.Group(func(r chi.Router) {
+		r.Use(routeGroup(config.RouteGroupAdmin))
+		r.Use(auth.PubKeyContextSuperAdmin)
+		r.With(routeGroup(config.RouteGroupPayments)).Post("/payments/reconcile", bountyHandler.ReconcilePayments)
+	})
+	return r
+}
+
 func BountyRoutes() chi.Router {
 	r := chi.NewRouter()
-	bountyHandler := handlers.NewBountyHandler(http.DefaultClient, db.DB)
+	bountyHandler := handlers.NewBountyHandler(utils.TracedClient, db.DB)
+	payments := routeGroup(config.RouteGroupPayments)
+	ai := routeGroup(config.RouteGroupAI)
 	r.Group(func(r chi.Router) {
+		r.Use(auth.OptionalPubKeyContext)
 		r.Get("/all", bountyHandler.GetAllBounties)
 
 		r.Get("/id/{bountyId}", bountyHandler.GetBountyById)
@@ -27,21 +49,59 @@ func BountyRoutes() chi.Router {
 		r.Get("/created/{created}", bountyHandler.GetBountyByCreated)
 		r.Get("/count/{personKey}/{tabType}", handlers.GetUserBountyCount)
 		r.Get("/count", handlers.GetBountyCount)
-		r.Get("/invoice/{paymentRequest}", bountyHandler.GetInvoiceData)
+		r.With(payments).Get("/invoice/{paymentRequest}", bountyHandler.GetInvoiceData)
 		r.Get("/filter/count", handlers.GetFilterCount)
+		r.Get("/{id}/assignee/history", bountyHandler.GetBountyAssigneeHistory)
+		r.Get("/{id}/hours", bountyHandler.GetBountyEffort)
+		r.Get("/{id}/receipt", bountyHandler.GetBountyReceipt)
+		r.Get("/{id}/transitions", bountyHandler.GetBountyStateTransitions)
+		r.Get("/{id}/nostr", bountyHandler.GetBountyNostrEvents)
+		r.Get("/{id}/comments", bountyHandler.GetBountyComments)
+		r.Post("/receipt/verify", handlers.VerifyBountyReceipt)
 
 	})
+	r.Group(func(r chi.Router) {
+		r.Use(auth.ApiTokenContext(db.ApiScopeCreateBounties, db.ApiScopeUpdateBounties))
+		r.Post("/api", bountyHandler.CreateOrEditBounty)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(auth.ApiTokenContext(db.ApiScopeCreateComments))
+		r.Post("/{id}/comments/agent", bountyHandler.CreateBountyComment)
+	})
 	r.Group(func(r chi.Router) {
 		r.Use(auth.PubKeyContext)
-		r.Post("/pay/{id}", bountyHandler.MakeBountyPayment)
-		r.Post("/budget/withdraw", bountyHandler.BountyBudgetWithdraw)
-		r.Post("/budget_workspace/withdraw", bountyHandler.NewBountyBudgetWithdraw)
+		r.With(payments).Post("/pay/{id}", bountyHandler.MakeBountyPayment)
+		r.With(payments).Post("/budget/withdraw", bountyHandler.BountyBudgetWithdraw)
+		r.With(payments).Post("/budget_workspace/withdraw", bountyHandler.NewBountyBudgetWithdraw)
 
 		r.Post("/", bountyHandler.CreateOrEditBounty)
+		r.Patch("/{id}", bountyHandler.PatchBounty)
 		r.Delete("/assignee", handlers.DeleteBountyAssignee)
 		r.Delete("/{pubkey}/{created}", bountyHandler.DeleteBounty)
-		r.Post("/paymentstatus/{created}", handlers.UpdatePaymentStatus)
+		r.With(payments).Post("/paymentstatus/{created}", handlers.UpdatePaymentStatus)
 		r.Post("/completedstatus/{created}", handlers.UpdateCompletedStatus)
+
+		r.With(ai).Post("/chat", bountyHandler.HandleChatCommand)
+		r.Get("/price/suggestion", bountyHandler.SuggestBountyPrice)
+
+		r.Post("/{id}/apply", bountyHandler.ApplyForBounty)
+		r.Delete("/{id}/apply", bountyHandler.WithdrawBountyApplication)
+		r.Get("/{id}/applicants", bountyHandler.GetBountyApplicants)
+		r.Post("/{id}/applicants/{applicationId}/accept", bountyHandler.AcceptBountyApplication)
+		r.Post("/{id}/applicants/{applicationId}/reject", bountyHandler.RejectBountyApplication)
+		r.Post("/{id}/auction", bountyHandler.CreateBountyAuction)
+		r.Get("/{id}/auction", bountyHandler.GetBountyAuction)
+		r.Post("/{id}/auction/bids", bountyHandler.PlaceBountyBid)
+		r.Delete("/{id}/auction/bids", bountyHandler.WithdrawBountyBid)
+		r.Post("/{id}/auction/bids/{bidId}/select", bountyHandler.SelectBountyBid)
+		r.Post("/{id}/activity", bountyHandler.RecordBountyActivity)
+		r.Post("/{id}/hours", bountyHandler.LogBountyHours)
+		r.Post("/{id}/transition", bountyHandler.TransitionBounty)
+		r.Post("/{id}/comments", bountyHandler.CreateBountyComment)
+		r.Put("/{id}/tags", bountyHandler.SetBountyTags)
+		r.Post("/{id}/tags/{tag_uuid}", bountyHandler.AddBountyTag)
+		r.Delete("/{id}/tags/{tag_uuid}", bountyHandler.RemoveBountyTag)
+		r.Delete("/{id}/comments/{commentId}", bountyHandler.DeleteBountyComment)
 	})
 	return r
 }
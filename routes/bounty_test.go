@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcilePaymentsRoute(t *testing.T) {
+	r := chi.NewRouter()
+	mountBountyRoutes(r)
+
+	serve := func(path string) int {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, path, nil))
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("/bounties/payments/reconcile"), "the route should be mounted and ask for a super admin")
+	assert.Equal(t, http.StatusMethodNotAllowed, serve("/gobounties/payments/reconcile"), "the route should not be under /gobounties anymore")
+}
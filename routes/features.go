@@ -3,28 +3,61 @@ package routes
 import (
 	"github.com/go-chi/chi"
 	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/utils"
 )
 
 func FeatureRoutes() chi.Router {
 	r := chi.NewRouter()
 	featureHandlers := handlers.NewFeatureHandler(&db.DB)
+	jiraHandlers := handlers.NewJiraHandler(&db.DB)
+	phasePlanHandlers := handlers.NewPhasePlanHandler(utils.TracedClient, &db.DB)
+	ai := routeGroup(config.RouteGroupAI)
+	r.Group(func(r chi.Router) {
+		stakwork := auth.SignedWebhookContext(auth.WebhookSourceStakwork)
+
+		r.With(ai, stakwork).Post("/plans/{plan_uuid}/result", phasePlanHandlers.ReceivePhasePlanResult)
+		r.With(stakwork).Post("/stakwork/brief", featureHandlers.UpdateFeatureBriefWebhook)
+		r.With(stakwork).Post("/stakwork/story", featureHandlers.CreateOrEditStoryWebhook)
+	})
+
 	r.Group(func(r chi.Router) {
 		r.Use(auth.PubKeyContext)
 
 		r.Post("/", featureHandlers.CreateOrEditFeatures)
 		r.Get("/{uuid}", featureHandlers.GetFeatureByUuid)
+		r.Patch("/{uuid}", featureHandlers.PatchFeature)
+		r.Get("/{uuid}/full", featureHandlers.GetFeatureDetail)
 		// Old route for to getting features for workspace uuid
 		r.Get("/forworkspace/{workspace_uuid}", featureHandlers.GetFeaturesByWorkspaceUuid)
 		r.Get("/workspace/count/{uuid}", featureHandlers.GetWorkspaceFeaturesCount)
 		r.Delete("/{uuid}", featureHandlers.DeleteFeature)
 
+		r.Post("/brief", featureHandlers.UpdateFeatureBrief)
+		r.Post("/{uuid}/brief/lock", featureHandlers.LockFeatureBrief)
+		r.Get("/{uuid}/brief/lock", featureHandlers.GetFeatureBriefLock)
+		r.Delete("/{uuid}/brief/lock", featureHandlers.UnlockFeatureBrief)
+
 		r.Post("/phase", featureHandlers.CreateOrEditFeaturePhase)
 		r.Get("/{feature_uuid}/phase", featureHandlers.GetFeaturePhases)
 		r.Get("/{feature_uuid}/phase/{phase_uuid}", featureHandlers.GetFeaturePhaseByUUID)
 		r.Delete("/{feature_uuid}/phase/{phase_uuid}", featureHandlers.DeleteFeaturePhase)
 
+		r.Post("/{feature_uuid}/phase/{phase_uuid}/documents/{doc_type}", featureHandlers.AddPhaseDocument)
+		r.Get("/{feature_uuid}/phase/{phase_uuid}/documents/{doc_type}", featureHandlers.GetPhaseDocument)
+		r.Get("/{feature_uuid}/phase/{phase_uuid}/documents/{doc_type}/history", featureHandlers.GetPhaseDocumentHistory)
+		r.Get("/{feature_uuid}/phase/{phase_uuid}/documents/{doc_type}/diff", featureHandlers.GetPhaseDocumentDiff)
+		r.Post("/{feature_uuid}/phase/{phase_uuid}/documents/{doc_type}/restore/{version}", featureHandlers.RestorePhaseDocument)
+
+		r.Post("/{feature_uuid}/calls", featureHandlers.CreateFeatureCall)
+		r.Get("/{feature_uuid}/calls", featureHandlers.GetFeatureCalls)
+		r.Get("/{feature_uuid}/calls/history", featureHandlers.GetFeatureCallHistory)
+		r.Put("/{feature_uuid}/calls/{call_uuid}", featureHandlers.UpdateFeatureCall)
+		r.Get("/{feature_uuid}/calls/{call_uuid}/transcript", featureHandlers.GetFeatureCallTranscript)
+		r.Get("/{feature_uuid}/transcripts/search", featureHandlers.SearchFeatureTranscripts)
+
 		r.Post("/story", featureHandlers.CreateOrEditStory)
 		r.Get("/{feature_uuid}/story", featureHandlers.GetStoriesByFeatureUuid)
 		r.Get("/{feature_uuid}/story/{story_uuid}", featureHandlers.GetStoryByUuid)
@@ -32,6 +65,17 @@ func FeatureRoutes() chi.Router {
 		r.Get("/{feature_uuid}/phase/{phase_uuid}/bounty", featureHandlers.GetBountiesByFeatureAndPhaseUuid)
 		r.Get("/{feature_uuid}/phase/{phase_uuid}/bounty/count", featureHandlers.GetBountiesCountByFeatureAndPhaseUuid)
 
+		r.Get("/{feature_uuid}/jira/export", jiraHandlers.ExportFeatureToJira)
+		r.Post("/{feature_uuid}/phase/{phase_uuid}/jira/import", jiraHandlers.ImportJiraIssues)
+
+		r.With(ai).Post("/{feature_uuid}/phase/{phase_uuid}/plan", phasePlanHandlers.PlanPhase)
+		r.With(ai).Get("/{feature_uuid}/phase/{phase_uuid}/plan", phasePlanHandlers.GetPhasePlans)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(auth.ApiTokenContext(db.ApiScopeUploadTranscripts))
+
+		r.Post("/{feature_uuid}/calls/{call_uuid}/transcript", featureHandlers.UploadFeatureCallTranscript)
 	})
 	return r
 }
@@ -0,0 +1,72 @@
+package routes
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	btcec "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureStoryAndBriefRoutes(t *testing.T) {
+	defer auth.SetWebhookSecretSource(nil)
+	auth.SetWebhookSecretSource(func(source string) []string {
+		if source == auth.WebhookSourceStakwork {
+			return []string{"stakwork_secret"}
+		}
+		return nil
+	})
+
+	key, _ := btcec.NewPrivateKey()
+	router := FeatureRoutes()
+
+	// the bodies are not json so a request let in stops at decoding, before the database
+	body := "not json"
+	withUser := func(path string) *http.Request {
+		timeBuf := make([]byte, 4)
+		binary.BigEndian.PutUint32(timeBuf, uint32(time.Now().Unix()))
+		sig, _ := auth.Sign(timeBuf, key)
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("x-jwt", base64.URLEncoding.EncodeToString(append(timeBuf, sig...)))
+		return req
+	}
+	withSignature := func(path string) *http.Request {
+		now := time.Now().Unix()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("x-webhook-timestamp", strconv.FormatInt(now, 10))
+		req.Header.Set("x-webhook-signature", auth.SignWebhook("stakwork_secret", now, []byte(body)))
+		return req
+	}
+	serve := func(req *http.Request) int {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	t.Run("should let users post briefs and stories with their token", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotAcceptable, serve(withUser("/brief")))
+		assert.Equal(t, http.StatusBadRequest, serve(withUser("/story")))
+	})
+
+	t.Run("should not ask users for a webhook signature", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve(withSignature("/brief")))
+		assert.Equal(t, http.StatusUnauthorized, serve(withSignature("/story")))
+	})
+
+	t.Run("should let stakwork post briefs and stories with a signature", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotAcceptable, serve(withSignature("/stakwork/brief")))
+		assert.Equal(t, http.StatusBadRequest, serve(withSignature("/stakwork/story")))
+	})
+
+	t.Run("should refuse unsigned stakwork callbacks even from a user", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve(withUser("/stakwork/brief")))
+		assert.Equal(t, http.StatusUnauthorized, serve(withUser("/stakwork/story")))
+	})
+}
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+func HivechatRoutes() chi.Router {
+	r := chi.NewRouter()
+	hivechatHandler := handlers.NewHivechatHandler(utils.TracedClient, db.DB)
+	r.Group(func(r chi.Router) {
+		r.Post("/{chat_id}/agent/callback", hivechatHandler.ReceiveChatAgentResponse)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+
+		r.Post("/", hivechatHandler.CreateChat)
+		r.Get("/{chat_id}/messages", hivechatHandler.GetChatMessages)
+		r.Get("/{chat_id}/messages/poll", hivechatHandler.PollChatMessages)
+		r.Post("/{chat_id}/messages", hivechatHandler.CreateChatMessage)
+		r.Get("/{chat_id}/export.md", hivechatHandler.ExportChat)
+		r.Get("/{chat_id}/export.html", hivechatHandler.ExportChat)
+		r.Put("/{chat_id}/agent", hivechatHandler.SetChatAgentWebhook)
+		r.Get("/{chat_id}/agent", hivechatHandler.GetChatAgentWebhook)
+		r.Delete("/{chat_id}/agent", hivechatHandler.DeleteChatAgentWebhook)
+		r.Get("/artifacts", hivechatHandler.GetArtifacts)
+		r.Post("/artifacts", hivechatHandler.CreateArtifact)
+		r.Get("/artifacts/{uuid}", hivechatHandler.GetArtifact)
+		r.Put("/artifacts/{uuid}", hivechatHandler.UpdateArtifact)
+		r.Delete("/artifacts/{uuid}", hivechatHandler.DeleteArtifact)
+		r.Get("/artifacts/{uuid}/versions", hivechatHandler.GetArtifactVersions)
+		r.Post("/embeddings", hivechatHandler.IngestEmbeddings)
+		r.Post("/context-search", hivechatHandler.ContextSearch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(auth.ApiTokenContext(db.ApiScopeChatMessages))
+		r.Post("/{chat_id}/messages/agent", hivechatHandler.CreateChatMessage)
+		r.Post("/{chat_id}/working", hivechatHandler.SetChatWorking)
+	})
+	return r
+}
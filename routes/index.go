@@ -13,8 +13,10 @@ import (
 	"github.com/rs/cors"
 
 	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/utils"
 )
 
 // NewRouter creates a chi router
@@ -24,7 +26,18 @@ func NewRouter() *http.Server {
 	authHandler := handlers.NewAuthHandler(db.DB)
 	channelHandler := handlers.NewChannelHandler(db.DB)
 	botHandler := handlers.NewBotHandler(db.DB)
-	bHandler := handlers.NewBountyHandler(http.DefaultClient, db.DB)
+	bHandler := handlers.NewBountyHandler(utils.TracedClient, db.DB)
+	searchHandler := handlers.NewSearchHandler(db.DB)
+	configHandler := handlers.NewConfigHandler(db.DB)
+	peopleHandler := handlers.NewPeopleHandler(db.DB)
+	featureHandler := handlers.NewFeatureHandler(db.DB)
+	healthHandler := handlers.NewHealthHandler(db.DB)
+	searchLimiter := utils.NewRateLimiter(config.SearchRateLimit, time.Minute)
+	config.OnConfigReload(func() {
+		searchLimiter.SetLimit(config.SearchRateLimit)
+	})
+	config.OnConfigReload(handlers.BroadcastMaintenanceChange)
+	handlers.OnWorkspaceEvent(handlers.NewBridgeHandler(utils.TracedClient, db.DB).OnWorkspaceEvent)
 
 	r.Mount("/tribes", TribeRoutes())
 	r.Mount("/bots", BotsRoutes())
@@ -33,10 +46,25 @@ func NewRouter() *http.Server {
 	r.Mount("/person", PersonRoutes())
 	r.Mount("/connectioncodes", ConnectionCodesRoutes())
 	r.Mount("/github_issue", GithubIssuesRoutes())
-	r.Mount("/gobounties", BountyRoutes())
+	mountBountyRoutes(r)
 	r.Mount("/workspaces", WorkspaceRoutes())
 	r.Mount("/metrics", MetricsRoutes())
 	r.Mount("/features", FeatureRoutes())
+	r.Mount("/notifications", NotificationRoutes())
+	r.With(routeGroup(config.RouteGroupAI)).Mount("/hivechat", HivechatRoutes())
+	r.With(routeGroup(config.RouteGroupAI)).Mount("/tickets", TicketRoutes())
+	r.With(routeGroup(config.RouteGroupAI)).Mount("/ticket-batches", TicketBatchRoutes())
+	r.Mount("/webhooks", WebhookRoutes())
+	r.Mount("/relay", RelayRoutes())
+	r.Mount("/orgs", OrgRoutes())
+	r.Mount("/s", ShortLinkRoutes())
+	r.Mount("/ap", ActivityPubRoutes())
+	r.Mount("/slack", SlackRoutes())
+	mountApiVersions(r)
+
+	r.Get("/readyz", healthHandler.Readyz)
+	r.Get("/maintenance", configHandler.GetMaintenance)
+	r.Get("/.well-known/webfinger", handlers.NewActivityPubHandler(db.DB).WebFinger)
 
 	r.Group(func(r chi.Router) {
 		r.Get("/tribe_by_feed", tribeHandlers.GetFirstTribeByFeed)
@@ -45,9 +73,10 @@ func NewRouter() *http.Server {
 		r.Get("/tribes_by_owner/{pubkey}", tribeHandlers.GetTribesByOwner)
 
 		r.Get("/search/bots/{query}", botHandler.SearchBots)
+		r.Get("/autocomplete/{type}", searchHandler.Autocomplete)
 		r.Get("/podcast", handlers.GetPodcast)
 		r.Get("/feed", handlers.GetGenericFeed)
-		r.Post("/feed/download", handlers.DownloadYoutubeFeed)
+		r.With(routeGroup(config.RouteGroupAI)).Post("/feed/download", handlers.DownloadYoutubeFeed)
 		r.Get("/search_podcasts", handlers.SearchPodcasts)
 		r.Get("/search_podcast_episodes", handlers.SearchPodcastEpisodes)
 		r.Get("/search_youtube", handlers.SearchYoutube)
@@ -59,7 +88,7 @@ func NewRouter() *http.Server {
 		r.Get("/poll/{challenge}", db.Poll)
 		r.Post("/save", db.PostSave)
 		r.Get("/save/{key}", db.PollSave)
-		r.Get("/websocket", handlers.HandleWebSocket)
+		r.With(auth.OptionalPubKeyContext).Get("/websocket", handlers.HandleWebSocket)
 		r.Get("/migrate_bounties", handlers.MigrateBounties)
 	})
 
@@ -77,17 +106,47 @@ func NewRouter() *http.Server {
 		r.Post("/badges", handlers.AddOrRemoveBadge)
 		r.Delete("/channel/{id}", channelHandler.DeleteChannel)
 		r.Delete("/ticket/{pubKey}/{created}", handlers.DeleteTicketByAdmin)
-		r.Get("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
+		r.With(routeGroup(config.RouteGroupPayments)).Get("/poll/invoice/{paymentRequest}", bHandler.PollInvoice)
 		r.Post("/meme_upload", handlers.MemeImageUpload)
 		r.Get("/admin/auth", authHandler.GetIsAdmin)
 	})
 
+	r.Group(func(r chi.Router) {
+		r.Use(routeGroup(config.RouteGroupAdmin))
+		r.Use(auth.PubKeyContextSuperAdmin)
+		r.Get("/admin/super_admins", authHandler.GetSuperAdmins)
+		r.Post("/admin/super_admins", authHandler.AddSuperAdmin)
+		r.Delete("/admin/super_admins/{pubkey}", authHandler.RemoveSuperAdmin)
+		r.Get("/admin/super_admins/changes", authHandler.GetPendingSuperAdminChanges)
+		r.Post("/admin/super_admins/confirm", authHandler.ConfirmSuperAdminChange)
+		r.Get("/admin/configs", configHandler.GetConfigOverrides)
+		r.Put("/admin/configs/{key}", configHandler.SetConfigOverride)
+		r.Delete("/admin/configs/{key}", configHandler.DeleteConfigOverride)
+		r.Put("/admin/maintenance", configHandler.SetMaintenance)
+		r.Get("/admin/webhook_secrets", configHandler.GetWebhookSigningSecrets)
+		r.Put("/admin/webhook_secrets/{source}", configHandler.RotateWebhookSigningSecret)
+		r.Delete("/admin/webhook_secrets/{source}", configHandler.DeleteWebhookSigningSecret)
+		r.Post("/admin/people/merge", peopleHandler.MergePeople)
+		r.Get("/admin/people/merges", peopleHandler.GetPersonMerges)
+		r.Get("/admin/people/alias_collisions", peopleHandler.GetAliasCollisions)
+		r.Get("/admin/people/handles/reservations", peopleHandler.GetHandleReservations)
+		r.Post("/admin/people/handles/reservations", peopleHandler.CreateHandleReservation)
+		r.Delete("/admin/people/handles/reservations/{handle}", peopleHandler.DeleteHandleReservation)
+		r.Post("/admin/features/counts/repair", featureHandler.RepairFeatureCounts)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.RealIP)
+		r.Use(searchLimiter.Middleware)
+		r.Get("/search", searchHandler.Search)
+	})
+
 	r.Group(func(r chi.Router) {
 		r.Get("/lnauth_login", handlers.ReceiveLnAuthData)
 		r.Get("/lnauth", handlers.GetLnurlAuth)
 		r.Get("/refresh_jwt", authHandler.RefreshToken)
-		r.Post("/invoices", handlers.GenerateInvoice)
-		r.Post("/budgetinvoices", tribeHandlers.GenerateBudgetInvoice)
+		r.With(routeGroup(config.RouteGroupPayments)).Post("/invoices", handlers.GenerateInvoice)
+		r.With(routeGroup(config.RouteGroupPayments)).Post("/budgetinvoices", tribeHandlers.GenerateBudgetInvoice)
 	})
 
 	PORT := os.Getenv("PORT")
@@ -138,19 +197,38 @@ func getFromAuth(path string) (*extractResponse, error) {
 	}, nil
 }
 
+// panicReporter forwards recovered panics to sentry when SENTRY_DSN is set
+func panicReporter() func(utils.PanicReport) {
+	if config.SentryDsn == "" {
+		return nil
+	}
+	reporter, err := utils.NewSentryReporter(config.SentryDsn, &http.Client{Timeout: 10 * time.Second})
+	if err != nil {
+		fmt.Println("[sentry] invalid SENTRY_DSN, panics will only be logged:", err)
+		return nil
+	}
+	return reporter.Report
+}
+
 func initChi() *chi.Mux {
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
+	r.Use(utils.TracingMiddleware)
+	r.Use(utils.RouteLatencies.Middleware)
+	r.Use(handlers.UsageMiddleware)
 	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
+	r.Use(utils.SecurityHeaders)
+	r.Use(utils.Recoverer(panicReporter()))
 	cors := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-User", "authorization", "x-jwt", "Referer", "User-Agent"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-User", "authorization", "x-jwt", "Referer", "User-Agent", "If-Match"},
+		ExposedHeaders:   []string{"ETag"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	})
 	r.Use(cors.Handler)
+	r.Use(handlers.MaintenanceMiddleware)
 	r.Use(middleware.Timeout(60 * time.Second))
 	return r
 }
@@ -14,6 +14,8 @@ func MetricsRoutes() chi.Router {
 		r.Use(auth.PubKeyContextSuperAdmin)
 
 		r.Get("/workspaces", handlers.GetAdminWorkspaces)
+		r.Get("/latency", handlers.GetRouteLatency)
+		r.Get("/usage", mh.GetUsageCapacity)
 
 		r.Post("/payment", handlers.PaymentMetrics)
 		r.Post("/people", handlers.PeopleMetrics)
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+)
+
+func NotificationRoutes() chi.Router {
+	r := chi.NewRouter()
+	notificationHandler := handlers.NewNotificationHandler(db.DB)
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+
+		r.Get("/", notificationHandler.GetNotifications)
+		r.Post("/{id}/read", notificationHandler.MarkNotificationRead)
+	})
+	return r
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+)
+
+// OrgRoutes manage the parent organizations that own workspaces
+func OrgRoutes() chi.Router {
+	r := chi.NewRouter()
+	orgHandlers := handlers.NewOrgHandler(db.DB)
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+		r.Post("/", orgHandlers.CreateOrg)
+		r.Get("/{uuid}/overview", orgHandlers.GetOrgOverview)
+		r.Post("/{uuid}/workspaces", orgHandlers.AddOrgWorkspace)
+		r.Delete("/{uuid}/workspaces/{workspace_uuid}", orgHandlers.RemoveOrgWorkspace)
+		r.Get("/{uuid}/members", orgHandlers.GetOrgMembers)
+		r.Put("/{uuid}/members", orgHandlers.SetOrgMemberRoles)
+		r.Post("/{uuid}/features/{feature_uuid}/transfer", orgHandlers.TransferOrgFeature)
+	})
+	return r
+}
@@ -1,19 +1,20 @@
 package routes
 
 import (
-	"net/http"
-
 	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/utils"
 )
 
 func PeopleRoutes() chi.Router {
 	r := chi.NewRouter()
-	bountyHandler := handlers.NewBountyHandler(http.DefaultClient, db.DB)
+	bountyHandler := handlers.NewBountyHandler(utils.TracedClient, db.DB)
 
 	peopleHandler := handlers.NewPeopleHandler(db.DB)
 	r.Group(func(r chi.Router) {
+		r.Use(auth.OptionalPubKeyContext)
 		r.Get("/", peopleHandler.GetListedPeople)
 		r.Get("/search", peopleHandler.GetPeopleBySearch)
 		r.Get("/posts", handlers.GetListedPosts)
@@ -23,6 +24,20 @@ func PeopleRoutes() chi.Router {
 		r.Get("/short", handlers.GetPeopleShortList)
 		r.Get("/offers", handlers.GetListedOffers)
 		r.Get("/bounty/leaderboard", handlers.GetBountiesLeaderboard)
+		r.Get("/bounty/leaderboard/ranked", handlers.GetRankedBountiesLeaderboard)
+		r.Get("/{pubkey}/portfolio", peopleHandler.GetPersonPortfolio)
+		r.Get("/handle/{handle}", peopleHandler.GetPersonByHandle)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+		r.Get("/me/board", peopleHandler.GetMyBoard)
+		r.Get("/me/earnings", peopleHandler.GetMyEarnings)
+		r.Post("/me/calendar/token", peopleHandler.CreateCalendarToken)
+		r.Delete("/me/calendar/token", peopleHandler.RevokeCalendarToken)
+		r.Put("/me/handle", peopleHandler.SetMyHandle)
+		r.Delete("/me/handle", peopleHandler.ClearMyHandle)
 	})
+	// calendar apps read the feed with its token instead of signing in
+	r.Get("/me/calendar.ics", peopleHandler.GetMyCalendar)
 	return r
 }
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// RelayRoutes receive events pushed by sphinx-relay, a batch is
+// authenticated by its signature made with the relay node key
+func RelayRoutes() chi.Router {
+	r := chi.NewRouter()
+	relayHandlers := handlers.NewRelayHandler(utils.TracedClient, db.DB)
+	r.Group(func(r chi.Router) {
+		r.Post("/events", relayHandlers.ReceiveRelayEvents)
+	})
+	return r
+}
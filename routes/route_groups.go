@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// routeGroup answers the routes of a group with a 501 when the deployment
+// turned the group off in DISABLED_ROUTE_GROUPS
+func routeGroup(group string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !config.RouteGroupDisabled(group) {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotImplemented)
+			json.NewEncoder(w).Encode(fmt.Sprintf("The %s routes are disabled on this deployment", group))
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+)
+
+func ShortLinkRoutes() chi.Router {
+	r := chi.NewRouter()
+	shortLinkHandlers := handlers.NewShortLinkHandler(db.DB)
+	r.Group(func(r chi.Router) {
+		r.Get("/{code}", shortLinkHandlers.FollowShortLink)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+		r.Post("/", shortLinkHandlers.CreateShortLink)
+		r.Get("/{code}/stats", shortLinkHandlers.GetShortLink)
+	})
+	return r
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+)
+
+func SlackRoutes() chi.Router {
+	r := chi.NewRouter()
+	slackHandlers := handlers.NewSlackHandler(db.DB)
+	r.Group(func(r chi.Router) {
+		r.Post("/commands", slackHandlers.SlackCommand)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+		r.Post("/link", slackHandlers.LinkSlackUser)
+	})
+	return r
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// TicketBatchRoutes manage the ticket drafts a phase plan made as a batch,
+// the id of a batch is the uuid of its plan
+func TicketBatchRoutes() chi.Router {
+	r := chi.NewRouter()
+	phasePlanHandlers := handlers.NewPhasePlanHandler(utils.TracedClient, &db.DB)
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+		r.Post("/{id}/rollback", phasePlanHandlers.RollbackTicketBatch)
+	})
+	return r
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+func TicketRoutes() chi.Router {
+	r := chi.NewRouter()
+	ticketReviewHandlers := handlers.NewTicketReviewHandler(utils.TracedClient, db.DB)
+	r.Group(func(r chi.Router) {
+		r.Post("/reviews/{review_uuid}/progress", ticketReviewHandlers.ReceiveTicketReviewProgress)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+		r.Post("/{uuid}/reviews", ticketReviewHandlers.SubmitTicketReview)
+		r.Get("/{uuid}/reviews", ticketReviewHandlers.GetTicketReviews)
+	})
+	return r
+}
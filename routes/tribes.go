@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
 )
@@ -16,6 +17,13 @@ func TribeRoutes() chi.Router {
 		r.Get("/{uuid}", tribeHandlers.GetTribe)
 		r.Get("/total", tribeHandlers.GetTotalribes)
 		r.Post("/", tribeHandlers.CreateOrEditTribe)
+		r.Get("/{uuid}/badges", tribeHandlers.GetTribeBadges)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(auth.PubKeyContext)
+
+		r.Post("/{uuid}/badges/issue", tribeHandlers.IssueTribeBadge)
+		r.Get("/{uuid}/badges/issuances", tribeHandlers.GetTribeBadgeIssuances)
 	})
 	return r
 }
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+)
+
+// V2Routes answer with the v2 envelope, errors included
+func V2Routes() chi.Router {
+	r := chi.NewRouter()
+	v2Handler := handlers.NewV2Handler(db.DB)
+
+	r.NotFound(handlers.V2NotFound)
+	r.MethodNotAllowed(handlers.V2MethodNotAllowed)
+
+	r.Group(func(r chi.Router) {
+		r.Get("/tribes", v2Handler.ListTribes)
+		r.Get("/tribes/{uuid}", v2Handler.GetTribe)
+		r.Get("/people", v2Handler.ListPeople)
+		r.Get("/people/{uuid}", v2Handler.GetPerson)
+		r.Get("/bounties", v2Handler.ListBounties)
+		r.Get("/bounties/{id}", v2Handler.GetBounty)
+	})
+	return r
+}
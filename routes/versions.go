@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/utils"
+)
+
+// ApiVersion is a version of the api with its own routes under /<name>,
+// the unversioned routes are the legacy api and stay as they are.
+// A breaking change goes into a new version added to apiVersions
+type ApiVersion struct {
+	Name   string
+	Routes func() chi.Router
+}
+
+var apiVersions = []ApiVersion{
+	{Name: "v2", Routes: V2Routes},
+}
+
+// mountApiVersions mounts every api version, the handlers read the version from the
+// request context and the responses tell it in the Api-Version header
+func mountApiVersions(r chi.Router) {
+	for _, version := range apiVersions {
+		routes := version.Routes()
+		name := version.Name
+		r.Mount("/"+name, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Api-Version", name)
+			routes.ServeHTTP(w, req.WithContext(utils.WithApiVersion(req.Context(), name)))
+		}))
+	}
+}
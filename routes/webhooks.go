@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/handlers"
+)
+
+// WebhookRoutes receive alerts from external services, a payload is
+// authenticated by the signature made with the webhook secret
+func WebhookRoutes() chi.Router {
+	r := chi.NewRouter()
+	webhookHandlers := handlers.NewWebhookHandler(db.DB)
+	r.Group(func(r chi.Router) {
+		r.Post("/{uuid}", webhookHandlers.ReceiveWebhookAlert)
+	})
+	return r
+}
@@ -3,14 +3,24 @@ package routes
 import (
 	"github.com/go-chi/chi"
 	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/db"
 	"github.com/stakwork/sphinx-tribes/handlers"
+	"github.com/stakwork/sphinx-tribes/utils"
 )
 
 func WorkspaceRoutes() chi.Router {
 	r := chi.NewRouter()
 	workspaceHandlers := handlers.NewWorkspaceHandler(db.DB)
+	bountyHandler := handlers.NewBountyHandler(utils.TracedClient, db.DB)
+	codeGraphHandlers := handlers.NewCodeGraphHandler(utils.TracedClient, db.DB)
+	webhookHandlers := handlers.NewWebhookHandler(db.DB)
+	bridgeHandlers := handlers.NewBridgeHandler(utils.TracedClient, db.DB)
+	jiraHandlers := handlers.NewJiraHandler(db.DB)
+	payments := routeGroup(config.RouteGroupPayments)
+	ai := routeGroup(config.RouteGroupAI)
 	r.Group(func(r chi.Router) {
+		r.Use(auth.OptionalPubKeyContext)
 		r.Get("/", handlers.GetWorkspaces)
 		r.Get("/count", handlers.GetWorkspacesCount)
 		r.Get("/{uuid}", handlers.GetWorkspaceByUuid)
@@ -18,9 +28,20 @@ func WorkspaceRoutes() chi.Router {
 		r.Get("/users/{uuid}/count", handlers.GetWorkspaceUsersCount)
 		r.Get("/bounties/{uuid}", workspaceHandlers.GetWorkspaceBounties)
 		r.Get("/bounties/{uuid}/count", workspaceHandlers.GetWorkspaceBountiesCount)
+		r.Get("/{uuid}/tags", workspaceHandlers.GetWorkspaceTags)
+		r.With(utils.Embeddable).Get("/{uuid}/showcase", workspaceHandlers.GetWorkspaceShowcase)
 		r.Get("/user/{userId}", handlers.GetUserWorkspaces)
 		r.Get("/user/dropdown/{userId}", workspaceHandlers.GetUserDropdownWorkspaces)
+
+		r.With(ai, auth.SignedWebhookContext(auth.WebhookSourceCodeGraph)).Post("/codegraph/callback", codeGraphHandlers.CodeGraphCallback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(routeGroup(config.RouteGroupAdmin))
+		r.Use(auth.PubKeyContextSuperAdmin)
+		r.Get("/deleted", workspaceHandlers.GetDeletedWorkspaces)
+		r.Post("/{uuid}/restore", workspaceHandlers.RestoreWorkspace)
 	})
+
 	r.Group(func(r chi.Router) {
 		r.Use(auth.PubKeyContext)
 
@@ -28,17 +49,46 @@ func WorkspaceRoutes() chi.Router {
 		r.Post("/users/{uuid}", handlers.CreateWorkspaceUser)
 		r.Delete("/users/{uuid}", handlers.DeleteWorkspaceUser)
 		r.Post("/users/role/{uuid}/{user}", handlers.AddUserRoles)
+		r.Post("/{uuid}/members/import", workspaceHandlers.ImportWorkspaceMembers)
+		r.Post("/{uuid}/clone", workspaceHandlers.CloneWorkspace)
 
 		r.Get("/foruser/{uuid}", handlers.GetWorkspaceUser)
 		r.Get("/bounty/roles", handlers.GetBountyRoles)
 		r.Get("/users/role/{uuid}/{user}", handlers.GetUserRoles)
-		r.Get("/budget/{uuid}", workspaceHandlers.GetWorkspaceBudget)
-		r.Get("/budget/history/{uuid}", workspaceHandlers.GetWorkspaceBudgetHistory)
-		r.Get("/payments/{uuid}", handlers.GetPaymentHistory)
-		r.Get("/poll/invoices/{uuid}", workspaceHandlers.PollBudgetInvoices)
-		r.Get("/poll/user/invoices", workspaceHandlers.PollUserWorkspacesBudget)
-		r.Get("/invoices/count/{uuid}", handlers.GetInvoicesCount)
-		r.Get("/user/invoices/count", handlers.GetAllUserInvoicesCount)
+		r.With(payments).Get("/budget/{uuid}", workspaceHandlers.GetWorkspaceBudget)
+		r.With(payments).Get("/budget/{uuid}/ledger", workspaceHandlers.GetWorkspaceBudgetLedger)
+		r.With(payments).Get("/budget/{uuid}/history", workspaceHandlers.GetWorkspaceBudgetSnapshots)
+		r.With(payments).Post("/budget/{uuid}/withdraw", bountyHandler.WithdrawWorkspaceBudget)
+		r.With(payments).Get("/budget/{uuid}/refunds", bountyHandler.GetWorkspaceBudgetRefunds)
+		r.With(payments).Post("/budget/{uuid}/refunds/{refundId}/approve", bountyHandler.ApproveWorkspaceBudgetRefund)
+		r.With(payments).Post("/budget/{uuid}/refunds/{refundId}/reject", bountyHandler.RejectWorkspaceBudgetRefund)
+		r.Get("/{uuid}/estimation/report", workspaceHandlers.GetWorkspaceEstimationReport)
+		r.Post("/{uuid}/api_tokens", workspaceHandlers.MintWorkspaceApiToken)
+		r.Get("/{uuid}/api_tokens", workspaceHandlers.GetWorkspaceApiTokens)
+		r.Delete("/{uuid}/api_tokens/{id}", workspaceHandlers.RevokeWorkspaceApiToken)
+		r.Get("/{uuid}/usage", workspaceHandlers.GetWorkspaceUsage)
+		r.Get("/{uuid}/presence", workspaceHandlers.GetWorkspacePresence)
+		r.Get("/{uuid}/settings", workspaceHandlers.GetWorkspaceSettings)
+		r.Put("/{uuid}/settings", workspaceHandlers.UpdateWorkspaceSettings)
+		r.Post("/{uuid}/webhooks", webhookHandlers.CreateWorkspaceWebhook)
+		r.Get("/{uuid}/webhooks", webhookHandlers.GetWorkspaceWebhooks)
+		r.Delete("/{uuid}/webhooks/{webhook_uuid}", webhookHandlers.DeleteWorkspaceWebhook)
+
+		r.Post("/{uuid}/bridges", bridgeHandlers.CreateWorkspaceBridge)
+		r.Get("/{uuid}/bridges", bridgeHandlers.GetWorkspaceBridges)
+		r.Delete("/{uuid}/bridges/{bridge_uuid}", bridgeHandlers.DeleteWorkspaceBridge)
+		r.Post("/{uuid}/tags", workspaceHandlers.CreateWorkspaceTag)
+		r.Put("/{uuid}/tags/{tag_uuid}", workspaceHandlers.UpdateWorkspaceTag)
+		r.Delete("/{uuid}/tags/{tag_uuid}", workspaceHandlers.DeleteWorkspaceTag)
+		r.Get("/{uuid}/jira/users", jiraHandlers.GetJiraUserLinks)
+		r.Post("/{uuid}/jira/users", jiraHandlers.SaveJiraUserLink)
+		r.Delete("/{uuid}/jira/users/{email}", jiraHandlers.DeleteJiraUserLink)
+		r.With(payments).Get("/budget/history/{uuid}", workspaceHandlers.GetWorkspaceBudgetHistory)
+		r.With(payments).Get("/payments/{uuid}", handlers.GetPaymentHistory)
+		r.With(payments).Get("/poll/invoices/{uuid}", workspaceHandlers.PollBudgetInvoices)
+		r.With(payments).Get("/poll/user/invoices", workspaceHandlers.PollUserWorkspacesBudget)
+		r.With(payments).Get("/invoices/count/{uuid}", handlers.GetInvoicesCount)
+		r.With(payments).Get("/user/invoices/count", handlers.GetAllUserInvoicesCount)
 		r.Delete("/delete/{uuid}", workspaceHandlers.DeleteWorkspace)
 
 		r.Post("/mission", workspaceHandlers.UpdateWorkspace)
@@ -51,6 +101,12 @@ func WorkspaceRoutes() chi.Router {
 		r.Get("/{workspace_uuid}/features", workspaceHandlers.GetFeaturesByWorkspaceUuid)
 		r.Get("/{workspace_uuid}/repository/{uuid}", workspaceHandlers.GetWorkspaceRepoByWorkspaceUuidAndRepoUuid)
 		r.Delete("/{workspace_uuid}/repository/{uuid}", workspaceHandlers.DeleteWorkspaceRepository)
+
+		r.With(ai).Post("/{workspace_uuid}/codegraph", codeGraphHandlers.CreateOrEditCodeGraph)
+		r.With(ai).Get("/{workspace_uuid}/codegraph", codeGraphHandlers.GetCodeGraphsByWorkspaceUuid)
+		r.With(ai).Get("/{workspace_uuid}/codegraph/{uuid}", codeGraphHandlers.GetCodeGraphByUuid)
+		r.With(ai).Delete("/{workspace_uuid}/codegraph/{uuid}", codeGraphHandlers.DeleteCodeGraph)
+		r.With(ai).Post("/{workspace_uuid}/codegraph/{uuid}/sync", codeGraphHandlers.SyncCodeGraph)
 	})
 	return r
 }
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stakwork/sphinx-tribes/demo"
+)
+
+// seedDemo is `sphinx-tribes seed-demo [--fixture <file>]`, it saves the demo
+// data and can write what it saved as a Cypress fixture
+func seedDemo(args []string) int {
+	flags := flag.NewFlagSet("seed-demo", flag.ContinueOnError)
+	fixture := flags.String("fixture", "", "json file to write the seeded rows to, like cypress/fixtures/demo.json")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	seeded, err := demo.Seed(db.DB)
+	if err != nil {
+		fmt.Println("could not seed demo data", err)
+		return 1
+	}
+
+	if *fixture != "" {
+		body, _ := json.MarshalIndent(seeded, "", "  ")
+		if err := os.WriteFile(*fixture, append(body, '\n'), 0644); err != nil {
+			fmt.Println("could not write fixture", err)
+			return 1
+		}
+	}
+
+	fmt.Printf("seeded %d people, %d tribes, %d workspaces, %d features and %d bounties\n",
+		len(seeded.People), len(seeded.Tribes), len(seeded.Workspaces), len(seeded.Features), len(seeded.Bounties))
+	return 0
+}
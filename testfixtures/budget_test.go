@@ -0,0 +1,89 @@
+package testfixtures
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stretchr/testify/assert"
+)
+
+// spendInParallel runs spend from many goroutines at once and
+// returns how many of the calls succeeded
+func spendInParallel(t *testing.T, workers int, spend func() error) int {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+
+	start := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			err := spend()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				succeeded++
+			} else {
+				assert.ErrorIs(t, err, db.ErrInsufficientBudget)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	return succeeded
+}
+
+func TestBudgetDoesNotOverspendUnderParallelPayments(t *testing.T) {
+	requireTestDB(t)
+
+	amount := uint(1000)
+	workers := 25
+	affordable := int(Budget.TotalBudget / amount)
+
+	t.Run("parallel reservations never reserve more than the budget", func(t *testing.T) {
+		Reset()
+
+		succeeded := spendInParallel(t, workers, func() error {
+			reservation, err := db.TestDB.ReserveBudget(Workspace.Uuid, amount)
+			if err != nil {
+				return err
+			}
+			return db.TestDB.WithdrawBudget(Owner.OwnerPubKey, reservation)
+		})
+
+		assert.Equal(t, affordable, succeeded)
+		assert.Equal(t, int64(0), db.TestDB.GetWorkspaceLedgerBalance(Workspace.Uuid))
+		assert.Equal(t, uint(0), db.TestDB.GetWorkspaceBudget(Workspace.Uuid).TotalBudget)
+		assert.Empty(t, db.TestDB.CheckBudgetLedgerConsistency())
+	})
+
+	t.Run("parallel unreserved withdrawals never spend more than the budget", func(t *testing.T) {
+		Reset()
+
+		succeeded := spendInParallel(t, workers, func() error {
+			return db.TestDB.WithdrawBudget(Owner.OwnerPubKey, db.BudgetReservation{WorkspaceUuid: Workspace.Uuid, Amount: amount})
+		})
+
+		assert.Equal(t, affordable, succeeded)
+		assert.Equal(t, int64(0), db.TestDB.GetWorkspaceLedgerBalance(Workspace.Uuid))
+		assert.Empty(t, db.TestDB.CheckBudgetLedgerConsistency())
+	})
+
+	t.Run("released reservations go back to the budget", func(t *testing.T) {
+		Reset()
+
+		reservation, err := db.TestDB.ReserveBudget(Workspace.Uuid, amount)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(Budget.TotalBudget-amount), db.TestDB.GetWorkspaceLedgerBalance(Workspace.Uuid))
+
+		assert.NoError(t, db.TestDB.ReleaseBudget(reservation))
+		assert.NoError(t, db.TestDB.ReleaseBudget(reservation))
+		assert.Equal(t, int64(Budget.TotalBudget), db.TestDB.GetWorkspaceLedgerBalance(Workspace.Uuid))
+	})
+}
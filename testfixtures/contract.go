@@ -0,0 +1,193 @@
+package testfixtures
+
+import (
+	"reflect"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stretchr/testify/assert"
+)
+
+// ContractCase pins down how a Database method behaves on the fixtures.
+//
+// Returns is what mocks.Database should be programmed to return for the
+// call, Check asserts the behaviour handlers rely on. The contract suite
+// runs Check against the real test DB and checks that Returns fit the
+// method, so a mock programmed from a case returns what gorm would.
+type ContractCase struct {
+	Name    string
+	Method  string
+	Args    []interface{}
+	Returns []interface{}
+	Check   func(t assert.TestingT, results []interface{})
+}
+
+// Call invokes the case's method on database and returns its results
+func (c ContractCase) Call(database db.Database) []interface{} {
+	args := make([]reflect.Value, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = reflect.ValueOf(arg)
+	}
+
+	out := reflect.ValueOf(database).MethodByName(c.Method).Call(args)
+
+	results := make([]interface{}, len(out))
+	for i, value := range out {
+		results[i] = value.Interface()
+	}
+	return results
+}
+
+func resultError(results []interface{}) error {
+	err, _ := results[len(results)-1].(error)
+	return err
+}
+
+var DatabaseContract = []ContractCase{
+	{
+		Name:    "GetBounty returns an empty bounty for an unknown id",
+		Method:  "GetBounty",
+		Args:    []interface{}{uint(999)},
+		Returns: []interface{}{db.NewBounty{}},
+		Check: func(t assert.TestingT, results []interface{}) {
+			assert.Equal(t, uint(0), results[0].(db.NewBounty).ID)
+		},
+	},
+	{
+		Name:    "GetBounty returns a bounty by id",
+		Method:  "GetBounty",
+		Args:    []interface{}{OpenBounty.ID},
+		Returns: []interface{}{OpenBounty},
+		Check: func(t assert.TestingT, results []interface{}) {
+			bounty := results[0].(db.NewBounty)
+			assert.Equal(t, OpenBounty.ID, bounty.ID)
+			assert.Equal(t, OpenBounty.Title, bounty.Title)
+			assert.Equal(t, OpenBounty.OwnerID, bounty.OwnerID)
+		},
+	},
+	{
+		Name:    "GetBountyById returns no bounties and no error for an unknown id",
+		Method:  "GetBountyById",
+		Args:    []interface{}{"999"},
+		Returns: []interface{}{[]db.NewBounty{}, nil},
+		Check: func(t assert.TestingT, results []interface{}) {
+			assert.NoError(t, resultError(results))
+			assert.Empty(t, results[0])
+		},
+	},
+	{
+		Name:    "GetBountyByCreated returns a bounty by its created timestamp",
+		Method:  "GetBountyByCreated",
+		Args:    []interface{}{uint(AssignedBounty.Created)},
+		Returns: []interface{}{AssignedBounty, nil},
+		Check: func(t assert.TestingT, results []interface{}) {
+			assert.NoError(t, resultError(results))
+			assert.Equal(t, AssignedBounty.Assignee, results[0].(db.NewBounty).Assignee)
+		},
+	},
+	{
+		Name:    "GetWorkspaceByUuid returns an empty workspace for an unknown uuid",
+		Method:  "GetWorkspaceByUuid",
+		Args:    []interface{}{"unknown_workspace_uuid"},
+		Returns: []interface{}{db.Workspace{}},
+		Check: func(t assert.TestingT, results []interface{}) {
+			assert.Empty(t, results[0].(db.Workspace).Uuid)
+		},
+	},
+	{
+		Name:    "GetWorkspaceByUuid returns a workspace by uuid",
+		Method:  "GetWorkspaceByUuid",
+		Args:    []interface{}{Workspace.Uuid},
+		Returns: []interface{}{Workspace},
+		Check: func(t assert.TestingT, results []interface{}) {
+			workspace := results[0].(db.Workspace)
+			assert.Equal(t, Workspace.Uuid, workspace.Uuid)
+			assert.Equal(t, Workspace.OwnerPubKey, workspace.OwnerPubKey)
+		},
+	},
+	{
+		Name:    "GetWorkspaceBudget returns the workspace budget",
+		Method:  "GetWorkspaceBudget",
+		Args:    []interface{}{Workspace.Uuid},
+		Returns: []interface{}{Budget},
+		Check: func(t assert.TestingT, results []interface{}) {
+			assert.Equal(t, Budget.TotalBudget, results[0].(db.NewBountyBudget).TotalBudget)
+		},
+	},
+	{
+		Name:    "GetWorkspaceLedgerBalance matches the workspace budget",
+		Method:  "GetWorkspaceLedgerBalance",
+		Args:    []interface{}{Workspace.Uuid},
+		Returns: []interface{}{int64(Budget.TotalBudget)},
+		Check: func(t assert.TestingT, results []interface{}) {
+			assert.Equal(t, int64(Budget.TotalBudget), results[0].(int64))
+		},
+	},
+	{
+		Name:    "CheckBudgetLedgerConsistency finds nothing on a consistent ledger",
+		Method:  "CheckBudgetLedgerConsistency",
+		Args:    []interface{}{},
+		Returns: []interface{}{[]db.BudgetLedgerInconsistency{}},
+		Check: func(t assert.TestingT, results []interface{}) {
+			assert.Empty(t, results[0])
+		},
+	},
+	{
+		Name:    "GetPersonByPubkey returns a person by pubkey",
+		Method:  "GetPersonByPubkey",
+		Args:    []interface{}{Assignee.OwnerPubKey},
+		Returns: []interface{}{Assignee},
+		Check: func(t assert.TestingT, results []interface{}) {
+			person := results[0].(db.Person)
+			assert.Equal(t, Assignee.OwnerPubKey, person.OwnerPubKey)
+			assert.Equal(t, Assignee.UniqueName, person.UniqueName)
+		},
+	},
+	{
+		Name:    "GetFeatureByUuid returns a feature by uuid",
+		Method:  "GetFeatureByUuid",
+		Args:    []interface{}{Feature.Uuid},
+		Returns: []interface{}{Feature},
+		Check: func(t assert.TestingT, results []interface{}) {
+			feature := results[0].(db.WorkspaceFeatures)
+			assert.Equal(t, Feature.Name, feature.Name)
+			assert.Equal(t, Feature.WorkspaceUuid, feature.WorkspaceUuid)
+		},
+	},
+	{
+		Name:    "CheckFeatureBountyCounts finds nothing on counts kept by the triggers",
+		Method:  "CheckFeatureBountyCounts",
+		Args:    []interface{}{},
+		Returns: []interface{}{[]db.FeatureCountDrift{}},
+		Check: func(t assert.TestingT, results []interface{}) {
+			assert.Empty(t, results[0])
+		},
+	},
+	{
+		Name:    "GetFeaturePhaseByUuid returns an error for an unknown phase",
+		Method:  "GetFeaturePhaseByUuid",
+		Args:    []interface{}{Feature.Uuid, "unknown_phase_uuid"},
+		Returns: []interface{}{db.FeaturePhase{}, assert.AnError},
+		Check: func(t assert.TestingT, results []interface{}) {
+			assert.Error(t, resultError(results))
+		},
+	},
+	{
+		Name:    "GetLatestPhaseDocument returns an error when there is no document",
+		Method:  "GetLatestPhaseDocument",
+		Args:    []interface{}{Phase.Uuid, db.PhasePlanDocument},
+		Returns: []interface{}{db.PhaseDocument{}, assert.AnError},
+		Check: func(t assert.TestingT, results []interface{}) {
+			assert.Error(t, resultError(results))
+		},
+	},
+	{
+		Name:    "GetNotificationsByPubkey returns no notifications for a new user",
+		Method:  "GetNotificationsByPubkey",
+		Args:    []interface{}{Member.OwnerPubKey, false},
+		Returns: []interface{}{[]db.Notification{}},
+		Check: func(t assert.TestingT, results []interface{}) {
+			assert.NotNil(t, results[0])
+			assert.Empty(t, results[0])
+		},
+	},
+}
@@ -0,0 +1,87 @@
+package testfixtures
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	dbMocks "github.com/stakwork/sphinx-tribes/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestDatabaseContractMock checks mocks.Database against db.Database, the
+// mock has to have every method of the interface with the same signature and
+// no method of its own, and the contract returns have to fit the interface
+func TestDatabaseContractMock(t *testing.T) {
+	database := reflect.TypeOf((*db.Database)(nil)).Elem()
+	mockDb := reflect.TypeOf(&dbMocks.Database{})
+
+	t.Run("should have the methods of the interface", func(t *testing.T) {
+		for i := 0; i < database.NumMethod(); i++ {
+			method := database.Method(i)
+			mocked, ok := mockDb.MethodByName(method.Name)
+			if !assert.True(t, ok, "mock is missing %s", method.Name) {
+				continue
+			}
+			// the method of the mock has its receiver as the first input
+			assert.Equal(t, method.Type.String(), funcWithoutReceiver(mocked.Type), method.Name)
+		}
+	})
+
+	t.Run("should not have methods the interface doesn't have", func(t *testing.T) {
+		embedded := reflect.TypeOf(&mock.Mock{})
+		for i := 0; i < mockDb.NumMethod(); i++ {
+			name := mockDb.Method(i).Name
+			if _, ok := embedded.MethodByName(name); ok || name == "EXPECT" {
+				continue
+			}
+			_, ok := database.MethodByName(name)
+			assert.True(t, ok, "mock has %s which db.Database doesn't have", name)
+		}
+	})
+
+	for _, c := range DatabaseContract {
+		t.Run(c.Name, func(t *testing.T) {
+			method, ok := database.MethodByName(c.Method)
+			if !assert.True(t, ok, "db.Database has no %s", c.Method) {
+				return
+			}
+			if !assert.Len(t, c.Args, method.Type.NumIn(), "arguments of %s", c.Method) ||
+				!assert.Len(t, c.Returns, method.Type.NumOut(), "returns of %s", c.Method) {
+				return
+			}
+			for i, arg := range c.Args {
+				assert.True(t, reflect.TypeOf(arg).AssignableTo(method.Type.In(i)), "argument %d of %s", i, c.Method)
+			}
+			for i, value := range c.Returns {
+				if value == nil {
+					continue
+				}
+				assert.True(t, reflect.TypeOf(value).AssignableTo(method.Type.Out(i)), "return %d of %s", i, c.Method)
+			}
+		})
+	}
+}
+
+func funcWithoutReceiver(fn reflect.Type) string {
+	in := make([]reflect.Type, 0, fn.NumIn()-1)
+	for i := 1; i < fn.NumIn(); i++ {
+		in = append(in, fn.In(i))
+	}
+	out := make([]reflect.Type, 0, fn.NumOut())
+	for i := 0; i < fn.NumOut(); i++ {
+		out = append(out, fn.Out(i))
+	}
+	return reflect.FuncOf(in, out, fn.IsVariadic()).String()
+}
+
+func TestDatabaseContractDB(t *testing.T) {
+	requireTestDB(t)
+
+	for _, c := range DatabaseContract {
+		t.Run(c.Name, func(t *testing.T) {
+			c.Check(t, c.Call(db.TestDB))
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Package testfixtures seeds a known set of people, workspaces, features
+// and bounties into the test DB, so DB backed tests don't depend on the
+// data earlier tests happened to insert.
+package testfixtures
+
+import (
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+var created = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var Owner = db.Person{
+	Uuid:        "fixture_owner_uuid",
+	OwnerAlias:  "fixture-owner",
+	UniqueName:  "fixture-owner",
+	OwnerPubKey: "fixture_owner_pubkey",
+	Description: "fixture workspace owner",
+	Created:     &created,
+}
+
+var Assignee = db.Person{
+	Uuid:        "fixture_assignee_uuid",
+	OwnerAlias:  "fixture-assignee",
+	UniqueName:  "fixture-assignee",
+	OwnerPubKey: "fixture_assignee_pubkey",
+	Description: "fixture bounty hunter",
+	Created:     &created,
+}
+
+var Member = db.Person{
+	Uuid:        "fixture_member_uuid",
+	OwnerAlias:  "fixture-member",
+	UniqueName:  "fixture-member",
+	OwnerPubKey: "fixture_member_pubkey",
+	Description: "fixture workspace member",
+	Created:     &created,
+}
+
+var Workspace = db.Workspace{
+	Uuid:        "fixture_workspace_uuid",
+	Name:        "Fixture Workspace",
+	Description: "fixture workspace",
+	OwnerPubKey: Owner.OwnerPubKey,
+	Created:     &created,
+	Updated:     &created,
+}
+
+var WorkspaceUser = db.WorkspaceUsers{
+	WorkspaceUuid: Workspace.Uuid,
+	OwnerPubKey:   Member.OwnerPubKey,
+	Created:       &created,
+	Updated:       &created,
+}
+
+var Budget = db.NewBountyBudget{
+	WorkspaceUuid: Workspace.Uuid,
+	TotalBudget:   10000,
+	Created:       &created,
+	Updated:       &created,
+}
+
+var Feature = db.WorkspaceFeatures{
+	Uuid:          "fixture_feature_uuid",
+	WorkspaceUuid: Workspace.Uuid,
+	Name:          "Fixture Feature",
+	Brief:         "fixture feature brief",
+	CreatedBy:     Owner.OwnerPubKey,
+	UpdatedBy:     Owner.OwnerPubKey,
+}
+
+var Phase = db.FeaturePhase{
+	Uuid:        "fixture_phase_uuid",
+	FeatureUuid: Feature.Uuid,
+	Name:        "Fixture Phase",
+	CreatedBy:   Owner.OwnerPubKey,
+	UpdatedBy:   Owner.OwnerPubKey,
+}
+
+// Bounties are created in order, so their ids are 1, 2 and 3
+var OpenBounty = db.NewBounty{
+	ID:            1,
+	Type:          "coding",
+	Title:         "Fixture open bounty",
+	Description:   "fixture open bounty",
+	WorkspaceUuid: Workspace.Uuid,
+	PhaseUuid:     Phase.Uuid,
+	OwnerID:       Owner.OwnerPubKey,
+	Price:         1000,
+	Show:          true,
+	Created:       1704067201,
+}
+
+var AssignedBounty = db.NewBounty{
+	ID:            2,
+	Type:          "coding",
+	Title:         "Fixture assigned bounty",
+	Description:   "fixture assigned bounty",
+	WorkspaceUuid: Workspace.Uuid,
+	OwnerID:       Owner.OwnerPubKey,
+	Assignee:      Assignee.OwnerPubKey,
+	Price:         2000,
+	Show:          true,
+	Created:       1704067202,
+}
+
+var PaidBounty = db.NewBounty{
+	ID:            3,
+	Type:          "coding",
+	Title:         "Fixture paid bounty",
+	Description:   "fixture paid bounty",
+	WorkspaceUuid: Workspace.Uuid,
+	OwnerID:       Owner.OwnerPubKey,
+	Assignee:      Assignee.OwnerPubKey,
+	Price:         3000,
+	Show:          true,
+	Paid:          true,
+	Completed:     true,
+	Created:       1704067203,
+}
+
+// InitTestDB connects to the test DB and resets it to the fixtures
+func InitTestDB() {
+	db.InitTestDB()
+	Reset()
+}
+
+// Reset empties the test DB and seeds the fixtures again
+func Reset() {
+	db.CleanTestData()
+	Seed()
+}
+
+// Seed inserts the fixtures into the test DB
+func Seed() {
+	for _, person := range []db.Person{Owner, Assignee, Member} {
+		db.TestDB.CreateOrEditPerson(person)
+	}
+
+	db.TestDB.CreateOrEditWorkspace(Workspace)
+	db.TestDB.CreateWorkspaceUser(WorkspaceUser)
+	db.TestDB.CreateWorkspaceBudget(Budget)
+
+	db.TestDB.CreateOrEditFeature(Feature)
+	db.TestDB.CreateOrEditFeaturePhase(Phase)
+
+	for _, bounty := range []db.NewBounty{OpenBounty, AssignedBounty, PaidBounty} {
+		db.TestDB.CreateOrEditBounty(bounty)
+	}
+}
@@ -0,0 +1,19 @@
+package testfixtures
+
+import "testing"
+
+// requireTestDB seeds the test DB or skips the test when it is not available
+func requireTestDB(t *testing.T) {
+	connected := func() (ok bool) {
+		defer func() {
+			if r := recover(); r != nil {
+				ok = false
+			}
+		}()
+		InitTestDB()
+		return true
+	}()
+	if !connected {
+		t.Skip("test DB is not available")
+	}
+}
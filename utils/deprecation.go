@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+type apiVersionContextKey struct{}
+
+// WithApiVersion tells the handlers which api version the request came in on
+func WithApiVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, apiVersionContextKey{}, version)
+}
+
+// ApiVersionFromContext is the version of the request, empty on the legacy routes
+func ApiVersionFromContext(ctx context.Context) string {
+	version, _ := ctx.Value(apiVersionContextKey{}).(string)
+	return version
+}
+
+// FieldDeprecation renames a response field. From the Since version on the field
+// is sent with both names, and from RemovedIn on only with the new one
+type FieldDeprecation struct {
+	Old       string
+	New       string
+	Since     string
+	RemovedIn string
+}
+
+// FieldDeprecations are the field renames rolling out, the legacy routes keep the old names
+var FieldDeprecations = []FieldDeprecation{
+	{Old: "owner_alias", New: "alias", Since: "v2"},
+}
+
+// versionNumber orders versions, the legacy api is v1
+func versionNumber(version string) int {
+	if version == "" {
+		return 1
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return 1
+	}
+	return number
+}
+
+// ActiveDeprecations are the renames that apply to a version
+func ActiveDeprecations(version string) []FieldDeprecation {
+	active := []FieldDeprecation{}
+	for _, deprecation := range FieldDeprecations {
+		if versionNumber(version) >= versionNumber(deprecation.Since) {
+			active = append(active, deprecation)
+		}
+	}
+	return active
+}
+
+// DeprecatedFields are the old names of the fields a version still sends with both names
+func DeprecatedFields(version string) []string {
+	fields := []string{}
+	for _, deprecation := range ActiveDeprecations(version) {
+		if deprecation.RemovedIn == "" || versionNumber(version) < versionNumber(deprecation.RemovedIn) {
+			fields = append(fields, deprecation.Old)
+		}
+	}
+	return fields
+}
+
+// TransformFields renames the fields of data, at any depth, as the version wants them.
+// data comes back as it is when no rename applies to the version
+func TransformFields(version string, data interface{}) (interface{}, error) {
+	deprecations := ActiveDeprecations(version)
+	if len(deprecations) == 0 {
+		return data, nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	removed := func(deprecation FieldDeprecation) bool {
+		return deprecation.RemovedIn != "" && versionNumber(version) >= versionNumber(deprecation.RemovedIn)
+	}
+
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for _, deprecation := range deprecations {
+				old, ok := v[deprecation.Old]
+				if !ok {
+					continue
+				}
+				if _, exists := v[deprecation.New]; !exists {
+					v[deprecation.New] = old
+				}
+				if removed(deprecation) {
+					delete(v, deprecation.Old)
+				}
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(generic)
+
+	return generic, nil
+}
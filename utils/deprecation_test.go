@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformFields(t *testing.T) {
+	previous := FieldDeprecations
+	defer func() { FieldDeprecations = previous }()
+	FieldDeprecations = []FieldDeprecation{
+		{Old: "owner_alias", New: "alias", Since: "v2", RemovedIn: "v3"},
+	}
+
+	type person struct {
+		OwnerAlias string `json:"owner_alias"`
+		Price      uint64 `json:"price"`
+	}
+	data := map[string]interface{}{
+		"people": []person{{OwnerAlias: "alice", Price: 9007199254740993}},
+	}
+
+	t.Run("should leave the legacy api as it is", func(t *testing.T) {
+		transformed, err := TransformFields("", data)
+		assert.NoError(t, err)
+		assert.Equal(t, data, transformed)
+		assert.Empty(t, DeprecatedFields(""))
+	})
+
+	t.Run("should send both names while the old one is deprecated", func(t *testing.T) {
+		transformed, err := TransformFields("v2", data)
+		assert.NoError(t, err)
+
+		people := transformed.(map[string]interface{})["people"].([]interface{})
+		alice := people[0].(map[string]interface{})
+		assert.Equal(t, "alice", alice["owner_alias"])
+		assert.Equal(t, "alice", alice["alias"])
+		assert.Equal(t, "9007199254740993", alice["price"].(interface{ String() string }).String())
+		assert.Equal(t, []string{"owner_alias"}, DeprecatedFields("v2"))
+	})
+
+	t.Run("should only send the new name once the old one is removed", func(t *testing.T) {
+		transformed, err := TransformFields("v3", data)
+		assert.NoError(t, err)
+
+		alice := transformed.(map[string]interface{})["people"].([]interface{})[0].(map[string]interface{})
+		assert.NotContains(t, alice, "owner_alias")
+		assert.Equal(t, "alice", alice["alias"])
+		assert.Empty(t, DeprecatedFields("v3"))
+	})
+
+	t.Run("should keep a new field that is already there", func(t *testing.T) {
+		transformed, err := TransformFields("v2", map[string]string{"owner_alias": "old", "alias": "new"})
+		assert.NoError(t, err)
+		assert.Equal(t, "new", transformed.(map[string]interface{})["alias"])
+	})
+
+	t.Run("should carry the api version in the context", func(t *testing.T) {
+		assert.Equal(t, "v2", ApiVersionFromContext(WithApiVersion(context.Background(), "v2")))
+		assert.Equal(t, "", ApiVersionFromContext(context.Background()))
+	})
+}
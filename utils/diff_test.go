@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	t.Run("should return only equal lines for identical texts", func(t *testing.T) {
+		diff := DiffLines("one\ntwo", "one\ntwo")
+		assert.Equal(t, []DiffLine{
+			{Op: DiffEqual, Text: "one"},
+			{Op: DiffEqual, Text: "two"},
+		}, diff)
+	})
+
+	t.Run("should mark removed and added lines", func(t *testing.T) {
+		diff := DiffLines("one\ntwo\nthree", "one\nthree\nfour")
+		assert.Equal(t, []DiffLine{
+			{Op: DiffEqual, Text: "one"},
+			{Op: DiffDelete, Text: "two"},
+			{Op: DiffEqual, Text: "three"},
+			{Op: DiffInsert, Text: "four"},
+		}, diff)
+	})
+
+	t.Run("should handle empty texts", func(t *testing.T) {
+		assert.Equal(t, []DiffLine{}, DiffLines("", ""))
+		assert.Equal(t, []DiffLine{{Op: DiffInsert, Text: "new"}}, DiffLines("", "new"))
+		assert.Equal(t, []DiffLine{{Op: DiffDelete, Text: "old"}}, DiffLines("old", ""))
+	})
+
+	t.Run("should not build an lcs table for texts that are too long", func(t *testing.T) {
+		from := make([]string, 3000)
+		to := make([]string, 3000)
+		for i := range from {
+			from[i] = fmt.Sprintf("old %d", i)
+			to[i] = fmt.Sprintf("new %d", i)
+		}
+
+		diff := DiffLines("title\n"+strings.Join(from, "\n")+"\nend", "title\n"+strings.Join(to, "\n")+"\nend")
+
+		assert.Len(t, diff, 6002)
+		assert.Equal(t, DiffLine{Op: DiffEqual, Text: "title"}, diff[0])
+		assert.Equal(t, DiffLine{Op: DiffDelete, Text: "old 0"}, diff[1])
+		assert.Equal(t, DiffLine{Op: DiffInsert, Text: "new 0"}, diff[3001])
+		assert.Equal(t, DiffLine{Op: DiffEqual, Text: "end"}, diff[6001])
+	})
+}
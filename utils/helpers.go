@@ -3,8 +3,11 @@ package utils
 import (
 	"crypto/rand"
 	"encoding/base32"
+	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	decodepay "github.com/nbd-wtf/ln-decodepay"
@@ -77,3 +80,39 @@ func GetDateDaysDifference(createdDate int64, paidDate *time.Time) int64 {
 	days := int64(difference.Hours() / 24)
 	return days
 }
+
+var lightningAddressPattern = regexp.MustCompile(`^[a-z0-9\-_.+]+@[a-z0-9\-]+(\.[a-z0-9\-]+)+$`)
+
+// IsLightningAddress tells if address is a LUD-16 lightning address, name@domain
+func IsLightningAddress(address string) bool {
+	return lightningAddressPattern.MatchString(strings.ToLower(address))
+}
+
+var base58AddressPattern = regexp.MustCompile(`^[13mn2][a-km-zA-HJ-NP-Z1-9]{25,34}$`)
+var bech32AddressPattern = regexp.MustCompile(`^(bc1|tb1|bcrt1)[ac-hj-np-z02-9]{8,87}$`)
+
+// IsBitcoinAddress tells if address looks like a base58 or bech32 bitcoin
+// address, the node paying it does the full validation
+func IsBitcoinAddress(address string) bool {
+	if base58AddressPattern.MatchString(address) {
+		return true
+	}
+	// bech32 addresses are all lower or all upper case
+	if address != strings.ToLower(address) && address != strings.ToUpper(address) {
+		return false
+	}
+	return bech32AddressPattern.MatchString(strings.ToLower(address))
+}
+
+// LightningAddressUrl is where the LNURL-pay parameters of a lightning address are served
+func LightningAddressUrl(address string) (string, error) {
+	if !IsLightningAddress(address) {
+		return "", errors.New("not a lightning address")
+	}
+	parts := strings.SplitN(strings.ToLower(address), "@", 2)
+	scheme := "https"
+	if strings.HasSuffix(parts[1], ".onion") {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/.well-known/lnurlp/%s", scheme, parts[1], parts[0]), nil
+}
@@ -78,3 +78,30 @@ func TestGetInvoiceExpired(t *testing.T) {
 	isInvoiceExpired := GetInvoiceExpired(expiredInvoice)
 	assert.Equal(t, true, isInvoiceExpired)
 }
+
+func TestLightningAddressUrl(t *testing.T) {
+	assert.True(t, IsLightningAddress("Satoshi@getalby.com"))
+	assert.False(t, IsLightningAddress("satoshi"))
+	assert.False(t, IsLightningAddress("satoshi@localhost"))
+	assert.False(t, IsLightningAddress("sat oshi@getalby.com"))
+
+	addressUrl, err := LightningAddressUrl("Satoshi@getalby.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://getalby.com/.well-known/lnurlp/satoshi", addressUrl)
+
+	onionUrl, _ := LightningAddressUrl("satoshi@abc.onion")
+	assert.Equal(t, "http://abc.onion/.well-known/lnurlp/satoshi", onionUrl)
+
+	_, err = LightningAddressUrl("not an address")
+	assert.Error(t, err)
+}
+
+func TestIsBitcoinAddress(t *testing.T) {
+	assert.True(t, IsBitcoinAddress("bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"))
+	assert.True(t, IsBitcoinAddress("BC1QAR0SRRR7XFKVY5L643LYDNW9RE59GTZZWF5MDQ"))
+	assert.True(t, IsBitcoinAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"))
+	assert.True(t, IsBitcoinAddress("3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy"))
+	assert.False(t, IsBitcoinAddress("bc1QAR0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"))
+	assert.False(t, IsBitcoinAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN0"))
+	assert.False(t, IsBitcoinAddress("hunter@getalby.com"))
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// streamFlushEvery is how many items are written between flushes, so the
+// client gets the list as it is read without a flush per item
+const streamFlushEvery = 100
+
+// JSONStream writes a list item by item, as a JSON array or with
+// ?format=ndjson as one JSON document per line, so a long list never sits in
+// memory and the response starts with its first items. The status is sent
+// with the first write, a list that fails halfway is left unterminated so
+// the client can't take it for a complete one
+type JSONStream struct {
+	w       http.ResponseWriter
+	encoder *json.Encoder
+	ndjson  bool
+	written int
+	started bool
+}
+
+func NewJSONStream(w http.ResponseWriter, r *http.Request) *JSONStream {
+	return &JSONStream{
+		w:       w,
+		encoder: json.NewEncoder(w),
+		ndjson:  r.URL.Query().Get("format") == "ndjson",
+	}
+}
+
+func (s *JSONStream) start() error {
+	if s.started {
+		return nil
+	}
+	s.started = true
+	if s.ndjson {
+		s.w.Header().Set("Content-Type", "application/x-ndjson")
+		s.w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	s.w.Header().Set("Content-Type", "application/json")
+	s.w.WriteHeader(http.StatusOK)
+	_, err := io.WriteString(s.w, "[")
+	return err
+}
+
+// Write adds an item to the list
+func (s *JSONStream) Write(item interface{}) error {
+	if err := s.start(); err != nil {
+		return err
+	}
+	if !s.ndjson && s.written > 0 {
+		if _, err := io.WriteString(s.w, ","); err != nil {
+			return err
+		}
+	}
+	if err := s.encoder.Encode(item); err != nil {
+		return err
+	}
+	s.written++
+	if s.written%streamFlushEvery == 0 {
+		s.flush()
+	}
+	return nil
+}
+
+// Close ends the list, an empty list is still a valid one
+func (s *JSONStream) Close() error {
+	if err := s.start(); err != nil {
+		return err
+	}
+	if !s.ndjson {
+		if _, err := io.WriteString(s.w, "]\n"); err != nil {
+			return err
+		}
+	}
+	s.flush()
+	return nil
+}
+
+// Abort ends a list that could not be read, before its first item the
+// client gets a 500 and after it the list stays unterminated
+func (s *JSONStream) Abort() {
+	if !s.started {
+		s.started = true
+		s.w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	s.flush()
+}
+
+func (s *JSONStream) flush() {
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
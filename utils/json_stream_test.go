@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONStream(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	stream := func(query string, count int) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		s := NewJSONStream(rr, httptest.NewRequest(http.MethodGet, "/people"+query, nil))
+		for i := 1; i <= count; i++ {
+			assert.NoError(t, s.Write(item{ID: i}))
+		}
+		assert.NoError(t, s.Close())
+		return rr
+	}
+
+	t.Run("should write a json array", func(t *testing.T) {
+		rr := stream("", 250)
+
+		items := []item{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &items))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.Len(t, items, 250)
+		assert.Equal(t, 250, items[249].ID)
+		assert.True(t, rr.Flushed)
+	})
+
+	t.Run("should write an empty array for an empty list", func(t *testing.T) {
+		rr := stream("", 0)
+		assert.Equal(t, "[]\n", rr.Body.String())
+	})
+
+	t.Run("should write a document per line with format ndjson", func(t *testing.T) {
+		rr := stream("?format=ndjson", 2)
+		assert.Equal(t, "application/x-ndjson", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "{\"id\":1}\n{\"id\":2}\n", rr.Body.String())
+	})
+
+	t.Run("should answer 500 for a list that failed before its first item", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		NewJSONStream(rr, httptest.NewRequest(http.MethodGet, "/people", nil)).Abort()
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("should leave a list that failed halfway unterminated", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		s := NewJSONStream(rr, httptest.NewRequest(http.MethodGet, "/people", nil))
+		assert.NoError(t, s.Write(item{ID: 1}))
+		s.Abort()
+
+		items := []item{}
+		assert.Error(t, json.Unmarshal(rr.Body.Bytes(), &items))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
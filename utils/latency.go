@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi"
+)
+
+// LatencyBucketsMs are the upper bounds of the latency histogram buckets
+var LatencyBucketsMs = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+type latencyHistogram struct {
+	count   uint64
+	sumMs   float64
+	buckets []uint64
+}
+
+type LatencyBucket struct {
+	// LeMs is nil for the bucket of requests slower than the last bound
+	LeMs  *float64 `json:"le_ms"`
+	Count uint64   `json:"count"`
+}
+
+type RouteLatency struct {
+	Route   string          `json:"route"`
+	Count   uint64          `json:"count"`
+	AvgMs   float64         `json:"avg_ms"`
+	P50Ms   float64         `json:"p50_ms"`
+	P95Ms   float64         `json:"p95_ms"`
+	P99Ms   float64         `json:"p99_ms"`
+	Buckets []LatencyBucket `json:"buckets"`
+}
+
+// LatencyHistograms keeps a latency histogram per route since the server started
+type LatencyHistograms struct {
+	mu     sync.Mutex
+	routes map[string]*latencyHistogram
+}
+
+func NewLatencyHistograms() *LatencyHistograms {
+	return &LatencyHistograms{routes: map[string]*latencyHistogram{}}
+}
+
+// RouteLatencies are the histograms of the api routes, served on /metrics/latency
+var RouteLatencies = NewLatencyHistograms()
+
+func (lh *LatencyHistograms) Observe(route string, elapsed time.Duration) {
+	ms := float64(elapsed.Microseconds()) / 1000
+
+	lh.mu.Lock()
+	defer lh.mu.Unlock()
+
+	histogram, ok := lh.routes[route]
+	if !ok {
+		histogram = &latencyHistogram{buckets: make([]uint64, len(LatencyBucketsMs)+1)}
+		lh.routes[route] = histogram
+	}
+	histogram.count++
+	histogram.sumMs += ms
+	histogram.buckets[sort.SearchFloat64s(LatencyBucketsMs, ms)]++
+}
+
+// Middleware observes the latency of every request that matched a route,
+// the route pattern is used so ids in the path don't make new histograms
+func (lh *LatencyHistograms) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(w, r)
+
+		rctx := chi.RouteContext(r.Context())
+		if rctx == nil || rctx.RoutePattern() == "" {
+			return
+		}
+		lh.Observe(r.Method+" "+rctx.RoutePattern(), time.Since(start))
+	})
+}
+
+// Snapshot returns the histograms sorted by route, the buckets are cumulative
+// and the percentiles are the upper bound of the bucket they fall in
+func (lh *LatencyHistograms) Snapshot() []RouteLatency {
+	lh.mu.Lock()
+	defer lh.mu.Unlock()
+
+	latencies := make([]RouteLatency, 0, len(lh.routes))
+	for route, histogram := range lh.routes {
+		latency := RouteLatency{
+			Route:   route,
+			Count:   histogram.count,
+			Buckets: make([]LatencyBucket, len(histogram.buckets)),
+		}
+		if histogram.count > 0 {
+			latency.AvgMs = histogram.sumMs / float64(histogram.count)
+		}
+
+		var cumulative uint64
+		for i, count := range histogram.buckets {
+			cumulative += count
+			latency.Buckets[i].Count = cumulative
+			if i < len(LatencyBucketsMs) {
+				le := LatencyBucketsMs[i]
+				latency.Buckets[i].LeMs = &le
+			}
+		}
+		latency.P50Ms = latency.percentile(0.50)
+		latency.P95Ms = latency.percentile(0.95)
+		latency.P99Ms = latency.percentile(0.99)
+
+		latencies = append(latencies, latency)
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i].Route < latencies[j].Route
+	})
+	return latencies
+}
+
+func (rl RouteLatency) percentile(p float64) float64 {
+	if rl.Count == 0 {
+		return 0
+	}
+	rank := uint64(p*float64(rl.Count) + 0.5)
+	if rank == 0 {
+		rank = 1
+	}
+	for i, bucket := range rl.Buckets {
+		if bucket.Count >= rank {
+			if bucket.LeMs == nil {
+				// slower than every bound, the last bound is the best estimate there is
+				return LatencyBucketsMs[len(LatencyBucketsMs)-1]
+			}
+			return *rl.Buckets[i].LeMs
+		}
+	}
+	return LatencyBucketsMs[len(LatencyBucketsMs)-1]
+}
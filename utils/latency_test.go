@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyHistograms(t *testing.T) {
+	t.Run("should keep a histogram per route pattern", func(t *testing.T) {
+		lh := NewLatencyHistograms()
+		r := chi.NewRouter()
+		r.Use(lh.Middleware)
+		r.Get("/gobounties/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+		for _, path := range []string{"/gobounties/1", "/gobounties/2", "/missing"} {
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		}
+
+		snapshot := lh.Snapshot()
+		assert.Len(t, snapshot, 1)
+		assert.Equal(t, "GET /gobounties/{id}", snapshot[0].Route)
+		assert.Equal(t, uint64(2), snapshot[0].Count)
+	})
+
+	t.Run("should have cumulative buckets and bucket percentiles", func(t *testing.T) {
+		lh := NewLatencyHistograms()
+		for i := 0; i < 90; i++ {
+			lh.Observe("GET /people", 3*time.Millisecond)
+		}
+		for i := 0; i < 9; i++ {
+			lh.Observe("GET /people", 200*time.Millisecond)
+		}
+		lh.Observe("GET /people", 20*time.Second)
+		lh.Observe("POST /people", 40*time.Millisecond)
+
+		snapshot := lh.Snapshot()
+		assert.Equal(t, []string{"GET /people", "POST /people"}, []string{snapshot[0].Route, snapshot[1].Route})
+
+		people := snapshot[0]
+		assert.Equal(t, uint64(100), people.Count)
+		assert.InDelta(t, 220.7, people.AvgMs, 0.01)
+		assert.Equal(t, 5.0, people.P50Ms)
+		assert.Equal(t, 250.0, people.P95Ms)
+		assert.Equal(t, 250.0, people.P99Ms)
+
+		assert.Len(t, people.Buckets, len(LatencyBucketsMs)+1)
+		assert.Equal(t, 5.0, *people.Buckets[0].LeMs)
+		assert.Equal(t, uint64(90), people.Buckets[0].Count)
+		assert.Equal(t, uint64(99), people.Buckets[5].Count)
+		assert.Nil(t, people.Buckets[len(LatencyBucketsMs)].LeMs)
+		assert.Equal(t, uint64(100), people.Buckets[len(LatencyBucketsMs)].Count)
+	})
+}
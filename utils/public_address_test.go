@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	for _, ip := range []string{"8.8.8.8", "1.1.1.1", "2606:4700:4700::1111"} {
+		assert.True(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{"127.0.0.1", "10.0.0.5", "172.16.3.4", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fe80::1", "fd00:ec2::254"} {
+		assert.False(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestCheckPublicHost(t *testing.T) {
+	assert.NoError(t, CheckPublicHost("8.8.8.8"))
+	assert.True(t, errors.Is(CheckPublicHost("127.0.0.1"), ErrNotPublicAddress))
+	assert.True(t, errors.Is(CheckPublicHost("169.254.169.254"), ErrNotPublicAddress))
+}
+
+func TestNewPublicHttpClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := NewPublicHttpClient(time.Second).Get(server.URL)
+	assert.True(t, errors.Is(err, ErrNotPublicAddress), "a loopback server should not be dialed")
+}
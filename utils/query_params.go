@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryParams reads typed query parameters from a request,
+// missing values fall back to a default and invalid ones are collected
+// so a handler can reject the request with all of them at once
+type QueryParams struct {
+	values url.Values
+	errs   []string
+}
+
+func NewQueryParams(r *http.Request) *QueryParams {
+	if r == nil || r.URL == nil {
+		return &QueryParams{values: url.Values{}}
+	}
+	return &QueryParams{values: r.URL.Query()}
+}
+
+func (q *QueryParams) addError(key string, format string, args ...interface{}) {
+	q.errs = append(q.errs, fmt.Sprintf("%s: %s", key, fmt.Sprintf(format, args...)))
+}
+
+func (q *QueryParams) String(key string, def string) string {
+	if value := strings.TrimSpace(q.values.Get(key)); value != "" {
+		return value
+	}
+	return def
+}
+
+func (q *QueryParams) Int(key string, def int) int {
+	value := strings.TrimSpace(q.values.Get(key))
+	if value == "" {
+		return def
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		q.addError(key, "%q is not a number", value)
+		return def
+	}
+	return number
+}
+
+func (q *QueryParams) Bool(key string, def bool) bool {
+	value := strings.TrimSpace(q.values.Get(key))
+	if value == "" {
+		return def
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		q.addError(key, "%q is not a boolean", value)
+		return def
+	}
+	return b
+}
+
+// Time accepts a unix timestamp, an RFC3339 time or a YYYY-MM-DD date
+func (q *QueryParams) Time(key string, def *time.Time) *time.Time {
+	value := strings.TrimSpace(q.values.Get(key))
+	if value == "" {
+		return def
+	}
+
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		t := time.Unix(unix, 0).UTC()
+		return &t
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t
+		}
+	}
+
+	q.addError(key, "%q is not a valid date", value)
+	return def
+}
+
+// OneOf returns the value if it is one of allowed, compared case-insensitively
+func (q *QueryParams) OneOf(key string, def string, allowed ...string) string {
+	value := strings.TrimSpace(q.values.Get(key))
+	if value == "" {
+		return def
+	}
+
+	for _, a := range allowed {
+		if strings.EqualFold(value, a) {
+			return a
+		}
+	}
+
+	q.addError(key, "%q must be one of %s", value, strings.Join(allowed, ", "))
+	return def
+}
+
+// Pagination returns the offset, limit, sort field, sort direction and search term
+// of a list request
+func (q *QueryParams) Pagination() (int, int, string, string, string) {
+	page := q.Int("page", 1)
+	limit := q.Int("limit", 1)
+	sortBy := q.String("sortBy", "created")
+	direction := q.OneOf("direction", "desc", "asc", "desc")
+	search := q.values.Get("search")
+
+	if page == 0 {
+		page = 1
+	}
+	if limit == 0 {
+		limit = 1
+	}
+
+	// offset for page, start index
+	offset := 0
+	if limit > 0 && page > 0 {
+		offset = (page - 1) * limit
+	}
+
+	return offset, limit, sortBy, direction, search
+}
+
+// Err returns all the invalid parameters read so far, or nil
+func (q *QueryParams) Err() error {
+	if len(q.errs) == 0 {
+		return nil
+	}
+	return errors.New("invalid query parameters: " + strings.Join(q.errs, "; "))
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryParams(t *testing.T) {
+	t.Run("should return defaults for missing parameters", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/bounties", nil)
+		params := NewQueryParams(req)
+
+		assert.Equal(t, 10, params.Int("limit", 10))
+		assert.Equal(t, "created", params.String("sortBy", "created"))
+		assert.True(t, params.Bool("open", true))
+		assert.Nil(t, params.Time("from", nil))
+		assert.NoError(t, params.Err())
+	})
+
+	t.Run("should parse typed parameters", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/bounties?limit=5&open=false&from=2024-01-02&to=1704067200&direction=ASC", nil)
+		params := NewQueryParams(req)
+
+		assert.Equal(t, 5, params.Int("limit", 10))
+		assert.False(t, params.Bool("open", true))
+		assert.Equal(t, time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), *params.Time("from", nil))
+		assert.Equal(t, int64(1704067200), params.Time("to", nil).Unix())
+		assert.Equal(t, "asc", params.OneOf("direction", "desc", "asc", "desc"))
+		assert.NoError(t, params.Err())
+	})
+
+	t.Run("should collect every invalid parameter", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/bounties?limit=ten&from=yesterday&direction=up", nil)
+		params := NewQueryParams(req)
+
+		assert.Equal(t, 10, params.Int("limit", 10))
+		assert.Nil(t, params.Time("from", nil))
+		assert.Equal(t, "desc", params.OneOf("direction", "desc", "asc", "desc"))
+
+		err := params.Err()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "limit")
+		assert.Contains(t, err.Error(), "from")
+		assert.Contains(t, err.Error(), "direction")
+	})
+
+	t.Run("should compute the pagination offset", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/bounties?page=3&limit=20&search=go", nil)
+		offset, limit, sortBy, direction, search := NewQueryParams(req).Pagination()
+
+		assert.Equal(t, 40, offset)
+		assert.Equal(t, 20, limit)
+		assert.Equal(t, "created", sortBy)
+		assert.Equal(t, "desc", direction)
+		assert.Equal(t, "go", search)
+	})
+}
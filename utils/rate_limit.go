@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter is an in-memory token bucket per client IP, each IP gets a burst
+// of Limit requests that refills at Limit requests per Window
+type RateLimiter struct {
+	Limit  int
+	Window time.Duration
+
+	mu      sync.Mutex
+	buckets map[string]*rateBucket
+	now     func() time.Time
+}
+
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		Limit:   limit,
+		Window:  window,
+		buckets: map[string]*rateBucket{},
+		now:     time.Now,
+	}
+}
+
+// SetLimit changes the requests per window, the buckets are kept
+func (rl *RateLimiter) SetLimit(limit int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.Limit = limit
+}
+
+// Allow takes a token of the key, it returns false and how long to wait
+// for the next token when the key has none left
+func (rl *RateLimiter) Allow(key string) (bool, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	rate := float64(rl.Limit) / rl.Window.Seconds()
+
+	bucket, ok := rl.buckets[key]
+	if !ok {
+		// drop the buckets that have refilled before tracking a new key
+		if len(rl.buckets) >= 10000 {
+			for k, b := range rl.buckets {
+				if now.Sub(b.last) >= rl.Window {
+					delete(rl.buckets, k)
+				}
+			}
+		}
+		bucket = &rateBucket{tokens: float64(rl.Limit), last: now}
+		rl.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(float64(rl.Limit), bucket.tokens+now.Sub(bucket.last).Seconds()*rate)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / rate * float64(time.Second))
+		return false, wait
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// Middleware rejects a request with 429 when its IP is over the limit, put it
+// after middleware.RealIP when the server runs behind a proxy
+func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		if ok, wait := rl.Allow(ip); !ok {
+			w.Header().Set("Retry-After", fmt.Sprintf("%d", int(math.Ceil(wait.Seconds()))))
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode("Too many requests, try again later")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
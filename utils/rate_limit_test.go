@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	rl := NewRateLimiter(2, time.Minute)
+	rl.now = func() time.Time { return now }
+
+	t.Run("should allow a burst up to the limit per key", func(t *testing.T) {
+		ok, _ := rl.Allow("1.1.1.1")
+		assert.True(t, ok)
+		ok, _ = rl.Allow("1.1.1.1")
+		assert.True(t, ok)
+
+		ok, wait := rl.Allow("1.1.1.1")
+		assert.False(t, ok)
+		assert.Equal(t, 30*time.Second, wait)
+
+		ok, _ = rl.Allow("2.2.2.2")
+		assert.True(t, ok)
+	})
+
+	t.Run("should refill over the window", func(t *testing.T) {
+		now = now.Add(30 * time.Second)
+		ok, _ := rl.Allow("1.1.1.1")
+		assert.True(t, ok)
+		ok, _ = rl.Allow("1.1.1.1")
+		assert.False(t, ok)
+	})
+
+	t.Run("should answer 429 with Retry-After from the middleware", func(t *testing.T) {
+		handler := rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/search?q=go", nil)
+		req.RemoteAddr = "3.3.3.3:5000"
+		codes := []int{}
+		for i := 0; i < 3; i++ {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			codes = append(codes, rr.Code)
+			if rr.Code == http.StatusTooManyRequests {
+				assert.Equal(t, "30", rr.Header().Get("Retry-After"))
+			}
+		}
+		assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	})
+}
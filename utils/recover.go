@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+)
+
+// PanicReport describes a panic recovered while serving a request
+type PanicReport struct {
+	RequestId string    `json:"request_id"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Panic     string    `json:"panic"`
+	Stack     string    `json:"stack"`
+	Time      time.Time `json:"time"`
+}
+
+// Recoverer turns a panic in a handler into a 500 with a json error envelope,
+// it logs the panic and its stack as one json line and passes the report to
+// report when it is set. Put it after middleware.RequestID
+func Recoverer(report func(PanicReport)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				// the server aborts the response on purpose, don't report it
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				panicReport := PanicReport{
+					RequestId: middleware.GetReqID(r.Context()),
+					Method:    r.Method,
+					Path:      r.URL.Path,
+					Panic:     fmt.Sprint(rec),
+					Stack:     string(debug.Stack()),
+					Time:      time.Now(),
+				}
+				if line, err := json.Marshal(panicReport); err == nil {
+					fmt.Println("[panic]", string(line))
+				}
+				if report != nil {
+					report(panicReport)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error":      "Internal server error",
+					"request_id": panicReport.RequestId,
+				})
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
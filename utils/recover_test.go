@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoverer(t *testing.T) {
+	panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var workspaces map[string]string
+		workspaces["uuid"] = "nil map"
+	})
+
+	t.Run("should answer 500 with the request id and report the panic", func(t *testing.T) {
+		reports := []PanicReport{}
+		handler := middleware.RequestID(Recoverer(func(report PanicReport) {
+			reports = append(reports, report)
+		})(panicking))
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/gobounties/pay/1", nil)
+		req.Header.Set("X-Request-Id", "req-1")
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+		body := map[string]string{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, map[string]string{"error": "Internal server error", "request_id": "req-1"}, body)
+
+		assert.Len(t, reports, 1)
+		assert.Equal(t, "req-1", reports[0].RequestId)
+		assert.Equal(t, http.MethodPost, reports[0].Method)
+		assert.Equal(t, "/gobounties/pay/1", reports[0].Path)
+		assert.Contains(t, reports[0].Panic, "nil map")
+		assert.Contains(t, reports[0].Stack, "recover_test.go")
+	})
+
+	t.Run("should pass through requests that don't panic", func(t *testing.T) {
+		handler := Recoverer(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should let http.ErrAbortHandler through", func(t *testing.T) {
+		handler := Recoverer(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
+
+func TestSentryReporter(t *testing.T) {
+	t.Run("should reject an invalid dsn", func(t *testing.T) {
+		_, err := NewSentryReporter("https://sentry.io/1", http.DefaultClient)
+		assert.Error(t, err)
+		_, err = NewSentryReporter("https://key@sentry.io", http.DefaultClient)
+		assert.Error(t, err)
+	})
+
+	t.Run("should send the report to the project store endpoint", func(t *testing.T) {
+		type received struct {
+			path  string
+			auth  string
+			event map[string]interface{}
+		}
+		events := make(chan received, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			event := map[string]interface{}{}
+			json.Unmarshal(body, &event)
+			events <- received{path: r.URL.Path, auth: r.Header.Get("X-Sentry-Auth"), event: event}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		dsn := "http://public-key@" + server.Listener.Addr().String() + "/42"
+		reporter, err := NewSentryReporter(dsn, server.Client())
+		assert.NoError(t, err)
+
+		reporter.Report(PanicReport{RequestId: "req-1", Method: "GET", Path: "/people", Panic: "boom", Stack: "stack", Time: time.Now()})
+
+		select {
+		case got := <-events:
+			assert.Equal(t, "/api/42/store/", got.path)
+			assert.Contains(t, got.auth, "sentry_key=public-key")
+			assert.Equal(t, "boom", got.event["message"])
+			assert.Equal(t, "error", got.event["level"])
+			assert.Equal(t, map[string]interface{}{"request_id": "req-1"}, got.event["tags"])
+			assert.Len(t, got.event["event_id"], 32)
+		case <-time.After(5 * time.Second):
+			t.Fatal("sentry did not receive the report")
+		}
+	})
+}
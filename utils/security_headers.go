@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/stakwork/sphinx-tribes/config"
+)
+
+// contentSecurityPolicy is the configured policy with its frame-ancestors
+// replaced, so a policy from the environment can't loosen framing by itself
+func contentSecurityPolicy(frameAncestors string) string {
+	directives := []string{}
+	for _, directive := range strings.Split(config.ContentSecurityPolicy, ";") {
+		directive = strings.TrimSpace(directive)
+		if directive == "" || strings.HasPrefix(strings.ToLower(directive), "frame-ancestors") {
+			continue
+		}
+		directives = append(directives, directive)
+	}
+	directives = append(directives, "frame-ancestors "+frameAncestors)
+	return strings.Join(directives, "; ")
+}
+
+// SecurityHeaders sets HSTS, nosniff and the content security policy on every
+// response, nothing can be framed unless its route is Embeddable
+func SecurityHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		if config.HstsMaxAge > 0 {
+			header.Set("Strict-Transport-Security", fmt.Sprintf("max-age=%d; includeSubDomains", config.HstsMaxAge))
+		}
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("Content-Security-Policy", contentSecurityPolicy("'none'"))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Embeddable lets the sites in EmbedFrameAncestors frame the previews a route serves
+func Embeddable(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", contentSecurityPolicy(config.EmbedFrameAncestors))
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stakwork/sphinx-tribes/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(SecurityHeaders)
+	r.Get("/workspaces/{uuid}", func(w http.ResponseWriter, r *http.Request) {})
+	r.With(Embeddable).Get("/workspaces/{uuid}/showcase", func(w http.ResponseWriter, r *http.Request) {})
+
+	get := func(path string) http.Header {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr.Header()
+	}
+
+	t.Run("should keep every response from being framed", func(t *testing.T) {
+		header := get("/workspaces/workspace_uuid")
+		assert.Equal(t, "max-age=31536000; includeSubDomains", header.Get("Strict-Transport-Security"))
+		assert.Equal(t, "nosniff", header.Get("X-Content-Type-Options"))
+		assert.Equal(t, "default-src 'none'; frame-ancestors 'none'", header.Get("Content-Security-Policy"))
+	})
+
+	t.Run("should let the embed sites frame the previews", func(t *testing.T) {
+		defer func(ancestors string) { config.EmbedFrameAncestors = ancestors }(config.EmbedFrameAncestors)
+		config.EmbedFrameAncestors = "https://community.sphinx.chat"
+
+		header := get("/workspaces/workspace_uuid/showcase")
+		assert.Equal(t, "default-src 'none'; frame-ancestors https://community.sphinx.chat", header.Get("Content-Security-Policy"))
+		assert.Equal(t, "nosniff", header.Get("X-Content-Type-Options"))
+	})
+
+	t.Run("should replace the frame-ancestors of a configured policy", func(t *testing.T) {
+		defer func(policy string, maxAge int) {
+			config.ContentSecurityPolicy = policy
+			config.HstsMaxAge = maxAge
+		}(config.ContentSecurityPolicy, config.HstsMaxAge)
+		config.ContentSecurityPolicy = "default-src 'self'; frame-ancestors *; img-src https:;"
+		config.HstsMaxAge = 0
+
+		header := get("/workspaces/workspace_uuid")
+		assert.Equal(t, "default-src 'self'; img-src https:; frame-ancestors 'none'", header.Get("Content-Security-Policy"))
+		assert.Empty(t, header.Get("Strict-Transport-Security"))
+	})
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SentryReporter sends panic reports to the store endpoint of a sentry project
+type SentryReporter struct {
+	storeUrl string
+	auth     string
+	client   *http.Client
+}
+
+// NewSentryReporter reads a dsn like https://<key>@<host>/<project id>
+func NewSentryReporter(dsn string, client *http.Client) (*SentryReporter, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, err
+	}
+	projectId := strings.Trim(u.Path, "/")
+	if u.User == nil || u.User.Username() == "" || u.Host == "" || projectId == "" {
+		return nil, errors.New("sentry dsn must look like https://<key>@<host>/<project id>")
+	}
+
+	return &SentryReporter{
+		storeUrl: fmt.Sprintf("%s://%s/api/%s/store/", u.Scheme, u.Host, projectId),
+		auth:     fmt.Sprintf("Sentry sentry_version=7, sentry_client=sphinx-tribes/1.0, sentry_key=%s", u.User.Username()),
+		client:   client,
+	}, nil
+}
+
+// Report sends the panic in the background, so a slow sentry doesn't hold the response
+func (s *SentryReporter) Report(report PanicReport) {
+	eventId := make([]byte, 16)
+	rand.Read(eventId)
+
+	event := map[string]interface{}{
+		"event_id":  hex.EncodeToString(eventId),
+		"timestamp": report.Time.UTC().Format("2006-01-02T15:04:05"),
+		"level":     "error",
+		"platform":  "go",
+		"logger":    "recoverer",
+		"message":   report.Panic,
+		"tags":      map[string]string{"request_id": report.RequestId},
+		"request":   map[string]string{"method": report.Method, "url": report.Path},
+		"extra":     map[string]string{"stack": report.Stack},
+	}
+
+	go func() {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return
+		}
+		req, err := http.NewRequest(http.MethodPost, s.storeUrl, bytes.NewReader(body))
+		if err != nil {
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Sentry-Auth", s.auth)
+
+		res, err := s.client.Do(req)
+		if err != nil {
+			fmt.Println("[sentry] could not send panic report", err)
+			return
+		}
+		res.Body.Close()
+		if res.StatusCode >= 300 {
+			fmt.Println("[sentry] panic report was rejected with status", res.StatusCode)
+		}
+	}()
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	spanAttr := func(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+		for _, attr := range span.Attributes() {
+			if attr.Key == key {
+				return attr.Value
+			}
+		}
+		return attribute.Value{}
+	}
+
+	t.Run("should name the request span after the route and nest spans under it", func(t *testing.T) {
+		r := chi.NewRouter()
+		r.Use(TracingMiddleware)
+		r.Get("/gobounties/{id}", func(w http.ResponseWriter, r *http.Request) {
+			_, span := StartSpan(r.Context(), "payment.keysend")
+			EndSpan(span, errors.New("relay answered with status 500"))
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/gobounties/12", nil))
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 2)
+		child, server := spans[0], spans[1]
+
+		assert.Equal(t, "GET /gobounties/{id}", server.Name())
+		assert.Equal(t, int64(http.StatusBadGateway), spanAttr(server, "http.response.status_code").AsInt64())
+		assert.Equal(t, codes.Error, server.Status().Code)
+
+		assert.Equal(t, "payment.keysend", child.Name())
+		assert.Equal(t, server.SpanContext().SpanID(), child.Parent().SpanID())
+		assert.Equal(t, codes.Error, child.Status().Code)
+	})
+
+	t.Run("should pass the trace to the server of an outgoing request", func(t *testing.T) {
+		traceparent := make(chan string, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceparent <- r.Header.Get("traceparent")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := &http.Client{Transport: NewTracingTransport(server.Client().Transport)}
+		req, _ := http.NewRequest(http.MethodPost, server.URL+"/payment", nil)
+		res, err := client.Do(req)
+		assert.NoError(t, err)
+		res.Body.Close()
+
+		spans := recorder.Ended()
+		span := spans[len(spans)-1]
+		assert.Equal(t, "HTTP POST "+req.URL.Host, span.Name())
+		assert.Equal(t, int64(http.StatusOK), spanAttr(span, "http.response.status_code").AsInt64())
+		assert.Contains(t, <-traceparent, span.SpanContext().TraceID().String())
+		assert.Empty(t, req.Header.Get("traceparent"), "the caller's request should not be changed")
+	})
+
+	t.Run("should only trace the traced client", func(t *testing.T) {
+		traceparent := make(chan string, 2)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceparent <- r.Header.Get("traceparent")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		res, err := TracedClient.Get(server.URL)
+		assert.NoError(t, err)
+		res.Body.Close()
+		assert.NotEmpty(t, <-traceparent)
+
+		res, err = http.DefaultClient.Get(server.URL)
+		assert.NoError(t, err)
+		res.Body.Close()
+		assert.Empty(t, <-traceparent, "the default transport should not be traced")
+	})
+}
@@ -3,7 +3,6 @@ package utils
 import (
 	"fmt"
 	"net/http"
-	"strconv"
 )
 
 func GetPaginationParams(r *http.Request) (int, int, string, string, string) {
@@ -12,39 +11,7 @@ func GetPaginationParams(r *http.Request) (int, int, string, string, string) {
 		return 0, 1, "updated", "asc", ""
 	}
 
-	keys := r.URL.Query()
-	page := keys.Get("page")
-	limit := keys.Get("limit")
-	sortBy := keys.Get("sortBy")
-	direction := keys.Get("direction")
-	search := keys.Get("search")
-
-	// convert string to int
-	intPage, _ := strconv.Atoi(page)
-	intLimit, _ := strconv.Atoi(limit)
-
-	if intPage == 0 {
-		intPage = 1
-	}
-	if intLimit == 0 {
-		intLimit = 1
-	}
-	if sortBy == "" {
-		sortBy = "created"
-	}
-	if direction == "" {
-		direction = "desc"
-	}
-
-	// offset for page, start index
-	offset := 0
-	if intLimit > 0 && intPage > 0 {
-		// this will give us an offset that includes part of the next/previous page,
-		// so that all results arent replaced, a "page shifting" effect
-		offset = (intPage - 1) * intLimit
-	}
-
-	return offset, intLimit, sortBy, direction, search
+	return NewQueryParams(r).Pagination()
 }
 
 func BuildSearchQuery(key string, term string) (string, string) {
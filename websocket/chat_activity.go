@@ -0,0 +1,98 @@
+package websocket
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+const (
+	joinChatEvent    = "join_chat"
+	leaveChatEvent   = "leave_chat"
+	chatTypingEvent  = "chat_typing"
+	chatWorkingEvent = "chat_working"
+)
+
+const (
+	// TypingInterval is how often a member typing in a chat is passed on,
+	// the UI keeps the indicator up for a while after the last one
+	TypingInterval = 3 * time.Second
+	// WorkingInterval is how often an assistant working on a chat is passed on
+	WorkingInterval = time.Second
+)
+
+// activityThrottle remembers the indicators sent lately, they are ephemeral so
+// a dropped one is replaced by the next
+var activityThrottle = cache.New(TypingInterval, time.Minute)
+
+// throttled tells if an indicator was already sent within its interval, and
+// marks it as sent when it wasn't
+func throttled(key string, interval time.Duration) bool {
+	return activityThrottle.Add(key, true, interval) != nil
+}
+
+var authorizeChat = func(pubkey string, chatId string) bool {
+	return false
+}
+
+// SetChatAuthorizer sets the check that a pubkey may follow the activity of a
+// chat, no one can until it is set
+func SetChatAuthorizer(authorize func(pubkey string, chatId string) bool) {
+	authorizeChat = authorize
+}
+
+// BroadcastChatWorking tells the connections following a chat that an
+// assistant is working on it, or stopped. Working updates are throttled, a
+// stop always goes out. It returns false when the update was dropped
+func BroadcastChatWorking(chatId string, working bool, step string) bool {
+	key := chatWorkingEvent + ":" + chatId
+	if working && throttled(key, WorkingInterval) {
+		return false
+	}
+	if !working {
+		activityThrottle.Delete(key)
+	}
+
+	message := map[string]interface{}{"msg": chatWorkingEvent, "chat_id": chatId, "working": working}
+	if step != "" {
+		message["step"] = step
+	}
+	notifyRoom(ChatPresence, chatId, message, "")
+	return true
+}
+
+// chatActivity handles a client following a chat, or typing in one
+func (c *Client) chatActivity(p []byte) bool {
+	event, payload := decodeEvent(c.Version(), p)
+	if event != joinChatEvent && event != leaveChatEvent && event != chatTypingEvent {
+		return false
+	}
+	if _, err := Validate(event, payload); err != nil {
+		c.Conn.WriteJSON(errorReply(c.Version(), err.Error()))
+		return true
+	}
+	chatId := payload["chat_id"].(string)
+
+	switch event {
+	case joinChatEvent:
+		if c.Pubkey == "" || !authorizeChat(c.Pubkey, chatId) {
+			fmt.Println("[websocket] chat refused for", chatId)
+			c.Conn.WriteJSON(errorReply(c.Version(), "not a member of the chat"))
+			return true
+		}
+		ChatPresence.Join(chatId, c.Pubkey, c.Host)
+	case leaveChatEvent:
+		ChatPresence.Leave(chatId, c.Pubkey, c.Host)
+	case chatTypingEvent:
+		if !ChatPresence.has(chatId, c.Host) {
+			c.Conn.WriteJSON(errorReply(c.Version(), "join the chat before typing in it"))
+			return true
+		}
+		if throttled(chatTypingEvent+":"+chatId+":"+c.Pubkey, TypingInterval) {
+			return true
+		}
+		notifyRoom(ChatPresence, chatId, map[string]interface{}{"msg": chatTypingEvent, "chat_id": chatId, "pubkey": c.Pubkey}, c.Host)
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatActivity(t *testing.T) {
+	db.InitCache()
+	activityThrottle.Flush()
+	pool := NewPool()
+	go pool.Start()
+
+	SetChatAuthorizer(func(pubkey string, chatId string) bool {
+		return pubkey != "stranger" && chatId == "chat_uuid"
+	})
+	defer SetChatAuthorizer(func(pubkey string, chatId string) bool { return false })
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), auth.ContextKey, r.URL.Query().Get("pubkey"))
+		ServeWs(pool, w, r.WithContext(ctx))
+	}))
+	defer s.Close()
+
+	connect := func(pubkey string) *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(s.URL, "http")+"?pubkey="+pubkey, nil)
+		assert.NoError(t, err)
+		connected := Message{}
+		assert.NoError(t, conn.ReadJSON(&connected))
+		return conn
+	}
+	joinChat := func(conn *websocket.Conn) {
+		assert.NoError(t, conn.WriteJSON(map[string]interface{}{"msg": joinChatEvent, "chat_id": "chat_uuid"}))
+	}
+	typing := map[string]interface{}{"msg": chatTypingEvent, "chat_id": "chat_uuid"}
+
+	t.Run("should refuse typing in a chat that wasn't joined", func(t *testing.T) {
+		stranger := connect("stranger")
+		defer stranger.Close()
+
+		joinChat(stranger)
+		reply := map[string]interface{}{}
+		assert.NoError(t, stranger.ReadJSON(&reply))
+		assert.Equal(t, "not a member of the chat", reply["error"])
+
+		assert.NoError(t, stranger.WriteJSON(typing))
+		assert.NoError(t, stranger.ReadJSON(&reply))
+		assert.Equal(t, "join the chat before typing in it", reply["error"])
+	})
+
+	t.Run("should pass typing on to the others once per interval", func(t *testing.T) {
+		alice := connect("alice")
+		defer alice.Close()
+		bob := connect("bob")
+		defer bob.Close()
+		joinChat(alice)
+		joinChat(bob)
+		assert.Eventually(t, func() bool {
+			return len(ChatPresence.Online("chat_uuid")) == 2
+		}, time.Second, 10*time.Millisecond)
+
+		assert.NoError(t, bob.WriteJSON(typing))
+		assert.NoError(t, bob.WriteJSON(typing))
+		assert.True(t, BroadcastChatWorking("chat_uuid", true, "thinking"))
+		assert.False(t, BroadcastChatWorking("chat_uuid", true, "still thinking"))
+		assert.True(t, BroadcastChatWorking("chat_uuid", false, ""))
+
+		received := []map[string]interface{}{}
+		for len(received) < 3 {
+			message := map[string]interface{}{}
+			alice.SetReadDeadline(time.Now().Add(time.Second))
+			if !assert.NoError(t, alice.ReadJSON(&message)) {
+				return
+			}
+			received = append(received, message)
+		}
+		assert.Contains(t, received, map[string]interface{}{"msg": chatTypingEvent, "chat_id": "chat_uuid", "pubkey": "bob"})
+		assert.Contains(t, received, map[string]interface{}{"msg": chatWorkingEvent, "chat_id": "chat_uuid", "working": true, "step": "thinking"})
+		assert.Contains(t, received, map[string]interface{}{"msg": chatWorkingEvent, "chat_id": "chat_uuid", "working": false})
+
+		alice.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		assert.Error(t, alice.ReadJSON(&map[string]interface{}{}), "the second typing is throttled")
+	})
+}
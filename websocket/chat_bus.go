@@ -0,0 +1,60 @@
+package websocket
+
+import (
+	"sync"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const chatMessageEvent = "chat_message"
+
+// ChatBus carries the new messages of chats, to the websocket connections
+// following a chat and to the clients long polling it. Like the pool it only
+// reaches the clients of this instance
+type ChatBus struct {
+	mu      sync.Mutex
+	waiters map[string]map[chan struct{}]bool
+}
+
+func NewChatBus() *ChatBus {
+	return &ChatBus{waiters: map[string]map[chan struct{}]bool{}}
+}
+
+var ChatMessages = NewChatBus()
+
+// Subscribe returns a channel that is signalled when a chat gets a message,
+// and the function that stops the subscription
+func (b *ChatBus) Subscribe(chatId string) (<-chan struct{}, func()) {
+	waiter := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	if b.waiters[chatId] == nil {
+		b.waiters[chatId] = map[chan struct{}]bool{}
+	}
+	b.waiters[chatId][waiter] = true
+	b.mu.Unlock()
+
+	return waiter, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.waiters[chatId], waiter)
+		if len(b.waiters[chatId]) == 0 {
+			delete(b.waiters, chatId)
+		}
+	}
+}
+
+// Publish sends a new message of a chat to its websocket followers and wakes
+// the clients waiting on it
+func (b *ChatBus) Publish(message db.ChatMessage) {
+	notifyRoom(ChatPresence, message.ChatId, map[string]interface{}{"msg": chatMessageEvent, "chat_id": message.ChatId, "message": message}, "")
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for waiter := range b.waiters[message.ChatId] {
+		select {
+		case waiter <- struct{}{}:
+		default:
+		}
+	}
+}
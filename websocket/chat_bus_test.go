@@ -0,0 +1,27 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatBus(t *testing.T) {
+	db.InitCache()
+	bus := NewChatBus()
+
+	posted, unsubscribe := bus.Subscribe("chat_uuid")
+	other, unsubscribeOther := bus.Subscribe("other_chat")
+	defer unsubscribeOther()
+
+	bus.Publish(db.ChatMessage{ChatId: "chat_uuid"})
+	bus.Publish(db.ChatMessage{ChatId: "chat_uuid"})
+
+	assert.Len(t, posted, 1, "a waiter is woken once however many messages came")
+	assert.Len(t, other, 0)
+
+	unsubscribe()
+	assert.NotContains(t, bus.waiters, "chat_uuid")
+	assert.Contains(t, bus.waiters, "other_chat")
+}
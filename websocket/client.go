@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sync/atomic"
 
 	"github.com/gorilla/websocket"
 	"github.com/stakwork/sphinx-tribes/db"
@@ -13,6 +14,9 @@ type Client struct {
 	Host string
 	Conn *websocket.Conn
 	Pool *Pool
+	// Pubkey is set when the client connected with a token
+	Pubkey  string
+	version int32
 }
 
 type ClientData struct {
@@ -26,8 +30,23 @@ type Message struct {
 	Body string `json:"body"`
 }
 
+// Version is the protocol the client negotiated, a client that never said
+// hello speaks the legacy one
+func (c *Client) Version() int {
+	if version := atomic.LoadInt32(&c.version); version > 0 {
+		return int(version)
+	}
+	return LegacyVersion
+}
+
+func (c *Client) setVersion(version int) {
+	atomic.StoreInt32(&c.version, int32(version))
+	db.Store.SetSocketConnections(db.Client{Host: c.Host, Conn: c.Conn, Version: version})
+}
+
 func (c *Client) Read() {
 	defer func() {
+		c.leavePresence()
 		c.Pool.Unregister <- c
 		c.Conn.Close()
 		db.Store.DeleteCache(c.Host)
@@ -41,6 +60,21 @@ func (c *Client) Read() {
 			return
 		}
 
+		if c.hello(p) || c.presence(p) || c.chatActivity(p) {
+			continue
+		}
+
+		if c.Version() >= CurrentVersion {
+			message, err := receiveEnvelope(messageType, p)
+			if err != nil {
+				fmt.Println("[websocket] dropped message", err)
+				c.Conn.WriteJSON(errorReply(c.Version(), err.Error()))
+				continue
+			}
+			c.Pool.Broadcast <- message
+			continue
+		}
+
 		err = json.Unmarshal(p, &socketMsg)
 		if err != nil {
 			fmt.Println("Message Decode Error", err, string(p))
@@ -51,3 +85,40 @@ func (c *Client) Read() {
 		c.Pool.Broadcast <- message
 	}
 }
+
+// hello negotiates the protocol when the message is a hello, a client that
+// asks for versions the server doesn't know stays on the legacy one
+func (c *Client) hello(p []byte) bool {
+	hello := Hello{}
+	if err := json.Unmarshal(p, &hello); err != nil || hello.Msg != "hello" {
+		return false
+	}
+
+	version, err := Negotiate(hello.Versions)
+	if err != nil {
+		c.Conn.WriteJSON(errorReply(LegacyVersion, err.Error()))
+		return true
+	}
+	c.setVersion(version)
+	c.Conn.WriteJSON(helloReply(version, c.Host))
+	return true
+}
+
+// receiveEnvelope validates a message of a client that negotiated a newer
+// protocol and turns it into a pool message
+func receiveEnvelope(messageType int, p []byte) (Message, error) {
+	envelope := Envelope{}
+	if err := json.Unmarshal(p, &envelope); err != nil {
+		return Message{}, err
+	}
+	schema, err := Validate(envelope.Event, envelope.Payload)
+	if err != nil {
+		return Message{}, err
+	}
+	if envelope.Kind != schema.Kind {
+		return Message{}, fmt.Errorf("%s is a %s message", envelope.Event, schema.Kind)
+	}
+
+	body, _ := json.Marshal(envelope.Payload)
+	return Message{Type: messageType, Msg: envelope.Event, Body: string(body)}, nil
+}
@@ -1,6 +1,7 @@
 package websocket
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/stakwork/sphinx-tribes/db"
@@ -50,7 +51,12 @@ func (pool *Pool) Start() {
 		case message := <-pool.Broadcast:
 			fmt.Println("Sending message to all clients in Pool")
 			for client, _ := range pool.Clients {
-				if err := pool.Clients[client].Client.Conn.WriteJSON(message); err != nil {
+				encoded, err := message.encode(pool.Clients[client].Client.Version())
+				if err != nil {
+					fmt.Println("[websocket] could not encode", message.Msg, err)
+					continue
+				}
+				if err := pool.Clients[client].Client.Conn.WriteJSON(encoded); err != nil {
 					fmt.Println(err)
 					return
 				}
@@ -58,3 +64,32 @@ func (pool *Pool) Start() {
 		}
 	}
 }
+
+// encode shapes a pool message for a client, a legacy client gets it as it
+// was sent and a newer one gets the Envelope of its event
+func (message Message) encode(version int) (interface{}, error) {
+	if version < CurrentVersion {
+		return message, nil
+	}
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(message.Body), &payload); err != nil {
+		return nil, err
+	}
+	return Encode(version, message.Msg, payload)
+}
+
+// SendMessage sends a message to one client in the protocol it negotiated.
+// The message is a JSON object whose msg names the event, its other fields
+// are checked against the schema of the event before anything is written
+func SendMessage(client db.Client, message interface{}) error {
+	event, payload, err := toPayload(message)
+	if err != nil {
+		return err
+	}
+	encoded, err := Encode(client.Version, event, payload)
+	if err != nil {
+		fmt.Println("[websocket] could not send", event, err)
+		return err
+	}
+	return client.Conn.WriteJSON(encoded)
+}
@@ -0,0 +1,223 @@
+package websocket
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stakwork/sphinx-tribes/db"
+)
+
+const (
+	joinWorkspaceEvent  = "join_workspace"
+	leaveWorkspaceEvent = "leave_workspace"
+	presenceJoinEvent   = "presence_join"
+	presenceLeaveEvent  = "presence_leave"
+)
+
+// OnlineMember is a member with at least one open connection in a room
+type OnlineMember struct {
+	Pubkey string
+	Since  time.Time
+}
+
+// Presence tracks which members are in a room, a workspace or a chat, on
+// this instance. A member counts once however many tabs they have open, they
+// join with their first connection and leave with their last
+type Presence struct {
+	mu sync.Mutex
+	// rooms maps a room to its members and their connections
+	rooms map[string]map[string]*presenceEntry
+}
+
+type presenceEntry struct {
+	since time.Time
+	hosts map[string]bool
+}
+
+func NewPresence() *Presence {
+	return &Presence{rooms: map[string]map[string]*presenceEntry{}}
+}
+
+var (
+	WorkspacePresence = NewPresence()
+	ChatPresence      = NewPresence()
+)
+
+var authorizePresence = func(pubkey string, workspaceUuid string) bool {
+	return false
+}
+
+// SetPresenceAuthorizer sets the check that a pubkey may join the presence
+// of a workspace, no one can until it is set
+func SetPresenceAuthorizer(authorize func(pubkey string, workspaceUuid string) bool) {
+	authorizePresence = authorize
+}
+
+// Join adds a connection of a member, it returns true when the member just
+// came online
+func (p *Presence) Join(room string, pubkey string, host string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	members, ok := p.rooms[room]
+	if !ok {
+		members = map[string]*presenceEntry{}
+		p.rooms[room] = members
+	}
+	entry, ok := members[pubkey]
+	if !ok {
+		entry = &presenceEntry{since: time.Now(), hosts: map[string]bool{}}
+		members[pubkey] = entry
+	}
+	entry.hosts[host] = true
+	return !ok
+}
+
+// Leave removes a connection of a member, it returns true when it was the
+// last one of the member
+func (p *Presence) Leave(room string, pubkey string, host string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.leave(room, pubkey, host)
+}
+
+func (p *Presence) leave(room string, pubkey string, host string) bool {
+	entry, ok := p.rooms[room][pubkey]
+	if !ok || !entry.hosts[host] {
+		return false
+	}
+	delete(entry.hosts, host)
+	if len(entry.hosts) > 0 {
+		return false
+	}
+	delete(p.rooms[room], pubkey)
+	if len(p.rooms[room]) == 0 {
+		delete(p.rooms, room)
+	}
+	return true
+}
+
+// LeaveAll removes a closed connection from every room, it returns the
+// rooms the member left
+func (p *Presence) LeaveAll(pubkey string, host string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	left := []string{}
+	for room := range p.rooms {
+		if p.leave(room, pubkey, host) {
+			left = append(left, room)
+		}
+	}
+	sort.Strings(left)
+	return left
+}
+
+// Online lists the members in a room, longest there first
+func (p *Presence) Online(room string) []OnlineMember {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	online := []OnlineMember{}
+	for pubkey, entry := range p.rooms[room] {
+		online = append(online, OnlineMember{Pubkey: pubkey, Since: entry.since})
+	}
+	sort.Slice(online, func(i, j int) bool {
+		if online[i].Since.Equal(online[j].Since) {
+			return online[i].Pubkey < online[j].Pubkey
+		}
+		return online[i].Since.Before(online[j].Since)
+	})
+	return online
+}
+
+func (p *Presence) hosts(room string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	hosts := []string{}
+	for _, entry := range p.rooms[room] {
+		for host := range entry.hosts {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+func (p *Presence) has(room string, host string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, entry := range p.rooms[room] {
+		if entry.hosts[host] {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyRoom sends a message to the connections in a room but one, the rest
+// of the pool doesn't hear about it
+func notifyRoom(presence *Presence, room string, message map[string]interface{}, except string) {
+	for _, host := range presence.hosts(room) {
+		if host == except {
+			continue
+		}
+		client, err := db.Store.GetSocketConnections(host)
+		if err != nil {
+			continue
+		}
+		SendMessage(client, message)
+	}
+}
+
+// notifyPresence tells the connections of a workspace that a member came
+// online or went away
+func notifyPresence(workspaceUuid string, event string, pubkey string) {
+	notifyRoom(WorkspacePresence, workspaceUuid, map[string]interface{}{"msg": event, "workspace_uuid": workspaceUuid, "pubkey": pubkey}, "")
+}
+
+// presence handles the join and leave messages of a client, the client has
+// to have connected with a token of a member of the workspace
+func (c *Client) presence(p []byte) bool {
+	event, payload := decodeEvent(c.Version(), p)
+	if event != joinWorkspaceEvent && event != leaveWorkspaceEvent {
+		return false
+	}
+	if _, err := Validate(event, payload); err != nil {
+		c.Conn.WriteJSON(errorReply(c.Version(), err.Error()))
+		return true
+	}
+	workspaceUuid := payload["workspace_uuid"].(string)
+
+	if event == leaveWorkspaceEvent {
+		if WorkspacePresence.Leave(workspaceUuid, c.Pubkey, c.Host) {
+			notifyPresence(workspaceUuid, presenceLeaveEvent, c.Pubkey)
+		}
+		return true
+	}
+
+	if c.Pubkey == "" || !authorizePresence(c.Pubkey, workspaceUuid) {
+		fmt.Println("[websocket] presence refused for", workspaceUuid)
+		c.Conn.WriteJSON(errorReply(c.Version(), "not a member of the workspace"))
+		return true
+	}
+	if WorkspacePresence.Join(workspaceUuid, c.Pubkey, c.Host) {
+		notifyPresence(workspaceUuid, presenceJoinEvent, c.Pubkey)
+	}
+	return true
+}
+
+// leavePresence takes a closed connection out of the workspaces and chats
+// it joined
+func (c *Client) leavePresence() {
+	if c.Pubkey == "" {
+		return
+	}
+	for _, workspaceUuid := range WorkspacePresence.LeaveAll(c.Pubkey, c.Host) {
+		notifyPresence(workspaceUuid, presenceLeaveEvent, c.Pubkey)
+	}
+	ChatPresence.LeaveAll(c.Pubkey, c.Host)
+}
@@ -0,0 +1,99 @@
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stakwork/sphinx-tribes/auth"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresence(t *testing.T) {
+	presence := NewPresence()
+
+	assert.True(t, presence.Join("workspace", "alice", "tab1"))
+	assert.False(t, presence.Join("workspace", "alice", "tab2"))
+	assert.True(t, presence.Join("workspace", "bob", "tab3"))
+	assert.True(t, presence.Join("other", "alice", "tab1"))
+
+	online := presence.Online("workspace")
+	assert.Len(t, online, 2)
+	assert.Equal(t, "alice", online[0].Pubkey)
+
+	assert.False(t, presence.Leave("workspace", "alice", "tab2"))
+	assert.False(t, presence.Leave("workspace", "alice", "unknown"))
+	assert.Equal(t, []string{"other", "workspace"}, presence.LeaveAll("alice", "tab1"))
+	assert.Equal(t, []OnlineMember{{Pubkey: "bob", Since: online[1].Since}}, presence.Online("workspace"))
+
+	assert.True(t, presence.Leave("workspace", "bob", "tab3"))
+	assert.Empty(t, presence.Online("workspace"))
+	assert.Empty(t, presence.rooms)
+}
+
+func TestPresenceOverWebsocket(t *testing.T) {
+	db.InitCache()
+	pool := NewPool()
+	go pool.Start()
+
+	SetPresenceAuthorizer(func(pubkey string, workspaceUuid string) bool {
+		return pubkey != "stranger" && workspaceUuid == "workspace_uuid"
+	})
+	defer SetPresenceAuthorizer(func(pubkey string, workspaceUuid string) bool { return false })
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), auth.ContextKey, r.URL.Query().Get("pubkey"))
+		ServeWs(pool, w, r.WithContext(ctx))
+	}))
+	defer s.Close()
+
+	connect := func(pubkey string) *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(s.URL, "http")+"?pubkey="+pubkey, nil)
+		assert.NoError(t, err)
+		connected := Message{}
+		assert.NoError(t, conn.ReadJSON(&connected))
+		return conn
+	}
+	join := map[string]interface{}{"msg": joinWorkspaceEvent, "workspace_uuid": "workspace_uuid"}
+
+	t.Run("should refuse someone outside the workspace", func(t *testing.T) {
+		conn := connect("stranger")
+		defer conn.Close()
+
+		assert.NoError(t, conn.WriteJSON(join))
+		reply := map[string]interface{}{}
+		assert.NoError(t, conn.ReadJSON(&reply))
+		assert.Equal(t, "error", reply["msg"])
+		assert.Empty(t, WorkspacePresence.Online("workspace_uuid"))
+	})
+
+	t.Run("should tell the workspace when members join and leave", func(t *testing.T) {
+		alice := connect("alice")
+		defer alice.Close()
+		assert.NoError(t, alice.WriteJSON(join))
+		joined := map[string]interface{}{}
+		assert.NoError(t, alice.ReadJSON(&joined))
+		assert.Equal(t, map[string]interface{}{"msg": presenceJoinEvent, "workspace_uuid": "workspace_uuid", "pubkey": "alice"}, joined)
+
+		bob := connect("bob")
+		assert.NoError(t, bob.WriteJSON(join))
+		assert.NoError(t, alice.ReadJSON(&joined))
+		assert.Equal(t, "bob", joined["pubkey"])
+		assert.Len(t, WorkspacePresence.Online("workspace_uuid"), 2)
+
+		bob.Close()
+		left := map[string]interface{}{}
+		assert.NoError(t, alice.ReadJSON(&left))
+		assert.Equal(t, map[string]interface{}{"msg": presenceLeaveEvent, "workspace_uuid": "workspace_uuid", "pubkey": "bob"}, left)
+
+		assert.NoError(t, alice.WriteJSON(map[string]interface{}{"msg": leaveWorkspaceEvent, "workspace_uuid": "workspace_uuid"}))
+		assert.Eventually(t, func() bool {
+			return len(WorkspacePresence.Online("workspace_uuid")) == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,312 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	// LegacyVersion is the protocol of clients that never said hello: a flat
+	// JSON object whose msg names the event
+	LegacyVersion = 1
+	// CurrentVersion wraps every message in an Envelope
+	CurrentVersion = 2
+)
+
+// SupportedVersions are the protocol versions a client can negotiate
+var SupportedVersions = []int{LegacyVersion, CurrentVersion}
+
+type MessageKind string
+
+const (
+	TicketMessage       MessageKind = "ticket"
+	PaymentMessage      MessageKind = "payment"
+	NotificationMessage MessageKind = "notification"
+	HelloMessage        MessageKind = "hello"
+	ErrorMessage        MessageKind = "error"
+)
+
+type FieldType string
+
+const (
+	StringField  FieldType = "string"
+	NumberField  FieldType = "number"
+	BooleanField FieldType = "boolean"
+	ObjectField  FieldType = "object"
+	ArrayField   FieldType = "array"
+)
+
+// Envelope is a message of protocol version 2
+type Envelope struct {
+	Version int                    `json:"version"`
+	Kind    MessageKind            `json:"kind"`
+	Event   string                 `json:"event"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// Schema describes the payload of an event, every required field has to be
+// there with its type and optional fields are checked when they are set.
+// Since is the first protocol version that knows the event
+type Schema struct {
+	Kind     MessageKind
+	Event    string
+	Since    int
+	Required map[string]FieldType
+	Optional map[string]FieldType
+}
+
+var schemas = map[string]Schema{}
+
+// RegisterSchema adds the schema of an event, an event belongs to one kind
+func RegisterSchema(schema Schema) {
+	if schema.Since == 0 {
+		schema.Since = LegacyVersion
+	}
+	schemas[schema.Event] = schema
+}
+
+// LookupSchema returns the schema of an event
+func LookupSchema(event string) (Schema, bool) {
+	schema, ok := schemas[event]
+	return schema, ok
+}
+
+func init() {
+	RegisterSchema(Schema{Kind: PaymentMessage, Event: "invoice_success", Required: map[string]FieldType{"invoice": StringField}})
+	RegisterSchema(Schema{Kind: PaymentMessage, Event: "budget_success", Required: map[string]FieldType{"invoice": StringField}})
+	RegisterSchema(Schema{Kind: PaymentMessage, Event: "assign_success", Required: map[string]FieldType{"invoice": StringField}})
+	RegisterSchema(Schema{Kind: PaymentMessage, Event: "keysend_error", Required: map[string]FieldType{"invoice": StringField}})
+	RegisterSchema(Schema{
+		Kind:     PaymentMessage,
+		Event:    "keysend_pending",
+		Required: map[string]FieldType{"invoice": StringField},
+		Optional: map[string]FieldType{"payment_method": StringField},
+	})
+	RegisterSchema(Schema{
+		Kind:     PaymentMessage,
+		Event:    "keysend_success",
+		Required: map[string]FieldType{"invoice": StringField},
+		Optional: map[string]FieldType{"payment_method": StringField, "txid": StringField},
+	})
+
+	RegisterSchema(Schema{
+		Kind:     TicketMessage,
+		Event:    "chat_bounty_created",
+		Required: map[string]FieldType{"bounty_id": NumberField, "link": StringField},
+	})
+	RegisterSchema(Schema{
+		Kind:     TicketMessage,
+		Event:    "ticket_review_progress",
+		Required: map[string]FieldType{"uuid": StringField, "ticket_uuid": StringField, "status": StringField, "progress": NumberField},
+		Optional: map[string]FieldType{"step": StringField, "score": NumberField, "feedback": StringField, "error": StringField},
+	})
+
+	RegisterSchema(Schema{
+		Kind:     NotificationMessage,
+		Event:    "lnauth_success",
+		Required: map[string]FieldType{"k1": StringField, "status": BooleanField, "jwt": StringField, "user": ObjectField},
+	})
+	RegisterSchema(Schema{
+		Kind:     NotificationMessage,
+		Event:    "code_graph_sync",
+		Required: map[string]FieldType{"uuid": StringField, "status": StringField, "progress": NumberField},
+		Optional: map[string]FieldType{"error": StringField},
+	})
+	RegisterSchema(Schema{
+		Kind:     NotificationMessage,
+		Event:    "maintenance",
+		Required: map[string]FieldType{"enabled": BooleanField, "message": StringField},
+	})
+	RegisterSchema(Schema{
+		Kind:     NotificationMessage,
+		Event:    "feature_brief_lock",
+		Required: map[string]FieldType{"feature_uuid": StringField, "locked": BooleanField},
+		Optional: map[string]FieldType{"lock": ObjectField},
+	})
+
+	for _, event := range []string{joinWorkspaceEvent, leaveWorkspaceEvent} {
+		RegisterSchema(Schema{
+			Kind:     NotificationMessage,
+			Event:    event,
+			Required: map[string]FieldType{"workspace_uuid": StringField},
+		})
+	}
+	for _, event := range []string{joinChatEvent, leaveChatEvent} {
+		RegisterSchema(Schema{
+			Kind:     NotificationMessage,
+			Event:    event,
+			Required: map[string]FieldType{"chat_id": StringField},
+		})
+	}
+	RegisterSchema(Schema{
+		Kind:     NotificationMessage,
+		Event:    chatTypingEvent,
+		Required: map[string]FieldType{"chat_id": StringField},
+		Optional: map[string]FieldType{"pubkey": StringField},
+	})
+	RegisterSchema(Schema{
+		Kind:     NotificationMessage,
+		Event:    chatMessageEvent,
+		Required: map[string]FieldType{"chat_id": StringField, "message": ObjectField},
+	})
+	RegisterSchema(Schema{
+		Kind:     NotificationMessage,
+		Event:    chatWorkingEvent,
+		Required: map[string]FieldType{"chat_id": StringField, "working": BooleanField},
+		Optional: map[string]FieldType{"step": StringField},
+	})
+	for _, event := range []string{presenceJoinEvent, presenceLeaveEvent} {
+		RegisterSchema(Schema{
+			Kind:     NotificationMessage,
+			Event:    event,
+			Required: map[string]FieldType{"workspace_uuid": StringField, "pubkey": StringField},
+		})
+	}
+}
+
+func fieldType(value interface{}) FieldType {
+	switch value.(type) {
+	case string:
+		return StringField
+	case float64:
+		return NumberField
+	case bool:
+		return BooleanField
+	case map[string]interface{}:
+		return ObjectField
+	case []interface{}:
+		return ArrayField
+	}
+	return ""
+}
+
+// Validate checks a payload against the schema of its event, a field set to
+// null counts as left out
+func Validate(event string, payload map[string]interface{}) (Schema, error) {
+	schema, ok := LookupSchema(event)
+	if !ok {
+		return Schema{}, fmt.Errorf("unknown event %s", event)
+	}
+
+	for field, expected := range schema.Required {
+		value, ok := payload[field]
+		if !ok || value == nil {
+			return schema, fmt.Errorf("%s: %s is required", event, field)
+		}
+		if fieldType(value) != expected {
+			return schema, fmt.Errorf("%s: %s must be a %s", event, field, expected)
+		}
+	}
+	for field, expected := range schema.Optional {
+		if value, ok := payload[field]; ok && value != nil && fieldType(value) != expected {
+			return schema, fmt.Errorf("%s: %s must be a %s", event, field, expected)
+		}
+	}
+	return schema, nil
+}
+
+// toPayload turns a message into its JSON fields, the msg field of a legacy
+// message names its event and is not part of the payload
+func toPayload(message interface{}) (string, map[string]interface{}, error) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return "", nil, err
+	}
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", nil, errors.New("a message must be a JSON object")
+	}
+	event, _ := payload["msg"].(string)
+	delete(payload, "msg")
+	return event, payload, nil
+}
+
+// decodeEvent reads the event and payload of a message of a client
+func decodeEvent(version int, p []byte) (string, map[string]interface{}) {
+	if version >= CurrentVersion {
+		envelope := Envelope{}
+		if err := json.Unmarshal(p, &envelope); err != nil {
+			return "", nil
+		}
+		return envelope.Event, envelope.Payload
+	}
+
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(p, &payload); err != nil {
+		return "", nil
+	}
+	event, _ := payload["msg"].(string)
+	delete(payload, "msg")
+	return event, payload
+}
+
+// Encode validates a message and shapes it for a protocol version. A legacy
+// client gets the flat object it always got, a newer one an Envelope
+func Encode(version int, event string, payload map[string]interface{}) (interface{}, error) {
+	if version < LegacyVersion {
+		version = LegacyVersion
+	}
+	schema, err := Validate(event, payload)
+	if err != nil {
+		return nil, err
+	}
+	if version < schema.Since {
+		return nil, fmt.Errorf("%s needs protocol version %d", event, schema.Since)
+	}
+
+	if version < CurrentVersion {
+		legacy := map[string]interface{}{"msg": event}
+		for field, value := range payload {
+			legacy[field] = value
+		}
+		return legacy, nil
+	}
+	return Envelope{Version: version, Kind: schema.Kind, Event: event, Payload: payload}, nil
+}
+
+// Hello is the first message of a client that speaks a newer protocol, it
+// lists the versions the client understands
+type Hello struct {
+	Msg      string `json:"msg"`
+	Versions []int  `json:"versions"`
+}
+
+// Negotiate picks the newest version both sides support
+func Negotiate(versions []int) (int, error) {
+	supported := map[int]bool{}
+	for _, version := range SupportedVersions {
+		supported[version] = true
+	}
+
+	common := []int{}
+	for _, version := range versions {
+		if supported[version] {
+			common = append(common, version)
+		}
+	}
+	if len(common) == 0 {
+		return LegacyVersion, fmt.Errorf("no common protocol version, the server supports %v", SupportedVersions)
+	}
+	sort.Ints(common)
+	return common[len(common)-1], nil
+}
+
+// helloReply answers a hello in the negotiated version
+func helloReply(version int, host string) interface{} {
+	payload := map[string]interface{}{"version": version, "versions": SupportedVersions, "host": host}
+	if version < CurrentVersion {
+		payload["msg"] = "hello"
+		return payload
+	}
+	return Envelope{Version: version, Kind: HelloMessage, Event: "hello", Payload: payload}
+}
+
+// errorReply tells a client why its message was dropped
+func errorReply(version int, reason string) interface{} {
+	if version < CurrentVersion {
+		return map[string]interface{}{"msg": "error", "error": reason}
+	}
+	return Envelope{Version: version, Kind: ErrorMessage, Event: "error", Payload: map[string]interface{}{"error": reason}}
+}
@@ -0,0 +1,145 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stakwork/sphinx-tribes/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("should accept a payload that matches the schema", func(t *testing.T) {
+		schema, err := Validate("keysend_success", map[string]interface{}{"invoice": "", "txid": "tx"})
+		assert.NoError(t, err)
+		assert.Equal(t, PaymentMessage, schema.Kind)
+	})
+
+	t.Run("should reject an unknown event", func(t *testing.T) {
+		_, err := Validate("unknown", map[string]interface{}{})
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject a missing or null required field", func(t *testing.T) {
+		_, err := Validate("chat_bounty_created", map[string]interface{}{"link": "link"})
+		assert.EqualError(t, err, "chat_bounty_created: bounty_id is required")
+
+		_, err = Validate("chat_bounty_created", map[string]interface{}{"bounty_id": nil, "link": "link"})
+		assert.EqualError(t, err, "chat_bounty_created: bounty_id is required")
+	})
+
+	t.Run("should reject fields of the wrong type", func(t *testing.T) {
+		_, err := Validate("chat_bounty_created", map[string]interface{}{"bounty_id": "1", "link": "link"})
+		assert.EqualError(t, err, "chat_bounty_created: bounty_id must be a number")
+
+		_, err = Validate("keysend_success", map[string]interface{}{"invoice": "", "txid": 1.0})
+		assert.EqualError(t, err, "keysend_success: txid must be a string")
+	})
+}
+
+func TestEncode(t *testing.T) {
+	payload := map[string]interface{}{"invoice": "lnbc1"}
+
+	t.Run("should give legacy clients the flat message", func(t *testing.T) {
+		for _, version := range []int{0, LegacyVersion} {
+			encoded, err := Encode(version, "invoice_success", payload)
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"msg": "invoice_success", "invoice": "lnbc1"}, encoded)
+		}
+	})
+
+	t.Run("should wrap the message for newer clients", func(t *testing.T) {
+		encoded, err := Encode(CurrentVersion, "invoice_success", payload)
+		assert.NoError(t, err)
+		assert.Equal(t, Envelope{Version: CurrentVersion, Kind: PaymentMessage, Event: "invoice_success", Payload: payload}, encoded)
+	})
+
+	t.Run("should not send an event to a client that predates it", func(t *testing.T) {
+		RegisterSchema(Schema{Kind: NotificationMessage, Event: "test_event", Since: CurrentVersion})
+		defer delete(schemas, "test_event")
+
+		_, err := Encode(LegacyVersion, "test_event", map[string]interface{}{})
+		assert.Error(t, err)
+
+		_, err = Encode(CurrentVersion, "test_event", map[string]interface{}{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestNegotiate(t *testing.T) {
+	version, err := Negotiate([]int{1, 2, 7})
+	assert.NoError(t, err)
+	assert.Equal(t, CurrentVersion, version)
+
+	version, err = Negotiate([]int{1})
+	assert.NoError(t, err)
+	assert.Equal(t, LegacyVersion, version)
+
+	version, err = Negotiate([]int{7})
+	assert.Error(t, err)
+	assert.Equal(t, LegacyVersion, version)
+}
+
+func TestHelloNegotiation(t *testing.T) {
+	db.InitCache()
+	pool := NewPool()
+	go pool.Start()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		pool.Register <- &Client{Host: "host", Conn: conn, Pool: pool}
+	}))
+	defer s.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(s.URL, "http"), nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	connected := Message{}
+	assert.NoError(t, conn.ReadJSON(&connected))
+	assert.Equal(t, "user_connect", connected.Msg)
+
+	assert.NoError(t, conn.WriteJSON(Hello{Msg: "hello", Versions: []int{1, 2}}))
+	hello := Envelope{}
+	assert.NoError(t, conn.ReadJSON(&hello))
+	assert.Equal(t, HelloMessage, hello.Kind)
+	assert.Equal(t, float64(CurrentVersion), hello.Payload["version"])
+
+	stored, err := db.Store.GetSocketConnections("host")
+	assert.NoError(t, err)
+	assert.Equal(t, CurrentVersion, stored.Version)
+
+	t.Run("should drop messages that don't match their schema", func(t *testing.T) {
+		assert.NoError(t, conn.WriteJSON(Envelope{Version: 2, Kind: TicketMessage, Event: "chat_bounty_created", Payload: map[string]interface{}{"link": "link"}}))
+		reply := Envelope{}
+		assert.NoError(t, conn.ReadJSON(&reply))
+		assert.Equal(t, ErrorMessage, reply.Kind)
+		assert.Equal(t, "chat_bounty_created: bounty_id is required", reply.Payload["error"])
+	})
+
+	t.Run("should broadcast valid messages as envelopes", func(t *testing.T) {
+		assert.NoError(t, conn.WriteJSON(Envelope{Version: 2, Kind: NotificationMessage, Event: "maintenance", Payload: map[string]interface{}{"enabled": true, "message": "soon"}}))
+		broadcast := Envelope{}
+		assert.NoError(t, conn.ReadJSON(&broadcast))
+		assert.Equal(t, NotificationMessage, broadcast.Kind)
+		assert.Equal(t, "maintenance", broadcast.Event)
+		assert.Equal(t, "soon", broadcast.Payload["message"])
+	})
+
+	t.Run("should send direct messages in the negotiated version", func(t *testing.T) {
+		assert.NoError(t, SendMessage(stored, map[string]interface{}{"msg": "assign_success", "invoice": "lnbc1"}))
+		sent := Envelope{}
+		assert.NoError(t, conn.ReadJSON(&sent))
+		assert.Equal(t, PaymentMessage, sent.Kind)
+		assert.Equal(t, "lnbc1", sent.Payload["invoice"])
+
+		assert.Error(t, SendMessage(stored, map[string]interface{}{"msg": "assign_success"}))
+	})
+}
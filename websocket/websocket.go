@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/websocket"
+	"github.com/stakwork/sphinx-tribes/auth"
 	"github.com/stakwork/sphinx-tribes/config"
 	"github.com/stakwork/sphinx-tribes/utils"
 )
@@ -43,10 +44,12 @@ func ServeWs(pool *Pool, w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "%+v\n", err)
 	}
 
+	pubkey, _ := r.Context().Value(auth.ContextKey).(string)
 	client := &Client{
-		Host: websocketToken,
-		Conn: conn,
-		Pool: pool,
+		Host:   websocketToken,
+		Conn:   conn,
+		Pool:   pool,
+		Pubkey: pubkey,
 	}
 	pool.Register <- client
 }